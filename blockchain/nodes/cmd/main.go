@@ -36,7 +36,7 @@ func main() {
 	defer db.Close()
 
 	// Run migrations
-	if err := repository.RunMigrations(db); err != nil {
+	if err := repository.RunMigrations(context.Background(), db); err != nil {
 		fmt.Printf("Failed to run migrations: %v\n", err)
 		os.Exit(1)
 	}
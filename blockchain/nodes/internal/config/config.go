@@ -40,6 +40,8 @@ type DatabaseConfig struct {
 	MaxOpenConns    int    `mapstructure:"max_open_conns"`
 	MaxIdleConns    int    `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime int    `mapstructure:"conn_max_idle_time"`
+	QueryTimeout    int    `mapstructure:"query_timeout"`
 }
 
 // RedisConfig contains Redis connection settings
@@ -173,6 +175,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_open_conns", 25)
 	v.SetDefault("database.max_idle_conns", 5)
 	v.SetDefault("database.conn_max_lifetime", 300)
+	v.SetDefault("database.conn_max_idle_time", 60)
+	v.SetDefault("database.query_timeout", 5)
 
 	v.SetDefault("redis.host", "localhost")
 	v.SetDefault("redis.port", 6379)
@@ -213,3 +217,18 @@ func (c *Config) GetRPCTimeout() time.Duration {
 func (c *Config) GetRetryDelay() time.Duration {
 	return time.Duration(c.NodeManager.AutoRecovery.RetryDelay) * time.Second
 }
+
+// GetConnMaxLifetime returns the maximum database connection lifetime as a duration
+func (c DatabaseConfig) GetConnMaxLifetime() time.Duration {
+	return time.Duration(c.ConnMaxLifetime) * time.Second
+}
+
+// GetConnMaxIdleTime returns the maximum database connection idle time as a duration
+func (c DatabaseConfig) GetConnMaxIdleTime() time.Duration {
+	return time.Duration(c.ConnMaxIdleTime) * time.Second
+}
+
+// GetQueryTimeout returns the per-query database deadline as a duration
+func (c DatabaseConfig) GetQueryTimeout() time.Duration {
+	return time.Duration(c.QueryTimeout) * time.Second
+}
@@ -165,3 +165,16 @@ type NetworkSummary struct {
 	OnlineNodes int    `json:"online_nodes"`
 	AvgBlockHeight int64 `json:"avg_block_height"`
 }
+
+// NetworkMetrics represents aggregated metrics for a network
+type NetworkMetrics struct {
+	Network        string    `json:"network"`
+	NodeCount      int       `json:"node_count"`
+	AvgBlockHeight float64   `json:"avg_block_height"`
+	AvgBlockTime   float64   `json:"avg_block_time"`
+	AvgPeerCount   float64   `json:"avg_peer_count"`
+	AvgCPU         float64   `json:"avg_cpu"`
+	AvgMemory      float64   `json:"avg_memory"`
+	AvgGasPrice    float64   `json:"avg_gas_price"`
+	Since          time.Time `json:"since"`
+}
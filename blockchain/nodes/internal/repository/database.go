@@ -1,35 +1,50 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/csic/platform/blockchain/nodes/internal/config"
 	_ "github.com/lib/pq"
 )
 
-// Database wraps the sql.DB connection with additional functionality
+// Database wraps the sql.DB connection with query deadlines, a prepared
+// statement cache for hot queries and pool statistics
 type Database struct {
 	*sql.DB
+	queryTimeout time.Duration
+
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+
+	timeouts atomic.Int64
 }
 
-// NewDatabase creates a new Database connection
-func NewDatabase(config interface{}) (*Database, error) {
-	// Type assertion to get config values
-	cfg, ok := config.(struct {
-		Host            string
-		Port            int
-		Username        string
-		Password        string
-		Name            string
-		SSLMode         string
-		MaxOpenConns    int
-		MaxIdleConns    int
-		ConnMaxLifetime int
-	})
-	if !ok {
-		return nil, fmt.Errorf("invalid database config type")
-	}
+// PoolStats reports connection pool usage. Saturation is the share of the
+// maximum open connections currently in use; a pool at 1 makes queries wait
+// for a connection, which shows up in WaitCount and WaitDuration.
+type PoolStats struct {
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	Saturation         float64       `json:"saturation"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDuration       time.Duration `json:"wait_duration"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64         `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+	QueryTimeouts      int64         `json:"query_timeouts"`
+	PreparedStatements int           `json:"prepared_statements"`
+}
 
+// NewDatabase creates a new Database connection
+func NewDatabase(cfg config.DatabaseConfig) (*Database, error) {
 	connStr := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.Name, cfg.SSLMode,
@@ -43,17 +58,115 @@ func NewDatabase(config interface{}) (*Database, error) {
 	// Configure connection pool
 	db.SetMaxOpenConns(cfg.MaxOpenConns)
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.GetConnMaxLifetime())
+	db.SetConnMaxIdleTime(cfg.GetConnMaxIdleTime())
+
+	database := &Database{
+		DB:           db,
+		queryTimeout: cfg.GetQueryTimeout(),
+		stmts:        make(map[string]*sql.Stmt),
+	}
 
 	// Test connection
-	if err := db.Ping(); err != nil {
+	ctx, cancel := database.WithTimeout(context.Background())
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &Database{db}, nil
+	return database, nil
+}
+
+// WithTimeout bounds ctx by the configured query timeout. The deadline also
+// covers waiting for a pooled connection, so a saturated pool fails fast
+// instead of piling up requests. Queries that run out of time are counted
+// when the returned cancel function is called.
+func (d *Database) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.queryTimeout)
+	return ctx, func() {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			d.timeouts.Add(1)
+		}
+		cancel()
+	}
+}
+
+// Statement returns the prepared statement for query, preparing and caching
+// it on first use. Statements are kept for the lifetime of the Database and
+// database/sql re-prepares them transparently on new connections.
+func (d *Database) Statement(ctx context.Context, query string) (*sql.Stmt, error) {
+	d.mu.RLock()
+	stmt, ok := d.stmts[query]
+	d.mu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	prepared, err := d.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Another caller may have prepared the same query in the meantime
+	if stmt, ok := d.stmts[query]; ok {
+		prepared.Close()
+		return stmt, nil
+	}
+	d.stmts[query] = prepared
+	return prepared, nil
+}
+
+// PoolStats returns a snapshot of the connection pool statistics
+func (d *Database) PoolStats() PoolStats {
+	stats := d.Stats()
+
+	d.mu.RLock()
+	prepared := len(d.stmts)
+	d.mu.RUnlock()
+
+	saturation := 0.0
+	if stats.MaxOpenConnections > 0 {
+		saturation = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+	}
+
+	return PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		Saturation:         saturation,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration,
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		QueryTimeouts:      d.timeouts.Load(),
+		PreparedStatements: prepared,
+	}
+}
+
+// Close closes the cached prepared statements and then the connection pool
+func (d *Database) Close() error {
+	d.mu.Lock()
+	for query, stmt := range d.stmts {
+		stmt.Close()
+		delete(d.stmts, query)
+	}
+	d.mu.Unlock()
+
+	return d.DB.Close()
 }
 
 // RunMigrations applies database migrations
-func RunMigrations(db *Database) error {
+func RunMigrations(ctx context.Context, db *Database) error {
 	migrations := []string{
 		// Nodes table
 		`CREATE TABLE IF NOT EXISTS nodes (
@@ -115,7 +228,7 @@ func RunMigrations(db *Database) error {
 	}
 
 	for _, migration := range migrations {
-		if _, err := db.Exec(migration); err != nil {
+		if _, err := db.ExecContext(ctx, migration); err != nil {
 			return fmt.Errorf("migration failed: %w", err)
 		}
 	}
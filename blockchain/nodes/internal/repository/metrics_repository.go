@@ -7,20 +7,45 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/csic/platform/blockchain/nodes/internal/config"
 	"github.com/csic/platform/blockchain/nodes/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
+// Hot metrics queries, prepared once and reused
+const (
+	metricsColumns = `id, node_id, timestamp, block_height, block_time, peer_count,
+			transaction_pool_size, gas_price, memory_usage, cpu_usage,
+			disk_usage, network_in, network_out, rpc_latency, sync_progress`
+
+	insertMetricsQuery = `
+		INSERT INTO node_metrics (` + metricsColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	latestMetricsQuery = `
+		SELECT ` + metricsColumns + `
+		FROM node_metrics WHERE node_id = $1
+		ORDER BY timestamp DESC LIMIT 1
+	`
+
+	metricsHistoryQuery = `
+		SELECT ` + metricsColumns + `
+		FROM node_metrics
+		WHERE node_id = $1 AND timestamp >= $2 AND timestamp <= $3
+		ORDER BY timestamp DESC
+		LIMIT $4
+	`
+)
+
 // MetricsRepository handles database operations for node metrics
 type MetricsRepository struct {
-	db        *sql.DB
+	db        *Database
 	redis     *redis.Client
 	keyPrefix string
 }
 
 // NewMetricsRepository creates a new MetricsRepository instance
-func NewMetricsRepository(db *sql.DB, redis *redis.Client, keyPrefix string) *MetricsRepository {
+func NewMetricsRepository(db *Database, redis *redis.Client, keyPrefix string) *MetricsRepository {
 	return &MetricsRepository{
 		db:        db,
 		redis:     redis,
@@ -31,16 +56,16 @@ func NewMetricsRepository(db *sql.DB, redis *redis.Client, keyPrefix string) *Me
 // SaveMetrics saves node metrics to both database and cache
 func (r *MetricsRepository) SaveMetrics(ctx context.Context, metrics *domain.NodeMetrics) error {
 	// Save to database
-	query := `
-		INSERT INTO node_metrics (
-			id, node_id, timestamp, block_height, block_time, peer_count,
-			transaction_pool_size, gas_price, memory_usage, cpu_usage,
-			disk_usage, network_in, network_out, rpc_latency, sync_progress
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-	`
+	dbCtx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	stmt, err := r.db.Statement(dbCtx, insertMetricsQuery)
+	if err != nil {
+		return fmt.Errorf("failed to save metrics to database: %w", err)
+	}
 
 	metrics.ID = fmt.Sprintf("%d", time.Now().UnixNano())
-	_, err := r.db.Exec(query,
+	_, err = stmt.ExecContext(dbCtx,
 		metrics.ID, metrics.NodeID, metrics.Timestamp, metrics.BlockHeight, metrics.BlockTime,
 		metrics.PeerCount, metrics.TransactionPoolSize, metrics.GasPrice, metrics.MemoryUsage,
 		metrics.CPUUsage, metrics.DiskUsage, metrics.NetworkIn, metrics.NetworkOut,
@@ -71,16 +96,16 @@ func (r *MetricsRepository) GetLatestMetrics(ctx context.Context, nodeID string)
 	}
 
 	// Fall back to database
-	query := `
-		SELECT id, node_id, timestamp, block_height, block_time, peer_count,
-			transaction_pool_size, gas_price, memory_usage, cpu_usage,
-			disk_usage, network_in, network_out, rpc_latency, sync_progress
-		FROM node_metrics WHERE node_id = $1
-		ORDER BY timestamp DESC LIMIT 1
-	`
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	stmt, err := r.db.Statement(ctx, latestMetricsQuery)
+	if err != nil {
+		return nil, err
+	}
 
 	var metrics domain.NodeMetrics
-	err = r.db.QueryRow(query, nodeID).Scan(
+	err = stmt.QueryRowContext(ctx, nodeID).Scan(
 		&metrics.ID, &metrics.NodeID, &metrics.Timestamp, &metrics.BlockHeight,
 		&metrics.BlockTime, &metrics.PeerCount, &metrics.TransactionPoolSize, &metrics.GasPrice,
 		&metrics.MemoryUsage, &metrics.CPUUsage, &metrics.DiskUsage, &metrics.NetworkIn,
@@ -99,17 +124,15 @@ func (r *MetricsRepository) GetLatestMetrics(ctx context.Context, nodeID string)
 
 // GetMetricsHistory retrieves historical metrics for a node
 func (r *MetricsRepository) GetMetricsHistory(ctx context.Context, nodeID string, startTime, endTime time.Time, limit int) ([]*domain.NodeMetrics, error) {
-	query := `
-		SELECT id, node_id, timestamp, block_height, block_time, peer_count,
-			transaction_pool_size, gas_price, memory_usage, cpu_usage,
-			disk_usage, network_in, network_out, rpc_latency, sync_progress
-		FROM node_metrics
-		WHERE node_id = $1 AND timestamp >= $2 AND timestamp <= $3
-		ORDER BY timestamp DESC
-		LIMIT $4
-	`
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
 
-	rows, err := r.db.Query(query, nodeID, startTime, endTime, limit)
+	stmt, err := r.db.Statement(ctx, metricsHistoryQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.QueryContext(ctx, nodeID, startTime, endTime, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +153,7 @@ func (r *MetricsRepository) GetMetricsHistory(ctx context.Context, nodeID string
 		metrics = append(metrics, &m)
 	}
 
-	return metrics, nil
+	return metrics, rows.Err()
 }
 
 // GetNetworkMetrics aggregates metrics for all nodes in a network
@@ -153,7 +176,10 @@ func (r *MetricsRepository) GetNetworkMetrics(ctx context.Context, network strin
 	result.Network = network
 	result.Since = since
 
-	err := r.db.QueryRow(query, network, since).Scan(
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, query, network, since).Scan(
 		&result.NodeCount, &result.AvgBlockHeight, &result.AvgBlockTime,
 		&result.AvgPeerCount, &result.AvgCPU, &result.AvgMemory, &result.AvgGasPrice,
 	)
@@ -179,8 +205,11 @@ func (r *MetricsRepository) GetSystemSummary(ctx context.Context) (*domain.Syste
 		FROM nodes
 	`
 
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	var summary domain.SystemSummary
-	err := r.db.QueryRow(query).Scan(
+	err := r.db.QueryRowContext(ctx, query).Scan(
 		&summary.TotalNodes, &summary.OnlineNodes, &summary.OfflineNodes, &summary.SyncingNodes,
 	)
 
@@ -189,7 +218,7 @@ func (r *MetricsRepository) GetSystemSummary(ctx context.Context) (*domain.Syste
 	}
 
 	// Get network-level stats
-	networkStats, err := r.GetNetworkStats()
+	networkStats, err := queryNetworkStats(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
@@ -202,15 +231,7 @@ func (r *MetricsRepository) GetSystemSummary(ctx context.Context) (*domain.Syste
 	return &summary, nil
 }
 
-// NetworkMetrics represents aggregated metrics for a network
-type NetworkMetrics struct {
-	Network       string  `json:"network"`
-	NodeCount     int     `json:"node_count"`
-	AvgBlockHeight float64 `json:"avg_block_height"`
-	AvgBlockTime   float64 `json:"avg_block_time"`
-	AvgPeerCount   float64 `json:"avg_peer_count"`
-	AvgCPU         float64 `json:"avg_cpu"`
-	AvgMemory      float64 `json:"avg_memory"`
-	AvgGasPrice    float64 `json:"avg_gas_price"`
-	Since          time.Time `json:"since"`
+// PoolStats returns the connection pool statistics of the underlying database
+func (r *MetricsRepository) PoolStats() PoolStats {
+	return r.db.PoolStats()
 }
@@ -1,24 +1,64 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
-	"github.com/csic/platform/blockchain/nodes/internal/config"
 	"github.com/csic/platform/blockchain/nodes/internal/domain"
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+)
+
+// Hot queries are fixed strings so they can be prepared once and reused.
+// Empty list filters match every row rather than changing the query text.
+const (
+	nodeColumns = `id, name, network, type, rpc_url, ws_url, status,
+			version, chain_id, block_number, peer_count, last_sync_time,
+			last_health_check, metadata, created_at, updated_at`
+
+	getNodeByIDQuery = `SELECT ` + nodeColumns + ` FROM nodes WHERE id = $1`
+
+	listNodesFilter = `
+		WHERE ($1 = '' OR network = $1)
+			AND ($2 = '' OR status = $2)
+			AND ($3 = '' OR type = $3)`
+
+	listNodesQuery = `SELECT ` + nodeColumns + ` FROM nodes` + listNodesFilter + `
+		ORDER BY created_at DESC LIMIT $4 OFFSET $5`
+
+	countNodesQuery = `SELECT COUNT(*) FROM nodes` + listNodesFilter
+
+	getNodesByNetworkQuery = `SELECT ` + nodeColumns + ` FROM nodes WHERE network = $1 ORDER BY name ASC`
+
+	updateNodeStatusQuery = `
+		UPDATE nodes SET
+			status = $1, last_health_check = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	updateNodeSyncInfoQuery = `
+		UPDATE nodes SET
+			block_number = $1, peer_count = $2, last_sync_time = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	networkStatsQuery = `
+		SELECT network, COUNT(*) as total,
+			COUNT(CASE WHEN status = 'online' THEN 1 END) as online,
+			AVG(block_number) as avg_height
+		FROM nodes GROUP BY network
+	`
 )
 
 // NodeRepository handles database operations for nodes
 type NodeRepository struct {
-	db        *sql.DB
+	db        *Database
 	keyPrefix string
 }
 
 // NewNodeRepository creates a new NodeRepository instance
-func NewNodeRepository(db *sql.DB, keyPrefix string) *NodeRepository {
+func NewNodeRepository(db *Database, keyPrefix string) *NodeRepository {
 	return &NodeRepository{
 		db:        db,
 		keyPrefix: keyPrefix,
@@ -26,13 +66,10 @@ func NewNodeRepository(db *sql.DB, keyPrefix string) *NodeRepository {
 }
 
 // Create creates a new node record
-func (r *NodeRepository) Create(node *domain.Node) error {
+func (r *NodeRepository) Create(ctx context.Context, node *domain.Node) error {
 	query := `
-		INSERT INTO nodes (
-			id, name, network, type, rpc_url, ws_url, status,
-			version, chain_id, block_number, peer_count, last_sync_time,
-			last_health_check, metadata, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		INSERT INTO nodes (` + nodeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
 	metadata, err := marshalMetadata(node.Metadata)
@@ -47,7 +84,10 @@ func (r *NodeRepository) Create(node *domain.Node) error {
 		node.Status = domain.NodeStatusUnknown
 	}
 
-	_, err = r.db.Exec(query,
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err = r.db.ExecContext(ctx, query,
 		node.ID, node.Name, node.Network, node.Type, node.RPCURL, node.WSURL,
 		node.Status, node.Version, node.ChainID, node.BlockNumber, node.PeerCount,
 		node.LastSyncTime, node.LastHealthCheck, metadata, node.CreatedAt, node.UpdatedAt,
@@ -57,36 +97,24 @@ func (r *NodeRepository) Create(node *domain.Node) error {
 }
 
 // GetByID retrieves a node by its ID
-func (r *NodeRepository) GetByID(id string) (*domain.Node, error) {
-	query := `
-		SELECT id, name, network, type, rpc_url, ws_url, status,
-			version, chain_id, block_number, peer_count, last_sync_time,
-			last_health_check, metadata, created_at, updated_at
-		FROM nodes WHERE id = $1
-	`
+func (r *NodeRepository) GetByID(ctx context.Context, id string) (*domain.Node, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
 
-	var node domain.Node
-	var metadataJSON []byte
-
-	err := r.db.QueryRow(query, id).Scan(
-		&node.ID, &node.Name, &node.Network, &node.Type, &node.RPCURL, &node.WSURL,
-		&node.Status, &node.Version, &node.ChainID, &node.BlockNumber, &node.PeerCount,
-		&node.LastSyncTime, &node.LastHealthCheck, &metadataJSON, &node.CreatedAt, &node.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
+	stmt, err := r.db.Statement(ctx, getNodeByIDQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	node.Metadata, err = unmarshalMetadata(metadataJSON)
-	return &node, err
+	node, err := scanNode(stmt.QueryRowContext(ctx, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return node, err
 }
 
 // Update updates an existing node record
-func (r *NodeRepository) Update(node *domain.Node) error {
+func (r *NodeRepository) Update(ctx context.Context, node *domain.Node) error {
 	query := `
 		UPDATE nodes SET
 			name = $1, rpc_url = $2, ws_url = $3, type = $4,
@@ -101,7 +129,10 @@ func (r *NodeRepository) Update(node *domain.Node) error {
 
 	node.UpdatedAt = time.Now()
 
-	_, err = r.db.Exec(query,
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err = r.db.ExecContext(ctx, query,
 		node.Name, node.RPCURL, node.WSURL, node.Type,
 		metadata, node.UpdatedAt, node.ID,
 	)
@@ -110,114 +141,78 @@ func (r *NodeRepository) Update(node *domain.Node) error {
 }
 
 // UpdateStatus updates the status of a node
-func (r *NodeRepository) UpdateStatus(id string, status domain.NodeStatus, details map[string]interface{}) error {
-	query := `
-		UPDATE nodes SET
-			status = $1, last_health_check = $2, updated_at = $3
-		WHERE id = $4
-	`
+func (r *NodeRepository) UpdateStatus(ctx context.Context, id string, status domain.NodeStatus, details map[string]interface{}) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	stmt, err := r.db.Statement(ctx, updateNodeStatusQuery)
+	if err != nil {
+		return err
+	}
 
 	now := time.Now()
-	_, err := r.db.Exec(query, status, now, now, id)
+	_, err = stmt.ExecContext(ctx, status, now, now, id)
 	return err
 }
 
 // UpdateSyncInfo updates synchronization information for a node
-func (r *NodeRepository) UpdateSyncInfo(id string, blockNumber int64, peerCount int) error {
-	query := `
-		UPDATE nodes SET
-			block_number = $1, peer_count = $2, last_sync_time = $3, updated_at = $4
-		WHERE id = $5
-	`
+func (r *NodeRepository) UpdateSyncInfo(ctx context.Context, id string, blockNumber int64, peerCount int) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	stmt, err := r.db.Statement(ctx, updateNodeSyncInfoQuery)
+	if err != nil {
+		return err
+	}
 
 	now := time.Now()
-	_, err := r.db.Exec(query, blockNumber, peerCount, now, now, id)
+	_, err = stmt.ExecContext(ctx, blockNumber, peerCount, now, now, id)
 	return err
 }
 
 // Delete deletes a node by its ID
-func (r *NodeRepository) Delete(id string) error {
+func (r *NodeRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	query := "DELETE FROM nodes WHERE id = $1"
-	_, err := r.db.Exec(query, id)
+	_, err := r.db.ExecContext(ctx, query, id)
 	return err
 }
 
 // List retrieves all nodes with optional filtering
-func (r *NodeRepository) List(filter *domain.NodeListFilter) (*domain.PaginatedNodes, error) {
-	baseQuery := "SELECT id, name, network, type, rpc_url, ws_url, status, version, chain_id, block_number, peer_count, last_sync_time, last_health_check, metadata, created_at, updated_at FROM nodes"
-	countQuery := "SELECT COUNT(*) FROM nodes"
+func (r *NodeRepository) List(ctx context.Context, filter *domain.NodeListFilter) (*domain.PaginatedNodes, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
 
-	whereClause := ""
-	args := []interface{}{}
-	argIndex := 1
-
-	if filter.Network != "" {
-		if whereClause != "" {
-			whereClause += " AND "
-		}
-		whereClause += fmt.Sprintf("network = $%d", argIndex)
-		args = append(args, filter.Network)
-		argIndex++
-	}
-
-	if filter.Status != "" {
-		if whereClause != "" {
-			whereClause += " AND "
-		}
-		whereClause += fmt.Sprintf("status = $%d", argIndex)
-		args = append(args, filter.Status)
-		argIndex++
-	}
-
-	if filter.Type != "" {
-		if whereClause != "" {
-			whereClause += " AND "
-		}
-		whereClause += fmt.Sprintf("type = $%d", argIndex)
-		args = append(args, filter.Type)
-		argIndex++
+	countStmt, err := r.db.Statement(ctx, countNodesQuery)
+	if err != nil {
+		return nil, err
 	}
-
-	if whereClause != "" {
-		baseQuery += " WHERE " + whereClause
-		countQuery += " WHERE " + whereClause
+	listStmt, err := r.db.Statement(ctx, listNodesQuery)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add pagination
-	baseQuery += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, filter.Limit, filter.Offset)
+	network, status, nodeType := filter.Network, string(filter.Status), string(filter.Type)
 
 	// Get total count
 	var total int
-	countArgs := args[:len(args)-2]
-	err := r.db.QueryRow(countQuery, countArgs...).Scan(&total)
+	err = countStmt.QueryRowContext(ctx, network, status, nodeType).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
 	// Execute query
-	rows, err := r.db.Query(baseQuery, args...)
+	rows, err := listStmt.QueryContext(ctx, network, status, nodeType, filter.Limit, filter.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	nodes := make([]*domain.Node, 0)
-	for rows.Next() {
-		var node domain.Node
-		var metadataJSON []byte
-
-		err := rows.Scan(
-			&node.ID, &node.Name, &node.Network, &node.Type, &node.RPCURL, &node.WSURL,
-			&node.Status, &node.Version, &node.ChainID, &node.BlockNumber, &node.PeerCount,
-			&node.LastSyncTime, &node.LastHealthCheck, &metadataJSON, &node.CreatedAt, &node.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		node.Metadata, _ = unmarshalMetadata(metadataJSON)
-		nodes = append(nodes, &node)
+	nodes, err := scanNodes(rows)
+	if err != nil {
+		return nil, err
 	}
 
 	return &domain.PaginatedNodes{
@@ -229,50 +224,35 @@ func (r *NodeRepository) List(filter *domain.NodeListFilter) (*domain.PaginatedN
 }
 
 // GetNodesByNetwork retrieves all nodes for a specific network
-func (r *NodeRepository) GetNodesByNetwork(network string) ([]*domain.Node, error) {
-	query := `
-		SELECT id, name, network, type, rpc_url, ws_url, status, version, chain_id,
-			block_number, peer_count, last_sync_time, last_health_check, metadata, created_at, updated_at
-		FROM nodes WHERE network = $1 ORDER BY name ASC
-	`
+func (r *NodeRepository) GetNodesByNetwork(ctx context.Context, network string) ([]*domain.Node, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
 
-	rows, err := r.db.Query(query, network)
+	stmt, err := r.db.Statement(ctx, getNodesByNetworkQuery)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	nodes := make([]*domain.Node, 0)
-	for rows.Next() {
-		var node domain.Node
-		var metadataJSON []byte
-
-		err := rows.Scan(
-			&node.ID, &node.Name, &node.Network, &node.Type, &node.RPCURL, &node.WSURL,
-			&node.Status, &node.Version, &node.ChainID, &node.BlockNumber, &node.PeerCount,
-			&node.LastSyncTime, &node.LastHealthCheck, &metadataJSON, &node.CreatedAt, &node.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
 
-		node.Metadata, _ = unmarshalMetadata(metadataJSON)
-		nodes = append(nodes, &node)
+	rows, err := stmt.QueryContext(ctx, network)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return nodes, nil
+	return scanNodes(rows)
 }
 
 // GetNetworkStats retrieves statistics for all networks
-func (r *NodeRepository) GetNetworkStats() (map[string]*domain.NetworkSummary, error) {
-	query := `
-		SELECT network, COUNT(*) as total,
-			COUNT(CASE WHEN status = 'online' THEN 1 END) as online,
-			AVG(block_number) as avg_height
-		FROM nodes GROUP BY network
-	`
+func (r *NodeRepository) GetNetworkStats(ctx context.Context) (map[string]*domain.NetworkSummary, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	return queryNetworkStats(ctx, r.db)
+}
 
-	rows, err := r.db.Query(query)
+// queryNetworkStats aggregates node counts and heights per network
+func queryNetworkStats(ctx context.Context, db *Database) (map[string]*domain.NetworkSummary, error) {
+	rows, err := db.QueryContext(ctx, networkStatsQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -295,7 +275,43 @@ func (r *NodeRepository) GetNetworkStats() (map[string]*domain.NetworkSummary, e
 		stats[summary.Network] = &summary
 	}
 
-	return stats, nil
+	return stats, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanNode reads one node selected with nodeColumns
+func scanNode(row rowScanner) (*domain.Node, error) {
+	var node domain.Node
+	var metadataJSON []byte
+
+	err := row.Scan(
+		&node.ID, &node.Name, &node.Network, &node.Type, &node.RPCURL, &node.WSURL,
+		&node.Status, &node.Version, &node.ChainID, &node.BlockNumber, &node.PeerCount,
+		&node.LastSyncTime, &node.LastHealthCheck, &metadataJSON, &node.CreatedAt, &node.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Metadata, err = unmarshalMetadata(metadataJSON)
+	return &node, err
+}
+
+// scanNodes reads all remaining rows selected with nodeColumns
+func scanNodes(rows *sql.Rows) ([]*domain.Node, error) {
+	nodes := make([]*domain.Node, 0)
+	for rows.Next() {
+		node, err := scanNode(rows)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, rows.Err()
 }
 
 // marshalMetadata converts map to JSON bytes
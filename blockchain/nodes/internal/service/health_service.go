@@ -48,7 +48,7 @@ func (s *HealthService) StartHealthCheckLoop(ctx context.Context) {
 // runHealthChecks performs health checks on all active nodes
 func (s *HealthService) runHealthChecks(ctx context.Context) {
 	filter := &domain.NodeListFilter{Limit: 1000}
-	paginatedNodes, err := s.nodeRepo.List(filter)
+	paginatedNodes, err := s.nodeRepo.List(ctx, filter)
 	if err != nil {
 		fmt.Printf("Failed to list nodes: %v\n", err)
 		return
@@ -58,7 +58,7 @@ func (s *HealthService) runHealthChecks(ctx context.Context) {
 		result := s.checkNodeHealth(ctx, node)
 
 		// Update node status in database
-		if err := s.nodeRepo.UpdateStatus(node.ID, result.Status, result.Details); err != nil {
+		if err := s.nodeRepo.UpdateStatus(ctx, node.ID, result.Status, result.Details); err != nil {
 			fmt.Printf("Failed to update node status: %v\n", err)
 		}
 
@@ -132,7 +132,7 @@ func (s *HealthService) handleStatusChange(ctx context.Context, node *domain.Nod
 
 // GetNodeHealth retrieves the current health status of a node
 func (s *HealthService) GetNodeHealth(ctx context.Context, nodeID string) (*domain.HealthCheckResult, error) {
-	node, err := s.nodeRepo.GetByID(nodeID)
+	node, err := s.nodeRepo.GetByID(ctx, nodeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node: %w", err)
 	}
@@ -146,7 +146,7 @@ func (s *HealthService) GetNodeHealth(ctx context.Context, nodeID string) (*doma
 // GetHealth returns overall system health
 func (s *HealthService) GetHealth(ctx context.Context) map[string]interface{} {
 	filter := &domain.NodeListFilter{Limit: 1000}
-	paginatedNodes, err := s.nodeRepo.List(filter)
+	paginatedNodes, err := s.nodeRepo.List(ctx, filter)
 	if err != nil {
 		return map[string]interface{}{
 			"status":  "unhealthy",
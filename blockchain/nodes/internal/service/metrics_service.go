@@ -107,5 +107,33 @@ csic_nodes_offline %d
 csic_nodes_syncing %d
 `, summary.TotalNodes, summary.OnlineNodes, summary.OfflineNodes, summary.SyncingNodes)
 
+	pool := s.metricsRepo.PoolStats()
+	metrics += fmt.Sprintf(`# HELP csic_nodes_db_connections_max Maximum open database connections
+# TYPE csic_nodes_db_connections_max gauge
+csic_nodes_db_connections_max %d
+# HELP csic_nodes_db_connections_in_use Database connections currently in use
+# TYPE csic_nodes_db_connections_in_use gauge
+csic_nodes_db_connections_in_use %d
+# HELP csic_nodes_db_connections_idle Idle database connections
+# TYPE csic_nodes_db_connections_idle gauge
+csic_nodes_db_connections_idle %d
+# HELP csic_nodes_db_pool_saturation Share of the maximum open connections in use
+# TYPE csic_nodes_db_pool_saturation gauge
+csic_nodes_db_pool_saturation %g
+# HELP csic_nodes_db_wait_total Queries that waited for a free connection
+# TYPE csic_nodes_db_wait_total counter
+csic_nodes_db_wait_total %d
+# HELP csic_nodes_db_wait_seconds_total Time spent waiting for a free connection
+# TYPE csic_nodes_db_wait_seconds_total counter
+csic_nodes_db_wait_seconds_total %g
+# HELP csic_nodes_db_query_timeouts_total Queries that exceeded their deadline
+# TYPE csic_nodes_db_query_timeouts_total counter
+csic_nodes_db_query_timeouts_total %d
+# HELP csic_nodes_db_prepared_statements Cached prepared statements
+# TYPE csic_nodes_db_prepared_statements gauge
+csic_nodes_db_prepared_statements %d
+`, pool.MaxOpenConnections, pool.InUse, pool.Idle, pool.Saturation, pool.WaitCount,
+		pool.WaitDuration.Seconds(), pool.QueryTimeouts, pool.PreparedStatements)
+
 	return metrics, nil
 }
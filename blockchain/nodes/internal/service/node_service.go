@@ -37,7 +37,7 @@ func (s *NodeService) CreateNode(ctx context.Context, req *domain.CreateNodeRequ
 	}
 
 	// Validate node limit
-	currentNodes, err := s.nodeRepo.GetNodesByNetwork(req.Network)
+	currentNodes, err := s.nodeRepo.GetNodesByNetwork(ctx, req.Network)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check node count: %w", err)
 	}
@@ -61,7 +61,7 @@ func (s *NodeService) CreateNode(ctx context.Context, req *domain.CreateNodeRequ
 		Status:   domain.NodeStatusUnknown,
 	}
 
-	if err := s.nodeRepo.Create(node); err != nil {
+	if err := s.nodeRepo.Create(ctx, node); err != nil {
 		return nil, fmt.Errorf("failed to create node: %w", err)
 	}
 
@@ -78,7 +78,7 @@ func (s *NodeService) CreateNode(ctx context.Context, req *domain.CreateNodeRequ
 
 // GetNode retrieves a node by ID
 func (s *NodeService) GetNode(ctx context.Context, id string) (*domain.Node, error) {
-	node, err := s.nodeRepo.GetByID(id)
+	node, err := s.nodeRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node: %w", err)
 	}
@@ -97,12 +97,12 @@ func (s *NodeService) ListNodes(ctx context.Context, filter *domain.NodeListFilt
 		filter.Limit = 100
 	}
 
-	return s.nodeRepo.List(filter)
+	return s.nodeRepo.List(ctx, filter)
 }
 
 // UpdateNode updates an existing node
 func (s *NodeService) UpdateNode(ctx context.Context, id string, req *domain.UpdateNodeRequest) (*domain.Node, error) {
-	node, err := s.nodeRepo.GetByID(id)
+	node, err := s.nodeRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node: %w", err)
 	}
@@ -126,7 +126,7 @@ func (s *NodeService) UpdateNode(ctx context.Context, id string, req *domain.Upd
 		node.Metadata = req.Metadata
 	}
 
-	if err := s.nodeRepo.Update(node); err != nil {
+	if err := s.nodeRepo.Update(ctx, node); err != nil {
 		return nil, fmt.Errorf("failed to update node: %w", err)
 	}
 
@@ -135,7 +135,7 @@ func (s *NodeService) UpdateNode(ctx context.Context, id string, req *domain.Upd
 
 // DeleteNode deletes a node
 func (s *NodeService) DeleteNode(ctx context.Context, id string) error {
-	node, err := s.nodeRepo.GetByID(id)
+	node, err := s.nodeRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get node: %w", err)
 	}
@@ -143,7 +143,7 @@ func (s *NodeService) DeleteNode(ctx context.Context, id string) error {
 		return fmt.Errorf("node not found: %s", id)
 	}
 
-	if err := s.nodeRepo.Delete(id); err != nil {
+	if err := s.nodeRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete node: %w", err)
 	}
 
@@ -159,7 +159,7 @@ func (s *NodeService) DeleteNode(ctx context.Context, id string) error {
 
 // RestartNode restarts a node
 func (s *NodeService) RestartNode(ctx context.Context, id string) error {
-	node, err := s.nodeRepo.GetByID(id)
+	node, err := s.nodeRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get node: %w", err)
 	}
@@ -180,7 +180,7 @@ func (s *NodeService) RestartNode(ctx context.Context, id string) error {
 
 // ForceSync forces a synchronization update for a node
 func (s *NodeService) ForceSync(ctx context.Context, id string) error {
-	node, err := s.nodeRepo.GetByID(id)
+	node, err := s.nodeRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get node: %w", err)
 	}
@@ -200,7 +200,7 @@ func (s *NodeService) ForceSync(ctx context.Context, id string) error {
 
 // GetNetworkNodes retrieves all nodes for a specific network
 func (s *NodeService) GetNetworkNodes(ctx context.Context, network string) ([]*domain.Node, error) {
-	return s.nodeRepo.GetNodesByNetwork(network)
+	return s.nodeRepo.GetNodesByNetwork(ctx, network)
 }
 
 // ListNetworks lists all configured networks
@@ -208,7 +208,7 @@ func (s *NodeService) ListNetworks(ctx context.Context) ([]*domain.NetworkInfo,
 	networks := make([]*domain.NetworkInfo, 0)
 
 	for key, cfg := range s.config.Blockchains {
-		nodes, err := s.nodeRepo.GetNodesByNetwork(key)
+		nodes, err := s.nodeRepo.GetNodesByNetwork(ctx, key)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get nodes for network %s: %w", key, err)
 		}
@@ -0,0 +1,145 @@
+// Compliance Management Module - Appeal Models
+// Due-process appeals of regulated entities against freeze orders
+
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppealStatus represents the status of an appeal
+type AppealStatus string
+
+const (
+	AppealStatusPendingReview        AppealStatus = "PENDING_REVIEW"
+	AppealStatusInformationRequested AppealStatus = "INFORMATION_REQUESTED"
+	AppealStatusUpheld               AppealStatus = "UPHELD"
+	AppealStatusRevoked              AppealStatus = "REVOKED"
+)
+
+// AppealOutcome represents the outcome of a reviewer's determination
+type AppealOutcome string
+
+const (
+	// AppealOutcomeRequestInformation asks the appellant for more documents
+	// by the determination's response deadline
+	AppealOutcomeRequestInformation AppealOutcome = "REQUEST_INFORMATION"
+	// AppealOutcomeUphold rejects the appeal; the freeze order stands
+	AppealOutcomeUphold AppealOutcome = "UPHOLD"
+	// AppealOutcomeRevoke allows the appeal; the freeze order is revoked
+	AppealOutcomeRevoke AppealOutcome = "REVOKE"
+)
+
+// Appeal is a regulated entity's challenge of a freeze order on one of its
+// wallets. The entity must own the frozen wallet or operate the exchange
+// holding it. Reviewers record determinations until one upholds or revokes
+// the order, which closes the appeal; the decision is due by DecisionDueAt.
+type Appeal struct {
+	ID             string                `json:"id" db:"id"`
+	EntityID       string                `json:"entity_id" db:"entity_id"`
+	Jurisdiction   string                `json:"jurisdiction" db:"jurisdiction"`
+	FreezeOrderID  string                `json:"freeze_order_id" db:"freeze_order_id" binding:"required"`
+	WalletID       string                `json:"wallet_id" db:"wallet_id"`
+	WalletAddress  string                `json:"wallet_address" db:"wallet_address"`
+	Grounds        string                `json:"grounds" db:"grounds" binding:"required"`
+	Documents      []Attachment          `json:"documents" db:"documents" binding:"required,min=1"`
+	Status         AppealStatus          `json:"status" db:"status"`
+	DecisionDueAt  time.Time             `json:"decision_due_at" db:"decision_due_at"`
+	Determinations []AppealDetermination `json:"determinations" db:"determinations"`
+	FiledBy        string                `json:"filed_by" db:"filed_by"`
+	FiledAt        time.Time             `json:"filed_at" db:"filed_at"`
+	ResolvedAt     *time.Time            `json:"resolved_at,omitempty" db:"resolved_at"`
+	UpdatedAt      time.Time             `json:"updated_at" db:"updated_at"`
+	Version        int                   `json:"version" db:"version"`
+}
+
+// AppealDetermination is a reviewer's decision on an appeal. Determinations
+// are only ever added to an appeal, never changed.
+type AppealDetermination struct {
+	ID            string        `json:"id"`
+	Outcome       AppealOutcome `json:"outcome" binding:"required"`
+	Reasoning     string        `json:"reasoning" binding:"required"`
+	ResponseDueAt *time.Time    `json:"response_due_at,omitempty" description:"Deadline for the requested information"`
+	DecidedBy     string        `json:"decided_by"`
+	DecidedAt     time.Time     `json:"decided_at"`
+}
+
+// Validate validates the appeal data
+func (a *Appeal) Validate() error {
+	if a.EntityID == "" {
+		return NewValidationError("entity_id", "entity ID is required")
+	}
+	if a.FreezeOrderID == "" {
+		return NewValidationError("freeze_order_id", "freeze order ID is required")
+	}
+	if strings.TrimSpace(a.Grounds) == "" {
+		return NewValidationError("grounds", "grounds of appeal are required")
+	}
+	if len(a.Documents) == 0 {
+		return NewValidationError("documents", "at least one supporting document is required")
+	}
+	return nil
+}
+
+// IsOpen reports whether the appeal still awaits a final determination
+func (a *Appeal) IsOpen() bool {
+	return a.Status == AppealStatusPendingReview || a.Status == AppealStatusInformationRequested
+}
+
+// IsOverdue reports whether the appeal is still open past its decision deadline
+func (a *Appeal) IsOverdue(at time.Time) bool {
+	return a.IsOpen() && at.After(a.DecisionDueAt)
+}
+
+// AddDocuments adds supporting documents to an open appeal. Documents sent
+// after a request for information return the appeal to review.
+func (a *Appeal) AddDocuments(documents []Attachment, at time.Time) error {
+	if !a.IsOpen() {
+		return ErrAppealClosed
+	}
+	if len(documents) == 0 {
+		return NewValidationError("documents", "at least one document is required")
+	}
+	a.Documents = append(a.Documents, documents...)
+	a.Status = AppealStatusPendingReview
+	a.UpdatedAt = at
+	return nil
+}
+
+// RecordDetermination adds a reviewer's determination to an open appeal and
+// moves the appeal to the status it leads to
+func (a *Appeal) RecordDetermination(determination *AppealDetermination, actorID string, at time.Time) error {
+	if !a.IsOpen() {
+		return ErrAppealClosed
+	}
+	if strings.TrimSpace(determination.Reasoning) == "" {
+		return NewValidationError("reasoning", "reasoning is required")
+	}
+
+	switch determination.Outcome {
+	case AppealOutcomeRequestInformation:
+		if determination.ResponseDueAt == nil || !determination.ResponseDueAt.After(at) {
+			return NewValidationError("response_due_at", "a future response deadline is required")
+		}
+		a.Status = AppealStatusInformationRequested
+	case AppealOutcomeUphold, AppealOutcomeRevoke:
+		determination.ResponseDueAt = nil
+		a.Status = AppealStatusUpheld
+		if determination.Outcome == AppealOutcomeRevoke {
+			a.Status = AppealStatusRevoked
+		}
+		a.ResolvedAt = &at
+	default:
+		return NewValidationError("outcome", "must be REQUEST_INFORMATION, UPHOLD or REVOKE")
+	}
+
+	determination.ID = uuid.New().String()
+	determination.DecidedBy = actorID
+	determination.DecidedAt = at
+	a.Determinations = append(a.Determinations, *determination)
+	a.UpdatedAt = at
+	return nil
+}
@@ -17,6 +17,7 @@ const (
 	EntityTypeCustodian     EntityType = "CUSTODIAN"
 	EntityTypeICOIssuer     EntityType = "ICO_ISSUER"
 	EntityTypeOTCDesk       EntityType = "OTC_DESK"
+	EntityTypeP2PPlatform   EntityType = "P2P_PLATFORM"
 )
 
 // EntityStatus represents the operational status of an entity
@@ -43,8 +44,8 @@ type RegulatedEntity struct {
 	Address            Address           `json:"address"`
 	ContactInfo        ContactInfo       `json:"contact_info"`
 	AuthorizedUsers    []AuthorizedUser  `json:"authorized_users"`
-	BankAccounts       []BankAccount     `json:"bank_accounts"`
-	BlockchainAddresses []BlockchainAddr `json:"blockchain_addresses"`
+	BankAccounts       []BankAccount     `json:"bank_accounts" binding:"dive"`
+	BlockchainAddresses []BlockchainAddr `json:"blockchain_addresses" binding:"dive"`
 	LicenseIDs         []string          `json:"license_ids"`
 	RiskRating         string            `json:"risk_rating"`
 	ComplianceScore    float64           `json:"compliance_score"`
@@ -90,15 +91,15 @@ type BankAccount struct {
 	BankName      string `json:"bank_name"`
 	AccountNumber string `json:"account_number"`
 	SWIFTCode     string `json:"swift_code"`
-	Currency      string `json:"currency"`
+	Currency      string `json:"currency" binding:"omitempty,iso4217"`
 	IsPrimary     bool   `json:"is_primary"`
 }
 
 // BlockchainAddr represents a blockchain address
 type BlockchainAddr struct {
 	ID        string    `json:"id"`
-	Chain     string    `json:"chain"`
-	Address   string    `json:"address"`
+	Chain     string    `json:"chain" binding:"required"`
+	Address   string    `json:"address" binding:"required,chain_address=Chain"`
 	Tag       string    `json:"tag,omitempty"`
 	Label     string    `json:"label,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
@@ -121,6 +122,11 @@ func (e *RegulatedEntity) Validate() error {
 	return nil
 }
 
+// ReportsTrades checks if the entity must file daily trade reports
+func (e *RegulatedEntity) ReportsTrades() bool {
+	return e.Type == EntityTypeOTCDesk || e.Type == EntityTypeP2PPlatform
+}
+
 // CanApplyForLicense checks if entity can apply for a license
 func (e *RegulatedEntity) CanApplyForLicense() bool {
 	return e.Status == EntityStatusPending || e.Status == EntityStatusActive
@@ -31,15 +31,36 @@ var (
 	ErrViolationNotFound    = errors.New("violation not found")
 	ErrPenaltyNotFound      = errors.New("penalty not found")
 
+	// Trade report errors
+	ErrTradeReportNotFound  = errors.New("trade report not found")
+	ErrDuplicateTradeReport = errors.New("trade report already filed for this entity, date, asset and chain")
+	ErrNotTradeReporter     = errors.New("entity is not an OTC desk or P2P platform")
+
 	// State errors
 	ErrInvalidStateTransition = errors.New("invalid state transition")
 
+	// Concurrency errors
+	ErrVersionConflict = errors.New("resource was modified by another request")
+
 	// Validation errors
 	ErrValidationError = errors.New("validation error")
 
 	// Authorization errors
 	ErrUnauthorized        = errors.New("unauthorized access")
 	ErrInsufficientRole   = errors.New("insufficient permissions")
+	ErrOutsideEntityScope = errors.New("resource belongs to another entity")
+	ErrOutsideJurisdiction = errors.New("resource is outside the caller's jurisdiction")
+
+	// Sharing agreement errors
+	ErrSharingAgreementNotFound = errors.New("sharing agreement not found")
+	ErrSharingAgreementInactive = errors.New("sharing agreement is not active")
+
+	// Appeal errors
+	ErrAppealNotFound        = errors.New("appeal not found")
+	ErrAppealClosed          = errors.New("appeal has already been determined")
+	ErrDuplicateAppeal       = errors.New("freeze order already has an open appeal")
+	ErrFreezeOrderNotFound   = errors.New("freeze order not found")
+	ErrFreezeOrderNotInForce = errors.New("freeze order is no longer in force")
 
 	// Audit errors
 	ErrAuditLogFailed     = errors.New("audit log operation failed")
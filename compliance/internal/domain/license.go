@@ -16,6 +16,7 @@ const (
 	LicenseTypeWallet         LicenseType = "WALLET_LICENSE"
 	LicenseTypeMining         LicenseType = "MINING_LICENSE"
 	LicenseTypeOTC            LicenseType = "OTC_LICENSE"
+	LicenseTypeP2P            LicenseType = "P2P_LICENSE"
 	LicenseTypeICO            LicenseType = "ICO_LICENSE"
 	LicenseTypeATM            LicenseType = "ATM_LICENSE"
 )
@@ -59,6 +60,16 @@ type License struct {
 	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time         `json:"updated_at" db:"updated_at"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	Version          int               `json:"version" db:"version"`
+}
+
+// LicenseTerms are the parts of a license that can be amended in place
+type LicenseTerms struct {
+	Conditions     []LicenseCondition     `json:"conditions"`
+	Scope          LicenseScope           `json:"scope"`
+	Fee            LicenseFee             `json:"fee"`
+	RenewalDueDate *time.Time             `json:"renewal_due_date,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // LicenseCondition represents a license condition or restriction
@@ -86,7 +97,7 @@ type TransactionLimits struct {
 	DailyLimit      float64 `json:"daily_limit"`
 	MonthlyLimit    float64 `json:"monthly_limit"`
 	SingleTxLimit   float64 `json:"single_tx_limit"`
-	Currency        string  `json:"currency"`
+	Currency        string  `json:"currency" binding:"omitempty,iso4217"`
 }
 
 // LicenseFee represents license fees
@@ -218,6 +229,24 @@ func (l *License) Surrender() error {
 	return l.TransitionTo(LicenseStatusSurrendered)
 }
 
+// Amend replaces the license's amendable terms. Revoked and surrendered
+// licenses can no longer be amended.
+func (l *License) Amend(terms LicenseTerms) error {
+	switch l.Status {
+	case LicenseStatusRevoked:
+		return ErrLicenseRevoked
+	case LicenseStatusSurrendered:
+		return ErrLicenseInactive
+	}
+
+	l.Conditions = terms.Conditions
+	l.Scope = terms.Scope
+	l.Fee = terms.Fee
+	l.RenewalDueDate = terms.RenewalDueDate
+	l.Metadata = terms.Metadata
+	return nil
+}
+
 // AddCondition adds a condition to the license
 func (l *License) AddCondition(condition LicenseCondition) {
 	l.Conditions = append(l.Conditions, condition)
@@ -244,6 +273,7 @@ func getLicensePrefix(licenseType LicenseType) string {
 		LicenseTypeWallet:     "WAL",
 		LicenseTypeMining:     "MIN",
 		LicenseTypeOTC:        "OTC",
+		LicenseTypeP2P:        "P2P",
 		LicenseTypeICO:        "ICO",
 		LicenseTypeATM:        "ATM",
 	}
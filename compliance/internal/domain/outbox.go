@@ -0,0 +1,71 @@
+// Compliance Management Module - Outbox Events
+// Transactional outbox records for reliable event publishing
+
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus represents the delivery status of an outbox event
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "PENDING"
+	OutboxStatusPublished OutboxStatus = "PUBLISHED"
+)
+
+// OutboxEvent is an event written in the same transaction as the state change
+// it describes and published to Kafka afterwards by the outbox relay.
+// DedupKey identifies the logical event: it is unique in the outbox so an event
+// is only enqueued once, and it is sent as a message header so consumers can
+// discard redeliveries.
+type OutboxEvent struct {
+	ID            string          `json:"id" db:"id"`
+	AggregateType string          `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id" db:"aggregate_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Topic         string          `json:"topic" db:"topic"`
+	Jurisdiction  string          `json:"jurisdiction,omitempty" db:"jurisdiction"`
+	MessageKey    string          `json:"message_key" db:"message_key"`
+	DedupKey      string          `json:"dedup_key" db:"dedup_key"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Status        OutboxStatus    `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	LastError     string          `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" db:"published_at"`
+}
+
+// NewOutboxEvent creates a pending outbox event keyed by its aggregate so that
+// events for the same aggregate land on the same partition in order
+func NewOutboxEvent(topic, aggregateType, aggregateID, eventType, dedupKey string, payload interface{}) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	if dedupKey == "" {
+		dedupKey = uuid.New().String()
+	}
+
+	now := time.Now()
+	return &OutboxEvent{
+		ID:            uuid.New().String(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Topic:         topic,
+		MessageKey:    aggregateID,
+		DedupKey:      dedupKey,
+		Payload:       data,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
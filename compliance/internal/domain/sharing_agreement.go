@@ -0,0 +1,82 @@
+// Compliance Management Module - Sharing Agreement Models
+// Cross-jurisdiction access to the records of a single case
+
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// SharingAgreementStatus represents the status of a sharing agreement
+type SharingAgreementStatus string
+
+const (
+	SharingAgreementStatusActive  SharingAgreementStatus = "ACTIVE"
+	SharingAgreementStatusRevoked SharingAgreementStatus = "REVOKED"
+)
+
+// SharingAgreement lets the regulators of another jurisdiction read the
+// records of one regulated entity for a named case, such as a joint
+// investigation. Access is read-only and lasts from ValidFrom until ValidUntil
+// or revocation; the owning jurisdiction keeps sole control of the records.
+type SharingAgreement struct {
+	ID                  string                 `json:"id" db:"id"`
+	CaseReference       string                 `json:"case_reference" db:"case_reference" binding:"required"`
+	EntityID            string                 `json:"entity_id" db:"entity_id" binding:"required"`
+	OwnerJurisdiction   string                 `json:"owner_jurisdiction" db:"owner_jurisdiction"`
+	GranteeJurisdiction string                 `json:"grantee_jurisdiction" db:"grantee_jurisdiction" binding:"required"`
+	Purpose             string                 `json:"purpose" db:"purpose" binding:"required"`
+	Status              SharingAgreementStatus `json:"status" db:"status"`
+	ValidFrom           time.Time              `json:"valid_from" db:"valid_from"`
+	ValidUntil          time.Time              `json:"valid_until" db:"valid_until" binding:"required"`
+	CreatedBy           string                 `json:"created_by" db:"created_by"`
+	CreatedAt           time.Time              `json:"created_at" db:"created_at"`
+	RevokedBy           string                 `json:"revoked_by,omitempty" db:"revoked_by"`
+	RevokedAt           *time.Time             `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevocationReason    string                 `json:"revocation_reason,omitempty" db:"revocation_reason"`
+}
+
+// Validate validates the sharing agreement data
+func (a *SharingAgreement) Validate() error {
+	if strings.TrimSpace(a.CaseReference) == "" {
+		return NewValidationError("case_reference", "case reference is required")
+	}
+	if a.EntityID == "" {
+		return NewValidationError("entity_id", "entity ID is required")
+	}
+	if strings.TrimSpace(a.GranteeJurisdiction) == "" {
+		return NewValidationError("grantee_jurisdiction", "grantee jurisdiction is required")
+	}
+	if strings.EqualFold(a.GranteeJurisdiction, a.OwnerJurisdiction) {
+		return NewValidationError("grantee_jurisdiction", "records are already visible in their own jurisdiction")
+	}
+	if strings.TrimSpace(a.Purpose) == "" {
+		return NewValidationError("purpose", "purpose is required")
+	}
+	if !a.ValidUntil.After(a.ValidFrom) {
+		return NewValidationError("valid_until", "must be after valid_from")
+	}
+	return nil
+}
+
+// IsActive reports whether the agreement grants access at the given time
+func (a *SharingAgreement) IsActive(at time.Time) bool {
+	return a.Status == SharingAgreementStatusActive && !at.Before(a.ValidFrom) && at.Before(a.ValidUntil)
+}
+
+// Revoke ends the agreement before its expiry
+func (a *SharingAgreement) Revoke(reason, actorID string) error {
+	if a.Status != SharingAgreementStatusActive {
+		return ErrSharingAgreementInactive
+	}
+	if strings.TrimSpace(reason) == "" {
+		return NewValidationError("reason", "revocation reason is required")
+	}
+	now := time.Now()
+	a.Status = SharingAgreementStatusRevoked
+	a.RevokedBy = actorID
+	a.RevokedAt = &now
+	a.RevocationReason = reason
+	return nil
+}
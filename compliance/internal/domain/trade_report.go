@@ -0,0 +1,184 @@
+// Compliance Management Module - Trade Report Models
+// Daily trade reporting for OTC desks and P2P platforms
+
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TradeReportStatus represents the reconciliation status of a trade report
+type TradeReportStatus string
+
+const (
+	TradeReportStatusSubmitted    TradeReportStatus = "SUBMITTED"
+	TradeReportStatusReconciled   TradeReportStatus = "RECONCILED"
+	TradeReportStatusDiscrepancy  TradeReportStatus = "DISCREPANCY"
+	TradeReportStatusUnverifiable TradeReportStatus = "UNVERIFIABLE"
+)
+
+// TradeReport is an OTC desk's or P2P platform's aggregated trading in one
+// asset on one chain for one day. BuyVolume is the amount of the asset the
+// reporter acquired from counterparties and SellVolume the amount it delivered
+// to them, both in asset units.
+type TradeReport struct {
+	ID               string                `json:"id" db:"id"`
+	EntityID         string                `json:"entity_id" db:"entity_id"`
+	EntityType       EntityType            `json:"entity_type" db:"entity_type"`
+	Jurisdiction     string                `json:"jurisdiction" db:"jurisdiction"`
+	TradeDate        time.Time             `json:"trade_date" db:"trade_date"`
+	Asset            string                `json:"asset" db:"asset"`
+	Chain            string                `json:"chain" db:"chain"`
+	BuyVolume        float64               `json:"buy_volume" db:"buy_volume" binding:"gte=0"`
+	SellVolume       float64               `json:"sell_volume" db:"sell_volume" binding:"gte=0"`
+	TradeCount       int                   `json:"trade_count" db:"trade_count" binding:"gte=0"`
+	NotionalValue    decimal.Decimal       `json:"notional_value" db:"notional_value" binding:"omitempty,positive_decimal"`
+	NotionalCurrency string                `json:"notional_currency" db:"notional_currency" binding:"omitempty,iso4217"`
+	Counterparties   []CounterpartyCountry `json:"counterparties"`
+	Status           TradeReportStatus     `json:"status" db:"status"`
+	Reconciliation   *TradeReconciliation  `json:"reconciliation,omitempty"`
+	SubmittedBy      string                `json:"submitted_by" db:"submitted_by"`
+	CreatedAt        time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// CounterpartyCountry aggregates a day's trades by counterparty country
+type CounterpartyCountry struct {
+	Country      string  `json:"country"` // ISO 3166-1 alpha-2
+	Counterparts int     `json:"counterparts"`
+	TradeCount   int     `json:"trade_count"`
+	Volume       float64 `json:"volume"`
+}
+
+// TradeReconciliation compares a trade report with the on-chain flows of the
+// reporter's registered addresses over the trade date
+type TradeReconciliation struct {
+	Addresses      []string  `json:"addresses"`
+	OnChainInflow  float64   `json:"on_chain_inflow"`
+	OnChainOutflow float64   `json:"on_chain_outflow"`
+	ReportedNet    float64   `json:"reported_net"`
+	OnChainNet     float64   `json:"on_chain_net"`
+	Difference     float64   `json:"difference"`
+	Tolerance      float64   `json:"tolerance"`
+	ReconciledAt   time.Time `json:"reconciled_at"`
+}
+
+// Validate validates the trade report data
+func (r *TradeReport) Validate() error {
+	if r.EntityID == "" {
+		return ErrValidationError("entity ID is required")
+	}
+	if r.TradeDate.IsZero() {
+		return ErrValidationError("trade date is required")
+	}
+	if r.TradeDate.After(time.Now()) {
+		return ErrValidationError("trade date cannot be in the future")
+	}
+	if r.Asset == "" {
+		return ErrValidationError("asset is required")
+	}
+	if r.Chain == "" {
+		return ErrValidationError("chain is required")
+	}
+	if r.BuyVolume < 0 || r.SellVolume < 0 || r.NotionalValue.IsNegative() {
+		return ErrValidationError("volumes cannot be negative")
+	}
+	if r.TradeCount < 0 {
+		return ErrValidationError("trade count cannot be negative")
+	}
+	if r.TradeCount == 0 && (r.BuyVolume > 0 || r.SellVolume > 0) {
+		return ErrValidationError("trade count is required when volume is reported")
+	}
+
+	trades := 0
+	for _, cp := range r.Counterparties {
+		if len(cp.Country) != 2 {
+			return NewValidationError("counterparties", "country must be an ISO 3166-1 alpha-2 code")
+		}
+		if cp.TradeCount < 0 || cp.Volume < 0 || cp.Counterparts < 0 {
+			return NewValidationError("counterparties", "counts and volumes cannot be negative")
+		}
+		trades += cp.TradeCount
+	}
+	if len(r.Counterparties) > 0 && trades != r.TradeCount {
+		return NewValidationError("counterparties", "counterparty trade counts must add up to the trade count")
+	}
+	return nil
+}
+
+// Normalize truncates the trade date to the day and canonicalises codes
+func (r *TradeReport) Normalize() {
+	r.TradeDate = time.Date(r.TradeDate.Year(), r.TradeDate.Month(), r.TradeDate.Day(), 0, 0, 0, 0, time.UTC)
+	r.Asset = strings.ToUpper(r.Asset)
+	r.Chain = strings.ToLower(r.Chain)
+	r.NotionalCurrency = strings.ToUpper(r.NotionalCurrency)
+	for i := range r.Counterparties {
+		r.Counterparties[i].Country = strings.ToUpper(r.Counterparties[i].Country)
+	}
+}
+
+// NetVolume returns the reported net flow of the asset to the reporter
+func (r *TradeReport) NetVolume() float64 {
+	return r.BuyVolume - r.SellVolume
+}
+
+// Reconcile records the on-chain flows for the trade date and sets the status.
+// The report reconciles when the reported and on-chain net flows differ by no
+// more than tolerance as a fraction of the gross reported volume. Without any
+// registered addresses on the report's chain it cannot be verified.
+func (r *TradeReport) Reconcile(addresses []string, inflow, outflow, tolerance float64) {
+	rec := &TradeReconciliation{
+		Addresses:      addresses,
+		OnChainInflow:  inflow,
+		OnChainOutflow: outflow,
+		ReportedNet:    r.NetVolume(),
+		OnChainNet:     inflow - outflow,
+		Tolerance:      tolerance,
+		ReconciledAt:   time.Now(),
+	}
+	rec.Difference = rec.OnChainNet - rec.ReportedNet
+	r.Reconciliation = rec
+	r.UpdatedAt = rec.ReconciledAt
+
+	gross := r.BuyVolume + r.SellVolume
+	switch {
+	case len(addresses) == 0:
+		r.Status = TradeReportStatusUnverifiable
+	case math.Abs(rec.Difference) <= gross*tolerance:
+		r.Status = TradeReportStatusReconciled
+	default:
+		r.Status = TradeReportStatusDiscrepancy
+	}
+}
+
+// DailyTradeSummary aggregates all trade reports filed for one day
+type DailyTradeSummary struct {
+	Reporters      int                       `json:"reporters"`
+	Reports        int                       `json:"reports"`
+	TradeCount     int                       `json:"trade_count"`
+	ByAsset        map[string]*AssetVolume   `json:"by_asset"`
+	ByCountry      []CounterpartyCountry     `json:"by_country"`
+	ByStatus       map[TradeReportStatus]int `json:"by_status"`
+	Discrepancies  []*TradeReport            `json:"discrepancies"`
+	MissingReports []string                  `json:"missing_reports"`
+}
+
+// AssetVolume is the reported volume in one asset
+type AssetVolume struct {
+	BuyVolume     float64         `json:"buy_volume"`
+	SellVolume    float64         `json:"sell_volume"`
+	NotionalValue decimal.Decimal `json:"notional_value"`
+	TradeCount    int             `json:"trade_count"`
+}
+
+// DailyRegulatoryReport is the regulator's daily summary of licensed activity
+type DailyRegulatoryReport struct {
+	ReportDate    time.Time          `json:"report_date"`
+	GeneratedAt   time.Time          `json:"generated_at"`
+	Jurisdictions []string           `json:"jurisdictions,omitempty"` // empty when the report covers all of them
+	OTCTrading    *DailyTradeSummary `json:"otc_p2p_trading"`
+}
@@ -5,6 +5,8 @@ package domain
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // ViolationType represents the type of violation
@@ -130,26 +132,26 @@ type FinancialImpact struct {
 
 // Penalty represents a penalty for a violation
 type Penalty struct {
-	ID              string         `json:"id" db:"id"`
-	ViolationID     string         `json:"violation_id" db:"violation_id"`
-	PenaltyNumber   string         `json:"penalty_number" db:"penalty_number"`
-	Type            PenaltyType    `json:"type" db:"type"`
-	Status          PenaltyStatus  `json:"status" db:"status"`
-	Amount          float64        `json:"amount" db:"amount"`
-	Currency        string         `json:"currency" db:"currency"`
-	Description     string         `json:"description" db:"description"`
-	LegalBasis      string         `json:"legal_basis" db:"legal_basis"`
-	IssuedDate      time.Time      `json:"issued_date" db:"issued_date"`
-	DueDate         time.Time      `json:"due_date" db:"due_date"`
-	PaidDate        *time.Time     `json:"paid_date,omitempty" db:"paid_date"`
-	PaymentRef      string         `json:"payment_ref,omitempty" db:"payment_ref"`
-	AppealDeadline  *time.Time     `json:"appeal_deadline,omitempty" db:"appeal_deadline"`
-	AppealStatus    string         `json:"appeal_status,omitempty"`
-	Notes           string         `json:"notes,omitempty"`
-	EnforcementDate *time.Time     `json:"enforcement_date,omitempty" db:"enforcement_date"`
+	ID              string                 `json:"id" db:"id"`
+	ViolationID     string                 `json:"violation_id" db:"violation_id"`
+	PenaltyNumber   string                 `json:"penalty_number" db:"penalty_number"`
+	Type            PenaltyType            `json:"type" db:"type"`
+	Status          PenaltyStatus          `json:"status" db:"status"`
+	Amount          decimal.Decimal        `json:"amount" db:"amount" binding:"omitempty,positive_decimal"`
+	Currency        string                 `json:"currency" db:"currency" binding:"omitempty,iso4217"`
+	Description     string                 `json:"description" db:"description"`
+	LegalBasis      string                 `json:"legal_basis" db:"legal_basis"`
+	IssuedDate      time.Time              `json:"issued_date" db:"issued_date"`
+	DueDate         time.Time              `json:"due_date" db:"due_date"`
+	PaidDate        *time.Time             `json:"paid_date,omitempty" db:"paid_date"`
+	PaymentRef      string                 `json:"payment_ref,omitempty" db:"payment_ref"`
+	AppealDeadline  *time.Time             `json:"appeal_deadline,omitempty" db:"appeal_deadline"`
+	AppealStatus    string                 `json:"appeal_status,omitempty"`
+	Notes           string                 `json:"notes,omitempty"`
+	EnforcementDate *time.Time             `json:"enforcement_date,omitempty" db:"enforcement_date"`
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at" db:"updated_at"`
+	CreatedAt       time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at" db:"updated_at"`
 }
 
 // EnforcementAction represents an enforcement action
@@ -252,10 +254,10 @@ func (p *Penalty) MarkPaid(paymentRef string) error {
 	return nil
 }
 
-func (p *Penalty) MarkPartial(amount float64) error {
+func (p *Penalty) MarkPartial(amount decimal.Decimal) error {
 	p.Status = PenaltyStatusPartial
-	p.Amount -= amount
-	if p.Amount <= 0 {
+	p.Amount = p.Amount.Sub(amount)
+	if !p.Amount.IsPositive() {
 		return p.MarkPaid(paymentRef)
 	}
 	return nil
@@ -283,15 +285,15 @@ func GetDefaultEscalationMatrix() EscalationMatrix {
 
 // ViolationReport represents a violation summary report
 type ViolationReport struct {
-	EntityID       string            `json:"entity_id"`
-	EntityName     string            `json:"entity_name"`
-	PeriodStart    time.Time         `json:"period_start"`
-	PeriodEnd      time.Time         `json:"period_end"`
-	TotalCount     int               `json:"total_count"`
-	ByType         map[ViolationType]int `json:"by_type"`
+	EntityID       string                    `json:"entity_id"`
+	EntityName     string                    `json:"entity_name"`
+	PeriodStart    time.Time                 `json:"period_start"`
+	PeriodEnd      time.Time                 `json:"period_end"`
+	TotalCount     int                       `json:"total_count"`
+	ByType         map[ViolationType]int     `json:"by_type"`
 	BySeverity     map[ViolationSeverity]int `json:"by_severity"`
-	ByStatus       map[ViolationStatus]int `json:"by_status"`
-	TotalPenalties float64           `json:"total_penalties"`
-	ResolvedCount  int               `json:"resolved_count"`
-	OpenCount      int               `json:"open_count"`
+	ByStatus       map[ViolationStatus]int   `json:"by_status"`
+	TotalPenalties decimal.Decimal           `json:"total_penalties"`
+	ResolvedCount  int                       `json:"resolved_count"`
+	OpenCount      int                       `json:"open_count"`
 }
@@ -0,0 +1,72 @@
+// Compliance Management Module - Appeal Handlers
+// REST API handlers for reviewing appeals against freeze orders
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// ListAppeals lists appeals by entity, freeze order and status, most urgent
+// decision first
+func (h *ComplianceHandler) ListAppeals(c *gin.Context) {
+	filter := port.AppealFilter{
+		EntityID:      c.Query("entity_id"),
+		FreezeOrderID: c.Query("freeze_order_id"),
+	}
+	for _, s := range c.QueryArray("status") {
+		filter.Status = append(filter.Status, domain.AppealStatus(s))
+	}
+	if overdue, _ := strconv.ParseBool(c.Query("overdue")); overdue {
+		now := time.Now()
+		filter.DueBefore = &now
+	}
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	appeals, err := h.appealService.ListAppeals(c.Request.Context(), filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appeals": appeals,
+		"count":   len(appeals),
+	})
+}
+
+// GetAppeal retrieves an appeal and its determinations
+func (h *ComplianceHandler) GetAppeal(c *gin.Context) {
+	appeal, err := h.appealService.GetAppeal(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appeal)
+}
+
+// RecordAppealDetermination records a reviewer's determination on an appeal
+func (h *ComplianceHandler) RecordAppealDetermination(c *gin.Context) {
+	var determination domain.AppealDetermination
+	if err := c.ShouldBindJSON(&determination); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	actorID := c.GetString("actor_id")
+	appeal, err := h.appealService.RecordDetermination(c.Request.Context(), c.Param("id"), &determination, actorID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appeal)
+}
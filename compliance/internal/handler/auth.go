@@ -0,0 +1,61 @@
+// Compliance Management Module - Authentication
+// Bearer token authentication of staff and portal requests
+
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/session"
+	"github.com/csic-platform/shared/tenancy"
+	"github.com/gin-gonic/gin"
+)
+
+// JurisdictionAuth authenticates staff requests by their bearer access token
+// and scopes them to the jurisdictions named by its jurisdictions claim; "*"
+// grants every jurisdiction. Tokens without the claim are refused. With a nil
+// checker only the token's signature and expiry are checked.
+func JurisdictionAuth(secret string, checker session.Checker) gin.HandlerFunc {
+	key := []byte(secret)
+	return func(c *gin.Context) {
+		claims, ok := authenticate(c, key, checker)
+		if !ok {
+			return
+		}
+
+		scope := tenancy.NewScope(claims.Jurisdictions...)
+		if scope.Empty() {
+			writeError(c, problem.New(problem.PermissionDenied, "token is not scoped to any jurisdiction"))
+			return
+		}
+
+		c.Set("actor_id", claims.UserID)
+		ctx := tenancy.WithScope(c.Request.Context(), scope)
+		c.Request = c.Request.WithContext(session.WithClaims(ctx, claims))
+		c.Next()
+	}
+}
+
+// authenticate verifies the request's bearer access token. On failure it
+// writes the problem response and returns false.
+func authenticate(c *gin.Context, key []byte, checker session.Checker) (*session.Claims, bool) {
+	header := c.GetHeader("Authorization")
+	token := strings.TrimPrefix(header, "Bearer ")
+	if token == "" || token == header {
+		writeError(c, problem.New(problem.Unauthenticated, "bearer access token is required"))
+		return nil, false
+	}
+
+	claims, err := session.Verify(c.Request.Context(), key, checker, token)
+	switch {
+	case err == nil:
+		return claims, true
+	case errors.Is(err, session.ErrExpiredToken), errors.Is(err, session.ErrInvalidToken), errors.Is(err, session.ErrSessionRevoked):
+		writeError(c, problem.New(problem.Unauthenticated, err.Error()))
+	default:
+		writeError(c, err)
+	}
+	return nil, false
+}
@@ -0,0 +1,64 @@
+// Compliance Management Module - Error Responses
+// Classification of compliance errors for problem+json responses
+
+package handler
+
+import (
+	"errors"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/query"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterErrors classifies the compliance domain errors. It is called once
+// at startup, before the router serves requests.
+func RegisterErrors() {
+	problem.Register(problem.NotFound,
+		domain.ErrEntityNotFound,
+		domain.ErrLicenseNotFound,
+		domain.ErrObligationNotFound,
+		domain.ErrViolationNotFound,
+		domain.ErrPenaltyNotFound,
+		domain.ErrTradeReportNotFound,
+		domain.ErrSharingAgreementNotFound,
+		domain.ErrAppealNotFound,
+		domain.ErrFreezeOrderNotFound,
+	)
+	problem.Register(problem.Conflict,
+		domain.ErrEntityAlreadyExists,
+		domain.ErrDuplicateLicense,
+		domain.ErrDuplicateTradeReport,
+		domain.ErrVersionConflict,
+		domain.ErrDuplicateAppeal,
+	)
+	problem.Register(problem.FailedPrecondition,
+		domain.ErrEntityInactive,
+		domain.ErrLicenseInactive,
+		domain.ErrLicenseExpired,
+		domain.ErrLicenseSuspended,
+		domain.ErrLicenseRevoked,
+		domain.ErrObligationOverdue,
+		domain.ErrNotTradeReporter,
+		domain.ErrSharingAgreementInactive,
+		domain.ErrAppealClosed,
+		domain.ErrFreezeOrderNotInForce,
+	)
+	problem.Register(problem.Unauthenticated, domain.ErrUnauthorized)
+	problem.Register(problem.PermissionDenied, domain.ErrInsufficientRole, domain.ErrOutsideEntityScope, domain.ErrOutsideJurisdiction)
+	problem.Register(problem.InvalidArgument, query.ErrInvalidQuery)
+}
+
+// writeError responds with the problem document for err. Domain validation
+// errors name the field at fault.
+func writeError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		err = problem.Invalid(validationErr.Error(), problem.FieldError{
+			Field:   validationErr.Field,
+			Message: validationErr.Message,
+		})
+	}
+	problem.Write(c, err)
+}
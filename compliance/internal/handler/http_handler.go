@@ -4,13 +4,19 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/csic-platform/compliance/internal/domain"
 	"github.com/csic-platform/compliance/internal/port"
 	"github.com/csic-platform/compliance/internal/service"
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/validation"
+	"github.com/csic-platform/shared/query"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,6 +26,9 @@ type ComplianceHandler struct {
 	licensingService    *service.LicensingService
 	obligationService   *service.ObligationService
 	violationService    *service.ViolationService
+	tradeReportingService *service.TradeReportingService
+	sharingAgreementService *service.SharingAgreementService
+	appealService           *service.AppealService
 }
 
 // NewComplianceHandler creates a new compliance handler
@@ -28,12 +37,18 @@ func NewComplianceHandler(
 	licensingService *service.LicensingService,
 	obligationService *service.ObligationService,
 	violationService *service.ViolationService,
+	tradeReportingService *service.TradeReportingService,
+	sharingAgreementService *service.SharingAgreementService,
+	appealService *service.AppealService,
 ) *ComplianceHandler {
 	return &ComplianceHandler{
-		entityService:     entityService,
-		licensingService:  licensingService,
-		obligationService: obligationService,
-		violationService:  violationService,
+		entityService:         entityService,
+		licensingService:      licensingService,
+		obligationService:     obligationService,
+		violationService:      violationService,
+		tradeReportingService: tradeReportingService,
+		sharingAgreementService: sharingAgreementService,
+		appealService:           appealService,
 	}
 }
 
@@ -50,13 +65,13 @@ func (h *ComplianceHandler) HealthCheck(c *gin.Context) {
 func (h *ComplianceHandler) CreateEntity(c *gin.Context) {
 	var entity domain.RegulatedEntity
 	if err := c.ShouldBindJSON(&entity); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, validation.BindError(err))
 		return
 	}
 
 	actorID := c.GetString("actor_id")
 	if err := h.entityService.CreateEntity(c.Request.Context(), &entity, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -68,7 +83,7 @@ func (h *ComplianceHandler) GetEntity(c *gin.Context) {
 	entityID := c.Param("id")
 	entity, err := h.entityService.GetEntity(c.Request.Context(), entityID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "entity not found"})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, entity)
@@ -107,7 +122,7 @@ func (h *ComplianceHandler) ListEntities(c *gin.Context) {
 
 	entities, err := h.entityService.ListEntities(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -122,14 +137,14 @@ func (h *ComplianceHandler) UpdateEntity(c *gin.Context) {
 	entityID := c.Param("id")
 	var entity domain.RegulatedEntity
 	if err := c.ShouldBindJSON(&entity); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, validation.BindError(err))
 		return
 	}
 	entity.ID = entityID
 
 	actorID := c.GetString("actor_id")
 	if err := h.entityService.UpdateEntity(c.Request.Context(), &entity, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -142,7 +157,7 @@ func (h *ComplianceHandler) ActivateEntity(c *gin.Context) {
 	actorID := c.GetString("actor_id")
 
 	if err := h.entityService.ActivateEntity(c.Request.Context(), entityID, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -152,14 +167,12 @@ func (h *ComplianceHandler) ActivateEntity(c *gin.Context) {
 // SuspendEntity suspends an entity
 func (h *ComplianceHandler) SuspendEntity(c *gin.Context) {
 	entityID := c.Param("id")
-	var req struct {
-		Reason string `json:"reason"`
-	}
+	var req reasonRequest
 	c.ShouldBindJSON(&req)
 
 	actorID := c.GetString("actor_id")
 	if err := h.entityService.SuspendEntity(c.Request.Context(), entityID, req.Reason, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -170,13 +183,13 @@ func (h *ComplianceHandler) SuspendEntity(c *gin.Context) {
 func (h *ComplianceHandler) CreateLicense(c *gin.Context) {
 	var license domain.License
 	if err := c.ShouldBindJSON(&license); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, validation.BindError(err))
 		return
 	}
 
 	actorID := c.GetString("actor_id")
 	if err := h.licensingService.CreateLicense(c.Request.Context(), &license, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -188,15 +201,49 @@ func (h *ComplianceHandler) GetLicense(c *gin.Context) {
 	licenseID := c.Param("id")
 	license, err := h.licensingService.GetLicense(c.Request.Context(), licenseID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "license not found"})
+		writeError(c, err)
 		return
 	}
+	setETag(c, license.Version)
+	c.JSON(http.StatusOK, license)
+}
+
+// UpdateLicense amends a license's terms. The version being amended comes from
+// the If-Match header or the version field; a stale version gets 409 Conflict
+// with the current license.
+func (h *ComplianceHandler) UpdateLicense(c *gin.Context) {
+	licenseID := c.Param("id")
+	var req updateLicenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	version, ok := expectedVersion(c, req.Version)
+	if !ok {
+		writeError(c, problem.New(problem.PreconditionRequired, "If-Match header or version field is required"))
+		return
+	}
+
+	actorID := c.GetString("actor_id")
+	license, err := h.licensingService.UpdateLicense(c.Request.Context(), licenseID, version, req.LicenseTerms, actorID)
+	if err != nil {
+		h.writeLicenseError(c, licenseID, err)
+		return
+	}
+
+	setETag(c, license.Version)
 	c.JSON(http.StatusOK, license)
 }
 
 // ListLicenses lists licenses with filters
 func (h *ComplianceHandler) ListLicenses(c *gin.Context) {
-	filter := port.LicenseFilter{Limit: 100, Offset: 0}
+	params, err := query.Parse(c.Request.URL.Query(), query.DefaultOptions())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	filter := port.LicenseFilter{Query: params}
 
 	if entityID := c.Query("entity_id"); entityID != "" {
 		filter.EntityID = entityID
@@ -208,29 +255,29 @@ func (h *ComplianceHandler) ListLicenses(c *gin.Context) {
 		}
 	}
 
-	licenses, err := h.licensingService.ListLicenses(c.Request.Context(), filter)
+	licenses, page, err := h.licensingService.ListLicenses(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"licenses": licenses,
-		"count":    len(licenses),
+		"licenses":    licenses,
+		"count":       len(licenses),
+		"total":       page.Total,
+		"next_cursor": page.NextCursor,
 	})
 }
 
 // ApproveLicense approves a license
 func (h *ComplianceHandler) ApproveLicense(c *gin.Context) {
 	licenseID := c.Param("id")
-	var req struct {
-		Conditions []domain.LicenseCondition `json:"conditions"`
-	}
+	var req approveLicenseRequest
 	c.ShouldBindJSON(&req)
 
 	actorID := c.GetString("actor_id")
 	if err := h.licensingService.ApproveLicense(c.Request.Context(), licenseID, actorID, req.Conditions); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.writeLicenseError(c, licenseID, err)
 		return
 	}
 
@@ -240,14 +287,12 @@ func (h *ComplianceHandler) ApproveLicense(c *gin.Context) {
 // SuspendLicense suspends a license
 func (h *ComplianceHandler) SuspendLicense(c *gin.Context) {
 	licenseID := c.Param("id")
-	var req struct {
-		Reason string `json:"reason"`
-	}
+	var req reasonRequest
 	c.ShouldBindJSON(&req)
 
 	actorID := c.GetString("actor_id")
 	if err := h.licensingService.SuspendLicense(c.Request.Context(), licenseID, req.Reason, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.writeLicenseError(c, licenseID, err)
 		return
 	}
 
@@ -257,14 +302,12 @@ func (h *ComplianceHandler) SuspendLicense(c *gin.Context) {
 // RevokeLicense revokes a license
 func (h *ComplianceHandler) RevokeLicense(c *gin.Context) {
 	licenseID := c.Param("id")
-	var req struct {
-		Reason string `json:"reason"`
-	}
+	var req reasonRequest
 	c.ShouldBindJSON(&req)
 
 	actorID := c.GetString("actor_id")
 	if err := h.licensingService.RevokeLicense(c.Request.Context(), licenseID, req.Reason, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.writeLicenseError(c, licenseID, err)
 		return
 	}
 
@@ -275,13 +318,13 @@ func (h *ComplianceHandler) RevokeLicense(c *gin.Context) {
 func (h *ComplianceHandler) CreateObligation(c *gin.Context) {
 	var obligation domain.ComplianceObligation
 	if err := c.ShouldBindJSON(&obligation); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, validation.BindError(err))
 		return
 	}
 
 	actorID := c.GetString("actor_id")
 	if err := h.obligationService.CreateObligation(c.Request.Context(), &obligation, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -293,7 +336,7 @@ func (h *ComplianceHandler) GetObligation(c *gin.Context) {
 	obligationID := c.Param("id")
 	obligation, err := h.obligationService.GetObligation(c.Request.Context(), obligationID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "obligation not found"})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, obligation)
@@ -309,7 +352,7 @@ func (h *ComplianceHandler) ListObligations(c *gin.Context) {
 
 	obligations, err := h.obligationService.ListObligations(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -322,14 +365,12 @@ func (h *ComplianceHandler) ListObligations(c *gin.Context) {
 // VerifyObligation verifies a submitted obligation
 func (h *ComplianceHandler) VerifyObligation(c *gin.Context) {
 	obligationID := c.Param("id")
-	var req struct {
-		Notes string `json:"notes"`
-	}
+	var req verifyObligationRequest
 	c.ShouldBindJSON(&req)
 
 	actorID := c.GetString("actor_id")
 	if err := h.obligationService.VerifyObligation(c.Request.Context(), obligationID, req.Notes, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -340,7 +381,7 @@ func (h *ComplianceHandler) VerifyObligation(c *gin.Context) {
 func (h *ComplianceHandler) GetOverdueObligations(c *gin.Context) {
 	obligations, err := h.obligationService.GetOverdueObligations(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -354,13 +395,13 @@ func (h *ComplianceHandler) GetOverdueObligations(c *gin.Context) {
 func (h *ComplianceHandler) CreateViolation(c *gin.Context) {
 	var violation domain.ComplianceViolation
 	if err := c.ShouldBindJSON(&violation); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, validation.BindError(err))
 		return
 	}
 
 	actorID := c.GetString("actor_id")
 	if err := h.violationService.CreateViolation(c.Request.Context(), &violation, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -372,7 +413,7 @@ func (h *ComplianceHandler) GetViolation(c *gin.Context) {
 	violationID := c.Param("id")
 	violation, err := h.violationService.GetViolation(c.Request.Context(), violationID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "violation not found"})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, violation)
@@ -380,37 +421,73 @@ func (h *ComplianceHandler) GetViolation(c *gin.Context) {
 
 // ListViolations lists violations with filters
 func (h *ComplianceHandler) ListViolations(c *gin.Context) {
-	filter := port.ViolationFilter{Limit: 100, Offset: 0}
+	params, err := query.Parse(c.Request.URL.Query(), query.DefaultOptions())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	filter := port.ViolationFilter{Query: params}
 
 	if entityID := c.Query("entity_id"); entityID != "" {
 		filter.EntityID = entityID
 	}
 
-	violations, err := h.violationService.ListViolations(c.Request.Context(), filter)
+	violations, page, err := h.violationService.ListViolations(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"violations": violations,
-		"count":      len(violations),
+		"violations":  violations,
+		"count":       len(violations),
+		"total":       page.Total,
+		"next_cursor": page.NextCursor,
 	})
 }
 
+// writeLicenseError responds to a failed license change. A version conflict
+// returns the current license so the client can reapply its change.
+func (h *ComplianceHandler) writeLicenseError(c *gin.Context, licenseID string, err error) {
+	if errors.Is(err, domain.ErrVersionConflict) {
+		current, getErr := h.licensingService.GetLicense(c.Request.Context(), licenseID)
+		if getErr == nil {
+			setETag(c, current.Version)
+			writeError(c, problem.Classify(err).With("current", current))
+			return
+		}
+	}
+	writeError(c, err)
+}
+
+// expectedVersion returns the version a conditional update applies to, taken
+// from the If-Match header or, without one, from the request body
+func expectedVersion(c *gin.Context, bodyVersion int) (int, bool) {
+	if match := c.GetHeader("If-Match"); match != "" {
+		version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(match, "W/"), `"`))
+		return version, err == nil && version > 0
+	}
+	return bodyVersion, bodyVersion > 0
+}
+
+// setETag exposes a resource's version for use in If-Match
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
 // IssuePenalty issues a penalty for a violation
 func (h *ComplianceHandler) IssuePenalty(c *gin.Context) {
 	violationID := c.Param("id")
 	var penalty domain.Penalty
 	if err := c.ShouldBindJSON(&penalty); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, validation.BindError(err))
 		return
 	}
 	penalty.ViolationID = violationID
 
 	actorID := c.GetString("actor_id")
 	if err := h.violationService.IssuePenalty(c.Request.Context(), violationID, &penalty, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -420,15 +497,12 @@ func (h *ComplianceHandler) IssuePenalty(c *gin.Context) {
 // ResolveViolation resolves a violation
 func (h *ComplianceHandler) ResolveViolation(c *gin.Context) {
 	violationID := c.Param("id")
-	var req struct {
-		CorrectiveAction string `json:"corrective_action"`
-		PreventiveAction string `json:"preventive_action"`
-	}
+	var req resolveViolationRequest
 	c.ShouldBindJSON(&req)
 
 	actorID := c.GetString("actor_id")
 	if err := h.violationService.ResolveViolation(c.Request.Context(), violationID, req.CorrectiveAction, req.PreventiveAction, actorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -440,7 +514,7 @@ func (h *ComplianceHandler) GetOpenViolations(c *gin.Context) {
 	entityID := c.Param("id")
 	violations, err := h.violationService.GetOpenViolations(c.Request.Context(), entityID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -0,0 +1,223 @@
+// Compliance Management Module - API Description
+// OpenAPI annotations for the compliance REST API
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/shared/openapi"
+)
+
+// reasonRequest is the body of suspensions and revocations
+type reasonRequest struct {
+	Reason string `json:"reason"`
+}
+
+// updateLicenseRequest amends a license's terms at a known version
+type updateLicenseRequest struct {
+	domain.LicenseTerms
+	Version int `json:"version" description:"Version being amended, unless given in If-Match"`
+}
+
+// approveLicenseRequest attaches conditions to an approval
+type approveLicenseRequest struct {
+	Conditions []domain.LicenseCondition `json:"conditions"`
+}
+
+// verifyObligationRequest records the reviewer's notes
+type verifyObligationRequest struct {
+	Notes string `json:"notes"`
+}
+
+// resolveViolationRequest records how a violation was remedied
+type resolveViolationRequest struct {
+	CorrectiveAction string `json:"corrective_action"`
+	PreventiveAction string `json:"preventive_action"`
+}
+
+// submitObligationRequest lists the documents fulfilling an obligation
+type submitObligationRequest struct {
+	Attachments []domain.Attachment `json:"attachments" binding:"required,min=1" description:"Documents already uploaded to the document store"`
+}
+
+// appealDocumentsRequest lists documents supporting an appeal
+type appealDocumentsRequest struct {
+	Documents []domain.Attachment `json:"documents" binding:"required,min=1" description:"Documents already uploaded to the document store"`
+}
+
+// Response bodies the handlers write as gin.H
+type (
+	Message struct {
+		Message string `json:"message"`
+	}
+	EntityList struct {
+		Entities []domain.RegulatedEntity `json:"entities"`
+		Count    int                      `json:"count"`
+	}
+	LicenseList struct {
+		Licenses   []domain.License `json:"licenses"`
+		Count      int              `json:"count"`
+		Total      int64            `json:"total"`
+		NextCursor string           `json:"next_cursor"`
+	}
+	ObligationList struct {
+		Obligations []domain.ComplianceObligation `json:"obligations"`
+		Count       int                           `json:"count"`
+	}
+	ViolationList struct {
+		Violations []domain.ComplianceViolation `json:"violations"`
+		Count      int                          `json:"count"`
+		Total      int64                        `json:"total"`
+		NextCursor string                       `json:"next_cursor"`
+	}
+	TradeReportList struct {
+		TradeReports []domain.TradeReport `json:"trade_reports"`
+		Count        int                  `json:"count"`
+	}
+	SharingAgreementList struct {
+		SharingAgreements []domain.SharingAgreement `json:"sharing_agreements"`
+		Count             int                       `json:"count"`
+	}
+	AppealList struct {
+		Appeals []domain.Appeal `json:"appeals"`
+		Count   int             `json:"count"`
+	}
+)
+
+type entityQuery struct {
+	Status []string `form:"status"`
+	Type   []string `form:"type"`
+	Limit  int      `form:"limit" binding:"omitempty,min=1"`
+	Offset int      `form:"offset" binding:"omitempty,min=0"`
+}
+
+type licenseQuery struct {
+	openapi.ListQuery
+	EntityID string   `form:"entity_id"`
+	Status   []string `form:"status"`
+}
+
+type entityIDQuery struct {
+	EntityID string `form:"entity_id"`
+}
+
+type violationQuery struct {
+	openapi.ListQuery
+	EntityID string `form:"entity_id"`
+}
+
+type tradeReportQuery struct {
+	EntityID string   `form:"entity_id"`
+	Status   []string `form:"status"`
+	From     string   `form:"from" description:"First trade date, YYYY-MM-DD"`
+	To       string   `form:"to" description:"Last trade date, YYYY-MM-DD"`
+	Limit    int      `form:"limit" binding:"omitempty,min=1"`
+}
+
+type sharingAgreementQuery struct {
+	EntityID string   `form:"entity_id"`
+	Status   []string `form:"status"`
+	Limit    int      `form:"limit" binding:"omitempty,min=1"`
+}
+
+type appealQuery struct {
+	EntityID      string   `form:"entity_id"`
+	FreezeOrderID string   `form:"freeze_order_id"`
+	Status        []string `form:"status"`
+	Overdue       bool     `form:"overdue" description:"Only open appeals past their decision deadline"`
+	Limit         int      `form:"limit" binding:"omitempty,min=1"`
+}
+
+type portalLicenseQuery struct {
+	openapi.ListQuery
+	Status []string `form:"status"`
+}
+
+type portalStatusQuery struct {
+	Status []string `form:"status"`
+}
+
+type portalTradeReportQuery struct {
+	Status []string `form:"status"`
+	From   string   `form:"from" description:"First trade date, YYYY-MM-DD"`
+	To     string   `form:"to" description:"Last trade date, YYYY-MM-DD"`
+	Limit  int      `form:"limit" binding:"omitempty,min=1"`
+}
+
+type reportDateQuery struct {
+	Date string `form:"date" description:"Report date, YYYY-MM-DD; yesterday by default"`
+}
+
+// Describe annotates the handlers for the service's OpenAPI document
+func (h *ComplianceHandler) Describe(spec *openapi.Spec) {
+	// Entities
+	spec.Describe(h.CreateEntity, openapi.Operation{Summary: "Register a regulated entity", Tags: []string{"entities"}, Request: domain.RegulatedEntity{}, Response: domain.RegulatedEntity{}, Status: http.StatusCreated})
+	spec.Describe(h.ListEntities, openapi.Operation{Summary: "List regulated entities", Tags: []string{"entities"}, Query: entityQuery{}, Response: EntityList{}})
+	spec.Describe(h.GetEntity, openapi.Operation{Summary: "Get a regulated entity", Tags: []string{"entities"}, Response: domain.RegulatedEntity{}})
+	spec.Describe(h.UpdateEntity, openapi.Operation{Summary: "Update a regulated entity", Tags: []string{"entities"}, Request: domain.RegulatedEntity{}, Response: domain.RegulatedEntity{}})
+	spec.Describe(h.ActivateEntity, openapi.Operation{Summary: "Activate an entity", Tags: []string{"entities"}, Response: Message{}})
+	spec.Describe(h.SuspendEntity, openapi.Operation{Summary: "Suspend an entity", Tags: []string{"entities"}, Request: reasonRequest{}, RequestOptional: true, Response: Message{}})
+	spec.Describe(h.GetOpenViolations, openapi.Operation{Summary: "List an entity's open violations", Tags: []string{"entities", "violations"}, Response: ViolationList{}})
+
+	// Licenses
+	spec.Describe(h.CreateLicense, openapi.Operation{Summary: "Apply for a license", Tags: []string{"licenses"}, Request: domain.License{}, Response: domain.License{}, Status: http.StatusCreated})
+	spec.Describe(h.ListLicenses, openapi.Operation{Summary: "List licenses", Tags: []string{"licenses"}, Query: licenseQuery{}, Response: LicenseList{}})
+	spec.Describe(h.GetLicense, openapi.Operation{Summary: "Get a license", Description: "The ETag header carries the license version for If-Match.", Tags: []string{"licenses"}, Response: domain.License{}})
+	spec.Describe(h.UpdateLicense, openapi.Operation{Summary: "Amend a license's terms", Description: "A stale version is answered with 409 Conflict and the current license.", Tags: []string{"licenses"}, Request: updateLicenseRequest{}, Response: domain.License{}})
+	spec.Describe(h.ApproveLicense, openapi.Operation{Summary: "Approve a license", Tags: []string{"licenses"}, Request: approveLicenseRequest{}, RequestOptional: true, Response: Message{}})
+	spec.Describe(h.SuspendLicense, openapi.Operation{Summary: "Suspend a license", Tags: []string{"licenses"}, Request: reasonRequest{}, RequestOptional: true, Response: Message{}})
+	spec.Describe(h.RevokeLicense, openapi.Operation{Summary: "Revoke a license", Tags: []string{"licenses"}, Request: reasonRequest{}, RequestOptional: true, Response: Message{}})
+
+	// Obligations
+	spec.Describe(h.CreateObligation, openapi.Operation{Summary: "Create an obligation", Tags: []string{"obligations"}, Request: domain.ComplianceObligation{}, Response: domain.ComplianceObligation{}, Status: http.StatusCreated})
+	spec.Describe(h.ListObligations, openapi.Operation{Summary: "List obligations", Tags: []string{"obligations"}, Query: entityIDQuery{}, Response: ObligationList{}})
+	spec.Describe(h.GetObligation, openapi.Operation{Summary: "Get an obligation", Tags: []string{"obligations"}, Response: domain.ComplianceObligation{}})
+	spec.Describe(h.VerifyObligation, openapi.Operation{Summary: "Verify a submitted obligation", Tags: []string{"obligations"}, Request: verifyObligationRequest{}, RequestOptional: true, Response: Message{}})
+	spec.Describe(h.GetOverdueObligations, openapi.Operation{Summary: "List overdue obligations", Tags: []string{"obligations"}, Response: ObligationList{}})
+
+	// Violations
+	spec.Describe(h.CreateViolation, openapi.Operation{Summary: "Record a violation", Tags: []string{"violations"}, Request: domain.ComplianceViolation{}, Response: domain.ComplianceViolation{}, Status: http.StatusCreated})
+	spec.Describe(h.ListViolations, openapi.Operation{Summary: "List violations", Tags: []string{"violations"}, Query: violationQuery{}, Response: ViolationList{}})
+	spec.Describe(h.GetViolation, openapi.Operation{Summary: "Get a violation", Tags: []string{"violations"}, Response: domain.ComplianceViolation{}})
+	spec.Describe(h.IssuePenalty, openapi.Operation{Summary: "Issue a penalty for a violation", Tags: []string{"violations"}, Request: domain.Penalty{}, Response: domain.Penalty{}, Status: http.StatusCreated})
+	spec.Describe(h.ResolveViolation, openapi.Operation{Summary: "Resolve a violation", Tags: []string{"violations"}, Request: resolveViolationRequest{}, RequestOptional: true, Response: Message{}})
+
+	// Trade reporting
+	spec.Describe(h.SubmitTradeReport, openapi.Operation{Summary: "Submit a daily trade report", Tags: []string{"trade-reports"}, Request: domain.TradeReport{}, Response: domain.TradeReport{}, Status: http.StatusCreated})
+	spec.Describe(h.ListTradeReports, openapi.Operation{Summary: "List trade reports", Tags: []string{"trade-reports"}, Query: tradeReportQuery{}, Response: TradeReportList{}})
+	spec.Describe(h.GetTradeReport, openapi.Operation{Summary: "Get a trade report", Tags: []string{"trade-reports"}, Response: domain.TradeReport{}})
+	spec.Describe(h.ReconcileTradeReport, openapi.Operation{Summary: "Reconcile a trade report against on-chain flows", Tags: []string{"trade-reports"}, Response: domain.TradeReport{}})
+	spec.Describe(h.GetDailyRegulatoryReport, openapi.Operation{Summary: "Get the daily regulatory report", Tags: []string{"reports"}, Query: reportDateQuery{}, Response: domain.DailyRegulatoryReport{}})
+
+	// Sharing agreements
+	spec.Describe(h.CreateSharingAgreement, openapi.Operation{Summary: "Share an entity's records with another jurisdiction", Description: "Only the entity's own jurisdiction may share it; access is read-only.", Tags: []string{"sharing-agreements"}, Request: domain.SharingAgreement{}, Response: domain.SharingAgreement{}, Status: http.StatusCreated})
+	spec.Describe(h.ListSharingAgreements, openapi.Operation{Summary: "List sharing agreements owned or received by the caller's jurisdictions", Tags: []string{"sharing-agreements"}, Query: sharingAgreementQuery{}, Response: SharingAgreementList{}})
+	spec.Describe(h.GetSharingAgreement, openapi.Operation{Summary: "Get a sharing agreement", Tags: []string{"sharing-agreements"}, Response: domain.SharingAgreement{}})
+	spec.Describe(h.RevokeSharingAgreement, openapi.Operation{Summary: "Revoke a sharing agreement", Tags: []string{"sharing-agreements"}, Request: reasonRequest{}, Response: domain.SharingAgreement{}})
+
+	// Appeals
+	spec.Describe(h.ListAppeals, openapi.Operation{Summary: "List appeals against freeze orders", Tags: []string{"appeals"}, Query: appealQuery{}, Response: AppealList{}})
+	spec.Describe(h.GetAppeal, openapi.Operation{Summary: "Get an appeal and its determinations", Tags: []string{"appeals"}, Response: domain.Appeal{}})
+	spec.Describe(h.RecordAppealDetermination, openapi.Operation{Summary: "Record a determination on an appeal", Description: "REQUEST_INFORMATION needs a response_due_at. UPHOLD and REVOKE close the appeal and are applied to the freeze order.", Tags: []string{"appeals"}, Request: domain.AppealDetermination{}, Response: domain.Appeal{}})
+}
+
+// Describe annotates the portal handlers for the service's OpenAPI document
+func (h *PortalHandler) Describe(spec *openapi.Spec) {
+	spec.Describe(h.GetEntity, openapi.Operation{Summary: "Get the caller's entity record", Tags: []string{"portal"}, Response: domain.RegulatedEntity{}})
+	spec.Describe(h.ListLicenses, openapi.Operation{Summary: "List the caller's licenses", Tags: []string{"portal"}, Query: portalLicenseQuery{}, Response: LicenseList{}})
+	spec.Describe(h.GetLicense, openapi.Operation{Summary: "Get one of the caller's licenses", Tags: []string{"portal"}, Response: domain.License{}})
+	spec.Describe(h.ListOpenViolations, openapi.Operation{Summary: "List the caller's open violations", Tags: []string{"portal"}, Response: ViolationList{}})
+	spec.Describe(h.GetViolation, openapi.Operation{Summary: "Get one of the caller's violations", Tags: []string{"portal"}, Response: domain.ComplianceViolation{}})
+	spec.Describe(h.ListObligations, openapi.Operation{Summary: "List the caller's reporting obligations", Tags: []string{"portal"}, Query: portalStatusQuery{}, Response: ObligationList{}})
+	spec.Describe(h.GetObligation, openapi.Operation{Summary: "Get one of the caller's reporting obligations", Tags: []string{"portal"}, Response: domain.ComplianceObligation{}})
+	spec.Describe(h.SubmitObligation, openapi.Operation{Summary: "Submit the documents fulfilling an obligation", Tags: []string{"portal"}, Request: submitObligationRequest{}, Response: domain.ComplianceObligation{}})
+	spec.Describe(h.SubmitTradeReport, openapi.Operation{Summary: "Submit a daily trade report", Description: "The report is filed for the caller's entity; naming another entity is refused.", Tags: []string{"portal"}, Request: domain.TradeReport{}, Response: domain.TradeReport{}, Status: http.StatusCreated})
+	spec.Describe(h.ListTradeReports, openapi.Operation{Summary: "List the caller's trade reports", Tags: []string{"portal"}, Query: portalTradeReportQuery{}, Response: TradeReportList{}})
+	spec.Describe(h.GetTradeReport, openapi.Operation{Summary: "Get one of the caller's trade reports", Tags: []string{"portal"}, Response: domain.TradeReport{}})
+	spec.Describe(h.FileAppeal, openapi.Operation{Summary: "Appeal a freeze order on one of the caller's wallets", Description: "The caller must own the frozen wallet or operate the exchange holding it.", Tags: []string{"portal"}, Request: domain.Appeal{}, Response: domain.Appeal{}, Status: http.StatusCreated})
+	spec.Describe(h.ListAppeals, openapi.Operation{Summary: "List the caller's appeals", Tags: []string{"portal"}, Query: portalStatusQuery{}, Response: AppealList{}})
+	spec.Describe(h.GetAppeal, openapi.Operation{Summary: "Get one of the caller's appeals", Tags: []string{"portal"}, Response: domain.Appeal{}})
+	spec.Describe(h.AddAppealDocuments, openapi.Operation{Summary: "Add supporting documents to an open appeal", Tags: []string{"portal"}, Request: appealDocumentsRequest{}, Response: domain.Appeal{}})
+}
@@ -0,0 +1,299 @@
+// Compliance Management Module - Self-Service Portal Handlers
+// Externally scoped REST API through which regulated entities see their own records
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/compliance/internal/service"
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/query"
+	"github.com/csic-platform/shared/session"
+	"github.com/csic-platform/shared/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// portalEntityKey is the gin context key of the caller's entity ID
+const portalEntityKey = "portal_entity_id"
+
+// PortalHandler handles the self-service portal of regulated entities. Its
+// routes must sit behind PortalAuth, which scopes each request to the entity
+// named by the caller's token.
+type PortalHandler struct {
+	portalService *service.PortalService
+}
+
+// NewPortalHandler creates a new portal handler
+func NewPortalHandler(portalService *service.PortalService) *PortalHandler {
+	return &PortalHandler{portalService: portalService}
+}
+
+// PortalAuth authenticates portal requests by their bearer access token. The
+// token must carry an entity_id claim: staff tokens, which name no entity, are
+// refused. With a nil checker only the token's signature and expiry are checked.
+func PortalAuth(secret string, checker session.Checker) gin.HandlerFunc {
+	key := []byte(secret)
+	return func(c *gin.Context) {
+		claims, ok := authenticate(c, key, checker)
+		if !ok {
+			return
+		}
+
+		if claims.EntityID == "" {
+			writeError(c, problem.New(problem.PermissionDenied, "token is not scoped to a regulated entity"))
+			return
+		}
+
+		c.Set("actor_id", claims.UserID)
+		c.Set(portalEntityKey, claims.EntityID)
+		c.Request = c.Request.WithContext(session.WithClaims(c.Request.Context(), claims))
+		c.Next()
+	}
+}
+
+// Routes registers the portal routes on group, which must already use PortalAuth
+func (h *PortalHandler) Routes(group *gin.RouterGroup) {
+	group.GET("/entity", h.GetEntity)
+	group.GET("/licenses", h.ListLicenses)
+	group.GET("/licenses/:id", h.GetLicense)
+	group.GET("/violations", h.ListOpenViolations)
+	group.GET("/violations/:id", h.GetViolation)
+	group.GET("/obligations", h.ListObligations)
+	group.GET("/obligations/:id", h.GetObligation)
+	group.POST("/obligations/:id/submit", h.SubmitObligation)
+	group.POST("/trade-reports", h.SubmitTradeReport)
+	group.GET("/trade-reports", h.ListTradeReports)
+	group.GET("/trade-reports/:id", h.GetTradeReport)
+	group.POST("/appeals", h.FileAppeal)
+	group.GET("/appeals", h.ListAppeals)
+	group.GET("/appeals/:id", h.GetAppeal)
+	group.POST("/appeals/:id/documents", h.AddAppealDocuments)
+}
+
+// GetEntity retrieves the caller's exchange record
+func (h *PortalHandler) GetEntity(c *gin.Context) {
+	entity, err := h.portalService.GetEntity(c.Request.Context(), c.GetString(portalEntityKey))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, entity)
+}
+
+// ListLicenses lists the caller's licenses
+func (h *PortalHandler) ListLicenses(c *gin.Context) {
+	params, err := query.Parse(c.Request.URL.Query(), query.DefaultOptions())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	filter := port.LicenseFilter{Query: params}
+	for _, s := range c.QueryArray("status") {
+		filter.Status = append(filter.Status, domain.LicenseStatus(s))
+	}
+
+	licenses, page, err := h.portalService.ListLicenses(c.Request.Context(), c.GetString(portalEntityKey), filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"licenses":    licenses,
+		"count":       len(licenses),
+		"total":       page.Total,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// GetLicense retrieves one of the caller's licenses and its status
+func (h *PortalHandler) GetLicense(c *gin.Context) {
+	license, err := h.portalService.GetLicense(c.Request.Context(), c.GetString(portalEntityKey), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, license)
+}
+
+// ListOpenViolations lists the caller's open violations
+func (h *PortalHandler) ListOpenViolations(c *gin.Context) {
+	violations, err := h.portalService.GetOpenViolations(c.Request.Context(), c.GetString(portalEntityKey))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"violations": violations,
+		"count":      len(violations),
+	})
+}
+
+// GetViolation retrieves one of the caller's violations
+func (h *PortalHandler) GetViolation(c *gin.Context) {
+	violation, err := h.portalService.GetViolation(c.Request.Context(), c.GetString(portalEntityKey), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, violation)
+}
+
+// ListObligations lists the caller's reporting obligations
+func (h *PortalHandler) ListObligations(c *gin.Context) {
+	filter := port.ObligationFilter{Limit: 100, Offset: 0}
+	for _, s := range c.QueryArray("status") {
+		filter.Status = append(filter.Status, domain.ObligationStatus(s))
+	}
+
+	obligations, err := h.portalService.ListObligations(c.Request.Context(), c.GetString(portalEntityKey), filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"obligations": obligations,
+		"count":       len(obligations),
+	})
+}
+
+// GetObligation retrieves one of the caller's reporting obligations
+func (h *PortalHandler) GetObligation(c *gin.Context) {
+	obligation, err := h.portalService.GetObligation(c.Request.Context(), c.GetString(portalEntityKey), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, obligation)
+}
+
+// SubmitObligation files the documents fulfilling one of the caller's
+// reporting obligations
+func (h *PortalHandler) SubmitObligation(c *gin.Context) {
+	var req submitObligationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	obligation, err := h.portalService.SubmitObligation(c.Request.Context(), c.GetString(portalEntityKey), c.Param("id"), req.Attachments, c.GetString("actor_id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, obligation)
+}
+
+// SubmitTradeReport files a daily trade report for the caller's entity
+func (h *PortalHandler) SubmitTradeReport(c *gin.Context) {
+	var report domain.TradeReport
+	if err := c.ShouldBindJSON(&report); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	if err := h.portalService.SubmitTradeReport(c.Request.Context(), c.GetString(portalEntityKey), &report, c.GetString("actor_id")); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// ListTradeReports lists the caller's trade reports by status and trade date range
+func (h *PortalHandler) ListTradeReports(c *gin.Context) {
+	filter, err := tradeReportFilter(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	reports, err := h.portalService.ListTradeReports(c.Request.Context(), c.GetString(portalEntityKey), filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"trade_reports": reports,
+		"count":         len(reports),
+	})
+}
+
+// GetTradeReport retrieves one of the caller's trade reports
+func (h *PortalHandler) GetTradeReport(c *gin.Context) {
+	report, err := h.portalService.GetTradeReport(c.Request.Context(), c.GetString(portalEntityKey), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// FileAppeal files an appeal against a freeze order on one of the caller's
+// wallets
+func (h *PortalHandler) FileAppeal(c *gin.Context) {
+	var appeal domain.Appeal
+	if err := c.ShouldBindJSON(&appeal); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	if err := h.portalService.FileAppeal(c.Request.Context(), c.GetString(portalEntityKey), &appeal, c.GetString("actor_id")); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, appeal)
+}
+
+// ListAppeals lists the caller's appeals
+func (h *PortalHandler) ListAppeals(c *gin.Context) {
+	filter := port.AppealFilter{Limit: 100}
+	for _, s := range c.QueryArray("status") {
+		filter.Status = append(filter.Status, domain.AppealStatus(s))
+	}
+
+	appeals, err := h.portalService.ListAppeals(c.Request.Context(), c.GetString(portalEntityKey), filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appeals": appeals,
+		"count":   len(appeals),
+	})
+}
+
+// GetAppeal retrieves one of the caller's appeals and its determinations
+func (h *PortalHandler) GetAppeal(c *gin.Context) {
+	appeal, err := h.portalService.GetAppeal(c.Request.Context(), c.GetString(portalEntityKey), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, appeal)
+}
+
+// AddAppealDocuments adds supporting documents to one of the caller's open
+// appeals
+func (h *PortalHandler) AddAppealDocuments(c *gin.Context) {
+	var req appealDocumentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	appeal, err := h.portalService.AddAppealDocuments(c.Request.Context(), c.GetString(portalEntityKey), c.Param("id"), req.Documents, c.GetString("actor_id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, appeal)
+}
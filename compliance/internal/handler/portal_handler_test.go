@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/csic-platform/shared/session"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testPortalSecret = "portal-test-secret"
+
+func signPortalToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + token
+}
+
+func newPortalAuthRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/portal/whoami", PortalAuth(testPortalSecret, nil), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"entity_id": c.GetString(portalEntityKey),
+			"actor_id":  c.GetString("actor_id"),
+		})
+	})
+	return router
+}
+
+func TestPortalAuth(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"not a bearer token", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"wrong signature", signPortalToken(t, "other-secret", jwt.MapClaims{"user_id": "u1", session.ClaimEntityID: "entity-1", "exp": exp}), http.StatusUnauthorized},
+		{"expired", signPortalToken(t, testPortalSecret, jwt.MapClaims{"user_id": "u1", session.ClaimEntityID: "entity-1", "exp": time.Now().Add(-time.Hour).Unix()}), http.StatusUnauthorized},
+		{"staff token without entity", signPortalToken(t, testPortalSecret, jwt.MapClaims{"user_id": "u1", "role": "ADMIN", "exp": exp}), http.StatusForbidden},
+		{"entity token", signPortalToken(t, testPortalSecret, jwt.MapClaims{"user_id": "u1", session.ClaimEntityID: "entity-1", "exp": exp}), http.StatusOK},
+	}
+
+	router := newPortalAuthRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/portal/whoami", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("got status %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPortalAuth_ScopesRequestToTokenEntity(t *testing.T) {
+	router := newPortalAuthRouter()
+	token := signPortalToken(t, testPortalSecret, jwt.MapClaims{
+		"user_id":             "u1",
+		session.ClaimEntityID: "entity-1",
+		"exp":                 time.Now().Add(time.Hour).Unix(),
+	})
+
+	// The entity comes from the token alone; a query parameter cannot widen it
+	req := httptest.NewRequest(http.MethodGet, "/portal/whoami?entity_id=entity-2", nil)
+	req.Header.Set("Authorization", token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", rec.Code, rec.Body.String())
+	}
+	if want := `{"actor_id":"u1","entity_id":"entity-1"}`; rec.Body.String() != want {
+		t.Errorf("got %s, want %s", rec.Body.String(), want)
+	}
+}
@@ -0,0 +1,77 @@
+// Compliance Management Module - Sharing Agreement Handlers
+// REST API handlers for cross-jurisdiction sharing of a case's records
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateSharingAgreement shares an entity's records with another jurisdiction
+func (h *ComplianceHandler) CreateSharingAgreement(c *gin.Context) {
+	var agreement domain.SharingAgreement
+	if err := c.ShouldBindJSON(&agreement); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	actorID := c.GetString("actor_id")
+	if err := h.sharingAgreementService.CreateSharingAgreement(c.Request.Context(), &agreement, actorID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, agreement)
+}
+
+// GetSharingAgreement retrieves a sharing agreement by ID
+func (h *ComplianceHandler) GetSharingAgreement(c *gin.Context) {
+	agreement, err := h.sharingAgreementService.GetSharingAgreement(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, agreement)
+}
+
+// ListSharingAgreements lists the sharing agreements the caller owns or receives
+func (h *ComplianceHandler) ListSharingAgreements(c *gin.Context) {
+	filter := port.SharingAgreementFilter{EntityID: c.Query("entity_id")}
+	for _, s := range c.QueryArray("status") {
+		filter.Status = append(filter.Status, domain.SharingAgreementStatus(s))
+	}
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	agreements, err := h.sharingAgreementService.ListSharingAgreements(c.Request.Context(), filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sharing_agreements": agreements,
+		"count":              len(agreements),
+	})
+}
+
+// RevokeSharingAgreement ends a sharing agreement before its expiry
+func (h *ComplianceHandler) RevokeSharingAgreement(c *gin.Context) {
+	var req reasonRequest
+	c.ShouldBindJSON(&req)
+
+	actorID := c.GetString("actor_id")
+	agreement, err := h.sharingAgreementService.RevokeSharingAgreement(c.Request.Context(), c.Param("id"), req.Reason, actorID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, agreement)
+}
@@ -0,0 +1,124 @@
+// Compliance Management Module - Trade Report Handlers
+// REST API handlers for OTC desk and P2P platform trade reporting
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// dateLayout is the format of trade dates in query parameters
+const dateLayout = "2006-01-02"
+
+// SubmitTradeReport files a daily trade report for an OTC desk or P2P platform
+func (h *ComplianceHandler) SubmitTradeReport(c *gin.Context) {
+	var report domain.TradeReport
+	if err := c.ShouldBindJSON(&report); err != nil {
+		writeError(c, validation.BindError(err))
+		return
+	}
+
+	actorID := c.GetString("actor_id")
+	if err := h.tradeReportingService.SubmitTradeReport(c.Request.Context(), &report, actorID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetTradeReport retrieves a trade report by ID
+func (h *ComplianceHandler) GetTradeReport(c *gin.Context) {
+	report, err := h.tradeReportingService.GetTradeReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ListTradeReports lists trade reports, optionally by entity, status and trade date range
+func (h *ComplianceHandler) ListTradeReports(c *gin.Context) {
+	filter, err := tradeReportFilter(c)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	filter.EntityID = c.Query("entity_id")
+
+	reports, err := h.tradeReportingService.ListTradeReports(c.Request.Context(), filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"trade_reports": reports,
+		"count":         len(reports),
+	})
+}
+
+// ReconcileTradeReport reconciles a trade report against on-chain flows
+func (h *ComplianceHandler) ReconcileTradeReport(c *gin.Context) {
+	actorID := c.GetString("actor_id")
+	report, err := h.tradeReportingService.ReconcileTradeReport(c.Request.Context(), c.Param("id"), actorID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetDailyRegulatoryReport returns the daily regulatory report for ?date=, defaulting to yesterday
+func (h *ComplianceHandler) GetDailyRegulatoryReport(c *gin.Context) {
+	date := time.Now().UTC().AddDate(0, 0, -1)
+	if value := c.Query("date"); value != "" {
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			writeError(c, problem.Invalid("date must be a YYYY-MM-DD date", problem.FieldError{Field: "date", Message: "must be a YYYY-MM-DD date"}))
+			return
+		}
+		date = parsed
+	}
+
+	report, err := h.tradeReportingService.GetDailyRegulatoryReport(c.Request.Context(), date)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// tradeReportFilter reads the status, trade date range and limit of a trade
+// report listing from the query string
+func tradeReportFilter(c *gin.Context) (port.TradeReportFilter, error) {
+	var filter port.TradeReportFilter
+
+	for _, s := range c.QueryArray("status") {
+		filter.Status = append(filter.Status, domain.TradeReportStatus(s))
+	}
+
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(param); value != "" {
+			date, err := time.Parse(dateLayout, value)
+			if err != nil {
+				return filter, problem.Invalid(param+" must be a YYYY-MM-DD date", problem.FieldError{Field: param, Message: "must be a YYYY-MM-DD date"})
+			}
+			*target = &date
+		}
+	}
+
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "100"))
+	return filter, nil
+}
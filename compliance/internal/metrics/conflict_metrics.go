@@ -0,0 +1,35 @@
+// Compliance Management Module - Metrics
+// Prometheus metrics for compliance operations
+
+package metrics
+
+import (
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ConflictMetrics implements the ConflictMetrics port using Prometheus
+type ConflictMetrics struct {
+	conflicts *prometheus.CounterVec
+}
+
+// NewConflictMetrics creates and registers the optimistic locking metrics
+func NewConflictMetrics(registerer prometheus.Registerer) *ConflictMetrics {
+	factory := promauto.With(registerer)
+
+	return &ConflictMetrics{
+		conflicts: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "compliance_version_conflicts_total",
+			Help: "Total number of updates rejected because the resource was modified concurrently",
+		}, []string{"resource"}),
+	}
+}
+
+// IncVersionConflict counts an update rejected with a version conflict
+func (m *ConflictMetrics) IncVersionConflict(resource string) {
+	m.conflicts.WithLabelValues(resource).Inc()
+}
+
+// Ensure ConflictMetrics implements the ConflictMetrics port
+var _ port.ConflictMetrics = (*ConflictMetrics)(nil)
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/shared/query"
+	"github.com/shopspring/decimal"
 )
 
 // AuditLogPort defines the interface for audit logging
@@ -31,6 +33,98 @@ type AuditEntry struct {
 	Metadata      map[string]interface{}
 }
 
+// NotificationPort defines the interface for notifying regulated entities
+type NotificationPort interface {
+	NotifyLicenseExpiry(ctx context.Context, notice *LicenseExpiryNotice) error
+}
+
+// LicenseExpiryNotice represents an upcoming license expiry notification
+type LicenseExpiryNotice struct {
+	LicenseID     string
+	LicenseNumber string
+	EntityID      string
+	EntityName    string
+	ThresholdDays int
+	DaysRemaining int
+	ExpiresAt     time.Time
+}
+
+// EnforcementPort defines the interface for requesting enforcement actions
+type EnforcementPort interface {
+	IssueFreezeOrder(ctx context.Context, order *FreezeOrderRequest) error
+}
+
+// FreezeOrderRequest represents a request to freeze a regulated entity's operations
+type FreezeOrderRequest struct {
+	EntityID    string
+	LicenseID   string
+	Reason      string
+	RequestedBy string
+	Metadata    map[string]interface{}
+}
+
+// FreezeOrderPort defines the interface for reading and deciding freeze orders
+// issued by wallet governance
+type FreezeOrderPort interface {
+	// GetFreezeOrder fails with domain.ErrFreezeOrderNotFound for an unknown order
+	GetFreezeOrder(ctx context.Context, orderID string) (*FreezeOrder, error)
+	AppealFreezeOrder(ctx context.Context, orderID, appealID string) error
+	UpholdFreezeOrder(ctx context.Context, orderID, appealID string) error
+	RevokeFreezeOrder(ctx context.Context, orderID, appealID, reason string) error
+}
+
+// FreezeOrder represents a freeze order and the parties entitled to appeal it
+type FreezeOrder struct {
+	ID            string
+	WalletID      string
+	WalletAddress string
+	Status        string
+	UnderAppeal   bool
+	OwnerEntityID string
+	ExchangeID    string // empty unless the wallet is held by an exchange
+}
+
+// InForce reports whether the order still holds its wallet
+func (o *FreezeOrder) InForce() bool {
+	return o.Status == "ACTIVE" || o.Status == "PARTIAL"
+}
+
+// OnChainFlowPort defines the interface for querying on-chain flows of an address
+type OnChainFlowPort interface {
+	GetAddressFlows(ctx context.Context, chain, address, asset string, start, end time.Time) (*AddressFlows, error)
+}
+
+// AddressFlows holds the amounts of an asset an address received and sent over a period
+type AddressFlows struct {
+	Inflow  float64
+	Outflow float64
+}
+
+// ConflictMetrics defines the interface for recording optimistic locking conflicts
+type ConflictMetrics interface {
+	IncVersionConflict(resource string)
+}
+
+// TxManager defines the interface for running repository calls in one transaction.
+// Repositories called with the context passed to fn take part in the transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxRepository defines the interface for transactional outbox storage
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, events ...*domain.OutboxEvent) error
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id, lastError string, nextAttemptAt time.Time) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventPublisher defines the interface for publishing outbox events to the message bus
+type EventPublisher interface {
+	Publish(ctx context.Context, event *domain.OutboxEvent) error
+}
+
 // EntityRepository defines the interface for entity storage
 type EntityRepository interface {
 	Create(ctx context.Context, entity *domain.RegulatedEntity) error
@@ -47,13 +141,55 @@ type LicenseRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.License, error)
 	GetByLicenseNumber(ctx context.Context, number string) (*domain.License, error)
 	GetActiveByEntityAndType(ctx context.Context, entityID string, licenseType domain.LicenseType) (*domain.License, error)
+	// Update fails with domain.ErrVersionConflict if the license has changed
+	// since it was read, and bumps its version otherwise
 	Update(ctx context.Context, license *domain.License) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, filter LicenseFilter) ([]*domain.License, error)
+	List(ctx context.Context, filter LicenseFilter) ([]*domain.License, *query.PageInfo, error)
 	GetExpiring(ctx context.Context, withinDays int) ([]*domain.License, error)
 	GetStatistics(ctx context.Context) (*LicenseStatistics, error)
 }
 
+// ExpiryNoticeRepository defines the interface for tracking sent expiry notices
+type ExpiryNoticeRepository interface {
+	HasExpiryNotice(ctx context.Context, licenseID string, thresholdDays int) (bool, error)
+	RecordExpiryNotice(ctx context.Context, licenseID string, thresholdDays int, sentAt time.Time) error
+}
+
+// TradeReportRepository defines the interface for OTC and P2P trade report storage
+type TradeReportRepository interface {
+	// CreateTradeReport fails with domain.ErrDuplicateTradeReport if the entity
+	// has already filed for the same date, asset and chain
+	CreateTradeReport(ctx context.Context, report *domain.TradeReport) error
+	GetTradeReport(ctx context.Context, id string) (*domain.TradeReport, error)
+	UpdateTradeReportReconciliation(ctx context.Context, report *domain.TradeReport) error
+	ListTradeReports(ctx context.Context, filter TradeReportFilter) ([]*domain.TradeReport, error)
+}
+
+// SharingAgreementRepository defines the interface for cross-jurisdiction
+// sharing agreement storage
+type SharingAgreementRepository interface {
+	CreateSharingAgreement(ctx context.Context, agreement *domain.SharingAgreement) error
+	GetSharingAgreement(ctx context.Context, id string) (*domain.SharingAgreement, error)
+	UpdateSharingAgreement(ctx context.Context, agreement *domain.SharingAgreement) error
+	ListSharingAgreements(ctx context.Context, filter SharingAgreementFilter) ([]*domain.SharingAgreement, error)
+	// HasActiveSharingAgreement reports whether an agreement active at the
+	// given time shares the entity's records with any of the jurisdictions
+	HasActiveSharingAgreement(ctx context.Context, entityID string, jurisdictions []string, at time.Time) (bool, error)
+}
+
+// AppealRepository defines the interface for freeze order appeal storage
+type AppealRepository interface {
+	// CreateAppeal fails with domain.ErrDuplicateAppeal if the freeze order
+	// already has an open appeal
+	CreateAppeal(ctx context.Context, appeal *domain.Appeal) error
+	GetAppeal(ctx context.Context, id string) (*domain.Appeal, error)
+	// UpdateAppeal fails with domain.ErrVersionConflict if the appeal has
+	// changed since it was read, and bumps its version otherwise
+	UpdateAppeal(ctx context.Context, appeal *domain.Appeal) error
+	ListAppeals(ctx context.Context, filter AppealFilter) ([]*domain.Appeal, error)
+}
+
 // ObligationRepository defines the interface for obligation storage
 type ObligationRepository interface {
 	Create(ctx context.Context, obligation *domain.ComplianceObligation) error
@@ -73,7 +209,7 @@ type ViolationRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.ComplianceViolation, error)
 	Update(ctx context.Context, violation *domain.ComplianceViolation) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, filter ViolationFilter) ([]*domain.ComplianceViolation, error)
+	List(ctx context.Context, filter ViolationFilter) ([]*domain.ComplianceViolation, *query.PageInfo, error)
 	GetOpenByEntityID(ctx context.Context, entityID string) ([]*domain.ComplianceViolation, error)
 	GetStatistics(ctx context.Context, entityID string) (*ViolationStatistics, error)
 }
@@ -96,21 +232,24 @@ type EntityFilter struct {
 	Status     []domain.EntityStatus
 	Type       []domain.EntityType
 	Jurisdiction string
+	Jurisdictions []string // row scope of the caller; nil means unrestricted
 	Search     string
 	Limit      int
 	Offset     int
 }
 
-// LicenseFilter defines filters for license queries
+// LicenseFilter defines filters for license queries. Query carries the
+// request's sort, filter and pagination parameters; the typed fields are
+// added to its filters.
 type LicenseFilter struct {
 	EntityID    string
 	Status      []domain.LicenseStatus
 	Type        []domain.LicenseType
 	Jurisdiction string
+	Jurisdictions []string // row scope of the caller; nil means unrestricted
 	ExpiresBefore *time.Time
 	ExpiresAfter  *time.Time
-	Limit       int
-	Offset      int
+	Query       *query.Params
 }
 
 // ObligationFilter defines filters for obligation queries
@@ -126,7 +265,9 @@ type ObligationFilter struct {
 	Offset      int
 }
 
-// ViolationFilter defines filters for violation queries
+// ViolationFilter defines filters for violation queries. Query carries the
+// request's sort, filter and pagination parameters; the typed fields are
+// added to its filters.
 type ViolationFilter struct {
 	EntityID    string
 	Status      []domain.ViolationStatus
@@ -134,8 +275,36 @@ type ViolationFilter struct {
 	Severity    []domain.ViolationSeverity
 	DetectedAfter *time.Time
 	DetectedBefore *time.Time
-	Limit       int
-	Offset      int
+	Query       *query.Params
+}
+
+// TradeReportFilter defines filters for trade report queries
+type TradeReportFilter struct {
+	EntityID      string
+	Jurisdictions []string // row scope of the caller; nil means unrestricted
+	Status        []domain.TradeReportStatus
+	From          *time.Time
+	To            *time.Time
+	Limit         int
+}
+
+// SharingAgreementFilter defines filters for sharing agreement queries.
+// Jurisdictions matches agreements either owned or received by them.
+type SharingAgreementFilter struct {
+	EntityID      string
+	Jurisdictions []string
+	Status        []domain.SharingAgreementStatus
+	Limit         int
+}
+
+// AppealFilter defines filters for appeal queries
+type AppealFilter struct {
+	EntityID      string
+	FreezeOrderID string
+	Jurisdictions []string // row scope of the caller; nil means unrestricted
+	Status        []domain.AppealStatus
+	DueBefore     *time.Time // open appeals whose decision is due before
+	Limit         int
 }
 
 // PenaltyFilter defines filters for penalty queries
@@ -174,13 +343,13 @@ type ObligationStatistics struct {
 
 // ViolationStatistics holds violation statistics
 type ViolationStatistics struct {
-	Total       int
-	Open        int
-	Resolved    int
-	Closed      int
-	BySeverity  map[domain.ViolationSeverity]int
-	ByType      map[domain.ViolationType]int
-	TotalPenalties float64
+	Total          int
+	Open           int
+	Resolved       int
+	Closed         int
+	BySeverity     map[domain.ViolationSeverity]int
+	ByType         map[domain.ViolationType]int
+	TotalPenalties decimal.Decimal
 }
 
 // ComplianceReport represents a compliance report
@@ -0,0 +1,180 @@
+// Compliance Management Module - Appeal Repository
+// PostgreSQL storage for freeze order appeals and their determinations
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+	"github.com/google/uuid"
+)
+
+// appealColumns lists appeals columns in scan order
+const appealColumns = `id, entity_id, jurisdiction, freeze_order_id, wallet_id, wallet_address,
+	grounds, documents, status, decision_due_at, determinations, filed_by, filed_at, resolved_at,
+	updated_at, version`
+
+// CreateAppeal stores a new appeal
+func (r *PostgresRepository) CreateAppeal(ctx context.Context, appeal *domain.Appeal) error {
+	if appeal.ID == "" {
+		appeal.ID = uuid.New().String()
+	}
+
+	documents, determinations, err := encodeAppeal(appeal)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO appeals (id, entity_id, jurisdiction, freeze_order_id, wallet_id,
+			wallet_address, grounds, documents, status, decision_due_at, determinations,
+			filed_by, filed_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, 1)
+		ON CONFLICT (freeze_order_id) WHERE status IN ('PENDING_REVIEW', 'INFORMATION_REQUESTED') DO NOTHING
+		RETURNING id
+	`
+	err = r.writer(ctx).QueryRowContext(ctx, query,
+		appeal.ID, appeal.EntityID, appeal.Jurisdiction, appeal.FreezeOrderID, appeal.WalletID,
+		appeal.WalletAddress, appeal.Grounds, documents, appeal.Status, appeal.DecisionDueAt,
+		determinations, appeal.FiledBy, appeal.FiledAt, appeal.UpdatedAt,
+	).Scan(&appeal.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrDuplicateAppeal
+	}
+	if err != nil {
+		return err
+	}
+	appeal.Version = 1
+	return nil
+}
+
+// GetAppeal retrieves an appeal by ID
+func (r *PostgresRepository) GetAppeal(ctx context.Context, id string) (*domain.Appeal, error) {
+	query := "SELECT " + appealColumns + " FROM appeals WHERE id = $1"
+	appeal, err := scanAppeal(r.conn(ctx).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAppealNotFound
+	}
+	return appeal, err
+}
+
+// UpdateAppeal saves an appeal's documents, determinations and status if it
+// is still at the version it was read at, bumping the version
+func (r *PostgresRepository) UpdateAppeal(ctx context.Context, appeal *domain.Appeal) error {
+	documents, determinations, err := encodeAppeal(appeal)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.writer(ctx).ExecContext(ctx, `
+		UPDATE appeals
+		SET documents = $1, status = $2, determinations = $3, resolved_at = $4, updated_at = $5,
+			version = version + 1
+		WHERE id = $6 AND version = $7`,
+		documents, appeal.Status, determinations, appeal.ResolvedAt, appeal.UpdatedAt,
+		appeal.ID, appeal.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		if _, err := r.GetAppeal(ctx, appeal.ID); err != nil {
+			return err
+		}
+		return domain.ErrVersionConflict
+	}
+
+	appeal.Version++
+	return nil
+}
+
+// ListAppeals lists appeals matching the filter, oldest decision deadline first
+func (r *PostgresRepository) ListAppeals(ctx context.Context, filter port.AppealFilter) ([]*domain.Appeal, error) {
+	b := query.NewBuilder("SELECT " + appealColumns + " FROM appeals")
+	if filter.EntityID != "" {
+		b.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.FreezeOrderID != "" {
+		b.Where("freeze_order_id = ?", filter.FreezeOrderID)
+	}
+	if filter.Jurisdictions != nil {
+		b.WhereIn("jurisdiction", query.Values(filter.Jurisdictions))
+	}
+	if len(filter.Status) > 0 {
+		b.WhereIn("status", query.Values(filter.Status))
+	}
+	if filter.DueBefore != nil {
+		b.Where("decision_due_at < ?", *filter.DueBefore)
+		b.WhereIn("status", query.Values([]domain.AppealStatus{
+			domain.AppealStatusPendingReview, domain.AppealStatusInformationRequested,
+		}))
+	}
+	sqlQuery, args, err := b.OrderBy("decision_due_at ASC", "filed_at ASC").Limit(filter.Limit).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.reader(ctx).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appeals []*domain.Appeal
+	for rows.Next() {
+		appeal, err := scanAppeal(rows)
+		if err != nil {
+			return nil, err
+		}
+		appeals = append(appeals, appeal)
+	}
+	return appeals, rows.Err()
+}
+
+// encodeAppeal encodes an appeal's documents and determinations for storage
+func encodeAppeal(appeal *domain.Appeal) (documents, determinations []byte, err error) {
+	if documents, err = json.Marshal(appeal.Documents); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode documents: %w", err)
+	}
+	if appeal.Determinations == nil {
+		return documents, []byte("[]"), nil
+	}
+	if determinations, err = json.Marshal(appeal.Determinations); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode determinations: %w", err)
+	}
+	return documents, determinations, nil
+}
+
+func scanAppeal(row rowScanner) (*domain.Appeal, error) {
+	appeal := &domain.Appeal{}
+	var documents, determinations []byte
+	err := row.Scan(
+		&appeal.ID, &appeal.EntityID, &appeal.Jurisdiction, &appeal.FreezeOrderID, &appeal.WalletID,
+		&appeal.WalletAddress, &appeal.Grounds, &documents, &appeal.Status, &appeal.DecisionDueAt,
+		&determinations, &appeal.FiledBy, &appeal.FiledAt, &appeal.ResolvedAt, &appeal.UpdatedAt,
+		&appeal.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(documents, &appeal.Documents); err != nil {
+		return nil, fmt.Errorf("failed to decode documents: %w", err)
+	}
+	if err := json.Unmarshal(determinations, &appeal.Determinations); err != nil {
+		return nil, fmt.Errorf("failed to decode determinations: %w", err)
+	}
+	return appeal, nil
+}
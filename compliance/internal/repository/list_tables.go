@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+)
+
+// List endpoint whitelists. Only NOT NULL columns are sortable so keyset
+// pagination sees every row.
+
+var licenseTable = query.Table{
+	Name: "licenses",
+	Key:  "id",
+	Columns: map[string]query.Column{
+		"license_number":   {Name: "license_number", Sortable: true, Filterable: true},
+		"entity_id":        {Name: "entity_id", Filterable: true},
+		"entity_name":      {Name: "entity_name", Sortable: true, Filterable: true},
+		"type":             {Name: "type", Sortable: true, Filterable: true},
+		"status":           {Name: "status", Sortable: true, Filterable: true},
+		"jurisdiction":     {Name: "jurisdiction", Sortable: true, Filterable: true},
+		"issued_at":        {Name: "issued_at", Sortable: true, Filterable: true},
+		"effective_date":   {Name: "effective_date", Sortable: true, Filterable: true},
+		"expires_at":       {Name: "expires_at", Sortable: true, Filterable: true},
+		"renewal_due_date": {Name: "renewal_due_date", Filterable: true},
+		"created_at":       {Name: "created_at", Sortable: true, Filterable: true},
+		"updated_at":       {Name: "updated_at", Sortable: true, Filterable: true},
+	},
+}
+
+var violationTable = query.Table{
+	Name: "violations",
+	Key:  "id",
+	Columns: map[string]query.Column{
+		"entity_id":             {Name: "entity_id", Filterable: true},
+		"license_id":            {Name: "license_id", Filterable: true},
+		"violation_number":      {Name: "violation_number", Sortable: true, Filterable: true},
+		"type":                  {Name: "type", Sortable: true, Filterable: true},
+		"severity":              {Name: "severity", Sortable: true, Filterable: true},
+		"status":                {Name: "status", Sortable: true, Filterable: true},
+		"incident_date":         {Name: "incident_date", Sortable: true, Filterable: true},
+		"detection_date":        {Name: "detection_date", Sortable: true, Filterable: true},
+		"resolved_date":         {Name: "resolved_date", Filterable: true},
+		"assigned_investigator": {Name: "assigned_investigator", Filterable: true},
+		"created_at":            {Name: "created_at", Sortable: true, Filterable: true},
+		"updated_at":            {Name: "updated_at", Sortable: true, Filterable: true},
+	},
+}
+
+// listParams copies a filter's query parameters, defaulting them for callers
+// that have none, so the typed filter fields can be added without changing
+// the caller's params
+func listParams(params *query.Params) *query.Params {
+	if params == nil {
+		return &query.Params{
+			Page:     1,
+			PageSize: 100,
+			Sorts:    query.DefaultOptions().DefaultSort,
+		}
+	}
+
+	copied := *params
+	copied.Filters = append([]query.Filter{}, params.Filters...)
+	return &copied
+}
+
+func licenseParams(filter port.LicenseFilter) *query.Params {
+	params := listParams(filter.Query)
+
+	if filter.EntityID != "" {
+		params.Where("entity_id", query.OpEq, filter.EntityID)
+	}
+	if len(filter.Status) > 0 {
+		statuses := make([]string, len(filter.Status))
+		for i, s := range filter.Status {
+			statuses[i] = string(s)
+		}
+		params.Where("status", query.OpIn, statuses...)
+	}
+	if len(filter.Type) > 0 {
+		types := make([]string, len(filter.Type))
+		for i, t := range filter.Type {
+			types[i] = string(t)
+		}
+		params.Where("type", query.OpIn, types...)
+	}
+	if filter.Jurisdiction != "" {
+		params.Where("jurisdiction", query.OpEq, filter.Jurisdiction)
+	}
+	if filter.Jurisdictions != nil {
+		params.Where("jurisdiction", query.OpIn, filter.Jurisdictions...)
+	}
+	if filter.ExpiresBefore != nil {
+		params.Where("expires_at", query.OpLt, filter.ExpiresBefore.Format(time.RFC3339))
+	}
+	if filter.ExpiresAfter != nil {
+		params.Where("expires_at", query.OpGt, filter.ExpiresAfter.Format(time.RFC3339))
+	}
+
+	return params
+}
+
+func violationParams(filter port.ViolationFilter) *query.Params {
+	params := listParams(filter.Query)
+
+	if filter.EntityID != "" {
+		params.Where("entity_id", query.OpEq, filter.EntityID)
+	}
+	if len(filter.Status) > 0 {
+		statuses := make([]string, len(filter.Status))
+		for i, s := range filter.Status {
+			statuses[i] = string(s)
+		}
+		params.Where("status", query.OpIn, statuses...)
+	}
+	if len(filter.Type) > 0 {
+		types := make([]string, len(filter.Type))
+		for i, t := range filter.Type {
+			types[i] = string(t)
+		}
+		params.Where("type", query.OpIn, types...)
+	}
+	if len(filter.Severity) > 0 {
+		severities := make([]string, len(filter.Severity))
+		for i, s := range filter.Severity {
+			severities[i] = string(s)
+		}
+		params.Where("severity", query.OpIn, severities...)
+	}
+	if filter.DetectedAfter != nil {
+		params.Where("detection_date", query.OpGt, filter.DetectedAfter.Format(time.RFC3339))
+	}
+	if filter.DetectedBefore != nil {
+		params.Where("detection_date", query.OpLt, filter.DetectedBefore.Format(time.RFC3339))
+	}
+
+	return params
+}
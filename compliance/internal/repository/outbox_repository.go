@@ -0,0 +1,179 @@
+// Compliance Management Module - Outbox Repository
+// Transactional outbox storage and transaction management
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/shared/database"
+)
+
+// txKey is the context key holding the active transaction
+type txKey struct{}
+
+// dbConn is the subset of *sql.DB and *sql.Tx used by the repository
+type dbConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn returns the transaction carried by ctx, or the database handle
+func (r *PostgresRepository) conn(ctx context.Context) dbConn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return r.db
+}
+
+// writer returns the connection for a write. Outside a transaction the rest of
+// ctx's request then reads from the primary, so it sees its own writes.
+func (r *PostgresRepository) writer(ctx context.Context) dbConn {
+	database.MarkWritten(ctx)
+	return r.conn(ctx)
+}
+
+// reader returns the connection for a read-only listing: the transaction
+// carried by ctx, or a read replica within the lag limit unless ctx has written
+func (r *PostgresRepository) reader(ctx context.Context) dbConn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return r.dbs.Reader(ctx)
+}
+
+// WithinTx runs fn in a transaction, committing if fn succeeds and rolling back
+// otherwise. Nested calls join the outer transaction.
+func (r *PostgresRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.dbs.Writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Outbox Repository Implementation
+
+// Enqueue stores events in the outbox. Events whose dedup key is already present
+// are ignored so that retried operations do not enqueue an event twice.
+func (r *PostgresRepository) Enqueue(ctx context.Context, events ...*domain.OutboxEvent) error {
+	query := `
+		INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, topic,
+			jurisdiction, message_key, dedup_key, payload, status, attempts, next_attempt_at,
+			created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (dedup_key) DO NOTHING
+	`
+
+	conn := r.writer(ctx)
+	for _, event := range events {
+		_, err := conn.ExecContext(ctx, query,
+			event.ID, event.AggregateType, event.AggregateID, event.EventType, event.Topic,
+			event.Jurisdiction, event.MessageKey, event.DedupKey, []byte(event.Payload), event.Status,
+			event.Attempts, event.NextAttemptAt, event.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue outbox event %s: %w", event.EventType, err)
+		}
+	}
+	return nil
+}
+
+// ClaimPending locks up to limit due events for the caller by pushing their next
+// attempt past the lease, so concurrent relays never claim the same event.
+// Events are returned oldest first.
+func (r *PostgresRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEvent, error) {
+	query := `
+		UPDATE outbox_events
+		SET attempts = attempts + 1, next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = $3 AND next_attempt_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, aggregate_type, aggregate_id, event_type, topic, jurisdiction, message_key,
+			dedup_key, payload, status, attempts, COALESCE(last_error, ''),
+			next_attempt_at, created_at, published_at
+	`
+
+	rows, err := r.writer(ctx).QueryContext(ctx, query, limit, time.Now().Add(lease), domain.OutboxStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.OutboxEvent
+	for rows.Next() {
+		event := &domain.OutboxEvent{}
+		var payload []byte
+		if err := rows.Scan(
+			&event.ID, &event.AggregateType, &event.AggregateID, &event.EventType,
+			&event.Topic, &event.Jurisdiction, &event.MessageKey, &event.DedupKey, &payload, &event.Status,
+			&event.Attempts, &event.LastError, &event.NextAttemptAt, &event.CreatedAt,
+			&event.PublishedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return events, nil
+}
+
+// MarkPublished records that an event has been delivered to the message bus
+func (r *PostgresRepository) MarkPublished(ctx context.Context, id string) error {
+	query := `
+		UPDATE outbox_events SET status = $1, published_at = $2, last_error = NULL
+		WHERE id = $3
+	`
+	_, err := r.writer(ctx).ExecContext(ctx, query, domain.OutboxStatusPublished, time.Now(), id)
+	return err
+}
+
+// MarkFailed records a failed delivery attempt and when to retry it
+func (r *PostgresRepository) MarkFailed(ctx context.Context, id, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE outbox_events SET last_error = $1, next_attempt_at = $2
+		WHERE id = $3 AND status = $4
+	`
+	_, err := r.writer(ctx).ExecContext(ctx, query, lastError, nextAttemptAt, id, domain.OutboxStatusPending)
+	return err
+}
+
+// DeletePublishedBefore removes delivered events older than the retention cutoff
+func (r *PostgresRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := "DELETE FROM outbox_events WHERE status = $1 AND published_at < $2"
+	result, err := r.writer(ctx).ExecContext(ctx, query, domain.OutboxStatusPublished, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
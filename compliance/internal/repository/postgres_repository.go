@@ -6,22 +6,37 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"time"
 
 	"github.com/csic-platform/compliance/internal/domain"
 	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/database"
+	"github.com/csic-platform/shared/query"
 	"github.com/google/uuid"
 )
 
-// PostgresRepository implements compliance repositories using PostgreSQL
+// PostgresRepository implements compliance repositories using PostgreSQL.
+// Entity, license and violation listings may be served by read replicas;
+// everything else uses the primary.
 type PostgresRepository struct {
-	db *sql.DB
+	db  *sql.DB
+	dbs *database.ReplicaRouter
 }
 
-// NewPostgresRepository creates a new PostgreSQL repository
-func NewPostgresRepository(db *sql.DB) *PostgresRepository {
-	return &PostgresRepository{db: db}
+// NewPostgresRepository creates a new PostgreSQL repository over the primary
+// and read replicas of dbs
+func NewPostgresRepository(dbs *database.ReplicaRouter) *PostgresRepository {
+	return &PostgresRepository{db: dbs.Primary(), dbs: dbs}
+}
+
+// countRows returns the number of rows a list statement's filters match
+func (r *PostgresRepository) countRows(ctx context.Context, stmt *query.Statement) (int64, error) {
+	q, args := stmt.Count()
+	var total int64
+	if err := r.reader(ctx).QueryRowContext(ctx, q, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
 }
 
 // Entity Repository Implementation
@@ -40,7 +55,7 @@ func (r *PostgresRepository) Create(ctx context.Context, entity *domain.Regulate
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		entity.ID, entity.RegistrationNumber, entity.Name, entity.Type, entity.Status,
 		entity.Jurisdiction, entity.RegistrationDate, entity.Address, entity.ContactInfo,
 		entity.RiskRating, entity.ComplianceScore, entity.CreatedAt, entity.UpdatedAt,
@@ -87,7 +102,7 @@ func (r *PostgresRepository) Update(ctx context.Context, entity *domain.Regulate
 			updated_at = $5, metadata = $6
 		WHERE id = $7
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		entity.Name, entity.Status, entity.RiskRating, entity.ComplianceScore,
 		entity.UpdatedAt, entity.Metadata, entity.ID,
 	)
@@ -96,39 +111,24 @@ func (r *PostgresRepository) Update(ctx context.Context, entity *domain.Regulate
 
 func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
 	query := "DELETE FROM entities WHERE id = $1"
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.writer(ctx).ExecContext(ctx, query, id)
 	return err
 }
 
 func (r *PostgresRepository) List(ctx context.Context, filter port.EntityFilter) ([]*domain.RegulatedEntity, error) {
-	query := "SELECT * FROM entities WHERE 1=1"
-	args := []interface{}{}
-	argNum := 1
-
+	b := query.NewBuilder("SELECT * FROM entities")
 	if len(filter.Status) > 0 {
-		for _, s := range filter.Status {
-			if argNum == 1 {
-				query += " AND status = $" + fmt.Sprintf("%d", argNum)
-			} else {
-				query += " OR status = $" + fmt.Sprintf("%d", argNum)
-			}
-			args = append(args, s)
-			argNum++
-		}
+		b.WhereIn("status", query.Values(filter.Status))
 	}
-
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argNum)
-		args = append(args, filter.Limit)
-		argNum++
+	if filter.Jurisdictions != nil {
+		b.WhereIn("jurisdiction", query.Values(filter.Jurisdictions))
 	}
-
-	if filter.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argNum)
-		args = append(args, filter.Offset)
+	sqlQuery, args, err := b.Limit(filter.Limit).Offset(filter.Offset).Build()
+	if err != nil {
+		return nil, err
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader(ctx).QueryContext(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,22 +158,23 @@ func (r *PostgresRepository) CreateLicense(ctx context.Context, license *domain.
 	}
 	license.CreatedAt = time.Now()
 	license.UpdatedAt = time.Now()
+	license.Version = 1
 
 	query := `
 		INSERT INTO licenses (id, license_number, entity_id, entity_name, type, status,
 			jurisdiction, issued_at, effective_date, expires_at, renewal_due_date,
 			approval_date, approval_officer, conditions, scope, fee,
-			previous_license, last_audit_date, created_at, updated_at, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+			previous_license, last_audit_date, created_at, updated_at, metadata, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		license.ID, license.LicenseNumber, license.EntityID, license.EntityName,
 		license.Type, license.Status, license.Jurisdiction, license.IssuedAt,
 		license.EffectiveDate, license.ExpiresAt, license.RenewalDueDate,
 		license.ApprovalDate, license.ApprovalOfficer, license.Conditions,
 		license.Scope, license.Fee, license.PreviousLicense, license.LastAuditDate,
-		license.CreatedAt, license.UpdatedAt, license.Metadata,
+		license.CreatedAt, license.UpdatedAt, license.Metadata, license.Version,
 	)
 	return err
 }
@@ -187,7 +188,7 @@ func (r *PostgresRepository) GetByIDLicense(ctx context.Context, id string) (*do
 		&license.EffectiveDate, &license.ExpiresAt, &license.RenewalDueDate,
 		&license.ApprovalDate, &license.ApprovalOfficer, &license.Conditions,
 		&license.Scope, &license.Fee, &license.PreviousLicense, &license.LastAuditDate,
-		&license.CreatedAt, &license.UpdatedAt, &license.Metadata,
+		&license.CreatedAt, &license.UpdatedAt, &license.Metadata, &license.Version,
 	)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrLicenseNotFound
@@ -195,46 +196,99 @@ func (r *PostgresRepository) GetByIDLicense(ctx context.Context, id string) (*do
 	return license, err
 }
 
+// UpdateLicense saves a license if it is still at the version it was read at,
+// bumping the version. A license changed in the meantime is left untouched and
+// ErrVersionConflict is returned.
 func (r *PostgresRepository) UpdateLicense(ctx context.Context, license *domain.License) error {
 	license.UpdatedAt = time.Now()
 	query := `
-		UPDATE licenses SET status = $1, updated_at = $2, conditions = $3 WHERE id = $4
+		UPDATE licenses SET status = $1, issued_at = $2, effective_date = $3, expires_at = $4,
+			renewal_due_date = $5, approval_date = $6, approval_officer = $7, conditions = $8,
+			scope = $9, fee = $10, last_audit_date = $11, metadata = $12, updated_at = $13,
+			version = version + 1
+		WHERE id = $14 AND version = $15
 	`
-	_, err := r.db.ExecContext(ctx, query,
-		license.Status, license.UpdatedAt, license.Conditions, license.ID,
+	result, err := r.writer(ctx).ExecContext(ctx, query,
+		license.Status, license.IssuedAt, license.EffectiveDate, license.ExpiresAt,
+		license.RenewalDueDate, license.ApprovalDate, license.ApprovalOfficer, license.Conditions,
+		license.Scope, license.Fee, license.LastAuditDate, license.Metadata, license.UpdatedAt,
+		license.ID, license.Version,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		if _, err := r.GetByIDLicense(ctx, license.ID); err != nil {
+			return err
+		}
+		return domain.ErrVersionConflict
+	}
+
+	license.Version++
+	return nil
 }
 
-func (r *PostgresRepository) ListLicense(ctx context.Context, filter port.LicenseFilter) ([]*domain.License, error) {
-	query := "SELECT * FROM licenses WHERE 1=1"
-	args := []interface{}{}
-	argNum := 1
+func (r *PostgresRepository) ListLicense(ctx context.Context, filter port.LicenseFilter) ([]*domain.License, *query.PageInfo, error) {
+	params := licenseParams(filter)
+	stmt, err := licenseTable.Build(params)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if filter.EntityID != "" {
-		query += fmt.Sprintf(" AND entity_id = $%d", argNum)
-		args = append(args, filter.EntityID)
-		argNum++
+	total, err := r.countRows(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if len(filter.Status) > 0 {
-		for _, s := range filter.Status {
-			if argNum == 1 {
-				query += " AND status = $" + fmt.Sprintf("%d", argNum)
-			} else {
-				query += " OR status = $" + fmt.Sprintf("%d", argNum)
-			}
-			args = append(args, s)
-			argNum++
+	q, args := stmt.Select(`id, license_number, entity_id, entity_name, type, status, jurisdiction,
+		issued_at, effective_date, expires_at, renewal_due_date, approval_date, approval_officer,
+		conditions, scope, fee, previous_license, last_audit_date, created_at, updated_at, metadata,
+		version`)
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var licenses []*domain.License
+	for rows.Next() {
+		license := &domain.License{}
+		if err := rows.Scan(
+			&license.ID, &license.LicenseNumber, &license.EntityID, &license.EntityName,
+			&license.Type, &license.Status, &license.Jurisdiction, &license.IssuedAt,
+			&license.EffectiveDate, &license.ExpiresAt, &license.RenewalDueDate,
+			&license.ApprovalDate, &license.ApprovalOfficer, &license.Conditions,
+			&license.Scope, &license.Fee, &license.PreviousLicense, &license.LastAuditDate,
+			&license.CreatedAt, &license.UpdatedAt, &license.Metadata, &license.Version,
+		); err != nil {
+			return nil, nil, err
 		}
+		licenses = append(licenses, license)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
 	}
 
-	if filter.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argNum)
-		args = append(args, filter.Limit)
+	info := &query.PageInfo{Total: total}
+	if stmt.HasMore(len(licenses)) {
+		licenses = licenses[:params.PageSize]
+		info.NextCursor = params.NextCursor(licenses[len(licenses)-1].ID)
 	}
+	return licenses, info, nil
+}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+func (r *PostgresRepository) GetExpiring(ctx context.Context, withinDays int) ([]*domain.License, error) {
+	query := `
+		SELECT * FROM licenses
+		WHERE status = $1 AND expires_at <= NOW() + make_interval(days => $2)
+		ORDER BY expires_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, domain.LicenseStatusActive, withinDays)
 	if err != nil {
 		return nil, err
 	}
@@ -249,7 +303,7 @@ func (r *PostgresRepository) ListLicense(ctx context.Context, filter port.Licens
 			&license.EffectiveDate, &license.ExpiresAt, &license.RenewalDueDate,
 			&license.ApprovalDate, &license.ApprovalOfficer, &license.Conditions,
 			&license.Scope, &license.Fee, &license.PreviousLicense, &license.LastAuditDate,
-			&license.CreatedAt, &license.UpdatedAt, &license.Metadata,
+			&license.CreatedAt, &license.UpdatedAt, &license.Metadata, &license.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -258,6 +312,25 @@ func (r *PostgresRepository) ListLicense(ctx context.Context, filter port.Licens
 	return licenses, rows.Err()
 }
 
+// Expiry Notice Repository Implementation
+
+func (r *PostgresRepository) HasExpiryNotice(ctx context.Context, licenseID string, thresholdDays int) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM license_expiry_notices WHERE license_id = $1 AND threshold_days = $2)"
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, licenseID, thresholdDays).Scan(&exists)
+	return exists, err
+}
+
+func (r *PostgresRepository) RecordExpiryNotice(ctx context.Context, licenseID string, thresholdDays int, sentAt time.Time) error {
+	query := `
+		INSERT INTO license_expiry_notices (license_id, threshold_days, sent_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (license_id, threshold_days) DO NOTHING
+	`
+	_, err := r.writer(ctx).ExecContext(ctx, query, licenseID, thresholdDays, sentAt)
+	return err
+}
+
 // Placeholder implementations for other repositories ( Obligation, Violation, Penalty )
 // These would follow the same pattern as above
 
@@ -313,8 +386,51 @@ func (r *PostgresRepository) UpdateViolation(ctx context.Context, violation *dom
 	return nil
 }
 
-func (r *PostgresRepository) ListViolation(ctx context.Context, filter port.ViolationFilter) ([]*domain.ComplianceViolation, error) {
-	return nil, nil
+func (r *PostgresRepository) ListViolation(ctx context.Context, filter port.ViolationFilter) ([]*domain.ComplianceViolation, *query.PageInfo, error) {
+	params := violationParams(filter)
+	stmt, err := violationTable.Build(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, err := r.countRows(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q, args := stmt.Select(`id, entity_id, entity_name, license_id, violation_number, type, severity,
+		status, title, description, incident_date, detection_date, reported_date, resolved_date,
+		closed_date, detection_source, regulatory_ref, assigned_investigator, reviewer_id,
+		created_at, updated_at`)
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var violations []*domain.ComplianceViolation
+	for rows.Next() {
+		v := &domain.ComplianceViolation{}
+		if err := rows.Scan(
+			&v.ID, &v.EntityID, &v.EntityName, &v.LicenseID, &v.ViolationNumber, &v.Type,
+			&v.Severity, &v.Status, &v.Title, &v.Description, &v.IncidentDate, &v.DetectionDate,
+			&v.ReportedDate, &v.ResolvedDate, &v.ClosedDate, &v.DetectionSource, &v.RegulatoryRef,
+			&v.AssignedInvestigator, &v.ReviewerID, &v.CreatedAt, &v.UpdatedAt,
+		); err != nil {
+			return nil, nil, err
+		}
+		violations = append(violations, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	info := &query.PageInfo{Total: total}
+	if stmt.HasMore(len(violations)) {
+		violations = violations[:params.PageSize]
+		info.NextCursor = params.NextCursor(violations[len(violations)-1].ID)
+	}
+	return violations, info, nil
 }
 
 func (r *PostgresRepository) GetOpenByEntityID(ctx context.Context, entityID string) ([]*domain.ComplianceViolation, error) {
@@ -0,0 +1,149 @@
+// Compliance Management Module - Sharing Agreement Repository
+// PostgreSQL storage for cross-jurisdiction sharing agreements
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+	"github.com/google/uuid"
+)
+
+// sharingAgreementColumns lists sharing_agreements columns in scan order
+const sharingAgreementColumns = `id, case_reference, entity_id, owner_jurisdiction,
+	grantee_jurisdiction, purpose, status, valid_from, valid_until, created_by, created_at,
+	COALESCE(revoked_by, ''), revoked_at, COALESCE(revocation_reason, '')`
+
+// CreateSharingAgreement stores a new sharing agreement
+func (r *PostgresRepository) CreateSharingAgreement(ctx context.Context, agreement *domain.SharingAgreement) error {
+	if agreement.ID == "" {
+		agreement.ID = uuid.New().String()
+	}
+
+	query := `
+		INSERT INTO sharing_agreements (id, case_reference, entity_id, owner_jurisdiction,
+			grantee_jurisdiction, purpose, status, valid_from, valid_until, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		agreement.ID, agreement.CaseReference, agreement.EntityID, agreement.OwnerJurisdiction,
+		agreement.GranteeJurisdiction, agreement.Purpose, agreement.Status, agreement.ValidFrom,
+		agreement.ValidUntil, agreement.CreatedBy, agreement.CreatedAt,
+	)
+	return err
+}
+
+// GetSharingAgreement retrieves a sharing agreement by ID
+func (r *PostgresRepository) GetSharingAgreement(ctx context.Context, id string) (*domain.SharingAgreement, error) {
+	query := "SELECT " + sharingAgreementColumns + " FROM sharing_agreements WHERE id = $1"
+	agreement, err := scanSharingAgreement(r.conn(ctx).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrSharingAgreementNotFound
+	}
+	return agreement, err
+}
+
+// UpdateSharingAgreement stores an agreement's status and revocation
+func (r *PostgresRepository) UpdateSharingAgreement(ctx context.Context, agreement *domain.SharingAgreement) error {
+	result, err := r.writer(ctx).ExecContext(ctx, `
+		UPDATE sharing_agreements
+		SET status = $1, revoked_by = $2, revoked_at = $3, revocation_reason = $4
+		WHERE id = $5`,
+		agreement.Status, agreement.RevokedBy, agreement.RevokedAt, agreement.RevocationReason,
+		agreement.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrSharingAgreementNotFound
+	}
+	return nil
+}
+
+// ListSharingAgreements lists sharing agreements matching the filter, newest first
+func (r *PostgresRepository) ListSharingAgreements(ctx context.Context, filter port.SharingAgreementFilter) ([]*domain.SharingAgreement, error) {
+	b := query.NewBuilder("SELECT " + sharingAgreementColumns + " FROM sharing_agreements")
+	if filter.EntityID != "" {
+		b.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Jurisdictions != nil {
+		if len(filter.Jurisdictions) == 0 {
+			return nil, nil
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.Jurisdictions)), ", ")
+		jurisdictions := query.Values(filter.Jurisdictions)
+		b.Where("(owner_jurisdiction IN ("+placeholders+") OR grantee_jurisdiction IN ("+placeholders+"))",
+			append(jurisdictions, jurisdictions...)...)
+	}
+	if len(filter.Status) > 0 {
+		b.WhereIn("status", query.Values(filter.Status))
+	}
+	sqlQuery, args, err := b.OrderBy("created_at DESC").Limit(filter.Limit).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.reader(ctx).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var agreements []*domain.SharingAgreement
+	for rows.Next() {
+		agreement, err := scanSharingAgreement(rows)
+		if err != nil {
+			return nil, err
+		}
+		agreements = append(agreements, agreement)
+	}
+	return agreements, rows.Err()
+}
+
+// HasActiveSharingAgreement reports whether an agreement active at the given
+// time shares the entity's records with any of the jurisdictions
+func (r *PostgresRepository) HasActiveSharingAgreement(ctx context.Context, entityID string, jurisdictions []string, at time.Time) (bool, error) {
+	if len(jurisdictions) == 0 {
+		return false, nil
+	}
+
+	sqlQuery, args, err := query.NewBuilder("SELECT 1 FROM sharing_agreements").
+		Where("entity_id = ?", entityID).
+		Where("status = ?", domain.SharingAgreementStatusActive).
+		Where("valid_from <= ? AND valid_until > ?", at, at).
+		WhereIn("grantee_jurisdiction", query.Values(jurisdictions)).
+		Limit(1).
+		Build()
+	if err != nil {
+		return false, err
+	}
+
+	var one int
+	err = r.conn(ctx).QueryRowContext(ctx, sqlQuery, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func scanSharingAgreement(row rowScanner) (*domain.SharingAgreement, error) {
+	agreement := &domain.SharingAgreement{}
+	err := row.Scan(
+		&agreement.ID, &agreement.CaseReference, &agreement.EntityID, &agreement.OwnerJurisdiction,
+		&agreement.GranteeJurisdiction, &agreement.Purpose, &agreement.Status, &agreement.ValidFrom,
+		&agreement.ValidUntil, &agreement.CreatedBy, &agreement.CreatedAt, &agreement.RevokedBy,
+		&agreement.RevokedAt, &agreement.RevocationReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return agreement, nil
+}
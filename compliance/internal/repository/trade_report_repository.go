@@ -0,0 +1,156 @@
+// Compliance Management Module - Trade Report Repository
+// PostgreSQL storage for OTC desk and P2P platform trade reports
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+	"github.com/google/uuid"
+)
+
+// tradeReportColumns lists trade_reports columns in scan order
+const tradeReportColumns = `id, entity_id, entity_type, jurisdiction, trade_date, asset, chain,
+	buy_volume, sell_volume, trade_count, notional_value, notional_currency, counterparties,
+	status, reconciliation, submitted_by, created_at, updated_at`
+
+// CreateTradeReport stores a new trade report
+func (r *PostgresRepository) CreateTradeReport(ctx context.Context, report *domain.TradeReport) error {
+	if report.ID == "" {
+		report.ID = uuid.New().String()
+	}
+
+	counterparties, err := json.Marshal(report.Counterparties)
+	if err != nil {
+		return fmt.Errorf("failed to encode counterparties: %w", err)
+	}
+
+	query := `
+		INSERT INTO trade_reports (id, entity_id, entity_type, jurisdiction, trade_date, asset,
+			chain, buy_volume, sell_volume, trade_count, notional_value, notional_currency,
+			counterparties, status, submitted_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (entity_id, trade_date, asset, chain) DO NOTHING
+		RETURNING id
+	`
+
+	err = r.writer(ctx).QueryRowContext(ctx, query,
+		report.ID, report.EntityID, report.EntityType, report.Jurisdiction, report.TradeDate,
+		report.Asset, report.Chain, report.BuyVolume, report.SellVolume, report.TradeCount,
+		report.NotionalValue, report.NotionalCurrency, counterparties, report.Status,
+		report.SubmittedBy, report.CreatedAt, report.UpdatedAt,
+	).Scan(&report.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrDuplicateTradeReport
+	}
+	return err
+}
+
+// GetTradeReport retrieves a trade report by ID
+func (r *PostgresRepository) GetTradeReport(ctx context.Context, id string) (*domain.TradeReport, error) {
+	query := "SELECT " + tradeReportColumns + " FROM trade_reports WHERE id = $1"
+	report, err := scanTradeReport(r.conn(ctx).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTradeReportNotFound
+	}
+	return report, err
+}
+
+// UpdateTradeReportReconciliation stores a report's reconciliation result and status
+func (r *PostgresRepository) UpdateTradeReportReconciliation(ctx context.Context, report *domain.TradeReport) error {
+	reconciliation, err := json.Marshal(report.Reconciliation)
+	if err != nil {
+		return fmt.Errorf("failed to encode reconciliation: %w", err)
+	}
+
+	result, err := r.writer(ctx).ExecContext(ctx,
+		"UPDATE trade_reports SET status = $1, reconciliation = $2, updated_at = $3 WHERE id = $4",
+		report.Status, reconciliation, report.UpdatedAt, report.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrTradeReportNotFound
+	}
+	return nil
+}
+
+// ListTradeReports lists trade reports matching the filter, newest trade date first
+func (r *PostgresRepository) ListTradeReports(ctx context.Context, filter port.TradeReportFilter) ([]*domain.TradeReport, error) {
+	b := query.NewBuilder("SELECT " + tradeReportColumns + " FROM trade_reports")
+	if filter.EntityID != "" {
+		b.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Jurisdictions != nil {
+		b.WhereIn("jurisdiction", query.Values(filter.Jurisdictions))
+	}
+	if len(filter.Status) > 0 {
+		b.WhereIn("status", query.Values(filter.Status))
+	}
+	if filter.From != nil {
+		b.Where("trade_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		b.Where("trade_date <= ?", *filter.To)
+	}
+	sqlQuery, args, err := b.OrderBy("trade_date DESC", "created_at DESC").Limit(filter.Limit).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.reader(ctx).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*domain.TradeReport
+	for rows.Next() {
+		report, err := scanTradeReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTradeReport(row rowScanner) (*domain.TradeReport, error) {
+	report := &domain.TradeReport{}
+	var counterparties, reconciliation []byte
+	err := row.Scan(
+		&report.ID, &report.EntityID, &report.EntityType, &report.Jurisdiction, &report.TradeDate,
+		&report.Asset, &report.Chain, &report.BuyVolume, &report.SellVolume, &report.TradeCount,
+		&report.NotionalValue, &report.NotionalCurrency, &counterparties, &report.Status,
+		&reconciliation, &report.SubmittedBy, &report.CreatedAt, &report.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(counterparties) > 0 {
+		if err := json.Unmarshal(counterparties, &report.Counterparties); err != nil {
+			return nil, fmt.Errorf("failed to decode counterparties: %w", err)
+		}
+	}
+	if len(reconciliation) > 0 && string(reconciliation) != "null" {
+		report.Reconciliation = &domain.TradeReconciliation{}
+		if err := json.Unmarshal(reconciliation, report.Reconciliation); err != nil {
+			return nil, fmt.Errorf("failed to decode reconciliation: %w", err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,256 @@
+// Compliance Management Module - Appeal Service
+// Due-process review of regulated entities' appeals against freeze orders
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+)
+
+// DefaultAppealReviewPeriod is how long reviewers have to decide an appeal
+// unless configured otherwise
+const DefaultAppealReviewPeriod = 30 * 24 * time.Hour
+
+// AppealService handles appeals against freeze orders. An appeal is filed by
+// the entity owning the frozen wallet or operating the exchange holding it,
+// and marks the order as under appeal. A determination upholding or revoking
+// the appeal closes it and is applied to the freeze order in the same
+// transaction, so a decision is only recorded once wallet governance has
+// applied it.
+type AppealService struct {
+	repo         port.AppealRepository
+	entityRepo   port.EntityRepository
+	freezes      port.FreezeOrderPort
+	audit        port.AuditLogPort
+	tx           port.TxManager
+	guard        *JurisdictionGuard
+	reviewPeriod time.Duration
+}
+
+// NewAppealService creates a new appeal service. A reviewPeriod of zero
+// means DefaultAppealReviewPeriod.
+func NewAppealService(
+	repo port.AppealRepository,
+	entityRepo port.EntityRepository,
+	freezes port.FreezeOrderPort,
+	audit port.AuditLogPort,
+	tx port.TxManager,
+	guard *JurisdictionGuard,
+	reviewPeriod time.Duration,
+) *AppealService {
+	if reviewPeriod <= 0 {
+		reviewPeriod = DefaultAppealReviewPeriod
+	}
+	return &AppealService{
+		repo:         repo,
+		entityRepo:   entityRepo,
+		freezes:      freezes,
+		audit:        audit,
+		tx:           tx,
+		guard:        guard,
+		reviewPeriod: reviewPeriod,
+	}
+}
+
+// FileAppeal files an entity's appeal against a freeze order in force. Orders
+// on wallets the entity neither owns nor holds are reported as not found.
+func (s *AppealService) FileAppeal(ctx context.Context, appeal *domain.Appeal, actorID string) error {
+	entity, err := s.entityRepo.GetByID(ctx, appeal.EntityID)
+	if err != nil {
+		return err
+	}
+	order, err := s.freezes.GetFreezeOrder(ctx, appeal.FreezeOrderID)
+	if err != nil {
+		return err
+	}
+	if order.OwnerEntityID != entity.ID && order.ExchangeID != entity.ID {
+		return domain.ErrFreezeOrderNotFound
+	}
+	if !order.InForce() {
+		return domain.ErrFreezeOrderNotInForce
+	}
+	if order.UnderAppeal {
+		return domain.ErrDuplicateAppeal
+	}
+
+	now := time.Now()
+	appeal.ID = ""
+	appeal.Jurisdiction = entity.Jurisdiction
+	appeal.WalletID = order.WalletID
+	appeal.WalletAddress = order.WalletAddress
+	appeal.Status = domain.AppealStatusPendingReview
+	appeal.DecisionDueAt = now.Add(s.reviewPeriod)
+	appeal.Determinations = nil
+	appeal.FiledBy = actorID
+	appeal.FiledAt = now
+	appeal.ResolvedAt = nil
+	appeal.UpdatedAt = now
+	for i := range appeal.Documents {
+		appeal.Documents[i].UploadedBy = actorID
+		if appeal.Documents[i].UploadedAt.IsZero() {
+			appeal.Documents[i].UploadedAt = now
+		}
+	}
+	if err := appeal.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateAppeal(ctx, appeal); err != nil {
+			return err
+		}
+		return s.freezes.AppealFreezeOrder(ctx, appeal.FreezeOrderID, appeal.ID)
+	}); err != nil {
+		return fmt.Errorf("failed to file appeal: %w", err)
+	}
+
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "APPEAL_FILED",
+		ResourceType: "APPEAL",
+		ResourceID:   appeal.ID,
+		EntityID:     entity.ID,
+		Description: fmt.Sprintf("%s appealed freeze order %s on wallet %s; decision due %s",
+			entity.Name, appeal.FreezeOrderID, appeal.WalletAddress,
+			appeal.DecisionDueAt.Format(time.RFC3339)),
+		Result: "SUCCESS",
+		Metadata: map[string]interface{}{
+			"freeze_order_id": appeal.FreezeOrderID,
+			"documents":       len(appeal.Documents),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return nil
+}
+
+// GetAppeal retrieves an appeal
+func (s *AppealService) GetAppeal(ctx context.Context, id string) (*domain.Appeal, error) {
+	appeal, err := s.repo.GetAppeal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canRead(ctx, appeal.Jurisdiction, appeal.EntityID, domain.ErrAppealNotFound); err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// ListAppeals lists appeals, most urgent decision first
+func (s *AppealService) ListAppeals(ctx context.Context, filter port.AppealFilter) ([]*domain.Appeal, error) {
+	jurisdictions, err := s.guard.listScope(ctx, filter.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	filter.Jurisdictions = jurisdictions
+	return s.repo.ListAppeals(ctx, filter)
+}
+
+// AddAppealDocuments adds supporting documents to an open appeal, returning
+// it to review if the reviewers had requested more information
+func (s *AppealService) AddAppealDocuments(ctx context.Context, id string, documents []domain.Attachment, actorID string) (*domain.Appeal, error) {
+	appeal, err := s.GetAppeal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range documents {
+		documents[i].UploadedBy = actorID
+		if documents[i].UploadedAt.IsZero() {
+			documents[i].UploadedAt = now
+		}
+	}
+	previousStatus := appeal.Status
+	if err := appeal.AddDocuments(documents, now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateAppeal(ctx, appeal); err != nil {
+		return nil, fmt.Errorf("failed to update appeal: %w", err)
+	}
+
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "APPEAL_DOCUMENTS_ADDED",
+		ResourceType: "APPEAL",
+		ResourceID:   appeal.ID,
+		EntityID:     appeal.EntityID,
+		Description:  fmt.Sprintf("Added %d documents to appeal of freeze order %s", len(documents), appeal.FreezeOrderID),
+		Result:       "SUCCESS",
+		Metadata: map[string]interface{}{
+			"previous_status": previousStatus,
+			"new_status":      appeal.Status,
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return appeal, nil
+}
+
+// RecordDetermination records a reviewer's determination on an appeal. Upheld
+// and revoked appeals are applied to the freeze order, and the determination
+// is only kept if wallet governance accepts it.
+func (s *AppealService) RecordDetermination(ctx context.Context, id string, determination *domain.AppealDetermination, actorID string) (*domain.Appeal, error) {
+	appeal, err := s.repo.GetAppeal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canWrite(ctx, appeal.Jurisdiction, appeal.EntityID, domain.ErrAppealNotFound); err != nil {
+		return nil, err
+	}
+
+	overdue := appeal.IsOverdue(time.Now())
+	if err := appeal.RecordDetermination(determination, actorID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateAppeal(ctx, appeal); err != nil {
+			return err
+		}
+		switch determination.Outcome {
+		case domain.AppealOutcomeUphold:
+			return s.freezes.UpholdFreezeOrder(ctx, appeal.FreezeOrderID, appeal.ID)
+		case domain.AppealOutcomeRevoke:
+			return s.freezes.RevokeFreezeOrder(ctx, appeal.FreezeOrderID, appeal.ID, determination.Reasoning)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record determination: %w", err)
+	}
+
+	metadata := map[string]interface{}{
+		"determination_id": determination.ID,
+		"outcome":          determination.Outcome,
+		"freeze_order_id":  appeal.FreezeOrderID,
+		"decided_late":     overdue,
+	}
+	if determination.ResponseDueAt != nil {
+		metadata["response_due_at"] = determination.ResponseDueAt.Format(time.RFC3339)
+	}
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "APPEAL_" + string(determination.Outcome),
+		ResourceType: "APPEAL",
+		ResourceID:   appeal.ID,
+		EntityID:     appeal.EntityID,
+		Description: fmt.Sprintf("Determination %s on appeal of freeze order %s - Reasoning: %s",
+			determination.Outcome, appeal.FreezeOrderID, determination.Reasoning),
+		Result:   "SUCCESS",
+		Metadata: metadata,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return appeal, nil
+}
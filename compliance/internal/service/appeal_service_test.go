@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/google/uuid"
+)
+
+// appealStore backs the appeal service with in-memory appeals, entities and
+// the freeze orders wallet governance would hold
+type appealStore struct {
+	port.EntityRepository
+	entities map[string]*domain.RegulatedEntity
+	appeals  map[string]*domain.Appeal
+	orders   map[string]*port.FreezeOrder
+	steps    []string
+}
+
+func newAppealStore() *appealStore {
+	s := &appealStore{
+		entities: make(map[string]*domain.RegulatedEntity),
+		appeals:  make(map[string]*domain.Appeal),
+		orders:   make(map[string]*port.FreezeOrder),
+	}
+	for _, entityID := range []string{ownEntity, otherEntity} {
+		s.entities[entityID] = &domain.RegulatedEntity{ID: entityID, Jurisdiction: "SG"}
+	}
+	s.orders["order-owned"] = &port.FreezeOrder{ID: "order-owned", WalletID: "wallet-1", Status: "ACTIVE", OwnerEntityID: ownEntity}
+	s.orders["order-held"] = &port.FreezeOrder{ID: "order-held", WalletID: "wallet-2", Status: "PARTIAL", OwnerEntityID: "customer", ExchangeID: ownEntity}
+	s.orders["order-other"] = &port.FreezeOrder{ID: "order-other", WalletID: "wallet-3", Status: "ACTIVE", OwnerEntityID: otherEntity}
+	return s
+}
+
+func (s *appealStore) GetByID(ctx context.Context, id string) (*domain.RegulatedEntity, error) {
+	if entity, ok := s.entities[id]; ok {
+		return entity, nil
+	}
+	return nil, domain.ErrEntityNotFound
+}
+
+func (s *appealStore) CreateAppeal(ctx context.Context, appeal *domain.Appeal) error {
+	appeal.ID = uuid.New().String()
+	appeal.Version = 1
+	s.appeals[appeal.ID] = appeal
+	return nil
+}
+
+func (s *appealStore) GetAppeal(ctx context.Context, id string) (*domain.Appeal, error) {
+	if appeal, ok := s.appeals[id]; ok {
+		copied := *appeal
+		return &copied, nil
+	}
+	return nil, domain.ErrAppealNotFound
+}
+
+func (s *appealStore) UpdateAppeal(ctx context.Context, appeal *domain.Appeal) error {
+	appeal.Version++
+	copied := *appeal
+	s.appeals[appeal.ID] = &copied
+	return nil
+}
+
+func (s *appealStore) ListAppeals(ctx context.Context, filter port.AppealFilter) ([]*domain.Appeal, error) {
+	var appeals []*domain.Appeal
+	for _, appeal := range s.appeals {
+		if filter.EntityID == "" || appeal.EntityID == filter.EntityID {
+			appeals = append(appeals, appeal)
+		}
+	}
+	return appeals, nil
+}
+
+func (s *appealStore) GetFreezeOrder(ctx context.Context, orderID string) (*port.FreezeOrder, error) {
+	if order, ok := s.orders[orderID]; ok {
+		return order, nil
+	}
+	return nil, domain.ErrFreezeOrderNotFound
+}
+
+func (s *appealStore) AppealFreezeOrder(ctx context.Context, orderID, appealID string) error {
+	s.orders[orderID].UnderAppeal = true
+	s.steps = append(s.steps, "appeal "+orderID)
+	return nil
+}
+
+func (s *appealStore) UpholdFreezeOrder(ctx context.Context, orderID, appealID string) error {
+	s.orders[orderID].UnderAppeal = false
+	s.steps = append(s.steps, "uphold "+orderID)
+	return nil
+}
+
+func (s *appealStore) RevokeFreezeOrder(ctx context.Context, orderID, appealID, reason string) error {
+	s.orders[orderID].Status = "RELEASED"
+	s.orders[orderID].UnderAppeal = false
+	s.steps = append(s.steps, "revoke "+orderID)
+	return nil
+}
+
+func (s *appealStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s *appealStore) Log(ctx context.Context, entry *port.AuditEntry) error {
+	return nil
+}
+
+func newTestAppealService(store *appealStore) *AppealService {
+	return NewAppealService(store, store, store, store, store, nil, 0)
+}
+
+func newTestAppeal(entityID, orderID string) *domain.Appeal {
+	return &domain.Appeal{
+		EntityID:      entityID,
+		FreezeOrderID: orderID,
+		Grounds:       "The wallet holds segregated customer funds",
+		Documents:     []domain.Attachment{{ID: "doc-1", FileName: "custody-agreement.pdf"}},
+	}
+}
+
+func TestAppealService_FileAppealByWalletParty(t *testing.T) {
+	ctx := context.Background()
+	store := newAppealStore()
+	svc := newTestAppealService(store)
+
+	err := svc.FileAppeal(ctx, newTestAppeal(ownEntity, "order-other"), "user")
+	if !errors.Is(err, domain.ErrFreezeOrderNotFound) {
+		t.Fatalf("appeal of another entity's order: got %v, want ErrFreezeOrderNotFound", err)
+	}
+	if len(store.appeals) != 0 || len(store.steps) != 0 {
+		t.Fatalf("appeal of another entity's order was filed: %v", store.steps)
+	}
+
+	for _, orderID := range []string{"order-owned", "order-held"} {
+		appeal := newTestAppeal(ownEntity, orderID)
+		if err := svc.FileAppeal(ctx, appeal, "user"); err != nil {
+			t.Fatalf("%s: %v", orderID, err)
+		}
+		if appeal.Status != domain.AppealStatusPendingReview || appeal.Jurisdiction != "SG" {
+			t.Errorf("%s: filed as %s in %q", orderID, appeal.Status, appeal.Jurisdiction)
+		}
+		if !store.orders[orderID].UnderAppeal {
+			t.Errorf("%s: freeze order not marked as under appeal", orderID)
+		}
+	}
+
+	if err := svc.FileAppeal(ctx, newTestAppeal(ownEntity, "order-owned"), "user"); !errors.Is(err, domain.ErrDuplicateAppeal) {
+		t.Errorf("second appeal: got %v, want ErrDuplicateAppeal", err)
+	}
+}
+
+func TestAppealService_DeterminationsDecideFreezeOrder(t *testing.T) {
+	ctx := context.Background()
+	store := newAppealStore()
+	svc := newTestAppealService(store)
+
+	appeal := newTestAppeal(ownEntity, "order-owned")
+	if err := svc.FileAppeal(ctx, appeal, "user"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A request for information needs a deadline and leaves the order alone
+	_, err := svc.RecordDetermination(ctx, appeal.ID, &domain.AppealDetermination{
+		Outcome: domain.AppealOutcomeRequestInformation, Reasoning: "Provide the custody agreement's annex",
+	}, "reviewer")
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "response_due_at" {
+		t.Fatalf("request without deadline: got %v, want response_due_at validation error", err)
+	}
+
+	due := time.Now().Add(7 * 24 * time.Hour)
+	got, err := svc.RecordDetermination(ctx, appeal.ID, &domain.AppealDetermination{
+		Outcome: domain.AppealOutcomeRequestInformation, Reasoning: "Provide the custody agreement's annex", ResponseDueAt: &due,
+	}, "reviewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != domain.AppealStatusInformationRequested || len(store.steps) != 1 {
+		t.Fatalf("after request: status %s, freeze steps %v", got.Status, store.steps)
+	}
+
+	got, err = svc.AddAppealDocuments(ctx, appeal.ID, []domain.Attachment{{ID: "doc-2"}}, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != domain.AppealStatusPendingReview || len(got.Documents) != 2 {
+		t.Fatalf("after documents: status %s with %d documents", got.Status, len(got.Documents))
+	}
+
+	got, err = svc.RecordDetermination(ctx, appeal.ID, &domain.AppealDetermination{
+		Outcome: domain.AppealOutcomeRevoke, Reasoning: "Funds belong to customers",
+	}, "reviewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != domain.AppealStatusRevoked || got.ResolvedAt == nil || len(got.Determinations) != 2 {
+		t.Errorf("after revocation: status %s, resolved %v, %d determinations", got.Status, got.ResolvedAt, len(got.Determinations))
+	}
+	if store.orders["order-owned"].Status != "RELEASED" {
+		t.Errorf("freeze order not revoked: %v", store.steps)
+	}
+
+	_, err = svc.RecordDetermination(ctx, appeal.ID, &domain.AppealDetermination{
+		Outcome: domain.AppealOutcomeUphold, Reasoning: "Reconsidered",
+	}, "reviewer")
+	if !errors.Is(err, domain.ErrAppealClosed) {
+		t.Errorf("determination on closed appeal: got %v, want ErrAppealClosed", err)
+	}
+}
@@ -10,19 +10,22 @@ import (
 
 	"github.com/csic-platform/compliance/internal/domain"
 	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/tenancy"
 )
 
 // EntityService handles regulated entity operations
 type EntityService struct {
 	repo      port.EntityRepository
 	audit     port.AuditLogPort
+	guard     *JurisdictionGuard
 }
 
 // NewEntityService creates a new entity service
-func NewEntityService(repo port.EntityRepository, audit port.AuditLogPort) *EntityService {
+func NewEntityService(repo port.EntityRepository, audit port.AuditLogPort, guard *JurisdictionGuard) *EntityService {
 	return &EntityService{
 		repo:  repo,
 		audit: audit,
+		guard: guard,
 	}
 }
 
@@ -32,6 +35,10 @@ func (s *EntityService) CreateEntity(ctx context.Context, entity *domain.Regulat
 	if err := entity.Validate(); err != nil {
 		return err
 	}
+	entity.Jurisdiction = tenancy.Normalize(entity.Jurisdiction)
+	if err := s.guard.canAssign(ctx, entity.Jurisdiction); err != nil {
+		return err
+	}
 
 	// Check for duplicate registration number
 	existing, err := s.repo.GetByRegistrationNumber(ctx, entity.RegistrationNumber)
@@ -75,25 +82,55 @@ func (s *EntityService) GetEntity(ctx context.Context, entityID string) (*domain
 	if err != nil {
 		return nil, err
 	}
+	if err := s.guard.canRead(ctx, entity.Jurisdiction, entity.ID, domain.ErrEntityNotFound); err != nil {
+		return nil, err
+	}
 	return entity, nil
 }
 
 // GetEntityByRegistration retrieves an entity by registration number
 func (s *EntityService) GetEntityByRegistration(ctx context.Context, regNumber string) (*domain.RegulatedEntity, error) {
-	return s.repo.GetByRegistrationNumber(ctx, regNumber)
+	entity, err := s.repo.GetByRegistrationNumber(ctx, regNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canRead(ctx, entity.Jurisdiction, entity.ID, domain.ErrEntityNotFound); err != nil {
+		return nil, err
+	}
+	return entity, nil
 }
 
-// ListEntities lists entities with filters
+// ListEntities lists entities with filters, within the caller's jurisdictions
 func (s *EntityService) ListEntities(ctx context.Context, filter port.EntityFilter) ([]*domain.RegulatedEntity, error) {
+	jurisdictions, err := s.guard.listScope(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	filter.Jurisdictions = jurisdictions
 	return s.repo.List(ctx, filter)
 }
 
+// getForUpdate retrieves an entity the caller may change
+func (s *EntityService) getForUpdate(ctx context.Context, entityID string) (*domain.RegulatedEntity, error) {
+	entity, err := s.repo.GetByID(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canWrite(ctx, entity.Jurisdiction, entity.ID, domain.ErrEntityNotFound); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
 // UpdateEntity updates an entity
 func (s *EntityService) UpdateEntity(ctx context.Context, entity *domain.RegulatedEntity, actorID string) error {
 	// Validate entity data
 	if err := entity.Validate(); err != nil {
 		return err
 	}
+	if _, err := s.getForUpdate(ctx, entity.ID); err != nil {
+		return err
+	}
 
 	entity.UpdatedAt = time.Now()
 
@@ -120,7 +157,7 @@ func (s *EntityService) UpdateEntity(ctx context.Context, entity *domain.Regulat
 
 // ActivateEntity activates an entity
 func (s *EntityService) ActivateEntity(ctx context.Context, entityID string, actorID string) error {
-	entity, err := s.repo.GetByID(ctx, entityID)
+	entity, err := s.getForUpdate(ctx, entityID)
 	if err != nil {
 		return err
 	}
@@ -152,7 +189,7 @@ func (s *EntityService) ActivateEntity(ctx context.Context, entityID string, act
 
 // SuspendEntity suspends an entity
 func (s *EntityService) SuspendEntity(ctx context.Context, entityID string, reason string, actorID string) error {
-	entity, err := s.repo.GetByID(ctx, entityID)
+	entity, err := s.getForUpdate(ctx, entityID)
 	if err != nil {
 		return err
 	}
@@ -187,7 +224,7 @@ func (s *EntityService) SuspendEntity(ctx context.Context, entityID string, reas
 
 // RevokeEntity revokes an entity
 func (s *EntityService) RevokeEntity(ctx context.Context, entityID string, reason string, actorID string) error {
-	entity, err := s.repo.GetByID(ctx, entityID)
+	entity, err := s.getForUpdate(ctx, entityID)
 	if err != nil {
 		return err
 	}
@@ -222,7 +259,7 @@ func (s *EntityService) RevokeEntity(ctx context.Context, entityID string, reaso
 
 // AddLicenseToEntity adds a license ID to an entity
 func (s *EntityService) AddLicenseToEntity(ctx context.Context, entityID, licenseID string, actorID string) error {
-	entity, err := s.repo.GetByID(ctx, entityID)
+	entity, err := s.getForUpdate(ctx, entityID)
 	if err != nil {
 		return err
 	}
@@ -255,7 +292,7 @@ func (s *EntityService) AddLicenseToEntity(ctx context.Context, entityID, licens
 
 // GetEntityComplianceReport generates a compliance report for an entity
 func (s *EntityService) GetEntityComplianceReport(ctx context.Context, entityID string, startDate, endDate time.Time) (*port.ComplianceReport, error) {
-	entity, err := s.repo.GetByID(ctx, entityID)
+	entity, err := s.GetEntity(ctx, entityID)
 	if err != nil {
 		return nil, err
 	}
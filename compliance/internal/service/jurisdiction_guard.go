@@ -0,0 +1,118 @@
+// Compliance Management Module - Jurisdiction Guard
+// Row-level jurisdiction scoping of entities, licenses and reports
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/tenancy"
+)
+
+// JurisdictionGuard applies the caller's jurisdiction scope, carried in the
+// request context, to the records services read and change. Callers see the
+// records of their own jurisdictions and, read-only, those of entities shared
+// with one of their jurisdictions by an active sharing agreement. Records
+// outside the scope are reported as not found. Work without a scope in its
+// context, such as scheduled jobs, is not restricted, and neither is any work
+// through a nil guard.
+type JurisdictionGuard struct {
+	agreements port.SharingAgreementRepository
+}
+
+// NewJurisdictionGuard creates a new jurisdiction guard
+func NewJurisdictionGuard(agreements port.SharingAgreementRepository) *JurisdictionGuard {
+	return &JurisdictionGuard{agreements: agreements}
+}
+
+// scope returns the caller's scope; ok is false for unrestricted work
+func (g *JurisdictionGuard) scope(ctx context.Context) (tenancy.Scope, bool) {
+	if g == nil {
+		return tenancy.Scope{}, false
+	}
+	scope, ok := tenancy.FromContext(ctx)
+	if !ok || scope.All() {
+		return scope, false
+	}
+	return scope, true
+}
+
+// shared reports whether the entity's records are shared with the scope
+func (g *JurisdictionGuard) shared(ctx context.Context, scope tenancy.Scope, entityID string) (bool, error) {
+	if entityID == "" {
+		return false, nil
+	}
+	return g.agreements.HasActiveSharingAgreement(ctx, entityID, scope.Jurisdictions(), time.Now())
+}
+
+// listScope returns the jurisdictions a listing is restricted to, nil when it
+// is not restricted. A listing of one entity's records covers them in full
+// when the entity is shared with the caller.
+func (g *JurisdictionGuard) listScope(ctx context.Context, entityID string) ([]string, error) {
+	scope, ok := g.scope(ctx)
+	if !ok {
+		return nil, nil
+	}
+	shared, err := g.shared(ctx, scope, entityID)
+	if err != nil || shared {
+		return nil, err
+	}
+	return scope.Jurisdictions(), nil
+}
+
+// canRead returns notFound unless the caller may read a record of the
+// jurisdiction belonging to the entity
+func (g *JurisdictionGuard) canRead(ctx context.Context, jurisdiction, entityID string, notFound error) error {
+	scope, ok := g.scope(ctx)
+	if !ok || scope.Allows(jurisdiction) {
+		return nil
+	}
+	shared, err := g.shared(ctx, scope, entityID)
+	if err != nil {
+		return err
+	}
+	if !shared {
+		return notFound
+	}
+	return nil
+}
+
+// canWrite returns nil if the caller may change a record of the jurisdiction
+// belonging to the entity. Records shared with the caller are read-only and
+// fail with domain.ErrOutsideJurisdiction; unreadable ones with notFound.
+func (g *JurisdictionGuard) canWrite(ctx context.Context, jurisdiction, entityID string, notFound error) error {
+	scope, ok := g.scope(ctx)
+	if !ok || scope.Allows(jurisdiction) {
+		return nil
+	}
+	if err := g.canRead(ctx, jurisdiction, entityID, notFound); err != nil {
+		return err
+	}
+	return domain.ErrOutsideJurisdiction
+}
+
+// canAssign returns domain.ErrOutsideJurisdiction unless the caller may create
+// records in the jurisdiction
+func (g *JurisdictionGuard) canAssign(ctx context.Context, jurisdiction string) error {
+	if !g.inScope(ctx, jurisdiction) {
+		return domain.ErrOutsideJurisdiction
+	}
+	return nil
+}
+
+// inScope reports whether the caller's scope grants any of the jurisdictions
+func (g *JurisdictionGuard) inScope(ctx context.Context, jurisdictions ...string) bool {
+	scope, ok := g.scope(ctx)
+	if !ok {
+		return true
+	}
+	for _, jurisdiction := range jurisdictions {
+		if scope.Allows(jurisdiction) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+// Compliance Management Module - License Expiry Job
+// Scheduled expiry notifications and automatic suspension of expired licenses
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+)
+
+// expiryJobActorID identifies the expiry job in audit entries
+const expiryJobActorID = "SYSTEM:LICENSE_EXPIRY_JOB"
+
+// DefaultExpiryThresholds are the days before expiry at which holders are notified
+var DefaultExpiryThresholds = []int{90, 30, 7}
+
+// LicenseExpiryJob notifies license holders ahead of expiry and suspends expired licenses
+type LicenseExpiryJob struct {
+	repo        port.LicenseRepository
+	entityRepo  port.EntityRepository
+	notices     port.ExpiryNoticeRepository
+	notifier    port.NotificationPort
+	enforcement port.EnforcementPort
+	audit       port.AuditLogPort
+	thresholds  []int
+}
+
+// NewLicenseExpiryJob creates a new license expiry job. It is run on a
+// schedule by calling RunOnce.
+func NewLicenseExpiryJob(
+	repo port.LicenseRepository,
+	entityRepo port.EntityRepository,
+	notices port.ExpiryNoticeRepository,
+	notifier port.NotificationPort,
+	enforcement port.EnforcementPort,
+	audit port.AuditLogPort,
+	thresholds ...int,
+) *LicenseExpiryJob {
+	if len(thresholds) == 0 {
+		thresholds = DefaultExpiryThresholds
+	}
+	sorted := append([]int(nil), thresholds...)
+	sort.Ints(sorted)
+
+	return &LicenseExpiryJob{
+		repo:        repo,
+		entityRepo:  entityRepo,
+		notices:     notices,
+		notifier:    notifier,
+		enforcement: enforcement,
+		audit:       audit,
+		thresholds:  sorted,
+	}
+}
+
+// RunOnce performs a single pass over expiring and expired active licenses
+func (j *LicenseExpiryJob) RunOnce(ctx context.Context) error {
+	licenses, err := j.repo.GetExpiring(ctx, j.thresholds[len(j.thresholds)-1])
+	if err != nil {
+		return fmt.Errorf("failed to load expiring licenses: %w", err)
+	}
+
+	var errs []error
+	for _, license := range licenses {
+		if !license.IsActive() {
+			continue
+		}
+
+		if license.IsExpired() {
+			if err := j.suspendExpired(ctx, license); err != nil {
+				errs = append(errs, fmt.Errorf("license %s: %w", license.LicenseNumber, err))
+			}
+			continue
+		}
+
+		if err := j.notifyExpiring(ctx, license); err != nil {
+			errs = append(errs, fmt.Errorf("license %s: %w", license.LicenseNumber, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// thresholdFor returns the tightest notification threshold the license falls within
+func (j *LicenseExpiryJob) thresholdFor(daysRemaining int) (int, bool) {
+	for _, threshold := range j.thresholds {
+		if daysRemaining <= threshold {
+			return threshold, true
+		}
+	}
+	return 0, false
+}
+
+// notifyExpiring sends the expiry notice for the current threshold if not already sent
+func (j *LicenseExpiryJob) notifyExpiring(ctx context.Context, license *domain.License) error {
+	daysRemaining := license.DaysUntilExpiry()
+	threshold, ok := j.thresholdFor(daysRemaining)
+	if !ok {
+		return nil
+	}
+
+	sent, err := j.notices.HasExpiryNotice(ctx, license.ID, threshold)
+	if err != nil {
+		return fmt.Errorf("failed to check expiry notice: %w", err)
+	}
+	if sent {
+		return nil
+	}
+
+	notice := &port.LicenseExpiryNotice{
+		LicenseID:     license.ID,
+		LicenseNumber: license.LicenseNumber,
+		EntityID:      license.EntityID,
+		EntityName:    license.EntityName,
+		ThresholdDays: threshold,
+		DaysRemaining: daysRemaining,
+		ExpiresAt:     license.ExpiresAt,
+	}
+
+	result, notifyErr := "SUCCESS", j.notifier.NotifyLicenseExpiry(ctx, notice)
+	if notifyErr != nil {
+		result = "FAILURE"
+	} else if err := j.notices.RecordExpiryNotice(ctx, license.ID, threshold, time.Now()); err != nil {
+		return fmt.Errorf("failed to record expiry notice: %w", err)
+	}
+
+	if err := j.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      expiryJobActorID,
+		Action:       "LICENSE_EXPIRY_NOTICE_SENT",
+		ResourceType: "LICENSE",
+		ResourceID:   license.ID,
+		EntityID:     license.EntityID,
+		Description:  fmt.Sprintf("Notified holder that license %s expires in %d days", license.LicenseNumber, daysRemaining),
+		Result:       result,
+		Error:        errorString(notifyErr),
+		Metadata: map[string]interface{}{
+			"threshold_days": threshold,
+			"days_remaining": daysRemaining,
+			"expires_at":     license.ExpiresAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	if notifyErr != nil {
+		return fmt.Errorf("failed to notify license holder: %w", notifyErr)
+	}
+	return nil
+}
+
+// suspendExpired freezes the exchange operating under an expired license,
+// then suspends the license. Only active licenses are selected by the job,
+// so the license stays active until the freeze order has been issued; a
+// failed freeze is returned and retried on the next run.
+func (j *LicenseExpiryJob) suspendExpired(ctx context.Context, license *domain.License) error {
+	reason := fmt.Sprintf("License expired on %s", license.ExpiresAt.Format("2006-01-02"))
+
+	if license.Type == domain.LicenseTypeExchange {
+		if err := j.freezeExchange(ctx, license, reason); err != nil {
+			return err
+		}
+	}
+
+	if err := license.Suspend(reason); err != nil {
+		return err
+	}
+	license.UpdatedAt = time.Now()
+
+	if err := j.repo.Update(ctx, license); err != nil {
+		return fmt.Errorf("failed to suspend license: %w", err)
+	}
+
+	if err := j.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      expiryJobActorID,
+		Action:       "LICENSE_AUTO_SUSPENDED",
+		ResourceType: "LICENSE",
+		ResourceID:   license.ID,
+		EntityID:     license.EntityID,
+		Description:  fmt.Sprintf("Automatically suspended license %s - Reason: %s", license.LicenseNumber, reason),
+		Result:       "SUCCESS",
+		Metadata: map[string]interface{}{
+			"reason":     reason,
+			"expires_at": license.ExpiresAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return nil
+}
+
+// freezeExchange cascades a freeze order to the exchange operating under the license
+func (j *LicenseExpiryJob) freezeExchange(ctx context.Context, license *domain.License, reason string) error {
+	entity, err := j.entityRepo.GetByID(ctx, license.EntityID)
+	if err != nil {
+		return fmt.Errorf("entity not found: %w", err)
+	}
+
+	order := &port.FreezeOrderRequest{
+		EntityID:    entity.ID,
+		LicenseID:   license.ID,
+		Reason:      reason,
+		RequestedBy: expiryJobActorID,
+		Metadata: map[string]interface{}{
+			"license_number": license.LicenseNumber,
+			"entity_name":    entity.Name,
+		},
+	}
+
+	result, freezeErr := "SUCCESS", j.enforcement.IssueFreezeOrder(ctx, order)
+	if freezeErr != nil {
+		result = "FAILURE"
+	}
+
+	if err := j.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      expiryJobActorID,
+		Action:       "FREEZE_ORDER_REQUESTED",
+		ResourceType: "ENTITY",
+		ResourceID:   entity.ID,
+		EntityID:     entity.ID,
+		Description:  fmt.Sprintf("Requested freeze of exchange %s after expiry of license %s", entity.Name, license.LicenseNumber),
+		Result:       result,
+		Error:        errorString(freezeErr),
+		Metadata: map[string]interface{}{
+			"license_id": license.ID,
+			"reason":     reason,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	if freezeErr != nil {
+		return fmt.Errorf("failed to issue freeze order: %w", freezeErr)
+	}
+	return nil
+}
+
+// errorString returns the error message or an empty string for nil errors
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -5,26 +5,51 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/csic-platform/compliance/internal/domain"
 	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+	"github.com/csic-platform/shared/tenancy"
 )
 
+// LicenseEventsTopic is the Kafka topic license lifecycle events are published to
+const LicenseEventsTopic = "csic.license_events"
+
+// LicenseEventRevoked is published when a license is revoked
+const LicenseEventRevoked = "LICENSE_REVOKED"
+
 // LicensingService handles license operations
 type LicensingService struct {
 	repo      port.LicenseRepository
 	entityRepo port.EntityRepository
 	audit     port.AuditLogPort
+	conflicts port.ConflictMetrics
+	tx        port.TxManager
+	outbox    port.OutboxRepository
+	guard     *JurisdictionGuard
 }
 
 // NewLicensingService creates a new licensing service
-func NewLicensingService(repo port.LicenseRepository, entityRepo port.EntityRepository, audit port.AuditLogPort) *LicensingService {
+func NewLicensingService(
+	repo port.LicenseRepository,
+	entityRepo port.EntityRepository,
+	audit port.AuditLogPort,
+	conflicts port.ConflictMetrics,
+	tx port.TxManager,
+	outbox port.OutboxRepository,
+	guard *JurisdictionGuard,
+) *LicensingService {
 	return &LicensingService{
 		repo:      repo,
 		entityRepo: entityRepo,
 		audit:     audit,
+		conflicts: conflicts,
+		tx:        tx,
+		outbox:    outbox,
+		guard:     guard,
 	}
 }
 
@@ -34,12 +59,19 @@ func (s *LicensingService) CreateLicense(ctx context.Context, license *domain.Li
 	if err := license.Validate(); err != nil {
 		return err
 	}
+	license.Jurisdiction = tenancy.Normalize(license.Jurisdiction)
+	if err := s.guard.canAssign(ctx, license.Jurisdiction); err != nil {
+		return err
+	}
 
 	// Verify entity exists and can apply for license
 	entity, err := s.entityRepo.GetByID(ctx, license.EntityID)
 	if err != nil {
 		return fmt.Errorf("entity not found: %w", err)
 	}
+	if err := s.guard.canWrite(ctx, entity.Jurisdiction, entity.ID, domain.ErrEntityNotFound); err != nil {
+		return err
+	}
 
 	if !entity.CanApplyForLicense() {
 		return domain.ErrEntityInactive
@@ -90,7 +122,7 @@ func (s *LicensingService) CreateLicense(ctx context.Context, license *domain.Li
 
 // SubmitLicense submits a license application for review
 func (s *LicensingService) SubmitLicense(ctx context.Context, licenseID string, actorID string) error {
-	license, err := s.repo.GetByID(ctx, licenseID)
+	license, err := s.getForUpdate(ctx, licenseID)
 	if err != nil {
 		return err
 	}
@@ -101,7 +133,7 @@ func (s *LicensingService) SubmitLicense(ctx context.Context, licenseID string,
 
 	license.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, license); err != nil {
+	if err := s.update(ctx, license); err != nil {
 		return fmt.Errorf("failed to submit license: %w", err)
 	}
 
@@ -124,7 +156,7 @@ func (s *LicensingService) SubmitLicense(ctx context.Context, licenseID string,
 
 // ApproveLicense approves a license
 func (s *LicensingService) ApproveLicense(ctx context.Context, licenseID string, officerID string, conditions []domain.LicenseCondition) error {
-	license, err := s.repo.GetByID(ctx, licenseID)
+	license, err := s.getForUpdate(ctx, licenseID)
 	if err != nil {
 		return err
 	}
@@ -140,7 +172,7 @@ func (s *LicensingService) ApproveLicense(ctx context.Context, licenseID string,
 
 	license.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, license); err != nil {
+	if err := s.update(ctx, license); err != nil {
 		return fmt.Errorf("failed to approve license: %w", err)
 	}
 
@@ -167,7 +199,7 @@ func (s *LicensingService) ApproveLicense(ctx context.Context, licenseID string,
 
 // ActivateLicense activates an approved license
 func (s *LicensingService) ActivateLicense(ctx context.Context, licenseID string, actorID string) error {
-	license, err := s.repo.GetByID(ctx, licenseID)
+	license, err := s.getForUpdate(ctx, licenseID)
 	if err != nil {
 		return err
 	}
@@ -178,7 +210,7 @@ func (s *LicensingService) ActivateLicense(ctx context.Context, licenseID string
 
 	license.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, license); err != nil {
+	if err := s.update(ctx, license); err != nil {
 		return fmt.Errorf("failed to activate license: %w", err)
 	}
 
@@ -201,7 +233,7 @@ func (s *LicensingService) ActivateLicense(ctx context.Context, licenseID string
 
 // SuspendLicense suspends a license
 func (s *LicensingService) SuspendLicense(ctx context.Context, licenseID string, reason string, actorID string) error {
-	license, err := s.repo.GetByID(ctx, licenseID)
+	license, err := s.getForUpdate(ctx, licenseID)
 	if err != nil {
 		return err
 	}
@@ -212,7 +244,7 @@ func (s *LicensingService) SuspendLicense(ctx context.Context, licenseID string,
 
 	license.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, license); err != nil {
+	if err := s.update(ctx, license); err != nil {
 		return fmt.Errorf("failed to suspend license: %w", err)
 	}
 
@@ -238,7 +270,7 @@ func (s *LicensingService) SuspendLicense(ctx context.Context, licenseID string,
 
 // RevokeLicense revokes a license
 func (s *LicensingService) RevokeLicense(ctx context.Context, licenseID string, reason string, actorID string) error {
-	license, err := s.repo.GetByID(ctx, licenseID)
+	license, err := s.getForUpdate(ctx, licenseID)
 	if err != nil {
 		return err
 	}
@@ -249,7 +281,33 @@ func (s *LicensingService) RevokeLicense(ctx context.Context, licenseID string,
 
 	license.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, license); err != nil {
+	// The revocation event is enqueued with the update so partner agencies
+	// are notified if and only if the revocation is committed
+	if err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.update(ctx, license); err != nil {
+			return err
+		}
+
+		event, err := domain.NewOutboxEvent(LicenseEventsTopic, "LICENSE", license.ID, LicenseEventRevoked,
+			fmt.Sprintf("license:%s:%s", license.ID, LicenseEventRevoked),
+			map[string]interface{}{
+				"event_type":     LicenseEventRevoked,
+				"license_id":     license.ID,
+				"license_number": license.LicenseNumber,
+				"license_type":   license.Type,
+				"entity_id":      license.EntityID,
+				"entity_name":    license.EntityName,
+				"jurisdiction":   license.Jurisdiction,
+				"reason":         reason,
+				"revoked_by":     actorID,
+				"timestamp":      license.UpdatedAt.UTC().Format(time.RFC3339),
+			})
+		if err != nil {
+			return err
+		}
+		event.Jurisdiction = license.Jurisdiction
+		return s.outbox.Enqueue(ctx, event)
+	}); err != nil {
 		return fmt.Errorf("failed to revoke license: %w", err)
 	}
 
@@ -275,7 +333,7 @@ func (s *LicensingService) RevokeLicense(ctx context.Context, licenseID string,
 
 // RenewLicense renews a license
 func (s *LicensingService) RenewLicense(ctx context.Context, licenseID string, newExpiryDate time.Time, actorID string) error {
-	license, err := s.repo.GetByID(ctx, licenseID)
+	license, err := s.getForUpdate(ctx, licenseID)
 	if err != nil {
 		return err
 	}
@@ -306,7 +364,7 @@ func (s *LicensingService) RenewLicense(ctx context.Context, licenseID string, n
 		return err
 	}
 	license.UpdatedAt = time.Now()
-	if err := s.repo.Update(ctx, license); err != nil {
+	if err := s.update(ctx, license); err != nil {
 		return fmt.Errorf("failed to update old license: %w", err)
 	}
 
@@ -331,13 +389,90 @@ func (s *LicensingService) RenewLicense(ctx context.Context, licenseID string, n
 	return nil
 }
 
+// UpdateLicense amends a license's terms. expectedVersion is the version the
+// caller last read; if the license has changed since, nothing is written and
+// domain.ErrVersionConflict is returned.
+func (s *LicensingService) UpdateLicense(ctx context.Context, licenseID string, expectedVersion int, terms domain.LicenseTerms, actorID string) (*domain.License, error) {
+	license, err := s.getForUpdate(ctx, licenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if license.Version != expectedVersion {
+		s.conflicts.IncVersionConflict("license")
+		return nil, domain.ErrVersionConflict
+	}
+
+	if err := license.Amend(terms); err != nil {
+		return nil, err
+	}
+
+	license.UpdatedAt = time.Now()
+
+	if err := s.update(ctx, license); err != nil {
+		return nil, fmt.Errorf("failed to update license: %w", err)
+	}
+
+	// Audit log
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "LICENSE_AMENDED",
+		ResourceType: "LICENSE",
+		ResourceID:   license.ID,
+		EntityID:     license.EntityID,
+		Description:  fmt.Sprintf("Amended terms of license %s", license.LicenseNumber),
+		Result:       "SUCCESS",
+		Metadata: map[string]interface{}{
+			"version": license.Version,
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return license, nil
+}
+
+// update saves a license, counting optimistic locking conflicts
+func (s *LicensingService) update(ctx context.Context, license *domain.License) error {
+	err := s.repo.Update(ctx, license)
+	if errors.Is(err, domain.ErrVersionConflict) {
+		s.conflicts.IncVersionConflict("license")
+	}
+	return err
+}
+
+// getForUpdate retrieves a license the caller may change
+func (s *LicensingService) getForUpdate(ctx context.Context, licenseID string) (*domain.License, error) {
+	license, err := s.repo.GetByID(ctx, licenseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canWrite(ctx, license.Jurisdiction, license.EntityID, domain.ErrLicenseNotFound); err != nil {
+		return nil, err
+	}
+	return license, nil
+}
+
 // GetLicense retrieves a license by ID
 func (s *LicensingService) GetLicense(ctx context.Context, licenseID string) (*domain.License, error) {
-	return s.repo.GetByID(ctx, licenseID)
+	license, err := s.repo.GetByID(ctx, licenseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canRead(ctx, license.Jurisdiction, license.EntityID, domain.ErrLicenseNotFound); err != nil {
+		return nil, err
+	}
+	return license, nil
 }
 
-// ListLicenses lists licenses with filters
-func (s *LicensingService) ListLicenses(ctx context.Context, filter port.LicenseFilter) ([]*domain.License, error) {
+// ListLicenses lists licenses with filters, within the caller's jurisdictions
+func (s *LicensingService) ListLicenses(ctx context.Context, filter port.LicenseFilter) ([]*domain.License, *query.PageInfo, error) {
+	jurisdictions, err := s.guard.listScope(ctx, filter.EntityID)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter.Jurisdictions = jurisdictions
 	return s.repo.List(ctx, filter)
 }
 
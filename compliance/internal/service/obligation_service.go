@@ -121,6 +121,55 @@ func (s *ObligationService) CompleteObligation(ctx context.Context, obligationID
 	return nil
 }
 
+// SubmitObligation files the documents fulfilling an obligation for review. A
+// pending obligation is started first; the attachments reference documents
+// already uploaded to the document store.
+func (s *ObligationService) SubmitObligation(ctx context.Context, obligationID string, attachments []domain.Attachment, actorID string) (*domain.ComplianceObligation, error) {
+	if len(attachments) == 0 {
+		return nil, domain.NewValidationError("attachments", "at least one document is required")
+	}
+
+	obligation, err := s.repo.GetByID(ctx, obligationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if obligation.Status == domain.ObligationStatusPending {
+		if err := obligation.StartProgress(); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	for _, attachment := range attachments {
+		attachment.UploadedAt = now
+		attachment.UploadedBy = actorID
+		obligation.AddAttachment(attachment)
+	}
+	if err := obligation.Submit(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, obligation); err != nil {
+		return nil, fmt.Errorf("failed to submit obligation: %w", err)
+	}
+
+	// Audit log
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:     time.Now(),
+		ActorID:       actorID,
+		Action:        "OBLIGATION_SUBMITTED",
+		ResourceType:  "OBLIGATION",
+		ResourceID:    obligation.ID,
+		EntityID:      obligation.EntityID,
+		Description:   fmt.Sprintf("Submitted obligation: %s (%d documents)", obligation.Title, len(attachments)),
+		Result:        "SUCCESS",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return obligation, nil
+}
+
 // VerifyObligation verifies a submitted obligation
 func (s *ObligationService) VerifyObligation(ctx context.Context, obligationID string, notes string, actorID string) error {
 	obligation, err := s.repo.GetByID(ctx, obligationID)
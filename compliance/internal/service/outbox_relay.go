@@ -0,0 +1,160 @@
+// Compliance Management Module - Outbox Relay
+// Background delivery of transactional outbox events to the message bus
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/port"
+)
+
+// OutboxRelayConfig contains outbox relay settings
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	MaxBackoff   time.Duration
+	Retention    time.Duration
+}
+
+// DefaultOutboxRelayConfig returns the default outbox relay settings
+func DefaultOutboxRelayConfig() OutboxRelayConfig {
+	return OutboxRelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		Lease:        30 * time.Second,
+		MaxBackoff:   5 * time.Minute,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// OutboxRelay publishes pending outbox events and marks them delivered.
+// An event is only marked published after the broker acknowledges it, so a
+// crash between the two results in redelivery rather than loss.
+type OutboxRelay struct {
+	outbox    port.OutboxRepository
+	publisher port.EventPublisher
+	cfg       OutboxRelayConfig
+}
+
+// NewOutboxRelay creates a new outbox relay
+func NewOutboxRelay(outbox port.OutboxRepository, publisher port.EventPublisher, cfg OutboxRelayConfig) *OutboxRelay {
+	defaults := DefaultOutboxRelayConfig()
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaults.Lease
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaults.MaxBackoff
+	}
+
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		cfg:       cfg,
+	}
+}
+
+// Start relays events until ctx is cancelled. Full batches are followed
+// immediately by another pass so that a backlog drains without waiting.
+func (r *OutboxRelay) Start(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		published, err := r.RunOnce(ctx)
+		if err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		if r.cfg.Retention > 0 && time.Since(lastCleanup) >= time.Hour {
+			if _, err := r.outbox.DeletePublishedBefore(ctx, time.Now().Add(-r.cfg.Retention)); err != nil && onError != nil && ctx.Err() == nil {
+				onError(fmt.Errorf("failed to prune outbox: %w", err))
+			}
+			lastCleanup = time.Now()
+		}
+
+		if published == r.cfg.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush publishes due events batch by batch until none are left or ctx is
+// done. Events it could not publish stay in the outbox for the next start.
+func (r *OutboxRelay) Flush(ctx context.Context) error {
+	for {
+		published, err := r.RunOnce(ctx)
+		if err != nil {
+			return err
+		}
+		if published < r.cfg.BatchSize {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// RunOnce claims and publishes one batch of due events, returning how many were published
+func (r *OutboxRelay) RunOnce(ctx context.Context) (int, error) {
+	events, err := r.outbox.ClaimPending(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	published := 0
+	var errs []error
+	for _, event := range events {
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			retryAt := time.Now().Add(r.backoff(event.Attempts))
+			if markErr := r.outbox.MarkFailed(ctx, event.ID, err.Error(), retryAt); markErr != nil {
+				errs = append(errs, fmt.Errorf("event %s: %w", event.ID, markErr))
+			}
+			errs = append(errs, fmt.Errorf("failed to publish event %s (%s): %w", event.ID, event.EventType, err))
+			continue
+		}
+
+		// If this fails the lease expires and the event is published again;
+		// consumers discard the duplicate by its dedup key
+		if err := r.outbox.MarkPublished(ctx, event.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to mark event %s published: %w", event.ID, err))
+			continue
+		}
+		published++
+	}
+
+	return published, errors.Join(errs...)
+}
+
+// backoff returns the exponential retry delay after the given number of attempts
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := r.cfg.PollInterval
+	for i := 1; i < attempts && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.MaxBackoff {
+		delay = r.cfg.MaxBackoff
+	}
+	return delay
+}
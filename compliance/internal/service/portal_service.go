@@ -0,0 +1,248 @@
+// Compliance Management Module - Self-Service Portal Service
+// Read access, report filing and appeals for regulated entities, scoped to the caller's entity
+
+package service
+
+import (
+	"context"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+)
+
+// The reads and writes the portal makes on behalf of an entity, satisfied by
+// the entity, licensing, obligation, violation, trade reporting and appeal
+// services
+type (
+	portalEntities interface {
+		GetEntity(ctx context.Context, entityID string) (*domain.RegulatedEntity, error)
+	}
+	portalLicenses interface {
+		GetLicense(ctx context.Context, licenseID string) (*domain.License, error)
+		ListLicenses(ctx context.Context, filter port.LicenseFilter) ([]*domain.License, *query.PageInfo, error)
+	}
+	portalObligations interface {
+		GetObligation(ctx context.Context, obligationID string) (*domain.ComplianceObligation, error)
+		ListObligations(ctx context.Context, filter port.ObligationFilter) ([]*domain.ComplianceObligation, error)
+		SubmitObligation(ctx context.Context, obligationID string, attachments []domain.Attachment, actorID string) (*domain.ComplianceObligation, error)
+	}
+	portalViolations interface {
+		GetViolation(ctx context.Context, violationID string) (*domain.ComplianceViolation, error)
+		GetOpenViolations(ctx context.Context, entityID string) ([]*domain.ComplianceViolation, error)
+	}
+	portalTradeReports interface {
+		SubmitTradeReport(ctx context.Context, report *domain.TradeReport, actorID string) error
+		GetTradeReport(ctx context.Context, id string) (*domain.TradeReport, error)
+		ListTradeReports(ctx context.Context, filter port.TradeReportFilter) ([]*domain.TradeReport, error)
+	}
+	portalAppeals interface {
+		FileAppeal(ctx context.Context, appeal *domain.Appeal, actorID string) error
+		GetAppeal(ctx context.Context, id string) (*domain.Appeal, error)
+		ListAppeals(ctx context.Context, filter port.AppealFilter) ([]*domain.Appeal, error)
+		AddAppealDocuments(ctx context.Context, id string, documents []domain.Attachment, actorID string) (*domain.Appeal, error)
+	}
+)
+
+// PortalService serves the self-service portal of regulated entities. Every
+// method takes the caller's entity ID from its token and only returns or
+// changes that entity's records; records of other entities are reported as
+// not found so their existence is not disclosed.
+type PortalService struct {
+	entities     portalEntities
+	licenses     portalLicenses
+	obligations  portalObligations
+	violations   portalViolations
+	tradeReports portalTradeReports
+	appeals      portalAppeals
+}
+
+// NewPortalService creates a new portal service
+func NewPortalService(
+	entityService *EntityService,
+	licensingService *LicensingService,
+	obligationService *ObligationService,
+	violationService *ViolationService,
+	tradeReportingService *TradeReportingService,
+	appealService *AppealService,
+) *PortalService {
+	return &PortalService{
+		entities:     entityService,
+		licenses:     licensingService,
+		obligations:  obligationService,
+		violations:   violationService,
+		tradeReports: tradeReportingService,
+		appeals:      appealService,
+	}
+}
+
+// GetEntity retrieves the caller's own entity record
+func (s *PortalService) GetEntity(ctx context.Context, entityID string) (*domain.RegulatedEntity, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	return s.entities.GetEntity(ctx, entityID)
+}
+
+// ListLicenses lists the caller's licenses
+func (s *PortalService) ListLicenses(ctx context.Context, entityID string, filter port.LicenseFilter) ([]*domain.License, *query.PageInfo, error) {
+	if entityID == "" {
+		return nil, nil, domain.ErrUnauthorized
+	}
+	filter.EntityID = entityID
+	return s.licenses.ListLicenses(ctx, filter)
+}
+
+// GetLicense retrieves one of the caller's licenses
+func (s *PortalService) GetLicense(ctx context.Context, entityID, licenseID string) (*domain.License, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	license, err := s.licenses.GetLicense(ctx, licenseID)
+	if err != nil {
+		return nil, err
+	}
+	if license.EntityID != entityID {
+		return nil, domain.ErrLicenseNotFound
+	}
+	return license, nil
+}
+
+// GetOpenViolations lists the caller's open violations
+func (s *PortalService) GetOpenViolations(ctx context.Context, entityID string) ([]*domain.ComplianceViolation, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	return s.violations.GetOpenViolations(ctx, entityID)
+}
+
+// GetViolation retrieves one of the caller's violations
+func (s *PortalService) GetViolation(ctx context.Context, entityID, violationID string) (*domain.ComplianceViolation, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	violation, err := s.violations.GetViolation(ctx, violationID)
+	if err != nil {
+		return nil, err
+	}
+	if violation.EntityID != entityID {
+		return nil, domain.ErrViolationNotFound
+	}
+	return violation, nil
+}
+
+// ListObligations lists the caller's reporting obligations
+func (s *PortalService) ListObligations(ctx context.Context, entityID string, filter port.ObligationFilter) ([]*domain.ComplianceObligation, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	filter.EntityID = entityID
+	return s.obligations.ListObligations(ctx, filter)
+}
+
+// GetObligation retrieves one of the caller's reporting obligations
+func (s *PortalService) GetObligation(ctx context.Context, entityID, obligationID string) (*domain.ComplianceObligation, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	obligation, err := s.obligations.GetObligation(ctx, obligationID)
+	if err != nil {
+		return nil, err
+	}
+	if obligation.EntityID != entityID {
+		return nil, domain.ErrObligationNotFound
+	}
+	return obligation, nil
+}
+
+// SubmitObligation files the documents fulfilling one of the caller's
+// reporting obligations
+func (s *PortalService) SubmitObligation(ctx context.Context, entityID, obligationID string, attachments []domain.Attachment, actorID string) (*domain.ComplianceObligation, error) {
+	if _, err := s.GetObligation(ctx, entityID, obligationID); err != nil {
+		return nil, err
+	}
+	return s.obligations.SubmitObligation(ctx, obligationID, attachments, actorID)
+}
+
+// SubmitTradeReport files a trade report for the caller's entity. A report
+// naming another entity is rejected.
+func (s *PortalService) SubmitTradeReport(ctx context.Context, entityID string, report *domain.TradeReport, actorID string) error {
+	if entityID == "" {
+		return domain.ErrUnauthorized
+	}
+	if report.EntityID != "" && report.EntityID != entityID {
+		return domain.ErrOutsideEntityScope
+	}
+	report.EntityID = entityID
+	return s.tradeReports.SubmitTradeReport(ctx, report, actorID)
+}
+
+// ListTradeReports lists the caller's trade reports
+func (s *PortalService) ListTradeReports(ctx context.Context, entityID string, filter port.TradeReportFilter) ([]*domain.TradeReport, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	filter.EntityID = entityID
+	return s.tradeReports.ListTradeReports(ctx, filter)
+}
+
+// GetTradeReport retrieves one of the caller's trade reports
+func (s *PortalService) GetTradeReport(ctx context.Context, entityID, reportID string) (*domain.TradeReport, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	report, err := s.tradeReports.GetTradeReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.EntityID != entityID {
+		return nil, domain.ErrTradeReportNotFound
+	}
+	return report, nil
+}
+
+// FileAppeal files an appeal of the caller's entity against a freeze order on
+// one of its wallets. An appeal naming another entity is rejected.
+func (s *PortalService) FileAppeal(ctx context.Context, entityID string, appeal *domain.Appeal, actorID string) error {
+	if entityID == "" {
+		return domain.ErrUnauthorized
+	}
+	if appeal.EntityID != "" && appeal.EntityID != entityID {
+		return domain.ErrOutsideEntityScope
+	}
+	appeal.EntityID = entityID
+	return s.appeals.FileAppeal(ctx, appeal, actorID)
+}
+
+// ListAppeals lists the caller's appeals
+func (s *PortalService) ListAppeals(ctx context.Context, entityID string, filter port.AppealFilter) ([]*domain.Appeal, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	filter.EntityID = entityID
+	return s.appeals.ListAppeals(ctx, filter)
+}
+
+// GetAppeal retrieves one of the caller's appeals and its determinations
+func (s *PortalService) GetAppeal(ctx context.Context, entityID, appealID string) (*domain.Appeal, error) {
+	if entityID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	appeal, err := s.appeals.GetAppeal(ctx, appealID)
+	if err != nil {
+		return nil, err
+	}
+	if appeal.EntityID != entityID {
+		return nil, domain.ErrAppealNotFound
+	}
+	return appeal, nil
+}
+
+// AddAppealDocuments adds supporting documents to one of the caller's open
+// appeals, such as information the reviewers requested
+func (s *PortalService) AddAppealDocuments(ctx context.Context, entityID, appealID string, documents []domain.Attachment, actorID string) (*domain.Appeal, error) {
+	if _, err := s.GetAppeal(ctx, entityID, appealID); err != nil {
+		return nil, err
+	}
+	return s.appeals.AddAppealDocuments(ctx, appealID, documents, actorID)
+}
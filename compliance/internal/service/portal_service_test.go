@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+)
+
+const (
+	ownEntity   = "entity-own"
+	otherEntity = "entity-other"
+)
+
+// portalStore backs every service the portal reads through. Like the
+// repositories, its listings return every entity's records when the filter
+// names no entity, so a missing scope shows up as a leak.
+type portalStore struct {
+	entities     map[string]*domain.RegulatedEntity
+	licenses     map[string]*domain.License
+	obligations  map[string]*domain.ComplianceObligation
+	violations   map[string]*domain.ComplianceViolation
+	tradeReports map[string]*domain.TradeReport
+	appeals      map[string]*domain.Appeal
+	submitted    []string
+	filed        []*domain.TradeReport
+	appealed     []*domain.Appeal
+}
+
+func newPortalStore() *portalStore {
+	s := &portalStore{
+		entities:     make(map[string]*domain.RegulatedEntity),
+		licenses:     make(map[string]*domain.License),
+		obligations:  make(map[string]*domain.ComplianceObligation),
+		violations:   make(map[string]*domain.ComplianceViolation),
+		tradeReports: make(map[string]*domain.TradeReport),
+		appeals:      make(map[string]*domain.Appeal),
+	}
+	for _, entityID := range []string{ownEntity, otherEntity} {
+		s.entities[entityID] = &domain.RegulatedEntity{ID: entityID}
+		s.licenses["license-"+entityID] = &domain.License{ID: "license-" + entityID, EntityID: entityID}
+		s.obligations["obligation-"+entityID] = &domain.ComplianceObligation{ID: "obligation-" + entityID, EntityID: entityID}
+		s.violations["violation-"+entityID] = &domain.ComplianceViolation{ID: "violation-" + entityID, EntityID: entityID}
+		s.tradeReports["report-"+entityID] = &domain.TradeReport{ID: "report-" + entityID, EntityID: entityID}
+		s.appeals["appeal-"+entityID] = &domain.Appeal{ID: "appeal-" + entityID, EntityID: entityID}
+	}
+	return s
+}
+
+func (s *portalStore) GetEntity(ctx context.Context, entityID string) (*domain.RegulatedEntity, error) {
+	if entity, ok := s.entities[entityID]; ok {
+		return entity, nil
+	}
+	return nil, domain.ErrEntityNotFound
+}
+
+func (s *portalStore) GetLicense(ctx context.Context, licenseID string) (*domain.License, error) {
+	if license, ok := s.licenses[licenseID]; ok {
+		return license, nil
+	}
+	return nil, domain.ErrLicenseNotFound
+}
+
+func (s *portalStore) ListLicenses(ctx context.Context, filter port.LicenseFilter) ([]*domain.License, *query.PageInfo, error) {
+	var licenses []*domain.License
+	for _, license := range s.licenses {
+		if filter.EntityID == "" || license.EntityID == filter.EntityID {
+			licenses = append(licenses, license)
+		}
+	}
+	return licenses, &query.PageInfo{Total: int64(len(licenses))}, nil
+}
+
+func (s *portalStore) GetObligation(ctx context.Context, obligationID string) (*domain.ComplianceObligation, error) {
+	if obligation, ok := s.obligations[obligationID]; ok {
+		return obligation, nil
+	}
+	return nil, domain.ErrObligationNotFound
+}
+
+func (s *portalStore) ListObligations(ctx context.Context, filter port.ObligationFilter) ([]*domain.ComplianceObligation, error) {
+	var obligations []*domain.ComplianceObligation
+	for _, obligation := range s.obligations {
+		if filter.EntityID == "" || obligation.EntityID == filter.EntityID {
+			obligations = append(obligations, obligation)
+		}
+	}
+	return obligations, nil
+}
+
+func (s *portalStore) SubmitObligation(ctx context.Context, obligationID string, attachments []domain.Attachment, actorID string) (*domain.ComplianceObligation, error) {
+	s.submitted = append(s.submitted, obligationID)
+	return s.GetObligation(ctx, obligationID)
+}
+
+func (s *portalStore) GetViolation(ctx context.Context, violationID string) (*domain.ComplianceViolation, error) {
+	if violation, ok := s.violations[violationID]; ok {
+		return violation, nil
+	}
+	return nil, domain.ErrViolationNotFound
+}
+
+func (s *portalStore) GetOpenViolations(ctx context.Context, entityID string) ([]*domain.ComplianceViolation, error) {
+	var violations []*domain.ComplianceViolation
+	for _, violation := range s.violations {
+		if entityID == "" || violation.EntityID == entityID {
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+func (s *portalStore) SubmitTradeReport(ctx context.Context, report *domain.TradeReport, actorID string) error {
+	s.filed = append(s.filed, report)
+	return nil
+}
+
+func (s *portalStore) GetTradeReport(ctx context.Context, id string) (*domain.TradeReport, error) {
+	if report, ok := s.tradeReports[id]; ok {
+		return report, nil
+	}
+	return nil, domain.ErrTradeReportNotFound
+}
+
+func (s *portalStore) ListTradeReports(ctx context.Context, filter port.TradeReportFilter) ([]*domain.TradeReport, error) {
+	var reports []*domain.TradeReport
+	for _, report := range s.tradeReports {
+		if filter.EntityID == "" || report.EntityID == filter.EntityID {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+func (s *portalStore) FileAppeal(ctx context.Context, appeal *domain.Appeal, actorID string) error {
+	s.appealed = append(s.appealed, appeal)
+	return nil
+}
+
+func (s *portalStore) GetAppeal(ctx context.Context, id string) (*domain.Appeal, error) {
+	if appeal, ok := s.appeals[id]; ok {
+		return appeal, nil
+	}
+	return nil, domain.ErrAppealNotFound
+}
+
+func (s *portalStore) ListAppeals(ctx context.Context, filter port.AppealFilter) ([]*domain.Appeal, error) {
+	var appeals []*domain.Appeal
+	for _, appeal := range s.appeals {
+		if filter.EntityID == "" || appeal.EntityID == filter.EntityID {
+			appeals = append(appeals, appeal)
+		}
+	}
+	return appeals, nil
+}
+
+func (s *portalStore) AddAppealDocuments(ctx context.Context, id string, documents []domain.Attachment, actorID string) (*domain.Appeal, error) {
+	s.submitted = append(s.submitted, id)
+	return s.GetAppeal(ctx, id)
+}
+
+func newTestPortalService(store *portalStore) *PortalService {
+	return &PortalService{
+		entities:     store,
+		licenses:     store,
+		obligations:  store,
+		violations:   store,
+		tradeReports: store,
+		appeals:      store,
+	}
+}
+
+func TestPortalService_GetHidesOtherEntitiesRecords(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestPortalService(newPortalStore())
+
+	tests := []struct {
+		name string
+		get  func(id string) (string, error)
+		id   string
+		want error
+	}{
+		{"license", func(id string) (string, error) {
+			l, err := svc.GetLicense(ctx, ownEntity, id)
+			if err != nil {
+				return "", err
+			}
+			return l.EntityID, nil
+		}, "license-", domain.ErrLicenseNotFound},
+		{"obligation", func(id string) (string, error) {
+			o, err := svc.GetObligation(ctx, ownEntity, id)
+			if err != nil {
+				return "", err
+			}
+			return o.EntityID, nil
+		}, "obligation-", domain.ErrObligationNotFound},
+		{"violation", func(id string) (string, error) {
+			v, err := svc.GetViolation(ctx, ownEntity, id)
+			if err != nil {
+				return "", err
+			}
+			return v.EntityID, nil
+		}, "violation-", domain.ErrViolationNotFound},
+		{"trade report", func(id string) (string, error) {
+			r, err := svc.GetTradeReport(ctx, ownEntity, id)
+			if err != nil {
+				return "", err
+			}
+			return r.EntityID, nil
+		}, "report-", domain.ErrTradeReportNotFound},
+		{"appeal", func(id string) (string, error) {
+			a, err := svc.GetAppeal(ctx, ownEntity, id)
+			if err != nil {
+				return "", err
+			}
+			return a.EntityID, nil
+		}, "appeal-", domain.ErrAppealNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, err := tt.get(tt.id + ownEntity)
+			if err != nil || owner != ownEntity {
+				t.Fatalf("own record: got owner %q, err %v", owner, err)
+			}
+
+			if _, err := tt.get(tt.id + otherEntity); !errors.Is(err, tt.want) {
+				t.Errorf("other entity's record: got err %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPortalService_ListsAreScopedToCaller(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestPortalService(newPortalStore())
+
+	// A filter naming another entity must not widen the scope
+	licenses, _, err := svc.ListLicenses(ctx, ownEntity, port.LicenseFilter{EntityID: otherEntity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, license := range licenses {
+		if license.EntityID != ownEntity {
+			t.Errorf("listed license %s of %s", license.ID, license.EntityID)
+		}
+	}
+
+	obligations, err := svc.ListObligations(ctx, ownEntity, port.ObligationFilter{EntityID: otherEntity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, obligation := range obligations {
+		if obligation.EntityID != ownEntity {
+			t.Errorf("listed obligation %s of %s", obligation.ID, obligation.EntityID)
+		}
+	}
+
+	violations, err := svc.GetOpenViolations(ctx, ownEntity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, violation := range violations {
+		if violation.EntityID != ownEntity {
+			t.Errorf("listed violation %s of %s", violation.ID, violation.EntityID)
+		}
+	}
+
+	reports, err := svc.ListTradeReports(ctx, ownEntity, port.TradeReportFilter{EntityID: otherEntity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, report := range reports {
+		if report.EntityID != ownEntity {
+			t.Errorf("listed trade report %s of %s", report.ID, report.EntityID)
+		}
+	}
+
+	appeals, err := svc.ListAppeals(ctx, ownEntity, port.AppealFilter{EntityID: otherEntity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, appeal := range appeals {
+		if appeal.EntityID != ownEntity {
+			t.Errorf("listed appeal %s of %s", appeal.ID, appeal.EntityID)
+		}
+	}
+
+	if len(licenses) != 1 || len(obligations) != 1 || len(violations) != 1 || len(reports) != 1 || len(appeals) != 1 {
+		t.Errorf("got %d licenses, %d obligations, %d violations, %d reports, %d appeals; want one of each",
+			len(licenses), len(obligations), len(violations), len(reports), len(appeals))
+	}
+}
+
+func TestPortalService_RequiresEntity(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestPortalService(newPortalStore())
+
+	if _, err := svc.GetEntity(ctx, ""); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("GetEntity: got %v, want ErrUnauthorized", err)
+	}
+	if _, _, err := svc.ListLicenses(ctx, "", port.LicenseFilter{}); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("ListLicenses: got %v, want ErrUnauthorized", err)
+	}
+	if _, err := svc.ListObligations(ctx, "", port.ObligationFilter{}); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("ListObligations: got %v, want ErrUnauthorized", err)
+	}
+	if _, err := svc.GetOpenViolations(ctx, ""); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("GetOpenViolations: got %v, want ErrUnauthorized", err)
+	}
+	if _, err := svc.ListTradeReports(ctx, "", port.TradeReportFilter{}); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("ListTradeReports: got %v, want ErrUnauthorized", err)
+	}
+	if err := svc.SubmitTradeReport(ctx, "", &domain.TradeReport{}, "user"); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("SubmitTradeReport: got %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestPortalService_SubmitObligationOfOtherEntity(t *testing.T) {
+	ctx := context.Background()
+	store := newPortalStore()
+	svc := newTestPortalService(store)
+	attachments := []domain.Attachment{{ID: "doc-1", FileName: "q3-report.pdf"}}
+
+	_, err := svc.SubmitObligation(ctx, ownEntity, "obligation-"+otherEntity, attachments, "user")
+	if !errors.Is(err, domain.ErrObligationNotFound) {
+		t.Fatalf("got %v, want ErrObligationNotFound", err)
+	}
+	if len(store.submitted) != 0 {
+		t.Fatalf("other entity's obligation was submitted: %v", store.submitted)
+	}
+
+	if _, err := svc.SubmitObligation(ctx, ownEntity, "obligation-"+ownEntity, attachments, "user"); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.submitted) != 1 || store.submitted[0] != "obligation-"+ownEntity {
+		t.Errorf("submitted %v, want the caller's obligation", store.submitted)
+	}
+}
+
+func TestPortalService_SubmitTradeReportForCaller(t *testing.T) {
+	ctx := context.Background()
+	store := newPortalStore()
+	svc := newTestPortalService(store)
+
+	err := svc.SubmitTradeReport(ctx, ownEntity, &domain.TradeReport{EntityID: otherEntity}, "user")
+	if !errors.Is(err, domain.ErrOutsideEntityScope) {
+		t.Fatalf("report naming another entity: got %v, want ErrOutsideEntityScope", err)
+	}
+	if len(store.filed) != 0 {
+		t.Fatalf("report for another entity was filed")
+	}
+
+	report := &domain.TradeReport{}
+	if err := svc.SubmitTradeReport(ctx, ownEntity, report, "user"); err != nil {
+		t.Fatal(err)
+	}
+	if report.EntityID != ownEntity {
+		t.Errorf("report filed for %q, want %q", report.EntityID, ownEntity)
+	}
+}
+
+func TestPortalService_AppealsForCaller(t *testing.T) {
+	ctx := context.Background()
+	store := newPortalStore()
+	svc := newTestPortalService(store)
+
+	err := svc.FileAppeal(ctx, ownEntity, &domain.Appeal{EntityID: otherEntity, FreezeOrderID: "order-1"}, "user")
+	if !errors.Is(err, domain.ErrOutsideEntityScope) {
+		t.Fatalf("appeal naming another entity: got %v, want ErrOutsideEntityScope", err)
+	}
+	if len(store.appealed) != 0 {
+		t.Fatalf("appeal for another entity was filed")
+	}
+
+	appeal := &domain.Appeal{FreezeOrderID: "order-1"}
+	if err := svc.FileAppeal(ctx, ownEntity, appeal, "user"); err != nil {
+		t.Fatal(err)
+	}
+	if appeal.EntityID != ownEntity {
+		t.Errorf("appeal filed for %q, want %q", appeal.EntityID, ownEntity)
+	}
+
+	documents := []domain.Attachment{{ID: "doc-1"}}
+	if _, err := svc.AddAppealDocuments(ctx, ownEntity, "appeal-"+otherEntity, documents, "user"); !errors.Is(err, domain.ErrAppealNotFound) {
+		t.Errorf("documents for another entity's appeal: got %v, want ErrAppealNotFound", err)
+	}
+	if len(store.submitted) != 0 {
+		t.Errorf("documents were added to another entity's appeal: %v", store.submitted)
+	}
+}
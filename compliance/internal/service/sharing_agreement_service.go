@@ -0,0 +1,152 @@
+// Compliance Management Module - Sharing Agreement Service
+// Cross-jurisdiction sharing of a regulated entity's records for a case
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/tenancy"
+)
+
+// SharingAgreementService handles cross-jurisdiction sharing agreements. Only
+// the jurisdiction owning an entity may share its records or revoke a share;
+// both the owner and the grantee see the agreement.
+type SharingAgreementService struct {
+	repo       port.SharingAgreementRepository
+	entityRepo port.EntityRepository
+	audit      port.AuditLogPort
+	guard      *JurisdictionGuard
+}
+
+// NewSharingAgreementService creates a new sharing agreement service
+func NewSharingAgreementService(
+	repo port.SharingAgreementRepository,
+	entityRepo port.EntityRepository,
+	audit port.AuditLogPort,
+	guard *JurisdictionGuard,
+) *SharingAgreementService {
+	return &SharingAgreementService{
+		repo:       repo,
+		entityRepo: entityRepo,
+		audit:      audit,
+		guard:      guard,
+	}
+}
+
+// CreateSharingAgreement shares an entity's records with the regulators of
+// another jurisdiction for the agreement's case and period
+func (s *SharingAgreementService) CreateSharingAgreement(ctx context.Context, agreement *domain.SharingAgreement, actorID string) error {
+	entity, err := s.entityRepo.GetByID(ctx, agreement.EntityID)
+	if err != nil {
+		return err
+	}
+	if err := s.guard.canWrite(ctx, entity.Jurisdiction, entity.ID, domain.ErrEntityNotFound); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	agreement.ID = ""
+	agreement.OwnerJurisdiction = entity.Jurisdiction
+	agreement.GranteeJurisdiction = tenancy.Normalize(agreement.GranteeJurisdiction)
+	agreement.Status = domain.SharingAgreementStatusActive
+	if agreement.ValidFrom.IsZero() {
+		agreement.ValidFrom = now
+	}
+	agreement.CreatedBy = actorID
+	agreement.CreatedAt = now
+	agreement.RevokedBy = ""
+	agreement.RevokedAt = nil
+	agreement.RevocationReason = ""
+	if err := agreement.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateSharingAgreement(ctx, agreement); err != nil {
+		return fmt.Errorf("failed to create sharing agreement: %w", err)
+	}
+
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "SHARING_AGREEMENT_CREATED",
+		ResourceType: "SHARING_AGREEMENT",
+		ResourceID:   agreement.ID,
+		EntityID:     entity.ID,
+		Description: fmt.Sprintf("Shared records of %s with %s for case %s until %s",
+			entity.Name, agreement.GranteeJurisdiction, agreement.CaseReference,
+			agreement.ValidUntil.Format(time.RFC3339)),
+		Result: "SUCCESS",
+		Metadata: map[string]interface{}{
+			"owner_jurisdiction":   agreement.OwnerJurisdiction,
+			"grantee_jurisdiction": agreement.GranteeJurisdiction,
+			"purpose":              agreement.Purpose,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return nil
+}
+
+// GetSharingAgreement retrieves a sharing agreement the caller's
+// jurisdictions own or receive
+func (s *SharingAgreementService) GetSharingAgreement(ctx context.Context, id string) (*domain.SharingAgreement, error) {
+	agreement, err := s.repo.GetSharingAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.guard.inScope(ctx, agreement.OwnerJurisdiction, agreement.GranteeJurisdiction) {
+		return nil, domain.ErrSharingAgreementNotFound
+	}
+	return agreement, nil
+}
+
+// ListSharingAgreements lists the sharing agreements the caller's
+// jurisdictions own or receive
+func (s *SharingAgreementService) ListSharingAgreements(ctx context.Context, filter port.SharingAgreementFilter) ([]*domain.SharingAgreement, error) {
+	jurisdictions, err := s.guard.listScope(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	filter.Jurisdictions = jurisdictions
+	return s.repo.ListSharingAgreements(ctx, filter)
+}
+
+// RevokeSharingAgreement ends a sharing agreement before its expiry
+func (s *SharingAgreementService) RevokeSharingAgreement(ctx context.Context, id, reason, actorID string) (*domain.SharingAgreement, error) {
+	agreement, err := s.GetSharingAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canAssign(ctx, agreement.OwnerJurisdiction); err != nil {
+		return nil, err
+	}
+
+	if err := agreement.Revoke(reason, actorID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSharingAgreement(ctx, agreement); err != nil {
+		return nil, fmt.Errorf("failed to revoke sharing agreement: %w", err)
+	}
+
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "SHARING_AGREEMENT_REVOKED",
+		ResourceType: "SHARING_AGREEMENT",
+		ResourceID:   agreement.ID,
+		EntityID:     agreement.EntityID,
+		Description: fmt.Sprintf("Revoked sharing of case %s with %s - Reason: %s",
+			agreement.CaseReference, agreement.GranteeJurisdiction, reason),
+		Result: "SUCCESS",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return agreement, nil
+}
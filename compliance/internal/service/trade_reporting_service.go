@@ -0,0 +1,339 @@
+// Compliance Management Module - Trade Reporting Service
+// Daily OTC desk and P2P platform trade reports and on-chain reconciliation
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/compliance/internal/domain"
+	"github.com/csic-platform/compliance/internal/port"
+)
+
+// tradeReconciliationActorID identifies the reconciliation job in audit entries
+const tradeReconciliationActorID = "SYSTEM:TRADE_RECONCILIATION_JOB"
+
+// DefaultReconciliationTolerance is the share of gross reported volume the
+// reported and on-chain net flows may differ by
+const DefaultReconciliationTolerance = 0.02
+
+// reconciliationLookbackDays bounds how far back unreconciled reports are picked up
+const reconciliationLookbackDays = 7
+
+// TradeReportingService handles OTC desk and P2P platform trade reports
+type TradeReportingService struct {
+	repo       port.TradeReportRepository
+	entityRepo port.EntityRepository
+	flows      port.OnChainFlowPort
+	audit      port.AuditLogPort
+	guard      *JurisdictionGuard
+	tolerance  float64
+}
+
+// NewTradeReportingService creates a new trade reporting service. Submitted
+// reports for closed days are reconciled by calling ReconcilePending on a
+// schedule.
+func NewTradeReportingService(
+	repo port.TradeReportRepository,
+	entityRepo port.EntityRepository,
+	flows port.OnChainFlowPort,
+	audit port.AuditLogPort,
+	guard *JurisdictionGuard,
+) *TradeReportingService {
+	return &TradeReportingService{
+		repo:       repo,
+		entityRepo: entityRepo,
+		flows:      flows,
+		audit:      audit,
+		guard:      guard,
+		tolerance:  DefaultReconciliationTolerance,
+	}
+}
+
+// SubmitTradeReport files a daily trade report for an active OTC desk or P2P platform
+func (s *TradeReportingService) SubmitTradeReport(ctx context.Context, report *domain.TradeReport, actorID string) error {
+	report.Normalize()
+	if err := report.Validate(); err != nil {
+		return err
+	}
+
+	entity, err := s.entityRepo.GetByID(ctx, report.EntityID)
+	if err != nil {
+		return err
+	}
+	if err := s.guard.canWrite(ctx, entity.Jurisdiction, entity.ID, domain.ErrEntityNotFound); err != nil {
+		return err
+	}
+	if !entity.ReportsTrades() {
+		return domain.ErrNotTradeReporter
+	}
+	if !entity.IsOperational() {
+		return domain.ErrEntityInactive
+	}
+
+	now := time.Now()
+	report.ID = ""
+	report.EntityType = entity.Type
+	report.Jurisdiction = entity.Jurisdiction
+	report.Status = domain.TradeReportStatusSubmitted
+	report.Reconciliation = nil
+	report.SubmittedBy = actorID
+	report.CreatedAt = now
+	report.UpdatedAt = now
+
+	if err := s.repo.CreateTradeReport(ctx, report); err != nil {
+		if errors.Is(err, domain.ErrDuplicateTradeReport) {
+			return err
+		}
+		return fmt.Errorf("failed to create trade report: %w", err)
+	}
+
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "TRADE_REPORT_SUBMITTED",
+		ResourceType: "TRADE_REPORT",
+		ResourceID:   report.ID,
+		EntityID:     report.EntityID,
+		Description: fmt.Sprintf("%s filed %s trade report for %s on %s: %d trades",
+			entity.Name, report.Asset, report.TradeDate.Format("2006-01-02"), report.Chain, report.TradeCount),
+		Result: "SUCCESS",
+	}); err != nil {
+		return fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return nil
+}
+
+// GetTradeReport retrieves a trade report by ID
+func (s *TradeReportingService) GetTradeReport(ctx context.Context, id string) (*domain.TradeReport, error) {
+	report, err := s.repo.GetTradeReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canRead(ctx, report.Jurisdiction, report.EntityID, domain.ErrTradeReportNotFound); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ListTradeReports lists trade reports matching the filter, within the
+// caller's jurisdictions
+func (s *TradeReportingService) ListTradeReports(ctx context.Context, filter port.TradeReportFilter) ([]*domain.TradeReport, error) {
+	jurisdictions, err := s.guard.listScope(ctx, filter.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	filter.Jurisdictions = jurisdictions
+	return s.repo.ListTradeReports(ctx, filter)
+}
+
+// ReconcileTradeReport compares a report with the on-chain flows of the
+// reporter's registered addresses on the report's chain over the trade date
+func (s *TradeReportingService) ReconcileTradeReport(ctx context.Context, id, actorID string) (*domain.TradeReport, error) {
+	report, err := s.repo.GetTradeReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.canWrite(ctx, report.Jurisdiction, report.EntityID, domain.ErrTradeReportNotFound); err != nil {
+		return nil, err
+	}
+	if err := s.reconcile(ctx, report, actorID); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// reconcile sums the on-chain flows for the report, stores the result and audits it
+func (s *TradeReportingService) reconcile(ctx context.Context, report *domain.TradeReport, actorID string) error {
+	entity, err := s.entityRepo.GetByID(ctx, report.EntityID)
+	if err != nil {
+		return err
+	}
+
+	start := report.TradeDate
+	end := start.AddDate(0, 0, 1)
+
+	var addresses []string
+	var inflow, outflow float64
+	for _, addr := range entity.BlockchainAddresses {
+		if !strings.EqualFold(addr.Chain, report.Chain) {
+			continue
+		}
+		flows, err := s.flows.GetAddressFlows(ctx, report.Chain, addr.Address, report.Asset, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to load on-chain flows for %s: %w", addr.Address, err)
+		}
+		addresses = append(addresses, addr.Address)
+		inflow += flows.Inflow
+		outflow += flows.Outflow
+	}
+
+	report.Reconcile(addresses, inflow, outflow, s.tolerance)
+
+	if err := s.repo.UpdateTradeReportReconciliation(ctx, report); err != nil {
+		return fmt.Errorf("failed to store reconciliation: %w", err)
+	}
+
+	result := "SUCCESS"
+	if report.Status == domain.TradeReportStatusDiscrepancy {
+		result = "DISCREPANCY"
+	}
+	if err := s.audit.Log(ctx, &port.AuditEntry{
+		Timestamp:    time.Now(),
+		ActorID:      actorID,
+		Action:       "TRADE_REPORT_RECONCILED",
+		ResourceType: "TRADE_REPORT",
+		ResourceID:   report.ID,
+		EntityID:     report.EntityID,
+		Description: fmt.Sprintf("Reconciled %s trade report for %s: reported net %g, on-chain net %g",
+			report.Asset, report.TradeDate.Format("2006-01-02"),
+			report.Reconciliation.ReportedNet, report.Reconciliation.OnChainNet),
+		Result: result,
+		Metadata: map[string]interface{}{
+			"status":     report.Status,
+			"difference": report.Reconciliation.Difference,
+			"addresses":  addresses,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to audit log: %w", err)
+	}
+
+	return nil
+}
+
+// ReconcilePending reconciles submitted reports for days that have closed.
+// Reports for the current day are left until its on-chain activity is complete.
+func (s *TradeReportingService) ReconcilePending(ctx context.Context) error {
+	today := startOfDay(time.Now())
+	from := today.AddDate(0, 0, -reconciliationLookbackDays)
+	to := today.AddDate(0, 0, -1)
+
+	reports, err := s.repo.ListTradeReports(ctx, port.TradeReportFilter{
+		Status: []domain.TradeReportStatus{domain.TradeReportStatusSubmitted},
+		From:   &from,
+		To:     &to,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load pending trade reports: %w", err)
+	}
+
+	var errs []error
+	for _, report := range reports {
+		if err := s.reconcile(ctx, report, tradeReconciliationActorID); err != nil {
+			errs = append(errs, fmt.Errorf("trade report %s: %w", report.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetDailyRegulatoryReport builds the daily regulatory report for a date,
+// covering the caller's jurisdictions. Its OTC and P2P trading section
+// aggregates every trade report filed for the day and lists the active OTC
+// desks and P2P platforms that have not filed.
+func (s *TradeReportingService) GetDailyRegulatoryReport(ctx context.Context, date time.Time) (*domain.DailyRegulatoryReport, error) {
+	day := startOfDay(date)
+
+	jurisdictions, err := s.guard.listScope(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.repo.ListTradeReports(ctx, port.TradeReportFilter{
+		Jurisdictions: jurisdictions,
+		From:          &day,
+		To:            &day,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trade reports: %w", err)
+	}
+
+	reporters, err := s.entityRepo.List(ctx, port.EntityFilter{
+		Type:          []domain.EntityType{domain.EntityTypeOTCDesk, domain.EntityTypeP2PPlatform},
+		Status:        []domain.EntityStatus{domain.EntityStatusActive},
+		Jurisdictions: jurisdictions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trade reporters: %w", err)
+	}
+
+	return &domain.DailyRegulatoryReport{
+		ReportDate:    day,
+		GeneratedAt:   time.Now(),
+		Jurisdictions: jurisdictions,
+		OTCTrading:    SummarizeTrades(reports, reporters),
+	}, nil
+}
+
+// SummarizeTrades aggregates a day's trade reports. Reporters without any
+// report for the day are listed as missing.
+func SummarizeTrades(reports []*domain.TradeReport, reporters []*domain.RegulatedEntity) *domain.DailyTradeSummary {
+	summary := &domain.DailyTradeSummary{
+		Reports:        len(reports),
+		ByAsset:        make(map[string]*domain.AssetVolume),
+		ByCountry:      []domain.CounterpartyCountry{},
+		ByStatus:       make(map[domain.TradeReportStatus]int),
+		Discrepancies:  []*domain.TradeReport{},
+		MissingReports: []string{},
+	}
+
+	filed := make(map[string]bool)
+	countries := make(map[string]*domain.CounterpartyCountry)
+	for _, report := range reports {
+		filed[report.EntityID] = true
+		summary.TradeCount += report.TradeCount
+		summary.ByStatus[report.Status]++
+		if report.Status == domain.TradeReportStatusDiscrepancy {
+			summary.Discrepancies = append(summary.Discrepancies, report)
+		}
+
+		asset, ok := summary.ByAsset[report.Asset]
+		if !ok {
+			asset = &domain.AssetVolume{}
+			summary.ByAsset[report.Asset] = asset
+		}
+		asset.BuyVolume += report.BuyVolume
+		asset.SellVolume += report.SellVolume
+		asset.NotionalValue = asset.NotionalValue.Add(report.NotionalValue)
+		asset.TradeCount += report.TradeCount
+
+		for _, cp := range report.Counterparties {
+			country, ok := countries[cp.Country]
+			if !ok {
+				country = &domain.CounterpartyCountry{Country: cp.Country}
+				countries[cp.Country] = country
+			}
+			country.Counterparts += cp.Counterparts
+			country.TradeCount += cp.TradeCount
+			country.Volume += cp.Volume
+		}
+	}
+	summary.Reporters = len(filed)
+
+	for _, country := range countries {
+		summary.ByCountry = append(summary.ByCountry, *country)
+	}
+	sort.Slice(summary.ByCountry, func(i, j int) bool {
+		return summary.ByCountry[i].Volume > summary.ByCountry[j].Volume
+	})
+
+	for _, entity := range reporters {
+		if !filed[entity.ID] {
+			summary.MissingReports = append(summary.MissingReports, entity.ID)
+		}
+	}
+	sort.Strings(summary.MissingReports)
+
+	return summary
+}
+
+// startOfDay truncates t to midnight UTC
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
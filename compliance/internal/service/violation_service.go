@@ -6,10 +6,23 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/csic-platform/compliance/internal/domain"
 	"github.com/csic-platform/compliance/internal/port"
+	"github.com/csic-platform/shared/query"
+)
+
+// ViolationEventsTopic is the Kafka topic violation lifecycle events are published to
+const ViolationEventsTopic = "csic.compliance_violations"
+
+// Violation lifecycle event types
+const (
+	ViolationEventDetected  = "VIOLATION_DETECTED"
+	ViolationEventConfirmed = "VIOLATION_CONFIRMED"
+	ViolationEventResolved  = "VIOLATION_RESOLVED"
+	ViolationEventClosed    = "VIOLATION_CLOSED"
 )
 
 // ViolationService handles compliance violation operations
@@ -18,6 +31,8 @@ type ViolationService struct {
 	penaltyRepo   port.PenaltyRepository
 	entityRepo    port.EntityRepository
 	audit         port.AuditLogPort
+	tx            port.TxManager
+	outbox        port.OutboxRepository
 }
 
 // NewViolationService creates a new violation service
@@ -26,13 +41,71 @@ func NewViolationService(
 	penaltyRepo port.PenaltyRepository,
 	entityRepo port.EntityRepository,
 	audit port.AuditLogPort,
+	tx port.TxManager,
+	outbox port.OutboxRepository,
 ) *ViolationService {
 	return &ViolationService{
 		violationRepo: violationRepo,
 		penaltyRepo:   penaltyRepo,
 		entityRepo:    entityRepo,
 		audit:         audit,
+		tx:            tx,
+		outbox:        outbox,
+	}
+}
+
+// saveWithEvent runs save and enqueues the matching lifecycle event in one
+// transaction, so the event is published if and only if the change is committed
+func (s *ViolationService) saveWithEvent(ctx context.Context, violation *domain.ComplianceViolation, eventType string, save func(ctx context.Context) error) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := save(ctx); err != nil {
+			return err
+		}
+
+		event, err := s.newViolationEvent(ctx, violation, eventType)
+		if err != nil {
+			return err
+		}
+		return s.outbox.Enqueue(ctx, event)
+	})
+}
+
+// newViolationEvent builds the outbox event describing a violation change
+func (s *ViolationService) newViolationEvent(ctx context.Context, violation *domain.ComplianceViolation, eventType string) (*domain.OutboxEvent, error) {
+	payload := map[string]interface{}{
+		"event_type":       eventType,
+		"violation_id":     violation.ID,
+		"violation_number": violation.ViolationNumber,
+		"violation_type":   violation.Type,
+		"severity":         violation.Severity,
+		"status":           violation.Status,
+		"title":            violation.Title,
+		"description":      violation.Description,
+		"entity_id":        violation.EntityID,
+		"entity_name":      violation.EntityName,
+		"license_id":       violation.LicenseID,
+		"detection_source": violation.DetectionSource,
+		"regulatory_ref":   violation.RegulatoryRef,
+		"detected_at":      violation.DetectionDate.UTC().Format(time.RFC3339),
+		"metadata":         violation.Metadata,
+	}
+	if addresses, ok := violation.Metadata["wallet_addresses"]; ok {
+		payload["wallet_addresses"] = addresses
+	}
+	var jurisdiction string
+	if entity, err := s.entityRepo.GetByID(ctx, violation.EntityID); err == nil && entity != nil {
+		payload["entity_type"] = entity.Type
+		payload["jurisdiction"] = entity.Jurisdiction
+		jurisdiction = entity.Jurisdiction
 	}
+
+	dedupKey := fmt.Sprintf("violation:%s:%s:%s", violation.ID, eventType, strconv.FormatInt(violation.UpdatedAt.UnixNano(), 10))
+	event, err := domain.NewOutboxEvent(ViolationEventsTopic, "VIOLATION", violation.ID, eventType, dedupKey, payload)
+	if err != nil {
+		return nil, err
+	}
+	event.Jurisdiction = jurisdiction
+	return event, nil
 }
 
 // CreateViolation creates a new violation record
@@ -47,7 +120,9 @@ func (s *ViolationService) CreateViolation(ctx context.Context, violation *domai
 	violation.CreatedAt = time.Now()
 	violation.UpdatedAt = time.Now()
 
-	if err := s.violationRepo.Create(ctx, violation); err != nil {
+	if err := s.saveWithEvent(ctx, violation, ViolationEventDetected, func(ctx context.Context) error {
+		return s.violationRepo.Create(ctx, violation)
+	}); err != nil {
 		return fmt.Errorf("failed to create violation: %w", err)
 	}
 
@@ -79,7 +154,7 @@ func (s *ViolationService) GetViolation(ctx context.Context, violationID string)
 }
 
 // ListViolations lists violations with filters
-func (s *ViolationService) ListViolations(ctx context.Context, filter port.ViolationFilter) ([]*domain.ComplianceViolation, error) {
+func (s *ViolationService) ListViolations(ctx context.Context, filter port.ViolationFilter) ([]*domain.ComplianceViolation, *query.PageInfo, error) {
 	return s.violationRepo.List(ctx, filter)
 }
 
@@ -155,7 +230,9 @@ func (s *ViolationService) ConfirmViolation(ctx context.Context, violationID, ac
 
 	violation.UpdatedAt = time.Now()
 
-	if err := s.violationRepo.Update(ctx, violation); err != nil {
+	if err := s.saveWithEvent(ctx, violation, ViolationEventConfirmed, func(ctx context.Context) error {
+		return s.violationRepo.Update(ctx, violation)
+	}); err != nil {
 		return fmt.Errorf("failed to confirm violation: %w", err)
 	}
 
@@ -209,7 +286,7 @@ func (s *ViolationService) IssuePenalty(ctx context.Context, violationID string,
 		ResourceType:  "PENALTY",
 		ResourceID:    penalty.ID,
 		EntityID:      violation.EntityID,
-		Description:   fmt.Sprintf("Issued penalty %s for violation %s (Amount: %s %.2f)", penalty.PenaltyNumber, violation.ViolationNumber, penalty.Currency, penalty.Amount),
+		Description:   fmt.Sprintf("Issued penalty %s for violation %s (Amount: %s %s)", penalty.PenaltyNumber, violation.ViolationNumber, penalty.Currency, penalty.Amount.StringFixed(2)),
 		Result:        "SUCCESS",
 		Metadata: map[string]interface{}{
 			"penalty_number": penalty.PenaltyNumber,
@@ -239,7 +316,9 @@ func (s *ViolationService) ResolveViolation(ctx context.Context, violationID, co
 
 	violation.UpdatedAt = time.Now()
 
-	if err := s.violationRepo.Update(ctx, violation); err != nil {
+	if err := s.saveWithEvent(ctx, violation, ViolationEventResolved, func(ctx context.Context) error {
+		return s.violationRepo.Update(ctx, violation)
+	}); err != nil {
 		return fmt.Errorf("failed to resolve violation: %w", err)
 	}
 
@@ -273,7 +352,9 @@ func (s *ViolationService) CloseViolation(ctx context.Context, violationID, acto
 
 	violation.UpdatedAt = time.Now()
 
-	if err := s.violationRepo.Update(ctx, violation); err != nil {
+	if err := s.saveWithEvent(ctx, violation, ViolationEventClosed, func(ctx context.Context) error {
+		return s.violationRepo.Update(ctx, violation)
+	}); err != nil {
 		return fmt.Errorf("failed to close violation: %w", err)
 	}
 
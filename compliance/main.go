@@ -6,28 +6,59 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/csic-platform/compliance/internal/domain"
 	"github.com/csic-platform/compliance/internal/handler"
+	"github.com/csic-platform/compliance/internal/metrics"
 	"github.com/csic-platform/compliance/internal/port"
 	"github.com/csic-platform/compliance/internal/repository"
 	"github.com/csic-platform/compliance/internal/service"
 	"github.com/csic-platform/shared/config"
+	"github.com/csic-platform/shared/correlation"
+	"github.com/csic-platform/shared/database"
+	"github.com/csic-platform/shared/lifecycle"
 	"github.com/csic-platform/shared/logger"
+	"github.com/csic-platform/shared/openapi"
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/queue"
+	"github.com/csic-platform/shared/scheduler"
+	"github.com/csic-platform/shared/startup"
+	"github.com/csic-platform/shared/tenancy"
+	"github.com/csic-platform/shared/validation"
+	"github.com/IBM/sarama"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Background tasks and their default schedules, which scheduler.tasks in the
+// config file can override
+const (
+	licenseExpiryTask      = "license-expiry-check"
+	licenseExpirySchedule  = "0 */6 * * *"
+	tradeReconcileTask     = "trade-reconciliation"
+	tradeReconcileSchedule = "15 * * * *"
+	defaultTaskJitter      = 2 * time.Minute
 )
 
 func main() {
 	// Parse command line flags
 	configPath := flag.String("config", "internal/config/config.yaml", "Path to configuration file")
+	txMonitoringURL := flag.String("tx-monitoring-url", "http://localhost:8080", "Transaction monitoring service base URL for on-chain reconciliation")
+	walletGovernanceURL := flag.String("wallet-governance-url", "http://localhost:8080", "Wallet governance service base URL for the freeze orders under appeal")
+	appealReviewPeriod := flag.Duration("appeal-review-period", service.DefaultAppealReviewPeriod, "Time reviewers have to decide an appeal against a freeze order")
 	flag.Parse()
 
 	// Load configuration
@@ -37,6 +68,7 @@ func main() {
 		fmt.Printf("Warning: Failed to load config file, using defaults: %v\n", err)
 		cfg = getDefaultConfig()
 	}
+	defer configLoader.Close()
 
 	// Initialize logger
 	logConfig := logger.Config{
@@ -55,28 +87,143 @@ func main() {
 	}
 	defer appLogger.Close()
 
-	// Initialize database connection
-	db, err := initDatabase(cfg.Database)
-	if err != nil {
-		appLogger.Fatal("failed to connect to database", logger.WithFields(logger.Error(err)))
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Shutdown stops intake first, then drains in-flight requests and
+	// scheduled tasks, then flushes the outbox, all within the shutdown timeout
+	shutdown := lifecycle.New(func(step lifecycle.Step) {
+		appLogger.Error("shutdown step incomplete", logger.WithFields(
+			logger.String("phase", step.Phase),
+			logger.String("step", step.Name),
+			logger.Error(step.Err),
+		))
+	})
+
+	// Connect dependencies, retrying with backoff so a rolling deploy survives
+	// a briefly unavailable database. Kafka is optional: until it connects,
+	// events stay queued in the outbox and the service reports itself degraded.
+	dependencies := startup.New(startup.Options{
+		InitialBackoff: cfg.Startup.GetInitialBackoff(),
+		MaxBackoff:     cfg.Startup.GetMaxBackoff(),
+		MaxWait:        cfg.Startup.GetMaxWait(),
+		CheckTimeout:   cfg.Startup.GetCheckTimeout(),
+	}, appLogger)
+
+	var db *sql.DB
+	var dbs *database.ReplicaRouter
+	dependencies.Add(startup.Dependency{
+		Name: "postgres",
+		Connect: func(ctx context.Context) error {
+			var err error
+			if db, err = initDatabase(ctx, cfg.Database); err != nil {
+				return err
+			}
+			dbs, err = initReplicas(db, cfg.Database)
+			return err
+		},
+		Check: func(ctx context.Context) error {
+			return db.PingContext(ctx)
+		},
+	})
+	dependencies.Add(startup.Dependency{
+		Name:     "kafka",
+		Optional: true,
+		Connect: func(ctx context.Context) error {
+			return startOutboxRelay(ctx, cfg.Kafka, dbs, appLogger, shutdown)
+		},
+	})
+
+	if err := dependencies.Start(jobCtx); err != nil {
+		appLogger.Fatal("failed to connect dependencies", logger.WithFields(logger.Error(err)))
 	}
 	defer db.Close()
+	defer dbs.Close()
+	go dbs.Start(jobCtx, cfg.Database.GetReplicaCheckInterval(), func(err error) {
+		appLogger.Warn("read replica unavailable, reading from primary", logger.WithFields(logger.Error(err)))
+	})
 
 	// Initialize repositories
-	entityRepo := repository.NewPostgresRepository(db)
-	licenseRepo := repository.NewPostgresRepository(db)
-	obligationRepo := repository.NewPostgresRepository(db)
-	violationRepo := repository.NewPostgresRepository(db)
-	penaltyRepo := repository.NewPostgresRepository(db)
+	entityRepo := repository.NewPostgresRepository(dbs)
+	licenseRepo := repository.NewPostgresRepository(dbs)
+	obligationRepo := repository.NewPostgresRepository(dbs)
+	violationRepo := repository.NewPostgresRepository(dbs)
+	penaltyRepo := repository.NewPostgresRepository(dbs)
 
 	// Initialize audit client
 	auditClient := NewAuditClient(cfg.AuditLog.ServiceURL, appLogger)
 
-	// Initialize services
-	entityService := service.NewEntityService(entityRepo, auditClient)
-	licensingService := service.NewLicensingService(licenseRepo, entityRepo, auditClient)
+	// Initialize services; requests see only the records of their caller's
+	// jurisdictions and of entities shared with them
+	sharingAgreementRepo := repository.NewPostgresRepository(dbs)
+	guard := service.NewJurisdictionGuard(sharingAgreementRepo)
+	entityService := service.NewEntityService(entityRepo, auditClient, guard)
+	outboxRepo := repository.NewPostgresRepository(dbs)
+	licensingService := service.NewLicensingService(licenseRepo, entityRepo, auditClient, metrics.NewConflictMetrics(prometheus.DefaultRegisterer), outboxRepo, outboxRepo, guard)
 	obligationService := service.NewObligationService(obligationRepo, auditClient)
-	violationService := service.NewViolationService(violationRepo, penaltyRepo, entityRepo, auditClient)
+	violationService := service.NewViolationService(violationRepo, penaltyRepo, entityRepo, auditClient, outboxRepo, outboxRepo)
+
+	// Background tasks run on cron schedules, each with jitter and at most one
+	// run at a time
+	tasks := scheduler.New("compliance-service", prometheus.DefaultRegisterer, func(task string, err error) {
+		appLogger.Error("scheduled task failed", logger.WithFields(logger.String("task", task), logger.Error(err)))
+	})
+	addTask := func(task scheduler.Task) {
+		if err := tasks.Add(cfg.Scheduler.Apply(task)); err != nil {
+			appLogger.Fatal("failed to schedule task", logger.WithFields(logger.Error(err)))
+		}
+	}
+
+	// Initialize license expiry job
+	noticeRepo := repository.NewPostgresRepository(dbs)
+	notificationClient := NewNotificationClient(appLogger)
+	enforcementClient := NewEnforcementClient(appLogger)
+	expiryJob := service.NewLicenseExpiryJob(
+		licenseRepo,
+		entityRepo,
+		noticeRepo,
+		notificationClient,
+		enforcementClient,
+		auditClient,
+	)
+	addTask(scheduler.Task{
+		Name:       licenseExpiryTask,
+		Schedule:   licenseExpirySchedule,
+		Jitter:     defaultTaskJitter,
+		RunOnStart: true,
+		Run:        expiryJob.RunOnce,
+	})
+
+	// Initialize trade reporting and its reconciliation against on-chain flows
+	tradeReportRepo := repository.NewPostgresRepository(dbs)
+	tradeReportingService := service.NewTradeReportingService(
+		tradeReportRepo,
+		entityRepo,
+		NewOnChainFlowClient(*txMonitoringURL),
+		auditClient,
+		guard,
+	)
+	addTask(scheduler.Task{
+		Name:       tradeReconcileTask,
+		Schedule:   tradeReconcileSchedule,
+		Jitter:     defaultTaskJitter,
+		RunOnStart: true,
+		Run:        tradeReportingService.ReconcilePending,
+	})
+
+	tasks.Start(jobCtx)
+
+	// Initialize appeals against the freeze orders issued by wallet governance
+	appealRepo := repository.NewPostgresRepository(dbs)
+	appealService := service.NewAppealService(
+		appealRepo,
+		entityRepo,
+		NewFreezeOrderClient(*walletGovernanceURL),
+		auditClient,
+		appealRepo,
+		guard,
+		*appealReviewPeriod,
+	)
 
 	// Initialize HTTP handler
 	complianceHandler := handler.NewComplianceHandler(
@@ -84,19 +231,56 @@ func main() {
 		licensingService,
 		obligationService,
 		violationService,
+		tradeReportingService,
+		service.NewSharingAgreementService(sharingAgreementRepo, entityRepo, auditClient, guard),
+		appealService,
 	)
-
-	// Setup Gin router
+	portalHandler := handler.NewPortalHandler(service.NewPortalService(
+		entityService,
+		licensingService,
+		obligationService,
+		violationService,
+		tradeReportingService,
+		appealService,
+	))
+
+	// Setup Gin router; failed requests are answered with problem+json
+	handler.RegisterErrors()
+	if err := validation.RegisterBindings(); err != nil {
+		appLogger.Fatal("failed to register request validators", logger.WithFields(logger.Error(err)))
+	}
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(correlation.Middleware())
+	router.Use(database.ReadYourWrites())
 	router.Use(LoggingMiddleware(appLogger))
+	router.Use(problem.Middleware(appLogger.Logger))
+
+	// OpenAPI document of the routes below. Requests to described handlers are
+	// checked against it before binding; in development responses are too.
+	spec := openapi.New(openapi.Config{
+		Title:   "Compliance Management API",
+		Version: cfg.App.Version,
+		Error:   problem.Problem{},
+		Exclude: []string{"/health", "/ready", "/metrics"},
+	})
+	complianceHandler.Describe(spec)
+	portalHandler.Describe(spec)
+	router.Use(spec.Validator(openapi.ValidatorOptions{
+		Responses: cfg.App.Environment == "development",
+		Logger:    appLogger.Logger,
+	}))
+	router.GET(openapi.Path, spec.Handler(router))
 
 	// Health check endpoints
 	router.GET("/health", complianceHandler.HealthCheck)
+	router.GET("/ready", dependencies.ReadyHandler())
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-	// API v1 routes
-	v1 := router.Group("/api/v1/compliance")
+	// API v1 routes, scoped to the jurisdictions claimed by the caller's
+	// access token
+	v1 := router.Group("/api/v1/compliance", handler.JurisdictionAuth(cfg.Security.JWT.Secret, nil))
 	{
 		// Entity management
 		entities := v1.Group("/entities")
@@ -116,6 +300,7 @@ func main() {
 			licenses.POST("", complianceHandler.CreateLicense)
 			licenses.GET("", complianceHandler.ListLicenses)
 			licenses.GET("/:id", complianceHandler.GetLicense)
+			licenses.PUT("/:id", complianceHandler.UpdateLicense)
 			licenses.POST("/:id/approve", complianceHandler.ApproveLicense)
 			licenses.POST("/:id/suspend", complianceHandler.SuspendLicense)
 			licenses.POST("/:id/revoke", complianceHandler.RevokeLicense)
@@ -140,8 +325,44 @@ func main() {
 			violations.POST("/:id/penalty", complianceHandler.IssuePenalty)
 			violations.POST("/:id/resolve", complianceHandler.ResolveViolation)
 		}
+
+		// OTC desk and P2P platform trade reporting
+		tradeReports := v1.Group("/trade-reports")
+		{
+			tradeReports.POST("", complianceHandler.SubmitTradeReport)
+			tradeReports.GET("", complianceHandler.ListTradeReports)
+			tradeReports.GET("/:id", complianceHandler.GetTradeReport)
+			tradeReports.POST("/:id/reconcile", complianceHandler.ReconcileTradeReport)
+		}
+
+		// Regulatory reports
+		v1.GET("/reports/daily", complianceHandler.GetDailyRegulatoryReport)
+
+		// Cross-jurisdiction sharing agreements
+		sharingAgreements := v1.Group("/sharing-agreements")
+		{
+			sharingAgreements.POST("", complianceHandler.CreateSharingAgreement)
+			sharingAgreements.GET("", complianceHandler.ListSharingAgreements)
+			sharingAgreements.GET("/:id", complianceHandler.GetSharingAgreement)
+			sharingAgreements.POST("/:id/revoke", complianceHandler.RevokeSharingAgreement)
+		}
+
+		// Appeals against freeze orders
+		appeals := v1.Group("/appeals")
+		{
+			appeals.GET("", complianceHandler.ListAppeals)
+			appeals.GET("/:id", complianceHandler.GetAppeal)
+			appeals.POST("/:id/determinations", complianceHandler.RecordAppealDetermination)
+		}
+
+		// Background task runtime: status, pause/resume and manual runs
+		tasks.Routes(v1.Group("/scheduler/tasks"))
 	}
 
+	// Self-service portal for regulated entities. Requests are scoped to the
+	// entity named by the entity_id claim of the caller's access token.
+	portalHandler.Routes(router.Group("/api/v1/portal", handler.PortalAuth(cfg.Security.JWT.Secret, nil)))
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
@@ -161,6 +382,11 @@ func main() {
 		}
 	}()
 
+	shutdown.OnShutdown(lifecycle.PhaseIntake, "http", srv.Shutdown)
+	shutdown.OnShutdown(lifecycle.PhaseIntake, "jobs", lifecycle.Func(stopJobs))
+	shutdown.OnShutdown(lifecycle.PhaseDrain, "dependency-retries", lifecycle.Func(dependencies.Wait))
+	shutdown.OnShutdown(lifecycle.PhaseDrain, "scheduled-tasks", lifecycle.Func(tasks.Wait))
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -172,15 +398,13 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		appLogger.Error("server forced shutdown", logger.WithFields(logger.Error(err)))
-	}
+	report := shutdown.Shutdown(ctx)
 
-	appLogger.Info("compliance service exited")
+	appLogger.Info("compliance service exited", logger.WithFields(logger.String("duration", report.Duration.String())))
 }
 
 // initDatabase initializes the database connection
-func initDatabase(cfg config.DatabaseConfig) (*sql.DB, error) {
+func initDatabase(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.Name, cfg.SSLMode,
@@ -191,7 +415,8 @@ func initDatabase(cfg config.DatabaseConfig) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
@@ -202,6 +427,59 @@ func initDatabase(cfg config.DatabaseConfig) (*sql.DB, error) {
 	return db, nil
 }
 
+// initReplicas opens the configured read replicas and returns the router that
+// sends listing queries to them
+func initReplicas(primary *sql.DB, cfg config.DatabaseConfig) (*database.ReplicaRouter, error) {
+	replicas, err := database.OpenReplicas("postgres", cfg.ReadReplicas)
+	if err != nil {
+		primary.Close()
+		return nil, err
+	}
+
+	for _, replica := range replicas {
+		replica.SetMaxOpenConns(cfg.MaxOpenConns)
+		replica.SetMaxIdleConns(cfg.MaxIdleConns)
+		replica.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	}
+
+	return database.NewReplicaRouter(primary, replicas, cfg.GetMaxReplicaLag()), nil
+}
+
+// startOutboxRelay connects the Kafka producer and starts relaying outbox
+// events through it. Events stay queued in the outbox while Kafka is
+// unavailable and are delivered once the relay runs. At shutdown the relay
+// flushes events written by drained requests before the producer is closed.
+func startOutboxRelay(ctx context.Context, cfg config.KafkaConfig, dbs *database.ReplicaRouter, log *logger.Logger, shutdown *lifecycle.Manager) error {
+	eventProducer, err := queue.NewProducer(queue.Config{
+		Brokers:      cfg.Brokers,
+		ClientID:     "compliance-service",
+		RetryMax:     3,
+		RetryBackoff: 100 * time.Millisecond,
+		RequiredAcks: sarama.WaitForAll,
+	}, log.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+
+	outboxRelay := service.NewOutboxRelay(repository.NewPostgresRepository(dbs), NewKafkaEventPublisher(eventProducer, cfg.TenantTopics), service.DefaultOutboxRelayConfig())
+	relayCtx, stopRelay := context.WithCancel(context.WithoutCancel(ctx))
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		outboxRelay.Start(relayCtx, func(err error) {
+			log.Error("outbox relay failed", logger.WithFields(logger.Error(err)))
+		})
+	}()
+
+	shutdown.OnShutdown(lifecycle.PhaseFlush, "outbox-relay", func(ctx context.Context) error {
+		stopRelay()
+		<-relayDone
+		defer eventProducer.Close()
+		return outboxRelay.Flush(ctx)
+	})
+	return nil
+}
+
 // AuditClient implements port.AuditLogPort for audit logging
 type AuditClient struct {
 	baseURL string
@@ -229,6 +507,267 @@ func (c *AuditClient) Log(ctx context.Context, entry *port.AuditEntry) error {
 	return nil
 }
 
+// NotificationClient implements port.NotificationPort for license holder notifications
+type NotificationClient struct {
+	logger *logger.Logger
+}
+
+// NewNotificationClient creates a new notification client
+func NewNotificationClient(log *logger.Logger) *NotificationClient {
+	return &NotificationClient{logger: log}
+}
+
+// NotifyLicenseExpiry sends a license expiry notice to the license holder
+func (c *NotificationClient) NotifyLicenseExpiry(ctx context.Context, notice *port.LicenseExpiryNotice) error {
+	c.logger.Info("license expiry notice",
+		logger.WithFields(
+			logger.String("license_id", notice.LicenseID),
+			logger.String("license_number", notice.LicenseNumber),
+			logger.String("entity_id", notice.EntityID),
+			logger.Int("threshold_days", notice.ThresholdDays),
+			logger.Int("days_remaining", notice.DaysRemaining),
+		),
+	)
+	return nil
+}
+
+// EnforcementClient implements port.EnforcementPort for freeze order requests
+type EnforcementClient struct {
+	logger *logger.Logger
+}
+
+// NewEnforcementClient creates a new enforcement client
+func NewEnforcementClient(log *logger.Logger) *EnforcementClient {
+	return &EnforcementClient{logger: log}
+}
+
+// IssueFreezeOrder requests a freeze order against a regulated entity
+func (c *EnforcementClient) IssueFreezeOrder(ctx context.Context, order *port.FreezeOrderRequest) error {
+	c.logger.Warn("freeze order requested",
+		logger.WithFields(
+			logger.String("entity_id", order.EntityID),
+			logger.String("license_id", order.LicenseID),
+			logger.String("reason", order.Reason),
+			logger.String("requested_by", order.RequestedBy),
+		),
+	)
+	return nil
+}
+
+// OnChainFlowClient implements port.OnChainFlowPort using the transaction monitoring service
+type OnChainFlowClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOnChainFlowClient creates a new on-chain flow client
+func NewOnChainFlowClient(baseURL string) *OnChainFlowClient {
+	return &OnChainFlowClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// GetAddressFlows sums the address's transfers of the asset within [start, end)
+func (c *OnChainFlowClient) GetAddressFlows(ctx context.Context, chain, address, asset string, start, end time.Time) (*port.AddressFlows, error) {
+	endpoint := fmt.Sprintf("%s/v1/wallets/%s/%s/transactions?limit=1000",
+		c.baseURL, url.PathEscape(chain), url.PathEscape(address))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("transaction monitoring returned %s", resp.Status)
+	}
+
+	var body struct {
+		Transactions []struct {
+			Sender    string          `json:"sender"`
+			Receiver  string          `json:"receiver"`
+			Amount    json.RawMessage `json:"amount"`
+			Asset     string          `json:"asset"`
+			Timestamp time.Time       `json:"timestamp"`
+		} `json:"transactions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode transactions: %w", err)
+	}
+
+	flows := &port.AddressFlows{}
+	for _, tx := range body.Transactions {
+		if !strings.EqualFold(tx.Asset, asset) || tx.Timestamp.Before(start) || !tx.Timestamp.Before(end) {
+			continue
+		}
+		amount, err := strconv.ParseFloat(strings.Trim(string(tx.Amount), `"`), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %s: %w", tx.Amount, err)
+		}
+		if strings.EqualFold(tx.Receiver, address) {
+			flows.Inflow += amount
+		}
+		if strings.EqualFold(tx.Sender, address) {
+			flows.Outflow += amount
+		}
+	}
+	return flows, nil
+}
+
+// FreezeOrderClient implements port.FreezeOrderPort using the wallet governance service
+type FreezeOrderClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewFreezeOrderClient creates a new freeze order client
+func NewFreezeOrderClient(baseURL string) *FreezeOrderClient {
+	return &FreezeOrderClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// GetFreezeOrder retrieves a freeze order and the owner and exchange of its wallet
+func (c *FreezeOrderClient) GetFreezeOrder(ctx context.Context, orderID string) (*port.FreezeOrder, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.orderURL(orderID, ""), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+		return nil, domain.ErrFreezeOrderNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wallet governance returned %s", resp.Status)
+	}
+
+	var body struct {
+		ID            string  `json:"id"`
+		WalletID      string  `json:"wallet_id"`
+		WalletAddress string  `json:"wallet_address"`
+		Status        string  `json:"status"`
+		UnderAppeal   bool    `json:"under_appeal"`
+		OwnerEntityID string  `json:"owner_entity_id"`
+		ExchangeID    *string `json:"exchange_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode freeze order: %w", err)
+	}
+
+	order := &port.FreezeOrder{
+		ID:            body.ID,
+		WalletID:      body.WalletID,
+		WalletAddress: body.WalletAddress,
+		Status:        body.Status,
+		UnderAppeal:   body.UnderAppeal,
+		OwnerEntityID: body.OwnerEntityID,
+	}
+	if body.ExchangeID != nil {
+		order.ExchangeID = *body.ExchangeID
+	}
+	return order, nil
+}
+
+// AppealFreezeOrder marks a freeze order as under appeal
+func (c *FreezeOrderClient) AppealFreezeOrder(ctx context.Context, orderID, appealID string) error {
+	return c.decide(ctx, orderID, "appeal", appealID, "", domain.ErrDuplicateAppeal)
+}
+
+// UpholdFreezeOrder records that an appeal against a freeze order was rejected
+func (c *FreezeOrderClient) UpholdFreezeOrder(ctx context.Context, orderID, appealID string) error {
+	return c.decide(ctx, orderID, "uphold", appealID, "", domain.ErrFreezeOrderNotInForce)
+}
+
+// RevokeFreezeOrder lifts a freeze order on appeal
+func (c *FreezeOrderClient) RevokeFreezeOrder(ctx context.Context, orderID, appealID, reason string) error {
+	return c.decide(ctx, orderID, "revoke", appealID, reason, domain.ErrFreezeOrderNotInForce)
+}
+
+// decide posts an appeal step to a freeze order. A conflict, meaning the
+// order's state no longer allows the step, is reported as conflict.
+func (c *FreezeOrderClient) decide(ctx context.Context, orderID, action, appealID, reason string, conflict error) error {
+	payload, err := json.Marshal(map[string]string{"appeal_id": appealID, "reason": reason})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.orderURL(orderID, action), strings.NewReader(string(payload)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		var body struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return fmt.Errorf("%w: %s", conflict, body.Error)
+	default:
+		return fmt.Errorf("wallet governance returned %s for %s of freeze order %s", resp.Status, action, orderID)
+	}
+}
+
+// orderURL returns the URL of a freeze order, or of one of its actions
+func (c *FreezeOrderClient) orderURL(orderID, action string) string {
+	endpoint := fmt.Sprintf("%s/api/v1/wallet/freeze/orders/%s", c.baseURL, url.PathEscape(orderID))
+	if action != "" {
+		endpoint += "/" + action
+	}
+	return endpoint
+}
+
+// KafkaEventPublisher implements port.EventPublisher for outbox events
+type KafkaEventPublisher struct {
+	producer     *queue.Producer
+	tenantTopics bool
+}
+
+// NewKafkaEventPublisher creates a new Kafka event publisher. With
+// tenantTopics, events of a jurisdiction go to that jurisdiction's topic.
+func NewKafkaEventPublisher(producer *queue.Producer, tenantTopics bool) *KafkaEventPublisher {
+	return &KafkaEventPublisher{producer: producer, tenantTopics: tenantTopics}
+}
+
+// Publish sends an outbox event with its dedup key so consumers can drop redeliveries
+func (p *KafkaEventPublisher) Publish(ctx context.Context, event *domain.OutboxEvent) error {
+	headers := map[string]string{
+		"event-id":   event.ID,
+		"event-type": event.EventType,
+		"dedup-key":  event.DedupKey,
+	}
+	topic := event.Topic
+	if event.Jurisdiction != "" {
+		headers["jurisdiction"] = event.Jurisdiction
+		if p.tenantTopics {
+			topic = tenancy.Topic(topic, event.Jurisdiction)
+		}
+	}
+	return p.producer.SendWithHeaders(ctx, topic, event.MessageKey, json.RawMessage(event.Payload), headers)
+}
+
 // LoggingMiddleware returns a gin middleware for logging
 func LoggingMiddleware(log *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -282,6 +821,10 @@ func getDefaultConfig() *config.Config {
 			Format:  "json",
 			Output:  "stdout",
 		},
+		Kafka: config.KafkaConfig{
+			Brokers:       []string{"localhost:9092"},
+			ConsumerGroup: "csic-compliance-service",
+		},
 		AuditLog: config.AuditLogConfig{
 			ServiceURL:    "http://localhost:8081",
 			StoragePath:   "/var/lib/csic/compliance-audit",
@@ -0,0 +1,12 @@
+-- Compliance Management Module Database Schema
+-- Migration: 001_license_expiry_notices
+
+-- License Expiry Notices Table (one row per license and notification threshold)
+CREATE TABLE IF NOT EXISTS license_expiry_notices (
+    license_id VARCHAR(64) NOT NULL,
+    threshold_days INTEGER NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (license_id, threshold_days)
+);
+
+CREATE INDEX IF NOT EXISTS idx_license_expiry_notices_sent_at ON license_expiry_notices(sent_at);
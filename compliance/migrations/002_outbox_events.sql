@@ -0,0 +1,26 @@
+-- Compliance Management Module Database Schema
+-- Migration: 002_outbox_events
+
+-- Transactional Outbox Table (events written with the state change they describe)
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id VARCHAR(64) PRIMARY KEY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    message_key VARCHAR(255) NOT NULL,
+    dedup_key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ,
+    CONSTRAINT uq_outbox_events_dedup_key UNIQUE (dedup_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(next_attempt_at, created_at)
+    WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events(published_at)
+    WHERE status = 'PUBLISHED';
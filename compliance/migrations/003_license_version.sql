@@ -0,0 +1,5 @@
+-- Compliance Management Module Database Schema
+-- Migration: 003_license_version
+
+-- License version, bumped on every update for optimistic locking
+ALTER TABLE licenses ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
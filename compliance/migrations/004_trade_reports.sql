@@ -0,0 +1,27 @@
+-- Compliance Management Module Database Schema
+-- Migration: 004_trade_reports
+
+-- Trade Reports Table (one row per OTC desk or P2P platform, day, asset and chain)
+CREATE TABLE IF NOT EXISTS trade_reports (
+    id VARCHAR(64) PRIMARY KEY,
+    entity_id VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    trade_date DATE NOT NULL,
+    asset VARCHAR(20) NOT NULL,
+    chain VARCHAR(50) NOT NULL,
+    buy_volume NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    sell_volume NUMERIC(36, 18) NOT NULL DEFAULT 0,
+    trade_count INTEGER NOT NULL DEFAULT 0,
+    notional_value NUMERIC(24, 2) NOT NULL DEFAULT 0,
+    notional_currency VARCHAR(3) NOT NULL DEFAULT '',
+    counterparties JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'SUBMITTED',
+    reconciliation JSONB,
+    submitted_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_trade_reports_entity_day UNIQUE (entity_id, trade_date, asset, chain)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trade_reports_trade_date ON trade_reports(trade_date);
+CREATE INDEX IF NOT EXISTS idx_trade_reports_status ON trade_reports(status, trade_date);
@@ -0,0 +1,40 @@
+-- Compliance Management Module Database Schema
+-- Migration: 005_jurisdictions
+
+-- Trade reports belong to the jurisdiction of their reporter
+ALTER TABLE trade_reports ADD COLUMN IF NOT EXISTS jurisdiction VARCHAR(50) NOT NULL DEFAULT '';
+
+UPDATE trade_reports t SET jurisdiction = e.jurisdiction
+FROM entities e
+WHERE e.id = t.entity_id AND t.jurisdiction = '';
+
+CREATE INDEX IF NOT EXISTS idx_trade_reports_jurisdiction ON trade_reports(jurisdiction, trade_date);
+CREATE INDEX IF NOT EXISTS idx_entities_jurisdiction ON entities(jurisdiction);
+CREATE INDEX IF NOT EXISTS idx_licenses_jurisdiction ON licenses(jurisdiction);
+
+-- Outbox events carry the jurisdiction of their aggregate so they can be
+-- published to per-jurisdiction topics
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS jurisdiction VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Sharing Agreements Table (read access to one entity's records for a case)
+CREATE TABLE IF NOT EXISTS sharing_agreements (
+    id VARCHAR(64) PRIMARY KEY,
+    case_reference VARCHAR(255) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    owner_jurisdiction VARCHAR(50) NOT NULL,
+    grantee_jurisdiction VARCHAR(50) NOT NULL,
+    purpose TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_until TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_by VARCHAR(255),
+    revoked_at TIMESTAMPTZ,
+    revocation_reason TEXT,
+    CONSTRAINT chk_sharing_agreements_period CHECK (valid_until > valid_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sharing_agreements_grant ON sharing_agreements(entity_id, grantee_jurisdiction)
+    WHERE status = 'ACTIVE';
+CREATE INDEX IF NOT EXISTS idx_sharing_agreements_owner ON sharing_agreements(owner_jurisdiction, created_at);
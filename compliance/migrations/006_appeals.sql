@@ -0,0 +1,29 @@
+-- Compliance Management Module Database Schema
+-- Migration: 006_appeals
+
+-- Appeals Table (a regulated entity's challenge of a freeze order)
+CREATE TABLE IF NOT EXISTS appeals (
+    id VARCHAR(64) PRIMARY KEY,
+    entity_id VARCHAR(64) NOT NULL,
+    jurisdiction VARCHAR(50) NOT NULL DEFAULT '',
+    freeze_order_id VARCHAR(64) NOT NULL,
+    wallet_id VARCHAR(64) NOT NULL DEFAULT '',
+    wallet_address VARCHAR(255) NOT NULL DEFAULT '',
+    grounds TEXT NOT NULL,
+    documents JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(30) NOT NULL DEFAULT 'PENDING_REVIEW',
+    decision_due_at TIMESTAMPTZ NOT NULL,
+    determinations JSONB NOT NULL DEFAULT '[]',
+    filed_by VARCHAR(255) NOT NULL DEFAULT '',
+    filed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+-- A freeze order has at most one open appeal at a time
+CREATE UNIQUE INDEX IF NOT EXISTS uq_appeals_open_freeze_order ON appeals(freeze_order_id)
+    WHERE status IN ('PENDING_REVIEW', 'INFORMATION_REQUESTED');
+
+CREATE INDEX IF NOT EXISTS idx_appeals_entity ON appeals(entity_id, filed_at);
+CREATE INDEX IF NOT EXISTS idx_appeals_jurisdiction ON appeals(jurisdiction, status, decision_due_at);
@@ -83,12 +83,21 @@ const createApiClient = (): AxiosInstance => {
           window.location.href = '/login';
         }
         
-        const data = error.response.data as { message?: string; code?: string; details?: Record<string, unknown> };
+        // Services answer with application/problem+json documents; the
+        // message/details fields are kept for older endpoints
+        const data = error.response.data as {
+          title?: string;
+          detail?: string;
+          code?: string;
+          errors?: { field: string; message: string }[];
+          message?: string;
+          details?: Record<string, unknown>;
+        };
         throw new APIError(
-          data?.message || 'An error occurred',
+          data?.detail || data?.message || data?.title || 'An error occurred',
           status || 0,
           data?.code || 'UNKNOWN_ERROR',
-          data?.details
+          data?.errors ? { errors: data.errors } : data?.details
         );
       } else if (error.request) {
         throw new APIError('Network error - please check your connection', 0, 'NETWORK_ERROR');
@@ -197,11 +197,11 @@ func (r *PostgresRepository) GetExpiringSoon(ctx context.Context, days int) ([]*
 			   created_at, updated_at
 		FROM licenses
 		WHERE status = 'active'
-		  AND expiry_date BETWEEN NOW() AND NOW() + INTERVAL '%d days'
+		  AND expiry_date BETWEEN NOW() AND NOW() + make_interval(days => $1)
 		ORDER BY expiry_date ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(query, days))
+	rows, err := r.db.QueryContext(ctx, query, days)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ module github.com/csic/mining-control
 go 1.21
 
 require (
+	github.com/csic-platform/shared v0.0.0
 	github.com/gin-gonic/gin v1.9.1
 	github.com/google/uuid v1.4.0
 	github.com/shopspring/decimal v1.3.1
@@ -11,3 +12,5 @@ require (
 	github.com/lib/pq v1.10.9
 	github.com/robfig/cron/v3 v3.0.1
 )
+
+replace github.com/csic-platform/shared => ../../../shared
@@ -11,11 +11,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/csic-platform/shared/resilience"
 	"github.com/csic/mining-control/internal/config"
+	"github.com/csic/mining-control/internal/domain"
 	"github.com/csic/mining-control/internal/handler"
 	"github.com/csic/mining-control/internal/repository"
 	"github.com/csic/mining-control/internal/service"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 func main() {
@@ -66,14 +69,68 @@ func main() {
 	}
 	defer complianceRepo.Close()
 
+	emissionsRepo, err := repository.NewPostgresEmissionsRepository(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize emissions repository: %v", err)
+	}
+	defer emissionsRepo.Close()
+
+	quotaRepo, err := repository.NewPostgresEnergyQuotaRepository(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize energy quota repository: %v", err)
+	}
+	defer quotaRepo.Close()
+
+	commandRepo, err := repository.NewPostgresMiningCommandRepository(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize mining command repository: %v", err)
+	}
+	defer commandRepo.Close()
+
+	demandRepo, err := repository.NewPostgresDemandResponseRepository(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize demand-response repository: %v", err)
+	}
+	defer demandRepo.Close()
+
+	// Miner licenses are held by the compliance service
+	complianceCfg := cfg.Integration.ComplianceService
+	licenseRegistry := repository.NewComplianceLicenseClient(
+		complianceCfg.Endpoint,
+		time.Duration(complianceCfg.CacheTTL)*time.Second,
+		time.Duration(complianceCfg.StaleTTL)*time.Second,
+		resilience.New(resilience.Options{
+			MaxAttempts:      complianceCfg.MaxAttempts,
+			AttemptTimeout:   time.Duration(complianceCfg.Timeout) * time.Second,
+			FailureThreshold: complianceCfg.FailureThreshold,
+			OpenTimeout:      time.Duration(complianceCfg.OpenTimeout) * time.Second,
+		}),
+	)
+
+	notifications := cfg.DemandResponse.Notifications
+	operatorNotifier := repository.NewOperatorNotifier(repository.OperatorNotifierConfig{
+		WebhookURL:    notifications.WebhookURL,
+		WebhookSecret: notifications.WebhookSecret,
+		Timeout:       time.Duration(notifications.TimeoutSeconds) * time.Second,
+		SMTPHost:      notifications.SMTPHost,
+		SMTPPort:      notifications.SMTPPort,
+		SMTPUsername:  notifications.SMTPUsername,
+		SMTPPassword:  notifications.SMTPPassword,
+		From:          notifications.From,
+	})
+
 	// Initialize service layer
-	registrationSvc := service.NewRegistrationService(poolRepo, machineRepo, complianceRepo)
+	registrationSvc := service.NewRegistrationService(poolRepo, machineRepo, complianceRepo, licenseRegistry)
 	monitoringSvc := service.NewMonitoringService(energyRepo, hashRepo, poolRepo, machineRepo, violationRepo)
 	enforcementSvc := service.NewEnforcementService(poolRepo, violationRepo, complianceRepo)
-	reportingSvc := service.NewReportingService(poolRepo, energyRepo, hashRepo, violationRepo)
+	reportingSvc := service.NewReportingService(poolRepo, machineRepo, energyRepo, hashRepo, violationRepo)
+	emissionsSvc := service.NewEmissionsService(energyRepo, poolRepo, machineRepo, emissionsRepo, emissionsConfig(cfg))
+	licenseSvc := service.NewLicenseService(poolRepo, machineRepo, violationRepo, licenseRegistry, licenseConfig(cfg))
+	quotaSvc := service.NewEnergyQuotaService(poolRepo, energyRepo, quotaRepo, commandRepo, energyQuotaConfig(cfg))
+	demandSvc := service.NewDemandResponseService(poolRepo, energyRepo, commandRepo, demandRepo, operatorNotifier, demandResponseConfig(cfg))
 
 	// Initialize handlers
-	httpHandler := handler.NewHTTPHandler(registrationSvc, monitoringSvc, enforcementSvc, reportingSvc)
+	httpHandler := handler.NewHTTPHandler(registrationSvc, monitoringSvc, enforcementSvc, reportingSvc, emissionsSvc, licenseSvc, quotaSvc, demandSvc)
 
 	// Setup Gin router
 	router := gin.Default()
@@ -131,7 +188,34 @@ func main() {
 		api.GET("/reports/regional-load", httpHandler.GetRegionalEnergyLoad)
 		api.GET("/reports/carbon-footprint/:pool_id", httpHandler.GetCarbonFootprintReport)
 		api.GET("/reports/summary/:pool_id", httpHandler.GetMiningSummaryReport)
+		api.GET("/reports/unlicensed-hashrate", httpHandler.GetUnlicensedHashRate)
 		api.GET("/dashboard/stats", httpHandler.GetDashboardStats)
+		api.GET("/heatmap", httpHandler.GetMiningHeatmap)
+
+		// Emissions endpoints
+		api.GET("/machines/:id/emissions", httpHandler.GetMachineEmissions)
+		api.GET("/emissions/operators", httpHandler.GetOperatorEmissions)
+		api.GET("/emissions/regions", httpHandler.GetRegionalEmissions)
+
+		// Energy quota endpoints
+		api.PUT("/energy-quotas", httpHandler.SetEnergyQuota)
+		api.GET("/energy-quotas", httpHandler.ListEnergyQuotas)
+		api.DELETE("/energy-quotas/:id", httpHandler.DeleteEnergyQuota)
+		api.POST("/energy-quotas/enforce", httpHandler.EnforceEnergyQuotas)
+		api.GET("/operators/:operator_id/energy-quota", httpHandler.GetOperatorEnergyQuota)
+
+		// Mining command endpoints, polled by pool controllers
+		api.GET("/pools/:pool_id/commands", httpHandler.ListPoolCommands)
+		api.POST("/commands/:id/status", httpHandler.UpdateCommandStatus)
+
+		// Grid demand-response endpoints
+		api.POST("/demand-response/preview", httpHandler.PreviewDemandResponse)
+		api.POST("/demand-response/events", httpHandler.ScheduleDemandResponseEvent)
+		api.GET("/demand-response/events", httpHandler.ListDemandResponseEvents)
+		api.GET("/demand-response/events/:id", httpHandler.GetDemandResponseEvent)
+		api.GET("/demand-response/events/:id/preview", httpHandler.PreviewDemandResponseEvent)
+		api.POST("/demand-response/events/:id/cancel", httpHandler.CancelDemandResponseEvent)
+		api.GET("/demand-response/events/:id/report", httpHandler.GetDemandResponseReport)
 	}
 
 	// Create HTTP server
@@ -154,6 +238,26 @@ func main() {
 	// Start background compliance checker
 	go monitoringSvc.StartComplianceChecker()
 
+	// Start hourly emissions accounting
+	if cfg.EnergyMonitoring.Emissions.Enabled {
+		emissionsSvc.StartAccounting()
+	}
+
+	// Start nightly miner license reconciliation
+	if cfg.Registration.License.Reconciliation.Enabled {
+		licenseSvc.StartReconciliation()
+	}
+
+	// Start energy quota enforcement
+	if cfg.EnergyMonitoring.Quotas.Enabled {
+		quotaSvc.StartEnforcement()
+	}
+
+	// Start the demand-response event scheduler
+	if cfg.DemandResponse.Enabled {
+		demandSvc.StartScheduler()
+	}
+
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -168,6 +272,22 @@ func main() {
 	// Stop compliance checker
 	monitoringSvc.StopComplianceChecker()
 
+	if cfg.EnergyMonitoring.Emissions.Enabled {
+		emissionsSvc.StopAccounting()
+	}
+
+	if cfg.Registration.License.Reconciliation.Enabled {
+		licenseSvc.StopReconciliation()
+	}
+
+	if cfg.EnergyMonitoring.Quotas.Enabled {
+		quotaSvc.StopEnforcement()
+	}
+
+	if cfg.DemandResponse.Enabled {
+		demandSvc.StopScheduler()
+	}
+
 	// Shutdown server
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
@@ -175,3 +295,60 @@ func main() {
 
 	log.Println("Server exited properly")
 }
+
+// emissionsConfig builds the emissions service configuration from the energy
+// monitoring settings
+func emissionsConfig(cfg *config.Config) service.EmissionsConfig {
+	emissions := cfg.EnergyMonitoring.Emissions
+
+	factors := make(map[domain.EnergySourceType]decimal.Decimal)
+	for source, factor := range cfg.EnergyMonitoring.EmissionFactors() {
+		factors[domain.EnergySourceType(source)] = decimal.NewFromFloat(factor)
+	}
+
+	return service.EmissionsConfig{
+		SourceFactors: factors,
+		DefaultFactor: decimal.NewFromFloat(emissions.DefaultFactor),
+		Interval:      time.Duration(emissions.IntervalMinutes) * time.Minute,
+		BackfillHours: emissions.BackfillHours,
+	}
+}
+
+// licenseConfig builds the license service configuration, running the
+// reconciliation at 02:00 UTC unless another time is configured
+func licenseConfig(cfg *config.Config) service.LicenseConfig {
+	reconcileAt := 2 * time.Hour
+	if at := cfg.Registration.License.Reconciliation.Time; at != "" {
+		t, err := time.Parse("15:04", at)
+		if err != nil {
+			log.Printf("Invalid license reconciliation time %q, using 02:00: %v", at, err)
+		} else {
+			reconcileAt = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+		}
+	}
+
+	return service.LicenseConfig{
+		ReconcileAt: reconcileAt,
+	}
+}
+
+// energyQuotaConfig builds the energy quota service configuration
+func energyQuotaConfig(cfg *config.Config) service.EnergyQuotaConfig {
+	quotas := cfg.EnergyMonitoring.Quotas
+
+	return service.EnergyQuotaConfig{
+		Interval:        time.Duration(quotas.IntervalMinutes) * time.Minute,
+		ThrottlePercent: decimal.NewFromFloat(quotas.ThrottlePercent),
+	}
+}
+
+// demandResponseConfig builds the demand-response service configuration
+func demandResponseConfig(cfg *config.Config) service.DemandResponseConfig {
+	demand := cfg.DemandResponse
+
+	return service.DemandResponseConfig{
+		Interval:         time.Duration(demand.IntervalSeconds) * time.Second,
+		NoticeMinutes:    demand.NoticeMinutes,
+		TolerancePercent: decimal.NewFromFloat(demand.ComplianceTolerancePercent),
+	}
+}
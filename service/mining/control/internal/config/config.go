@@ -21,6 +21,7 @@ type Config struct {
 	Compliance     ComplianceConfig     `yaml:"compliance"`
 	Enforcement    EnforcementConfig    `yaml:"enforcement"`
 	Regional       RegionalConfig       `yaml:"regional"`
+	DemandResponse DemandResponseConfig `yaml:"demand_response"`
 	Integration    IntegrationConfig    `yaml:"integration"`
 	Logging        LoggingConfig        `yaml:"logging"`
 	Metrics        MetricsConfig        `yaml:"metrics"`
@@ -117,6 +118,14 @@ type LicenseConfig struct {
 	DefaultValidityDays    int  `yaml:"default_validity_days"`
 	RenewalNotificationDays int  `yaml:"renewal_notification_days"`
 	RequireComplianceCheck bool `yaml:"require_compliance_check"`
+	Reconciliation         LicenseReconciliationConfig `yaml:"reconciliation"`
+}
+
+// LicenseReconciliationConfig contains settings for the nightly check of
+// online miners against their licenses
+type LicenseReconciliationConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Time    string `yaml:"time"` // HH:MM, UTC
 }
 
 // EnergyMonitoringConfig contains energy monitoring settings
@@ -125,6 +134,8 @@ type EnergyMonitoringConfig struct {
 	Carbon         CarbonConfig           `yaml:"carbon"`
 	SourceTypes    []EnergySourceConfig   `yaml:"source_types"`
 	Telemetry      TelemetryConfig        `yaml:"telemetry"`
+	Emissions      EmissionsConfig        `yaml:"emissions"`
+	Quotas         EnergyQuotaConfig      `yaml:"quotas"`
 }
 
 // EnergyThresholdsConfig contains energy threshold settings
@@ -146,6 +157,33 @@ type EnergySourceConfig struct {
 	CarbonFactor float64 `yaml:"carbon_factor"`
 }
 
+// EmissionsConfig contains hourly emissions accounting settings. Emission
+// factors per energy source come from source_types.
+type EmissionsConfig struct {
+	Enabled         bool    `yaml:"enabled"`
+	IntervalMinutes int     `yaml:"interval_minutes"`
+	BackfillHours   int     `yaml:"backfill_hours"`
+	DefaultFactor   float64 `yaml:"default_factor"`
+}
+
+// EnergyQuotaConfig contains monthly energy quota enforcement settings. The
+// quotas themselves are managed through the API.
+type EnergyQuotaConfig struct {
+	Enabled         bool    `yaml:"enabled"`
+	IntervalMinutes int     `yaml:"interval_minutes"`
+	ThrottlePercent float64 `yaml:"throttle_percent"`
+}
+
+// EmissionFactors returns the configured emission factors in kg CO2 per kWh
+// keyed by energy source name
+func (c *EnergyMonitoringConfig) EmissionFactors() map[string]float64 {
+	factors := make(map[string]float64, len(c.SourceTypes))
+	for _, source := range c.SourceTypes {
+		factors[source.Name] = source.CarbonFactor
+	}
+	return factors
+}
+
 // TelemetryConfig contains telemetry reporting settings
 type TelemetryConfig struct {
 	MinIntervalSeconds int `yaml:"min_interval_seconds"`
@@ -246,6 +284,29 @@ type RegionalRestriction struct {
 	AllowedEnergySources []string `yaml:"allowed_energy_sources"`
 }
 
+// DemandResponseConfig contains grid demand-response event settings. Events
+// themselves are scheduled through the API.
+type DemandResponseConfig struct {
+	Enabled                    bool                              `yaml:"enabled"`
+	IntervalSeconds            int                               `yaml:"interval_seconds"`
+	NoticeMinutes              int                               `yaml:"notice_minutes"`
+	ComplianceTolerancePercent float64                           `yaml:"compliance_tolerance_percent"`
+	Notifications              DemandResponseNotificationsConfig `yaml:"notifications"`
+}
+
+// DemandResponseNotificationsConfig contains operator notification settings.
+// Webhook and email notices are each sent only when configured.
+type DemandResponseNotificationsConfig struct {
+	WebhookURL     string `yaml:"webhook_url"`
+	WebhookSecret  string `yaml:"webhook_secret"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+	SMTPHost       string `yaml:"smtp_host"`
+	SMTPPort       int    `yaml:"smtp_port"`
+	SMTPUsername   string `yaml:"smtp_username"`
+	SMTPPassword   string `yaml:"smtp_password"`
+	From           string `yaml:"from"`
+}
+
 // IntegrationConfig contains integration settings
 type IntegrationConfig struct {
 	ComplianceService ComplianceServiceConfig `yaml:"compliance_service"`
@@ -259,6 +320,12 @@ type ComplianceServiceConfig struct {
 	Endpoint  string `yaml:"endpoint"`
 	Timeout   int    `yaml:"timeout"`
 	CacheTTL  int    `yaml:"cache_ttl"`
+	// StaleTTL is how long, in seconds, a cached license may stand in while
+	// the compliance service is unavailable
+	StaleTTL         int `yaml:"stale_ttl"`
+	MaxAttempts      int `yaml:"max_attempts"`
+	FailureThreshold int `yaml:"failure_threshold"`
+	OpenTimeout      int `yaml:"open_timeout"`
 }
 
 // ExchangeSurveillanceServiceConfig contains exchange surveillance service settings
@@ -0,0 +1,32 @@
+-- Migration V3: Create Miner Emissions Table
+-- This migration adds hourly carbon emissions accounting per mining machine
+-- and energy source, computed from energy consumption telemetry.
+-- Direction: UP
+
+-- Create miner emissions table
+-- One row per machine, hour and energy source. Energy reported by a pool
+-- with no active machines is recorded against the nil machine ID.
+CREATE TABLE IF NOT EXISTS miner_emissions (
+    id UUID PRIMARY KEY,
+    machine_id UUID NOT NULL,
+    pool_id UUID NOT NULL,
+    operator_id UUID NOT NULL,
+    operator_name VARCHAR(200) NOT NULL,
+    region_code VARCHAR(20) NOT NULL,
+    hour_start TIMESTAMPTZ NOT NULL,
+    energy_source VARCHAR(32) NOT NULL,
+    energy_kwh DECIMAL(20, 6) NOT NULL,
+    emission_factor DECIMAL(10, 6) NOT NULL,
+    emissions_kg DECIMAL(20, 6) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (pool_id, machine_id, hour_start, energy_source)
+);
+
+-- Indexes for per-miner history and period aggregation
+CREATE INDEX IF NOT EXISTS idx_miner_emissions_machine_hour ON miner_emissions(machine_id, hour_start);
+CREATE INDEX IF NOT EXISTS idx_miner_emissions_hour ON miner_emissions(hour_start);
+CREATE INDEX IF NOT EXISTS idx_miner_emissions_operator_hour ON miner_emissions(operator_id, hour_start);
+CREATE INDEX IF NOT EXISTS idx_miner_emissions_region_hour ON miner_emissions(region_code, hour_start);
+
+-- Direction: DOWN
+-- DROP TABLE IF EXISTS miner_emissions CASCADE;
@@ -0,0 +1,14 @@
+-- Migration V4: Link Mining Machines to Licenses
+-- This migration records the compliance service license each mining machine
+-- operates under. Machines can only go online with an active mining license.
+-- Direction: UP
+
+-- License held in the compliance service; NULL until one is assigned
+ALTER TABLE mining_machines ADD COLUMN IF NOT EXISTS license_id UUID;
+
+-- Index for finding the miners operating under a license
+CREATE INDEX IF NOT EXISTS idx_mining_machines_license_id ON mining_machines(license_id);
+
+-- Direction: DOWN
+-- DROP INDEX IF EXISTS idx_mining_machines_license_id;
+-- ALTER TABLE mining_machines DROP COLUMN IF EXISTS license_id;
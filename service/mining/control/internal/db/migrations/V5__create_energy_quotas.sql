@@ -0,0 +1,43 @@
+-- Migration V5: Create Energy Quotas and Mining Commands
+-- This migration adds monthly energy quotas per operator and region, and the
+-- command queue through which pool controllers are throttled when an
+-- operator exceeds its quota.
+-- Direction: UP
+
+-- Create energy quotas table
+-- The row with the nil operator ID is the region's default quota; an
+-- operator's own row overrides it.
+CREATE TABLE IF NOT EXISTS energy_quotas (
+    id UUID PRIMARY KEY,
+    region_code VARCHAR(20) NOT NULL,
+    operator_id UUID NOT NULL,
+    monthly_mwh DECIMAL(20, 6) NOT NULL CHECK (monthly_mwh >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (region_code, operator_id)
+);
+
+-- Create mining commands table
+-- Commands queued for pool controllers, which poll for pending commands and
+-- report back as they carry them out.
+CREATE TABLE IF NOT EXISTS mining_commands (
+    id UUID PRIMARY KEY,
+    pool_id UUID NOT NULL REFERENCES mining_pools(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    target_power_kw DECIMAL(20, 6),
+    reason TEXT NOT NULL,
+    issued_by VARCHAR(100) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    acknowledged_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    result TEXT
+);
+
+-- Indexes for polling a pool's commands and finding its latest command
+CREATE INDEX IF NOT EXISTS idx_mining_commands_pool_issued ON mining_commands(pool_id, issued_at DESC);
+CREATE INDEX IF NOT EXISTS idx_mining_commands_pool_status ON mining_commands(pool_id, status);
+
+-- Direction: DOWN
+-- DROP TABLE IF EXISTS mining_commands CASCADE;
+-- DROP TABLE IF EXISTS energy_quotas CASCADE;
@@ -0,0 +1,52 @@
+-- Migration V6: Create Demand-Response Events
+-- This migration adds grid demand-response events, during which the mining
+-- pools of the affected regions are throttled to reduce their combined load,
+-- and the pools taking part in each event.
+-- Direction: UP
+
+-- Create demand-response events table
+CREATE TABLE IF NOT EXISTS demand_response_events (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    region_codes TEXT[] NOT NULL,
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ NOT NULL,
+    target_reduction_mw DECIMAL(20, 6) NOT NULL CHECK (target_reduction_mw > 0),
+    notice_minutes INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'SCHEDULED',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    notified_at TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    ended_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (end_at > start_at)
+);
+
+-- Index for the scheduler, which polls for scheduled and active events
+CREATE INDEX IF NOT EXISTS idx_demand_response_events_status ON demand_response_events(status, start_at);
+
+-- Create demand-response participants table
+-- A pool's cap is fixed when its operator is notified of the event.
+CREATE TABLE IF NOT EXISTS demand_response_participants (
+    event_id UUID NOT NULL REFERENCES demand_response_events(id) ON DELETE CASCADE,
+    pool_id UUID NOT NULL REFERENCES mining_pools(id) ON DELETE CASCADE,
+    pool_name VARCHAR(255) NOT NULL,
+    operator_id UUID NOT NULL,
+    operator_name VARCHAR(255) NOT NULL,
+    region_code VARCHAR(20) NOT NULL,
+    contact_email VARCHAR(255) NOT NULL DEFAULT '',
+    active_machines INTEGER NOT NULL DEFAULT 0,
+    baseline_kw DECIMAL(20, 6) NOT NULL,
+    cap_kw DECIMAL(20, 6) NOT NULL,
+    reduction_kw DECIMAL(20, 6) NOT NULL,
+    throttle_command_id UUID REFERENCES mining_commands(id) ON DELETE SET NULL,
+    restore_command_id UUID REFERENCES mining_commands(id) ON DELETE SET NULL,
+    PRIMARY KEY (event_id, pool_id)
+);
+
+-- Direction: DOWN
+-- DROP TABLE IF EXISTS demand_response_participants CASCADE;
+-- DROP TABLE IF EXISTS demand_response_events CASCADE;
@@ -64,6 +64,7 @@ const (
 	ViolationTypeLicenseExpired        ViolationType = "LICENSE_EXPIRED"
 	ViolationTypeCarbonLimitExceeded   ViolationType = "CARBON_LIMIT_EXCEEDED"
 	ViolationTypeReportingViolation    ViolationType = "REPORTING_VIOLATION"
+	ViolationTypeUnlicensedMiner       ViolationType = "UNLICENSED_MINER"
 )
 
 // ViolationStatus represents the status of a violation
@@ -126,6 +127,7 @@ type MiningMachine struct {
 	ID                 uuid.UUID      `json:"id" db:"id"`
 	SerialNumber       string         `json:"serial_number" db:"serial_number"`
 	PoolID             uuid.UUID      `json:"pool_id" db:"pool_id"`
+	LicenseID          *uuid.UUID     `json:"license_id,omitempty" db:"license_id"`
 	ModelType          MachineType    `json:"model_type" db:"model_type"`
 	Manufacturer       string         `json:"manufacturer" db:"manufacturer"`
 	ModelName          string         `json:"model_name" db:"model_name"`
@@ -448,6 +450,7 @@ type MachineRegistrationRequest struct {
 	LocationDescription string         `json:"location_description" binding:"required"`
 	GPSCoordinates      *GPSCoordinates `json:"gps_coordinates,omitempty"`
 	InstallationDate    time.Time      `json:"installation_date" binding:"required"`
+	LicenseID           *uuid.UUID     `json:"license_id,omitempty"`
 }
 
 // PoolRegistrationRequest represents a request to register a mining pool
@@ -462,3 +465,395 @@ type PoolRegistrationRequest struct {
 	FacilityAddress      string           `json:"facility_address" binding:"required"`
 	GPSCoordinates       *GPSCoordinates  `json:"gps_coordinates,omitempty"`
 }
+
+// BoundingBox represents a geographic area in degrees
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// Contains reports whether the point lies inside the box
+func (b BoundingBox) Contains(lat, lon float64) bool {
+	return lat >= b.MinLat && lat <= b.MaxLat && lon >= b.MinLon && lon <= b.MaxLon
+}
+
+// MachineLocation represents the position and current output of a mining machine
+type MachineLocation struct {
+	MachineID         uuid.UUID       `json:"machine_id" db:"machine_id"`
+	PoolID            uuid.UUID       `json:"pool_id" db:"pool_id"`
+	Latitude          float64         `json:"latitude" db:"latitude"`
+	Longitude         float64         `json:"longitude" db:"longitude"`
+	CurrentHashRateTH decimal.Decimal `json:"current_hash_rate_th" db:"current_hash_rate_th"`
+	CurrentPowerWatts decimal.Decimal `json:"current_power_watts" db:"current_power_watts"`
+}
+
+// HeatmapCell represents the mining activity aggregated over a geohash cell
+type HeatmapCell struct {
+	Geohash      string          `json:"geohash"`
+	Latitude     float64         `json:"latitude"`
+	Longitude    float64         `json:"longitude"`
+	Bounds       BoundingBox     `json:"bounds"`
+	MachineCount int             `json:"machine_count"`
+	PoolCount    int             `json:"pool_count"`
+	HashRateTH   decimal.Decimal `json:"hash_rate_th"`
+	PowerKW      decimal.Decimal `json:"power_kw"`
+}
+
+// MiningHeatmap represents mining activity within a bounding box bucketed by geohash
+type MiningHeatmap struct {
+	BoundingBox  BoundingBox     `json:"bbox"`
+	Zoom         int             `json:"zoom"`
+	Precision    int             `json:"precision"`
+	Cells        []HeatmapCell   `json:"cells"`
+	MachineCount int             `json:"machine_count"`
+	HashRateTH   decimal.Decimal `json:"hash_rate_th"`
+	PowerKW      decimal.Decimal `json:"power_kw"`
+	GeneratedAt  time.Time       `json:"generated_at"`
+}
+
+// EmissionsGrouping represents how emissions are aggregated
+type EmissionsGrouping string
+
+const (
+	EmissionsGroupingOperator EmissionsGrouping = "OPERATOR"
+	EmissionsGroupingRegion   EmissionsGrouping = "REGION"
+)
+
+// MinerEmissionRecord represents the carbon emissions attributed to a mining
+// machine for one hour of one energy source. Energy a pool reports while it
+// has no active machines is recorded against uuid.Nil.
+type MinerEmissionRecord struct {
+	ID             uuid.UUID        `json:"id" db:"id"`
+	MachineID      uuid.UUID        `json:"machine_id" db:"machine_id"`
+	PoolID         uuid.UUID        `json:"pool_id" db:"pool_id"`
+	OperatorID     uuid.UUID        `json:"operator_id" db:"operator_id"`
+	OperatorName   string           `json:"operator_name" db:"operator_name"`
+	RegionCode     string           `json:"region_code" db:"region_code"`
+	HourStart      time.Time        `json:"hour_start" db:"hour_start"`
+	EnergySource   EnergySourceType `json:"energy_source" db:"energy_source"`
+	EnergyKWh      decimal.Decimal  `json:"energy_kwh" db:"energy_kwh"`
+	EmissionFactor decimal.Decimal  `json:"emission_factor_kg_per_kwh" db:"emission_factor"`
+	EmissionsKG    decimal.Decimal  `json:"emissions_kg" db:"emissions_kg"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+}
+
+// EmissionsSummary represents emissions aggregated for an operator or region
+type EmissionsSummary struct {
+	Grouping     EmissionsGrouping                    `json:"grouping"`
+	Key          string                               `json:"key"`
+	Name         string                               `json:"name"`
+	PeriodStart  time.Time                            `json:"period_start"`
+	PeriodEnd    time.Time                            `json:"period_end"`
+	EnergyKWh    decimal.Decimal                      `json:"energy_kwh"`
+	EmissionsKG  decimal.Decimal                      `json:"emissions_kg"`
+	BySource     map[EnergySourceType]decimal.Decimal `json:"emissions_by_source_kg"`
+	PoolCount    int                                  `json:"pool_count"`
+	MachineCount int                                  `json:"machine_count"`
+}
+
+// Compliance service license type and statuses checked for miners. Only an
+// active mining license allows a miner to go online.
+const (
+	LicenseTypeMining    = "MINING_LICENSE"
+	LicenseStatusActive  = "ACTIVE"
+	LicenseStatusExpired = "EXPIRED"
+)
+
+// LicenseProblem represents why a miner's license does not allow it to mine
+type LicenseProblem string
+
+const (
+	LicenseProblemMissing   LicenseProblem = "MISSING"
+	LicenseProblemNotFound  LicenseProblem = "NOT_FOUND"
+	LicenseProblemWrongType LicenseProblem = "WRONG_TYPE"
+	LicenseProblemInactive  LicenseProblem = "INACTIVE"
+	LicenseProblemExpired   LicenseProblem = "EXPIRED"
+)
+
+// MinerLicense represents a license held in the compliance service, as far as
+// the mining control service needs it
+type MinerLicense struct {
+	ID            string    `json:"id"`
+	LicenseNumber string    `json:"license_number"`
+	EntityID      string    `json:"entity_id"`
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// Problem returns why the license does not allow mining at the given time, or
+// an empty LicenseProblem for an active mining license. A nil license was not
+// found.
+func (l *MinerLicense) Problem(at time.Time) LicenseProblem {
+	switch {
+	case l == nil:
+		return LicenseProblemNotFound
+	case l.Type != LicenseTypeMining:
+		return LicenseProblemWrongType
+	case l.Status == LicenseStatusExpired || (!l.ExpiresAt.IsZero() && at.After(l.ExpiresAt)):
+		return LicenseProblemExpired
+	case l.Status != LicenseStatusActive:
+		return LicenseProblemInactive
+	}
+	return ""
+}
+
+// UnlicensedMiner represents an active mining machine without a valid license
+type UnlicensedMiner struct {
+	MachineID     uuid.UUID       `json:"machine_id"`
+	SerialNumber  string          `json:"serial_number"`
+	PoolID        uuid.UUID       `json:"pool_id"`
+	LicenseID     *uuid.UUID      `json:"license_id,omitempty"`
+	LicenseStatus string          `json:"license_status,omitempty"`
+	Problem       LicenseProblem  `json:"problem"`
+	HashRateTH    decimal.Decimal `json:"hash_rate_th"`
+}
+
+// UnlicensedHashRate represents the hash rate of unlicensed miners in a region
+type UnlicensedHashRate struct {
+	RegionCode   string                             `json:"region_code"`
+	HashRateTH   decimal.Decimal                    `json:"hash_rate_th"`
+	ByProblem    map[LicenseProblem]decimal.Decimal `json:"hash_rate_by_problem_th"`
+	MachineCount int                                `json:"machine_count"`
+	PoolCount    int                                `json:"pool_count"`
+}
+
+// LicenseReconciliation summarizes a reconciliation of miners against their
+// licenses
+type LicenseReconciliation struct {
+	StartedAt        time.Time `json:"started_at"`
+	CompletedAt      time.Time `json:"completed_at"`
+	MachinesChecked  int       `json:"machines_checked"`
+	Unlicensed       int       `json:"unlicensed"`
+	ViolationsOpened int       `json:"violations_opened"`
+}
+
+// EnergyQuota represents the energy a mining operator may consume in a region
+// per calendar month (UTC). The quota of uuid.Nil is the region's default and
+// applies to every operator without a quota of its own.
+type EnergyQuota struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	RegionCode string          `json:"region_code" db:"region_code"`
+	OperatorID uuid.UUID       `json:"operator_id" db:"operator_id"`
+	MonthlyMWh decimal.Decimal `json:"monthly_mwh" db:"monthly_mwh"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// IsRegionDefault reports whether the quota is the default of its region
+func (q *EnergyQuota) IsRegionDefault() bool {
+	return q.OperatorID == uuid.Nil
+}
+
+// EnergyQuotaRequest represents a request to set an energy quota. Without an
+// operator the region's default quota is set.
+type EnergyQuotaRequest struct {
+	RegionCode string          `json:"region_code" binding:"required"`
+	OperatorID *uuid.UUID      `json:"operator_id,omitempty"`
+	MonthlyMWh decimal.Decimal `json:"monthly_mwh"`
+}
+
+// EnergyQuotaStatus represents an operator's energy consumption in a region
+// for one month against the quota that applies to it. Quota and remaining
+// energy are omitted when no quota applies.
+type EnergyQuotaStatus struct {
+	OperatorID   uuid.UUID        `json:"operator_id"`
+	OperatorName string           `json:"operator_name"`
+	RegionCode   string           `json:"region_code"`
+	Month        string           `json:"month"` // YYYY-MM
+	QuotaID      *uuid.UUID       `json:"quota_id,omitempty"`
+	QuotaMWh     *decimal.Decimal `json:"quota_mwh,omitempty"`
+	ConsumedMWh  decimal.Decimal  `json:"consumed_mwh"`
+	RemainingMWh *decimal.Decimal `json:"remaining_mwh,omitempty"`
+	Breached     bool             `json:"breached"`
+	PoolIDs      []uuid.UUID      `json:"pool_ids"`
+	AsOf         time.Time        `json:"as_of"`
+}
+
+// EnergyQuotaEnforcement summarizes a run of energy quota enforcement
+type EnergyQuotaEnforcement struct {
+	StartedAt        time.Time `json:"started_at"`
+	CompletedAt      time.Time `json:"completed_at"`
+	Month            string    `json:"month"`
+	OperatorsChecked int       `json:"operators_checked"`
+	Breaches         int       `json:"breaches"`
+	ThrottlesIssued  int       `json:"throttles_issued"`
+	ResumesIssued    int       `json:"resumes_issued"`
+}
+
+// MiningCommandType represents an instruction sent to a pool's controller
+type MiningCommandType string
+
+const (
+	MiningCommandThrottle MiningCommandType = "THROTTLE"
+	MiningCommandResume   MiningCommandType = "RESUME"
+)
+
+// MiningCommandStatus represents how far a pool's controller has carried out
+// a command
+type MiningCommandStatus string
+
+const (
+	MiningCommandStatusPending      MiningCommandStatus = "PENDING"
+	MiningCommandStatusAcknowledged MiningCommandStatus = "ACKNOWLEDGED"
+	MiningCommandStatusExecuted     MiningCommandStatus = "EXECUTED"
+	MiningCommandStatusFailed       MiningCommandStatus = "FAILED"
+)
+
+// IsFinal reports whether the status ends the command's lifecycle
+func (s MiningCommandStatus) IsFinal() bool {
+	return s == MiningCommandStatusExecuted || s == MiningCommandStatusFailed
+}
+
+// MiningCommand represents a command queued for a mining pool. Pool
+// controllers poll for pending commands and report back as they carry them
+// out. A THROTTLE command caps the pool's power draw at TargetPowerKW; RESUME
+// lifts the cap.
+type MiningCommand struct {
+	ID             uuid.UUID           `json:"id" db:"id"`
+	PoolID         uuid.UUID           `json:"pool_id" db:"pool_id"`
+	Type           MiningCommandType   `json:"type" db:"type"`
+	Status         MiningCommandStatus `json:"status" db:"status"`
+	TargetPowerKW  *decimal.Decimal    `json:"target_power_kw,omitempty" db:"target_power_kw"`
+	Reason         string              `json:"reason" db:"reason"`
+	IssuedBy       string              `json:"issued_by" db:"issued_by"`
+	IssuedAt       time.Time           `json:"issued_at" db:"issued_at"`
+	AcknowledgedAt *time.Time          `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty" db:"completed_at"`
+	Result         string              `json:"result,omitempty" db:"result"`
+}
+
+// DemandResponseStatus represents the lifecycle of a demand-response event
+type DemandResponseStatus string
+
+const (
+	DemandResponseStatusScheduled DemandResponseStatus = "SCHEDULED"
+	DemandResponseStatusActive    DemandResponseStatus = "ACTIVE"
+	DemandResponseStatusCompleted DemandResponseStatus = "COMPLETED"
+	DemandResponseStatusCancelled DemandResponseStatus = "CANCELLED"
+)
+
+// DemandResponseEvent represents a scheduled reduction of mining load in one
+// or more grid regions. Operators are notified NoticeMinutes before the
+// window; at its start the participating pools are throttled so that their
+// combined draw falls by TargetReductionMW, and at its end they are restored.
+type DemandResponseEvent struct {
+	ID                uuid.UUID                   `json:"id" db:"id"`
+	Name              string                      `json:"name" db:"name"`
+	Reason            string                      `json:"reason" db:"reason"`
+	RegionCodes       []string                    `json:"region_codes" db:"region_codes"`
+	StartAt           time.Time                   `json:"start_at" db:"start_at"`
+	EndAt             time.Time                   `json:"end_at" db:"end_at"`
+	TargetReductionMW decimal.Decimal             `json:"target_reduction_mw" db:"target_reduction_mw"`
+	NoticeMinutes     int                         `json:"notice_minutes" db:"notice_minutes"`
+	Status            DemandResponseStatus        `json:"status" db:"status"`
+	CreatedBy         string                      `json:"created_by,omitempty" db:"created_by"`
+	NotifiedAt        *time.Time                  `json:"notified_at,omitempty" db:"notified_at"`
+	StartedAt         *time.Time                  `json:"started_at,omitempty" db:"started_at"`
+	EndedAt           *time.Time                  `json:"ended_at,omitempty" db:"ended_at"`
+	CancelledAt       *time.Time                  `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	Participants      []DemandResponseParticipant `json:"participants,omitempty"`
+	CreatedAt         time.Time                   `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time                   `json:"updated_at" db:"updated_at"`
+}
+
+// NoticeAt returns when operators are to be notified of the event
+func (e *DemandResponseEvent) NoticeAt() time.Time {
+	return e.StartAt.Add(-time.Duration(e.NoticeMinutes) * time.Minute)
+}
+
+// DemandResponseParticipant represents a pool taking part in a
+// demand-response event with the power cap allocated to it. The allocation is
+// fixed when operators are notified.
+type DemandResponseParticipant struct {
+	EventID           uuid.UUID       `json:"event_id" db:"event_id"`
+	PoolID            uuid.UUID       `json:"pool_id" db:"pool_id"`
+	PoolName          string          `json:"pool_name" db:"pool_name"`
+	OperatorID        uuid.UUID       `json:"operator_id" db:"operator_id"`
+	OperatorName      string          `json:"operator_name" db:"operator_name"`
+	RegionCode        string          `json:"region_code" db:"region_code"`
+	ContactEmail      string          `json:"contact_email,omitempty" db:"contact_email"`
+	ActiveMachines    int             `json:"active_machines" db:"active_machines"`
+	BaselineKW        decimal.Decimal `json:"baseline_kw" db:"baseline_kw"`
+	CapKW             decimal.Decimal `json:"cap_kw" db:"cap_kw"`
+	ReductionKW       decimal.Decimal `json:"reduction_kw" db:"reduction_kw"`
+	ThrottleCommandID *uuid.UUID      `json:"throttle_command_id,omitempty" db:"throttle_command_id"`
+	RestoreCommandID  *uuid.UUID      `json:"restore_command_id,omitempty" db:"restore_command_id"`
+}
+
+// DemandResponseRequest represents a request to schedule or preview a
+// demand-response event
+type DemandResponseRequest struct {
+	Name              string          `json:"name" binding:"required"`
+	Reason            string          `json:"reason"`
+	RegionCodes       []string        `json:"region_codes" binding:"required,min=1"`
+	StartAt           time.Time       `json:"start_at" binding:"required"`
+	EndAt             time.Time       `json:"end_at" binding:"required"`
+	TargetReductionMW decimal.Decimal `json:"target_reduction_mw"`
+	NoticeMinutes     *int            `json:"notice_minutes,omitempty"`
+	CreatedBy         string          `json:"created_by"`
+}
+
+// DemandResponsePreview represents the pools a demand-response event would
+// throttle and the reduction that throttling them achieves
+type DemandResponsePreview struct {
+	RegionCodes        []string                    `json:"region_codes"`
+	TargetReductionMW  decimal.Decimal             `json:"target_reduction_mw"`
+	BaselineMW         decimal.Decimal             `json:"baseline_mw"`
+	PlannedReductionMW decimal.Decimal             `json:"planned_reduction_mw"`
+	ShortfallMW        decimal.Decimal             `json:"shortfall_mw"`
+	PoolCount          int                         `json:"pool_count"`
+	OperatorCount      int                         `json:"operator_count"`
+	MachineCount       int                         `json:"machine_count"`
+	Participants       []DemandResponseParticipant `json:"participants"`
+	GeneratedAt        time.Time                   `json:"generated_at"`
+}
+
+// DemandResponsePoolResult represents how a pool kept to its cap during a
+// demand-response event, measured from its energy telemetry
+type DemandResponsePoolResult struct {
+	PoolID              uuid.UUID           `json:"pool_id"`
+	PoolName            string              `json:"pool_name"`
+	OperatorID          uuid.UUID           `json:"operator_id"`
+	OperatorName        string              `json:"operator_name"`
+	RegionCode          string              `json:"region_code"`
+	BaselineKW          decimal.Decimal     `json:"baseline_kw"`
+	CapKW               decimal.Decimal     `json:"cap_kw"`
+	AveragePowerKW      decimal.Decimal     `json:"average_power_kw"`
+	AchievedReductionKW decimal.Decimal     `json:"achieved_reduction_kw"`
+	TelemetryReported   bool                `json:"telemetry_reported"`
+	Compliant           bool                `json:"compliant"`
+	CommandStatus       MiningCommandStatus `json:"command_status,omitempty"`
+}
+
+// DemandResponseReport represents the compliance of the participating pools
+// with a demand-response event. Pools that reported no telemetry during the
+// window are not compliant.
+type DemandResponseReport struct {
+	EventID             uuid.UUID                  `json:"event_id"`
+	Name                string                     `json:"name"`
+	Status              DemandResponseStatus       `json:"status"`
+	StartAt             time.Time                  `json:"start_at"`
+	EndAt               time.Time                  `json:"end_at"`
+	TargetReductionMW   decimal.Decimal            `json:"target_reduction_mw"`
+	AchievedReductionMW decimal.Decimal            `json:"achieved_reduction_mw"`
+	TargetMet           bool                       `json:"target_met"`
+	CompliantPools      int                        `json:"compliant_pools"`
+	NonCompliantPools   int                        `json:"non_compliant_pools"`
+	Pools               []DemandResponsePoolResult `json:"pools"`
+	GeneratedAt         time.Time                  `json:"generated_at"`
+}
+
+// OperatorNotice represents a message to a mining operator about its pools
+type OperatorNotice struct {
+	Type         string      `json:"type"`
+	OperatorID   uuid.UUID   `json:"operator_id"`
+	OperatorName string      `json:"operator_name"`
+	Emails       []string    `json:"-"`
+	Subject      string      `json:"subject"`
+	Message      string      `json:"message"`
+	Data         interface{} `json:"data,omitempty"`
+	SentAt       time.Time   `json:"sent_at"`
+}
@@ -0,0 +1,129 @@
+// Package geohash implements geohash encoding for bucketing coordinates into
+// grid cells
+package geohash
+
+import "strings"
+
+// MaxPrecision is the longest supported geohash
+const MaxPrecision = 12
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Box is the area covered by a geohash cell
+type Box struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// Center returns the center point of the box
+func (b Box) Center() (lat, lon float64) {
+	return (b.MinLat + b.MaxLat) / 2, (b.MinLon + b.MaxLon) / 2
+}
+
+// Encode returns the geohash of the given precision containing the point.
+// Precision is clamped to 1..MaxPrecision.
+func Encode(lat, lon float64, precision int) string {
+	precision = clampPrecision(precision)
+
+	minLat, maxLat := -90.0, 90.0
+	minLon, maxLon := -180.0, 180.0
+
+	var sb strings.Builder
+	sb.Grow(precision)
+
+	// Bits alternate between longitude and latitude, starting with longitude
+	even := true
+	bit, ch := 0, 0
+	for sb.Len() < precision {
+		if even {
+			mid := (minLon + maxLon) / 2
+			if lon >= mid {
+				ch = ch<<1 | 1
+				minLon = mid
+			} else {
+				ch <<= 1
+				maxLon = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				ch = ch<<1 | 1
+				minLat = mid
+			} else {
+				ch <<= 1
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			sb.WriteByte(base32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// Decode returns the box covered by a geohash. It returns false if the hash
+// contains characters outside the geohash alphabet.
+func Decode(hash string) (Box, bool) {
+	box := Box{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180}
+
+	even := true
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(base32, hash[i])
+		if idx < 0 {
+			return Box{}, false
+		}
+		for mask := 16; mask > 0; mask >>= 1 {
+			if even {
+				mid := (box.MinLon + box.MaxLon) / 2
+				if idx&mask != 0 {
+					box.MinLon = mid
+				} else {
+					box.MaxLon = mid
+				}
+			} else {
+				mid := (box.MinLat + box.MaxLat) / 2
+				if idx&mask != 0 {
+					box.MinLat = mid
+				} else {
+					box.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return box, true
+}
+
+// PrecisionForZoom returns the shortest geohash precision whose cells are at
+// most a quarter of the width of a web map tile at the given zoom level, so
+// each tile shows a grid of several cells across
+func PrecisionForZoom(zoom int) int {
+	if zoom < 0 {
+		zoom = 0
+	}
+	for precision := 1; precision < MaxPrecision; precision++ {
+		// A geohash of n characters spends ceil(5n/2) bits on longitude,
+		// and a tile at zoom z spans 360/2^z degrees of longitude
+		if (5*precision+1)/2 >= zoom+2 {
+			return precision
+		}
+	}
+	return MaxPrecision
+}
+
+func clampPrecision(precision int) int {
+	if precision < 1 {
+		return 1
+	}
+	if precision > MaxPrecision {
+		return MaxPrecision
+	}
+	return precision
+}
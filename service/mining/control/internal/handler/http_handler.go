@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/csic/mining-control/internal/domain"
@@ -12,21 +14,33 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// defaultHeatmapZoom is the heatmap zoom level used when none is given,
+// roughly a national view
+const defaultHeatmapZoom = 6
+
 // HTTPHandler handles HTTP requests for the mining control service
 type HTTPHandler struct {
 	registrationSvc *service.RegistrationService
 	monitoringSvc   *service.MonitoringService
 	enforcementSvc  *service.EnforcementService
 	reportingSvc    *service.ReportingService
+	emissionsSvc    *service.EmissionsService
+	licenseSvc      *service.LicenseService
+	quotaSvc        *service.EnergyQuotaService
+	demandSvc       *service.DemandResponseService
 }
 
 // NewHTTPHandler creates a new HTTP handler
-func NewHTTPHandler(registrationSvc *service.RegistrationService, monitoringSvc *service.MonitoringService, enforcementSvc *service.EnforcementService, reportingSvc *service.ReportingService) *HTTPHandler {
+func NewHTTPHandler(registrationSvc *service.RegistrationService, monitoringSvc *service.MonitoringService, enforcementSvc *service.EnforcementService, reportingSvc *service.ReportingService, emissionsSvc *service.EmissionsService, licenseSvc *service.LicenseService, quotaSvc *service.EnergyQuotaService, demandSvc *service.DemandResponseService) *HTTPHandler {
 	return &HTTPHandler{
 		registrationSvc: registrationSvc,
 		monitoringSvc:   monitoringSvc,
 		enforcementSvc:  enforcementSvc,
 		reportingSvc:    reportingSvc,
+		emissionsSvc:    emissionsSvc,
+		licenseSvc:      licenseSvc,
+		quotaSvc:        quotaSvc,
+		demandSvc:       demandSvc,
 	}
 }
 
@@ -380,8 +394,30 @@ func (h *HTTPHandler) UpdateMiningMachine(c *gin.Context) {
 		return
 	}
 
+	// JSON decodes to strings; the service expects typed values
+	if status, ok := updates["status"].(string); ok {
+		updates["status"] = domain.MachineStatus(status)
+	}
+	if licenseIDStr, ok := updates["license_id"].(string); ok {
+		licenseID, err := uuid.Parse(licenseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid license_id format",
+			})
+			return
+		}
+		updates["license_id"] = licenseID
+	}
+
 	machine, err := h.registrationSvc.UpdateMiningMachine(c.Request.Context(), id, updates)
 	if err != nil {
+		var svcErr *service.ServiceError
+		if errors.As(err, &svcErr) && svcErr.Code == "LICENSE_INVALID" {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": svcErr.Message,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to update mining machine",
 			"details": err.Error(),
@@ -873,6 +909,542 @@ func (h *HTTPHandler) GetDashboardStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// GetMiningHeatmap aggregates hash rate and power consumption into geohash
+// cells within a bounding box. The bbox query parameter is
+// min_lon,min_lat,max_lon,max_lat and zoom is a web map zoom level.
+func (h *HTTPHandler) GetMiningHeatmap(c *gin.Context) {
+	parts := splitString(c.Query("bbox"), ",")
+	if len(parts) != 4 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "bbox must be min_lon,min_lat,max_lon,max_lat",
+		})
+		return
+	}
+
+	var coords [4]float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid bbox coordinate",
+			})
+			return
+		}
+		coords[i] = value
+	}
+	bbox := domain.BoundingBox{MinLon: coords[0], MinLat: coords[1], MaxLon: coords[2], MaxLat: coords[3]}
+
+	zoom := defaultHeatmapZoom
+	if zoomStr := c.Query("zoom"); zoomStr != "" {
+		z, err := strconv.Atoi(zoomStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid zoom level",
+			})
+			return
+		}
+		zoom = z
+	}
+
+	heatmap, err := h.reportingSvc.GetMiningHeatmap(c.Request.Context(), bbox, zoom)
+	if err != nil {
+		var svcErr *service.ServiceError
+		if errors.As(err, &svcErr) && svcErr.Code != "DATABASE_ERROR" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": svcErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to generate mining heatmap",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, heatmap)
+}
+
+// Emissions Handlers
+
+// GetMachineEmissions retrieves the hourly emission records of a machine
+func (h *HTTPHandler) GetMachineEmissions(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid machine ID format",
+		})
+		return
+	}
+
+	startTime, endTime := emissionsPeriod(c)
+
+	records, err := h.emissionsSvc.GetMachineEmissions(c.Request.Context(), id, startTime, endTime)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve machine emissions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	totalKWh, totalKG := decimal.Zero, decimal.Zero
+	for _, record := range records {
+		totalKWh = totalKWh.Add(record.EnergyKWh)
+		totalKG = totalKG.Add(record.EmissionsKG)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"machine_id":         id,
+		"period":             gin.H{"start": startTime, "end": endTime},
+		"total_energy_kwh":   totalKWh,
+		"total_emissions_kg": totalKG,
+		"records":            records,
+	})
+}
+
+// GetOperatorEmissions retrieves emissions aggregated per operator
+func (h *HTTPHandler) GetOperatorEmissions(c *gin.Context) {
+	h.getEmissionsSummary(c, domain.EmissionsGroupingOperator)
+}
+
+// GetRegionalEmissions retrieves emissions aggregated per region
+func (h *HTTPHandler) GetRegionalEmissions(c *gin.Context) {
+	h.getEmissionsSummary(c, domain.EmissionsGroupingRegion)
+}
+
+func (h *HTTPHandler) getEmissionsSummary(c *gin.Context, grouping domain.EmissionsGrouping) {
+	startTime, endTime := emissionsPeriod(c)
+
+	summaries, err := h.emissionsSvc.GetEmissionsSummary(c.Request.Context(), grouping, startTime, endTime)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to aggregate emissions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"period":   gin.H{"start": startTime, "end": endTime},
+		"grouping": grouping,
+		"items":    summaries,
+	})
+}
+
+// emissionsPeriod reads the start_time and end_time query parameters,
+// defaulting to the last month
+func emissionsPeriod(c *gin.Context) (time.Time, time.Time) {
+	endTime := time.Now()
+	startTime := endTime.AddDate(0, -1, 0) // Default 1 month
+
+	if startStr := c.Query("start_time"); startStr != "" {
+		if t, err := time.Parse(time.RFC3339, startStr); err == nil {
+			startTime = t
+		}
+	}
+	if endStr := c.Query("end_time"); endStr != "" {
+		if t, err := time.Parse(time.RFC3339, endStr); err == nil {
+			endTime = t
+		}
+	}
+
+	return startTime, endTime
+}
+
+// License Handlers
+
+// GetUnlicensedHashRate retrieves the hash rate of online miners without a
+// valid mining license per region
+func (h *HTTPHandler) GetUnlicensedHashRate(c *gin.Context) {
+	regions, err := h.licenseSvc.GetUnlicensedHashRate(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to compute unlicensed hash rate",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	total := decimal.Zero
+	for _, region := range regions {
+		total = total.Add(region.HashRateTH)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"generated_at":       time.Now().UTC(),
+		"total_hash_rate_th": total,
+		"regions":            regions,
+	})
+}
+
+// Energy Quota Handlers
+
+// SetEnergyQuota sets an operator's monthly energy quota in a region, or the
+// region's default quota
+func (h *HTTPHandler) SetEnergyQuota(c *gin.Context) {
+	var req domain.EnergyQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	quota, err := h.quotaSvc.SetQuota(c.Request.Context(), &req)
+	if err != nil {
+		writeQuotaError(c, "Failed to set energy quota", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+// ListEnergyQuotas lists the energy quotas, optionally of one region
+func (h *HTTPHandler) ListEnergyQuotas(c *gin.Context) {
+	quotas, err := h.quotaSvc.ListQuotas(c.Request.Context(), c.Query("region_code"))
+	if err != nil {
+		writeQuotaError(c, "Failed to list energy quotas", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"quotas": quotas,
+		"count":  len(quotas),
+	})
+}
+
+// DeleteEnergyQuota deletes an energy quota
+func (h *HTTPHandler) DeleteEnergyQuota(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid quota ID format",
+		})
+		return
+	}
+
+	if err := h.quotaSvc.DeleteQuota(c.Request.Context(), id); err != nil {
+		writeQuotaError(c, "Failed to delete energy quota", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Energy quota deleted",
+	})
+}
+
+// EnforceEnergyQuotas runs energy quota enforcement immediately
+func (h *HTTPHandler) EnforceEnergyQuotas(c *gin.Context) {
+	result, err := h.quotaSvc.Enforce(c.Request.Context())
+	if err != nil {
+		writeQuotaError(c, "Failed to enforce energy quotas", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetOperatorEnergyQuota retrieves an operator's consumption and remaining
+// energy quota per region for the current month, or the month given as
+// YYYY-MM
+func (h *HTTPHandler) GetOperatorEnergyQuota(c *gin.Context) {
+	operatorID, err := uuid.Parse(c.Param("operator_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid operator ID format",
+		})
+		return
+	}
+
+	at := time.Now()
+	if monthStr := c.Query("month"); monthStr != "" {
+		at, err = time.Parse("2006-01", monthStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid month format, expected YYYY-MM",
+			})
+			return
+		}
+	}
+
+	statuses, err := h.quotaSvc.GetOperatorQuotaStatus(c.Request.Context(), operatorID, at)
+	if err != nil {
+		writeQuotaError(c, "Failed to retrieve energy quota", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"operator_id": operatorID,
+		"regions":     statuses,
+	})
+}
+
+// Mining Command Handlers
+
+// ListPoolCommands lists a pool's commands. Pool controllers poll this with
+// status=PENDING for commands to carry out.
+func (h *HTTPHandler) ListPoolCommands(c *gin.Context) {
+	poolID, err := uuid.Parse(c.Param("pool_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid pool ID format",
+		})
+		return
+	}
+
+	var statuses []domain.MiningCommandStatus
+	for _, status := range splitString(c.Query("status"), ",") {
+		statuses = append(statuses, domain.MiningCommandStatus(strings.ToUpper(strings.TrimSpace(status))))
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+
+	commands, err := h.quotaSvc.ListPoolCommands(c.Request.Context(), poolID, statuses, limit)
+	if err != nil {
+		writeQuotaError(c, "Failed to list mining commands", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pool_id":  poolID,
+		"commands": commands,
+		"count":    len(commands),
+	})
+}
+
+// UpdateCommandStatus records a pool controller's progress on a command
+func (h *HTTPHandler) UpdateCommandStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid command ID format",
+		})
+		return
+	}
+
+	var req struct {
+		Status domain.MiningCommandStatus `json:"status" binding:"required"`
+		Result string                     `json:"result"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Status is required",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	command, err := h.quotaSvc.UpdateCommandStatus(c.Request.Context(), id, req.Status, req.Result)
+	if err != nil {
+		writeQuotaError(c, "Failed to update mining command", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, command)
+}
+
+// writeQuotaError writes an energy quota or mining command service error with
+// the matching status code
+func writeQuotaError(c *gin.Context, message string, err error) {
+	var svcErr *service.ServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case "NOT_FOUND":
+			c.JSON(http.StatusNotFound, gin.H{"error": svcErr.Message})
+			return
+		case "INVALID_QUOTA", "INVALID_REQUEST":
+			c.JSON(http.StatusBadRequest, gin.H{"error": svcErr.Message})
+			return
+		case "INVALID_STATUS":
+			c.JSON(http.StatusConflict, gin.H{"error": svcErr.Message})
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+// Demand-Response Handlers
+
+// ScheduleDemandResponseEvent schedules a grid demand-response event
+func (h *HTTPHandler) ScheduleDemandResponseEvent(c *gin.Context) {
+	var req domain.DemandResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	event, err := h.demandSvc.ScheduleEvent(c.Request.Context(), &req)
+	if err != nil {
+		writeDemandResponseError(c, "Failed to schedule demand-response event", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, event)
+}
+
+// PreviewDemandResponse previews the pools a demand-response event would
+// throttle without scheduling it
+func (h *HTTPHandler) PreviewDemandResponse(c *gin.Context) {
+	var req domain.DemandResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	preview, err := h.demandSvc.PreviewRequest(c.Request.Context(), &req)
+	if err != nil {
+		writeDemandResponseError(c, "Failed to preview demand-response event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// ListDemandResponseEvents lists demand-response events, optionally filtered
+// by status
+func (h *HTTPHandler) ListDemandResponseEvents(c *gin.Context) {
+	var statuses []domain.DemandResponseStatus
+	for _, status := range splitString(c.Query("status"), ",") {
+		statuses = append(statuses, domain.DemandResponseStatus(strings.ToUpper(strings.TrimSpace(status))))
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	events, err := h.demandSvc.ListEvents(c.Request.Context(), statuses, limit, offset)
+	if err != nil {
+		writeDemandResponseError(c, "Failed to list demand-response events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"count":  len(events),
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetDemandResponseEvent retrieves a demand-response event with its participants
+func (h *HTTPHandler) GetDemandResponseEvent(c *gin.Context) {
+	id, ok := demandResponseEventID(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.demandSvc.GetEvent(c.Request.Context(), id)
+	if err != nil {
+		writeDemandResponseError(c, "Failed to retrieve demand-response event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// PreviewDemandResponseEvent returns the pools a scheduled event will throttle
+func (h *HTTPHandler) PreviewDemandResponseEvent(c *gin.Context) {
+	id, ok := demandResponseEventID(c)
+	if !ok {
+		return
+	}
+
+	preview, err := h.demandSvc.PreviewEvent(c.Request.Context(), id)
+	if err != nil {
+		writeDemandResponseError(c, "Failed to preview demand-response event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// CancelDemandResponseEvent cancels a scheduled or active demand-response event
+func (h *HTTPHandler) CancelDemandResponseEvent(c *gin.Context) {
+	id, ok := demandResponseEventID(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.demandSvc.CancelEvent(c.Request.Context(), id)
+	if err != nil {
+		writeDemandResponseError(c, "Failed to cancel demand-response event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// GetDemandResponseReport reports the participating pools' compliance with a
+// demand-response event
+func (h *HTTPHandler) GetDemandResponseReport(c *gin.Context) {
+	id, ok := demandResponseEventID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.demandSvc.GetReport(c.Request.Context(), id)
+	if err != nil {
+		writeDemandResponseError(c, "Failed to generate demand-response report", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// demandResponseEventID parses the event ID path parameter, writing a bad
+// request response if it is invalid
+func demandResponseEventID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid event ID format",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// writeDemandResponseError writes a demand-response service error with the
+// matching status code
+func writeDemandResponseError(c *gin.Context, message string, err error) {
+	var svcErr *service.ServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case "NOT_FOUND":
+			c.JSON(http.StatusNotFound, gin.H{"error": svcErr.Message})
+			return
+		case "INVALID_EVENT":
+			c.JSON(http.StatusBadRequest, gin.H{"error": svcErr.Message})
+			return
+		case "INVALID_STATUS":
+			c.JSON(http.StatusConflict, gin.H{"error": svcErr.Message})
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
 // Compliance Certificate Handlers
 
 // GetComplianceCertificate retrieves compliance certificate for a pool
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PostgresDemandResponseRepository implements DemandResponseRepository for PostgreSQL
+type PostgresDemandResponseRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDemandResponseRepository creates a new PostgreSQL demand-response repository
+func NewPostgresDemandResponseRepository(config PostgresConfig) (*PostgresDemandResponseRepository, error) {
+	connStr := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		config.Host, config.Port, config.Username, config.Password, config.Name, config.SSLMode,
+	)
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresDemandResponseRepository{db: db}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresDemandResponseRepository) Close() error {
+	return r.db.Close()
+}
+
+const demandResponseEventColumns = `id, name, reason, region_codes, start_at, end_at, target_reduction_mw,
+		notice_minutes, status, created_by, notified_at, started_at, ended_at, cancelled_at,
+		created_at, updated_at`
+
+const demandResponseParticipantColumns = `event_id, pool_id, pool_name, operator_id, operator_name,
+		region_code, contact_email, active_machines, baseline_kw, cap_kw, reduction_kw,
+		throttle_command_id, restore_command_id`
+
+// Create creates a new demand-response event
+func (r *PostgresDemandResponseRepository) Create(ctx context.Context, event *domain.DemandResponseEvent) error {
+	query := `INSERT INTO demand_response_events (` + demandResponseEventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID, event.Name, event.Reason, pq.Array(event.RegionCodes), event.StartAt, event.EndAt,
+		event.TargetReductionMW, event.NoticeMinutes, event.Status, event.CreatedBy,
+		event.NotifiedAt, event.StartedAt, event.EndedAt, event.CancelledAt, event.CreatedAt, event.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create demand-response event: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a demand-response event with its participants, or nil if
+// it does not exist
+func (r *PostgresDemandResponseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.DemandResponseEvent, error) {
+	query := `SELECT ` + demandResponseEventColumns + ` FROM demand_response_events WHERE id = $1`
+
+	event, err := scanDemandResponseEvent(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get demand-response event: %w", err)
+	}
+
+	query = `SELECT ` + demandResponseParticipantColumns + ` FROM demand_response_participants
+		WHERE event_id = $1 ORDER BY operator_name, pool_name`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get demand-response participants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p domain.DemandResponseParticipant
+		var throttleID, restoreID uuid.NullUUID
+		err := rows.Scan(
+			&p.EventID, &p.PoolID, &p.PoolName, &p.OperatorID, &p.OperatorName,
+			&p.RegionCode, &p.ContactEmail, &p.ActiveMachines, &p.BaselineKW, &p.CapKW, &p.ReductionKW,
+			&throttleID, &restoreID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan demand-response participant: %w", err)
+		}
+		if throttleID.Valid {
+			p.ThrottleCommandID = &throttleID.UUID
+		}
+		if restoreID.Valid {
+			p.RestoreCommandID = &restoreID.UUID
+		}
+		event.Participants = append(event.Participants, p)
+	}
+
+	return event, rows.Err()
+}
+
+// Update records a demand-response event's progress
+func (r *PostgresDemandResponseRepository) Update(ctx context.Context, event *domain.DemandResponseEvent) error {
+	query := `UPDATE demand_response_events SET status = $2, notified_at = $3, started_at = $4,
+		ended_at = $5, cancelled_at = $6, updated_at = $7 WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID, event.Status, event.NotifiedAt, event.StartedAt, event.EndedAt, event.CancelledAt, event.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update demand-response event: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves demand-response events with one of the given statuses, or
+// all events when no status is given, by start time
+func (r *PostgresDemandResponseRepository) List(ctx context.Context, statuses []domain.DemandResponseStatus, limit, offset int) ([]domain.DemandResponseEvent, error) {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	query := `SELECT ` + demandResponseEventColumns + ` FROM demand_response_events
+		WHERE cardinality($1::text[]) = 0 OR status = ANY($1)
+		ORDER BY start_at, id LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(names), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list demand-response events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.DemandResponseEvent
+	for rows.Next() {
+		event, err := scanDemandResponseEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan demand-response event: %w", err)
+		}
+		events = append(events, *event)
+	}
+
+	return events, rows.Err()
+}
+
+// ReplaceParticipants replaces the participants of a demand-response event
+func (r *PostgresDemandResponseRepository) ReplaceParticipants(ctx context.Context, eventID uuid.UUID, participants []domain.DemandResponseParticipant) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM demand_response_participants WHERE event_id = $1`, eventID); err != nil {
+		return fmt.Errorf("failed to delete demand-response participants: %w", err)
+	}
+
+	query := `INSERT INTO demand_response_participants (` + demandResponseParticipantColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	for _, p := range participants {
+		_, err := tx.ExecContext(ctx, query,
+			eventID, p.PoolID, p.PoolName, p.OperatorID, p.OperatorName,
+			p.RegionCode, p.ContactEmail, p.ActiveMachines, p.BaselineKW, p.CapKW, p.ReductionKW,
+			nullUUID(p.ThrottleCommandID), nullUUID(p.RestoreCommandID),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create demand-response participant: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateParticipant records the commands issued to a participating pool
+func (r *PostgresDemandResponseRepository) UpdateParticipant(ctx context.Context, participant *domain.DemandResponseParticipant) error {
+	query := `UPDATE demand_response_participants SET throttle_command_id = $3, restore_command_id = $4
+		WHERE event_id = $1 AND pool_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query,
+		participant.EventID, participant.PoolID,
+		nullUUID(participant.ThrottleCommandID), nullUUID(participant.RestoreCommandID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update demand-response participant: %w", err)
+	}
+
+	return nil
+}
+
+// scanDemandResponseEvent scans a demand_response_events row
+func scanDemandResponseEvent(row rowScanner) (*domain.DemandResponseEvent, error) {
+	event := &domain.DemandResponseEvent{}
+	var notifiedAt, startedAt, endedAt, cancelledAt sql.NullTime
+
+	err := row.Scan(
+		&event.ID, &event.Name, &event.Reason, pq.Array(&event.RegionCodes), &event.StartAt, &event.EndAt,
+		&event.TargetReductionMW, &event.NoticeMinutes, &event.Status, &event.CreatedBy,
+		&notifiedAt, &startedAt, &endedAt, &cancelledAt, &event.CreatedAt, &event.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if notifiedAt.Valid {
+		event.NotifiedAt = &notifiedAt.Time
+	}
+	if startedAt.Valid {
+		event.StartedAt = &startedAt.Time
+	}
+	if endedAt.Valid {
+		event.EndedAt = &endedAt.Time
+	}
+	if cancelledAt.Valid {
+		event.CancelledAt = &cancelledAt.Time
+	}
+
+	return event, nil
+}
+
+// nullUUID converts an optional UUID to a nullable column value
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
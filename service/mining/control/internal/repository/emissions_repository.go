@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresEmissionsRepository implements EmissionsRepository for PostgreSQL
+type PostgresEmissionsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEmissionsRepository creates a new PostgreSQL emissions repository
+func NewPostgresEmissionsRepository(config PostgresConfig) (*PostgresEmissionsRepository, error) {
+	connStr := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		config.Host, config.Port, config.Username, config.Password, config.Name, config.SSLMode,
+	)
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresEmissionsRepository{db: db}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresEmissionsRepository) Close() error {
+	return r.db.Close()
+}
+
+// ReplaceHour replaces the emission records of a pool for one hour, so an
+// hour can be recomputed without double counting
+func (r *PostgresEmissionsRepository) ReplaceHour(ctx context.Context, poolID uuid.UUID, hourStart time.Time, records []domain.MinerEmissionRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM miner_emissions WHERE pool_id = $1 AND hour_start = $2`, poolID, hourStart)
+	if err != nil {
+		return fmt.Errorf("failed to delete emission records: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO miner_emissions (
+		id, machine_id, pool_id, operator_id, operator_name, region_code, hour_start,
+		energy_source, energy_kwh, emission_factor, emissions_kg, created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, record := range records {
+		_, err := stmt.ExecContext(ctx,
+			record.ID, record.MachineID, record.PoolID, record.OperatorID, record.OperatorName, record.RegionCode,
+			record.HourStart, record.EnergySource, record.EnergyKWh, record.EmissionFactor, record.EmissionsKG,
+			record.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert emission record: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetLatestHour returns the most recent hour with emission records, or nil if
+// none have been computed yet
+func (r *PostgresEmissionsRepository) GetLatestHour(ctx context.Context) (*time.Time, error) {
+	var latest sql.NullTime
+	err := r.db.QueryRowContext(ctx, `SELECT MAX(hour_start) FROM miner_emissions`).Scan(&latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest emissions hour: %w", err)
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+// ListByMachine retrieves the hourly emission records of a machine for a time range
+func (r *PostgresEmissionsRepository) ListByMachine(ctx context.Context, machineID uuid.UUID, startTime, endTime time.Time) ([]domain.MinerEmissionRecord, error) {
+	query := `SELECT id, machine_id, pool_id, operator_id, operator_name, region_code, hour_start,
+		energy_source, energy_kwh, emission_factor, emissions_kg, created_at
+		FROM miner_emissions WHERE machine_id = $1 AND hour_start >= $2 AND hour_start < $3
+		ORDER BY hour_start, energy_source`
+
+	rows, err := r.db.QueryContext(ctx, query, machineID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emission records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []domain.MinerEmissionRecord
+	for rows.Next() {
+		var record domain.MinerEmissionRecord
+		err := rows.Scan(
+			&record.ID, &record.MachineID, &record.PoolID, &record.OperatorID, &record.OperatorName, &record.RegionCode,
+			&record.HourStart, &record.EnergySource, &record.EnergyKWh, &record.EmissionFactor, &record.EmissionsKG,
+			&record.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan emission record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// Aggregate sums emissions per operator or region for hours starting in
+// [startTime, endTime), ordered by emissions descending
+func (r *PostgresEmissionsRepository) Aggregate(ctx context.Context, grouping domain.EmissionsGrouping, startTime, endTime time.Time) ([]domain.EmissionsSummary, error) {
+	var key, name string
+	switch grouping {
+	case domain.EmissionsGroupingOperator:
+		key, name = "operator_id::text", "MAX(operator_name)"
+	case domain.EmissionsGroupingRegion:
+		key, name = "region_code", "MAX(region_code)"
+	default:
+		return nil, fmt.Errorf("unknown emissions grouping: %s", grouping)
+	}
+
+	totalsQuery := fmt.Sprintf(`SELECT %s AS key, %s,
+		COALESCE(SUM(energy_kwh), 0), COALESCE(SUM(emissions_kg), 0),
+		COUNT(DISTINCT pool_id),
+		COUNT(DISTINCT machine_id) FILTER (WHERE machine_id <> $3)
+		FROM miner_emissions WHERE hour_start >= $1 AND hour_start < $2
+		GROUP BY key ORDER BY 4 DESC`, key, name)
+
+	rows, err := r.db.QueryContext(ctx, totalsQuery, startTime, endTime, uuid.Nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate emissions: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []domain.EmissionsSummary
+	index := make(map[string]int)
+	for rows.Next() {
+		summary := domain.EmissionsSummary{
+			Grouping:    grouping,
+			PeriodStart: startTime,
+			PeriodEnd:   endTime,
+			BySource:    make(map[domain.EnergySourceType]decimal.Decimal),
+		}
+		err := rows.Scan(
+			&summary.Key, &summary.Name, &summary.EnergyKWh, &summary.EmissionsKG,
+			&summary.PoolCount, &summary.MachineCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan emissions summary: %w", err)
+		}
+		index[summary.Key] = len(summaries)
+		summaries = append(summaries, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to aggregate emissions: %w", err)
+	}
+
+	sourceQuery := fmt.Sprintf(`SELECT %s AS key, energy_source, COALESCE(SUM(emissions_kg), 0)
+		FROM miner_emissions WHERE hour_start >= $1 AND hour_start < $2
+		GROUP BY key, energy_source`, key)
+
+	sourceRows, err := r.db.QueryContext(ctx, sourceQuery, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate emissions by source: %w", err)
+	}
+	defer sourceRows.Close()
+
+	for sourceRows.Next() {
+		var groupKey string
+		var source domain.EnergySourceType
+		var emissions decimal.Decimal
+		if err := sourceRows.Scan(&groupKey, &source, &emissions); err != nil {
+			return nil, fmt.Errorf("failed to scan emissions by source: %w", err)
+		}
+		if i, ok := index[groupKey]; ok {
+			summaries[i].BySource[source] = emissions
+		}
+	}
+
+	return summaries, sourceRows.Err()
+}
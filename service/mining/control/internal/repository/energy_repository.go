@@ -201,6 +201,19 @@ func (r *TimescaleEnergyRepository) GetAggregated(ctx context.Context, poolID uu
 	return agg, nil
 }
 
+// GetTotalKWh sums the energy a pool reported for [startTime, endTime)
+func (r *TimescaleEnergyRepository) GetTotalKWh(ctx context.Context, poolID uuid.UUID, startTime, endTime time.Time) (decimal.Decimal, error) {
+	query := `SELECT COALESCE(SUM(power_usage_kwh), 0)
+		FROM energy_consumption WHERE pool_id = $1 AND time >= $2 AND time < $3`
+
+	var total decimal.Decimal
+	if err := r.db.QueryRowContext(ctx, query, poolID, startTime, endTime).Scan(&total); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get total energy: %w", err)
+	}
+
+	return total, nil
+}
+
 // Import for decimal
 import "github.com/shopspring/decimal"
 import "encoding/json"
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/shared/resilience"
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+)
+
+// complianceUpstream names the compliance service for retries and circuit breaking
+const complianceUpstream = "compliance"
+
+// ComplianceLicenseClient implements LicenseRegistry against the licenses API
+// of the Compliance Management module. Licenses are cached for cacheTTL, so a
+// reconciliation run looks up each license once. Requests are retried and the
+// service's circuit opened under the given policy; while the service is
+// unavailable, a license cached within staleTTL is returned instead.
+type ComplianceLicenseClient struct {
+	endpoint string
+	client   *http.Client
+	cacheTTL time.Duration
+	staleTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedLicense
+}
+
+type cachedLicense struct {
+	license   *domain.MinerLicense
+	fetchedAt time.Time
+}
+
+// NewComplianceLicenseClient creates a new compliance service license client
+func NewComplianceLicenseClient(endpoint string, cacheTTL, staleTTL time.Duration, policy *resilience.Client) *ComplianceLicenseClient {
+	return &ComplianceLicenseClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Transport: &resilience.Transport{Client: policy, Upstream: complianceUpstream}},
+		cacheTTL: cacheTTL,
+		staleTTL: max(staleTTL, cacheTTL),
+		cache:    make(map[uuid.UUID]cachedLicense),
+	}
+}
+
+// GetLicense retrieves a license by ID, returning nil if the compliance
+// service does not know it
+func (c *ComplianceLicenseClient) GetLicense(ctx context.Context, id uuid.UUID) (*domain.MinerLicense, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[id]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < c.cacheTTL {
+		return cached.license, nil
+	}
+
+	license, err := c.fetchLicense(ctx, id)
+	if err != nil {
+		if ok && resilience.IsUnavailable(err) && time.Since(cached.fetchedAt) < c.staleTTL {
+			return cached.license, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[id] = cachedLicense{license: license, fetchedAt: time.Now()}
+	c.mu.Unlock()
+
+	return license, nil
+}
+
+// fetchLicense asks the compliance service for a license
+func (c *ComplianceLicenseClient) fetchLicense(ctx context.Context, id uuid.UUID) (*domain.MinerLicense, error) {
+	url := fmt.Sprintf("%s/api/v1/compliance/licenses/%s", c.endpoint, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build license request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get license: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var license *domain.MinerLicense
+	switch resp.StatusCode {
+	case http.StatusOK:
+		license = &domain.MinerLicense{}
+		if err := json.NewDecoder(resp.Body).Decode(license); err != nil {
+			return nil, fmt.Errorf("failed to decode license: %w", err)
+		}
+	case http.StatusNotFound:
+	default:
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("failed to get license: %w", &resilience.StatusError{StatusCode: resp.StatusCode})
+		}
+		return nil, fmt.Errorf("failed to get license: compliance service returned %s", resp.Status)
+	}
+
+	return license, nil
+}
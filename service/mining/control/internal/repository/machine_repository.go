@@ -48,15 +48,15 @@ func (r *PostgresMachineRepository) Close() error {
 // Create creates a new mining machine
 func (r *PostgresMachineRepository) Create(ctx context.Context, machine *domain.MiningMachine) error {
 	query := `INSERT INTO mining_machines (
-		id, serial_number, pool_id, model_type, manufacturer, model_name,
+		id, serial_number, pool_id, license_id, model_type, manufacturer, model_name,
 		hash_rate_spec_th, power_spec_watts, location_id, location_description,
 		gps_coordinates, installation_date, last_maintenance_date, status, is_active,
 		current_hash_rate_th, current_power_watts, total_energy_kwh, uptime_hours,
 		created_at, updated_at, decommissioned_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`
 
 	_, err := r.db.ExecContext(ctx, query,
-		machine.ID, machine.SerialNumber, machine.PoolID, machine.ModelType, machine.Manufacturer, machine.ModelName,
+		machine.ID, machine.SerialNumber, machine.PoolID, machine.LicenseID, machine.ModelType, machine.Manufacturer, machine.ModelName,
 		machine.HashRateSpecTH, machine.PowerSpecWatts, machine.LocationID, machine.LocationDescription,
 		machine.GPSCoordinates, machine.InstallationDate, machine.LastMaintenanceDate, machine.Status, machine.IsActive,
 		machine.CurrentHashRateTH, machine.CurrentPowerWatts, machine.TotalEnergyKWh, machine.UptimeHours,
@@ -72,7 +72,7 @@ func (r *PostgresMachineRepository) Create(ctx context.Context, machine *domain.
 
 // GetByID retrieves a mining machine by ID
 func (r *PostgresMachineRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.MiningMachine, error) {
-	query := `SELECT id, serial_number, pool_id, model_type, manufacturer, model_name,
+	query := `SELECT id, serial_number, pool_id, license_id, model_type, manufacturer, model_name,
 		hash_rate_spec_th, power_spec_watts, location_id, location_description,
 		gps_coordinates, installation_date, last_maintenance_date, status, is_active,
 		current_hash_rate_th, current_power_watts, total_energy_kwh, uptime_hours,
@@ -81,7 +81,7 @@ func (r *PostgresMachineRepository) GetByID(ctx context.Context, id uuid.UUID) (
 
 	machine := &domain.MiningMachine{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&machine.ID, &machine.SerialNumber, &machine.PoolID, &machine.ModelType, &machine.Manufacturer, &machine.ModelName,
+		&machine.ID, &machine.SerialNumber, &machine.PoolID, &machine.LicenseID, &machine.ModelType, &machine.Manufacturer, &machine.ModelName,
 		&machine.HashRateSpecTH, &machine.PowerSpecWatts, &machine.LocationID, &machine.LocationDescription,
 		&machine.GPSCoordinates, &machine.InstallationDate, &machine.LastMaintenanceDate, &machine.Status, &machine.IsActive,
 		&machine.CurrentHashRateTH, &machine.CurrentPowerWatts, &machine.TotalEnergyKWh, &machine.UptimeHours,
@@ -100,7 +100,7 @@ func (r *PostgresMachineRepository) GetByID(ctx context.Context, id uuid.UUID) (
 
 // GetBySerialNumber retrieves a mining machine by serial number
 func (r *PostgresMachineRepository) GetBySerialNumber(ctx context.Context, serialNumber string) (*domain.MiningMachine, error) {
-	query := `SELECT id, serial_number, pool_id, model_type, manufacturer, model_name,
+	query := `SELECT id, serial_number, pool_id, license_id, model_type, manufacturer, model_name,
 		hash_rate_spec_th, power_spec_watts, location_id, location_description,
 		gps_coordinates, installation_date, last_maintenance_date, status, is_active,
 		current_hash_rate_th, current_power_watts, total_energy_kwh, uptime_hours,
@@ -109,7 +109,7 @@ func (r *PostgresMachineRepository) GetBySerialNumber(ctx context.Context, seria
 
 	machine := &domain.MiningMachine{}
 	err := r.db.QueryRowContext(ctx, query, serialNumber).Scan(
-		&machine.ID, &machine.SerialNumber, &machine.PoolID, &machine.ModelType, &machine.Manufacturer, &machine.ModelName,
+		&machine.ID, &machine.SerialNumber, &machine.PoolID, &machine.LicenseID, &machine.ModelType, &machine.Manufacturer, &machine.ModelName,
 		&machine.HashRateSpecTH, &machine.PowerSpecWatts, &machine.LocationID, &machine.LocationDescription,
 		&machine.GPSCoordinates, &machine.InstallationDate, &machine.LastMaintenanceDate, &machine.Status, &machine.IsActive,
 		&machine.CurrentHashRateTH, &machine.CurrentPowerWatts, &machine.TotalEnergyKWh, &machine.UptimeHours,
@@ -131,13 +131,13 @@ func (r *PostgresMachineRepository) Update(ctx context.Context, machine *domain.
 	query := `UPDATE mining_machines SET
 		location_description = $1, last_maintenance_date = $2, status = $3, is_active = $4,
 		current_hash_rate_th = $5, current_power_watts = $6, total_energy_kwh = $7,
-		uptime_hours = $8, updated_at = $9, decommissioned_at = $10
-		WHERE id = $11`
+		uptime_hours = $8, updated_at = $9, decommissioned_at = $10, license_id = $11
+		WHERE id = $12`
 
 	_, err := r.db.ExecContext(ctx, query,
 		machine.LocationDescription, machine.LastMaintenanceDate, machine.Status, machine.IsActive,
 		machine.CurrentHashRateTH, machine.CurrentPowerWatts, machine.TotalEnergyKWh,
-		machine.UptimeHours, machine.UpdatedAt, machine.DecommissionedAt, machine.ID,
+		machine.UptimeHours, machine.UpdatedAt, machine.DecommissionedAt, machine.LicenseID, machine.ID,
 	)
 
 	if err != nil {
@@ -159,7 +159,7 @@ func (r *PostgresMachineRepository) Delete(ctx context.Context, id uuid.UUID) er
 
 // ListByPool retrieves all machines for a pool
 func (r *PostgresMachineRepository) ListByPool(ctx context.Context, poolID uuid.UUID, activeOnly bool, limit, offset int) ([]domain.MiningMachine, error) {
-	query := `SELECT id, serial_number, pool_id, model_type, manufacturer, model_name,
+	query := `SELECT id, serial_number, pool_id, license_id, model_type, manufacturer, model_name,
 		hash_rate_spec_th, power_spec_watts, location_id, location_description,
 		gps_coordinates, installation_date, last_maintenance_date, status, is_active,
 		current_hash_rate_th, current_power_watts, total_energy_kwh, uptime_hours,
@@ -182,7 +182,7 @@ func (r *PostgresMachineRepository) ListByPool(ctx context.Context, poolID uuid.
 	for rows.Next() {
 		machine := &domain.MiningMachine{}
 		err := rows.Scan(
-			&machine.ID, &machine.SerialNumber, &machine.PoolID, &machine.ModelType, &machine.Manufacturer, &machine.ModelName,
+			&machine.ID, &machine.SerialNumber, &machine.PoolID, &machine.LicenseID, &machine.ModelType, &machine.Manufacturer, &machine.ModelName,
 			&machine.HashRateSpecTH, &machine.PowerSpecWatts, &machine.LocationID, &machine.LocationDescription,
 			&machine.GPSCoordinates, &machine.InstallationDate, &machine.LastMaintenanceDate, &machine.Status, &machine.IsActive,
 			&machine.CurrentHashRateTH, &machine.CurrentPowerWatts, &machine.TotalEnergyKWh, &machine.UptimeHours,
@@ -213,6 +213,45 @@ func (r *PostgresMachineRepository) CountByPool(ctx context.Context, poolID uuid
 	return count, nil
 }
 
+// ListLocationsInBounds retrieves the location and current output of every
+// machine that has not been decommissioned within the bounding box. Machines
+// without their own coordinates are placed at their pool's facility.
+func (r *PostgresMachineRepository) ListLocationsInBounds(ctx context.Context, bbox domain.BoundingBox) ([]domain.MachineLocation, error) {
+	query := `SELECT id, pool_id, latitude, longitude, current_hash_rate_th, current_power_watts
+		FROM (
+			SELECT m.id, m.pool_id,
+				(COALESCE(m.gps_coordinates, p.gps_coordinates)->>'latitude')::double precision AS latitude,
+				(COALESCE(m.gps_coordinates, p.gps_coordinates)->>'longitude')::double precision AS longitude,
+				m.current_hash_rate_th, m.current_power_watts
+			FROM mining_machines m
+			JOIN mining_pools p ON p.id = m.pool_id
+			WHERE m.status <> 'DECOMMISSIONED'
+			AND COALESCE(m.gps_coordinates, p.gps_coordinates) IS NOT NULL
+		) locations
+		WHERE latitude BETWEEN $1 AND $2 AND longitude BETWEEN $3 AND $4`
+
+	rows, err := r.db.QueryContext(ctx, query, bbox.MinLat, bbox.MaxLat, bbox.MinLon, bbox.MaxLon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine locations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []domain.MachineLocation
+	for rows.Next() {
+		var location domain.MachineLocation
+		err := rows.Scan(
+			&location.MachineID, &location.PoolID, &location.Latitude, &location.Longitude,
+			&location.CurrentHashRateTH, &location.CurrentPowerWatts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan machine location: %w", err)
+		}
+		locations = append(locations, location)
+	}
+
+	return locations, rows.Err()
+}
+
 // BatchCreate creates multiple mining machines
 func (r *PostgresMachineRepository) BatchCreate(ctx context.Context, machines []domain.MiningMachine) error {
 	if len(machines) == 0 {
@@ -226,12 +265,12 @@ func (r *PostgresMachineRepository) BatchCreate(ctx context.Context, machines []
 	defer tx.Rollback()
 
 	stmt, err := tx.PrepareContext(ctx, `INSERT INTO mining_machines (
-		id, serial_number, pool_id, model_type, manufacturer, model_name,
+		id, serial_number, pool_id, license_id, model_type, manufacturer, model_name,
 		hash_rate_spec_th, power_spec_watts, location_id, location_description,
 		gps_coordinates, installation_date, status, is_active,
 		current_hash_rate_th, current_power_watts, total_energy_kwh, uptime_hours,
 		created_at, updated_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`)
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
@@ -239,7 +278,7 @@ func (r *PostgresMachineRepository) BatchCreate(ctx context.Context, machines []
 
 	for _, machine := range machines {
 		_, err := stmt.ExecContext(ctx,
-			machine.ID, machine.SerialNumber, machine.PoolID, machine.ModelType, machine.Manufacturer, machine.ModelName,
+			machine.ID, machine.SerialNumber, machine.PoolID, machine.LicenseID, machine.ModelType, machine.Manufacturer, machine.ModelName,
 			machine.HashRateSpecTH, machine.PowerSpecWatts, machine.LocationID, machine.LocationDescription,
 			machine.GPSCoordinates, machine.InstallationDate, machine.Status, machine.IsActive,
 			machine.CurrentHashRateTH, machine.CurrentPowerWatts, machine.TotalEnergyKWh, machine.UptimeHours,
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/csic/mining-control/internal/domain"
+)
+
+// OperatorNotifierConfig holds the webhook and email settings for operator notices
+type OperatorNotifierConfig struct {
+	WebhookURL    string
+	WebhookSecret string
+	Timeout       time.Duration
+	SMTPHost      string
+	SMTPPort      int
+	SMTPUsername  string
+	SMTPPassword  string
+	From          string
+}
+
+// OperatorNotifier sends operator notices as a JSON webhook and by email to
+// the contacts of the operator's pools. Either channel is skipped when it is
+// not configured.
+type OperatorNotifier struct {
+	config OperatorNotifierConfig
+	client *http.Client
+}
+
+// NewOperatorNotifier creates a new operator notifier
+func NewOperatorNotifier(config OperatorNotifierConfig) *OperatorNotifier {
+	return &OperatorNotifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Notify sends a notice over every configured channel, attempting each even
+// if another fails
+func (n *OperatorNotifier) Notify(ctx context.Context, notice *domain.OperatorNotice) error {
+	var errs []error
+	if n.config.WebhookURL != "" {
+		if err := n.postWebhook(ctx, notice); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if n.config.SMTPHost != "" && len(notice.Emails) > 0 {
+		if err := n.sendEmail(notice); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postWebhook posts the notice as JSON. When a secret is configured the body
+// is signed with HMAC-SHA256 in the X-CSIC-Signature header.
+func (n *OperatorNotifier) postWebhook(ctx context.Context, notice *domain.OperatorNotice) error {
+	body, err := json.Marshal(notice)
+	if err != nil {
+		return fmt.Errorf("failed to encode notice: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.config.WebhookSecret != "" {
+		mac := hmac.New(sha256.New, []byte(n.config.WebhookSecret))
+		mac.Write(body)
+		req.Header.Set("X-CSIC-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post webhook: endpoint returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// sendEmail emails the notice to the operator's contacts
+func (n *OperatorNotifier) sendEmail(notice *domain.OperatorNotice) error {
+	addr := net.JoinHostPort(n.config.SMTPHost, strconv.Itoa(n.config.SMTPPort))
+
+	var auth smtp.Auth
+	if n.config.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", n.config.SMTPUsername, n.config.SMTPPassword, n.config.SMTPHost)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(notice.Emails, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", notice.Subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", notice.SentAt.Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(notice.Message, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	if err := smtp.SendMail(addr, auth, n.config.From, notice.Emails, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// PostgresEnergyQuotaRepository implements EnergyQuotaRepository for PostgreSQL
+type PostgresEnergyQuotaRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEnergyQuotaRepository creates a new PostgreSQL energy quota repository
+func NewPostgresEnergyQuotaRepository(config PostgresConfig) (*PostgresEnergyQuotaRepository, error) {
+	connStr := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		config.Host, config.Port, config.Username, config.Password, config.Name, config.SSLMode,
+	)
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresEnergyQuotaRepository{db: db}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresEnergyQuotaRepository) Close() error {
+	return r.db.Close()
+}
+
+// Upsert creates the quota or replaces the one set for the same region and
+// operator, keeping its ID and creation time
+func (r *PostgresEnergyQuotaRepository) Upsert(ctx context.Context, quota *domain.EnergyQuota) error {
+	query := `INSERT INTO energy_quotas (id, region_code, operator_id, monthly_mwh, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (region_code, operator_id) DO UPDATE SET
+			monthly_mwh = EXCLUDED.monthly_mwh,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		quota.ID, quota.RegionCode, quota.OperatorID, quota.MonthlyMWh, quota.CreatedAt, quota.UpdatedAt,
+	).Scan(&quota.ID, &quota.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert energy quota: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an energy quota by ID, or nil if it does not exist
+func (r *PostgresEnergyQuotaRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.EnergyQuota, error) {
+	query := `SELECT id, region_code, operator_id, monthly_mwh, created_at, updated_at
+		FROM energy_quotas WHERE id = $1`
+
+	quota := &domain.EnergyQuota{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&quota.ID, &quota.RegionCode, &quota.OperatorID, &quota.MonthlyMWh, &quota.CreatedAt, &quota.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get energy quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+// Delete deletes an energy quota
+func (r *PostgresEnergyQuotaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM energy_quotas WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete energy quota: %w", err)
+	}
+	return nil
+}
+
+// List retrieves the energy quotas of a region, or of all regions when the
+// region code is empty, region defaults first
+func (r *PostgresEnergyQuotaRepository) List(ctx context.Context, regionCode string) ([]domain.EnergyQuota, error) {
+	query := `SELECT id, region_code, operator_id, monthly_mwh, created_at, updated_at
+		FROM energy_quotas WHERE ($1 = '' OR region_code = $1)
+		ORDER BY region_code, operator_id <> $2, operator_id`
+
+	rows, err := r.db.QueryContext(ctx, query, regionCode, uuid.Nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list energy quotas: %w", err)
+	}
+	defer rows.Close()
+
+	var quotas []domain.EnergyQuota
+	for rows.Next() {
+		var quota domain.EnergyQuota
+		err := rows.Scan(
+			&quota.ID, &quota.RegionCode, &quota.OperatorID, &quota.MonthlyMWh, &quota.CreatedAt, &quota.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan energy quota: %w", err)
+		}
+		quotas = append(quotas, quota)
+	}
+
+	return quotas, rows.Err()
+}
+
+// GetEffective returns the operator's quota in the region, falling back to the
+// region's default, or nil if neither is set
+func (r *PostgresEnergyQuotaRepository) GetEffective(ctx context.Context, regionCode string, operatorID uuid.UUID) (*domain.EnergyQuota, error) {
+	query := `SELECT id, region_code, operator_id, monthly_mwh, created_at, updated_at
+		FROM energy_quotas WHERE region_code = $1 AND operator_id IN ($2, $3)
+		ORDER BY operator_id = $3 LIMIT 1`
+
+	quota := &domain.EnergyQuota{}
+	err := r.db.QueryRowContext(ctx, query, regionCode, operatorID, uuid.Nil).Scan(
+		&quota.ID, &quota.RegionCode, &quota.OperatorID, &quota.MonthlyMWh, &quota.CreatedAt, &quota.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective energy quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+// PostgresMiningCommandRepository implements MiningCommandRepository for PostgreSQL
+type PostgresMiningCommandRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMiningCommandRepository creates a new PostgreSQL mining command repository
+func NewPostgresMiningCommandRepository(config PostgresConfig) (*PostgresMiningCommandRepository, error) {
+	connStr := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		config.Host, config.Port, config.Username, config.Password, config.Name, config.SSLMode,
+	)
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresMiningCommandRepository{db: db}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresMiningCommandRepository) Close() error {
+	return r.db.Close()
+}
+
+const miningCommandColumns = `id, pool_id, type, status, target_power_kw, reason, issued_by,
+		issued_at, acknowledged_at, completed_at, result`
+
+// Create queues a new mining command
+func (r *PostgresMiningCommandRepository) Create(ctx context.Context, command *domain.MiningCommand) error {
+	query := `INSERT INTO mining_commands (` + miningCommandColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))`
+
+	_, err := r.db.ExecContext(ctx, query,
+		command.ID, command.PoolID, command.Type, command.Status, nullDecimal(command.TargetPowerKW),
+		command.Reason, command.IssuedBy, command.IssuedAt, command.AcknowledgedAt, command.CompletedAt,
+		command.Result,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create mining command: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a mining command by ID, or nil if it does not exist
+func (r *PostgresMiningCommandRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.MiningCommand, error) {
+	query := `SELECT ` + miningCommandColumns + ` FROM mining_commands WHERE id = $1`
+
+	command, err := scanMiningCommand(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mining command: %w", err)
+	}
+
+	return command, nil
+}
+
+// Update records a mining command's progress
+func (r *PostgresMiningCommandRepository) Update(ctx context.Context, command *domain.MiningCommand) error {
+	query := `UPDATE mining_commands SET status = $2, acknowledged_at = $3, completed_at = $4,
+		result = NULLIF($5, '') WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query,
+		command.ID, command.Status, command.AcknowledgedAt, command.CompletedAt, command.Result,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update mining command: %w", err)
+	}
+
+	return nil
+}
+
+// ListByPool retrieves a pool's commands with one of the given statuses, or
+// all of its commands when no status is given, most recent first
+func (r *PostgresMiningCommandRepository) ListByPool(ctx context.Context, poolID uuid.UUID, statuses []domain.MiningCommandStatus, limit int) ([]domain.MiningCommand, error) {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+
+	query := `SELECT ` + miningCommandColumns + ` FROM mining_commands
+		WHERE pool_id = $1 AND (cardinality($2::text[]) = 0 OR status = ANY($2))
+		ORDER BY issued_at DESC LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, poolID, pq.Array(names), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mining commands: %w", err)
+	}
+	defer rows.Close()
+
+	var commands []domain.MiningCommand
+	for rows.Next() {
+		command, err := scanMiningCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan mining command: %w", err)
+		}
+		commands = append(commands, *command)
+	}
+
+	return commands, rows.Err()
+}
+
+// GetLatestByPool returns the pool's most recent command of one of the given
+// types, or nil if there is none
+func (r *PostgresMiningCommandRepository) GetLatestByPool(ctx context.Context, poolID uuid.UUID, types []domain.MiningCommandType) (*domain.MiningCommand, error) {
+	names := make([]string, len(types))
+	for i, commandType := range types {
+		names[i] = string(commandType)
+	}
+
+	query := `SELECT ` + miningCommandColumns + ` FROM mining_commands
+		WHERE pool_id = $1 AND type = ANY($2) ORDER BY issued_at DESC LIMIT 1`
+
+	command, err := scanMiningCommand(r.db.QueryRowContext(ctx, query, poolID, pq.Array(names)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest mining command: %w", err)
+	}
+
+	return command, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanMiningCommand scans a mining_commands row
+func scanMiningCommand(row rowScanner) (*domain.MiningCommand, error) {
+	command := &domain.MiningCommand{}
+	var target decimal.NullDecimal
+	var acknowledgedAt, completedAt sql.NullTime
+	var result sql.NullString
+
+	err := row.Scan(
+		&command.ID, &command.PoolID, &command.Type, &command.Status, &target, &command.Reason,
+		&command.IssuedBy, &command.IssuedAt, &acknowledgedAt, &completedAt, &result,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Valid {
+		command.TargetPowerKW = &target.Decimal
+	}
+	if acknowledgedAt.Valid {
+		command.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if completedAt.Valid {
+		command.CompletedAt = &completedAt.Time
+	}
+	command.Result = result.String
+
+	return command, nil
+}
+
+// nullDecimal converts an optional decimal to a nullable column value
+func nullDecimal(d *decimal.Decimal) decimal.NullDecimal {
+	if d == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: *d, Valid: true}
+}
@@ -0,0 +1,728 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// demandResponseCommandIssuer identifies commands issued for demand-response events
+const demandResponseCommandIssuer = "demand-response"
+
+// demandResponsePageSize is the page size used when listing events and pools
+const demandResponsePageSize = 1000
+
+// Operator notice types sent for demand-response events
+const (
+	NoticeDemandResponseScheduled = "DEMAND_RESPONSE_SCHEDULED"
+	NoticeDemandResponseCancelled = "DEMAND_RESPONSE_CANCELLED"
+)
+
+// DemandResponseRepository defines the interface for demand-response event persistence
+type DemandResponseRepository interface {
+	Create(ctx context.Context, event *domain.DemandResponseEvent) error
+	// GetByID returns the event with its participants, or nil if it does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.DemandResponseEvent, error)
+	Update(ctx context.Context, event *domain.DemandResponseEvent) error
+	// List returns events without their participants, by start time
+	List(ctx context.Context, statuses []domain.DemandResponseStatus, limit, offset int) ([]domain.DemandResponseEvent, error)
+	ReplaceParticipants(ctx context.Context, eventID uuid.UUID, participants []domain.DemandResponseParticipant) error
+	UpdateParticipant(ctx context.Context, participant *domain.DemandResponseParticipant) error
+}
+
+// OperatorNotifier defines the interface for sending notices to mining operators
+type OperatorNotifier interface {
+	Notify(ctx context.Context, notice *domain.OperatorNotice) error
+}
+
+// DemandResponseConfig holds configuration for the demand-response service
+type DemandResponseConfig struct {
+	Interval time.Duration
+	// NoticeMinutes is how long before an event operators are notified,
+	// unless the event sets its own notice period
+	NoticeMinutes int
+	// TolerancePercent is how far above its cap a pool may average during an
+	// event and still comply
+	TolerancePercent decimal.Decimal
+}
+
+// DemandResponseService schedules grid demand-response events. Operators are
+// notified ahead of an event, the participating pools are throttled through
+// the mining command queue when it starts and restored when it ends, and
+// their compliance is reported from energy telemetry.
+type DemandResponseService struct {
+	poolRepo    MiningPoolRepository
+	energyRepo  EnergyRepository
+	commandRepo MiningCommandRepository
+	eventRepo   DemandResponseRepository
+	notifier    OperatorNotifier
+	config      DemandResponseConfig
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewDemandResponseService creates a new demand-response service
+func NewDemandResponseService(poolRepo MiningPoolRepository, energyRepo EnergyRepository, commandRepo MiningCommandRepository, eventRepo DemandResponseRepository, notifier OperatorNotifier, config DemandResponseConfig) *DemandResponseService {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.NoticeMinutes < 0 {
+		config.NoticeMinutes = 0
+	}
+	return &DemandResponseService{
+		poolRepo:    poolRepo,
+		energyRepo:  energyRepo,
+		commandRepo: commandRepo,
+		eventRepo:   eventRepo,
+		notifier:    notifier,
+		config:      config,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// StartScheduler starts the background demand-response scheduler
+func (s *DemandResponseService) StartScheduler() {
+	s.wg.Add(1)
+	go s.scheduler()
+	log.Println("Demand-response scheduler started")
+}
+
+// StopScheduler stops the background demand-response scheduler
+func (s *DemandResponseService) StopScheduler() {
+	close(s.stopChan)
+	s.wg.Wait()
+	log.Println("Demand-response scheduler stopped")
+}
+
+// scheduler advances due events at the configured interval
+func (s *DemandResponseService) scheduler() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	s.runScheduler()
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.runScheduler()
+		}
+	}
+}
+
+// runScheduler notifies, starts and ends the events that are due
+func (s *DemandResponseService) runScheduler() {
+	ctx := context.Background()
+
+	statuses := []domain.DemandResponseStatus{domain.DemandResponseStatusScheduled, domain.DemandResponseStatusActive}
+	for offset := 0; ; offset += demandResponsePageSize {
+		events, err := s.eventRepo.List(ctx, statuses, demandResponsePageSize, offset)
+		if err != nil {
+			log.Printf("Failed to list demand-response events: %v", err)
+			return
+		}
+		for i := range events {
+			if err := s.advance(ctx, &events[i], time.Now()); err != nil {
+				log.Printf("Failed to advance demand-response event %s: %v", events[i].ID, err)
+			}
+		}
+		if len(events) < demandResponsePageSize {
+			return
+		}
+	}
+}
+
+// advance moves an event through its lifecycle as far as now allows
+func (s *DemandResponseService) advance(ctx context.Context, summary *domain.DemandResponseEvent, now time.Time) error {
+	due := false
+	switch summary.Status {
+	case domain.DemandResponseStatusScheduled:
+		due = (summary.NotifiedAt == nil && !now.Before(summary.NoticeAt())) || !now.Before(summary.StartAt)
+	case domain.DemandResponseStatusActive:
+		due = !now.Before(summary.EndAt)
+	}
+	if !due {
+		return nil
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, summary.ID)
+	if err != nil || event == nil {
+		return err
+	}
+
+	if event.Status == domain.DemandResponseStatusScheduled && !now.Before(event.EndAt) {
+		// The window passed without the scheduler running
+		log.Printf("Demand-response event %s (%s) ended before it could start", event.ID, event.Name)
+		event.Status = domain.DemandResponseStatusCancelled
+		event.CancelledAt = &now
+		return s.update(ctx, event)
+	}
+
+	if event.Status == domain.DemandResponseStatusScheduled && event.NotifiedAt == nil {
+		if err := s.notifyScheduled(ctx, event, now); err != nil {
+			return err
+		}
+	}
+
+	if event.Status == domain.DemandResponseStatusScheduled && !now.Before(event.StartAt) {
+		if err := s.start(ctx, event, now); err != nil {
+			return err
+		}
+	}
+
+	if event.Status == domain.DemandResponseStatusActive && !now.Before(event.EndAt) {
+		if err := s.restore(ctx, event, fmt.Sprintf("Demand-response event %q ended", event.Name)); err != nil {
+			return err
+		}
+		event.Status = domain.DemandResponseStatusCompleted
+		event.EndedAt = &now
+		if err := s.update(ctx, event); err != nil {
+			return err
+		}
+		log.Printf("Demand-response event %s (%s) completed", event.ID, event.Name)
+	}
+
+	return nil
+}
+
+// notifyScheduled fixes the event's participants and notifies their operators
+func (s *DemandResponseService) notifyScheduled(ctx context.Context, event *domain.DemandResponseEvent, now time.Time) error {
+	preview, err := s.preview(ctx, event.RegionCodes, event.TargetReductionMW)
+	if err != nil {
+		return err
+	}
+	for i := range preview.Participants {
+		preview.Participants[i].EventID = event.ID
+	}
+	if err := s.eventRepo.ReplaceParticipants(ctx, event.ID, preview.Participants); err != nil {
+		return &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to store demand-response participants",
+			Err:     err,
+		}
+	}
+	event.Participants = preview.Participants
+
+	if preview.ShortfallMW.IsPositive() {
+		log.Printf("Demand-response event %s (%s) can only reduce load by %s of %s MW",
+			event.ID, event.Name, preview.PlannedReductionMW.Round(3), event.TargetReductionMW)
+	}
+
+	s.notifyOperators(ctx, event, NoticeDemandResponseScheduled, func(participants []domain.DemandResponseParticipant) string {
+		lines := []string{fmt.Sprintf("Demand-response event %q runs from %s to %s (UTC). Limit the power draw of your pools to:",
+			event.Name, event.StartAt.UTC().Format(time.RFC3339), event.EndAt.UTC().Format(time.RFC3339))}
+		for _, p := range participants {
+			lines = append(lines, fmt.Sprintf("  %s (%s): %s kW, down from %s kW", p.PoolName, p.RegionCode, p.CapKW, p.BaselineKW.Round(3)))
+		}
+		lines = append(lines, "Pools are throttled automatically at the start of the event and restored at its end.")
+		if event.Reason != "" {
+			lines = append(lines, "Reason: "+event.Reason)
+		}
+		return strings.Join(lines, "\n")
+	})
+
+	event.NotifiedAt = &now
+	return s.update(ctx, event)
+}
+
+// start throttles the event's participants to their caps
+func (s *DemandResponseService) start(ctx context.Context, event *domain.DemandResponseEvent, now time.Time) error {
+	reason := fmt.Sprintf("Demand-response event %q until %s", event.Name, event.EndAt.UTC().Format(time.RFC3339))
+	for i := range event.Participants {
+		p := &event.Participants[i]
+		if p.ThrottleCommandID != nil {
+			continue
+		}
+		capKW := p.CapKW
+		command, err := issueMiningCommand(ctx, s.commandRepo, demandResponseCommandIssuer, p.PoolID, domain.MiningCommandThrottle, &capKW, reason)
+		if err != nil {
+			log.Printf("Failed to throttle pool %s for demand-response event %s: %v", p.PoolID, event.ID, err)
+			continue
+		}
+		p.ThrottleCommandID = &command.ID
+		if err := s.eventRepo.UpdateParticipant(ctx, p); err != nil {
+			log.Printf("Failed to record throttle of pool %s for demand-response event %s: %v", p.PoolID, event.ID, err)
+		}
+	}
+
+	event.Status = domain.DemandResponseStatusActive
+	event.StartedAt = &now
+	log.Printf("Demand-response event %s (%s) started, throttling %d pools", event.ID, event.Name, len(event.Participants))
+	return s.update(ctx, event)
+}
+
+// restore resumes the participants throttled for the event. A pool that has
+// been sent another throttle since, such as for an exceeded energy quota, is
+// left to that throttle.
+func (s *DemandResponseService) restore(ctx context.Context, event *domain.DemandResponseEvent, reason string) error {
+	types := []domain.MiningCommandType{domain.MiningCommandThrottle, domain.MiningCommandResume}
+	for i := range event.Participants {
+		p := &event.Participants[i]
+		if p.ThrottleCommandID == nil || p.RestoreCommandID != nil {
+			continue
+		}
+
+		latest, err := s.commandRepo.GetLatestByPool(ctx, p.PoolID, types)
+		if err != nil {
+			log.Printf("Failed to get latest command of pool %s: %v", p.PoolID, err)
+			continue
+		}
+		if latest == nil || latest.ID != *p.ThrottleCommandID {
+			continue
+		}
+
+		command, err := issueMiningCommand(ctx, s.commandRepo, demandResponseCommandIssuer, p.PoolID, domain.MiningCommandResume, nil, reason)
+		if err != nil {
+			log.Printf("Failed to restore pool %s after demand-response event %s: %v", p.PoolID, event.ID, err)
+			continue
+		}
+		p.RestoreCommandID = &command.ID
+		if err := s.eventRepo.UpdateParticipant(ctx, p); err != nil {
+			log.Printf("Failed to record restore of pool %s for demand-response event %s: %v", p.PoolID, event.ID, err)
+		}
+	}
+	return nil
+}
+
+// ScheduleEvent schedules a demand-response event
+func (s *DemandResponseService) ScheduleEvent(ctx context.Context, req *domain.DemandResponseRequest) (*domain.DemandResponseEvent, error) {
+	if err := s.validateRequest(req); err != nil {
+		return nil, err
+	}
+	if !req.StartAt.After(time.Now()) {
+		return nil, &ServiceError{
+			Code:    "INVALID_EVENT",
+			Message: "Event must start in the future",
+		}
+	}
+
+	notice := s.config.NoticeMinutes
+	if req.NoticeMinutes != nil {
+		notice = *req.NoticeMinutes
+	}
+
+	now := time.Now()
+	event := &domain.DemandResponseEvent{
+		ID:                uuid.New(),
+		Name:              req.Name,
+		Reason:            req.Reason,
+		RegionCodes:       req.RegionCodes,
+		StartAt:           req.StartAt.UTC(),
+		EndAt:             req.EndAt.UTC(),
+		TargetReductionMW: req.TargetReductionMW,
+		NoticeMinutes:     notice,
+		Status:            domain.DemandResponseStatusScheduled,
+		CreatedBy:         req.CreatedBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to store demand-response event",
+			Err:     err,
+		}
+	}
+
+	log.Printf("Scheduled demand-response event %s (%s): %s MW in %s from %s to %s",
+		event.ID, event.Name, event.TargetReductionMW, strings.Join(event.RegionCodes, ","),
+		event.StartAt.Format(time.RFC3339), event.EndAt.Format(time.RFC3339))
+
+	// Operators are notified right away if the notice period has begun
+	if err := s.advance(ctx, event, now); err != nil {
+		log.Printf("Failed to notify operators of demand-response event %s: %v", event.ID, err)
+	}
+
+	return event, nil
+}
+
+// PreviewRequest previews the pools an event with the given regions and
+// target would throttle, based on their current power draw
+func (s *DemandResponseService) PreviewRequest(ctx context.Context, req *domain.DemandResponseRequest) (*domain.DemandResponsePreview, error) {
+	if err := s.validateRequest(req); err != nil {
+		return nil, err
+	}
+	return s.preview(ctx, req.RegionCodes, req.TargetReductionMW)
+}
+
+// PreviewEvent returns the participants of an event. Until operators are
+// notified the allocation is previewed from the pools' current power draw.
+func (s *DemandResponseService) PreviewEvent(ctx context.Context, id uuid.UUID) (*domain.DemandResponsePreview, error) {
+	event, err := s.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if event.NotifiedAt == nil {
+		return s.preview(ctx, event.RegionCodes, event.TargetReductionMW)
+	}
+	return summarizeParticipants(event.RegionCodes, event.TargetReductionMW, event.Participants), nil
+}
+
+// GetEvent retrieves a demand-response event with its participants
+func (s *DemandResponseService) GetEvent(ctx context.Context, id uuid.UUID) (*domain.DemandResponseEvent, error) {
+	event, err := s.eventRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve demand-response event",
+			Err:     err,
+		}
+	}
+	if event == nil {
+		return nil, &ServiceError{
+			Code:    "NOT_FOUND",
+			Message: "Demand-response event not found",
+		}
+	}
+	return event, nil
+}
+
+// ListEvents lists demand-response events with one of the given statuses, or
+// all events when no status is given
+func (s *DemandResponseService) ListEvents(ctx context.Context, statuses []domain.DemandResponseStatus, limit, offset int) ([]domain.DemandResponseEvent, error) {
+	events, err := s.eventRepo.List(ctx, statuses, limit, offset)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to list demand-response events",
+			Err:     err,
+		}
+	}
+	return events, nil
+}
+
+// CancelEvent cancels a scheduled or active event. Operators already notified
+// are told of the cancellation and throttled pools are restored.
+func (s *DemandResponseService) CancelEvent(ctx context.Context, id uuid.UUID) (*domain.DemandResponseEvent, error) {
+	event, err := s.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch event.Status {
+	case domain.DemandResponseStatusScheduled:
+		if event.NotifiedAt != nil {
+			s.notifyOperators(ctx, event, NoticeDemandResponseCancelled, func([]domain.DemandResponseParticipant) string {
+				return fmt.Sprintf("Demand-response event %q from %s to %s (UTC) has been cancelled. No change to your pools is required.",
+					event.Name, event.StartAt.UTC().Format(time.RFC3339), event.EndAt.UTC().Format(time.RFC3339))
+			})
+		}
+	case domain.DemandResponseStatusActive:
+		if err := s.restore(ctx, event, fmt.Sprintf("Demand-response event %q was cancelled", event.Name)); err != nil {
+			return nil, err
+		}
+		event.EndedAt = &now
+	default:
+		return nil, &ServiceError{
+			Code:    "INVALID_STATUS",
+			Message: fmt.Sprintf("Event is already %s", event.Status),
+		}
+	}
+
+	event.Status = domain.DemandResponseStatusCancelled
+	event.CancelledAt = &now
+	if err := s.update(ctx, event); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Cancelled demand-response event %s (%s)", event.ID, event.Name)
+	return event, nil
+}
+
+// GetReport reports how the participating pools kept to their caps during an
+// event, from the energy they reported while it was active
+func (s *DemandResponseService) GetReport(ctx context.Context, id uuid.UUID) (*domain.DemandResponseReport, error) {
+	event, err := s.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if event.StartedAt == nil {
+		return nil, &ServiceError{
+			Code:    "INVALID_STATUS",
+			Message: "Event has not started",
+		}
+	}
+
+	start := *event.StartedAt
+	end := time.Now()
+	if event.EndedAt != nil {
+		end = *event.EndedAt
+	}
+	hours := decimal.NewFromFloat(end.Sub(start).Hours())
+	tolerance := decimal.NewFromInt(1).Add(s.config.TolerancePercent.Div(decimal.NewFromInt(100)))
+
+	report := &domain.DemandResponseReport{
+		EventID:           event.ID,
+		Name:              event.Name,
+		Status:            event.Status,
+		StartAt:           start,
+		EndAt:             end,
+		TargetReductionMW: event.TargetReductionMW,
+		GeneratedAt:       time.Now(),
+	}
+
+	achievedKW := decimal.Zero
+	for _, p := range event.Participants {
+		result := domain.DemandResponsePoolResult{
+			PoolID:       p.PoolID,
+			PoolName:     p.PoolName,
+			OperatorID:   p.OperatorID,
+			OperatorName: p.OperatorName,
+			RegionCode:   p.RegionCode,
+			BaselineKW:   p.BaselineKW,
+			CapKW:        p.CapKW,
+		}
+
+		// GetTimeSeries includes the end time
+		logs, err := s.energyRepo.GetTimeSeries(ctx, p.PoolID, start, end.Add(-time.Microsecond))
+		if err != nil {
+			return nil, &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to retrieve energy telemetry",
+				Err:     err,
+			}
+		}
+		if len(logs) > 0 && hours.IsPositive() {
+			kwh := decimal.Zero
+			for _, entry := range logs {
+				kwh = kwh.Add(entry.PowerUsageKWh)
+			}
+			result.TelemetryReported = true
+			result.AveragePowerKW = kwh.Div(hours).Round(3)
+			result.Compliant = result.AveragePowerKW.LessThanOrEqual(p.CapKW.Mul(tolerance))
+			if reduction := p.BaselineKW.Sub(result.AveragePowerKW); reduction.IsPositive() {
+				result.AchievedReductionKW = reduction.Round(3)
+				achievedKW = achievedKW.Add(reduction)
+			}
+		}
+
+		if p.ThrottleCommandID != nil {
+			command, err := s.commandRepo.GetByID(ctx, *p.ThrottleCommandID)
+			if err == nil && command != nil {
+				result.CommandStatus = command.Status
+			}
+		}
+
+		if result.Compliant {
+			report.CompliantPools++
+		} else {
+			report.NonCompliantPools++
+		}
+		report.Pools = append(report.Pools, result)
+	}
+
+	report.AchievedReductionMW = achievedKW.Div(decimal.NewFromInt(1000)).Round(3)
+	report.TargetMet = report.AchievedReductionMW.GreaterThanOrEqual(event.TargetReductionMW)
+
+	return report, nil
+}
+
+// preview allocates the target reduction over the active pools of the regions
+// in proportion to their current power draw
+func (s *DemandResponseService) preview(ctx context.Context, regionCodes []string, targetMW decimal.Decimal) (*domain.DemandResponsePreview, error) {
+	filter := domain.PoolFilter{
+		Statuses:    []domain.PoolStatus{domain.PoolStatusActive},
+		RegionCodes: regionCodes,
+	}
+
+	var participants []domain.DemandResponseParticipant
+	totalKW := decimal.Zero
+	for offset := 0; ; offset += demandResponsePageSize {
+		pools, err := s.poolRepo.List(ctx, filter, demandResponsePageSize, offset)
+		if err != nil {
+			return nil, &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to list mining pools",
+				Err:     err,
+			}
+		}
+		for _, pool := range pools {
+			baseline, err := s.currentPowerKW(ctx, &pool)
+			if err != nil {
+				return nil, &ServiceError{
+					Code:    "DATABASE_ERROR",
+					Message: "Failed to retrieve energy telemetry",
+					Err:     err,
+				}
+			}
+			if !baseline.IsPositive() {
+				continue
+			}
+			participants = append(participants, domain.DemandResponseParticipant{
+				PoolID:         pool.ID,
+				PoolName:       pool.Name,
+				OperatorID:     pool.OwnerEntityID,
+				OperatorName:   pool.OwnerEntityName,
+				RegionCode:     pool.RegionCode,
+				ContactEmail:   pool.ContactEmail,
+				ActiveMachines: pool.ActiveMachineCount,
+				BaselineKW:     baseline,
+			})
+			totalKW = totalKW.Add(baseline)
+		}
+		if len(pools) < demandResponsePageSize {
+			break
+		}
+	}
+
+	share := decimal.Zero
+	if totalKW.IsPositive() {
+		share = decimal.Min(targetMW.Mul(decimal.NewFromInt(1000)).Div(totalKW), decimal.NewFromInt(1))
+	}
+	for i := range participants {
+		p := &participants[i]
+		p.ReductionKW = p.BaselineKW.Mul(share).Round(3)
+		p.CapKW = p.BaselineKW.Sub(p.ReductionKW)
+	}
+
+	return summarizeParticipants(regionCodes, targetMW, participants), nil
+}
+
+// currentPowerKW returns a pool's latest reported average power draw, or the
+// draw recorded on the pool if it has not reported energy telemetry
+func (s *DemandResponseService) currentPowerKW(ctx context.Context, pool *domain.MiningPool) (decimal.Decimal, error) {
+	latest, err := s.energyRepo.GetLatest(ctx, pool.ID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if latest != nil && latest.AveragePowerKW.IsPositive() {
+		return latest.AveragePowerKW, nil
+	}
+	return pool.CurrentEnergyUsageKW, nil
+}
+
+// notifyOperators sends one notice per operator covering its participating
+// pools. Failures are logged; they do not hold up the event.
+func (s *DemandResponseService) notifyOperators(ctx context.Context, event *domain.DemandResponseEvent, noticeType string, message func([]domain.DemandResponseParticipant) string) {
+	for _, participants := range participantsByOperator(event.Participants) {
+		first := participants[0]
+		emails := make(map[string]bool)
+		for _, p := range participants {
+			if p.ContactEmail != "" {
+				emails[p.ContactEmail] = true
+			}
+		}
+
+		notice := &domain.OperatorNotice{
+			Type:         noticeType,
+			OperatorID:   first.OperatorID,
+			OperatorName: first.OperatorName,
+			Subject:      fmt.Sprintf("Demand-response event %q on %s", event.Name, event.StartAt.UTC().Format("2006-01-02 15:04 MST")),
+			Message:      message(participants),
+			Data: map[string]interface{}{
+				"event_id":     event.ID,
+				"name":         event.Name,
+				"start_at":     event.StartAt,
+				"end_at":       event.EndAt,
+				"participants": participants,
+			},
+			SentAt: time.Now(),
+		}
+		for email := range emails {
+			notice.Emails = append(notice.Emails, email)
+		}
+		sort.Strings(notice.Emails)
+
+		if err := s.notifier.Notify(ctx, notice); err != nil {
+			log.Printf("Failed to notify operator %s of demand-response event %s: %v", first.OperatorID, event.ID, err)
+		}
+	}
+}
+
+// validateRequest checks a demand-response event request
+func (s *DemandResponseService) validateRequest(req *domain.DemandResponseRequest) error {
+	switch {
+	case len(req.RegionCodes) == 0:
+		return &ServiceError{
+			Code:    "INVALID_EVENT",
+			Message: "At least one region is required",
+		}
+	case !req.EndAt.After(req.StartAt):
+		return &ServiceError{
+			Code:    "INVALID_EVENT",
+			Message: "Event must end after it starts",
+		}
+	case !req.TargetReductionMW.IsPositive():
+		return &ServiceError{
+			Code:    "INVALID_EVENT",
+			Message: "Target reduction must be positive",
+		}
+	case req.NoticeMinutes != nil && *req.NoticeMinutes < 0:
+		return &ServiceError{
+			Code:    "INVALID_EVENT",
+			Message: "Notice period must not be negative",
+		}
+	}
+	return nil
+}
+
+// update stores an event's status and timestamps
+func (s *DemandResponseService) update(ctx context.Context, event *domain.DemandResponseEvent) error {
+	event.UpdatedAt = time.Now()
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		return &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to update demand-response event",
+			Err:     err,
+		}
+	}
+	return nil
+}
+
+// summarizeParticipants totals an event's allocation
+func summarizeParticipants(regionCodes []string, targetMW decimal.Decimal, participants []domain.DemandResponseParticipant) *domain.DemandResponsePreview {
+	preview := &domain.DemandResponsePreview{
+		RegionCodes:       regionCodes,
+		TargetReductionMW: targetMW,
+		PoolCount:         len(participants),
+		Participants:      participants,
+		GeneratedAt:       time.Now(),
+	}
+
+	baselineKW, reductionKW := decimal.Zero, decimal.Zero
+	operators := make(map[uuid.UUID]bool)
+	for _, p := range participants {
+		baselineKW = baselineKW.Add(p.BaselineKW)
+		reductionKW = reductionKW.Add(p.ReductionKW)
+		operators[p.OperatorID] = true
+		preview.MachineCount += p.ActiveMachines
+	}
+
+	kwPerMW := decimal.NewFromInt(1000)
+	preview.BaselineMW = baselineKW.Div(kwPerMW).Round(3)
+	preview.PlannedReductionMW = reductionKW.Div(kwPerMW).Round(3)
+	if shortfall := targetMW.Sub(preview.PlannedReductionMW); shortfall.IsPositive() {
+		preview.ShortfallMW = shortfall
+	}
+	preview.OperatorCount = len(operators)
+
+	return preview
+}
+
+// participantsByOperator groups participants by operator, in a stable order
+func participantsByOperator(participants []domain.DemandResponseParticipant) [][]domain.DemandResponseParticipant {
+	index := make(map[uuid.UUID]int)
+	var groups [][]domain.DemandResponseParticipant
+	for _, p := range participants {
+		i, ok := index[p.OperatorID]
+		if !ok {
+			i = len(groups)
+			index[p.OperatorID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], p)
+	}
+	return groups
+}
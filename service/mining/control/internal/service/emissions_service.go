@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// emissionsPageSize is the page size used when listing pools and machines
+const emissionsPageSize = 1000
+
+// EmissionsRepository defines the interface for emissions persistence
+type EmissionsRepository interface {
+	ReplaceHour(ctx context.Context, poolID uuid.UUID, hourStart time.Time, records []domain.MinerEmissionRecord) error
+	GetLatestHour(ctx context.Context) (*time.Time, error)
+	ListByMachine(ctx context.Context, machineID uuid.UUID, startTime, endTime time.Time) ([]domain.MinerEmissionRecord, error)
+	Aggregate(ctx context.Context, grouping domain.EmissionsGrouping, startTime, endTime time.Time) ([]domain.EmissionsSummary, error)
+}
+
+// EmissionsConfig holds configuration for the emissions service
+type EmissionsConfig struct {
+	// SourceFactors are emission factors in kg CO2 per kWh by energy source
+	SourceFactors map[domain.EnergySourceType]decimal.Decimal
+	// DefaultFactor applies to energy sources without a configured factor
+	DefaultFactor decimal.Decimal
+	Interval      time.Duration
+	// BackfillHours bounds how far back the first run computes
+	BackfillHours int
+}
+
+// EmissionsService computes hourly carbon emissions per miner from energy
+// telemetry and aggregates them per operator and region
+type EmissionsService struct {
+	energyRepo    EnergyRepository
+	poolRepo      MiningPoolRepository
+	machineRepo   MachineRepository
+	emissionsRepo EmissionsRepository
+	config        EmissionsConfig
+	stopChan      chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewEmissionsService creates a new emissions service
+func NewEmissionsService(energyRepo EnergyRepository, poolRepo MiningPoolRepository, machineRepo MachineRepository, emissionsRepo EmissionsRepository, config EmissionsConfig) *EmissionsService {
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.BackfillHours <= 0 {
+		config.BackfillHours = 24
+	}
+	return &EmissionsService{
+		energyRepo:    energyRepo,
+		poolRepo:      poolRepo,
+		machineRepo:   machineRepo,
+		emissionsRepo: emissionsRepo,
+		config:        config,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// StartAccounting starts the background emissions accounting
+func (s *EmissionsService) StartAccounting() {
+	s.wg.Add(1)
+	go s.accounting()
+	log.Println("Emissions accounting started")
+}
+
+// StopAccounting stops the background emissions accounting
+func (s *EmissionsService) StopAccounting() {
+	close(s.stopChan)
+	s.wg.Wait()
+	log.Println("Emissions accounting stopped")
+}
+
+// accounting computes every completed hour not yet accounted for
+func (s *EmissionsService) accounting() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	s.runAccounting()
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.runAccounting()
+		}
+	}
+}
+
+// runAccounting computes the completed hours after the latest accounted hour,
+// going back at most BackfillHours
+func (s *EmissionsService) runAccounting() {
+	ctx := context.Background()
+
+	current := time.Now().UTC().Truncate(time.Hour)
+	from := current.Add(-time.Duration(s.config.BackfillHours) * time.Hour)
+
+	latest, err := s.emissionsRepo.GetLatestHour(ctx)
+	if err != nil {
+		log.Printf("Failed to get latest emissions hour: %v", err)
+		return
+	}
+	if latest != nil && !latest.Before(from) {
+		from = latest.UTC().Add(time.Hour)
+	}
+
+	for hour := from; hour.Before(current); hour = hour.Add(time.Hour) {
+		if err := s.ComputeHour(ctx, hour); err != nil {
+			log.Printf("Failed to compute emissions for %s: %v", hour.Format(time.RFC3339), err)
+			return
+		}
+	}
+}
+
+// ComputeHour computes the emissions of every miner for the hour starting at
+// hourStart, replacing any records already computed for that hour. A pool's
+// energy is split by its reported source mix and apportioned to its active
+// machines by their current power draw.
+func (s *EmissionsService) ComputeHour(ctx context.Context, hourStart time.Time) error {
+	hourStart = hourStart.UTC().Truncate(time.Hour)
+	hourEnd := hourStart.Add(time.Hour)
+
+	for offset := 0; ; offset += emissionsPageSize {
+		pools, err := s.poolRepo.List(ctx, domain.PoolFilter{}, emissionsPageSize, offset)
+		if err != nil {
+			return &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to list mining pools",
+				Err:     err,
+			}
+		}
+
+		for i := range pools {
+			if err := s.computePoolHour(ctx, &pools[i], hourStart, hourEnd); err != nil {
+				return err
+			}
+		}
+
+		if len(pools) < emissionsPageSize {
+			return nil
+		}
+	}
+}
+
+// computePoolHour computes and stores the emissions of one pool for one hour
+func (s *EmissionsService) computePoolHour(ctx context.Context, pool *domain.MiningPool, hourStart, hourEnd time.Time) error {
+	// GetTimeSeries includes the end time, which belongs to the next hour
+	logs, err := s.energyRepo.GetTimeSeries(ctx, pool.ID, hourStart, hourEnd.Add(-time.Microsecond))
+	if err != nil {
+		return &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve energy telemetry",
+			Err:     err,
+		}
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+
+	energyBySource := make(map[domain.EnergySourceType]decimal.Decimal)
+	for _, entry := range logs {
+		for source, energy := range splitBySource(entry) {
+			energyBySource[source] = energyBySource[source].Add(energy)
+		}
+	}
+
+	machines, err := s.listActiveMachines(ctx, pool.ID)
+	if err != nil {
+		return err
+	}
+	shares := machineShares(machines)
+
+	now := time.Now()
+	var records []domain.MinerEmissionRecord
+	for source, energy := range energyBySource {
+		factor := s.emissionFactor(source)
+		for machineID, share := range shares {
+			machineEnergy := energy.Mul(share)
+			records = append(records, domain.MinerEmissionRecord{
+				ID:             uuid.New(),
+				MachineID:      machineID,
+				PoolID:         pool.ID,
+				OperatorID:     pool.OwnerEntityID,
+				OperatorName:   pool.OwnerEntityName,
+				RegionCode:     pool.RegionCode,
+				HourStart:      hourStart,
+				EnergySource:   source,
+				EnergyKWh:      machineEnergy.Round(6),
+				EmissionFactor: factor,
+				EmissionsKG:    machineEnergy.Mul(factor).Round(6),
+				CreatedAt:      now,
+			})
+		}
+	}
+
+	if err := s.emissionsRepo.ReplaceHour(ctx, pool.ID, hourStart, records); err != nil {
+		return &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to store emission records",
+			Err:     err,
+		}
+	}
+
+	return nil
+}
+
+// listActiveMachines lists all active machines of a pool
+func (s *EmissionsService) listActiveMachines(ctx context.Context, poolID uuid.UUID) ([]domain.MiningMachine, error) {
+	var machines []domain.MiningMachine
+	for offset := 0; ; offset += emissionsPageSize {
+		page, err := s.machineRepo.ListByPool(ctx, poolID, true, emissionsPageSize, offset)
+		if err != nil {
+			return nil, &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to list pool machines",
+				Err:     err,
+			}
+		}
+		machines = append(machines, page...)
+		if len(page) < emissionsPageSize {
+			return machines, nil
+		}
+	}
+}
+
+// emissionFactor returns the configured emission factor for an energy source
+func (s *EmissionsService) emissionFactor(source domain.EnergySourceType) decimal.Decimal {
+	if factor, ok := s.config.SourceFactors[source]; ok {
+		return factor
+	}
+	return s.config.DefaultFactor
+}
+
+// splitBySource splits the energy of a telemetry entry by its source mix,
+// falling back to its primary source when no mix was reported
+func splitBySource(entry domain.EnergyConsumptionLog) map[domain.EnergySourceType]decimal.Decimal {
+	total := 0
+	for _, percent := range entry.SourceMixPercentage {
+		if percent > 0 {
+			total += percent
+		}
+	}
+	if total == 0 {
+		return map[domain.EnergySourceType]decimal.Decimal{entry.EnergySource: entry.PowerUsageKWh}
+	}
+
+	split := make(map[domain.EnergySourceType]decimal.Decimal)
+	for source, percent := range entry.SourceMixPercentage {
+		if percent > 0 {
+			share := decimal.NewFromInt(int64(percent)).Div(decimal.NewFromInt(int64(total)))
+			split[domain.EnergySourceType(source)] = entry.PowerUsageKWh.Mul(share)
+		}
+	}
+	return split
+}
+
+// machineShares returns each machine's share of its pool's energy, weighted by
+// current power draw or, failing that, rated power. A pool without machines
+// attributes all energy to uuid.Nil.
+func machineShares(machines []domain.MiningMachine) map[uuid.UUID]decimal.Decimal {
+	if len(machines) == 0 {
+		return map[uuid.UUID]decimal.Decimal{uuid.Nil: decimal.NewFromInt(1)}
+	}
+
+	weights := make(map[uuid.UUID]decimal.Decimal, len(machines))
+	total := decimal.Zero
+	for _, machine := range machines {
+		weight := machine.CurrentPowerWatts
+		if !weight.IsPositive() {
+			weight = machine.PowerSpecWatts
+		}
+		if !weight.IsPositive() {
+			weight = decimal.Zero
+		}
+		weights[machine.ID] = weight
+		total = total.Add(weight)
+	}
+
+	shares := make(map[uuid.UUID]decimal.Decimal, len(machines))
+	for id, weight := range weights {
+		if total.IsZero() {
+			shares[id] = decimal.NewFromInt(1).Div(decimal.NewFromInt(int64(len(machines))))
+		} else {
+			shares[id] = weight.Div(total)
+		}
+	}
+	return shares
+}
+
+// GetMachineEmissions retrieves the hourly emission records of a machine
+func (s *EmissionsService) GetMachineEmissions(ctx context.Context, machineID uuid.UUID, startTime, endTime time.Time) ([]domain.MinerEmissionRecord, error) {
+	records, err := s.emissionsRepo.ListByMachine(ctx, machineID, startTime, endTime)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve machine emissions",
+			Err:     err,
+		}
+	}
+	return records, nil
+}
+
+// GetEmissionsSummary aggregates emissions per operator or region for a time range
+func (s *EmissionsService) GetEmissionsSummary(ctx context.Context, grouping domain.EmissionsGrouping, startTime, endTime time.Time) ([]domain.EmissionsSummary, error) {
+	if grouping != domain.EmissionsGroupingOperator && grouping != domain.EmissionsGroupingRegion {
+		return nil, &ServiceError{
+			Code:    "INVALID_GROUPING",
+			Message: "Grouping must be OPERATOR or REGION",
+		}
+	}
+
+	summaries, err := s.emissionsRepo.Aggregate(ctx, grouping, startTime, endTime)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to aggregate emissions",
+			Err:     err,
+		}
+	}
+	return summaries, nil
+}
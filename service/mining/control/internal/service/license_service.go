@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// licensePageSize is the page size used when listing pools and machines
+const licensePageSize = 1000
+
+// LicenseRegistry defines the interface for looking up licenses held in the
+// compliance service
+type LicenseRegistry interface {
+	// GetLicense returns nil if the license does not exist
+	GetLicense(ctx context.Context, id uuid.UUID) (*domain.MinerLicense, error)
+}
+
+// licenseProblemDescriptions explain license problems to operators
+var licenseProblemDescriptions = map[domain.LicenseProblem]string{
+	domain.LicenseProblemMissing:   "no license is assigned",
+	domain.LicenseProblemNotFound:  "the license does not exist",
+	domain.LicenseProblemWrongType: "the license is not a mining license",
+	domain.LicenseProblemInactive:  "the license is not active",
+	domain.LicenseProblemExpired:   "the license has expired",
+}
+
+// LicenseConfig holds configuration for the license service
+type LicenseConfig struct {
+	// ReconcileAt is the time of day, as an offset from midnight UTC, at
+	// which the nightly reconciliation runs
+	ReconcileAt time.Duration
+}
+
+// LicenseService checks online mining machines against the licenses they
+// operate under, flagging unlicensed miners and reporting their hash rate
+type LicenseService struct {
+	poolRepo      MiningPoolRepository
+	machineRepo   MachineRepository
+	violationRepo ViolationRepository
+	licenses      LicenseRegistry
+	config        LicenseConfig
+	stopChan      chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewLicenseService creates a new license service
+func NewLicenseService(poolRepo MiningPoolRepository, machineRepo MachineRepository, violationRepo ViolationRepository, licenses LicenseRegistry, config LicenseConfig) *LicenseService {
+	return &LicenseService{
+		poolRepo:      poolRepo,
+		machineRepo:   machineRepo,
+		violationRepo: violationRepo,
+		licenses:      licenses,
+		config:        config,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// StartReconciliation starts the nightly license reconciliation
+func (s *LicenseService) StartReconciliation() {
+	s.wg.Add(1)
+	go s.reconciliation()
+	log.Println("License reconciliation started")
+}
+
+// StopReconciliation stops the nightly license reconciliation
+func (s *LicenseService) StopReconciliation() {
+	close(s.stopChan)
+	s.wg.Wait()
+	log.Println("License reconciliation stopped")
+}
+
+// reconciliation runs Reconcile once a day at the configured time
+func (s *LicenseService) reconciliation() {
+	defer s.wg.Done()
+
+	for {
+		timer := time.NewTimer(time.Until(s.nextRun(time.Now())))
+		select {
+		case <-s.stopChan:
+			timer.Stop()
+			return
+		case <-timer.C:
+			if _, err := s.Reconcile(context.Background()); err != nil {
+				log.Printf("License reconciliation failed: %v", err)
+			}
+		}
+	}
+}
+
+// nextRun returns the first reconciliation time after now
+func (s *LicenseService) nextRun(now time.Time) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(s.config.ReconcileAt)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Reconcile checks every online miner against its license and opens a
+// violation for each one whose license is missing, expired, suspended or
+// revoked. Miners with an open license violation are not flagged again.
+func (s *LicenseService) Reconcile(ctx context.Context) (*domain.LicenseReconciliation, error) {
+	result := &domain.LicenseReconciliation{StartedAt: time.Now()}
+
+	err := s.forEachPool(ctx, func(pool *domain.MiningPool) error {
+		miners, checked, err := s.unlicensedMiners(ctx, pool)
+		if err != nil {
+			return err
+		}
+		result.MachinesChecked += checked
+		result.Unlicensed += len(miners)
+		if len(miners) == 0 {
+			return nil
+		}
+
+		flagged, err := s.flaggedMachines(ctx, pool.ID)
+		if err != nil {
+			return err
+		}
+		for i := range miners {
+			if flagged[miners[i].MachineID.String()] {
+				continue
+			}
+			if err := s.violationRepo.Create(ctx, licenseViolation(pool, &miners[i])); err != nil {
+				return &ServiceError{
+					Code:    "DATABASE_ERROR",
+					Message: "Failed to create license violation",
+					Err:     err,
+				}
+			}
+			result.ViolationsOpened++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.CompletedAt = time.Now()
+	log.Printf("License reconciliation checked %d machines: %d unlicensed, %d new violations",
+		result.MachinesChecked, result.Unlicensed, result.ViolationsOpened)
+
+	return result, nil
+}
+
+// GetUnlicensedHashRate returns the current hash rate of online miners
+// without a valid license per region, highest first
+func (s *LicenseService) GetUnlicensedHashRate(ctx context.Context) ([]domain.UnlicensedHashRate, error) {
+	regions := make(map[string]*domain.UnlicensedHashRate)
+
+	err := s.forEachPool(ctx, func(pool *domain.MiningPool) error {
+		miners, _, err := s.unlicensedMiners(ctx, pool)
+		if err != nil || len(miners) == 0 {
+			return err
+		}
+
+		region, ok := regions[pool.RegionCode]
+		if !ok {
+			region = &domain.UnlicensedHashRate{
+				RegionCode: pool.RegionCode,
+				HashRateTH: decimal.Zero,
+				ByProblem:  make(map[domain.LicenseProblem]decimal.Decimal),
+			}
+			regions[pool.RegionCode] = region
+		}
+		for _, miner := range miners {
+			region.HashRateTH = region.HashRateTH.Add(miner.HashRateTH)
+			region.ByProblem[miner.Problem] = region.ByProblem[miner.Problem].Add(miner.HashRateTH)
+			region.MachineCount++
+		}
+		region.PoolCount++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.UnlicensedHashRate, 0, len(regions))
+	for _, region := range regions {
+		result = append(result, *region)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].HashRateTH.Equal(result[j].HashRateTH) {
+			return result[i].HashRateTH.GreaterThan(result[j].HashRateTH)
+		}
+		return result[i].RegionCode < result[j].RegionCode
+	})
+
+	return result, nil
+}
+
+// forEachPool calls fn for every registered mining pool
+func (s *LicenseService) forEachPool(ctx context.Context, fn func(pool *domain.MiningPool) error) error {
+	for offset := 0; ; offset += licensePageSize {
+		pools, err := s.poolRepo.List(ctx, domain.PoolFilter{}, licensePageSize, offset)
+		if err != nil {
+			return &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to list mining pools",
+				Err:     err,
+			}
+		}
+
+		for i := range pools {
+			if err := fn(&pools[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(pools) < licensePageSize {
+			return nil
+		}
+	}
+}
+
+// unlicensedMiners returns the online machines of a pool without a valid
+// license, and the number of online machines checked
+func (s *LicenseService) unlicensedMiners(ctx context.Context, pool *domain.MiningPool) ([]domain.UnlicensedMiner, int, error) {
+	now := time.Now()
+	var miners []domain.UnlicensedMiner
+	checked := 0
+
+	for offset := 0; ; offset += licensePageSize {
+		machines, err := s.machineRepo.ListByPool(ctx, pool.ID, true, licensePageSize, offset)
+		if err != nil {
+			return nil, 0, &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to list pool machines",
+				Err:     err,
+			}
+		}
+
+		for _, machine := range machines {
+			checked++
+			license, problem, err := checkMinerLicense(ctx, s.licenses, machine.LicenseID, now)
+			if err != nil {
+				return nil, 0, err
+			}
+			if problem == "" {
+				continue
+			}
+
+			miner := domain.UnlicensedMiner{
+				MachineID:    machine.ID,
+				SerialNumber: machine.SerialNumber,
+				PoolID:       pool.ID,
+				LicenseID:    machine.LicenseID,
+				Problem:      problem,
+				HashRateTH:   machine.CurrentHashRateTH,
+			}
+			if license != nil {
+				miner.LicenseStatus = license.Status
+			}
+			miners = append(miners, miner)
+		}
+
+		if len(machines) < licensePageSize {
+			return miners, checked, nil
+		}
+	}
+}
+
+// flaggedMachines returns the IDs of the machines of a pool with an open
+// license violation
+func (s *LicenseService) flaggedMachines(ctx context.Context, poolID uuid.UUID) (map[string]bool, error) {
+	violations, err := s.violationRepo.GetOpenViolations(ctx, poolID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve open violations",
+			Err:     err,
+		}
+	}
+
+	flagged := make(map[string]bool)
+	for _, violation := range violations {
+		if violation.ViolationType != domain.ViolationTypeUnlicensedMiner && violation.ViolationType != domain.ViolationTypeLicenseExpired {
+			continue
+		}
+		if machineID, ok := violation.Details["machine_id"].(string); ok {
+			flagged[machineID] = true
+		}
+	}
+	return flagged, nil
+}
+
+// licenseViolation builds the violation opened for an unlicensed miner
+func licenseViolation(pool *domain.MiningPool, miner *domain.UnlicensedMiner) *domain.ComplianceViolation {
+	violationType := domain.ViolationTypeUnlicensedMiner
+	title := "Miner operating without a valid license"
+	if miner.Problem == domain.LicenseProblemExpired {
+		violationType = domain.ViolationTypeLicenseExpired
+		title = "Miner operating on an expired license"
+	}
+
+	details := map[string]interface{}{
+		"machine_id":    miner.MachineID.String(),
+		"serial_number": miner.SerialNumber,
+		"problem":       string(miner.Problem),
+		"hash_rate_th":  miner.HashRateTH.String(),
+	}
+	if miner.LicenseID != nil {
+		details["license_id"] = miner.LicenseID.String()
+	}
+	if miner.LicenseStatus != "" {
+		details["license_status"] = miner.LicenseStatus
+	}
+
+	now := time.Now()
+	return &domain.ComplianceViolation{
+		ID:            uuid.New(),
+		PoolID:        pool.ID,
+		PoolName:      pool.Name,
+		ViolationType: violationType,
+		Severity:      domain.ViolationSeverityHigh,
+		Status:        domain.ViolationStatusOpen,
+		Title:         title,
+		Description:   "Machine " + miner.SerialNumber + " is online but " + licenseProblemDescriptions[miner.Problem],
+		Details:       details,
+		DetectedAt:    now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// checkMinerLicense looks up the license a miner operates under and returns
+// why it does not allow mining at the given time, if it does not
+func checkMinerLicense(ctx context.Context, licenses LicenseRegistry, licenseID *uuid.UUID, at time.Time) (*domain.MinerLicense, domain.LicenseProblem, error) {
+	if licenseID == nil {
+		return nil, domain.LicenseProblemMissing, nil
+	}
+
+	license, err := licenses.GetLicense(ctx, *licenseID)
+	if err != nil {
+		return nil, "", &ServiceError{
+			Code:    "INTEGRATION_ERROR",
+			Message: "Failed to retrieve license from compliance service",
+			Err:     err,
+		}
+	}
+
+	return license, license.Problem(at), nil
+}
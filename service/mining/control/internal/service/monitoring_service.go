@@ -19,6 +19,7 @@ type EnergyRepository interface {
 	GetLatest(ctx context.Context, poolID uuid.UUID) (*domain.EnergyConsumptionLog, error)
 	GetTimeSeries(ctx context.Context, poolID uuid.UUID, startTime, endTime time.Time) ([]domain.EnergyConsumptionLog, error)
 	GetAggregated(ctx context.Context, poolID uuid.UUID, startTime, endTime time.Time, interval string) (*EnergyAggregation, error)
+	GetTotalKWh(ctx context.Context, poolID uuid.UUID, startTime, endTime time.Time) (decimal.Decimal, error)
 }
 
 // HashRateRepository defines the interface for hash rate data persistence
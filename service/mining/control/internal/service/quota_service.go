@@ -0,0 +1,524 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/csic/mining-control/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// quotaPageSize is the page size used when listing pools
+const quotaPageSize = 1000
+
+// quotaCommandIssuer identifies commands issued by energy quota enforcement
+const quotaCommandIssuer = "energy-quota-enforcement"
+
+// quotaMonthFormat is the format of the month an energy quota status covers
+const quotaMonthFormat = "2006-01"
+
+// EnergyQuotaRepository defines the interface for energy quota persistence
+type EnergyQuotaRepository interface {
+	// Upsert creates the quota or replaces the one set for the same region
+	// and operator, keeping its ID
+	Upsert(ctx context.Context, quota *domain.EnergyQuota) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.EnergyQuota, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, regionCode string) ([]domain.EnergyQuota, error)
+	// GetEffective returns the operator's quota in the region, falling back
+	// to the region's default, or nil if neither is set
+	GetEffective(ctx context.Context, regionCode string, operatorID uuid.UUID) (*domain.EnergyQuota, error)
+}
+
+// MiningCommandRepository defines the interface for mining command persistence
+type MiningCommandRepository interface {
+	Create(ctx context.Context, command *domain.MiningCommand) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.MiningCommand, error)
+	Update(ctx context.Context, command *domain.MiningCommand) error
+	ListByPool(ctx context.Context, poolID uuid.UUID, statuses []domain.MiningCommandStatus, limit int) ([]domain.MiningCommand, error)
+	// GetLatestByPool returns the pool's most recent command of one of the
+	// given types, or nil if there is none
+	GetLatestByPool(ctx context.Context, poolID uuid.UUID, types []domain.MiningCommandType) (*domain.MiningCommand, error)
+}
+
+// EnergyQuotaConfig holds configuration for the energy quota service
+type EnergyQuotaConfig struct {
+	Interval time.Duration
+	// ThrottlePercent is the share of its current power draw a pool is
+	// throttled to once its operator exceeds the quota
+	ThrottlePercent decimal.Decimal
+}
+
+// EnergyQuotaService tracks each operator's monthly energy consumption per
+// region against its quota and throttles the operator's pools through the
+// mining command queue while the quota is exceeded
+type EnergyQuotaService struct {
+	poolRepo    MiningPoolRepository
+	energyRepo  EnergyRepository
+	quotaRepo   EnergyQuotaRepository
+	commandRepo MiningCommandRepository
+	config      EnergyQuotaConfig
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewEnergyQuotaService creates a new energy quota service
+func NewEnergyQuotaService(poolRepo MiningPoolRepository, energyRepo EnergyRepository, quotaRepo EnergyQuotaRepository, commandRepo MiningCommandRepository, config EnergyQuotaConfig) *EnergyQuotaService {
+	if config.Interval <= 0 {
+		config.Interval = 15 * time.Minute
+	}
+	if !config.ThrottlePercent.IsPositive() {
+		config.ThrottlePercent = decimal.NewFromInt(50)
+	}
+	return &EnergyQuotaService{
+		poolRepo:    poolRepo,
+		energyRepo:  energyRepo,
+		quotaRepo:   quotaRepo,
+		commandRepo: commandRepo,
+		config:      config,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// StartEnforcement starts the background energy quota enforcement
+func (s *EnergyQuotaService) StartEnforcement() {
+	s.wg.Add(1)
+	go s.enforcement()
+	log.Println("Energy quota enforcement started")
+}
+
+// StopEnforcement stops the background energy quota enforcement
+func (s *EnergyQuotaService) StopEnforcement() {
+	close(s.stopChan)
+	s.wg.Wait()
+	log.Println("Energy quota enforcement stopped")
+}
+
+// enforcement runs Enforce at the configured interval
+func (s *EnergyQuotaService) enforcement() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := s.Enforce(context.Background()); err != nil {
+				log.Printf("Energy quota enforcement failed: %v", err)
+			}
+		}
+	}
+}
+
+// SetQuota sets the monthly energy quota of an operator in a region, or the
+// region's default quota when no operator is given
+func (s *EnergyQuotaService) SetQuota(ctx context.Context, req *domain.EnergyQuotaRequest) (*domain.EnergyQuota, error) {
+	if req.RegionCode == "" {
+		return nil, &ServiceError{
+			Code:    "INVALID_QUOTA",
+			Message: "Region code is required",
+		}
+	}
+	if req.MonthlyMWh.IsNegative() {
+		return nil, &ServiceError{
+			Code:    "INVALID_QUOTA",
+			Message: "Monthly quota must not be negative",
+		}
+	}
+
+	now := time.Now()
+	quota := &domain.EnergyQuota{
+		ID:         uuid.New(),
+		RegionCode: req.RegionCode,
+		MonthlyMWh: req.MonthlyMWh,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if req.OperatorID != nil {
+		quota.OperatorID = *req.OperatorID
+	}
+
+	if err := s.quotaRepo.Upsert(ctx, quota); err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to store energy quota",
+			Err:     err,
+		}
+	}
+
+	log.Printf("Set energy quota for operator %s in region %s to %s MWh/month", quota.OperatorID, quota.RegionCode, quota.MonthlyMWh)
+
+	return quota, nil
+}
+
+// ListQuotas lists the energy quotas of a region, or of all regions
+func (s *EnergyQuotaService) ListQuotas(ctx context.Context, regionCode string) ([]domain.EnergyQuota, error) {
+	quotas, err := s.quotaRepo.List(ctx, regionCode)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to list energy quotas",
+			Err:     err,
+		}
+	}
+	return quotas, nil
+}
+
+// DeleteQuota deletes an energy quota. Operators covered by it fall back to
+// their region's default quota.
+func (s *EnergyQuotaService) DeleteQuota(ctx context.Context, id uuid.UUID) error {
+	quota, err := s.quotaRepo.GetByID(ctx, id)
+	if err != nil {
+		return &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve energy quota",
+			Err:     err,
+		}
+	}
+	if quota == nil {
+		return &ServiceError{
+			Code:    "NOT_FOUND",
+			Message: "Energy quota not found",
+		}
+	}
+
+	if err := s.quotaRepo.Delete(ctx, id); err != nil {
+		return &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to delete energy quota",
+			Err:     err,
+		}
+	}
+
+	log.Printf("Deleted energy quota %s for operator %s in region %s", id, quota.OperatorID, quota.RegionCode)
+
+	return nil
+}
+
+// GetOperatorQuotaStatus returns an operator's consumption against its quota
+// in every region it has pools in, for the month containing at
+func (s *EnergyQuotaService) GetOperatorQuotaStatus(ctx context.Context, operatorID uuid.UUID, at time.Time) ([]domain.EnergyQuotaStatus, error) {
+	pools, err := s.listPools(ctx, domain.PoolFilter{EntityIDs: []uuid.UUID{operatorID}})
+	if err != nil {
+		return nil, err
+	}
+
+	monthStart, monthEnd := quotaMonth(at)
+	end := monthEnd
+	if now := time.Now(); now.Before(end) {
+		end = now
+	}
+
+	var statuses []domain.EnergyQuotaStatus
+	for _, group := range groupPools(pools) {
+		status, err := s.quotaStatus(ctx, group, monthStart, end)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, nil
+}
+
+// Enforce checks every operator's consumption this month against its quota.
+// Pools of an operator over its quota are throttled; pools throttled earlier
+// are resumed once the operator is within its quota again, such as after the
+// month rolls over or the quota is raised.
+func (s *EnergyQuotaService) Enforce(ctx context.Context) (*domain.EnergyQuotaEnforcement, error) {
+	now := time.Now()
+	monthStart, _ := quotaMonth(now)
+	result := &domain.EnergyQuotaEnforcement{
+		StartedAt: now,
+		Month:     monthStart.Format(quotaMonthFormat),
+	}
+
+	pools, err := s.listPools(ctx, domain.PoolFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groupPools(pools) {
+		status, err := s.quotaStatus(ctx, group, monthStart, now)
+		if err != nil {
+			return nil, err
+		}
+		result.OperatorsChecked++
+		if status.Breached {
+			result.Breaches++
+		}
+
+		for i := range group {
+			pool := &group[i]
+			issued, err := s.enforcePool(ctx, pool, status)
+			if err != nil {
+				log.Printf("Failed to enforce energy quota on pool %s: %v", pool.ID, err)
+				continue
+			}
+			switch issued {
+			case domain.MiningCommandThrottle:
+				result.ThrottlesIssued++
+			case domain.MiningCommandResume:
+				result.ResumesIssued++
+			}
+		}
+	}
+
+	result.CompletedAt = time.Now()
+	log.Printf("Energy quota enforcement for %s: %d operators checked, %d over quota, %d throttles and %d resumes issued",
+		result.Month, result.OperatorsChecked, result.Breaches, result.ThrottlesIssued, result.ResumesIssued)
+
+	return result, nil
+}
+
+// enforcePool throttles the pool if its operator is over quota and it is not
+// throttled yet, or resumes it if it is throttled and its operator is within
+// quota. It returns the type of command issued, if any.
+func (s *EnergyQuotaService) enforcePool(ctx context.Context, pool *domain.MiningPool, status *domain.EnergyQuotaStatus) (domain.MiningCommandType, error) {
+	latest, err := s.commandRepo.GetLatestByPool(ctx, pool.ID, []domain.MiningCommandType{domain.MiningCommandThrottle, domain.MiningCommandResume})
+	if err != nil {
+		return "", err
+	}
+	throttled := latest != nil && latest.Type == domain.MiningCommandThrottle
+
+	switch {
+	case status.Breached && !throttled:
+		target, err := s.throttleTarget(ctx, pool)
+		if err != nil {
+			return "", err
+		}
+		reason := fmt.Sprintf("Operator %s consumed %s MWh of its %s MWh energy quota for %s in region %s",
+			status.OperatorName, status.ConsumedMWh.Round(3), status.QuotaMWh, status.Month, status.RegionCode)
+		_, err = issueMiningCommand(ctx, s.commandRepo, quotaCommandIssuer, pool.ID, domain.MiningCommandThrottle, &target, reason)
+		return domain.MiningCommandThrottle, err
+
+	case !status.Breached && throttled && latest.IssuedBy == quotaCommandIssuer:
+		reason := fmt.Sprintf("Operator %s is within its energy quota for %s in region %s", status.OperatorName, status.Month, status.RegionCode)
+		_, err = issueMiningCommand(ctx, s.commandRepo, quotaCommandIssuer, pool.ID, domain.MiningCommandResume, nil, reason)
+		return domain.MiningCommandResume, err
+	}
+
+	return "", nil
+}
+
+// throttleTarget returns the power a pool is throttled to: the configured
+// share of its latest reported average power draw
+func (s *EnergyQuotaService) throttleTarget(ctx context.Context, pool *domain.MiningPool) (decimal.Decimal, error) {
+	current := pool.CurrentEnergyUsageKW
+	latest, err := s.energyRepo.GetLatest(ctx, pool.ID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if latest != nil && latest.AveragePowerKW.IsPositive() {
+		current = latest.AveragePowerKW
+	}
+	return current.Mul(s.config.ThrottlePercent).Div(decimal.NewFromInt(100)).Round(3), nil
+}
+
+// quotaStatus computes the consumption of one operator's pools in one region
+// for [monthStart, end) against the quota that applies to them
+func (s *EnergyQuotaService) quotaStatus(ctx context.Context, pools []domain.MiningPool, monthStart, end time.Time) (*domain.EnergyQuotaStatus, error) {
+	first := pools[0]
+	status := &domain.EnergyQuotaStatus{
+		OperatorID:   first.OwnerEntityID,
+		OperatorName: first.OwnerEntityName,
+		RegionCode:   first.RegionCode,
+		Month:        monthStart.Format(quotaMonthFormat),
+		AsOf:         end,
+	}
+
+	consumedKWh := decimal.Zero
+	for _, pool := range pools {
+		kwh, err := s.energyRepo.GetTotalKWh(ctx, pool.ID, monthStart, end)
+		if err != nil {
+			return nil, &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to retrieve energy consumption",
+				Err:     err,
+			}
+		}
+		consumedKWh = consumedKWh.Add(kwh)
+		status.PoolIDs = append(status.PoolIDs, pool.ID)
+	}
+	status.ConsumedMWh = consumedKWh.Div(decimal.NewFromInt(1000))
+
+	quota, err := s.quotaRepo.GetEffective(ctx, status.RegionCode, status.OperatorID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve energy quota",
+			Err:     err,
+		}
+	}
+	if quota == nil {
+		return status, nil
+	}
+
+	remaining := quota.MonthlyMWh.Sub(status.ConsumedMWh)
+	if remaining.IsNegative() {
+		remaining = decimal.Zero
+	}
+	status.QuotaID = &quota.ID
+	status.QuotaMWh = &quota.MonthlyMWh
+	status.RemainingMWh = &remaining
+	status.Breached = status.ConsumedMWh.GreaterThan(quota.MonthlyMWh)
+
+	return status, nil
+}
+
+// listPools lists all pools matching the filter
+func (s *EnergyQuotaService) listPools(ctx context.Context, filter domain.PoolFilter) ([]domain.MiningPool, error) {
+	var pools []domain.MiningPool
+	for offset := 0; ; offset += quotaPageSize {
+		page, err := s.poolRepo.List(ctx, filter, quotaPageSize, offset)
+		if err != nil {
+			return nil, &ServiceError{
+				Code:    "DATABASE_ERROR",
+				Message: "Failed to list mining pools",
+				Err:     err,
+			}
+		}
+		pools = append(pools, page...)
+		if len(page) < quotaPageSize {
+			return pools, nil
+		}
+	}
+}
+
+// ListPoolCommands lists a pool's commands with one of the given statuses,
+// most recent first, or all of its commands when no status is given
+func (s *EnergyQuotaService) ListPoolCommands(ctx context.Context, poolID uuid.UUID, statuses []domain.MiningCommandStatus, limit int) ([]domain.MiningCommand, error) {
+	commands, err := s.commandRepo.ListByPool(ctx, poolID, statuses, limit)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to list mining commands",
+			Err:     err,
+		}
+	}
+	return commands, nil
+}
+
+// UpdateCommandStatus records a pool controller's progress on a command.
+// Commands move from PENDING to ACKNOWLEDGED and then to EXECUTED or FAILED;
+// a final status cannot be changed.
+func (s *EnergyQuotaService) UpdateCommandStatus(ctx context.Context, id uuid.UUID, status domain.MiningCommandStatus, result string) (*domain.MiningCommand, error) {
+	command, err := s.commandRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve mining command",
+			Err:     err,
+		}
+	}
+	if command == nil {
+		return nil, &ServiceError{
+			Code:    "NOT_FOUND",
+			Message: "Mining command not found",
+		}
+	}
+
+	switch {
+	case status != domain.MiningCommandStatusAcknowledged && !status.IsFinal():
+		return nil, &ServiceError{
+			Code:    "INVALID_REQUEST",
+			Message: "Status must be ACKNOWLEDGED, EXECUTED or FAILED",
+		}
+	case command.Status.IsFinal():
+		return nil, &ServiceError{
+			Code:    "INVALID_STATUS",
+			Message: fmt.Sprintf("Command is already %s", command.Status),
+		}
+	case status == domain.MiningCommandStatusAcknowledged && command.Status != domain.MiningCommandStatusPending:
+		return nil, &ServiceError{
+			Code:    "INVALID_STATUS",
+			Message: "Only pending commands can be acknowledged",
+		}
+	}
+
+	now := time.Now()
+	if command.AcknowledgedAt == nil {
+		command.AcknowledgedAt = &now
+	}
+	if status.IsFinal() {
+		command.CompletedAt = &now
+	}
+	command.Status = status
+	if result != "" {
+		command.Result = result
+	}
+
+	if err := s.commandRepo.Update(ctx, command); err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to update mining command",
+			Err:     err,
+		}
+	}
+
+	return command, nil
+}
+
+// issueMiningCommand queues a command for a pool on behalf of issuer
+func issueMiningCommand(ctx context.Context, repo MiningCommandRepository, issuer string, poolID uuid.UUID, commandType domain.MiningCommandType, target *decimal.Decimal, reason string) (*domain.MiningCommand, error) {
+	command := &domain.MiningCommand{
+		ID:            uuid.New(),
+		PoolID:        poolID,
+		Type:          commandType,
+		Status:        domain.MiningCommandStatusPending,
+		TargetPowerKW: target,
+		Reason:        reason,
+		IssuedBy:      issuer,
+		IssuedAt:      time.Now(),
+	}
+	if err := repo.Create(ctx, command); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Issued %s command %s to pool %s: %s", commandType, command.ID, poolID, reason)
+	return command, nil
+}
+
+// groupPools groups pools by operator and region, in a stable order
+func groupPools(pools []domain.MiningPool) [][]domain.MiningPool {
+	type groupKey struct {
+		operatorID uuid.UUID
+		regionCode string
+	}
+
+	index := make(map[groupKey]int)
+	var groups [][]domain.MiningPool
+	for _, pool := range pools {
+		key := groupKey{pool.OwnerEntityID, pool.RegionCode}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], pool)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i][0], groups[j][0]
+		if a.OwnerEntityID != b.OwnerEntityID {
+			return a.OwnerEntityID.String() < b.OwnerEntityID.String()
+		}
+		return a.RegionCode < b.RegionCode
+	})
+	return groups
+}
+
+// quotaMonth returns the bounds of the calendar month (UTC) containing t
+func quotaMonth(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
@@ -53,6 +53,7 @@ type MachineRepository interface {
 	CountByPool(ctx context.Context, poolID uuid.UUID, activeOnly bool) (int64, error)
 	BatchCreate(ctx context.Context, machines []domain.MiningMachine) error
 	GetPoolMachinesStats(ctx context.Context, poolID uuid.UUID) (*MachineStats, error)
+	ListLocationsInBounds(ctx context.Context, bbox domain.BoundingBox) ([]domain.MachineLocation, error)
 }
 
 // MachineStats represents statistics for machines in a pool
@@ -68,14 +69,16 @@ type RegistrationService struct {
 	poolRepo    MiningPoolRepository
 	machineRepo MachineRepository
 	complianceRepo ComplianceRepository
+	licenses    LicenseRegistry
 }
 
 // NewRegistrationService creates a new registration service
-func NewRegistrationService(poolRepo MiningPoolRepository, machineRepo MachineRepository, complianceRepo ComplianceRepository) *RegistrationService {
+func NewRegistrationService(poolRepo MiningPoolRepository, machineRepo MachineRepository, complianceRepo ComplianceRepository, licenses LicenseRegistry) *RegistrationService {
 	return &RegistrationService{
 		poolRepo:    poolRepo,
 		machineRepo: machineRepo,
 		complianceRepo: complianceRepo,
+		licenses:    licenses,
 	}
 }
 
@@ -382,6 +385,7 @@ func (s *RegistrationService) RegisterMiningMachine(ctx context.Context, poolID
 		ID:                 uuid.New(),
 		SerialNumber:       req.SerialNumber,
 		PoolID:             poolID,
+		LicenseID:          req.LicenseID,
 		ModelType:          req.ModelType,
 		Manufacturer:       req.Manufacturer,
 		ModelName:          req.ModelName,
@@ -451,6 +455,7 @@ func (s *RegistrationService) BatchRegisterMachines(ctx context.Context, poolID
 			ID:                  uuid.New(),
 			SerialNumber:        req.SerialNumber,
 			PoolID:              poolID,
+			LicenseID:           req.LicenseID,
 			ModelType:           req.ModelType,
 			Manufacturer:        req.Manufacturer,
 			ModelName:           req.ModelName,
@@ -542,6 +547,9 @@ func (s *RegistrationService) UpdateMiningMachine(ctx context.Context, id uuid.U
 	}
 
 	// Apply updates
+	if licenseID, ok := updates["license_id"].(uuid.UUID); ok {
+		machine.LicenseID = &licenseID
+	}
 	if status, ok := updates["status"].(domain.MachineStatus); ok {
 		machine.Status = status
 		if status == domain.MachineStatusActive {
@@ -550,6 +558,13 @@ func (s *RegistrationService) UpdateMiningMachine(ctx context.Context, id uuid.U
 			machine.IsActive = false
 		}
 	}
+	_, statusUpdated := updates["status"]
+	_, licenseUpdated := updates["license_id"]
+	if machine.Status == domain.MachineStatusActive && (statusUpdated || licenseUpdated) {
+		if err := s.requireMiningLicense(ctx, machine); err != nil {
+			return nil, err
+		}
+	}
 	if locDesc, ok := updates["location_description"].(string); ok {
 		machine.LocationDescription = locDesc
 	}
@@ -612,6 +627,24 @@ func (s *RegistrationService) DecommissionMachine(ctx context.Context, id uuid.U
 	return nil
 }
 
+// requireMiningLicense returns an error unless the machine holds an active
+// mining license, which it needs to go online
+func (s *RegistrationService) requireMiningLicense(ctx context.Context, machine *domain.MiningMachine) error {
+	_, problem, err := checkMinerLicense(ctx, s.licenses, machine.LicenseID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if problem != "" {
+		return &ServiceError{
+			Code:    "LICENSE_INVALID",
+			Message: "Machine cannot go online: " + licenseProblemDescriptions[problem],
+		}
+	}
+
+	return nil
+}
+
 // validateMiningPool validates a mining pool
 func (s *RegistrationService) validateMiningPool(pool *domain.MiningPool) error {
 	if pool.Name == "" {
@@ -3,25 +3,32 @@ package service
 import (
 	"context"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/csic/mining-control/internal/domain"
+	"github.com/csic/mining-control/internal/geohash"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
+// maxHeatmapZoom is the deepest web map zoom level accepted for heatmaps
+const maxHeatmapZoom = 22
+
 // ReportingService handles report generation
 type ReportingService struct {
 	poolRepo      MiningPoolRepository
+	machineRepo   MachineRepository
 	energyRepo    EnergyRepository
 	hashRepo      HashRateRepository
 	violationRepo ViolationRepository
 }
 
 // NewReportingService creates a new reporting service
-func NewReportingService(poolRepo MiningPoolRepository, energyRepo EnergyRepository, hashRepo HashRateRepository, violationRepo ViolationRepository) *ReportingService {
+func NewReportingService(poolRepo MiningPoolRepository, machineRepo MachineRepository, energyRepo EnergyRepository, hashRepo HashRateRepository, violationRepo ViolationRepository) *ReportingService {
 	return &ReportingService{
 		poolRepo:      poolRepo,
+		machineRepo:   machineRepo,
 		energyRepo:    energyRepo,
 		hashRepo:      hashRepo,
 		violationRepo: violationRepo,
@@ -388,6 +395,94 @@ func (s *ReportingService) GetDashboardStats(ctx context.Context) (*domain.Dashb
 	return stats, nil
 }
 
+// GetMiningHeatmap aggregates the current hash rate and power consumption of
+// the machines within a bounding box into geohash cells sized for the zoom level
+func (s *ReportingService) GetMiningHeatmap(ctx context.Context, bbox domain.BoundingBox, zoom int) (*domain.MiningHeatmap, error) {
+	// Written so that NaN coordinates fail the check
+	validBox := -90 <= bbox.MinLat && bbox.MinLat <= bbox.MaxLat && bbox.MaxLat <= 90 &&
+		-180 <= bbox.MinLon && bbox.MinLon <= bbox.MaxLon && bbox.MaxLon <= 180
+	if !validBox {
+		return nil, &ServiceError{
+			Code:    "INVALID_BOUNDING_BOX",
+			Message: "Bounding box must be min_lon,min_lat,max_lon,max_lat within -180..180 and -90..90",
+		}
+	}
+	if zoom < 0 || zoom > maxHeatmapZoom {
+		return nil, &ServiceError{
+			Code:    "INVALID_ZOOM",
+			Message: "Zoom must be between 0 and 22",
+		}
+	}
+
+	locations, err := s.machineRepo.ListLocationsInBounds(ctx, bbox)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    "DATABASE_ERROR",
+			Message: "Failed to retrieve machine locations",
+			Err:     err,
+		}
+	}
+
+	precision := geohash.PrecisionForZoom(zoom)
+	heatmap := &domain.MiningHeatmap{
+		BoundingBox: bbox,
+		Zoom:        zoom,
+		Precision:   precision,
+		Cells:       []domain.HeatmapCell{},
+		HashRateTH:  decimal.Zero,
+		PowerKW:     decimal.Zero,
+		GeneratedAt: time.Now(),
+	}
+
+	cells := make(map[string]*domain.HeatmapCell)
+	cellPools := make(map[string]map[uuid.UUID]struct{})
+	thousand := decimal.NewFromInt(1000)
+
+	for _, location := range locations {
+		hash := geohash.Encode(location.Latitude, location.Longitude, precision)
+		cell, ok := cells[hash]
+		if !ok {
+			box, _ := geohash.Decode(hash)
+			lat, lon := box.Center()
+			cell = &domain.HeatmapCell{
+				Geohash:   hash,
+				Latitude:  lat,
+				Longitude: lon,
+				Bounds: domain.BoundingBox{
+					MinLat: box.MinLat,
+					MinLon: box.MinLon,
+					MaxLat: box.MaxLat,
+					MaxLon: box.MaxLon,
+				},
+				HashRateTH: decimal.Zero,
+				PowerKW:    decimal.Zero,
+			}
+			cells[hash] = cell
+			cellPools[hash] = make(map[uuid.UUID]struct{})
+		}
+
+		powerKW := location.CurrentPowerWatts.Div(thousand)
+		cell.MachineCount++
+		cell.HashRateTH = cell.HashRateTH.Add(location.CurrentHashRateTH)
+		cell.PowerKW = cell.PowerKW.Add(powerKW)
+		cellPools[hash][location.PoolID] = struct{}{}
+
+		heatmap.MachineCount++
+		heatmap.HashRateTH = heatmap.HashRateTH.Add(location.CurrentHashRateTH)
+		heatmap.PowerKW = heatmap.PowerKW.Add(powerKW)
+	}
+
+	for hash, cell := range cells {
+		cell.PoolCount = len(cellPools[hash])
+		heatmap.Cells = append(heatmap.Cells, *cell)
+	}
+	sort.Slice(heatmap.Cells, func(i, j int) bool {
+		return heatmap.Cells[i].Geohash < heatmap.Cells[j].Geohash
+	})
+
+	return heatmap, nil
+}
+
 // calculateCarbonRating calculates a carbon rating based on emissions
 func (s *ReportingService) calculateCarbonRating(carbonPerKWh decimal.Decimal) string {
 	carbonFloat := carbonPerKWh.InexactFloat64()
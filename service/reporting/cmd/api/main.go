@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
@@ -10,11 +11,16 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/csic-platform/shared/openapi"
+	"github.com/csic-platform/shared/problem"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 
 	"csic-platform/service/reporting/internal/db"
+	"csic-platform/service/reporting/internal/domain"
 	"csic-platform/service/reporting/internal/handler/http/handler"
 	"csic-platform/service/reporting/internal/handler/kafka"
 	"csic-platform/service/reporting/internal/repository"
@@ -66,10 +72,21 @@ type CacheConfig struct {
 
 // KafkaConfig contains Kafka settings
 type KafkaConfig struct {
-	Enabled       bool     `yaml:"enabled"`
-	Brokers       []string `yaml:"brokers"`
-	ConsumerGroup string   `yaml:"consumer_group"`
-	TopicPrefix   string   `yaml:"topic_prefix"`
+	Enabled       bool         `yaml:"enabled"`
+	Brokers       []string     `yaml:"brokers"`
+	ConsumerGroup string       `yaml:"consumer_group"`
+	TopicPrefix   string       `yaml:"topic_prefix"`
+	Outbox        OutboxConfig `yaml:"outbox"`
+}
+
+// OutboxConfig contains transactional outbox relay settings
+type OutboxConfig struct {
+	Enabled      bool `yaml:"enabled"`
+	PollInterval int  `yaml:"poll_interval"` // milliseconds
+	BatchSize    int  `yaml:"batch_size"`
+	Lease        int  `yaml:"lease"`       // seconds
+	MaxBackoff   int  `yaml:"max_backoff"` // seconds
+	Retention    int  `yaml:"retention"`   // hours
 }
 
 // SecurityConfig contains security settings
@@ -143,9 +160,14 @@ func main() {
 	reportRepo := repository.NewPostgresGeneratedReportRepository(database)
 	scheduleRepo := repository.NewPostgresScheduleRepository(database)
 	exportLogRepo := repository.NewPostgresExportLogRepository(database)
+	templateVersionRepo := repository.NewPostgresTemplateVersionRepository(database)
+	dataSourceRunner := repository.NewPostgresDataSourceRunner(database)
 
 	// Initialize Kafka
 	var kafkaProducer *kafka.Producer
+	var eventProducer service.KafkaProducer
+	var txManager repository.TxManager
+	var outboxRelay *service.OutboxRelay
 	if cfg.Kafka.Enabled {
 		kafkaCfg := kafka.KafkaConfig{
 			Brokers:       cfg.Kafka.Brokers,
@@ -154,7 +176,24 @@ func main() {
 		}
 		kafkaProducer = kafka.NewProducer(kafkaCfg)
 		defer kafkaProducer.Close()
+		eventProducer = kafkaProducer
 		log.Println("Kafka producer initialized")
+
+		// Route events through the transactional outbox so they are stored with
+		// the report change and delivered at least once by the relay
+		if cfg.Kafka.Outbox.Enabled {
+			outboxRepo := repository.NewPostgresOutboxRepository(database)
+			txManager = repository.NewPostgresTxManager(database)
+			eventProducer = kafka.NewOutboxProducer(outboxRepo, kafkaCfg)
+			outboxRelay = service.NewOutboxRelay(outboxRepo, kafkaProducer, service.OutboxRelayConfig{
+				PollInterval: time.Duration(cfg.Kafka.Outbox.PollInterval) * time.Millisecond,
+				BatchSize:    cfg.Kafka.Outbox.BatchSize,
+				Lease:        time.Duration(cfg.Kafka.Outbox.Lease) * time.Second,
+				MaxBackoff:   time.Duration(cfg.Kafka.Outbox.MaxBackoff) * time.Second,
+				Retention:    time.Duration(cfg.Kafka.Outbox.Retention) * time.Hour,
+			})
+			log.Println("Kafka outbox enabled")
+		}
 	}
 
 	// Initialize file storage (placeholder - would use S3, GCS, etc.)
@@ -166,8 +205,14 @@ func main() {
 	metrics := &MetricsRecorder{}
 
 	// Initialize services
+	templateEngine := service.NewTemplateEngine(dataSourceRunner)
+
 	reportService := service.NewReportGenerationService(
-		templateRepo, reportRepo, cacheRepo, kafkaProducer, fileStorage, metrics,
+		templateRepo, reportRepo, cacheRepo, txManager, eventProducer, fileStorage, metrics, templateEngine,
+	)
+
+	templateService := service.NewTemplateService(
+		templateRepo, templateVersionRepo, cacheRepo, repository.NewPostgresTxManager(database), templateEngine,
 	)
 
 	exportConfig := service.SecureExportConfig{
@@ -187,7 +232,7 @@ func main() {
 	var schedulerService *service.SchedulerService
 	if cfg.Kafka.Enabled {
 		schedulerService = service.NewSchedulerService(
-			scheduleRepo, templateRepo, reportService, kafkaProducer,
+			scheduleRepo, templateRepo, reportService, eventProducer,
 		)
 	}
 
@@ -200,21 +245,61 @@ func main() {
 		}()
 	}
 
+	// Start outbox relay
+	relayCtx, relayCancel := context.WithCancel(ctx)
+	relayDone := make(chan struct{})
+	if outboxRelay != nil {
+		go func() {
+			defer close(relayDone)
+			outboxRelay.Start(relayCtx)
+		}()
+	} else {
+		close(relayDone)
+	}
+
 	// Initialize HTTP handler
-	httpHandler := handler.NewHandler(reportService, exportService, schedulerService)
+	httpHandler := handler.NewHandler(reportService, exportService, schedulerService, templateService)
 
 	// Set Gin mode
 	gin.SetMode(cfg.App.Mode)
 
 	// Initialize router
+	errorLogger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to create error logger: %v", err)
+	}
+	defer errorLogger.Sync()
+	handler.RegisterErrors()
+
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(httpHandler.LoggerMiddleware())
+	router.Use(problem.Middleware(errorLogger))
 	router.Use(httpHandler.CORSMiddleware())
 	router.Use(httpHandler.AuthenticationMiddleware())
 
+	// OpenAPI document of the routes. Requests to described handlers are
+	// checked against it before binding; in debug mode responses are too.
+	spec := openapi.New(openapi.Config{
+		Title:           "Regulatory Reporting API",
+		Version:         "1.0.0",
+		Error:           problem.Problem{},
+		SecuritySchemes: map[string]*openapi.SecurityScheme{"bearerAuth": openapi.BearerAuth},
+		Exclude:         []string{"/health", "/ready"},
+	})
+	httpHandler.Describe(spec)
+	validatorOptions := openapi.ValidatorOptions{}
+	if cfg.App.Mode == gin.DebugMode {
+		if devLogger, err := zap.NewDevelopment(); err == nil {
+			validatorOptions.Responses = true
+			validatorOptions.Logger = devLogger
+		}
+	}
+	router.Use(spec.Validator(validatorOptions))
+
 	// Setup routes
 	httpHandler.SetupRoutes(router)
+	router.GET(openapi.Path, spec.Handler(router))
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -243,6 +328,11 @@ func main() {
 		schedulerService.Stop()
 	}
 
+	// Stop outbox relay before the Kafka producer is closed; undelivered
+	// events stay in the outbox and are relayed on the next start
+	relayCancel()
+	<-relayDone
+
 	// Graceful shutdown
 	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, time.Duration(cfg.App.ShutdownTimeout)*time.Second)
 	defer shutdownCancel()
@@ -302,7 +392,7 @@ func (s *LocalFileStorage) SaveReport(ctx context.Context, reportID uuid.UUID, f
 }
 
 // GetReport retrieves a report from local filesystem
-func (s *LocalFileStorage) GetReport(ctx context.Context, filePath string) (io.ReadCloser, error) {
+func (s *LocalFileStorage) GetReport(ctx context.Context, filePath string) (io.ReadSeekCloser, error) {
 	return os.Open(filePath)
 }
 
@@ -323,9 +413,3 @@ func (m *MetricsRecorder) RecordReportGenerated(reportType string, duration time
 func (m *MetricsRecorder) RecordReportSize(reportType string, size int64) {
 	log.Printf("Report size: type=%s size=%d", reportType, size)
 }
-
-// Import necessary packages
-import (
-	"io"
-	"csic-platform/service/reporting/internal/domain"
-)
@@ -3,6 +3,7 @@ module csic-platform/service/reporting
 go 1.21
 
 require (
+	github.com/csic-platform/shared v0.0.0
 	github.com/gin-gonic/gin v1.9.1
 	github.com/google/uuid v1.4.0
 	github.com/lib/pq v1.10.9
@@ -12,6 +13,7 @@ require (
 	github.com/segmentio/kafka-go v0.4.45
 	github.com/spf13/viper v1.18.0
 	github.com/stretchr/testify v1.8.4
+	go.uber.org/zap v1.26.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -65,3 +67,5 @@ require (
 	golang.org/x/text v0.14.0 // indirect
 	google.golang.org/protobuf v1.31.0 // indirect
 )
+
+replace github.com/csic-platform/shared => ../../shared
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Immutable snapshots of report templates, one per create or update, so every
+-- generated report can be traced to the exact sections and queries it used.
+CREATE TABLE IF NOT EXISTS report_template_versions (
+    id UUID PRIMARY KEY,
+    template_id UUID NOT NULL REFERENCES report_templates(id),
+    report_type report_type NOT NULL,
+    version_number INTEGER NOT NULL,
+    template_schema JSONB NOT NULL,
+    generation_rules JSONB NOT NULL,
+    change_note TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_report_template_versions_number UNIQUE (template_id, version_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_versions_type ON report_template_versions(report_type, template_id);
+
+-- +goose StatementEnd
+
+-- HTML output for rendered templates with inline charts
+ALTER TYPE report_format ADD VALUE IF NOT EXISTS 'html';
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS report_template_versions CASCADE;
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The locale a report's documents are generated in
+ALTER TABLE generated_reports ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT 'en-US';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE generated_reports DROP COLUMN IF EXISTS locale;
+
+-- +goose StatementEnd
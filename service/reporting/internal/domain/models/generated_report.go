@@ -20,6 +20,7 @@ type GeneratedReport struct {
 	// Report metadata
 	Title             string          `json:"title" db:"title"`
 	Description       string          `json:"description" db:"description"`
+	Locale            string          `json:"locale" db:"locale"`
 	PeriodStart       time.Time       `json:"period_start" db:"period_start"`
 	PeriodEnd         time.Time       `json:"period_end" db:"period_end"`
 	ReportDate        time.Time       `json:"report_date" db:"report_date"`
@@ -90,6 +91,29 @@ type ReportFile struct {
 	CreatedAt    time.Time   `json:"created_at"`
 }
 
+// ReportManifest lists the files of a multi-file report bundle so clients can
+// download, resume and verify each part independently
+type ReportManifest struct {
+	ReportID    uuid.UUID      `json:"report_id"`
+	Title       string         `json:"title"`
+	Version     string         `json:"version"`
+	PeriodStart time.Time      `json:"period_start"`
+	PeriodEnd   time.Time      `json:"period_end"`
+	GeneratedAt *time.Time     `json:"generated_at"`
+	Files       []ManifestFile `json:"files"`
+	TotalSize   int64          `json:"total_size"`
+}
+
+// ManifestFile describes one downloadable file of a report bundle
+type ManifestFile struct {
+	Format      ReportFormat `json:"format"`
+	Filename    string       `json:"filename"`
+	ContentType string       `json:"content_type"`
+	Size        int64        `json:"size"`
+	SHA256      string       `json:"sha256"`
+	DownloadURL string       `json:"download_url"`
+}
+
 // ReportQueueItem represents a report in the generation queue
 type ReportQueueItem struct {
 	ID              uuid.UUID       `json:"id"`
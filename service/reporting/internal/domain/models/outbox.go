@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus represents the delivery status of an outbox event
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusPublished OutboxStatus = "published"
+)
+
+// OutboxEvent represents an event stored alongside a report change and relayed
+// to Kafka afterwards. DedupKey is unique in the outbox and is sent as a message
+// header so that consumers can discard redelivered events.
+type OutboxEvent struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	AggregateType string          `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id" db:"aggregate_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Topic         string          `json:"topic" db:"topic"`
+	MessageKey    string          `json:"message_key" db:"message_key"`
+	DedupKey      string          `json:"dedup_key" db:"dedup_key"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Status        OutboxStatus    `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	LastError     string          `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" db:"published_at"`
+}
+
+// NewOutboxEvent creates a pending outbox event keyed by its aggregate
+func NewOutboxEvent(topic, aggregateType, aggregateID, eventType, dedupKey string, payload interface{}) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	if dedupKey == "" {
+		dedupKey = uuid.New().String()
+	}
+
+	now := time.Now()
+	return &OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Topic:         topic,
+		MessageKey:    aggregateID,
+		DedupKey:      dedupKey,
+		Payload:       data,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
@@ -40,6 +40,7 @@ const (
 	ReportFormatJSON  ReportFormat = "json"
 	ReportFormatXBRL  ReportFormat = "xbrl"
 	ReportFormatXML   ReportFormat = "xml"
+	ReportFormatHTML  ReportFormat = "html"
 )
 
 // ReportFrequency defines how often a report should be generated
@@ -83,7 +84,8 @@ type TemplateSchema struct {
 	ValidationRules ValidationRules       `json:"validation_rules"`
 }
 
-// ReportSection defines a section within a report
+// ReportSection defines a section within a report. Content is Go html/template
+// markup rendered with the rows of the data source named by DataBinding.
 type ReportSection struct {
 	ID          string              `json:"id"`
 	Name        string              `json:"name"`
@@ -91,6 +93,20 @@ type ReportSection struct {
 	Fields      []SectionField      `json:"fields"`
 	Order       int                 `json:"order"`
 	Conditional ConditionalRule     `json:"conditional"`
+	Content     string              `json:"content"`
+	DataBinding string              `json:"data_binding"`
+	Chart       *ChartSpec          `json:"chart,omitempty"`
+}
+
+// ChartSpec defines a chart drawn inline from a section's bound rows
+type ChartSpec struct {
+	Kind       string `json:"kind"`   // bar, line
+	Format     string `json:"format"` // svg, png
+	Title      string `json:"title"`
+	LabelField string `json:"label_field"`
+	ValueField string `json:"value_field"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
 }
 
 // SectionField represents a field within a report section
@@ -104,9 +120,12 @@ type SectionField struct {
 	Aggregate   string      `json:"aggregate"`
 	Hidden      bool        `json:"hidden"`
 	Order       int         `json:"order"`
+	Labels      map[string]string `json:"labels,omitempty"` // per-locale overrides of Label
 }
 
-// DataSourceConfig defines where report data comes from
+// DataSourceConfig defines where report data comes from. Database sources are
+// named queries: sections bind to them by ID. Query may reference the report
+// period as $1 (start) and $2 (end) and the regulator as $3.
 type DataSourceConfig struct {
 	ID       string `json:"id"`
 	Type     string `json:"type"` // database, api, kafka, calculation
@@ -236,3 +255,45 @@ type WatermarkConfig struct {
 	Angle    int    `json:"angle"` // 0-360
 	FontSize int    `json:"font_size"`
 }
+
+// ReportTemplateVersion is an immutable snapshot of a template. A version is
+// recorded each time the template is created or updated.
+type ReportTemplateVersion struct {
+	ID              uuid.UUID       `json:"id" db:"id"`
+	TemplateID      uuid.UUID       `json:"template_id" db:"template_id"`
+	ReportType      ReportType      `json:"report_type" db:"report_type"`
+	VersionNumber   int             `json:"version_number" db:"version_number"`
+	TemplateSchema  TemplateSchema  `json:"template_schema" db:"template_schema"`
+	GenerationRules GenerationRules `json:"generation_rules" db:"generation_rules"`
+	ChangeNote      string          `json:"change_note" db:"change_note"`
+	CreatedBy       string          `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+}
+
+// RenderedReport is a template rendered against its data sources
+type RenderedReport struct {
+	Title       string                              `json:"title"`
+	Locale      string                              `json:"locale"`
+	PeriodStart time.Time                           `json:"period_start"`
+	PeriodEnd   time.Time                           `json:"period_end"`
+	Sections    []RenderedSection                   `json:"sections"`
+	Data        map[string][]map[string]interface{} `json:"data"`
+	HTML        string                              `json:"html"`
+	RenderedAt  time.Time                           `json:"rendered_at"`
+}
+
+// RenderedSection is the output of a single report section
+type RenderedSection struct {
+	ID    string         `json:"id"`
+	Name  string         `json:"name"`
+	Type  string         `json:"type"`
+	HTML  string         `json:"html"`
+	Chart *RenderedChart `json:"chart,omitempty"`
+}
+
+// RenderedChart is a chart image generated for a section
+type RenderedChart struct {
+	Format   string `json:"format"`
+	MimeType string `json:"mime_type"`
+	Data     []byte `json:"data"`
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"csic-platform/service/reporting/internal/service"
+	"github.com/csic-platform/shared/problem"
+)
+
+// RegisterErrors classifies the reporting service errors. It is called once
+// at startup, before the router serves requests.
+func RegisterErrors() {
+	problem.Register(problem.NotFound,
+		service.ErrReportNotFound,
+		service.ErrReportFileNotFound,
+		service.ErrTemplateNotFound,
+	)
+	problem.Register(problem.Conflict, service.ErrReportNotReady)
+	problem.Register(problem.InvalidArgument, service.ErrInvalidTemplate)
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/csic-platform/shared/problem"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"csic-platform/service/reporting/internal/domain"
@@ -17,6 +19,7 @@ type Handler struct {
 	reportService  *service.ReportGenerationService
 	exportService  *service.ExportService
 	schedulerService *service.SchedulerService
+	templateService  *service.TemplateService
 }
 
 // NewHandler creates a new handler
@@ -24,11 +27,13 @@ func NewHandler(
 	reportService *service.ReportGenerationService,
 	exportService *service.ExportService,
 	schedulerService *service.SchedulerService,
+	templateService *service.TemplateService,
 ) *Handler {
 	return &Handler{
 		reportService:    reportService,
 		exportService:    exportService,
 		schedulerService: schedulerService,
+		templateService:  templateService,
 	}
 }
 
@@ -53,6 +58,7 @@ func (h *Handler) SetupRoutes(router *gin.Engine) {
 			reports.POST("/:id/archive", h.ArchiveReport)
 			reports.POST("/:id/submit", h.SubmitReport)
 			reports.GET("/:id/download", h.DownloadReport)
+			reports.GET("/:id/manifest", h.GetReportManifest)
 		}
 
 		// Templates
@@ -63,6 +69,9 @@ func (h *Handler) SetupRoutes(router *gin.Engine) {
 			templates.GET("/:id", h.GetTemplate)
 			templates.PUT("/:id", h.UpdateTemplate)
 			templates.DELETE("/:id", h.DeleteTemplate)
+			templates.GET("/:id/versions", h.ListTemplateVersions)
+			templates.GET("/:id/versions/:version", h.GetTemplateVersion)
+			templates.POST("/:id/preview", h.PreviewTemplate)
 		}
 
 		// Schedules
@@ -139,7 +148,7 @@ func (h *Handler) ListReports(c *gin.Context) {
 
 	reports, total, err := h.reportService.ListReports(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -156,7 +165,7 @@ func (h *Handler) ListReports(c *gin.Context) {
 func (h *Handler) CreateReport(c *gin.Context) {
 	var req service.GenerateReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Write(c, problem.Invalid(err.Error()))
 		return
 	}
 
@@ -164,9 +173,14 @@ func (h *Handler) CreateReport(c *gin.Context) {
 	req.GenerationMethod = "manual"
 	req.GeneratedBy = c.GetString("user_id")
 
+	// Documents default to the caller's preferred language
+	if !h.resolveLocale(c, &req.Locale) {
+		return
+	}
+
 	report, err := h.reportService.GenerateReport(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -177,18 +191,18 @@ func (h *Handler) CreateReport(c *gin.Context) {
 func (h *Handler) GetReport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
 	report, err := h.reportService.GetReport(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
 	if report == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		problem.Write(c, problem.New(problem.NotFound, "report not found"))
 		return
 	}
 
@@ -199,14 +213,14 @@ func (h *Handler) GetReport(c *gin.Context) {
 func (h *Handler) RegenerateReport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
 	userID := c.GetString("user_id")
 	report, err := h.reportService.RegenerateReport(c.Request.Context(), id, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -217,13 +231,13 @@ func (h *Handler) RegenerateReport(c *gin.Context) {
 func (h *Handler) ApproveReport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
 	userID := c.GetString("user_id")
 	if err := h.reportService.ApproveReport(c.Request.Context(), id, userID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -234,13 +248,13 @@ func (h *Handler) ApproveReport(c *gin.Context) {
 func (h *Handler) ValidateReport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
 	userID := c.GetString("user_id")
 	if err := h.reportService.ValidateReport(c.Request.Context(), id, userID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -251,12 +265,12 @@ func (h *Handler) ValidateReport(c *gin.Context) {
 func (h *Handler) ArchiveReport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
 	if err := h.reportService.ArchiveReport(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -267,21 +281,19 @@ func (h *Handler) ArchiveReport(c *gin.Context) {
 func (h *Handler) SubmitReport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
-	var req struct {
-		SubmissionRef string `json:"submission_ref"`
-	}
+	var req submitReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Write(c, problem.Invalid(err.Error()))
 		return
 	}
 
 	userID := c.GetString("user_id")
 	if err := h.reportService.SubmitReport(c.Request.Context(), id, userID, req.SubmissionRef); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -292,7 +304,7 @@ func (h *Handler) SubmitReport(c *gin.Context) {
 func (h *Handler) DownloadReport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
@@ -314,56 +326,268 @@ func (h *Handler) DownloadReport(c *gin.Context) {
 
 	result, err := h.exportService.ExportReport(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
+		return
+	}
+
+	h.serveExport(c, result)
+}
+
+// GetReportManifest handles GET /api/v1/reports/:id/manifest
+func (h *Handler) GetReportManifest(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, problem.Invalid("invalid report ID"))
 		return
 	}
 
+	manifest, err := h.exportService.GetManifest(c.Request.Context(), id)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, manifest)
+}
+
+// serveExport writes an export result with Range, If-Range and conditional
+// request support, keyed by an ETag of the content's SHA-256. Only the
+// request that starts a download is recorded; resumed ranges are not.
+func (h *Handler) serveExport(c *gin.Context, result *service.ExportResult) {
 	defer result.FileReader.Close()
 
-	// Record download
-	h.exportService.RecordDownload(c.Request.Context(), result.ExportLog.ID)
+	if !isResumedRange(c.GetHeader("Range")) {
+		h.exportService.RecordDownload(c.Request.Context(), result.ExportLog.ID)
+	}
 
-	// Set headers
 	c.Header("Content-Description", "File Transfer")
 	c.Header("Content-Disposition", "attachment; filename="+result.FileName)
 	c.Header("Content-Type", result.ContentType)
-	c.Header("Content-Length", strconv.FormatInt(result.FileSize, 10))
+	c.Header("ETag", `"`+result.ChecksumSHA256+`"`)
 	c.Header("X-Export-Log-ID", result.ExportLog.ID.String())
 	c.Header("X-Checksum", result.ExportLog.Checksum)
+	c.Header("X-Checksum-SHA256", result.ChecksumSHA256)
 
-	c.Data(http.StatusOK, result.ContentType, result.FileReader)
+	http.ServeContent(c.Writer, c.Request, result.FileName, result.ModTime, result.FileReader)
+}
+
+// isResumedRange reports whether a Range header continues a download rather
+// than starting one at the first byte
+func isResumedRange(header string) bool {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok {
+		return false
+	}
+	return !strings.HasPrefix(strings.TrimSpace(spec), "0-")
 }
 
 // ListTemplates handles GET /api/v1/templates
 func (h *Handler) ListTemplates(c *gin.Context) {
-	// Placeholder - would call template repository
-	c.JSON(http.StatusOK, gin.H{"message": "templates list"})
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	reportType := domain.ReportType(c.Query("type"))
+
+	templates, total, err := h.templateService.ListTemplates(c.Request.Context(), reportType, page, pageSize)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":      templates,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
 }
 
 // CreateTemplate handles POST /api/v1/templates
 func (h *Handler) CreateTemplate(c *gin.Context) {
-	// Placeholder
-	c.JSON(http.StatusCreated, gin.H{"message": "template created"})
+	var template domain.ReportTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		problem.Write(c, problem.Invalid(err.Error()))
+		return
+	}
+
+	if err := h.templateService.CreateTemplate(c.Request.Context(), &template, c.GetString("user_id")); err != nil {
+		h.writeTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
 }
 
 // GetTemplate handles GET /api/v1/templates/:id
 func (h *Handler) GetTemplate(c *gin.Context) {
-	// Placeholder
-	c.JSON(http.StatusOK, gin.H{"message": "template details"})
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, problem.Invalid("invalid template ID"))
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(c.Request.Context(), id)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	if template == nil {
+		problem.Write(c, problem.New(problem.NotFound, "template not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
 }
 
 // UpdateTemplate handles PUT /api/v1/templates/:id
 func (h *Handler) UpdateTemplate(c *gin.Context) {
-	// Placeholder
-	c.JSON(http.StatusOK, gin.H{"message": "template updated"})
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, problem.Invalid("invalid template ID"))
+		return
+	}
+
+	var req updateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Write(c, problem.Invalid(err.Error()))
+		return
+	}
+
+	template := req.ReportTemplate
+	template.ID = id
+	if err := h.templateService.UpdateTemplate(c.Request.Context(), &template, req.ChangeNote, c.GetString("user_id")); err != nil {
+		h.writeTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
 }
 
 // DeleteTemplate handles DELETE /api/v1/templates/:id
 func (h *Handler) DeleteTemplate(c *gin.Context) {
-	// Placeholder
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, problem.Invalid("invalid template ID"))
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), id); err != nil {
+		problem.Write(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "template deleted"})
 }
 
+// ListTemplateVersions handles GET /api/v1/templates/:id/versions
+func (h *Handler) ListTemplateVersions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, problem.Invalid("invalid template ID"))
+		return
+	}
+
+	versions, err := h.templateService.ListVersions(c.Request.Context(), id)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  versions,
+		"total": len(versions),
+	})
+}
+
+// GetTemplateVersion handles GET /api/v1/templates/:id/versions/:version
+func (h *Handler) GetTemplateVersion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, problem.Invalid("invalid template ID"))
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil || number < 1 {
+		problem.Write(c, problem.Invalid("invalid version number"))
+		return
+	}
+
+	version, err := h.templateService.GetVersion(c.Request.Context(), id, number)
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	if version == nil {
+		problem.Write(c, problem.New(problem.NotFound, "template version not found"))
+		return
+	}
+
+	c.JSON(http.StatusOK, version)
+}
+
+// PreviewTemplate handles POST /api/v1/templates/:id/preview. The rendered
+// document is returned as HTML with ?format=html, otherwise as JSON.
+func (h *Handler) PreviewTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		problem.Write(c, problem.Invalid("invalid template ID"))
+		return
+	}
+
+	var req service.PreviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Write(c, problem.Invalid(err.Error()))
+			return
+		}
+	}
+
+	if !h.resolveLocale(c, &req.Locale) {
+		return
+	}
+
+	rendered, err := h.templateService.PreviewTemplate(c.Request.Context(), id, &req)
+	if err != nil {
+		h.writeTemplateError(c, err)
+		return
+	}
+
+	if c.Query("format") == "html" {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(rendered.HTML))
+		return
+	}
+
+	c.JSON(http.StatusOK, rendered)
+}
+
+// resolveLocale normalizes a requested document locale, defaulting to the
+// Accept-Language header, and responds 400 if the locale is not supported
+func (h *Handler) resolveLocale(c *gin.Context, locale *string) bool {
+	if *locale == "" {
+		*locale = service.NegotiateLocale(c.GetHeader("Accept-Language"))
+		return true
+	}
+
+	normalized := service.NormalizeLocale(*locale)
+	if normalized == "" {
+		problem.Write(c, problem.Invalid("unsupported locale: "+*locale))
+		return false
+	}
+	*locale = normalized
+	return true
+}
+
+// writeTemplateError responds with the problem document for a template
+// service error. Validation failures keep their detail.
+func (h *Handler) writeTemplateError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidTemplate) {
+		err = problem.Invalid(err.Error())
+	}
+	problem.Write(c, err)
+}
+
 // ListSchedules handles GET /api/v1/schedules
 func (h *Handler) ListSchedules(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -371,7 +595,7 @@ func (h *Handler) ListSchedules(c *gin.Context) {
 
 	schedules, total, err := h.schedulerService.ListSchedules(c.Request.Context(), page, pageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -388,13 +612,13 @@ func (h *Handler) ListSchedules(c *gin.Context) {
 func (h *Handler) CreateSchedule(c *gin.Context) {
 	var schedule domain.ReportSchedule
 	if err := c.ShouldBindJSON(&schedule); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Write(c, problem.Invalid(err.Error()))
 		return
 	}
 
 	schedule.CreatedBy = c.GetString("user_id")
 	if err := h.schedulerService.AddSchedule(c.Request.Context(), &schedule); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -405,18 +629,18 @@ func (h *Handler) CreateSchedule(c *gin.Context) {
 func (h *Handler) GetSchedule(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		problem.Write(c, problem.Invalid("invalid schedule ID"))
 		return
 	}
 
 	schedule, err := h.schedulerService.GetSchedule(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
 	if schedule == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+		problem.Write(c, problem.New(problem.NotFound, "schedule not found"))
 		return
 	}
 
@@ -427,19 +651,19 @@ func (h *Handler) GetSchedule(c *gin.Context) {
 func (h *Handler) UpdateSchedule(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		problem.Write(c, problem.Invalid("invalid schedule ID"))
 		return
 	}
 
 	var schedule domain.ReportSchedule
 	if err := c.ShouldBindJSON(&schedule); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Write(c, problem.Invalid(err.Error()))
 		return
 	}
 
 	schedule.ID = id
 	if err := h.schedulerService.UpdateSchedule(c.Request.Context(), &schedule); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -450,12 +674,12 @@ func (h *Handler) UpdateSchedule(c *gin.Context) {
 func (h *Handler) DeleteSchedule(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		problem.Write(c, problem.Invalid("invalid schedule ID"))
 		return
 	}
 
 	if err := h.schedulerService.RemoveSchedule(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -466,12 +690,12 @@ func (h *Handler) DeleteSchedule(c *gin.Context) {
 func (h *Handler) TriggerSchedule(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		problem.Write(c, problem.Invalid("invalid schedule ID"))
 		return
 	}
 
 	if err := h.schedulerService.TriggerSchedule(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -482,12 +706,12 @@ func (h *Handler) TriggerSchedule(c *gin.Context) {
 func (h *Handler) ActivateSchedule(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		problem.Write(c, problem.Invalid("invalid schedule ID"))
 		return
 	}
 
 	if err := h.schedulerService.ActivateSchedule(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -498,12 +722,12 @@ func (h *Handler) ActivateSchedule(c *gin.Context) {
 func (h *Handler) DeactivateSchedule(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		problem.Write(c, problem.Invalid("invalid schedule ID"))
 		return
 	}
 
 	if err := h.schedulerService.DeactivateSchedule(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -518,7 +742,7 @@ func (h *Handler) ListExports(c *gin.Context) {
 
 	exports, total, err := h.exportService.GetExportLogsByUser(c.Request.Context(), userID, page, pageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -535,7 +759,7 @@ func (h *Handler) ListExports(c *gin.Context) {
 func (h *Handler) GetExport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid export ID"})
+		problem.Write(c, problem.Invalid("invalid export ID"))
 		return
 	}
 
@@ -547,12 +771,12 @@ func (h *Handler) GetExport(c *gin.Context) {
 
 	exports, _, err := h.exportService.GetExportLogs(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
 	if len(exports) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "export not found"})
+		problem.Write(c, problem.New(problem.NotFound, "export not found"))
 		return
 	}
 
@@ -563,7 +787,7 @@ func (h *Handler) GetExport(c *gin.Context) {
 func (h *Handler) DownloadExport(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid export ID"})
+		problem.Write(c, problem.Invalid("invalid export ID"))
 		return
 	}
 
@@ -581,20 +805,11 @@ func (h *Handler) DownloadExport(c *gin.Context) {
 
 	result, err := h.exportService.ExportReport(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
-	defer result.FileReader.Close()
-
-	h.exportService.RecordDownload(c.Request.Context(), result.ExportLog.ID)
-
-	c.Header("Content-Description", "File Transfer")
-	c.Header("Content-Disposition", "attachment; filename="+result.FileName)
-	c.Header("Content-Type", result.ContentType)
-	c.Header("Content-Length", strconv.FormatInt(result.FileSize, 10))
-
-	c.Data(http.StatusOK, result.ContentType, result.FileReader)
+	h.serveExport(c, result)
 }
 
 // GetReportStats handles GET /api/v1/stats/reports
@@ -632,7 +847,7 @@ func (h *Handler) GetExportStats(c *gin.Context) {
 
 	stats, err := h.exportService.GetExportStats(c.Request.Context(), start, end)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -643,7 +858,7 @@ func (h *Handler) GetExportStats(c *gin.Context) {
 func (h *Handler) GetSchedulerStats(c *gin.Context) {
 	stats, err := h.schedulerService.GetSchedulerStats(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Write(c, err)
 		return
 	}
 
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+
+	"csic-platform/service/reporting/internal/domain"
+	"csic-platform/service/reporting/internal/service"
+	"github.com/csic-platform/shared/openapi"
+)
+
+// Response bodies the handlers write as gin.H
+type (
+	Message struct {
+		Message string `json:"message"`
+	}
+	ReportPage struct {
+		Data       []*domain.GeneratedReport `json:"data"`
+		Total      int                       `json:"total"`
+		Page       int                       `json:"page"`
+		PageSize   int                       `json:"page_size"`
+		TotalPages int                       `json:"total_pages"`
+	}
+	TemplatePage struct {
+		Data     []*domain.ReportTemplate `json:"data"`
+		Total    int                      `json:"total"`
+		Page     int                      `json:"page"`
+		PageSize int                      `json:"page_size"`
+	}
+	TemplateVersionList struct {
+		Data  []*domain.ReportTemplateVersion `json:"data"`
+		Total int                             `json:"total"`
+	}
+	SchedulePage struct {
+		Data       []*domain.ReportSchedule `json:"data"`
+		Total      int                      `json:"total"`
+		Page       int                      `json:"page"`
+		PageSize   int                      `json:"page_size"`
+		TotalPages int                      `json:"total_pages"`
+	}
+	ExportPage struct {
+		Data       []*domain.ExportLog `json:"data"`
+		Total      int                 `json:"total"`
+		Page       int                 `json:"page"`
+		PageSize   int                 `json:"page_size"`
+		TotalPages int                 `json:"total_pages"`
+	}
+	ReportStats struct {
+		TotalReports int `json:"total_reports"`
+		Completed    int `json:"completed"`
+		Pending      int `json:"pending"`
+		Failed       int `json:"failed"`
+	}
+)
+
+// submitReportRequest records the regulator's reference for a submission
+type submitReportRequest struct {
+	SubmissionRef string `json:"submission_ref"`
+}
+
+// updateTemplateRequest is a template with a note for its new version
+type updateTemplateRequest struct {
+	domain.ReportTemplate
+	ChangeNote string `json:"change_note"`
+}
+
+type pageQuery struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+type reportQuery struct {
+	pageQuery
+	SortBy      string `form:"sort_by"`
+	SortOrder   string `form:"sort_order" binding:"omitempty,oneof=asc desc"`
+	RegulatorID string `form:"regulator_id" binding:"omitempty,uuid"`
+	Type        string `form:"type"`
+	Status      string `form:"status"`
+}
+
+type templateQuery struct {
+	pageQuery
+	Type string `form:"type"`
+}
+
+type formatQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=pdf csv xlsx json xbrl xml html"`
+}
+
+type previewQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json html" description:"html returns the rendered document as text/html"`
+}
+
+type dateRangeQuery struct {
+	StartDate string `form:"start_date" description:"YYYY-MM-DD"`
+	EndDate   string `form:"end_date" description:"YYYY-MM-DD"`
+}
+
+// Describe annotates the handlers for the service's OpenAPI document
+func (h *Handler) Describe(spec *openapi.Spec) {
+	// Reports
+	tags := []string{"reports"}
+	spec.Describe(h.ListReports, openapi.Operation{Summary: "List generated reports", Tags: tags, Query: reportQuery{}, Response: ReportPage{}})
+	spec.Describe(h.CreateReport, openapi.Operation{Summary: "Generate a report", Tags: tags, Request: service.GenerateReportRequest{}, Response: domain.GeneratedReport{}, Status: http.StatusCreated})
+	spec.Describe(h.GetReport, openapi.Operation{Summary: "Get a report", Tags: tags, Response: domain.GeneratedReport{}})
+	spec.Describe(h.RegenerateReport, openapi.Operation{Summary: "Regenerate a report", Tags: tags, Response: domain.GeneratedReport{}})
+	spec.Describe(h.ApproveReport, openapi.Operation{Summary: "Approve a report", Tags: tags, Response: Message{}})
+	spec.Describe(h.ValidateReport, openapi.Operation{Summary: "Validate a report", Tags: tags, Response: Message{}})
+	spec.Describe(h.ArchiveReport, openapi.Operation{Summary: "Archive a report", Tags: tags, Response: Message{}})
+	spec.Describe(h.SubmitReport, openapi.Operation{Summary: "Record a report's submission to the regulator", Tags: tags, Request: submitReportRequest{}, Response: Message{}})
+	spec.Describe(h.DownloadReport, openapi.Operation{Summary: "Download a report", Description: "Responds with the file in the requested format. Supports Range and If-Range requests for resuming; the ETag and X-Checksum-SHA256 header carry the SHA-256 of the complete file.", Tags: tags, Query: formatQuery{}})
+	spec.Describe(h.GetReportManifest, openapi.Operation{Summary: "Get a report's file manifest", Description: "Lists every file of the report with its size, SHA-256 and download URL.", Tags: tags, Response: domain.ReportManifest{}})
+
+	// Templates
+	tags = []string{"templates"}
+	spec.Describe(h.ListTemplates, openapi.Operation{Summary: "List report templates", Tags: tags, Query: templateQuery{}, Response: TemplatePage{}})
+	spec.Describe(h.CreateTemplate, openapi.Operation{Summary: "Create a report template", Tags: tags, Request: domain.ReportTemplate{}, Response: domain.ReportTemplate{}, Status: http.StatusCreated})
+	spec.Describe(h.GetTemplate, openapi.Operation{Summary: "Get a report template", Tags: tags, Response: domain.ReportTemplate{}})
+	spec.Describe(h.UpdateTemplate, openapi.Operation{Summary: "Update a report template as a new version", Tags: tags, Request: updateTemplateRequest{}, Response: domain.ReportTemplate{}})
+	spec.Describe(h.DeleteTemplate, openapi.Operation{Summary: "Delete a report template", Tags: tags, Response: Message{}})
+	spec.Describe(h.ListTemplateVersions, openapi.Operation{Summary: "List a template's versions", Tags: tags, Response: TemplateVersionList{}})
+	spec.Describe(h.GetTemplateVersion, openapi.Operation{Summary: "Get a template version", Tags: tags, Response: domain.ReportTemplateVersion{}})
+	spec.Describe(h.PreviewTemplate, openapi.Operation{Summary: "Render a template with sample data", Tags: tags, Query: previewQuery{}, Request: service.PreviewRequest{}, RequestOptional: true, Response: domain.RenderedReport{}})
+
+	// Schedules
+	tags = []string{"schedules"}
+	spec.Describe(h.ListSchedules, openapi.Operation{Summary: "List report schedules", Tags: tags, Query: pageQuery{}, Response: SchedulePage{}})
+	spec.Describe(h.CreateSchedule, openapi.Operation{Summary: "Create a report schedule", Tags: tags, Request: domain.ReportSchedule{}, Response: domain.ReportSchedule{}, Status: http.StatusCreated})
+	spec.Describe(h.GetSchedule, openapi.Operation{Summary: "Get a report schedule", Tags: tags, Response: domain.ReportSchedule{}})
+	spec.Describe(h.UpdateSchedule, openapi.Operation{Summary: "Update a report schedule", Tags: tags, Request: domain.ReportSchedule{}, Response: domain.ReportSchedule{}})
+	spec.Describe(h.DeleteSchedule, openapi.Operation{Summary: "Delete a report schedule", Tags: tags, Response: Message{}})
+	spec.Describe(h.TriggerSchedule, openapi.Operation{Summary: "Run a schedule now", Tags: tags, Response: Message{}})
+	spec.Describe(h.ActivateSchedule, openapi.Operation{Summary: "Activate a schedule", Tags: tags, Response: Message{}})
+	spec.Describe(h.DeactivateSchedule, openapi.Operation{Summary: "Deactivate a schedule", Tags: tags, Response: Message{}})
+
+	// Exports
+	tags = []string{"exports"}
+	spec.Describe(h.ListExports, openapi.Operation{Summary: "List the caller's exports", Tags: tags, Query: pageQuery{}, Response: ExportPage{}})
+	spec.Describe(h.GetExport, openapi.Operation{Summary: "Get an export", Tags: tags, Response: domain.ExportLog{}})
+	spec.Describe(h.DownloadExport, openapi.Operation{Summary: "Download an export", Description: "Responds with the file in the requested format. Supports Range and If-Range requests for resuming; the ETag and X-Checksum-SHA256 header carry the SHA-256 of the complete file.", Tags: tags, Query: formatQuery{}})
+
+	// Statistics
+	tags = []string{"stats"}
+	spec.Describe(h.GetReportStats, openapi.Operation{Summary: "Get report statistics", Tags: tags, Response: ReportStats{}})
+	spec.Describe(h.GetExportStats, openapi.Operation{Summary: "Get export statistics", Tags: tags, Query: dateRangeQuery{}, Response: service.ExportStats{}})
+	spec.Describe(h.GetSchedulerStats, openapi.Operation{Summary: "Get scheduler statistics", Tags: tags, Response: service.SchedulerStats{}})
+}
@@ -43,8 +43,12 @@ func NewProducer(config KafkaConfig) *Producer {
 // PublishReportGenerated publishes a report generated event
 func (p *Producer) PublishReportGenerated(ctx context.Context, report *domain.GeneratedReport) error {
 	topic := fmt.Sprintf("%s_report_generated", p.config.TopicPrefix)
+	return p.publish(ctx, topic, report.ID.String(), newReportGeneratedEvent(report))
+}
 
-	event := ReportGeneratedEvent{
+// newReportGeneratedEvent builds the event published when a report is generated
+func newReportGeneratedEvent(report *domain.GeneratedReport) ReportGeneratedEvent {
+	return ReportGeneratedEvent{
 		EventID:       uuid.New().String(),
 		EventType:     "report.generated",
 		Timestamp:     time.Now().UTC().Format(time.RFC3339),
@@ -61,15 +65,17 @@ func (p *Producer) PublishReportGenerated(ctx context.Context, report *domain.Ge
 		FileCount:     len(report.Files),
 		TotalSize:     report.TotalSize,
 	}
-
-	return p.publish(ctx, topic, report.ID.String(), event)
 }
 
 // PublishReportFailed publishes a report failed event
 func (p *Producer) PublishReportFailed(ctx context.Context, reportID uuid.UUID, reason string) error {
 	topic := fmt.Sprintf("%s_report_failed", p.config.TopicPrefix)
+	return p.publish(ctx, topic, reportID.String(), newReportFailedEvent(reportID, reason))
+}
 
-	event := ReportFailedEvent{
+// newReportFailedEvent builds the event published when a report fails to generate
+func newReportFailedEvent(reportID uuid.UUID, reason string) ReportFailedEvent {
+	return ReportFailedEvent{
 		EventID:     uuid.New().String(),
 		EventType:   "report.failed",
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
@@ -77,15 +83,17 @@ func (p *Producer) PublishReportFailed(ctx context.Context, reportID uuid.UUID,
 		Reason:      reason,
 		RetryPolicy: "exponential_backoff",
 	}
-
-	return p.publish(ctx, topic, reportID.String(), event)
 }
 
 // PublishReportScheduled publishes a report scheduled event
 func (p *Producer) PublishReportScheduled(ctx context.Context, schedule *domain.ReportSchedule) error {
 	topic := fmt.Sprintf("%s_report_scheduled", p.config.TopicPrefix)
+	return p.publish(ctx, topic, schedule.ID.String(), newReportScheduledEvent(schedule))
+}
 
-	event := ReportScheduledEvent{
+// newReportScheduledEvent builds the event published when a schedule triggers a report
+func newReportScheduledEvent(schedule *domain.ReportSchedule) ReportScheduledEvent {
+	return ReportScheduledEvent{
 		EventID:        uuid.New().String(),
 		EventType:      "report.scheduled",
 		Timestamp:      time.Now().UTC().Format(time.RFC3339),
@@ -96,8 +104,6 @@ func (p *Producer) PublishReportScheduled(ctx context.Context, schedule *domain.
 		CronExpression: schedule.CronExpression,
 		NextRunAt:      schedule.NextRunAt.Format(time.RFC3339),
 	}
-
-	return p.publish(ctx, topic, schedule.ID.String(), event)
 }
 
 // PublishExportAudit publishes an export audit event
@@ -146,6 +152,28 @@ func (p *Producer) publish(ctx context.Context, topic, key string, event interfa
 	return nil
 }
 
+// PublishOutboxEvent publishes an event relayed from the transactional outbox.
+// The dedup key is sent as a header so consumers can discard redeliveries.
+func (p *Producer) PublishOutboxEvent(ctx context.Context, event *domain.OutboxEvent) error {
+	msg := kafka.Message{
+		Topic: event.Topic,
+		Key:   []byte(event.MessageKey),
+		Value: event.Payload,
+		Headers: []kafka.Header{
+			{Key: "event-id", Value: []byte(event.ID.String())},
+			{Key: "event-type", Value: []byte(event.EventType)},
+			{Key: "dedup-key", Value: []byte(event.DedupKey)},
+		},
+		Time: time.Now(),
+	}
+
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("failed to publish outbox event %s to topic %s: %w", event.ID, event.Topic, err)
+	}
+
+	return nil
+}
+
 // Close closes the producer
 func (p *Producer) Close() error {
 	return p.writer.Close()
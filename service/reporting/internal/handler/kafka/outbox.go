@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"csic-platform/service/reporting/internal/domain"
+	"csic-platform/service/reporting/internal/repository"
+	"github.com/google/uuid"
+)
+
+// OutboxProducer implements the KafkaProducer interface by writing events to the
+// transactional outbox instead of Kafka. Called within a transaction, the event
+// is committed atomically with the report change; the outbox relay delivers it.
+type OutboxProducer struct {
+	outbox repository.OutboxRepository
+	config KafkaConfig
+}
+
+// NewOutboxProducer creates a new outbox-backed producer
+func NewOutboxProducer(outbox repository.OutboxRepository, config KafkaConfig) *OutboxProducer {
+	return &OutboxProducer{
+		outbox: outbox,
+		config: config,
+	}
+}
+
+// PublishReportGenerated enqueues a report generated event. A report is
+// generated once, so the report ID identifies the event.
+func (p *OutboxProducer) PublishReportGenerated(ctx context.Context, report *domain.GeneratedReport) error {
+	topic := fmt.Sprintf("%s_report_generated", p.config.TopicPrefix)
+	event := newReportGeneratedEvent(report)
+	dedupKey := fmt.Sprintf("report.generated:%s", report.ID)
+
+	return p.enqueue(ctx, topic, "report", report.ID.String(), event.EventType, dedupKey, event)
+}
+
+// PublishReportFailed enqueues a report failed event. Every failure is reported,
+// so each event is identified by its own event ID.
+func (p *OutboxProducer) PublishReportFailed(ctx context.Context, reportID uuid.UUID, reason string) error {
+	topic := fmt.Sprintf("%s_report_failed", p.config.TopicPrefix)
+	event := newReportFailedEvent(reportID, reason)
+	dedupKey := fmt.Sprintf("report.failed:%s:%s", reportID, event.EventID)
+
+	return p.enqueue(ctx, topic, "report", reportID.String(), event.EventType, dedupKey, event)
+}
+
+// PublishReportScheduled enqueues a report scheduled event, identified by the
+// schedule and the number of runs preceding it
+func (p *OutboxProducer) PublishReportScheduled(ctx context.Context, schedule *domain.ReportSchedule) error {
+	topic := fmt.Sprintf("%s_report_scheduled", p.config.TopicPrefix)
+	event := newReportScheduledEvent(schedule)
+	dedupKey := fmt.Sprintf("report.scheduled:%s:%d", schedule.ID, schedule.RunCount)
+
+	return p.enqueue(ctx, topic, "schedule", schedule.ID.String(), event.EventType, dedupKey, event)
+}
+
+// enqueue stores an event in the outbox
+func (p *OutboxProducer) enqueue(ctx context.Context, topic, aggregateType, aggregateID, eventType, dedupKey string, event interface{}) error {
+	outboxEvent, err := domain.NewOutboxEvent(topic, aggregateType, aggregateID, eventType, dedupKey, event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.outbox.Enqueue(ctx, outboxEvent); err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"csic-platform/service/reporting/internal/db"
+	"csic-platform/service/reporting/internal/domain"
+	"github.com/google/uuid"
+)
+
+// txKey is the context key holding the active transaction
+type txKey struct{}
+
+// dbConn is the subset of the database handle and *sql.Tx used by repositories
+type dbConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn returns the transaction carried by ctx, or the database handle
+func conn(ctx context.Context, database *db.Database) dbConn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return database
+}
+
+// PostgresTxManager implements TxManager for PostgreSQL
+type PostgresTxManager struct {
+	db *db.Database
+}
+
+// NewPostgresTxManager creates a new PostgreSQL transaction manager
+func NewPostgresTxManager(database *db.Database) TxManager {
+	return &PostgresTxManager{db: database}
+}
+
+// WithinTx runs fn in a transaction, committing if fn succeeds and rolling back
+// otherwise. Nested calls join the outer transaction.
+func (m *PostgresTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// PostgresOutboxRepository implements OutboxRepository for PostgreSQL
+type PostgresOutboxRepository struct {
+	db *db.Database
+}
+
+// NewPostgresOutboxRepository creates a new PostgreSQL outbox repository
+func NewPostgresOutboxRepository(database *db.Database) OutboxRepository {
+	return &PostgresOutboxRepository{db: database}
+}
+
+// Enqueue stores events in the outbox, joining the transaction carried by ctx.
+// Events whose dedup key is already present are ignored.
+func (r *PostgresOutboxRepository) Enqueue(ctx context.Context, events ...*domain.OutboxEvent) error {
+	query := `
+		INSERT INTO outbox_events (
+			id, aggregate_type, aggregate_id, event_type, topic, message_key,
+			dedup_key, payload, status, attempts, next_attempt_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (dedup_key) DO NOTHING
+	`
+
+	c := conn(ctx, r.db)
+	for _, event := range events {
+		_, err := c.ExecContext(ctx, query,
+			event.ID, event.AggregateType, event.AggregateID, event.EventType, event.Topic,
+			event.MessageKey, event.DedupKey, []byte(event.Payload), event.Status,
+			event.Attempts, event.NextAttemptAt, event.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue outbox event %s: %w", event.EventType, err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimPending leases up to limit due events by pushing their next attempt past
+// the lease, so concurrent relays never claim the same event. Events are
+// returned oldest first.
+func (r *PostgresOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEvent, error) {
+	query := `
+		UPDATE outbox_events
+		SET attempts = attempts + 1, next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = $3 AND next_attempt_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, aggregate_type, aggregate_id, event_type, topic, message_key,
+			dedup_key, payload, status, attempts, COALESCE(last_error, ''),
+			next_attempt_at, created_at, published_at
+	`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit, time.Now().Add(lease), domain.OutboxStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.OutboxEvent
+	for rows.Next() {
+		event := &domain.OutboxEvent{}
+		var payload []byte
+		if err := rows.Scan(
+			&event.ID, &event.AggregateType, &event.AggregateID, &event.EventType,
+			&event.Topic, &event.MessageKey, &event.DedupKey, &payload, &event.Status,
+			&event.Attempts, &event.LastError, &event.NextAttemptAt, &event.CreatedAt,
+			&event.PublishedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	return events, nil
+}
+
+// MarkPublished records that an event has been delivered to Kafka
+func (r *PostgresOutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE outbox_events SET status = $2, published_at = NOW(), last_error = NULL WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, domain.OutboxStatusPublished)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt and when to retry it
+func (r *PostgresOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	query := `UPDATE outbox_events SET last_error = $2, next_attempt_at = $3 WHERE id = $1 AND status = $4`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, lastError, nextAttemptAt, domain.OutboxStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePublishedBefore removes delivered events older than the retention cutoff
+func (r *PostgresOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM outbox_events WHERE status = $1 AND published_at < $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, domain.OutboxStatusPublished, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -49,7 +49,7 @@ func (r *PostgresReportTemplateRepository) Create(ctx context.Context, template
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
 		template.ID, template.Name, template.Description, template.ReportType,
 		template.RegulatorID, template.Version, templateSchemaJSON, generationRulesJSON,
 		outputFormatsJSON, template.Frequency, template.CronSchedule, template.IsActive,
@@ -89,7 +89,7 @@ func (r *PostgresReportTemplateRepository) Update(ctx context.Context, template
 		WHERE id = $1
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
 		template.ID, template.Name, template.Description, template.ReportType,
 		template.RegulatorID, template.Version, templateSchemaJSON, generationRulesJSON,
 		outputFormatsJSON, template.Frequency, template.CronSchedule, template.IsActive,
@@ -377,9 +377,9 @@ func (r *PostgresGeneratedReportRepository) Create(ctx context.Context, report *
 			generated_by, generation_method, triggered_by, output_formats,
 			total_size, checksum, processing_time, retry_count,
 			is_validated, validated_by, is_approved, approved_by,
-			submission_status, retention_period, custom_fields,
+			submission_status, retention_period, custom_fields, locale,
 			created_by, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, NOW(), NOW())
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, NOW(), NOW())
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
@@ -389,7 +389,7 @@ func (r *PostgresGeneratedReportRepository) Create(ctx context.Context, report *
 		report.GeneratedBy, report.GenerationMethod, report.TriggeredBy, outputFormatsJSON,
 		report.TotalSize, report.Checksum, report.ProcessingTime, report.RetryCount,
 		report.IsValidated, report.ValidatedBy, report.IsApproved, report.ApprovedBy,
-		report.SubmissionStatus, report.RetentionPeriod, customFieldsJSON,
+		report.SubmissionStatus, report.RetentionPeriod, customFieldsJSON, report.Locale,
 		report.CreatedBy,
 	)
 	
@@ -425,11 +425,11 @@ func (r *PostgresGeneratedReportRepository) Update(ctx context.Context, report *
 			submission_status = $31, submitted_at = $32, submitted_by = $33,
 			submission_ref = $34, acknowledgment_ref = $35,
 			is_archived = $36, archived_at = $37,
-			custom_fields = $38, updated_by = $39, updated_at = NOW()
+			custom_fields = $38, updated_by = $39, locale = $40, updated_at = NOW()
 		WHERE id = $1
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
 		report.ID, report.TemplateID, report.TemplateName, report.ReportType,
 		report.RegulatorID, report.RegulatorName, report.Status, report.Version,
 		report.Title, report.Description, report.PeriodStart, report.PeriodEnd, report.ReportDate,
@@ -441,7 +441,7 @@ func (r *PostgresGeneratedReportRepository) Update(ctx context.Context, report *
 		report.SubmissionStatus, report.SubmittedAt, report.SubmittedBy,
 		report.SubmissionRef, report.AcknowledgmentRef,
 		report.IsArchived, report.ArchivedAt,
-		customFieldsJSON, report.UpdatedBy,
+		customFieldsJSON, report.UpdatedBy, report.Locale,
 	)
 	
 	if err != nil {
@@ -472,7 +472,7 @@ func (r *PostgresGeneratedReportRepository) GetByID(ctx context.Context, id uuid
 			total_size, checksum, processing_time, retry_count, last_retry_at, failure_reason,
 			is_validated, validated_at, validated_by, is_approved, approved_at, approved_by,
 			submission_status, submitted_at, submitted_by, submission_ref, acknowledgment_ref,
-			is_archived, archived_at, retention_period, custom_fields,
+			is_archived, archived_at, retention_period, custom_fields, locale,
 			created_by, created_at, updated_by, updated_at
 		FROM generated_reports WHERE id = $1
 	`
@@ -491,7 +491,7 @@ func (r *PostgresGeneratedReportRepository) GetByID(ctx context.Context, id uuid
 		&report.IsApproved, &report.ApprovedAt, &report.ApprovedBy,
 		&report.SubmissionStatus, &report.SubmittedAt, &report.SubmittedBy,
 		&report.SubmissionRef, &report.AcknowledgmentRef,
-		&report.IsArchived, &report.ArchivedAt, &report.RetentionPeriod, &customFieldsJSON,
+		&report.IsArchived, &report.ArchivedAt, &report.RetentionPeriod, &customFieldsJSON, &report.Locale,
 		&report.CreatedAt, &report.UpdatedAt, &report.UpdatedBy,
 	)
 	
@@ -513,6 +513,14 @@ func (r *PostgresGeneratedReportRepository) GetByID(ctx context.Context, id uuid
 	return &report, nil
 }
 
+// reportSortColumns whitelists the columns reports can be sorted by; the
+// requested sort is never placed into the query as is
+var reportSortColumns = map[string]string{
+	"created_at":  "created_at",
+	"report_date": "report_date",
+	"title":       "title",
+}
+
 // List retrieves paginated generated reports with filters
 func (r *PostgresGeneratedReportRepository) List(ctx context.Context, filter domain.ReportFilter) ([]*domain.GeneratedReport, int, error) {
 	var conditions []string
@@ -592,9 +600,9 @@ func (r *PostgresGeneratedReportRepository) List(ctx context.Context, filter dom
 	}
 
 	// Determine sort order
-	sortBy := "created_at"
-	if filter.SortBy != "" {
-		sortBy = filter.SortBy
+	sortBy, ok := reportSortColumns[filter.SortBy]
+	if !ok {
+		sortBy = "created_at"
 	}
 	sortOrder := "DESC"
 	if filter.SortOrder == "asc" {
@@ -615,7 +623,7 @@ func (r *PostgresGeneratedReportRepository) List(ctx context.Context, filter dom
 			total_size, checksum, processing_time, retry_count, last_retry_at, failure_reason,
 			is_validated, validated_at, validated_by, is_approved, approved_at, approved_by,
 			submission_status, submitted_at, submitted_by, submission_ref, acknowledgment_ref,
-			is_archived, archived_at, retention_period, custom_fields,
+			is_archived, archived_at, retention_period, custom_fields, locale,
 			created_by, created_at, updated_by, updated_at
 		FROM generated_reports %s
 		ORDER BY %s %s LIMIT $%d OFFSET $%d
@@ -652,7 +660,7 @@ func (r *PostgresGeneratedReportRepository) GetByStatus(ctx context.Context, sta
 			total_size, checksum, processing_time, retry_count, last_retry_at, failure_reason,
 			is_validated, validated_at, validated_by, is_approved, approved_at, approved_by,
 			submission_status, submitted_at, submitted_by, submission_ref, acknowledgment_ref,
-			is_archived, archived_at, retention_period, custom_fields,
+			is_archived, archived_at, retention_period, custom_fields, locale,
 			created_by, created_at, updated_by, updated_at
 		FROM generated_reports WHERE status = $1 AND is_archived = false
 		ORDER BY created_at ASC LIMIT $2
@@ -681,7 +689,7 @@ func (r *PostgresGeneratedReportRepository) GetOverdueReports(ctx context.Contex
 			total_size, checksum, processing_time, retry_count, last_retry_at, failure_reason,
 			is_validated, validated_at, validated_by, is_approved, approved_at, approved_by,
 			submission_status, submitted_at, submitted_by, submission_ref, acknowledgment_ref,
-			is_archived, archived_at, retention_period, custom_fields,
+			is_archived, archived_at, retention_period, custom_fields, locale,
 			created_by, created_at, updated_by, updated_at
 		FROM generated_reports WHERE report_date < $1 AND status = 'pending' AND is_archived = false
 		ORDER BY report_date ASC
@@ -760,7 +768,7 @@ func (r *PostgresGeneratedReportRepository) Approve(ctx context.Context, id uuid
 func (r *PostgresGeneratedReportRepository) RecordGeneration(ctx context.Context, id uuid.UUID, processingTime int64) error {
 	query := `UPDATE generated_reports SET status = 'completed', generated_at = NOW(), processing_time = $2, updated_at = NOW() WHERE id = $1`
 	
-	_, err := r.db.ExecContext(ctx, query, id, processingTime)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, processingTime)
 	if err != nil {
 		return fmt.Errorf("failed to record generation: %w", err)
 	}
@@ -772,7 +780,7 @@ func (r *PostgresGeneratedReportRepository) RecordGeneration(ctx context.Context
 func (r *PostgresGeneratedReportRepository) RecordFailure(ctx context.Context, id uuid.UUID, reason string) error {
 	query := `UPDATE generated_reports SET status = 'failed', last_retry_at = NOW(), retry_count = retry_count + 1, failure_reason = $2, updated_at = NOW() WHERE id = $1`
 	
-	_, err := r.db.ExecContext(ctx, query, id, reason)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, reason)
 	if err != nil {
 		return fmt.Errorf("failed to record failure: %w", err)
 	}
@@ -809,7 +817,7 @@ func (r *PostgresGeneratedReportRepository) scanReports(rows *sql.Rows) ([]*doma
 			&report.IsApproved, &report.ApprovedAt, &report.ApprovedBy,
 			&report.SubmissionStatus, &report.SubmittedAt, &report.SubmittedBy,
 			&report.SubmissionRef, &report.AcknowledgmentRef,
-			&report.IsArchived, &report.ArchivedAt, &report.RetentionPeriod, &customFieldsJSON,
+			&report.IsArchived, &report.ArchivedAt, &report.RetentionPeriod, &customFieldsJSON, &report.Locale,
 			&report.CreatedAt, &report.UpdatedAt, &report.UpdatedBy,
 		)
 		if err != nil {
@@ -24,6 +24,26 @@ type ReportTemplateRepository interface {
 	IncrementVersion(ctx context.Context, id uuid.UUID) error
 }
 
+// ReportTemplateVersionRepository defines the interface for template version data access
+type ReportTemplateVersionRepository interface {
+	Create(ctx context.Context, version *domain.ReportTemplateVersion) error
+	GetByNumber(ctx context.Context, templateID uuid.UUID, number int) (*domain.ReportTemplateVersion, error)
+	GetLatest(ctx context.Context, templateID uuid.UUID) (*domain.ReportTemplateVersion, error)
+	ListByTemplateID(ctx context.Context, templateID uuid.UUID) ([]*domain.ReportTemplateVersion, error)
+}
+
+// QueryParams are the values bound into a template's named queries
+type QueryParams struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	RegulatorID uuid.UUID
+}
+
+// DataSourceRunner executes a template's named database queries
+type DataSourceRunner interface {
+	Run(ctx context.Context, source domain.DataSourceConfig, params QueryParams) ([]map[string]interface{}, error)
+}
+
 // GeneratedReportRepository defines the interface for generated report data access
 type GeneratedReportRepository interface {
 	Create(ctx context.Context, report *domain.GeneratedReport) error
@@ -158,6 +178,20 @@ type ExportLogRepository interface {
 	GetTopExporters(ctx context.Context, limit int) ([]*ExporterStats, error)
 }
 
+// TxManager defines the interface for running repository calls in a transaction
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxRepository defines the interface for transactional outbox data access
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, events ...*domain.OutboxEvent) error
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
 // ExporterStats represents statistics for an exporter
 type ExporterStats struct {
 	UserID         string `json:"user_id"`
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"csic-platform/service/reporting/internal/db"
+	"csic-platform/service/reporting/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// PostgresTemplateVersionRepository implements ReportTemplateVersionRepository for PostgreSQL
+type PostgresTemplateVersionRepository struct {
+	db *db.Database
+}
+
+// NewPostgresTemplateVersionRepository creates a new PostgreSQL template version repository
+func NewPostgresTemplateVersionRepository(database *db.Database) ReportTemplateVersionRepository {
+	return &PostgresTemplateVersionRepository{db: database}
+}
+
+// Create stores a new template version. The version number must be unique per template.
+func (r *PostgresTemplateVersionRepository) Create(ctx context.Context, version *domain.ReportTemplateVersion) error {
+	templateSchemaJSON, err := json.Marshal(version.TemplateSchema)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template schema: %w", err)
+	}
+
+	generationRulesJSON, err := json.Marshal(version.GenerationRules)
+	if err != nil {
+		return fmt.Errorf("failed to marshal generation rules: %w", err)
+	}
+
+	query := `
+		INSERT INTO report_template_versions (
+			id, template_id, report_type, version_number, template_schema,
+			generation_rules, change_note, created_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		version.ID, version.TemplateID, version.ReportType, version.VersionNumber,
+		templateSchemaJSON, generationRulesJSON, version.ChangeNote, version.CreatedBy,
+		version.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create template version: %w", err)
+	}
+
+	return nil
+}
+
+// GetByNumber retrieves a specific version of a template
+func (r *PostgresTemplateVersionRepository) GetByNumber(ctx context.Context, templateID uuid.UUID, number int) (*domain.ReportTemplateVersion, error) {
+	query := `SELECT ` + templateVersionColumns + ` FROM report_template_versions
+		WHERE template_id = $1 AND version_number = $2`
+
+	return r.scanVersion(conn(ctx, r.db).QueryRowContext(ctx, query, templateID, number))
+}
+
+// GetLatest retrieves the most recent version of a template
+func (r *PostgresTemplateVersionRepository) GetLatest(ctx context.Context, templateID uuid.UUID) (*domain.ReportTemplateVersion, error) {
+	query := `SELECT ` + templateVersionColumns + ` FROM report_template_versions
+		WHERE template_id = $1 ORDER BY version_number DESC LIMIT 1`
+
+	return r.scanVersion(conn(ctx, r.db).QueryRowContext(ctx, query, templateID))
+}
+
+// ListByTemplateID lists all versions of a template, newest first
+func (r *PostgresTemplateVersionRepository) ListByTemplateID(ctx context.Context, templateID uuid.UUID) ([]*domain.ReportTemplateVersion, error) {
+	query := `SELECT ` + templateVersionColumns + ` FROM report_template_versions
+		WHERE template_id = $1 ORDER BY version_number DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*domain.ReportTemplateVersion
+	for rows.Next() {
+		version, err := r.scanVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
+
+// templateVersionColumns lists report_template_versions columns in scan order
+const templateVersionColumns = `id, template_id, report_type, version_number, template_schema,
+	generation_rules, change_note, created_by, created_at`
+
+// scanVersion scans a template version, returning nil if there is no row
+func (r *PostgresTemplateVersionRepository) scanVersion(row interface{ Scan(...interface{}) error }) (*domain.ReportTemplateVersion, error) {
+	var version domain.ReportTemplateVersion
+	var templateSchemaJSON, generationRulesJSON []byte
+
+	err := row.Scan(
+		&version.ID, &version.TemplateID, &version.ReportType, &version.VersionNumber,
+		&templateSchemaJSON, &generationRulesJSON, &version.ChangeNote, &version.CreatedBy,
+		&version.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan template version: %w", err)
+	}
+
+	if err := json.Unmarshal(templateSchemaJSON, &version.TemplateSchema); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal template schema: %w", err)
+	}
+
+	if err := json.Unmarshal(generationRulesJSON, &version.GenerationRules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal generation rules: %w", err)
+	}
+
+	return &version, nil
+}
+
+// maxDataSourceRows caps the rows a single named query may return
+const maxDataSourceRows = 10000
+
+// placeholderPattern matches positional query parameters
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+// filterOperators maps data source filter operators to SQL
+var filterOperators = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+}
+
+// PostgresDataSourceRunner implements DataSourceRunner for PostgreSQL
+type PostgresDataSourceRunner struct {
+	db *db.Database
+}
+
+// NewPostgresDataSourceRunner creates a new PostgreSQL data source runner
+func NewPostgresDataSourceRunner(database *db.Database) DataSourceRunner {
+	return &PostgresDataSourceRunner{db: database}
+}
+
+// Run executes a named query in a read-only transaction. A source with a Query
+// runs it as written and must be a single SELECT; otherwise a query is built
+// from Table, Fields and Filters with quoted identifiers.
+func (r *PostgresDataSourceRunner) Run(ctx context.Context, source domain.DataSourceConfig, params QueryParams) ([]map[string]interface{}, error) {
+	if source.Type != "" && source.Type != "database" {
+		return nil, fmt.Errorf("data source %s: unsupported type %q", source.ID, source.Type)
+	}
+
+	query, args, err := buildDataSourceQuery(source, params)
+	if err != nil {
+		return nil, fmt.Errorf("data source %s: %w", source.ID, err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("data source %s: %w", source.ID, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []map[string]interface{}
+	for rows.Next() {
+		if len(results) == maxDataSourceRows {
+			return nil, fmt.Errorf("data source %s: more than %d rows", source.ID, maxDataSourceRows)
+		}
+
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("data source %s: %w", source.ID, err)
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		results = append(results, row)
+	}
+
+	return results, rows.Err()
+}
+
+// buildDataSourceQuery returns the SQL and arguments for a data source
+func buildDataSourceQuery(source domain.DataSourceConfig, params QueryParams) (string, []interface{}, error) {
+	if source.Query != "" {
+		query := strings.TrimSpace(source.Query)
+		query = strings.TrimSuffix(query, ";")
+		words := strings.Fields(query)
+		if len(words) == 0 || strings.Contains(query, ";") ||
+			(!strings.EqualFold(words[0], "SELECT") && !strings.EqualFold(words[0], "WITH")) {
+			return "", nil, fmt.Errorf("query must be a single SELECT statement")
+		}
+
+		// Bind only as many parameters as the query references
+		available := []interface{}{params.PeriodStart, params.PeriodEnd, params.RegulatorID}
+		used := 0
+		for _, match := range placeholderPattern.FindAllStringSubmatch(query, -1) {
+			n, _ := strconv.Atoi(match[1])
+			if n > len(available) {
+				return "", nil, fmt.Errorf("query references undefined parameter $%d", n)
+			}
+			if n > used {
+				used = n
+			}
+		}
+		return query, available[:used], nil
+	}
+
+	if source.Table == "" {
+		return "", nil, fmt.Errorf("either query or table is required")
+	}
+
+	columns := "*"
+	if len(source.Fields) > 0 {
+		quoted := make([]string, len(source.Fields))
+		for i, field := range source.Fields {
+			quoted[i] = pq.QuoteIdentifier(field)
+		}
+		columns = strings.Join(quoted, ", ")
+	}
+
+	var conditions []string
+	var args []interface{}
+	for _, filter := range source.Filters {
+		column := pq.QuoteIdentifier(filter.Field)
+		if filter.Operator == "in" {
+			args = append(args, pq.Array(filter.Value))
+			conditions = append(conditions, fmt.Sprintf("%s = ANY($%d)", column, len(args)))
+			continue
+		}
+		op, ok := filterOperators[filter.Operator]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported filter operator %q", filter.Operator)
+		}
+		args = append(args, filter.Value)
+		conditions = append(conditions, fmt.Sprintf("%s %s $%d", column, op, len(args)))
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", columns, pq.QuoteIdentifier(source.Table))
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return query, args, nil
+}
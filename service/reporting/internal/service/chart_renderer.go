@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+
+	"csic-platform/service/reporting/internal/domain"
+)
+
+const (
+	defaultChartWidth  = 640
+	defaultChartHeight = 320
+	chartMargin        = 40
+)
+
+// chartPalette is the series colour used for bars and lines
+var chartPalette = color.RGBA{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff}
+
+// chartPoint is a single labelled value plotted on a chart
+type chartPoint struct {
+	Label string
+	Value float64
+}
+
+// RenderChart draws a chart from a section's bound rows. SVG charts carry axis
+// labels and a title; PNG charts are plain raster images for formats that
+// cannot embed SVG.
+func RenderChart(spec *domain.ChartSpec, rows []map[string]interface{}) (*domain.RenderedChart, error) {
+	if spec.LabelField == "" || spec.ValueField == "" {
+		return nil, fmt.Errorf("chart requires label_field and value_field")
+	}
+
+	points := make([]chartPoint, 0, len(rows))
+	for _, row := range rows {
+		value, ok := toFloat(row[spec.ValueField])
+		if !ok {
+			return nil, fmt.Errorf("chart value %q is not numeric", spec.ValueField)
+		}
+		points = append(points, chartPoint{Label: fmt.Sprint(row[spec.LabelField]), Value: value})
+	}
+
+	width, height := spec.Width, spec.Height
+	if width <= 0 {
+		width = defaultChartWidth
+	}
+	if height <= 0 {
+		height = defaultChartHeight
+	}
+
+	switch spec.Kind {
+	case "", "bar", "line":
+	default:
+		return nil, fmt.Errorf("unsupported chart kind %q", spec.Kind)
+	}
+
+	switch spec.Format {
+	case "", "svg":
+		return &domain.RenderedChart{
+			Format:   "svg",
+			MimeType: "image/svg+xml",
+			Data:     renderSVGChart(spec, points, width, height),
+		}, nil
+	case "png":
+		data, err := renderPNGChart(spec, points, width, height)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.RenderedChart{Format: "png", MimeType: "image/png", Data: data}, nil
+	default:
+		return nil, fmt.Errorf("unsupported chart format %q", spec.Format)
+	}
+}
+
+// chartScale returns the value range plotted on the y axis, always including zero
+func chartScale(points []chartPoint) (float64, float64) {
+	minValue, maxValue := 0.0, 0.0
+	for _, p := range points {
+		minValue = math.Min(minValue, p.Value)
+		maxValue = math.Max(maxValue, p.Value)
+	}
+	if maxValue == minValue {
+		maxValue = minValue + 1
+	}
+	return minValue, maxValue
+}
+
+// renderSVGChart draws the chart as an SVG document
+func renderSVGChart(spec *domain.ChartSpec, points []chartPoint, width, height int) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`, width, height)
+	if spec.Title != "" {
+		fmt.Fprintf(&b, `<text x="%d" y="20" text-anchor="middle" font-family="sans-serif" font-size="14">%s</text>`,
+			width/2, html.EscapeString(spec.Title))
+	}
+
+	plotW := float64(width - 2*chartMargin)
+	plotH := float64(height - 2*chartMargin)
+	minValue, maxValue := chartScale(points)
+	y := func(v float64) float64 {
+		return float64(chartMargin) + plotH*(maxValue-v)/(maxValue-minValue)
+	}
+	zero := y(0)
+
+	fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#333333"/>`,
+		chartMargin, zero, width-chartMargin, zero)
+	fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end" font-family="sans-serif" font-size="10">%s</text>`,
+		chartMargin-4, y(maxValue)+4, formatChartValue(maxValue))
+
+	if len(points) > 0 {
+		step := plotW / float64(len(points))
+		var line []string
+		for i, p := range points {
+			x := float64(chartMargin) + step*float64(i)
+			if spec.Kind == "line" {
+				line = append(line, fmt.Sprintf("%.1f,%.1f", x+step/2, y(p.Value)))
+			} else {
+				top, bottom := math.Min(y(p.Value), zero), math.Max(y(p.Value), zero)
+				fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#1f77b4"><title>%s: %s</title></rect>`,
+					x+step*0.1, top, step*0.8, bottom-top, html.EscapeString(p.Label), formatChartValue(p.Value))
+			}
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle" font-family="sans-serif" font-size="10">%s</text>`,
+				x+step/2, height-chartMargin+14, html.EscapeString(p.Label))
+		}
+		if len(line) > 0 {
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#1f77b4" stroke-width="2"/>`, strings.Join(line, " "))
+		}
+	}
+
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+// renderPNGChart draws the chart as a PNG image
+func renderPNGChart(spec *domain.ChartSpec, points []chartPoint, width, height int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	plotW := float64(width - 2*chartMargin)
+	plotH := float64(height - 2*chartMargin)
+	minValue, maxValue := chartScale(points)
+	y := func(v float64) int {
+		return chartMargin + int(plotH*(maxValue-v)/(maxValue-minValue))
+	}
+	zero := y(0)
+
+	axis := color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	draw.Draw(img, image.Rect(chartMargin, zero, width-chartMargin, zero+1), &image.Uniform{C: axis}, image.Point{}, draw.Src)
+
+	if len(points) > 0 {
+		step := plotW / float64(len(points))
+		prevX, prevY := -1, -1
+		for i, p := range points {
+			x0 := chartMargin + int(step*float64(i))
+			if spec.Kind == "line" {
+				cx, cy := x0+int(step/2), y(p.Value)
+				if prevX >= 0 {
+					drawLine(img, prevX, prevY, cx, cy, chartPalette)
+				}
+				prevX, prevY = cx, cy
+				continue
+			}
+			top, bottom := y(p.Value), zero
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+			rect := image.Rect(x0+int(step*0.1), top, x0+int(step*0.9), bottom)
+			draw.Draw(img, rect, &image.Uniform{C: chartPalette}, image.Point{}, draw.Src)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawLine draws a two pixel wide line between two points
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	steps := int(math.Max(math.Abs(float64(x1-x0)), math.Abs(float64(y1-y0))))
+	if steps == 0 {
+		img.Set(x0, y0, c)
+		return
+	}
+	for i := 0; i <= steps; i++ {
+		x := x0 + (x1-x0)*i/steps
+		y := y0 + (y1-y0)*i/steps
+		img.Set(x, y, c)
+		img.Set(x, y+1, c)
+	}
+}
+
+// formatChartValue formats an axis or tooltip value
+func formatChartValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// toFloat converts a query value to a float
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	case nil:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -18,6 +19,15 @@ import (
 	"csic-platform/service/reporting/internal/repository"
 )
 
+var (
+	// ErrReportNotFound is returned when the report to export does not exist
+	ErrReportNotFound = errors.New("report not found")
+	// ErrReportNotReady is returned when the report has not completed generation
+	ErrReportNotReady = errors.New("report is not ready for export")
+	// ErrReportFileNotFound is returned when the report has no file in the requested format
+	ErrReportFileNotFound = errors.New("report file not found")
+)
+
 // ExportService handles secure export operations
 type ExportService struct {
 	reportRepo    repository.GeneratedReportRepository
@@ -79,13 +89,17 @@ type ExportRequest struct {
 	DeliveryAddress string
 }
 
-// ExportResult represents the result of an export operation
+// ExportResult represents the result of an export operation. FileReader is
+// seekable so downloads can be served in ranges; ChecksumSHA256 is the hex
+// digest of the complete content served, after any encryption.
 type ExportResult struct {
-	ExportLog     *domain.ExportLog
-	FileReader    io.ReadCloser
-	FileName      string
-	FileSize      int64
-	ContentType   string
+	ExportLog      *domain.ExportLog
+	FileReader     io.ReadSeekCloser
+	FileName       string
+	FileSize       int64
+	ContentType    string
+	ChecksumSHA256 string
+	ModTime        time.Time
 }
 
 // ExportReport exports a report securely
@@ -97,11 +111,11 @@ func (s *ExportService) ExportReport(ctx context.Context, req *ExportRequest) (*
 	}
 
 	if report == nil {
-		return nil, fmt.Errorf("report not found: %s", req.ReportID)
+		return nil, fmt.Errorf("%w: %s", ErrReportNotFound, req.ReportID)
 	}
 
 	if report.Status != domain.ReportStatusCompleted {
-		return nil, fmt.Errorf("report is not ready for export: status=%s", report.Status)
+		return nil, fmt.Errorf("%w: status=%s", ErrReportNotReady, report.Status)
 	}
 
 	// Find the file in the requested format
@@ -114,7 +128,7 @@ func (s *ExportService) ExportReport(ctx context.Context, req *ExportRequest) (*
 	}
 
 	if targetFile == nil {
-		return nil, fmt.Errorf("report file not found in format: %s", req.Format)
+		return nil, fmt.Errorf("%w in format: %s", ErrReportFileNotFound, req.Format)
 	}
 
 	// Create export log entry
@@ -134,12 +148,13 @@ func (s *ExportService) ExportReport(ctx context.Context, req *ExportRequest) (*
 
 	// Apply encryption if requested
 	if req.Encryption == domain.EncryptionAES256 {
-		fileReader, err = s.encryptReader(ctx, fileReader, req.Password)
+		// encryptReader consumes and closes the stored file
+		encrypted, err := s.encryptReader(ctx, fileReader, req.Password)
 		if err != nil {
-			fileReader.Close()
 			s.updateExportStatus(ctx, exportLog.ID, domain.ExportStatusFailed, err.Error())
 			return nil, fmt.Errorf("failed to encrypt file: %w", err)
 		}
+		fileReader = encrypted
 
 		// Update export log with encryption info
 		s.exportLogRepo.RecordEncryption(ctx, exportLog.ID, "aes256")
@@ -147,26 +162,122 @@ func (s *ExportService) ExportReport(ctx context.Context, req *ExportRequest) (*
 
 	// Apply watermark if requested
 	if req.Watermark {
-		fileReader, err = s.applyWatermark(ctx, fileReader, req.WatermarkText)
+		watermarked, err := s.applyWatermark(ctx, fileReader, req.WatermarkText)
 		if err != nil {
 			fileReader.Close()
 			s.updateExportStatus(ctx, exportLog.ID, domain.ExportStatusFailed, err.Error())
 			return nil, fmt.Errorf("failed to apply watermark: %w", err)
 		}
+		fileReader = watermarked
+	}
+
+	// Stored checksums cover the file as generated; transformed content is
+	// hashed as served
+	checksum := targetFile.Checksum
+	if req.Encryption != domain.EncryptionNone || req.Watermark || targetFile.ChecksumType != "sha256" || checksum == "" {
+		checksum, err = sha256Hex(fileReader)
+		if err != nil {
+			fileReader.Close()
+			s.updateExportStatus(ctx, exportLog.ID, domain.ExportStatusFailed, err.Error())
+			return nil, fmt.Errorf("failed to checksum file: %w", err)
+		}
 	}
 
 	// Update export log status
 	s.updateExportStatus(ctx, exportLog.ID, domain.ExportStatusCompleted, "")
 
 	return &ExportResult{
-		ExportLog:   exportLog,
-		FileReader:  fileReader,
-		FileName:    targetFile.Filename,
-		FileSize:    targetFile.FileSize,
-		ContentType: s.getContentType(req.Format),
+		ExportLog:      exportLog,
+		FileReader:     fileReader,
+		FileName:       targetFile.Filename,
+		FileSize:       targetFile.FileSize,
+		ContentType:    s.getContentType(req.Format),
+		ChecksumSHA256: checksum,
+		ModTime:        targetFile.CreatedAt,
 	}, nil
 }
 
+// GetManifest lists the files of a completed report with their sizes, SHA-256
+// checksums and download URLs, so each part of a multi-file bundle can be
+// fetched, resumed and verified independently
+func (s *ExportService) GetManifest(ctx context.Context, reportID uuid.UUID) (*domain.ReportManifest, error) {
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+
+	if report == nil {
+		return nil, fmt.Errorf("%w: %s", ErrReportNotFound, reportID)
+	}
+
+	if report.Status != domain.ReportStatusCompleted {
+		return nil, fmt.Errorf("%w: status=%s", ErrReportNotReady, report.Status)
+	}
+
+	manifest := &domain.ReportManifest{
+		ReportID:    report.ID,
+		Title:       report.Title,
+		Version:     report.Version,
+		PeriodStart: report.PeriodStart,
+		PeriodEnd:   report.PeriodEnd,
+		GeneratedAt: report.GeneratedAt,
+		Files:       make([]domain.ManifestFile, 0, len(report.Files)),
+	}
+
+	for _, file := range report.Files {
+		checksum := file.Checksum
+		if file.ChecksumType != "sha256" || checksum == "" {
+			checksum, err = s.storedChecksum(ctx, file.FilePath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to checksum %s file: %w", file.Format, err)
+			}
+		}
+
+		manifest.Files = append(manifest.Files, domain.ManifestFile{
+			Format:      file.Format,
+			Filename:    file.Filename,
+			ContentType: s.getContentType(file.Format),
+			Size:        file.FileSize,
+			SHA256:      checksum,
+			DownloadURL: fmt.Sprintf("/api/v1/reports/%s/download?format=%s", report.ID, file.Format),
+		})
+		manifest.TotalSize += file.FileSize
+	}
+
+	return manifest, nil
+}
+
+// storedChecksum hashes a file in storage
+func (s *ExportService) storedChecksum(ctx context.Context, filePath string) (string, error) {
+	reader, err := s.storage.GetReport(ctx, filePath)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	return sha256Hex(reader)
+}
+
+// sha256Hex returns the hex SHA-256 digest of the reader's content and
+// rewinds it for serving
+func sha256Hex(reader io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// nopSeekCloser adds a no-op Close to content held in memory
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
 // createExportLog creates an export log entry
 func (s *ExportService) createExportLog(ctx context.Context, report *domain.GeneratedReport, req *ExportRequest, file *domain.ReportFile) *domain.ExportLog {
 	// Determine expiration
@@ -210,7 +321,9 @@ func (s *ExportService) createExportLog(ctx context.Context, report *domain.Gene
 }
 
 // encryptReader encrypts the content of a reader
-func (s *ExportService) encryptReader(ctx context.Context, reader io.Reader, password string) (io.ReadCloser, error) {
+func (s *ExportService) encryptReader(ctx context.Context, reader io.ReadCloser, password string) (io.ReadSeekCloser, error) {
+	defer reader.Close()
+
 	// Derive key from password
 	key := sha256.Sum256([]byte(password))
 
@@ -241,11 +354,11 @@ func (s *ExportService) encryptReader(ctx context.Context, reader io.Reader, pas
 	// Encrypt content
 	encrypted := gcm.Seal(nonce, nonce, content, nil)
 
-	return io.NopCloser(io.Reader(bytes.NewReader(encrypted))), nil
+	return nopSeekCloser{bytes.NewReader(encrypted)}, nil
 }
 
 // applyWatermark applies a watermark to the content
-func (s *ExportService) applyWatermark(ctx context.Context, reader io.Reader, watermarkText string) (io.ReadCloser, error) {
+func (s *ExportService) applyWatermark(ctx context.Context, reader io.ReadSeekCloser, watermarkText string) (io.ReadSeekCloser, error) {
 	// This is a placeholder implementation
 	// In a real implementation, this would:
 	// 1. Detect the file type (PDF, image, etc.)
@@ -273,6 +386,7 @@ func (s *ExportService) getContentType(format domain.ReportFormat) string {
 		domain.ReportFormatJSON: "application/json",
 		domain.ReportFormatXBRL: "application/xbrl+xml",
 		domain.ReportFormatXML:  "application/xml",
+		domain.ReportFormatHTML: "text/html; charset=utf-8",
 	}
 
 	if ct, ok := contentTypes[format]; ok {
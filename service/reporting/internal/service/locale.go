@@ -0,0 +1,93 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LocaleEnUS is American English, the default document locale
+	LocaleEnUS = "en-US"
+	// LocaleZhCN is Simplified Chinese
+	LocaleZhCN = "zh-CN"
+)
+
+// documentLabels holds the fixed text of generated documents per locale.
+// Section markup and field labels are written by template authors; these are
+// the words the engine adds around them.
+var documentLabels = map[string]map[string]string{
+	LocaleEnUS: {
+		"period":  "Period: %s to %s",
+		"records": "%d records",
+		"date":    "2006-01-02",
+	},
+	LocaleZhCN: {
+		"period":  "报告期间：%s 至 %s",
+		"records": "共 %d 条记录",
+		"date":    "2006年01月02日",
+	},
+}
+
+// NormalizeLocale maps a language tag to a supported document locale, or
+// returns "" if none matches. "en-GB" maps to en-US and "zh-Hans" to zh-CN;
+// Traditional Chinese tags do not match.
+func NormalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	switch {
+	case tag == "":
+		return ""
+	case tag == "en" || strings.HasPrefix(tag, "en-"):
+		return LocaleEnUS
+	case strings.Contains(tag, "hant"), strings.HasSuffix(tag, "-tw"),
+		strings.HasSuffix(tag, "-hk"), strings.HasSuffix(tag, "-mo"):
+		return ""
+	case tag == "zh" || strings.HasPrefix(tag, "zh-"):
+		return LocaleZhCN
+	}
+	return ""
+}
+
+// NegotiateLocale picks the supported locale the caller prefers from an
+// Accept-Language header value, or returns "" if it names none
+func NegotiateLocale(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		q := 1.0
+		for _, param := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if tag != "" && q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	for _, c := range candidates {
+		if locale := NormalizeLocale(c.tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// documentLabel returns a fixed document label in the locale, falling back to English
+func documentLabel(locale, key string) string {
+	if label, ok := documentLabels[locale][key]; ok {
+		return label
+	}
+	return documentLabels[LocaleEnUS][key]
+}
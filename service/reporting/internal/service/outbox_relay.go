@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"csic-platform/service/reporting/internal/domain"
+	"csic-platform/service/reporting/internal/repository"
+)
+
+// OutboxPublisher defines the interface for delivering outbox events to Kafka
+type OutboxPublisher interface {
+	PublishOutboxEvent(ctx context.Context, event *domain.OutboxEvent) error
+}
+
+// OutboxRelayConfig holds the outbox relay configuration
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	MaxBackoff   time.Duration
+	Retention    time.Duration
+}
+
+// DefaultOutboxRelayConfig returns the default outbox relay configuration
+func DefaultOutboxRelayConfig() OutboxRelayConfig {
+	return OutboxRelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		Lease:        30 * time.Second,
+		MaxBackoff:   5 * time.Minute,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// OutboxRelay delivers pending outbox events to Kafka. An event is marked
+// published only after Kafka acknowledges it, so a crash in between leads to
+// redelivery rather than loss.
+type OutboxRelay struct {
+	outbox    repository.OutboxRepository
+	publisher OutboxPublisher
+	config    OutboxRelayConfig
+}
+
+// NewOutboxRelay creates a new outbox relay
+func NewOutboxRelay(outbox repository.OutboxRepository, publisher OutboxPublisher, config OutboxRelayConfig) *OutboxRelay {
+	defaults := DefaultOutboxRelayConfig()
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaults.Lease
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		config:    config,
+	}
+}
+
+// Start relays events until ctx is cancelled. A full batch is followed
+// immediately by another pass so that a backlog drains without waiting.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		published, err := r.RunOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Outbox relay error: %v", err)
+		}
+
+		if r.config.Retention > 0 && time.Since(lastCleanup) >= time.Hour {
+			if _, err := r.outbox.DeletePublishedBefore(ctx, time.Now().Add(-r.config.Retention)); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to prune outbox: %v", err)
+			}
+			lastCleanup = time.Now()
+		}
+
+		if published == r.config.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce claims and publishes one batch of due events, returning how many were published
+func (r *OutboxRelay) RunOnce(ctx context.Context) (int, error) {
+	events, err := r.outbox.ClaimPending(ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	var errs []error
+	for _, event := range events {
+		if err := r.publisher.PublishOutboxEvent(ctx, event); err != nil {
+			retryAt := time.Now().Add(r.backoff(event.Attempts))
+			if markErr := r.outbox.MarkFailed(ctx, event.ID, err.Error(), retryAt); markErr != nil {
+				errs = append(errs, markErr)
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		// If this fails the lease expires and the event is published again;
+		// consumers discard the duplicate by its dedup key
+		if err := r.outbox.MarkPublished(ctx, event.ID); err != nil {
+			errs = append(errs, fmt.Errorf("event %s: %w", event.ID, err))
+			continue
+		}
+		published++
+	}
+
+	return published, errors.Join(errs...)
+}
+
+// backoff returns the exponential retry delay after the given number of attempts
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := r.config.PollInterval
+	for i := 1; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.config.MaxBackoff {
+		delay = r.config.MaxBackoff
+	}
+	return delay
+}
@@ -20,9 +20,11 @@ type ReportGenerationService struct {
 	templateRepo   repository.ReportTemplateRepository
 	reportRepo     repository.GeneratedReportRepository
 	cacheRepo      *repository.CacheRepository
+	txManager      repository.TxManager
 	kafkaProducer  KafkaProducer
 	fileStorage    FileStorage
 	metrics        MetricsRecorder
+	engine         *TemplateEngine
 }
 
 // NewReportGenerationService creates a new report generation service
@@ -30,17 +32,21 @@ func NewReportGenerationService(
 	templateRepo repository.ReportTemplateRepository,
 	reportRepo repository.GeneratedReportRepository,
 	cacheRepo *repository.CacheRepository,
+	txManager repository.TxManager,
 	kafkaProducer KafkaProducer,
 	fileStorage FileStorage,
 	metrics MetricsRecorder,
+	engine *TemplateEngine,
 ) *ReportGenerationService {
 	return &ReportGenerationService{
 		templateRepo:  templateRepo,
 		reportRepo:    reportRepo,
 		cacheRepo:     cacheRepo,
+		txManager:     txManager,
 		kafkaProducer: kafkaProducer,
 		fileStorage:   fileStorage,
 		metrics:       metrics,
+		engine:        engine,
 	}
 }
 
@@ -57,10 +63,11 @@ type KafkaProducer interface {
 	PublishReportScheduled(ctx context.Context, schedule *domain.ReportSchedule) error
 }
 
-// FileStorage defines the interface for file storage operations
+// FileStorage defines the interface for file storage operations. GetReport
+// returns a seekable reader so downloads can be served in byte ranges.
 type FileStorage interface {
 	SaveReport(ctx context.Context, reportID uuid.UUID, format domain.ReportFormat, content io.Reader) (string, int64, error)
-	GetReport(ctx context.Context, filePath string) (io.ReadCloser, error)
+	GetReport(ctx context.Context, filePath string) (io.ReadSeekCloser, error)
 	DeleteReport(ctx context.Context, filePath string) error
 }
 
@@ -74,6 +81,7 @@ type GenerateReportRequest struct {
 	TriggeredBy     string
 	OutputFormats   []domain.ReportFormat
 	CustomFields    map[string]interface{}
+	Locale          string // document locale, en-US when empty
 }
 
 // GenerateReport generates a new report
@@ -92,6 +100,13 @@ func (s *ReportGenerationService) GenerateReport(ctx context.Context, req *Gener
 		return nil, fmt.Errorf("template is not active: %s", req.TemplateID)
 	}
 
+	locale := LocaleEnUS
+	if req.Locale != "" {
+		if locale = NormalizeLocale(req.Locale); locale == "" {
+			return nil, fmt.Errorf("unsupported locale: %s", req.Locale)
+		}
+	}
+
 	// Create the generated report record
 	report := &domain.GeneratedReport{
 		ID:               uuid.New(),
@@ -103,6 +118,7 @@ func (s *ReportGenerationService) GenerateReport(ctx context.Context, req *Gener
 		Version:          template.Version,
 		Title:            fmt.Sprintf("%s - %s", template.Name, req.PeriodEnd.Format("2006-01-02")),
 		Description:      template.Description,
+		Locale:           locale,
 		PeriodStart:      req.PeriodStart,
 		PeriodEnd:        req.PeriodEnd,
 		ReportDate:       time.Now(),
@@ -185,19 +201,20 @@ func (s *ReportGenerationService) processReportGeneration(ctx context.Context, r
 	report.TotalSize = totalSize
 	report.Checksum = s.calculateChecksum(data)
 
-	// Record generation completion
-	if err := s.reportRepo.RecordGeneration(ctx, report.ID, time.Since(startTime).Milliseconds()); err != nil {
-		log.Printf("Failed to record generation: %v", err)
-	}
-
-	// Update the report
-	if err := s.reportRepo.Update(ctx, report); err != nil {
-		log.Printf("Failed to update report: %v", err)
-	}
-
-	// Publish Kafka event
-	if pubErr := s.kafkaProducer.PublishReportGenerated(ctx, report); pubErr != nil {
-		log.Printf("Failed to publish Kafka event: %v", pubErr)
+	// Record completion and the generated event together so the event is
+	// published if and only if the report is stored as completed
+	processingTime := time.Since(startTime).Milliseconds()
+	txErr := s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.reportRepo.RecordGeneration(ctx, report.ID, processingTime); err != nil {
+			return err
+		}
+		if err := s.reportRepo.Update(ctx, report); err != nil {
+			return err
+		}
+		return s.kafkaProducer.PublishReportGenerated(ctx, report)
+	})
+	if txErr != nil {
+		log.Printf("Failed to record generation of report %s: %v", report.ID, txErr)
 	}
 
 	log.Printf("Successfully generated report %s with %d files", report.ID, len(files))
@@ -233,35 +250,43 @@ func (s *ReportGenerationService) getTemplateWithCache(ctx context.Context, temp
 	return template, nil
 }
 
-// collectReportData collects data for the report
+// collectReportData runs the template's named queries for the report period
+// and renders its sections, returning the rendered report as JSON
 func (s *ReportGenerationService) collectReportData(ctx context.Context, report *domain.GeneratedReport, template *domain.ReportTemplate) ([]byte, error) {
-	// This is a placeholder implementation
-	// In a real implementation, this would:
-	// 1. Query data sources defined in template.TemplateSchema.DataSources
-	// 2. Apply transformations defined in template.TemplateSchema.Transformations
-	// 3. Validate data against template.TemplateSchema.ValidationRules
-	// 4. Return the collected data as JSON
-
-	// For now, return sample data
-	sampleData := map[string]interface{}{
-		"report_id":      report.ID.String(),
-		"template_name":  template.Name,
-		"report_type":    report.ReportType,
-		"period_start":   report.PeriodStart,
-		"period_end":     report.PeriodEnd,
-		"generated_at":   time.Now(),
-		"data_sources":   template.TemplateSchema.DataSources,
-		"sample_metric":  1234.56,
-		"record_count":   100,
-	}
-
-	return json.Marshal(sampleData)
+	rendered, err := s.engine.Render(ctx, template.TemplateSchema, repository.QueryParams{
+		PeriodStart: report.PeriodStart,
+		PeriodEnd:   report.PeriodEnd,
+		RegulatorID: report.RegulatorID,
+	}, report.CustomFields, report.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"report_id":        report.ID.String(),
+		"template_id":      template.ID.String(),
+		"template_name":    template.Name,
+		"template_version": template.Version,
+		"report_type":      report.ReportType,
+		"rendered":         rendered,
+	})
 }
 
 // generateFile generates a file in the specified format
 func (s *ReportGenerationService) generateFile(ctx context.Context, report *domain.GeneratedReport, template *domain.ReportTemplate, format domain.ReportFormat, data []byte) (*domain.ReportFile, error) {
 	filename := fmt.Sprintf("%s_%s.%s", report.ID.String(), format, format)
-	
+
+	// HTML output is the rendered document with charts inline
+	if format == domain.ReportFormatHTML {
+		var payload struct {
+			Rendered domain.RenderedReport `json:"rendered"`
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode rendered report: %w", err)
+		}
+		data = []byte(payload.Rendered.HTML)
+	}
+
 	// Create a reader from the data
 	reader := io.NopCloser(io.Reader(bytes.NewReader(data)))
 
@@ -290,17 +315,27 @@ func (s *ReportGenerationService) generateFile(ctx context.Context, report *doma
 
 // handleGenerationFailure handles a generation failure
 func (s *ReportGenerationService) handleGenerationFailure(ctx context.Context, report *domain.GeneratedReport, err error) {
-	if updateErr := s.reportRepo.RecordFailure(ctx, report.ID, err.Error()); updateErr != nil {
-		log.Printf("Failed to record failure: %v", updateErr)
-	}
-
-	if pubErr := s.kafkaProducer.PublishReportFailed(ctx, report.ID, err.Error()); pubErr != nil {
-		log.Printf("Failed to publish failure event: %v", pubErr)
+	txErr := s.withinTx(ctx, func(ctx context.Context) error {
+		if updateErr := s.reportRepo.RecordFailure(ctx, report.ID, err.Error()); updateErr != nil {
+			return updateErr
+		}
+		return s.kafkaProducer.PublishReportFailed(ctx, report.ID, err.Error())
+	})
+	if txErr != nil {
+		log.Printf("Failed to record failure: %v", txErr)
 	}
 
 	log.Printf("Failed to generate report %s: %v", report.ID, err)
 }
 
+// withinTx runs fn in a transaction when a transaction manager is configured
+func (s *ReportGenerationService) withinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.txManager == nil {
+		return fn(ctx)
+	}
+	return s.txManager.WithinTx(ctx, fn)
+}
+
 // calculateChecksum calculates a checksum for the data
 func (s *ReportGenerationService) calculateChecksum(data []byte) string {
 	hash := sha256.Sum256(data)
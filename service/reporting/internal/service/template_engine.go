@@ -0,0 +1,305 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"csic-platform/service/reporting/internal/domain"
+	"csic-platform/service/reporting/internal/repository"
+)
+
+// TemplateEngine renders report templates. Each section's content is Go
+// html/template markup executed against the rows of its bound named query;
+// sections without content fall back to a table or chart of those rows.
+type TemplateEngine struct {
+	runner repository.DataSourceRunner
+}
+
+// NewTemplateEngine creates a new template engine
+func NewTemplateEngine(runner repository.DataSourceRunner) *TemplateEngine {
+	return &TemplateEngine{runner: runner}
+}
+
+// sectionContext is the data available to section markup
+type sectionContext struct {
+	Title   string
+	Locale  string
+	Period  periodContext
+	Section domain.ReportSection
+	Rows    []map[string]interface{}
+	Data    map[string][]map[string]interface{}
+	Params  map[string]interface{}
+	chart   *domain.RenderedChart
+}
+
+// periodContext is the reporting period exposed to section markup
+type periodContext struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Validate checks that every section parses and binds to a defined data source
+func (e *TemplateEngine) Validate(schema domain.TemplateSchema) error {
+	sources := make(map[string]bool, len(schema.DataSources))
+	for _, source := range schema.DataSources {
+		if source.ID == "" {
+			return fmt.Errorf("data source ID is required")
+		}
+		if sources[source.ID] {
+			return fmt.Errorf("duplicate data source %q", source.ID)
+		}
+		sources[source.ID] = true
+	}
+
+	for _, section := range schema.Sections {
+		if section.DataBinding != "" && !sources[section.DataBinding] {
+			return fmt.Errorf("section %q binds to unknown data source %q", section.ID, section.DataBinding)
+		}
+		if section.Chart != nil && (section.Chart.LabelField == "" || section.Chart.ValueField == "") {
+			return fmt.Errorf("section %q chart requires label_field and value_field", section.ID)
+		}
+		if _, err := e.parseSection(section, &sectionContext{}); err != nil {
+			return fmt.Errorf("section %q: %w", section.ID, err)
+		}
+	}
+	return nil
+}
+
+// Render runs the template's named queries for the period and renders every
+// section, with the document's own text in the given locale
+func (e *TemplateEngine) Render(ctx context.Context, schema domain.TemplateSchema, params repository.QueryParams, custom map[string]interface{}, locale string) (*domain.RenderedReport, error) {
+	if locale = NormalizeLocale(locale); locale == "" {
+		locale = LocaleEnUS
+	}
+
+	data := make(map[string][]map[string]interface{}, len(schema.DataSources))
+	for _, source := range schema.DataSources {
+		rows, err := e.runner.Run(ctx, source, params)
+		if err != nil {
+			return nil, err
+		}
+		if rows == nil {
+			rows = []map[string]interface{}{}
+		}
+		data[source.ID] = rows
+	}
+
+	sections := make([]domain.ReportSection, len(schema.Sections))
+	copy(sections, schema.Sections)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Order < sections[j].Order
+	})
+
+	report := &domain.RenderedReport{
+		Title:       schema.Title,
+		Locale:      locale,
+		PeriodStart: params.PeriodStart,
+		PeriodEnd:   params.PeriodEnd,
+		Sections:    make([]domain.RenderedSection, 0, len(sections)),
+		Data:        data,
+		RenderedAt:  time.Now(),
+	}
+
+	for _, section := range sections {
+		rendered, err := e.renderSection(section, &sectionContext{
+			Title:   schema.Title,
+			Locale:  locale,
+			Period:  periodContext{Start: params.PeriodStart, End: params.PeriodEnd},
+			Section: section,
+			Rows:    data[section.DataBinding],
+			Data:    data,
+			Params:  custom,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("section %q: %w", section.ID, err)
+		}
+		report.Sections = append(report.Sections, *rendered)
+	}
+
+	report.HTML = assembleHTML(report)
+	return report, nil
+}
+
+// renderSection draws the section's chart, if any, and executes its markup
+func (e *TemplateEngine) renderSection(section domain.ReportSection, sc *sectionContext) (*domain.RenderedSection, error) {
+	if section.Chart != nil {
+		chart, err := RenderChart(section.Chart, sc.Rows)
+		if err != nil {
+			return nil, err
+		}
+		sc.chart = chart
+	}
+
+	tmpl, err := e.parseSection(section, sc)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, sc); err != nil {
+		return nil, err
+	}
+
+	return &domain.RenderedSection{
+		ID:    section.ID,
+		Name:  section.Name,
+		Type:  section.Type,
+		HTML:  buf.String(),
+		Chart: sc.chart,
+	}, nil
+}
+
+// parseSection parses the section's markup, or its default layout when it has none
+func (e *TemplateEngine) parseSection(section domain.ReportSection, sc *sectionContext) (*template.Template, error) {
+	content := section.Content
+	if strings.TrimSpace(content) == "" {
+		content = defaultSectionContent(section)
+	}
+	return template.New(section.ID).Funcs(templateFuncs(sc)).Parse(content)
+}
+
+// defaultSectionContent lays out a section that has no markup of its own
+func defaultSectionContent(section domain.ReportSection) string {
+	if section.Chart != nil || section.Type == "chart" {
+		return `{{chart}}`
+	}
+	if section.Type == "table" {
+		return `{{table .Rows}}`
+	}
+	return `{{printf (label "records") (len .Rows)}}`
+}
+
+// templateFuncs returns the functions available to section markup
+func templateFuncs(sc *sectionContext) template.FuncMap {
+	return template.FuncMap{
+		"chart": func() template.HTML {
+			if sc.chart == nil {
+				return ""
+			}
+			return template.HTML(fmt.Sprintf(`<img alt="%s" src="data:%s;base64,%s"/>`,
+				template.HTMLEscapeString(sc.Section.Name), sc.chart.MimeType,
+				base64.StdEncoding.EncodeToString(sc.chart.Data)))
+		},
+		"table": func(rows []map[string]interface{}) template.HTML {
+			return renderTable(sc.Section.Fields, rows, sc.Locale)
+		},
+		"label": func(key string) string {
+			return documentLabel(sc.Locale, key)
+		},
+		"rows": func(source string) []map[string]interface{} {
+			return sc.Data[source]
+		},
+		"sum": func(field string, rows []map[string]interface{}) float64 {
+			total := 0.0
+			for _, row := range rows {
+				if v, ok := toFloat(row[field]); ok {
+					total += v
+				}
+			}
+			return total
+		},
+		"count": func(rows []map[string]interface{}) int {
+			return len(rows)
+		},
+		"formatNumber": func(v interface{}, decimals int) string {
+			f, ok := toFloat(v)
+			if !ok {
+				return fmt.Sprint(v)
+			}
+			return strconv.FormatFloat(f, 'f', decimals, 64)
+		},
+		"percent": func(v interface{}) string {
+			f, _ := toFloat(v)
+			return strconv.FormatFloat(f*100, 'f', 2, 64) + "%"
+		},
+		"formatDate": func(t time.Time, layout string) string {
+			return t.Format(layout)
+		},
+	}
+}
+
+// renderTable renders rows as an HTML table. Visible section fields choose and
+// label the columns, in the document locale where the field has a label for
+// it; without fields every column of the first row is shown.
+func renderTable(fields []domain.SectionField, rows []map[string]interface{}, locale string) template.HTML {
+	type column struct{ name, label string }
+	var columns []column
+
+	visible := make([]domain.SectionField, 0, len(fields))
+	for _, field := range fields {
+		if !field.Hidden {
+			visible = append(visible, field)
+		}
+	}
+	sort.SliceStable(visible, func(i, j int) bool { return visible[i].Order < visible[j].Order })
+	for _, field := range visible {
+		name := field.Source
+		if name == "" {
+			name = field.Name
+		}
+		label := field.Labels[locale]
+		if label == "" {
+			label = field.Label
+		}
+		if label == "" {
+			label = field.Name
+		}
+		columns = append(columns, column{name: name, label: label})
+	}
+
+	if len(columns) == 0 && len(rows) > 0 {
+		names := make([]string, 0, len(rows[0]))
+		for name := range rows[0] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			columns = append(columns, column{name: name, label: name})
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<table><thead><tr>")
+	for _, c := range columns {
+		b.WriteString("<th>" + template.HTMLEscapeString(c.label) + "</th>")
+	}
+	b.WriteString("</tr></thead><tbody>")
+	for _, row := range rows {
+		b.WriteString("<tr>")
+		for _, c := range columns {
+			value := ""
+			if v := row[c.name]; v != nil {
+				value = fmt.Sprint(v)
+			}
+			b.WriteString("<td>" + template.HTMLEscapeString(value) + "</td>")
+		}
+		b.WriteString("</tr>")
+	}
+	b.WriteString("</tbody></table>")
+	return template.HTML(b.String())
+}
+
+// assembleHTML wraps the rendered sections in a standalone HTML document
+func assembleHTML(report *domain.RenderedReport) string {
+	var b strings.Builder
+	title := template.HTMLEscapeString(report.Title)
+	dateLayout := documentLabel(report.Locale, "date")
+	fmt.Fprintf(&b, "<!DOCTYPE html><html lang=\"%s\"><head><meta charset=\"utf-8\"><title>%s</title></head><body>",
+		template.HTMLEscapeString(report.Locale), title)
+	b.WriteString("<h1>" + title + "</h1>")
+	b.WriteString("<p>" + template.HTMLEscapeString(fmt.Sprintf(documentLabel(report.Locale, "period"),
+		report.PeriodStart.Format(dateLayout), report.PeriodEnd.Format(dateLayout))) + "</p>")
+	for _, section := range report.Sections {
+		fmt.Fprintf(&b, "<section id=\"%s\"><h2>%s</h2>%s</section>",
+			template.HTMLEscapeString(section.ID), template.HTMLEscapeString(section.Name), section.HTML)
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
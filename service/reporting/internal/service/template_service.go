@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"csic-platform/service/reporting/internal/domain"
+	"csic-platform/service/reporting/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidTemplate is returned when a template fails validation
+	ErrInvalidTemplate = errors.New("invalid template")
+	// ErrTemplateNotFound is returned when a template or version does not exist
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+// TemplateService manages versioned report templates and template previews
+type TemplateService struct {
+	templateRepo repository.ReportTemplateRepository
+	versionRepo  repository.ReportTemplateVersionRepository
+	cacheRepo    *repository.CacheRepository
+	txManager    repository.TxManager
+	engine       *TemplateEngine
+}
+
+// NewTemplateService creates a new template service
+func NewTemplateService(
+	templateRepo repository.ReportTemplateRepository,
+	versionRepo repository.ReportTemplateVersionRepository,
+	cacheRepo *repository.CacheRepository,
+	txManager repository.TxManager,
+	engine *TemplateEngine,
+) *TemplateService {
+	return &TemplateService{
+		templateRepo: templateRepo,
+		versionRepo:  versionRepo,
+		cacheRepo:    cacheRepo,
+		txManager:    txManager,
+		engine:       engine,
+	}
+}
+
+// PreviewRequest represents a request to preview a template
+type PreviewRequest struct {
+	PeriodStart   time.Time              `json:"period_start"`
+	PeriodEnd     time.Time              `json:"period_end"`
+	VersionNumber int                    `json:"version_number"` // 0 previews the current template
+	Locale        string                 `json:"locale"`
+	CustomFields  map[string]interface{} `json:"custom_fields"`
+}
+
+// CreateTemplate validates and stores a new template as version 1
+func (s *TemplateService) CreateTemplate(ctx context.Context, template *domain.ReportTemplate, createdBy string) error {
+	if err := s.validate(template); err != nil {
+		return err
+	}
+
+	template.ID = uuid.New()
+	template.CreatedBy = createdBy
+	template.Version = "1"
+
+	return s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.templateRepo.Create(ctx, template); err != nil {
+			return err
+		}
+		return s.recordVersion(ctx, template, 1, "initial version", createdBy)
+	})
+}
+
+// UpdateTemplate validates and stores a template change as a new version
+func (s *TemplateService) UpdateTemplate(ctx context.Context, template *domain.ReportTemplate, changeNote, updatedBy string) error {
+	if err := s.validate(template); err != nil {
+		return err
+	}
+
+	existing, err := s.templateRepo.GetByID(ctx, template.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrTemplateNotFound
+	}
+	template.CreatedBy = existing.CreatedBy
+
+	err = s.withinTx(ctx, func(ctx context.Context) error {
+		latest, err := s.versionRepo.GetLatest(ctx, template.ID)
+		if err != nil {
+			return err
+		}
+		number := 1
+		if latest != nil {
+			number = latest.VersionNumber + 1
+		}
+
+		template.Version = strconv.Itoa(number)
+		if err := s.templateRepo.Update(ctx, template); err != nil {
+			return err
+		}
+		return s.recordVersion(ctx, template, number, changeNote, updatedBy)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateCache(ctx, template.ID)
+	return nil
+}
+
+// DeleteTemplate deactivates a template; its versions are kept
+func (s *TemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateCache(ctx, id)
+	return nil
+}
+
+// GetTemplate retrieves a template by ID
+func (s *TemplateService) GetTemplate(ctx context.Context, id uuid.UUID) (*domain.ReportTemplate, error) {
+	return s.templateRepo.GetByID(ctx, id)
+}
+
+// ListTemplates lists templates, optionally only the active ones of a report type
+func (s *TemplateService) ListTemplates(ctx context.Context, reportType domain.ReportType, page, pageSize int) ([]*domain.ReportTemplate, int, error) {
+	if reportType != "" {
+		templates, err := s.templateRepo.GetByReportType(ctx, reportType)
+		return templates, len(templates), err
+	}
+	return s.templateRepo.List(ctx, page, pageSize)
+}
+
+// ListVersions lists a template's versions, newest first
+func (s *TemplateService) ListVersions(ctx context.Context, templateID uuid.UUID) ([]*domain.ReportTemplateVersion, error) {
+	return s.versionRepo.ListByTemplateID(ctx, templateID)
+}
+
+// GetVersion retrieves a single version of a template
+func (s *TemplateService) GetVersion(ctx context.Context, templateID uuid.UUID, number int) (*domain.ReportTemplateVersion, error) {
+	return s.versionRepo.GetByNumber(ctx, templateID, number)
+}
+
+// PreviewTemplate renders a template, or one of its versions, for a period
+// without storing a report
+func (s *TemplateService) PreviewTemplate(ctx context.Context, templateID uuid.UUID, req *PreviewRequest) (*domain.RenderedReport, error) {
+	template, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	schema := template.TemplateSchema
+	if req.VersionNumber > 0 {
+		version, err := s.versionRepo.GetByNumber(ctx, templateID, req.VersionNumber)
+		if err != nil {
+			return nil, err
+		}
+		if version == nil {
+			return nil, fmt.Errorf("%w: version %d", ErrTemplateNotFound, req.VersionNumber)
+		}
+		schema = version.TemplateSchema
+	}
+
+	if req.PeriodEnd.IsZero() {
+		req.PeriodEnd = time.Now()
+	}
+	if req.PeriodStart.IsZero() {
+		req.PeriodStart = req.PeriodEnd.AddDate(0, -1, 0)
+	}
+
+	return s.engine.Render(ctx, schema, repository.QueryParams{
+		PeriodStart: req.PeriodStart,
+		PeriodEnd:   req.PeriodEnd,
+		RegulatorID: template.RegulatorID,
+	}, req.CustomFields, req.Locale)
+}
+
+// validate checks the template's required fields and section markup
+func (s *TemplateService) validate(template *domain.ReportTemplate) error {
+	if template.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+	if template.ReportType == "" {
+		return fmt.Errorf("%w: report type is required", ErrInvalidTemplate)
+	}
+	if err := s.engine.Validate(template.TemplateSchema); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return nil
+}
+
+// recordVersion stores a snapshot of the template
+func (s *TemplateService) recordVersion(ctx context.Context, template *domain.ReportTemplate, number int, changeNote, createdBy string) error {
+	return s.versionRepo.Create(ctx, &domain.ReportTemplateVersion{
+		ID:              uuid.New(),
+		TemplateID:      template.ID,
+		ReportType:      template.ReportType,
+		VersionNumber:   number,
+		TemplateSchema:  template.TemplateSchema,
+		GenerationRules: template.GenerationRules,
+		ChangeNote:      changeNote,
+		CreatedBy:       createdBy,
+		CreatedAt:       time.Now(),
+	})
+}
+
+// invalidateCache drops a cached template so generation picks up the change
+func (s *TemplateService) invalidateCache(ctx context.Context, id uuid.UUID) {
+	if s.cacheRepo == nil {
+		return
+	}
+	if err := s.cacheRepo.DeleteTemplate(ctx, id.String()); err != nil {
+		log.Printf("Failed to invalidate cached template %s: %v", id, err)
+	}
+}
+
+// withinTx runs fn in a transaction when a transaction manager is configured
+func (s *TemplateService) withinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.txManager == nil {
+		return fn(ctx)
+	}
+	return s.txManager.WithinTx(ctx, fn)
+}
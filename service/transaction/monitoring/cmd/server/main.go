@@ -13,10 +13,20 @@ import (
 	httpHandler "github.com/csic/transaction-monitoring/internal/handler/http"
 	kafkaConsumer "github.com/csic/transaction-monitoring/internal/handler/kafka"
 	"github.com/csic/transaction-monitoring/internal/repository"
+	analyticsSvc "github.com/csic/transaction-monitoring/internal/service/analytics"
+	bridgeSvc "github.com/csic/transaction-monitoring/internal/service/bridge"
+	complianceSvc "github.com/csic/transaction-monitoring/internal/service/compliance"
 	graphSvc "github.com/csic/transaction-monitoring/internal/service/graph"
 	ingestSvc "github.com/csic/transaction-monitoring/internal/service/ingest"
+	layer2Svc "github.com/csic/transaction-monitoring/internal/service/layer2"
+	mempoolSvc "github.com/csic/transaction-monitoring/internal/service/mempool"
+	reorgSvc "github.com/csic/transaction-monitoring/internal/service/reorg"
 	riskSvc "github.com/csic/transaction-monitoring/internal/service/risk"
 	sanctionsSvc "github.com/csic/transaction-monitoring/internal/service/sanctions"
+	screeningSvc "github.com/csic/transaction-monitoring/internal/service/screening"
+	taintSvc "github.com/csic/transaction-monitoring/internal/service/taint"
+	tokenSvc "github.com/csic/transaction-monitoring/internal/service/token"
+	watchSvc "github.com/csic/transaction-monitoring/internal/service/watch"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -55,17 +65,53 @@ func main() {
 	defer cacheRepo.Close()
 
 	// Initialize services
-	ingestionService, err := ingestSvc.NewIngestionService(cfg, repo, logger)
+	reorgService := reorgSvc.NewService(cfg, repo, logger)
+
+	tokenRegistry, err := tokenSvc.NewRegistry(cfg, repo, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize token registry", zap.Error(err))
+	}
+	if err := tokenRegistry.Seed(ctx); err != nil {
+		logger.Fatal("Failed to seed token registry", zap.Error(err))
+	}
+
+	ingestionService, err := ingestSvc.NewIngestionService(cfg, repo, reorgService, tokenRegistry, logger)
 	if err != nil {
 		logger.Fatal("Failed to initialize ingestion service", zap.Error(err))
 	}
 
 	riskService := riskSvc.NewRiskScoringService(cfg, repo, cacheRepo, logger)
 
+	scoringPipeline := riskSvc.NewScoringPipeline(cfg, repo, cacheRepo, logger)
+
 	clusteringService := graphSvc.NewClusteringService(cfg, repo, nil, logger)
 
 	sanctionsService := sanctionsSvc.NewSanctionsService(cfg, repo, cacheRepo, logger)
 
+	screeningService := screeningSvc.NewScreeningService(cfg, repo, sanctionsService, logger)
+
+	complianceService := complianceSvc.NewComplianceService(cfg, cacheRepo, riskService, screeningService, logger)
+
+	structuringService := analyticsSvc.NewStructuringService(cfg, repo, logger)
+
+	bridgeMonitor := bridgeSvc.NewMonitorService(cfg, repo, logger)
+
+	concentrationService := analyticsSvc.NewConcentrationService(cfg, repo, logger)
+
+	watchService := watchSvc.NewService(cfg, repo, logger)
+
+	mempoolService, err := mempoolSvc.NewService(cfg, repo, watchService, logger)
+	if err != nil {
+		logger.Fatal("Failed to create mempool monitoring service", zap.Error(err))
+	}
+
+	layer2Service, err := layer2Svc.NewService(cfg, repo, logger)
+	if err != nil {
+		logger.Fatal("Failed to create layer-two monitoring service", zap.Error(err))
+	}
+
+	taintService := taintSvc.NewService(cfg, repo, logger)
+
 	// Start services
 	if err := sanctionsService.Start(ctx); err != nil {
 		logger.Fatal("Failed to start sanctions service", zap.Error(err))
@@ -82,9 +128,44 @@ func main() {
 	}
 	defer clusteringService.Stop()
 
+	if err := structuringService.Start(ctx); err != nil {
+		logger.Fatal("Failed to start structuring analytics service", zap.Error(err))
+	}
+	defer structuringService.Stop()
+
+	if err := bridgeMonitor.Start(ctx); err != nil {
+		logger.Fatal("Failed to start bridge monitoring service", zap.Error(err))
+	}
+	defer bridgeMonitor.Stop()
+
+	if err := concentrationService.Start(ctx); err != nil {
+		logger.Fatal("Failed to start concentration analytics service", zap.Error(err))
+	}
+	defer concentrationService.Stop()
+
+	if err := watchService.Start(ctx); err != nil {
+		logger.Fatal("Failed to start address watch service", zap.Error(err))
+	}
+	defer watchService.Stop()
+
+	if err := mempoolService.Start(ctx); err != nil {
+		logger.Fatal("Failed to start mempool monitoring service", zap.Error(err))
+	}
+	defer mempoolService.Stop()
+
+	if err := layer2Service.Start(ctx); err != nil {
+		logger.Fatal("Failed to start layer-two monitoring service", zap.Error(err))
+	}
+	defer layer2Service.Stop()
+
+	if err := scoringPipeline.Start(ctx); err != nil {
+		logger.Fatal("Failed to start risk scoring pipeline", zap.Error(err))
+	}
+	defer scoringPipeline.Stop()
+
 	// Initialize Kafka consumer
 	consumer := kafkaConsumer.NewConsumer(
-		cfg, repo, cacheRepo, riskService, clusteringService, sanctionsService, logger)
+		cfg, repo, cacheRepo, riskService, clusteringService, sanctionsService, watchService, reorgService, logger)
 	if err := consumer.Start(ctx); err != nil {
 		logger.Fatal("Failed to start Kafka consumer", zap.Error(err))
 	}
@@ -92,7 +173,7 @@ func main() {
 
 	// Initialize HTTP handler
 	handler := httpHandler.NewHandler(
-		cfg, repo, cacheRepo, ingestionService, riskService, clusteringService, sanctionsService, logger)
+		cfg, repo, cacheRepo, ingestionService, riskService, scoringPipeline, clusteringService, sanctionsService, screeningService, complianceService, bridgeMonitor, concentrationService, watchService, mempoolService, layer2Service, reorgService, taintService, tokenRegistry, logger)
 
 	// Setup router
 	router := handler.SetupRouter()
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -15,9 +16,18 @@ type Config struct {
 	Database    DatabaseConfig   `yaml:"database"`
 	Neo4j       Neo4jConfig      `yaml:"neo4j"`
 	Redis       RedisConfig      `yaml:"redis"`
+	Kafka       KafkaConfig      `yaml:"kafka"`
 	Blockchain  BlockchainConfig `yaml:"blockchain"`
 	RiskScoring RiskScoringConfig `yaml:"risk_scoring"`
 	Clustering  ClusteringConfig `yaml:"clustering"`
+	Analytics   AnalyticsConfig  `yaml:"analytics"`
+	BridgeMonitoring BridgeMonitoringConfig `yaml:"bridge_monitoring"`
+	Concentration    ConcentrationConfig    `yaml:"concentration"`
+	AddressWatch     AddressWatchConfig     `yaml:"address_watch"`
+	Mempool          MempoolConfig          `yaml:"mempool"`
+	LayerTwo         LayerTwoConfig         `yaml:"layer2"`
+	Taint            TaintConfig            `yaml:"taint"`
+	Tokens           TokenConfig            `yaml:"tokens"`
 	Alerting    AlertingConfig   `yaml:"alerting"`
 	Logging     LoggingConfig    `yaml:"logging"`
 	Metrics     MetricsConfig    `yaml:"metrics"`
@@ -73,11 +83,23 @@ type RedisConfig struct {
 	KeyPrefix string `yaml:"key_prefix"`
 }
 
+// KafkaConfig contains Kafka consumer settings. Messages are processed in
+// micro-batches of up to BatchSize, waiting at most BatchWait for a batch to
+// fill once its first message has arrived.
+type KafkaConfig struct {
+	Brokers   []string `yaml:"brokers"`
+	BatchSize int      `yaml:"batch_size"`
+	BatchWait string   `yaml:"batch_wait"`
+}
+
 // BlockchainConfig contains blockchain node settings
 type BlockchainConfig struct {
 	Bitcoin  BitcoinConfig  `yaml:"bitcoin"`
 	Ethereum EthereumConfig `yaml:"ethereum"`
 	Chains   []ChainConfig  `yaml:"chains"`
+	// MaxReorgDepth bounds how far back ingestion walks to find the fork
+	// point of a reorganization
+	MaxReorgDepth int `yaml:"max_reorg_depth"`
 }
 
 // BitcoinConfig contains Bitcoin node settings
@@ -89,6 +111,8 @@ type BitcoinConfig struct {
 	RPCPort     int    `yaml:"rpc_port"`
 	UseTLS      bool   `yaml:"use_tls"`
 	WalletName  string `yaml:"wallet_name"`
+	ZMQRawTx    string `yaml:"zmq_rawtx"` // e.g. tcp://btc-node:28332, for mempool monitoring
+	Chain       string `yaml:"chain"`     // mainnet, testnet or regtest
 }
 
 // EthereumConfig contains Ethereum node settings
@@ -119,6 +143,16 @@ type RiskScoringConfig struct {
 	SanctionsListURL   string          `yaml:"sanctions_list_url"`
 	RefreshInterval    string          `yaml:"refresh_interval"`
 	EnableRealTime     bool            `yaml:"enable_real_time"`
+	Pipeline           RiskPipelineConfig `yaml:"pipeline"`
+}
+
+// RiskPipelineConfig contains model-based scoring pipeline settings
+type RiskPipelineConfig struct {
+	HistoryLimit     int    `yaml:"history_limit"`
+	TopFeatures      int    `yaml:"top_features"`
+	RescoreEnabled   bool   `yaml:"rescore_enabled"`
+	RescoreInterval  string `yaml:"rescore_interval"`
+	RescoreBatchSize int    `yaml:"rescore_batch_size"`
 }
 
 // RiskRuleConfig contains individual risk rule settings
@@ -143,6 +177,160 @@ type ClusteringConfig struct {
 	MaxClusterDepth      int    `yaml:"max_cluster_depth"`
 }
 
+// AnalyticsConfig contains structuring, smurfing and layering detection settings.
+// Amounts are expressed in units of the transaction asset.
+type AnalyticsConfig struct {
+	Enabled                bool      `yaml:"enabled"`
+	Interval               string    `yaml:"interval"`
+	Window                 string    `yaml:"window"`
+	ReportingThresholds    []float64 `yaml:"reporting_thresholds"`
+	SubThresholdBand       float64   `yaml:"sub_threshold_band"`
+	MinStructuredTransfers int       `yaml:"min_structured_transfers"`
+	MinDistinctSenders     int       `yaml:"min_distinct_senders"`
+	RoundAmountUnit        float64   `yaml:"round_amount_unit"`
+	MinRoundTransfers      int       `yaml:"min_round_transfers"`
+	RoundAmountRatio       float64   `yaml:"round_amount_ratio"`
+	LayeringMaxHold        string    `yaml:"layering_max_hold"`
+	LayeringPassThrough    float64   `yaml:"layering_pass_through"`
+	MinLayeringHops        int       `yaml:"min_layering_hops"`
+}
+
+// BridgeMonitoringConfig contains cross-chain bridge outflow monitoring settings.
+// Thresholds are expressed in units of the transaction asset; AssetThresholds
+// overrides DefaultThreshold per asset symbol.
+type BridgeMonitoringConfig struct {
+	Enabled            bool               `yaml:"enabled"`
+	Interval           string             `yaml:"interval"`
+	Window             string             `yaml:"window"`
+	DefaultThreshold   float64            `yaml:"default_threshold"`
+	AssetThresholds    map[string]float64 `yaml:"asset_thresholds"`
+	MinWalletRiskScore float64            `yaml:"min_wallet_risk_score"`
+	MatchWindow        string             `yaml:"match_window"`
+	MatchTolerance     float64            `yaml:"match_tolerance"`
+	Bridges            []BridgeConfig     `yaml:"bridges"`
+}
+
+// BridgeConfig describes one bridge and the contract or locking addresses it
+// uses on each chain
+type BridgeConfig struct {
+	Name      string                `yaml:"name"`
+	Addresses []BridgeAddressConfig `yaml:"addresses"`
+}
+
+// BridgeAddressConfig is a bridge contract or locking address on one chain
+type BridgeAddressConfig struct {
+	Network string `yaml:"network"`
+	Address string `yaml:"address"`
+}
+
+// ConcentrationConfig contains token-holder concentration analytics settings.
+// Shares are fractions of the observed supply; ChangeThreshold is the move in
+// top-holder or top-N share within ChangeWindow that raises an alert.
+type ConcentrationConfig struct {
+	Enabled         bool    `yaml:"enabled"`
+	Interval        string  `yaml:"interval"`
+	TopN            int     `yaml:"top_n"`
+	MinHolders      int     `yaml:"min_holders"`
+	DominantShare   float64 `yaml:"dominant_share"`
+	ChangeThreshold float64 `yaml:"change_threshold"`
+	ChangeWindow    string  `yaml:"change_window"`
+}
+
+// AddressWatchConfig contains address watch settings. Confirmed transactions
+// are checked on every network; mempool transactions only on MempoolNetworks.
+type AddressWatchConfig struct {
+	Enabled         bool     `yaml:"enabled"`
+	RefreshInterval string   `yaml:"refresh_interval"`
+	MempoolNetworks []string `yaml:"mempool_networks"`
+	WebhookTimeout  string   `yaml:"webhook_timeout"`
+}
+
+// MempoolConfig contains mempool monitoring settings. Unconfirmed transactions
+// are read from Bitcoin Core's ZMQ rawtx feed and Ethereum's pending transaction
+// subscription, screened against frozen and watchlisted addresses, and sent to
+// the licensed exchanges' webhooks so they can withhold crediting them.
+type MempoolConfig struct {
+	Enabled         bool                    `yaml:"enabled"`
+	Networks        []string                `yaml:"networks"`
+	Workers         int                     `yaml:"workers"`
+	QueueSize       int                     `yaml:"queue_size"`
+	FreezeSourceURL string                  `yaml:"freeze_source_url"`
+	RefreshInterval string                  `yaml:"refresh_interval"`
+	NotifyTimeout   string                  `yaml:"notify_timeout"`
+	Exchanges       []ExchangeWebhookConfig `yaml:"exchanges"`
+}
+
+// ExchangeWebhookConfig is a licensed exchange notified of interdictions.
+// Payloads are signed with HMAC-SHA256 over Secret in X-CSIC-Signature.
+type ExchangeWebhookConfig struct {
+	Name      string `yaml:"name"`
+	LicenseID string `yaml:"license_id"`
+	URL       string `yaml:"url"`
+	Secret    string `yaml:"secret"`
+}
+
+// LayerTwoConfig contains Lightning Network and rollup monitoring settings.
+// The risk of an L1 wallet at or above MinSourceRiskScore is propagated to the
+// same account on a rollup multiplied by PropagationFactor, and to both nodes
+// of a Lightning channel it funded multiplied by ChannelFundingFactor, as the
+// funder controls only one of them. L2 transfers whose propagated risk reaches
+// AlertScore raise an alert.
+type LayerTwoConfig struct {
+	Enabled              bool            `yaml:"enabled"`
+	MinSourceRiskScore   float64         `yaml:"min_source_risk_score"`
+	PropagationFactor    float64         `yaml:"propagation_factor"`
+	ChannelFundingFactor float64         `yaml:"channel_funding_factor"`
+	AlertScore           float64         `yaml:"alert_score"`
+	Lightning            LightningConfig `yaml:"lightning"`
+	Rollups              []RollupConfig  `yaml:"rollups"`
+}
+
+// LightningConfig contains the LND node whose gossip graph is read
+type LightningConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	RESTEndpoint string `yaml:"rest_endpoint"` // e.g. https://lnd:8080
+	MacaroonPath string `yaml:"macaroon_path"` // a read-only macaroon is sufficient
+	TLSCertPath  string `yaml:"tls_cert_path"`
+	PollInterval string `yaml:"poll_interval"`
+}
+
+// RollupConfig is an Ethereum rollup whose sequencer blocks are followed
+type RollupConfig struct {
+	Network      string `yaml:"network"`       // arbitrum, optimism or base
+	FeedEndpoint string `yaml:"feed_endpoint"` // sequencer WebSocket RPC
+	NativeAsset  string `yaml:"native_asset"`
+}
+
+// TaintConfig contains UTXO taint tracing settings. A trace uses
+// DefaultStrategy unless it selects one, and follows tainted outputs through
+// at most MaxDepth transactions and MaxTransactions transactions in total;
+// a trace may lower but not raise the limits.
+type TaintConfig struct {
+	DefaultStrategy string `yaml:"default_strategy"` // haircut, fifo or poison
+	MaxDepth        int    `yaml:"max_depth"`
+	MaxTransactions int    `yaml:"max_transactions"`
+}
+
+// TokenConfig contains token registry settings. Registry entries are loaded
+// at startup with the verification status configured for them. With Discover
+// set, a contract emitting transfers that is not in the registry has its
+// symbol and decimals read from the contract and is registered unverified.
+type TokenConfig struct {
+	Discover bool          `yaml:"discover"`
+	Registry []TokenEntry `yaml:"registry"`
+}
+
+// TokenEntry is a token contract known in advance
+type TokenEntry struct {
+	Network  string `yaml:"network"`
+	Contract string `yaml:"contract"`
+	Standard string `yaml:"standard"` // erc20 or erc721
+	Symbol   string `yaml:"symbol"`
+	Name     string `yaml:"name"`
+	Decimals int    `yaml:"decimals"`
+	Verified bool   `yaml:"verified"`
+}
+
 // AlertingConfig contains alert settings
 type AlertingConfig struct {
 	Enabled       bool          `yaml:"enabled"`
@@ -287,6 +475,29 @@ func applyEnvOverrides(cfg *Config) {
 	}
 }
 
+// ConfirmationDepth returns the number of confirmations after which a
+// transaction on the network is treated as final. Networks without a chain
+// entry are final at one confirmation.
+func (c *BlockchainConfig) ConfirmationDepth(network string) int {
+	for _, chain := range c.Chains {
+		if chain.ID == network && chain.Confirmations > 0 {
+			return chain.Confirmations
+		}
+	}
+	return 1
+}
+
+// IsUTXO reports whether the network is configured as a UTXO chain, whose
+// outputs are tracked individually
+func (c *BlockchainConfig) IsUTXO(network string) bool {
+	for _, chain := range c.Chains {
+		if chain.ID == network {
+			return strings.EqualFold(chain.Type, "utxo")
+		}
+	}
+	return false
+}
+
 // GetConnMaxLifetime returns the database connection max lifetime as a duration
 func (c *DatabaseConfig) GetConnMaxLifetime() time.Duration {
 	return time.Duration(c.ConnMaxLifetime) * time.Second
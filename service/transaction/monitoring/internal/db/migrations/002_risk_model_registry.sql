@@ -0,0 +1,47 @@
+-- Transaction Monitoring Service Database Schema
+-- Risk model registry and batch re-scoring
+
+-- Risk models table
+CREATE TABLE IF NOT EXISTS risk_models (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    version VARCHAR(50) NOT NULL,
+    model_type VARCHAR(50) NOT NULL,
+    description TEXT,
+    features TEXT[] DEFAULT '{}',
+    parameters JSONB NOT NULL DEFAULT '{}',
+    metrics JSONB DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'staged',
+    trained_at TIMESTAMP,
+    activated_at TIMESTAMP,
+    created_by VARCHAR(64),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(name, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_models_status ON risk_models(status);
+
+-- Only one model may be active at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_risk_models_single_active ON risk_models(status) WHERE status = 'active';
+
+-- Re-scoring jobs table
+CREATE TABLE IF NOT EXISTS risk_rescoring_jobs (
+    id VARCHAR(64) PRIMARY KEY,
+    model_id VARCHAR(64) REFERENCES risk_models(id),
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total INTEGER DEFAULT 0,
+    scored INTEGER DEFAULT 0,
+    failed INTEGER DEFAULT 0,
+    error TEXT,
+    started_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rescoring_jobs_started ON risk_rescoring_jobs(started_at DESC);
+
+-- Record which model produced each historical score
+ALTER TABLE risk_score_history ADD COLUMN IF NOT EXISTS model_id VARCHAR(64) REFERENCES risk_models(id);
+ALTER TABLE risk_score_history ADD COLUMN IF NOT EXISTS model_version VARCHAR(50);
+ALTER TABLE risk_score_history ADD COLUMN IF NOT EXISTS explanation JSONB DEFAULT '[]';
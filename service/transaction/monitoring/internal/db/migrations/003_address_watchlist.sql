@@ -0,0 +1,17 @@
+-- Transaction Monitoring Service Database Schema
+-- Address watchlist for pre-onboarding screening
+
+-- Watchlist table
+CREATE TABLE IF NOT EXISTS address_watchlist (
+    id VARCHAR(64) PRIMARY KEY,
+    address VARCHAR(128) NOT NULL,
+    network VARCHAR(20) NOT NULL,
+    list_name VARCHAR(100) NOT NULL DEFAULT 'default',
+    reason TEXT,
+    added_by VARCHAR(64),
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(address, network, list_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_address ON address_watchlist(address, network);
@@ -0,0 +1,30 @@
+-- Transaction Monitoring Service Database Schema
+-- Cross-chain bridge outflow monitoring
+
+-- Bridge outflows from monitored entities. Each row is an edge from the sender
+-- to the bridge and, once the release is matched, on to the destination chain.
+CREATE TABLE IF NOT EXISTS bridge_outflows (
+    id VARCHAR(64) PRIMARY KEY,
+    bridge_name VARCHAR(100) NOT NULL,
+    network VARCHAR(20) NOT NULL,
+    bridge_address VARCHAR(128) NOT NULL,
+    tx_hash VARCHAR(128) NOT NULL,
+    sender VARCHAR(128) NOT NULL,
+    amount DECIMAL(36, 18) NOT NULL,
+    asset VARCHAR(20) NOT NULL,
+    threshold DECIMAL(36, 18) NOT NULL,
+    monitored_reasons TEXT[] DEFAULT '{}',
+    cluster_id VARCHAR(64),
+    destination_network VARCHAR(20),
+    destination_address VARCHAR(128),
+    destination_tx_hash VARCHAR(128),
+    alert_id VARCHAR(64),
+    timestamp TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(tx_hash, bridge_address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bridge_outflows_sender ON bridge_outflows(sender, network);
+CREATE INDEX IF NOT EXISTS idx_bridge_outflows_destination ON bridge_outflows(destination_address, destination_network);
+CREATE INDEX IF NOT EXISTS idx_bridge_outflows_cluster ON bridge_outflows(cluster_id);
+CREATE INDEX IF NOT EXISTS idx_bridge_outflows_timestamp ON bridge_outflows(timestamp DESC);
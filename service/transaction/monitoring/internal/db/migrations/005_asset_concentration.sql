@@ -0,0 +1,20 @@
+-- Transaction Monitoring Service Database Schema
+-- Token-holder concentration analytics
+
+-- Concentration snapshots, one row per asset per analytics run
+CREATE TABLE IF NOT EXISTS asset_concentration (
+    id VARCHAR(64) PRIMARY KEY,
+    network VARCHAR(20) NOT NULL,
+    asset VARCHAR(20) NOT NULL,
+    holders INTEGER NOT NULL,
+    total_supply DECIMAL(36, 18) NOT NULL,
+    gini DECIMAL(6, 5) NOT NULL,
+    top_n INTEGER NOT NULL,
+    top_n_share DECIMAL(6, 5) NOT NULL,
+    top_holder VARCHAR(128) NOT NULL,
+    top_holder_share DECIMAL(6, 5) NOT NULL,
+    top_holders JSONB DEFAULT '[]',
+    computed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_asset_concentration_asset ON asset_concentration(network, asset, computed_at DESC);
@@ -0,0 +1,48 @@
+-- Transaction Monitoring Service Database Schema
+-- Address watches with real-time on-chain triggers
+
+-- Addresses whose every movement raises an alert. Removed watches are kept for
+-- the audit trail and excluded through removed_at.
+CREATE TABLE IF NOT EXISTS address_watches (
+    id VARCHAR(64) PRIMARY KEY,
+    address VARCHAR(128) NOT NULL,
+    network VARCHAR(20) NOT NULL,
+    direction VARCHAR(10) NOT NULL DEFAULT 'any',
+    label VARCHAR(255),
+    reason TEXT,
+    case_id VARCHAR(64),
+    webhook_url TEXT,
+    created_by VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP,
+    trigger_count BIGINT NOT NULL DEFAULT 0,
+    last_triggered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    removed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_address_watches_address ON address_watches(address, network);
+CREATE INDEX IF NOT EXISTS idx_address_watches_active ON address_watches(network) WHERE removed_at IS NULL;
+
+-- Transactions that moved funds of a watched address. A transaction is recorded
+-- once per watch and direction, whether first seen in the mempool or a block.
+CREATE TABLE IF NOT EXISTS watch_triggers (
+    id VARCHAR(64) PRIMARY KEY,
+    watch_id VARCHAR(64) NOT NULL REFERENCES address_watches(id),
+    address VARCHAR(128) NOT NULL,
+    network VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(128) NOT NULL,
+    direction VARCHAR(10) NOT NULL,
+    amount DECIMAL(36, 18) NOT NULL,
+    asset VARCHAR(20),
+    counterparties TEXT[] DEFAULT '{}',
+    stage VARCHAR(10) NOT NULL,
+    block_number BIGINT,
+    alert_id VARCHAR(64),
+    webhook_status VARCHAR(20),
+    detected_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP,
+    UNIQUE(watch_id, tx_hash, direction)
+);
+
+CREATE INDEX IF NOT EXISTS idx_watch_triggers_watch ON watch_triggers(watch_id, detected_at DESC);
+CREATE INDEX IF NOT EXISTS idx_watch_triggers_tx ON watch_triggers(tx_hash);
@@ -0,0 +1,34 @@
+-- Transaction Monitoring Service Database Schema
+-- Mempool interdictions of frozen and watchlisted addresses
+
+-- Unconfirmed transactions involving a frozen or watchlisted address. Each
+-- transaction is recorded once, however often it is rebroadcast.
+CREATE TABLE IF NOT EXISTS mempool_interdictions (
+    id VARCHAR(64) PRIMARY KEY,
+    tx_hash VARCHAR(128) NOT NULL,
+    network VARCHAR(20) NOT NULL,
+    asset VARCHAR(20),
+    total_value DECIMAL(36, 18) NOT NULL DEFAULT 0,
+    matches JSONB NOT NULL DEFAULT '[]',
+    alert_id VARCHAR(64),
+    seen_at TIMESTAMP NOT NULL,
+    detected_at TIMESTAMP NOT NULL,
+    UNIQUE(tx_hash, network)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mempool_interdictions_detected ON mempool_interdictions(detected_at DESC);
+CREATE INDEX IF NOT EXISTS idx_mempool_interdictions_matches ON mempool_interdictions USING GIN (matches jsonb_path_ops);
+
+-- Webhook deliveries of each interdiction to licensed exchanges
+CREATE TABLE IF NOT EXISTS mempool_interdiction_notifications (
+    id VARCHAR(64) PRIMARY KEY,
+    interdiction_id VARCHAR(64) NOT NULL REFERENCES mempool_interdictions(id),
+    exchange VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    status_code INTEGER,
+    error TEXT,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    sent_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mempool_notifications_interdiction ON mempool_interdiction_notifications(interdiction_id);
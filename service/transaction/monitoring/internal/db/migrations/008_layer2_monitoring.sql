@@ -0,0 +1,63 @@
+-- Transaction Monitoring Service Database Schema
+-- Lightning Network and rollup monitoring
+
+-- Lightning node public keys are 66 hex characters
+ALTER TABLE wallets ALTER COLUMN address TYPE VARCHAR(128);
+
+-- Public Lightning channels learned from gossip
+CREATE TABLE IF NOT EXISTS lightning_channels (
+    channel_id VARCHAR(32) PRIMARY KEY,
+    channel_point VARCHAR(80) NOT NULL,
+    node1 VARCHAR(66) NOT NULL,
+    node2 VARCHAR(66) NOT NULL,
+    capacity DECIMAL(36, 18) NOT NULL,
+    funding_tx_hash VARCHAR(64) NOT NULL,
+    funder VARCHAR(128),
+    first_seen TIMESTAMP NOT NULL,
+    last_update TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_lightning_channels_node1 ON lightning_channels(node1);
+CREATE INDEX IF NOT EXISTS idx_lightning_channels_node2 ON lightning_channels(node2);
+CREATE INDEX IF NOT EXISTS idx_lightning_channels_open ON lightning_channels(channel_id) WHERE closed_at IS NULL;
+
+-- Layer-two transfers: Lightning channel opens and closes, and rollup
+-- transfers involving addresses linked to risky L1 wallets
+CREATE TABLE IF NOT EXISTS l2_transfers (
+    id VARCHAR(64) PRIMARY KEY,
+    network VARCHAR(20) NOT NULL,
+    transfer_type VARCHAR(20) NOT NULL,
+    reference VARCHAR(128) NOT NULL,
+    sender VARCHAR(128) NOT NULL,
+    receiver VARCHAR(128) NOT NULL,
+    amount DECIMAL(36, 18) NOT NULL,
+    asset VARCHAR(20) NOT NULL,
+    l1_network VARCHAR(20),
+    l1_tx_hash VARCHAR(128),
+    risk_score DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    alert_id VARCHAR(64),
+    timestamp TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(network, transfer_type, reference)
+);
+
+CREATE INDEX IF NOT EXISTS idx_l2_transfers_sender ON l2_transfers(sender, network);
+CREATE INDEX IF NOT EXISTS idx_l2_transfers_receiver ON l2_transfers(receiver, network);
+CREATE INDEX IF NOT EXISTS idx_l2_transfers_timestamp ON l2_transfers(timestamp DESC);
+
+-- L2 addresses associated with L1 wallets, with the risk propagated from them
+CREATE TABLE IF NOT EXISTS l2_address_links (
+    l2_network VARCHAR(20) NOT NULL,
+    l2_address VARCHAR(128) NOT NULL,
+    l1_network VARCHAR(20) NOT NULL,
+    l1_address VARCHAR(128) NOT NULL,
+    link_type VARCHAR(20) NOT NULL,
+    reference VARCHAR(128),
+    source_risk_score DECIMAL(5, 2) NOT NULL,
+    risk_score DECIMAL(5, 2) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (l2_network, l2_address, l1_network, l1_address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_l2_address_links_l1 ON l2_address_links(l1_address, l1_network);
@@ -0,0 +1,43 @@
+-- Transaction Monitoring Service Database Schema
+-- Chain reorganization handling
+
+-- Block headers seen by ingestion. A block is canonical until a
+-- reorganization orphans it.
+CREATE TABLE IF NOT EXISTS chain_blocks (
+    network VARCHAR(20) NOT NULL,
+    number BIGINT NOT NULL,
+    hash VARCHAR(66) NOT NULL,
+    parent_hash VARCHAR(66) NOT NULL,
+    seen_at TIMESTAMP NOT NULL,
+    orphaned_at TIMESTAMP,
+    PRIMARY KEY (network, hash)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chain_blocks_canonical ON chain_blocks(network, number) WHERE orphaned_at IS NULL;
+
+-- Reorganizations detected, with what they invalidated
+CREATE TABLE IF NOT EXISTS chain_reorgs (
+    id VARCHAR(64) PRIMARY KEY,
+    network VARCHAR(20) NOT NULL,
+    fork_height BIGINT NOT NULL,
+    depth INTEGER NOT NULL,
+    orphaned_blocks TEXT[] DEFAULT '{}',
+    reorged_txs TEXT[] DEFAULT '{}',
+    invalidated_alerts TEXT[] DEFAULT '{}',
+    flagged_alerts TEXT[] DEFAULT '{}',
+    detected_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_chain_reorgs_network ON chain_reorgs(network, detected_at DESC);
+
+-- Mined transactions are pending until their block has the chain's
+-- confirmation depth
+ALTER TABLE transactions ALTER COLUMN status SET DEFAULT 'pending';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reorged_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_transactions_pending ON transactions(network, block_number) WHERE status = 'pending';
+
+ALTER TABLE watch_triggers ADD COLUMN IF NOT EXISTS reorged_at TIMESTAMP;
+ALTER TABLE bridge_outflows ADD COLUMN IF NOT EXISTS reorged_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_bridge_outflows_destination_tx ON bridge_outflows(destination_tx_hash);
+
+CREATE INDEX IF NOT EXISTS idx_alert_evidence_reference ON alert_evidence(reference_id, evidence_type);
@@ -0,0 +1,26 @@
+-- Transaction Monitoring Service Database Schema
+-- UTXO set tracking
+
+-- Outputs of ingested transactions on UTXO networks. An output spent by an
+-- ingested transaction but created before ingestion started is recorded from
+-- the spending input, without a creation block.
+CREATE TABLE IF NOT EXISTS utxos (
+    network VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    output_index INTEGER NOT NULL,
+    address VARCHAR(128),
+    amount DECIMAL(36, 18) NOT NULL,
+    block_number BIGINT,
+    created_at TIMESTAMP,
+    spent_tx_hash VARCHAR(66),
+    spent_input_index INTEGER,
+    spent_block_number BIGINT,
+    spent_at TIMESTAMP,
+    PRIMARY KEY (network, tx_hash, output_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_utxos_address ON utxos(address, network);
+CREATE INDEX IF NOT EXISTS idx_utxos_unspent ON utxos(address, network) WHERE spent_tx_hash IS NULL;
+CREATE INDEX IF NOT EXISTS idx_utxos_spent_tx ON utxos(network, spent_tx_hash);
+CREATE INDEX IF NOT EXISTS idx_utxos_block ON utxos(network, block_number);
+CREATE INDEX IF NOT EXISTS idx_utxos_spent_block ON utxos(network, spent_block_number);
@@ -0,0 +1,47 @@
+-- Transaction Monitoring Service Database Schema
+-- Token registry and token transfers
+
+-- Token contracts. Contract addresses are stored lowercase.
+CREATE TABLE IF NOT EXISTS tokens (
+    network VARCHAR(20) NOT NULL,
+    contract VARCHAR(42) NOT NULL,
+    standard VARCHAR(10) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    name VARCHAR(255),
+    decimals INTEGER NOT NULL DEFAULT 0,
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (network, contract)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tokens_symbol ON tokens(symbol, network);
+
+-- ERC-20 and ERC-721 Transfer events decoded from transaction receipts.
+-- Confirmation and reorganization status is that of the parent transaction.
+-- Addresses keep the checksummed form used for transactions and are matched
+-- case-insensitively.
+CREATE TABLE IF NOT EXISTS token_transfers (
+    id VARCHAR(64) PRIMARY KEY,
+    network VARCHAR(20) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INTEGER NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    contract VARCHAR(42) NOT NULL,
+    standard VARCHAR(10) NOT NULL,
+    sender VARCHAR(64) NOT NULL,
+    receiver VARCHAR(64) NOT NULL,
+    amount NUMERIC NOT NULL,
+    raw_amount NUMERIC(78, 0),
+    token_id NUMERIC(78, 0),
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(network, tx_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_transfers_sender ON token_transfers(lower(sender), network);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_receiver ON token_transfers(lower(receiver), network);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_contract ON token_transfers(contract, network, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_tx ON token_transfers(tx_hash);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_timestamp ON token_transfers(timestamp DESC);
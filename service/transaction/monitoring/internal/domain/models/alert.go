@@ -27,6 +27,7 @@ const (
 	AlertTypeStructuring         AlertType = "structuring"
 	AlertTypeLayering            AlertType = "layering"
 	AlertTypeSmurfing            AlertType = "smurfing"
+	AlertTypeRoundAmounts        AlertType = "round_amounts"
 	AlertTypeUnusualPattern      AlertType = "unusual_pattern"
 	AlertTypeVelocityBreach      AlertType = "velocity_breach"
 	AlertTypeNewHighRiskEntity   AlertType = "new_high_risk_entity"
@@ -34,6 +35,11 @@ const (
 	AlertTypeTransactionAnomaly  AlertType = "transaction_anomaly"
 	AlertTypeBlacklistMatch      AlertType = "blacklist_match"
 	AlertTypeWhitelistException  AlertType = "whitelist_exception"
+	AlertTypeBridgeOutflow       AlertType = "bridge_outflow"
+	AlertTypeConcentration       AlertType = "holder_concentration"
+	AlertTypeAddressWatch        AlertType = "address_watch"
+	AlertTypeMempoolInterdiction AlertType = "mempool_interdiction"
+	AlertTypeLayerTwoExposure    AlertType = "l2_risk_exposure"
 )
 
 // AlertStatus represents alert investigation status
@@ -47,6 +53,7 @@ const (
 	AlertStatusResolved     AlertStatus = "resolved"
 	AlertStatusDismissed    AlertStatus = "dismissed"
 	AlertStatusFalsePositive AlertStatus = "false_positive"
+	AlertStatusInvalidated  AlertStatus = "invalidated"
 )
 
 // Alert represents a compliance alert
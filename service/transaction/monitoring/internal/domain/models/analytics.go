@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PatternDetection is a structuring, smurfing, round-amount or layering pattern
+// found by the transaction analytics module
+type PatternDetection struct {
+	AlertType      AlertType       `json:"alert_type"`
+	Severity       AlertSeverity   `json:"severity"`
+	Network        Network         `json:"network"`
+	Address        string          `json:"address"`
+	Title          string          `json:"title"`
+	Description    string          `json:"description"`
+	Score          float64         `json:"score"`
+	TotalAmount    decimal.Decimal `json:"total_amount"`
+	WindowStart    time.Time       `json:"window_start"`
+	WindowEnd      time.Time       `json:"window_end"`
+	Transactions   []string        `json:"transactions"`
+	Counterparties []string        `json:"counterparties"`
+	ClusterIDs     []string        `json:"cluster_ids,omitempty"`
+}
+
+// Key identifies the pattern for de-duplication across analysis runs
+func (d *PatternDetection) Key() string {
+	return string(d.AlertType) + ":" + string(d.Network) + ":" + d.Address
+}
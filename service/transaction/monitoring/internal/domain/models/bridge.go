@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BridgeOutflow is a transfer from a monitored entity into a bridge contract or
+// locking address. When the release on the destination chain can be matched the
+// destination leg is recorded, linking both sides of the bridge in the graph.
+type BridgeOutflow struct {
+	ID                 string          `json:"id" db:"id"`
+	BridgeName         string          `json:"bridge_name" db:"bridge_name"`
+	Network            Network         `json:"network" db:"network"`
+	BridgeAddress      string          `json:"bridge_address" db:"bridge_address"`
+	TxHash             string          `json:"tx_hash" db:"tx_hash"`
+	Sender             string          `json:"sender" db:"sender"`
+	Amount             decimal.Decimal `json:"amount" db:"amount"`
+	Asset              string          `json:"asset" db:"asset"`
+	Threshold          decimal.Decimal `json:"threshold" db:"threshold"`
+	MonitoredReasons   []string        `json:"monitored_reasons" db:"-"`
+	ClusterID          *string         `json:"cluster_id,omitempty" db:"cluster_id"`
+	DestinationNetwork *Network        `json:"destination_network,omitempty" db:"destination_network"`
+	DestinationAddress *string         `json:"destination_address,omitempty" db:"destination_address"`
+	DestinationTxHash  *string         `json:"destination_tx_hash,omitempty" db:"destination_tx_hash"`
+	AlertID            *string         `json:"alert_id,omitempty" db:"alert_id"`
+	Timestamp          time.Time       `json:"timestamp" db:"timestamp"`
+	CreatedAt          time.Time       `json:"created_at" db:"created_at"`
+}
+
+// BridgeOutflowFilter narrows a bridge outflow query
+type BridgeOutflowFilter struct {
+	Network    Network
+	Address    string
+	BridgeName string
+	Since      *time.Time
+	Limit      int
+}
+
+// BridgeGraph is the flow graph of bridge outflows around an address. Nodes are
+// keyed by network and address so the same address on two chains stays distinct.
+type BridgeGraph struct {
+	Nodes []GraphNode     `json:"nodes"`
+	Edges []GraphEdge     `json:"edges"`
+	Flows []BridgeOutflow `json:"flows"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AssetConcentration is a snapshot of how an asset's observed supply is
+// distributed across holders. Addresses in the same entity cluster are counted
+// as one holder, keyed by the cluster ID.
+type AssetConcentration struct {
+	ID             string          `json:"id" db:"id"`
+	Network        Network         `json:"network" db:"network"`
+	Asset          string          `json:"asset" db:"asset"`
+	Holders        int             `json:"holders" db:"holders"`
+	TotalSupply    decimal.Decimal `json:"total_supply" db:"total_supply"`
+	Gini           float64         `json:"gini" db:"gini"`
+	TopN           int             `json:"top_n" db:"top_n"`
+	TopNShare      float64         `json:"top_n_share" db:"top_n_share"`
+	TopHolder      string          `json:"top_holder" db:"top_holder"`
+	TopHolderShare float64         `json:"top_holder_share" db:"top_holder_share"`
+	TopHolders     []HolderBalance `json:"top_holders" db:"-"`
+	ComputedAt     time.Time       `json:"computed_at" db:"computed_at"`
+}
+
+// HolderBalance is a holder's net balance of an asset. Holder is the cluster ID
+// when IsCluster is set and the address otherwise.
+type HolderBalance struct {
+	Holder    string          `json:"holder"`
+	IsCluster bool            `json:"is_cluster"`
+	Balance   decimal.Decimal `json:"balance"`
+	Share     float64         `json:"share"`
+}
+
+// ConcentrationReport is an asset's latest concentration snapshot and its history
+type ConcentrationReport struct {
+	Latest  *AssetConcentration  `json:"latest"`
+	History []AssetConcentration `json:"history"`
+}
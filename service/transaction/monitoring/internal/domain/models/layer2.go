@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// L2TransferType distinguishes the kinds of layer-two transfer records
+type L2TransferType string
+
+const (
+	L2TransferChannelOpen  L2TransferType = "channel_open"
+	L2TransferChannelClose L2TransferType = "channel_close"
+	L2TransferRollup       L2TransferType = "rollup_transfer"
+)
+
+// Link types between an L1 wallet and an L2 address. A same-key link is the
+// same account on Ethereum and a rollup; a channel-funding link ties the L1
+// wallet that funded a Lightning channel to the channel's two nodes, one of
+// which it controls.
+const (
+	L2LinkSameKey        = "same_key"
+	L2LinkChannelFunding = "channel_funding"
+)
+
+// L2Transfer is a value movement on a layer-two network. Lightning payments
+// are not visible to an observer, so Lightning is recorded at channel level:
+// the capacity committed when a channel opens and released when it closes.
+// Rollup transfers are recorded when either party is linked to a risky L1 wallet.
+type L2Transfer struct {
+	ID           string          `json:"id" db:"id"`
+	Network      Network         `json:"network" db:"network"`
+	TransferType L2TransferType  `json:"transfer_type" db:"transfer_type"`
+	Reference    string          `json:"reference" db:"reference"` // rollup tx hash or Lightning channel ID
+	Sender       string          `json:"sender" db:"sender"`
+	Receiver     string          `json:"receiver" db:"receiver"`
+	Amount       decimal.Decimal `json:"amount" db:"amount"`
+	Asset        string          `json:"asset" db:"asset"`
+	L1Network    *Network        `json:"l1_network,omitempty" db:"l1_network"`
+	L1TxHash     *string         `json:"l1_tx_hash,omitempty" db:"l1_tx_hash"`
+	RiskScore    float64         `json:"risk_score" db:"risk_score"`
+	AlertID      *string         `json:"alert_id,omitempty" db:"alert_id"`
+	Timestamp    time.Time       `json:"timestamp" db:"timestamp"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// L2TransferFilter narrows a layer-two transfer query
+type L2TransferFilter struct {
+	Network      Network
+	Address      string
+	TransferType L2TransferType
+	Since        *time.Time
+	Limit        int
+}
+
+// LightningChannel is a public channel learned from Lightning gossip. The
+// funding transaction is the channel point's transaction on Bitcoin; Funder
+// is its sender when that transaction has been ingested.
+type LightningChannel struct {
+	ChannelID     string          `json:"channel_id" db:"channel_id"`
+	ChannelPoint  string          `json:"channel_point" db:"channel_point"`
+	Node1         string          `json:"node1" db:"node1"`
+	Node2         string          `json:"node2" db:"node2"`
+	Capacity      decimal.Decimal `json:"capacity" db:"capacity"`
+	FundingTxHash string          `json:"funding_tx_hash" db:"funding_tx_hash"`
+	Funder        *string         `json:"funder,omitempty" db:"funder"`
+	FirstSeen     time.Time       `json:"first_seen" db:"first_seen"`
+	LastUpdate    time.Time       `json:"last_update" db:"last_update"`
+	ClosedAt      *time.Time      `json:"closed_at,omitempty" db:"closed_at"`
+}
+
+// L2AddressLink associates an L2 address with an L1 wallet and carries the
+// risk propagated from it
+type L2AddressLink struct {
+	L2Network       Network   `json:"l2_network" db:"l2_network"`
+	L2Address       string    `json:"l2_address" db:"l2_address"`
+	L1Network       Network   `json:"l1_network" db:"l1_network"`
+	L1Address       string    `json:"l1_address" db:"l1_address"`
+	LinkType        string    `json:"link_type" db:"link_type"`
+	Reference       string    `json:"reference,omitempty" db:"reference"`
+	SourceRiskScore float64   `json:"source_risk_score" db:"source_risk_score"`
+	RiskScore       float64   `json:"risk_score" db:"risk_score"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Screening reasons of an interdiction match
+const (
+	InterdictionReasonFrozen    = "frozen"
+	InterdictionReasonWatchlist = "watchlist"
+)
+
+// InterdictionMatch is an address of an unconfirmed transaction that is frozen
+// or watchlisted. Direction is sent when the address funds the transaction and
+// received when it is paid by it.
+type InterdictionMatch struct {
+	Address      string          `json:"address"`
+	Direction    WatchDirection  `json:"direction"`
+	Amount       decimal.Decimal `json:"amount"`
+	Reasons      []string        `json:"reasons"`
+	FreezeID     string          `json:"freeze_id,omitempty"`
+	LegalOrderID string          `json:"legal_order_id,omitempty"`
+	Watchlists   []string        `json:"watchlists,omitempty"`
+}
+
+// MempoolInterdiction records an unconfirmed transaction involving a frozen or
+// watchlisted address, and the notifications sent to licensed exchanges so
+// they can withhold crediting it
+type MempoolInterdiction struct {
+	ID            string                     `json:"id" db:"id"`
+	TxHash        string                     `json:"tx_hash" db:"tx_hash"`
+	Network       Network                    `json:"network" db:"network"`
+	Asset         string                     `json:"asset" db:"asset"`
+	TotalValue    decimal.Decimal            `json:"total_value" db:"total_value"`
+	Matches       []InterdictionMatch        `json:"matches" db:"matches"`
+	AlertID       string                     `json:"alert_id,omitempty" db:"alert_id"`
+	SeenAt        time.Time                  `json:"seen_at" db:"seen_at"`
+	DetectedAt    time.Time                  `json:"detected_at" db:"detected_at"`
+	Notifications []InterdictionNotification `json:"notifications,omitempty"`
+}
+
+// InterdictionNotification is the delivery of an interdiction to one exchange.
+// LatencyMs is measured from when the transaction was first seen in the mempool.
+type InterdictionNotification struct {
+	ID             string    `json:"id" db:"id"`
+	InterdictionID string    `json:"interdiction_id" db:"interdiction_id"`
+	Exchange       string    `json:"exchange" db:"exchange"`
+	Status         string    `json:"status" db:"status"`
+	StatusCode     int       `json:"status_code,omitempty" db:"status_code"`
+	Error          string    `json:"error,omitempty" db:"error"`
+	LatencyMs      int64     `json:"latency_ms" db:"latency_ms"`
+	SentAt         time.Time `json:"sent_at" db:"sent_at"`
+}
+
+// MempoolInterdictionFilter selects recorded interdictions
+type MempoolInterdictionFilter struct {
+	Network Network
+	Address string
+	Since   *time.Time
+	Limit   int
+}
+
+// MempoolStatus reports the state of the mempool listeners and of the index of
+// frozen and watchlisted addresses they are screened against
+type MempoolStatus struct {
+	Enabled            bool                   `json:"enabled"`
+	Networks           []MempoolNetworkStatus `json:"networks"`
+	FrozenAddresses    int                    `json:"frozen_addresses"`
+	WatchlistAddresses int                    `json:"watchlist_addresses"`
+	IndexRefreshedAt   *time.Time             `json:"index_refreshed_at,omitempty"`
+	QueueDepth         int                    `json:"queue_depth"`
+}
+
+// MempoolNetworkStatus reports the mempool listener of one network
+type MempoolNetworkStatus struct {
+	Network     Network    `json:"network"`
+	Connected   bool       `json:"connected"`
+	Received    int64      `json:"received"`
+	Dropped     int64      `json:"dropped"`
+	Interdicted int64      `json:"interdicted"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+}
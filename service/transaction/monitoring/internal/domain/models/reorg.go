@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// ChainBlock is a block header seen by the ingestion pipeline. Blocks are kept
+// per network so that a new block whose height or parent conflicts with the
+// stored chain reveals a reorganization.
+type ChainBlock struct {
+	Network    Network    `json:"network" db:"network"`
+	Number     int64      `json:"number" db:"number"`
+	Hash       string     `json:"hash" db:"hash"`
+	ParentHash string     `json:"parent_hash" db:"parent_hash"`
+	SeenAt     time.Time  `json:"seen_at" db:"seen_at"`
+	OrphanedAt *time.Time `json:"orphaned_at,omitempty" db:"orphaned_at"`
+}
+
+// ChainReorg records a reorganization: the blocks orphaned above the fork
+// height, the transactions they held and the results invalidated with them
+type ChainReorg struct {
+	ID                string    `json:"id" db:"id"`
+	Network           Network   `json:"network" db:"network"`
+	ForkHeight        int64     `json:"fork_height" db:"fork_height"`
+	Depth             int       `json:"depth" db:"depth"`
+	OrphanedBlocks    []string  `json:"orphaned_blocks" db:"orphaned_blocks"`
+	ReorgedTxs        []string  `json:"reorged_txs" db:"reorged_txs"`
+	InvalidatedAlerts []string  `json:"invalidated_alerts" db:"invalidated_alerts"`
+	FlaggedAlerts     []string  `json:"flagged_alerts" db:"flagged_alerts"`
+	DetectedAt        time.Time `json:"detected_at" db:"detected_at"`
+}
+
+// BlockEvent is a block or reorganization event on the raw blockchain topic.
+// For a reorg event Height is the first height replaced.
+type BlockEvent struct {
+	Type       string  `json:"type"`
+	Network    Network `json:"network"`
+	Height     int64   `json:"height"`
+	Hash       string  `json:"hash"`
+	ParentHash string  `json:"parent_hash"`
+}
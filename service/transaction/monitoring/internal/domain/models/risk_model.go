@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+)
+
+// RiskModelStatus represents the lifecycle state of a registered risk model
+type RiskModelStatus string
+
+const (
+	RiskModelStatusStaged  RiskModelStatus = "staged"
+	RiskModelStatusActive  RiskModelStatus = "active"
+	RiskModelStatusRetired RiskModelStatus = "retired"
+)
+
+// RiskModel is a versioned scoring model stored in the model registry.
+// Parameters holds the model coefficients keyed by feature name; the
+// reserved key "intercept" holds the bias term.
+type RiskModel struct {
+	ID          string             `json:"id" db:"id"`
+	Name        string             `json:"name" db:"name"`
+	Version     string             `json:"version" db:"version"`
+	ModelType   string             `json:"model_type" db:"model_type"` // logistic, linear
+	Description string             `json:"description" db:"description"`
+	Features    []string           `json:"features" db:"features"`
+	Parameters  map[string]float64 `json:"parameters" db:"parameters"`
+	Metrics     map[string]float64 `json:"metrics" db:"metrics"` // auc, precision, recall, ...
+	Status      RiskModelStatus    `json:"status" db:"status"`
+	TrainedAt   *time.Time         `json:"trained_at,omitempty" db:"trained_at"`
+	ActivatedAt *time.Time         `json:"activated_at,omitempty" db:"activated_at"`
+	CreatedBy   string             `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// FeatureVector holds extracted model inputs keyed by feature name
+type FeatureVector map[string]float64
+
+// FeatureContribution describes how much a single feature moved a score
+type FeatureContribution struct {
+	Feature      string  `json:"feature"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+// RiskScoreExplanation is the result of scoring a wallet, including the
+// features that contributed most to the score
+type RiskScoreExplanation struct {
+	WalletID     string                `json:"wallet_id"`
+	Address      string                `json:"address"`
+	Network      Network               `json:"network"`
+	Score        float64               `json:"score"`
+	RiskLevel    string                `json:"risk_level"`
+	ModelID      string                `json:"model_id,omitempty"`
+	ModelName    string                `json:"model_name"`
+	ModelVersion string                `json:"model_version"`
+	TopFeatures  []FeatureContribution `json:"top_features"`
+	Features     FeatureVector         `json:"features"`
+	ScoredAt     time.Time             `json:"scored_at"`
+}
+
+// RescoringJobStatus represents the state of a batch re-scoring job
+type RescoringJobStatus string
+
+const (
+	RescoringJobPending   RescoringJobStatus = "pending"
+	RescoringJobRunning   RescoringJobStatus = "running"
+	RescoringJobCompleted RescoringJobStatus = "completed"
+	RescoringJobFailed    RescoringJobStatus = "failed"
+)
+
+// RescoringJob tracks a batch re-scoring run over all known wallets
+type RescoringJob struct {
+	ID          string             `json:"id" db:"id"`
+	ModelID     string             `json:"model_id,omitempty" db:"model_id"`
+	Trigger     string             `json:"trigger" db:"trigger"` // scheduled, manual, activation
+	Status      RescoringJobStatus `json:"status" db:"status"`
+	Total       int                `json:"total" db:"total"`
+	Scored      int                `json:"scored" db:"scored"`
+	Failed      int                `json:"failed" db:"failed"`
+	Error       string             `json:"error,omitempty" db:"error"`
+	StartedAt   time.Time          `json:"started_at" db:"started_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty" db:"completed_at"`
+}
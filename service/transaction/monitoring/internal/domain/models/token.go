@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TokenStandard is the interface a token contract implements
+type TokenStandard string
+
+const (
+	TokenStandardERC20  TokenStandard = "erc20"
+	TokenStandardERC721 TokenStandard = "erc721"
+)
+
+// Token is a token contract in the registry. Verified tokens are those whose
+// contract has been confirmed as the genuine issuer of the symbol; anyone can
+// deploy a contract calling itself USDT, so only transfers of verified tokens
+// count toward value thresholds.
+type Token struct {
+	Network   Network       `json:"network" db:"network"`
+	Contract  string        `json:"contract" db:"contract"`
+	Standard  TokenStandard `json:"standard" db:"standard"`
+	Symbol    string        `json:"symbol" db:"symbol"`
+	Name      string        `json:"name,omitempty" db:"name"`
+	Decimals  int           `json:"decimals" db:"decimals"`
+	Verified  bool          `json:"verified" db:"verified"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// TokenRequest registers a token or updates its registry entry
+type TokenRequest struct {
+	Network  Network       `json:"network" binding:"required"`
+	Contract string        `json:"contract" binding:"required"`
+	Standard TokenStandard `json:"standard"`
+	Symbol   string        `json:"symbol" binding:"required"`
+	Name     string        `json:"name"`
+	Decimals int           `json:"decimals"`
+	Verified bool          `json:"verified"`
+}
+
+// TokenTransfer is a Transfer event emitted by a token contract. Amount is
+// scaled by the token's decimals; an ERC-721 transfer moves one token,
+// identified by TokenID.
+type TokenTransfer struct {
+	ID          string          `json:"id" db:"id"`
+	Network     Network         `json:"network" db:"network"`
+	TxHash      string          `json:"tx_hash" db:"tx_hash"`
+	LogIndex    int             `json:"log_index" db:"log_index"`
+	BlockNumber int64           `json:"block_number" db:"block_number"`
+	BlockHash   string          `json:"block_hash" db:"block_hash"`
+	Timestamp   time.Time       `json:"timestamp" db:"timestamp"`
+	Contract    string          `json:"contract" db:"contract"`
+	Standard    TokenStandard   `json:"standard" db:"standard"`
+	Symbol      string          `json:"symbol" db:"symbol"`
+	Verified    bool            `json:"verified" db:"verified"`
+	Sender      string          `json:"sender" db:"sender"`
+	Receiver    string          `json:"receiver" db:"receiver"`
+	Amount      decimal.Decimal `json:"amount" db:"amount"`
+	RawAmount   string          `json:"raw_amount,omitempty" db:"raw_amount"`
+	TokenID     string          `json:"token_id,omitempty" db:"token_id"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// TokenTransferFilter selects token transfers. Zero fields do not filter.
+type TokenTransferFilter struct {
+	Network      Network
+	Address      string
+	Contract     string
+	Symbol       string
+	TxHash       string
+	VerifiedOnly bool
+	Since        *time.Time
+	Limit        int
+}
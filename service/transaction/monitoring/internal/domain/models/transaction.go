@@ -14,15 +14,26 @@ const (
 	NetworkEthereum Network = "ethereum"
 	NetworkPolygon  Network = "polygon"
 	NetworkBSC      Network = "bsc"
+	NetworkTron     Network = "tron"
+
+	// Layer-two networks. Lightning addresses are node public keys; rollup
+	// addresses are accounts controlled by the same keys as on Ethereum.
+	NetworkLightning Network = "lightning"
+	NetworkArbitrum  Network = "arbitrum"
+	NetworkOptimism  Network = "optimism"
+	NetworkBase      Network = "base"
 )
 
-// TransactionStatus represents transaction confirmation status
+// TransactionStatus represents transaction confirmation status. A mined
+// transaction is pending until its block reaches the chain's confirmation
+// depth, then confirmed; it is reorged if its block leaves the canonical chain.
 type TransactionStatus string
 
 const (
 	TxStatusPending   TransactionStatus = "pending"
 	TxStatusConfirmed TransactionStatus = "confirmed"
 	TxStatusFailed    TransactionStatus = "failed"
+	TxStatusReorged   TransactionStatus = "reorged"
 )
 
 // Transaction represents a blockchain transaction
@@ -81,6 +92,38 @@ type NormalizedTransaction struct {
 	Fee           decimal.Decimal    `json:"fee"`
 	InputCount    int                `json:"input_count"`
 	OutputCount   int                `json:"output_count"`
+	// TokenTransfers are the token Transfer events the transaction emitted;
+	// Inputs and Outputs carry only the native asset
+	TokenTransfers []TokenTransfer `json:"token_transfers,omitempty"`
+}
+
+// UniqueWallets returns the wallets of all inputs, outputs and token transfer
+// parties of txs, each once, in order of first appearance
+func UniqueWallets(txs []*NormalizedTransaction) []WalletKey {
+	seen := make(map[WalletKey]bool)
+	var wallets []WalletKey
+	add := func(address string, network Network) {
+		key := WalletKey{Address: address, Network: network}
+		if address == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		wallets = append(wallets, key)
+	}
+
+	for _, tx := range txs {
+		for _, input := range tx.Inputs {
+			add(input.Address, tx.Network)
+		}
+		for _, output := range tx.Outputs {
+			add(output.Address, tx.Network)
+		}
+		for _, transfer := range tx.TokenTransfers {
+			add(transfer.Sender, tx.Network)
+			add(transfer.Receiver, tx.Network)
+		}
+	}
+	return wallets
 }
 
 // NormalizedInput represents a normalized transaction input
@@ -88,6 +131,10 @@ type NormalizedInput struct {
 	Address  string          `json:"address"`
 	Amount   decimal.Decimal `json:"amount"`
 	Tag      string          `json:"tag,omitempty"`
+	// PrevTxHash and PrevIndex identify the output spent by the input on
+	// UTXO networks
+	PrevTxHash string `json:"prev_tx_hash,omitempty"`
+	PrevIndex  int    `json:"prev_index,omitempty"`
 }
 
 // NormalizedOutput represents a normalized transaction output
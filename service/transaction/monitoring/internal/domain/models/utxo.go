@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// UTXO is a transaction output on a UTXO network, with pointers to the
+// transaction that created it and, once spent, the input that spent it. An
+// output created before ingestion started is known only from its spending
+// input and has no creation block.
+type UTXO struct {
+	Network          Network         `json:"network" db:"network"`
+	TxHash           string          `json:"tx_hash" db:"tx_hash"`
+	OutputIndex      int             `json:"output_index" db:"output_index"`
+	Address          string          `json:"address,omitempty" db:"address"`
+	Amount           decimal.Decimal `json:"amount" db:"amount"`
+	BlockNumber      int64           `json:"block_number,omitempty" db:"block_number"`
+	CreatedAt        *time.Time      `json:"created_at,omitempty" db:"created_at"`
+	SpentTxHash      string          `json:"spent_tx_hash,omitempty" db:"spent_tx_hash"`
+	SpentInputIndex  int             `json:"spent_input_index,omitempty" db:"spent_input_index"`
+	SpentBlockNumber int64           `json:"spent_block_number,omitempty" db:"spent_block_number"`
+	SpentAt          *time.Time      `json:"spent_at,omitempty" db:"spent_at"`
+}
+
+// IsSpent reports whether the output has been spent
+func (u *UTXO) IsSpent() bool {
+	return u.SpentTxHash != ""
+}
+
+// TaintStrategy decides how taint on the inputs of a transaction is passed
+// to its outputs
+type TaintStrategy string
+
+const (
+	// TaintHaircut taints every output in proportion to the tainted share of
+	// the inputs
+	TaintHaircut TaintStrategy = "haircut"
+	// TaintFIFO taints outputs first-in first-out: inputs are consumed in
+	// order by outputs in order, and each output carries the taint of the
+	// input value it consumed
+	TaintFIFO TaintStrategy = "fifo"
+	// TaintPoison taints every output in full if any input is tainted
+	TaintPoison TaintStrategy = "poison"
+)
+
+// TaintTraceRequest starts a taint trace from the outputs received by an
+// address, or from the outputs of a transaction
+type TaintTraceRequest struct {
+	Network         Network       `json:"network" binding:"required"`
+	Address         string        `json:"address"`
+	TxHash          string        `json:"tx_hash"`
+	Strategy        TaintStrategy `json:"strategy"`
+	MaxDepth        int           `json:"max_depth"`
+	MaxTransactions int           `json:"max_transactions"`
+}
+
+// TaintedUTXO is an output reached by a trace and the value of it that is tainted
+type TaintedUTXO struct {
+	UTXO
+	Taint decimal.Decimal `json:"taint"`
+	// Share is the tainted fraction of the output's amount
+	Share decimal.Decimal `json:"share"`
+	// Depth is the number of transactions between the source and the output
+	Depth int `json:"depth"`
+}
+
+// TaintedAddress sums the tainted outputs received by an address. Unspent
+// taint is the tainted value the address still holds.
+type TaintedAddress struct {
+	Address       string          `json:"address"`
+	Taint         decimal.Decimal `json:"taint"`
+	UnspentTaint  decimal.Decimal `json:"unspent_taint"`
+	UnspentAmount decimal.Decimal `json:"unspent_amount"`
+	UTXOCount     int             `json:"utxo_count"`
+}
+
+// TaintTrace is the result of a taint trace
+type TaintTrace struct {
+	Network      Network         `json:"network"`
+	Address      string          `json:"address,omitempty"`
+	TxHash       string          `json:"tx_hash,omitempty"`
+	Strategy     TaintStrategy   `json:"strategy"`
+	SourceTaint  decimal.Decimal `json:"source_taint"`
+	Transactions int             `json:"transactions"`
+	// Truncated is set when the trace stopped at its depth or transaction
+	// limit with tainted outputs still spent further
+	Truncated bool             `json:"truncated"`
+	UTXOs     []TaintedUTXO    `json:"utxos"`
+	Addresses []TaintedAddress `json:"addresses"`
+	TracedAt  time.Time        `json:"traced_at"`
+}
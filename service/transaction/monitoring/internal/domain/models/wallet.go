@@ -30,6 +30,12 @@ type Wallet struct {
 	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
 }
 
+// WalletKey identifies a wallet by its address on one network
+type WalletKey struct {
+	Address string  `json:"address"`
+	Network Network `json:"network"`
+}
+
 // WalletTag represents tags associated with a wallet
 type WalletTag struct {
 	ID        string    `json:"id" db:"id"`
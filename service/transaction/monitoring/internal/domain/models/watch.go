@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WatchDirection selects which movements of a watched address raise a trigger
+type WatchDirection string
+
+const (
+	WatchDirectionAny      WatchDirection = "any"
+	WatchDirectionSent     WatchDirection = "sent"
+	WatchDirectionReceived WatchDirection = "received"
+)
+
+// WatchStage is where in the ingestion pipeline a movement was detected
+type WatchStage string
+
+const (
+	WatchStageMempool   WatchStage = "mempool"
+	WatchStageConfirmed WatchStage = "confirmed"
+)
+
+// Webhook delivery states of a watch trigger
+const (
+	WebhookStatusPending   = "pending"
+	WebhookStatusDelivered = "delivered"
+	WebhookStatusFailed    = "failed"
+)
+
+// AddressWatch is an address of interest. Any transaction sending from or
+// receiving to it raises an alert as soon as it is seen, and is posted to
+// WebhookURL when one is set.
+type AddressWatch struct {
+	ID              string         `json:"id" db:"id"`
+	Address         string         `json:"address" db:"address"`
+	Network         Network        `json:"network" db:"network"`
+	Direction       WatchDirection `json:"direction" db:"direction"`
+	Label           string         `json:"label,omitempty" db:"label"`
+	Reason          string         `json:"reason,omitempty" db:"reason"`
+	CaseID          string         `json:"case_id,omitempty" db:"case_id"`
+	WebhookURL      string         `json:"webhook_url,omitempty" db:"webhook_url"`
+	CreatedBy       string         `json:"created_by" db:"created_by"`
+	ExpiresAt       *time.Time     `json:"expires_at,omitempty" db:"expires_at"`
+	TriggerCount    int64          `json:"trigger_count" db:"trigger_count"`
+	LastTriggeredAt *time.Time     `json:"last_triggered_at,omitempty" db:"last_triggered_at"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+}
+
+// AddressWatchRequest registers an address watch
+type AddressWatchRequest struct {
+	Address    string         `json:"address" binding:"required"`
+	Network    Network        `json:"network" binding:"required"`
+	Direction  WatchDirection `json:"direction"`
+	Label      string         `json:"label"`
+	Reason     string         `json:"reason"`
+	CaseID     string         `json:"case_id"`
+	WebhookURL string         `json:"webhook_url"`
+	CreatedBy  string         `json:"created_by" binding:"required"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+}
+
+// WatchTrigger records a transaction that moved funds of a watched address.
+// A transaction first seen in the mempool is recorded once and marked
+// confirmed when it is included in a block.
+type WatchTrigger struct {
+	ID             string          `json:"id" db:"id"`
+	WatchID        string          `json:"watch_id" db:"watch_id"`
+	Address        string          `json:"address" db:"address"`
+	Network        Network         `json:"network" db:"network"`
+	TxHash         string          `json:"tx_hash" db:"tx_hash"`
+	Direction      WatchDirection  `json:"direction" db:"direction"`
+	Amount         decimal.Decimal `json:"amount" db:"amount"`
+	Asset          string          `json:"asset" db:"asset"`
+	Counterparties []string        `json:"counterparties" db:"counterparties"`
+	Stage          WatchStage      `json:"stage" db:"stage"`
+	BlockNumber    int64           `json:"block_number,omitempty" db:"block_number"`
+	AlertID        string          `json:"alert_id,omitempty" db:"alert_id"`
+	WebhookStatus  string          `json:"webhook_status,omitempty" db:"webhook_status"`
+	DetectedAt     time.Time       `json:"detected_at" db:"detected_at"`
+	ConfirmedAt    *time.Time      `json:"confirmed_at,omitempty" db:"confirmed_at"`
+}
+
+// MempoolTransactionEvent is a mempool_tx event on the raw blockchain topic
+type MempoolTransactionEvent struct {
+	Type        string                `json:"type"`
+	Network     Network               `json:"network"`
+	Transaction NormalizedTransaction `json:"transaction"`
+}
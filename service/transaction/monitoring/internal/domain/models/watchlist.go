@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// WatchlistEntry is an address placed under observation. Unlike blacklisted
+// wallets, watchlisted addresses are not blocked but require review.
+type WatchlistEntry struct {
+	ID        string     `json:"id" db:"id"`
+	Address   string     `json:"address" db:"address"`
+	Network   Network    `json:"network" db:"network"`
+	ListName  string     `json:"list_name" db:"list_name"`
+	Reason    string     `json:"reason" db:"reason"`
+	AddedBy   string     `json:"added_by" db:"added_by"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,10 +10,20 @@ import (
 	"github.com/csic/transaction-monitoring/internal/config"
 	"github.com/csic/transaction-monitoring/internal/domain/models"
 	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/csic/transaction-monitoring/internal/service/analytics"
+	"github.com/csic/transaction-monitoring/internal/service/bridge"
+	"github.com/csic/transaction-monitoring/internal/service/compliance"
 	"github.com/csic/transaction-monitoring/internal/service/graph"
 	"github.com/csic/transaction-monitoring/internal/service/ingest"
+	"github.com/csic/transaction-monitoring/internal/service/layer2"
+	"github.com/csic/transaction-monitoring/internal/service/mempool"
+	"github.com/csic/transaction-monitoring/internal/service/reorg"
 	"github.com/csic/transaction-monitoring/internal/service/risk"
 	"github.com/csic/transaction-monitoring/internal/service/sanctions"
+	"github.com/csic/transaction-monitoring/internal/service/screening"
+	"github.com/csic/transaction-monitoring/internal/service/taint"
+	"github.com/csic/transaction-monitoring/internal/service/token"
+	"github.com/csic/transaction-monitoring/internal/service/watch"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -24,8 +35,19 @@ type Handler struct {
 	cache          *repository.CacheRepository
 	ingestionSvc   *ingest.IngestionService
 	riskSvc        *risk.RiskScoringService
+	scoringSvc     *risk.ScoringPipeline
 	clusteringSvc  *graph.ClusteringService
 	sanctionsSvc   *sanctions.SanctionsService
+	screeningSvc   *screening.ScreeningService
+	complianceSvc  *compliance.ComplianceService
+	bridgeSvc      *bridge.MonitorService
+	concentration  *analytics.ConcentrationService
+	watchSvc       *watch.Service
+	mempoolSvc     *mempool.Service
+	layer2Svc      *layer2.Service
+	reorgSvc       *reorg.Service
+	taintSvc       *taint.Service
+	tokens         *token.Registry
 	logger         *zap.Logger
 }
 
@@ -36,8 +58,19 @@ func NewHandler(
 	cache *repository.CacheRepository,
 	ingestionSvc *ingest.IngestionService,
 	riskSvc *risk.RiskScoringService,
+	scoringSvc *risk.ScoringPipeline,
 	clusteringSvc *graph.ClusteringService,
 	sanctionsSvc *sanctions.SanctionsService,
+	screeningSvc *screening.ScreeningService,
+	complianceSvc *compliance.ComplianceService,
+	bridgeSvc *bridge.MonitorService,
+	concentration *analytics.ConcentrationService,
+	watchSvc *watch.Service,
+	mempoolSvc *mempool.Service,
+	layer2Svc *layer2.Service,
+	reorgSvc *reorg.Service,
+	taintSvc *taint.Service,
+	tokens *token.Registry,
 	logger *zap.Logger,
 ) *Handler {
 	return &Handler{
@@ -46,8 +79,19 @@ func NewHandler(
 		cache:         cache,
 		ingestionSvc:  ingestionSvc,
 		riskSvc:       riskSvc,
+		scoringSvc:    scoringSvc,
 		clusteringSvc: clusteringSvc,
 		sanctionsSvc:  sanctionsSvc,
+		screeningSvc:  screeningSvc,
+		complianceSvc: complianceSvc,
+		bridgeSvc:     bridgeSvc,
+		concentration: concentration,
+		watchSvc:      watchSvc,
+		mempoolSvc:    mempoolSvc,
+		layer2Svc:     layer2Svc,
+		reorgSvc:      reorgSvc,
+		taintSvc:      taintSvc,
+		tokens:        tokens,
 		logger:        logger,
 	}
 }
@@ -74,6 +118,28 @@ func (h *Handler) SetupRouter() *gin.Engine {
 			wallets.GET("/:network/:address/history", h.getWalletHistory)
 			wallets.GET("/:network/:address/cluster", h.getWalletCluster)
 			wallets.GET("/:network/:address/transactions", h.getWalletTransactions)
+			wallets.GET("/:network/:address/utxos", h.getWalletUTXOs)
+			wallets.POST("/watch", h.registerAddressWatch)
+			wallets.GET("/watch", h.listAddressWatches)
+			wallets.GET("/watch/:id", h.getAddressWatch)
+			wallets.DELETE("/watch/:id", h.removeAddressWatch)
+			wallets.GET("/watch/:id/triggers", h.listWatchTriggers)
+		}
+
+		// Risk scoring endpoints
+		riskGroup := v1.Group("/risk")
+		{
+			riskGroup.GET("/score/:address", h.getRiskScore)
+			riskGroup.GET("/models", h.listRiskModels)
+			riskGroup.POST("/models", h.registerRiskModel)
+			riskGroup.GET("/models/active", h.getActiveRiskModel)
+			riskGroup.GET("/models/:id", h.getRiskModel)
+			riskGroup.POST("/models/:id/activate", h.activateRiskModel)
+			riskGroup.POST("/rescore", h.startRescoring)
+			riskGroup.GET("/rescore/:id", h.getRescoringJob)
+			riskGroup.POST("/screen", h.screenAddressBatch)
+			riskGroup.POST("/watchlist", h.addWatchlistEntry)
+			riskGroup.DELETE("/watchlist/:network/:address", h.removeWatchlistEntry)
 		}
 
 		// Screening endpoints
@@ -83,6 +149,13 @@ func (h *Handler) SetupRouter() *gin.Engine {
 			screen.GET("/:network/:address", h.screenAddress)
 		}
 
+		// Transaction compliance checks
+		check := v1.Group("/check")
+		{
+			check.POST("", h.checkCompliance)
+			check.POST("/batch", h.checkComplianceBatch)
+		}
+
 		// Cluster endpoints
 		clusters := v1.Group("/clusters")
 		{
@@ -110,6 +183,49 @@ func (h *Handler) SetupRouter() *gin.Engine {
 			txs.POST("/:hash/screen", h.screenTransaction)
 		}
 
+		// Bridge monitoring endpoints
+		bridges := v1.Group("/bridges")
+		{
+			bridges.GET("/outflows", h.listBridgeOutflows)
+			bridges.GET("/graph/:network/:address", h.getBridgeGraph)
+		}
+
+		// Mempool interdiction endpoints
+		mempoolGroup := v1.Group("/mempool")
+		{
+			mempoolGroup.GET("/status", h.getMempoolStatus)
+			mempoolGroup.GET("/interdictions", h.listMempoolInterdictions)
+			mempoolGroup.GET("/interdictions/:id", h.getMempoolInterdiction)
+		}
+
+		// Layer-two monitoring endpoints
+		l2 := v1.Group("/l2")
+		{
+			l2.GET("/transfers", h.listL2Transfers)
+			l2.GET("/channels", h.listLightningChannels)
+			l2.GET("/links/:network/:address", h.getL2AddressLinks)
+		}
+
+		// Chain reorganization endpoints
+		v1.GET("/reorgs", h.listChainReorgs)
+
+		// UTXO taint tracing endpoints
+		v1.POST("/taint/trace", h.traceTaint)
+
+		// Token endpoints
+		tokens := v1.Group("/tokens")
+		{
+			tokens.GET("", h.listTokens)
+			tokens.POST("", h.registerToken)
+			tokens.GET("/transfers", h.listTokenTransfers)
+		}
+
+		// Asset analytics endpoints
+		assets := v1.Group("/assets")
+		{
+			assets.GET("/:network/:asset/concentration", h.getAssetConcentration)
+		}
+
 		// Stats endpoints
 		stats := v1.Group("/stats")
 		{
@@ -291,10 +407,49 @@ func (h *Handler) getWalletTransactions(c *gin.Context) {
 		return
 	}
 
+	transfers, err := h.tokens.ListTransfers(ctx, models.TokenTransferFilter{
+		Network: network,
+		Address: address,
+		Limit:   limit,
+	})
+	if err != nil {
+		h.logger.Error("Failed to get token transfers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve transactions"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"address":      address,
-		"network":      network,
-		"transactions": txs,
+		"address":         address,
+		"network":         network,
+		"transactions":    txs,
+		"token_transfers": transfers,
+	})
+}
+
+func (h *Handler) getWalletUTXOs(c *gin.Context) {
+	network := models.Network(c.Param("network"))
+	address := c.Param("address")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	unspentOnly := c.Query("unspent") == "true"
+
+	ctx := c.Request.Context()
+
+	utxos, balance, err := h.taintSvc.ListUTXOs(ctx, network, address, unspentOnly, limit)
+	if err != nil {
+		h.logger.Error("Failed to list UTXOs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve UTXOs"})
+		return
+	}
+	if utxos == nil {
+		utxos = []models.UTXO{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address": address,
+		"network": network,
+		"balance": balance,
+		"utxos":   utxos,
+		"count":   len(utxos),
 	})
 }
 
@@ -352,6 +507,63 @@ func (h *Handler) screenAddresses(c *gin.Context) {
 	})
 }
 
+// Compliance check endpoints
+
+func (h *Handler) checkCompliance(c *gin.Context) {
+	var tx models.NormalizedTransaction
+	if err := c.ShouldBindJSON(&tx); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	c.JSON(http.StatusOK, h.complianceSvc.CheckCompliance(ctx, &tx))
+}
+
+func (h *Handler) checkComplianceBatch(c *gin.Context) {
+	var req struct {
+		Transactions []*models.NormalizedTransaction `json:"transactions"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if len(req.Transactions) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one transaction is required"})
+		return
+	}
+	if len(req.Transactions) > compliance.MaxCheckBatch {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Too many transactions",
+			"limit": compliance.MaxCheckBatch,
+		})
+		return
+	}
+	for _, tx := range req.Transactions {
+		if tx == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	results := h.complianceSvc.CheckComplianceBatch(ctx, req.Transactions)
+
+	summary := map[screening.Verdict]int{}
+	for _, result := range results {
+		summary[result.Verdict]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"count":   len(results),
+		"summary": summary,
+	})
+}
+
 // Cluster endpoints
 
 func (h *Handler) getCluster(c *gin.Context) {
@@ -601,6 +813,717 @@ func (h *Handler) screenTransaction(c *gin.Context) {
 	})
 }
 
+// Risk scoring endpoints
+
+func (h *Handler) getRiskScore(c *gin.Context) {
+	address := c.Param("address")
+	network := models.Network(c.Query("network"))
+	if network == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "network query parameter is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	explanation, err := h.scoringSvc.ScoreAddress(ctx, address, network)
+	if errors.Is(err, risk.ErrWalletNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Wallet not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to score wallet", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate risk score"})
+		return
+	}
+
+	c.JSON(http.StatusOK, explanation)
+}
+
+func (h *Handler) listRiskModels(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	riskModels, err := h.repo.ListRiskModels(ctx)
+	if err != nil {
+		h.logger.Error("Failed to list risk models", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve risk models"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"models": riskModels,
+		"count":  len(riskModels),
+	})
+}
+
+func (h *Handler) registerRiskModel(c *gin.Context) {
+	var model models.RiskModel
+	if err := c.ShouldBindJSON(&model); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.scoringSvc.RegisterModel(ctx, &model); err != nil {
+		if errors.Is(err, risk.ErrInvalidModel) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to register risk model", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register risk model"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, model)
+}
+
+func (h *Handler) getActiveRiskModel(c *gin.Context) {
+	c.JSON(http.StatusOK, h.scoringSvc.ActiveModel())
+}
+
+func (h *Handler) getRiskModel(c *gin.Context) {
+	modelID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	model, err := h.repo.GetRiskModel(ctx, modelID)
+	if err != nil {
+		h.logger.Error("Failed to get risk model", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve risk model"})
+		return
+	}
+
+	if model == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Risk model not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, model)
+}
+
+func (h *Handler) activateRiskModel(c *gin.Context) {
+	modelID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	job, err := h.scoringSvc.ActivateModel(ctx, modelID)
+	switch {
+	case errors.Is(err, risk.ErrModelNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Risk model not found"})
+		return
+	case errors.Is(err, risk.ErrInvalidModel):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, risk.ErrRescoringInProgress):
+		// The model is active; the running job will finish with the old model
+		c.JSON(http.StatusAccepted, gin.H{
+			"model":   h.scoringSvc.ActiveModel(),
+			"warning": "Model activated but a re-scoring job is already running",
+		})
+		return
+	case err != nil:
+		h.logger.Error("Failed to activate risk model", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to activate risk model"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"model":         h.scoringSvc.ActiveModel(),
+		"rescoring_job": job,
+	})
+}
+
+func (h *Handler) startRescoring(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	job, err := h.scoringSvc.StartRescoring(ctx, "manual")
+	if errors.Is(err, risk.ErrRescoringInProgress) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to start re-scoring", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start re-scoring"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+func (h *Handler) getRescoringJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	job, err := h.scoringSvc.GetRescoringJob(ctx, jobID)
+	if err != nil {
+		h.logger.Error("Failed to get re-scoring job", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve re-scoring job"})
+		return
+	}
+
+	if job == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Re-scoring job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func (h *Handler) screenAddressBatch(c *gin.Context) {
+	var req struct {
+		Addresses []screening.AddressRequest `json:"addresses"`
+		Network   models.Network             `json:"network"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if len(req.Addresses) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one address is required"})
+		return
+	}
+	if len(req.Addresses) > screening.MaxScreeningBatch {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Too many addresses",
+			"limit": screening.MaxScreeningBatch,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	results := h.screeningSvc.ScreenAddresses(ctx, req.Addresses, req.Network)
+
+	summary := map[screening.Verdict]int{}
+	for _, result := range results {
+		summary[result.Verdict]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"count":   len(results),
+		"summary": summary,
+	})
+}
+
+func (h *Handler) addWatchlistEntry(c *gin.Context) {
+	var entry models.WatchlistEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.screeningSvc.AddToWatchlist(ctx, &entry); err != nil {
+		if errors.Is(err, screening.ErrInvalidAddress) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to add watchlist entry", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add watchlist entry"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *Handler) removeWatchlistEntry(c *gin.Context) {
+	network := models.Network(c.Param("network"))
+	address := c.Param("address")
+
+	ctx := c.Request.Context()
+
+	removed, err := h.screeningSvc.RemoveFromWatchlist(ctx, address, network)
+	if errors.Is(err, screening.ErrInvalidAddress) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to remove watchlist entry", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove watchlist entry"})
+		return
+	}
+
+	if removed == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Address is not on a watchlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
+
+// Address watch endpoints
+
+func (h *Handler) registerAddressWatch(c *gin.Context) {
+	var req models.AddressWatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	addressWatch, err := h.watchSvc.Register(ctx, &req)
+	if errors.Is(err, screening.ErrInvalidAddress) || errors.Is(err, watch.ErrInvalidWatch) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to register address watch", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register address watch"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, addressWatch)
+}
+
+func (h *Handler) listAddressWatches(c *gin.Context) {
+	network := models.Network(c.Query("network"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	ctx := c.Request.Context()
+
+	watches, err := h.watchSvc.List(ctx, network, limit)
+	if err != nil {
+		h.logger.Error("Failed to list address watches", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve address watches"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"watches": watches,
+		"count":   len(watches),
+	})
+}
+
+func (h *Handler) getAddressWatch(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	addressWatch, err := h.watchSvc.Get(ctx, c.Param("id"))
+	if errors.Is(err, watch.ErrWatchNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Address watch not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get address watch", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve address watch"})
+		return
+	}
+
+	c.JSON(http.StatusOK, addressWatch)
+}
+
+func (h *Handler) removeAddressWatch(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx := c.Request.Context()
+
+	err := h.watchSvc.Remove(ctx, id)
+	if errors.Is(err, watch.ErrWatchNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Address watch not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to remove address watch", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove address watch"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id, "removed": true})
+}
+
+func (h *Handler) listWatchTriggers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	ctx := c.Request.Context()
+
+	triggers, err := h.watchSvc.Triggers(ctx, c.Param("id"), limit)
+	if errors.Is(err, watch.ErrWatchNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Address watch not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to list watch triggers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve watch triggers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"triggers": triggers,
+		"count":    len(triggers),
+	})
+}
+
+// Bridge monitoring endpoints
+
+func (h *Handler) listBridgeOutflows(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	filter := models.BridgeOutflowFilter{
+		Network:    models.Network(c.Query("network")),
+		Address:    c.Query("address"),
+		BridgeName: c.Query("bridge"),
+		Limit:      limit,
+	}
+
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		filter.Since = &t
+	}
+
+	ctx := c.Request.Context()
+
+	outflows, err := h.bridgeSvc.ListOutflows(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list bridge outflows", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bridge outflows"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"outflows": outflows,
+		"count":    len(outflows),
+	})
+}
+
+func (h *Handler) getBridgeGraph(c *gin.Context) {
+	network := models.Network(c.Param("network"))
+	address := c.Param("address")
+
+	var since *time.Time
+	if value := c.Query("since"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		since = &t
+	}
+
+	ctx := c.Request.Context()
+
+	flowGraph, err := h.bridgeSvc.GetBridgeGraph(ctx, address, network, since)
+	if err != nil {
+		h.logger.Error("Failed to build bridge graph", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build bridge graph"})
+		return
+	}
+
+	c.JSON(http.StatusOK, flowGraph)
+}
+
+// Mempool interdiction endpoints
+
+func (h *Handler) getMempoolStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.mempoolSvc.GetStatus())
+}
+
+func (h *Handler) listMempoolInterdictions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	filter := models.MempoolInterdictionFilter{
+		Network: models.Network(c.Query("network")),
+		Address: c.Query("address"),
+		Limit:   limit,
+	}
+
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		filter.Since = &t
+	}
+
+	ctx := c.Request.Context()
+
+	interdictions, err := h.mempoolSvc.ListInterdictions(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list mempool interdictions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve mempool interdictions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"interdictions": interdictions,
+		"count":         len(interdictions),
+	})
+}
+
+func (h *Handler) getMempoolInterdiction(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	interdiction, err := h.mempoolSvc.GetInterdiction(ctx, c.Param("id"))
+	if errors.Is(err, mempool.ErrInterdictionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Mempool interdiction not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get mempool interdiction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve mempool interdiction"})
+		return
+	}
+
+	c.JSON(http.StatusOK, interdiction)
+}
+
+// Layer-two monitoring endpoints
+
+func (h *Handler) listL2Transfers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	filter := models.L2TransferFilter{
+		Network:      models.Network(c.Query("network")),
+		Address:      c.Query("address"),
+		TransferType: models.L2TransferType(c.Query("type")),
+		Limit:        limit,
+	}
+
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		filter.Since = &t
+	}
+
+	ctx := c.Request.Context()
+
+	transfers, err := h.layer2Svc.ListTransfers(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list L2 transfers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve L2 transfers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transfers": transfers,
+		"count":     len(transfers),
+	})
+}
+
+func (h *Handler) listLightningChannels(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	openOnly := c.Query("open") == "true"
+
+	ctx := c.Request.Context()
+
+	channels, err := h.layer2Svc.ListChannels(ctx, c.Query("node"), openOnly, limit)
+	if err != nil {
+		h.logger.Error("Failed to list lightning channels", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve lightning channels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"channels": channels,
+		"count":    len(channels),
+	})
+}
+
+func (h *Handler) getL2AddressLinks(c *gin.Context) {
+	network := models.Network(c.Param("network"))
+	address := c.Param("address")
+
+	ctx := c.Request.Context()
+
+	links, err := h.layer2Svc.GetLinks(ctx, network, address)
+	if err != nil {
+		h.logger.Error("Failed to get L2 address links", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve L2 address links"})
+		return
+	}
+	if links == nil {
+		links = []models.L2AddressLink{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"network": network,
+		"address": address,
+		"links":   links,
+	})
+}
+
+// Chain reorganization endpoints
+
+func (h *Handler) listChainReorgs(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	var since time.Time
+	if value := c.Query("since"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		since = t
+	}
+
+	ctx := c.Request.Context()
+
+	reorgs, err := h.reorgSvc.ListReorgs(ctx, models.Network(c.Query("network")), since, limit)
+	if err != nil {
+		h.logger.Error("Failed to list chain reorganizations", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve chain reorganizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reorgs": reorgs,
+		"count":  len(reorgs),
+	})
+}
+
+// UTXO taint tracing endpoints
+
+func (h *Handler) traceTaint(c *gin.Context) {
+	var req models.TaintTraceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	trace, err := h.taintSvc.Trace(ctx, &req)
+	if errors.Is(err, taint.ErrInvalidTrace) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, taint.ErrSourceNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to trace taint", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to trace taint"})
+		return
+	}
+
+	c.JSON(http.StatusOK, trace)
+}
+
+// Token endpoints
+
+func (h *Handler) listTokens(c *gin.Context) {
+	network := models.Network(c.Query("network"))
+	verifiedOnly := c.Query("verified") == "true"
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	ctx := c.Request.Context()
+
+	tokens, err := h.tokens.List(ctx, network, verifiedOnly, limit)
+	if err != nil {
+		h.logger.Error("Failed to list tokens", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+		"count":  len(tokens),
+	})
+}
+
+func (h *Handler) registerToken(c *gin.Context) {
+	var req models.TokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tk, err := h.tokens.Register(ctx, &req)
+	if errors.Is(err, token.ErrInvalidToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to register token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tk)
+}
+
+func (h *Handler) listTokenTransfers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	filter := models.TokenTransferFilter{
+		Network:      models.Network(c.Query("network")),
+		Address:      c.Query("address"),
+		Contract:     c.Query("contract"),
+		Symbol:       c.Query("symbol"),
+		TxHash:       c.Query("tx_hash"),
+		VerifiedOnly: c.Query("verified") == "true",
+		Limit:        limit,
+	}
+	if value := c.Query("since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		filter.Since = &since
+	}
+
+	ctx := c.Request.Context()
+
+	transfers, err := h.tokens.ListTransfers(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list token transfers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve token transfers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transfers": transfers,
+		"count":     len(transfers),
+	})
+}
+
+// Asset analytics endpoints
+
+func (h *Handler) getAssetConcentration(c *gin.Context) {
+	network := models.Network(c.Param("network"))
+	asset := c.Param("asset")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	since := time.Now().Add(-30 * 24 * time.Hour)
+	if value := c.Query("since"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp"})
+			return
+		}
+		since = t
+	}
+
+	ctx := c.Request.Context()
+
+	report, err := h.concentration.GetConcentrationReport(ctx, network, asset, since, limit)
+	if err != nil {
+		h.logger.Error("Failed to get asset concentration", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve asset concentration"})
+		return
+	}
+
+	if report.Latest == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No concentration data for asset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // Stats endpoints
 
 func (h *Handler) getStats(c *gin.Context) {
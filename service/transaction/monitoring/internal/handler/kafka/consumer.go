@@ -11,8 +11,10 @@ import (
 	"github.com/csic/transaction-monitoring/internal/domain/models"
 	"github.com/csic/transaction-monitoring/internal/repository"
 	"github.com/csic/transaction-monitoring/internal/service/graph"
+	"github.com/csic/transaction-monitoring/internal/service/reorg"
 	"github.com/csic/transaction-monitoring/internal/service/risk"
 	"github.com/csic/transaction-monitoring/internal/service/sanctions"
+	"github.com/csic/transaction-monitoring/internal/service/watch"
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
@@ -25,11 +27,16 @@ type Consumer struct {
 	riskSvc       *risk.RiskScoringService
 	clusteringSvc *graph.ClusteringService
 	sanctionsSvc  *sanctions.SanctionsService
+	watchSvc      *watch.Service
+	reorgSvc      *reorg.Service
 	logger        *zap.Logger
 	stopChan      chan struct{}
 	wg            sync.WaitGroup
+	mu            sync.Mutex
 	readers       []*kafka.Reader
 	isRunning     bool
+	batchSize     int
+	batchWait     time.Duration
 }
 
 // NewConsumer creates a new Kafka consumer
@@ -40,8 +47,15 @@ func NewConsumer(
 	riskSvc *risk.RiskScoringService,
 	clusteringSvc *graph.ClusteringService,
 	sanctionsSvc *sanctions.SanctionsService,
+	watchSvc *watch.Service,
+	reorgSvc *reorg.Service,
 	logger *zap.Logger,
 ) *Consumer {
+	batchSize := cfg.Kafka.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
 	return &Consumer{
 		cfg:           cfg,
 		repo:          repo,
@@ -49,8 +63,12 @@ func NewConsumer(
 		riskSvc:       riskSvc,
 		clusteringSvc: clusteringSvc,
 		sanctionsSvc:  sanctionsSvc,
+		watchSvc:      watchSvc,
+		reorgSvc:      reorgSvc,
 		logger:        logger,
 		stopChan:      make(chan struct{}),
+		batchSize:     batchSize,
+		batchWait:     parseDuration(cfg.Kafka.BatchWait, 200*time.Millisecond),
 	}
 }
 
@@ -113,7 +131,10 @@ func (c *Consumer) consumeNormalizedTransactions(ctx context.Context) {
 	})
 	c.readers = append(c.readers, reader)
 
-	c.logger.Info("Consuming normalized transactions", zap.String("topic", "csic.tx.normalized"))
+	c.logger.Info("Consuming normalized transactions",
+		zap.String("topic", "csic.tx.normalized"),
+		zap.Int("batch_size", c.batchSize),
+		zap.Duration("batch_wait", c.batchWait))
 
 	for {
 		select {
@@ -122,96 +143,142 @@ func (c *Consumer) consumeNormalizedTransactions(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		default:
-			msg, err := reader.FetchMessage(ctx)
+			batch, err := c.fetchBatch(ctx, reader)
+			if len(batch) > 0 {
+				c.processNormalizedTransactions(ctx, batch)
+
+				// Commit the batch
+				if err := reader.CommitMessages(ctx, batch...); err != nil {
+					c.logger.Warn("Failed to commit messages", zap.Int("messages", len(batch)), zap.Error(err))
+				}
+			}
+
 			if err != nil {
 				if ctx.Err() != nil {
 					return
 				}
 				c.logger.Warn("Failed to fetch message", zap.Error(err))
 				time.Sleep(5 * time.Second)
-				continue
-			}
-
-			if err := c.processNormalizedTransaction(ctx, msg); err != nil {
-				c.logger.Error("Failed to process transaction", zap.Error(err))
-				// Continue processing even on error
-			}
-
-			// Commit message
-			if err := reader.CommitMessages(ctx, msg); err != nil {
-				c.logger.Warn("Failed to commit message", zap.Error(err))
 			}
 		}
 	}
 }
 
-func (c *Consumer) processNormalizedTransaction(ctx context.Context, msg kafka.Message) error {
-	var tx models.NormalizedTransaction
-	if err := json.Unmarshal(msg.Value, &tx); err != nil {
-		return fmt.Errorf("failed to unmarshal transaction: %w", err)
+// fetchBatch waits for a message and then reads more until the batch is full
+// or batchWait has passed. Messages fetched before an error are returned with
+// it.
+func (c *Consumer) fetchBatch(ctx context.Context, reader *kafka.Reader) ([]kafka.Message, error) {
+	msg, err := reader.FetchMessage(ctx)
+	if err != nil {
+		return nil, err
 	}
+	batch := []kafka.Message{msg}
 
-	c.logger.Debug("Processing transaction",
-		zap.String("tx_hash", tx.TxHash),
-		zap.String("network", string(tx.Network)),
-		zap.Int("inputs", len(tx.Inputs)),
-		zap.Int("outputs", len(tx.Outputs)))
+	waitCtx, cancel := context.WithTimeout(ctx, c.batchWait)
+	defer cancel()
 
-	// 1. Update wallet metrics for all addresses
-	for _, input := range tx.Inputs {
-		if err := c.updateWalletMetrics(ctx, input.Address, tx.Network, input.Amount, "outgoing"); err != nil {
-			c.logger.Warn("Failed to update input wallet metrics",
-				zap.String("address", input.Address),
-				zap.Error(err))
+	for len(batch) < c.batchSize {
+		msg, err := reader.FetchMessage(waitCtx)
+		if err != nil {
+			if waitCtx.Err() != nil && ctx.Err() == nil {
+				// The wait is over; process what has arrived
+				return batch, nil
+			}
+			return batch, err
 		}
+		batch = append(batch, msg)
+	}
+	return batch, nil
+}
 
-		// Screen for sanctions
-		screenResult, err := c.sanctionsSvc.ScreenAddress(ctx, input.Address, tx.Network)
-		if err != nil {
-			c.logger.Warn("Failed to screen address",
-				zap.String("address", input.Address),
-				zap.Error(err))
-		} else if screenResult.IsSanctioned || screenResult.DirectLinks > 0 {
-			// Create alert for sanctions match
-			c.sanctionsSvc.CreateSanctionsAlert(ctx, screenResult)
+// processNormalizedTransactions processes a batch of normalized transactions.
+// Work is shared across the batch: each wallet's metrics and risk score are
+// updated and each input address screened once however many transactions it
+// appears in, and risk scores are written to and read from the cache in one
+// round trip each.
+func (c *Consumer) processNormalizedTransactions(ctx context.Context, msgs []kafka.Message) {
+	txs := make([]*models.NormalizedTransaction, 0, len(msgs))
+	for _, msg := range msgs {
+		var tx models.NormalizedTransaction
+		if err := json.Unmarshal(msg.Value, &tx); err != nil {
+			c.logger.Error("Failed to process transaction",
+				zap.Int64("offset", msg.Offset),
+				zap.Error(fmt.Errorf("failed to unmarshal transaction: %w", err)))
+			continue
 		}
+		txs = append(txs, &tx)
+	}
+	if len(txs) == 0 {
+		return
 	}
 
-	for _, output := range tx.Outputs {
-		if err := c.updateWalletMetrics(ctx, output.Address, tx.Network, output.Amount, "incoming"); err != nil {
-			c.logger.Warn("Failed to update output wallet metrics",
-				zap.String("address", output.Address),
+	c.logger.Debug("Processing transaction batch",
+		zap.Int("messages", len(msgs)),
+		zap.Int("transactions", len(txs)))
+
+	// Watched addresses are alerted on before the slower analysis below
+	if err := c.watchSvc.CheckTransactions(ctx, txs, models.WatchStageConfirmed); err != nil {
+		c.logger.Warn("Failed to check address watches", zap.Error(err))
+	}
+
+	// Maintain the UTXO set of UTXO networks
+	for _, tx := range txs {
+		if !c.cfg.Blockchain.IsUTXO(string(tx.Network)) {
+			continue
+		}
+		if err := c.repo.SaveUTXOs(ctx, tx); err != nil {
+			c.logger.Warn("Failed to update UTXO set",
+				zap.String("tx_hash", tx.TxHash),
 				zap.Error(err))
 		}
 	}
 
-	// 2. Calculate risk scores for involved wallets
-	for _, input := range tx.Inputs {
-		if err := c.riskSvc.UpdateWalletRiskScore(ctx, input.Address, tx.Network); err != nil {
-			c.logger.Warn("Failed to update risk score for input",
-				zap.String("address", input.Address),
+	wallets := models.UniqueWallets(txs)
+
+	// 1. Update wallet metrics for all addresses
+	for _, wallet := range wallets {
+		if err := c.updateWalletMetrics(ctx, wallet.Address, wallet.Network); err != nil {
+			c.logger.Warn("Failed to update wallet metrics",
+				zap.String("address", wallet.Address),
 				zap.Error(err))
 		}
 	}
 
-	for _, output := range tx.Outputs {
-		if err := c.riskSvc.UpdateWalletRiskScore(ctx, output.Address, tx.Network); err != nil {
-			c.logger.Warn("Failed to update risk score for output",
-				zap.String("address", output.Address),
-				zap.Error(err))
+	// Screen input addresses for sanctions
+	screened := make(map[models.WalletKey]bool)
+	for _, tx := range txs {
+		for _, input := range tx.Inputs {
+			key := models.WalletKey{Address: input.Address, Network: tx.Network}
+			if screened[key] {
+				continue
+			}
+			screened[key] = true
+
+			screenResult, err := c.sanctionsSvc.ScreenAddress(ctx, input.Address, tx.Network)
+			if err != nil {
+				c.logger.Warn("Failed to screen address",
+					zap.String("address", input.Address),
+					zap.Error(err))
+			} else if screenResult.IsSanctioned || screenResult.DirectLinks > 0 {
+				// Create alert for sanctions match
+				c.sanctionsSvc.CreateSanctionsAlert(ctx, screenResult)
+			}
 		}
 	}
 
+	// 2. Calculate risk scores for involved wallets
+	c.riskSvc.UpdateWalletRiskScores(ctx, wallets)
+
 	// 3. Evaluate transaction risk
-	riskScore, reasons := c.riskSvc.EvaluateTransactionRisk(ctx, &tx)
-	if riskScore >= float64(c.cfg.RiskScoring.HighThreshold) {
-		c.createTransactionRiskAlert(ctx, &tx, riskScore, reasons)
+	risks := c.riskSvc.EvaluateTransactionRiskBatch(ctx, txs)
+	for i, tx := range txs {
+		if risks[i].Score >= float64(c.cfg.RiskScoring.HighThreshold) {
+			c.createTransactionRiskAlert(ctx, tx, risks[i].Score, risks[i].Reasons)
+		}
 	}
-
-	return nil
 }
 
-func (c *Consumer) updateWalletMetrics(ctx context.Context, address string, network models.Network, amount interface{}, txType string) error {
+func (c *Consumer) updateWalletMetrics(ctx context.Context, address string, network models.Network) error {
 	// Get or create wallet
 	wallet, err := c.repo.GetWalletByAddress(ctx, address, network)
 	if err != nil {
@@ -235,7 +302,6 @@ func (c *Consumer) updateWalletMetrics(ctx context.Context, address string, netw
 	// Update wallet
 	wallet.LastSeen = time.Now()
 	wallet.TxCount++
-	// Update total received/sent based on txType
 
 	return nil
 }
@@ -297,28 +363,92 @@ func (c *Consumer) processBlockchainEvent(ctx context.Context, msg kafka.Message
 
 	switch eventType {
 	case "new_block":
-		c.handleNewBlock(ctx, event)
+		c.handleNewBlock(ctx, msg.Value)
 	case "reorg":
-		c.handleReorg(ctx, event)
+		c.handleReorg(ctx, msg.Value)
 	case "mempool_tx":
-		c.handleMempoolTx(ctx, event)
+		c.handleMempoolTx(ctx, msg.Value)
 	}
 }
 
-func (c *Consumer) handleNewBlock(ctx context.Context, event map[string]interface{}) {
+// handleNewBlock records a block announced by a node, which detects
+// reorganizations the node did not announce
+func (c *Consumer) handleNewBlock(ctx context.Context, value []byte) {
+	var event models.BlockEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.logger.Warn("Failed to unmarshal block event", zap.Error(err))
+		return
+	}
+
 	c.logger.Info("New block detected",
-		zap.String("network", fmt.Sprintf("%v", event["network"])),
-		zap.Int64("height", int64(event["height"].(float64))))
+		zap.String("network", string(event.Network)),
+		zap.Int64("height", event.Height))
+
+	if event.Hash == "" {
+		return
+	}
+
+	if _, err := c.reorgSvc.ObserveBlock(ctx, &models.ChainBlock{
+		Network:    event.Network,
+		Number:     event.Height,
+		Hash:       event.Hash,
+		ParentHash: event.ParentHash,
+		SeenAt:     time.Now(),
+	}); err != nil {
+		c.logger.Error("Failed to record block",
+			zap.String("network", string(event.Network)),
+			zap.Int64("height", event.Height),
+			zap.Error(err))
+	}
 }
 
-func (c *Consumer) handleReorg(ctx context.Context, event map[string]interface{}) {
+// handleReorg invalidates the transactions of the blocks a node reports as
+// replaced, from the event height up
+func (c *Consumer) handleReorg(ctx context.Context, value []byte) {
+	var event models.BlockEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.logger.Warn("Failed to unmarshal reorg event", zap.Error(err))
+		return
+	}
+
 	c.logger.Warn("Blockchain reorganization detected",
-		zap.String("network", fmt.Sprintf("%v", event["network"])),
-		zap.Int64("height", int64(event["height"].(float64))))
+		zap.String("network", string(event.Network)),
+		zap.Int64("height", event.Height))
+
+	if _, err := c.reorgSvc.Reorg(ctx, event.Network, event.Height-1); err != nil {
+		c.logger.Error("Failed to handle chain reorganization",
+			zap.String("network", string(event.Network)),
+			zap.Int64("height", event.Height),
+			zap.Error(err))
+	}
 }
 
-func (c *Consumer) handleMempoolTx(ctx context.Context, event map[string]interface{}) {
-	c.logger.Debug("Mempool transaction detected")
+// handleMempoolTx checks an unconfirmed transaction against the address
+// watches of networks with mempool monitoring enabled
+func (c *Consumer) handleMempoolTx(ctx context.Context, value []byte) {
+	var event models.MempoolTransactionEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.logger.Warn("Failed to unmarshal mempool transaction", zap.Error(err))
+		return
+	}
+
+	tx := &event.Transaction
+	if tx.Network == "" {
+		tx.Network = event.Network
+	}
+	if !c.watchSvc.MempoolEnabled(tx.Network) {
+		return
+	}
+
+	c.logger.Debug("Mempool transaction detected",
+		zap.String("network", string(tx.Network)),
+		zap.String("tx_hash", tx.TxHash))
+
+	if err := c.watchSvc.CheckTransactions(ctx, []*models.NormalizedTransaction{tx}, models.WatchStageMempool); err != nil {
+		c.logger.Warn("Failed to check address watches",
+			zap.String("tx_hash", tx.TxHash),
+			zap.Error(err))
+	}
 }
 
 func (c *Consumer) createTransactionRiskAlert(ctx context.Context, tx *models.NormalizedTransaction, score float64, reasons []string) {
@@ -352,6 +482,13 @@ func mapScoreToSeverity(score float64) models.AlertSeverity {
 	}
 }
 
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
 func generateID() string {
 	return fmt.Sprintf("%d", time.Now().UnixNano())
 }
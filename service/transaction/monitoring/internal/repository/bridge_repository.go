@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+// Bridge monitoring operations
+
+// GetMonitoredAddresses returns the reasons each address in the list is under
+// monitoring: an unexpired watchlist entry, a sanctioned or blacklisted wallet, a
+// wallet risk score at or above minRiskScore, or membership of a cluster scored
+// at or above minRiskScore. Addresses that are not monitored are omitted.
+func (r *Repository) GetMonitoredAddresses(ctx context.Context, addresses []string, network models.Network, minRiskScore float64) (map[string][]string, error) {
+	query := `
+		SELECT address, 'watchlist:' || list_name FROM address_watchlist
+		WHERE address = ANY($1) AND network = $2 AND (expires_at IS NULL OR expires_at > $4)
+		UNION ALL
+		SELECT address, 'sanctioned' FROM wallets
+		WHERE address = ANY($1) AND network = $2 AND is_sanctioned = true
+		UNION ALL
+		SELECT address, 'blacklisted' FROM wallets
+		WHERE address = ANY($1) AND network = $2 AND is_blacklisted = true
+		UNION ALL
+		SELECT address, 'high_risk_wallet' FROM wallets
+		WHERE address = ANY($1) AND network = $2 AND risk_score >= $3
+		UNION ALL
+		SELECT w.address, 'high_risk_cluster:' || c.id FROM wallets w
+		JOIN entity_clusters c ON c.id = w.cluster_id
+		WHERE w.address = ANY($1) AND w.network = $2 AND c.risk_score >= $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(addresses), network, minRiskScore, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	monitored := make(map[string][]string)
+	for rows.Next() {
+		var address, reason string
+		if err := rows.Scan(&address, &reason); err != nil {
+			return nil, err
+		}
+		monitored[address] = append(monitored[address], reason)
+	}
+
+	return monitored, rows.Err()
+}
+
+// SaveBridgeOutflow records a bridge outflow and reports whether it was new.
+// An outflow already recorded for the same transaction and bridge address is left unchanged.
+func (r *Repository) SaveBridgeOutflow(ctx context.Context, outflow *models.BridgeOutflow) (bool, error) {
+	query := `
+		INSERT INTO bridge_outflows (
+			id, bridge_name, network, bridge_address, tx_hash, sender, amount, asset,
+			threshold, monitored_reasons, cluster_id, timestamp, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (tx_hash, bridge_address) DO NOTHING
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		outflow.ID, outflow.BridgeName, outflow.Network, outflow.BridgeAddress, outflow.TxHash,
+		outflow.Sender, outflow.Amount, outflow.Asset, outflow.Threshold,
+		pq.Array(outflow.MonitoredReasons), outflow.ClusterID, outflow.Timestamp, outflow.CreatedAt,
+	).Scan(&outflow.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetBridgeOutflowAlert links a bridge outflow to the alert raised for it
+func (r *Repository) SetBridgeOutflowAlert(ctx context.Context, id, alertID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE bridge_outflows SET alert_id = $1 WHERE id = $2`, alertID, id)
+	return err
+}
+
+// SetBridgeOutflowDestination records the release on the destination chain matched to an outflow
+func (r *Repository) SetBridgeOutflowDestination(ctx context.Context, id string, network models.Network, address, txHash string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE bridge_outflows
+		SET destination_network = $1, destination_address = $2, destination_tx_hash = $3
+		WHERE id = $4
+	`, network, address, txHash, id)
+	return err
+}
+
+// GetUnmatchedBridgeOutflows returns outflows since the given time whose destination leg is not yet known
+func (r *Repository) GetUnmatchedBridgeOutflows(ctx context.Context, since time.Time) ([]models.BridgeOutflow, error) {
+	query := `SELECT ` + bridgeOutflowColumns + ` FROM bridge_outflows
+		WHERE timestamp >= $1 AND destination_tx_hash IS NULL AND reorged_at IS NULL
+		ORDER BY timestamp ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBridgeOutflows(rows)
+}
+
+// ListBridgeOutflows returns bridge outflows matching the filter, newest first.
+// An address filter matches either the sender or the destination address.
+func (r *Repository) ListBridgeOutflows(ctx context.Context, filter models.BridgeOutflowFilter) ([]models.BridgeOutflow, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Address != "" {
+		add("(sender = $%[1]d OR destination_address = $%[1]d)", filter.Address)
+	}
+	if filter.Network != "" {
+		add("(network = $%[1]d OR destination_network = $%[1]d)", filter.Network)
+	}
+	if filter.BridgeName != "" {
+		add("bridge_name = $%d", filter.BridgeName)
+	}
+	if filter.Since != nil {
+		add("timestamp >= $%d", *filter.Since)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`SELECT %s FROM bridge_outflows WHERE %s ORDER BY timestamp DESC LIMIT $%d`,
+		bridgeOutflowColumns, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBridgeOutflows(rows)
+}
+
+// bridgeOutflowColumns lists bridge_outflows columns in scan order
+const bridgeOutflowColumns = `id, bridge_name, network, bridge_address, tx_hash, sender, amount, asset,
+	threshold, monitored_reasons, cluster_id, destination_network, destination_address,
+	destination_tx_hash, alert_id, timestamp, created_at`
+
+func scanBridgeOutflows(rows *sql.Rows) ([]models.BridgeOutflow, error) {
+	var outflows []models.BridgeOutflow
+	for rows.Next() {
+		var outflow models.BridgeOutflow
+		var clusterID, destNetwork, destAddress, destTxHash, alertID sql.NullString
+		if err := rows.Scan(
+			&outflow.ID, &outflow.BridgeName, &outflow.Network, &outflow.BridgeAddress, &outflow.TxHash,
+			&outflow.Sender, &outflow.Amount, &outflow.Asset, &outflow.Threshold,
+			pq.Array(&outflow.MonitoredReasons), &clusterID, &destNetwork, &destAddress,
+			&destTxHash, &alertID, &outflow.Timestamp, &outflow.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if clusterID.Valid {
+			outflow.ClusterID = &clusterID.String
+		}
+		if destNetwork.Valid {
+			network := models.Network(destNetwork.String)
+			outflow.DestinationNetwork = &network
+		}
+		if destAddress.Valid {
+			outflow.DestinationAddress = &destAddress.String
+		}
+		if destTxHash.Valid {
+			outflow.DestinationTxHash = &destTxHash.String
+		}
+		if alertID.Valid {
+			outflow.AlertID = &alertID.String
+		}
+		outflows = append(outflows, outflow)
+	}
+	return outflows, rows.Err()
+}
@@ -77,6 +77,48 @@ func (r *CacheRepository) GetWalletRiskScore(ctx context.Context, address string
 	return val, err
 }
 
+// SetWalletRiskScores caches several wallet risk scores in one round trip
+func (r *CacheRepository) SetWalletRiskScores(ctx context.Context, scores map[models.WalletKey]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for wallet, score := range scores {
+		pipe.Set(ctx, r.key("risk", string(wallet.Network), wallet.Address), score, 24*time.Hour)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetWalletRiskScores retrieves the cached risk scores of several wallets in
+// one round trip. Wallets without a cached score are left out of the result.
+func (r *CacheRepository) GetWalletRiskScores(ctx context.Context, wallets []models.WalletKey) (map[models.WalletKey]float64, error) {
+	scores := make(map[models.WalletKey]float64, len(wallets))
+	if len(wallets) == 0 {
+		return scores, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(wallets))
+	for i, wallet := range wallets {
+		cmds[i] = pipe.Get(ctx, r.key("risk", string(wallet.Network), wallet.Address))
+	}
+	// A miss fails its own command with redis.Nil, not the pipeline
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	for i, cmd := range cmds {
+		score, err := cmd.Float64()
+		if err != nil {
+			continue
+		}
+		scores[wallets[i]] = score
+	}
+	return scores, nil
+}
+
 // SetWalletRiskFactors caches wallet risk factors
 func (r *CacheRepository) SetWalletRiskFactors(ctx context.Context, factors *models.RiskFactors) error {
 	key := r.key("risk:factors", factors.WalletID)
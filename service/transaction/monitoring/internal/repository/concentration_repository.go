@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+)
+
+// Token-holder concentration operations
+
+// AssetKey identifies an asset on a network
+type AssetKey struct {
+	Network models.Network
+	Asset   string
+}
+
+// GetTrackedAssets returns every network and asset with ingested transactions
+func (r *Repository) GetTrackedAssets(ctx context.Context) ([]AssetKey, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT DISTINCT network, asset FROM transactions WHERE asset <> '' ORDER BY network, asset`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assets []AssetKey
+	for rows.Next() {
+		var key AssetKey
+		if err := rows.Scan(&key.Network, &key.Asset); err != nil {
+			return nil, err
+		}
+		assets = append(assets, key)
+	}
+	return assets, rows.Err()
+}
+
+// GetHolderBalances returns the positive net balances of an asset derived from
+// ingested transactions and verified token transfers, received minus sent.
+// Addresses belonging to an entity cluster are aggregated under the cluster ID.
+func (r *Repository) GetHolderBalances(ctx context.Context, network models.Network, asset string) ([]models.HolderBalance, error) {
+	query := `
+		SELECT COALESCE(w.cluster_id, f.address) AS holder, w.cluster_id IS NOT NULL, SUM(f.delta)
+		FROM (
+			SELECT receiver AS address, amount AS delta FROM transactions
+			WHERE network = $1 AND asset = $2 AND status NOT IN ('failed', 'reorged') AND receiver <> ''
+			UNION ALL
+			SELECT sender, -amount FROM transactions
+			WHERE network = $1 AND asset = $2 AND status NOT IN ('failed', 'reorged') AND sender <> ''
+			UNION ALL
+			SELECT tt.receiver, tt.amount FROM token_transfers tt
+			JOIN tokens tk ON tk.network = tt.network AND tk.contract = tt.contract
+			JOIN transactions t ON t.tx_hash = tt.tx_hash
+			WHERE tt.network = $1 AND tk.symbol = $2 AND tk.verified AND tt.standard = 'erc20'
+				AND t.status NOT IN ('failed', 'reorged')
+			UNION ALL
+			SELECT tt.sender, -tt.amount FROM token_transfers tt
+			JOIN tokens tk ON tk.network = tt.network AND tk.contract = tt.contract
+			JOIN transactions t ON t.tx_hash = tt.tx_hash
+			WHERE tt.network = $1 AND tk.symbol = $2 AND tk.verified AND tt.standard = 'erc20'
+				AND t.status NOT IN ('failed', 'reorged')
+		) f
+		LEFT JOIN wallets w ON w.address = f.address AND w.network = $1
+		GROUP BY 1, 2
+		HAVING SUM(f.delta) > 0
+		ORDER BY 3 DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, network, asset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balances []models.HolderBalance
+	for rows.Next() {
+		var balance models.HolderBalance
+		if err := rows.Scan(&balance.Holder, &balance.IsCluster, &balance.Balance); err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	return balances, rows.Err()
+}
+
+// SaveConcentrationSnapshot stores a concentration snapshot
+func (r *Repository) SaveConcentrationSnapshot(ctx context.Context, snapshot *models.AssetConcentration) error {
+	topHolders, err := json.Marshal(snapshot.TopHolders)
+	if err != nil {
+		return fmt.Errorf("failed to marshal top holders: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO asset_concentration (
+			id, network, asset, holders, total_supply, gini, top_n, top_n_share,
+			top_holder, top_holder_share, top_holders, computed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		snapshot.ID, snapshot.Network, snapshot.Asset, snapshot.Holders, snapshot.TotalSupply,
+		snapshot.Gini, snapshot.TopN, snapshot.TopNShare, snapshot.TopHolder,
+		snapshot.TopHolderShare, topHolders, snapshot.ComputedAt,
+	)
+	return err
+}
+
+// GetConcentrationHistory returns an asset's snapshots since the given time, newest first
+func (r *Repository) GetConcentrationHistory(ctx context.Context, network models.Network, asset string, since time.Time, limit int) ([]models.AssetConcentration, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	query := `SELECT ` + concentrationColumns + ` FROM asset_concentration
+		WHERE network = $1 AND asset = $2 AND computed_at >= $3
+		ORDER BY computed_at DESC LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, network, asset, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []models.AssetConcentration
+	for rows.Next() {
+		snapshot, err := scanConcentration(rows)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, *snapshot)
+	}
+	return history, rows.Err()
+}
+
+// GetConcentrationSnapshotBefore returns an asset's latest snapshot taken at or
+// before the given time, or nil if there is none
+func (r *Repository) GetConcentrationSnapshotBefore(ctx context.Context, network models.Network, asset string, before time.Time) (*models.AssetConcentration, error) {
+	query := `SELECT ` + concentrationColumns + ` FROM asset_concentration
+		WHERE network = $1 AND asset = $2 AND computed_at <= $3
+		ORDER BY computed_at DESC LIMIT 1`
+
+	snapshot, err := scanConcentration(r.db.QueryRowContext(ctx, query, network, asset, before))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return snapshot, err
+}
+
+// concentrationColumns lists asset_concentration columns in scan order
+const concentrationColumns = `id, network, asset, holders, total_supply, gini, top_n, top_n_share,
+	top_holder, top_holder_share, top_holders, computed_at`
+
+func scanConcentration(row interface{ Scan(...interface{}) error }) (*models.AssetConcentration, error) {
+	var snapshot models.AssetConcentration
+	var topHolders []byte
+	if err := row.Scan(
+		&snapshot.ID, &snapshot.Network, &snapshot.Asset, &snapshot.Holders, &snapshot.TotalSupply,
+		&snapshot.Gini, &snapshot.TopN, &snapshot.TopNShare, &snapshot.TopHolder,
+		&snapshot.TopHolderShare, &topHolders, &snapshot.ComputedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(topHolders) > 0 {
+		if err := json.Unmarshal(topHolders, &snapshot.TopHolders); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal top holders: %w", err)
+		}
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Layer-two monitoring operations
+
+// GetL1RiskScores returns the risk of each listed wallet on an L1 network,
+// keyed by lowercase address. Sanctioned and blacklisted wallets score 100;
+// otherwise the higher of the wallet and cluster scores is used. Addresses
+// are matched case-insensitively so checksummed and lowercase EVM addresses
+// find the same wallet. Unknown addresses are omitted.
+func (r *Repository) GetL1RiskScores(ctx context.Context, addresses []string, network models.Network) (map[string]float64, error) {
+	lowered := make([]string, len(addresses))
+	for i, address := range addresses {
+		lowered[i] = strings.ToLower(address)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT lower(w.address),
+			CASE WHEN w.is_sanctioned OR w.is_blacklisted THEN 100
+			ELSE GREATEST(w.risk_score, COALESCE(c.risk_score, 0)) END
+		FROM wallets w
+		LEFT JOIN entity_clusters c ON c.id = w.cluster_id
+		WHERE lower(w.address) = ANY($1) AND w.network = $2
+	`, pq.Array(lowered), network)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := make(map[string]float64)
+	for rows.Next() {
+		var address string
+		var score float64
+		if err := rows.Scan(&address, &score); err != nil {
+			return nil, err
+		}
+		if score > scores[address] {
+			scores[address] = score
+		}
+	}
+	return scores, rows.Err()
+}
+
+// PropagateWalletRisk raises the risk score of an L2 wallet to at least score,
+// creating the wallet if it has not been seen. A higher score already held by
+// the wallet is kept.
+func (r *Repository) PropagateWalletRisk(ctx context.Context, address string, network models.Network, score float64, level string) error {
+	now := time.Now()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO wallets (id, address, network, first_seen, last_seen, risk_score, risk_level, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4, $5, $6, $4, $4)
+		ON CONFLICT (address, network) DO UPDATE SET
+			risk_score = EXCLUDED.risk_score,
+			risk_level = EXCLUDED.risk_level,
+			updated_at = EXCLUDED.updated_at
+		WHERE wallets.risk_score < EXCLUDED.risk_score
+	`, uuid.New().String(), address, network, now, score, level)
+	return err
+}
+
+// SaveL2AddressLink records the link between an L2 address and an L1 wallet,
+// replacing the scores of an existing link with the latest propagation
+func (r *Repository) SaveL2AddressLink(ctx context.Context, link *models.L2AddressLink) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO l2_address_links (
+			l2_network, l2_address, l1_network, l1_address, link_type, reference,
+			source_risk_score, risk_score, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9)
+		ON CONFLICT (l2_network, l2_address, l1_network, l1_address) DO UPDATE SET
+			link_type = EXCLUDED.link_type,
+			reference = EXCLUDED.reference,
+			source_risk_score = EXCLUDED.source_risk_score,
+			risk_score = EXCLUDED.risk_score,
+			updated_at = EXCLUDED.updated_at
+	`,
+		link.L2Network, link.L2Address, link.L1Network, link.L1Address, link.LinkType,
+		link.Reference, link.SourceRiskScore, link.RiskScore, link.UpdatedAt,
+	)
+	return err
+}
+
+// ListL2AddressLinks returns the L1 wallets linked to an L2 address, highest
+// propagated risk first
+func (r *Repository) ListL2AddressLinks(ctx context.Context, network models.Network, address string) ([]models.L2AddressLink, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l2_network, l2_address, l1_network, l1_address, link_type, COALESCE(reference, ''),
+			source_risk_score, risk_score, updated_at
+		FROM l2_address_links
+		WHERE l2_network = $1 AND l2_address = $2
+		ORDER BY risk_score DESC
+	`, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []models.L2AddressLink
+	for rows.Next() {
+		var link models.L2AddressLink
+		if err := rows.Scan(
+			&link.L2Network, &link.L2Address, &link.L1Network, &link.L1Address, &link.LinkType,
+			&link.Reference, &link.SourceRiskScore, &link.RiskScore, &link.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// SaveLightningChannel records a channel learned from gossip and reports
+// whether it was new. A known channel is left unchanged.
+func (r *Repository) SaveLightningChannel(ctx context.Context, channel *models.LightningChannel) (bool, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO lightning_channels (
+			channel_id, channel_point, node1, node2, capacity, funding_tx_hash,
+			funder, first_seen, last_update
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (channel_id) DO NOTHING
+		RETURNING channel_id
+	`,
+		channel.ChannelID, channel.ChannelPoint, channel.Node1, channel.Node2, channel.Capacity,
+		channel.FundingTxHash, channel.Funder, channel.FirstSeen, channel.LastUpdate,
+	).Scan(&channel.ChannelID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CloseLightningChannel marks a channel as closed
+func (r *Repository) CloseLightningChannel(ctx context.Context, channelID string, closedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE lightning_channels SET closed_at = $1 WHERE channel_id = $2 AND closed_at IS NULL`,
+		closedAt, channelID)
+	return err
+}
+
+// ListLightningChannels returns channels, newest first. A node filter matches
+// either side of the channel; openOnly leaves out closed channels.
+func (r *Repository) ListLightningChannels(ctx context.Context, node string, openOnly bool, limit int) ([]models.LightningChannel, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	if node != "" {
+		args = append(args, node)
+		conditions = append(conditions, fmt.Sprintf("(node1 = $%[1]d OR node2 = $%[1]d)", len(args)))
+	}
+	if openOnly {
+		conditions = append(conditions, "closed_at IS NULL")
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM lightning_channels WHERE %s ORDER BY first_seen DESC`,
+		lightningChannelColumns, strings.Join(conditions, " AND "))
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var channels []models.LightningChannel
+	for rows.Next() {
+		var channel models.LightningChannel
+		var funder sql.NullString
+		var closedAt sql.NullTime
+		if err := rows.Scan(
+			&channel.ChannelID, &channel.ChannelPoint, &channel.Node1, &channel.Node2, &channel.Capacity,
+			&channel.FundingTxHash, &funder, &channel.FirstSeen, &channel.LastUpdate, &closedAt,
+		); err != nil {
+			return nil, err
+		}
+		if funder.Valid {
+			channel.Funder = &funder.String
+		}
+		if closedAt.Valid {
+			channel.ClosedAt = &closedAt.Time
+		}
+		channels = append(channels, channel)
+	}
+	return channels, rows.Err()
+}
+
+// lightningChannelColumns lists lightning_channels columns in scan order
+const lightningChannelColumns = `channel_id, channel_point, node1, node2, capacity, funding_tx_hash,
+	funder, first_seen, last_update, closed_at`
+
+// SaveL2Transfer records a layer-two transfer and reports whether it was new.
+// A transfer already recorded with the same network, type and reference is left unchanged.
+func (r *Repository) SaveL2Transfer(ctx context.Context, transfer *models.L2Transfer) (bool, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO l2_transfers (
+			id, network, transfer_type, reference, sender, receiver, amount, asset,
+			l1_network, l1_tx_hash, risk_score, timestamp, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (network, transfer_type, reference) DO NOTHING
+		RETURNING id
+	`,
+		transfer.ID, transfer.Network, transfer.TransferType, transfer.Reference, transfer.Sender,
+		transfer.Receiver, transfer.Amount, transfer.Asset, transfer.L1Network, transfer.L1TxHash,
+		transfer.RiskScore, transfer.Timestamp, transfer.CreatedAt,
+	).Scan(&transfer.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetL2TransferAlert links a layer-two transfer to the alert raised for it
+func (r *Repository) SetL2TransferAlert(ctx context.Context, id, alertID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE l2_transfers SET alert_id = $1 WHERE id = $2`, alertID, id)
+	return err
+}
+
+// ListL2Transfers returns layer-two transfers matching the filter, newest
+// first. An address filter matches either the sender or the receiver.
+func (r *Repository) ListL2Transfers(ctx context.Context, filter models.L2TransferFilter) ([]models.L2Transfer, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Network != "" {
+		add("network = $%d", filter.Network)
+	}
+	if filter.Address != "" {
+		add("(sender = $%[1]d OR receiver = $%[1]d)", filter.Address)
+	}
+	if filter.TransferType != "" {
+		add("transfer_type = $%d", filter.TransferType)
+	}
+	if filter.Since != nil {
+		add("timestamp >= $%d", *filter.Since)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT id, network, transfer_type, reference, sender, receiver, amount, asset,
+			l1_network, l1_tx_hash, risk_score, alert_id, timestamp, created_at
+		FROM l2_transfers WHERE %s ORDER BY timestamp DESC LIMIT $%d`,
+		strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []models.L2Transfer
+	for rows.Next() {
+		var transfer models.L2Transfer
+		var l1Network, l1TxHash, alertID sql.NullString
+		if err := rows.Scan(
+			&transfer.ID, &transfer.Network, &transfer.TransferType, &transfer.Reference,
+			&transfer.Sender, &transfer.Receiver, &transfer.Amount, &transfer.Asset,
+			&l1Network, &l1TxHash, &transfer.RiskScore, &alertID, &transfer.Timestamp, &transfer.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if l1Network.Valid {
+			network := models.Network(l1Network.String)
+			transfer.L1Network = &network
+		}
+		if l1TxHash.Valid {
+			transfer.L1TxHash = &l1TxHash.String
+		}
+		if alertID.Valid {
+			transfer.AlertID = &alertID.String
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, rows.Err()
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+)
+
+// Mempool interdiction operations
+
+// mempoolInterdictionColumns lists mempool_interdictions columns in scan order
+const mempoolInterdictionColumns = `id, tx_hash, network, COALESCE(asset, ''), total_value, matches,
+	COALESCE(alert_id, ''), seen_at, detected_at`
+
+// SaveMempoolInterdiction records an interdiction and reports whether it was new.
+// A transaction already recorded on the same network is left unchanged.
+func (r *Repository) SaveMempoolInterdiction(ctx context.Context, interdiction *models.MempoolInterdiction) (bool, error) {
+	matches, err := json.Marshal(interdiction.Matches)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal matches: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO mempool_interdictions (
+			id, tx_hash, network, asset, total_value, matches, seen_at, detected_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tx_hash, network) DO NOTHING
+		RETURNING id
+	`,
+		interdiction.ID, interdiction.TxHash, interdiction.Network, interdiction.Asset,
+		interdiction.TotalValue, matches, interdiction.SeenAt, interdiction.DetectedAt,
+	).Scan(&interdiction.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetMempoolInterdictionAlert links an interdiction to the alert raised for it
+func (r *Repository) SetMempoolInterdictionAlert(ctx context.Context, id, alertID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE mempool_interdictions SET alert_id = $1 WHERE id = $2`, alertID, id)
+	return err
+}
+
+// SaveInterdictionNotification records the delivery of an interdiction to an exchange
+func (r *Repository) SaveInterdictionNotification(ctx context.Context, notification *models.InterdictionNotification) error {
+	var statusCode sql.NullInt64
+	if notification.StatusCode != 0 {
+		statusCode = sql.NullInt64{Int64: int64(notification.StatusCode), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO mempool_interdiction_notifications (
+			id, interdiction_id, exchange, status, status_code, error, latency_ms, sent_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		notification.ID, notification.InterdictionID, notification.Exchange, notification.Status,
+		statusCode, notification.Error, notification.LatencyMs, notification.SentAt,
+	)
+	return err
+}
+
+// GetMempoolInterdiction returns an interdiction with its notifications, or nil if there is none
+func (r *Repository) GetMempoolInterdiction(ctx context.Context, id string) (*models.MempoolInterdiction, error) {
+	query := `SELECT ` + mempoolInterdictionColumns + ` FROM mempool_interdictions WHERE id = $1`
+
+	interdiction, err := scanMempoolInterdiction(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, interdiction_id, exchange, status, COALESCE(status_code, 0), COALESCE(error, ''),
+			   latency_ms, sent_at
+		FROM mempool_interdiction_notifications
+		WHERE interdiction_id = $1
+		ORDER BY sent_at
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var notification models.InterdictionNotification
+		if err := rows.Scan(
+			&notification.ID, &notification.InterdictionID, &notification.Exchange, &notification.Status,
+			&notification.StatusCode, &notification.Error, &notification.LatencyMs, &notification.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		interdiction.Notifications = append(interdiction.Notifications, notification)
+	}
+
+	return interdiction, rows.Err()
+}
+
+// ListMempoolInterdictions returns interdictions matching the filter, newest first
+func (r *Repository) ListMempoolInterdictions(ctx context.Context, filter models.MempoolInterdictionFilter) ([]models.MempoolInterdiction, error) {
+	if filter.Limit <= 0 || filter.Limit > 1000 {
+		filter.Limit = 100
+	}
+
+	query := `SELECT ` + mempoolInterdictionColumns + ` FROM mempool_interdictions WHERE 1=1`
+	var args []interface{}
+
+	if filter.Network != "" {
+		args = append(args, filter.Network)
+		query += fmt.Sprintf(" AND network = $%d", len(args))
+	}
+	if filter.Address != "" {
+		match, err := json.Marshal([]map[string]string{{"address": filter.Address}})
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, match)
+		query += fmt.Sprintf(" AND matches @> $%d", len(args))
+	}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		query += fmt.Sprintf(" AND detected_at >= $%d", len(args))
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY detected_at DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var interdictions []models.MempoolInterdiction
+	for rows.Next() {
+		interdiction, err := scanMempoolInterdiction(rows)
+		if err != nil {
+			return nil, err
+		}
+		interdictions = append(interdictions, *interdiction)
+	}
+	return interdictions, rows.Err()
+}
+
+func scanMempoolInterdiction(row interface{ Scan(...interface{}) error }) (*models.MempoolInterdiction, error) {
+	var interdiction models.MempoolInterdiction
+	var matches []byte
+	if err := row.Scan(
+		&interdiction.ID, &interdiction.TxHash, &interdiction.Network, &interdiction.Asset,
+		&interdiction.TotalValue, &matches, &interdiction.AlertID, &interdiction.SeenAt,
+		&interdiction.DetectedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(matches) > 0 {
+		if err := json.Unmarshal(matches, &interdiction.Matches); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal matches: %w", err)
+		}
+	}
+	return &interdiction, nil
+}
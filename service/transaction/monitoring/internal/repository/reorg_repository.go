@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+// Chain reorganization operations
+
+// SaveChainBlock records a block as canonical at its height. A block seen
+// again after being orphaned, when the chain switches back, is restored.
+func (r *Repository) SaveChainBlock(ctx context.Context, block *models.ChainBlock) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO chain_blocks (network, number, hash, parent_hash, seen_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (network, hash) DO UPDATE SET orphaned_at = NULL
+	`, block.Network, block.Number, block.Hash, block.ParentHash, block.SeenAt)
+	return err
+}
+
+// GetCanonicalBlock returns the canonical block at a height, or nil if no
+// block has been seen there
+func (r *Repository) GetCanonicalBlock(ctx context.Context, network models.Network, number int64) (*models.ChainBlock, error) {
+	var block models.ChainBlock
+	err := r.db.QueryRowContext(ctx, `
+		SELECT network, number, hash, parent_hash, seen_at
+		FROM chain_blocks
+		WHERE network = $1 AND number = $2 AND orphaned_at IS NULL
+	`, network, number).Scan(&block.Network, &block.Number, &block.Hash, &block.ParentHash, &block.SeenAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// OrphanBlocksAbove marks the canonical blocks above a height as orphaned
+// and returns their hashes
+func (r *Repository) OrphanBlocksAbove(ctx context.Context, network models.Network, height int64, orphanedAt time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE chain_blocks SET orphaned_at = $3
+		WHERE network = $1 AND number > $2 AND orphaned_at IS NULL
+		RETURNING hash
+	`, network, height, orphanedAt)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// ReorgTransactions marks the transactions mined above a height as reorged
+// and returns their hashes. Transactions already reorged are not returned.
+func (r *Repository) ReorgTransactions(ctx context.Context, network models.Network, height int64, reorgedAt time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE transactions SET status = 'reorged', reorged_at = $3, updated_at = $3
+		WHERE network = $1 AND block_number > $2 AND status IN ('pending', 'confirmed')
+		RETURNING tx_hash
+	`, network, height, reorgedAt)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// ConfirmTransactions marks the pending transactions mined at or below a
+// height as confirmed and returns how many were confirmed
+func (r *Repository) ConfirmTransactions(ctx context.Context, network models.Network, height int64) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE transactions SET status = 'confirmed', updated_at = NOW()
+		WHERE network = $1 AND block_number <= $2 AND status = 'pending'
+	`, network, height)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// InvalidateTransactionResults withdraws the results derived from reorged
+// transactions, which must already be marked reorged. Open alerts that target
+// one of the transactions, or whose transaction evidence is all reorged, are
+// invalidated; other alerts citing one of them are flagged in their metadata.
+// Watch triggers lose their confirmation and bridge outflows their match.
+// It returns the IDs of the invalidated and flagged alerts.
+func (r *Repository) InvalidateTransactionResults(ctx context.Context, txHashes []string, reason string, reorgedAt time.Time) (invalidated, flagged []string, err error) {
+	tx, err := r.BeginTx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE alerts a SET status = 'invalidated',
+			metadata = COALESCE(a.metadata, '{}'::jsonb) || jsonb_build_object('invalidated_reason', $2::text),
+			updated_at = $3
+		WHERE a.status NOT IN ('resolved', 'dismissed', 'false_positive', 'invalidated')
+		AND (
+			(a.target_type = 'transaction' AND a.target_id = ANY($1))
+			OR (
+				EXISTS (
+					SELECT 1 FROM alert_evidence e
+					WHERE e.alert_id = a.id AND e.evidence_type = 'transaction' AND e.reference_id = ANY($1)
+				)
+				AND NOT EXISTS (
+					SELECT 1 FROM alert_evidence e
+					WHERE e.alert_id = a.id AND e.evidence_type = 'transaction'
+					AND NOT EXISTS (
+						SELECT 1 FROM transactions t
+						WHERE t.tx_hash = e.reference_id AND t.status = 'reorged'
+					)
+				)
+			)
+		)
+		RETURNING a.id
+	`, pq.Array(txHashes), reason, reorgedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	invalidated, err = scanIDs(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		UPDATE alerts a SET
+			metadata = COALESCE(a.metadata, '{}'::jsonb) || jsonb_build_object('reorged_evidence', $2::text),
+			updated_at = $3
+		WHERE a.status <> 'invalidated'
+		AND EXISTS (
+			SELECT 1 FROM alert_evidence e
+			WHERE e.alert_id = a.id AND e.evidence_type = 'transaction' AND e.reference_id = ANY($1)
+		)
+		RETURNING a.id
+	`, pq.Array(txHashes), reason, reorgedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	flagged, err = scanIDs(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE watch_triggers SET reorged_at = $2, confirmed_at = NULL
+		WHERE tx_hash = ANY($1)
+	`, pq.Array(txHashes), reorgedAt); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE bridge_outflows SET reorged_at = $2
+		WHERE tx_hash = ANY($1)
+	`, pq.Array(txHashes), reorgedAt); err != nil {
+		return nil, nil, err
+	}
+
+	// An outflow matched to a reorged destination leg is matched again once
+	// the funds arrive in a canonical block
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE bridge_outflows
+		SET destination_network = NULL, destination_address = NULL, destination_tx_hash = NULL
+		WHERE destination_tx_hash = ANY($1)
+	`, pq.Array(txHashes)); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return invalidated, flagged, nil
+}
+
+// SaveChainReorg records a detected reorganization
+func (r *Repository) SaveChainReorg(ctx context.Context, reorg *models.ChainReorg) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO chain_reorgs (
+			id, network, fork_height, depth, orphaned_blocks, reorged_txs,
+			invalidated_alerts, flagged_alerts, detected_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		reorg.ID, reorg.Network, reorg.ForkHeight, reorg.Depth, pq.Array(reorg.OrphanedBlocks),
+		pq.Array(reorg.ReorgedTxs), pq.Array(reorg.InvalidatedAlerts), pq.Array(reorg.FlaggedAlerts),
+		reorg.DetectedAt,
+	)
+	return err
+}
+
+// ListChainReorgs returns recent reorganizations, newest first. An empty
+// network lists all networks.
+func (r *Repository) ListChainReorgs(ctx context.Context, network models.Network, since time.Time, limit int) ([]models.ChainReorg, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, network, fork_height, depth, orphaned_blocks, reorged_txs,
+			invalidated_alerts, flagged_alerts, detected_at
+		FROM chain_reorgs
+		WHERE ($1 = '' OR network = $1) AND detected_at >= $2
+		ORDER BY detected_at DESC
+		LIMIT $3
+	`, network, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reorgs []models.ChainReorg
+	for rows.Next() {
+		var reorg models.ChainReorg
+		if err := rows.Scan(
+			&reorg.ID, &reorg.Network, &reorg.ForkHeight, &reorg.Depth,
+			pq.Array(&reorg.OrphanedBlocks), pq.Array(&reorg.ReorgedTxs),
+			pq.Array(&reorg.InvalidatedAlerts), pq.Array(&reorg.FlaggedAlerts),
+			&reorg.DetectedAt,
+		); err != nil {
+			return nil, err
+		}
+		reorgs = append(reorgs, reorg)
+	}
+	return reorgs, rows.Err()
+}
+
+// scanIDs reads a single string column, such as IDs or hashes, and closes
+// the rows
+func scanIDs(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
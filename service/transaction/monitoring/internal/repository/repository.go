@@ -8,6 +8,7 @@ import (
 
 	"github.com/csic/transaction-monitoring/internal/config"
 	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
@@ -72,17 +73,27 @@ func (r *Repository) BeginTx(ctx context.Context) (*Transaction, error) {
 	return &Transaction{Tx: tx}, nil
 }
 
-// SaveTransaction saves a normalized transaction to the database
+// SaveTransaction saves a normalized transaction to the database as pending
+// until its block is confirmed. A reorged transaction mined again in a new
+// block is restored with that block.
 func (r *Repository) SaveTransaction(ctx context.Context, tx *models.NormalizedTransaction) error {
 	query := `
 		INSERT INTO transactions (
-			tx_hash, network, block_number, block_hash, timestamp,
-			total_value, asset, fee, input_count, output_count, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		ON CONFLICT (tx_hash) DO NOTHING
+			id, tx_hash, network, block_number, block_hash, timestamp,
+			amount, asset, fee, input_count, output_count, status, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 'pending', $12)
+		ON CONFLICT (tx_hash) DO UPDATE SET
+			block_number = EXCLUDED.block_number,
+			block_hash = EXCLUDED.block_hash,
+			timestamp = EXCLUDED.timestamp,
+			status = 'pending',
+			reorged_at = NULL,
+			updated_at = EXCLUDED.created_at
+		WHERE transactions.status = 'reorged'
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
+		uuid.New().String(),
 		tx.TxHash,
 		tx.Network,
 		tx.BlockNumber,
@@ -681,3 +692,86 @@ func (r *Repository) GetTransactionsWithChangeOutputs(ctx context.Context, limit
 
 	return txs, rows.Err()
 }
+
+// GetTransactionsInWindow returns all transactions within a time window, oldest
+// first. Transfers of verified ERC-20 tokens are included as transactions in
+// the token's symbol, so that value thresholds apply to them too.
+func (r *Repository) GetTransactionsInWindow(ctx context.Context, start, end time.Time) ([]models.Transaction, error) {
+	query := `
+		SELECT id, tx_hash, network, block_number, block_hash, timestamp,
+			   sender, receiver, amount, asset, status, created_at
+		FROM transactions
+		WHERE timestamp >= $1 AND timestamp < $2 AND status <> 'reorged'
+		UNION ALL
+		SELECT tt.id, tt.tx_hash, tt.network, tt.block_number, tt.block_hash, tt.timestamp,
+			   tt.sender, tt.receiver, tt.amount, tk.symbol, t.status, tt.created_at
+		FROM token_transfers tt
+		JOIN tokens tk ON tk.network = tt.network AND tk.contract = tt.contract
+		JOIN transactions t ON t.tx_hash = tt.tx_hash
+		WHERE tt.timestamp >= $1 AND tt.timestamp < $2 AND t.status <> 'reorged'
+			AND tk.verified AND tt.standard = 'erc20'
+		ORDER BY timestamp ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txs []models.Transaction
+	for rows.Next() {
+		var tx models.Transaction
+		var sender, receiver sql.NullString
+		if err := rows.Scan(
+			&tx.ID, &tx.TxHash, &tx.Network, &tx.BlockNumber, &tx.BlockHash, &tx.Timestamp,
+			&sender, &receiver, &tx.Amount, &tx.Asset, &tx.Status, &tx.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tx.Sender = sender.String
+		tx.Receiver = receiver.String
+		txs = append(txs, tx)
+	}
+
+	return txs, rows.Err()
+}
+
+// GetWalletClusterIDs returns the cluster ID of each clustered address in the list
+func (r *Repository) GetWalletClusterIDs(ctx context.Context, addresses []string, network models.Network) (map[string]string, error) {
+	query := `
+		SELECT address, cluster_id FROM wallets
+		WHERE address = ANY($1) AND network = $2 AND cluster_id IS NOT NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(addresses), network)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clusters := make(map[string]string)
+	for rows.Next() {
+		var address, clusterID string
+		if err := rows.Scan(&address, &clusterID); err != nil {
+			return nil, err
+		}
+		clusters[address] = clusterID
+	}
+
+	return clusters, rows.Err()
+}
+
+// AddAlertEvidence attaches a piece of evidence to an alert
+func (r *Repository) AddAlertEvidence(ctx context.Context, evidence *models.AlertEvidence) error {
+	query := `
+		INSERT INTO alert_evidence (id, alert_id, evidence_type, reference_id, description, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		evidence.ID, evidence.AlertID, evidence.EvidenceType, evidence.ReferenceID,
+		evidence.Description, evidence.Data, evidence.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// riskModelColumns lists risk_models columns in scan order
+const riskModelColumns = `id, name, version, model_type, COALESCE(description, ''), features,
+	parameters, COALESCE(metrics, '{}'), status, trained_at, activated_at,
+	COALESCE(created_by, ''), created_at, updated_at`
+
+// Risk model registry operations
+
+// CreateRiskModel registers a new model in the staged state
+func (r *Repository) CreateRiskModel(ctx context.Context, model *models.RiskModel) error {
+	parameters, err := json.Marshal(model.Parameters)
+	if err != nil {
+		return fmt.Errorf("failed to marshal model parameters: %w", err)
+	}
+	metrics, err := json.Marshal(model.Metrics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal model metrics: %w", err)
+	}
+
+	query := `
+		INSERT INTO risk_models (
+			id, name, version, model_type, description, features, parameters, metrics,
+			status, trained_at, created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		model.ID, model.Name, model.Version, model.ModelType, model.Description,
+		pq.Array(model.Features), parameters, metrics, model.Status, model.TrainedAt,
+		model.CreatedBy, model.CreatedAt, model.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetRiskModel retrieves a registered model by ID
+func (r *Repository) GetRiskModel(ctx context.Context, id string) (*models.RiskModel, error) {
+	query := `SELECT ` + riskModelColumns + ` FROM risk_models WHERE id = $1`
+
+	model, err := scanRiskModel(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return model, err
+}
+
+// GetActiveRiskModel retrieves the currently active model, or nil if none is active
+func (r *Repository) GetActiveRiskModel(ctx context.Context) (*models.RiskModel, error) {
+	query := `SELECT ` + riskModelColumns + ` FROM risk_models WHERE status = $1`
+
+	model, err := scanRiskModel(r.db.QueryRowContext(ctx, query, models.RiskModelStatusActive))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return model, err
+}
+
+// ListRiskModels returns all registered models, newest first
+func (r *Repository) ListRiskModels(ctx context.Context) ([]models.RiskModel, error) {
+	query := `SELECT ` + riskModelColumns + ` FROM risk_models ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var riskModels []models.RiskModel
+	for rows.Next() {
+		model, err := scanRiskModel(rows)
+		if err != nil {
+			return nil, err
+		}
+		riskModels = append(riskModels, *model)
+	}
+
+	return riskModels, rows.Err()
+}
+
+// ActivateRiskModel retires the currently active model and activates the given one
+func (r *Repository) ActivateRiskModel(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE risk_models SET status = $1, updated_at = $2 WHERE status = $3`,
+		models.RiskModelStatusRetired, now, models.RiskModelStatusActive,
+	); err != nil {
+		return fmt.Errorf("failed to retire active model: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE risk_models SET status = $1, activated_at = $2, updated_at = $2 WHERE id = $3`,
+		models.RiskModelStatusActive, now, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to activate model: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+func scanRiskModel(row interface{ Scan(...interface{}) error }) (*models.RiskModel, error) {
+	var model models.RiskModel
+	var features []string
+	var parameters, metrics []byte
+	var trainedAt, activatedAt sql.NullTime
+
+	if err := row.Scan(
+		&model.ID, &model.Name, &model.Version, &model.ModelType, &model.Description,
+		pq.Array(&features), &parameters, &metrics, &model.Status, &trainedAt, &activatedAt,
+		&model.CreatedBy, &model.CreatedAt, &model.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	model.Features = features
+	if err := json.Unmarshal(parameters, &model.Parameters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal model parameters: %w", err)
+	}
+	if err := json.Unmarshal(metrics, &model.Metrics); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal model metrics: %w", err)
+	}
+	if trainedAt.Valid {
+		model.TrainedAt = &trainedAt.Time
+	}
+	if activatedAt.Valid {
+		model.ActivatedAt = &activatedAt.Time
+	}
+
+	return &model, nil
+}
+
+// Scoring operations
+
+// ListWalletsForRescoring returns a page of wallets ordered by ID, starting after afterID
+func (r *Repository) ListWalletsForRescoring(ctx context.Context, afterID string, limit int) ([]models.Wallet, error) {
+	query := `
+		SELECT id, address, network, wallet_type, COALESCE(label, ''), first_seen, last_seen,
+			   tx_count, total_received, total_sent, current_balance,
+			   risk_score, risk_level, is_sanctioned, is_blacklisted, is_whitelisted,
+			   cluster_id, created_at, updated_at
+		FROM wallets WHERE id > $1 ORDER BY id LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets []models.Wallet
+	for rows.Next() {
+		var wallet models.Wallet
+		var clusterID sql.NullString
+		if err := rows.Scan(
+			&wallet.ID, &wallet.Address, &wallet.Network, &wallet.WalletType, &wallet.Label,
+			&wallet.FirstSeen, &wallet.LastSeen, &wallet.TxCount,
+			&wallet.TotalReceived, &wallet.TotalSent, &wallet.CurrentBalance,
+			&wallet.RiskScore, &wallet.RiskLevel, &wallet.IsSanctioned,
+			&wallet.IsBlacklisted, &wallet.IsWhitelisted, &clusterID,
+			&wallet.CreatedAt, &wallet.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if clusterID.Valid {
+			wallet.ClusterID = &clusterID.String
+		}
+		wallets = append(wallets, wallet)
+	}
+
+	return wallets, rows.Err()
+}
+
+// CountWallets returns the number of known wallets
+func (r *Repository) CountWallets(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM wallets`).Scan(&count)
+	return count, err
+}
+
+// SaveRiskScore records a scored wallet in the risk score history
+func (r *Repository) SaveRiskScore(ctx context.Context, explanation *models.RiskScoreExplanation) error {
+	features, err := json.Marshal(explanation.Features)
+	if err != nil {
+		return fmt.Errorf("failed to marshal features: %w", err)
+	}
+	topFeatures, err := json.Marshal(explanation.TopFeatures)
+	if err != nil {
+		return fmt.Errorf("failed to marshal explanation: %w", err)
+	}
+
+	triggered := make([]string, 0, len(explanation.TopFeatures))
+	for _, contribution := range explanation.TopFeatures {
+		if contribution.Contribution > 0 {
+			triggered = append(triggered, contribution.Feature)
+		}
+	}
+
+	var modelID sql.NullString
+	if explanation.ModelID != "" {
+		modelID.String = explanation.ModelID
+		modelID.Valid = true
+	}
+
+	query := `
+		INSERT INTO risk_score_history (
+			id, wallet_id, score, factors, triggered_rules, calculated_at,
+			model_id, model_version, explanation
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (wallet_id, calculated_at) DO NOTHING
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		uuid.New().String(), explanation.WalletID, explanation.Score, features,
+		pq.Array(triggered), explanation.ScoredAt, modelID, explanation.ModelVersion, topFeatures,
+	)
+
+	return err
+}
+
+// Re-scoring job operations
+
+// CreateRescoringJob records the start of a batch re-scoring job
+func (r *Repository) CreateRescoringJob(ctx context.Context, job *models.RescoringJob) error {
+	query := `
+		INSERT INTO risk_rescoring_jobs (id, model_id, trigger, status, total, scored, failed, started_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	var modelID sql.NullString
+	if job.ModelID != "" {
+		modelID.String = job.ModelID
+		modelID.Valid = true
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		job.ID, modelID, job.Trigger, job.Status, job.Total, job.Scored, job.Failed, job.StartedAt,
+	)
+
+	return err
+}
+
+// UpdateRescoringJob stores the progress and outcome of a re-scoring job
+func (r *Repository) UpdateRescoringJob(ctx context.Context, job *models.RescoringJob) error {
+	query := `
+		UPDATE risk_rescoring_jobs SET
+			status = $1, total = $2, scored = $3, failed = $4, error = NULLIF($5, ''), completed_at = $6
+		WHERE id = $7
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		job.Status, job.Total, job.Scored, job.Failed, job.Error, job.CompletedAt, job.ID,
+	)
+
+	return err
+}
+
+// GetRescoringJob retrieves a re-scoring job by ID
+func (r *Repository) GetRescoringJob(ctx context.Context, id string) (*models.RescoringJob, error) {
+	query := `
+		SELECT id, COALESCE(model_id, ''), trigger, status, total, scored, failed,
+			   COALESCE(error, ''), started_at, completed_at
+		FROM risk_rescoring_jobs WHERE id = $1
+	`
+
+	var job models.RescoringJob
+	var completedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&job.ID, &job.ModelID, &job.Trigger, &job.Status, &job.Total, &job.Scored,
+		&job.Failed, &job.Error, &job.StartedAt, &completedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+)
+
+// Token registry and token transfer operations
+
+// SaveToken registers a token or replaces its registry entry
+func (r *Repository) SaveToken(ctx context.Context, token *models.Token) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO tokens (network, contract, standard, symbol, name, decimals, verified, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $8)
+		ON CONFLICT (network, contract) DO UPDATE SET
+			standard = EXCLUDED.standard,
+			symbol = EXCLUDED.symbol,
+			name = EXCLUDED.name,
+			decimals = EXCLUDED.decimals,
+			verified = EXCLUDED.verified,
+			updated_at = EXCLUDED.updated_at
+	`,
+		token.Network, strings.ToLower(token.Contract), token.Standard, token.Symbol, token.Name,
+		token.Decimals, token.Verified, time.Now(),
+	)
+	return err
+}
+
+// CreateToken registers a token unless the contract is already registered,
+// and reports whether it was created
+func (r *Repository) CreateToken(ctx context.Context, token *models.Token) (bool, error) {
+	var contract string
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO tokens (network, contract, standard, symbol, name, decimals, verified, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $8)
+		ON CONFLICT (network, contract) DO NOTHING
+		RETURNING contract
+	`,
+		token.Network, strings.ToLower(token.Contract), token.Standard, token.Symbol, token.Name,
+		token.Decimals, token.Verified, time.Now(),
+	).Scan(&contract)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetToken returns the registry entry of a token contract, or nil if the
+// contract is not registered
+func (r *Repository) GetToken(ctx context.Context, network models.Network, contract string) (*models.Token, error) {
+	query := `SELECT ` + tokenColumns + ` FROM tokens WHERE network = $1 AND contract = $2`
+
+	var token models.Token
+	err := r.db.QueryRowContext(ctx, query, network, strings.ToLower(contract)).Scan(
+		&token.Network, &token.Contract, &token.Standard, &token.Symbol, &token.Name,
+		&token.Decimals, &token.Verified, &token.CreatedAt, &token.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ListTokens returns registered tokens by symbol, optionally only verified ones
+func (r *Repository) ListTokens(ctx context.Context, network models.Network, verifiedOnly bool, limit int) ([]models.Token, error) {
+	query := `SELECT ` + tokenColumns + ` FROM tokens
+		WHERE ($1 = '' OR network = $1) AND ($2 = FALSE OR verified)
+		ORDER BY symbol, network
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, network, verifiedOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTokens(rows)
+}
+
+// SaveTokenTransfers records the token transfers of a transaction. A
+// transfer saved again, when its transaction is mined in a new block after a
+// reorganization, moves to that block.
+func (r *Repository) SaveTokenTransfers(ctx context.Context, transfers []models.TokenTransfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	tx, err := r.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, transfer := range transfers {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO token_transfers (
+				id, network, tx_hash, log_index, block_number, block_hash, timestamp,
+				contract, standard, sender, receiver, amount, raw_amount, token_id, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, '')::NUMERIC, NULLIF($14, '')::NUMERIC, $15)
+			ON CONFLICT (network, tx_hash, log_index) DO UPDATE SET
+				block_number = EXCLUDED.block_number,
+				block_hash = EXCLUDED.block_hash,
+				timestamp = EXCLUDED.timestamp
+		`,
+			transfer.ID, transfer.Network, transfer.TxHash, transfer.LogIndex, transfer.BlockNumber,
+			transfer.BlockHash, transfer.Timestamp, strings.ToLower(transfer.Contract), transfer.Standard,
+			transfer.Sender, transfer.Receiver, transfer.Amount, transfer.RawAmount, transfer.TokenID,
+			transfer.CreatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListTokenTransfers returns token transfers matching the filter, newest
+// first. Transfers of reorged transactions are excluded.
+func (r *Repository) ListTokenTransfers(ctx context.Context, filter models.TokenTransferFilter) ([]models.TokenTransfer, error) {
+	conditions := []string{`NOT EXISTS (
+		SELECT 1 FROM transactions t WHERE t.tx_hash = tt.tx_hash AND t.status = 'reorged'
+	)`}
+	args := []interface{}{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Network != "" {
+		add("tt.network = $%d", filter.Network)
+	}
+	if filter.Address != "" {
+		add("(lower(tt.sender) = $%[1]d OR lower(tt.receiver) = $%[1]d)", strings.ToLower(filter.Address))
+	}
+	if filter.Contract != "" {
+		add("tt.contract = $%d", strings.ToLower(filter.Contract))
+	}
+	if filter.Symbol != "" {
+		add("upper(tk.symbol) = $%d", strings.ToUpper(filter.Symbol))
+	}
+	if filter.TxHash != "" {
+		add("tt.tx_hash = $%d", filter.TxHash)
+	}
+	if filter.VerifiedOnly {
+		conditions = append(conditions, "tk.verified")
+	}
+	if filter.Since != nil {
+		add("tt.timestamp >= $%d", *filter.Since)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT tt.id, tt.network, tt.tx_hash, tt.log_index, tt.block_number, tt.block_hash, tt.timestamp,
+			tt.contract, tt.standard, COALESCE(tk.symbol, ''), COALESCE(tk.verified, FALSE),
+			tt.sender, tt.receiver, tt.amount, COALESCE(tt.raw_amount::TEXT, ''),
+			COALESCE(tt.token_id::TEXT, ''), tt.created_at
+		FROM token_transfers tt
+		LEFT JOIN tokens tk ON tk.network = tt.network AND tk.contract = tt.contract
+		WHERE %s ORDER BY tt.timestamp DESC, tt.log_index DESC LIMIT $%d`,
+		strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []models.TokenTransfer
+	for rows.Next() {
+		var transfer models.TokenTransfer
+		if err := rows.Scan(
+			&transfer.ID, &transfer.Network, &transfer.TxHash, &transfer.LogIndex, &transfer.BlockNumber,
+			&transfer.BlockHash, &transfer.Timestamp, &transfer.Contract, &transfer.Standard,
+			&transfer.Symbol, &transfer.Verified, &transfer.Sender, &transfer.Receiver, &transfer.Amount,
+			&transfer.RawAmount, &transfer.TokenID, &transfer.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, rows.Err()
+}
+
+// tokenColumns lists tokens columns in scan order
+const tokenColumns = `network, contract, standard, symbol, COALESCE(name, ''), decimals, verified, created_at, updated_at`
+
+func scanTokens(rows *sql.Rows) ([]models.Token, error) {
+	var tokens []models.Token
+	for rows.Next() {
+		var token models.Token
+		if err := rows.Scan(
+			&token.Network, &token.Contract, &token.Standard, &token.Symbol, &token.Name,
+			&token.Decimals, &token.Verified, &token.CreatedAt, &token.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
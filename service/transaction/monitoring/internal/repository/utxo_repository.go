@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/shopspring/decimal"
+)
+
+// UTXO set operations
+
+// SaveUTXOs records the outputs of a transaction on a UTXO network and marks
+// the outputs its inputs spend. Inputs without a previous outpoint are
+// skipped. An output spent before its creating transaction is saved, such as
+// one created before ingestion started, is recorded from the input.
+func (r *Repository) SaveUTXOs(ctx context.Context, tx *models.NormalizedTransaction) error {
+	dbTx, err := r.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	for i, output := range tx.Outputs {
+		if _, err := dbTx.ExecContext(ctx, `
+			INSERT INTO utxos (network, tx_hash, output_index, address, amount, block_number, created_at)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7)
+			ON CONFLICT (network, tx_hash, output_index) DO UPDATE SET
+				address = EXCLUDED.address,
+				amount = EXCLUDED.amount,
+				block_number = EXCLUDED.block_number,
+				created_at = EXCLUDED.created_at
+		`, tx.Network, tx.TxHash, i, output.Address, output.Amount, tx.BlockNumber, tx.Timestamp); err != nil {
+			return err
+		}
+	}
+
+	for i, input := range tx.Inputs {
+		if input.PrevTxHash == "" {
+			continue
+		}
+		if _, err := dbTx.ExecContext(ctx, `
+			INSERT INTO utxos (
+				network, tx_hash, output_index, address, amount,
+				spent_tx_hash, spent_input_index, spent_block_number, spent_at
+			) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+			ON CONFLICT (network, tx_hash, output_index) DO UPDATE SET
+				spent_tx_hash = EXCLUDED.spent_tx_hash,
+				spent_input_index = EXCLUDED.spent_input_index,
+				spent_block_number = EXCLUDED.spent_block_number,
+				spent_at = EXCLUDED.spent_at
+		`,
+			tx.Network, input.PrevTxHash, input.PrevIndex, input.Address, input.Amount,
+			tx.TxHash, i, tx.BlockNumber, tx.Timestamp,
+		); err != nil {
+			return err
+		}
+	}
+
+	return dbTx.Commit()
+}
+
+// RevertUTXOsAbove undoes SaveUTXOs for the transactions mined above a
+// height: their outputs are removed and the outputs they spent are unspent
+// again, except outputs known only from such a spend, which are removed too
+func (r *Repository) RevertUTXOsAbove(ctx context.Context, network models.Network, height int64) error {
+	dbTx, err := r.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	if _, err := dbTx.ExecContext(ctx, `
+		DELETE FROM utxos
+		WHERE network = $1 AND spent_block_number > $2 AND created_at IS NULL
+	`, network, height); err != nil {
+		return err
+	}
+
+	if _, err := dbTx.ExecContext(ctx, `
+		UPDATE utxos
+		SET spent_tx_hash = NULL, spent_input_index = NULL, spent_block_number = NULL, spent_at = NULL
+		WHERE network = $1 AND spent_block_number > $2
+	`, network, height); err != nil {
+		return err
+	}
+
+	if _, err := dbTx.ExecContext(ctx, `
+		DELETE FROM utxos WHERE network = $1 AND block_number > $2
+	`, network, height); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
+
+// ListUTXOs returns the outputs received by an address, newest first,
+// optionally only those unspent
+func (r *Repository) ListUTXOs(ctx context.Context, network models.Network, address string, unspentOnly bool, limit int) ([]models.UTXO, error) {
+	query := `SELECT ` + utxoColumns + ` FROM utxos
+		WHERE network = $1 AND address = $2 AND ($3 = FALSE OR spent_tx_hash IS NULL)
+		ORDER BY block_number DESC NULLS LAST, tx_hash, output_index
+		LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, network, address, unspentOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUTXOs(rows)
+}
+
+// GetUTXOBalance returns the sum of the unspent outputs of an address
+func (r *Repository) GetUTXOBalance(ctx context.Context, network models.Network, address string) (decimal.Decimal, error) {
+	var balance decimal.Decimal
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(amount), 0) FROM utxos
+		WHERE network = $1 AND address = $2 AND spent_tx_hash IS NULL
+	`, network, address).Scan(&balance)
+	return balance, err
+}
+
+// GetTransactionOutputs returns the recorded outputs of a transaction in
+// output order
+func (r *Repository) GetTransactionOutputs(ctx context.Context, network models.Network, txHash string) ([]models.UTXO, error) {
+	query := `SELECT ` + utxoColumns + ` FROM utxos
+		WHERE network = $1 AND tx_hash = $2
+		ORDER BY output_index`
+
+	rows, err := r.db.QueryContext(ctx, query, network, txHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUTXOs(rows)
+}
+
+// GetTransactionInputs returns the outputs spent by a transaction in input order
+func (r *Repository) GetTransactionInputs(ctx context.Context, network models.Network, txHash string) ([]models.UTXO, error) {
+	query := `SELECT ` + utxoColumns + ` FROM utxos
+		WHERE network = $1 AND spent_tx_hash = $2
+		ORDER BY spent_input_index`
+
+	rows, err := r.db.QueryContext(ctx, query, network, txHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUTXOs(rows)
+}
+
+// utxoColumns lists utxos columns in scan order
+const utxoColumns = `network, tx_hash, output_index, address, amount, block_number, created_at,
+	spent_tx_hash, spent_input_index, spent_block_number, spent_at`
+
+func scanUTXOs(rows *sql.Rows) ([]models.UTXO, error) {
+	var utxos []models.UTXO
+	for rows.Next() {
+		var utxo models.UTXO
+		var address, spentTxHash sql.NullString
+		var blockNumber, spentInputIndex, spentBlockNumber sql.NullInt64
+		var createdAt, spentAt sql.NullTime
+		if err := rows.Scan(
+			&utxo.Network, &utxo.TxHash, &utxo.OutputIndex, &address, &utxo.Amount,
+			&blockNumber, &createdAt, &spentTxHash, &spentInputIndex, &spentBlockNumber, &spentAt,
+		); err != nil {
+			return nil, err
+		}
+		utxo.Address = address.String
+		utxo.BlockNumber = blockNumber.Int64
+		utxo.SpentTxHash = spentTxHash.String
+		utxo.SpentInputIndex = int(spentInputIndex.Int64)
+		utxo.SpentBlockNumber = spentBlockNumber.Int64
+		if createdAt.Valid {
+			utxo.CreatedAt = &createdAt.Time
+		}
+		if spentAt.Valid {
+			utxo.SpentAt = &spentAt.Time
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos, rows.Err()
+}
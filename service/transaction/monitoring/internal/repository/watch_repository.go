@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+// Address watch operations
+
+const addressWatchColumns = `id, address, network, direction, COALESCE(label, ''), COALESCE(reason, ''),
+	COALESCE(case_id, ''), COALESCE(webhook_url, ''), created_by, expires_at, trigger_count,
+	last_triggered_at, created_at`
+
+const watchTriggerColumns = `id, watch_id, address, network, tx_hash, direction, amount,
+	COALESCE(asset, ''), counterparties, stage, COALESCE(block_number, 0), COALESCE(alert_id, ''),
+	COALESCE(webhook_status, ''), detected_at, confirmed_at`
+
+// CreateAddressWatch stores a new address watch
+func (r *Repository) CreateAddressWatch(ctx context.Context, watch *models.AddressWatch) error {
+	query := `
+		INSERT INTO address_watches (
+			id, address, network, direction, label, reason, case_id, webhook_url,
+			created_by, expires_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		watch.ID, watch.Address, watch.Network, watch.Direction, watch.Label, watch.Reason,
+		watch.CaseID, watch.WebhookURL, watch.CreatedBy, watch.ExpiresAt, watch.CreatedAt,
+	)
+	return err
+}
+
+// GetAddressWatch returns an address watch that has not been removed, or nil if there is none
+func (r *Repository) GetAddressWatch(ctx context.Context, id string) (*models.AddressWatch, error) {
+	query := `SELECT ` + addressWatchColumns + ` FROM address_watches WHERE id = $1 AND removed_at IS NULL`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	watches, err := scanAddressWatches(rows)
+	if err != nil || len(watches) == 0 {
+		return nil, err
+	}
+	return &watches[0], nil
+}
+
+// ListAddressWatches returns the address watches that have not been removed,
+// newest first, optionally restricted to a network
+func (r *Repository) ListAddressWatches(ctx context.Context, network models.Network, limit int) ([]models.AddressWatch, error) {
+	query := `SELECT ` + addressWatchColumns + ` FROM address_watches
+		WHERE removed_at IS NULL AND ($1 = '' OR network = $1)
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, string(network), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAddressWatches(rows)
+}
+
+// ListActiveAddressWatches returns every address watch that is neither removed nor expired
+func (r *Repository) ListActiveAddressWatches(ctx context.Context) ([]models.AddressWatch, error) {
+	query := `SELECT ` + addressWatchColumns + ` FROM address_watches
+		WHERE removed_at IS NULL AND (expires_at IS NULL OR expires_at > $1)`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAddressWatches(rows)
+}
+
+// RemoveAddressWatch marks an address watch as removed and reports whether it existed
+func (r *Repository) RemoveAddressWatch(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE address_watches SET removed_at = $1 WHERE id = $2 AND removed_at IS NULL`, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// SaveWatchTrigger records a watch trigger and reports whether it was new. A
+// trigger already recorded for the same watch, transaction and direction is left
+// unchanged. New triggers update the trigger count of the watch.
+func (r *Repository) SaveWatchTrigger(ctx context.Context, trigger *models.WatchTrigger) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO watch_triggers (
+			id, watch_id, address, network, tx_hash, direction, amount, asset,
+			counterparties, stage, block_number, detected_at, confirmed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (watch_id, tx_hash, direction) DO NOTHING
+		RETURNING id
+	`
+
+	var blockNumber sql.NullInt64
+	if trigger.BlockNumber > 0 {
+		blockNumber = sql.NullInt64{Int64: trigger.BlockNumber, Valid: true}
+	}
+
+	err = tx.QueryRowContext(ctx, query,
+		trigger.ID, trigger.WatchID, trigger.Address, trigger.Network, trigger.TxHash,
+		trigger.Direction, trigger.Amount, trigger.Asset, pq.Array(trigger.Counterparties),
+		trigger.Stage, blockNumber, trigger.DetectedAt, trigger.ConfirmedAt,
+	).Scan(&trigger.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE address_watches
+		SET trigger_count = trigger_count + 1, last_triggered_at = $1
+		WHERE id = $2
+	`, trigger.DetectedAt, trigger.WatchID); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// ConfirmWatchTrigger marks the trigger of a transaction first seen in the
+// mempool as included in a block
+func (r *Repository) ConfirmWatchTrigger(ctx context.Context, watchID, txHash string, direction models.WatchDirection, blockNumber int64, confirmedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE watch_triggers
+		SET confirmed_at = $1, block_number = $2, reorged_at = NULL
+		WHERE watch_id = $3 AND tx_hash = $4 AND direction = $5 AND confirmed_at IS NULL
+	`, confirmedAt, blockNumber, watchID, txHash, direction)
+	return err
+}
+
+// SetWatchTriggerAlert links a watch trigger to the alert raised for it
+func (r *Repository) SetWatchTriggerAlert(ctx context.Context, id, alertID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE watch_triggers SET alert_id = $1 WHERE id = $2`, alertID, id)
+	return err
+}
+
+// SetWatchTriggerWebhookStatus records the webhook delivery state of a watch trigger
+func (r *Repository) SetWatchTriggerWebhookStatus(ctx context.Context, id, status string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE watch_triggers SET webhook_status = $1 WHERE id = $2`, status, id)
+	return err
+}
+
+// ListWatchTriggers returns the triggers of an address watch, newest first
+func (r *Repository) ListWatchTriggers(ctx context.Context, watchID string, limit int) ([]models.WatchTrigger, error) {
+	query := `SELECT ` + watchTriggerColumns + ` FROM watch_triggers
+		WHERE watch_id = $1
+		ORDER BY detected_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, watchID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []models.WatchTrigger
+	for rows.Next() {
+		var trigger models.WatchTrigger
+		var confirmedAt sql.NullTime
+		if err := rows.Scan(
+			&trigger.ID, &trigger.WatchID, &trigger.Address, &trigger.Network, &trigger.TxHash,
+			&trigger.Direction, &trigger.Amount, &trigger.Asset, pq.Array(&trigger.Counterparties),
+			&trigger.Stage, &trigger.BlockNumber, &trigger.AlertID, &trigger.WebhookStatus,
+			&trigger.DetectedAt, &confirmedAt,
+		); err != nil {
+			return nil, err
+		}
+		if confirmedAt.Valid {
+			trigger.ConfirmedAt = &confirmedAt.Time
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, rows.Err()
+}
+
+func scanAddressWatches(rows *sql.Rows) ([]models.AddressWatch, error) {
+	var watches []models.AddressWatch
+	for rows.Next() {
+		var watch models.AddressWatch
+		var expiresAt, lastTriggeredAt sql.NullTime
+		if err := rows.Scan(
+			&watch.ID, &watch.Address, &watch.Network, &watch.Direction, &watch.Label, &watch.Reason,
+			&watch.CaseID, &watch.WebhookURL, &watch.CreatedBy, &expiresAt, &watch.TriggerCount,
+			&lastTriggeredAt, &watch.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if expiresAt.Valid {
+			watch.ExpiresAt = &expiresAt.Time
+		}
+		if lastTriggeredAt.Valid {
+			watch.LastTriggeredAt = &lastTriggeredAt.Time
+		}
+		watches = append(watches, watch)
+	}
+
+	return watches, rows.Err()
+}
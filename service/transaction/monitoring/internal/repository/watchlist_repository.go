@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+)
+
+// Watchlist operations
+
+// AddWatchlistEntry adds an address to a watchlist, refreshing the entry if it already exists
+func (r *Repository) AddWatchlistEntry(ctx context.Context, entry *models.WatchlistEntry) error {
+	query := `
+		INSERT INTO address_watchlist (id, address, network, list_name, reason, added_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (address, network, list_name) DO UPDATE SET
+			reason = EXCLUDED.reason, added_by = EXCLUDED.added_by, expires_at = EXCLUDED.expires_at
+		RETURNING id, created_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		entry.ID, entry.Address, entry.Network, entry.ListName, entry.Reason,
+		entry.AddedBy, entry.ExpiresAt, entry.CreatedAt,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+// RemoveWatchlistEntries removes an address from all watchlists and reports how many entries were removed
+func (r *Repository) RemoveWatchlistEntries(ctx context.Context, address string, network models.Network) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM address_watchlist WHERE address = $1 AND network = $2`, address, network)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetWatchlistEntries returns the unexpired watchlist entries for an address
+func (r *Repository) GetWatchlistEntries(ctx context.Context, address string, network models.Network) ([]models.WatchlistEntry, error) {
+	query := `
+		SELECT id, address, network, list_name, COALESCE(reason, ''), COALESCE(added_by, ''),
+			   expires_at, created_at
+		FROM address_watchlist
+		WHERE address = $1 AND network = $2 AND (expires_at IS NULL OR expires_at > $3)
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, address, network, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.WatchlistEntry
+	for rows.Next() {
+		var entry models.WatchlistEntry
+		var expiresAt sql.NullTime
+		if err := rows.Scan(
+			&entry.ID, &entry.Address, &entry.Network, &entry.ListName, &entry.Reason,
+			&entry.AddedBy, &expiresAt, &entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if expiresAt.Valid {
+			entry.ExpiresAt = &expiresAt.Time
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// ListActiveWatchlistEntries returns every unexpired watchlist entry
+func (r *Repository) ListActiveWatchlistEntries(ctx context.Context) ([]models.WatchlistEntry, error) {
+	query := `
+		SELECT id, address, network, list_name, COALESCE(reason, ''), COALESCE(added_by, ''),
+			   expires_at, created_at
+		FROM address_watchlist
+		WHERE expires_at IS NULL OR expires_at > $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.WatchlistEntry
+	for rows.Next() {
+		var entry models.WatchlistEntry
+		var expiresAt sql.NullTime
+		if err := rows.Scan(
+			&entry.ID, &entry.Address, &entry.Network, &entry.ListName, &entry.Reason,
+			&entry.AddedBy, &expiresAt, &entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if expiresAt.Valid {
+			entry.ExpiresAt = &expiresAt.Time
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
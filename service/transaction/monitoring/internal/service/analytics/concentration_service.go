@@ -0,0 +1,368 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// ConcentrationService computes token-holder concentration per asset from
+// ingested transactions and alerts when it changes rapidly
+type ConcentrationService struct {
+	cfg       *config.Config
+	repo      *repository.Repository
+	logger    *zap.Logger
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	isRunning bool
+
+	// alerted suppresses repeat alerts for the same asset within the change window
+	alerted map[string]time.Time
+}
+
+// NewConcentrationService creates a new concentration analytics service
+func NewConcentrationService(
+	cfg *config.Config,
+	repo *repository.Repository,
+	logger *zap.Logger,
+) *ConcentrationService {
+	return &ConcentrationService{
+		cfg:      cfg,
+		repo:     repo,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+		alerted:  make(map[string]time.Time),
+	}
+}
+
+// Start begins periodic concentration analysis
+func (s *ConcentrationService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	s.logger.Info("Starting concentration analytics service")
+
+	if s.cfg.Concentration.Enabled {
+		s.wg.Add(1)
+		go s.analysisLoop(ctx)
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the analytics service
+func (s *ConcentrationService) Stop() {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = false
+	s.mu.Unlock()
+
+	s.logger.Info("Stopping concentration analytics service")
+	close(s.stopChan)
+	s.wg.Wait()
+}
+
+// analysisLoop runs periodic analysis
+func (s *ConcentrationService) analysisLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(parseDuration(s.cfg.Concentration.Interval, time.Hour))
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunAnalysis(ctx); err != nil {
+			s.logger.Error("Concentration analysis failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunAnalysis snapshots the holder distribution of every tracked asset and
+// raises an alert for each asset whose concentration moved past the threshold
+func (s *ConcentrationService) RunAnalysis(ctx context.Context) error {
+	cfg := s.cfg.Concentration
+	window := parseDuration(cfg.ChangeWindow, 24*time.Hour)
+	now := time.Now()
+
+	assets, err := s.repo.GetTrackedAssets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load tracked assets: %w", err)
+	}
+
+	for _, asset := range assets {
+		balances, err := s.repo.GetHolderBalances(ctx, asset.Network, asset.Asset)
+		if err != nil {
+			s.logger.Warn("Failed to load holder balances",
+				zap.String("network", string(asset.Network)),
+				zap.String("asset", asset.Asset),
+				zap.Error(err))
+			continue
+		}
+		if len(balances) == 0 || len(balances) < cfg.MinHolders {
+			continue
+		}
+
+		snapshot := ComputeConcentration(asset.Network, asset.Asset, balances, cfg.TopN)
+		snapshot.ID = uuid.New().String()
+		snapshot.ComputedAt = now
+
+		baseline, err := s.repo.GetConcentrationSnapshotBefore(ctx, asset.Network, asset.Asset, now.Add(-window))
+		if err != nil {
+			s.logger.Warn("Failed to load concentration baseline",
+				zap.String("asset", asset.Asset),
+				zap.Error(err))
+		}
+
+		if err := s.repo.SaveConcentrationSnapshot(ctx, snapshot); err != nil {
+			s.logger.Error("Failed to save concentration snapshot",
+				zap.String("network", string(asset.Network)),
+				zap.String("asset", asset.Asset),
+				zap.Error(err))
+			continue
+		}
+
+		change := DetectConcentrationChange(cfg, baseline, snapshot)
+		if change == nil || !s.shouldAlert(change.Key(), now, window) {
+			continue
+		}
+		if err := s.raiseAlert(ctx, change); err != nil {
+			s.logger.Error("Failed to raise concentration alert",
+				zap.String("asset", asset.Asset),
+				zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// GetConcentrationReport returns an asset's latest snapshot and its history since the given time
+func (s *ConcentrationService) GetConcentrationReport(ctx context.Context, network models.Network, asset string, since time.Time, limit int) (*models.ConcentrationReport, error) {
+	history, err := s.repo.GetConcentrationHistory(ctx, network, asset, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ConcentrationReport{History: history}
+	if len(history) > 0 {
+		report.Latest = &history[0]
+	} else {
+		report.History = []models.AssetConcentration{}
+	}
+	return report, nil
+}
+
+// shouldAlert records the key and reports whether it has not been alerted within the window
+func (s *ConcentrationService) shouldAlert(key string, now time.Time, window time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, at := range s.alerted {
+		if now.Sub(at) > window {
+			delete(s.alerted, k)
+		}
+	}
+
+	if _, ok := s.alerted[key]; ok {
+		return false
+	}
+	s.alerted[key] = now
+	return true
+}
+
+// ConcentrationChange is a rapid shift in an asset's holder concentration
+type ConcentrationChange struct {
+	Baseline *models.AssetConcentration
+	Current  *models.AssetConcentration
+	Severity models.AlertSeverity
+	Reasons  []string
+}
+
+// Key identifies the change for de-duplication across analysis runs
+func (c *ConcentrationChange) Key() string {
+	return string(c.Current.Network) + ":" + c.Current.Asset
+}
+
+// DetectConcentrationChange compares a snapshot with the baseline from the start
+// of the change window. It reports a change when the top holder's or top-N
+// share moved by at least the change threshold, or when a single holder newly
+// controls the dominant share of supply. It returns nil when nothing changed.
+func DetectConcentrationChange(cfg config.ConcentrationConfig, baseline, current *models.AssetConcentration) *ConcentrationChange {
+	change := &ConcentrationChange{
+		Baseline: baseline,
+		Current:  current,
+		Severity: models.SeverityMedium,
+	}
+
+	if baseline != nil && cfg.ChangeThreshold > 0 {
+		if delta := current.TopHolderShare - baseline.TopHolderShare; math.Abs(delta) >= cfg.ChangeThreshold {
+			change.Reasons = append(change.Reasons, fmt.Sprintf(
+				"top holder share moved %+.1f points to %.1f%%", delta*100, current.TopHolderShare*100))
+		}
+		if delta := current.TopNShare - baseline.TopNShare; math.Abs(delta) >= cfg.ChangeThreshold {
+			change.Reasons = append(change.Reasons, fmt.Sprintf(
+				"top %d share moved %+.1f points to %.1f%%", current.TopN, delta*100, current.TopNShare*100))
+		}
+	}
+
+	if cfg.DominantShare > 0 && current.TopHolderShare >= cfg.DominantShare &&
+		(baseline == nil || baseline.TopHolderShare < cfg.DominantShare || baseline.TopHolder != current.TopHolder) {
+		change.Severity = models.SeverityHigh
+		change.Reasons = append(change.Reasons, fmt.Sprintf(
+			"%s now holds %.1f%% of observed supply", current.TopHolder, current.TopHolderShare*100))
+	}
+
+	if len(change.Reasons) == 0 {
+		return nil
+	}
+	return change
+}
+
+// raiseAlert stores an alert for the change with the snapshots as evidence
+func (s *ConcentrationService) raiseAlert(ctx context.Context, change *ConcentrationChange) error {
+	current := change.Current
+	target := string(current.Network) + ":" + current.Asset
+
+	alert := models.NewAlert(models.AlertTypeConcentration, change.Severity, "asset", target,
+		fmt.Sprintf("Rapid holder concentration change in %s", current.Asset))
+	alert.TargetValue = current.Asset
+	alert.Description = fmt.Sprintf("%s on %s: %s", current.Asset, current.Network, strings.Join(change.Reasons, "; "))
+	alert.RuleName = "holder_concentration_analytics"
+	alert.Score = math.Min(100, math.Round(current.TopHolderShare*100))
+	alert.Metadata = map[string]interface{}{
+		"network":          current.Network,
+		"asset":            current.Asset,
+		"gini":             current.Gini,
+		"top_n_share":      current.TopNShare,
+		"top_holder":       current.TopHolder,
+		"top_holder_share": current.TopHolderShare,
+		"snapshot_id":      current.ID,
+	}
+	if change.Baseline != nil {
+		alert.Metadata["baseline_snapshot_id"] = change.Baseline.ID
+		alert.Metadata["baseline_computed_at"] = change.Baseline.ComputedAt
+	}
+
+	if err := s.repo.CreateAlert(ctx, alert); err != nil {
+		return err
+	}
+
+	for _, snapshot := range []*models.AssetConcentration{change.Baseline, current} {
+		if snapshot == nil {
+			continue
+		}
+		data, _ := json.Marshal(snapshot)
+		if err := s.repo.AddAlertEvidence(ctx, &models.AlertEvidence{
+			ID:           uuid.New().String(),
+			AlertID:      alert.ID,
+			EvidenceType: "concentration_snapshot",
+			ReferenceID:  snapshot.ID,
+			Description:  fmt.Sprintf("Holder distribution of %s at %s", snapshot.Asset, snapshot.ComputedAt.Format(time.RFC3339)),
+			Data:         string(data),
+			CreatedAt:    time.Now(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Concentration alert raised",
+		zap.String("alert_id", alert.ID),
+		zap.String("network", string(current.Network)),
+		zap.String("asset", current.Asset),
+		zap.Float64("top_holder_share", current.TopHolderShare))
+
+	return nil
+}
+
+// ComputeConcentration builds a concentration snapshot from holder balances.
+// Shares are fractions of the summed positive balances, which is the observed
+// supply rather than the asset's total supply.
+func ComputeConcentration(network models.Network, asset string, balances []models.HolderBalance, topN int) *models.AssetConcentration {
+	if topN <= 0 {
+		topN = 10
+	}
+
+	sorted := make([]models.HolderBalance, len(balances))
+	copy(sorted, balances)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Balance.GreaterThan(sorted[j].Balance)
+	})
+
+	total := decimal.Zero
+	for _, b := range sorted {
+		total = total.Add(b.Balance)
+	}
+
+	snapshot := &models.AssetConcentration{
+		Network:     network,
+		Asset:       asset,
+		Holders:     len(sorted),
+		TotalSupply: total,
+		TopN:        topN,
+		TopHolders:  []models.HolderBalance{},
+	}
+	if total.IsZero() {
+		return snapshot
+	}
+
+	for i := range sorted {
+		sorted[i].Share, _ = sorted[i].Balance.Div(total).Float64()
+	}
+
+	for i := 0; i < len(sorted) && i < topN; i++ {
+		snapshot.TopNShare += sorted[i].Share
+		snapshot.TopHolders = append(snapshot.TopHolders, sorted[i])
+	}
+	snapshot.TopHolder = sorted[0].Holder
+	snapshot.TopHolderShare = sorted[0].Share
+	snapshot.Gini = giniCoefficient(sorted)
+
+	return snapshot
+}
+
+// giniCoefficient computes the Gini coefficient of balances sorted in descending order
+func giniCoefficient(sorted []models.HolderBalance) float64 {
+	n := len(sorted)
+	if n < 2 {
+		return 0
+	}
+
+	// With balances ranked ascending from 1, G = 2*sum(i*x_i)/(n*sum(x)) - (n+1)/n
+	var weighted, sum float64
+	for i, b := range sorted {
+		rank := float64(n - i)
+		weighted += rank * b.Share
+		sum += b.Share
+	}
+	if sum == 0 {
+		return 0
+	}
+	return 2*weighted/(float64(n)*sum) - float64(n+1)/float64(n)
+}
@@ -0,0 +1,536 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// StructuringService detects structuring, smurfing, round-amount and layering
+// patterns across all transactions in a sliding window
+type StructuringService struct {
+	cfg       *config.Config
+	repo      *repository.Repository
+	logger    *zap.Logger
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	isRunning bool
+
+	// alerted suppresses repeat alerts for the same pattern within one window
+	alerted map[string]time.Time
+}
+
+// NewStructuringService creates a new structuring analytics service
+func NewStructuringService(
+	cfg *config.Config,
+	repo *repository.Repository,
+	logger *zap.Logger,
+) *StructuringService {
+	return &StructuringService{
+		cfg:      cfg,
+		repo:     repo,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+		alerted:  make(map[string]time.Time),
+	}
+}
+
+// Start begins periodic pattern analysis
+func (s *StructuringService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	s.logger.Info("Starting structuring analytics service")
+
+	if s.cfg.Analytics.Enabled {
+		s.wg.Add(1)
+		go s.analysisLoop(ctx)
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the analytics service
+func (s *StructuringService) Stop() {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = false
+	s.mu.Unlock()
+
+	s.logger.Info("Stopping structuring analytics service")
+	close(s.stopChan)
+	s.wg.Wait()
+}
+
+// analysisLoop runs periodic analysis
+func (s *StructuringService) analysisLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(parseDuration(s.cfg.Analytics.Interval, 10*time.Minute))
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunAnalysis(ctx); err != nil {
+			s.logger.Error("Structuring analysis failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunAnalysis analyses the current window and raises an alert with evidence for each new pattern
+func (s *StructuringService) RunAnalysis(ctx context.Context) error {
+	window := parseDuration(s.cfg.Analytics.Window, 24*time.Hour)
+	end := time.Now()
+	start := end.Add(-window)
+
+	txs, err := s.repo.GetTransactionsInWindow(ctx, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to load transactions: %w", err)
+	}
+
+	byNetwork := make(map[models.Network][]models.Transaction)
+	for _, tx := range txs {
+		byNetwork[tx.Network] = append(byNetwork[tx.Network], tx)
+	}
+
+	for network, networkTxs := range byNetwork {
+		clusters, err := s.repo.GetWalletClusterIDs(ctx, senderAddresses(networkTxs), network)
+		if err != nil {
+			s.logger.Warn("Failed to load sender clusters",
+				zap.String("network", string(network)),
+				zap.Error(err))
+			clusters = map[string]string{}
+		}
+
+		detections := Analyze(s.cfg.Analytics, networkTxs, clusters)
+		for _, detection := range detections {
+			detection.WindowStart = start
+			detection.WindowEnd = end
+
+			if !s.shouldAlert(detection, end, window) {
+				continue
+			}
+			if err := s.raiseAlert(ctx, detection); err != nil {
+				s.logger.Error("Failed to raise pattern alert",
+					zap.String("type", string(detection.AlertType)),
+					zap.String("address", detection.Address),
+					zap.Error(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// shouldAlert records the detection and reports whether it has not been alerted within the window
+func (s *StructuringService) shouldAlert(detection *models.PatternDetection, now time.Time, window time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, at := range s.alerted {
+		if now.Sub(at) > window {
+			delete(s.alerted, key)
+		}
+	}
+
+	key := detection.Key()
+	if _, ok := s.alerted[key]; ok {
+		return false
+	}
+	s.alerted[key] = now
+	return true
+}
+
+// raiseAlert stores the alert and its supporting evidence
+func (s *StructuringService) raiseAlert(ctx context.Context, detection *models.PatternDetection) error {
+	alert := models.NewAlert(detection.AlertType, detection.Severity, "wallet", detection.Address, detection.Title)
+	alert.TargetValue = detection.Address
+	alert.Description = detection.Description
+	alert.RuleName = string(detection.AlertType) + "_analytics"
+	alert.Score = detection.Score
+	alert.Evidence = detection.Transactions
+	alert.Metadata = map[string]interface{}{
+		"network":        detection.Network,
+		"total_amount":   detection.TotalAmount.String(),
+		"window_start":   detection.WindowStart,
+		"window_end":     detection.WindowEnd,
+		"counterparties": detection.Counterparties,
+		"cluster_ids":    detection.ClusterIDs,
+	}
+
+	if err := s.repo.CreateAlert(ctx, alert); err != nil {
+		return err
+	}
+
+	for _, evidence := range buildEvidence(alert.ID, detection) {
+		if err := s.repo.AddAlertEvidence(ctx, evidence); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Pattern alert raised",
+		zap.String("alert_id", alert.ID),
+		zap.String("type", string(detection.AlertType)),
+		zap.String("address", detection.Address),
+		zap.Int("transactions", len(detection.Transactions)))
+
+	return nil
+}
+
+// buildEvidence converts a detection into alert evidence records
+func buildEvidence(alertID string, detection *models.PatternDetection) []*models.AlertEvidence {
+	now := time.Now()
+	var evidence []*models.AlertEvidence
+
+	summary, _ := json.Marshal(detection)
+	evidence = append(evidence, &models.AlertEvidence{
+		ID:           uuid.New().String(),
+		AlertID:      alertID,
+		EvidenceType: "pattern",
+		ReferenceID:  detection.Address,
+		Description:  detection.Description,
+		Data:         string(summary),
+		CreatedAt:    now,
+	})
+
+	for _, txHash := range detection.Transactions {
+		evidence = append(evidence, &models.AlertEvidence{
+			ID:           uuid.New().String(),
+			AlertID:      alertID,
+			EvidenceType: "transaction",
+			ReferenceID:  txHash,
+			Description:  fmt.Sprintf("Transaction contributing to %s pattern", detection.AlertType),
+			CreatedAt:    now,
+		})
+	}
+
+	for _, clusterID := range detection.ClusterIDs {
+		evidence = append(evidence, &models.AlertEvidence{
+			ID:           uuid.New().String(),
+			AlertID:      alertID,
+			EvidenceType: "cluster",
+			ReferenceID:  clusterID,
+			Description:  "Counterparties belong to the same entity cluster",
+			CreatedAt:    now,
+		})
+	}
+
+	return evidence
+}
+
+// Analyze runs all pattern detectors over a single network's transactions.
+// clusters maps sender addresses to their entity cluster IDs.
+func Analyze(cfg config.AnalyticsConfig, txs []models.Transaction, clusters map[string]string) []*models.PatternDetection {
+	var detections []*models.PatternDetection
+	detections = append(detections, detectSubThreshold(cfg, txs, clusters)...)
+	detections = append(detections, detectRoundAmounts(cfg, txs)...)
+	detections = append(detections, detectLayering(cfg, txs)...)
+	return detections
+}
+
+// detectSubThreshold finds beneficiaries receiving repeated transfers just below a
+// reporting threshold. Many distinct or clustered senders indicate smurfing; otherwise
+// the pattern is reported as structuring.
+func detectSubThreshold(cfg config.AnalyticsConfig, txs []models.Transaction, clusters map[string]string) []*models.PatternDetection {
+	if len(cfg.ReportingThresholds) == 0 || cfg.MinStructuredTransfers <= 0 {
+		return nil
+	}
+
+	byReceiver := make(map[string][]models.Transaction)
+	for _, tx := range txs {
+		if tx.Receiver == "" {
+			continue
+		}
+		if _, ok := subThreshold(cfg, tx.Amount); ok {
+			byReceiver[tx.Receiver] = append(byReceiver[tx.Receiver], tx)
+		}
+	}
+
+	var detections []*models.PatternDetection
+	for receiver, transfers := range byReceiver {
+		if len(transfers) < cfg.MinStructuredTransfers {
+			continue
+		}
+
+		total := decimal.Zero
+		threshold := 0.0
+		senders := make(map[string]bool)
+		clusterSenders := make(map[string]int)
+		hashes := make([]string, 0, len(transfers))
+		for _, tx := range transfers {
+			if t, _ := subThreshold(cfg, tx.Amount); t > threshold {
+				threshold = t
+			}
+			total = total.Add(tx.Amount)
+			hashes = append(hashes, tx.TxHash)
+			if !senders[tx.Sender] {
+				senders[tx.Sender] = true
+				if clusterID, ok := clusters[tx.Sender]; ok {
+					clusterSenders[clusterID]++
+				}
+			}
+		}
+
+		// Clusters that account for more than one sender suggest a single controller
+		var linkedClusters []string
+		linkedSenders := 0
+		for clusterID, count := range clusterSenders {
+			if count > 1 {
+				linkedClusters = append(linkedClusters, clusterID)
+				linkedSenders += count
+			}
+		}
+		sort.Strings(linkedClusters)
+
+		detection := &models.PatternDetection{
+			Network:        transfers[0].Network,
+			Address:        receiver,
+			TotalAmount:    total,
+			Transactions:   hashes,
+			Counterparties: sortedKeys(senders),
+			ClusterIDs:     linkedClusters,
+			Score:          scoreFor(40, len(transfers)-cfg.MinStructuredTransfers, 5),
+		}
+
+		switch {
+		case cfg.MinDistinctSenders > 0 && len(senders) >= cfg.MinDistinctSenders:
+			detection.AlertType = models.AlertTypeSmurfing
+			detection.Severity = models.SeverityHigh
+			if linkedSenders >= cfg.MinDistinctSenders {
+				detection.Severity = models.SeverityCritical
+				detection.Score = scoreFor(detection.Score, linkedSenders, 5)
+			}
+			detection.Title = fmt.Sprintf("Possible smurfing into %s", receiver)
+			detection.Description = fmt.Sprintf(
+				"%d transfers just below the %s reporting threshold from %d senders (%d in shared clusters), totalling %s",
+				len(transfers), decimal.NewFromFloat(threshold).String(), len(senders), linkedSenders, total.String())
+		default:
+			detection.AlertType = models.AlertTypeStructuring
+			detection.Severity = models.SeverityMedium
+			if total.GreaterThanOrEqual(decimal.NewFromFloat(threshold)) {
+				detection.Severity = models.SeverityHigh
+			}
+			detection.Title = fmt.Sprintf("Possible structuring into %s", receiver)
+			detection.Description = fmt.Sprintf(
+				"%d transfers just below the %s reporting threshold from %d senders, totalling %s",
+				len(transfers), decimal.NewFromFloat(threshold).String(), len(senders), total.String())
+		}
+
+		detections = append(detections, detection)
+	}
+
+	return detections
+}
+
+// detectRoundAmounts finds senders whose transfers are predominantly exact round amounts
+func detectRoundAmounts(cfg config.AnalyticsConfig, txs []models.Transaction) []*models.PatternDetection {
+	if cfg.RoundAmountUnit <= 0 || cfg.MinRoundTransfers <= 0 {
+		return nil
+	}
+	unit := decimal.NewFromFloat(cfg.RoundAmountUnit)
+
+	totals := make(map[string]int)
+	rounds := make(map[string][]models.Transaction)
+	for _, tx := range txs {
+		if tx.Sender == "" {
+			continue
+		}
+		totals[tx.Sender]++
+		if tx.Amount.GreaterThanOrEqual(unit) && tx.Amount.Mod(unit).IsZero() {
+			rounds[tx.Sender] = append(rounds[tx.Sender], tx)
+		}
+	}
+
+	var detections []*models.PatternDetection
+	for sender, transfers := range rounds {
+		ratio := float64(len(transfers)) / float64(totals[sender])
+		if len(transfers) < cfg.MinRoundTransfers || ratio < cfg.RoundAmountRatio {
+			continue
+		}
+
+		total := decimal.Zero
+		receivers := make(map[string]bool)
+		hashes := make([]string, 0, len(transfers))
+		for _, tx := range transfers {
+			total = total.Add(tx.Amount)
+			receivers[tx.Receiver] = true
+			hashes = append(hashes, tx.TxHash)
+		}
+
+		detections = append(detections, &models.PatternDetection{
+			AlertType: models.AlertTypeRoundAmounts,
+			Severity:  models.SeverityMedium,
+			Network:   transfers[0].Network,
+			Address:   sender,
+			Title:     fmt.Sprintf("Round-amount transfers from %s", sender),
+			Description: fmt.Sprintf(
+				"%d of %d transfers are exact multiples of %s, totalling %s",
+				len(transfers), totals[sender], unit.String(), total.String()),
+			Score:          scoreFor(25, len(transfers)-cfg.MinRoundTransfers, 3),
+			TotalAmount:    total,
+			Transactions:   hashes,
+			Counterparties: sortedKeys(receivers),
+		})
+	}
+
+	return detections
+}
+
+// detectLayering finds addresses that repeatedly forward most of each incoming
+// transfer onward within a short holding period
+func detectLayering(cfg config.AnalyticsConfig, txs []models.Transaction) []*models.PatternDetection {
+	if cfg.MinLayeringHops <= 0 {
+		return nil
+	}
+	maxHold := parseDuration(cfg.LayeringMaxHold, 30*time.Minute)
+	passThrough := decimal.NewFromFloat(cfg.LayeringPassThrough)
+
+	incoming := make(map[string][]models.Transaction)
+	outgoing := make(map[string][]models.Transaction)
+	for _, tx := range txs {
+		if tx.Receiver != "" {
+			incoming[tx.Receiver] = append(incoming[tx.Receiver], tx)
+		}
+		if tx.Sender != "" {
+			outgoing[tx.Sender] = append(outgoing[tx.Sender], tx)
+		}
+	}
+
+	var detections []*models.PatternDetection
+	for address, ins := range incoming {
+		outs := outgoing[address]
+		if len(outs) == 0 {
+			continue
+		}
+
+		used := make([]bool, len(outs))
+		var hashes []string
+		counterparties := make(map[string]bool)
+		total := decimal.Zero
+		hops := 0
+
+		for _, in := range ins {
+			minOut := in.Amount.Mul(passThrough)
+			for i, out := range outs {
+				if used[i] || !out.Timestamp.After(in.Timestamp) || out.Timestamp.Sub(in.Timestamp) > maxHold {
+					continue
+				}
+				if out.Amount.LessThan(minOut) {
+					continue
+				}
+
+				used[i] = true
+				hops++
+				total = total.Add(out.Amount)
+				hashes = append(hashes, in.TxHash, out.TxHash)
+				counterparties[in.Sender] = true
+				counterparties[out.Receiver] = true
+				break
+			}
+		}
+
+		if hops < cfg.MinLayeringHops {
+			continue
+		}
+
+		severity := models.SeverityHigh
+		if hops >= cfg.MinLayeringHops*2 {
+			severity = models.SeverityCritical
+		}
+
+		detections = append(detections, &models.PatternDetection{
+			AlertType: models.AlertTypeLayering,
+			Severity:  severity,
+			Network:   ins[0].Network,
+			Address:   address,
+			Title:     fmt.Sprintf("Rapid in-out layering through %s", address),
+			Description: fmt.Sprintf(
+				"%d transfers forwarded within %s of receipt, passing through %s",
+				hops, maxHold, total.String()),
+			Score:          scoreFor(50, hops-cfg.MinLayeringHops, 10),
+			TotalAmount:    total,
+			Transactions:   hashes,
+			Counterparties: sortedKeys(counterparties),
+		})
+	}
+
+	return detections
+}
+
+// subThreshold returns the reporting threshold an amount falls just below, if any
+func subThreshold(cfg config.AnalyticsConfig, amount decimal.Decimal) (float64, bool) {
+	for _, threshold := range cfg.ReportingThresholds {
+		upper := decimal.NewFromFloat(threshold)
+		lower := decimal.NewFromFloat(threshold * (1 - cfg.SubThresholdBand))
+		if amount.GreaterThanOrEqual(lower) && amount.LessThan(upper) {
+			return threshold, true
+		}
+	}
+	return 0, false
+}
+
+// scoreFor adds a per-unit increment to a base score, capped at 100
+func scoreFor(base float64, extra int, increment float64) float64 {
+	score := base
+	if extra > 0 {
+		score += float64(extra) * increment
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+func senderAddresses(txs []models.Transaction) []string {
+	seen := make(map[string]bool)
+	for _, tx := range txs {
+		if tx.Sender != "" {
+			seen[tx.Sender] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
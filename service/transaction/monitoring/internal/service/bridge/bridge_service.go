@@ -0,0 +1,555 @@
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Edge link types used when bridge outflows are added to the transaction graph
+const (
+	LinkTypeBridgeDeposit = "bridge_deposit"
+	LinkTypeBridgeRelease = "bridge_release"
+)
+
+// bridgeAddress identifies a configured bridge contract or locking address
+type bridgeAddress struct {
+	bridge  string
+	network models.Network
+	address string
+}
+
+// MonitorService watches configured bridge addresses for large outflows from
+// monitored entities and links each outflow to its release on the destination chain
+type MonitorService struct {
+	cfg       *config.Config
+	repo      *repository.Repository
+	logger    *zap.Logger
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	isRunning bool
+
+	// addresses indexes bridge addresses by addressKey
+	addresses map[string]bridgeAddress
+}
+
+// NewMonitorService creates a new bridge monitoring service
+func NewMonitorService(
+	cfg *config.Config,
+	repo *repository.Repository,
+	logger *zap.Logger,
+) *MonitorService {
+	addresses := make(map[string]bridgeAddress)
+	for _, bridge := range cfg.BridgeMonitoring.Bridges {
+		for _, addr := range bridge.Addresses {
+			network := models.Network(addr.Network)
+			addresses[addressKey(network, addr.Address)] = bridgeAddress{
+				bridge:  bridge.Name,
+				network: network,
+				address: addr.Address,
+			}
+		}
+	}
+
+	return &MonitorService{
+		cfg:       cfg,
+		repo:      repo,
+		logger:    logger,
+		stopChan:  make(chan struct{}),
+		addresses: addresses,
+	}
+}
+
+// Start begins periodic bridge monitoring
+func (s *MonitorService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	s.logger.Info("Starting bridge monitoring service",
+		zap.Int("bridge_addresses", len(s.addresses)))
+
+	if s.cfg.BridgeMonitoring.Enabled && len(s.addresses) > 0 {
+		s.wg.Add(1)
+		go s.monitorLoop(ctx)
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the bridge monitoring service
+func (s *MonitorService) Stop() {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = false
+	s.mu.Unlock()
+
+	s.logger.Info("Stopping bridge monitoring service")
+	close(s.stopChan)
+	s.wg.Wait()
+}
+
+// monitorLoop runs periodic scans
+func (s *MonitorService) monitorLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(parseDuration(s.cfg.BridgeMonitoring.Interval, 5*time.Minute))
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunScan(ctx); err != nil {
+			s.logger.Error("Bridge monitoring scan failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunScan records large outflows into bridges from monitored entities within the
+// window, raises an alert for each new one, and then matches unmatched outflows
+// to releases from the same bridge on another chain
+func (s *MonitorService) RunScan(ctx context.Context) error {
+	cfg := s.cfg.BridgeMonitoring
+	window := parseDuration(cfg.Window, time.Hour)
+	matchWindow := parseDuration(cfg.MatchWindow, 2*time.Hour)
+
+	end := time.Now()
+	lookback := window
+	if matchWindow > lookback {
+		lookback = matchWindow
+	}
+
+	txs, err := s.repo.GetTransactionsInWindow(ctx, end.Add(-lookback), end)
+	if err != nil {
+		return fmt.Errorf("failed to load transactions: %w", err)
+	}
+
+	deposits := s.findDeposits(txs, end.Add(-window))
+	for network, candidates := range deposits {
+		if err := s.recordOutflows(ctx, network, candidates); err != nil {
+			s.logger.Error("Failed to record bridge outflows",
+				zap.String("network", string(network)),
+				zap.Error(err))
+		}
+	}
+
+	unmatched, err := s.repo.GetUnmatchedBridgeOutflows(ctx, end.Add(-matchWindow))
+	if err != nil {
+		return fmt.Errorf("failed to load unmatched bridge outflows: %w", err)
+	}
+
+	for _, match := range MatchReleases(unmatched, s.findReleases(txs), matchWindow, cfg.MatchTolerance) {
+		if err := s.repo.SetBridgeOutflowDestination(ctx, match.OutflowID,
+			match.Release.Network, match.Release.Receiver, match.Release.TxHash); err != nil {
+			s.logger.Error("Failed to link bridge release",
+				zap.String("outflow_id", match.OutflowID),
+				zap.String("tx_hash", match.Release.TxHash),
+				zap.Error(err))
+			continue
+		}
+		s.logger.Info("Bridge outflow linked to destination chain",
+			zap.String("outflow_id", match.OutflowID),
+			zap.String("destination_network", string(match.Release.Network)),
+			zap.String("destination_address", match.Release.Receiver))
+	}
+
+	return nil
+}
+
+// findDeposits returns transfers into a bridge address since the given time whose
+// amount reaches the asset threshold, grouped by network
+func (s *MonitorService) findDeposits(txs []models.Transaction, since time.Time) map[models.Network][]models.Transaction {
+	deposits := make(map[models.Network][]models.Transaction)
+	for _, tx := range txs {
+		if tx.Timestamp.Before(since) || tx.Sender == "" {
+			continue
+		}
+		if _, ok := s.addresses[addressKey(tx.Network, tx.Receiver)]; !ok {
+			continue
+		}
+		if tx.Amount.LessThan(s.threshold(tx.Asset)) {
+			continue
+		}
+		deposits[tx.Network] = append(deposits[tx.Network], tx)
+	}
+	return deposits
+}
+
+// findReleases returns transfers out of a bridge address, keyed by bridge name
+func (s *MonitorService) findReleases(txs []models.Transaction) map[string][]models.Transaction {
+	releases := make(map[string][]models.Transaction)
+	for _, tx := range txs {
+		if tx.Receiver == "" {
+			continue
+		}
+		if bridge, ok := s.addresses[addressKey(tx.Network, tx.Sender)]; ok {
+			releases[bridge.bridge] = append(releases[bridge.bridge], tx)
+		}
+	}
+	return releases
+}
+
+// recordOutflows stores deposits whose sender is a monitored entity and alerts on new ones
+func (s *MonitorService) recordOutflows(ctx context.Context, network models.Network, deposits []models.Transaction) error {
+	monitored, err := s.repo.GetMonitoredAddresses(ctx, senderAddresses(deposits), network, s.cfg.BridgeMonitoring.MinWalletRiskScore)
+	if err != nil {
+		return fmt.Errorf("failed to load monitored addresses: %w", err)
+	}
+
+	for _, tx := range deposits {
+		reasons, ok := monitored[tx.Sender]
+		if !ok {
+			continue
+		}
+
+		bridge := s.addresses[addressKey(tx.Network, tx.Receiver)]
+		outflow := &models.BridgeOutflow{
+			ID:               uuid.New().String(),
+			BridgeName:       bridge.bridge,
+			Network:          tx.Network,
+			BridgeAddress:    tx.Receiver,
+			TxHash:           tx.TxHash,
+			Sender:           tx.Sender,
+			Amount:           tx.Amount,
+			Asset:            tx.Asset,
+			Threshold:        s.threshold(tx.Asset),
+			MonitoredReasons: reasons,
+			ClusterID:        clusterFromReasons(reasons),
+			Timestamp:        tx.Timestamp,
+			CreatedAt:        time.Now(),
+		}
+
+		created, err := s.repo.SaveBridgeOutflow(ctx, outflow)
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+
+		if err := s.raiseAlert(ctx, outflow); err != nil {
+			s.logger.Error("Failed to raise bridge outflow alert",
+				zap.String("tx_hash", outflow.TxHash),
+				zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// raiseAlert stores an alert for the outflow with its supporting evidence
+func (s *MonitorService) raiseAlert(ctx context.Context, outflow *models.BridgeOutflow) error {
+	severity := models.SeverityHigh
+	for _, reason := range outflow.MonitoredReasons {
+		if reason == "sanctioned" || reason == "blacklisted" {
+			severity = models.SeverityCritical
+			break
+		}
+	}
+
+	alert := models.NewAlert(models.AlertTypeBridgeOutflow, severity, "wallet", outflow.Sender,
+		fmt.Sprintf("Large %s outflow into %s bridge", outflow.Asset, outflow.BridgeName))
+	alert.TargetValue = outflow.Sender
+	alert.Description = fmt.Sprintf(
+		"%s %s sent from monitored address %s to %s bridge address %s on %s (threshold %s; monitored as %s)",
+		outflow.Amount.String(), outflow.Asset, outflow.Sender, outflow.BridgeName, outflow.BridgeAddress,
+		outflow.Network, outflow.Threshold.String(), strings.Join(outflow.MonitoredReasons, ", "))
+	alert.RuleName = "bridge_outflow_monitoring"
+	alert.Score = scoreFor(outflow)
+	alert.Evidence = []string{outflow.TxHash}
+	alert.Metadata = map[string]interface{}{
+		"network":           outflow.Network,
+		"bridge":            outflow.BridgeName,
+		"bridge_address":    outflow.BridgeAddress,
+		"amount":            outflow.Amount.String(),
+		"asset":             outflow.Asset,
+		"threshold":         outflow.Threshold.String(),
+		"monitored_reasons": outflow.MonitoredReasons,
+		"bridge_outflow_id": outflow.ID,
+	}
+
+	if err := s.repo.CreateAlert(ctx, alert); err != nil {
+		return err
+	}
+
+	for _, evidence := range buildEvidence(alert.ID, outflow) {
+		if err := s.repo.AddAlertEvidence(ctx, evidence); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.SetBridgeOutflowAlert(ctx, outflow.ID, alert.ID); err != nil {
+		return err
+	}
+	outflow.AlertID = &alert.ID
+
+	s.logger.Warn("Bridge outflow alert raised",
+		zap.String("alert_id", alert.ID),
+		zap.String("bridge", outflow.BridgeName),
+		zap.String("sender", outflow.Sender),
+		zap.String("amount", outflow.Amount.String()),
+		zap.String("asset", outflow.Asset))
+
+	return nil
+}
+
+// GetBridgeGraph returns the bridge flows touching an address as a graph. Each
+// outflow adds a deposit edge from the sender to the bridge address and, once
+// matched, a release edge from the bridge to the destination address.
+func (s *MonitorService) GetBridgeGraph(ctx context.Context, address string, network models.Network, since *time.Time) (*models.BridgeGraph, error) {
+	flows, err := s.repo.ListBridgeOutflows(ctx, models.BridgeOutflowFilter{
+		Network: network,
+		Address: address,
+		Since:   since,
+		Limit:   500,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildGraph(flows), nil
+}
+
+// ListOutflows returns recorded bridge outflows matching the filter
+func (s *MonitorService) ListOutflows(ctx context.Context, filter models.BridgeOutflowFilter) ([]models.BridgeOutflow, error) {
+	return s.repo.ListBridgeOutflows(ctx, filter)
+}
+
+// threshold returns the outflow threshold for an asset
+func (s *MonitorService) threshold(asset string) decimal.Decimal {
+	if t, ok := s.cfg.BridgeMonitoring.AssetThresholds[strings.ToUpper(asset)]; ok {
+		return decimal.NewFromFloat(t)
+	}
+	return decimal.NewFromFloat(s.cfg.BridgeMonitoring.DefaultThreshold)
+}
+
+// ReleaseMatch pairs a bridge outflow with the release transaction on the destination chain
+type ReleaseMatch struct {
+	OutflowID string
+	Release   models.Transaction
+}
+
+// MatchReleases pairs each outflow with a release from the same bridge on a
+// different network, in the same asset, within the match window after the
+// outflow and for an amount no more than tolerance below the outflow amount.
+// The earliest qualifying release is chosen and each release is used at most once.
+func MatchReleases(outflows []models.BridgeOutflow, releases map[string][]models.Transaction, matchWindow time.Duration, tolerance float64) []ReleaseMatch {
+	sorted := make([]models.BridgeOutflow, len(outflows))
+	copy(sorted, outflows)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	minRatio := decimal.NewFromFloat(1 - tolerance)
+	used := make(map[string]bool)
+
+	var matches []ReleaseMatch
+	for _, outflow := range sorted {
+		minAmount := outflow.Amount.Mul(minRatio)
+		for _, release := range releases[outflow.BridgeName] {
+			if used[release.TxHash] || release.Network == outflow.Network {
+				continue
+			}
+			if !strings.EqualFold(release.Asset, outflow.Asset) {
+				continue
+			}
+			if release.Timestamp.Before(outflow.Timestamp) || release.Timestamp.Sub(outflow.Timestamp) > matchWindow {
+				continue
+			}
+			if release.Amount.LessThan(minAmount) || release.Amount.GreaterThan(outflow.Amount) {
+				continue
+			}
+
+			used[release.TxHash] = true
+			matches = append(matches, ReleaseMatch{OutflowID: outflow.ID, Release: release})
+			break
+		}
+	}
+
+	return matches
+}
+
+// BuildGraph converts bridge outflows into graph nodes and edges. Node IDs are
+// "network:address" so the same address on two chains stays distinct.
+func BuildGraph(flows []models.BridgeOutflow) *models.BridgeGraph {
+	graph := &models.BridgeGraph{
+		Nodes: []models.GraphNode{},
+		Edges: []models.GraphEdge{},
+		Flows: flows,
+	}
+	if graph.Flows == nil {
+		graph.Flows = []models.BridgeOutflow{}
+	}
+
+	nodes := make(map[string]int)
+	volumes := make(map[string]decimal.Decimal)
+	addNode := func(network models.Network, address string, clusterID *string, amount decimal.Decimal) string {
+		id := nodeID(network, address)
+		idx, ok := nodes[id]
+		if !ok {
+			idx = len(graph.Nodes)
+			nodes[id] = idx
+			graph.Nodes = append(graph.Nodes, models.GraphNode{
+				Address:   address,
+				Network:   network,
+				ClusterID: clusterID,
+			})
+		}
+		graph.Nodes[idx].TxCount++
+		volumes[id] = volumes[id].Add(amount)
+		graph.Nodes[idx].Volume = volumes[id].String()
+		return id
+	}
+
+	for _, flow := range flows {
+		seen := flow.Timestamp.Format(time.RFC3339)
+		amount, _ := flow.Amount.Float64()
+
+		sender := addNode(flow.Network, flow.Sender, flow.ClusterID, flow.Amount)
+		bridge := addNode(flow.Network, flow.BridgeAddress, nil, flow.Amount)
+		graph.Edges = append(graph.Edges, models.GraphEdge{
+			Source:    sender,
+			Target:    bridge,
+			Weight:    amount,
+			LinkType:  LinkTypeBridgeDeposit,
+			TxCount:   1,
+			FirstSeen: seen,
+			LastSeen:  seen,
+		})
+
+		if flow.DestinationNetwork == nil || flow.DestinationAddress == nil {
+			continue
+		}
+		destination := addNode(*flow.DestinationNetwork, *flow.DestinationAddress, nil, flow.Amount)
+		graph.Edges = append(graph.Edges, models.GraphEdge{
+			Source:    bridge,
+			Target:    destination,
+			Weight:    amount,
+			LinkType:  LinkTypeBridgeRelease,
+			TxCount:   1,
+			FirstSeen: seen,
+			LastSeen:  seen,
+		})
+	}
+
+	return graph
+}
+
+// buildEvidence converts an outflow into alert evidence records
+func buildEvidence(alertID string, outflow *models.BridgeOutflow) []*models.AlertEvidence {
+	now := time.Now()
+
+	summary, _ := json.Marshal(outflow)
+	evidence := []*models.AlertEvidence{
+		{
+			ID:           uuid.New().String(),
+			AlertID:      alertID,
+			EvidenceType: "bridge_outflow",
+			ReferenceID:  outflow.ID,
+			Description:  fmt.Sprintf("Outflow into %s bridge on %s", outflow.BridgeName, outflow.Network),
+			Data:         string(summary),
+			CreatedAt:    now,
+		},
+		{
+			ID:           uuid.New().String(),
+			AlertID:      alertID,
+			EvidenceType: "transaction",
+			ReferenceID:  outflow.TxHash,
+			Description:  "Bridge deposit transaction",
+			CreatedAt:    now,
+		},
+	}
+
+	if outflow.ClusterID != nil {
+		evidence = append(evidence, &models.AlertEvidence{
+			ID:           uuid.New().String(),
+			AlertID:      alertID,
+			EvidenceType: "cluster",
+			ReferenceID:  *outflow.ClusterID,
+			Description:  "Sender belongs to a high-risk entity cluster",
+			CreatedAt:    now,
+		})
+	}
+
+	return evidence
+}
+
+// scoreFor scores an outflow by how far it exceeds the threshold, capped at 100
+func scoreFor(outflow *models.BridgeOutflow) float64 {
+	score := 60.0
+	if outflow.Threshold.IsPositive() {
+		multiple, _ := outflow.Amount.Div(outflow.Threshold).Float64()
+		score += (multiple - 1) * 10
+	}
+	score += float64(len(outflow.MonitoredReasons)-1) * 5
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+// clusterFromReasons returns the high-risk cluster named in the monitoring reasons, if any
+func clusterFromReasons(reasons []string) *string {
+	for _, reason := range reasons {
+		if clusterID := strings.TrimPrefix(reason, "high_risk_cluster:"); clusterID != reason {
+			return &clusterID
+		}
+	}
+	return nil
+}
+
+// addressKey normalises an address for lookup; EVM-style addresses are case-insensitive
+func addressKey(network models.Network, address string) string {
+	switch network {
+	case models.NetworkEthereum, models.NetworkPolygon, models.NetworkBSC:
+		address = strings.ToLower(address)
+	}
+	return string(network) + ":" + address
+}
+
+func nodeID(network models.Network, address string) string {
+	return string(network) + ":" + address
+}
+
+func senderAddresses(txs []models.Transaction) []string {
+	seen := make(map[string]bool)
+	var senders []string
+	for _, tx := range txs {
+		if !seen[tx.Sender] {
+			seen[tx.Sender] = true
+			senders = append(senders, tx.Sender)
+		}
+	}
+	sort.Strings(senders)
+	return senders
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,175 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/csic/transaction-monitoring/internal/service/risk"
+	"github.com/csic/transaction-monitoring/internal/service/screening"
+	"go.uber.org/zap"
+)
+
+// MaxCheckBatch caps the number of transactions checked in one request
+const MaxCheckBatch = 1000
+
+// screeningWorkers bounds how many addresses of a batch are screened at once
+const screeningWorkers = 16
+
+// CheckResult is the compliance verdict for one transaction. The verdict is the
+// most severe of its addresses' screening verdicts, raised to review when the
+// transaction's risk score reaches the high threshold.
+type CheckResult struct {
+	TxHash      string                              `json:"tx_hash"`
+	Network     models.Network                      `json:"network"`
+	Verdict     screening.Verdict                   `json:"verdict"`
+	Reasons     []string                            `json:"reasons"`
+	RiskScore   float64                             `json:"risk_score"`
+	RiskFactors []string                            `json:"risk_factors"`
+	Addresses   []*screening.AddressScreeningResult `json:"addresses"`
+	CheckedAt   time.Time                           `json:"checked_at"`
+}
+
+// ComplianceService checks submitted transactions before they are accepted by
+// screening every address involved and evaluating the transaction's risk.
+// Checks are read-only: unlike the Kafka pipeline they store no wallets,
+// scores or alerts.
+type ComplianceService struct {
+	cfg          *config.Config
+	cache        *repository.CacheRepository
+	riskSvc      *risk.RiskScoringService
+	screeningSvc *screening.ScreeningService
+	logger       *zap.Logger
+}
+
+// NewComplianceService creates a new transaction compliance service
+func NewComplianceService(
+	cfg *config.Config,
+	cache *repository.CacheRepository,
+	riskSvc *risk.RiskScoringService,
+	screeningSvc *screening.ScreeningService,
+	logger *zap.Logger,
+) *ComplianceService {
+	return &ComplianceService{
+		cfg:          cfg,
+		cache:        cache,
+		riskSvc:      riskSvc,
+		screeningSvc: screeningSvc,
+		logger:       logger,
+	}
+}
+
+// CheckCompliance checks a single transaction
+func (s *ComplianceService) CheckCompliance(ctx context.Context, tx *models.NormalizedTransaction) *CheckResult {
+	return s.CheckComplianceBatch(ctx, []*models.NormalizedTransaction{tx})[0]
+}
+
+// CheckComplianceBatch checks each transaction and returns one result per
+// transaction, in order. Lookups are shared across the batch: an address is
+// screened once however many transactions it appears in, and the cached wallet
+// risk scores of all addresses are read in one round trip.
+func (s *ComplianceService) CheckComplianceBatch(ctx context.Context, txs []*models.NormalizedTransaction) []*CheckResult {
+	wallets := models.UniqueWallets(txs)
+	screened := s.screenWallets(ctx, wallets)
+
+	scores, err := s.cache.GetWalletRiskScores(ctx, wallets)
+	if err != nil {
+		// Stored scores from screening are used instead
+		s.logger.Warn("Failed to read cached risk scores", zap.Error(err))
+		scores = make(map[models.WalletKey]float64)
+	}
+
+	checkedAt := time.Now().UTC()
+	results := make([]*CheckResult, len(txs))
+	for i, tx := range txs {
+		results[i] = s.checkTransaction(tx, screened, scores, checkedAt)
+	}
+	return results
+}
+
+// checkTransaction consolidates the screened addresses and risk of one
+// transaction into its verdict
+func (s *ComplianceService) checkTransaction(
+	tx *models.NormalizedTransaction,
+	screened map[models.WalletKey]*screening.AddressScreeningResult,
+	scores map[models.WalletKey]float64,
+	checkedAt time.Time,
+) *CheckResult {
+	result := &CheckResult{
+		TxHash:      tx.TxHash,
+		Network:     tx.Network,
+		Verdict:     screening.VerdictClear,
+		Reasons:     []string{},
+		RiskFactors: []string{},
+		Addresses:   []*screening.AddressScreeningResult{},
+		CheckedAt:   checkedAt,
+	}
+
+	for _, wallet := range models.UniqueWallets([]*models.NormalizedTransaction{tx}) {
+		address := screened[wallet]
+		result.Addresses = append(result.Addresses, address)
+		if address.Verdict == screening.VerdictClear {
+			continue
+		}
+		result.Verdict = result.Verdict.MoreSevere(address.Verdict)
+		for _, reason := range address.Reasons {
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%s: %s", wallet.Address, reason))
+		}
+	}
+
+	// Cached scores are the freshest; addresses without one fall back to the
+	// score stored with their wallet, or zero for unknown addresses
+	riskScore, factors := s.riskSvc.EvaluateTransactionRiskWith(tx, func(address string) float64 {
+		key := models.WalletKey{Address: address, Network: tx.Network}
+		if score, ok := scores[key]; ok {
+			return score
+		}
+		if screenedAddress, ok := screened[key]; ok {
+			return screenedAddress.RiskScore
+		}
+		return 0
+	})
+	result.RiskScore = riskScore
+	if factors != nil {
+		result.RiskFactors = factors
+	}
+
+	if riskScore >= float64(s.cfg.RiskScoring.HighThreshold) {
+		result.Verdict = result.Verdict.MoreSevere(screening.VerdictReview)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("transaction risk score %.2f reaches the high threshold", riskScore))
+	}
+
+	return result
+}
+
+// screenWallets screens each wallet once, a bounded number at a time
+func (s *ComplianceService) screenWallets(ctx context.Context, wallets []models.WalletKey) map[models.WalletKey]*screening.AddressScreeningResult {
+	results := make([]*screening.AddressScreeningResult, len(wallets))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < screeningWorkers && w < len(wallets); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = s.screeningSvc.ScreenAddress(ctx, wallets[i].Address, wallets[i].Network)
+			}
+		}()
+	}
+	for i := range wallets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	screened := make(map[models.WalletKey]*screening.AddressScreeningResult, len(wallets))
+	for i, wallet := range wallets {
+		screened[wallet] = results[i]
+	}
+	return screened
+}
@@ -2,6 +2,7 @@ package ingest
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"sync"
 	"time"
@@ -9,8 +10,9 @@ import (
 	"github.com/csic/transaction-monitoring/internal/config"
 	"github.com/csic/transaction-monitoring/internal/domain/models"
 	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/csic/transaction-monitoring/internal/service/reorg"
+	"github.com/csic/transaction-monitoring/internal/service/token"
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"go.uber.org/zap"
@@ -20,6 +22,8 @@ import (
 type IngestionService struct {
 	cfg          *config.Config
 	repo         *repository.Repository
+	reorgSvc     *reorg.Service
+	tokens       *token.Registry
 	ethClient    *ethclient.Client
 	btcClient    interface{} // BTC RPC client
 	kafkaProducer interface{}
@@ -34,10 +38,12 @@ type IngestionService struct {
 }
 
 // NewIngestionService creates a new ingestion service
-func NewIngestionService(cfg *config.Config, repo *repository.Repository, logger *zap.Logger) (*IngestionService, error) {
+func NewIngestionService(cfg *config.Config, repo *repository.Repository, reorgSvc *reorg.Service, tokens *token.Registry, logger *zap.Logger) (*IngestionService, error) {
 	svc := &IngestionService{
 		cfg:             cfg,
 		repo:            repo,
+		reorgSvc:        reorgSvc,
+		tokens:          tokens,
 		logger:          logger,
 		stopChan:        make(chan struct{}),
 		lastBlockHeight: make(map[string]int64),
@@ -157,6 +163,31 @@ func (s *IngestionService) processEthereumBlocks(ctx context.Context, startBlock
 				continue
 			}
 
+			// Rewind to the fork point if the block does not extend the
+			// chain ingested so far, so the replacement blocks are ingested
+			forkHeight, err := s.checkEthereumReorg(ctx, block)
+			if err != nil {
+				s.logger.Warn("Failed to check for chain reorganization", zap.Int64("block", currentHeight+1), zap.Error(err))
+				time.Sleep(5 * time.Second)
+				continue
+			}
+			if forkHeight >= 0 {
+				currentHeight = forkHeight
+				continue
+			}
+
+			if _, err := s.reorgSvc.ObserveBlock(ctx, &models.ChainBlock{
+				Network:    models.NetworkEthereum,
+				Number:     block.Number().Int64(),
+				Hash:       block.Hash().Hex(),
+				ParentHash: block.ParentHash().Hex(),
+				SeenAt:     time.Now(),
+			}); err != nil {
+				s.logger.Warn("Failed to record block", zap.Int64("block", currentHeight+1), zap.Error(err))
+				time.Sleep(5 * time.Second)
+				continue
+			}
+
 			// Process transactions
 			txCount := s.processEthereumBlock(block)
 
@@ -173,6 +204,55 @@ func (s *IngestionService) processEthereumBlocks(ctx context.Context, startBlock
 	}
 }
 
+// checkEthereumReorg compares the parent of a new block with the block
+// recorded below it. On a mismatch it walks back through the node's chain to
+// the highest block both chains share, handles the reorganization and
+// returns that fork height. It returns -1 if the block extends the chain.
+func (s *IngestionService) checkEthereumReorg(ctx context.Context, block *types.Block) (int64, error) {
+	number := block.Number().Int64()
+	parent, err := s.repo.GetCanonicalBlock(ctx, models.NetworkEthereum, number-1)
+	if err != nil {
+		return -1, err
+	}
+	if parent == nil || parent.Hash == block.ParentHash().Hex() {
+		return -1, nil
+	}
+
+	maxDepth := int64(s.cfg.Blockchain.MaxReorgDepth)
+	if maxDepth <= 0 {
+		maxDepth = 64
+	}
+
+	forkHeight := number - 1 - maxDepth
+	for height := number - 2; height >= number-1-maxDepth && height >= 0; height-- {
+		stored, err := s.repo.GetCanonicalBlock(ctx, models.NetworkEthereum, height)
+		if err != nil {
+			return -1, err
+		}
+		if stored == nil {
+			forkHeight = height
+			break
+		}
+
+		header, err := s.ethClient.HeaderByNumber(ctx, big.NewInt(height))
+		if err != nil {
+			return -1, fmt.Errorf("failed to get header %d: %w", height, err)
+		}
+		if header.Hash().Hex() == stored.Hash {
+			forkHeight = height
+			break
+		}
+	}
+	if forkHeight < 0 {
+		forkHeight = 0
+	}
+
+	if _, err := s.reorgSvc.Reorg(ctx, models.NetworkEthereum, forkHeight); err != nil {
+		return -1, err
+	}
+	return forkHeight, nil
+}
+
 // processEthereumBlock processes transactions in an Ethereum block
 func (s *IngestionService) processEthereumBlock(block *types.Block) int {
 	ctx := context.Background()
@@ -186,6 +266,9 @@ func (s *IngestionService) processEthereumBlock(block *types.Block) int {
 			s.logger.Error("Failed to save transaction", zap.String("hash", tx.Hash().Hex()), zap.Error(err))
 			continue
 		}
+		if err := s.repo.SaveTokenTransfers(ctx, normalizedTx.TokenTransfers); err != nil {
+			s.logger.Error("Failed to save token transfers", zap.String("hash", tx.Hash().Hex()), zap.Error(err))
+		}
 
 		// Publish to Kafka for downstream processing
 		s.publishNormalizedTransaction(ctx, normalizedTx)
@@ -209,33 +292,16 @@ func (s *IngestionService) normalizeEthereumTransaction(tx *types.Block, ethBloc
 		})
 	}
 
-	receipt, err := s.ethClient.TransactionReceipt(context.Background(), tx.Hash())
-	if err == nil {
-		for _, log := range receipt.Logs {
-			// Parse token transfers from logs
-			if len(log.Topics) >= 3 {
-				// ERC-20 Transfer event
-				if log.Topics[0].Hex() == "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
-					from := common.HexToAddress(log.Topics[1].Hex()).Hex()
-					to := common.HexToAddress(log.Topics[2].Hex()).Hex()
-					outputs = append(outputs, models.NormalizedOutput{
-						Address: to,
-						Amount:  s.parseLogData(log.Data),
-					})
-				}
-			}
-		}
-	}
-
-	// If no token transfers, add recipient
-	if len(outputs) == 0 && tx.To() != nil {
+	if tx.To() != nil {
 		outputs = append(outputs, models.NormalizedOutput{
 			Address: tx.To().Hex(),
 			Amount:  s.getTxValue(tx),
 		})
 	}
 
-	return &models.NormalizedTransaction{
+	receipt, _ := s.ethClient.TransactionReceipt(context.Background(), tx.Hash())
+
+	normalized := &models.NormalizedTransaction{
 		TxHash:        tx.Hash().Hex(),
 		Network:       models.NetworkEthereum,
 		BlockNumber:   ethBlock.Number().Int64(),
@@ -249,6 +315,13 @@ func (s *IngestionService) normalizeEthereumTransaction(tx *types.Block, ethBloc
 		InputCount:    len(inputs),
 		OutputCount:   len(outputs),
 	}
+
+	// Token transfers are recorded separately from the native value flow
+	if receipt != nil {
+		normalized.TokenTransfers = s.tokens.DecodeTransfers(context.Background(), normalized, receipt.Logs)
+	}
+
+	return normalized
 }
 
 // getTxValue returns the transaction value in Ether
@@ -275,17 +348,6 @@ func (s *IngestionService) calculateEthereumFee(tx *types.Transaction, receipt *
 	return decimal.NewFromFloat(f)
 }
 
-// parseLogData parses log data as token transfer amount
-func (s *IngestionService) parseLogData(data []byte) decimal.Decimal {
-	if len(data) >= 32 {
-		amount := new(big.Int).SetBytes(data[:32])
-		ether := new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetInt(big.NewInt(1e18)))
-		f, _ := ether.Float64()
-		return decimal.NewFromFloat(f)
-	}
-	return decimal.Zero
-}
-
 // ingestBitcoin handles Bitcoin block ingestion (placeholder)
 func (s *IngestionService) ingestBitcoin(ctx context.Context) {
 	defer s.wg.Done()
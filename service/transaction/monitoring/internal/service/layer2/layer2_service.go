@@ -0,0 +1,562 @@
+package layer2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// rollupNetworks are the rollups whose sequencer feeds can be followed
+var rollupNetworks = map[models.Network]bool{
+	models.NetworkArbitrum: true,
+	models.NetworkOptimism: true,
+	models.NetworkBase:     true,
+}
+
+// Service extends monitoring to layer-two networks. It records Lightning
+// channels from a node's gossip graph and value transfers from rollup
+// sequencer feeds, and propagates the risk of L1 wallets to the L2 addresses
+// they control so that risky funds stay visible once they leave L1.
+type Service struct {
+	cfg       *config.Config
+	repo      *repository.Repository
+	logger    *zap.Logger
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	isRunning bool
+
+	lightning *lndClient
+	rollups   []*rollupFeed
+
+	// openChannels holds the open Lightning channels by channel ID. It is
+	// only used by the gossip loop.
+	openChannels map[string]models.LightningChannel
+}
+
+// NewService creates a new layer-two monitoring service
+func NewService(
+	cfg *config.Config,
+	repo *repository.Repository,
+	logger *zap.Logger,
+) (*Service, error) {
+	s := &Service{
+		cfg:          cfg,
+		repo:         repo,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+		openChannels: make(map[string]models.LightningChannel),
+	}
+
+	if !cfg.LayerTwo.Enabled {
+		return s, nil
+	}
+
+	if cfg.LayerTwo.Lightning.Enabled {
+		client, err := newLNDClient(cfg.LayerTwo.Lightning)
+		if err != nil {
+			return nil, err
+		}
+		s.lightning = client
+	}
+
+	for _, rollup := range cfg.LayerTwo.Rollups {
+		network := models.Network(rollup.Network)
+		if !rollupNetworks[network] {
+			return nil, fmt.Errorf("rollup monitoring is not supported on %s", network)
+		}
+		s.rollups = append(s.rollups, newRollupFeed(network, rollup, logger))
+	}
+
+	return s, nil
+}
+
+// Start begins following Lightning gossip and the rollup sequencer feeds
+func (s *Service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	if !s.cfg.LayerTwo.Enabled {
+		return nil
+	}
+
+	s.logger.Info("Starting layer-two monitoring service",
+		zap.Bool("lightning", s.lightning != nil),
+		zap.Int("rollups", len(s.rollups)))
+
+	if s.lightning != nil {
+		channels, err := s.repo.ListLightningChannels(ctx, "", true, 0)
+		if err != nil {
+			return fmt.Errorf("failed to load open lightning channels: %w", err)
+		}
+		for _, channel := range channels {
+			s.openChannels[channel.ChannelID] = channel
+		}
+
+		s.wg.Add(1)
+		go s.gossipLoop(ctx)
+	}
+
+	for _, feed := range s.rollups {
+		s.wg.Add(1)
+		go func(feed *rollupFeed) {
+			defer s.wg.Done()
+			feed.run(ctx, s.stopChan, s.handleRollupBlock)
+		}(feed)
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the layer-two monitoring service
+func (s *Service) Stop() {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = false
+	s.mu.Unlock()
+
+	s.logger.Info("Stopping layer-two monitoring service")
+	close(s.stopChan)
+	s.wg.Wait()
+}
+
+// gossipLoop periodically syncs the Lightning channel graph
+func (s *Service) gossipLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(parseDuration(s.cfg.LayerTwo.Lightning.PollInterval, 10*time.Minute))
+	defer ticker.Stop()
+
+	for {
+		if err := s.SyncChannels(ctx); err != nil {
+			s.logger.Error("Lightning channel sync failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncChannels reads the node's channel graph and records channels that have
+// been announced or have disappeared since the last sync. A channel leaves
+// the graph when its funding output is spent, or when it has not been updated
+// for two weeks and the node prunes it as a zombie; both are recorded as closes.
+func (s *Service) SyncChannels(ctx context.Context) error {
+	edges, err := s.lightning.describeGraph(ctx)
+	if err != nil {
+		return err
+	}
+	if len(edges) == 0 {
+		// A node that has not finished syncing gossip reports an empty graph;
+		// closing every known channel on that would be wrong
+		return nil
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool, len(edges))
+	opened := 0
+	for _, edge := range edges {
+		seen[edge.ChannelID] = true
+		if _, ok := s.openChannels[edge.ChannelID]; ok {
+			continue
+		}
+
+		channel, err := s.channelFromEdge(ctx, edge, now)
+		if err != nil {
+			s.logger.Warn("Skipping lightning channel",
+				zap.String("channel_id", edge.ChannelID),
+				zap.Error(err))
+			continue
+		}
+		if _, err := s.repo.SaveLightningChannel(ctx, channel); err != nil {
+			return fmt.Errorf("failed to save lightning channel %s: %w", channel.ChannelID, err)
+		}
+		s.openChannels[channel.ChannelID] = *channel
+		opened++
+
+		s.recordChannelTransfer(ctx, channel, models.L2TransferChannelOpen, now)
+	}
+
+	closed := 0
+	for id, channel := range s.openChannels {
+		if seen[id] {
+			continue
+		}
+		if err := s.repo.CloseLightningChannel(ctx, id, now); err != nil {
+			return fmt.Errorf("failed to close lightning channel %s: %w", id, err)
+		}
+		delete(s.openChannels, id)
+		closed++
+
+		channel.ClosedAt = &now
+		s.recordChannelTransfer(ctx, &channel, models.L2TransferChannelClose, now)
+	}
+
+	s.logger.Info("Lightning channel graph synced",
+		zap.Int("channels", len(edges)),
+		zap.Int("opened", opened),
+		zap.Int("closed", closed))
+
+	return nil
+}
+
+// channelFromEdge converts a gossip edge into a channel, resolving the funder
+// from the funding transaction when it has been ingested
+func (s *Service) channelFromEdge(ctx context.Context, edge lndEdge, now time.Time) (*models.LightningChannel, error) {
+	fundingTx, _, ok := strings.Cut(edge.ChanPoint, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid channel point %q", edge.ChanPoint)
+	}
+	capacity, err := decimal.NewFromString(edge.Capacity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity %q", edge.Capacity)
+	}
+
+	channel := &models.LightningChannel{
+		ChannelID:     edge.ChannelID,
+		ChannelPoint:  edge.ChanPoint,
+		Node1:         edge.Node1Pub,
+		Node2:         edge.Node2Pub,
+		Capacity:      capacity.Shift(-8),
+		FundingTxHash: fundingTx,
+		FirstSeen:     now,
+		LastUpdate:    time.Unix(edge.LastUpdate, 0),
+	}
+
+	tx, err := s.repo.GetTransaction(ctx, fundingTx)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil && tx.Sender != "" {
+		channel.Funder = &tx.Sender
+	}
+	return channel, nil
+}
+
+// recordChannelTransfer records a channel open or close as a transfer between
+// its nodes, propagating the funder's risk to both of them
+func (s *Service) recordChannelTransfer(ctx context.Context, channel *models.LightningChannel, transferType models.L2TransferType, now time.Time) {
+	links, err := s.propagateChannelFunding(ctx, channel)
+	if err != nil {
+		s.logger.Error("Failed to propagate channel funding risk",
+			zap.String("channel_id", channel.ChannelID),
+			zap.Error(err))
+	}
+
+	l1Network := models.NetworkBitcoin
+	transfer := &models.L2Transfer{
+		ID:           uuid.New().String(),
+		Network:      models.NetworkLightning,
+		TransferType: transferType,
+		Reference:    channel.ChannelID,
+		Sender:       channel.Node1,
+		Receiver:     channel.Node2,
+		Amount:       channel.Capacity,
+		Asset:        "BTC",
+		L1Network:    &l1Network,
+		L1TxHash:     &channel.FundingTxHash,
+		RiskScore:    highestScore(links),
+		Timestamp:    now,
+		CreatedAt:    now,
+	}
+	s.recordTransfer(ctx, transfer, links)
+}
+
+// propagateChannelFunding links both nodes of a channel to the L1 wallet that
+// funded it when that wallet is risky
+func (s *Service) propagateChannelFunding(ctx context.Context, channel *models.LightningChannel) ([]models.L2AddressLink, error) {
+	if channel.Funder == nil {
+		return nil, nil
+	}
+
+	scores, err := s.repo.GetL1RiskScores(ctx, []string{*channel.Funder}, models.NetworkBitcoin)
+	if err != nil {
+		return nil, err
+	}
+	source := scores[strings.ToLower(*channel.Funder)]
+	if source <= 0 || source < s.cfg.LayerTwo.MinSourceRiskScore {
+		return nil, nil
+	}
+
+	var links []models.L2AddressLink
+	for _, node := range []string{channel.Node1, channel.Node2} {
+		link := models.L2AddressLink{
+			L2Network:       models.NetworkLightning,
+			L2Address:       node,
+			L1Network:       models.NetworkBitcoin,
+			L1Address:       *channel.Funder,
+			LinkType:        models.L2LinkChannelFunding,
+			Reference:       channel.ChannelID,
+			SourceRiskScore: source,
+			RiskScore:       capScore(source * s.cfg.LayerTwo.ChannelFundingFactor),
+			UpdatedAt:       time.Now(),
+		}
+		if err := s.saveLink(ctx, link); err != nil {
+			return links, err
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// handleRollupBlock records the value transfers of a sequencer block whose
+// sender or receiver is the same account as a risky wallet on Ethereum
+func (s *Service) handleRollupBlock(ctx context.Context, feed *rollupFeed, block *rollupBlock) error {
+	var addresses []string
+	for _, tx := range block.Transactions {
+		if tx.Value == nil || tx.Value.ToInt().Sign() == 0 {
+			continue
+		}
+		addresses = append(addresses, tx.From)
+		if tx.To != nil {
+			addresses = append(addresses, *tx.To)
+		}
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	scores, err := s.repo.GetL1RiskScores(ctx, addresses, models.NetworkEthereum)
+	if err != nil {
+		return fmt.Errorf("failed to load L1 risk scores: %w", err)
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+
+	timestamp := time.Unix(int64(block.Timestamp), 0)
+	for _, tx := range block.Transactions {
+		if tx.Value == nil || tx.Value.ToInt().Sign() == 0 {
+			continue
+		}
+		receiver := ""
+		if tx.To != nil {
+			receiver = strings.ToLower(*tx.To)
+		}
+		sender := strings.ToLower(tx.From)
+
+		var links []models.L2AddressLink
+		for _, address := range []string{sender, receiver} {
+			source, ok := scores[address]
+			if !ok || source <= 0 || source < s.cfg.LayerTwo.MinSourceRiskScore {
+				continue
+			}
+			link := models.L2AddressLink{
+				L2Network:       feed.network,
+				L2Address:       address,
+				L1Network:       models.NetworkEthereum,
+				L1Address:       address,
+				LinkType:        models.L2LinkSameKey,
+				SourceRiskScore: source,
+				RiskScore:       capScore(source * s.cfg.LayerTwo.PropagationFactor),
+				UpdatedAt:       time.Now(),
+			}
+			if err := s.saveLink(ctx, link); err != nil {
+				return err
+			}
+			links = append(links, link)
+		}
+		if len(links) == 0 {
+			continue
+		}
+
+		s.recordTransfer(ctx, &models.L2Transfer{
+			ID:           uuid.New().String(),
+			Network:      feed.network,
+			TransferType: models.L2TransferRollup,
+			Reference:    strings.ToLower(tx.Hash),
+			Sender:       sender,
+			Receiver:     receiver,
+			Amount:       decimal.NewFromBigInt(tx.Value.ToInt(), -18),
+			Asset:        feed.cfg.NativeAsset,
+			RiskScore:    highestScore(links),
+			Timestamp:    timestamp,
+			CreatedAt:    time.Now(),
+		}, links)
+	}
+
+	return nil
+}
+
+// saveLink stores a link and raises the L2 wallet's risk score to the propagated score
+func (s *Service) saveLink(ctx context.Context, link models.L2AddressLink) error {
+	if err := s.repo.SaveL2AddressLink(ctx, &link); err != nil {
+		return fmt.Errorf("failed to save L2 address link: %w", err)
+	}
+	if err := s.repo.PropagateWalletRisk(ctx, link.L2Address, link.L2Network,
+		link.RiskScore, riskLevelFor(s.cfg, link.RiskScore)); err != nil {
+		return fmt.Errorf("failed to propagate risk to %s: %w", link.L2Address, err)
+	}
+	return nil
+}
+
+// recordTransfer stores a transfer and alerts on it when new and its
+// propagated risk reaches the alert score
+func (s *Service) recordTransfer(ctx context.Context, transfer *models.L2Transfer, links []models.L2AddressLink) {
+	created, err := s.repo.SaveL2Transfer(ctx, transfer)
+	if err != nil {
+		s.logger.Error("Failed to save L2 transfer",
+			zap.String("network", string(transfer.Network)),
+			zap.String("reference", transfer.Reference),
+			zap.Error(err))
+		return
+	}
+	if !created || transfer.RiskScore < s.cfg.LayerTwo.AlertScore {
+		return
+	}
+
+	if err := s.raiseAlert(ctx, transfer, links); err != nil {
+		s.logger.Error("Failed to raise L2 risk exposure alert",
+			zap.String("network", string(transfer.Network)),
+			zap.String("reference", transfer.Reference),
+			zap.Error(err))
+	}
+}
+
+// raiseAlert stores an alert for a transfer involving addresses linked to risky L1 wallets
+func (s *Service) raiseAlert(ctx context.Context, transfer *models.L2Transfer, links []models.L2AddressLink) error {
+	severity := models.SeverityHigh
+	if transfer.RiskScore >= float64(s.cfg.RiskScoring.CriticalThreshold) {
+		severity = models.SeverityCritical
+	}
+
+	target := links[0].L2Address
+	var sources []string
+	for _, link := range links {
+		sources = append(sources, fmt.Sprintf("%s (%s, L1 score %.0f)", link.L1Address, link.LinkType, link.SourceRiskScore))
+	}
+
+	alert := models.NewAlert(models.AlertTypeLayerTwoExposure, severity, "wallet", target,
+		fmt.Sprintf("L2 %s on %s linked to a high-risk L1 wallet", strings.ReplaceAll(string(transfer.TransferType), "_", " "), transfer.Network))
+	alert.TargetValue = target
+	alert.Description = fmt.Sprintf(
+		"%s %s moved between %s and %s on %s. Linked L1 wallets: %s.",
+		transfer.Amount.String(), transfer.Asset, transfer.Sender, transfer.Receiver,
+		transfer.Network, strings.Join(sources, "; "))
+	alert.RuleName = "l2_risk_propagation"
+	alert.Score = transfer.RiskScore
+	alert.Evidence = []string{transfer.Reference}
+	alert.Metadata = map[string]interface{}{
+		"network":        transfer.Network,
+		"transfer_type":  transfer.TransferType,
+		"l2_transfer_id": transfer.ID,
+		"links":          links,
+	}
+
+	if err := s.repo.CreateAlert(ctx, alert); err != nil {
+		return err
+	}
+
+	data, _ := json.Marshal(transfer)
+	if err := s.repo.AddAlertEvidence(ctx, &models.AlertEvidence{
+		ID:           uuid.New().String(),
+		AlertID:      alert.ID,
+		EvidenceType: "l2_transfer",
+		ReferenceID:  transfer.ID,
+		Description:  fmt.Sprintf("L2 %s on %s", transfer.TransferType, transfer.Network),
+		Data:         string(data),
+		CreatedAt:    time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetL2TransferAlert(ctx, transfer.ID, alert.ID); err != nil {
+		return err
+	}
+	transfer.AlertID = &alert.ID
+
+	s.logger.Warn("L2 risk exposure alert raised",
+		zap.String("alert_id", alert.ID),
+		zap.String("network", string(transfer.Network)),
+		zap.String("reference", transfer.Reference),
+		zap.Float64("risk_score", transfer.RiskScore))
+
+	return nil
+}
+
+// ListTransfers returns recorded layer-two transfers matching the filter
+func (s *Service) ListTransfers(ctx context.Context, filter models.L2TransferFilter) ([]models.L2Transfer, error) {
+	if rollupNetworks[filter.Network] {
+		filter.Address = strings.ToLower(filter.Address)
+	}
+	return s.repo.ListL2Transfers(ctx, filter)
+}
+
+// ListChannels returns Lightning channels, optionally of one node and only open ones
+func (s *Service) ListChannels(ctx context.Context, node string, openOnly bool, limit int) ([]models.LightningChannel, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.ListLightningChannels(ctx, strings.ToLower(node), openOnly, limit)
+}
+
+// GetLinks returns the L1 wallets linked to an L2 address with the risk
+// propagated from each
+func (s *Service) GetLinks(ctx context.Context, network models.Network, address string) ([]models.L2AddressLink, error) {
+	if rollupNetworks[network] || network == models.NetworkLightning {
+		address = strings.ToLower(address)
+	}
+	return s.repo.ListL2AddressLinks(ctx, network, address)
+}
+
+// highestScore returns the highest propagated score of the links
+func highestScore(links []models.L2AddressLink) float64 {
+	highest := 0.0
+	for _, link := range links {
+		if link.RiskScore > highest {
+			highest = link.RiskScore
+		}
+	}
+	return highest
+}
+
+func capScore(score float64) float64 {
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+// riskLevelFor maps a score onto the configured risk levels
+func riskLevelFor(cfg *config.Config, score float64) string {
+	switch {
+	case score >= float64(cfg.RiskScoring.CriticalThreshold):
+		return "critical"
+	case score >= float64(cfg.RiskScoring.HighThreshold):
+		return "high"
+	case score >= float64(cfg.RiskScoring.MediumThreshold):
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,97 @@
+package layer2
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+)
+
+// lndEdge is a channel in LND's describegraph response. 64-bit integers are
+// encoded as strings by LND's REST gateway.
+type lndEdge struct {
+	ChannelID  string `json:"channel_id"`
+	ChanPoint  string `json:"chan_point"`
+	LastUpdate int64  `json:"last_update"`
+	Node1Pub   string `json:"node1_pub"`
+	Node2Pub   string `json:"node2_pub"`
+	Capacity   string `json:"capacity"`
+}
+
+// lndClient reads the channel graph an LND node has built from gossip
+type lndClient struct {
+	endpoint   string
+	macaroon   string
+	httpClient *http.Client
+}
+
+func newLNDClient(cfg config.LightningConfig) (*lndClient, error) {
+	if cfg.RESTEndpoint == "" {
+		return nil, errors.New("lightning monitoring requires layer2.lightning.rest_endpoint")
+	}
+
+	macaroon, err := os.ReadFile(cfg.MacaroonPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LND macaroon: %w", err)
+	}
+
+	// LND serves its REST API with a self-signed certificate
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.TLSCertPath != "" {
+		cert, err := os.ReadFile(cfg.TLSCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read LND TLS certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cert) {
+			return nil, errors.New("LND TLS certificate is not valid PEM")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &lndClient{
+		endpoint: strings.TrimRight(cfg.RESTEndpoint, "/"),
+		macaroon: hex.EncodeToString(macaroon),
+		httpClient: &http.Client{
+			// The mainnet graph is tens of megabytes
+			Timeout:   2 * time.Minute,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// describeGraph returns the public channels known to the node
+func (c *lndClient) describeGraph(ctx context.Context) ([]lndEdge, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+"/v1/graph", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Grpc-Metadata-macaroon", c.macaroon)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lightning graph: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch lightning graph: status %d", resp.StatusCode)
+	}
+
+	var graph struct {
+		Edges []lndEdge `json:"edges"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&graph); err != nil {
+		return nil, fmt.Errorf("failed to decode lightning graph: %w", err)
+	}
+	return graph.Edges, nil
+}
@@ -0,0 +1,145 @@
+package layer2
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+)
+
+// maxBlockGap is the most blocks fetched to catch up after a missed head.
+// Longer gaps, such as after a reconnect, are skipped.
+const maxBlockGap = 100
+
+// rollupBlock is a sequencer block as returned by eth_getBlockByNumber. It is
+// decoded by hand because rollups add transaction types, such as deposits,
+// that go-ethereum's types do not know.
+type rollupBlock struct {
+	Number       hexutil.Big    `json:"number"`
+	Timestamp    hexutil.Uint64 `json:"timestamp"`
+	Transactions []rollupTx     `json:"transactions"`
+}
+
+// rollupHead is a newHeads notification; only the number is needed
+type rollupHead struct {
+	Number hexutil.Big `json:"number"`
+}
+
+// rollupTx is a transaction in a sequencer block
+type rollupTx struct {
+	Hash  string       `json:"hash"`
+	From  string       `json:"from"`
+	To    *string      `json:"to"`
+	Value *hexutil.Big `json:"value"`
+}
+
+// rollupFeed follows the blocks a rollup's sequencer publishes. Sequencer
+// blocks are final for the rollup within seconds, well before their batch is
+// posted to Ethereum.
+type rollupFeed struct {
+	network models.Network
+	cfg     config.RollupConfig
+	logger  *zap.Logger
+}
+
+func newRollupFeed(network models.Network, cfg config.RollupConfig, logger *zap.Logger) *rollupFeed {
+	return &rollupFeed{network: network, cfg: cfg, logger: logger}
+}
+
+// run follows the feed until stopped, reconnecting on failure
+func (f *rollupFeed) run(ctx context.Context, stop <-chan struct{}, handle func(context.Context, *rollupFeed, *rollupBlock) error) {
+	f.logger.Info("Starting rollup feed",
+		zap.String("network", string(f.network)),
+		zap.String("endpoint", f.cfg.FeedEndpoint))
+
+	for {
+		err := f.subscribe(ctx, stop, handle)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-time.After(5 * time.Second):
+		}
+
+		f.logger.Warn("Rollup feed ended, reconnecting",
+			zap.String("network", string(f.network)),
+			zap.Error(err))
+	}
+}
+
+func (f *rollupFeed) subscribe(ctx context.Context, stop <-chan struct{}, handle func(context.Context, *rollupFeed, *rollupBlock) error) error {
+	if f.cfg.FeedEndpoint == "" {
+		return fmt.Errorf("rollup feed for %s has no feed_endpoint", f.network)
+	}
+
+	client, err := rpc.DialContext(ctx, f.cfg.FeedEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", f.cfg.FeedEndpoint, err)
+	}
+	defer client.Close()
+
+	heads := make(chan rollupHead, 64)
+	sub, err := client.EthSubscribe(ctx, heads, "newHeads")
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new heads: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	var last *big.Int
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-stop:
+			return nil
+		case err := <-sub.Err():
+			return err
+		case head := <-heads:
+			number := head.Number.ToInt()
+			from := new(big.Int).Set(number)
+			if last != nil {
+				gap := new(big.Int).Sub(number, last)
+				if gap.Sign() <= 0 {
+					continue
+				}
+				if gap.Cmp(big.NewInt(maxBlockGap)) <= 0 {
+					from.Add(last, big.NewInt(1))
+				}
+			}
+
+			for n := from; n.Cmp(number) <= 0; n = new(big.Int).Add(n, big.NewInt(1)) {
+				block, err := f.fetchBlock(ctx, client, n)
+				if err != nil {
+					return err
+				}
+				if err := handle(ctx, f, block); err != nil {
+					f.logger.Error("Failed to process rollup block",
+						zap.String("network", string(f.network)),
+						zap.String("block", n.String()),
+						zap.Error(err))
+				}
+			}
+			last = number
+		}
+	}
+}
+
+// fetchBlock loads a block with its transactions
+func (f *rollupFeed) fetchBlock(ctx context.Context, client *rpc.Client, number *big.Int) (*rollupBlock, error) {
+	var block *rollupBlock
+	if err := client.CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeBig(number), true); err != nil {
+		return nil, fmt.Errorf("failed to fetch block %s: %w", number, err)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", number)
+	}
+	return block, nil
+}
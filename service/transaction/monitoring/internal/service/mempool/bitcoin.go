@@ -0,0 +1,343 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/go-zeromq/zmq4"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// outputCacheSize is the number of recent mempool transactions whose outputs
+// are kept to resolve the inputs of their unconfirmed children
+const outputCacheSize = 50000
+
+// bitcoinListener reads raw transactions from Bitcoin Core's ZMQ rawtx feed.
+// Input addresses are not part of a raw transaction; they are resolved from
+// the spent outputs, either from recently seen mempool transactions or with
+// gettxout against the node's UTXO set.
+type bitcoinListener struct {
+	cfg     config.BitcoinConfig
+	params  *chaincfg.Params
+	rpc     *bitcoinRPC
+	outputs *outputCache
+	logger  *zap.Logger
+}
+
+func newBitcoinListener(cfg config.BitcoinConfig, logger *zap.Logger) (*bitcoinListener, error) {
+	if cfg.ZMQRawTx == "" {
+		return nil, errors.New("mempool monitoring on bitcoin requires blockchain.bitcoin.zmq_rawtx")
+	}
+
+	var params *chaincfg.Params
+	switch cfg.Chain {
+	case "", "mainnet":
+		params = &chaincfg.MainNetParams
+	case "testnet":
+		params = &chaincfg.TestNet3Params
+	case "regtest":
+		params = &chaincfg.RegressionNetParams
+	default:
+		return nil, fmt.Errorf("unknown bitcoin chain %q", cfg.Chain)
+	}
+
+	return &bitcoinListener{
+		cfg:     cfg,
+		params:  params,
+		rpc:     newBitcoinRPC(cfg),
+		outputs: newOutputCache(outputCacheSize),
+		logger:  logger,
+	}, nil
+}
+
+// run subscribes to the rawtx feed until stopped, reconnecting on failure
+func (l *bitcoinListener) run(ctx context.Context, stop <-chan struct{}, emit func(pendingTx), status *networkStatus) {
+	l.logger.Info("Starting Bitcoin mempool listener", zap.String("endpoint", l.cfg.ZMQRawTx))
+
+	for {
+		err := l.subscribe(ctx, stop, emit, status)
+		status.connected(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-time.After(5 * time.Second):
+		}
+
+		l.logger.Warn("Bitcoin mempool feed disconnected, reconnecting", zap.Error(err))
+	}
+}
+
+func (l *bitcoinListener) subscribe(ctx context.Context, stop <-chan struct{}, emit func(pendingTx), status *networkStatus) error {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-subCtx.Done():
+		}
+	}()
+
+	sub := zmq4.NewSub(subCtx)
+	defer sub.Close()
+
+	if err := sub.Dial(l.cfg.ZMQRawTx); err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", l.cfg.ZMQRawTx, err)
+	}
+	if err := sub.SetOption(zmq4.OptionSubscribe, "rawtx"); err != nil {
+		return fmt.Errorf("failed to subscribe to rawtx: %w", err)
+	}
+	status.connected(nil)
+
+	for {
+		msg, err := sub.Recv()
+		if err != nil {
+			if subCtx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if len(msg.Frames) < 2 {
+			continue
+		}
+
+		seenAt := time.Now()
+		var tx wire.MsgTx
+		if err := tx.Deserialize(bytes.NewReader(msg.Frames[1])); err != nil {
+			l.logger.Debug("Failed to decode raw bitcoin transaction", zap.Error(err))
+			continue
+		}
+
+		outputs := l.decodeOutputs(&tx)
+		txHash := tx.TxHash().String()
+		l.outputs.put(txHash, outputs)
+
+		emit(pendingTx{
+			network: models.NetworkBitcoin,
+			hash:    txHash,
+			seenAt:  seenAt,
+			load: func(ctx context.Context) (*models.NormalizedTransaction, error) {
+				return l.normalize(ctx, &tx, txHash, outputs, seenAt), nil
+			},
+		})
+	}
+}
+
+// decodeOutputs returns the outputs of tx with their addresses. Outputs
+// without a single standard address, such as OP_RETURN, have none.
+func (l *bitcoinListener) decodeOutputs(tx *wire.MsgTx) []models.NormalizedOutput {
+	outputs := make([]models.NormalizedOutput, 0, len(tx.TxOut))
+	for _, out := range tx.TxOut {
+		output := models.NormalizedOutput{Amount: decimal.New(out.Value, -8)}
+		if _, addresses, _, err := txscript.ExtractPkScriptAddrs(out.PkScript, l.params); err == nil && len(addresses) == 1 {
+			output.Address = addresses[0].EncodeAddress()
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+// normalize resolves the inputs of tx and returns it in normalized form.
+// Inputs whose spent output cannot be found are kept without an address.
+func (l *bitcoinListener) normalize(ctx context.Context, tx *wire.MsgTx, txHash string, outputs []models.NormalizedOutput, seenAt time.Time) *models.NormalizedTransaction {
+	inputs := make([]models.NormalizedInput, 0, len(tx.TxIn))
+	totalIn := decimal.Zero
+	resolved := true
+	for _, in := range tx.TxIn {
+		prev := in.PreviousOutPoint
+		spent, ok := l.outputs.get(prev.Hash.String(), prev.Index)
+		if !ok {
+			var err error
+			spent, err = l.rpc.getTxOut(ctx, prev.Hash.String(), prev.Index)
+			if err != nil {
+				l.logger.Debug("Failed to resolve bitcoin input",
+					zap.String("tx_hash", txHash),
+					zap.String("outpoint", prev.String()),
+					zap.Error(err))
+				resolved = false
+			}
+		}
+		inputs = append(inputs, models.NormalizedInput{
+			Address:    spent.Address,
+			Amount:     spent.Amount,
+			PrevTxHash: prev.Hash.String(),
+			PrevIndex:  int(prev.Index),
+		})
+		totalIn = totalIn.Add(spent.Amount)
+	}
+
+	totalOut := decimal.Zero
+	for _, out := range outputs {
+		totalOut = totalOut.Add(out.Amount)
+	}
+
+	normalized := &models.NormalizedTransaction{
+		TxHash:      txHash,
+		Network:     models.NetworkBitcoin,
+		Timestamp:   seenAt,
+		Inputs:      inputs,
+		Outputs:     outputs,
+		TotalValue:  totalOut,
+		Asset:       "BTC",
+		InputCount:  len(inputs),
+		OutputCount: len(outputs),
+	}
+	if resolved {
+		normalized.Fee = totalIn.Sub(totalOut)
+	}
+	return normalized
+}
+
+// outputCache keeps the outputs of the most recent mempool transactions
+type outputCache struct {
+	mu      sync.Mutex
+	size    int
+	outputs map[string][]models.NormalizedOutput
+	order   []string
+	next    int
+}
+
+func newOutputCache(size int) *outputCache {
+	return &outputCache{
+		size:    size,
+		outputs: make(map[string][]models.NormalizedOutput, size),
+		order:   make([]string, 0, size),
+	}
+}
+
+func (c *outputCache) put(txHash string, outputs []models.NormalizedOutput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.outputs[txHash]; ok {
+		return
+	}
+	if len(c.order) < c.size {
+		c.order = append(c.order, txHash)
+	} else {
+		delete(c.outputs, c.order[c.next])
+		c.order[c.next] = txHash
+		c.next = (c.next + 1) % c.size
+	}
+	c.outputs[txHash] = outputs
+}
+
+func (c *outputCache) get(txHash string, index uint32) (models.NormalizedOutput, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	outputs, ok := c.outputs[txHash]
+	if !ok || int(index) >= len(outputs) {
+		return models.NormalizedOutput{}, false
+	}
+	return outputs[index], true
+}
+
+// bitcoinRPC is a minimal Bitcoin Core JSON-RPC client
+type bitcoinRPC struct {
+	url        string
+	user       string
+	password   string
+	httpClient *http.Client
+}
+
+func newBitcoinRPC(cfg config.BitcoinConfig) *bitcoinRPC {
+	scheme := "http"
+	if cfg.UseTLS {
+		scheme = "https"
+	}
+	return &bitcoinRPC{
+		url:        fmt.Sprintf("%s://%s:%d", scheme, cfg.RPCHost, cfg.RPCPort),
+		user:       cfg.RPCUser,
+		password:   cfg.RPCPassword,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// rpcError is a JSON-RPC error returned by the node
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return "rpc error " + strconv.Itoa(e.Code) + ": " + e.Message
+}
+
+func (c *bitcoinRPC) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"id":      "csic-mempool",
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.user, c.password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return fmt.Errorf("failed to decode %s response (status %d): %w", method, resp.StatusCode, err)
+	}
+	if reply.Error != nil {
+		return reply.Error
+	}
+	return json.Unmarshal(reply.Result, result)
+}
+
+// getTxOut returns a confirmed unspent output. Outputs spent by a mempool
+// transaction are still unspent in the UTXO set until it confirms.
+func (c *bitcoinRPC) getTxOut(ctx context.Context, txHash string, index uint32) (models.NormalizedOutput, error) {
+	var out *struct {
+		Value        decimal.Decimal `json:"value"`
+		ScriptPubKey struct {
+			Address   string   `json:"address"`
+			Addresses []string `json:"addresses"`
+		} `json:"scriptPubKey"`
+	}
+	if err := c.call(ctx, "gettxout", []interface{}{txHash, index, false}, &out); err != nil {
+		return models.NormalizedOutput{}, err
+	}
+	if out == nil {
+		return models.NormalizedOutput{}, fmt.Errorf("output %s:%d is not in the UTXO set", txHash, index)
+	}
+
+	output := models.NormalizedOutput{Address: out.ScriptPubKey.Address, Amount: out.Value}
+	// Nodes before Bitcoin Core 22 report a list of addresses
+	if output.Address == "" && len(out.ScriptPubKey.Addresses) == 1 {
+		output.Address = out.ScriptPubKey.Addresses[0]
+	}
+	return output, nil
+}
@@ -0,0 +1,134 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// ethereumListener subscribes to the node's pending transaction hashes over
+// WebSocket and fetches each transaction on a worker
+type ethereumListener struct {
+	cfg    config.EthereumConfig
+	signer types.Signer
+	logger *zap.Logger
+}
+
+func newEthereumListener(cfg config.EthereumConfig, logger *zap.Logger) *ethereumListener {
+	return &ethereumListener{
+		cfg:    cfg,
+		signer: types.LatestSignerForChainID(big.NewInt(int64(cfg.ChainID))),
+		logger: logger,
+	}
+}
+
+// run subscribes to pending transactions until stopped, reconnecting on failure
+func (l *ethereumListener) run(ctx context.Context, stop <-chan struct{}, emit func(pendingTx), status *networkStatus) {
+	l.logger.Info("Starting Ethereum mempool listener", zap.String("endpoint", l.cfg.WSEndpoint))
+
+	for {
+		err := l.subscribe(ctx, stop, emit, status)
+		status.connected(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-time.After(5 * time.Second):
+		}
+
+		l.logger.Warn("Ethereum mempool subscription ended, reconnecting", zap.Error(err))
+	}
+}
+
+func (l *ethereumListener) subscribe(ctx context.Context, stop <-chan struct{}, emit func(pendingTx), status *networkStatus) error {
+	if l.cfg.WSEndpoint == "" {
+		return errors.New("mempool monitoring on ethereum requires blockchain.ethereum.ws_endpoint")
+	}
+
+	rpcClient, err := rpc.DialContext(ctx, l.cfg.WSEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", l.cfg.WSEndpoint, err)
+	}
+	client := ethclient.NewClient(rpcClient)
+	defer client.Close()
+
+	hashes := make(chan common.Hash, 1024)
+	sub, err := rpcClient.EthSubscribe(ctx, hashes, "newPendingTransactions")
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to pending transactions: %w", err)
+	}
+	defer sub.Unsubscribe()
+	status.connected(nil)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-stop:
+			return nil
+		case err := <-sub.Err():
+			return err
+		case hash := <-hashes:
+			seenAt := time.Now()
+			emit(pendingTx{
+				network: models.NetworkEthereum,
+				hash:    hash.Hex(),
+				seenAt:  seenAt,
+				load: func(ctx context.Context) (*models.NormalizedTransaction, error) {
+					return l.fetch(ctx, client, hash, seenAt)
+				},
+			})
+		}
+	}
+}
+
+// fetch loads a pending transaction and returns it in normalized form, or
+// nil if it has already left the mempool
+func (l *ethereumListener) fetch(ctx context.Context, client *ethclient.Client, hash common.Hash, seenAt time.Time) (*models.NormalizedTransaction, error) {
+	tx, isPending, err := client.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isPending {
+		// Already mined; the block ingestion path covers it
+		return nil, nil
+	}
+
+	sender, err := types.Sender(l.signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender: %w", err)
+	}
+
+	value := decimal.NewFromBigInt(tx.Value(), -18)
+	normalized := &models.NormalizedTransaction{
+		TxHash:     tx.Hash().Hex(),
+		Network:    models.NetworkEthereum,
+		Timestamp:  seenAt,
+		Inputs:     []models.NormalizedInput{{Address: sender.Hex(), Amount: value}},
+		TotalValue: value,
+		Asset:      "ETH",
+		InputCount: 1,
+	}
+	if to := tx.To(); to != nil {
+		normalized.Outputs = []models.NormalizedOutput{{Address: to.Hex(), Amount: value}}
+		normalized.OutputCount = 1
+	}
+	return normalized, nil
+}
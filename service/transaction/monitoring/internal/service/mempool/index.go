@@ -0,0 +1,215 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Freeze levels set by wallet governance. An INCOMING freeze blocks funds
+// reaching the address, an OUTGOING freeze funds leaving it, a FULL freeze both.
+const (
+	freezeLevelFull     = "FULL"
+	freezeLevelIncoming = "INCOMING"
+	freezeLevelOutgoing = "OUTGOING"
+)
+
+// activeFreeze is a freeze order as returned by the wallet governance
+// service's active freeze endpoint
+type activeFreeze struct {
+	ID            string `json:"id"`
+	WalletAddress string `json:"wallet_address"`
+	Blockchain    string `json:"blockchain"`
+	FreezeLevel   string `json:"freeze_level"`
+	LegalOrderID  string `json:"legal_order_id"`
+}
+
+// frozenAddress is a frozen address in the screening index
+type frozenAddress struct {
+	freezeID     string
+	legalOrderID string
+	level        string
+}
+
+// screenIndex holds the frozen and watchlisted addresses, keyed by addressKey.
+// It is rebuilt on every refresh and never modified afterwards, so it can be
+// read without locking once published.
+type screenIndex struct {
+	frozen      map[string]frozenAddress
+	watchlist   map[string][]string
+	refreshedAt time.Time
+}
+
+// match returns the frozen or watchlisted addresses of tx, one match per
+// address and direction with the amounts summed
+func (idx *screenIndex) match(tx *models.NormalizedTransaction) []models.InterdictionMatch {
+	type matchKey struct {
+		address   string
+		direction models.WatchDirection
+	}
+	amounts := make(map[matchKey]decimal.Decimal)
+	var order []matchKey
+
+	add := func(address string, amount decimal.Decimal, direction models.WatchDirection) {
+		if address == "" {
+			return
+		}
+		key := matchKey{address: normalizeAddress(tx.Network, address), direction: direction}
+		if _, ok := amounts[key]; !ok {
+			order = append(order, key)
+		}
+		amounts[key] = amounts[key].Add(amount)
+	}
+	for _, in := range tx.Inputs {
+		add(in.Address, in.Amount, models.WatchDirectionSent)
+	}
+	for _, out := range tx.Outputs {
+		add(out.Address, out.Amount, models.WatchDirectionReceived)
+	}
+
+	var matches []models.InterdictionMatch
+	for _, key := range order {
+		indexKey := string(tx.Network) + ":" + key.address
+		match := models.InterdictionMatch{
+			Address:   key.address,
+			Direction: key.direction,
+			Amount:    amounts[key],
+		}
+
+		if frozen, ok := idx.frozen[indexKey]; ok && frozen.blocks(key.direction) {
+			match.Reasons = append(match.Reasons, models.InterdictionReasonFrozen)
+			match.FreezeID = frozen.freezeID
+			match.LegalOrderID = frozen.legalOrderID
+		}
+		if lists, ok := idx.watchlist[indexKey]; ok {
+			match.Reasons = append(match.Reasons, models.InterdictionReasonWatchlist)
+			match.Watchlists = lists
+		}
+
+		if len(match.Reasons) > 0 {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// blocks reports whether the freeze covers funds moving in the given direction
+func (f frozenAddress) blocks(direction models.WatchDirection) bool {
+	switch f.level {
+	case freezeLevelIncoming:
+		return direction == models.WatchDirectionReceived
+	case freezeLevelOutgoing:
+		return direction == models.WatchDirectionSent
+	default:
+		return true
+	}
+}
+
+// loadIndex builds a screening index from the active freezes and the
+// unexpired watchlist entries. When the freeze source cannot be reached the
+// previously loaded frozen addresses are kept, so an outage of wallet
+// governance does not silently stop interdictions.
+func (s *Service) loadIndex(ctx context.Context, previous *screenIndex) (*screenIndex, error) {
+	idx := &screenIndex{
+		frozen:      make(map[string]frozenAddress),
+		watchlist:   make(map[string][]string),
+		refreshedAt: time.Now(),
+	}
+
+	entries, err := s.repo.ListActiveWatchlistEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load watchlist: %w", err)
+	}
+	for _, entry := range entries {
+		key := addressKey(entry.Network, entry.Address)
+		idx.watchlist[key] = append(idx.watchlist[key], entry.ListName)
+	}
+
+	freezes, err := s.fetchActiveFreezes(ctx)
+	if err != nil {
+		if previous == nil {
+			return nil, err
+		}
+		s.logger.Warn("Failed to load active freezes, keeping the previous set", zap.Error(err))
+		idx.frozen = previous.frozen
+		return idx, nil
+	}
+	for _, freeze := range freezes {
+		network, ok := freezeNetworks[strings.ToUpper(freeze.Blockchain)]
+		if !ok || freeze.WalletAddress == "" {
+			continue
+		}
+		idx.frozen[addressKey(network, freeze.WalletAddress)] = frozenAddress{
+			freezeID:     freeze.ID,
+			legalOrderID: freeze.LegalOrderID,
+			level:        strings.ToUpper(freeze.FreezeLevel),
+		}
+	}
+
+	return idx, nil
+}
+
+// freezeNetworks maps wallet governance blockchain types to networks
+var freezeNetworks = map[string]models.Network{
+	"BITCOIN":  models.NetworkBitcoin,
+	"ETHEREUM": models.NetworkEthereum,
+	"ERC20":    models.NetworkEthereum,
+	"POLYGON":  models.NetworkPolygon,
+	"BEP20":    models.NetworkBSC,
+	"TRC20":    models.NetworkTron,
+}
+
+// fetchActiveFreezes reads the active freeze orders from wallet governance
+func (s *Service) fetchActiveFreezes(ctx context.Context) ([]activeFreeze, error) {
+	if s.cfg.Mempool.FreezeSourceURL == "" {
+		return nil, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.Mempool.FreezeSourceURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active freezes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch active freezes: status %d", resp.StatusCode)
+	}
+
+	var freezes []activeFreeze
+	if err := json.NewDecoder(resp.Body).Decode(&freezes); err != nil {
+		return nil, fmt.Errorf("failed to decode active freezes: %w", err)
+	}
+	return freezes, nil
+}
+
+// normalizeAddress returns the form of an address stored in the index: EVM
+// and bech32 addresses are case-insensitive and kept in lowercase, as
+// screening.ValidateAddress normalizes them
+func normalizeAddress(network models.Network, address string) string {
+	switch network {
+	case models.NetworkEthereum, models.NetworkPolygon, models.NetworkBSC:
+		return strings.ToLower(address)
+	case models.NetworkBitcoin:
+		if lower := strings.ToLower(address); strings.HasPrefix(lower, "bc1") ||
+			strings.HasPrefix(lower, "tb1") || strings.HasPrefix(lower, "bcrt1") {
+			return lower
+		}
+	}
+	return address
+}
+
+func addressKey(network models.Network, address string) string {
+	return string(network) + ":" + normalizeAddress(network, address)
+}
@@ -0,0 +1,468 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/csic/transaction-monitoring/internal/service/watch"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrInterdictionNotFound is returned when a mempool interdiction does not exist
+var ErrInterdictionNotFound = errors.New("mempool interdiction not found")
+
+// pendingTx is an unconfirmed transaction announced by a node. load fetches
+// and normalizes it; it runs on a worker so slow lookups do not hold up the
+// listener.
+type pendingTx struct {
+	network models.Network
+	hash    string
+	seenAt  time.Time
+	load    func(ctx context.Context) (*models.NormalizedTransaction, error)
+}
+
+// Service reads unconfirmed transactions from the Bitcoin and Ethereum
+// mempools and screens them against frozen and watchlisted addresses. A match
+// is recorded as an interdiction and sent at once to the licensed exchanges so
+// they can withhold crediting the transaction before it confirms.
+type Service struct {
+	cfg        *config.Config
+	repo       *repository.Repository
+	watchSvc   *watch.Service
+	httpClient *http.Client
+	notifier   *exchangeNotifier
+	logger     *zap.Logger
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+	mu         sync.RWMutex
+	isRunning  bool
+
+	queue    chan pendingTx
+	index    *screenIndex
+	networks map[models.Network]*networkStatus
+	bitcoin  *bitcoinListener
+	ethereum *ethereumListener
+}
+
+// NewService creates a new mempool monitoring service
+func NewService(
+	cfg *config.Config,
+	repo *repository.Repository,
+	watchSvc *watch.Service,
+	logger *zap.Logger,
+) (*Service, error) {
+	queueSize := cfg.Mempool.QueueSize
+	if queueSize <= 0 {
+		queueSize = 10000
+	}
+
+	s := &Service{
+		cfg:        cfg,
+		repo:       repo,
+		watchSvc:   watchSvc,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+		stopChan:   make(chan struct{}),
+		queue:      make(chan pendingTx, queueSize),
+		networks:   make(map[models.Network]*networkStatus),
+	}
+	s.notifier = newExchangeNotifier(cfg.Mempool, repo, logger)
+
+	for _, name := range cfg.Mempool.Networks {
+		network := models.Network(name)
+		switch network {
+		case models.NetworkBitcoin:
+			listener, err := newBitcoinListener(cfg.Blockchain.Bitcoin, logger)
+			if err != nil {
+				return nil, err
+			}
+			s.bitcoin = listener
+		case models.NetworkEthereum:
+			s.ethereum = newEthereumListener(cfg.Blockchain.Ethereum, logger)
+		default:
+			return nil, fmt.Errorf("mempool monitoring is not supported on %s", network)
+		}
+		s.networks[network] = &networkStatus{network: network}
+	}
+
+	return s, nil
+}
+
+// Start loads the screening index and starts the mempool listeners and workers
+func (s *Service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	if !s.cfg.Mempool.Enabled {
+		return nil
+	}
+
+	idx, err := s.loadIndex(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to load mempool screening index: %w", err)
+	}
+	s.mu.Lock()
+	s.index = idx
+	s.mu.Unlock()
+
+	s.logger.Info("Starting mempool monitoring service",
+		zap.Strings("networks", s.cfg.Mempool.Networks),
+		zap.Int("frozen_addresses", len(idx.frozen)),
+		zap.Int("watchlist_addresses", len(idx.watchlist)),
+		zap.Int("exchanges", len(s.cfg.Mempool.Exchanges)))
+
+	workers := s.cfg.Mempool.Workers
+	if workers <= 0 {
+		workers = 8
+	}
+	for i := 0; i < workers; i++ {
+		s.wg.Add(1)
+		go s.worker(ctx)
+	}
+
+	s.wg.Add(1)
+	go s.refreshLoop(ctx)
+
+	if s.bitcoin != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.bitcoin.run(ctx, s.stopChan, s.enqueue, s.networks[models.NetworkBitcoin])
+		}()
+	}
+	if s.ethereum != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.ethereum.run(ctx, s.stopChan, s.enqueue, s.networks[models.NetworkEthereum])
+		}()
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the mempool monitoring service
+func (s *Service) Stop() {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = false
+	s.mu.Unlock()
+
+	s.logger.Info("Stopping mempool monitoring service")
+	close(s.stopChan)
+	s.wg.Wait()
+	s.notifier.wait()
+}
+
+// refreshLoop reloads the frozen and watchlisted addresses
+func (s *Service) refreshLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(parseDuration(s.cfg.Mempool.RefreshInterval, 15*time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.mu.RLock()
+			previous := s.index
+			s.mu.RUnlock()
+
+			idx, err := s.loadIndex(ctx, previous)
+			if err != nil {
+				s.logger.Error("Failed to refresh mempool screening index", zap.Error(err))
+				continue
+			}
+
+			s.mu.Lock()
+			s.index = idx
+			s.mu.Unlock()
+		}
+	}
+}
+
+// enqueue hands an announced transaction to the workers. Transactions are
+// dropped rather than blocking the listener when the queue is full.
+func (s *Service) enqueue(tx pendingTx) {
+	status := s.networks[tx.network]
+	status.received(tx.seenAt)
+
+	select {
+	case s.queue <- tx:
+	default:
+		status.dropped()
+	}
+}
+
+// worker loads and screens queued transactions
+func (s *Service) worker(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case pending := <-s.queue:
+			tx, err := pending.load(ctx)
+			if err != nil {
+				s.logger.Debug("Failed to load mempool transaction",
+					zap.String("network", string(pending.network)),
+					zap.String("tx_hash", pending.hash),
+					zap.Error(err))
+				continue
+			}
+			if tx == nil {
+				continue
+			}
+
+			if err := s.Screen(ctx, tx, pending.seenAt); err != nil {
+				s.logger.Error("Failed to screen mempool transaction",
+					zap.String("network", string(pending.network)),
+					zap.String("tx_hash", pending.hash),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// Screen checks an unconfirmed transaction against the frozen and watchlisted
+// addresses and the address watches. A match is recorded as an interdiction,
+// sent to the licensed exchanges and alerted on, once per transaction.
+func (s *Service) Screen(ctx context.Context, tx *models.NormalizedTransaction, seenAt time.Time) error {
+	if s.watchSvc.MempoolEnabled(tx.Network) {
+		if err := s.watchSvc.CheckTransactions(ctx, []*models.NormalizedTransaction{tx}, models.WatchStageMempool); err != nil {
+			s.logger.Warn("Failed to check address watches",
+				zap.String("tx_hash", tx.TxHash),
+				zap.Error(err))
+		}
+	}
+
+	s.mu.RLock()
+	idx := s.index
+	s.mu.RUnlock()
+	if idx == nil {
+		return nil
+	}
+
+	matches := idx.match(tx)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	interdiction := &models.MempoolInterdiction{
+		ID:         uuid.New().String(),
+		TxHash:     tx.TxHash,
+		Network:    tx.Network,
+		Asset:      tx.Asset,
+		TotalValue: tx.TotalValue,
+		Matches:    matches,
+		SeenAt:     seenAt,
+		DetectedAt: time.Now(),
+	}
+
+	created, err := s.repo.SaveMempoolInterdiction(ctx, interdiction)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+
+	if status, ok := s.networks[tx.Network]; ok {
+		status.interdicted()
+	}
+
+	// Exchanges are notified before the alert is written; they have only
+	// until the transaction confirms to act on it
+	s.notifier.notify(interdiction)
+
+	if err := s.raiseAlert(ctx, interdiction); err != nil {
+		s.logger.Error("Failed to raise mempool interdiction alert",
+			zap.String("interdiction_id", interdiction.ID),
+			zap.Error(err))
+	}
+
+	return nil
+}
+
+// raiseAlert stores an alert for the interdiction: critical when a frozen
+// address is involved, high for a watchlist match only
+func (s *Service) raiseAlert(ctx context.Context, interdiction *models.MempoolInterdiction) error {
+	severity := models.SeverityHigh
+	var addresses []string
+	for _, match := range interdiction.Matches {
+		addresses = append(addresses, match.Address)
+		for _, reason := range match.Reasons {
+			if reason == models.InterdictionReasonFrozen {
+				severity = models.SeverityCritical
+			}
+		}
+	}
+
+	target := interdiction.Matches[0].Address
+	alert := models.NewAlert(models.AlertTypeMempoolInterdiction, severity, "wallet", target,
+		fmt.Sprintf("Unconfirmed %s transaction involves a frozen or watchlisted address", interdiction.Asset))
+	alert.TargetValue = target
+	alert.Description = fmt.Sprintf(
+		"Transaction %s on %s was seen in the mempool moving %s %s and involves %s. %d licensed exchanges were notified to withhold crediting it.",
+		interdiction.TxHash, interdiction.Network, interdiction.TotalValue.String(), interdiction.Asset,
+		strings.Join(addresses, ", "), len(s.cfg.Mempool.Exchanges))
+	alert.RuleName = "mempool_interdiction"
+	alert.Evidence = []string{interdiction.TxHash}
+	alert.Metadata = map[string]interface{}{
+		"network":         interdiction.Network,
+		"interdiction_id": interdiction.ID,
+		"matches":         interdiction.Matches,
+		"seen_at":         interdiction.SeenAt,
+	}
+
+	if err := s.repo.CreateAlert(ctx, alert); err != nil {
+		return err
+	}
+
+	data, _ := json.Marshal(interdiction)
+	if err := s.repo.AddAlertEvidence(ctx, &models.AlertEvidence{
+		ID:           uuid.New().String(),
+		AlertID:      alert.ID,
+		EvidenceType: "transaction",
+		ReferenceID:  interdiction.TxHash,
+		Description:  "Unconfirmed transaction seen in the mempool",
+		Data:         string(data),
+		CreatedAt:    time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetMempoolInterdictionAlert(ctx, interdiction.ID, alert.ID); err != nil {
+		return err
+	}
+	interdiction.AlertID = alert.ID
+
+	s.logger.Warn("Mempool interdiction recorded",
+		zap.String("alert_id", alert.ID),
+		zap.String("interdiction_id", interdiction.ID),
+		zap.String("network", string(interdiction.Network)),
+		zap.String("tx_hash", interdiction.TxHash),
+		zap.Strings("addresses", addresses))
+
+	return nil
+}
+
+// GetInterdiction returns an interdiction with its exchange notifications
+func (s *Service) GetInterdiction(ctx context.Context, id string) (*models.MempoolInterdiction, error) {
+	interdiction, err := s.repo.GetMempoolInterdiction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if interdiction == nil {
+		return nil, ErrInterdictionNotFound
+	}
+	return interdiction, nil
+}
+
+// ListInterdictions returns recorded interdictions matching the filter
+func (s *Service) ListInterdictions(ctx context.Context, filter models.MempoolInterdictionFilter) ([]models.MempoolInterdiction, error) {
+	if filter.Address != "" {
+		filter.Address = normalizeAddress(filter.Network, filter.Address)
+	}
+	return s.repo.ListMempoolInterdictions(ctx, filter)
+}
+
+// GetStatus reports the mempool listeners and the screening index
+func (s *Service) GetStatus() *models.MempoolStatus {
+	status := &models.MempoolStatus{
+		Enabled:    s.cfg.Mempool.Enabled,
+		Networks:   []models.MempoolNetworkStatus{},
+		QueueDepth: len(s.queue),
+	}
+	for _, name := range s.cfg.Mempool.Networks {
+		if network, ok := s.networks[models.Network(name)]; ok {
+			status.Networks = append(status.Networks, network.snapshot())
+		}
+	}
+
+	s.mu.RLock()
+	idx := s.index
+	s.mu.RUnlock()
+	if idx != nil {
+		status.FrozenAddresses = len(idx.frozen)
+		status.WatchlistAddresses = len(idx.watchlist)
+		status.IndexRefreshedAt = &idx.refreshedAt
+	}
+
+	return status
+}
+
+// networkStatus tracks the mempool listener of one network
+type networkStatus struct {
+	mu      sync.Mutex
+	network models.Network
+	status  models.MempoolNetworkStatus
+}
+
+func (n *networkStatus) connected(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Connected = err == nil
+	if err != nil {
+		n.status.LastError = err.Error()
+	}
+}
+
+func (n *networkStatus) received(at time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Received++
+	n.status.LastSeenAt = &at
+}
+
+func (n *networkStatus) dropped() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Dropped++
+}
+
+func (n *networkStatus) interdicted() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Interdicted++
+}
+
+func (n *networkStatus) snapshot() models.MempoolNetworkStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	snapshot := n.status
+	snapshot.Network = n.network
+	return snapshot
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
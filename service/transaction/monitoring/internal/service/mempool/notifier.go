@@ -0,0 +1,155 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Headers of interdiction webhooks. The signature is the hex HMAC-SHA256 of
+// the timestamp, a dot and the body, keyed with the exchange's secret.
+const (
+	headerSignature = "X-CSIC-Signature"
+	headerTimestamp = "X-CSIC-Timestamp"
+	headerLicense   = "X-CSIC-License-ID"
+)
+
+// interdictionEvent is the webhook payload sent to exchanges
+type interdictionEvent struct {
+	Event        string                      `json:"event"`
+	Instruction  string                      `json:"instruction"`
+	Interdiction *models.MempoolInterdiction `json:"interdiction"`
+}
+
+// exchangeNotifier posts interdictions to the licensed exchanges' webhooks
+type exchangeNotifier struct {
+	exchanges  []config.ExchangeWebhookConfig
+	repo       *repository.Repository
+	httpClient *http.Client
+	logger     *zap.Logger
+	wg         sync.WaitGroup
+}
+
+func newExchangeNotifier(cfg config.MempoolConfig, repo *repository.Repository, logger *zap.Logger) *exchangeNotifier {
+	return &exchangeNotifier{
+		exchanges: cfg.Exchanges,
+		repo:      repo,
+		httpClient: &http.Client{
+			Timeout: parseDuration(cfg.NotifyTimeout, 3*time.Second),
+		},
+		logger: logger,
+	}
+}
+
+// notify posts the interdiction to every exchange concurrently and records
+// each delivery. It returns without waiting for the deliveries.
+func (n *exchangeNotifier) notify(interdiction *models.MempoolInterdiction) {
+	if len(n.exchanges) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(interdictionEvent{
+		Event:        "mempool.interdiction",
+		Instruction:  "withhold_credit",
+		Interdiction: interdiction,
+	})
+	if err != nil {
+		n.logger.Error("Failed to encode interdiction", zap.Error(err))
+		return
+	}
+
+	for _, exchange := range n.exchanges {
+		n.wg.Add(1)
+		go func(exchange config.ExchangeWebhookConfig) {
+			defer n.wg.Done()
+			n.deliver(exchange, interdiction, body)
+		}(exchange)
+	}
+}
+
+// wait blocks until pending deliveries have finished
+func (n *exchangeNotifier) wait() {
+	n.wg.Wait()
+}
+
+func (n *exchangeNotifier) deliver(exchange config.ExchangeWebhookConfig, interdiction *models.MempoolInterdiction, body []byte) {
+	// Deliveries are bounded by the client timeout and must not be cut short
+	// by the worker that detected the interdiction moving on
+	ctx := context.Background()
+
+	notification := &models.InterdictionNotification{
+		ID:             uuid.New().String(),
+		InterdictionID: interdiction.ID,
+		Exchange:       exchange.Name,
+		Status:         models.WebhookStatusDelivered,
+	}
+
+	statusCode, err := n.post(ctx, exchange, body)
+	notification.SentAt = time.Now()
+	notification.LatencyMs = notification.SentAt.Sub(interdiction.SeenAt).Milliseconds()
+	notification.StatusCode = statusCode
+	if err != nil {
+		notification.Status = models.WebhookStatusFailed
+		notification.Error = err.Error()
+		n.logger.Warn("Interdiction webhook failed",
+			zap.String("exchange", exchange.Name),
+			zap.String("interdiction_id", interdiction.ID),
+			zap.Error(err))
+	}
+
+	if err := n.repo.SaveInterdictionNotification(ctx, notification); err != nil {
+		n.logger.Error("Failed to record interdiction notification",
+			zap.String("exchange", exchange.Name),
+			zap.String("interdiction_id", interdiction.ID),
+			zap.Error(err))
+	}
+}
+
+func (n *exchangeNotifier) post(ctx context.Context, exchange config.ExchangeWebhookConfig, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchange.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerTimestamp, timestamp)
+	req.Header.Set(headerSignature, sign(exchange.Secret, timestamp, body))
+	if exchange.LicenseID != "" {
+		req.Header.Set(headerLicense, exchange.LicenseID)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// sign returns the webhook signature of a body sent at timestamp
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
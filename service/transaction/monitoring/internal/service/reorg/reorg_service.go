@@ -0,0 +1,182 @@
+package reorg
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Service keeps the transaction store consistent with the canonical chain.
+// It records the blocks ingestion sees, confirms transactions once their
+// block reaches the chain's confirmation depth, and on a reorganization marks
+// the transactions of orphaned blocks as reorged, withdraws the alerts, watch
+// triggers and bridge matches derived from them and rolls back the UTXO set.
+type Service struct {
+	cfg    *config.Config
+	repo   *repository.Repository
+	logger *zap.Logger
+
+	// mu serializes block observations so that concurrent sources of the
+	// same chain, such as node polling and the raw Kafka topic, see a
+	// consistent canonical chain
+	mu sync.Mutex
+}
+
+// NewService creates a new reorganization handling service
+func NewService(cfg *config.Config, repo *repository.Repository, logger *zap.Logger) *Service {
+	return &Service{
+		cfg:    cfg,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ObserveBlock records a new block. It must be called before the block's
+// transactions are saved, so that transactions the block re-includes after a
+// reorganization are restored rather than marked reorged. A block that
+// replaces the canonical block at its height, or whose parent is not the
+// canonical block below it, reveals a reorganization, which is handled before
+// the block is recorded. It returns the reorganization, if any.
+func (s *Service) ObserveBlock(ctx context.Context, block *models.ChainBlock) (*models.ChainReorg, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.repo.GetCanonicalBlock(ctx, block.Network, block.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get canonical block: %w", err)
+	}
+
+	forkHeight := int64(-1)
+	if current != nil && current.Hash != block.Hash {
+		forkHeight = block.Number - 1
+	}
+
+	// A different parent means the block below was replaced too. The
+	// replacement was missed, so the fork is taken to be below the parent;
+	// sources that can read the chain locate the fork themselves and call
+	// Reorg first.
+	parent, err := s.repo.GetCanonicalBlock(ctx, block.Network, block.Number-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent block: %w", err)
+	}
+	if parent != nil && block.ParentHash != "" && parent.Hash != block.ParentHash {
+		forkHeight = block.Number - 2
+	}
+
+	var reorg *models.ChainReorg
+	if forkHeight >= 0 {
+		reorg, err = s.reorg(ctx, block.Network, forkHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if block.SeenAt.IsZero() {
+		block.SeenAt = time.Now()
+	}
+	if err := s.repo.SaveChainBlock(ctx, block); err != nil {
+		return nil, fmt.Errorf("failed to save block: %w", err)
+	}
+
+	// A transaction in the tip block has one confirmation
+	depth := s.cfg.Blockchain.ConfirmationDepth(string(block.Network))
+	confirmed, err := s.repo.ConfirmTransactions(ctx, block.Network, block.Number-int64(depth)+1)
+	if err != nil {
+		return reorg, fmt.Errorf("failed to confirm transactions: %w", err)
+	}
+	if confirmed > 0 {
+		s.logger.Debug("Confirmed transactions",
+			zap.String("network", string(block.Network)),
+			zap.Int64("block", block.Number),
+			zap.Int64("count", confirmed))
+	}
+
+	return reorg, nil
+}
+
+// Reorg handles a reorganization that replaced the blocks above forkHeight,
+// the highest block shared by the old and new chains. It returns nil if no
+// recorded block or transaction was affected.
+func (s *Service) Reorg(ctx context.Context, network models.Network, forkHeight int64) (*models.ChainReorg, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reorg(ctx, network, forkHeight)
+}
+
+func (s *Service) reorg(ctx context.Context, network models.Network, forkHeight int64) (*models.ChainReorg, error) {
+	now := time.Now()
+
+	orphaned, err := s.repo.OrphanBlocksAbove(ctx, network, forkHeight, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to orphan blocks: %w", err)
+	}
+
+	reorged, err := s.repo.ReorgTransactions(ctx, network, forkHeight, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark reorged transactions: %w", err)
+	}
+
+	if s.cfg.Blockchain.IsUTXO(string(network)) {
+		if err := s.repo.RevertUTXOsAbove(ctx, network, forkHeight); err != nil {
+			return nil, fmt.Errorf("failed to revert UTXO set: %w", err)
+		}
+	}
+
+	if len(orphaned) == 0 && len(reorged) == 0 {
+		return nil, nil
+	}
+
+	reorg := &models.ChainReorg{
+		ID:             uuid.New().String(),
+		Network:        network,
+		ForkHeight:     forkHeight,
+		Depth:          len(orphaned),
+		OrphanedBlocks: orphaned,
+		ReorgedTxs:     reorged,
+		DetectedAt:     now,
+	}
+
+	if len(reorged) > 0 {
+		reason := fmt.Sprintf("%s reorganization above block %d", network, forkHeight)
+		reorg.InvalidatedAlerts, reorg.FlaggedAlerts, err = s.repo.InvalidateTransactionResults(ctx, reorged, reason, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to invalidate results of reorged transactions: %w", err)
+		}
+	}
+
+	if err := s.repo.SaveChainReorg(ctx, reorg); err != nil {
+		return nil, fmt.Errorf("failed to save reorganization: %w", err)
+	}
+
+	fields := []zap.Field{
+		zap.String("network", string(network)),
+		zap.Int64("fork_height", forkHeight),
+		zap.Int("depth", reorg.Depth),
+		zap.Int("reorged_txs", len(reorged)),
+		zap.Int("invalidated_alerts", len(reorg.InvalidatedAlerts)),
+		zap.Int("flagged_alerts", len(reorg.FlaggedAlerts)),
+	}
+
+	// A reorganization at least as deep as the confirmation depth has
+	// reverted transactions that were reported as final
+	if reorg.Depth >= s.cfg.Blockchain.ConfirmationDepth(string(network)) {
+		s.logger.Error("Chain reorganization deeper than confirmation depth", fields...)
+	} else {
+		s.logger.Warn("Chain reorganization", fields...)
+	}
+
+	return reorg, nil
+}
+
+// ListReorgs returns recent reorganizations, optionally for one network
+func (s *Service) ListReorgs(ctx context.Context, network models.Network, since time.Time, limit int) ([]models.ChainReorg, error) {
+	return s.repo.ListChainReorgs(ctx, network, since, limit)
+}
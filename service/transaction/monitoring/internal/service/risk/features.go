@@ -0,0 +1,193 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+)
+
+// Feature names produced by the FeatureExtractor
+const (
+	FeatureWalletAgeDays     = "wallet_age_days"
+	FeatureNewWallet         = "new_wallet"
+	FeatureLogTxCount        = "log_tx_count"
+	FeatureLogTotalReceived  = "log_total_received"
+	FeatureLogTotalSent      = "log_total_sent"
+	FeaturePassThroughRatio  = "pass_through_ratio"
+	FeatureIncomingRatio     = "incoming_ratio"
+	FeatureLogCounterparties = "log_unique_counterparties"
+	FeatureLogAvgTxSize      = "log_avg_tx_size"
+	FeatureLogMaxTxSize      = "log_max_tx_size"
+	FeatureRoundAmountRatio  = "round_amount_ratio"
+	FeatureTxCount24h        = "tx_count_24h"
+	FeatureLogVolume24h      = "log_volume_24h"
+	FeatureActiveDays30d     = "active_days_30d"
+	FeatureIsSanctioned      = "is_sanctioned"
+	FeatureIsBlacklisted     = "is_blacklisted"
+	FeatureClusterRisk       = "cluster_risk"
+)
+
+// KnownFeatures lists every feature the extractor produces, in a stable order
+var KnownFeatures = []string{
+	FeatureWalletAgeDays,
+	FeatureNewWallet,
+	FeatureLogTxCount,
+	FeatureLogTotalReceived,
+	FeatureLogTotalSent,
+	FeaturePassThroughRatio,
+	FeatureIncomingRatio,
+	FeatureLogCounterparties,
+	FeatureLogAvgTxSize,
+	FeatureLogMaxTxSize,
+	FeatureRoundAmountRatio,
+	FeatureTxCount24h,
+	FeatureLogVolume24h,
+	FeatureActiveDays30d,
+	FeatureIsSanctioned,
+	FeatureIsBlacklisted,
+	FeatureClusterRisk,
+}
+
+// FeatureExtractor builds model inputs from a wallet and its transaction history
+type FeatureExtractor struct {
+	repo         *repository.Repository
+	historyLimit int
+}
+
+// NewFeatureExtractor creates a new feature extractor
+func NewFeatureExtractor(repo *repository.Repository, historyLimit int) *FeatureExtractor {
+	if historyLimit <= 0 {
+		historyLimit = 500
+	}
+	return &FeatureExtractor{
+		repo:         repo,
+		historyLimit: historyLimit,
+	}
+}
+
+// Extract loads the wallet's recent history and cluster and computes its features
+func (e *FeatureExtractor) Extract(ctx context.Context, wallet *models.Wallet) (models.FeatureVector, error) {
+	history, err := e.repo.GetTransactionHistory(ctx, wallet.Address, wallet.Network, e.historyLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction history: %w", err)
+	}
+
+	clusterRisk := 0.0
+	if wallet.ClusterID != nil {
+		cluster, err := e.repo.GetClusterByID(ctx, *wallet.ClusterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster: %w", err)
+		}
+		if cluster != nil {
+			clusterRisk = clusterRiskValue(cluster.RiskLevel)
+		}
+	}
+
+	return ComputeFeatures(wallet, history, clusterRisk, time.Now()), nil
+}
+
+// ComputeFeatures derives the feature vector for a wallet from its history.
+// Monetary features are log-scaled so that a handful of very large transfers
+// does not dominate linear models.
+func ComputeFeatures(wallet *models.Wallet, history []models.Transaction, clusterRisk float64, now time.Time) models.FeatureVector {
+	features := make(models.FeatureVector, len(KnownFeatures))
+	for _, name := range KnownFeatures {
+		features[name] = 0
+	}
+
+	ageDays := now.Sub(wallet.FirstSeen).Hours() / 24
+	if wallet.FirstSeen.IsZero() || ageDays < 0 {
+		ageDays = 0
+	}
+	features[FeatureWalletAgeDays] = math.Round(ageDays*100) / 100
+	if ageDays < 7 {
+		features[FeatureNewWallet] = 1
+	}
+
+	received, _ := wallet.TotalReceived.Float64()
+	sent, _ := wallet.TotalSent.Float64()
+	features[FeatureLogTxCount] = math.Log1p(float64(wallet.TxCount))
+	features[FeatureLogTotalReceived] = math.Log1p(math.Max(received, 0))
+	features[FeatureLogTotalSent] = math.Log1p(math.Max(sent, 0))
+	if received > 0 {
+		features[FeaturePassThroughRatio] = math.Min(sent/received, 1.5)
+	}
+
+	if wallet.IsSanctioned {
+		features[FeatureIsSanctioned] = 1
+	}
+	if wallet.IsBlacklisted {
+		features[FeatureIsBlacklisted] = 1
+	}
+	features[FeatureClusterRisk] = clusterRisk
+
+	if len(history) == 0 {
+		return features
+	}
+
+	var incoming, round, count24h int
+	var total, max, volume24h float64
+	counterparties := make(map[string]struct{})
+	activeDays := make(map[string]struct{})
+
+	for _, tx := range history {
+		amount, _ := tx.Amount.Float64()
+		total += amount
+		if amount > max {
+			max = amount
+		}
+		if amount > 0 && tx.Amount.Equal(tx.Amount.Round(0)) {
+			round++
+		}
+
+		if tx.Receiver == wallet.Address {
+			incoming++
+			if tx.Sender != "" {
+				counterparties[tx.Sender] = struct{}{}
+			}
+		} else if tx.Receiver != "" {
+			counterparties[tx.Receiver] = struct{}{}
+		}
+
+		age := now.Sub(tx.Timestamp)
+		if age <= 24*time.Hour {
+			count24h++
+			volume24h += amount
+		}
+		if age <= 30*24*time.Hour {
+			activeDays[tx.Timestamp.Format("2006-01-02")] = struct{}{}
+		}
+	}
+
+	n := float64(len(history))
+	features[FeatureIncomingRatio] = float64(incoming) / n
+	features[FeatureLogCounterparties] = math.Log1p(float64(len(counterparties)))
+	features[FeatureLogAvgTxSize] = math.Log1p(math.Max(total/n, 0))
+	features[FeatureLogMaxTxSize] = math.Log1p(math.Max(max, 0))
+	features[FeatureRoundAmountRatio] = float64(round) / n
+	features[FeatureTxCount24h] = float64(count24h)
+	features[FeatureLogVolume24h] = math.Log1p(math.Max(volume24h, 0))
+	features[FeatureActiveDays30d] = float64(len(activeDays))
+
+	return features
+}
+
+// clusterRiskValue maps a cluster risk level onto [0, 1]
+func clusterRiskValue(level string) float64 {
+	switch level {
+	case "critical":
+		return 1
+	case "high":
+		return 0.75
+	case "medium":
+		return 0.5
+	case "low":
+		return 0.25
+	default:
+		return 0
+	}
+}
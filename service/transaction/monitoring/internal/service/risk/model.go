@@ -0,0 +1,189 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+)
+
+// interceptParameter is the reserved parameter key holding a model's bias term
+const interceptParameter = "intercept"
+
+var (
+	// ErrInvalidModel is returned when a model specification cannot be built
+	ErrInvalidModel = errors.New("invalid risk model")
+	// ErrModelNotFound is returned when a registered model does not exist
+	ErrModelNotFound = errors.New("risk model not found")
+)
+
+// Model scores a feature vector on a 0-100 scale and reports the
+// contribution of each feature to that score
+type Model interface {
+	Score(features models.FeatureVector) (float64, []models.FeatureContribution)
+}
+
+// ModelFactory builds a Model from a registry entry
+type ModelFactory func(spec *models.RiskModel) (Model, error)
+
+var (
+	modelTypesMu sync.RWMutex
+	modelTypes   = map[string]ModelFactory{
+		"linear":   newLinearModel,
+		"logistic": newLogisticModel,
+	}
+)
+
+// RegisterModelType makes a model type available to the scoring pipeline
+func RegisterModelType(modelType string, factory ModelFactory) {
+	modelTypesMu.Lock()
+	defer modelTypesMu.Unlock()
+	modelTypes[modelType] = factory
+}
+
+// BuildModel validates a registry entry and builds the model it describes
+func BuildModel(spec *models.RiskModel) (Model, error) {
+	modelTypesMu.RLock()
+	factory, ok := modelTypes[spec.ModelType]
+	modelTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown model type %q", ErrInvalidModel, spec.ModelType)
+	}
+
+	if len(spec.Features) == 0 {
+		return nil, fmt.Errorf("%w: at least one feature is required", ErrInvalidModel)
+	}
+
+	known := make(map[string]bool, len(KnownFeatures))
+	for _, name := range KnownFeatures {
+		known[name] = true
+	}
+	for _, name := range spec.Features {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown feature %q", ErrInvalidModel, name)
+		}
+		if _, ok := spec.Parameters[name]; !ok {
+			return nil, fmt.Errorf("%w: no weight for feature %q", ErrInvalidModel, name)
+		}
+	}
+
+	return factory(spec)
+}
+
+// BaselineModel returns the built-in model used when no registered model is active
+func BaselineModel() *models.RiskModel {
+	return &models.RiskModel{
+		Name:      "baseline",
+		Version:   "builtin",
+		ModelType: "linear",
+		Features: []string{
+			FeatureIsSanctioned,
+			FeatureIsBlacklisted,
+			FeatureClusterRisk,
+			FeatureNewWallet,
+			FeatureRoundAmountRatio,
+			FeaturePassThroughRatio,
+			FeatureIncomingRatio,
+			FeatureLogVolume24h,
+			FeatureLogCounterparties,
+		},
+		Parameters: map[string]float64{
+			interceptParameter:       0,
+			FeatureIsSanctioned:      100,
+			FeatureIsBlacklisted:     80,
+			FeatureClusterRisk:       40,
+			FeatureNewWallet:         15,
+			FeatureRoundAmountRatio:  15,
+			FeaturePassThroughRatio:  10,
+			FeatureIncomingRatio:     10,
+			FeatureLogVolume24h:      2,
+			FeatureLogCounterparties: 1,
+		},
+		Status: models.RiskModelStatusActive,
+	}
+}
+
+// weightedSum computes intercept + sum(weight * value) and each feature's term
+type weightedSum struct {
+	features  []string
+	weights   map[string]float64
+	intercept float64
+}
+
+func newWeightedSum(spec *models.RiskModel) weightedSum {
+	return weightedSum{
+		features:  spec.Features,
+		weights:   spec.Parameters,
+		intercept: spec.Parameters[interceptParameter],
+	}
+}
+
+func (w weightedSum) evaluate(features models.FeatureVector) (float64, []models.FeatureContribution) {
+	total := w.intercept
+	contributions := make([]models.FeatureContribution, 0, len(w.features))
+	for _, name := range w.features {
+		value := features[name]
+		weight := w.weights[name]
+		total += weight * value
+		contributions = append(contributions, models.FeatureContribution{
+			Feature:      name,
+			Value:        value,
+			Weight:       weight,
+			Contribution: weight * value,
+		})
+	}
+	return total, contributions
+}
+
+// linearModel clamps a weighted sum of features to 0-100.
+// Contributions are expressed in score points.
+type linearModel struct {
+	weightedSum
+}
+
+func newLinearModel(spec *models.RiskModel) (Model, error) {
+	return &linearModel{weightedSum: newWeightedSum(spec)}, nil
+}
+
+func (m *linearModel) Score(features models.FeatureVector) (float64, []models.FeatureContribution) {
+	total, contributions := m.evaluate(features)
+	return math.Max(0, math.Min(total, 100)), contributions
+}
+
+// logisticModel maps a weighted sum of features through the logistic function
+// and scales the resulting probability to 0-100.
+// Contributions are expressed in log-odds.
+type logisticModel struct {
+	weightedSum
+}
+
+func newLogisticModel(spec *models.RiskModel) (Model, error) {
+	return &logisticModel{weightedSum: newWeightedSum(spec)}, nil
+}
+
+func (m *logisticModel) Score(features models.FeatureVector) (float64, []models.FeatureContribution) {
+	logit, contributions := m.evaluate(features)
+	return 100 / (1 + math.Exp(-logit)), contributions
+}
+
+// topContributions returns the n features with the largest absolute contribution
+func topContributions(contributions []models.FeatureContribution, n int) []models.FeatureContribution {
+	sorted := make([]models.FeatureContribution, 0, len(contributions))
+	for _, c := range contributions {
+		if c.Contribution != 0 {
+			sorted = append(sorted, c)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return math.Abs(sorted[i].Contribution) > math.Abs(sorted[j].Contribution)
+	})
+
+	if n > 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
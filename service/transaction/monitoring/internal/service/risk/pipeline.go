@@ -0,0 +1,436 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrWalletNotFound is returned when scoring an address that has never been seen
+	ErrWalletNotFound = errors.New("wallet not found")
+	// ErrRescoringInProgress is returned when a re-scoring job is already running
+	ErrRescoringInProgress = errors.New("re-scoring job already in progress")
+)
+
+// ScoringPipeline scores wallets with the active registered model: features are
+// extracted from transaction history, scored by the model and persisted together
+// with the top contributing features. When no model is active the built-in
+// baseline model is used.
+type ScoringPipeline struct {
+	cfg       *config.Config
+	repo      *repository.Repository
+	cache     *repository.CacheRepository
+	extractor *FeatureExtractor
+	logger    *zap.Logger
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	isRunning bool
+	runCtx    context.Context
+
+	spec       *models.RiskModel
+	model      Model
+	currentJob *models.RescoringJob
+}
+
+// NewScoringPipeline creates a new scoring pipeline using the baseline model
+func NewScoringPipeline(
+	cfg *config.Config,
+	repo *repository.Repository,
+	cache *repository.CacheRepository,
+	logger *zap.Logger,
+) *ScoringPipeline {
+	baseline := BaselineModel()
+	model, _ := BuildModel(baseline)
+
+	return &ScoringPipeline{
+		cfg:       cfg,
+		repo:      repo,
+		cache:     cache,
+		extractor: NewFeatureExtractor(repo, cfg.RiskScoring.Pipeline.HistoryLimit),
+		logger:    logger,
+		stopChan:  make(chan struct{}),
+		runCtx:    context.Background(),
+		spec:      baseline,
+		model:     model,
+	}
+}
+
+// Start loads the active model and begins scheduled re-scoring
+func (p *ScoringPipeline) Start(ctx context.Context) error {
+	p.mu.Lock()
+	if p.isRunning {
+		p.mu.Unlock()
+		return nil
+	}
+	p.isRunning = true
+	p.runCtx = ctx
+	p.mu.Unlock()
+
+	p.logger.Info("Starting risk scoring pipeline")
+
+	if err := p.ReloadModel(ctx); err != nil {
+		return fmt.Errorf("failed to load active risk model: %w", err)
+	}
+
+	if p.cfg.RiskScoring.Pipeline.RescoreEnabled {
+		p.wg.Add(1)
+		go p.rescoringLoop(ctx)
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the pipeline and waits for running jobs
+func (p *ScoringPipeline) Stop() {
+	p.mu.Lock()
+	if !p.isRunning {
+		p.mu.Unlock()
+		return
+	}
+	p.isRunning = false
+	p.mu.Unlock()
+
+	p.logger.Info("Stopping risk scoring pipeline")
+	close(p.stopChan)
+	p.wg.Wait()
+}
+
+// rescoringLoop periodically re-scores all wallets
+func (p *ScoringPipeline) rescoringLoop(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(parseDuration(p.cfg.RiskScoring.Pipeline.RescoreInterval, 24*time.Hour))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopChan:
+			return
+		case <-ticker.C:
+			job, err := p.beginRescoring(ctx, "scheduled")
+			if err != nil {
+				p.logger.Warn("Skipping scheduled re-scoring", zap.Error(err))
+				continue
+			}
+			p.runRescoring(ctx, job)
+		}
+	}
+}
+
+// ReloadModel swaps in the active registered model, falling back to the baseline
+func (p *ScoringPipeline) ReloadModel(ctx context.Context) error {
+	spec, err := p.repo.GetActiveRiskModel(ctx)
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		spec = BaselineModel()
+	}
+
+	model, err := BuildModel(spec)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.spec = spec
+	p.model = model
+	p.mu.Unlock()
+
+	p.logger.Info("Risk model loaded",
+		zap.String("name", spec.Name),
+		zap.String("version", spec.Version),
+		zap.String("type", spec.ModelType))
+
+	return nil
+}
+
+// ActiveModel returns the model currently used for scoring
+func (p *ScoringPipeline) ActiveModel() *models.RiskModel {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.spec
+}
+
+// ScoreAddress scores a known wallet and returns the explanation
+func (p *ScoringPipeline) ScoreAddress(ctx context.Context, address string, network models.Network) (*models.RiskScoreExplanation, error) {
+	wallet, err := p.repo.GetWalletByAddress(ctx, address, network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	return p.ScoreWallet(ctx, wallet)
+}
+
+// ScoreWallet extracts features, scores the wallet with the active model and
+// stores the new score, risk level and explanation
+func (p *ScoringPipeline) ScoreWallet(ctx context.Context, wallet *models.Wallet) (*models.RiskScoreExplanation, error) {
+	features, err := p.extractor.Extract(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	spec, model := p.spec, p.model
+	p.mu.RUnlock()
+
+	score, contributions := model.Score(features)
+	score = math.Round(score*100) / 100
+
+	explanation := &models.RiskScoreExplanation{
+		WalletID:     wallet.ID,
+		Address:      wallet.Address,
+		Network:      wallet.Network,
+		Score:        score,
+		RiskLevel:    riskLevelFor(p.cfg, score),
+		ModelID:      spec.ID,
+		ModelName:    spec.Name,
+		ModelVersion: spec.Version,
+		TopFeatures:  topContributions(contributions, p.cfg.RiskScoring.Pipeline.TopFeatures),
+		Features:     features,
+		ScoredAt:     time.Now().UTC(),
+	}
+
+	wallet.RiskScore = explanation.Score
+	wallet.RiskLevel = explanation.RiskLevel
+	if err := p.repo.UpdateWalletRiskScore(ctx, wallet); err != nil {
+		return nil, fmt.Errorf("failed to update wallet risk score: %w", err)
+	}
+
+	if err := p.repo.SaveRiskScore(ctx, explanation); err != nil {
+		p.logger.Warn("Failed to record risk score history", zap.Error(err))
+	}
+
+	if err := p.cache.SetWalletRiskScore(ctx, wallet.Address, wallet.Network, explanation.Score); err != nil {
+		p.logger.Warn("Failed to cache risk score", zap.Error(err))
+	}
+
+	return explanation, nil
+}
+
+// RegisterModel validates and stores a new model in the staged state
+func (p *ScoringPipeline) RegisterModel(ctx context.Context, spec *models.RiskModel) error {
+	if spec.Name == "" || spec.Version == "" {
+		return fmt.Errorf("%w: name and version are required", ErrInvalidModel)
+	}
+	if _, err := BuildModel(spec); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	spec.ID = uuid.New().String()
+	spec.Status = models.RiskModelStatusStaged
+	spec.ActivatedAt = nil
+	spec.CreatedAt = now
+	spec.UpdatedAt = now
+
+	return p.repo.CreateRiskModel(ctx, spec)
+}
+
+// ActivateModel makes a registered model the active one and starts a
+// re-scoring job so stored scores reflect the new model
+func (p *ScoringPipeline) ActivateModel(ctx context.Context, id string) (*models.RescoringJob, error) {
+	spec, err := p.repo.GetRiskModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		return nil, ErrModelNotFound
+	}
+
+	if _, err := BuildModel(spec); err != nil {
+		return nil, err
+	}
+
+	if err := p.repo.ActivateRiskModel(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to activate model: %w", err)
+	}
+
+	if err := p.ReloadModel(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.StartRescoring(ctx, "activation")
+}
+
+// StartRescoring starts a batch re-scoring job in the background
+func (p *ScoringPipeline) StartRescoring(ctx context.Context, trigger string) (*models.RescoringJob, error) {
+	job, err := p.beginRescoring(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	runCtx := p.runCtx
+	p.mu.RUnlock()
+
+	snapshot := *job
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.runRescoring(runCtx, job)
+	}()
+
+	return &snapshot, nil
+}
+
+// GetRescoringJob returns the progress of a re-scoring job
+func (p *ScoringPipeline) GetRescoringJob(ctx context.Context, id string) (*models.RescoringJob, error) {
+	return p.repo.GetRescoringJob(ctx, id)
+}
+
+// beginRescoring records a new job, refusing to start while another is running
+func (p *ScoringPipeline) beginRescoring(ctx context.Context, trigger string) (*models.RescoringJob, error) {
+	p.mu.Lock()
+	if p.currentJob != nil {
+		p.mu.Unlock()
+		return nil, ErrRescoringInProgress
+	}
+
+	job := &models.RescoringJob{
+		ID:        uuid.New().String(),
+		ModelID:   p.spec.ID,
+		Trigger:   trigger,
+		Status:    models.RescoringJobRunning,
+		StartedAt: time.Now().UTC(),
+	}
+	p.currentJob = job
+	p.mu.Unlock()
+
+	total, err := p.repo.CountWallets(ctx)
+	if err != nil {
+		p.logger.Warn("Failed to count wallets for re-scoring", zap.Error(err))
+	}
+	job.Total = total
+
+	if err := p.repo.CreateRescoringJob(ctx, job); err != nil {
+		p.mu.Lock()
+		p.currentJob = nil
+		p.mu.Unlock()
+		return nil, fmt.Errorf("failed to create re-scoring job: %w", err)
+	}
+
+	return job, nil
+}
+
+// runRescoring scores every wallet in pages, recording progress after each page
+func (p *ScoringPipeline) runRescoring(ctx context.Context, job *models.RescoringJob) {
+	defer func() {
+		p.mu.Lock()
+		p.currentJob = nil
+		p.mu.Unlock()
+	}()
+
+	batchSize := p.cfg.RiskScoring.Pipeline.RescoreBatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	p.logger.Info("Starting risk re-scoring",
+		zap.String("job_id", job.ID),
+		zap.String("trigger", job.Trigger),
+		zap.Int("total", job.Total))
+
+	afterID := ""
+	for {
+		select {
+		case <-ctx.Done():
+			p.finishRescoring(job, ctx.Err())
+			return
+		case <-p.stopChan:
+			p.finishRescoring(job, errors.New("pipeline stopped"))
+			return
+		default:
+		}
+
+		wallets, err := p.repo.ListWalletsForRescoring(ctx, afterID, batchSize)
+		if err != nil {
+			p.finishRescoring(job, err)
+			return
+		}
+		if len(wallets) == 0 {
+			break
+		}
+
+		for i := range wallets {
+			if _, err := p.ScoreWallet(ctx, &wallets[i]); err != nil {
+				job.Failed++
+				p.logger.Debug("Failed to re-score wallet",
+					zap.String("address", wallets[i].Address),
+					zap.Error(err))
+				continue
+			}
+			job.Scored++
+		}
+		afterID = wallets[len(wallets)-1].ID
+
+		if err := p.repo.UpdateRescoringJob(ctx, job); err != nil {
+			p.logger.Warn("Failed to record re-scoring progress", zap.Error(err))
+		}
+	}
+
+	p.finishRescoring(job, nil)
+}
+
+func (p *ScoringPipeline) finishRescoring(job *models.RescoringJob, err error) {
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	job.Status = models.RescoringJobCompleted
+	if err != nil {
+		job.Status = models.RescoringJobFailed
+		job.Error = err.Error()
+	}
+
+	// Use a fresh context so the outcome is recorded even after cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := p.repo.UpdateRescoringJob(ctx, job); err != nil {
+		p.logger.Error("Failed to record re-scoring outcome", zap.Error(err))
+	}
+
+	p.logger.Info("Risk re-scoring finished",
+		zap.String("job_id", job.ID),
+		zap.String("status", string(job.Status)),
+		zap.Int("scored", job.Scored),
+		zap.Int("failed", job.Failed))
+}
+
+// riskLevelFor maps a score onto the configured risk levels
+func riskLevelFor(cfg *config.Config, score float64) string {
+	switch {
+	case score >= float64(cfg.RiskScoring.CriticalThreshold):
+		return "critical"
+	case score >= float64(cfg.RiskScoring.HighThreshold):
+		return "high"
+	case score >= float64(cfg.RiskScoring.MediumThreshold):
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// parseDuration parses a duration string, returning fallback when empty or invalid
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
@@ -277,38 +277,63 @@ func (s *RiskScoringService) getTriggeredRules(factors *models.RiskFactors) []st
 
 // UpdateWalletRiskScore updates the risk score for a wallet
 func (s *RiskScoringService) UpdateWalletRiskScore(ctx context.Context, address string, network models.Network) error {
-	wallet, err := s.repo.GetWalletByAddress(ctx, address, network)
+	score, err := s.rescoreWallet(ctx, address, network)
 	if err != nil {
 		return err
 	}
 
+	// Cache the score
+	if err := s.cache.SetWalletRiskScore(ctx, address, network, score); err != nil {
+		s.logger.Warn("Failed to cache risk score", zap.Error(err))
+	}
+
+	return nil
+}
+
+// UpdateWalletRiskScores updates the risk scores of several wallets and caches
+// them in one round trip. Wallets that cannot be scored are logged and skipped.
+func (s *RiskScoringService) UpdateWalletRiskScores(ctx context.Context, wallets []models.WalletKey) {
+	scores := make(map[models.WalletKey]float64, len(wallets))
+	for _, wallet := range wallets {
+		score, err := s.rescoreWallet(ctx, wallet.Address, wallet.Network)
+		if err != nil {
+			s.logger.Warn("Failed to update wallet risk score",
+				zap.String("address", wallet.Address),
+				zap.Error(err))
+			continue
+		}
+		scores[wallet] = score
+	}
+
+	if err := s.cache.SetWalletRiskScores(ctx, scores); err != nil {
+		s.logger.Warn("Failed to cache risk scores", zap.Int("wallets", len(scores)), zap.Error(err))
+	}
+}
+
+// rescoreWallet calculates and stores the risk score of a wallet, alerting
+// when it is above the threshold
+func (s *RiskScoringService) rescoreWallet(ctx context.Context, address string, network models.Network) (float64, error) {
+	wallet, err := s.repo.GetWalletByAddress(ctx, address, network)
+	if err != nil {
+		return 0, err
+	}
+	if wallet == nil {
+		return 0, ErrWalletNotFound
+	}
+
 	factors, err := s.CalculateRiskScore(ctx, address, network)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	wallet.RiskScore = factors.TotalScore
 
 	// Update risk level
-	switch {
-	case factors.TotalScore >= s.cfg.RiskScoring.CriticalThreshold:
-		wallet.RiskLevel = "critical"
-	case factors.TotalScore >= s.cfg.RiskScoring.HighThreshold:
-		wallet.RiskLevel = "high"
-	case factors.TotalScore >= s.cfg.RiskScoring.MediumThreshold:
-		wallet.RiskLevel = "medium"
-	default:
-		wallet.RiskLevel = "low"
-	}
+	wallet.RiskLevel = riskLevelFor(s.cfg, factors.TotalScore)
 
 	// Save to database
 	if err := s.repo.UpdateWalletRiskScore(ctx, wallet); err != nil {
-		return err
-	}
-
-	// Cache the score
-	if err := s.cache.SetWalletRiskScore(ctx, address, network, factors.TotalScore); err != nil {
-		s.logger.Warn("Failed to cache risk score", zap.Error(err))
+		return 0, err
 	}
 
 	// Trigger alert if above threshold
@@ -316,7 +341,7 @@ func (s *RiskScoringService) UpdateWalletRiskScore(ctx context.Context, address
 		s.triggerHighRiskAlert(ctx, wallet, factors)
 	}
 
-	return nil
+	return factors.TotalScore, nil
 }
 
 // triggerHighRiskAlert creates an alert for high-risk wallet
@@ -354,34 +379,88 @@ func (s *RiskScoringService) GetRiskScore(ctx context.Context, address string, n
 	return s.cache.GetWalletRiskScore(ctx, address, network)
 }
 
+// TransactionRisk is the evaluated risk of one transaction
+type TransactionRisk struct {
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
 // EvaluateTransactionRisk evaluates risk for a transaction
 func (s *RiskScoringService) EvaluateTransactionRisk(ctx context.Context, tx *models.NormalizedTransaction) (float64, []string) {
+	return s.EvaluateTransactionRiskWith(tx, func(address string) float64 {
+		score, _ := s.GetRiskScore(ctx, address, tx.Network)
+		return score
+	})
+}
+
+// EvaluateTransactionRiskBatch evaluates risk for each transaction. The wallet
+// scores of all their addresses are read from the cache in one round trip, and
+// a wallet missing from it is scored once for the whole batch.
+func (s *RiskScoringService) EvaluateTransactionRiskBatch(ctx context.Context, txs []*models.NormalizedTransaction) []TransactionRisk {
+	scores, err := s.cache.GetWalletRiskScores(ctx, models.UniqueWallets(txs))
+	if err != nil {
+		s.logger.Warn("Failed to read cached risk scores", zap.Error(err))
+		scores = make(map[models.WalletKey]float64)
+	}
+
+	results := make([]TransactionRisk, len(txs))
+	for i, tx := range txs {
+		network := tx.Network
+		score, reasons := s.EvaluateTransactionRiskWith(tx, func(address string) float64 {
+			key := models.WalletKey{Address: address, Network: network}
+			if score, ok := scores[key]; ok {
+				return score
+			}
+			score, _ := s.GetRiskScore(ctx, address, network)
+			scores[key] = score
+			return score
+		})
+		results[i] = TransactionRisk{Score: score, Reasons: reasons}
+	}
+	return results
+}
+
+// EvaluateTransactionRiskWith evaluates risk for a transaction, taking wallet
+// risk scores from walletScore
+func (s *RiskScoringService) EvaluateTransactionRiskWith(tx *models.NormalizedTransaction, walletScore func(address string) float64) (float64, []string) {
 	var riskScore float64
 	var reasons []string
 
 	// Check sender risk
-	senderScore, _ := s.GetRiskScore(ctx, tx.Inputs[0].Address, tx.Network)
-	riskScore += senderScore * 0.4
+	if len(tx.Inputs) > 0 {
+		riskScore += walletScore(tx.Inputs[0].Address) * 0.4
+	}
 
 	// Check recipient risk
 	for _, output := range tx.Outputs {
 		if output.IsChange {
 			continue
 		}
-		recipientScore, _ := s.GetRiskScore(ctx, output.Address, tx.Network)
-		riskScore += recipientScore * 0.3
+		riskScore += walletScore(output.Address) * 0.3
+	}
+
+	// Token recipient risk
+	for _, transfer := range tx.TokenTransfers {
+		riskScore += walletScore(transfer.Receiver) * 0.3
 	}
 
 	// Transaction amount risk
-	if tx.TotalValue.GreaterThan(decimal.NewFromFloat(100000)) {
-		riskScore += 20
-		reasons = append(reasons, "large_transaction_amount")
+	amounts := transferredAmounts(tx)
+	for _, amount := range amounts {
+		if amount.GreaterThan(decimal.NewFromFloat(100000)) {
+			riskScore += 20
+			reasons = append(reasons, "large_transaction_amount")
+			break
+		}
 	}
 
 	// Check for structuring
-	if s.detectStructuring(tx) {
-		riskScore += 30
-		reasons = append(reasons, "potential_structuring_detected")
+	for _, amount := range amounts {
+		if s.detectStructuring(amount) {
+			riskScore += 30
+			reasons = append(reasons, "potential_structuring_detected")
+			break
+		}
 	}
 
 	// Check for layering
@@ -393,13 +472,26 @@ func (s *RiskScoringService) EvaluateTransactionRisk(ctx context.Context, tx *mo
 	return math.Min(riskScore, 100), reasons
 }
 
+// transferredAmounts returns the native value of tx and the amounts of its
+// verified ERC-20 transfers. Unverified tokens are left out, as anyone can mint
+// them in any amount.
+func transferredAmounts(tx *models.NormalizedTransaction) []decimal.Decimal {
+	amounts := []decimal.Decimal{tx.TotalValue}
+	for _, transfer := range tx.TokenTransfers {
+		if transfer.Verified && transfer.Standard == models.TokenStandardERC20 {
+			amounts = append(amounts, transfer.Amount)
+		}
+	}
+	return amounts
+}
+
 // detectStructuring detects potential structuring patterns
-func (s *RiskScoringService) detectStructuring(tx *models.NormalizedTransaction) bool {
+func (s *RiskScoringService) detectStructuring(amount decimal.Decimal) bool {
 	// Check for round amounts near reporting thresholds
 	thresholds := []float64{9000, 10000, 49000, 50000}
 	for _, threshold := range thresholds {
-		if tx.TotalValue.GreaterThan(decimal.NewFromFloat(threshold-100)) &&
-			tx.TotalValue.LessThan(decimal.NewFromFloat(threshold+100)) {
+		if amount.GreaterThan(decimal.NewFromFloat(threshold-100)) &&
+			amount.LessThan(decimal.NewFromFloat(threshold+100)) {
 			return true
 		}
 	}
@@ -0,0 +1,332 @@
+package screening
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Address encodings recognised by ValidateAddress
+const (
+	FormatBech32      = "bech32"
+	FormatBech32m     = "bech32m"
+	FormatBase58Check = "base58check"
+	FormatHex         = "hex"
+	FormatEIP55       = "eip55"
+)
+
+// AddressValidation is the outcome of validating an address for a network
+type AddressValidation struct {
+	Network    models.Network `json:"network"`
+	Valid      bool           `json:"valid"`
+	Format     string         `json:"format,omitempty"`
+	Normalized string         `json:"normalized,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+}
+
+// base58Versions lists the accepted base58check version bytes per network
+var base58Versions = map[models.Network][]byte{
+	models.NetworkBitcoin: {0x00, 0x05, 0x6f, 0xc4}, // P2PKH, P2SH, testnet P2PKH, testnet P2SH
+	models.NetworkTron:    {0x41},
+}
+
+// bech32Prefixes lists the accepted segwit human-readable parts per network
+var bech32Prefixes = map[models.Network][]string{
+	models.NetworkBitcoin: {"bc", "tb", "bcrt"},
+}
+
+// ValidateAddress checks that an address is well-formed for the given network,
+// including its checksum, and returns the normalized form used for lookups
+func ValidateAddress(address string, network models.Network) AddressValidation {
+	address = strings.TrimSpace(address)
+	result := AddressValidation{Network: network}
+
+	if address == "" {
+		result.Reason = "address is empty"
+		return result
+	}
+
+	var err error
+	switch network {
+	case models.NetworkEthereum, models.NetworkPolygon, models.NetworkBSC:
+		result.Format, result.Normalized, err = validateEVMAddress(address)
+	case models.NetworkBitcoin:
+		if hasBech32Prefix(address, bech32Prefixes[network]) {
+			result.Format, result.Normalized, err = validateSegwitAddress(address, bech32Prefixes[network])
+		} else {
+			result.Format, result.Normalized, err = validateBase58Address(address, base58Versions[network])
+		}
+	case models.NetworkTron:
+		if !strings.HasPrefix(address, "T") {
+			err = fmt.Errorf("tron addresses start with T")
+			break
+		}
+		result.Format, result.Normalized, err = validateBase58Address(address, base58Versions[network])
+	default:
+		err = fmt.Errorf("unsupported network %q", network)
+	}
+
+	if err != nil {
+		result.Format = ""
+		result.Normalized = ""
+		result.Reason = err.Error()
+		return result
+	}
+
+	result.Valid = true
+	return result
+}
+
+// DetectNetwork infers the network from an address's shape. Hex addresses are
+// reported as ethereum since EVM chains share the same format.
+func DetectNetwork(address string) (models.Network, bool) {
+	address = strings.TrimSpace(address)
+	switch {
+	case strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X"):
+		return models.NetworkEthereum, true
+	case hasBech32Prefix(address, bech32Prefixes[models.NetworkBitcoin]):
+		return models.NetworkBitcoin, true
+	case strings.HasPrefix(address, "T") && len(address) == 34:
+		return models.NetworkTron, true
+	case strings.HasPrefix(address, "1") || strings.HasPrefix(address, "3"):
+		return models.NetworkBitcoin, true
+	default:
+		return "", false
+	}
+}
+
+// validateEVMAddress accepts all-lowercase or all-uppercase hex, and verifies the
+// EIP-55 checksum when the address is mixed case
+func validateEVMAddress(address string) (string, string, error) {
+	if !strings.HasPrefix(address, "0x") {
+		return "", "", fmt.Errorf("address must start with 0x")
+	}
+	if !common.IsHexAddress(address) {
+		return "", "", fmt.Errorf("address must be 20 bytes of hex")
+	}
+
+	digits := address[2:]
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return FormatHex, strings.ToLower(address), nil
+	}
+
+	if common.HexToAddress(address).Hex() != address {
+		return "", "", fmt.Errorf("invalid EIP-55 checksum")
+	}
+	return FormatEIP55, strings.ToLower(address), nil
+}
+
+// validateBase58Address decodes a base58check address and checks its version byte
+func validateBase58Address(address string, versions []byte) (string, string, error) {
+	decoded, err := base58Decode(address)
+	if err != nil {
+		return "", "", err
+	}
+	if len(decoded) != 25 {
+		return "", "", fmt.Errorf("decoded address has %d bytes, expected 25", len(decoded))
+	}
+
+	payload, checksum := decoded[:21], decoded[21:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return "", "", fmt.Errorf("invalid base58check checksum")
+	}
+
+	if bytes.IndexByte(versions, payload[0]) < 0 {
+		return "", "", fmt.Errorf("unexpected version byte 0x%02x", payload[0])
+	}
+
+	return FormatBase58Check, address, nil
+}
+
+// validateSegwitAddress decodes a BIP-173/BIP-350 segwit address
+func validateSegwitAddress(address string, prefixes []string) (string, string, error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", "", fmt.Errorf("bech32 address must not be mixed case")
+	}
+	address = strings.ToLower(address)
+
+	hrp, data, format, err := bech32Decode(address)
+	if err != nil {
+		return "", "", err
+	}
+
+	known := false
+	for _, prefix := range prefixes {
+		if hrp == prefix {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return "", "", fmt.Errorf("unexpected human-readable part %q", hrp)
+	}
+
+	if len(data) < 1 {
+		return "", "", fmt.Errorf("missing witness version")
+	}
+	version := data[0]
+	if version > 16 {
+		return "", "", fmt.Errorf("invalid witness version %d", version)
+	}
+
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return "", "", err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", "", fmt.Errorf("invalid witness program length %d", len(program))
+	}
+
+	if version == 0 {
+		if format != FormatBech32 {
+			return "", "", fmt.Errorf("witness v0 addresses must use bech32")
+		}
+		if len(program) != 20 && len(program) != 32 {
+			return "", "", fmt.Errorf("invalid witness v0 program length %d", len(program))
+		}
+	} else if format != FormatBech32m {
+		return "", "", fmt.Errorf("witness v%d addresses must use bech32m", version)
+	}
+
+	return format, address, nil
+}
+
+func hasBech32Prefix(address string, prefixes []string) bool {
+	lower := strings.ToLower(address)
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(lower, prefix+"1") {
+			return true
+		}
+	}
+	return false
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Decode(s string) ([]byte, error) {
+	value := new(big.Int)
+	radix := big.NewInt(58)
+
+	for _, r := range s {
+		index := strings.IndexRune(base58Alphabet, r)
+		if index < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", r)
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(index)))
+	}
+
+	decoded := value.Bytes()
+
+	// Each leading '1' encodes a leading zero byte
+	leading := 0
+	for leading < len(s) && s[leading] == '1' {
+		leading++
+	}
+
+	return append(make([]byte, leading), decoded...), nil
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants from BIP-173 (bech32) and BIP-350 (bech32m)
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+func bech32Decode(s string) (string, []byte, string, error) {
+	if len(s) > 90 {
+		return "", nil, "", fmt.Errorf("bech32 address exceeds 90 characters")
+	}
+
+	separator := strings.LastIndexByte(s, '1')
+	if separator < 1 || separator+7 > len(s) {
+		return "", nil, "", fmt.Errorf("invalid bech32 separator position")
+	}
+
+	hrp := s[:separator]
+	data := make([]byte, 0, len(s)-separator-1)
+	for _, r := range s[separator+1:] {
+		index := strings.IndexRune(bech32Charset, r)
+		if index < 0 {
+			return "", nil, "", fmt.Errorf("invalid bech32 character %q", r)
+		}
+		data = append(data, byte(index))
+	}
+
+	var format string
+	switch bech32Polymod(append(bech32HRPExpand(hrp), data...)) {
+	case bech32Const:
+		format = FormatBech32
+	case bech32mConst:
+		format = FormatBech32m
+	default:
+		return "", nil, "", fmt.Errorf("invalid bech32 checksum")
+	}
+
+	return hrp, data[:len(data)-6], format, nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxValue := uint32(1)<<toBits - 1
+	converted := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data range")
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			converted = append(converted, byte(acc>>bits&maxValue))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			converted = append(converted, byte(acc<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxValue != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+
+	return converted, nil
+}
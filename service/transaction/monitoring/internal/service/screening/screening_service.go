@@ -0,0 +1,310 @@
+package screening
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/csic/transaction-monitoring/internal/service/sanctions"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxScreeningBatch caps the number of addresses screened in one request
+const MaxScreeningBatch = 100
+
+// ErrInvalidAddress is returned when an address fails validation for its network
+var ErrInvalidAddress = errors.New("invalid address")
+
+// Verdict is the consolidated screening outcome for an address
+type Verdict string
+
+const (
+	VerdictClear   Verdict = "clear"
+	VerdictReview  Verdict = "review"
+	VerdictBlock   Verdict = "block"
+	VerdictInvalid Verdict = "invalid"
+)
+
+// verdictRank orders verdicts so checks can only escalate the outcome
+var verdictRank = map[Verdict]int{
+	VerdictClear:   0,
+	VerdictReview:  1,
+	VerdictBlock:   2,
+	VerdictInvalid: 3,
+}
+
+// MoreSevere returns whichever of v and other is the more severe verdict
+func (v Verdict) MoreSevere(other Verdict) Verdict {
+	if verdictRank[other] > verdictRank[v] {
+		return other
+	}
+	return v
+}
+
+// AddressRequest identifies an address to screen. Network may be omitted,
+// in which case it is taken from the request default or detected from the address.
+type AddressRequest struct {
+	Address string         `json:"address"`
+	Network models.Network `json:"network"`
+}
+
+// UnmarshalJSON accepts either a bare address string or an object
+func (r *AddressRequest) UnmarshalJSON(data []byte) error {
+	var address string
+	if err := json.Unmarshal(data, &address); err == nil {
+		r.Address = address
+		return nil
+	}
+
+	type plain AddressRequest
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// ClusterMembership summarises the entity cluster an address belongs to
+type ClusterMembership struct {
+	ClusterID       string  `json:"cluster_id"`
+	Label           string  `json:"label,omitempty"`
+	RiskScore       float64 `json:"risk_score"`
+	RiskLevel       string  `json:"risk_level"`
+	WalletCount     int     `json:"wallet_count"`
+	SuspectedEntity string  `json:"suspected_entity,omitempty"`
+}
+
+// AddressScreeningResult is the consolidated screening verdict for one address
+type AddressScreeningResult struct {
+	Address       string                     `json:"address"`
+	Network       models.Network             `json:"network"`
+	Validation    AddressValidation          `json:"validation"`
+	Verdict       Verdict                    `json:"verdict"`
+	Reasons       []string                   `json:"reasons"`
+	KnownWallet   bool                       `json:"known_wallet"`
+	RiskScore     float64                    `json:"risk_score"`
+	RiskLevel     string                     `json:"risk_level,omitempty"`
+	IsBlacklisted bool                       `json:"is_blacklisted"`
+	IsWhitelisted bool                       `json:"is_whitelisted"`
+	Sanctions     *sanctions.ScreeningResult `json:"sanctions,omitempty"`
+	Watchlist     []models.WatchlistEntry    `json:"watchlist"`
+	Cluster       *ClusterMembership         `json:"cluster,omitempty"`
+	ScreenedAt    time.Time                  `json:"screened_at"`
+}
+
+// escalate raises the verdict if the new one is more severe and records the reason
+func (r *AddressScreeningResult) escalate(verdict Verdict, reason string) {
+	if verdictRank[verdict] > verdictRank[r.Verdict] {
+		r.Verdict = verdict
+	}
+	r.Reasons = append(r.Reasons, reason)
+}
+
+// ScreeningService screens arbitrary addresses before onboarding by combining
+// format validation, sanctions, blacklist, watchlist and cluster checks
+type ScreeningService struct {
+	cfg          *config.Config
+	repo         *repository.Repository
+	sanctionsSvc *sanctions.SanctionsService
+	logger       *zap.Logger
+}
+
+// NewScreeningService creates a new address screening service
+func NewScreeningService(
+	cfg *config.Config,
+	repo *repository.Repository,
+	sanctionsSvc *sanctions.SanctionsService,
+	logger *zap.Logger,
+) *ScreeningService {
+	return &ScreeningService{
+		cfg:          cfg,
+		repo:         repo,
+		sanctionsSvc: sanctionsSvc,
+		logger:       logger,
+	}
+}
+
+// ScreenAddresses screens each address and returns one result per request, in order
+func (s *ScreeningService) ScreenAddresses(ctx context.Context, requests []AddressRequest, defaultNetwork models.Network) []*AddressScreeningResult {
+	results := make([]*AddressScreeningResult, 0, len(requests))
+	for _, req := range requests {
+		network := req.Network
+		if network == "" {
+			network = defaultNetwork
+		}
+		if network == "" {
+			network, _ = DetectNetwork(req.Address)
+		}
+		results = append(results, s.ScreenAddress(ctx, req.Address, network))
+	}
+	return results
+}
+
+// ScreenAddress validates an address and consolidates all checks into a verdict.
+// Lookup failures are reported as review reasons rather than errors so that a
+// partial outage never produces a clear verdict.
+func (s *ScreeningService) ScreenAddress(ctx context.Context, address string, network models.Network) *AddressScreeningResult {
+	result := &AddressScreeningResult{
+		Address:    address,
+		Network:    network,
+		Verdict:    VerdictClear,
+		Reasons:    []string{},
+		Watchlist:  []models.WatchlistEntry{},
+		ScreenedAt: time.Now().UTC(),
+	}
+
+	if network == "" {
+		result.Validation = AddressValidation{Reason: "network could not be determined"}
+		result.escalate(VerdictInvalid, "network could not be determined from the address")
+		return result
+	}
+
+	result.Validation = ValidateAddress(address, network)
+	if !result.Validation.Valid {
+		result.escalate(VerdictInvalid, fmt.Sprintf("invalid %s address: %s", network, result.Validation.Reason))
+		return result
+	}
+	normalized := result.Validation.Normalized
+
+	// degraded is set when a check could not run; whitelisting must not waive that
+	degraded := false
+
+	// Sanctions lists
+	sanctionsResult, err := s.sanctionsSvc.ScreenAddress(ctx, address, network)
+	if err != nil {
+		s.logger.Warn("Sanctions screening failed", zap.String("address", address), zap.Error(err))
+		result.escalate(VerdictReview, "sanctions screening unavailable")
+		degraded = true
+	} else {
+		result.Sanctions = sanctionsResult
+		if sanctionsResult.IsSanctioned {
+			result.escalate(VerdictBlock, "address matches a sanctions list")
+		} else if sanctionsResult.IndirectLinks > 0 {
+			result.escalate(VerdictReview, fmt.Sprintf("transacted with %d sanctioned counterparties", sanctionsResult.IndirectLinks))
+		}
+	}
+
+	// Watchlists
+	entries, err := s.repo.GetWatchlistEntries(ctx, normalized, network)
+	if err != nil {
+		s.logger.Warn("Watchlist lookup failed", zap.String("address", address), zap.Error(err))
+		result.escalate(VerdictReview, "watchlist lookup unavailable")
+		degraded = true
+	} else if len(entries) > 0 {
+		result.Watchlist = entries
+		lists := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			lists = append(lists, entry.ListName)
+		}
+		result.escalate(VerdictReview, "address is on watchlist: "+strings.Join(lists, ", "))
+	}
+
+	// Known wallet: blacklist, whitelist, risk score and cluster membership
+	wallet, err := s.lookupWallet(ctx, address, normalized, network)
+	if err != nil {
+		s.logger.Warn("Wallet lookup failed", zap.String("address", address), zap.Error(err))
+		result.escalate(VerdictReview, "wallet lookup unavailable")
+		degraded = true
+	}
+	if wallet != nil {
+		result.KnownWallet = true
+		result.RiskScore = wallet.RiskScore
+		result.RiskLevel = wallet.RiskLevel
+		result.IsBlacklisted = wallet.IsBlacklisted
+		result.IsWhitelisted = wallet.IsWhitelisted
+
+		if wallet.IsBlacklisted {
+			result.escalate(VerdictBlock, "address is blacklisted")
+		}
+		if wallet.IsSanctioned && (result.Sanctions == nil || !result.Sanctions.IsSanctioned) {
+			result.escalate(VerdictBlock, "wallet is flagged as sanctioned")
+		}
+		if wallet.RiskLevel == "high" || wallet.RiskLevel == "critical" {
+			result.escalate(VerdictReview, fmt.Sprintf("wallet risk level is %s (score %.2f)", wallet.RiskLevel, wallet.RiskScore))
+		}
+
+		if wallet.ClusterID != nil && !s.checkCluster(ctx, *wallet.ClusterID, result) {
+			degraded = true
+		}
+	}
+
+	// A whitelist entry clears review findings but never overrides a block
+	if result.IsWhitelisted && result.Verdict == VerdictReview && !degraded {
+		result.Verdict = VerdictClear
+		result.Reasons = append(result.Reasons, "review findings waived: address is whitelisted")
+	}
+
+	return result
+}
+
+// checkCluster records cluster membership and escalates on high-risk clusters.
+// It reports false when the cluster could not be looked up.
+func (s *ScreeningService) checkCluster(ctx context.Context, clusterID string, result *AddressScreeningResult) bool {
+	cluster, err := s.repo.GetClusterByID(ctx, clusterID)
+	if err != nil {
+		s.logger.Warn("Cluster lookup failed", zap.String("cluster_id", clusterID), zap.Error(err))
+		result.escalate(VerdictReview, "cluster lookup unavailable")
+		return false
+	}
+	if cluster == nil {
+		return true
+	}
+
+	result.Cluster = &ClusterMembership{
+		ClusterID:       cluster.ID,
+		Label:           cluster.Label,
+		RiskScore:       cluster.RiskScore,
+		RiskLevel:       cluster.RiskLevel,
+		WalletCount:     cluster.WalletCount,
+		SuspectedEntity: cluster.SuspectedEntity,
+	}
+
+	switch cluster.RiskLevel {
+	case "critical":
+		result.escalate(VerdictBlock, fmt.Sprintf("member of critical-risk cluster %s", cluster.ID))
+	case "high":
+		result.escalate(VerdictReview, fmt.Sprintf("member of high-risk cluster %s", cluster.ID))
+	}
+
+	return true
+}
+
+// lookupWallet finds a wallet by its normalized address, falling back to the
+// address as submitted since stored addresses may keep their original casing
+func (s *ScreeningService) lookupWallet(ctx context.Context, address, normalized string, network models.Network) (*models.Wallet, error) {
+	wallet, err := s.repo.GetWalletByAddress(ctx, normalized, network)
+	if err != nil || wallet != nil || address == normalized {
+		return wallet, err
+	}
+	return s.repo.GetWalletByAddress(ctx, address, network)
+}
+
+// AddToWatchlist validates the address and adds it to a watchlist
+func (s *ScreeningService) AddToWatchlist(ctx context.Context, entry *models.WatchlistEntry) error {
+	validation := ValidateAddress(entry.Address, entry.Network)
+	if !validation.Valid {
+		return fmt.Errorf("%w: %s", ErrInvalidAddress, validation.Reason)
+	}
+
+	entry.ID = uuid.New().String()
+	entry.Address = validation.Normalized
+	if entry.ListName == "" {
+		entry.ListName = "default"
+	}
+	entry.CreatedAt = time.Now()
+
+	return s.repo.AddWatchlistEntry(ctx, entry)
+}
+
+// RemoveFromWatchlist removes an address from all watchlists
+func (s *ScreeningService) RemoveFromWatchlist(ctx context.Context, address string, network models.Network) (int64, error) {
+	validation := ValidateAddress(address, network)
+	if !validation.Valid {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAddress, validation.Reason)
+	}
+
+	return s.repo.RemoveWatchlistEntries(ctx, validation.Normalized, network)
+}
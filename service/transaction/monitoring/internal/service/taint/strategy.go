@@ -0,0 +1,72 @@
+package taint
+
+import (
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/shopspring/decimal"
+)
+
+// propagate returns the taint passed to each output of a transaction under
+// the strategy, given each input and the taint it carries. The fee takes its
+// share of the taint under haircut and whatever is left after the outputs
+// under FIFO.
+func propagate(strategy models.TaintStrategy, inputs []models.UTXO, inputTaint []decimal.Decimal, outputs []models.UTXO) []decimal.Decimal {
+	taint := make([]decimal.Decimal, len(outputs))
+	for i := range taint {
+		taint[i] = decimal.Zero
+	}
+
+	switch strategy {
+	case models.TaintPoison:
+		for _, t := range inputTaint {
+			if t.IsPositive() {
+				for i, output := range outputs {
+					taint[i] = output.Amount
+				}
+				break
+			}
+		}
+
+	case models.TaintFIFO:
+		// Inputs form a queue of value, each unit tainted at its input's share
+		type segment struct {
+			remaining decimal.Decimal
+			share     decimal.Decimal
+		}
+		queue := make([]segment, 0, len(inputs))
+		for i, input := range inputs {
+			if !input.Amount.IsPositive() {
+				continue
+			}
+			queue = append(queue, segment{remaining: input.Amount, share: inputTaint[i].Div(input.Amount)})
+		}
+
+		for i, output := range outputs {
+			need := output.Amount
+			for need.IsPositive() && len(queue) > 0 {
+				take := decimal.Min(need, queue[0].remaining)
+				taint[i] = taint[i].Add(take.Mul(queue[0].share))
+				need = need.Sub(take)
+				queue[0].remaining = queue[0].remaining.Sub(take)
+				if !queue[0].remaining.IsPositive() {
+					queue = queue[1:]
+				}
+			}
+		}
+
+	default: // models.TaintHaircut
+		totalIn, tainted := decimal.Zero, decimal.Zero
+		for i, input := range inputs {
+			totalIn = totalIn.Add(input.Amount)
+			tainted = tainted.Add(inputTaint[i])
+		}
+		if !totalIn.IsPositive() || !tainted.IsPositive() {
+			return taint
+		}
+		share := decimal.Min(tainted.Div(totalIn), decimal.NewFromInt(1))
+		for i, output := range outputs {
+			taint[i] = output.Amount.Mul(share)
+		}
+	}
+
+	return taint
+}
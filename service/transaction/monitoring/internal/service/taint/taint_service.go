@@ -0,0 +1,275 @@
+package taint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidTrace is returned when a taint trace request is malformed
+	ErrInvalidTrace = errors.New("invalid taint trace")
+	// ErrSourceNotFound is returned when no outputs are recorded for the
+	// source of a trace
+	ErrSourceNotFound = errors.New("no outputs recorded for taint source")
+)
+
+// outpoint identifies a transaction output
+type outpoint struct {
+	txHash string
+	index  int
+}
+
+// Service traces tainted value through the UTXO set. Taint starts on the
+// outputs received by a source address or created by a source transaction
+// and follows each tainted output into the transaction that spent it, where
+// the trace's strategy decides how much of it each output of that
+// transaction carries.
+type Service struct {
+	cfg    *config.Config
+	repo   *repository.Repository
+	logger *zap.Logger
+}
+
+// NewService creates a new taint tracing service
+func NewService(cfg *config.Config, repo *repository.Repository, logger *zap.Logger) *Service {
+	return &Service{
+		cfg:    cfg,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Trace runs a taint trace
+func (s *Service) Trace(ctx context.Context, req *models.TaintTraceRequest) (*models.TaintTrace, error) {
+	if !s.cfg.Blockchain.IsUTXO(string(req.Network)) {
+		return nil, fmt.Errorf("%w: %s is not a UTXO network", ErrInvalidTrace, req.Network)
+	}
+	if (req.Address == "") == (req.TxHash == "") {
+		return nil, fmt.Errorf("%w: exactly one of address and tx_hash is required", ErrInvalidTrace)
+	}
+
+	strategy := req.Strategy
+	if strategy == "" {
+		strategy = models.TaintStrategy(s.cfg.Taint.DefaultStrategy)
+	}
+	switch strategy {
+	case "":
+		strategy = models.TaintHaircut
+	case models.TaintHaircut, models.TaintFIFO, models.TaintPoison:
+	default:
+		return nil, fmt.Errorf("%w: unknown strategy %q", ErrInvalidTrace, strategy)
+	}
+
+	maxDepth := capLimit(req.MaxDepth, s.cfg.Taint.MaxDepth, 10)
+	maxTransactions := capLimit(req.MaxTransactions, s.cfg.Taint.MaxTransactions, 2000)
+
+	var sources []models.UTXO
+	var err error
+	if req.Address != "" {
+		sources, err = s.repo.ListUTXOs(ctx, req.Network, req.Address, false, maxTransactions)
+	} else {
+		sources, err = s.repo.GetTransactionOutputs(ctx, req.Network, req.TxHash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load taint source: %w", err)
+	}
+	if len(sources) == 0 {
+		return nil, ErrSourceNotFound
+	}
+
+	trace := &models.TaintTrace{
+		Network:     req.Network,
+		Address:     req.Address,
+		TxHash:      req.TxHash,
+		Strategy:    strategy,
+		SourceTaint: decimal.Zero,
+		TracedAt:    time.Now(),
+	}
+
+	tainted := make(map[outpoint]*models.TaintedUTXO)
+	var queue []string
+	queued := make(map[string]bool)
+	visited := make(map[string]bool)
+
+	// follow queues the transaction spending a tainted output unless the
+	// output is at the depth limit
+	follow := func(utxo *models.TaintedUTXO) {
+		if !utxo.IsSpent() || queued[utxo.SpentTxHash] {
+			return
+		}
+		if utxo.Depth >= maxDepth {
+			trace.Truncated = true
+			return
+		}
+		queued[utxo.SpentTxHash] = true
+		queue = append(queue, utxo.SpentTxHash)
+	}
+
+	for _, source := range sources {
+		utxo := &models.TaintedUTXO{UTXO: source, Taint: source.Amount, Share: decimal.NewFromInt(1)}
+		tainted[outpoint{source.TxHash, source.OutputIndex}] = utxo
+		trace.SourceTaint = trace.SourceTaint.Add(source.Amount)
+		follow(utxo)
+	}
+
+	// A transaction is evaluated again when more taint reaches its inputs,
+	// as happens when two tainted paths meet
+	evaluations := 0
+	for len(queue) > 0 {
+		if evaluations >= maxTransactions {
+			trace.Truncated = true
+			break
+		}
+		txHash := queue[0]
+		queue = queue[1:]
+		queued[txHash] = false
+		visited[txHash] = true
+		evaluations++
+
+		inputs, err := s.repo.GetTransactionInputs(ctx, req.Network, txHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load inputs of %s: %w", txHash, err)
+		}
+		outputs, err := s.repo.GetTransactionOutputs(ctx, req.Network, txHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load outputs of %s: %w", txHash, err)
+		}
+
+		inputTaint := make([]decimal.Decimal, len(inputs))
+		depth := -1
+		for i, input := range inputs {
+			inputTaint[i] = decimal.Zero
+			if utxo, ok := tainted[outpoint{input.TxHash, input.OutputIndex}]; ok {
+				inputTaint[i] = utxo.Taint
+				if depth < 0 || utxo.Depth < depth {
+					depth = utxo.Depth
+				}
+			}
+		}
+
+		for i, taint := range propagate(strategy, inputs, inputTaint, outputs) {
+			if !taint.IsPositive() {
+				continue
+			}
+			key := outpoint{outputs[i].TxHash, outputs[i].OutputIndex}
+			utxo, ok := tainted[key]
+			// Sources stay fully tainted when funds return to them
+			if ok && (utxo.Depth == 0 || utxo.Taint.Equal(taint)) {
+				continue
+			}
+			if !ok {
+				utxo = &models.TaintedUTXO{UTXO: outputs[i]}
+				tainted[key] = utxo
+			}
+			utxo.Taint = taint
+			utxo.Share = decimal.Zero
+			if outputs[i].Amount.IsPositive() {
+				utxo.Share = taint.Div(outputs[i].Amount)
+			}
+			utxo.Depth = depth + 1
+			follow(utxo)
+		}
+	}
+	trace.Transactions = len(visited)
+
+	trace.UTXOs = make([]models.TaintedUTXO, 0, len(tainted))
+	for _, utxo := range tainted {
+		trace.UTXOs = append(trace.UTXOs, *utxo)
+	}
+	sort.Slice(trace.UTXOs, func(i, j int) bool {
+		a, b := trace.UTXOs[i], trace.UTXOs[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		if a.TxHash != b.TxHash {
+			return a.TxHash < b.TxHash
+		}
+		return a.OutputIndex < b.OutputIndex
+	})
+	trace.Addresses = summarize(trace.UTXOs)
+
+	s.logger.Info("Taint trace completed",
+		zap.String("network", string(req.Network)),
+		zap.String("strategy", string(strategy)),
+		zap.Int("transactions", trace.Transactions),
+		zap.Int("utxos", len(trace.UTXOs)),
+		zap.Bool("truncated", trace.Truncated))
+
+	return trace, nil
+}
+
+// ListUTXOs returns the outputs received by an address and the balance of
+// those unspent
+func (s *Service) ListUTXOs(ctx context.Context, network models.Network, address string, unspentOnly bool, limit int) ([]models.UTXO, decimal.Decimal, error) {
+	utxos, err := s.repo.ListUTXOs(ctx, network, address, unspentOnly, limit)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+	balance, err := s.repo.GetUTXOBalance(ctx, network, address)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+	return utxos, balance, nil
+}
+
+// summarize totals the tainted outputs by receiving address, the addresses
+// holding the most unspent taint first
+func summarize(utxos []models.TaintedUTXO) []models.TaintedAddress {
+	byAddress := make(map[string]*models.TaintedAddress)
+	var order []string
+	for _, utxo := range utxos {
+		if utxo.Address == "" {
+			continue
+		}
+		summary, ok := byAddress[utxo.Address]
+		if !ok {
+			summary = &models.TaintedAddress{
+				Address:       utxo.Address,
+				Taint:         decimal.Zero,
+				UnspentTaint:  decimal.Zero,
+				UnspentAmount: decimal.Zero,
+			}
+			byAddress[utxo.Address] = summary
+			order = append(order, utxo.Address)
+		}
+		summary.Taint = summary.Taint.Add(utxo.Taint)
+		summary.UTXOCount++
+		if !utxo.IsSpent() {
+			summary.UnspentTaint = summary.UnspentTaint.Add(utxo.Taint)
+			summary.UnspentAmount = summary.UnspentAmount.Add(utxo.Amount)
+		}
+	}
+
+	addresses := make([]models.TaintedAddress, 0, len(order))
+	for _, address := range order {
+		addresses = append(addresses, *byAddress[address])
+	}
+	sort.SliceStable(addresses, func(i, j int) bool {
+		if !addresses[i].UnspentTaint.Equal(addresses[j].UnspentTaint) {
+			return addresses[i].UnspentTaint.GreaterThan(addresses[j].UnspentTaint)
+		}
+		return addresses[i].Taint.GreaterThan(addresses[j].Taint)
+	})
+	return addresses
+}
+
+// capLimit returns the requested limit capped at the configured one, or the
+// configured limit if none was requested
+func capLimit(requested, configured, fallback int) int {
+	if configured <= 0 {
+		configured = fallback
+	}
+	if requested <= 0 || requested > configured {
+		return configured
+	}
+	return requested
+}
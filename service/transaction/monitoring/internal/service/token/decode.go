@@ -0,0 +1,78 @@
+package token
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// transferTopic is the topic of Transfer(address,address,uint256), shared by
+// ERC-20 and ERC-721. ERC-721 indexes the token ID, giving it a fourth topic.
+var transferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// DecodeTransfers decodes the ERC-20 and ERC-721 Transfer events in the logs
+// of tx. Transfers of contracts the registry cannot resolve are kept without a
+// symbol and are not counted towards amounts.
+func (r *Registry) DecodeTransfers(ctx context.Context, tx *models.NormalizedTransaction, logs []*types.Log) []models.TokenTransfer {
+	var transfers []models.TokenTransfer
+	for _, log := range logs {
+		if len(log.Topics) < 3 || log.Topics[0] != transferTopic || log.Removed {
+			continue
+		}
+
+		transfer := models.TokenTransfer{
+			ID:          uuid.New().String(),
+			Network:     tx.Network,
+			TxHash:      tx.TxHash,
+			LogIndex:    int(log.Index),
+			BlockNumber: tx.BlockNumber,
+			BlockHash:   tx.BlockHash,
+			Timestamp:   tx.Timestamp,
+			Contract:    log.Address.Hex(),
+			Sender:      common.BytesToAddress(log.Topics[1].Bytes()).Hex(),
+			Receiver:    common.BytesToAddress(log.Topics[2].Bytes()).Hex(),
+			CreatedAt:   time.Now(),
+		}
+
+		var raw *big.Int
+		switch {
+		case len(log.Topics) == 3 && len(log.Data) == 32:
+			transfer.Standard = models.TokenStandardERC20
+			raw = new(big.Int).SetBytes(log.Data)
+		case len(log.Topics) == 4 && len(log.Data) == 0:
+			transfer.Standard = models.TokenStandardERC721
+			transfer.TokenID = log.Topics[3].Big().String()
+			raw = big.NewInt(1)
+		default:
+			continue
+		}
+		transfer.RawAmount = raw.String()
+
+		token, err := r.Lookup(ctx, tx.Network, transfer.Contract, transfer.Standard)
+		if err != nil {
+			r.logger.Warn("Failed to resolve token",
+				zap.String("contract", transfer.Contract),
+				zap.Error(err))
+		}
+		if token != nil {
+			transfer.Symbol = token.Symbol
+			transfer.Verified = token.Verified
+			if transfer.Standard == models.TokenStandardERC20 {
+				transfer.Amount = decimal.NewFromBigInt(raw, -int32(token.Decimals))
+			} else {
+				transfer.Amount = decimal.NewFromInt(1)
+			}
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers
+}
@@ -0,0 +1,108 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Selectors of the optional ERC-20/721 metadata functions
+var (
+	selectorName     = common.Hex2Bytes("06fdde03")
+	selectorSymbol   = common.Hex2Bytes("95d89b41")
+	selectorDecimals = common.Hex2Bytes("313ce567")
+)
+
+// maxSymbolLength matches the width of the symbol column
+const maxSymbolLength = 20
+
+type metadata struct {
+	symbol   string
+	name     string
+	decimals int
+}
+
+// readMetadata calls a token contract's metadata functions. A symbol is
+// required; ERC-721 tokens have no decimals.
+func readMetadata(ctx context.Context, caller ethereum.ContractCaller, contract string, standard models.TokenStandard) (*metadata, error) {
+	address := common.HexToAddress(contract)
+	call := func(selector []byte) ([]byte, error) {
+		return caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: selector}, nil)
+	}
+
+	result, err := call(selectorSymbol)
+	if err != nil {
+		return nil, err
+	}
+	symbol := truncate(decodeString(result), maxSymbolLength)
+	if symbol == "" {
+		return nil, errors.New("contract has no symbol")
+	}
+
+	md := &metadata{symbol: symbol}
+	if result, err := call(selectorName); err == nil {
+		md.name = decodeString(result)
+	}
+
+	if standard == models.TokenStandardERC20 {
+		result, err := call(selectorDecimals)
+		if err != nil {
+			return nil, err
+		}
+		if len(result) != 32 {
+			return nil, errors.New("malformed decimals")
+		}
+		decimals := new(big.Int).SetBytes(result)
+		if !decimals.IsInt64() || decimals.Int64() > 36 {
+			return nil, errors.New("decimals out of range")
+		}
+		md.decimals = int(decimals.Int64())
+	}
+
+	return md, nil
+}
+
+// decodeString decodes an ABI-encoded string return value. Some early tokens
+// return bytes32 instead, which is padded with zero bytes.
+func decodeString(data []byte) string {
+	var raw []byte
+	switch {
+	case len(data) == 32:
+		raw = data
+	case len(data) >= 64:
+		offset := new(big.Int).SetBytes(data[:32])
+		if !offset.IsInt64() || offset.Int64()+32 > int64(len(data)) {
+			return ""
+		}
+		start := offset.Int64() + 32
+		length := new(big.Int).SetBytes(data[start-32 : start])
+		if !length.IsInt64() || start+length.Int64() > int64(len(data)) {
+			return ""
+		}
+		raw = data[start : start+length.Int64()]
+	default:
+		return ""
+	}
+
+	// Drop padding and anything that is not printable
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, string(raw)))
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
@@ -0,0 +1,232 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidToken is returned when a token registration is malformed
+var ErrInvalidToken = errors.New("invalid token")
+
+// tokenKey identifies a token contract; contracts are lowercase
+type tokenKey struct {
+	network  models.Network
+	contract string
+}
+
+// Registry resolves token contracts to their symbol, decimals and
+// verification status, and decodes the Transfer events they emit. Contracts
+// not in the registry are read on-chain when discovery is enabled and
+// registered unverified.
+type Registry struct {
+	cfg    *config.Config
+	repo   *repository.Repository
+	caller ethereum.ContractCaller
+	logger *zap.Logger
+
+	mu     sync.RWMutex
+	tokens map[tokenKey]*models.Token
+	// unknown holds contracts whose metadata could not be read, so that
+	// spam contracts are not queried on every transfer
+	unknown map[tokenKey]bool
+}
+
+// NewRegistry creates a new token registry
+func NewRegistry(cfg *config.Config, repo *repository.Repository, logger *zap.Logger) (*Registry, error) {
+	r := &Registry{
+		cfg:     cfg,
+		repo:    repo,
+		logger:  logger,
+		tokens:  make(map[tokenKey]*models.Token),
+		unknown: make(map[tokenKey]bool),
+	}
+
+	if cfg.Tokens.Discover && cfg.Blockchain.Ethereum.Enabled {
+		client, err := ethclient.Dial(cfg.Blockchain.Ethereum.RPCEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to ethereum for token discovery: %w", err)
+		}
+		r.caller = client
+	}
+
+	return r, nil
+}
+
+// Seed loads the configured tokens into the registry, setting their
+// verification status as configured
+func (r *Registry) Seed(ctx context.Context) error {
+	for _, entry := range r.cfg.Tokens.Registry {
+		_, err := r.Register(ctx, &models.TokenRequest{
+			Network:  models.Network(entry.Network),
+			Contract: entry.Contract,
+			Standard: models.TokenStandard(entry.Standard),
+			Symbol:   entry.Symbol,
+			Name:     entry.Name,
+			Decimals: entry.Decimals,
+			Verified: entry.Verified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register token %s: %w", entry.Symbol, err)
+		}
+	}
+
+	r.logger.Info("Token registry seeded", zap.Int("tokens", len(r.cfg.Tokens.Registry)))
+	return nil
+}
+
+// Register adds a token to the registry or updates its entry, including its
+// verification status
+func (r *Registry) Register(ctx context.Context, req *models.TokenRequest) (*models.Token, error) {
+	if req.Network == "" {
+		return nil, fmt.Errorf("%w: network is required", ErrInvalidToken)
+	}
+	if req.Symbol == "" || len([]rune(req.Symbol)) > maxSymbolLength {
+		return nil, fmt.Errorf("%w: symbol must be 1 to %d characters", ErrInvalidToken, maxSymbolLength)
+	}
+
+	contract := strings.ToLower(req.Contract)
+	if len(contract) != 42 || !strings.HasPrefix(contract, "0x") {
+		return nil, fmt.Errorf("%w: contract must be a 0x-prefixed 20-byte address", ErrInvalidToken)
+	}
+
+	standard := req.Standard
+	switch standard {
+	case "":
+		standard = models.TokenStandardERC20
+	case models.TokenStandardERC20, models.TokenStandardERC721:
+	default:
+		return nil, fmt.Errorf("%w: unknown standard %q", ErrInvalidToken, standard)
+	}
+
+	if req.Decimals < 0 || req.Decimals > 36 {
+		return nil, fmt.Errorf("%w: decimals must be between 0 and 36", ErrInvalidToken)
+	}
+
+	now := time.Now()
+	token := &models.Token{
+		Network:   req.Network,
+		Contract:  contract,
+		Standard:  standard,
+		Symbol:    req.Symbol,
+		Name:      req.Name,
+		Decimals:  req.Decimals,
+		Verified:  req.Verified,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := r.repo.SaveToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	key := tokenKey{network: token.Network, contract: contract}
+	r.mu.Lock()
+	r.tokens[key] = token
+	delete(r.unknown, key)
+	r.mu.Unlock()
+
+	return token, nil
+}
+
+// Lookup returns the registry entry of a token contract, discovering it if
+// needed. It returns nil if the contract is unknown and cannot be read.
+func (r *Registry) Lookup(ctx context.Context, network models.Network, contract string, standard models.TokenStandard) (*models.Token, error) {
+	key := tokenKey{network: network, contract: strings.ToLower(contract)}
+
+	r.mu.RLock()
+	token, ok := r.tokens[key]
+	unknown := r.unknown[key]
+	r.mu.RUnlock()
+	if ok {
+		return token, nil
+	}
+	if unknown {
+		return nil, nil
+	}
+
+	token, err := r.repo.GetToken(ctx, network, key.contract)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		token, err = r.discover(ctx, key, standard)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	if token != nil {
+		r.tokens[key] = token
+	} else {
+		r.unknown[key] = true
+	}
+	r.mu.Unlock()
+
+	return token, nil
+}
+
+// discover reads a contract's metadata and registers it unverified. It
+// returns nil if discovery is disabled or the contract has no symbol.
+func (r *Registry) discover(ctx context.Context, key tokenKey, standard models.TokenStandard) (*models.Token, error) {
+	if r.caller == nil || key.network != models.NetworkEthereum {
+		return nil, nil
+	}
+
+	metadata, err := readMetadata(ctx, r.caller, key.contract, standard)
+	if err != nil {
+		r.logger.Debug("Failed to read token metadata",
+			zap.String("contract", key.contract),
+			zap.Error(err))
+		return nil, nil
+	}
+
+	now := time.Now()
+	token := &models.Token{
+		Network:   key.network,
+		Contract:  key.contract,
+		Standard:  standard,
+		Symbol:    metadata.symbol,
+		Name:      metadata.name,
+		Decimals:  metadata.decimals,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	created, err := r.repo.CreateToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register discovered token: %w", err)
+	}
+	if !created {
+		// Registered concurrently; the stored entry wins
+		return r.repo.GetToken(ctx, key.network, key.contract)
+	}
+
+	r.logger.Info("Discovered token",
+		zap.String("network", string(key.network)),
+		zap.String("contract", key.contract),
+		zap.String("symbol", token.Symbol))
+	return token, nil
+}
+
+// List returns registered tokens, optionally only verified ones
+func (r *Registry) List(ctx context.Context, network models.Network, verifiedOnly bool, limit int) ([]models.Token, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return r.repo.ListTokens(ctx, network, verifiedOnly, limit)
+}
+
+// ListTransfers returns token transfers matching the filter
+func (r *Registry) ListTransfers(ctx context.Context, filter models.TokenTransferFilter) ([]models.TokenTransfer, error) {
+	return r.repo.ListTokenTransfers(ctx, filter)
+}
@@ -0,0 +1,600 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic/transaction-monitoring/internal/config"
+	"github.com/csic/transaction-monitoring/internal/domain/models"
+	"github.com/csic/transaction-monitoring/internal/repository"
+	"github.com/csic/transaction-monitoring/internal/service/screening"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrWatchNotFound is returned when an address watch does not exist or was removed
+	ErrWatchNotFound = errors.New("address watch not found")
+	// ErrInvalidWatch is returned when a watch request is malformed
+	ErrInvalidWatch = errors.New("invalid address watch")
+)
+
+// Service keeps an in-memory index of active address watches and checks every
+// ingested transaction against it, in the mempool where the network supports
+// it and again once confirmed. The first sighting of a transaction raises a
+// high-severity alert and posts the trigger to the watch's webhook.
+type Service struct {
+	cfg        *config.Config
+	repo       *repository.Repository
+	httpClient *http.Client
+	logger     *zap.Logger
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+	mu         sync.RWMutex
+	isRunning  bool
+
+	// watches indexes active watches by watchKey
+	watches map[string][]models.AddressWatch
+	// mempool holds the networks whose mempool transactions are checked
+	mempool map[models.Network]bool
+}
+
+// NewService creates a new address watch service
+func NewService(
+	cfg *config.Config,
+	repo *repository.Repository,
+	logger *zap.Logger,
+) *Service {
+	mempool := make(map[models.Network]bool)
+	for _, network := range cfg.AddressWatch.MempoolNetworks {
+		mempool[models.Network(network)] = true
+	}
+
+	return &Service{
+		cfg:  cfg,
+		repo: repo,
+		httpClient: &http.Client{
+			Timeout: parseDuration(cfg.AddressWatch.WebhookTimeout, 10*time.Second),
+		},
+		logger:   logger,
+		stopChan: make(chan struct{}),
+		watches:  make(map[string][]models.AddressWatch),
+		mempool:  mempool,
+	}
+}
+
+// Start loads the active watches and keeps the index refreshed
+func (s *Service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.isRunning {
+		s.mu.Unlock()
+		return nil
+	}
+	s.isRunning = true
+	s.mu.Unlock()
+
+	if !s.cfg.AddressWatch.Enabled {
+		return nil
+	}
+
+	if err := s.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to load address watches: %w", err)
+	}
+
+	s.logger.Info("Starting address watch service",
+		zap.Int("watches", s.watchCount()),
+		zap.Strings("mempool_networks", s.cfg.AddressWatch.MempoolNetworks))
+
+	s.wg.Add(1)
+	go s.refreshLoop(ctx)
+
+	return nil
+}
+
+// Stop gracefully stops the address watch service
+func (s *Service) Stop() {
+	s.mu.Lock()
+	if !s.isRunning {
+		s.mu.Unlock()
+		return
+	}
+	s.isRunning = false
+	s.mu.Unlock()
+
+	s.logger.Info("Stopping address watch service")
+	close(s.stopChan)
+	s.wg.Wait()
+}
+
+// refreshLoop reloads the index so expired watches drop out and watches
+// changed by other instances are picked up
+func (s *Service) refreshLoop(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(parseDuration(s.cfg.AddressWatch.RefreshInterval, 30*time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				s.logger.Error("Failed to refresh address watches", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Refresh rebuilds the index of active watches from the database
+func (s *Service) Refresh(ctx context.Context) error {
+	watches, err := s.repo.ListActiveAddressWatches(ctx)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string][]models.AddressWatch, len(watches))
+	for _, watch := range watches {
+		key := watchKey(watch.Network, watch.Address)
+		index[key] = append(index[key], watch)
+	}
+
+	s.mu.Lock()
+	s.watches = index
+	s.mu.Unlock()
+	return nil
+}
+
+// Register validates and stores a new address watch and adds it to the index
+func (s *Service) Register(ctx context.Context, req *models.AddressWatchRequest) (*models.AddressWatch, error) {
+	validation := screening.ValidateAddress(req.Address, req.Network)
+	if !validation.Valid {
+		return nil, fmt.Errorf("%w: %s", screening.ErrInvalidAddress, validation.Reason)
+	}
+
+	direction := req.Direction
+	switch direction {
+	case "":
+		direction = models.WatchDirectionAny
+	case models.WatchDirectionAny, models.WatchDirectionSent, models.WatchDirectionReceived:
+	default:
+		return nil, fmt.Errorf("%w: unknown direction %q", ErrInvalidWatch, direction)
+	}
+
+	if req.WebhookURL != "" {
+		u, err := url.Parse(req.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: webhook_url must be an http or https URL", ErrInvalidWatch)
+		}
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at is in the past", ErrInvalidWatch)
+	}
+
+	watch := &models.AddressWatch{
+		ID:         uuid.New().String(),
+		Address:    validation.Normalized,
+		Network:    req.Network,
+		Direction:  direction,
+		Label:      req.Label,
+		Reason:     req.Reason,
+		CaseID:     req.CaseID,
+		WebhookURL: req.WebhookURL,
+		CreatedBy:  req.CreatedBy,
+		ExpiresAt:  req.ExpiresAt,
+		CreatedAt:  now,
+	}
+
+	if err := s.repo.CreateAddressWatch(ctx, watch); err != nil {
+		return nil, err
+	}
+
+	key := watchKey(watch.Network, watch.Address)
+	s.mu.Lock()
+	s.watches[key] = append(s.watches[key], *watch)
+	s.mu.Unlock()
+
+	s.logger.Info("Address watch registered",
+		zap.String("watch_id", watch.ID),
+		zap.String("address", watch.Address),
+		zap.String("network", string(watch.Network)),
+		zap.String("direction", string(watch.Direction)))
+
+	return watch, nil
+}
+
+// Get returns an address watch
+func (s *Service) Get(ctx context.Context, id string) (*models.AddressWatch, error) {
+	watch, err := s.repo.GetAddressWatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if watch == nil {
+		return nil, ErrWatchNotFound
+	}
+	return watch, nil
+}
+
+// List returns the address watches, optionally restricted to a network
+func (s *Service) List(ctx context.Context, network models.Network, limit int) ([]models.AddressWatch, error) {
+	return s.repo.ListAddressWatches(ctx, network, limit)
+}
+
+// Remove removes an address watch and drops it from the index
+func (s *Service) Remove(ctx context.Context, id string) error {
+	removed, err := s.repo.RemoveAddressWatch(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrWatchNotFound
+	}
+
+	s.mu.Lock()
+	for key, watches := range s.watches {
+		kept := watches[:0]
+		for _, watch := range watches {
+			if watch.ID != id {
+				kept = append(kept, watch)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.watches, key)
+		} else {
+			s.watches[key] = kept
+		}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Triggers returns the triggers of an address watch
+func (s *Service) Triggers(ctx context.Context, id string, limit int) ([]models.WatchTrigger, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListWatchTriggers(ctx, id, limit)
+}
+
+// MempoolEnabled reports whether mempool transactions of a network are checked
+func (s *Service) MempoolEnabled(network models.Network) bool {
+	return s.cfg.AddressWatch.Enabled && s.mempool[network]
+}
+
+// CheckTransactions records a trigger for every movement of a watched address
+// in txs. Triggers seen for the first time raise an alert; a confirmed
+// transaction already alerted on from the mempool is only marked confirmed.
+func (s *Service) CheckTransactions(ctx context.Context, txs []*models.NormalizedTransaction, stage models.WatchStage) error {
+	if !s.cfg.AddressWatch.Enabled {
+		return nil
+	}
+
+	s.mu.RLock()
+	empty := len(s.watches) == 0
+	s.mu.RUnlock()
+	if empty {
+		return nil
+	}
+
+	for _, tx := range txs {
+		for _, trigger := range s.match(tx, stage) {
+			if err := s.record(ctx, trigger); err != nil {
+				s.logger.Error("Failed to record address watch trigger",
+					zap.String("watch_id", trigger.watch.ID),
+					zap.String("tx_hash", tx.TxHash),
+					zap.Error(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// pendingTrigger is a watch trigger together with the watch that raised it
+type pendingTrigger struct {
+	watch   models.AddressWatch
+	trigger *models.WatchTrigger
+}
+
+// match returns a trigger for each watch whose address sends or receives
+// funds in tx, summing the inputs and outputs of the address per direction
+func (s *Service) match(tx *models.NormalizedTransaction, stage models.WatchStage) []pendingTrigger {
+	sent := make(map[string]decimal.Decimal)
+	received := make(map[string]decimal.Decimal)
+	for _, in := range tx.Inputs {
+		if in.Address != "" {
+			key := watchKey(tx.Network, in.Address)
+			sent[key] = sent[key].Add(in.Amount)
+		}
+	}
+	for _, out := range tx.Outputs {
+		if out.Address != "" {
+			key := watchKey(tx.Network, out.Address)
+			received[key] = received[key].Add(out.Amount)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	var triggers []pendingTrigger
+	add := func(amounts map[string]decimal.Decimal, direction models.WatchDirection, counterparties []string) {
+		for key, amount := range amounts {
+			for _, watch := range s.watches[key] {
+				if watch.Direction != models.WatchDirectionAny && watch.Direction != direction {
+					continue
+				}
+				if watch.ExpiresAt != nil && !watch.ExpiresAt.After(now) {
+					continue
+				}
+
+				trigger := &models.WatchTrigger{
+					ID:             uuid.New().String(),
+					WatchID:        watch.ID,
+					Address:        watch.Address,
+					Network:        tx.Network,
+					TxHash:         tx.TxHash,
+					Direction:      direction,
+					Amount:         amount,
+					Asset:          tx.Asset,
+					Counterparties: counterparties,
+					Stage:          stage,
+					BlockNumber:    tx.BlockNumber,
+					DetectedAt:     now,
+				}
+				if stage == models.WatchStageConfirmed {
+					trigger.ConfirmedAt = &now
+				}
+				triggers = append(triggers, pendingTrigger{watch: watch, trigger: trigger})
+			}
+		}
+	}
+
+	add(sent, models.WatchDirectionSent, outputAddresses(tx))
+	add(received, models.WatchDirectionReceived, inputAddresses(tx))
+
+	return triggers
+}
+
+// record stores a trigger and, when it is new, raises its alert and posts it
+// to the watch's webhook
+func (s *Service) record(ctx context.Context, pending pendingTrigger) error {
+	trigger := pending.trigger
+
+	created, err := s.repo.SaveWatchTrigger(ctx, trigger)
+	if err != nil {
+		return err
+	}
+	if !created {
+		if trigger.Stage == models.WatchStageConfirmed {
+			return s.repo.ConfirmWatchTrigger(ctx, trigger.WatchID, trigger.TxHash,
+				trigger.Direction, trigger.BlockNumber, *trigger.ConfirmedAt)
+		}
+		return nil
+	}
+
+	if err := s.raiseAlert(ctx, pending.watch, trigger); err != nil {
+		s.logger.Error("Failed to raise address watch alert",
+			zap.String("watch_id", trigger.WatchID),
+			zap.String("tx_hash", trigger.TxHash),
+			zap.Error(err))
+	}
+
+	if pending.watch.WebhookURL != "" {
+		if err := s.repo.SetWatchTriggerWebhookStatus(ctx, trigger.ID, models.WebhookStatusPending); err != nil {
+			return err
+		}
+		trigger.WebhookStatus = models.WebhookStatusPending
+
+		s.wg.Add(1)
+		go s.deliverWebhook(pending.watch, *trigger)
+	}
+
+	return nil
+}
+
+// raiseAlert stores a high-severity alert for the trigger with its supporting evidence
+func (s *Service) raiseAlert(ctx context.Context, watch models.AddressWatch, trigger *models.WatchTrigger) error {
+	verb := "sent"
+	if trigger.Direction == models.WatchDirectionReceived {
+		verb = "received"
+	}
+
+	title := fmt.Sprintf("Watched address %s %s", verb, trigger.Asset)
+	if watch.Label != "" {
+		title = fmt.Sprintf("Watched address %q %s %s", watch.Label, verb, trigger.Asset)
+	}
+
+	alert := models.NewAlert(models.AlertTypeAddressWatch, models.SeverityHigh, "wallet", watch.Address, title)
+	alert.TargetValue = watch.Address
+	alert.Description = fmt.Sprintf("%s %s %s by watched address %s on %s in transaction %s (seen in %s)",
+		trigger.Amount.String(), trigger.Asset, verb, watch.Address, trigger.Network, trigger.TxHash, trigger.Stage)
+	alert.RuleName = "address_watch"
+	alert.Evidence = []string{trigger.TxHash}
+	alert.Metadata = map[string]interface{}{
+		"network":          trigger.Network,
+		"watch_id":         watch.ID,
+		"watch_trigger_id": trigger.ID,
+		"direction":        trigger.Direction,
+		"amount":           trigger.Amount.String(),
+		"asset":            trigger.Asset,
+		"stage":            trigger.Stage,
+		"counterparties":   trigger.Counterparties,
+	}
+	if watch.CaseID != "" {
+		alert.Metadata["case_id"] = watch.CaseID
+	}
+	if watch.Reason != "" {
+		alert.Metadata["reason"] = watch.Reason
+	}
+
+	if err := s.repo.CreateAlert(ctx, alert); err != nil {
+		return err
+	}
+
+	summary, _ := json.Marshal(trigger)
+	evidence := &models.AlertEvidence{
+		ID:           uuid.New().String(),
+		AlertID:      alert.ID,
+		EvidenceType: "transaction",
+		ReferenceID:  trigger.TxHash,
+		Description:  fmt.Sprintf("Transaction moving funds of the watched address, seen in %s", trigger.Stage),
+		Data:         string(summary),
+		CreatedAt:    time.Now(),
+	}
+	if err := s.repo.AddAlertEvidence(ctx, evidence); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetWatchTriggerAlert(ctx, trigger.ID, alert.ID); err != nil {
+		return err
+	}
+	trigger.AlertID = alert.ID
+
+	s.logger.Warn("Address watch alert raised",
+		zap.String("alert_id", alert.ID),
+		zap.String("watch_id", watch.ID),
+		zap.String("address", watch.Address),
+		zap.String("direction", string(trigger.Direction)),
+		zap.String("stage", string(trigger.Stage)),
+		zap.String("tx_hash", trigger.TxHash))
+
+	return nil
+}
+
+// webhookPayload is posted to a watch's webhook for each new trigger
+type webhookPayload struct {
+	Event   string              `json:"event"`
+	Watch   models.AddressWatch `json:"watch"`
+	Trigger models.WatchTrigger `json:"trigger"`
+}
+
+// deliverWebhook posts a trigger to the watch's webhook and records the outcome
+func (s *Service) deliverWebhook(watch models.AddressWatch, trigger models.WatchTrigger) {
+	defer s.wg.Done()
+
+	// The webhook must not block ingestion, nor be cut short when the
+	// ingestion context that detected the trigger is done
+	ctx := context.Background()
+
+	status := models.WebhookStatusDelivered
+	if err := s.postWebhook(ctx, watch, trigger); err != nil {
+		status = models.WebhookStatusFailed
+		s.logger.Warn("Address watch webhook failed",
+			zap.String("watch_id", watch.ID),
+			zap.String("trigger_id", trigger.ID),
+			zap.Error(err))
+	}
+
+	if err := s.repo.SetWatchTriggerWebhookStatus(ctx, trigger.ID, status); err != nil {
+		s.logger.Error("Failed to record webhook status",
+			zap.String("trigger_id", trigger.ID),
+			zap.Error(err))
+	}
+}
+
+func (s *Service) postWebhook(ctx context.Context, watch models.AddressWatch, trigger models.WatchTrigger) error {
+	body, err := json.Marshal(webhookPayload{
+		Event:   "address_watch.triggered",
+		Watch:   watch,
+		Trigger: trigger,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, watch.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *Service) watchCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, watches := range s.watches {
+		count += len(watches)
+	}
+	return count
+}
+
+// watchKey normalizes an address the way screening.ValidateAddress does, so
+// addresses from ingested transactions match the stored watch addresses
+func watchKey(network models.Network, address string) string {
+	switch network {
+	case models.NetworkEthereum, models.NetworkPolygon, models.NetworkBSC:
+		address = strings.ToLower(address)
+	case models.NetworkBitcoin:
+		if lower := strings.ToLower(address); strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") {
+			address = lower
+		}
+	}
+	return string(network) + ":" + address
+}
+
+func inputAddresses(tx *models.NormalizedTransaction) []string {
+	addresses := make([]string, 0, len(tx.Inputs))
+	for _, in := range tx.Inputs {
+		addresses = append(addresses, in.Address)
+	}
+	return uniqueAddresses(addresses)
+}
+
+func outputAddresses(tx *models.NormalizedTransaction) []string {
+	addresses := make([]string, 0, len(tx.Outputs))
+	for _, out := range tx.Outputs {
+		if !out.IsChange {
+			addresses = append(addresses, out.Address)
+		}
+	}
+	return uniqueAddresses(addresses)
+}
+
+func uniqueAddresses(addresses []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, address := range addresses {
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		unique = append(unique, address)
+	}
+	return unique
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csic/wallet-governance/internal/domain/models"
+)
+
+// WORMChain appends freeze order transitions to the audit log service's
+// WORM chain
+type WORMChain struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewWORMChain creates a WORM chain writer for the audit log service at baseURL
+func NewWORMChain(baseURL string, timeout time.Duration) *WORMChain {
+	return &WORMChain{
+		endpoint: strings.TrimRight(baseURL, "/") + "/api/v1/audit/entries",
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// entry is the subset of the audit log service's entry format written for
+// freeze order transitions
+type entry struct {
+	ActorID        string                 `json:"actor_id"`
+	ActorType      string                 `json:"actor_type"`
+	ActorRole      string                 `json:"actor_role"`
+	Service        string                 `json:"service"`
+	Operation      string                 `json:"operation"`
+	ActionType     string                 `json:"action_type"`
+	Resource       string                 `json:"resource"`
+	ResourceID     string                 `json:"resource_id"`
+	Description    string                 `json:"description"`
+	Result         string                 `json:"result"`
+	AffectedIDs    []string               `json:"affected_ids"`
+	ComplianceTags []string               `json:"compliance_tags"`
+	RiskLevel      string                 `json:"risk_level"`
+	RegulatoryRef  string                 `json:"regulatory_ref,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata"`
+}
+
+// AppendFreezeTransition appends a freeze order transition to the audit chain
+func (w *WORMChain) AppendFreezeTransition(ctx context.Context, record *models.FreezeTransitionRecord) error {
+	freeze := record.Freeze
+
+	metadata := map[string]interface{}{
+		"transition":     string(record.Transition),
+		"wallet_id":      freeze.WalletID.String(),
+		"wallet_address": freeze.WalletAddress,
+		"blockchain":     string(freeze.Blockchain),
+		"status":         string(freeze.Status),
+		"freeze_level":   freeze.FreezeLevel,
+		"reason":         string(freeze.Reason),
+		"issued_by":      freeze.IssuedBy.String(),
+		"occurred_at":    record.OccurredAt.Format(time.RFC3339Nano),
+	}
+	if freeze.ExpiresAt != nil {
+		metadata["expires_at"] = freeze.ExpiresAt.Format(time.RFC3339)
+	}
+	for key, value := range record.Details {
+		metadata[key] = value
+	}
+
+	actorType, actorRole := "user", "officer"
+	if record.ActorType == "SYSTEM" {
+		actorType, actorRole = "system", "freeze-enforcement"
+	}
+
+	body, err := json.Marshal(entry{
+		ActorID:        record.ActorID.String(),
+		ActorType:      actorType,
+		ActorRole:      actorRole,
+		Service:        "wallet-governance",
+		Operation:      "freeze." + strings.ToLower(string(record.Transition)),
+		ActionType:     actionType(record.Transition),
+		Resource:       "wallet_freeze",
+		ResourceID:     freeze.ID.String(),
+		Description:    fmt.Sprintf("Freeze order %s on wallet %s %s by %s", freeze.ID, freeze.WalletAddress, strings.ToLower(string(record.Transition)), record.ActorName),
+		Result:         "success",
+		AffectedIDs:    []string{freeze.WalletID.String()},
+		ComplianceTags: []string{"FREEZE_ORDER"},
+		RiskLevel:      "high",
+		RegulatoryRef:  freeze.LegalOrderID,
+		Metadata:       metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal freeze transition: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to append freeze transition: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("audit log rejected freeze transition for %s: status %d", freeze.ID, resp.StatusCode)
+	}
+	return nil
+}
+
+// actionType maps a freeze transition to the audit log service's action types
+func actionType(transition models.FreezeTransition) string {
+	switch transition {
+	case models.FreezeTransitionIssued:
+		return "create"
+	case models.FreezeTransitionRenewalReminded:
+		return "execute"
+	default:
+		return "update"
+	}
+}
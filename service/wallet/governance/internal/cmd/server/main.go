@@ -11,8 +11,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/csic/wallet-governance/internal/audit"
 	"github.com/csic/wallet-governance/internal/config"
+	"github.com/csic/wallet-governance/internal/encryption"
 	"github.com/csic/wallet-governance/internal/handler"
+	"github.com/csic/wallet-governance/internal/legal"
+	"github.com/csic/wallet-governance/internal/notification"
 	"github.com/csic/wallet-governance/internal/repository"
 	"github.com/csic/wallet-governance/internal/service"
 	"github.com/gin-gonic/gin"
@@ -29,6 +33,18 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Initialize HSM service
+	hsmService, err := service.NewHSMService(cfg.HSM)
+	if err != nil {
+		log.Fatalf("Failed to initialize HSM service: %v", err)
+	}
+
+	// Sensitive columns are encrypted with keys wrapped by the HSM
+	var fieldCipher repository.FieldCipher
+	if cfg.Encryption.Enabled {
+		fieldCipher = encryption.NewFieldEncryptor(hsmService)
+	}
+
 	// Initialize repository layer
 	walletRepo, err := repository.NewPostgresWalletRepository(cfg.Database)
 	if err != nil {
@@ -54,7 +70,7 @@ func main() {
 	}
 	defer whitelistRepo.Close()
 
-	freezeRepo, err := repository.NewPostgresWalletFreezeRepository(cfg.Database)
+	freezeRepo, err := repository.NewPostgresWalletFreezeRepository(cfg.Database, fieldCipher)
 	if err != nil {
 		log.Fatalf("Failed to initialize freeze repository: %v", err)
 	}
@@ -66,18 +82,27 @@ func main() {
 	}
 	defer auditRepo.Close()
 
-	// Initialize HSM service
-	hsmService, err := service.NewHSMService(cfg.HSM)
-	if err != nil {
-		log.Fatalf("Failed to initialize HSM service: %v", err)
-	}
-
 	// Initialize service layer
 	walletSvc := service.NewWalletService(walletRepo, blacklistRepo, whitelistRepo, freezeRepo, auditRepo)
 	signatureSvc := service.NewSignatureService(signatureRepo, walletRepo, hsmService, auditRepo)
 	governanceSvc := service.NewGovernanceService(walletRepo, signatureSvc, hsmService, auditRepo)
-	freezeSvc := service.NewFreezeService(walletRepo, freezeRepo, signatureSvc, auditRepo)
+	freezeSvc := service.NewFreezeService(walletRepo, freezeRepo, signatureSvc, auditRepo,
+		legal.NewCatalogueClient(cfg.LegalBasis.CatalogueURL, time.Duration(cfg.LegalBasis.TimeoutSeconds)*time.Second),
+		audit.NewWORMChain(cfg.AuditLog.URL, time.Duration(cfg.AuditLog.TimeoutSeconds)*time.Second),
+		renewalNotifier(cfg),
+		service.FreezeEnforcementConfig{
+			CheckInterval:          time.Duration(cfg.Freeze.CheckIntervalSeconds) * time.Second,
+			ReminderLead:           time.Duration(cfg.Freeze.ReminderDays) * 24 * time.Hour,
+			MinJustificationLength: cfg.Freeze.MinJustificationLength,
+			MaxRenewal:             time.Duration(cfg.Freeze.MaxRenewalDays) * 24 * time.Hour,
+		},
+	)
 	complianceSvc := service.NewComplianceService(walletRepo, blacklistRepo, whitelistRepo, freezeRepo, auditRepo)
+	reencryptionSvc := service.NewReencryptionService(
+		map[string]service.FieldReencryptor{"wallet_freezes": freezeRepo},
+		time.Duration(cfg.Encryption.ReencryptIntervalMinutes)*time.Minute,
+		cfg.Encryption.ReencryptBatchSize,
+	)
 
 	// Initialize handlers
 	httpHandler := handler.NewHTTPHandler(walletSvc, signatureSvc, governanceSvc, freezeSvc, complianceSvc)
@@ -145,6 +170,14 @@ func main() {
 		api.GET("/freeze/:wallet_id", httpHandler.GetFreezeStatus)
 		api.GET("/freeze/active", httpHandler.GetActiveFreezes)
 		api.GET("/freeze/history/:wallet_id", httpHandler.GetFreezeHistory)
+		api.GET("/freeze/orders/:id", httpHandler.GetFreezeOrder)
+		api.POST("/freeze/orders/:id/renew", httpHandler.RenewFreeze)
+		api.GET("/freeze/orders/:id/renewals", httpHandler.GetFreezeRenewals)
+		api.POST("/freeze/orders/:id/amend", httpHandler.AmendFreeze)
+		api.GET("/freeze/orders/:id/timeline", httpHandler.GetFreezeTimeline)
+		api.POST("/freeze/orders/:id/appeal", httpHandler.AppealFreeze)
+		api.POST("/freeze/orders/:id/uphold", httpHandler.UpholdFreeze)
+		api.POST("/freeze/orders/:id/revoke", httpHandler.RevokeFreeze)
 
 		// Compliance endpoints
 		api.GET("/compliance/wallets/:id", httpHandler.GetWalletComplianceStatus)
@@ -162,6 +195,7 @@ func main() {
 		api.GET("/reports/by-exchange", httpHandler.GetWalletsByExchange)
 		api.GET("/reports/by-type", httpHandler.GetWalletsByType)
 		api.GET("/reports/freeze-summary", httpHandler.GetFreezeSummary)
+		api.GET("/reports/freezes-by-legal-basis", httpHandler.GetFreezesByLegalBasis)
 	}
 
 	// Create HTTP server
@@ -184,6 +218,9 @@ func main() {
 	// Start background tasks
 	go governanceSvc.StartTransactionExpiryChecker()
 	go freezeSvc.StartFreezeExpiryChecker()
+	if cfg.Encryption.Enabled {
+		go reencryptionSvc.StartReencryptionJob()
+	}
 
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -199,6 +236,9 @@ func main() {
 	// Stop background tasks
 	governanceSvc.StopTransactionExpiryChecker()
 	freezeSvc.StopFreezeExpiryChecker()
+	if cfg.Encryption.Enabled {
+		reencryptionSvc.StopReencryptionJob()
+	}
 
 	// Shutdown server
 	if err := srv.Shutdown(ctx); err != nil {
@@ -207,3 +247,12 @@ func main() {
 
 	log.Println("Server exited properly")
 }
+
+// renewalNotifier returns the webhook notifier for freeze renewal reminders,
+// or nil when no webhook is configured
+func renewalNotifier(cfg *config.Config) service.RenewalNotifier {
+	if cfg.Freeze.ReminderWebhookURL == "" {
+		return nil
+	}
+	return notification.NewWebhookNotifier(cfg.Freeze.ReminderWebhookURL, time.Duration(cfg.AuditLog.TimeoutSeconds)*time.Second)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,10 @@ type Config struct {
 	Redis    RedisConfig    `yaml:"redis"`
 	Kafka    KafkaConfig    `yaml:"kafka"`
 	HSM      HSMConfig      `yaml:"hsm"`
+	Encryption EncryptionConfig `yaml:"encryption"`
+	Freeze   FreezeConfig   `yaml:"freeze"`
+	AuditLog AuditLogConfig `yaml:"audit_log"`
+	LegalBasis LegalBasisConfig `yaml:"legal_basis"`
 	Governance GovernanceConfig `yaml:"governance"`
 	Signing  SigningConfig  `yaml:"signing"`
 	Logging  LoggingConfig  `yaml:"logging"`
@@ -85,6 +90,40 @@ type HSMConfig struct {
 	Pin         string `yaml:"pin"`
 	KeyLabel    string `yaml:"key_label"`
 	Timeout     int    `yaml:"timeout"`
+
+	// Wrapping keys (base64 AES-256) by ID, used to wrap field encryption keys.
+	// Keep retired keys until the re-encryption job has moved data off them.
+	WrappingKeys      map[string]string `yaml:"wrapping_keys"`
+	ActiveWrappingKey string            `yaml:"active_wrapping_key"`
+}
+
+// EncryptionConfig contains field-level encryption settings
+type EncryptionConfig struct {
+	Enabled                  bool `yaml:"enabled"`
+	ReencryptIntervalMinutes int  `yaml:"reencrypt_interval_minutes"`
+	ReencryptBatchSize       int  `yaml:"reencrypt_batch_size"`
+}
+
+// FreezeConfig contains freeze order expiry and renewal settings
+type FreezeConfig struct {
+	CheckIntervalSeconds   int    `yaml:"check_interval_seconds"`
+	ReminderDays           int    `yaml:"reminder_days"`
+	MinJustificationLength int    `yaml:"min_justification_length"`
+	MaxRenewalDays         int    `yaml:"max_renewal_days"`
+	ReminderWebhookURL     string `yaml:"reminder_webhook_url"`
+}
+
+// AuditLogConfig contains settings for the audit log service's WORM chain
+type AuditLogConfig struct {
+	URL            string `yaml:"url"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+}
+
+// LegalBasisConfig contains settings for the control layer's legal basis
+// catalogue, which freeze citations are checked against
+type LegalBasisConfig struct {
+	CatalogueURL   string `yaml:"catalogue_url"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
 }
 
 // GovernanceConfig contains multi-signature governance settings
@@ -207,6 +246,31 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("HSM_PIN"); v != "" {
 		cfg.HSM.Pin = v
 	}
+	if v := os.Getenv("HSM_ACTIVE_WRAPPING_KEY"); v != "" {
+		cfg.HSM.ActiveWrappingKey = v
+	}
+	// HSM_WRAPPING_KEYS holds comma separated id=base64key pairs
+	if v := os.Getenv("HSM_WRAPPING_KEYS"); v != "" {
+		if cfg.HSM.WrappingKeys == nil {
+			cfg.HSM.WrappingKeys = make(map[string]string)
+		}
+		for _, pair := range strings.Split(v, ",") {
+			if id, key, ok := strings.Cut(strings.TrimSpace(pair), "="); ok {
+				cfg.HSM.WrappingKeys[id] = key
+			}
+		}
+	}
+
+	// Freeze and audit log overrides
+	if v := os.Getenv("FREEZE_REMINDER_WEBHOOK_URL"); v != "" {
+		cfg.Freeze.ReminderWebhookURL = v
+	}
+	if v := os.Getenv("AUDIT_LOG_URL"); v != "" {
+		cfg.AuditLog.URL = v
+	}
+	if v := os.Getenv("LEGAL_BASIS_CATALOGUE_URL"); v != "" {
+		cfg.LegalBasis.CatalogueURL = v
+	}
 
 	// Server overrides
 	if v := os.Getenv("APP_PORT"); v != "" {
@@ -0,0 +1,25 @@
+-- Migration V2: Freeze Order Expiry and Renewals
+-- Direction: UP
+
+-- Set when the issuing officer was reminded of the coming expiry; cleared by
+-- a renewal so the renewed order is reminded again
+ALTER TABLE wallet_freezes ADD COLUMN IF NOT EXISTS renewal_reminder_sent_at TIMESTAMP WITH TIME ZONE;
+
+-- Freeze renewals table
+CREATE TABLE IF NOT EXISTS wallet_freeze_renewals (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	freeze_id UUID NOT NULL REFERENCES wallet_freezes(id) ON DELETE CASCADE,
+	previous_expires_at TIMESTAMP WITH TIME ZONE,
+	new_expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	justification TEXT NOT NULL,
+	document_reference VARCHAR(255) NOT NULL,
+	renewed_by UUID NOT NULL,
+	renewed_by_name VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_freeze_renewals_freeze ON wallet_freeze_renewals(freeze_id, created_at);
+
+-- Direction: DOWN
+-- DROP TABLE IF EXISTS wallet_freeze_renewals CASCADE;
+-- ALTER TABLE wallet_freezes DROP COLUMN IF EXISTS renewal_reminder_sent_at;
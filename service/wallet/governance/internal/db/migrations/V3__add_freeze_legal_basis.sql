@@ -0,0 +1,15 @@
+-- Migration V3: Freeze Legal Basis Citations
+-- Direction: UP
+
+-- Catalogue entry the freeze was issued under, held by the control layer.
+-- The code is copied so reports stay readable if the catalogue is unreachable.
+-- Freezes issued before citations were required have no legal basis.
+ALTER TABLE wallet_freezes ADD COLUMN IF NOT EXISTS legal_basis_id UUID;
+ALTER TABLE wallet_freezes ADD COLUMN IF NOT EXISTS legal_basis_code VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_wallet_freezes_legal_basis ON wallet_freezes(legal_basis_id, created_at);
+
+-- Direction: DOWN
+-- DROP INDEX IF EXISTS idx_wallet_freezes_legal_basis;
+-- ALTER TABLE wallet_freezes DROP COLUMN IF EXISTS legal_basis_code;
+-- ALTER TABLE wallet_freezes DROP COLUMN IF EXISTS legal_basis_id;
@@ -0,0 +1,63 @@
+-- Migration V4: Freeze Order Event Stream
+-- Direction: UP
+
+-- wallet_freezes becomes the current-state projection of each order's
+-- events. It is only written together with the event that changes it, and
+-- version is the sequence of the last event applied.
+ALTER TABLE wallet_freezes ADD COLUMN IF NOT EXISTS under_appeal BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE wallet_freezes ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
+
+-- Freeze order events table. Reason details and metadata stay in
+-- wallet_freezes, where they are encrypted.
+CREATE TABLE IF NOT EXISTS wallet_freeze_events (
+	id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	freeze_id UUID NOT NULL REFERENCES wallet_freezes(id) ON DELETE RESTRICT,
+	sequence INTEGER NOT NULL,
+	event_type VARCHAR(20) NOT NULL,
+	payload JSONB NOT NULL DEFAULT '{}',
+	actor_id UUID NOT NULL,
+	actor_name VARCHAR(255) NOT NULL,
+	actor_type VARCHAR(20) NOT NULL,
+	occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+	UNIQUE (freeze_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_freeze_events_type ON wallet_freeze_events(event_type, occurred_at);
+
+-- Orders issued before the event stream start from an ISSUED event carrying
+-- their state at migration; their earlier history is only in the audit chain
+INSERT INTO wallet_freeze_events (freeze_id, sequence, event_type, payload, actor_id, actor_name, actor_type, occurred_at)
+SELECT id, 1, 'ISSUED',
+	jsonb_build_object(
+		'order', jsonb_build_object(
+			'id', id,
+			'wallet_id', wallet_id,
+			'wallet_address', wallet_address,
+			'blockchain', blockchain,
+			'reason', reason,
+			'status', status,
+			'freeze_level', freeze_level,
+			'legal_order_id', legal_order_id,
+			'legal_basis_id', legal_basis_id,
+			'legal_basis_code', legal_basis_code,
+			'issued_by', issued_by,
+			'issued_by_name', issued_by_name,
+			'approved_by', approved_by,
+			'expires_at', expires_at,
+			'released_at', released_at,
+			'release_reason', release_reason,
+			'created_at', created_at,
+			'updated_at', updated_at
+		),
+		'reason', 'State at migration to the event stream'
+	),
+	issued_by, issued_by_name, 'SYSTEM', created_at
+FROM wallet_freezes
+WHERE version = 0;
+
+UPDATE wallet_freezes SET version = 1 WHERE version = 0;
+
+-- Direction: DOWN
+-- DROP TABLE IF EXISTS wallet_freeze_events CASCADE;
+-- ALTER TABLE wallet_freezes DROP COLUMN IF EXISTS version;
+-- ALTER TABLE wallet_freezes DROP COLUMN IF EXISTS under_appeal;
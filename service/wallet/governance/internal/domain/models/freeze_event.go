@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FreezeEventType represents an event in a freeze order's event stream
+type FreezeEventType string
+
+const (
+	FreezeEventIssued   FreezeEventType = "ISSUED"
+	FreezeEventAmended  FreezeEventType = "AMENDED"
+	FreezeEventAppealed FreezeEventType = "APPEALED"
+	FreezeEventUpheld   FreezeEventType = "UPHELD"
+	FreezeEventRevoked  FreezeEventType = "REVOKED"
+	FreezeEventExpired  FreezeEventType = "EXPIRED"
+)
+
+// ErrInvalidFreezeEvent is returned when an event does not follow from the
+// freeze order's current state
+var ErrInvalidFreezeEvent = errors.New("invalid freeze event")
+
+// ErrFreezeVersionConflict is returned when a freeze order gained an event
+// after it was read
+var ErrFreezeVersionConflict = errors.New("freeze order was changed concurrently")
+
+// FreezeEvent is an entry in a freeze order's event stream. A freeze order's
+// status, scope, expiry and appeal state are the projection of its events.
+type FreezeEvent struct {
+	ID         uuid.UUID          `json:"id" db:"id"`
+	FreezeID   uuid.UUID          `json:"freeze_id" db:"freeze_id"`
+	Sequence   int                `json:"sequence" db:"sequence"` // 1 for the ISSUED event
+	Type       FreezeEventType    `json:"type" db:"event_type"`
+	Payload    FreezeEventPayload `json:"payload" db:"payload"`
+	ActorID    uuid.UUID          `json:"actor_id" db:"actor_id"`
+	ActorName  string             `json:"actor_name" db:"actor_name"`
+	ActorType  string             `json:"actor_type" db:"actor_type"` // "USER", "SYSTEM"
+	OccurredAt time.Time          `json:"occurred_at" db:"occurred_at"`
+}
+
+// FreezeEventPayload holds what an event changes. Reason details and metadata
+// are kept out of events so that they are only stored encrypted.
+type FreezeEventPayload struct {
+	Order             *WalletFreeze `json:"order,omitempty"`              // ISSUED
+	FreezeLevel       string        `json:"freeze_level,omitempty"`       // AMENDED
+	ExpiresAt         *time.Time    `json:"expires_at,omitempty"`         // AMENDED
+	Justification     string        `json:"justification,omitempty"`      // AMENDED
+	DocumentReference string        `json:"document_reference,omitempty"` // AMENDED
+	RenewalID         *uuid.UUID    `json:"renewal_id,omitempty"`         // AMENDED by a renewal
+	AppealID          *uuid.UUID    `json:"appeal_id,omitempty"`          // APPEALED, UPHELD, REVOKED on appeal
+	Reason            string        `json:"reason,omitempty"`             // REVOKED, EXPIRED
+}
+
+// FreezeTimelineEntry is a freeze order event with the order's state after it
+type FreezeTimelineEntry struct {
+	*FreezeEvent
+	Status      FreezeStatus `json:"status"`
+	FreezeLevel string       `json:"freeze_level"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+	UnderAppeal bool         `json:"under_appeal"`
+}
+
+// FreezeAmendmentRequest represents a request to change a freeze order's scope
+type FreezeAmendmentRequest struct {
+	FreezeLevel       string `json:"freeze_level" binding:"required"` // "FULL", "INCOMING", "OUTGOING"
+	Justification     string `json:"justification" binding:"required"`
+	DocumentReference string `json:"document_reference" binding:"required"`
+}
+
+// IsInForce reports whether the freeze order still holds its wallet
+func (f *WalletFreeze) IsInForce() bool {
+	return f.Status == FreezeStatusActive || f.Status == FreezeStatusPartial
+}
+
+// Apply advances the freeze order by event, which must be the next in its
+// stream. Reason details and metadata are left as they are.
+func (f *WalletFreeze) Apply(event *FreezeEvent) error {
+	if event.Sequence != f.Version+1 {
+		return fmt.Errorf("%w: event %d does not follow version %d", ErrInvalidFreezeEvent, event.Sequence, f.Version)
+	}
+	if event.Type != FreezeEventIssued && !f.IsInForce() {
+		return fmt.Errorf("%w: %s does not apply to a %s freeze", ErrInvalidFreezeEvent, event.Type, f.Status)
+	}
+
+	payload := event.Payload
+	switch event.Type {
+	case FreezeEventIssued:
+		if payload.Order == nil {
+			return fmt.Errorf("%w: ISSUED event carries no order", ErrInvalidFreezeEvent)
+		}
+		reasonDetails, metadata := f.ReasonDetails, f.Metadata
+		*f = *payload.Order
+		f.ID = event.FreezeID
+		f.ReasonDetails, f.Metadata = reasonDetails, metadata
+		f.CreatedAt = event.OccurredAt
+	case FreezeEventAmended:
+		if payload.FreezeLevel != "" {
+			f.FreezeLevel = payload.FreezeLevel
+			f.Status = FreezeStatusActive
+			if payload.FreezeLevel != "FULL" {
+				f.Status = FreezeStatusPartial
+			}
+		}
+		if payload.ExpiresAt != nil {
+			f.ExpiresAt = payload.ExpiresAt
+		}
+	case FreezeEventAppealed:
+		if f.UnderAppeal {
+			return fmt.Errorf("%w: freeze is already under appeal", ErrInvalidFreezeEvent)
+		}
+		f.UnderAppeal = true
+	case FreezeEventUpheld:
+		if !f.UnderAppeal {
+			return fmt.Errorf("%w: freeze is not under appeal", ErrInvalidFreezeEvent)
+		}
+		f.UnderAppeal = false
+	case FreezeEventRevoked, FreezeEventExpired:
+		f.Status = FreezeStatusReleased
+		if event.Type == FreezeEventExpired {
+			f.Status = FreezeStatusExpired
+		}
+		occurredAt := event.OccurredAt
+		f.ReleasedAt = &occurredAt
+		f.ReleaseReason = payload.Reason
+		f.UnderAppeal = false
+	default:
+		return fmt.Errorf("%w: unknown event type %q", ErrInvalidFreezeEvent, event.Type)
+	}
+
+	f.Version = event.Sequence
+	f.UpdatedAt = event.OccurredAt
+	return nil
+}
+
+// ProjectFreeze replays a freeze order's events, oldest first, returning the
+// state after each
+func ProjectFreeze(events []*FreezeEvent) (*WalletFreeze, []*FreezeTimelineEntry, error) {
+	freeze := &WalletFreeze{}
+	timeline := make([]*FreezeTimelineEntry, 0, len(events))
+	for _, event := range events {
+		if err := freeze.Apply(event); err != nil {
+			return nil, nil, err
+		}
+		timeline = append(timeline, &FreezeTimelineEntry{
+			FreezeEvent: event,
+			Status:      freeze.Status,
+			FreezeLevel: freeze.FreezeLevel,
+			ExpiresAt:   freeze.ExpiresAt,
+			UnderAppeal: freeze.UnderAppeal,
+		})
+	}
+	return freeze, timeline, nil
+}
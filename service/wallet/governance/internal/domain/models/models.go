@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -299,17 +300,105 @@ type WalletFreeze struct {
 	Status        FreezeStatus  `json:"status" db:"status"`
 	FreezeLevel   string        `json:"freeze_level" db:"freeze_level"` // "FULL", "INCOMING", "OUTGOING"
 	LegalOrderID  string        `json:"legal_order_id,omitempty" db:"legal_order_id"`
+	LegalBasisID  *uuid.UUID    `json:"legal_basis_id,omitempty" db:"legal_basis_id"`
+	LegalBasisCode string       `json:"legal_basis_code,omitempty" db:"legal_basis_code"`
 	IssuedBy      uuid.UUID     `json:"issued_by" db:"issued_by"`
 	IssuedByName  string        `json:"issued_by_name" db:"issued_by_name"`
 	ApprovedBy    *uuid.UUID    `json:"approved_by,omitempty" db:"approved_by"`
 	ExpiresAt     *time.Time    `json:"expires_at,omitempty" db:"expires_at"`
 	ReleasedAt    *time.Time    `json:"released_at,omitempty" db:"released_at"`
 	ReleaseReason string        `json:"release_reason,omitempty" db:"release_reason"`
+	UnderAppeal   bool          `json:"under_appeal" db:"under_appeal"`
+	Version       int           `json:"version" db:"version"` // sequence of the last event applied
 	Metadata      JSONMap       `json:"metadata,omitempty" db:"metadata"`
 	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
 }
 
+// LegalBasis is a legal basis catalogue entry, as held by the control layer
+type LegalBasis struct {
+	ID        uuid.UUID  `json:"id"`
+	Code      string     `json:"code"`
+	Statute   string     `json:"statute"`
+	Section   string     `json:"section"`
+	Title     string     `json:"title"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+}
+
+// ErrInvalidCitation is returned when a freeze cites a legal basis that is
+// missing from the catalogue, not in force or does not cover wallet freezes
+var ErrInvalidCitation = errors.New("invalid legal basis citation")
+
+// FreezeLegalBasisCount aggregates the freezes citing a legal basis
+type FreezeLegalBasisCount struct {
+	LegalBasisID   *uuid.UUID `json:"legal_basis_id"` // nil for freezes issued before citations were required
+	LegalBasisCode string     `json:"legal_basis_code"`
+	Count          int        `json:"count"`
+	Active         int        `json:"active"`
+}
+
+// FreezeRenewal records the extension of a freeze order's expiry
+type FreezeRenewal struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	FreezeID          uuid.UUID  `json:"freeze_id" db:"freeze_id"`
+	PreviousExpiresAt *time.Time `json:"previous_expires_at,omitempty" db:"previous_expires_at"`
+	NewExpiresAt      time.Time  `json:"new_expires_at" db:"new_expires_at"`
+	Justification     string     `json:"justification" db:"justification"`
+	DocumentReference string     `json:"document_reference" db:"document_reference"` // court order or case document
+	RenewedBy         uuid.UUID  `json:"renewed_by" db:"renewed_by"`
+	RenewedByName     string     `json:"renewed_by_name" db:"renewed_by_name"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+}
+
+// FreezeRenewalRequest represents a request to extend a freeze order
+type FreezeRenewalRequest struct {
+	ExpiresAt         time.Time `json:"expires_at" binding:"required"`
+	Justification     string    `json:"justification" binding:"required"`
+	DocumentReference string    `json:"document_reference" binding:"required"`
+}
+
+// FreezeOrderDetail is a freeze order with the parties entitled to appeal it:
+// the owner of the frozen wallet and, for exchange wallets, the exchange
+type FreezeOrderDetail struct {
+	*WalletFreeze
+	OwnerEntityID uuid.UUID  `json:"owner_entity_id"`
+	ExchangeID    *uuid.UUID `json:"exchange_id,omitempty"`
+}
+
+// FreezeAppealRequest names the appeal an appeal, uphold or revocation of a
+// freeze order belongs to
+type FreezeAppealRequest struct {
+	AppealID uuid.UUID `json:"appeal_id" binding:"required"`
+	Reason   string    `json:"reason"` // revocation only
+}
+
+// FreezeTransition represents a step in a freeze order's lifecycle
+type FreezeTransition string
+
+const (
+	FreezeTransitionIssued          FreezeTransition = "ISSUED"
+	FreezeTransitionReleased        FreezeTransition = "RELEASED"
+	FreezeTransitionExpired         FreezeTransition = "EXPIRED"
+	FreezeTransitionRenewed         FreezeTransition = "RENEWED"
+	FreezeTransitionRenewalReminded FreezeTransition = "RENEWAL_REMINDED"
+	FreezeTransitionAmended         FreezeTransition = "AMENDED"
+	FreezeTransitionAppealed        FreezeTransition = "APPEALED"
+	FreezeTransitionUpheld          FreezeTransition = "UPHELD"
+)
+
+// FreezeTransitionRecord represents a freeze order transition as recorded in
+// the audit chain
+type FreezeTransitionRecord struct {
+	Transition FreezeTransition       `json:"transition"`
+	Freeze     *WalletFreeze          `json:"freeze"`
+	ActorID    uuid.UUID              `json:"actor_id"`
+	ActorName  string                 `json:"actor_name"`
+	ActorType  string                 `json:"actor_type"` // "USER", "SYSTEM"
+	Details    map[string]interface{} `json:"details,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
 // AssetRecoveryRequest represents an asset recovery request
 type AssetRecoveryRequest struct {
 	ID              uuid.UUID    `json:"id" db:"id"`
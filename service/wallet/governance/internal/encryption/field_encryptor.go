@@ -0,0 +1,149 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// valuePrefix marks a value produced by FieldEncryptor. Values without it are
+// treated as plaintext written before encryption was enabled.
+const valuePrefix = "enc:v1:"
+
+// KeyWrapper wraps and unwraps data encryption keys, normally with the HSM
+type KeyWrapper interface {
+	ActiveWrappingKeyID() string
+	WrapKey(ctx context.Context, dek []byte) (string, []byte, error)
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// FieldEncryptor encrypts individual column values with AES-256-GCM. Every
+// value gets its own data encryption key, which is stored next to the
+// ciphertext wrapped by the KeyWrapper:
+//
+//	enc:v1:<wrapping key id>:<wrapped key>:<nonce and ciphertext>
+type FieldEncryptor struct {
+	keys KeyWrapper
+}
+
+// NewFieldEncryptor creates a new field encryptor
+func NewFieldEncryptor(keys KeyWrapper) *FieldEncryptor {
+	return &FieldEncryptor{keys: keys}
+}
+
+// Encrypt encrypts a value. The additional data binds the ciphertext to its
+// row and column so it cannot be copied elsewhere. Empty values are returned
+// unchanged.
+func (e *FieldEncryptor) Encrypt(ctx context.Context, plaintext, aad string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(aad))
+
+	keyID, wrapped, err := e.keys.WrapKey(ctx, dek)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return valuePrefix + keyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value produced by Encrypt with the same additional data.
+// Plaintext values are returned unchanged.
+func (e *FieldEncryptor) Decrypt(ctx context.Context, value, aad string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	keyID, wrapped, sealed, err := parseValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := e.keys.UnwrapKey(ctx, keyID, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("encrypted value is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(aad))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsReencryption reports whether a non-empty value is plaintext or was
+// encrypted under a wrapping key other than the active one
+func (e *FieldEncryptor) NeedsReencryption(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+
+	keyID, _, _, err := parseValue(value)
+	return err != nil || keyID != e.keys.ActiveWrappingKeyID()
+}
+
+// IsEncrypted reports whether a value was produced by FieldEncryptor
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, valuePrefix)
+}
+
+// parseValue splits an encrypted value into its wrapping key ID, wrapped data
+// key and sealed payload
+func parseValue(value string) (string, []byte, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(value, valuePrefix), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, nil, fmt.Errorf("malformed encrypted value")
+	}
+
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("malformed wrapped key: %w", err)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("malformed ciphertext: %w", err)
+	}
+
+	return parts[0], wrapped, sealed, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/csic/wallet-governance/internal/domain/models"
 	"github.com/csic/wallet-governance/internal/service"
@@ -302,6 +304,10 @@ func (h *HTTPHandler) FreezeWallet(c *gin.Context) {
 	actorName := getUserName(c)
 
 	if err := h.freezeSvc.FreezeWallet(c.Request.Context(), &freeze, actorID, actorName); err != nil {
+		if errors.Is(err, models.ErrInvalidCitation) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -316,6 +322,7 @@ func (h *HTTPHandler) EmergencyFreeze(c *gin.Context) {
 		Reason        models.FreezeReason `json:"reason" binding:"required"`
 		ReasonDetails string           `json:"reason_details"`
 		LegalOrderID  string           `json:"legal_order_id"`
+		LegalBasisID  uuid.UUID        `json:"legal_basis_id" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -325,7 +332,11 @@ func (h *HTTPHandler) EmergencyFreeze(c *gin.Context) {
 	actorID := getUserID(c)
 	actorName := getUserName(c)
 
-	if err := h.freezeSvc.EmergencyFreeze(c.Request.Context(), req.WalletID, req.Reason, req.ReasonDetails, req.LegalOrderID, actorID, actorName); err != nil {
+	if err := h.freezeSvc.EmergencyFreeze(c.Request.Context(), req.WalletID, req.Reason, req.ReasonDetails, req.LegalOrderID, req.LegalBasisID, actorID, actorName); err != nil {
+		if errors.Is(err, models.ErrInvalidCitation) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -404,6 +415,184 @@ func (h *HTTPHandler) GetFreezeHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, freezes)
 }
 
+// RenewFreeze extends a freeze order with a documented justification
+func (h *HTTPHandler) RenewFreeze(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid freeze ID"})
+		return
+	}
+
+	var req models.FreezeRenewalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actorID := getUserID(c)
+	actorName := getUserName(c)
+
+	renewal, err := h.freezeSvc.RenewFreeze(c.Request.Context(), id, &req, actorID, actorName)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRenewal) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, models.ErrFreezeVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, renewal)
+}
+
+// GetFreezeRenewals retrieves the renewals of a freeze order
+func (h *HTTPHandler) GetFreezeRenewals(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid freeze ID"})
+		return
+	}
+
+	renewals, err := h.freezeSvc.GetFreezeRenewals(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, renewals)
+}
+
+// AmendFreeze changes the scope of a freeze order with a documented
+// justification
+func (h *HTTPHandler) AmendFreeze(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid freeze ID"})
+		return
+	}
+
+	var req models.FreezeAmendmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actorID := getUserID(c)
+	actorName := getUserName(c)
+
+	freeze, err := h.freezeSvc.AmendFreeze(c.Request.Context(), id, &req, actorID, actorName)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAmendment) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, models.ErrInvalidFreezeEvent) || errors.Is(err, models.ErrFreezeVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, freeze)
+}
+
+// GetFreezeOrder retrieves a freeze order with the owner and exchange of its
+// wallet
+func (h *HTTPHandler) GetFreezeOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid freeze ID"})
+		return
+	}
+
+	freeze, err := h.freezeSvc.GetFreezeOrder(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if freeze == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "freeze not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, freeze)
+}
+
+// AppealFreeze marks a freeze order as under appeal
+func (h *HTTPHandler) AppealFreeze(c *gin.Context) {
+	h.decideAppeal(c, func(id uuid.UUID, req *models.FreezeAppealRequest, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error) {
+		return h.freezeSvc.AppealFreeze(c.Request.Context(), id, req.AppealID, actorID, actorName)
+	})
+}
+
+// UpholdFreeze records that an appeal against a freeze order was rejected
+func (h *HTTPHandler) UpholdFreeze(c *gin.Context) {
+	h.decideAppeal(c, func(id uuid.UUID, req *models.FreezeAppealRequest, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error) {
+		return h.freezeSvc.UpholdFreeze(c.Request.Context(), id, req.AppealID, actorID, actorName)
+	})
+}
+
+// RevokeFreeze lifts a freeze order on appeal
+func (h *HTTPHandler) RevokeFreeze(c *gin.Context) {
+	h.decideAppeal(c, func(id uuid.UUID, req *models.FreezeAppealRequest, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error) {
+		return h.freezeSvc.RevokeFreeze(c.Request.Context(), id, &req.AppealID, req.Reason, actorID, actorName)
+	})
+}
+
+// decideAppeal binds an appeal step on the freeze order named in the path and
+// applies it with apply
+func (h *HTTPHandler) decideAppeal(c *gin.Context, apply func(id uuid.UUID, req *models.FreezeAppealRequest, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid freeze ID"})
+		return
+	}
+
+	var req models.FreezeAppealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	freeze, err := apply(id, &req, getUserID(c), getUserName(c))
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidFreezeEvent) || errors.Is(err, models.ErrFreezeVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, freeze)
+}
+
+// GetFreezeTimeline retrieves the event stream of a freeze order
+func (h *HTTPHandler) GetFreezeTimeline(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid freeze ID"})
+		return
+	}
+
+	timeline, err := h.freezeSvc.GetFreezeTimeline(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if timeline == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "freeze not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
 // Blacklist handlers
 
 // AddToBlacklist adds an address to the blacklist
@@ -893,6 +1082,51 @@ func (h *HTTPHandler) GetFreezeSummary(c *gin.Context) {
 	})
 }
 
+// GetFreezesByLegalBasis counts the freezes issued in a window by the legal
+// basis they cite. The window defaults to the last 30 days.
+func (h *HTTPHandler) GetFreezesByLegalBasis(c *gin.Context) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from time, expected RFC 3339"})
+			return
+		}
+		from = t
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to time, expected RFC 3339"})
+			return
+		}
+		to = t
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+
+	counts, err := h.freezeSvc.GetFreezesByLegalBasis(c.Request.Context(), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count.Count
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from":    from,
+		"to":      to,
+		"total":   total,
+		"entries": counts,
+	})
+}
+
 // Helper functions
 
 func getUserID(c *gin.Context) uuid.UUID {
@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
@@ -22,12 +25,29 @@ import (
 type HSMService struct {
 	config config.HSMConfig
 	key    *ecdsa.PrivateKey
+
+	// wrapping keys encrypt the data encryption keys used for field encryption
+	wrappingKeys     map[string]cipher.AEAD
+	activeWrappingID string
 }
 
 // NewHSMService creates a new HSM service
 func NewHSMService(cfg config.HSMConfig) (*HSMService, error) {
 	svc := &HSMService{
-		config: cfg,
+		config:           cfg,
+		wrappingKeys:     make(map[string]cipher.AEAD, len(cfg.WrappingKeys)),
+		activeWrappingID: cfg.ActiveWrappingKey,
+	}
+
+	for id, encoded := range cfg.WrappingKeys {
+		aead, err := newWrappingAEAD(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wrapping key %s: %w", id, err)
+		}
+		svc.wrappingKeys[id] = aead
+	}
+	if svc.activeWrappingID != "" && svc.wrappingKeys[svc.activeWrappingID] == nil {
+		return nil, fmt.Errorf("active wrapping key %s is not configured", svc.activeWrappingID)
 	}
 
 	if !cfg.Enabled {
@@ -163,6 +183,64 @@ func (s *HSMService) GetPublicKeyHash() string {
 	return s.HashPublicKey(elliptic.Marshal(elliptic.P256(), s.key.X, s.key.Y))
 }
 
+// ActiveWrappingKeyID returns the ID of the key new data encryption keys are
+// wrapped with, or an empty string if none is configured
+func (s *HSMService) ActiveWrappingKeyID() string {
+	return s.activeWrappingID
+}
+
+// WrapKey encrypts a data encryption key with the active wrapping key and
+// returns the wrapping key ID with the wrapped key
+func (s *HSMService) WrapKey(ctx context.Context, dek []byte) (string, []byte, error) {
+	aead := s.wrappingKeys[s.activeWrappingID]
+	if aead == nil {
+		return "", nil, fmt.Errorf("no active wrapping key configured")
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return s.activeWrappingID, aead.Seal(nonce, nonce, dek, []byte(s.activeWrappingID)), nil
+}
+
+// UnwrapKey decrypts a data encryption key wrapped by WrapKey. Retired
+// wrapping keys stay configured until data wrapped with them is re-encrypted.
+func (s *HSMService) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead := s.wrappingKeys[keyID]
+	if aead == nil {
+		return nil, fmt.Errorf("wrapping key %s is not configured", keyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, fmt.Errorf("wrapped key is too short")
+	}
+
+	nonce, sealed := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	dek, err := aead.Open(nil, nonce, sealed, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key: %w", err)
+	}
+	return dek, nil
+}
+
+// newWrappingAEAD creates an AES-256-GCM cipher from a base64 encoded key
+func newWrappingAEAD(encoded string) (cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
 // KeyPair represents a generated key pair
 type KeyPair struct {
 	ID           string
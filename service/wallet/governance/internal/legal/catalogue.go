@@ -0,0 +1,73 @@
+package legal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/csic/wallet-governance/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// freezeAction is the control layer's action name for wallet freezes
+const freezeAction = "wallet_freeze"
+
+// CatalogueClient checks legal basis citations against the control layer's
+// legal basis catalogue
+type CatalogueClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewCatalogueClient creates a catalogue client for the control layer at baseURL
+func NewCatalogueClient(baseURL string, timeout time.Duration) *CatalogueClient {
+	return &CatalogueClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// CheckCitation returns the catalogue entry with the given ID if it can be
+// cited for a wallet freeze at the given time. Rejected citations return an
+// error wrapping models.ErrInvalidCitation.
+func (c *CatalogueClient) CheckCitation(ctx context.Context, id uuid.UUID, at time.Time) (*models.LegalBasis, error) {
+	query := url.Values{}
+	query.Set("action", freezeAction)
+	query.Set("at", at.UTC().Format(time.RFC3339))
+	endpoint := fmt.Sprintf("%s/api/v1/legal-bases/%s/check?%s", c.baseURL, id, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach legal basis catalogue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var basis models.LegalBasis
+		if err := json.NewDecoder(resp.Body).Decode(&basis); err != nil {
+			return nil, fmt.Errorf("failed to decode legal basis: %w", err)
+		}
+		return &basis, nil
+	case http.StatusNotFound, http.StatusUnprocessableEntity:
+		var body struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		if body.Error == "" {
+			body.Error = fmt.Sprintf("legal basis %s cannot be cited", id)
+		}
+		return nil, fmt.Errorf("%w: %s", models.ErrInvalidCitation, strings.TrimPrefix(body.Error, models.ErrInvalidCitation.Error()+": "))
+	default:
+		return nil, fmt.Errorf("legal basis catalogue returned status %d", resp.StatusCode)
+	}
+}
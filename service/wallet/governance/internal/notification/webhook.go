@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/csic/wallet-governance/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// WebhookNotifier delivers freeze renewal reminders to the platform's
+// notification webhook, addressed to the issuing officer
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to url
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// renewalReminder is the webhook body of a renewal reminder
+type renewalReminder struct {
+	Type          string    `json:"type"`
+	RecipientID   uuid.UUID `json:"recipient_id"`
+	RecipientName string    `json:"recipient_name"`
+	FreezeID      uuid.UUID `json:"freeze_id"`
+	WalletID      uuid.UUID `json:"wallet_id"`
+	WalletAddress string    `json:"wallet_address"`
+	Blockchain    string    `json:"blockchain"`
+	LegalOrderID  string    `json:"legal_order_id,omitempty"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	Message       string    `json:"message"`
+}
+
+// NotifyRenewalDue reminds the issuing officer of a freeze that it is about to
+// expire and will be lifted unless renewed
+func (n *WebhookNotifier) NotifyRenewalDue(ctx context.Context, freeze *models.WalletFreeze) error {
+	body, err := json.Marshal(renewalReminder{
+		Type:          "FREEZE_RENEWAL_DUE",
+		RecipientID:   freeze.IssuedBy,
+		RecipientName: freeze.IssuedByName,
+		FreezeID:      freeze.ID,
+		WalletID:      freeze.WalletID,
+		WalletAddress: freeze.WalletAddress,
+		Blockchain:    string(freeze.Blockchain),
+		LegalOrderID:  freeze.LegalOrderID,
+		ExpiresAt:     *freeze.ExpiresAt,
+		Message: fmt.Sprintf("The freeze on wallet %s expires at %s and will be lifted automatically unless it is renewed with a documented justification.",
+			freeze.WalletAddress, freeze.ExpiresAt.UTC().Format(time.RFC3339)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal renewal reminder: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send renewal reminder: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook rejected renewal reminder for %s: status %d", freeze.ID, resp.StatusCode)
+	}
+	return nil
+}
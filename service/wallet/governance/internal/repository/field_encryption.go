@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/csic/wallet-governance/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// FieldCipher encrypts and decrypts sensitive column values
+type FieldCipher interface {
+	Encrypt(ctx context.Context, plaintext, aad string) (string, error)
+	Decrypt(ctx context.Context, value, aad string) (string, error)
+	NeedsReencryption(value string) bool
+}
+
+// fieldAAD binds an encrypted value to its table, column and row
+func fieldAAD(table, column string, id uuid.UUID) string {
+	return fmt.Sprintf("%s.%s:%s", table, column, id)
+}
+
+// encryptFreezeFields returns the stored form of a freeze's reason details and
+// metadata. Encrypted metadata is kept in the JSONB column as a JSON string.
+func encryptFreezeFields(ctx context.Context, cipher FieldCipher, freeze *models.WalletFreeze) (string, []byte, error) {
+	metadataJSON, err := json.Marshal(freeze.Metadata)
+	if err != nil {
+		metadataJSON = []byte("{}")
+	}
+	if cipher == nil {
+		return freeze.ReasonDetails, metadataJSON, nil
+	}
+
+	reasonDetails, err := cipher.Encrypt(ctx, freeze.ReasonDetails, fieldAAD("wallet_freezes", "reason_details", freeze.ID))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encrypt reason details: %w", err)
+	}
+
+	metadata, err := cipher.Encrypt(ctx, string(metadataJSON), fieldAAD("wallet_freezes", "metadata", freeze.ID))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encrypt metadata: %w", err)
+	}
+	encryptedMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return reasonDetails, encryptedMetadata, nil
+}
+
+// decryptFreezeFields decrypts a scanned freeze's reason details and metadata.
+// Rows written before encryption was enabled are read as plaintext.
+func decryptFreezeFields(ctx context.Context, cipher FieldCipher, freeze *models.WalletFreeze, metadata []byte) error {
+	if cipher != nil {
+		reasonDetails, err := cipher.Decrypt(ctx, freeze.ReasonDetails, fieldAAD("wallet_freezes", "reason_details", freeze.ID))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt reason details of freeze %s: %w", freeze.ID, err)
+		}
+		freeze.ReasonDetails = reasonDetails
+
+		var encrypted string
+		if json.Unmarshal(metadata, &encrypted) == nil {
+			decrypted, err := cipher.Decrypt(ctx, encrypted, fieldAAD("wallet_freezes", "metadata", freeze.ID))
+			if err != nil {
+				return fmt.Errorf("failed to decrypt metadata of freeze %s: %w", freeze.ID, err)
+			}
+			metadata = []byte(decrypted)
+		}
+	}
+
+	json.Unmarshal(metadata, &freeze.Metadata)
+	return nil
+}
@@ -16,11 +16,13 @@ import (
 
 // PostgresWalletFreezeRepository handles wallet freeze data access
 type PostgresWalletFreezeRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	cipher FieldCipher
 }
 
-// NewPostgresWalletFreezeRepository creates a new wallet freeze repository
-func NewPostgresWalletFreezeRepository(cfg config.DatabaseConfig) (*PostgresWalletFreezeRepository, error) {
+// NewPostgresWalletFreezeRepository creates a new wallet freeze repository.
+// When cipher is not nil, reason details and metadata are stored encrypted.
+func NewPostgresWalletFreezeRepository(cfg config.DatabaseConfig, cipher FieldCipher) (*PostgresWalletFreezeRepository, error) {
 	connStr := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.Name, cfg.SSLMode,
@@ -39,7 +41,7 @@ func NewPostgresWalletFreezeRepository(cfg config.DatabaseConfig) (*PostgresWall
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &PostgresWalletFreezeRepository{db: db}, nil
+	return &PostgresWalletFreezeRepository{db: db, cipher: cipher}, nil
 }
 
 // Close closes the database connection
@@ -47,43 +49,53 @@ func (r *PostgresWalletFreezeRepository) Close() error {
 	return r.db.Close()
 }
 
-// Create creates a new wallet freeze record
-func (r *PostgresWalletFreezeRepository) Create(ctx context.Context, freeze *models.WalletFreeze) error {
+// Create stores a new freeze order with its ISSUED event
+func (r *PostgresWalletFreezeRepository) Create(ctx context.Context, freeze *models.WalletFreeze, event *models.FreezeEvent) error {
 	query := `
 		INSERT INTO wallet_freezes (
 			id, wallet_id, wallet_address, blockchain, reason, reason_details,
-			status, freeze_level, legal_order_id, issued_by, issued_by_name,
-			approved_by, expires_at, metadata, created_at, updated_at
+			status, freeze_level, legal_order_id, legal_basis_id, legal_basis_code, issued_by, issued_by_name,
+			approved_by, expires_at, version, metadata, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
 		)
 	`
 
-	freeze.ID = uuid.New()
-	freeze.CreatedAt = time.Now()
-	freeze.UpdatedAt = time.Now()
+	reasonDetails, metadataJSON, err := encryptFreezeFields(ctx, r.cipher, freeze)
+	if err != nil {
+		return err
+	}
 
-	metadataJSON, err := json.Marshal(freeze.Metadata)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		metadataJSON = []byte("{}")
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		freeze.ID, freeze.WalletID, freeze.WalletAddress, freeze.Blockchain, freeze.Reason,
-		freeze.ReasonDetails, freeze.Status, freeze.FreezeLevel, freeze.LegalOrderID,
+		reasonDetails, freeze.Status, freeze.FreezeLevel, freeze.LegalOrderID,
+		freeze.LegalBasisID, freeze.LegalBasisCode,
 		freeze.IssuedBy, freeze.IssuedByName, freeze.ApprovedBy, freeze.ExpiresAt,
-		metadataJSON, freeze.CreatedAt, freeze.UpdatedAt,
+		freeze.Version, metadataJSON, freeze.CreatedAt, freeze.UpdatedAt,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := insertFreezeEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // GetByID retrieves a freeze record by ID
 func (r *PostgresWalletFreezeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WalletFreeze, error) {
 	query := `
 		SELECT id, wallet_id, wallet_address, blockchain, reason, reason_details,
-			status, freeze_level, legal_order_id, issued_by, issued_by_name,
-			approved_by, expires_at, released_at, release_reason, metadata,
+			status, freeze_level, legal_order_id, legal_basis_id, legal_basis_code, issued_by, issued_by_name,
+			approved_by, expires_at, released_at, release_reason, under_appeal, version, metadata,
 			created_at, updated_at
 		FROM wallet_freezes WHERE id = $1
 	`
@@ -94,8 +106,9 @@ func (r *PostgresWalletFreezeRepository) GetByID(ctx context.Context, id uuid.UU
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&freeze.ID, &freeze.WalletID, &freeze.WalletAddress, &freeze.Blockchain, &freeze.Reason,
 		&freeze.ReasonDetails, &freeze.Status, &freeze.FreezeLevel, &freeze.LegalOrderID,
+		&freeze.LegalBasisID, &freeze.LegalBasisCode,
 		&freeze.IssuedBy, &freeze.IssuedByName, &freeze.ApprovedBy, &freeze.ExpiresAt,
-		&freeze.ReleasedAt, &freeze.ReleaseReason, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
+		&freeze.ReleasedAt, &freeze.ReleaseReason, &freeze.UnderAppeal, &freeze.Version, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -105,7 +118,9 @@ func (r *PostgresWalletFreezeRepository) GetByID(ctx context.Context, id uuid.UU
 		return nil, err
 	}
 
-	json.Unmarshal(metadata, &freeze.Metadata)
+	if err := decryptFreezeFields(ctx, r.cipher, &freeze, metadata); err != nil {
+		return nil, err
+	}
 	return &freeze, nil
 }
 
@@ -113,8 +128,8 @@ func (r *PostgresWalletFreezeRepository) GetByID(ctx context.Context, id uuid.UU
 func (r *PostgresWalletFreezeRepository) GetActiveByWallet(ctx context.Context, walletID uuid.UUID) (*models.WalletFreeze, error) {
 	query := `
 		SELECT id, wallet_id, wallet_address, blockchain, reason, reason_details,
-			status, freeze_level, legal_order_id, issued_by, issued_by_name,
-			approved_by, expires_at, released_at, release_reason, metadata,
+			status, freeze_level, legal_order_id, legal_basis_id, legal_basis_code, issued_by, issued_by_name,
+			approved_by, expires_at, released_at, release_reason, under_appeal, version, metadata,
 			created_at, updated_at
 		FROM wallet_freezes
 		WHERE wallet_id = $1 AND status IN ('ACTIVE', 'PARTIAL')
@@ -127,8 +142,9 @@ func (r *PostgresWalletFreezeRepository) GetActiveByWallet(ctx context.Context,
 	err := r.db.QueryRowContext(ctx, query, walletID).Scan(
 		&freeze.ID, &freeze.WalletID, &freeze.WalletAddress, &freeze.Blockchain, &freeze.Reason,
 		&freeze.ReasonDetails, &freeze.Status, &freeze.FreezeLevel, &freeze.LegalOrderID,
+		&freeze.LegalBasisID, &freeze.LegalBasisCode,
 		&freeze.IssuedBy, &freeze.IssuedByName, &freeze.ApprovedBy, &freeze.ExpiresAt,
-		&freeze.ReleasedAt, &freeze.ReleaseReason, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
+		&freeze.ReleasedAt, &freeze.ReleaseReason, &freeze.UnderAppeal, &freeze.Version, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -138,46 +154,116 @@ func (r *PostgresWalletFreezeRepository) GetActiveByWallet(ctx context.Context,
 		return nil, err
 	}
 
-	json.Unmarshal(metadata, &freeze.Metadata)
+	if err := decryptFreezeFields(ctx, r.cipher, &freeze, metadata); err != nil {
+		return nil, err
+	}
 	return &freeze, nil
 }
 
-// Update updates a freeze record
-func (r *PostgresWalletFreezeRepository) Update(ctx context.Context, freeze *models.WalletFreeze) error {
+// AppendEvent appends an event to a freeze order's stream and stores the
+// order's projected state after it. It fails with
+// models.ErrFreezeVersionConflict if another event was appended since the
+// order was read.
+func (r *PostgresWalletFreezeRepository) AppendEvent(ctx context.Context, freeze *models.WalletFreeze, event *models.FreezeEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := appendFreezeEvent(ctx, tx, freeze, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListEvents retrieves the event stream of a freeze order, oldest first
+func (r *PostgresWalletFreezeRepository) ListEvents(ctx context.Context, freezeID uuid.UUID) ([]*models.FreezeEvent, error) {
 	query := `
-		UPDATE wallet_freezes SET
-			status = $1, released_at = $2, release_reason = $3, updated_at = $4
-		WHERE id = $5
+		SELECT id, freeze_id, sequence, event_type, payload, actor_id, actor_name, actor_type, occurred_at
+		FROM wallet_freeze_events
+		WHERE freeze_id = $1
+		ORDER BY sequence
 	`
 
-	freeze.UpdatedAt = time.Now()
+	rows, err := r.db.QueryContext(ctx, query, freezeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.FreezeEvent
+	for rows.Next() {
+		var event models.FreezeEvent
+		var payload []byte
 
-	_, err := r.db.ExecContext(ctx, query,
-		freeze.Status, freeze.ReleasedAt, freeze.ReleaseReason, freeze.UpdatedAt, freeze.ID,
-	)
+		err := rows.Scan(
+			&event.ID, &event.FreezeID, &event.Sequence, &event.Type, &payload,
+			&event.ActorID, &event.ActorName, &event.ActorType, &event.OccurredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &event.Payload); err != nil {
+			return nil, fmt.Errorf("failed to decode event %d of freeze %s: %w", event.Sequence, freezeID, err)
+		}
+		events = append(events, &event)
+	}
 
-	return err
+	return events, rows.Err()
 }
 
-// Release releases a wallet freeze
-func (r *PostgresWalletFreezeRepository) Release(ctx context.Context, id uuid.UUID, releasedBy uuid.UUID, reason string) error {
-	query := `
+// appendFreezeEvent stores event and the projected state of freeze after it,
+// provided the stored order is still at the version before the event
+func appendFreezeEvent(ctx context.Context, tx *sql.Tx, freeze *models.WalletFreeze, event *models.FreezeEvent) error {
+	result, err := tx.ExecContext(ctx, `
 		UPDATE wallet_freezes SET
-			status = $1, released_at = $2, release_reason = $3, updated_at = $4
-		WHERE id = $5
-	`
+			status = $1, freeze_level = $2, expires_at = $3, released_at = $4, release_reason = $5,
+			under_appeal = $6, version = $7, updated_at = $8
+		WHERE id = $9 AND version = $10
+	`,
+		freeze.Status, freeze.FreezeLevel, freeze.ExpiresAt, freeze.ReleasedAt, freeze.ReleaseReason,
+		freeze.UnderAppeal, freeze.Version, freeze.UpdatedAt, freeze.ID, event.Sequence-1,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update freeze: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: freeze %s is no longer at version %d", models.ErrFreezeVersionConflict, freeze.ID, event.Sequence-1)
+	}
 
-	_, err := r.db.ExecContext(ctx, query, models.FreezeStatusReleased, time.Now(), reason, time.Now(), id)
+	return insertFreezeEvent(ctx, tx, event)
+}
 
-	return err
+// insertFreezeEvent adds an event to a freeze order's stream
+func insertFreezeEvent(ctx context.Context, tx *sql.Tx, event *models.FreezeEvent) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode freeze event: %w", err)
+	}
+
+	event.ID = uuid.New()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO wallet_freeze_events (
+			id, freeze_id, sequence, event_type, payload, actor_id, actor_name, actor_type, occurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		event.ID, event.FreezeID, event.Sequence, event.Type, payload,
+		event.ActorID, event.ActorName, event.ActorType, event.OccurredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record freeze event: %w", err)
+	}
+	return nil
 }
 
 // List retrieves freeze records with filters
 func (r *PostgresWalletFreezeRepository) List(ctx context.Context, filter *models.FreezeFilter, limit, offset int) ([]*models.WalletFreeze, error) {
 	query := `
 		SELECT id, wallet_id, wallet_address, blockchain, reason, reason_details,
-			status, freeze_level, legal_order_id, issued_by, issued_by_name,
-			approved_by, expires_at, released_at, release_reason, metadata,
+			status, freeze_level, legal_order_id, legal_basis_id, legal_basis_code, issued_by, issued_by_name,
+			approved_by, expires_at, released_at, release_reason, under_appeal, version, metadata,
 			created_at, updated_at
 		FROM wallet_freezes WHERE 1=1
 	`
@@ -224,14 +310,17 @@ func (r *PostgresWalletFreezeRepository) List(ctx context.Context, filter *model
 		err := rows.Scan(
 			&freeze.ID, &freeze.WalletID, &freeze.WalletAddress, &freeze.Blockchain, &freeze.Reason,
 			&freeze.ReasonDetails, &freeze.Status, &freeze.FreezeLevel, &freeze.LegalOrderID,
+			&freeze.LegalBasisID, &freeze.LegalBasisCode,
 			&freeze.IssuedBy, &freeze.IssuedByName, &freeze.ApprovedBy, &freeze.ExpiresAt,
-			&freeze.ReleasedAt, &freeze.ReleaseReason, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
+			&freeze.ReleasedAt, &freeze.ReleaseReason, &freeze.UnderAppeal, &freeze.Version, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		json.Unmarshal(metadata, &freeze.Metadata)
+		if err := decryptFreezeFields(ctx, r.cipher, &freeze, metadata); err != nil {
+			return nil, err
+		}
 		freezes = append(frees, &freeze)
 	}
 
@@ -242,8 +331,8 @@ func (r *PostgresWalletFreezeRepository) List(ctx context.Context, filter *model
 func (r *PostgresWalletFreezeRepository) GetActiveFreezes(ctx context.Context) ([]*models.WalletFreeze, error) {
 	query := `
 		SELECT id, wallet_id, wallet_address, blockchain, reason, reason_details,
-			status, freeze_level, legal_order_id, issued_by, issued_by_name,
-			approved_by, expires_at, released_at, release_reason, metadata,
+			status, freeze_level, legal_order_id, legal_basis_id, legal_basis_code, issued_by, issued_by_name,
+			approved_by, expires_at, released_at, release_reason, under_appeal, version, metadata,
 			created_at, updated_at
 		FROM wallet_freezes
 		WHERE status IN ('ACTIVE', 'PARTIAL')
@@ -264,14 +353,17 @@ func (r *PostgresWalletFreezeRepository) GetActiveFreezes(ctx context.Context) (
 		err := rows.Scan(
 			&freeze.ID, &freeze.WalletID, &freeze.WalletAddress, &freeze.Blockchain, &freeze.Reason,
 			&freeze.ReasonDetails, &freeze.Status, &freeze.FreezeLevel, &freeze.LegalOrderID,
+			&freeze.LegalBasisID, &freeze.LegalBasisCode,
 			&freeze.IssuedBy, &freeze.IssuedByName, &freeze.ApprovedBy, &freeze.ExpiresAt,
-			&freeze.ReleasedAt, &freeze.ReleaseReason, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
+			&freeze.ReleasedAt, &freeze.ReleaseReason, &freeze.UnderAppeal, &freeze.Version, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		json.Unmarshal(metadata, &freeze.Metadata)
+		if err := decryptFreezeFields(ctx, r.cipher, &freeze, metadata); err != nil {
+			return nil, err
+		}
 		freezes = append(freezes, &freeze)
 	}
 
@@ -295,8 +387,8 @@ func (r *PostgresWalletFreezeRepository) Count(ctx context.Context, activeOnly b
 func (r *PostgresWalletFreezeRepository) GetExpiredFreezes(ctx context.Context) ([]*models.WalletFreeze, error) {
 	query := `
 		SELECT id, wallet_id, wallet_address, blockchain, reason, reason_details,
-			status, freeze_level, legal_order_id, issued_by, issued_by_name,
-			approved_by, expires_at, released_at, release_reason, metadata,
+			status, freeze_level, legal_order_id, legal_basis_id, legal_basis_code, issued_by, issued_by_name,
+			approved_by, expires_at, released_at, release_reason, under_appeal, version, metadata,
 			created_at, updated_at
 		FROM wallet_freezes
 		WHERE status IN ('ACTIVE', 'PARTIAL')
@@ -318,16 +410,252 @@ func (r *PostgresWalletFreezeRepository) GetExpiredFreezes(ctx context.Context)
 		err := rows.Scan(
 			&freeze.ID, &freeze.WalletID, &freeze.WalletAddress, &freeze.Blockchain, &freeze.Reason,
 			&freeze.ReasonDetails, &freeze.Status, &freeze.FreezeLevel, &freeze.LegalOrderID,
+			&freeze.LegalBasisID, &freeze.LegalBasisCode,
 			&freeze.IssuedBy, &freeze.IssuedByName, &freeze.ApprovedBy, &freeze.ExpiresAt,
-			&freeze.ReleasedAt, &freeze.ReleaseReason, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
+			&freeze.ReleasedAt, &freeze.ReleaseReason, &freeze.UnderAppeal, &freeze.Version, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		json.Unmarshal(metadata, &freeze.Metadata)
+		if err := decryptFreezeFields(ctx, r.cipher, &freeze, metadata); err != nil {
+			return nil, err
+		}
 		freezes = append(freezes, &freeze)
 	}
 
 	return freezes, rows.Err()
 }
+
+// GetFreezesDueForReminder retrieves active freezes expiring by the given time
+// whose issuing officer has not been reminded since they were issued or last
+// renewed
+func (r *PostgresWalletFreezeRepository) GetFreezesDueForReminder(ctx context.Context, expiringBy time.Time) ([]*models.WalletFreeze, error) {
+	query := `
+		SELECT id, wallet_id, wallet_address, blockchain, reason, reason_details,
+			status, freeze_level, legal_order_id, legal_basis_id, legal_basis_code, issued_by, issued_by_name,
+			approved_by, expires_at, released_at, release_reason, under_appeal, version, metadata,
+			created_at, updated_at
+		FROM wallet_freezes
+		WHERE status IN ('ACTIVE', 'PARTIAL')
+		AND expires_at > NOW()
+		AND expires_at <= $1
+		AND renewal_reminder_sent_at IS NULL
+		ORDER BY expires_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, expiringBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var freezes []*models.WalletFreeze
+	for rows.Next() {
+		var freeze models.WalletFreeze
+		var metadata []byte
+
+		err := rows.Scan(
+			&freeze.ID, &freeze.WalletID, &freeze.WalletAddress, &freeze.Blockchain, &freeze.Reason,
+			&freeze.ReasonDetails, &freeze.Status, &freeze.FreezeLevel, &freeze.LegalOrderID,
+			&freeze.LegalBasisID, &freeze.LegalBasisCode,
+			&freeze.IssuedBy, &freeze.IssuedByName, &freeze.ApprovedBy, &freeze.ExpiresAt,
+			&freeze.ReleasedAt, &freeze.ReleaseReason, &freeze.UnderAppeal, &freeze.Version, &metadata, &freeze.CreatedAt, &freeze.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := decryptFreezeFields(ctx, r.cipher, &freeze, metadata); err != nil {
+			return nil, err
+		}
+		freezes = append(freezes, &freeze)
+	}
+
+	return freezes, rows.Err()
+}
+
+// MarkReminderSent records that the issuing officer of a freeze was reminded
+// of its expiry
+func (r *PostgresWalletFreezeRepository) MarkReminderSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	query := `UPDATE wallet_freezes SET renewal_reminder_sent_at = $1 WHERE id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, sentAt, id)
+
+	return err
+}
+
+// Renew records a renewal together with the AMENDED event that extends the
+// freeze to the renewal's new expiry. The freeze's reminder is reset for the
+// new expiry.
+func (r *PostgresWalletFreezeRepository) Renew(ctx context.Context, renewal *models.FreezeRenewal, freeze *models.WalletFreeze, event *models.FreezeEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := appendFreezeEvent(ctx, tx, freeze, event); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE wallet_freezes SET renewal_reminder_sent_at = NULL WHERE id = $1`, freeze.ID)
+	if err != nil {
+		return fmt.Errorf("failed to reset renewal reminder: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO wallet_freeze_renewals (
+			id, freeze_id, previous_expires_at, new_expires_at, justification,
+			document_reference, renewed_by, renewed_by_name, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		renewal.ID, renewal.FreezeID, renewal.PreviousExpiresAt, renewal.NewExpiresAt, renewal.Justification,
+		renewal.DocumentReference, renewal.RenewedBy, renewal.RenewedByName, renewal.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record freeze renewal: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListRenewals retrieves the renewals of a freeze, oldest first
+func (r *PostgresWalletFreezeRepository) ListRenewals(ctx context.Context, freezeID uuid.UUID) ([]*models.FreezeRenewal, error) {
+	query := `
+		SELECT id, freeze_id, previous_expires_at, new_expires_at, justification,
+			document_reference, renewed_by, renewed_by_name, created_at
+		FROM wallet_freeze_renewals
+		WHERE freeze_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, freezeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var renewals []*models.FreezeRenewal
+	for rows.Next() {
+		var renewal models.FreezeRenewal
+		err := rows.Scan(
+			&renewal.ID, &renewal.FreezeID, &renewal.PreviousExpiresAt, &renewal.NewExpiresAt, &renewal.Justification,
+			&renewal.DocumentReference, &renewal.RenewedBy, &renewal.RenewedByName, &renewal.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		renewals = append(renewals, &renewal)
+	}
+
+	return renewals, rows.Err()
+}
+
+// CountByLegalBasis counts the freezes issued in [from, to) by the legal basis
+// they cite. Freezes issued before citations were required are counted under
+// a nil legal basis.
+func (r *PostgresWalletFreezeRepository) CountByLegalBasis(ctx context.Context, from, to time.Time) ([]*models.FreezeLegalBasisCount, error) {
+	query := `
+		SELECT legal_basis_id, legal_basis_code, COUNT(*),
+			COUNT(*) FILTER (WHERE status IN ('ACTIVE', 'PARTIAL'))
+		FROM wallet_freezes
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY legal_basis_id, legal_basis_code
+		ORDER BY COUNT(*) DESC, legal_basis_code
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []*models.FreezeLegalBasisCount
+	for rows.Next() {
+		var count models.FreezeLegalBasisCount
+		if err := rows.Scan(&count.LegalBasisID, &count.LegalBasisCode, &count.Count, &count.Active); err != nil {
+			return nil, err
+		}
+		counts = append(counts, &count)
+	}
+
+	return counts, rows.Err()
+}
+
+// ReencryptFields encrypts reason details and metadata that are still plaintext
+// or were encrypted under a retired wrapping key, working through the table in
+// batches. It returns the number of rows rewritten.
+func (r *PostgresWalletFreezeRepository) ReencryptFields(ctx context.Context, batchSize int) (int, error) {
+	if r.cipher == nil {
+		return 0, nil
+	}
+
+	type storedFields struct {
+		id            uuid.UUID
+		reasonDetails sql.NullString
+		metadata      []byte
+	}
+
+	var lastID uuid.UUID
+	rewritten := 0
+	for {
+		rows, err := r.db.QueryContext(ctx, `
+			SELECT id, reason_details, metadata FROM wallet_freezes
+			WHERE id > $1 ORDER BY id LIMIT $2
+		`, lastID, batchSize)
+		if err != nil {
+			return rewritten, err
+		}
+
+		var batch []storedFields
+		for rows.Next() {
+			var f storedFields
+			if err := rows.Scan(&f.id, &f.reasonDetails, &f.metadata); err != nil {
+				rows.Close()
+				return rewritten, err
+			}
+			batch = append(batch, f)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return rewritten, err
+		}
+
+		for _, f := range batch {
+			lastID = f.id
+
+			var encryptedMetadata string
+			metadataIsEncrypted := json.Unmarshal(f.metadata, &encryptedMetadata) == nil
+			metadataNeedsWork := f.metadata != nil && (!metadataIsEncrypted || r.cipher.NeedsReencryption(encryptedMetadata))
+			if !r.cipher.NeedsReencryption(f.reasonDetails.String) && !metadataNeedsWork {
+				continue
+			}
+
+			freeze := &models.WalletFreeze{ID: f.id, ReasonDetails: f.reasonDetails.String}
+			if err := decryptFreezeFields(ctx, r.cipher, freeze, f.metadata); err != nil {
+				return rewritten, err
+			}
+			reasonDetails, metadataJSON, err := encryptFreezeFields(ctx, r.cipher, freeze)
+			if err != nil {
+				return rewritten, err
+			}
+
+			// Skip rows changed since they were read; the next run picks them up
+			result, err := r.db.ExecContext(ctx, `
+				UPDATE wallet_freezes SET reason_details = $1, metadata = $2
+				WHERE id = $3 AND reason_details IS NOT DISTINCT FROM $4 AND metadata IS NOT DISTINCT FROM $5
+			`, reasonDetails, metadataJSON, f.id, f.reasonDetails, f.metadata)
+			if err != nil {
+				return rewritten, fmt.Errorf("failed to re-encrypt freeze %s: %w", f.id, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				rewritten++
+			}
+		}
+
+		if len(batch) < batchSize {
+			return rewritten, nil
+		}
+	}
+}
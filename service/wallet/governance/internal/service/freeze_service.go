@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/csic/wallet-governance/internal/domain/models"
@@ -10,34 +14,107 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrInvalidRenewal is returned when a freeze renewal is rejected
+var ErrInvalidRenewal = errors.New("invalid renewal")
+
+// ErrInvalidAmendment is returned when a freeze amendment is rejected
+var ErrInvalidAmendment = errors.New("invalid amendment")
+
+// FreezeAuditChain is implemented by the WORM audit chain that records every
+// freeze order transition
+type FreezeAuditChain interface {
+	AppendFreezeTransition(ctx context.Context, record *models.FreezeTransitionRecord) error
+}
+
+// LegalBasisCatalogue checks the legal basis cited by a freeze. Rejected
+// citations return an error wrapping models.ErrInvalidCitation.
+type LegalBasisCatalogue interface {
+	CheckCitation(ctx context.Context, id uuid.UUID, at time.Time) (*models.LegalBasis, error)
+}
+
+// RenewalNotifier reminds issuing officers of freeze orders about to expire
+type RenewalNotifier interface {
+	NotifyRenewalDue(ctx context.Context, freeze *models.WalletFreeze) error
+}
+
+// FreezeEnforcementConfig holds settings for freeze order expiry and renewal
+type FreezeEnforcementConfig struct {
+	// CheckInterval is how often expired orders are lifted and reminders sent
+	CheckInterval time.Duration
+	// ReminderLead is how long before expiry the issuing officer is reminded
+	ReminderLead time.Duration
+	// MinJustificationLength is the minimum length of a renewal or amendment
+	// justification
+	MinJustificationLength int
+	// MaxRenewal is the furthest from now a renewal may extend an order
+	MaxRenewal time.Duration
+}
+
 // FreezeService handles wallet freeze operations
 type FreezeService struct {
 	walletRepo   repository.WalletRepository
 	freezeRepo   repository.WalletFreezeRepository
 	signatureSvc *SignatureService
 	auditRepo    repository.AuditRepository
+	legalBases   LegalBasisCatalogue
+	chain        FreezeAuditChain
+	notifier     RenewalNotifier
+	config       FreezeEnforcementConfig
 
 	stopChan chan struct{}
 }
 
-// NewFreezeService creates a new freeze service
+// NewFreezeService creates a new freeze service. The notifier may be nil, in
+// which case renewal reminders are only logged.
 func NewFreezeService(
 	walletRepo repository.WalletRepository,
 	freezeRepo repository.WalletFreezeRepository,
 	signatureSvc *SignatureService,
 	auditRepo repository.AuditRepository,
+	legalBases LegalBasisCatalogue,
+	chain FreezeAuditChain,
+	notifier RenewalNotifier,
+	config FreezeEnforcementConfig,
 ) *FreezeService {
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = time.Minute
+	}
+	if config.ReminderLead <= 0 {
+		config.ReminderLead = 7 * 24 * time.Hour
+	}
+	if config.MaxRenewal <= 0 {
+		config.MaxRenewal = 180 * 24 * time.Hour
+	}
+
 	return &FreezeService{
 		walletRepo:   walletRepo,
 		freezeRepo:   freezeRepo,
 		signatureSvc: signatureSvc,
 		auditRepo:    auditRepo,
+		legalBases:   legalBases,
+		chain:        chain,
+		notifier:     notifier,
+		config:       config,
 		stopChan:     make(chan struct{}),
 	}
 }
 
-// FreezeWallet freezes a wallet
+// FreezeWallet freezes a wallet. The freeze must cite a legal basis that is in
+// force and covers wallet freezes.
 func (s *FreezeService) FreezeWallet(ctx context.Context, freeze *models.WalletFreeze, actorID uuid.UUID, actorName string) error {
+	if freeze.LegalBasisID == nil {
+		return fmt.Errorf("%w: legal_basis_id is required", models.ErrInvalidCitation)
+	}
+	basis, err := s.legalBases.CheckCitation(ctx, *freeze.LegalBasisID, time.Now())
+	if err != nil {
+		return err
+	}
+	freeze.LegalBasisCode = basis.Code
+	if freeze.ExpiresAt != nil && basis.ValidTo != nil && freeze.ExpiresAt.After(*basis.ValidTo) {
+		return fmt.Errorf("%w: %s ceases to be in force at %s, before the freeze expires",
+			models.ErrInvalidCitation, basis.Code, basis.ValidTo.Format(time.RFC3339))
+	}
+
 	// Check if wallet exists
 	wallet, err := s.walletRepo.GetByID(ctx, freeze.WalletID)
 	if err != nil {
@@ -56,11 +133,21 @@ func (s *FreezeService) FreezeWallet(ctx context.Context, freeze *models.WalletF
 		return fmt.Errorf("wallet is already frozen")
 	}
 
+	freeze.ID = uuid.New()
 	freeze.Status = models.FreezeStatusActive
 	freeze.WalletAddress = wallet.Address
 	freeze.Blockchain = wallet.Blockchain
+	freeze.UnderAppeal = false
+	freeze.Version = 0
 
-	if err := s.freezeRepo.Create(ctx, freeze); err != nil {
+	order := *freeze
+	order.ReasonDetails, order.Metadata = "", nil
+	event := newFreezeEvent(freeze, models.FreezeEventIssued, models.FreezeEventPayload{Order: &order}, actorID, actorName, "USER")
+	if err := freeze.Apply(event); err != nil {
+		return err
+	}
+
+	if err := s.freezeRepo.Create(ctx, freeze, event); err != nil {
 		return fmt.Errorf("failed to create freeze: %w", err)
 	}
 
@@ -69,17 +156,19 @@ func (s *FreezeService) FreezeWallet(ctx context.Context, freeze *models.WalletF
 	s.walletRepo.Update(ctx, wallet)
 
 	s.logAudit(ctx, "WALLET_FREEZE", freeze.ID, "CREATE", actorID, actorName, nil, freeze, true, "")
+	s.recordTransition(ctx, models.FreezeTransitionIssued, freeze, actorID, actorName, "USER", nil)
 
 	return nil
 }
 
 // EmergencyFreeze performs an emergency freeze without approval
-func (s *FreezeService) EmergencyFreeze(ctx context.Context, walletID uuid.UUID, reason models.FreezeReason, reasonDetails, legalOrderID string, actorID uuid.UUID, actorName string) error {
+func (s *FreezeService) EmergencyFreeze(ctx context.Context, walletID uuid.UUID, reason models.FreezeReason, reasonDetails, legalOrderID string, legalBasisID uuid.UUID, actorID uuid.UUID, actorName string) error {
 	freeze := &models.WalletFreeze{
 		WalletID:      walletID,
 		Reason:        reason,
 		ReasonDetails: reasonDetails,
 		LegalOrderID:  legalOrderID,
+		LegalBasisID:  &legalBasisID,
 		FreezeLevel:   "FULL",
 		IssuedBy:      actorID,
 		IssuedByName:  actorName,
@@ -99,7 +188,8 @@ func (s *FreezeService) UnfreezeWallet(ctx context.Context, walletID uuid.UUID,
 	}
 
 	// Release freeze
-	if err := s.freezeRepo.Release(ctx, freeze.ID, actorID, reason); err != nil {
+	event := newFreezeEvent(freeze, models.FreezeEventRevoked, models.FreezeEventPayload{Reason: reason}, actorID, actorName, "USER")
+	if err := s.appendEvent(ctx, freeze, event); err != nil {
 		return fmt.Errorf("failed to release freeze: %w", err)
 	}
 
@@ -117,9 +207,267 @@ func (s *FreezeService) UnfreezeWallet(ctx context.Context, walletID uuid.UUID,
 		"reason": reason,
 	}, true, "")
 
+	s.recordTransition(ctx, models.FreezeTransitionReleased, freeze, actorID, actorName, "USER", map[string]interface{}{
+		"release_reason": reason,
+	})
+
 	return nil
 }
 
+// RenewFreeze extends an active freeze order to a new expiry. Renewals must
+// carry a written justification and a reference to the supporting document.
+func (s *FreezeService) RenewFreeze(ctx context.Context, freezeID uuid.UUID, req *models.FreezeRenewalRequest, actorID uuid.UUID, actorName string) (*models.FreezeRenewal, error) {
+	freeze, err := s.freezeRepo.GetByID(ctx, freezeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get freeze: %w", err)
+	}
+	if freeze == nil {
+		return nil, fmt.Errorf("%w: freeze not found", ErrInvalidRenewal)
+	}
+	if !freeze.IsInForce() {
+		return nil, fmt.Errorf("%w: only active freezes can be renewed", ErrInvalidRenewal)
+	}
+
+	justification := strings.TrimSpace(req.Justification)
+	if len(justification) < s.config.MinJustificationLength {
+		return nil, fmt.Errorf("%w: justification must be at least %d characters", ErrInvalidRenewal, s.config.MinJustificationLength)
+	}
+	documentReference := strings.TrimSpace(req.DocumentReference)
+	if documentReference == "" {
+		return nil, fmt.Errorf("%w: document reference is required", ErrInvalidRenewal)
+	}
+
+	now := time.Now()
+	if !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: new expiry must be in the future", ErrInvalidRenewal)
+	}
+	if freeze.ExpiresAt != nil && !req.ExpiresAt.After(*freeze.ExpiresAt) {
+		return nil, fmt.Errorf("%w: new expiry must be after the current expiry %s", ErrInvalidRenewal, freeze.ExpiresAt.Format(time.RFC3339))
+	}
+	if req.ExpiresAt.After(now.Add(s.config.MaxRenewal)) {
+		return nil, fmt.Errorf("%w: renewal may extend a freeze by at most %s from now", ErrInvalidRenewal, s.config.MaxRenewal)
+	}
+
+	// Freezes issued before citations were required are renewed without one
+	if freeze.LegalBasisID != nil {
+		basis, err := s.legalBases.CheckCitation(ctx, *freeze.LegalBasisID, now)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRenewal, err)
+		}
+		if basis.ValidTo != nil && req.ExpiresAt.After(*basis.ValidTo) {
+			return nil, fmt.Errorf("%w: %s ceases to be in force at %s", ErrInvalidRenewal, basis.Code, basis.ValidTo.Format(time.RFC3339))
+		}
+	}
+
+	renewal := &models.FreezeRenewal{
+		ID:                uuid.New(),
+		FreezeID:          freeze.ID,
+		PreviousExpiresAt: freeze.ExpiresAt,
+		NewExpiresAt:      req.ExpiresAt,
+		Justification:     justification,
+		DocumentReference: documentReference,
+		RenewedBy:         actorID,
+		RenewedByName:     actorName,
+		CreatedAt:         now,
+	}
+	event := newFreezeEvent(freeze, models.FreezeEventAmended, models.FreezeEventPayload{
+		ExpiresAt:         &renewal.NewExpiresAt,
+		Justification:     justification,
+		DocumentReference: documentReference,
+		RenewalID:         &renewal.ID,
+	}, actorID, actorName, "USER")
+	if err := freeze.Apply(event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRenewal, err)
+	}
+	if err := s.freezeRepo.Renew(ctx, renewal, freeze, event); err != nil {
+		return nil, fmt.Errorf("failed to renew freeze: %w", err)
+	}
+
+	s.logAudit(ctx, "WALLET_FREEZE", freeze.ID, "RENEW", actorID, actorName, nil, renewal, true, "")
+
+	details := map[string]interface{}{
+		"renewal_id":         renewal.ID.String(),
+		"new_expires_at":     renewal.NewExpiresAt.Format(time.RFC3339),
+		"justification":      renewal.Justification,
+		"document_reference": renewal.DocumentReference,
+	}
+	if renewal.PreviousExpiresAt != nil {
+		details["previous_expires_at"] = renewal.PreviousExpiresAt.Format(time.RFC3339)
+	}
+	s.recordTransition(ctx, models.FreezeTransitionRenewed, freeze, actorID, actorName, "USER", details)
+
+	return renewal, nil
+}
+
+// AmendFreeze changes the scope of a freeze order in force. Amendments must
+// carry a written justification and a reference to the supporting document.
+func (s *FreezeService) AmendFreeze(ctx context.Context, freezeID uuid.UUID, req *models.FreezeAmendmentRequest, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error) {
+	freeze, err := s.freezeRepo.GetByID(ctx, freezeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get freeze: %w", err)
+	}
+	if freeze == nil {
+		return nil, fmt.Errorf("%w: freeze not found", ErrInvalidAmendment)
+	}
+
+	switch req.FreezeLevel {
+	case "FULL", "INCOMING", "OUTGOING":
+	default:
+		return nil, fmt.Errorf("%w: freeze level must be FULL, INCOMING or OUTGOING", ErrInvalidAmendment)
+	}
+	if req.FreezeLevel == freeze.FreezeLevel {
+		return nil, fmt.Errorf("%w: freeze level is already %s", ErrInvalidAmendment, req.FreezeLevel)
+	}
+	justification := strings.TrimSpace(req.Justification)
+	if len(justification) < s.config.MinJustificationLength {
+		return nil, fmt.Errorf("%w: justification must be at least %d characters", ErrInvalidAmendment, s.config.MinJustificationLength)
+	}
+	documentReference := strings.TrimSpace(req.DocumentReference)
+	if documentReference == "" {
+		return nil, fmt.Errorf("%w: document reference is required", ErrInvalidAmendment)
+	}
+
+	previousLevel := freeze.FreezeLevel
+	event := newFreezeEvent(freeze, models.FreezeEventAmended, models.FreezeEventPayload{
+		FreezeLevel:       req.FreezeLevel,
+		Justification:     justification,
+		DocumentReference: documentReference,
+	}, actorID, actorName, "USER")
+	if err := s.appendEvent(ctx, freeze, event); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"previous_freeze_level": previousLevel,
+		"freeze_level":          freeze.FreezeLevel,
+		"justification":         justification,
+		"document_reference":    documentReference,
+	}
+	s.logAudit(ctx, "WALLET_FREEZE", freeze.ID, "AMEND", actorID, actorName, nil, details, true, "")
+	s.recordTransition(ctx, models.FreezeTransitionAmended, freeze, actorID, actorName, "USER", details)
+
+	return freeze, nil
+}
+
+// AppealFreeze records that the freeze order in force has been appealed. The
+// order continues to hold the wallet until the appeal is determined.
+func (s *FreezeService) AppealFreeze(ctx context.Context, freezeID, appealID uuid.UUID, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error) {
+	freeze, err := s.getFreeze(ctx, freezeID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := newFreezeEvent(freeze, models.FreezeEventAppealed, models.FreezeEventPayload{AppealID: &appealID}, actorID, actorName, "USER")
+	if err := s.appendEvent(ctx, freeze, event); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"appeal_id": appealID.String()}
+	s.logAudit(ctx, "WALLET_FREEZE", freeze.ID, "APPEAL", actorID, actorName, nil, details, true, "")
+	s.recordTransition(ctx, models.FreezeTransitionAppealed, freeze, actorID, actorName, "USER", details)
+
+	return freeze, nil
+}
+
+// UpholdFreeze records that an appeal against the freeze order was rejected
+// and the order stands
+func (s *FreezeService) UpholdFreeze(ctx context.Context, freezeID, appealID uuid.UUID, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error) {
+	freeze, err := s.getFreeze(ctx, freezeID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := newFreezeEvent(freeze, models.FreezeEventUpheld, models.FreezeEventPayload{AppealID: &appealID}, actorID, actorName, "USER")
+	if err := s.appendEvent(ctx, freeze, event); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"appeal_id": appealID.String()}
+	s.logAudit(ctx, "WALLET_FREEZE", freeze.ID, "UPHOLD", actorID, actorName, nil, details, true, "")
+	s.recordTransition(ctx, models.FreezeTransitionUpheld, freeze, actorID, actorName, "USER", details)
+
+	return freeze, nil
+}
+
+// RevokeFreeze lifts a freeze order in force, on appeal when appealID is set,
+// and returns its wallet to active unless another freeze still holds it
+func (s *FreezeService) RevokeFreeze(ctx context.Context, freezeID uuid.UUID, appealID *uuid.UUID, reason string, actorID uuid.UUID, actorName string) (*models.WalletFreeze, error) {
+	freeze, err := s.getFreeze(ctx, freezeID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := newFreezeEvent(freeze, models.FreezeEventRevoked, models.FreezeEventPayload{AppealID: appealID, Reason: reason}, actorID, actorName, "USER")
+	if err := s.appendEvent(ctx, freeze, event); err != nil {
+		return nil, err
+	}
+	walletReleased := s.releaseWallet(ctx, freeze.WalletID)
+
+	details := map[string]interface{}{
+		"release_reason":  reason,
+		"wallet_released": walletReleased,
+	}
+	if appealID != nil {
+		details["appeal_id"] = appealID.String()
+	}
+	s.logAudit(ctx, "WALLET_FREEZE", freeze.ID, "RELEASE", actorID, actorName, nil, details, true, "")
+	s.recordTransition(ctx, models.FreezeTransitionReleased, freeze, actorID, actorName, "USER", details)
+
+	return freeze, nil
+}
+
+// GetFreezeOrder retrieves a freeze order with the owner and exchange of its
+// wallet, or nil if there is no such order
+func (s *FreezeService) GetFreezeOrder(ctx context.Context, freezeID uuid.UUID) (*models.FreezeOrderDetail, error) {
+	freeze, err := s.freezeRepo.GetByID(ctx, freezeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get freeze: %w", err)
+	}
+	if freeze == nil {
+		return nil, nil
+	}
+
+	wallet, err := s.walletRepo.GetByID(ctx, freeze.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	detail := &models.FreezeOrderDetail{WalletFreeze: freeze}
+	if wallet != nil {
+		detail.OwnerEntityID = wallet.OwnerEntityID
+		detail.ExchangeID = wallet.ExchangeID
+	}
+	return detail, nil
+}
+
+// GetFreezeTimeline retrieves the events of a freeze order, oldest first,
+// each with the order's state after it
+func (s *FreezeService) GetFreezeTimeline(ctx context.Context, freezeID uuid.UUID) ([]*models.FreezeTimelineEntry, error) {
+	events, err := s.freezeRepo.ListEvents(ctx, freezeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get freeze events: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	_, timeline, err := models.ProjectFreeze(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay freeze %s: %w", freezeID, err)
+	}
+	return timeline, nil
+}
+
+// GetFreezesByLegalBasis counts the freezes issued in [from, to) by the legal
+// basis they cite
+func (s *FreezeService) GetFreezesByLegalBasis(ctx context.Context, from, to time.Time) ([]*models.FreezeLegalBasisCount, error) {
+	return s.freezeRepo.CountByLegalBasis(ctx, from, to)
+}
+
+// GetFreezeRenewals retrieves the renewals of a freeze order
+func (s *FreezeService) GetFreezeRenewals(ctx context.Context, freezeID uuid.UUID) ([]*models.FreezeRenewal, error) {
+	return s.freezeRepo.ListRenewals(ctx, freezeID)
+}
+
 // GetFreezeStatus retrieves the freeze status for a wallet
 func (s *FreezeService) GetFreezeStatus(ctx context.Context, walletID uuid.UUID) (*models.WalletFreeze, error) {
 	return s.freezeRepo.GetActiveByWallet(ctx, walletID)
@@ -138,28 +486,114 @@ func (s *FreezeService) GetFreezeHistory(ctx context.Context, walletID uuid.UUID
 	return s.freezeRepo.List(ctx, filter, 100, 0)
 }
 
-// StartFreezeExpiryChecker starts the background task to check for expired freezes
+// StartFreezeExpiryChecker starts the background task that lifts expired
+// freezes and reminds issuing officers of freezes about to expire
 func (s *FreezeService) StartFreezeExpiryChecker() {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(s.config.CheckInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
 			ctx := context.Background()
-			expired, err := s.freezeRepo.GetExpiredFreezes(ctx)
-			if err != nil {
+			s.liftExpiredFreezes(ctx)
+			s.sendRenewalReminders(ctx)
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// liftExpiredFreezes expires freezes past their expiry and returns their
+// wallets to active unless another freeze still holds them
+func (s *FreezeService) liftExpiredFreezes(ctx context.Context) {
+	expired, err := s.freezeRepo.GetExpiredFreezes(ctx)
+	if err != nil {
+		log.Printf("Failed to get expired freezes: %v", err)
+		return
+	}
+
+	for _, freeze := range expired {
+		event := newFreezeEvent(freeze, models.FreezeEventExpired, models.FreezeEventPayload{Reason: "Freeze order expired"}, uuid.Nil, "freeze-enforcement", "SYSTEM")
+		if err := s.appendEvent(ctx, freeze, event); err != nil {
+			log.Printf("Failed to expire freeze %s: %v", freeze.ID, err)
+			continue
+		}
+		walletReleased := s.releaseWallet(ctx, freeze.WalletID)
+
+		s.logSystemAudit(ctx, freeze.ID, "EXPIRE", map[string]interface{}{
+			"expires_at":      freeze.ExpiresAt,
+			"wallet_released": walletReleased,
+		})
+		s.recordTransition(ctx, models.FreezeTransitionExpired, freeze, uuid.Nil, "freeze-enforcement", "SYSTEM", map[string]interface{}{
+			"wallet_released": walletReleased,
+		})
+	}
+}
+
+// sendRenewalReminders reminds the issuing officers of freezes expiring
+// within the reminder lead time, once per expiry
+func (s *FreezeService) sendRenewalReminders(ctx context.Context) {
+	due, err := s.freezeRepo.GetFreezesDueForReminder(ctx, time.Now().Add(s.config.ReminderLead))
+	if err != nil {
+		log.Printf("Failed to get freezes due for renewal reminder: %v", err)
+		return
+	}
+
+	for _, freeze := range due {
+		if s.notifier != nil {
+			if err := s.notifier.NotifyRenewalDue(ctx, freeze); err != nil {
+				log.Printf("Failed to send renewal reminder for freeze %s: %v", freeze.ID, err)
 				continue
 			}
+		} else {
+			log.Printf("Freeze %s on wallet %s expires at %s; renewal reminder due for %s",
+				freeze.ID, freeze.WalletAddress, freeze.ExpiresAt.Format(time.RFC3339), freeze.IssuedByName)
+		}
 
-			for _, freeze := range expired {
-				freeze.Status = models.FreezeStatusExpired
-				s.freezeRepo.Update(ctx, freeze)
-			}
-		case <-s.stopChan:
-			return
+		if err := s.freezeRepo.MarkReminderSent(ctx, freeze.ID, time.Now()); err != nil {
+			log.Printf("Failed to mark renewal reminder sent for freeze %s: %v", freeze.ID, err)
+			continue
 		}
+
+		s.logSystemAudit(ctx, freeze.ID, "RENEWAL_REMINDER", map[string]interface{}{
+			"expires_at": freeze.ExpiresAt,
+			"recipient":  freeze.IssuedBy,
+		})
+		s.recordTransition(ctx, models.FreezeTransitionRenewalReminded, freeze, uuid.Nil, "freeze-enforcement", "SYSTEM", map[string]interface{}{
+			"recipient_id":   freeze.IssuedBy.String(),
+			"recipient_name": freeze.IssuedByName,
+		})
+	}
+}
+
+// releaseWallet returns a wallet to active once no freeze holds it, reporting
+// whether it did
+func (s *FreezeService) releaseWallet(ctx context.Context, walletID uuid.UUID) bool {
+	remaining, err := s.freezeRepo.GetActiveByWallet(ctx, walletID)
+	if err != nil {
+		log.Printf("Failed to check remaining freezes on wallet %s: %v", walletID, err)
+		return false
+	}
+	if remaining != nil {
+		return false
+	}
+
+	wallet, err := s.walletRepo.GetByID(ctx, walletID)
+	if err != nil {
+		log.Printf("Failed to get wallet %s: %v", walletID, err)
+		return false
+	}
+	if wallet == nil || wallet.Status != models.WalletStatusFrozen {
+		return false
+	}
+
+	wallet.Status = models.WalletStatusActive
+	if err := s.walletRepo.Update(ctx, wallet); err != nil {
+		log.Printf("Failed to unfreeze wallet %s: %v", wallet.ID, err)
+		return false
 	}
+	return true
 }
 
 // StopFreezeExpiryChecker stops the freeze expiry checker
@@ -183,6 +617,74 @@ func (s *FreezeService) logAudit(ctx context.Context, entityType string, entityI
 	s.auditRepo.Create(ctx, log)
 }
 
+// logSystemAudit logs an audit event raised by the freeze enforcement job
+func (s *FreezeService) logSystemAudit(ctx context.Context, entityID uuid.UUID, action string, newValue interface{}) {
+	s.auditRepo.Create(ctx, &models.WalletAuditLog{
+		EntityType: "WALLET_FREEZE",
+		EntityID:   entityID,
+		Action:     action,
+		ActorName:  "freeze-enforcement",
+		ActorType:  "SYSTEM",
+		NewValue:   toJSONMap(newValue),
+		Success:    true,
+	})
+}
+
+// getFreeze retrieves a freeze order that events are to be applied to
+func (s *FreezeService) getFreeze(ctx context.Context, freezeID uuid.UUID) (*models.WalletFreeze, error) {
+	freeze, err := s.freezeRepo.GetByID(ctx, freezeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get freeze: %w", err)
+	}
+	if freeze == nil {
+		return nil, fmt.Errorf("%w: freeze %s not found", models.ErrInvalidFreezeEvent, freezeID)
+	}
+	return freeze, nil
+}
+
+// newFreezeEvent creates the next event in a freeze order's stream
+func newFreezeEvent(freeze *models.WalletFreeze, eventType models.FreezeEventType, payload models.FreezeEventPayload, actorID uuid.UUID, actorName, actorType string) *models.FreezeEvent {
+	return &models.FreezeEvent{
+		FreezeID:   freeze.ID,
+		Sequence:   freeze.Version + 1,
+		Type:       eventType,
+		Payload:    payload,
+		ActorID:    actorID,
+		ActorName:  actorName,
+		ActorType:  actorType,
+		OccurredAt: time.Now(),
+	}
+}
+
+// appendEvent applies event to freeze and stores both
+func (s *FreezeService) appendEvent(ctx context.Context, freeze *models.WalletFreeze, event *models.FreezeEvent) error {
+	if err := freeze.Apply(event); err != nil {
+		return err
+	}
+	return s.freezeRepo.AppendEvent(ctx, freeze, event)
+}
+
+// recordTransition appends a freeze order transition to the WORM audit chain.
+// Failures are logged; the local audit log still holds the transition.
+func (s *FreezeService) recordTransition(ctx context.Context, transition models.FreezeTransition, freeze *models.WalletFreeze, actorID uuid.UUID, actorName, actorType string, details map[string]interface{}) {
+	if s.chain == nil {
+		return
+	}
+
+	err := s.chain.AppendFreezeTransition(ctx, &models.FreezeTransitionRecord{
+		Transition: transition,
+		Freeze:     freeze,
+		ActorID:    actorID,
+		ActorName:  actorName,
+		ActorType:  actorType,
+		Details:    details,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to record freeze %s transition %s in audit chain: %v", freeze.ID, transition, err)
+	}
+}
+
 func toJSONMap(v interface{}) models.JSONMap {
 	if v == nil {
 		return nil
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// FieldReencryptor is implemented by repositories with encrypted columns
+type FieldReencryptor interface {
+	ReencryptFields(ctx context.Context, batchSize int) (int, error)
+}
+
+// ReencryptionService periodically moves encrypted columns onto the active
+// wrapping key and encrypts rows written before encryption was enabled
+type ReencryptionService struct {
+	repos     map[string]FieldReencryptor
+	interval  time.Duration
+	batchSize int
+
+	stopChan chan struct{}
+}
+
+// NewReencryptionService creates a new re-encryption service
+func NewReencryptionService(repos map[string]FieldReencryptor, interval time.Duration, batchSize int) *ReencryptionService {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	return &ReencryptionService{
+		repos:     repos,
+		interval:  interval,
+		batchSize: batchSize,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// RunOnce re-encrypts every repository and returns the number of rows rewritten
+func (s *ReencryptionService) RunOnce(ctx context.Context) int {
+	total := 0
+	for name, repo := range s.repos {
+		n, err := repo.ReencryptFields(ctx, s.batchSize)
+		total += n
+		if err != nil {
+			log.Printf("Re-encryption of %s stopped after %d rows: %v", name, n, err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Re-encrypted %d rows of %s", n, name)
+		}
+	}
+	return total
+}
+
+// StartReencryptionJob starts the background re-encryption task. It runs once
+// immediately so a key rotation takes effect without waiting a full interval.
+func (s *ReencryptionService) StartReencryptionJob() {
+	s.RunOnce(context.Background())
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.RunOnce(context.Background())
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// StopReencryptionJob stops the re-encryption task
+func (s *ReencryptionService) StopReencryptionJob() {
+	close(s.stopChan)
+}
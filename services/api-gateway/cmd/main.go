@@ -7,19 +7,44 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
 	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/adapter/auth"
 	"github.com/csic-platform/services/api-gateway/internal/adapter/messaging"
+	"github.com/csic-platform/services/api-gateway/internal/adapter/metrics"
+	"github.com/csic-platform/services/api-gateway/internal/adapter/redis"
 	"github.com/csic-platform/services/api-gateway/internal/adapter/repository"
+	"github.com/csic-platform/services/api-gateway/internal/adapter/screening"
+	"github.com/csic-platform/services/api-gateway/internal/adapter/upstream"
+	"github.com/csic-platform/services/api-gateway/internal/adapter/webhook"
 	"github.com/csic-platform/services/api-gateway/internal/config"
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
 	"github.com/csic-platform/services/api-gateway/internal/core/ports"
 	"github.com/csic-platform/services/api-gateway/internal/core/service"
+	"github.com/csic-platform/services/api-gateway/internal/graph"
 	"github.com/csic-platform/services/api-gateway/internal/handler"
 	"github.com/csic-platform/services/api-gateway/internal/middleware"
+	"github.com/csic-platform/shared/correlation"
+	"github.com/csic-platform/shared/database"
+	"github.com/csic-platform/shared/i18n"
 	"github.com/csic-platform/shared/logger"
+	"github.com/csic-platform/shared/openapi"
+	"github.com/csic-platform/shared/ratelimit"
+	"github.com/csic-platform/shared/resilience"
+	"github.com/csic-platform/shared/startup"
+	"github.com/csic-platform/shared/validation"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Build information, set at link time with -ldflags "-X main.commit=..."
+var (
+	commit    string
+	buildTime string
 )
 
 func main() {
@@ -38,18 +63,22 @@ func main() {
 
 	// Initialize logger using shared logger package
 	appLogger, err := logger.NewLogger(logger.Config{
-		ServiceName:  "api-gateway",
-		LogLevel:     cfg.Logging.Level,
-		OutputPath:   cfg.Logging.Output,
-		AuditLogPath: cfg.Logging.Path,
-		Development:  cfg.App.Environment == "development",
-		JSONOutput:   cfg.Logging.Format == "json",
+		ServiceName:        "api-gateway",
+		Environment:        cfg.App.Environment,
+		LogLevel:           cfg.Logging.Level,
+		OutputPath:         cfg.Logging.Output,
+		AuditLogPath:       cfg.Logging.Path,
+		Development:        cfg.App.Environment == "development",
+		JSONOutput:         cfg.Logging.Format == "json",
+		SamplingInitial:    cfg.Logging.SamplingInitial,
+		SamplingThereafter: cfg.Logging.SamplingThereafter,
 	})
 	if err != nil {
 		fmt.Printf("Fatal: Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
 	defer appLogger.Close()
+	defer appLogger.RedirectStdLog()()
 
 	// Log startup
 	appLogger.Info("starting API gateway",
@@ -63,88 +92,619 @@ func main() {
 	var repo ports.Repository
 	var cache ports.CacheRepository
 
-	// Initialize PostgreSQL repository
-	repo, err = repository.NewPostgresRepository(&cfg.Database)
-	if err != nil {
-		appLogger.Warn("Failed to connect to PostgreSQL, using in-memory storage",
-			logger.WithFields(logger.Error(err)))
-		// In production, this should fail hard
+	// Initialize Kafka producer
+	producer := messaging.NewKafkaProducer(cfg.Kafka.Brokers)
+	defer producer.Close()
+
+	// Connect dependencies, retrying with backoff so a rolling deploy survives
+	// a briefly unavailable database or Redis. Kafka is optional: until it
+	// connects, published events are held in memory and /ready reports the
+	// gateway as degraded.
+	startupCtx, stopStartup := context.WithCancel(context.Background())
+	defer stopStartup()
+	dependencies := startup.New(startup.Options{
+		InitialBackoff: cfg.Startup.GetInitialBackoff(),
+		MaxBackoff:     cfg.Startup.GetMaxBackoff(),
+		MaxWait:        cfg.Startup.GetMaxWait(),
+		CheckTimeout:   cfg.Startup.GetCheckTimeout(),
+	}, appLogger)
+
+	var postgresRepo *repository.PostgresRepository
+	dependencies.Add(startup.Dependency{
+		Name: "postgres",
+		Connect: func(ctx context.Context) error {
+			var err error
+			postgresRepo, err = repository.NewPostgresRepository(&cfg.Database)
+			return err
+		},
+		Check: func(ctx context.Context) error {
+			return postgresRepo.DB().PingContext(ctx)
+		},
+	})
+
+	// Sessions, API key usage, rate limit buckets and cached entities live in
+	// Redis so they are shared across gateway instances
+	var redisClient *goredis.Client
+	if cfg.Security.Session.Enabled || cfg.Security.APIKeys.Enabled || cfg.RateLimit.Enabled || cfg.Cache.Enabled {
+		dependencies.Add(startup.Dependency{
+			Name: "redis",
+			Connect: func(ctx context.Context) error {
+				var err error
+				redisClient, err = redis.NewClient(&cfg.Redis)
+				return err
+			},
+			Check: func(ctx context.Context) error {
+				return redisClient.Ping(ctx).Err()
+			},
+		})
+	}
+
+	producer.Hold(cfg.Kafka.GetQueueSize())
+	dependencies.Add(startup.Dependency{
+		Name:     "kafka",
+		Optional: true,
+		Connect: func(ctx context.Context) error {
+			if err := producer.Ping(ctx); err != nil {
+				return err
+			}
+			return producer.Flush(ctx)
+		},
+		Check: producer.Ping,
+	})
+
+	if err := dependencies.Start(startupCtx); err != nil {
+		appLogger.Fatal("failed to connect dependencies", logger.WithFields(logger.Error(err)))
+	}
+	defer postgresRepo.Close()
+	if redisClient != nil {
+		defer redisClient.Close()
+	}
+	repo = postgresRepo
+	if cfg.Cache.Enabled {
+		repo = redis.NewCachedRepository(postgresRepo, redisClient, cfg.Cache.GetTTL(), metrics.NewEntityCacheMetrics(prometheus.DefaultRegisterer))
 	}
 
+	// Measure read replica lag so lagging replicas are skipped
+	go postgresRepo.Replicas().Start(startupCtx, cfg.Database.GetReplicaCheckInterval(), func(err error) {
+		appLogger.Warn("read replica unavailable, reading from primary", logger.WithFields(logger.Error(err)))
+	})
+
 	// Initialize Redis cache (optional, for rate limiting and sessions)
 	// cache = redis.NewRedisCache(&cfg.Redis)
 
-	// Initialize Kafka producer
-	producer := messaging.NewKafkaProducer(cfg.Kafka.Brokers)
-	defer producer.Close()
-
 	// Initialize services
 	authService := auth.NewAuthService(cfg.Security.JWT.Secret)
-	gatewayService := service.NewGatewayService(repo, cache, producer, authService)
+	gatewayService := service.NewGatewayService(repo, cache, producer, authService, metrics.NewConflictMetrics(prometheus.DefaultRegisterer))
+
+	// Initialize dead-letter queue for compliance consumers
+	var deadLetters ports.DeadLetterService
+	var deadLetterHandler *handler.DeadLetterHandler
+	dlqCtx, stopDLQ := context.WithCancel(context.Background())
+	defer stopDLQ()
+	if cfg.Compliance.DeadLetter.Enabled {
+		deadLetterService := service.NewDeadLetterService(repo, producer, metrics.NewDeadLetterMetrics(prometheus.DefaultRegisterer))
+		go deadLetterService.StartDepthMonitor(dlqCtx, cfg.Compliance.DeadLetter.GetDepthRefreshInterval(), func(err error) {
+			appLogger.Error("failed to refresh dead-letter depth", logger.WithFields(logger.Error(err)))
+		})
+		deadLetters = deadLetterService
+		deadLetterHandler = handler.NewDeadLetterHandler(deadLetterService)
+	}
+
+	// Calls the gateway makes to other services share one retry budget and keep
+	// a circuit breaker per service
+	downstream := resilience.New(resilience.Options{
+		MaxAttempts:         cfg.Downstream.MaxAttempts,
+		Backoff:             resilience.Backoff{Base: cfg.Downstream.GetInitialBackoff(), Max: cfg.Downstream.GetMaxBackoff()},
+		RetryRatio:          cfg.Downstream.RetryRatio,
+		MinRetriesPerSecond: cfg.Downstream.MinRetriesPerSecond,
+		FailureThreshold:    cfg.Downstream.FailureThreshold,
+		OpenTimeout:         cfg.Downstream.GetOpenTimeout(),
+	})
+
+	// Initialize transaction imports and their compliance check workers
+	var transactionImportHandler *handler.TransactionImportHandler
+	importCtx, stopImports := context.WithCancel(context.Background())
+	defer stopImports()
+	if importCfg := cfg.Compliance.TransactionImport; importCfg.Enabled {
+		screener := screening.NewSanctionsClient(importCfg.ScreeningURL, importCfg.GetScreeningTimeout(), downstream, importCfg.GetScreeningStaleTTL())
+		transactionImportService := service.NewTransactionImportService(repo, screener, producer, service.TransactionImportOptions{
+			MaxRows:             importCfg.GetMaxRows(),
+			MaxRowErrors:        importCfg.GetMaxRowErrors(),
+			BatchSize:           importCfg.GetBatchSize(),
+			Workers:             importCfg.GetWorkers(),
+			MaxCheckAttempts:    importCfg.GetMaxCheckAttempts(),
+			CheckLease:          importCfg.GetCheckLease(),
+			PollInterval:        importCfg.GetPollInterval(),
+			LargeTransactionUSD: importCfg.LargeTransactionUSD,
+		})
+		transactionImportService.StartWorkers(importCtx, func(err error) {
+			appLogger.Error("failed to check imported transactions", logger.WithFields(logger.Error(err)))
+		})
+		transactionImportHandler = handler.NewTransactionImportHandler(transactionImportService, importCfg.GetMaxUploadBytes())
+	}
+
+	// Initialize compliance violation consumer
+	if cfg.Compliance.ViolationConsumer.Enabled {
+		violationService := service.NewViolationService(repo, gatewayService, producer, service.AutoFreezePolicy{
+			Enabled:          cfg.Compliance.AutoFreeze.Enabled,
+			Severities:       cfg.Compliance.AutoFreeze.Severities,
+			ViolationTypes:   cfg.Compliance.AutoFreeze.ViolationTypes,
+			SuspendExchanges: cfg.Compliance.AutoFreeze.SuspendExchanges,
+			ActorID:          cfg.Compliance.AutoFreeze.ActorID,
+		})
+
+		topic := cfg.Kafka.Topics.ComplianceViolations
+		if topic == "" {
+			topic = "csic.compliance_violations"
+		}
+		consumerGroup := cfg.Compliance.ViolationConsumer.ConsumerGroup
+		if consumerGroup == "" {
+			consumerGroup = cfg.Kafka.ConsumerGroup + "-violations"
+		}
+
+		violationConsumer := messaging.NewViolationConsumer(messaging.ViolationConsumerConfig{
+			Brokers:       cfg.Kafka.Brokers,
+			Topic:         topic,
+			ConsumerGroup: consumerGroup,
+			MaxRetries:    cfg.Compliance.ViolationConsumer.MaxRetries,
+			RetryBackoff:  cfg.Compliance.ViolationConsumer.GetRetryBackoff(),
+		}, violationService, deadLetters, appLogger)
+		violationConsumer.Start(context.Background())
+		defer violationConsumer.Stop()
+	}
+
+	// Initialize emergency stop enforcement; every instance follows the
+	// control layer's emergency events
+	var emergencyHalt *middleware.EmergencyHaltMiddleware
+	if cfg.Emergency.Enabled {
+		haltService := service.NewEmergencyHaltService()
+
+		topic := cfg.Kafka.Topics.EmergencyEvents
+		if topic == "" {
+			topic = "control-layer.emergency.events"
+		}
+		groupPrefix := cfg.Emergency.GroupPrefix
+		if groupPrefix == "" {
+			groupPrefix = cfg.Kafka.ConsumerGroup + "-emergency"
+		}
+
+		emergencyConsumer := messaging.NewEmergencyConsumer(messaging.EmergencyConsumerConfig{
+			Brokers:      cfg.Kafka.Brokers,
+			Topic:        topic,
+			GroupPrefix:  groupPrefix,
+			RetryBackoff: cfg.Emergency.GetRetryBackoff(),
+		}, haltService, appLogger)
+		emergencyConsumer.Start(context.Background())
+		defer emergencyConsumer.Stop()
+
+		emergencyHalt = middleware.NewEmergencyHaltMiddleware(appLogger.KV(), haltService, cfg.Emergency.ExemptPaths)
+	}
+
+	// Initialize partner webhooks: license and wallet events are queued per
+	// subscribed endpoint and delivered by background workers
+	var webhookHandler *handler.WebhookHandler
+	webhookCtx, stopWebhooks := context.WithCancel(context.Background())
+	defer stopWebhooks()
+	if cfg.Webhooks.Enabled {
+		webhookService := service.NewWebhookService(repo, webhook.NewHTTPSender(cfg.Webhooks.GetTimeout()), service.WebhookOptions{
+			Workers:        cfg.Webhooks.GetWorkers(),
+			BatchSize:      cfg.Webhooks.GetBatchSize(),
+			MaxAttempts:    cfg.Webhooks.GetMaxAttempts(),
+			InitialBackoff: cfg.Webhooks.GetInitialBackoff(),
+			MaxBackoff:     cfg.Webhooks.GetMaxBackoff(),
+			Lease:          cfg.Webhooks.GetLease(),
+			PollInterval:   cfg.Webhooks.GetPollInterval(),
+			AllowHTTP:      cfg.Webhooks.AllowHTTP,
+		})
+		if cfg.Webhooks.AllowHTTP {
+			appLogger.Warn("webhook endpoints may use plain http; this is for development and tests only")
+		}
+		webhookService.StartDispatcher(webhookCtx, func(err error) {
+			appLogger.Error("failed to deliver webhooks", logger.WithFields(logger.Error(err)))
+		})
+
+		licenseTopic := cfg.Kafka.Topics.LicenseEvents
+		if licenseTopic == "" {
+			licenseTopic = "csic.license_events"
+		}
+		walletTopic := cfg.Kafka.Topics.WalletEvents
+		if walletTopic == "" {
+			walletTopic = "csic.wallet_events"
+		}
+		consumerGroup := cfg.Webhooks.ConsumerGroup
+		if consumerGroup == "" {
+			consumerGroup = cfg.Kafka.ConsumerGroup + "-webhooks"
+		}
+
+		webhookConsumer := messaging.NewWebhookConsumer(messaging.WebhookConsumerConfig{
+			Brokers:       cfg.Kafka.Brokers,
+			Topics:        []string{licenseTopic, walletTopic},
+			ConsumerGroup: consumerGroup,
+			RetryBackoff:  cfg.Webhooks.GetRetryBackoff(),
+		}, webhookService, appLogger)
+		webhookConsumer.Start(context.Background())
+		defer webhookConsumer.Stop()
+
+		webhookHandler = handler.NewWebhookHandler(webhookService)
+	}
+
+	// Initialize upstream routing; the routing table follows config file changes
+	upstreamRouter := upstream.NewRouter(appLogger)
+	routingCtx, stopRouting := context.WithCancel(context.Background())
+	defer stopRouting()
+	if err := upstreamRouter.Start(routingCtx, cfg.Routing); err != nil {
+		appLogger.Fatal("failed to load upstream routes", logger.WithFields(logger.Error(err)))
+	}
+	defer upstreamRouter.Stop()
+	if err := configLoader.Watch(func(newCfg *config.Config, err error) {
+		if err == nil {
+			if levelErr := appLogger.SetLevel(newCfg.Logging.Level); levelErr != nil {
+				appLogger.Warn("ignoring configured log level", logger.WithFields(logger.Error(levelErr)))
+			}
+			err = upstreamRouter.Reload(newCfg.Routing)
+		}
+		if err != nil {
+			appLogger.Error("failed to reload upstream routes", logger.WithFields(logger.Error(err)))
+		}
+	}); err != nil {
+		appLogger.Warn("upstream routes and log level will not be reloaded", logger.WithFields(logger.Error(err)))
+	}
+	upstreamHandler := handler.NewUpstreamHandler(upstreamRouter, downstream.Breakers())
+
+	// Initialize API keys for machine clients
+	var apiKeyMiddleware *middleware.APIKeyMiddleware
+	var apiKeyHandler *handler.APIKeyHandler
+	if cfg.Security.APIKeys.Enabled {
+		apiKeyService := service.NewAPIKeyService(repo, redis.NewAPIKeyUsageStore(redisClient), service.APIKeyDefaults{
+			RateLimit:  cfg.Security.APIKeys.DefaultRateLimit,
+			DailyQuota: cfg.Security.APIKeys.DefaultDailyQuota,
+		})
+		apiKeyMiddleware = middleware.NewAPIKeyMiddleware(appLogger.KV(), apiKeyService)
+		apiKeyHandler = handler.NewAPIKeyHandler(apiKeyService, cfg.Security.APIKeys.GetDefaultGracePeriod())
+	}
+
+	// Initialize revocable sessions; access tokens are short-lived and name
+	// their session, which the auth middleware checks on every request
+	var sessionService ports.SessionService
+	var sessionHandler *handler.SessionHandler
+	if cfg.Security.Session.Enabled {
+		sessions := service.NewSessionService(authService, redis.NewSessionStore(redisClient), service.SessionLimits{
+			AccessTokenTTL:  cfg.Security.Session.GetAccessTokenTTL(),
+			IdleTimeout:     cfg.Security.Session.GetIdleTimeout(),
+			AbsoluteTimeout: cfg.Security.Session.GetAbsoluteTimeout(),
+			ConcurrentLimit: cfg.Security.Session.ConcurrentLimit,
+		})
+		sessionService = sessions
+		sessionHandler = handler.NewSessionHandler(sessions)
+	}
+
+	// Initialize the admin console: feature flags and maintenance mode are
+	// shared by every instance and refreshed in the background
+	var adminMiddleware *middleware.AdminMiddleware
+	var adminHandler *handler.AdminHandler
+	adminCtx, stopAdmin := context.WithCancel(context.Background())
+	defer stopAdmin()
+	if cfg.Admin.Enabled {
+		adminService := service.NewAdminService(repo)
+		if err := adminService.Refresh(adminCtx); err != nil {
+			appLogger.Warn("failed to load feature flags", logger.WithFields(logger.Error(err)))
+		}
+		go adminService.Run(adminCtx, cfg.Admin.GetRefreshInterval(), func(err error) {
+			appLogger.Error("failed to refresh feature flags", logger.WithFields(logger.Error(err)))
+		})
+		adminMiddleware = middleware.NewAdminMiddleware(adminService, cfg.Admin.ExemptPaths)
+		adminHandler = handler.NewAdminHandler(adminService, handler.BuildInfo{
+			Service:   "api-gateway",
+			Version:   cfg.App.Version,
+			Commit:    commit,
+			BuildTime: buildTime,
+		}, cfg.Fingerprint(), dependencies.Readiness)
+	}
+
+	// Measure each route group's requests against its availability and latency
+	// objectives; error budgets are checked for alerts in the background
+	var sloService ports.SLOService
+	var sloHandler *handler.SLOHandler
+	sloCtx, stopSLO := context.WithCancel(context.Background())
+	defer stopSLO()
+	if cfg.SLO.Enabled {
+		slos := service.NewSLOService(repo, producer, metrics.NewSLOMetrics(prometheus.DefaultRegisterer), service.SLOOptions{
+			Targets:      sloTargets(cfg.SLO),
+			Window:       cfg.SLO.GetWindow(),
+			FastBurnRate: cfg.SLO.FastBurnRate,
+			MinRequests:  cfg.SLO.MinRequests,
+		})
+		go slos.Run(sloCtx, cfg.SLO.GetEvaluationInterval(), func(err error) {
+			appLogger.Error("failed to evaluate SLOs", logger.WithFields(logger.Error(err)))
+		})
+		sloService = slos
+		sloHandler = handler.NewSLOHandler(slos)
+	}
+
+	// Initialize the read-only GraphQL endpoint; nested lookups are batched
+	// per request
+	var graphqlHandler *handler.GraphQLHandler
+	if cfg.GraphQL.Enabled {
+		graphqlHandler = handler.NewGraphQLHandler(graph.NewServer(gatewayService, graph.Options{
+			Introspection:   cfg.GraphQL.Introspection,
+			ComplexityLimit: cfg.GraphQL.GetComplexityLimit(),
+			BatchWait:       cfg.GraphQL.GetBatchWait(),
+			MaxBatch:        cfg.GraphQL.GetMaxBatch(),
+		}))
+	}
 
 	// Initialize HTTP handler
 	httpHandler := handler.NewHTTPHandler(gatewayService, cfg)
 
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(appLogger, cfg.Security.JWT.Secret)
-	rateLimiter := middleware.NewRateLimitMiddleware(appLogger)
-	loggingMiddleware := middleware.NewLoggingMiddleware(appLogger)
+	authMiddleware := middleware.NewAuthMiddleware(appLogger.KV(), cfg.Security.JWT.Secret, sessionService)
+	loggingMiddleware := middleware.NewLoggingMiddleware(appLogger.KV())
+	loggingMiddleware.SampleProbes(appLogger.Sampled(time.Minute, 1, 0).KV(), "/health", "/ready", "/metrics")
 	securityHeaders := middleware.NewSecurityHeadersMiddleware()
 	corsMiddleware := middleware.NewCORSMiddleware()
+	requestMetrics := middleware.NewMetricsMiddleware(metrics.NewRequestMetrics(prometheus.DefaultRegisterer), sloService)
 
-	// Setup Gin router
+	// Setup Gin router; request bodies are checked with the platform's
+	// validators, such as eth_address and iso4217
+	if err := validation.RegisterBindings(); err != nil {
+		appLogger.Fatal("failed to register request validators", logger.WithFields(logger.Error(err)))
+	}
 	gin.SetMode(gin.ReleaseMode)
 	ginRouter := gin.New()
 
 	// Apply global middleware
 	ginRouter.Use(gin.Recovery())
+	ginRouter.Use(correlation.Middleware())
+	ginRouter.Use(i18n.Middleware())
+	ginRouter.Use(database.ReadYourWrites())
 	ginRouter.Use(handler.ErrorHandler())
 	ginRouter.Use(loggingMiddleware.Middleware())
 	ginRouter.Use(securityHeaders.Headers())
 	ginRouter.Use(corsMiddleware.Middleware())
-	ginRouter.Use(rateLimiter.Middleware())
+	if emergencyHalt != nil {
+		ginRouter.Use(emergencyHalt.Enforce())
+	}
+
+	// Requests to described handlers are checked against the OpenAPI
+	// document before they reach the handler; in development responses are
+	// checked too and mismatches logged
+	spec := openapi.New(openapi.Config{
+		Title:            "CSIC API Gateway",
+		Version:          cfg.App.Version,
+		Error:            handler.Response{},
+		ErrorContentType: "application/json",
+		SecuritySchemes: map[string]*openapi.SecurityScheme{
+			"bearerAuth": openapi.BearerAuth,
+			"apiKey":     {Type: "apiKey", In: "header", Name: middleware.APIKeyHeader},
+		},
+		Exclude: []string{"/health", "/ready", "/metrics"},
+	})
+	httpHandler.Describe(spec)
+	upstreamHandler.Describe(spec)
+	if sessionHandler != nil {
+		sessionHandler.Describe(spec)
+	}
+	if apiKeyHandler != nil {
+		apiKeyHandler.Describe(spec)
+	}
+	if webhookHandler != nil {
+		webhookHandler.Describe(spec)
+	}
+	if adminHandler != nil {
+		adminHandler.Describe(spec)
+	}
+	if deadLetterHandler != nil {
+		deadLetterHandler.Describe(spec)
+	}
+	if transactionImportHandler != nil {
+		transactionImportHandler.Describe(spec)
+	}
+	if graphqlHandler != nil {
+		graphqlHandler.Describe(spec)
+	}
+	if sloHandler != nil {
+		sloHandler.Describe(spec)
+	}
+	ginRouter.Use(spec.Validator(openapi.ValidatorOptions{
+		MaxBodyBytes: cfg.Server.MaxBodySize,
+		Responses:    cfg.App.Environment == "development",
+		Logger:       appLogger.Logger,
+		Reject:       handler.RejectInvalid,
+	}))
+
+	// Prometheus metrics, including dead-letter queue depth and throttled requests
+	ginRouter.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Per-dependency readiness; degraded while Kafka is unavailable
+	ginRouter.GET("/ready", dependencies.ReadyHandler())
+
+	// Rate limits apply per route group after authentication, so clients are
+	// limited by API key or user rather than only by IP
+	rateLimit := func(group string) gin.HandlerFunc {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if cfg.RateLimit.Enabled {
+		rateLimiter := ratelimit.NewMiddleware(ratelimit.NewLimiter(redisClient, "ratelimit"), "api-gateway", appLogger, prometheus.DefaultRegisterer)
+		rateLimit = func(group string) gin.HandlerFunc {
+			rule := cfg.RateLimit.GetRule(group)
+			return rateLimiter.Limit(group, ratelimit.Rule{Rate: rule.RequestsPerSecond, Burst: rule.Burst})
+		}
+	}
+
+	// Maintenance mode and feature flags depend on the caller's role, so they
+	// also apply per route group after authentication
+	adminChecks := func(group *gin.RouterGroup) {}
+	proxyChain := []gin.HandlerFunc{upstreamHandler.Match, requestMetrics.Track("proxy"), authMiddleware.Authenticate(), rateLimit("proxy")}
+	if adminMiddleware != nil {
+		adminChecks = func(group *gin.RouterGroup) {
+			group.Use(adminMiddleware.Maintenance(), adminMiddleware.Features())
+		}
+		proxyChain = append(proxyChain, adminMiddleware.Maintenance(), adminMiddleware.Features())
+	}
+
+	// Sign-in and token refresh are open; both are rate limited by IP
+	if sessionHandler != nil {
+		sessionAuth := v1.Group("/auth")
+		sessionAuth.Use(requestMetrics.Track("auth"), rateLimit("auth"))
+		sessionAuth.POST("/login", sessionHandler.Login)
+		sessionAuth.POST("/refresh", sessionHandler.Refresh)
+	}
+
+	// Routes open to machine clients accept an API key in place of a JWT; API
+	// keys are limited to the routes their scopes allow
+	clientAccess := v1.Group("")
+	clientAccess.Use(requestMetrics.Track("client"))
+	requireScope := func(scope string) gin.HandlerFunc {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if apiKeyMiddleware != nil {
+		clientAccess.Use(apiKeyMiddleware.Authenticate(authMiddleware.Authenticate()))
+		requireScope = apiKeyMiddleware.RequireScope
+	} else {
+		clientAccess.Use(authMiddleware.Authenticate())
+	}
+	clientAccess.Use(rateLimit("client"))
+	adminChecks(clientAccess)
+	{
+		// Alerts
+		clientAccess.GET("/alerts", requireScope(domain.APIScopeAlertsRead), h.GetAlerts)
+		clientAccess.GET("/alerts/:id", requireScope(domain.APIScopeAlertsRead), h.GetAlertByID)
+
+		// Exchanges
+		clientAccess.GET("/exchanges", requireScope(domain.APIScopeExchangesRead), h.GetExchanges)
+		clientAccess.GET("/exchanges/:id", requireScope(domain.APIScopeExchangesRead), h.GetExchangeByID)
+
+		// Transaction reports
+		if transactionImportHandler != nil {
+			clientAccess.POST("/exchanges/:id/transactions/import", requireScope(domain.APIScopeTransactionsWrite), transactionImportHandler.ImportTransactions)
+			clientAccess.GET("/exchanges/:id/transactions/imports/:importId", requireScope(domain.APIScopeTransactionsRead), transactionImportHandler.GetTransactionImport)
+			clientAccess.GET("/exchanges/:id/transactions/imports/:importId/errors", requireScope(domain.APIScopeTransactionsRead), transactionImportHandler.GetTransactionImportErrors)
+		}
+
+		// Wallets
+		clientAccess.GET("/wallets", requireScope(domain.APIScopeWalletsRead), h.GetWallets)
+		clientAccess.GET("/wallets/:id", requireScope(domain.APIScopeWalletsRead), h.GetWalletByID)
+
+		// Miners
+		clientAccess.GET("/miners", requireScope(domain.APIScopeMinersRead), h.GetMiners)
+		clientAccess.GET("/miners/:id", requireScope(domain.APIScopeMinersRead), h.GetMinerByID)
+
+		// Compliance
+		clientAccess.GET("/compliance/reports", requireScope(domain.APIScopeComplianceRead), h.GetComplianceReports)
+		clientAccess.POST("/compliance/reports", requireScope(domain.APIScopeComplianceWrite), h.GenerateComplianceReport)
+
+		// Audit Logs
+		clientAccess.GET("/audit/logs", requireScope(domain.APIScopeAuditRead), h.GetAuditLogs)
+
+		// Blockchain
+		clientAccess.GET("/blockchain/status", requireScope(domain.APIScopeBlockchainRead), h.GetBlockchainStatus)
+
+		// GraphQL: each field checks the caller's role or API key scopes
+		if graphqlHandler != nil {
+			clientAccess.POST("/graphql", graphqlHandler.Query)
+		}
+	}
 
 	// Apply authentication middleware to API routes
 	authRequired := v1.Group("")
-	authRequired.Use(authMiddleware.Authenticate())
+	authRequired.Use(requestMetrics.Track("default"), authMiddleware.Authenticate(), rateLimit("default"))
+	adminChecks(authRequired)
 	{
 		// Dashboard
 		authRequired.GET("/dashboard/stats", h.GetDashboardStats)
+		if sloHandler != nil {
+			authRequired.GET("/dashboard/slo", authMiddleware.RequireRole("ADMIN", "OPERATOR"), sloHandler.GetSLOStatus)
+		}
 
 		// Alerts
-		authRequired.GET("/alerts", h.GetAlerts)
-		authRequired.GET("/alerts/:id", h.GetAlertByID)
 		authRequired.POST("/alerts/:id/acknowledge", h.AcknowledgeAlert)
 
 		// Exchanges
-		authRequired.GET("/exchanges", h.GetExchanges)
-		authRequired.GET("/exchanges/:id", h.GetExchangeByID)
+		authRequired.PUT("/exchanges/:id", h.UpdateExchange)
 		authRequired.POST("/exchanges/:id/suspend", h.SuspendExchange)
 
 		// Wallets
-		authRequired.GET("/wallets", h.GetWallets)
-		authRequired.GET("/wallets/:id", h.GetWalletByID)
 		authRequired.POST("/wallets/:id/freeze", h.FreezeWallet)
 
-		// Miners
-		authRequired.GET("/miners", h.GetMiners)
-		authRequired.GET("/miners/:id", h.GetMinerByID)
+		// Users
+		authRequired.GET("/users/me", h.GetCurrentUser)
+		authRequired.GET("/users", h.GetUsers)
 
-		// Compliance
-		authRequired.GET("/compliance/reports", h.GetComplianceReports)
-		authRequired.POST("/compliance/reports", h.GenerateComplianceReport)
+		// Sessions: users see and revoke their own devices, admins any user's
+		if sessionHandler != nil {
+			authRequired.POST("/auth/logout", sessionHandler.Logout)
+			authRequired.GET("/sessions", sessionHandler.GetSessions)
+			authRequired.DELETE("/sessions", sessionHandler.RevokeOtherSessions)
+			authRequired.DELETE("/sessions/:id", sessionHandler.RevokeSession)
+		}
 
-		// Audit Logs
-		authRequired.GET("/audit/logs", h.GetAuditLogs)
+		// Dead-letter queue
+		if deadLetterHandler != nil {
+			dlq := authRequired.Group("/dlq")
+			dlq.Use(authMiddleware.RequireRole("ADMIN", "OPERATOR"))
+			dlq.GET("", deadLetterHandler.GetDeadLetters)
+			dlq.GET("/:id", deadLetterHandler.GetDeadLetterByID)
+			dlq.POST("/:id/reprocess", deadLetterHandler.ReprocessDeadLetter)
+			dlq.POST("/:id/discard", deadLetterHandler.DiscardDeadLetter)
+		}
 
-		// Blockchain
-		authRequired.GET("/blockchain/status", h.GetBlockchainStatus)
+		// Upstream routing
+		authRequired.GET("/routing/upstreams", authMiddleware.RequireRole("ADMIN", "OPERATOR"), upstreamHandler.GetUpstreams)
+		authRequired.GET("/routing/downstreams", authMiddleware.RequireRole("ADMIN", "OPERATOR"), upstreamHandler.GetDownstreams)
+
+		// API keys
+		if apiKeyHandler != nil {
+			apiKeys := authRequired.Group("/apikeys")
+			apiKeys.Use(authMiddleware.RequireRole("ADMIN"))
+			apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+			apiKeys.GET("", apiKeyHandler.GetAPIKeys)
+			apiKeys.GET("/:id", apiKeyHandler.GetAPIKeyByID)
+			apiKeys.PATCH("/:id", apiKeyHandler.UpdateAPIKey)
+			apiKeys.POST("/:id/rotate", apiKeyHandler.RotateAPIKey)
+			apiKeys.POST("/:id/revoke", apiKeyHandler.RevokeAPIKey)
+			apiKeys.GET("/:id/usage", apiKeyHandler.GetAPIKeyUsage)
+		}
 
-		// Users
-		authRequired.GET("/users/me", h.GetCurrentUser)
-		authRequired.GET("/users", h.GetUsers)
+		// Partner webhooks and their delivery log
+		if webhookHandler != nil {
+			webhooks := authRequired.Group("/webhooks")
+			webhooks.Use(authMiddleware.RequireRole("ADMIN"))
+			webhooks.POST("", webhookHandler.CreateWebhook)
+			webhooks.GET("", webhookHandler.GetWebhooks)
+			webhooks.GET("/deliveries", webhookHandler.GetWebhookDeliveries)
+			webhooks.GET("/deliveries/:deliveryId", webhookHandler.GetWebhookDeliveryByID)
+			webhooks.POST("/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhookDelivery)
+			webhooks.GET("/:id", webhookHandler.GetWebhookByID)
+			webhooks.PATCH("/:id", webhookHandler.UpdateWebhook)
+			webhooks.POST("/:id/rotate-secret", webhookHandler.RotateWebhookSecret)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+		}
+
+		// Admin console
+		if adminHandler != nil {
+			authRequired.GET("/features", adminHandler.GetEnabledFeatures)
+
+			admin := authRequired.Group("/admin")
+			admin.Use(authMiddleware.RequireRole("ADMIN"))
+			admin.GET("/runtime", adminHandler.GetRuntimeInfo)
+			admin.GET("/flags", adminHandler.GetFeatureFlags)
+			admin.GET("/flags/:key", adminHandler.GetFeatureFlag)
+			admin.PUT("/flags/:key", adminHandler.SetFeatureFlag)
+			admin.DELETE("/flags/:key", adminHandler.DeleteFeatureFlag)
+			admin.GET("/maintenance", adminHandler.GetMaintenanceMode)
+			admin.PUT("/maintenance", adminHandler.SetMaintenanceMode)
+			admin.GET("/log-level", gin.WrapH(appLogger.LevelHandler()))
+			admin.PUT("/log-level", gin.WrapH(appLogger.LevelHandler()))
+		}
 	}
 
+	// The OpenAPI document of every route above
+	ginRouter.GET(openapi.Path, spec.Handler(ginRouter))
+
+	// Requests the gateway does not serve itself are proxied by path prefix
+	ginRouter.NoRoute(append(proxyChain, upstreamHandler.Proxy)...)
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
@@ -176,6 +736,7 @@ func main() {
 	<-quit
 
 	appLogger.Info("shutting down server")
+	stopStartup()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -205,6 +766,36 @@ func setupRoutes(router *gin.Engine, h *handler.HTTPHandler) {
 	}
 }
 
+// sloTargets lists the objectives of each route group, ordered by group
+func sloTargets(cfg config.SLOConfig) []domain.SLOTarget {
+	groups := make([]string, 0, len(cfg.Groups))
+	for group := range cfg.Groups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var targets []domain.SLOTarget
+	for _, group := range groups {
+		objective := cfg.Groups[group]
+		if objective.Availability > 0 {
+			targets = append(targets, domain.SLOTarget{
+				Group:     group,
+				Objective: domain.SLOObjectiveAvailability,
+				Target:    objective.Availability,
+			})
+		}
+		if objective.LatencyTarget > 0 {
+			targets = append(targets, domain.SLOTarget{
+				Group:            group,
+				Objective:        domain.SLOObjectiveLatency,
+				Target:           objective.LatencyTarget,
+				LatencyThreshold: objective.GetLatencyThreshold(),
+			})
+		}
+	}
+	return targets
+}
+
 func getDefaultConfig() *config.Config {
 	return &config.Config{
 		App: config.AppConfig{
@@ -238,7 +829,45 @@ func getDefaultConfig() *config.Config {
 			PoolSize: 10,
 		},
 		Kafka: config.KafkaConfig{
-			Brokers: []string{"localhost:9092"},
+			Brokers:       []string{"localhost:9092"},
+			ConsumerGroup: "csic-api-gateway",
+			Topics: config.KafkaTopicsConfig{
+				ComplianceViolations: "csic.compliance_violations",
+				EmergencyEvents:      "control-layer.emergency.events",
+				LicenseEvents:        "csic.license_events",
+				WalletEvents:         "csic.wallet_events",
+			},
+		},
+		Compliance: config.ComplianceConfig{
+			ViolationConsumer: config.ViolationConsumerConfig{
+				Enabled:       true,
+				ConsumerGroup: "csic-api-gateway-violations",
+				MaxRetries:    5,
+				RetryBackoff:  500,
+			},
+			AutoFreeze: config.AutoFreezeConfig{
+				Enabled:    false,
+				Severities: []string{"CRITICAL"},
+				ActorID:    "system:compliance",
+			},
+			DeadLetter: config.DeadLetterConfig{
+				Enabled:              true,
+				DepthRefreshInterval: 30,
+			},
+			TransactionImport: config.TransactionImportConfig{
+				Enabled: false,
+			},
+		},
+		Emergency: config.EmergencyConfig{
+			Enabled:      true,
+			GroupPrefix:  "csic-api-gateway-emergency",
+			ExemptPaths:  []string{"/health", "/ready", "/metrics"},
+			RetryBackoff: 500,
+		},
+		Webhooks: config.WebhookConfig{
+			Enabled:       false,
+			ConsumerGroup: "csic-api-gateway-webhooks",
+			RetryBackoff:  500,
 		},
 		Security: config.SecurityConfig{
 			JWT: config.JWTConfig{
@@ -3,9 +3,17 @@ module github.com/api-gateway/gateway
 go 1.21
 
 require (
+	github.com/99designs/gqlgen v0.17.45
+	github.com/csic-platform/shared v0.0.0
 	github.com/gin-gonic/gin v1.9.1
+	github.com/golang-jwt/jwt/v5 v5.2.0
 	github.com/jackc/pgx/v5 v5.5.1
 	github.com/prometheus/client_golang v1.17.0
+	github.com/redis/go-redis/v9 v9.3.0
+	github.com/shopspring/decimal v1.3.1
 	github.com/spf13/viper v1.18.2
+	github.com/vektah/gqlparser/v2 v2.5.11
 	go.uber.org/zap v1.26.0
 )
+
+replace github.com/csic-platform/shared => ../../shared
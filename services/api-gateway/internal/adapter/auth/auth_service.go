@@ -10,7 +10,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
 	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/session"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
@@ -35,8 +37,14 @@ func NewAuthService(secret string) *AuthServiceImpl {
 
 // GenerateToken generates a new JWT token for a user
 func (s *AuthServiceImpl) GenerateToken(userID, username, role string, permissions []string) (string, error) {
+	return s.GenerateSessionToken(userID, username, role, permissions, "", 8*time.Hour)
+}
+
+// GenerateSessionToken generates a JWT access token that names the session
+// it was issued for, so it stops working once the session is revoked
+func (s *AuthServiceImpl) GenerateSessionToken(userID, username, role string, permissions []string, sessionID string, ttl time.Duration) (string, error) {
 	now := time.Now()
-	expiresAt := now.Add(8 * time.Hour)
+	expiresAt := now.Add(ttl)
 
 	claims := jwt.MapClaims{
 		"user_id":    userID,
@@ -47,6 +55,9 @@ func (s *AuthServiceImpl) GenerateToken(userID, username, role string, permissio
 		"iat":        now.Unix(),
 		"exp":        expiresAt.Unix(),
 	}
+	if sessionID != "" {
+		claims[session.ClaimSessionID] = sessionID
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(s.secret)
@@ -86,24 +97,23 @@ func (s *AuthServiceImpl) RefreshToken(tokenString string) (string, error) {
 }
 
 // Authenticate authenticates a user with username and password
-func (s *AuthServiceImpl) Authenticate(username, password string) (*User, error) {
+func (s *AuthServiceImpl) Authenticate(username, password string) (*domain.User, error) {
 	// This is a placeholder - in production, this would query the database
 	// For demo purposes, we return a mock user
 	if username == "admin" && password == "admin123" {
-		return &User{
-			ID:           "00000000-0000-0000-0000-000000000001",
-			Username:     "admin",
-			Email:        "admin@csic.gov",
-			Role:         "ADMIN",
-			Status:       "ACTIVE",
-			Password:     "", // Not needed after auth
-			MFAEnabled:   false,
-			Permissions:  []string{"*"},
-			LastLogin:    time.Now().UTC().Format(time.RFC3339),
+		return &domain.User{
+			BaseEntity:  domain.BaseEntity{ID: "00000000-0000-0000-0000-000000000001"},
+			Username:    "admin",
+			Email:       "admin@csic.gov",
+			Role:        "ADMIN",
+			Status:      "ACTIVE",
+			MFAEnabled:  false,
+			Permissions: []string{"*"},
+			LastLogin:   time.Now().UTC().Format(time.RFC3339),
 		}, nil
 	}
 
-	return nil, errors.New("invalid credentials")
+	return nil, domain.ErrInvalidCredentials
 }
 
 // HashPassword hashes a password using bcrypt or similar
@@ -131,6 +141,7 @@ func parseClaims(claims jwt.MapClaims) *ports.TokenClaims {
 	username, _ := claims["username"].(string)
 	role, _ := claims["role"].(string)
 	tokenID, _ := claims["token_id"].(string)
+	sessionID, _ := claims[session.ClaimSessionID].(string)
 
 	iat, _ := claims["iat"].(float64)
 	exp, _ := claims["exp"].(float64)
@@ -141,24 +152,12 @@ func parseClaims(claims jwt.MapClaims) *ports.TokenClaims {
 		Role:        role,
 		Permissions: permissions,
 		TokenID:     tokenID,
+		SessionID:   sessionID,
 		IssueAt:     int64(iat),
 		ExpiresAt:   int64(exp),
 	}
 }
 
-// User represents a user entity for authentication
-type User struct {
-	ID           string
-	Username     string
-	Email        string
-	Role         string
-	Status       string
-	Password     string
-	MFAEnabled   bool
-	Permissions  []string
-	LastLogin    string
-}
-
 // GenerateResetToken generates a password reset token
 func (s *AuthServiceImpl) GenerateResetToken(userID string) (string, error) {
 	tokenBytes := make([]byte, 32)
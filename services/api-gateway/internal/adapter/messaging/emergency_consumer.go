@@ -0,0 +1,141 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/logger"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// EmergencyConsumerConfig contains settings for the emergency event consumer
+type EmergencyConsumerConfig struct {
+	Brokers      []string
+	Topic        string
+	GroupPrefix  string
+	RetryBackoff time.Duration
+}
+
+// EmergencyConsumer applies the control layer's emergency stop events. Every
+// gateway instance must see every event, so each consumer joins a consumer
+// group of its own and never commits offsets: on every start it replays the
+// compacted topic from the beginning and rebuilds the stops in force.
+type EmergencyConsumer struct {
+	reader  *kafka.Reader
+	handler ports.EmergencyEventHandler
+	logger  *logger.Logger
+	cfg     EmergencyConsumerConfig
+	groupID string
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewEmergencyConsumer creates a new emergency event consumer
+func NewEmergencyConsumer(
+	cfg EmergencyConsumerConfig,
+	handler ports.EmergencyEventHandler,
+	log *logger.Logger,
+) *EmergencyConsumer {
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 500 * time.Millisecond
+	}
+
+	hostname, _ := os.Hostname()
+	groupID := fmt.Sprintf("%s-%s-%s", cfg.GroupPrefix, hostname, uuid.NewString()[:8])
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     cfg.Brokers,
+		Topic:       cfg.Topic,
+		GroupID:     groupID,
+		MinBytes:    1,
+		MaxBytes:    10e6, // 10MB
+		StartOffset: kafka.FirstOffset,
+	})
+
+	return &EmergencyConsumer{
+		reader:  reader,
+		handler: handler,
+		logger:  log,
+		cfg:     cfg,
+		groupID: groupID,
+	}
+}
+
+// Start begins consuming emergency events in the background
+func (c *EmergencyConsumer) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.run(ctx)
+	}()
+
+	c.logger.Info("emergency event consumer started",
+		zap.String("topic", c.cfg.Topic),
+		zap.String("consumer_group", c.groupID),
+	)
+}
+
+// Stop stops consuming and closes the underlying reader
+func (c *EmergencyConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	if err := c.reader.Close(); err != nil {
+		return fmt.Errorf("failed to close emergency consumer: %w", err)
+	}
+	return nil
+}
+
+func (c *EmergencyConsumer) run(ctx context.Context) {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Error("failed to fetch emergency event", zap.Error(err))
+			if !sleepContext(ctx, c.cfg.RetryBackoff) {
+				return
+			}
+			continue
+		}
+
+		var event domain.EmergencyEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			c.logger.Warn("skipping malformed emergency event",
+				zap.Int("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := c.handler.HandleEmergencyEvent(ctx, &event); err != nil {
+			c.logger.Warn("skipping invalid emergency event",
+				zap.Int("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		c.logger.Info("emergency event applied",
+			zap.String("type", event.Type),
+			zap.String("stop_id", event.Stop.ID),
+			zap.String("scope", event.Stop.Scope),
+			zap.String("target", event.Stop.Target),
+		)
+	}
+}
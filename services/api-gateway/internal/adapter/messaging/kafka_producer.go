@@ -3,18 +3,38 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/core/domain"
 	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/correlation"
 	"github.com/segmentio/kafka-go"
 )
 
+// ErrProducerQueueFull is returned when Kafka is unavailable and the queue of
+// messages held for it is full
+var ErrProducerQueueFull = errors.New("kafka unavailable and producer queue is full")
+
 // KafkaProducer implements the MessageProducer interface using Kafka
 type KafkaProducer struct {
+	mu      sync.Mutex
 	writers map[string]*kafka.Writer
 	brokers []string
+
+	// While holding, messages are queued in pending instead of being written,
+	// so callers are not failed while Kafka is unavailable at startup
+	holding    bool
+	pending    []pendingMessage
+	maxPending int
+}
+
+// pendingMessage is a message held until Kafka is available
+type pendingMessage struct {
+	topic string
+	msg   kafka.Message
 }
 
 // NewKafkaProducer creates a new Kafka producer instance
@@ -27,6 +47,9 @@ func NewKafkaProducer(brokers []string) *KafkaProducer {
 
 // getWriter gets or creates a Kafka writer for a topic
 func (p *KafkaProducer) getWriter(topic string) *kafka.Writer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if w, ok := p.writers[topic]; ok {
 		return w
 	}
@@ -55,6 +78,46 @@ func (p *KafkaProducer) Publish(ctx context.Context, topic string, message []byt
 		Time:  time.Now(),
 	}
 
+	return p.write(ctx, topic, writer, msg)
+}
+
+// PublishWithHeaders publishes a keyed message with headers to a Kafka topic
+func (p *KafkaProducer) PublishWithHeaders(ctx context.Context, topic, key string, message []byte, headers map[string]string) error {
+	writer := p.getWriter(topic)
+
+	// The topic is set on the writer, so it must not be repeated on the message
+	msg := kafka.Message{
+		Key:   []byte(key),
+		Value: message,
+		Time:  time.Now(),
+	}
+	for k, v := range headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	return p.write(ctx, topic, writer, msg)
+}
+
+// write writes a message, or queues it while the producer is holding. The
+// correlation ID of the request being served is added as a header.
+func (p *KafkaProducer) write(ctx context.Context, topic string, writer *kafka.Writer, msg kafka.Message) error {
+	if id := correlation.FromContext(ctx); id != "" && !hasHeader(msg, correlation.KafkaHeader) {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: correlation.KafkaHeader, Value: []byte(id)})
+	}
+
+	p.mu.Lock()
+	if p.holding {
+		defer p.mu.Unlock()
+		if len(p.pending) >= p.maxPending {
+			return ErrProducerQueueFull
+		}
+		// The topic is kept beside the message and set by its writer on flush
+		msg.Topic = ""
+		p.pending = append(p.pending, pendingMessage{topic: topic, msg: msg})
+		return nil
+	}
+	p.mu.Unlock()
+
 	if err := writer.WriteMessages(ctx, msg); err != nil {
 		return fmt.Errorf("failed to publish message to Kafka: %w", err)
 	}
@@ -62,6 +125,73 @@ func (p *KafkaProducer) Publish(ctx context.Context, topic string, message []byt
 	return nil
 }
 
+// hasHeader reports whether a message carries the header
+func hasHeader(msg kafka.Message, key string) bool {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Hold makes the producer queue up to maxPending messages in memory instead
+// of writing them, until Flush succeeds. Queued messages are lost if the
+// gateway stops first.
+func (p *KafkaProducer) Hold(maxPending int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.holding = true
+	p.maxPending = maxPending
+}
+
+// Flush writes the queued messages in order and stops holding. On error the
+// unwritten messages stay queued and the producer keeps holding.
+func (p *KafkaProducer) Flush(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		if len(p.pending) == 0 {
+			p.holding = false
+			p.pending = nil
+			p.mu.Unlock()
+			return nil
+		}
+		next := p.pending[0]
+		p.mu.Unlock()
+
+		if err := p.getWriter(next.topic).WriteMessages(ctx, next.msg); err != nil {
+			return fmt.Errorf("failed to flush queued messages to Kafka: %w", err)
+		}
+
+		p.mu.Lock()
+		p.pending = p.pending[1:]
+		p.mu.Unlock()
+	}
+}
+
+// Pending returns the number of queued messages
+func (p *KafkaProducer) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pending)
+}
+
+// Ping checks that at least one broker is reachable
+func (p *KafkaProducer) Ping(ctx context.Context) error {
+	var err error
+	for _, broker := range p.brokers {
+		var conn *kafka.Conn
+		if conn, err = kafka.DialContext(ctx, "tcp", broker); err == nil {
+			return conn.Close()
+		}
+	}
+	if err == nil {
+		return errors.New("no Kafka brokers configured")
+	}
+	return fmt.Errorf("failed to reach Kafka: %w", err)
+}
+
 // PublishAlert publishes an alert event to Kafka
 func (p *KafkaProducer) PublishAlert(ctx context.Context, alert *domain.Alert) error {
 	event := map[string]interface{}{
@@ -104,6 +234,11 @@ func (p *KafkaProducer) PublishAuditLog(ctx context.Context, log *domain.AuditLo
 		"resource_type": log.ResourceType,
 		"status":        log.Status,
 	}
+	if correlationID := log.CorrelationID; correlationID != "" {
+		event["correlation_id"] = correlationID
+	} else if correlationID = correlation.FromContext(ctx); correlationID != "" {
+		event["correlation_id"] = correlationID
+	}
 
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -135,6 +270,9 @@ func (p *KafkaProducer) PublishComplianceReport(ctx context.Context, report *dom
 
 // Close closes all Kafka writers
 func (p *KafkaProducer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	for _, w := range p.writers {
 		if err := w.Close(); err != nil {
 			return fmt.Errorf("failed to close Kafka writer: %w", err)
@@ -176,5 +314,8 @@ func (c *KafkaConsumer) Close() error {
 	return c.reader.Close()
 }
 
-// Ensure the KafkaProducer implements the MessageProducer interface
-var _ ports.MessageProducer = (*KafkaProducer)(nil)
+// Ensure the KafkaProducer implements the MessageProducer and HeaderPublisher interfaces
+var (
+	_ ports.MessageProducer = (*KafkaProducer)(nil)
+	_ ports.HeaderPublisher = (*KafkaProducer)(nil)
+)
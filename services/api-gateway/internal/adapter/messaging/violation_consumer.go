@@ -0,0 +1,249 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/logger"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// ViolationConsumerConfig contains settings for the violation consumer
+type ViolationConsumerConfig struct {
+	Brokers       []string
+	Topic         string
+	ConsumerGroup string
+	MaxRetries    int
+	RetryBackoff  time.Duration
+}
+
+// ViolationConsumer consumes compliance violation events as part of a consumer
+// group and hands them to a ViolationEventHandler. Offsets are committed only
+// once an event has been handled or dead-lettered, so events are processed at
+// least once and a poison message cannot block its partition.
+type ViolationConsumer struct {
+	reader      *kafka.Reader
+	handler     ports.ViolationEventHandler
+	deadLetters ports.DeadLetterService
+	logger      *logger.Logger
+	cfg         ViolationConsumerConfig
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// NewViolationConsumer creates a new compliance violation consumer. Events that
+// cannot be handled are sent to the dead-letter queue; with a nil deadLetters
+// service they are logged and skipped.
+func NewViolationConsumer(
+	cfg ViolationConsumerConfig,
+	handler ports.ViolationEventHandler,
+	deadLetters ports.DeadLetterService,
+	log *logger.Logger,
+) *ViolationConsumer {
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 500 * time.Millisecond
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        cfg.Brokers,
+		Topic:          cfg.Topic,
+		GroupID:        cfg.ConsumerGroup,
+		MinBytes:       1,
+		MaxBytes:       10e6, // 10MB
+		CommitInterval: 0,    // commit synchronously after each handled message
+		StartOffset:    kafka.FirstOffset,
+	})
+
+	return &ViolationConsumer{
+		reader:      reader,
+		handler:     handler,
+		deadLetters: deadLetters,
+		logger:      log,
+		cfg:         cfg,
+	}
+}
+
+// Start begins consuming violation events in the background
+func (c *ViolationConsumer) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.run(ctx)
+	}()
+
+	c.logger.Info("compliance violation consumer started",
+		zap.String("topic", c.cfg.Topic),
+		zap.String("consumer_group", c.cfg.ConsumerGroup),
+	)
+}
+
+// Stop stops consuming and closes the underlying reader
+func (c *ViolationConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	if err := c.reader.Close(); err != nil {
+		return fmt.Errorf("failed to close violation consumer: %w", err)
+	}
+	return nil
+}
+
+func (c *ViolationConsumer) run(ctx context.Context) {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Error("failed to fetch violation event", zap.Error(err))
+			if !sleepContext(ctx, c.cfg.RetryBackoff) {
+				return
+			}
+			continue
+		}
+
+		if !c.process(ctx, msg) {
+			// Shutting down mid-retry or before the event was dead-lettered:
+			// leave the offset uncommitted so the event is redelivered to the
+			// next group member
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			c.logger.Error("failed to commit violation event offset",
+				zap.Int("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// process handles a single message. Malformed events and events that still fail
+// after the configured retries are dead-lettered. It returns false only when the
+// context is cancelled before the event could be handled or dead-lettered.
+func (c *ViolationConsumer) process(ctx context.Context, msg kafka.Message) bool {
+	var event domain.ComplianceViolationEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		c.logger.Warn("dead-lettering malformed violation event",
+			zap.Int("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Error(err),
+		)
+		return c.deadLetter(ctx, msg, fmt.Errorf("malformed event: %w", err), 1)
+	}
+
+	backoff := c.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		alert, err := c.handler.HandleViolation(ctx, &event)
+		if err == nil {
+			c.logger.Info("compliance violation alert recorded",
+				zap.String("violation_id", event.ViolationID),
+				zap.String("alert_id", alert.ID),
+				zap.String("severity", alert.Severity),
+			)
+			return true
+		}
+
+		if errors.Is(err, domain.ErrInvalidViolationEvent) {
+			c.logger.Warn("dead-lettering invalid violation event",
+				zap.Int("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+			return c.deadLetter(ctx, msg, err, attempt+1)
+		}
+
+		if attempt >= c.cfg.MaxRetries {
+			c.logger.Error("giving up on violation event, dead-lettering",
+				zap.String("violation_id", event.ViolationID),
+				zap.Int("attempts", attempt+1),
+				zap.Error(err),
+			)
+			return c.deadLetter(ctx, msg, err, attempt+1)
+		}
+
+		c.logger.Warn("failed to handle violation event, retrying",
+			zap.String("violation_id", event.ViolationID),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		if !sleepContext(ctx, backoff) {
+			return false
+		}
+		backoff *= 2
+	}
+}
+
+// deadLetter hands a failed message to the dead-letter queue, retrying until it
+// is accepted so that the offset is never committed past an unrecorded failure.
+// It returns false if the context is cancelled first.
+func (c *ViolationConsumer) deadLetter(ctx context.Context, msg kafka.Message, cause error, attempts int) bool {
+	if c.deadLetters == nil {
+		return true
+	}
+
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	backoff := c.cfg.RetryBackoff
+	for {
+		err := c.deadLetters.DeadLetter(ctx, &domain.DeadLetter{
+			OriginalTopic: msg.Topic,
+			Partition:     msg.Partition,
+			Offset:        msg.Offset,
+			ConsumerGroup: c.cfg.ConsumerGroup,
+			MessageKey:    string(msg.Key),
+			Payload:       string(msg.Value),
+			Headers:       headers,
+			Error:         cause.Error(),
+			Attempts:      attempts,
+		})
+		if err == nil {
+			return true
+		}
+
+		c.logger.Error("failed to dead-letter violation event, retrying",
+			zap.Int("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		if !sleepContext(ctx, backoff) {
+			return false
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// sleepContext waits for d, returning false if the context is cancelled first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,214 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/logger"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// webhookEventTypes maps the platform's event types to the webhook event
+// types partner agencies subscribe to. Other events are ignored.
+var webhookEventTypes = map[string]string{
+	"LICENSE_REVOKED": domain.WebhookEventLicenseRevoked,
+	"WALLET_FROZEN":   domain.WebhookEventWalletFrozen,
+}
+
+// WebhookConsumerConfig contains settings for the webhook event consumer
+type WebhookConsumerConfig struct {
+	Brokers       []string
+	Topics        []string
+	ConsumerGroup string
+	RetryBackoff  time.Duration
+}
+
+// WebhookConsumer follows the license and wallet event topics and hands the
+// events partner agencies can subscribe to to a WebhookEventHandler. An offset
+// is committed only once its event has been queued for delivery.
+type WebhookConsumer struct {
+	reader  *kafka.Reader
+	handler ports.WebhookEventHandler
+	logger  *logger.Logger
+	cfg     WebhookConsumerConfig
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewWebhookConsumer creates a new webhook event consumer
+func NewWebhookConsumer(
+	cfg WebhookConsumerConfig,
+	handler ports.WebhookEventHandler,
+	log *logger.Logger,
+) *WebhookConsumer {
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 500 * time.Millisecond
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        cfg.Brokers,
+		GroupTopics:    cfg.Topics,
+		GroupID:        cfg.ConsumerGroup,
+		MinBytes:       1,
+		MaxBytes:       10e6,             // 10MB
+		CommitInterval: 0,                // commit synchronously after each handled message
+		StartOffset:    kafka.LastOffset, // a new group does not replay past events to partners
+	})
+
+	return &WebhookConsumer{
+		reader:  reader,
+		handler: handler,
+		logger:  log,
+		cfg:     cfg,
+	}
+}
+
+// Start begins consuming events in the background
+func (c *WebhookConsumer) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.run(ctx)
+	}()
+
+	c.logger.Info("webhook event consumer started",
+		zap.Strings("topics", c.cfg.Topics),
+		zap.String("consumer_group", c.cfg.ConsumerGroup),
+	)
+}
+
+// Stop stops consuming and closes the underlying reader
+func (c *WebhookConsumer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	if err := c.reader.Close(); err != nil {
+		return fmt.Errorf("failed to close webhook consumer: %w", err)
+	}
+	return nil
+}
+
+func (c *WebhookConsumer) run(ctx context.Context) {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.logger.Error("failed to fetch webhook source event", zap.Error(err))
+			if !sleepContext(ctx, c.cfg.RetryBackoff) {
+				return
+			}
+			continue
+		}
+
+		if !c.process(ctx, msg) {
+			// Shutting down before the event was queued: leave the offset
+			// uncommitted so the event is redelivered to the next group member
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+			c.logger.Error("failed to commit webhook source event offset",
+				zap.String("topic", msg.Topic),
+				zap.Int("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// process queues a message's event for delivery, retrying until the handler
+// succeeds. Malformed messages and events without a webhook type are skipped.
+// It returns false only when the context is cancelled first.
+func (c *WebhookConsumer) process(ctx context.Context, msg kafka.Message) bool {
+	event, err := webhookEventFromMessage(msg)
+	if err != nil {
+		c.logger.Warn("skipping malformed webhook source event",
+			zap.String("topic", msg.Topic),
+			zap.Int("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Error(err),
+		)
+		return true
+	}
+	if event == nil {
+		return true
+	}
+
+	backoff := c.cfg.RetryBackoff
+	for {
+		err := c.handler.HandleWebhookEvent(ctx, event)
+		if err == nil {
+			c.logger.Info("webhook event queued",
+				zap.String("event_id", event.ID),
+				zap.String("type", event.Type),
+			)
+			return true
+		}
+
+		c.logger.Error("failed to queue webhook event, retrying",
+			zap.String("event_id", event.ID),
+			zap.String("type", event.Type),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		if !sleepContext(ctx, backoff) {
+			return false
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// webhookEventFromMessage builds the webhook event for a message, or returns
+// nil if its event type is not one partners can subscribe to. The event ID is
+// the producer's dedup key when there is one, so an event published twice is
+// delivered once.
+func webhookEventFromMessage(msg kafka.Message) (*domain.WebhookEvent, error) {
+	var fields struct {
+		EventType string `json:"event_type"`
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal(msg.Value, &fields); err != nil {
+		return nil, err
+	}
+
+	eventType, ok := webhookEventTypes[fields.EventType]
+	if !ok {
+		return nil, nil
+	}
+
+	event := &domain.WebhookEvent{
+		Type:       eventType,
+		OccurredAt: msg.Time.UTC(),
+		Data:       json.RawMessage(msg.Value),
+	}
+	if ts, err := time.Parse(time.RFC3339, fields.Timestamp); err == nil {
+		event.OccurredAt = ts.UTC()
+	}
+
+	for _, h := range msg.Headers {
+		if h.Key == "dedup-key" && len(h.Value) > 0 {
+			event.ID = string(h.Value)
+		}
+	}
+	if event.ID == "" {
+		event.ID = msg.Topic + ":" + strconv.Itoa(msg.Partition) + ":" + strconv.FormatInt(msg.Offset, 10)
+	}
+
+	return event, nil
+}
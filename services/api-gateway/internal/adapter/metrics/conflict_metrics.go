@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ConflictMetrics implements the ConflictMetrics interface using Prometheus
+type ConflictMetrics struct {
+	conflicts *prometheus.CounterVec
+}
+
+// NewConflictMetrics creates and registers the optimistic locking metrics
+func NewConflictMetrics(registerer prometheus.Registerer) *ConflictMetrics {
+	factory := promauto.With(registerer)
+
+	return &ConflictMetrics{
+		conflicts: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_gateway_version_conflicts_total",
+			Help: "Total number of updates rejected because the resource was modified concurrently",
+		}, []string{"resource"}),
+	}
+}
+
+// IncVersionConflict counts an update rejected for a stale version
+func (m *ConflictMetrics) IncVersionConflict(resource string) {
+	m.conflicts.WithLabelValues(resource).Inc()
+}
+
+// Ensure the ConflictMetrics implements the ConflictMetrics interface
+var _ ports.ConflictMetrics = (*ConflictMetrics)(nil)
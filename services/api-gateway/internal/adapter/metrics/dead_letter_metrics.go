@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DeadLetterMetrics implements the DeadLetterMetrics interface using Prometheus
+type DeadLetterMetrics struct {
+	depth        *prometheus.GaugeVec
+	deadLettered *prometheus.CounterVec
+	reprocessed  *prometheus.CounterVec
+}
+
+// NewDeadLetterMetrics creates and registers the dead-letter queue metrics
+func NewDeadLetterMetrics(registerer prometheus.Registerer) *DeadLetterMetrics {
+	factory := promauto.With(registerer)
+
+	return &DeadLetterMetrics{
+		depth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "api_gateway_dlq_depth",
+			Help: "Number of pending dead letters per dead-letter topic",
+		}, []string{"topic"}),
+		deadLettered: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_gateway_dlq_messages_total",
+			Help: "Total number of messages sent to a dead-letter topic",
+		}, []string{"topic"}),
+		reprocessed: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_gateway_dlq_reprocessed_total",
+			Help: "Total number of dead letters republished to their original topic",
+		}, []string{"topic"}),
+	}
+}
+
+// SetDepth records the number of pending dead letters for a topic
+func (m *DeadLetterMetrics) SetDepth(topic string, depth int64) {
+	m.depth.WithLabelValues(topic).Set(float64(depth))
+}
+
+// IncDeadLettered counts a message sent to a dead-letter topic
+func (m *DeadLetterMetrics) IncDeadLettered(topic string) {
+	m.deadLettered.WithLabelValues(topic).Inc()
+}
+
+// IncReprocessed counts a dead letter republished to its original topic
+func (m *DeadLetterMetrics) IncReprocessed(topic string) {
+	m.reprocessed.WithLabelValues(topic).Inc()
+}
+
+// Ensure the DeadLetterMetrics implements the DeadLetterMetrics interface
+var _ ports.DeadLetterMetrics = (*DeadLetterMetrics)(nil)
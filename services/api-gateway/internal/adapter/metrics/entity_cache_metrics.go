@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// EntityCacheMetrics implements the EntityCacheMetrics interface using Prometheus
+type EntityCacheMetrics struct {
+	lookups              *prometheus.CounterVec
+	invalidationFailures *prometheus.CounterVec
+}
+
+// NewEntityCacheMetrics creates and registers the entity cache metrics
+func NewEntityCacheMetrics(registerer prometheus.Registerer) *EntityCacheMetrics {
+	factory := promauto.With(registerer)
+
+	return &EntityCacheMetrics{
+		lookups: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_gateway_entity_cache_lookups_total",
+			Help: "Total number of entity cache lookups by result (hit, miss or error)",
+		}, []string{"entity", "result"}),
+		invalidationFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_gateway_entity_cache_invalidation_failures_total",
+			Help: "Total number of cache entries that could not be deleted after a write",
+		}, []string{"entity"}),
+	}
+}
+
+// IncLookup counts a cache lookup and its result
+func (m *EntityCacheMetrics) IncLookup(entity, result string) {
+	m.lookups.WithLabelValues(entity, result).Inc()
+}
+
+// IncInvalidationFailure counts a failed invalidation
+func (m *EntityCacheMetrics) IncInvalidationFailure(entity string) {
+	m.invalidationFailures.WithLabelValues(entity).Inc()
+}
+
+// Ensure the EntityCacheMetrics implements the EntityCacheMetrics interface
+var _ ports.EntityCacheMetrics = (*EntityCacheMetrics)(nil)
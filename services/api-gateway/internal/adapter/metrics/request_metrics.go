@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RequestMetrics implements the RequestMetrics interface using Prometheus
+type RequestMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewRequestMetrics creates and registers the per route group request metrics
+func NewRequestMetrics(registerer prometheus.Registerer) *RequestMetrics {
+	factory := promauto.With(registerer)
+
+	return &RequestMetrics{
+		requests: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "api_gateway_http_requests_total",
+			Help: "Total number of requests answered per route group, method and status code",
+		}, []string{"group", "method", "status"}),
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "api_gateway_http_request_duration_seconds",
+			Help:    "Time taken to answer requests per route group",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		}, []string{"group"}),
+	}
+}
+
+// ObserveRequest counts a request and records how long it took
+func (m *RequestMetrics) ObserveRequest(group, method string, status int, duration time.Duration) {
+	m.requests.WithLabelValues(group, method, strconv.Itoa(status)).Inc()
+	m.duration.WithLabelValues(group).Observe(duration.Seconds())
+}
+
+// Ensure the RequestMetrics implements the RequestMetrics interface
+var _ ports.RequestMetrics = (*RequestMetrics)(nil)
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// SLOMetrics implements the SLOMetrics interface using Prometheus
+type SLOMetrics struct {
+	budgetRemaining *prometheus.GaugeVec
+	burnRate        *prometheus.GaugeVec
+}
+
+// NewSLOMetrics creates and registers the SLO error budget metrics
+func NewSLOMetrics(registerer prometheus.Registerer) *SLOMetrics {
+	factory := promauto.With(registerer)
+
+	return &SLOMetrics{
+		budgetRemaining: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "api_gateway_slo_budget_remaining_ratio",
+			Help: "Fraction of the SLO window's error budget left per route group and objective; negative once overspent",
+		}, []string{"group", "objective"}),
+		burnRate: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "api_gateway_slo_burn_rate",
+			Help: "Rate the error budget was spent at over a lookback window; 1 spends exactly the budget over the SLO window",
+		}, []string{"group", "objective", "window"}),
+	}
+}
+
+// SetBudgetRemaining records the fraction of an objective's error budget left
+func (m *SLOMetrics) SetBudgetRemaining(group, objective string, remaining float64) {
+	m.budgetRemaining.WithLabelValues(group, objective).Set(remaining)
+}
+
+// SetBurnRate records the burn rate of an objective over a lookback window
+func (m *SLOMetrics) SetBurnRate(group, objective, window string, rate float64) {
+	m.burnRate.WithLabelValues(group, objective, window).Set(rate)
+}
+
+// Ensure the SLOMetrics implements the SLOMetrics interface
+var _ ports.SLOMetrics = (*SLOMetrics)(nil)
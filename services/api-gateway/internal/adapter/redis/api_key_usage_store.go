@@ -0,0 +1,138 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/config"
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// consumeScript counts a request against the per-minute and daily counters
+// only if neither limit would be exceeded, so rejected requests do not use up
+// quota. A limit of 0 is unlimited. It returns {outcome, minute, daily} where
+// outcome is 0 when allowed, 1 when rate limited and 2 when over quota.
+var consumeScript = goredis.NewScript(`
+local minute = tonumber(redis.call('GET', KEYS[1]) or '0')
+local daily = tonumber(redis.call('GET', KEYS[2]) or '0')
+local rateLimit = tonumber(ARGV[1])
+local dailyQuota = tonumber(ARGV[2])
+if rateLimit > 0 and minute >= rateLimit then
+	return {1, minute, daily}
+end
+if dailyQuota > 0 and daily >= dailyQuota then
+	return {2, minute, daily}
+end
+minute = redis.call('INCR', KEYS[1])
+if minute == 1 then
+	redis.call('EXPIRE', KEYS[1], ARGV[3])
+end
+daily = redis.call('INCR', KEYS[2])
+if daily == 1 then
+	redis.call('EXPIRE', KEYS[2], ARGV[4])
+end
+return {0, minute, daily}
+`)
+
+// APIKeyUsageStore implements ports.APIKeyUsageStore with fixed-window counters
+// in Redis, shared by every gateway instance
+type APIKeyUsageStore struct {
+	client *goredis.Client
+}
+
+// NewClient creates a Redis client and checks that Redis is reachable
+func NewClient(cfg *config.RedisConfig) (*goredis.Client, error) {
+	client := goredis.NewClient(&goredis.Options{
+		Addr:         cfg.GetRedisAddr(),
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return client, nil
+}
+
+// NewAPIKeyUsageStore creates a new Redis-backed API key usage store
+func NewAPIKeyUsageStore(client *goredis.Client) *APIKeyUsageStore {
+	return &APIKeyUsageStore{client: client}
+}
+
+// Consume counts a request against the key's rate limit and daily quota
+func (s *APIKeyUsageStore) Consume(ctx context.Context, key *domain.ClientAPIKey, now time.Time) (*domain.APIKeyUsage, error) {
+	minuteKey, dailyKey := usageKeys(key.ID, now)
+
+	result, err := consumeScript.Run(ctx, s.client, []string{minuteKey, dailyKey},
+		key.RateLimit, key.DailyQuota, int((2 * time.Minute).Seconds()), int((48 * time.Hour).Seconds()),
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to record API key usage: %w", err)
+	}
+	if len(result) != 3 {
+		return nil, fmt.Errorf("unexpected API key usage result: %v", result)
+	}
+
+	usage := newUsage(key, result[1], result[2], now)
+	switch result[0] {
+	case 1:
+		return usage, domain.ErrRateLimitExceeded
+	case 2:
+		return usage, domain.ErrDailyQuotaExceeded
+	}
+	return usage, nil
+}
+
+// GetUsage returns the key's request counts for the current minute and day
+func (s *APIKeyUsageStore) GetUsage(ctx context.Context, key *domain.ClientAPIKey, now time.Time) (*domain.APIKeyUsage, error) {
+	minuteKey, dailyKey := usageKeys(key.ID, now)
+
+	values, err := s.client.MGet(ctx, minuteKey, dailyKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key usage: %w", err)
+	}
+
+	return newUsage(key, parseCount(values[0]), parseCount(values[1]), now), nil
+}
+
+// usageKeys returns the counter keys for the minute and UTC day containing now
+func usageKeys(keyID string, now time.Time) (string, string) {
+	now = now.UTC()
+	return fmt.Sprintf("apikey:%s:minute:%d", keyID, now.Unix()/60),
+		fmt.Sprintf("apikey:%s:day:%s", keyID, now.Format("20060102"))
+}
+
+func newUsage(key *domain.ClientAPIKey, minute, daily int64, now time.Time) *domain.APIKeyUsage {
+	y, m, d := now.UTC().Date()
+	return &domain.APIKeyUsage{
+		KeyID:          key.ID,
+		MinuteRequests: minute,
+		RateLimit:      key.RateLimit,
+		DailyRequests:  daily,
+		DailyQuota:     key.DailyQuota,
+		QuotaResetsAt:  time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	}
+}
+
+func parseCount(value interface{}) int64 {
+	s, ok := value.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
+
+// Ensure APIKeyUsageStore implements the APIKeyUsageStore interface
+var _ ports.APIKeyUsageStore = (*APIKeyUsageStore)(nil)
@@ -0,0 +1,195 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Cache lookup results recorded in metrics
+const (
+	cacheHit   = "hit"
+	cacheMiss  = "miss"
+	cacheError = "error"
+)
+
+// Cached entity types, used in keys and metric labels
+const (
+	entityExchange = "exchange"
+	entityWallet   = "wallet"
+)
+
+// CachedRepository adds a cache-aside layer in Redis in front of the exchange
+// and wallet lookups made by handlers and risk checks. A miss reads the
+// repository and caches the result for the TTL; updates, suspensions and
+// freezes made through it delete the cached entries once the write succeeds.
+// Redis errors never fail a lookup, which falls back to the repository.
+type CachedRepository struct {
+	ports.Repository
+	client  *goredis.Client
+	ttl     time.Duration
+	metrics ports.EntityCacheMetrics
+}
+
+// NewCachedRepository wraps repo with a Redis cache for hot entity lookups
+func NewCachedRepository(repo ports.Repository, client *goredis.Client, ttl time.Duration, metrics ports.EntityCacheMetrics) *CachedRepository {
+	return &CachedRepository{
+		Repository: repo,
+		client:     client,
+		ttl:        ttl,
+		metrics:    metrics,
+	}
+}
+
+// GetExchangeByID returns an exchange, from the cache when it holds one
+func (r *CachedRepository) GetExchangeByID(ctx context.Context, id string) (*domain.Exchange, error) {
+	var cached domain.Exchange
+	if r.get(ctx, entityExchange, exchangeKey(id), &cached) {
+		return &cached, nil
+	}
+
+	exchange, err := r.Repository.GetExchangeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.set(ctx, exchangeKey(id), exchange)
+	return exchange, nil
+}
+
+// UpdateExchange updates an exchange and drops its cached copy
+func (r *CachedRepository) UpdateExchange(ctx context.Context, exchange *domain.Exchange) error {
+	if err := r.Repository.UpdateExchange(ctx, exchange); err != nil {
+		return err
+	}
+	r.invalidate(ctx, entityExchange, exchangeKey(exchange.ID))
+	return nil
+}
+
+// SuspendExchange suspends an exchange and drops its cached copy
+func (r *CachedRepository) SuspendExchange(ctx context.Context, id, reason, userID string) error {
+	if err := r.Repository.SuspendExchange(ctx, id, reason, userID); err != nil {
+		return err
+	}
+	r.invalidate(ctx, entityExchange, exchangeKey(id))
+	return nil
+}
+
+// GetWalletByID returns a wallet, from the cache when it holds one
+func (r *CachedRepository) GetWalletByID(ctx context.Context, id string) (*domain.Wallet, error) {
+	var cached domain.Wallet
+	if r.get(ctx, entityWallet, walletKey(id), &cached) {
+		return &cached, nil
+	}
+
+	wallet, err := r.Repository.GetWalletByID(ctx, id)
+	if err != nil || wallet == nil {
+		return wallet, err
+	}
+	r.set(ctx, walletKey(id), wallet)
+	return wallet, nil
+}
+
+// GetWalletByAddress returns a wallet, from the cache when it holds one.
+// Unknown addresses are not cached.
+func (r *CachedRepository) GetWalletByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	var cached domain.Wallet
+	if r.get(ctx, entityWallet, walletAddressKey(address), &cached) {
+		return &cached, nil
+	}
+
+	wallet, err := r.Repository.GetWalletByAddress(ctx, address)
+	if err != nil || wallet == nil {
+		return wallet, err
+	}
+	r.set(ctx, walletAddressKey(address), wallet)
+	return wallet, nil
+}
+
+// UpdateWallet updates a wallet and drops its cached copies
+func (r *CachedRepository) UpdateWallet(ctx context.Context, wallet *domain.Wallet) error {
+	if err := r.Repository.UpdateWallet(ctx, wallet); err != nil {
+		return err
+	}
+	r.invalidateWallet(ctx, wallet.ID, wallet.Address)
+	return nil
+}
+
+// FreezeWallet freezes a wallet and drops its cached copies, so the next
+// lookup sees it frozen
+func (r *CachedRepository) FreezeWallet(ctx context.Context, id, reason, userID string) error {
+	if err := r.Repository.FreezeWallet(ctx, id, reason, userID); err != nil {
+		return err
+	}
+	r.invalidateWallet(ctx, id, "")
+	return nil
+}
+
+// invalidateWallet deletes a wallet's entries under both its ID and address,
+// reading the address from the repository when the caller does not have it
+func (r *CachedRepository) invalidateWallet(ctx context.Context, id, address string) {
+	keys := []string{walletKey(id)}
+	if address == "" {
+		if wallet, err := r.Repository.GetWalletByID(ctx, id); err == nil && wallet != nil {
+			address = wallet.Address
+		}
+	}
+	if address != "" {
+		keys = append(keys, walletAddressKey(address))
+	} else {
+		// Without the address its entry cannot be found, so it is left to expire
+		r.metrics.IncInvalidationFailure(entityWallet)
+	}
+	r.invalidate(ctx, entityWallet, keys...)
+}
+
+// get reads a cached entity into dest and reports whether it was found
+func (r *CachedRepository) get(ctx context.Context, entity, key string, dest interface{}) bool {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		r.metrics.IncLookup(entity, cacheMiss)
+		return false
+	}
+	if err != nil || json.Unmarshal(data, dest) != nil {
+		r.metrics.IncLookup(entity, cacheError)
+		return false
+	}
+
+	r.metrics.IncLookup(entity, cacheHit)
+	return true
+}
+
+// set caches an entity. Failures are ignored: the next lookup misses and
+// reads the repository again.
+func (r *CachedRepository) set(ctx context.Context, key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	r.client.Set(ctx, key, data, r.ttl)
+}
+
+func (r *CachedRepository) invalidate(ctx context.Context, entity string, keys ...string) {
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		r.metrics.IncInvalidationFailure(entity)
+	}
+}
+
+func exchangeKey(id string) string {
+	return "cache:exchange:" + id
+}
+
+func walletKey(id string) string {
+	return "cache:wallet:" + id
+}
+
+func walletAddressKey(address string) string {
+	return "cache:wallet:address:" + address
+}
+
+// Ensure the CachedRepository implements the Repository interface
+var _ ports.Repository = (*CachedRepository)(nil)
@@ -0,0 +1,250 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/session"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// sessionTTLScript sets a session's expiry to the idle timeout, capped at its
+// absolute expiry, deleting it once the absolute expiry has passed. It is
+// shared by the scripts below as their final step.
+const sessionTTLScript = `
+local expiresAt = tonumber(redis.call('HGET', KEYS[1], 'expires_at') or '0')
+local ttl = math.min(tonumber(ARGV[2]), expiresAt - tonumber(ARGV[1]))
+if ttl <= 0 then
+	redis.call('DEL', KEYS[1])
+	return false
+end
+redis.call('EXPIRE', KEYS[1], ttl)
+return true
+`
+
+// touchScript records activity on a live session and extends its idle
+// timeout. It returns 1 while the session is live and 0 otherwise.
+var touchScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'last_seen', ARGV[1], 'ip', ARGV[3])
+local function extend()` + sessionTTLScript + `end
+if extend() then
+	return 1
+end
+return 0
+`)
+
+// rotateScript replaces a session's refresh token hash if the presented hash
+// is current. It returns 0 when rotated, 1 when the hash is stale, in which
+// case the session is deleted as its refresh token was reused, and 2 when
+// the session does not exist.
+var rotateScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 2
+end
+if redis.call('HGET', KEYS[1], 'refresh') ~= ARGV[3] then
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+redis.call('HSET', KEYS[1], 'refresh', ARGV[4], 'last_seen', ARGV[1])
+local function extend()` + sessionTTLScript + `end
+if extend() then
+	return 0
+end
+return 2
+`)
+
+// SessionStore implements ports.SessionStore with a Redis hash per session,
+// expiring when the session is idle, and a set of session IDs per user. The
+// hash key is session.Key(id), which services outside the gateway check to
+// honour revocation.
+type SessionStore struct {
+	client *goredis.Client
+}
+
+// NewSessionStore creates a new Redis-backed session store
+func NewSessionStore(client *goredis.Client) *SessionStore {
+	return &SessionStore{client: client}
+}
+
+// Create stores a session and indexes it under its user
+func (s *SessionStore) Create(ctx context.Context, sess *domain.Session, refreshHash string, idleTimeout time.Duration) error {
+	data, err := json.Marshal(storedSession{
+		UserID:      sess.UserID,
+		Username:    sess.Username,
+		Role:        sess.Role,
+		Permissions: sess.Permissions,
+		Device:      sess.Device,
+		UserAgent:   sess.UserAgent,
+		CreatedAt:   sess.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	ttl := idleTimeout
+	if remaining := time.Until(sess.ExpiresAt); remaining < ttl {
+		ttl = remaining
+	}
+
+	key := session.Key(sess.ID)
+	indexKey := userSessionsKey(sess.UserID)
+	// The index does not expire; ListByUser drops the IDs of expired sessions
+	_, err = s.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"data", data,
+			"refresh", refreshHash,
+			"ip", sess.IPAddress,
+			"last_seen", sess.LastSeenAt.Unix(),
+			"expires_at", sess.ExpiresAt.Unix(),
+		)
+		pipe.Expire(ctx, key, ttl)
+		pipe.SAdd(ctx, indexKey, sess.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store session: %w", err)
+	}
+	return nil
+}
+
+// Get returns a live session
+func (s *SessionStore) Get(ctx context.Context, id string) (*domain.Session, error) {
+	fields, err := s.client.HGetAll(ctx, session.Key(id)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, domain.ErrSessionNotFound
+	}
+	return decodeSession(id, fields)
+}
+
+// ListByUser returns a user's live sessions, dropping index entries of
+// sessions that have expired
+func (s *SessionStore) ListByUser(ctx context.Context, userID string) ([]*domain.Session, error) {
+	indexKey := userSessionsKey(userID)
+	ids, err := s.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*domain.Session, 0, len(ids))
+	var expired []interface{}
+	for _, id := range ids {
+		sess, err := s.Get(ctx, id)
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			expired = append(expired, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, sess)
+	}
+
+	if len(expired) > 0 {
+		s.client.SRem(ctx, indexKey, expired...)
+	}
+	return sessions, nil
+}
+
+// Delete removes a session
+func (s *SessionStore) Delete(ctx context.Context, id string) error {
+	sess, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, session.Key(id))
+		pipe.SRem(ctx, userSessionsKey(sess.UserID), id)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// RotateRefresh replaces the session's refresh token hash if oldHash is current
+func (s *SessionStore) RotateRefresh(ctx context.Context, id, oldHash, newHash string, now time.Time, idleTimeout time.Duration) error {
+	outcome, err := rotateScript.Run(ctx, s.client, []string{session.Key(id)},
+		now.Unix(), int(idleTimeout.Seconds()), oldHash, newHash,
+	).Int()
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	switch outcome {
+	case 0:
+		return nil
+	case 1:
+		return domain.ErrRefreshTokenReused
+	default:
+		return domain.ErrSessionNotFound
+	}
+}
+
+// Touch records activity on the session and extends its idle timeout
+func (s *SessionStore) Touch(ctx context.Context, id, ipAddress string, now time.Time, idleTimeout time.Duration) (bool, error) {
+	live, err := touchScript.Run(ctx, s.client, []string{session.Key(id)},
+		now.Unix(), int(idleTimeout.Seconds()), ipAddress,
+	).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to touch session: %w", err)
+	}
+	return live == 1, nil
+}
+
+// storedSession holds the session fields that do not change after sign-in
+type storedSession struct {
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username"`
+	Role        string    `json:"role"`
+	Permissions []string  `json:"permissions"`
+	Device      string    `json:"device"`
+	UserAgent   string    `json:"user_agent"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// decodeSession builds a session from its hash fields
+func decodeSession(id string, fields map[string]string) (*domain.Session, error) {
+	var stored storedSession
+	if err := json.Unmarshal([]byte(fields["data"]), &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+
+	lastSeen, _ := strconv.ParseInt(fields["last_seen"], 10, 64)
+	expiresAt, _ := strconv.ParseInt(fields["expires_at"], 10, 64)
+
+	return &domain.Session{
+		ID:          id,
+		UserID:      stored.UserID,
+		Username:    stored.Username,
+		Role:        stored.Role,
+		Permissions: stored.Permissions,
+		Device:      stored.Device,
+		UserAgent:   stored.UserAgent,
+		IPAddress:   fields["ip"],
+		CreatedAt:   stored.CreatedAt,
+		LastSeenAt:  time.Unix(lastSeen, 0).UTC(),
+		ExpiresAt:   time.Unix(expiresAt, 0).UTC(),
+	}, nil
+}
+
+// userSessionsKey returns the key of the set of a user's session IDs
+func userSessionsKey(userID string) string {
+	return "user_sessions:" + userID
+}
+
+// Ensure SessionStore implements ports.SessionStore
+var _ ports.SessionStore = (*SessionStore)(nil)
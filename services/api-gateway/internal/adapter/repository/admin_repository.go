@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+)
+
+// Feature flag operations
+
+// GetFeatureFlags returns every feature flag ordered by key
+func (r *PostgresRepository) GetFeatureFlags(ctx context.Context) ([]*domain.FeatureFlag, error) {
+	query := `
+		SELECT key, description, enabled, roles, percentage, updated_by, created_at, updated_at
+		FROM feature_flags ORDER BY key
+	`
+
+	rows, err := r.reader(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []*domain.FeatureFlag{}
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, rows.Err()
+}
+
+func (r *PostgresRepository) GetFeatureFlag(ctx context.Context, key string) (*domain.FeatureFlag, error) {
+	query := `
+		SELECT key, description, enabled, roles, percentage, updated_by, created_at, updated_at
+		FROM feature_flags WHERE key=$1
+	`
+
+	flag, err := scanFeatureFlag(r.reader(ctx).QueryRowContext(ctx, query, key))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrFeatureFlagNotFound, key)
+	}
+	return flag, err
+}
+
+// SaveFeatureFlag creates the flag or replaces the one with the same key,
+// keeping its creation time
+func (r *PostgresRepository) SaveFeatureFlag(ctx context.Context, flag *domain.FeatureFlag) error {
+	flag.UpdatedAt = time.Now()
+	if flag.CreatedAt.IsZero() {
+		flag.CreatedAt = flag.UpdatedAt
+	}
+
+	roles := flag.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	rolesJSON, _ := json.Marshal(roles)
+
+	query := `
+		INSERT INTO feature_flags (key, description, enabled, roles, percentage, updated_by,
+		                           created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (key) DO UPDATE SET description=EXCLUDED.description, enabled=EXCLUDED.enabled,
+		       roles=EXCLUDED.roles, percentage=EXCLUDED.percentage, updated_by=EXCLUDED.updated_by,
+		       updated_at=EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	return r.writer(ctx).QueryRowContext(ctx, query,
+		flag.Key, flag.Description, flag.Enabled, rolesJSON, flag.Percentage, flag.UpdatedBy,
+		flag.CreatedAt, flag.UpdatedAt,
+	).Scan(&flag.CreatedAt)
+}
+
+func (r *PostgresRepository) DeleteFeatureFlag(ctx context.Context, key string) error {
+	result, err := r.writer(ctx).ExecContext(ctx, `DELETE FROM feature_flags WHERE key=$1`, key)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrFeatureFlagNotFound, key)
+	}
+	return nil
+}
+
+// Maintenance mode operations
+
+// GetMaintenanceMode returns the maintenance mode switch, which is off if it
+// has never been set
+func (r *PostgresRepository) GetMaintenanceMode(ctx context.Context) (*domain.MaintenanceMode, error) {
+	query := `
+		SELECT enabled, message, started_at, updated_by, updated_at
+		FROM maintenance_mode WHERE id=1
+	`
+
+	var mode domain.MaintenanceMode
+	var startedAt sql.NullTime
+	err := r.reader(ctx).QueryRowContext(ctx, query).Scan(
+		&mode.Enabled, &mode.Message, &startedAt, &mode.UpdatedBy, &mode.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return &domain.MaintenanceMode{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+	if startedAt.Valid {
+		mode.StartedAt = &startedAt.Time
+	}
+	return &mode, nil
+}
+
+func (r *PostgresRepository) SaveMaintenanceMode(ctx context.Context, mode *domain.MaintenanceMode) error {
+	mode.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO maintenance_mode (id, enabled, message, started_at, updated_by, updated_at)
+		VALUES (1, $1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET enabled=EXCLUDED.enabled, message=EXCLUDED.message,
+		       started_at=EXCLUDED.started_at, updated_by=EXCLUDED.updated_by,
+		       updated_at=EXCLUDED.updated_at
+	`
+
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		mode.Enabled, mode.Message, mode.StartedAt, mode.UpdatedBy, mode.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save maintenance mode: %w", err)
+	}
+	return nil
+}
+
+func scanFeatureFlag(row rowScanner) (*domain.FeatureFlag, error) {
+	var f domain.FeatureFlag
+	var roles []byte
+	err := row.Scan(
+		&f.Key, &f.Description, &f.Enabled, &roles, &f.Percentage, &f.UpdatedBy,
+		&f.CreatedAt, &f.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+	}
+
+	_ = json.Unmarshal(roles, &f.Roles)
+	return &f, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import "github.com/csic-platform/shared/query"
+
+// List endpoint whitelists. Only NOT NULL columns are sortable so keyset
+// pagination sees every row.
+
+var exchangeTable = query.Table{
+	Name: "exchanges",
+	Key:  "id",
+	Columns: map[string]query.Column{
+		"id":                {Name: "id", Filterable: true},
+		"name":              {Name: "name", Sortable: true, Filterable: true},
+		"license_number":    {Name: "license_number", Sortable: true, Filterable: true},
+		"status":            {Name: "status", Sortable: true, Filterable: true},
+		"jurisdiction":      {Name: "jurisdiction", Filterable: true},
+		"compliance_score":  {Name: "compliance_score", Filterable: true},
+		"risk_level":        {Name: "risk_level", Filterable: true},
+		"registration_date": {Name: "registration_date", Filterable: true},
+		"next_audit":        {Name: "next_audit", Filterable: true},
+		"created_at":        {Name: "created_at", Sortable: true, Filterable: true},
+		"updated_at":        {Name: "updated_at", Sortable: true, Filterable: true},
+	},
+}
+
+var walletTable = query.Table{
+	Name: "wallets",
+	Key:  "id",
+	Columns: map[string]query.Column{
+		"id":            {Name: "id", Filterable: true},
+		"address":       {Name: "address", Sortable: true, Filterable: true},
+		"label":         {Name: "label", Filterable: true},
+		"type":          {Name: "type", Sortable: true, Filterable: true},
+		"status":        {Name: "status", Sortable: true, Filterable: true},
+		"risk_score":    {Name: "risk_score", Filterable: true},
+		"first_seen":    {Name: "first_seen", Filterable: true},
+		"last_activity": {Name: "last_activity", Filterable: true},
+		"created_at":    {Name: "created_at", Sortable: true, Filterable: true},
+		"updated_at":    {Name: "updated_at", Sortable: true, Filterable: true},
+	},
+}
+
+var minerTable = query.Table{
+	Name: "miners",
+	Key:  "id",
+	Columns: map[string]query.Column{
+		"id":                 {Name: "id", Filterable: true},
+		"name":               {Name: "name", Sortable: true, Filterable: true},
+		"license_number":     {Name: "license_number", Sortable: true, Filterable: true},
+		"status":             {Name: "status", Sortable: true, Filterable: true},
+		"jurisdiction":       {Name: "jurisdiction", Filterable: true},
+		"hash_rate":          {Name: "hash_rate", Filterable: true},
+		"energy_consumption": {Name: "energy_consumption", Filterable: true},
+		"energy_source":      {Name: "energy_source", Filterable: true},
+		"compliance_status":  {Name: "compliance_status", Filterable: true},
+		"registration_date":  {Name: "registration_date", Filterable: true},
+		"created_at":         {Name: "created_at", Sortable: true, Filterable: true},
+		"updated_at":         {Name: "updated_at", Sortable: true, Filterable: true},
+	},
+}
+
+var alertTable = query.Table{
+	Name: "alerts",
+	Key:  "id",
+	Columns: map[string]query.Column{
+		"id":              {Name: "id", Filterable: true},
+		"severity":        {Name: "severity", Sortable: true, Filterable: true},
+		"status":          {Name: "status", Sortable: true, Filterable: true},
+		"category":        {Name: "category", Filterable: true},
+		"source":          {Name: "source", Filterable: true},
+		"acknowledged_by": {Name: "acknowledged_by", Filterable: true},
+		"created_at":      {Name: "created_at", Sortable: true, Filterable: true},
+		"updated_at":      {Name: "updated_at", Sortable: true, Filterable: true},
+	},
+}
+
+var complianceReportTable = query.Table{
+	Name: "compliance_reports",
+	Key:  "id",
+	Columns: map[string]query.Column{
+		"entity_type":  {Name: "entity_type", Sortable: true, Filterable: true},
+		"entity_id":    {Name: "entity_id", Filterable: true},
+		"entity_name":  {Name: "entity_name", Sortable: true, Filterable: true},
+		"period":       {Name: "period", Sortable: true, Filterable: true},
+		"status":       {Name: "status", Sortable: true, Filterable: true},
+		"score":        {Name: "score", Filterable: true},
+		"locale":       {Name: "locale", Filterable: true},
+		"generated_at": {Name: "generated_at", Filterable: true},
+		"created_at":   {Name: "created_at", Sortable: true, Filterable: true},
+		"updated_at":   {Name: "updated_at", Sortable: true, Filterable: true},
+	},
+}
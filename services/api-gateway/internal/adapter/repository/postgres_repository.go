@@ -3,21 +3,28 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/config"
 	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/shared/database"
+	"github.com/csic-platform/shared/query"
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 )
 
-// PostgresRepository implements the Repository interface using PostgreSQL
+// PostgresRepository implements the Repository interface using PostgreSQL.
+// List, search and count queries may be served by read replicas; writes and
+// lookups by ID, which usually precede an update, always use the primary.
 type PostgresRepository struct {
-	db *sql.DB
+	db  *sql.DB
+	dbs *database.ReplicaRouter
 }
 
-// NewPostgresRepository creates a new PostgreSQL repository instance
+// NewPostgresRepository creates a new PostgreSQL repository instance, with a
+// connection pool for each of the configured read replicas
 func NewPostgresRepository(cfg *config.DatabaseConfig) (*PostgresRepository, error) {
 	db, err := sql.Open("postgres", cfg.GetDSN())
 	if err != nil {
@@ -30,38 +37,86 @@ func NewPostgresRepository(cfg *config.DatabaseConfig) (*PostgresRepository, err
 
 	// Verify connection
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &PostgresRepository{db: db}, nil
+	replicas, err := database.OpenReplicas("postgres", cfg.ReadReplicas)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	for _, replica := range replicas {
+		replica.SetMaxOpenConns(cfg.MaxOpenConns)
+		replica.SetMaxIdleConns(cfg.MaxIdleConns)
+		replica.SetConnMaxLifetime(cfg.GetConnMaxLifetime())
+	}
+
+	return &PostgresRepository{
+		db:  db,
+		dbs: database.NewReplicaRouter(db, replicas, cfg.GetMaxReplicaLag()),
+	}, nil
 }
 
-// Close closes the database connection
+// Close closes the database connections
 func (r *PostgresRepository) Close() error {
+	if err := r.dbs.Close(); err != nil {
+		return err
+	}
 	return r.db.Close()
 }
 
+// Replicas returns the router that sends read-only queries to read replicas
+func (r *PostgresRepository) Replicas() *database.ReplicaRouter {
+	return r.dbs
+}
+
+// reader returns the connection pool for a read-only list, search or count
+// query: a replica within the lag limit, unless ctx has already written
+func (r *PostgresRepository) reader(ctx context.Context) *sql.DB {
+	return r.dbs.Reader(ctx)
+}
+
+// writer returns the primary for a write, keeping the rest of ctx's request
+// on the primary so it reads its own writes
+func (r *PostgresRepository) writer(ctx context.Context) *sql.DB {
+	return r.dbs.Writer(ctx)
+}
+
 // DB returns the underlying database connection
 func (r *PostgresRepository) DB() *sql.DB {
 	return r.db
 }
 
+// countRows returns the number of rows a list statement's filters match
+func (r *PostgresRepository) countRows(ctx context.Context, stmt *query.Statement) (int64, error) {
+	q, args := stmt.Count()
+	var total int64
+	if err := r.reader(ctx).QueryRowContext(ctx, q, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
 // Exchange operations
 
-func (r *PostgresRepository) GetExchanges(ctx context.Context, page, pageSize int) ([]*domain.Exchange, error) {
-	offset := (page - 1) * pageSize
-	query := `
-		SELECT id, name, license_number, status, jurisdiction, website, contact_email,
-		       compliance_score, risk_level, registration_date, last_audit, next_audit,
-		       created_at, updated_at
-		FROM exchanges
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+func (r *PostgresRepository) GetExchanges(ctx context.Context, params *query.Params) ([]*domain.Exchange, *query.PageInfo, error) {
+	stmt, err := exchangeTable.Build(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, err := r.countRows(ctx, stmt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count exchanges: %w", err)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	q, args := stmt.Select(`id, name, license_number, status, jurisdiction, website, contact_email,
+		       compliance_score, risk_level, registration_date, last_audit, next_audit,
+		       created_at, updated_at, version`)
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query exchanges: %w", err)
+		return nil, nil, fmt.Errorf("failed to query exchanges: %w", err)
 	}
 	defer rows.Close()
 
@@ -72,21 +127,27 @@ func (r *PostgresRepository) GetExchanges(ctx context.Context, page, pageSize in
 			&e.ID, &e.Name, &e.LicenseNumber, &e.Status, &e.Jurisdiction,
 			&e.Website, &e.ContactEmail, &e.ComplianceScore, &e.RiskLevel,
 			&e.RegistrationDate, &e.LastAudit, &e.NextAudit, &e.CreatedAt, &e.UpdatedAt,
+			&e.Version,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan exchange: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan exchange: %w", err)
 		}
 		exchanges = append(exchanges, &e)
 	}
 
-	return exchanges, nil
+	info := &query.PageInfo{Total: total}
+	if stmt.HasMore(len(exchanges)) {
+		exchanges = exchanges[:params.PageSize]
+		info.NextCursor = params.NextCursor(exchanges[len(exchanges)-1].ID)
+	}
+	return exchanges, info, nil
 }
 
 func (r *PostgresRepository) GetExchangeByID(ctx context.Context, id string) (*domain.Exchange, error) {
 	query := `
 		SELECT id, name, license_number, status, jurisdiction, website, contact_email,
 		       compliance_score, risk_level, registration_date, last_audit, next_audit,
-		       created_at, updated_at
+		       created_at, updated_at, version
 		FROM exchanges WHERE id = $1
 	`
 
@@ -95,9 +156,10 @@ func (r *PostgresRepository) GetExchangeByID(ctx context.Context, id string) (*d
 		&e.ID, &e.Name, &e.LicenseNumber, &e.Status, &e.Jurisdiction,
 		&e.Website, &e.ContactEmail, &e.ComplianceScore, &e.RiskLevel,
 		&e.RegistrationDate, &e.LastAudit, &e.NextAudit, &e.CreatedAt, &e.UpdatedAt,
+		&e.Version,
 	)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("exchange not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", domain.ErrExchangeNotFound, id)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get exchange: %w", err)
@@ -112,59 +174,87 @@ func (r *PostgresRepository) CreateExchange(ctx context.Context, exchange *domai
 	}
 	exchange.CreatedAt = time.Now()
 	exchange.UpdatedAt = time.Now()
+	exchange.Version = 1
 
 	query := `
 		INSERT INTO exchanges (id, name, license_number, status, jurisdiction, website,
 		                       contact_email, compliance_score, risk_level, registration_date,
-		                       last_audit, next_audit, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		                       last_audit, next_audit, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		exchange.ID, exchange.Name, exchange.LicenseNumber, exchange.Status,
 		exchange.Jurisdiction, exchange.Website, exchange.ContactEmail,
 		exchange.ComplianceScore, exchange.RiskLevel, exchange.RegistrationDate,
 		exchange.LastAudit, exchange.NextAudit, exchange.CreatedAt, exchange.UpdatedAt,
+		exchange.Version,
 	)
 	return err
 }
 
+// UpdateExchange writes the exchange only if its stored version still matches
+// exchange.Version, returning domain.ErrVersionConflict otherwise. On success
+// exchange.Version holds the new version.
 func (r *PostgresRepository) UpdateExchange(ctx context.Context, exchange *domain.Exchange) error {
 	exchange.UpdatedAt = time.Now()
 
 	query := `
 		UPDATE exchanges SET name=$1, status=$2, compliance_score=$3, risk_level=$4,
-		       last_audit=$5, updated_at=$6 WHERE id=$7
+		       last_audit=$5, updated_at=$6, version = version + 1
+		WHERE id=$7 AND version=$8
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	result, err := r.writer(ctx).ExecContext(ctx, query,
 		exchange.Name, exchange.Status, exchange.ComplianceScore,
 		exchange.RiskLevel, exchange.LastAudit, exchange.UpdatedAt, exchange.ID,
+		exchange.Version,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		if _, err := r.GetExchangeByID(ctx, exchange.ID); err != nil {
+			return err
+		}
+		return domain.ErrVersionConflict
+	}
+
+	exchange.Version++
+	return nil
 }
 
 func (r *PostgresRepository) SuspendExchange(ctx context.Context, id, reason, userID string) error {
 	query := `
-		UPDATE exchanges SET status=$1, updated_at=$2 WHERE id=$3
+		UPDATE exchanges SET status=$1, updated_at=$2, version = version + 1 WHERE id=$3
 	`
-	_, err := r.db.ExecContext(ctx, query, domain.ExchangeStatusSuspended, time.Now(), id)
+	_, err := r.writer(ctx).ExecContext(ctx, query, domain.ExchangeStatusSuspended, time.Now(), id)
 	return err
 }
 
 // Wallet operations
 
-func (r *PostgresRepository) GetWallets(ctx context.Context, page, pageSize int) ([]*domain.Wallet, error) {
-	offset := (page - 1) * pageSize
-	query := `
-		SELECT id, address, label, type, status, risk_score, first_seen, last_activity,
-		       created_at, updated_at
-		FROM wallets ORDER BY created_at DESC LIMIT $1 OFFSET $2
-	`
+func (r *PostgresRepository) GetWallets(ctx context.Context, params *query.Params) ([]*domain.Wallet, *query.PageInfo, error) {
+	stmt, err := walletTable.Build(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, err := r.countRows(ctx, stmt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count wallets: %w", err)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	q, args := stmt.Select(`id, address, label, type, status, risk_score, first_seen, last_activity,
+		       created_at, updated_at`)
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query wallets: %w", err)
+		return nil, nil, fmt.Errorf("failed to query wallets: %w", err)
 	}
 	defer rows.Close()
 
@@ -176,12 +266,17 @@ func (r *PostgresRepository) GetWallets(ctx context.Context, page, pageSize int)
 			&w.FirstSeen, &w.LastActivity, &w.CreatedAt, &w.UpdatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan wallet: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan wallet: %w", err)
 		}
 		wallets = append(wallets, &w)
 	}
 
-	return wallets, nil
+	info := &query.PageInfo{Total: total}
+	if stmt.HasMore(len(wallets)) {
+		wallets = wallets[:params.PageSize]
+		info.NextCursor = params.NextCursor(wallets[len(wallets)-1].ID)
+	}
+	return wallets, info, nil
 }
 
 func (r *PostgresRepository) GetWalletByID(ctx context.Context, id string) (*domain.Wallet, error) {
@@ -239,7 +334,7 @@ func (r *PostgresRepository) CreateWallet(ctx context.Context, wallet *domain.Wa
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		wallet.ID, wallet.Address, wallet.Label, wallet.Type, wallet.Status,
 		wallet.RiskScore, wallet.FirstSeen, wallet.LastActivity,
 		wallet.CreatedAt, wallet.UpdatedAt,
@@ -253,7 +348,7 @@ func (r *PostgresRepository) UpdateWallet(ctx context.Context, wallet *domain.Wa
 	query := `
 		UPDATE wallets SET label=$1, status=$2, risk_score=$3, updated_at=$4 WHERE id=$5
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		wallet.Label, wallet.Status, wallet.RiskScore, wallet.UpdatedAt, wallet.ID,
 	)
 	return err
@@ -263,24 +358,29 @@ func (r *PostgresRepository) FreezeWallet(ctx context.Context, id, reason, userI
 	query := `
 		UPDATE wallets SET status=$1, updated_at=$2 WHERE id=$3
 	`
-	_, err := r.db.ExecContext(ctx, query, domain.WalletStatusFrozen, time.Now(), id)
+	_, err := r.writer(ctx).ExecContext(ctx, query, domain.WalletStatusFrozen, time.Now(), id)
 	return err
 }
 
 // Miner operations
 
-func (r *PostgresRepository) GetMiners(ctx context.Context, page, pageSize int) ([]*domain.Miner, error) {
-	offset := (page - 1) * pageSize
-	query := `
-		SELECT id, name, license_number, status, jurisdiction, hash_rate, energy_consumption,
-		       energy_source, compliance_status, registration_date, last_inspection,
-		       created_at, updated_at
-		FROM miners ORDER BY created_at DESC LIMIT $1 OFFSET $2
-	`
+func (r *PostgresRepository) GetMiners(ctx context.Context, params *query.Params) ([]*domain.Miner, *query.PageInfo, error) {
+	stmt, err := minerTable.Build(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, err := r.countRows(ctx, stmt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count miners: %w", err)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	q, args := stmt.Select(`id, name, license_number, status, jurisdiction, hash_rate, energy_consumption,
+		       energy_source, compliance_status, registration_date, last_inspection,
+		       created_at, updated_at`)
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query miners: %w", err)
+		return nil, nil, fmt.Errorf("failed to query miners: %w", err)
 	}
 	defer rows.Close()
 
@@ -293,12 +393,17 @@ func (r *PostgresRepository) GetMiners(ctx context.Context, page, pageSize int)
 			&m.RegistrationDate, &m.LastInspection, &m.CreatedAt, &m.UpdatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan miner: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan miner: %w", err)
 		}
 		miners = append(miners, &m)
 	}
 
-	return miners, nil
+	info := &query.PageInfo{Total: total}
+	if stmt.HasMore(len(miners)) {
+		miners = miners[:params.PageSize]
+		info.NextCursor = params.NextCursor(miners[len(miners)-1].ID)
+	}
+	return miners, info, nil
 }
 
 func (r *PostgresRepository) GetMinerByID(ctx context.Context, id string) (*domain.Miner, error) {
@@ -338,7 +443,7 @@ func (r *PostgresRepository) CreateMiner(ctx context.Context, miner *domain.Mine
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		miner.ID, miner.Name, miner.LicenseNumber, miner.Status, miner.Jurisdiction,
 		miner.HashRate, miner.EnergyConsumption, miner.EnergySource, miner.ComplianceStatus,
 		miner.RegistrationDate, miner.LastInspection, miner.CreatedAt, miner.UpdatedAt,
@@ -353,7 +458,7 @@ func (r *PostgresRepository) UpdateMiner(ctx context.Context, miner *domain.Mine
 		UPDATE miners SET name=$1, status=$2, hash_rate=$3, compliance_status=$4,
 		       updated_at=$5 WHERE id=$6
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		miner.Name, miner.Status, miner.HashRate, miner.ComplianceStatus,
 		miner.UpdatedAt, miner.ID,
 	)
@@ -362,17 +467,22 @@ func (r *PostgresRepository) UpdateMiner(ctx context.Context, miner *domain.Mine
 
 // Alert operations
 
-func (r *PostgresRepository) GetAlerts(ctx context.Context, page, pageSize int) ([]*domain.Alert, error) {
-	offset := (page - 1) * pageSize
-	query := `
-		SELECT id, title, description, severity, status, category, source, evidence,
-		       acknowledged_by, acknowledged_at, created_at, updated_at
-		FROM alerts ORDER BY created_at DESC LIMIT $1 OFFSET $2
-	`
+func (r *PostgresRepository) GetAlerts(ctx context.Context, params *query.Params) ([]*domain.Alert, *query.PageInfo, error) {
+	stmt, err := alertTable.Build(params)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	total, err := r.countRows(ctx, stmt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query alerts: %w", err)
+		return nil, nil, fmt.Errorf("failed to count alerts: %w", err)
+	}
+
+	q, args := stmt.Select(`id, title, description, severity, status, category, source, evidence,
+		       acknowledged_by, acknowledged_at, created_at, updated_at`)
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query alerts: %w", err)
 	}
 	defer rows.Close()
 
@@ -387,7 +497,7 @@ func (r *PostgresRepository) GetAlerts(ctx context.Context, page, pageSize int)
 			&a.CreatedAt, &a.UpdatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan alert: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan alert: %w", err)
 		}
 		if evidence.Valid {
 			_ = json.Unmarshal([]byte(evidence.String), &a.Evidence)
@@ -401,7 +511,12 @@ func (r *PostgresRepository) GetAlerts(ctx context.Context, page, pageSize int)
 		alerts = append(alerts, &a)
 	}
 
-	return alerts, nil
+	info := &query.PageInfo{Total: total}
+	if stmt.HasMore(len(alerts)) {
+		alerts = alerts[:params.PageSize]
+		info.NextCursor = params.NextCursor(alerts[len(alerts)-1].ID)
+	}
+	return alerts, info, nil
 }
 
 func (r *PostgresRepository) GetAlertByID(ctx context.Context, id string) (*domain.Alert, error) {
@@ -454,7 +569,7 @@ func (r *PostgresRepository) CreateAlert(ctx context.Context, alert *domain.Aler
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		alert.ID, alert.Title, alert.Description, alert.Severity, alert.Status,
 		alert.Category, alert.Source, evidenceJSON, alert.AcknowledgedBy,
 		alert.AcknowledgedAt, alert.CreatedAt, alert.UpdatedAt,
@@ -470,7 +585,7 @@ func (r *PostgresRepository) UpdateAlert(ctx context.Context, alert *domain.Aler
 		UPDATE alerts SET title=$1, description=$2, severity=$3, status=$4,
 		       evidence=$5, acknowledged_by=$6, acknowledged_at=$7, updated_at=$8 WHERE id=$9
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		alert.Title, alert.Description, alert.Severity, alert.Status,
 		evidenceJSON, alert.AcknowledgedBy, alert.AcknowledgedAt,
 		alert.UpdatedAt, alert.ID,
@@ -483,26 +598,55 @@ func (r *PostgresRepository) AcknowledgeAlert(ctx context.Context, id, userID st
 		UPDATE alerts SET status=$1, acknowledged_by=$2, acknowledged_at=$3, updated_at=$4
 		WHERE id=$5
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		domain.AlertStatusAcknowledged, userID,
 		time.Now().UTC().Format(time.RFC3339), time.Now(), id,
 	)
 	return err
 }
 
-// Compliance report operations
+// FindAlertByEvidence returns the alert carrying the given evidence entry, or nil if none does
+func (r *PostgresRepository) FindAlertByEvidence(ctx context.Context, evidenceType string, value string) (*domain.Alert, error) {
+	filter, err := json.Marshal([]domain.AlertEvidence{{Type: evidenceType, Value: value}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal evidence filter: %w", err)
+	}
 
-func (r *PostgresRepository) GetComplianceReports(ctx context.Context, page, pageSize int) ([]*domain.ComplianceReport, error) {
-	offset := (page - 1) * pageSize
 	query := `
-		SELECT id, entity_type, entity_id, entity_name, period, status, score,
-		       generated_at, created_at, updated_at
-		FROM compliance_reports ORDER BY created_at DESC LIMIT $1 OFFSET $2
+		SELECT id FROM alerts WHERE evidence @> $1::jsonb
+		ORDER BY created_at ASC LIMIT 1
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	var id string
+	err = r.db.QueryRowContext(ctx, query, string(filter)).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to query compliance reports: %w", err)
+		return nil, fmt.Errorf("failed to find alert by evidence: %w", err)
+	}
+
+	return r.GetAlertByID(ctx, id)
+}
+
+// Compliance report operations
+
+func (r *PostgresRepository) GetComplianceReports(ctx context.Context, params *query.Params) ([]*domain.ComplianceReport, *query.PageInfo, error) {
+	stmt, err := complianceReportTable.Build(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, err := r.countRows(ctx, stmt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count compliance reports: %w", err)
+	}
+
+	q, args := stmt.Select(`id, entity_type, entity_id, entity_name, period, status, score,
+		       locale, generated_at, created_at, updated_at`)
+	rows, err := r.reader(ctx).QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query compliance reports: %w", err)
 	}
 	defer rows.Close()
 
@@ -511,29 +655,34 @@ func (r *PostgresRepository) GetComplianceReports(ctx context.Context, page, pag
 		var rpt domain.ComplianceReport
 		err := rows.Scan(
 			&rpt.ID, &rpt.EntityType, &rpt.EntityID, &rpt.EntityName,
-			&rpt.Period, &rpt.Status, &rpt.Score, &rpt.GeneratedAt,
+			&rpt.Period, &rpt.Status, &rpt.Score, &rpt.Locale, &rpt.GeneratedAt,
 			&rpt.CreatedAt, &rpt.UpdatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan compliance report: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan compliance report: %w", err)
 		}
 		reports = append(reports, &rpt)
 	}
 
-	return reports, nil
+	info := &query.PageInfo{Total: total}
+	if stmt.HasMore(len(reports)) {
+		reports = reports[:params.PageSize]
+		info.NextCursor = params.NextCursor(reports[len(reports)-1].ID)
+	}
+	return reports, info, nil
 }
 
 func (r *PostgresRepository) GetComplianceReportByID(ctx context.Context, id string) (*domain.ComplianceReport, error) {
 	query := `
 		SELECT id, entity_type, entity_id, entity_name, period, status, score,
-		       generated_at, created_at, updated_at
+		       locale, generated_at, created_at, updated_at
 		FROM compliance_reports WHERE id=$1
 	`
 
 	var rpt domain.ComplianceReport
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&rpt.ID, &rpt.EntityType, &rpt.EntityID, &rpt.EntityName,
-		&rpt.Period, &rpt.Status, &rpt.Score, &rpt.GeneratedAt,
+		&rpt.Period, &rpt.Status, &rpt.Score, &rpt.Locale, &rpt.GeneratedAt,
 		&rpt.CreatedAt, &rpt.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -555,13 +704,13 @@ func (r *PostgresRepository) CreateComplianceReport(ctx context.Context, report
 
 	query := `
 		INSERT INTO compliance_reports (id, entity_type, entity_id, entity_name, period,
-		                               status, score, generated_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		                               status, score, locale, generated_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		report.ID, report.EntityType, report.EntityID, report.EntityName,
-		report.Period, report.Status, report.Score, report.GeneratedAt,
+		report.Period, report.Status, report.Score, report.Locale, report.GeneratedAt,
 		report.CreatedAt, report.UpdatedAt,
 	)
 	return err
@@ -577,7 +726,7 @@ func (r *PostgresRepository) GetAuditLogs(ctx context.Context, page, pageSize in
 		FROM audit_logs ORDER BY created_at DESC LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	rows, err := r.reader(ctx).QueryContext(ctx, query, pageSize, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query audit logs: %w", err)
 	}
@@ -612,13 +761,384 @@ func (r *PostgresRepository) CreateAuditLog(ctx context.Context, log *domain.Aud
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		log.ID, log.Timestamp, log.UserID, log.Username, log.Action,
 		log.ResourceType, log.Details, log.IPAddress, log.Status, log.CreatedAt,
 	)
 	return err
 }
 
+// Dead letter operations
+
+// CreateDeadLetter stores a dead letter. A message that was already dead-lettered
+// by the same consumer group is ignored, so retried dead-lettering is idempotent.
+func (r *PostgresRepository) CreateDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error {
+	if deadLetter.ID == "" {
+		deadLetter.ID = uuid.New().String()
+	}
+	deadLetter.CreatedAt = time.Now()
+	deadLetter.UpdatedAt = time.Now()
+
+	headersJSON, _ := json.Marshal(deadLetter.Headers)
+
+	query := `
+		INSERT INTO dead_letters (id, topic, original_topic, partition, "offset", consumer_group,
+		                          message_key, payload, headers, error, attempts, status,
+		                          failed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (original_topic, partition, "offset", consumer_group) DO NOTHING
+	`
+
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		deadLetter.ID, deadLetter.Topic, deadLetter.OriginalTopic, deadLetter.Partition,
+		deadLetter.Offset, deadLetter.ConsumerGroup, deadLetter.MessageKey,
+		[]byte(deadLetter.Payload), headersJSON, deadLetter.Error, deadLetter.Attempts,
+		deadLetter.Status, deadLetter.FailedAt, deadLetter.CreatedAt, deadLetter.UpdatedAt,
+	)
+	return err
+}
+
+// GetDeadLetters returns dead letters newest first, optionally filtered by status
+func (r *PostgresRepository) GetDeadLetters(ctx context.Context, status string, page, pageSize int) ([]*domain.DeadLetter, error) {
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT id, topic, original_topic, partition, "offset", consumer_group, message_key,
+		       payload, headers, error, attempts, status, failed_at, resolved_by,
+		       resolved_at, created_at, updated_at
+		FROM dead_letters WHERE ($1 = '' OR status = $1)
+		ORDER BY failed_at DESC LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.reader(ctx).QueryContext(ctx, query, status, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var deadLetters []*domain.DeadLetter
+	for rows.Next() {
+		deadLetter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, nil
+}
+
+func (r *PostgresRepository) GetDeadLetterByID(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	query := `
+		SELECT id, topic, original_topic, partition, "offset", consumer_group, message_key,
+		       payload, headers, error, attempts, status, failed_at, resolved_by,
+		       resolved_at, created_at, updated_at
+		FROM dead_letters WHERE id=$1
+	`
+
+	deadLetter, err := scanDeadLetter(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("dead letter not found: %s", id)
+	}
+	return deadLetter, err
+}
+
+func (r *PostgresRepository) UpdateDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error {
+	deadLetter.UpdatedAt = time.Now()
+
+	var resolvedAt interface{}
+	if deadLetter.ResolvedAt != "" {
+		resolvedAt = deadLetter.ResolvedAt
+	}
+
+	query := `
+		UPDATE dead_letters SET status=$1, resolved_by=$2, resolved_at=$3, updated_at=$4
+		WHERE id=$5
+	`
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		deadLetter.Status, deadLetter.ResolvedBy, resolvedAt, deadLetter.UpdatedAt, deadLetter.ID,
+	)
+	return err
+}
+
+// CountDeadLetters counts dead letters, optionally filtered by status
+func (r *PostgresRepository) CountDeadLetters(ctx context.Context, status string) (int64, error) {
+	query := `SELECT COUNT(*) FROM dead_letters WHERE ($1 = '' OR status = $1)`
+
+	var count int64
+	if err := r.reader(ctx).QueryRowContext(ctx, query, status).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+	return count, nil
+}
+
+// CountPendingDeadLettersByTopic returns the number of pending dead letters per dead-letter topic
+func (r *PostgresRepository) CountPendingDeadLettersByTopic(ctx context.Context) (map[string]int64, error) {
+	query := `SELECT topic, COUNT(*) FROM dead_letters WHERE status=$1 GROUP BY topic`
+
+	rows, err := r.reader(ctx).QueryContext(ctx, query, domain.DeadLetterStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	depths := make(map[string]int64)
+	for rows.Next() {
+		var topic string
+		var count int64
+		if err := rows.Scan(&topic, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter count: %w", err)
+		}
+		depths[topic] = count
+	}
+
+	return depths, rows.Err()
+}
+
+// API key operations
+
+func (r *PostgresRepository) CreateAPIKey(ctx context.Context, key *domain.ClientAPIKey) error {
+	return insertAPIKey(ctx, r.writer(ctx), key)
+}
+
+// RotateAPIKey stores a replacement key and saves the key it replaces in one
+// transaction, so a rotation never leaves both keys active
+func (r *PostgresRepository) RotateAPIKey(ctx context.Context, old, replacement *domain.ClientAPIKey) error {
+	dbTx, err := r.writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	if err := insertAPIKey(ctx, dbTx, replacement); err != nil {
+		return err
+	}
+	if err := rotateOutAPIKey(ctx, dbTx, old); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
+
+func insertAPIKey(ctx context.Context, db execer, key *domain.ClientAPIKey) error {
+	if key.ID == "" {
+		key.ID = uuid.New().String()
+	}
+	key.CreatedAt = time.Now()
+	key.UpdatedAt = time.Now()
+
+	scopesJSON, _ := json.Marshal(key.Scopes)
+
+	query := `
+		INSERT INTO api_keys (id, name, client_id, key_prefix, key_hash, scopes, rate_limit,
+		                      daily_quota, status, created_by, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := db.ExecContext(ctx, query,
+		key.ID, key.Name, key.ClientID, key.KeyPrefix, key.KeyHash, scopesJSON, key.RateLimit,
+		key.DailyQuota, key.Status, key.CreatedBy, key.ExpiresAt, key.CreatedAt, key.UpdatedAt,
+	)
+	return err
+}
+
+// GetAPIKeys returns API keys newest first, optionally filtered by client
+func (r *PostgresRepository) GetAPIKeys(ctx context.Context, clientID string, page, pageSize int) ([]*domain.ClientAPIKey, error) {
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT id, name, client_id, key_prefix, key_hash, scopes, rate_limit, daily_quota,
+		       status, created_by, expires_at, grace_ends_at, replaced_by, revoked_by,
+		       revoked_at, created_at, updated_at
+		FROM api_keys WHERE ($1 = '' OR client_id = $1)
+		ORDER BY created_at DESC LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.reader(ctx).QueryContext(ctx, query, clientID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.ClientAPIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (r *PostgresRepository) GetAPIKeyByID(ctx context.Context, id string) (*domain.ClientAPIKey, error) {
+	query := `
+		SELECT id, name, client_id, key_prefix, key_hash, scopes, rate_limit, daily_quota,
+		       status, created_by, expires_at, grace_ends_at, replaced_by, revoked_by,
+		       revoked_at, created_at, updated_at
+		FROM api_keys WHERE id=$1
+	`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAPIKeyNotFound, id)
+	}
+	return key, err
+}
+
+// GetAPIKeyByPrefix returns the API key with the given public prefix
+func (r *PostgresRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.ClientAPIKey, error) {
+	query := `
+		SELECT id, name, client_id, key_prefix, key_hash, scopes, rate_limit, daily_quota,
+		       status, created_by, expires_at, grace_ends_at, replaced_by, revoked_by,
+		       revoked_at, created_at, updated_at
+		FROM api_keys WHERE key_prefix=$1
+	`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAPIKeyNotFound, prefix)
+	}
+	return key, err
+}
+
+func (r *PostgresRepository) UpdateAPIKey(ctx context.Context, key *domain.ClientAPIKey) error {
+	return updateAPIKey(ctx, r.writer(ctx), key)
+}
+
+func updateAPIKey(ctx context.Context, db execer, key *domain.ClientAPIKey) error {
+	_, err := execAPIKeyUpdate(ctx, db, key, "WHERE id=$11")
+	return err
+}
+
+// rotateOutAPIKey saves a rotated key only if it is still active, so of two
+// concurrent rotations of the same key only the first succeeds. The other gets
+// domain.ErrAPIKeyNotActive and its transaction rolls back with its replacement.
+func rotateOutAPIKey(ctx context.Context, db execer, key *domain.ClientAPIKey) error {
+	result, err := execAPIKeyUpdate(ctx, db, key, "WHERE id=$11 AND status=$12", string(domain.APIKeyStatusActive))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s was rotated or revoked by another request", domain.ErrAPIKeyNotActive, key.ID)
+	}
+	return nil
+}
+
+func execAPIKeyUpdate(ctx context.Context, db execer, key *domain.ClientAPIKey, where string, whereArgs ...interface{}) (sql.Result, error) {
+	key.UpdatedAt = time.Now()
+
+	scopesJSON, _ := json.Marshal(key.Scopes)
+
+	query := `
+		UPDATE api_keys SET name=$1, scopes=$2, rate_limit=$3, daily_quota=$4, status=$5,
+		       grace_ends_at=$6, replaced_by=$7, revoked_by=$8, revoked_at=$9, updated_at=$10
+		` + where
+	args := []interface{}{
+		key.Name, scopesJSON, key.RateLimit, key.DailyQuota, key.Status, key.GraceEndsAt,
+		nullString(key.ReplacedBy), nullString(key.RevokedBy), key.RevokedAt, key.UpdatedAt, key.ID,
+	}
+	return db.ExecContext(ctx, query, append(args, whereArgs...)...)
+}
+
+// CountAPIKeys counts API keys, optionally filtered by client
+func (r *PostgresRepository) CountAPIKeys(ctx context.Context, clientID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM api_keys WHERE ($1 = '' OR client_id = $1)`
+
+	var count int64
+	if err := r.reader(ctx).QueryRowContext(ctx, query, clientID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count API keys: %w", err)
+	}
+	return count, nil
+}
+
+func scanAPIKey(row rowScanner) (*domain.ClientAPIKey, error) {
+	var k domain.ClientAPIKey
+	var scopes []byte
+	var replacedBy, revokedBy sql.NullString
+	var expiresAt, graceEndsAt, revokedAt sql.NullTime
+	err := row.Scan(
+		&k.ID, &k.Name, &k.ClientID, &k.KeyPrefix, &k.KeyHash, &scopes, &k.RateLimit,
+		&k.DailyQuota, &k.Status, &k.CreatedBy, &expiresAt, &graceEndsAt, &replacedBy,
+		&revokedBy, &revokedAt, &k.CreatedAt, &k.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan API key: %w", err)
+	}
+
+	_ = json.Unmarshal(scopes, &k.Scopes)
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	if graceEndsAt.Valid {
+		k.GraceEndsAt = &graceEndsAt.Time
+	}
+	if replacedBy.Valid {
+		k.ReplacedBy = replacedBy.String
+	}
+	if revokedBy.Valid {
+		k.RevokedBy = revokedBy.String
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+
+	return &k, nil
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDeadLetter(row rowScanner) (*domain.DeadLetter, error) {
+	var d domain.DeadLetter
+	var payload []byte
+	var messageKey, headers, resolvedBy, resolvedAt sql.NullString
+	err := row.Scan(
+		&d.ID, &d.Topic, &d.OriginalTopic, &d.Partition, &d.Offset, &d.ConsumerGroup,
+		&messageKey, &payload, &headers, &d.Error, &d.Attempts, &d.Status, &d.FailedAt,
+		&resolvedBy, &resolvedAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+	}
+
+	d.Payload = string(payload)
+	if messageKey.Valid {
+		d.MessageKey = messageKey.String
+	}
+	if headers.Valid {
+		_ = json.Unmarshal([]byte(headers.String), &d.Headers)
+	}
+	if resolvedBy.Valid {
+		d.ResolvedBy = resolvedBy.String
+	}
+	if resolvedAt.Valid {
+		d.ResolvedAt = resolvedAt.String
+	}
+
+	return &d, nil
+}
+
 // User operations
 
 func (r *PostgresRepository) GetUsers(ctx context.Context, page, pageSize int) ([]*domain.User, error) {
@@ -629,7 +1149,7 @@ func (r *PostgresRepository) GetUsers(ctx context.Context, page, pageSize int) (
 		FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	rows, err := r.reader(ctx).QueryContext(ctx, query, pageSize, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
 	}
@@ -734,7 +1254,7 @@ func (r *PostgresRepository) CreateUser(ctx context.Context, user *domain.User)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		user.ID, user.Username, user.Email, user.Role, user.Status,
 		user.Password, user.LastLogin, user.MFAEnabled, user.Permissions,
 		user.CreatedAt, user.UpdatedAt,
@@ -749,7 +1269,7 @@ func (r *PostgresRepository) UpdateUser(ctx context.Context, user *domain.User)
 		UPDATE users SET email=$1, role=$2, status=$3, last_login=$4, mfa_enabled=$5,
 		       updated_at=$6 WHERE id=$7
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.writer(ctx).ExecContext(ctx, query,
 		user.Email, user.Role, user.Status, user.LastLogin,
 		user.MFAEnabled, user.UpdatedAt, user.ID,
 	)
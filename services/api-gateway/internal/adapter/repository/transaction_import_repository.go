@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// insertChunkSize bounds the rows of one multi-row INSERT, keeping it well
+// under PostgreSQL's limit of 65535 bind parameters
+const insertChunkSize = 1000
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Transaction import operations
+
+func (r *PostgresRepository) CreateTransactionImport(ctx context.Context, imp *domain.TransactionImport) error {
+	if imp.ID == "" {
+		imp.ID = uuid.New().String()
+	}
+	imp.CreatedAt = time.Now()
+	imp.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO transaction_imports (id, exchange_id, format, status, submitted_by,
+		                                 created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		imp.ID, imp.ExchangeID, imp.Format, imp.Status, imp.SubmittedBy, imp.CreatedAt, imp.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgresRepository) GetTransactionImport(ctx context.Context, id string) (*domain.TransactionImport, error) {
+	query := `
+		SELECT id, exchange_id, format, status, submitted_by, total_rows, accepted_rows,
+		       rejected_rows, checked_rows, flagged_rows, failed_rows, error, completed_at,
+		       created_at, updated_at
+		FROM transaction_imports WHERE id=$1
+	`
+
+	imp, err := scanTransactionImport(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTransactionImportNotFound, id)
+	}
+	return imp, err
+}
+
+// GetTransactionImportsByStatus returns imports in a status, oldest first
+func (r *PostgresRepository) GetTransactionImportsByStatus(ctx context.Context, status string, limit int) ([]*domain.TransactionImport, error) {
+	query := `
+		SELECT id, exchange_id, format, status, submitted_by, total_rows, accepted_rows,
+		       rejected_rows, checked_rows, flagged_rows, failed_rows, error, completed_at,
+		       created_at, updated_at
+		FROM transaction_imports WHERE status=$1
+		ORDER BY created_at LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transaction imports: %w", err)
+	}
+	defer rows.Close()
+
+	var imports []*domain.TransactionImport
+	for rows.Next() {
+		imp, err := scanTransactionImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+
+	return imports, rows.Err()
+}
+
+func (r *PostgresRepository) UpdateTransactionImport(ctx context.Context, imp *domain.TransactionImport) error {
+	imp.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE transaction_imports SET status=$1, total_rows=$2, accepted_rows=$3, rejected_rows=$4,
+		       error=$5, updated_at=$6
+		WHERE id=$7
+	`
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		imp.Status, imp.TotalRows, imp.AcceptedRows, imp.RejectedRows, nullString(imp.Error),
+		imp.UpdatedAt, imp.ID,
+	)
+	return err
+}
+
+// CreateReportedTransactions stores transactions, skipping any the exchange has
+// already reported, and returns the row numbers that were stored
+func (r *PostgresRepository) CreateReportedTransactions(ctx context.Context, txs []*domain.ReportedTransaction) ([]int, error) {
+	const columns = 13
+	var stored []int
+
+	for start := 0; start < len(txs); start += insertChunkSize {
+		chunk := txs[start:min(start+insertChunkSize, len(txs))]
+
+		placeholders := make([]string, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*columns)
+		for i, tx := range chunk {
+			if tx.ID == "" {
+				tx.ID = uuid.New().String()
+			}
+			placeholders[i] = valuesPlaceholder(i*columns, columns)
+			args = append(args,
+				tx.ID, tx.ImportID, tx.ExchangeID, tx.RowNumber, tx.TransactionID, tx.Type,
+				tx.Asset, tx.Amount, tx.AmountUSD, tx.CustomerID, nullString(tx.FromAddress),
+				nullString(tx.ToAddress), tx.Timestamp,
+			)
+		}
+
+		query := `
+			INSERT INTO reported_transactions (id, import_id, exchange_id, row_num, transaction_id,
+			                                   type, asset, amount, amount_usd, customer_id,
+			                                   from_address, to_address, occurred_at)
+			VALUES ` + strings.Join(placeholders, ", ") + `
+			ON CONFLICT (exchange_id, transaction_id) DO NOTHING
+			RETURNING row_num
+		`
+
+		rows, err := r.writer(ctx).QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var row int
+			if err := rows.Scan(&row); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			stored = append(stored, row)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stored, nil
+}
+
+func (r *PostgresRepository) DeleteReportedTransactions(ctx context.Context, importID string) error {
+	_, err := r.writer(ctx).ExecContext(ctx, `DELETE FROM reported_transactions WHERE import_id=$1`, importID)
+	return err
+}
+
+// ClaimReportedTransactions marks up to limit pending transactions of an import
+// as being checked. Transactions claimed longer ago than lease are taken over,
+// and rows locked by another worker are skipped.
+func (r *PostgresRepository) ClaimReportedTransactions(ctx context.Context, importID string, limit int, lease time.Duration) ([]*domain.ReportedTransaction, error) {
+	now := time.Now()
+	query := `
+		UPDATE reported_transactions SET check_status=$1, claimed_at=$2
+		WHERE id IN (
+			SELECT id FROM reported_transactions
+			WHERE import_id=$3 AND (check_status=$4 OR (check_status=$1 AND claimed_at < $5))
+			ORDER BY row_num LIMIT $6
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, import_id, exchange_id, row_num, transaction_id, type, asset, amount,
+		          amount_usd, customer_id, from_address, to_address, occurred_at, check_status,
+		          check_attempts, checked_at
+	`
+
+	rows, err := r.writer(ctx).QueryContext(ctx, query,
+		domain.CheckStatusChecking, now, importID, domain.CheckStatusPending, now.Add(-lease), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim reported transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var txs []*domain.ReportedTransaction
+	for rows.Next() {
+		var tx domain.ReportedTransaction
+		var fromAddress, toAddress sql.NullString
+		var checkedAt sql.NullTime
+		if err := rows.Scan(
+			&tx.ID, &tx.ImportID, &tx.ExchangeID, &tx.RowNumber, &tx.TransactionID, &tx.Type,
+			&tx.Asset, &tx.Amount, &tx.AmountUSD, &tx.CustomerID, &fromAddress, &toAddress,
+			&tx.Timestamp, &tx.CheckStatus, &tx.CheckAttempts, &checkedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan reported transaction: %w", err)
+		}
+		tx.FromAddress = fromAddress.String
+		tx.ToAddress = toAddress.String
+		if checkedAt.Valid {
+			tx.CheckedAt = &checkedAt.Time
+		}
+		txs = append(txs, &tx)
+	}
+
+	return txs, rows.Err()
+}
+
+// SaveTransactionCheck records the outcome of a transaction's checks together
+// with its findings, releasing the claim
+func (r *PostgresRepository) SaveTransactionCheck(ctx context.Context, tx *domain.ReportedTransaction, findings []*domain.ImportRowError) error {
+	dbTx, err := r.writer(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	query := `
+		UPDATE reported_transactions SET check_status=$1, check_attempts=$2, checked_at=$3,
+		       claimed_at=NULL
+		WHERE id=$4
+	`
+	if _, err := dbTx.ExecContext(ctx, query, tx.CheckStatus, tx.CheckAttempts, tx.CheckedAt, tx.ID); err != nil {
+		return err
+	}
+	if err := insertImportRowErrors(ctx, dbTx, findings); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
+}
+
+// SyncTransactionImportChecks refreshes a checking import's check counts from
+// its transactions and completes it once none are left to check. Only the
+// call that completes the import reports true.
+func (r *PostgresRepository) SyncTransactionImportChecks(ctx context.Context, importID string) (bool, error) {
+	now := time.Now()
+	query := `
+		UPDATE transaction_imports i SET
+		       checked_rows = s.checked, flagged_rows = s.flagged, failed_rows = s.failed,
+		       status = CASE WHEN s.open = 0 THEN $2 ELSE i.status END,
+		       completed_at = CASE WHEN s.open = 0 THEN $3 ELSE i.completed_at END,
+		       updated_at = $3
+		FROM (
+			SELECT COUNT(*) FILTER (WHERE check_status IN ($4, $5)) AS checked,
+			       COUNT(*) FILTER (WHERE check_status = $5) AS flagged,
+			       COUNT(*) FILTER (WHERE check_status = $6) AS failed,
+			       COUNT(*) FILTER (WHERE check_status IN ($7, $8)) AS open
+			FROM reported_transactions WHERE import_id = $1
+		) s
+		WHERE i.id = $1 AND i.status = $9
+		RETURNING i.status
+	`
+
+	var status string
+	err := r.writer(ctx).QueryRowContext(ctx, query,
+		importID, domain.TransactionImportStatusCompleted, now,
+		domain.CheckStatusPassed, domain.CheckStatusFlagged, domain.CheckStatusFailed,
+		domain.CheckStatusPending, domain.CheckStatusChecking,
+		domain.TransactionImportStatusChecking,
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return status == string(domain.TransactionImportStatusCompleted), nil
+}
+
+func (r *PostgresRepository) CreateImportRowErrors(ctx context.Context, rowErrors []*domain.ImportRowError) error {
+	return insertImportRowErrors(ctx, r.db, rowErrors)
+}
+
+// GetImportRowErrors returns an import's row errors ordered by row
+func (r *PostgresRepository) GetImportRowErrors(ctx context.Context, importID string, page, pageSize int) ([]*domain.ImportRowError, error) {
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT import_id, row_num, stage, field, code, message
+		FROM import_row_errors WHERE import_id=$1
+		ORDER BY row_num, id LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.reader(ctx).QueryContext(ctx, query, importID, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import row errors: %w", err)
+	}
+	defer rows.Close()
+
+	rowErrors := []*domain.ImportRowError{}
+	for rows.Next() {
+		var e domain.ImportRowError
+		var field sql.NullString
+		if err := rows.Scan(&e.ImportID, &e.Row, &e.Stage, &field, &e.Code, &e.Message); err != nil {
+			return nil, fmt.Errorf("failed to scan import row error: %w", err)
+		}
+		e.Field = field.String
+		rowErrors = append(rowErrors, &e)
+	}
+
+	return rowErrors, rows.Err()
+}
+
+func (r *PostgresRepository) CountImportRowErrors(ctx context.Context, importID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM import_row_errors WHERE import_id=$1`
+
+	var count int64
+	if err := r.reader(ctx).QueryRowContext(ctx, query, importID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count import row errors: %w", err)
+	}
+	return count, nil
+}
+
+func insertImportRowErrors(ctx context.Context, db execer, rowErrors []*domain.ImportRowError) error {
+	const columns = 6
+
+	for start := 0; start < len(rowErrors); start += insertChunkSize {
+		chunk := rowErrors[start:min(start+insertChunkSize, len(rowErrors))]
+
+		placeholders := make([]string, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*columns)
+		for i, e := range chunk {
+			placeholders[i] = valuesPlaceholder(i*columns, columns)
+			args = append(args, e.ImportID, e.Row, e.Stage, nullString(e.Field), e.Code, e.Message)
+		}
+
+		query := `
+			INSERT INTO import_row_errors (import_id, row_num, stage, field, code, message)
+			VALUES ` + strings.Join(placeholders, ", ")
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert import row errors: %w", err)
+		}
+	}
+	return nil
+}
+
+// valuesPlaceholder returns "($n+1, ..., $n+count)" for one row of a multi-row INSERT
+func valuesPlaceholder(offset, count int) string {
+	params := make([]string, count)
+	for i := range params {
+		params[i] = fmt.Sprintf("$%d", offset+i+1)
+	}
+	return "(" + strings.Join(params, ", ") + ")"
+}
+
+func scanTransactionImport(row rowScanner) (*domain.TransactionImport, error) {
+	var imp domain.TransactionImport
+	var importErr sql.NullString
+	var completedAt sql.NullTime
+	err := row.Scan(
+		&imp.ID, &imp.ExchangeID, &imp.Format, &imp.Status, &imp.SubmittedBy, &imp.TotalRows,
+		&imp.AcceptedRows, &imp.RejectedRows, &imp.CheckedRows, &imp.FlaggedRows, &imp.FailedRows,
+		&importErr, &completedAt, &imp.CreatedAt, &imp.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan transaction import: %w", err)
+	}
+
+	imp.Error = importErr.String
+	if completedAt.Valid {
+		imp.CompletedAt = &completedAt.Time
+	}
+	return &imp, nil
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// Webhook endpoint operations
+
+func (r *PostgresRepository) CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	if endpoint.ID == "" {
+		endpoint.ID = uuid.New().String()
+	}
+	endpoint.CreatedAt = time.Now()
+	endpoint.UpdatedAt = time.Now()
+
+	eventTypesJSON, _ := json.Marshal(endpoint.EventTypes)
+
+	query := `
+		INSERT INTO webhook_endpoints (id, name, url, secret, event_types, active, created_by,
+		                               created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		endpoint.ID, endpoint.Name, endpoint.URL, endpoint.Secret, eventTypesJSON, endpoint.Active,
+		endpoint.CreatedBy, endpoint.CreatedAt, endpoint.UpdatedAt,
+	)
+	return err
+}
+
+// GetWebhookEndpoints returns webhook endpoints newest first
+func (r *PostgresRepository) GetWebhookEndpoints(ctx context.Context, page, pageSize int) ([]*domain.WebhookEndpoint, error) {
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT id, name, url, secret, event_types, active, created_by, created_at, updated_at
+		FROM webhook_endpoints
+		ORDER BY created_at DESC LIMIT $1 OFFSET $2
+	`
+
+	return r.queryWebhookEndpoints(ctx, query, pageSize, offset)
+}
+
+func (r *PostgresRepository) GetWebhookEndpointByID(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	query := `
+		SELECT id, name, url, secret, event_types, active, created_by, created_at, updated_at
+		FROM webhook_endpoints WHERE id=$1
+	`
+
+	endpoint, err := scanWebhookEndpoint(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrWebhookEndpointNotFound, id)
+	}
+	return endpoint, err
+}
+
+// GetWebhookSubscribers returns the active endpoints subscribed to an event type
+func (r *PostgresRepository) GetWebhookSubscribers(ctx context.Context, eventType string) ([]*domain.WebhookEndpoint, error) {
+	query := `
+		SELECT id, name, url, secret, event_types, active, created_by, created_at, updated_at
+		FROM webhook_endpoints
+		WHERE active AND event_types @> jsonb_build_array($1::text)
+	`
+
+	return r.queryWebhookEndpoints(ctx, query, eventType)
+}
+
+func (r *PostgresRepository) UpdateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	endpoint.UpdatedAt = time.Now()
+
+	eventTypesJSON, _ := json.Marshal(endpoint.EventTypes)
+
+	query := `
+		UPDATE webhook_endpoints SET name=$1, url=$2, secret=$3, event_types=$4, active=$5,
+		       updated_at=$6
+		WHERE id=$7
+	`
+	result, err := r.writer(ctx).ExecContext(ctx, query,
+		endpoint.Name, endpoint.URL, endpoint.Secret, eventTypesJSON, endpoint.Active,
+		endpoint.UpdatedAt, endpoint.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return requireWebhookRow(result, domain.ErrWebhookEndpointNotFound, endpoint.ID)
+}
+
+// DeleteWebhookEndpoint deletes an endpoint; its deliveries are removed with it
+func (r *PostgresRepository) DeleteWebhookEndpoint(ctx context.Context, id string) error {
+	result, err := r.writer(ctx).ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	return requireWebhookRow(result, domain.ErrWebhookEndpointNotFound, id)
+}
+
+func (r *PostgresRepository) CountWebhookEndpoints(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.reader(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_endpoints`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count webhook endpoints: %w", err)
+	}
+	return count, nil
+}
+
+func (r *PostgresRepository) queryWebhookEndpoints(ctx context.Context, query string, args ...interface{}) ([]*domain.WebhookEndpoint, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []*domain.WebhookEndpoint{}
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, rows.Err()
+}
+
+// Webhook delivery operations
+
+// CreateWebhookDeliveries queues deliveries, skipping any event already queued
+// for the same endpoint so a redelivered event is only sent once
+func (r *PostgresRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	const columns = 9
+	now := time.Now()
+
+	for start := 0; start < len(deliveries); start += insertChunkSize {
+		chunk := deliveries[start:min(start+insertChunkSize, len(deliveries))]
+
+		placeholders := make([]string, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*columns)
+		for i, d := range chunk {
+			if d.ID == "" {
+				d.ID = uuid.New().String()
+			}
+			d.CreatedAt = now
+			d.UpdatedAt = now
+			placeholders[i] = valuesPlaceholder(i*columns, columns)
+			args = append(args,
+				d.ID, d.EndpointID, d.EventID, d.EventType, []byte(d.Payload), d.Status, d.NextAttemptAt,
+				d.CreatedAt, d.UpdatedAt,
+			)
+		}
+
+		query := `
+			INSERT INTO webhook_deliveries (id, endpoint_id, event_id, event_type, payload, status,
+			                                next_attempt_at, created_at, updated_at)
+			VALUES ` + strings.Join(placeholders, ", ") + `
+			ON CONFLICT (endpoint_id, event_id) DO NOTHING
+		`
+		if _, err := r.writer(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert webhook deliveries: %w", err)
+		}
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries marks up to limit due deliveries as sending, oldest
+// first. Deliveries claimed longer ago than lease are taken over, and rows
+// locked by another worker are skipped.
+func (r *PostgresRepository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	now := time.Now()
+	query := `
+		UPDATE webhook_deliveries SET status=$1, claimed_at=$2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE (status=$3 AND next_attempt_at <= $2) OR (status=$1 AND claimed_at < $4)
+			ORDER BY next_attempt_at LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts,
+		          last_status_code, last_error, last_duration_ms, next_attempt_at,
+		          delivered_at, created_at, updated_at
+	`
+
+	rows, err := r.writer(ctx).QueryContext(ctx, query,
+		domain.WebhookDeliverySending, now, domain.WebhookDeliveryPending, now.Add(-lease), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return collectWebhookDeliveries(rows)
+}
+
+// UpdateWebhookDelivery records the outcome of an attempt, releasing the claim
+func (r *PostgresRepository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE webhook_deliveries SET status=$1, attempts=$2, last_status_code=$3, last_error=$4,
+		       last_duration_ms=$5, next_attempt_at=$6, delivered_at=$7, claimed_at=NULL,
+		       updated_at=$8
+		WHERE id=$9
+	`
+	_, err := r.writer(ctx).ExecContext(ctx, query,
+		delivery.Status, delivery.Attempts, delivery.LastStatusCode, nullString(delivery.LastError),
+		delivery.LastDurationMs, delivery.NextAttemptAt, delivery.DeliveredAt, delivery.UpdatedAt,
+		delivery.ID,
+	)
+	return err
+}
+
+// GetWebhookDeliveries returns deliveries newest first, optionally filtered by
+// endpoint and status
+func (r *PostgresRepository) GetWebhookDeliveries(ctx context.Context, endpointID, status string, page, pageSize int) ([]*domain.WebhookDelivery, error) {
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT id, endpoint_id, event_id, event_type, payload, status, attempts,
+		       last_status_code, last_error, last_duration_ms, next_attempt_at,
+		       delivered_at, created_at, updated_at
+		FROM webhook_deliveries
+		WHERE ($1 = '' OR endpoint_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.reader(ctx).QueryContext(ctx, query, endpointID, status, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+
+	return collectWebhookDeliveries(rows)
+}
+
+func (r *PostgresRepository) GetWebhookDeliveryByID(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	query := `
+		SELECT id, endpoint_id, event_id, event_type, payload, status, attempts,
+		       last_status_code, last_error, last_duration_ms, next_attempt_at,
+		       delivered_at, created_at, updated_at
+		FROM webhook_deliveries WHERE id=$1
+	`
+
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrWebhookDeliveryNotFound, id)
+	}
+	return delivery, err
+}
+
+// CountWebhookDeliveries counts deliveries, optionally filtered by endpoint and status
+func (r *PostgresRepository) CountWebhookDeliveries(ctx context.Context, endpointID, status string) (int64, error) {
+	query := `
+		SELECT COUNT(*) FROM webhook_deliveries
+		WHERE ($1 = '' OR endpoint_id = $1) AND ($2 = '' OR status = $2)
+	`
+
+	var count int64
+	if err := r.reader(ctx).QueryRowContext(ctx, query, endpointID, status).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	return count, nil
+}
+
+// requireWebhookRow returns notFound if the statement affected no rows
+func requireWebhookRow(result sql.Result, notFound error, id string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", notFound, id)
+	}
+	return nil
+}
+
+func collectWebhookDeliveries(rows *sql.Rows) ([]*domain.WebhookDelivery, error) {
+	defer rows.Close()
+
+	deliveries := []*domain.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func scanWebhookEndpoint(row rowScanner) (*domain.WebhookEndpoint, error) {
+	var e domain.WebhookEndpoint
+	var eventTypes []byte
+	err := row.Scan(
+		&e.ID, &e.Name, &e.URL, &e.Secret, &eventTypes, &e.Active, &e.CreatedBy,
+		&e.CreatedAt, &e.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+	}
+
+	_ = json.Unmarshal(eventTypes, &e.EventTypes)
+	return &e, nil
+}
+
+func scanWebhookDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var payload []byte
+	var lastStatusCode, lastDurationMs sql.NullInt64
+	var lastError sql.NullString
+	var nextAttemptAt, deliveredAt sql.NullTime
+	err := row.Scan(
+		&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &payload, &d.Status, &d.Attempts,
+		&lastStatusCode, &lastError, &lastDurationMs, &nextAttemptAt, &deliveredAt,
+		&d.CreatedAt, &d.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+	}
+
+	d.Payload = json.RawMessage(payload)
+	d.LastStatusCode = int(lastStatusCode.Int64)
+	d.LastError = lastError.String
+	d.LastDurationMs = lastDurationMs.Int64
+	if nextAttemptAt.Valid {
+		d.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
@@ -0,0 +1,91 @@
+package screening
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/resilience"
+)
+
+// sanctionsUpstream names the transaction monitoring service for retries and circuit breaking
+const sanctionsUpstream = "transaction-monitoring"
+
+// maxStaleResults caps the previous screening results kept for fallback
+const maxStaleResults = 100000
+
+// SanctionsClient screens wallet addresses against the sanctions lists kept by
+// the transaction monitoring service. Failed requests are retried and the
+// service's circuit opened under the downstream policy; while it is
+// unavailable, an address screened within staleTTL gets its previous result.
+type SanctionsClient struct {
+	baseURL string
+	client  *http.Client
+	stale   *resilience.StaleCache[string, bool]
+}
+
+// NewSanctionsClient creates a client for the transaction monitoring service at baseURL
+func NewSanctionsClient(baseURL string, timeout time.Duration, policy *resilience.Client, staleTTL time.Duration) *SanctionsClient {
+	return &SanctionsClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client: &http.Client{Transport: &resilience.Transport{
+			Client:         policy,
+			Upstream:       sanctionsUpstream,
+			AttemptTimeout: timeout,
+		}},
+		stale: resilience.NewStaleCache[string, bool](staleTTL, maxStaleResults),
+	}
+}
+
+// IsSanctioned reports whether address appears on a sanctions list
+func (c *SanctionsClient) IsSanctioned(ctx context.Context, address string) (bool, error) {
+	sanctioned, err := c.screen(ctx, address)
+	if err == nil {
+		c.stale.Put(address, sanctioned)
+		return sanctioned, nil
+	}
+
+	if resilience.IsUnavailable(err) {
+		if previous, _, ok := c.stale.Get(address); ok {
+			return previous, nil
+		}
+	}
+	return false, err
+}
+
+// screen asks the transaction monitoring service to screen address
+func (c *SanctionsClient) screen(ctx context.Context, address string) (bool, error) {
+	endpoint := c.baseURL + "/api/v1/wallets/" + url.PathEscape(address) + "/sanctions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("sanctions screening failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			return false, fmt.Errorf("sanctions screening failed: %w", &resilience.StatusError{StatusCode: resp.StatusCode})
+		}
+		return false, fmt.Errorf("sanctions screening returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		IsSanctioned bool `json:"is_sanctioned"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode sanctions screening response: %w", err)
+	}
+	return result.IsSanctioned, nil
+}
+
+var _ ports.SanctionsScreener = (*SanctionsClient)(nil)
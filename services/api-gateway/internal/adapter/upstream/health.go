@@ -0,0 +1,92 @@
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// runHealthChecks probes every target of the upstream on the configured
+// interval until ctx is cancelled. Without a health check path targets are
+// always considered healthy and only the circuit breakers take them out.
+func (u *Upstream) runHealthChecks(ctx context.Context, client *http.Client, onChange func(target *Target, healthy bool, err error)) {
+	if u.cfg.HealthCheck.Path == "" {
+		return
+	}
+
+	ticker := time.NewTicker(u.cfg.HealthCheck.GetInterval())
+	defer ticker.Stop()
+
+	for {
+		for _, target := range u.targets {
+			err := u.probe(ctx, client, target)
+			if ctx.Err() != nil {
+				return
+			}
+			if changed := u.record(target, err); changed {
+				onChange(target, target.healthy.Load(), err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe sends a health check request to a target, treating any 2xx as healthy
+func (u *Upstream) probe(ctx context.Context, client *http.Client, target *Target) error {
+	ctx, cancel := context.WithTimeout(ctx, u.cfg.HealthCheck.GetTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.url.JoinPath(u.cfg.HealthCheck.Path).String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// record applies a health check result to a target, flipping its health once
+// the configured number of consecutive results agree. It reports whether the
+// target's health changed.
+func (u *Upstream) record(target *Target, err error) bool {
+	unhealthyThreshold := u.cfg.HealthCheck.UnhealthyThreshold
+	if unhealthyThreshold <= 0 {
+		unhealthyThreshold = 3
+	}
+	healthyThreshold := u.cfg.HealthCheck.HealthyThreshold
+	if healthyThreshold <= 0 {
+		healthyThreshold = 1
+	}
+
+	if err != nil {
+		target.successes = 0
+		target.failures++
+		if target.failures >= unhealthyThreshold && target.healthy.Load() {
+			target.healthy.Store(false)
+			return true
+		}
+		return false
+	}
+
+	target.failures = 0
+	target.successes++
+	if target.successes >= healthyThreshold && !target.healthy.Load() {
+		target.healthy.Store(true)
+		return true
+	}
+	return false
+}
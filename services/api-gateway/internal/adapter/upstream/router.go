@@ -0,0 +1,196 @@
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/csic-platform/services/api-gateway/internal/config"
+	"github.com/csic-platform/shared/logger"
+	"github.com/csic-platform/shared/resilience"
+	"go.uber.org/zap"
+)
+
+// Router maps request paths to upstream services. Its routing table can be
+// replaced at runtime with Reload; requests in flight finish against the
+// table they started with.
+type Router struct {
+	transport *http.Transport
+	client    *http.Client
+	logger    *logger.Logger
+
+	mu        sync.RWMutex
+	ctx       context.Context
+	upstreams []*Upstream
+	stop      context.CancelFunc
+}
+
+// UpstreamStatus describes an upstream and the state of its targets
+type UpstreamStatus struct {
+	Name       string         `json:"name"`
+	PathPrefix string         `json:"path_prefix"`
+	Timeout    string         `json:"timeout"`
+	Targets    []TargetStatus `json:"targets"`
+}
+
+// TargetStatus describes the health and circuit state of an upstream target
+type TargetStatus struct {
+	URL            string                  `json:"url"`
+	Healthy        bool                    `json:"healthy"`
+	CircuitBreaker resilience.BreakerState `json:"circuit_breaker"`
+}
+
+// NewRouter creates a router with an empty routing table
+func NewRouter(log *logger.Logger) *Router {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 32
+
+	return &Router{
+		transport: transport,
+		client:    &http.Client{Transport: transport},
+		logger:    log,
+		ctx:       context.Background(),
+	}
+}
+
+// Start loads the routing table and runs health checks until ctx is cancelled
+func (r *Router) Start(ctx context.Context, cfg config.RoutingConfig) error {
+	r.mu.Lock()
+	r.ctx = ctx
+	r.mu.Unlock()
+
+	return r.Reload(cfg)
+}
+
+// Reload replaces the routing table. Targets kept across the reload keep their
+// health so that traffic is not sent to a target known to be down. If the new
+// configuration is invalid the current table stays in place.
+func (r *Router) Reload(cfg config.RoutingConfig) error {
+	var upstreams []*Upstream
+	if cfg.Enabled {
+		for _, upstreamCfg := range cfg.Upstreams {
+			u, err := newUpstream(upstreamCfg, r.transport)
+			if err != nil {
+				return err
+			}
+			upstreams = append(upstreams, u)
+		}
+	}
+
+	// Longest prefix wins
+	sort.SliceStable(upstreams, func(i, j int) bool {
+		return len(upstreams[i].prefix) > len(upstreams[j].prefix)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	carryOverHealth(r.upstreams, upstreams)
+
+	if r.stop != nil {
+		r.stop()
+	}
+	ctx, stop := context.WithCancel(r.ctx)
+	r.stop = stop
+	r.upstreams = upstreams
+
+	for _, u := range upstreams {
+		go u.runHealthChecks(ctx, r.client, r.logHealthChange(u))
+	}
+
+	r.logger.Info("upstream routing table loaded", zap.Int("upstreams", len(upstreams)))
+	return nil
+}
+
+// Match returns the upstream serving the path, or nil if none does
+func (r *Router) Match(path string) *Upstream {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, u := range r.upstreams {
+		if u.Matches(path) {
+			return u
+		}
+	}
+	return nil
+}
+
+// Status returns the state of every upstream and target
+func (r *Router) Status() []UpstreamStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	statuses := make([]UpstreamStatus, 0, len(r.upstreams))
+	for _, u := range r.upstreams {
+		status := UpstreamStatus{
+			Name:       u.cfg.Name,
+			PathPrefix: u.cfg.PathPrefix,
+			Timeout:    u.timeout.String(),
+		}
+		for _, target := range u.targets {
+			status.Targets = append(status.Targets, TargetStatus{
+				URL:            target.url.String(),
+				Healthy:        target.healthy.Load(),
+				CircuitBreaker: target.breaker.State(),
+			})
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Stop stops the health checks and closes idle upstream connections
+func (r *Router) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stop != nil {
+		r.stop()
+		r.stop = nil
+	}
+	r.transport.CloseIdleConnections()
+}
+
+// logHealthChange returns a callback that logs target health transitions
+func (r *Router) logHealthChange(u *Upstream) func(*Target, bool, error) {
+	return func(target *Target, healthy bool, err error) {
+		if healthy {
+			r.logger.Info("upstream target healthy",
+				zap.String("upstream", u.cfg.Name),
+				zap.String("target", target.url.String()),
+			)
+			return
+		}
+		r.logger.Warn("upstream target unhealthy",
+			zap.String("upstream", u.cfg.Name),
+			zap.String("target", target.url.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// carryOverHealth copies the health of targets that exist under the same
+// upstream name in both the old and new routing tables
+func carryOverHealth(old, upstreams []*Upstream) {
+	previous := make(map[string]bool)
+	for _, u := range old {
+		for _, target := range u.targets {
+			previous[targetKey(u, target)] = target.healthy.Load()
+		}
+	}
+
+	for _, u := range upstreams {
+		for _, target := range u.targets {
+			if healthy, ok := previous[targetKey(u, target)]; ok {
+				target.healthy.Store(healthy)
+			}
+		}
+	}
+}
+
+// targetKey identifies a target within its upstream
+func targetKey(u *Upstream, target *Target) string {
+	return fmt.Sprintf("%s|%s", u.cfg.Name, target.url.String())
+}
@@ -0,0 +1,166 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/config"
+	"github.com/csic-platform/shared/resilience"
+)
+
+// ErrNoAvailableTarget is returned when every target of an upstream is
+// unhealthy or has an open circuit
+var ErrNoAvailableTarget = errors.New("no available upstream target")
+
+// Target is a single instance of an upstream service
+type Target struct {
+	url     *url.URL
+	proxy   *httputil.ReverseProxy
+	breaker *resilience.CircuitBreaker
+	healthy atomic.Bool
+
+	// consecutive health check results, owned by the health check loop
+	successes int
+	failures  int
+}
+
+// Upstream is a service reached through a path prefix and load balanced
+// round-robin across its healthy targets
+type Upstream struct {
+	cfg     config.UpstreamConfig
+	prefix  string
+	timeout time.Duration
+	targets []*Target
+	next    atomic.Uint64
+}
+
+// resultKey is the context key under which a proxied request records its error
+type resultKey struct{}
+
+// proxyResult carries the outcome of a proxied request out of the reverse proxy
+type proxyResult struct {
+	err error
+}
+
+// newUpstream creates an upstream whose targets share the given transport
+func newUpstream(cfg config.UpstreamConfig, transport http.RoundTripper) (*Upstream, error) {
+	u := &Upstream{
+		cfg:     cfg,
+		prefix:  strings.TrimSuffix(cfg.PathPrefix, "/"),
+		timeout: cfg.GetTimeout(),
+	}
+
+	for _, rawURL := range cfg.Targets {
+		targetURL, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("upstream %s has invalid target %q: %w", cfg.Name, rawURL, err)
+		}
+
+		target := &Target{
+			url:     targetURL,
+			breaker: resilience.NewCircuitBreaker(cfg.CircuitBreaker.FailureThreshold, cfg.CircuitBreaker.GetOpenTimeout()),
+		}
+		target.healthy.Store(true)
+		target.proxy = u.newReverseProxy(target, transport)
+		u.targets = append(u.targets, target)
+	}
+
+	return u, nil
+}
+
+// Name returns the upstream name
+func (u *Upstream) Name() string {
+	return u.cfg.Name
+}
+
+// Matches reports whether the request path falls under the upstream's prefix
+func (u *Upstream) Matches(path string) bool {
+	if u.prefix == "" {
+		return true
+	}
+	return path == u.prefix || strings.HasPrefix(path, u.prefix+"/")
+}
+
+// Proxy forwards the request to an available target. Failures are returned
+// rather than written, so the caller decides how to report them; a response
+// from the target, including an error status, is written as is.
+func (u *Upstream) Proxy(w http.ResponseWriter, r *http.Request) error {
+	target := u.pick()
+	if target == nil {
+		return ErrNoAvailableTarget
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), u.timeout)
+	defer cancel()
+
+	result := &proxyResult{}
+	ctx = context.WithValue(ctx, resultKey{}, result)
+	target.proxy.ServeHTTP(w, r.WithContext(ctx))
+
+	return result.err
+}
+
+// IsTimeout reports whether a proxy error was caused by the upstream timeout expiring
+func IsTimeout(err error) bool {
+	var netErr interface{ Timeout() bool }
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// pick returns the next healthy target whose circuit allows a request
+func (u *Upstream) pick() *Target {
+	n := uint64(len(u.targets))
+	start := u.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		target := u.targets[(start+i)%n]
+		if target.healthy.Load() && target.breaker.Allow() {
+			return target
+		}
+	}
+	return nil
+}
+
+// newReverseProxy creates the reverse proxy for a target. Server errors and
+// transport failures count against the target's circuit breaker.
+func (u *Upstream) newReverseProxy(target *Target, transport http.RoundTripper) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Transport: transport,
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			if u.cfg.StripPrefix {
+				path := strings.TrimPrefix(pr.In.URL.Path, u.prefix)
+				if !strings.HasPrefix(path, "/") {
+					path = "/" + path
+				}
+				pr.Out.URL.Path = path
+				pr.Out.URL.RawPath = ""
+			}
+			pr.SetURL(target.url)
+			pr.SetXForwarded()
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			if resp.StatusCode >= http.StatusInternalServerError {
+				target.breaker.Failure()
+			} else {
+				target.breaker.Success()
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			if errors.Is(err, context.Canceled) {
+				target.breaker.Release()
+			} else {
+				target.breaker.Failure()
+			}
+
+			if result, ok := r.Context().Value(resultKey{}).(*proxyResult); ok {
+				result.err = fmt.Errorf("upstream %s target %s: %w", u.cfg.Name, target.url.Host, err)
+			}
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+)
+
+// HTTPSender posts webhook deliveries to partner endpoints
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a sender whose requests time out after timeout.
+// Redirects are not followed, so a delivery only counts as accepted if the
+// registered URL itself responds with 2xx.
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts body to url with the given headers
+func (s *HTTPSender) Send(ctx context.Context, url string, body []byte, headers map[string]string) *domain.WebhookDeliveryResult {
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return &domain.WebhookDeliveryResult{Err: err}
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("User-Agent", "CSIC-Webhooks/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return &domain.WebhookDeliveryResult{Duration: time.Since(start), Err: err}
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return &domain.WebhookDeliveryResult{
+		StatusCode: resp.StatusCode,
+		Duration:   time.Since(start),
+	}
+}
+
+var _ ports.WebhookSender = (*HTTPSender)(nil)
@@ -1,9 +1,15 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
@@ -13,11 +19,22 @@ type Config struct {
 	Server      ServerConfig      `mapstructure:"server"`
 	Database    DatabaseConfig    `mapstructure:"database"`
 	Redis       RedisConfig       `mapstructure:"redis"`
+	Cache       CacheConfig       `mapstructure:"cache"`
 	Kafka       KafkaConfig       `mapstructure:"kafka"`
+	Compliance  ComplianceConfig  `mapstructure:"compliance"`
+	Emergency   EmergencyConfig   `mapstructure:"emergency"`
+	Webhooks    WebhookConfig     `mapstructure:"webhooks"`
+	Routing     RoutingConfig     `mapstructure:"routing"`
+	Downstream  DownstreamConfig  `mapstructure:"downstream"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	SLO         SLOConfig         `mapstructure:"slo"`
 	Blockchain  BlockchainConfig  `mapstructure:"blockchain"`
 	Security    SecurityConfig    `mapstructure:"security"`
 	Logging     LoggingConfig     `mapstructure:"logging"`
 	Monitoring  MonitoringConfig  `mapstructure:"monitoring"`
+	Startup     StartupConfig     `mapstructure:"startup"`
+	Admin       AdminConfig       `mapstructure:"admin"`
+	GraphQL     GraphQLConfig     `mapstructure:"graphql"`
 }
 
 // AppConfig contains application metadata
@@ -49,6 +66,11 @@ type DatabaseConfig struct {
 	MaxOpenConns    int    `mapstructure:"max_open_conns"`
 	MaxIdleConns    int    `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime"`
+
+	// Read replicas serving list, search and count queries
+	ReadReplicas         []string `mapstructure:"read_replicas"`          // DSNs
+	MaxReplicaLag        int      `mapstructure:"max_replica_lag"`        // seconds a replica may lag before reads avoid it
+	ReplicaCheckInterval int      `mapstructure:"replica_check_interval"` // seconds
 }
 
 // RedisConfig contains Redis connection settings
@@ -61,12 +83,19 @@ type RedisConfig struct {
 	MinIdleConns int    `mapstructure:"min_idle_conns"`
 }
 
+// CacheConfig contains settings for caching exchange and wallet lookups in Redis
+type CacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	TTL     int  `mapstructure:"ttl"` // seconds
+}
+
 // KafkaConfig contains Kafka broker settings
 type KafkaConfig struct {
 	Brokers       []string            `mapstructure:"brokers"`
 	ConsumerGroup string              `mapstructure:"consumer_group"`
 	Topics        KafkaTopicsConfig   `mapstructure:"topics"`
 	Security      KafkaSecurityConfig `mapstructure:"security"`
+	QueueSize     int                 `mapstructure:"queue_size"` // messages held while Kafka is unavailable
 }
 
 // KafkaTopicsConfig contains Kafka topic names
@@ -76,6 +105,10 @@ type KafkaTopicsConfig struct {
 	AuditLogs     string `mapstructure:"audit_logs"`
 	ExchangeData  string `mapstructure:"exchange_data"`
 	MiningMetrics string `mapstructure:"mining_metrics"`
+	ComplianceViolations string `mapstructure:"compliance_violations"`
+	EmergencyEvents      string `mapstructure:"emergency_events"`
+	LicenseEvents        string `mapstructure:"license_events"`
+	WalletEvents         string `mapstructure:"wallet_events"`
 }
 
 // KafkaSecurityConfig contains Kafka security settings
@@ -84,6 +117,185 @@ type KafkaSecurityConfig struct {
 	TLSEnabled    bool   `mapstructure:"tls_enabled"`
 }
 
+// RateLimitConfig contains per route group token bucket rate limits. Groups
+// without their own entry use the "default" group.
+type RateLimitConfig struct {
+	Enabled bool                     `mapstructure:"enabled"`
+	Groups  map[string]RateLimitRule `mapstructure:"groups"`
+}
+
+// RateLimitRule contains the sustained rate and burst size of a token bucket
+type RateLimitRule struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"`
+}
+
+// SLOConfig contains availability and latency objectives per route group,
+// using the rate limit group names, and when their error budgets raise alerts
+type SLOConfig struct {
+	Enabled            bool                    `mapstructure:"enabled"`
+	Window             int                     `mapstructure:"window"`              // days
+	EvaluationInterval int                     `mapstructure:"evaluation_interval"` // seconds
+	FastBurnRate       float64                 `mapstructure:"fast_burn_rate"`
+	MinRequests        int64                   `mapstructure:"min_requests"`
+	Groups             map[string]SLOObjective `mapstructure:"groups"`
+}
+
+// SLOObjective contains the objectives of a route group; a zero target leaves
+// the objective untracked
+type SLOObjective struct {
+	Availability     float64 `mapstructure:"availability"`      // percent of requests without a 5xx
+	LatencyThreshold int     `mapstructure:"latency_threshold"` // milliseconds
+	LatencyTarget    float64 `mapstructure:"latency_target"`    // percent of requests within the threshold
+}
+
+// ComplianceConfig contains compliance event processing settings
+type ComplianceConfig struct {
+	ViolationConsumer ViolationConsumerConfig `mapstructure:"violation_consumer"`
+	AutoFreeze        AutoFreezeConfig        `mapstructure:"auto_freeze"`
+	DeadLetter        DeadLetterConfig        `mapstructure:"dead_letter"`
+	TransactionImport TransactionImportConfig `mapstructure:"transaction_import"`
+}
+
+// ViolationConsumerConfig contains settings for the compliance violation consumer
+type ViolationConsumerConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	ConsumerGroup string `mapstructure:"consumer_group"`
+	MaxRetries    int    `mapstructure:"max_retries"`
+	RetryBackoff  int    `mapstructure:"retry_backoff"` // milliseconds
+}
+
+// AutoFreezeConfig contains the policy for automatic freeze orders on violations
+type AutoFreezeConfig struct {
+	Enabled          bool     `mapstructure:"enabled"`
+	Severities       []string `mapstructure:"severities"`
+	ViolationTypes   []string `mapstructure:"violation_types"`
+	SuspendExchanges bool     `mapstructure:"suspend_exchanges"`
+	ActorID          string   `mapstructure:"actor_id"`
+}
+
+// DeadLetterConfig contains dead-letter queue settings for compliance consumers
+type DeadLetterConfig struct {
+	Enabled              bool `mapstructure:"enabled"`
+	DepthRefreshInterval int  `mapstructure:"depth_refresh_interval"` // seconds
+}
+
+// TransactionImportConfig contains settings for exchanges' transaction report uploads
+type TransactionImportConfig struct {
+	Enabled             bool    `mapstructure:"enabled"`
+	MaxUploadSizeMB     int     `mapstructure:"max_upload_size_mb"`
+	MaxRows             int     `mapstructure:"max_rows"`
+	MaxRowErrors        int     `mapstructure:"max_row_errors"`
+	BatchSize           int     `mapstructure:"batch_size"`
+	Workers             int     `mapstructure:"workers"`
+	MaxCheckAttempts    int     `mapstructure:"max_check_attempts"`
+	CheckLease          int     `mapstructure:"check_lease"`   // seconds
+	PollInterval        int     `mapstructure:"poll_interval"` // seconds
+	LargeTransactionUSD float64 `mapstructure:"large_transaction_usd"`
+	ScreeningURL        string  `mapstructure:"screening_url"`
+	ScreeningTimeout    int     `mapstructure:"screening_timeout"`   // seconds
+	ScreeningStaleTTL   int     `mapstructure:"screening_stale_ttl"` // seconds
+}
+
+// EmergencyConfig contains settings for enforcing the control layer's emergency
+// stops at the gateway
+type EmergencyConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	GroupPrefix  string   `mapstructure:"group_prefix"`
+	ExemptPaths  []string `mapstructure:"exempt_paths"`
+	RetryBackoff int      `mapstructure:"retry_backoff"` // milliseconds
+}
+
+// AdminConfig contains settings for the admin console's feature flags and
+// maintenance mode
+type AdminConfig struct {
+	Enabled         bool     `mapstructure:"enabled"`
+	RefreshInterval int      `mapstructure:"refresh_interval"` // seconds
+	ExemptPaths     []string `mapstructure:"exempt_paths"`
+}
+
+// GraphQLConfig contains settings for the read-only GraphQL endpoint
+type GraphQLConfig struct {
+	Enabled         bool `mapstructure:"enabled"`
+	Introspection   bool `mapstructure:"introspection"`
+	ComplexityLimit int  `mapstructure:"complexity_limit"`
+	BatchWait       int  `mapstructure:"batch_wait"` // milliseconds
+	MaxBatch        int  `mapstructure:"max_batch"`
+}
+
+// WebhookConfig contains settings for notifying partner agencies' webhook endpoints
+type WebhookConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	ConsumerGroup  string `mapstructure:"consumer_group"`
+	RetryBackoff   int    `mapstructure:"retry_backoff"` // milliseconds
+	Workers        int    `mapstructure:"workers"`
+	BatchSize      int    `mapstructure:"batch_size"`
+	MaxAttempts    int    `mapstructure:"max_attempts"`
+	InitialBackoff int    `mapstructure:"initial_backoff"` // seconds
+	MaxBackoff     int    `mapstructure:"max_backoff"`     // seconds
+	Lease          int    `mapstructure:"lease"`           // seconds
+	PollInterval   int    `mapstructure:"poll_interval"`   // seconds
+	Timeout        int    `mapstructure:"timeout"`         // seconds
+
+	// AllowHTTP accepts plain http endpoint URLs, for development and tests
+	// only. Endpoints must otherwise use https.
+	AllowHTTP bool `mapstructure:"allow_http"`
+}
+
+// DownstreamConfig contains the retry and circuit breaker policy for calls the
+// gateway makes to other services itself, such as sanctions screening. Unset
+// values take the resilience package defaults.
+type DownstreamConfig struct {
+	MaxAttempts         int     `mapstructure:"max_attempts"`
+	InitialBackoff      int     `mapstructure:"initial_backoff"` // milliseconds
+	MaxBackoff          int     `mapstructure:"max_backoff"`     // milliseconds
+	RetryRatio          float64 `mapstructure:"retry_ratio"`
+	MinRetriesPerSecond int     `mapstructure:"min_retries_per_second"`
+	FailureThreshold    int     `mapstructure:"failure_threshold"`
+	OpenTimeout         int     `mapstructure:"open_timeout"` // seconds
+}
+
+// StartupConfig controls how long the gateway waits for its dependencies at
+// startup. Unset values take the startup package defaults.
+type StartupConfig struct {
+	MaxWait        int `mapstructure:"max_wait"`        // seconds
+	InitialBackoff int `mapstructure:"initial_backoff"` // seconds
+	MaxBackoff     int `mapstructure:"max_backoff"`     // seconds
+	CheckTimeout   int `mapstructure:"check_timeout"`   // seconds
+}
+
+// RoutingConfig contains the upstream services the gateway proxies requests to
+type RoutingConfig struct {
+	Enabled   bool             `mapstructure:"enabled"`
+	Upstreams []UpstreamConfig `mapstructure:"upstreams"`
+}
+
+// UpstreamConfig contains settings for an upstream service reached through a path prefix
+type UpstreamConfig struct {
+	Name           string                       `mapstructure:"name"`
+	PathPrefix     string                       `mapstructure:"path_prefix"`
+	StripPrefix    bool                         `mapstructure:"strip_prefix"`
+	Targets        []string                     `mapstructure:"targets"`
+	Timeout        int                          `mapstructure:"timeout"` // seconds
+	HealthCheck    UpstreamHealthCheckConfig    `mapstructure:"health_check"`
+	CircuitBreaker UpstreamCircuitBreakerConfig `mapstructure:"circuit_breaker"`
+}
+
+// UpstreamHealthCheckConfig contains active health check settings for upstream targets
+type UpstreamHealthCheckConfig struct {
+	Path               string `mapstructure:"path"` // empty disables health checks
+	Interval           int    `mapstructure:"interval"` // seconds
+	Timeout            int    `mapstructure:"timeout"`  // seconds
+	UnhealthyThreshold int    `mapstructure:"unhealthy_threshold"`
+	HealthyThreshold   int    `mapstructure:"healthy_threshold"`
+}
+
+// UpstreamCircuitBreakerConfig contains circuit breaker settings for upstream targets
+type UpstreamCircuitBreakerConfig struct {
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	OpenTimeout      int `mapstructure:"open_timeout"` // seconds
+}
+
 // BlockchainConfig contains blockchain node settings
 type BlockchainConfig struct {
 	Bitcoin  BlockchainNodeConfig  `mapstructure:"bitcoin"`
@@ -109,6 +321,7 @@ type SecurityConfig struct {
 	MFA       MFAConfig       `mapstructure:"mfa"`
 	Session   SessionConfig   `mapstructure:"session"`
 	HSM       HSMConfig       `mapstructure:"hsm"`
+	APIKeys   APIKeyConfig    `mapstructure:"api_keys"`
 }
 
 // JWTConfig contains JWT authentication settings
@@ -140,9 +353,11 @@ type MFAConfig struct {
 
 // SessionConfig contains session management settings
 type SessionConfig struct {
-	IdleTimeout      int `mapstructure:"idle_timeout"`
-	AbsoluteTimeout  int `mapstructure:"absolute_timeout"`
-	ConcurrentLimit  int `mapstructure:"concurrent_limit"`
+	Enabled          bool `mapstructure:"enabled"`
+	AccessTokenTTL   int  `mapstructure:"access_token_ttl"` // minutes
+	IdleTimeout      int  `mapstructure:"idle_timeout"`     // minutes
+	AbsoluteTimeout  int  `mapstructure:"absolute_timeout"` // minutes
+	ConcurrentLimit  int  `mapstructure:"concurrent_limit"`
 }
 
 // HSMConfig contains Hardware Security Module settings
@@ -154,12 +369,25 @@ type HSMConfig struct {
 	KeyType     string `mapstructure:"key_type"`
 }
 
+// APIKeyConfig contains settings for API keys issued to machine clients
+type APIKeyConfig struct {
+	Enabled            bool `mapstructure:"enabled"`
+	DefaultRateLimit   int  `mapstructure:"default_rate_limit"`   // requests per minute
+	DefaultDailyQuota  int  `mapstructure:"default_daily_quota"`  // requests per UTC day
+	DefaultGracePeriod int  `mapstructure:"default_grace_period"` // hours an old key stays valid after rotation
+}
+
 // LoggingConfig contains logging settings
 type LoggingConfig struct {
 	Level   string `mapstructure:"level"`
 	Format  string `mapstructure:"format"`
 	Output  string `mapstructure:"output"`
 	Path    string `mapstructure:"path"`
+
+	// Repeated entries are sampled per second: the first SamplingInitial
+	// are logged, then every SamplingThereafter-th. Zero keeps the default.
+	SamplingInitial    int `mapstructure:"sampling_initial"`
+	SamplingThereafter int `mapstructure:"sampling_thereafter"`
 }
 
 // MonitoringConfig contains monitoring settings
@@ -175,6 +403,7 @@ type MonitoringConfig struct {
 type ConfigLoader struct {
 	configPath string
 	vip        *viper.Viper
+	loaded     bool
 }
 
 // NewConfigLoader creates a new configuration loader
@@ -208,9 +437,35 @@ func (cl *ConfigLoader) Load() (*Config, error) {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
 
+	cl.loaded = true
 	return &cfg, nil
 }
 
+// Watch calls onChange with the reloaded configuration whenever the config file
+// changes. An invalid file is reported as an error and leaves the previous
+// configuration in effect. Load must have succeeded first.
+func (cl *ConfigLoader) Watch(onChange func(*Config, error)) error {
+	if !cl.loaded {
+		return fmt.Errorf("config file %s has not been loaded", cl.configPath)
+	}
+
+	cl.vip.OnConfigChange(func(fsnotify.Event) {
+		var cfg Config
+		if err := cl.vip.Unmarshal(&cfg); err != nil {
+			onChange(nil, fmt.Errorf("failed to unmarshal config: %w", err))
+			return
+		}
+		if err := cfg.Validate(); err != nil {
+			onChange(nil, fmt.Errorf("config validation failed: %w", err))
+			return
+		}
+		onChange(&cfg, nil)
+	})
+	cl.vip.WatchConfig()
+
+	return nil
+}
+
 // LoadFromBytes loads configuration from a byte slice
 func (cl *ConfigLoader) LoadFromBytes(data []byte) (*Config, error) {
 	cl.vip.SetConfigType("yaml")
@@ -241,6 +496,103 @@ func (c *Config) Validate() error {
 	if c.Security.JWT.Secret == "" {
 		return fmt.Errorf("JWT secret is required")
 	}
+	if err := c.Routing.Validate(); err != nil {
+		return err
+	}
+	if err := c.RateLimit.Validate(); err != nil {
+		return err
+	}
+	if err := c.SLO.Validate(); err != nil {
+		return err
+	}
+	if c.Compliance.TransactionImport.Enabled && c.Compliance.TransactionImport.ScreeningURL == "" {
+		return fmt.Errorf("transaction import screening URL is required")
+	}
+	return nil
+}
+
+// Validate validates the rate limit configuration
+func (c *RateLimitConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if _, ok := c.Groups["default"]; !ok {
+		return fmt.Errorf("rate limit group \"default\" is required")
+	}
+	for name, rule := range c.Groups {
+		if rule.RequestsPerSecond <= 0 {
+			return fmt.Errorf("rate limit group %s: requests_per_second must be positive", name)
+		}
+		if rule.Burst < 1 {
+			return fmt.Errorf("rate limit group %s: burst must be at least 1", name)
+		}
+	}
+	return nil
+}
+
+// Validate validates the SLO configuration
+func (c *SLOConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	for name, objective := range c.Groups {
+		if objective.Availability < 0 || objective.Availability >= 100 {
+			return fmt.Errorf("slo group %s: availability must be between 0 and 100", name)
+		}
+		if objective.LatencyTarget < 0 || objective.LatencyTarget >= 100 {
+			return fmt.Errorf("slo group %s: latency_target must be between 0 and 100", name)
+		}
+		if objective.LatencyTarget > 0 && objective.LatencyThreshold <= 0 {
+			return fmt.Errorf("slo group %s: latency_threshold must be positive", name)
+		}
+	}
+	return nil
+}
+
+// GetRule returns the rule for a route group, or the default rule if the group
+// has none
+func (c *RateLimitConfig) GetRule(group string) RateLimitRule {
+	if rule, ok := c.Groups[group]; ok {
+		return rule
+	}
+	return c.Groups["default"]
+}
+
+// Validate validates the upstream routing configuration
+func (c *RoutingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	names := make(map[string]bool, len(c.Upstreams))
+	prefixes := make(map[string]bool, len(c.Upstreams))
+	for _, upstream := range c.Upstreams {
+		if upstream.Name == "" {
+			return fmt.Errorf("upstream name is required")
+		}
+		if names[upstream.Name] {
+			return fmt.Errorf("duplicate upstream %s", upstream.Name)
+		}
+		names[upstream.Name] = true
+
+		if !strings.HasPrefix(upstream.PathPrefix, "/") {
+			return fmt.Errorf("upstream %s path prefix must start with /", upstream.Name)
+		}
+		if prefixes[upstream.PathPrefix] {
+			return fmt.Errorf("upstream %s path prefix %s is already routed", upstream.Name, upstream.PathPrefix)
+		}
+		prefixes[upstream.PathPrefix] = true
+
+		if len(upstream.Targets) == 0 {
+			return fmt.Errorf("upstream %s has no targets", upstream.Name)
+		}
+		for _, target := range upstream.Targets {
+			u, err := url.Parse(target)
+			if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+				return fmt.Errorf("upstream %s has invalid target %q", upstream.Name, target)
+			}
+		}
+	}
 	return nil
 }
 
@@ -259,11 +611,230 @@ func (c *ServerConfig) GetIdleTimeout() time.Duration {
 	return time.Duration(c.IdleTimeout) * time.Second
 }
 
+// GetRetryBackoff returns the delay between violation handling retries as a duration
+func (c *ViolationConsumerConfig) GetRetryBackoff() time.Duration {
+	return time.Duration(c.RetryBackoff) * time.Millisecond
+}
+
+// GetRetryBackoff returns the delay before refetching emergency events after an error
+func (c *EmergencyConfig) GetRetryBackoff() time.Duration {
+	return time.Duration(c.RetryBackoff) * time.Millisecond
+}
+
+// GetDepthRefreshInterval returns how often dead-letter depth metrics are refreshed
+func (c *DeadLetterConfig) GetDepthRefreshInterval() time.Duration {
+	if c.DepthRefreshInterval <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.DepthRefreshInterval) * time.Second
+}
+
+// GetMaxUploadBytes returns the largest transaction upload accepted, in bytes
+func (c *TransactionImportConfig) GetMaxUploadBytes() int64 {
+	if c.MaxUploadSizeMB <= 0 {
+		return 100 << 20
+	}
+	return int64(c.MaxUploadSizeMB) << 20
+}
+
+// GetMaxRows returns the number of rows allowed in one upload
+func (c *TransactionImportConfig) GetMaxRows() int {
+	if c.MaxRows <= 0 {
+		return 1000000
+	}
+	return c.MaxRows
+}
+
+// GetMaxRowErrors returns the number of row errors kept per import
+func (c *TransactionImportConfig) GetMaxRowErrors() int {
+	if c.MaxRowErrors <= 0 {
+		return 10000
+	}
+	return c.MaxRowErrors
+}
+
+// GetBatchSize returns the number of rows stored or checked at a time
+func (c *TransactionImportConfig) GetBatchSize() int {
+	switch {
+	case c.BatchSize <= 0:
+		return 500
+	case c.BatchSize > 2000:
+		return 2000
+	}
+	return c.BatchSize
+}
+
+// GetWorkers returns the number of concurrent check workers
+func (c *TransactionImportConfig) GetWorkers() int {
+	if c.Workers <= 0 {
+		return 4
+	}
+	return c.Workers
+}
+
+// GetMaxCheckAttempts returns the attempts before a row's checks are reported as failed
+func (c *TransactionImportConfig) GetMaxCheckAttempts() int {
+	if c.MaxCheckAttempts <= 0 {
+		return 3
+	}
+	return c.MaxCheckAttempts
+}
+
+// GetCheckLease returns how long a claimed row is left to a worker as a duration
+func (c *TransactionImportConfig) GetCheckLease() time.Duration {
+	if c.CheckLease <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.CheckLease) * time.Second
+}
+
+// GetPollInterval returns how often check workers look for pending imports
+func (c *TransactionImportConfig) GetPollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.PollInterval) * time.Second
+}
+
+// GetScreeningTimeout returns the sanctions screening request timeout as a duration
+func (c *TransactionImportConfig) GetScreeningTimeout() time.Duration {
+	if c.ScreeningTimeout <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.ScreeningTimeout) * time.Second
+}
+
+// GetScreeningStaleTTL returns how long a previous screening result may stand
+// in while the screening service is unavailable
+func (c *TransactionImportConfig) GetScreeningStaleTTL() time.Duration {
+	if c.ScreeningStaleTTL <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.ScreeningStaleTTL) * time.Second
+}
+
+// GetRetryBackoff returns the delay before retrying to queue a webhook event as a duration
+func (c *WebhookConfig) GetRetryBackoff() time.Duration {
+	return time.Duration(c.RetryBackoff) * time.Millisecond
+}
+
+// GetWorkers returns the number of concurrent webhook delivery workers
+func (c *WebhookConfig) GetWorkers() int {
+	if c.Workers <= 0 {
+		return 2
+	}
+	return c.Workers
+}
+
+// GetBatchSize returns the number of deliveries claimed at a time
+func (c *WebhookConfig) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return 50
+	}
+	return c.BatchSize
+}
+
+// GetMaxAttempts returns the attempts before a delivery is marked failed
+func (c *WebhookConfig) GetMaxAttempts() int {
+	if c.MaxAttempts <= 0 {
+		return 8
+	}
+	return c.MaxAttempts
+}
+
+// GetInitialBackoff returns the delay before the first delivery retry as a duration
+func (c *WebhookConfig) GetInitialBackoff() time.Duration {
+	if c.InitialBackoff <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.InitialBackoff) * time.Second
+}
+
+// GetMaxBackoff returns the longest delay between delivery retries as a duration
+func (c *WebhookConfig) GetMaxBackoff() time.Duration {
+	if c.MaxBackoff <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.MaxBackoff) * time.Second
+}
+
+// GetLease returns how long a claimed delivery is left to a worker as a duration
+func (c *WebhookConfig) GetLease() time.Duration {
+	if c.Lease <= 0 {
+		return 2 * time.Minute
+	}
+	return time.Duration(c.Lease) * time.Second
+}
+
+// GetPollInterval returns how often delivery workers look for due retries
+func (c *WebhookConfig) GetPollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return 15 * time.Second
+	}
+	return time.Duration(c.PollInterval) * time.Second
+}
+
+// GetTimeout returns the webhook delivery request timeout as a duration
+func (c *WebhookConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// GetTimeout returns the per-request upstream timeout as a duration
+func (c *UpstreamConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// GetInterval returns the health check interval as a duration
+func (c *UpstreamHealthCheckConfig) GetInterval() time.Duration {
+	if c.Interval <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.Interval) * time.Second
+}
+
+// GetTimeout returns the health check timeout as a duration
+func (c *UpstreamHealthCheckConfig) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 2 * time.Second
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// GetOpenTimeout returns how long a tripped circuit stays open as a duration
+func (c *UpstreamCircuitBreakerConfig) GetOpenTimeout() time.Duration {
+	if c.OpenTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.OpenTimeout) * time.Second
+}
+
 // GetConnMaxLifetime returns the connection max lifetime as a duration
 func (c *DatabaseConfig) GetConnMaxLifetime() time.Duration {
 	return time.Duration(c.ConnMaxLifetime) * time.Second
 }
 
+// GetMaxReplicaLag returns how far a read replica may lag and still serve reads
+func (c *DatabaseConfig) GetMaxReplicaLag() time.Duration {
+	if c.MaxReplicaLag <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.MaxReplicaLag) * time.Second
+}
+
+// GetReplicaCheckInterval returns how often read replica lag is measured
+func (c *DatabaseConfig) GetReplicaCheckInterval() time.Duration {
+	if c.ReplicaCheckInterval <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.ReplicaCheckInterval) * time.Second
+}
+
 // GetDSN returns the PostgreSQL connection string
 func (c *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf(
@@ -276,3 +847,157 @@ func (c *DatabaseConfig) GetDSN() string {
 func (c *RedisConfig) GetRedisAddr() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
+
+// GetQueueSize returns how many messages are held while Kafka is unavailable
+func (c *KafkaConfig) GetQueueSize() int {
+	if c.QueueSize <= 0 {
+		return 10000
+	}
+	return c.QueueSize
+}
+
+// GetTTL returns how long a cached entity is served before it is reloaded
+func (c *CacheConfig) GetTTL() time.Duration {
+	if c.TTL <= 0 {
+		return time.Minute
+	}
+	return time.Duration(c.TTL) * time.Second
+}
+
+// GetInitialBackoff returns the ceiling of the first downstream retry delay as a duration
+func (c *DownstreamConfig) GetInitialBackoff() time.Duration {
+	return time.Duration(c.InitialBackoff) * time.Millisecond
+}
+
+// GetMaxBackoff returns the ceiling of any downstream retry delay as a duration
+func (c *DownstreamConfig) GetMaxBackoff() time.Duration {
+	return time.Duration(c.MaxBackoff) * time.Millisecond
+}
+
+// GetOpenTimeout returns how long a tripped downstream circuit stays open as a duration
+func (c *DownstreamConfig) GetOpenTimeout() time.Duration {
+	return time.Duration(c.OpenTimeout) * time.Second
+}
+
+// GetMaxWait returns how long required dependencies may take to connect
+func (c *StartupConfig) GetMaxWait() time.Duration {
+	return time.Duration(c.MaxWait) * time.Second
+}
+
+// GetInitialBackoff returns the delay after a dependency's first failed attempt
+func (c *StartupConfig) GetInitialBackoff() time.Duration {
+	return time.Duration(c.InitialBackoff) * time.Second
+}
+
+// GetMaxBackoff returns the longest delay between dependency attempts
+func (c *StartupConfig) GetMaxBackoff() time.Duration {
+	return time.Duration(c.MaxBackoff) * time.Second
+}
+
+// GetCheckTimeout returns the timeout of each dependency readiness check
+func (c *StartupConfig) GetCheckTimeout() time.Duration {
+	return time.Duration(c.CheckTimeout) * time.Second
+}
+
+// GetDefaultGracePeriod returns how long a rotated key stays valid when the
+// rotation request does not specify a grace period
+func (c *APIKeyConfig) GetDefaultGracePeriod() time.Duration {
+	if c.DefaultGracePeriod < 0 {
+		return 0
+	}
+	if c.DefaultGracePeriod == 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.DefaultGracePeriod) * time.Hour
+}
+
+// GetAccessTokenTTL returns how long a session's access tokens are valid
+func (c *SessionConfig) GetAccessTokenTTL() time.Duration {
+	if c.AccessTokenTTL <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.AccessTokenTTL) * time.Minute
+}
+
+// GetIdleTimeout returns how long a session may go unused before it expires
+func (c *SessionConfig) GetIdleTimeout() time.Duration {
+	if c.IdleTimeout <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(c.IdleTimeout) * time.Minute
+}
+
+// GetAbsoluteTimeout returns how long a session lasts however often it is used
+func (c *SessionConfig) GetAbsoluteTimeout() time.Duration {
+	if c.AbsoluteTimeout <= 0 {
+		return 8 * time.Hour
+	}
+	return time.Duration(c.AbsoluteTimeout) * time.Minute
+}
+
+// GetRefreshInterval returns how often feature flags and maintenance mode are
+// reloaded from the database
+func (c *AdminConfig) GetRefreshInterval() time.Duration {
+	if c.RefreshInterval <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.RefreshInterval) * time.Second
+}
+
+// GetWindow returns the period each SLO error budget covers
+func (c *SLOConfig) GetWindow() time.Duration {
+	if c.Window <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.Window) * 24 * time.Hour
+}
+
+// GetEvaluationInterval returns how often SLO objectives are checked for alerts
+func (c *SLOConfig) GetEvaluationInterval() time.Duration {
+	if c.EvaluationInterval <= 0 {
+		return time.Minute
+	}
+	return time.Duration(c.EvaluationInterval) * time.Second
+}
+
+// GetLatencyThreshold returns the duration a request must be answered within
+// to count towards the latency objective
+func (c *SLOObjective) GetLatencyThreshold() time.Duration {
+	return time.Duration(c.LatencyThreshold) * time.Millisecond
+}
+
+// GetComplexityLimit returns the highest estimated cost of a GraphQL query
+func (c *GraphQLConfig) GetComplexityLimit() int {
+	if c.ComplexityLimit <= 0 {
+		return 500
+	}
+	return c.ComplexityLimit
+}
+
+// GetBatchWait returns how long GraphQL loaders collect lookups into a batch
+func (c *GraphQLConfig) GetBatchWait() time.Duration {
+	if c.BatchWait <= 0 {
+		return 2 * time.Millisecond
+	}
+	return time.Duration(c.BatchWait) * time.Millisecond
+}
+
+// GetMaxBatch returns the most IDs a GraphQL loader fetches at once; list
+// filters accept at most 50 values
+func (c *GraphQLConfig) GetMaxBatch() int {
+	if c.MaxBatch <= 0 || c.MaxBatch > 50 {
+		return 50
+	}
+	return c.MaxBatch
+}
+
+// Fingerprint returns a short hash of the configuration, so operators can tell
+// whether instances run the same settings without the settings being exposed
+func (c *Config) Fingerprint() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
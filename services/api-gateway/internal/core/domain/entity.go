@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -47,8 +50,42 @@ type Exchange struct {
 	RegistrationDate string `json:"registration_date"`
 	LastAudit        string `json:"last_audit,omitempty"`
 	NextAudit        string `json:"next_audit,omitempty"`
+	Version          int    `json:"version"`
 }
 
+// ExchangeUpdate holds the exchange fields a client may change. Nil fields
+// are left as they are.
+type ExchangeUpdate struct {
+	Name            *string `json:"name,omitempty"`
+	ComplianceScore *int    `json:"compliance_score,omitempty"`
+	RiskLevel       *string `json:"risk_level,omitempty"`
+	LastAudit       *string `json:"last_audit,omitempty"`
+}
+
+// Apply copies the set fields of the update onto the exchange
+func (u ExchangeUpdate) Apply(e *Exchange) {
+	if u.Name != nil {
+		e.Name = *u.Name
+	}
+	if u.ComplianceScore != nil {
+		e.ComplianceScore = *u.ComplianceScore
+	}
+	if u.RiskLevel != nil {
+		e.RiskLevel = *u.RiskLevel
+	}
+	if u.LastAudit != nil {
+		e.LastAudit = *u.LastAudit
+	}
+}
+
+var (
+	// ErrExchangeNotFound is returned when no exchange has the requested ID
+	ErrExchangeNotFound = errors.New("exchange not found")
+	// ErrVersionConflict is returned when a resource was modified after the
+	// version the caller read
+	ErrVersionConflict = errors.New("resource was modified by another request")
+)
+
 // ExchangeStatus represents the possible statuses of an exchange
 type ExchangeStatus string
 
@@ -142,6 +179,337 @@ const (
 	AlertStatusAcknowledged AlertStatus = "ACKNOWLEDGED"
 	AlertStatusResolved     AlertStatus = "RESOLVED"
 	AlertStatusDismissed    AlertStatus = "DISMISSED"
+)
+
+// Alert categories and evidence types used for compliance violation alerts
+const (
+	AlertCategoryComplianceViolation = "COMPLIANCE_VIOLATION"
+
+	EvidenceTypeViolation   = "compliance_violation"
+	EvidenceTypeWallet      = "wallet"
+	EvidenceTypeExchange    = "exchange"
+	EvidenceTypeFreezeOrder = "freeze_order"
+)
+
+// ErrInvalidViolationEvent is returned when a violation event is missing required fields
+var ErrInvalidViolationEvent = errors.New("invalid compliance violation event")
+
+// ComplianceViolationEvent represents a violation published by the compliance service
+type ComplianceViolationEvent struct {
+	EventType       string                 `json:"event_type"`
+	ViolationID     string                 `json:"violation_id"`
+	ViolationNumber string                 `json:"violation_number"`
+	ViolationType   string                 `json:"violation_type"`
+	Severity        string                 `json:"severity"`
+	Status          string                 `json:"status"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	EntityID        string                 `json:"entity_id"`
+	EntityName      string                 `json:"entity_name"`
+	EntityType      string                 `json:"entity_type"`
+	LicenseID       string                 `json:"license_id,omitempty"`
+	ExchangeID      string                 `json:"exchange_id,omitempty"`
+	WalletAddresses []string               `json:"wallet_addresses,omitempty"`
+	DetectionSource string                 `json:"detection_source,omitempty"`
+	RegulatoryRef   string                 `json:"regulatory_ref,omitempty"`
+	DetectedAt      string                 `json:"detected_at"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Validate checks that the event carries the fields needed to raise an alert
+func (e *ComplianceViolationEvent) Validate() error {
+	if e.ViolationID == "" {
+		return fmt.Errorf("%w: violation_id is required", ErrInvalidViolationEvent)
+	}
+	if e.Severity == "" {
+		return fmt.Errorf("%w: severity is required", ErrInvalidViolationEvent)
+	}
+	if e.Title == "" && e.ViolationType == "" {
+		return fmt.Errorf("%w: title or violation_type is required", ErrInvalidViolationEvent)
+	}
+	return nil
+}
+
+// LinkedExchangeID returns the exchange the violation concerns, if any
+func (e *ComplianceViolationEvent) LinkedExchangeID() string {
+	if e.ExchangeID != "" {
+		return e.ExchangeID
+	}
+	if strings.EqualFold(e.EntityType, "EXCHANGE") {
+		return e.EntityID
+	}
+	return ""
+}
+
+// AlertSeverity maps the compliance severity scale onto alert severities
+func (e *ComplianceViolationEvent) AlertSeverity() AlertSeverity {
+	switch strings.ToUpper(e.Severity) {
+	case "CRITICAL":
+		return AlertSeverityCritical
+	case "MAJOR", "MODERATE":
+		return AlertSeverityWarning
+	default:
+		return AlertSeverityInfo
+	}
+}
+
+// Emergency stop scopes published by the control layer
+const (
+	EmergencyScopeGlobal      = "GLOBAL"
+	EmergencyScopeRegion      = "REGION"
+	EmergencyScopeExchange    = "EXCHANGE"
+	EmergencyScopeTransaction = "TRANSACTION"
+)
+
+// Emergency event types published by the control layer
+const (
+	EmergencyEventHalt   = "halt"
+	EmergencyEventResume = "resume"
+)
+
+// ErrInvalidEmergencyEvent is returned when an emergency event is missing required fields
+var ErrInvalidEmergencyEvent = errors.New("invalid emergency event")
+
+// EmergencyStop represents an emergency halt issued by the control layer
+type EmergencyStop struct {
+	ID          string     `json:"id"`
+	Scope       string     `json:"scope"`
+	Target      string     `json:"target,omitempty"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	InitiatedBy string     `json:"initiated_by"`
+	ResumeAt    *time.Time `json:"resume_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// EmergencyEvent is broadcast by the control layer when an emergency stop is
+// issued or lifted
+type EmergencyEvent struct {
+	EventID   string         `json:"event_id"`
+	Type      string         `json:"type"`
+	Stop      *EmergencyStop `json:"stop"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// Validate checks that the event identifies a stop and what it halts
+func (e *EmergencyEvent) Validate() error {
+	if e.Type != EmergencyEventHalt && e.Type != EmergencyEventResume {
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidEmergencyEvent, e.Type)
+	}
+	if e.Stop == nil || e.Stop.ID == "" {
+		return fmt.Errorf("%w: stop id is required", ErrInvalidEmergencyEvent)
+	}
+	switch e.Stop.Scope {
+	case EmergencyScopeGlobal, EmergencyScopeTransaction:
+	case EmergencyScopeRegion, EmergencyScopeExchange:
+		if e.Stop.Target == "" {
+			return fmt.Errorf("%w: %s stop requires a target", ErrInvalidEmergencyEvent, e.Stop.Scope)
+		}
+	default:
+		return fmt.Errorf("%w: unknown scope %q", ErrInvalidEmergencyEvent, e.Stop.Scope)
+	}
+	return nil
+}
+
+// HaltCheck describes a request checked against active emergency stops
+type HaltCheck struct {
+	Method     string
+	Path       string
+	ExchangeID string
+	Region     string
+}
+
+// DeadLetter represents a message a consumer gave up on, kept for inspection
+// and reprocessing. The same message is published to the dead-letter topic.
+type DeadLetter struct {
+	BaseEntity
+	Topic         string            `json:"topic"`
+	OriginalTopic string            `json:"original_topic"`
+	Partition     int               `json:"partition"`
+	Offset        int64             `json:"offset"`
+	ConsumerGroup string            `json:"consumer_group"`
+	MessageKey    string            `json:"message_key,omitempty"`
+	Payload       string            `json:"payload"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	Error         string            `json:"error"`
+	Attempts      int               `json:"attempts"`
+	Status        string            `json:"status"`
+	FailedAt      time.Time         `json:"failed_at"`
+	ResolvedBy    string            `json:"resolved_by,omitempty"`
+	ResolvedAt    string            `json:"resolved_at,omitempty"`
+}
+
+// DeadLetterStatus represents the possible statuses of a dead letter
+type DeadLetterStatus string
+
+const (
+	DeadLetterStatusPending     DeadLetterStatus = "PENDING"
+	DeadLetterStatusReprocessed DeadLetterStatus = "REPROCESSED"
+	DeadLetterStatusDiscarded   DeadLetterStatus = "DISCARDED"
+)
+
+// Headers attached to dead-lettered messages describing the failure
+const (
+	DeadLetterHeaderOriginalTopic     = "dlq-original-topic"
+	DeadLetterHeaderOriginalPartition = "dlq-original-partition"
+	DeadLetterHeaderOriginalOffset    = "dlq-original-offset"
+	DeadLetterHeaderConsumerGroup     = "dlq-consumer-group"
+	DeadLetterHeaderError             = "dlq-error"
+	DeadLetterHeaderAttempts          = "dlq-attempts"
+	DeadLetterHeaderFailedAt          = "dlq-failed-at"
+	DeadLetterHeaderReprocessedFrom   = "dlq-reprocessed-from"
+)
+
+// ErrDeadLetterNotPending is returned when a dead letter was already reprocessed or discarded
+var ErrDeadLetterNotPending = errors.New("dead letter is not pending")
+
+// DeadLetterTopic returns the dead-letter topic for a topic
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// ClientAPIKey represents a credential issued to a machine client, such as an
+// exchange submitting reports. Only a hash of the key is stored.
+type ClientAPIKey struct {
+	BaseEntity
+	Name        string     `json:"name"`
+	ClientID    string     `json:"client_id"`
+	KeyPrefix   string     `json:"key_prefix"`
+	KeyHash     string     `json:"-"`
+	Scopes      []string   `json:"scopes"`
+	RateLimit   int        `json:"rate_limit"`  // requests per minute, 0 for unlimited
+	DailyQuota  int        `json:"daily_quota"` // requests per UTC day, 0 for unlimited
+	Status      string     `json:"status"`
+	CreatedBy   string     `json:"created_by"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	GraceEndsAt *time.Time `json:"grace_ends_at,omitempty"`
+	ReplacedBy  string     `json:"replaced_by,omitempty"`
+	RevokedBy   string     `json:"revoked_by,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyStatus represents the possible statuses of an API key
+type APIKeyStatus string
+
+const (
+	APIKeyStatusActive  APIKeyStatus = "ACTIVE"
+	APIKeyStatusRotated APIKeyStatus = "ROTATED" // replaced, accepted until the grace period ends
+	APIKeyStatusRevoked APIKeyStatus = "REVOKED"
+)
+
+// Scopes that can be granted to API keys
+const (
+	APIScopeAlertsRead        = "alerts:read"
+	APIScopeExchangesRead     = "exchanges:read"
+	APIScopeWalletsRead       = "wallets:read"
+	APIScopeMinersRead        = "miners:read"
+	APIScopeComplianceRead    = "compliance:read"
+	APIScopeComplianceWrite   = "compliance:write"
+	APIScopeAuditRead         = "audit:read"
+	APIScopeBlockchainRead    = "blockchain:read"
+	APIScopeTransactionsRead  = "transactions:read"
+	APIScopeTransactionsWrite = "transactions:write"
+)
+
+// APIScopes lists every scope that can be granted to an API key
+var APIScopes = []string{
+	APIScopeAlertsRead,
+	APIScopeExchangesRead,
+	APIScopeWalletsRead,
+	APIScopeMinersRead,
+	APIScopeComplianceRead,
+	APIScopeComplianceWrite,
+	APIScopeAuditRead,
+	APIScopeBlockchainRead,
+	APIScopeTransactionsRead,
+	APIScopeTransactionsWrite,
+}
+
+// API key errors
+var (
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrAPIKeyExpired      = errors.New("API key has expired")
+	ErrAPIKeyNotActive    = errors.New("API key is not active")
+	ErrInvalidAPIScope    = errors.New("invalid API key scope")
+	ErrInvalidAPIKeySpec  = errors.New("invalid API key settings")
+	ErrRateLimitExceeded  = errors.New("API key rate limit exceeded")
+	ErrDailyQuotaExceeded = errors.New("API key daily quota exceeded")
+)
+
+// HasScope reports whether the key has been granted the scope
+func (k *ClientAPIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Usable reports whether the key can authenticate requests at the given time
+func (k *ClientAPIKey) Usable(now time.Time) error {
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return ErrAPIKeyExpired
+	}
+
+	switch APIKeyStatus(k.Status) {
+	case APIKeyStatusActive:
+		return nil
+	case APIKeyStatusRotated:
+		if k.GraceEndsAt != nil && now.Before(*k.GraceEndsAt) {
+			return nil
+		}
+		return ErrAPIKeyExpired
+	default:
+		return ErrAPIKeyNotActive
+	}
+}
+
+// ValidateAPIScopes checks that every scope is known
+func ValidateAPIScopes(scopes []string) error {
+	for _, scope := range scopes {
+		known := false
+		for _, s := range APIScopes {
+			if s == scope {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %s", ErrInvalidAPIScope, scope)
+		}
+	}
+	return nil
+}
+
+// APIKeyUpdate holds the API key settings to change; nil fields are left as is
+type APIKeyUpdate struct {
+	Name       *string  `json:"name"`
+	Scopes     []string `json:"scopes"`
+	RateLimit  *int     `json:"rate_limit"`
+	DailyQuota *int     `json:"daily_quota"`
+}
+
+// APIKeyUsage represents an API key's consumption of its rate limit and daily quota
+type APIKeyUsage struct {
+	KeyID          string `json:"key_id"`
+	MinuteRequests int64  `json:"minute_requests"`
+	RateLimit      int    `json:"rate_limit"`
+	DailyRequests  int64  `json:"daily_requests"`
+	DailyQuota     int    `json:"daily_quota"`
+	QuotaResetsAt  string `json:"quota_resets_at"`
+}
+
+// RemainingQuota returns how many requests the key may still make today, or -1 if unlimited
+func (u *APIKeyUsage) RemainingQuota() int64 {
+	if u.DailyQuota <= 0 {
+		return -1
+	}
+	if remaining := int64(u.DailyQuota) - u.DailyRequests; remaining > 0 {
+		return remaining
+	}
+	return 0
 }
 
 // ComplianceReport represents a compliance report entity
@@ -153,6 +521,7 @@ type ComplianceReport struct {
 	Period      string `json:"period"`
 	Status      string `json:"status"`
 	Score       int    `json:"score"`
+	Locale      string `json:"locale"`
 	GeneratedAt string `json:"generated_at"`
 }
 
@@ -167,6 +536,7 @@ type AuditLog struct {
 	Details       string `json:"details"`
 	IPAddress     string `json:"ip_address"`
 	Status        string `json:"status"`
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 // User represents a user entity
@@ -267,6 +637,7 @@ type PaginatedResponse struct {
 	PageSize   int         `json:"page_size"`
 	Total      int64       `json:"total"`
 	TotalPages int         `json:"total_pages"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 // NewPaginatedResponse creates a new paginated response
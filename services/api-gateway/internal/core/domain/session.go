@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Session is a signed-in user on one device. Access tokens name the session
+// they were issued for, so deleting the session revokes them before they
+// expire. The session lives until it is idle for too long, reaches its
+// absolute lifetime or is revoked.
+type Session struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username"`
+	Role        string    `json:"role"`
+	Permissions []string  `json:"-"`
+	Device      string    `json:"device"`
+	UserAgent   string    `json:"user_agent"`
+	IPAddress   string    `json:"ip_address"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Current     bool      `json:"current"`
+}
+
+// SessionTokens are the tokens issued on sign-in and on each refresh. The
+// refresh token can be used once; every refresh returns a new one.
+type SessionTokens struct {
+	SessionID    string `json:"session_id"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"` // seconds until the access token expires
+}
+
+// SessionClient describes the device a session is signed in from
+type SessionClient struct {
+	Device    string
+	UserAgent string
+	IPAddress string
+}
+
+// Session errors
+var (
+	ErrInvalidCredentials  = errors.New("invalid username or password")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+)
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// SLO objectives a route group can be measured against
+const (
+	// SLOObjectiveAvailability counts requests answered with a 5xx as bad
+	SLOObjectiveAvailability = "availability"
+	// SLOObjectiveLatency counts requests slower than the group's latency
+	// threshold as bad
+	SLOObjectiveLatency = "latency"
+)
+
+// SLOState summarises how much of an objective's error budget is left
+type SLOState string
+
+const (
+	// SLOStateOK means the budget is being spent at a sustainable rate
+	SLOStateOK SLOState = "OK"
+	// SLOStateBurning means the budget is being spent fast enough to run out
+	// well before the end of the window
+	SLOStateBurning SLOState = "BURNING"
+	// SLOStateExhausted means the whole budget of the window has been spent
+	SLOStateExhausted SLOState = "EXHAUSTED"
+)
+
+// Alert category and evidence type of SLO alerts
+const (
+	AlertCategorySLO = "SLO_ERROR_BUDGET"
+
+	EvidenceTypeSLO = "slo"
+)
+
+// SLOTarget is an objective of a route group: the percentage of requests that
+// must be good over the SLO window
+type SLOTarget struct {
+	Group            string        `json:"group"`
+	Objective        string        `json:"objective"`
+	Target           float64       `json:"target"`
+	LatencyThreshold time.Duration `json:"-"`
+}
+
+// ErrorBudget returns the fraction of requests the target allows to be bad
+func (t SLOTarget) ErrorBudget() float64 {
+	return 1 - t.Target/100
+}
+
+// SLOBurnRate is the rate an objective spent its error budget at over a
+// lookback window; 1 spends exactly the budget over the SLO window
+type SLOBurnRate struct {
+	Window      string  `json:"window"`
+	Requests    int64   `json:"requests"`
+	BadRequests int64   `json:"bad_requests"`
+	BurnRate    float64 `json:"burn_rate"`
+}
+
+// SLOStatus reports a route group's objective over the SLO window. Requests
+// are counted by the gateway instance answering, since it started.
+type SLOStatus struct {
+	Group              string        `json:"group"`
+	Objective          string        `json:"objective"`
+	Target             float64       `json:"target"`
+	LatencyThresholdMs int64         `json:"latency_threshold_ms,omitempty"`
+	Window             string        `json:"window"`
+	Requests           int64         `json:"requests"`
+	BadRequests        int64         `json:"bad_requests"`
+	SLI                float64       `json:"sli"`
+	BudgetRemaining    float64       `json:"budget_remaining"`
+	BurnRates          []SLOBurnRate `json:"burn_rates"`
+	State              SLOState      `json:"state"`
+	Since              time.Time     `json:"since"`
+	EvaluatedAt        time.Time     `json:"evaluated_at"`
+}
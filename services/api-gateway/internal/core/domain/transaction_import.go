@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TransactionImport represents an upload of an exchange's internal transactions
+// for its daily reporting obligation. Rows are validated while the upload is
+// received; accepted rows are then run through compliance checks in the
+// background.
+type TransactionImport struct {
+	BaseEntity
+	ExchangeID   string     `json:"exchange_id"`
+	Format       string     `json:"format"`
+	Status       string     `json:"status"`
+	SubmittedBy  string     `json:"submitted_by"`
+	TotalRows    int        `json:"total_rows"`
+	AcceptedRows int        `json:"accepted_rows"`
+	RejectedRows int        `json:"rejected_rows"`
+	CheckedRows  int        `json:"checked_rows"`
+	FlaggedRows  int        `json:"flagged_rows"`
+	FailedRows   int        `json:"failed_rows"` // rows whose checks could not be completed
+	Error        string     `json:"error,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+// TransactionImportStatus represents the possible statuses of a transaction import
+type TransactionImportStatus string
+
+const (
+	TransactionImportStatusReceiving TransactionImportStatus = "RECEIVING"
+	TransactionImportStatusChecking  TransactionImportStatus = "CHECKING"
+	TransactionImportStatusCompleted TransactionImportStatus = "COMPLETED"
+	TransactionImportStatusFailed    TransactionImportStatus = "FAILED" // the upload was aborted and nothing was kept
+)
+
+// Supported transaction import formats
+const (
+	ImportFormatNDJSON = "ndjson"
+	ImportFormatCSV    = "csv"
+)
+
+// ReportedTransaction represents one transaction reported by an exchange
+type ReportedTransaction struct {
+	ID            string          `json:"id"`
+	ImportID      string          `json:"import_id"`
+	ExchangeID    string          `json:"exchange_id"`
+	RowNumber     int             `json:"row"`
+	TransactionID string          `json:"transaction_id"`
+	Type          string          `json:"type"`
+	Asset         string          `json:"asset"`
+	Amount        decimal.Decimal `json:"amount"`
+	AmountUSD     decimal.Decimal `json:"amount_usd"`
+	CustomerID    string          `json:"customer_id"`
+	FromAddress   string          `json:"from_address,omitempty"`
+	ToAddress     string          `json:"to_address,omitempty"`
+	Timestamp     time.Time       `json:"timestamp"`
+	CheckStatus   string          `json:"check_status"`
+	CheckAttempts int             `json:"check_attempts"`
+	CheckedAt     *time.Time      `json:"checked_at,omitempty"`
+}
+
+// ReportedTransactionType represents the kinds of transaction an exchange reports
+type ReportedTransactionType string
+
+const (
+	ReportedTransactionDeposit    ReportedTransactionType = "DEPOSIT"
+	ReportedTransactionWithdrawal ReportedTransactionType = "WITHDRAWAL"
+	ReportedTransactionTrade      ReportedTransactionType = "TRADE"
+	ReportedTransactionTransfer   ReportedTransactionType = "TRANSFER"
+)
+
+// CheckStatus represents the progress of a reported transaction's compliance checks
+type CheckStatus string
+
+const (
+	CheckStatusPending  CheckStatus = "PENDING"
+	CheckStatusChecking CheckStatus = "CHECKING"
+	CheckStatusPassed   CheckStatus = "PASSED"
+	CheckStatusFlagged  CheckStatus = "FLAGGED"
+	CheckStatusFailed   CheckStatus = "FAILED"
+)
+
+// ImportRowError describes a problem with one row of a transaction import,
+// found either while validating the upload or by a compliance check
+type ImportRowError struct {
+	ImportID string `json:"-"`
+	Row      int    `json:"row"`
+	Stage    string `json:"stage"`
+	Field    string `json:"field,omitempty"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// Stages at which an import row error is found
+const (
+	ImportStageValidation = "VALIDATION"
+	ImportStageCompliance = "COMPLIANCE"
+)
+
+// Import row error codes
+const (
+	ImportErrorRequired          = "REQUIRED"
+	ImportErrorInvalid           = "INVALID"
+	ImportErrorMalformedRow      = "MALFORMED_ROW"
+	ImportErrorDuplicate         = "DUPLICATE"
+	ImportErrorSanctionedAddress = "SANCTIONED_ADDRESS"
+	ImportErrorBlacklistedWallet = "BLACKLISTED_WALLET"
+	ImportErrorFrozenWallet      = "FROZEN_WALLET"
+	ImportErrorLargeTransaction  = "LARGE_TRANSACTION"
+	ImportErrorCheckFailed       = "CHECK_FAILED"
+)
+
+var (
+	// ErrTransactionImportNotFound is returned when no import of the exchange has the requested ID
+	ErrTransactionImportNotFound = errors.New("transaction import not found")
+	// ErrUnsupportedImportFormat is returned for uploads that are neither NDJSON nor CSV
+	ErrUnsupportedImportFormat = errors.New("unsupported transaction import format")
+	// ErrInvalidImport is returned when an upload cannot be read as a whole,
+	// such as a CSV file without the required columns
+	ErrInvalidImport = errors.New("invalid transaction import")
+	// ErrImportTooLarge is returned when an upload has more rows than allowed
+	ErrImportTooLarge = errors.New("transaction import has too many rows")
+)
+
+// ReportedTransactionColumns lists the fields of a reported transaction row.
+// CSV uploads need a header naming at least the required ones.
+var ReportedTransactionColumns = []string{
+	"transaction_id", "timestamp", "type", "asset", "amount", "amount_usd",
+	"customer_id", "from_address", "to_address",
+}
+
+// RequiredTransactionColumns lists the fields every row must have
+var RequiredTransactionColumns = []string{
+	"transaction_id", "timestamp", "type", "asset", "amount", "amount_usd", "customer_id",
+}
+
+// maxFutureSkew is how far ahead of the gateway's clock a reported timestamp may be
+const maxFutureSkew = 5 * time.Minute
+
+// ParseReportedTransaction validates a row's fields and builds the reported
+// transaction. All problems with the row are returned, without row numbers.
+func ParseReportedTransaction(fields map[string]string, now time.Time) (*ReportedTransaction, []*ImportRowError) {
+	var rowErrors []*ImportRowError
+	invalid := func(field, code, message string) {
+		rowErrors = append(rowErrors, &ImportRowError{
+			Stage:   ImportStageValidation,
+			Field:   field,
+			Code:    code,
+			Message: message,
+		})
+	}
+
+	value := func(field string) string {
+		return strings.TrimSpace(fields[field])
+	}
+	for _, field := range RequiredTransactionColumns {
+		if value(field) == "" {
+			invalid(field, ImportErrorRequired, field+" is required")
+		}
+	}
+
+	tx := &ReportedTransaction{
+		TransactionID: value("transaction_id"),
+		Type:          strings.ToUpper(value("type")),
+		Asset:         strings.ToUpper(value("asset")),
+		CustomerID:    value("customer_id"),
+		FromAddress:   value("from_address"),
+		ToAddress:     value("to_address"),
+		CheckStatus:   string(CheckStatusPending),
+	}
+
+	if len(tx.TransactionID) > 128 {
+		invalid("transaction_id", ImportErrorInvalid, "transaction_id must be at most 128 characters")
+	}
+	if len(tx.Asset) > 20 {
+		invalid("asset", ImportErrorInvalid, "asset must be at most 20 characters")
+	}
+
+	if raw := value("timestamp"); raw != "" {
+		ts, err := time.Parse(time.RFC3339, raw)
+		switch {
+		case err != nil:
+			invalid("timestamp", ImportErrorInvalid, "timestamp must be an RFC 3339 date and time")
+		case ts.After(now.Add(maxFutureSkew)):
+			invalid("timestamp", ImportErrorInvalid, "timestamp is in the future")
+		default:
+			tx.Timestamp = ts.UTC()
+		}
+	}
+
+	switch ReportedTransactionType(tx.Type) {
+	case "":
+	case ReportedTransactionDeposit:
+		if tx.FromAddress == "" {
+			invalid("from_address", ImportErrorRequired, "from_address is required for deposits")
+		}
+	case ReportedTransactionWithdrawal:
+		if tx.ToAddress == "" {
+			invalid("to_address", ImportErrorRequired, "to_address is required for withdrawals")
+		}
+	case ReportedTransactionTrade, ReportedTransactionTransfer:
+	default:
+		invalid("type", ImportErrorInvalid, "type must be DEPOSIT, WITHDRAWAL, TRADE or TRANSFER")
+	}
+
+	if raw := value("amount"); raw != "" {
+		amount, err := parseAmount(raw)
+		switch {
+		case err != nil || !amount.IsPositive():
+			invalid("amount", ImportErrorInvalid, "amount must be a positive number")
+		case amount.Exponent() < -maxAmountScale:
+			invalid("amount", ImportErrorInvalid, "amount must have at most 18 decimal places")
+		}
+		tx.Amount = amount
+	}
+	if raw := value("amount_usd"); raw != "" {
+		amountUSD, err := parseAmount(raw)
+		if err != nil || amountUSD.IsNegative() {
+			invalid("amount_usd", ImportErrorInvalid, "amount_usd must be a non-negative number")
+		}
+		tx.AmountUSD = amountUSD
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+	return tx, nil
+}
+
+// maxAmountScale is the number of decimal places stored for an amount, enough
+// for the smallest unit of any supported asset (wei)
+const maxAmountScale = 18
+
+// parseAmount parses an exact decimal amount, keeping every digit given
+func parseAmount(raw string) (decimal.Decimal, error) {
+	return decimal.NewFromString(raw)
+}
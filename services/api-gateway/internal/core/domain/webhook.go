@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// WebhookEndpoint is a partner agency's URL that receives signed notifications
+// of the event types it subscribes to. The secret signs every delivery and is
+// only returned when the endpoint is registered.
+type WebhookEndpoint struct {
+	BaseEntity
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"-"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	CreatedBy  string   `json:"created_by"`
+}
+
+// WebhookEndpointUpdate holds the changes to a webhook endpoint; nil fields are left unchanged
+type WebhookEndpointUpdate struct {
+	Name       *string  `json:"name"`
+	URL        *string  `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
+
+// Webhook event types partner agencies can subscribe to
+const (
+	WebhookEventLicenseRevoked = "license.revoked"
+	WebhookEventWalletFrozen   = "wallet.frozen"
+)
+
+// WebhookEventTypes lists every webhook event type
+var WebhookEventTypes = []string{
+	WebhookEventLicenseRevoked,
+	WebhookEventWalletFrozen,
+}
+
+// WebhookEvent is the body of a webhook delivery
+type WebhookEvent struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WebhookDelivery is one event queued for, or sent to, one endpoint. Failed
+// attempts are retried with exponential backoff until the delivery succeeds
+// or runs out of attempts.
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	EndpointID     string          `json:"endpoint_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	LastDurationMs int64           `json:"last_duration_ms,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookDeliveryStatus represents the possible statuses of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySending   WebhookDeliveryStatus = "SENDING"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED" // out of attempts
+)
+
+// WebhookDeliveryResult is the outcome of one delivery attempt
+type WebhookDeliveryResult struct {
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// Succeeded reports whether the endpoint accepted the delivery
+func (r *WebhookDeliveryResult) Succeeded() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+var (
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookSpec      = errors.New("invalid webhook endpoint settings")
+	ErrInvalidWebhookEventType = errors.New("invalid webhook event type")
+	// ErrWebhookDeliveryInProgress is returned when redelivering a delivery
+	// that has not finished its current attempts
+	ErrWebhookDeliveryInProgress = errors.New("webhook delivery is still in progress")
+)
+
+// ValidateWebhookURL checks that a webhook URL is an absolute https URL.
+// Deliveries carry regulatory events, so plain http is accepted only when
+// allowHTTP is set, which is meant for development and tests.
+func ValidateWebhookURL(raw string, allowHTTP bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute https URL", ErrInvalidWebhookSpec)
+	}
+	if u.Scheme != "https" && !(allowHTTP && u.Scheme == "http") {
+		return fmt.Errorf("%w: url must use https", ErrInvalidWebhookSpec)
+	}
+	return nil
+}
+
+// ValidateWebhookEventTypes checks that every event type is known
+func ValidateWebhookEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		known := false
+		for _, t := range WebhookEventTypes {
+			if t == eventType {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookEventType, eventType)
+		}
+	}
+	return nil
+}
+
+// Subscribes reports whether the endpoint receives the event type
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	for _, t := range e.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
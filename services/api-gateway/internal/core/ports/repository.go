@@ -2,21 +2,25 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/shared/query"
 )
 
 // Repository defines the interface for data access operations
 type Repository interface {
 	// Exchange operations
-	GetExchanges(ctx context.Context, page, pageSize int) ([]*domain.Exchange, error)
+	GetExchanges(ctx context.Context, params *query.Params) ([]*domain.Exchange, *query.PageInfo, error)
 	GetExchangeByID(ctx context.Context, id string) (*domain.Exchange, error)
 	CreateExchange(ctx context.Context, exchange *domain.Exchange) error
+	// UpdateExchange fails with domain.ErrVersionConflict if the exchange has
+	// changed since it was read, and bumps its version otherwise
 	UpdateExchange(ctx context.Context, exchange *domain.Exchange) error
 	SuspendExchange(ctx context.Context, id, reason, userID string) error
 
 	// Wallet operations
-	GetWallets(ctx context.Context, page, pageSize int) ([]*domain.Wallet, error)
+	GetWallets(ctx context.Context, params *query.Params) ([]*domain.Wallet, *query.PageInfo, error)
 	GetWalletByID(ctx context.Context, id string) (*domain.Wallet, error)
 	GetWalletByAddress(ctx context.Context, address string) (*domain.Wallet, error)
 	CreateWallet(ctx context.Context, wallet *domain.Wallet) error
@@ -24,20 +28,21 @@ type Repository interface {
 	FreezeWallet(ctx context.Context, id, reason, userID string) error
 
 	// Miner operations
-	GetMiners(ctx context.Context, page, pageSize int) ([]*domain.Miner, error)
+	GetMiners(ctx context.Context, params *query.Params) ([]*domain.Miner, *query.PageInfo, error)
 	GetMinerByID(ctx context.Context, id string) (*domain.Miner, error)
 	CreateMiner(ctx context.Context, miner *domain.Miner) error
 	UpdateMiner(ctx context.Context, miner *domain.Miner) error
 
 	// Alert operations
-	GetAlerts(ctx context.Context, page, pageSize int) ([]*domain.Alert, error)
+	GetAlerts(ctx context.Context, params *query.Params) ([]*domain.Alert, *query.PageInfo, error)
 	GetAlertByID(ctx context.Context, id string) (*domain.Alert, error)
 	CreateAlert(ctx context.Context, alert *domain.Alert) error
 	UpdateAlert(ctx context.Context, alert *domain.Alert) error
 	AcknowledgeAlert(ctx context.Context, id, userID string) error
+	FindAlertByEvidence(ctx context.Context, evidenceType string, value string) (*domain.Alert, error)
 
 	// Compliance report operations
-	GetComplianceReports(ctx context.Context, page, pageSize int) ([]*domain.ComplianceReport, error)
+	GetComplianceReports(ctx context.Context, params *query.Params) ([]*domain.ComplianceReport, *query.PageInfo, error)
 	GetComplianceReportByID(ctx context.Context, id string) (*domain.ComplianceReport, error)
 	CreateComplianceReport(ctx context.Context, report *domain.ComplianceReport) error
 
@@ -45,6 +50,75 @@ type Repository interface {
 	GetAuditLogs(ctx context.Context, page, pageSize int) ([]*domain.AuditLog, error)
 	CreateAuditLog(ctx context.Context, log *domain.AuditLog) error
 
+	// Dead letter operations
+	CreateDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error
+	GetDeadLetters(ctx context.Context, status string, page, pageSize int) ([]*domain.DeadLetter, error)
+	GetDeadLetterByID(ctx context.Context, id string) (*domain.DeadLetter, error)
+	UpdateDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error
+	CountDeadLetters(ctx context.Context, status string) (int64, error)
+	CountPendingDeadLettersByTopic(ctx context.Context) (map[string]int64, error)
+
+	// API key operations
+	CreateAPIKey(ctx context.Context, key *domain.ClientAPIKey) error
+	GetAPIKeys(ctx context.Context, clientID string, page, pageSize int) ([]*domain.ClientAPIKey, error)
+	GetAPIKeyByID(ctx context.Context, id string) (*domain.ClientAPIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.ClientAPIKey, error)
+	UpdateAPIKey(ctx context.Context, key *domain.ClientAPIKey) error
+	// RotateAPIKey stores replacement and saves old in one transaction. It fails
+	// with domain.ErrAPIKeyNotActive if old is no longer active in the store.
+	RotateAPIKey(ctx context.Context, old, replacement *domain.ClientAPIKey) error
+	CountAPIKeys(ctx context.Context, clientID string) (int64, error)
+
+	// Transaction import operations
+	CreateTransactionImport(ctx context.Context, imp *domain.TransactionImport) error
+	GetTransactionImport(ctx context.Context, id string) (*domain.TransactionImport, error)
+	GetTransactionImportsByStatus(ctx context.Context, status string, limit int) ([]*domain.TransactionImport, error)
+	UpdateTransactionImport(ctx context.Context, imp *domain.TransactionImport) error
+	// CreateReportedTransactions stores transactions, skipping any the exchange
+	// has already reported, and returns the row numbers that were stored
+	CreateReportedTransactions(ctx context.Context, txs []*domain.ReportedTransaction) ([]int, error)
+	DeleteReportedTransactions(ctx context.Context, importID string) error
+	// ClaimReportedTransactions marks up to limit pending transactions of an
+	// import as being checked, reclaiming claims older than lease
+	ClaimReportedTransactions(ctx context.Context, importID string, limit int, lease time.Duration) ([]*domain.ReportedTransaction, error)
+	SaveTransactionCheck(ctx context.Context, tx *domain.ReportedTransaction, findings []*domain.ImportRowError) error
+	// SyncTransactionImportChecks refreshes a checking import's check counts and
+	// completes it once no transactions are left to check, reporting whether
+	// this call completed it
+	SyncTransactionImportChecks(ctx context.Context, importID string) (bool, error)
+	CreateImportRowErrors(ctx context.Context, rowErrors []*domain.ImportRowError) error
+	GetImportRowErrors(ctx context.Context, importID string, page, pageSize int) ([]*domain.ImportRowError, error)
+	CountImportRowErrors(ctx context.Context, importID string) (int64, error)
+
+	// Webhook operations
+	CreateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	GetWebhookEndpoints(ctx context.Context, page, pageSize int) ([]*domain.WebhookEndpoint, error)
+	GetWebhookEndpointByID(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+	// GetWebhookSubscribers returns the active endpoints subscribed to an event type
+	GetWebhookSubscribers(ctx context.Context, eventType string) ([]*domain.WebhookEndpoint, error)
+	UpdateWebhookEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	DeleteWebhookEndpoint(ctx context.Context, id string) error
+	CountWebhookEndpoints(ctx context.Context) (int64, error)
+	// CreateWebhookDeliveries queues deliveries, skipping any event already
+	// queued for the same endpoint
+	CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	// ClaimWebhookDeliveries marks up to limit due deliveries as sending,
+	// reclaiming claims older than lease
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, endpointID, status string, page, pageSize int) ([]*domain.WebhookDelivery, error)
+	GetWebhookDeliveryByID(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+	CountWebhookDeliveries(ctx context.Context, endpointID, status string) (int64, error)
+
+	// Admin console operations
+	GetFeatureFlags(ctx context.Context) ([]*domain.FeatureFlag, error)
+	GetFeatureFlag(ctx context.Context, key string) (*domain.FeatureFlag, error)
+	// SaveFeatureFlag creates the flag or replaces the one with the same key
+	SaveFeatureFlag(ctx context.Context, flag *domain.FeatureFlag) error
+	DeleteFeatureFlag(ctx context.Context, key string) error
+	GetMaintenanceMode(ctx context.Context) (*domain.MaintenanceMode, error)
+	SaveMaintenanceMode(ctx context.Context, mode *domain.MaintenanceMode) error
+
 	// User operations
 	GetUsers(ctx context.Context, page, pageSize int) ([]*domain.User, error)
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
@@ -2,8 +2,11 @@ package ports
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/shared/query"
 )
 
 // GatewayService defines the interface for gateway business logic
@@ -12,27 +15,28 @@ type GatewayService interface {
 	GetDashboardStats(ctx context.Context) (*domain.DashboardStats, error)
 
 	// Exchange operations
-	GetExchanges(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetExchanges(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error)
 	GetExchangeByID(ctx context.Context, id string) (*domain.Exchange, error)
+	UpdateExchange(ctx context.Context, id string, expectedVersion int, update domain.ExchangeUpdate, userID string) (*domain.Exchange, error)
 	SuspendExchange(ctx context.Context, id, reason, userID string) error
 
 	// Wallet operations
-	GetWallets(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetWallets(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error)
 	GetWalletByID(ctx context.Context, id string) (*domain.Wallet, error)
 	FreezeWallet(ctx context.Context, id, reason, userID string) error
 
 	// Miner operations
-	GetMiners(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetMiners(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error)
 	GetMinerByID(ctx context.Context, id string) (*domain.Miner, error)
 
 	// Alert operations
-	GetAlerts(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetAlerts(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error)
 	GetAlertByID(ctx context.Context, id string) (*domain.Alert, error)
 	AcknowledgeAlert(ctx context.Context, id, userID string) error
 
 	// Compliance operations
-	GetComplianceReports(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error)
-	GenerateComplianceReport(ctx context.Context, entityType, entityID, period string) (*domain.ComplianceReport, error)
+	GetComplianceReports(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error)
+	GenerateComplianceReport(ctx context.Context, entityType, entityID, period, locale string) (*domain.ComplianceReport, error)
 
 	// Audit operations
 	GetAuditLogs(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error)
@@ -49,10 +53,158 @@ type GatewayService interface {
 	HealthCheck(ctx context.Context) error
 }
 
+// ViolationEventHandler defines the interface for processing compliance violation events
+type ViolationEventHandler interface {
+	HandleViolation(ctx context.Context, event *domain.ComplianceViolationEvent) (*domain.Alert, error)
+}
+
+// EmergencyEventHandler defines the interface for applying emergency stop events
+type EmergencyEventHandler interface {
+	HandleEmergencyEvent(ctx context.Context, event *domain.EmergencyEvent) error
+}
+
+// EmergencyHaltService defines the interface for checking requests against
+// active emergency stops
+type EmergencyHaltService interface {
+	EmergencyEventHandler
+	ActiveStops() []*domain.EmergencyStop
+	BlockingStop(check *domain.HaltCheck) *domain.EmergencyStop
+}
+
+// DeadLetterService defines the interface for dead-letter queue operations
+type DeadLetterService interface {
+	DeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error
+	GetDeadLetters(ctx context.Context, status string, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetDeadLetterByID(ctx context.Context, id string) (*domain.DeadLetter, error)
+	ReprocessDeadLetter(ctx context.Context, id, userID string) (*domain.DeadLetter, error)
+	DiscardDeadLetter(ctx context.Context, id, userID string) (*domain.DeadLetter, error)
+}
+
+// APIKeyService defines the interface for API key issuance and authentication
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, key *domain.ClientAPIKey, userID string) (string, error)
+	GetAPIKeys(ctx context.Context, clientID string, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetAPIKeyByID(ctx context.Context, id string) (*domain.ClientAPIKey, error)
+	UpdateAPIKey(ctx context.Context, id string, update *domain.APIKeyUpdate) (*domain.ClientAPIKey, error)
+	RotateAPIKey(ctx context.Context, id string, gracePeriod time.Duration, userID string) (*domain.ClientAPIKey, string, error)
+	RevokeAPIKey(ctx context.Context, id, userID string) (*domain.ClientAPIKey, error)
+	GetAPIKeyUsage(ctx context.Context, id string) (*domain.APIKeyUsage, error)
+	Authenticate(ctx context.Context, rawKey string) (*domain.ClientAPIKey, *domain.APIKeyUsage, error)
+}
+
+// AdminService defines the interface for the admin console's feature flags
+// and maintenance mode. Reads are served from a snapshot refreshed from the
+// repository, so they are cheap enough to make on every request.
+type AdminService interface {
+	GetFeatureFlags() []*domain.FeatureFlag
+	GetFeatureFlag(ctx context.Context, key string) (*domain.FeatureFlag, error)
+	// SetFeatureFlag creates the flag or applies the update to it
+	SetFeatureFlag(ctx context.Context, key string, update *domain.FeatureFlagUpdate, userID string) (*domain.FeatureFlag, error)
+	DeleteFeatureFlag(ctx context.Context, key string) error
+	// EnabledFeatures returns the keys of the flags on for a caller
+	EnabledFeatures(userID, role string) []string
+	IsEnabled(key, userID, role string) bool
+
+	GetMaintenanceMode() *domain.MaintenanceMode
+	SetMaintenanceMode(ctx context.Context, enabled bool, message, userID string) (*domain.MaintenanceMode, error)
+}
+
+// SLOService defines the interface for measuring route groups against their
+// availability and latency objectives
+type SLOService interface {
+	// Record counts a request answered by a route group
+	Record(group string, status int, duration time.Duration)
+	GetSLOStatus() []*domain.SLOStatus
+}
+
+// TransactionImportService defines the interface for exchange transaction imports
+type TransactionImportService interface {
+	Import(ctx context.Context, exchangeID, format string, body io.Reader, submittedBy string) (*domain.TransactionImport, error)
+	GetImport(ctx context.Context, exchangeID, importID string) (*domain.TransactionImport, error)
+	GetImportErrors(ctx context.Context, exchangeID, importID string, page, pageSize int) (*domain.PaginatedResponse, error)
+}
+
+// WebhookService defines the interface for managing partner webhook endpoints
+// and their delivery log
+type WebhookService interface {
+	WebhookEventHandler
+	CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, userID string) (string, error)
+	GetEndpoints(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetEndpointByID(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, id string, update *domain.WebhookEndpointUpdate) (*domain.WebhookEndpoint, error)
+	RotateEndpointSecret(ctx context.Context, id string) (*domain.WebhookEndpoint, string, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+	GetDeliveries(ctx context.Context, endpointID, status string, page, pageSize int) (*domain.PaginatedResponse, error)
+	GetDeliveryByID(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+	RedeliverDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+}
+
+// WebhookEventHandler defines the interface for fanning an event out to the
+// webhook endpoints subscribed to it
+type WebhookEventHandler interface {
+	HandleWebhookEvent(ctx context.Context, event *domain.WebhookEvent) error
+}
+
+// WebhookSender defines the interface for posting a signed webhook delivery
+type WebhookSender interface {
+	Send(ctx context.Context, url string, body []byte, headers map[string]string) *domain.WebhookDeliveryResult
+}
+
+// SanctionsScreener defines the interface for screening addresses against sanctions lists
+type SanctionsScreener interface {
+	IsSanctioned(ctx context.Context, address string) (bool, error)
+}
+
+// APIKeyUsageStore defines the interface for counting API key requests against
+// rate limits and daily quotas
+type APIKeyUsageStore interface {
+	// Consume counts a request unless it would exceed the rate limit or daily
+	// quota, returning domain.ErrRateLimitExceeded or ErrDailyQuotaExceeded
+	Consume(ctx context.Context, key *domain.ClientAPIKey, now time.Time) (*domain.APIKeyUsage, error)
+	GetUsage(ctx context.Context, key *domain.ClientAPIKey, now time.Time) (*domain.APIKeyUsage, error)
+}
+
+// SessionStore defines the interface for storing signed-in sessions. Only a
+// hash of each session's current refresh token is stored.
+type SessionStore interface {
+	// Create stores a session and indexes it under its user
+	Create(ctx context.Context, session *domain.Session, refreshHash string, idleTimeout time.Duration) error
+	// Get returns a live session, or domain.ErrSessionNotFound
+	Get(ctx context.Context, id string) (*domain.Session, error)
+	ListByUser(ctx context.Context, userID string) ([]*domain.Session, error)
+	// Delete removes a session, returning domain.ErrSessionNotFound if it is not live
+	Delete(ctx context.Context, id string) error
+	// RotateRefresh replaces the refresh token hash if oldHash is current. A
+	// stale hash means the token was reused, so the session is deleted and
+	// domain.ErrRefreshTokenReused returned.
+	RotateRefresh(ctx context.Context, id, oldHash, newHash string, now time.Time, idleTimeout time.Duration) error
+	// Touch records activity from an IP address and extends the idle timeout,
+	// reporting whether the session is still live
+	Touch(ctx context.Context, id, ipAddress string, now time.Time, idleTimeout time.Duration) (bool, error)
+}
+
+// SessionService defines the interface for signing users in and managing
+// their sessions
+type SessionService interface {
+	Login(ctx context.Context, username, password string, client domain.SessionClient) (*domain.SessionTokens, error)
+	// Refresh exchanges a refresh token for new access and refresh tokens
+	Refresh(ctx context.Context, refreshToken string, client domain.SessionClient) (*domain.SessionTokens, error)
+	// ListSessions lists a user's live sessions, marking the current one
+	ListSessions(ctx context.Context, userID, currentSessionID string) ([]*domain.Session, error)
+	// RevokeSession ends a session; an empty userID revokes any user's session
+	RevokeSession(ctx context.Context, sessionID, userID string) error
+	// RevokeOtherSessions ends every session of the user but the current one
+	RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) (int, error)
+	// Touch records a request made with the session's access token, reporting
+	// whether the session is still live
+	Touch(ctx context.Context, sessionID, ipAddress string) (bool, error)
+}
+
 // AuthService defines the interface for authentication operations
 type AuthService interface {
 	// Token operations
 	GenerateToken(userID, username, role string, permissions []string) (string, error)
+	GenerateSessionToken(userID, username, role string, permissions []string, sessionID string, ttl time.Duration) (string, error)
 	ValidateToken(token string) (*TokenClaims, error)
 	RefreshToken(token string) (string, error)
 
@@ -69,6 +221,7 @@ type TokenClaims struct {
 	Role         string   `json:"role"`
 	Permissions  []string `json:"permissions"`
 	TokenID      string   `json:"token_id"`
+	SessionID    string   `json:"sid,omitempty"`
 	IssueAt      int64    `json:"iat"`
 	ExpiresAt    int64    `json:"exp"`
 }
@@ -85,6 +238,40 @@ type MessageProducer interface {
 	PublishComplianceReport(ctx context.Context, report *domain.ComplianceReport) error
 }
 
+// HeaderPublisher defines the interface for publishing keyed messages with headers
+type HeaderPublisher interface {
+	PublishWithHeaders(ctx context.Context, topic, key string, message []byte, headers map[string]string) error
+}
+
+// ConflictMetrics defines the interface for recording optimistic locking conflicts
+type ConflictMetrics interface {
+	IncVersionConflict(resource string)
+}
+
+// EntityCacheMetrics defines the interface for recording entity cache metrics
+type EntityCacheMetrics interface {
+	IncLookup(entity, result string)
+	IncInvalidationFailure(entity string)
+}
+
+// DeadLetterMetrics defines the interface for recording dead-letter queue metrics
+type DeadLetterMetrics interface {
+	SetDepth(topic string, depth int64)
+	IncDeadLettered(topic string)
+	IncReprocessed(topic string)
+}
+
+// RequestMetrics defines the interface for recording the requests of each route group
+type RequestMetrics interface {
+	ObserveRequest(group, method string, status int, duration time.Duration)
+}
+
+// SLOMetrics defines the interface for recording the error budgets of SLO objectives
+type SLOMetrics interface {
+	SetBudgetRemaining(group, objective string, remaining float64)
+	SetBurnRate(group, objective, window string, rate float64)
+}
+
 // HealthChecker defines the interface for health checking operations
 type HealthChecker interface {
 	Check(ctx context.Context) HealthStatus
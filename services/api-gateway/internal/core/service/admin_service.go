@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+)
+
+// AdminServiceImpl serves the admin console's feature flags and maintenance
+// mode. Both are stored in the repository and shared by every gateway
+// instance; each instance evaluates requests against a snapshot it refreshes
+// periodically, and immediately after its own changes.
+type AdminServiceImpl struct {
+	repo ports.Repository
+
+	mu          sync.RWMutex
+	flags       map[string]*domain.FeatureFlag
+	maintenance *domain.MaintenanceMode
+}
+
+// NewAdminService creates a new admin service instance
+func NewAdminService(repo ports.Repository) *AdminServiceImpl {
+	return &AdminServiceImpl{
+		repo:        repo,
+		flags:       make(map[string]*domain.FeatureFlag),
+		maintenance: &domain.MaintenanceMode{},
+	}
+}
+
+// Refresh reloads the snapshot from the repository
+func (s *AdminServiceImpl) Refresh(ctx context.Context) error {
+	flags, err := s.repo.GetFeatureFlags(ctx)
+	if err != nil {
+		return err
+	}
+	maintenance, err := s.repo.GetMaintenanceMode(ctx)
+	if err != nil {
+		return err
+	}
+
+	byKey := make(map[string]*domain.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		byKey[flag.Key] = flag
+	}
+
+	s.mu.Lock()
+	s.flags = byKey
+	s.maintenance = maintenance
+	s.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the snapshot every interval until ctx is done. A failed
+// refresh keeps the previous snapshot.
+func (s *AdminServiceImpl) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// GetFeatureFlags returns every feature flag ordered by key
+func (s *AdminServiceImpl) GetFeatureFlags() []*domain.FeatureFlag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	flags := make([]*domain.FeatureFlag, 0, len(s.flags))
+	for _, flag := range s.flags {
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Key < flags[j].Key
+	})
+	return flags
+}
+
+// GetFeatureFlag returns a feature flag as stored
+func (s *AdminServiceImpl) GetFeatureFlag(ctx context.Context, key string) (*domain.FeatureFlag, error) {
+	return s.repo.GetFeatureFlag(ctx, key)
+}
+
+// SetFeatureFlag creates the flag or applies the update to it. New flags are
+// off and rolled out to everyone unless the update says otherwise.
+func (s *AdminServiceImpl) SetFeatureFlag(ctx context.Context, key string, update *domain.FeatureFlagUpdate, userID string) (*domain.FeatureFlag, error) {
+	flag, err := s.repo.GetFeatureFlag(ctx, key)
+	if errors.Is(err, domain.ErrFeatureFlagNotFound) {
+		flag, err = &domain.FeatureFlag{Key: key, Percentage: 100}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Description != nil {
+		flag.Description = *update.Description
+	}
+	if update.Enabled != nil {
+		flag.Enabled = *update.Enabled
+	}
+	if update.Roles != nil {
+		flag.Roles = update.Roles
+	}
+	if update.Percentage != nil {
+		flag.Percentage = *update.Percentage
+	}
+	flag.UpdatedBy = userID
+
+	if err := flag.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveFeatureFlag(ctx, flag); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.flags[flag.Key] = flag
+	s.mu.Unlock()
+	return flag, nil
+}
+
+// DeleteFeatureFlag deletes a flag, which turns its feature off
+func (s *AdminServiceImpl) DeleteFeatureFlag(ctx context.Context, key string) error {
+	if err := s.repo.DeleteFeatureFlag(ctx, key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.flags, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// EnabledFeatures returns the keys of the flags on for a caller, ordered by key
+func (s *AdminServiceImpl) EnabledFeatures(userID, role string) []string {
+	keys := []string{}
+	for _, flag := range s.GetFeatureFlags() {
+		if flag.EnabledFor(userID, role) {
+			keys = append(keys, flag.Key)
+		}
+	}
+	return keys
+}
+
+// IsEnabled reports whether a flag is on for a caller; unknown flags are off
+func (s *AdminServiceImpl) IsEnabled(key, userID, role string) bool {
+	s.mu.RLock()
+	flag, ok := s.flags[key]
+	s.mu.RUnlock()
+
+	return ok && flag.EnabledFor(userID, role)
+}
+
+// GetMaintenanceMode returns the maintenance mode switch
+func (s *AdminServiceImpl) GetMaintenanceMode() *domain.MaintenanceMode {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.maintenance
+}
+
+// SetMaintenanceMode turns maintenance mode on or off. Turning it on while on
+// only changes the message, keeping the original start time.
+func (s *AdminServiceImpl) SetMaintenanceMode(ctx context.Context, enabled bool, message, userID string) (*domain.MaintenanceMode, error) {
+	current, err := s.repo.GetMaintenanceMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := &domain.MaintenanceMode{
+		Enabled:   enabled,
+		Message:   message,
+		UpdatedBy: userID,
+	}
+	if enabled {
+		mode.StartedAt = current.StartedAt
+		if !current.Enabled || mode.StartedAt == nil {
+			now := time.Now()
+			mode.StartedAt = &now
+		}
+	}
+
+	if err := s.repo.SaveMaintenanceMode(ctx, mode); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.maintenance = mode
+	s.mu.Unlock()
+	return mode, nil
+}
+
+// Ensure AdminServiceImpl implements ports.AdminService
+var _ ports.AdminService = (*AdminServiceImpl)(nil)
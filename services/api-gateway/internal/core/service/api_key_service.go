@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// apiKeyPrefix marks the start of every issued API key so leaked keys are easy
+// to recognise in logs and secret scanners
+const apiKeyPrefix = "csic"
+
+// APIKeyDefaults holds the limits applied to new keys that do not set their own
+type APIKeyDefaults struct {
+	RateLimit  int
+	DailyQuota int
+}
+
+// APIKeyServiceImpl issues API keys to machine clients and authenticates
+// requests made with them. Keys have the form csic_<prefix>_<secret>; the
+// prefix identifies the key and only a SHA-256 hash of the whole key is stored.
+type APIKeyServiceImpl struct {
+	repo     ports.Repository
+	usage    ports.APIKeyUsageStore
+	defaults APIKeyDefaults
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService(repo ports.Repository, usage ports.APIKeyUsageStore, defaults APIKeyDefaults) *APIKeyServiceImpl {
+	return &APIKeyServiceImpl{
+		repo:     repo,
+		usage:    usage,
+		defaults: defaults,
+	}
+}
+
+// CreateAPIKey issues a new key and returns it in plain text. The plain text key
+// is not stored and cannot be retrieved again.
+func (s *APIKeyServiceImpl) CreateAPIKey(ctx context.Context, key *domain.ClientAPIKey, userID string) (string, error) {
+	if key.Name == "" || key.ClientID == "" {
+		return "", fmt.Errorf("%w: name and client_id are required", domain.ErrInvalidAPIKeySpec)
+	}
+	if len(key.Scopes) == 0 {
+		return "", fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidAPIKeySpec)
+	}
+	if err := domain.ValidateAPIScopes(key.Scopes); err != nil {
+		return "", err
+	}
+	if key.RateLimit < 0 || key.DailyQuota < 0 {
+		return "", fmt.Errorf("%w: rate_limit and daily_quota must not be negative", domain.ErrInvalidAPIKeySpec)
+	}
+	if key.RateLimit == 0 {
+		key.RateLimit = s.defaults.RateLimit
+	}
+	if key.DailyQuota == 0 {
+		key.DailyQuota = s.defaults.DailyQuota
+	}
+
+	return s.issue(ctx, key, userID)
+}
+
+// GetAPIKeys returns a paginated list of API keys, optionally filtered by client
+func (s *APIKeyServiceImpl) GetAPIKeys(ctx context.Context, clientID string, page, pageSize int) (*domain.PaginatedResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	keys, err := s.repo.GetAPIKeys(ctx, clientID, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	total, err := s.repo.CountAPIKeys(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPaginatedResponse(keys, page, pageSize, total), nil
+}
+
+// GetAPIKeyByID returns a specific API key by ID
+func (s *APIKeyServiceImpl) GetAPIKeyByID(ctx context.Context, id string) (*domain.ClientAPIKey, error) {
+	return s.repo.GetAPIKeyByID(ctx, id)
+}
+
+// UpdateAPIKey changes the name, scopes or limits of a key that is not revoked
+func (s *APIKeyServiceImpl) UpdateAPIKey(ctx context.Context, id string, update *domain.APIKeyUpdate) (*domain.ClientAPIKey, error) {
+	key, err := s.repo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.Status == string(domain.APIKeyStatusRevoked) {
+		return nil, fmt.Errorf("%w: %s is %s", domain.ErrAPIKeyNotActive, id, key.Status)
+	}
+
+	if update.Name != nil {
+		if *update.Name == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", domain.ErrInvalidAPIKeySpec)
+		}
+		key.Name = *update.Name
+	}
+	if update.Scopes != nil {
+		if len(update.Scopes) == 0 {
+			return nil, fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidAPIKeySpec)
+		}
+		if err := domain.ValidateAPIScopes(update.Scopes); err != nil {
+			return nil, err
+		}
+		key.Scopes = update.Scopes
+	}
+	if update.RateLimit != nil {
+		if *update.RateLimit < 0 {
+			return nil, fmt.Errorf("%w: rate_limit must not be negative", domain.ErrInvalidAPIKeySpec)
+		}
+		key.RateLimit = *update.RateLimit
+	}
+	if update.DailyQuota != nil {
+		if *update.DailyQuota < 0 {
+			return nil, fmt.Errorf("%w: daily_quota must not be negative", domain.ErrInvalidAPIKeySpec)
+		}
+		key.DailyQuota = *update.DailyQuota
+	}
+
+	if err := s.repo.UpdateAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to update API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// RotateAPIKey issues a replacement for an active key with the same client,
+// scopes and limits. The old key keeps working until the grace period ends so
+// the client can roll out the new key without downtime.
+func (s *APIKeyServiceImpl) RotateAPIKey(ctx context.Context, id string, gracePeriod time.Duration, userID string) (*domain.ClientAPIKey, string, error) {
+	old, err := s.repo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if old.Status != string(domain.APIKeyStatusActive) {
+		return nil, "", fmt.Errorf("%w: %s is %s", domain.ErrAPIKeyNotActive, id, old.Status)
+	}
+	if gracePeriod < 0 {
+		gracePeriod = 0
+	}
+
+	replacement := &domain.ClientAPIKey{
+		Name:       old.Name,
+		ClientID:   old.ClientID,
+		Scopes:     old.Scopes,
+		RateLimit:  old.RateLimit,
+		DailyQuota: old.DailyQuota,
+		ExpiresAt:  old.ExpiresAt,
+	}
+	// The ID is set here so the old key can refer to its replacement
+	replacement.ID = uuid.New().String()
+	rawKey, err := newAPIKey(replacement, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	graceEndsAt := time.Now().UTC().Add(gracePeriod)
+	old.Status = string(domain.APIKeyStatusRotated)
+	old.GraceEndsAt = &graceEndsAt
+	old.ReplacedBy = replacement.ID
+	if err := s.repo.RotateAPIKey(ctx, old, replacement); err != nil {
+		return nil, "", fmt.Errorf("failed to rotate API key %s: %w", id, err)
+	}
+
+	return replacement, rawKey, nil
+}
+
+// RevokeAPIKey immediately stops a key, including one in its rotation grace period
+func (s *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, id, userID string) (*domain.ClientAPIKey, error) {
+	key, err := s.repo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.Status == string(domain.APIKeyStatusRevoked) {
+		return nil, fmt.Errorf("%w: %s is already revoked", domain.ErrAPIKeyNotActive, id)
+	}
+
+	now := time.Now().UTC()
+	key.Status = string(domain.APIKeyStatusRevoked)
+	key.RevokedBy = userID
+	key.RevokedAt = &now
+	if err := s.repo.UpdateAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeyUsage returns a key's request counts for the current minute and day
+func (s *APIKeyServiceImpl) GetAPIKeyUsage(ctx context.Context, id string) (*domain.APIKeyUsage, error) {
+	key, err := s.repo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.usage.GetUsage(ctx, key, time.Now())
+}
+
+// Authenticate resolves a plain text key and counts the request against its
+// limits. When a limit is exceeded the key and usage are returned together with
+// domain.ErrRateLimitExceeded or domain.ErrDailyQuotaExceeded.
+func (s *APIKeyServiceImpl) Authenticate(ctx context.Context, rawKey string) (*domain.ClientAPIKey, *domain.APIKeyUsage, error) {
+	prefix, ok := parseAPIKey(rawKey)
+	if !ok {
+		return nil, nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, nil, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(rawKey)), []byte(key.KeyHash)) != 1 {
+		return nil, nil, domain.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if err := key.Usable(now); err != nil {
+		return nil, nil, err
+	}
+
+	usage, err := s.usage.Consume(ctx, key, now)
+	return key, usage, err
+}
+
+// issue generates the key material for a new key and stores it
+func (s *APIKeyServiceImpl) issue(ctx context.Context, key *domain.ClientAPIKey, userID string) (string, error) {
+	rawKey, err := newAPIKey(key, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		return "", fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return rawKey, nil
+}
+
+// newAPIKey generates the key material for a new active key and returns the
+// plain text key
+func newAPIKey(key *domain.ClientAPIKey, userID string) (string, error) {
+	rawKey, prefix, err := generateAPIKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	key.KeyPrefix = prefix
+	key.KeyHash = hashAPIKey(rawKey)
+	key.Status = string(domain.APIKeyStatusActive)
+	key.CreatedBy = userID
+	return rawKey, nil
+}
+
+// apiKeyPrefixBytes is the number of random bytes in a key's public prefix,
+// enough that prefixes, which must be unique, do not collide in practice.
+// Keys issued with shorter prefixes still authenticate.
+const apiKeyPrefixBytes = 8
+
+// generateAPIKey returns a new random key and its public prefix
+func generateAPIKey() (string, string, error) {
+	prefixBytes := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	return fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, base64.RawURLEncoding.EncodeToString(secret)), prefix, nil
+}
+
+// parseAPIKey returns the public prefix of a well-formed key
+func parseAPIKey(rawKey string) (string, bool) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ensure APIKeyServiceImpl implements the APIKeyService interface
+var _ ports.APIKeyService = (*APIKeyServiceImpl)(nil)
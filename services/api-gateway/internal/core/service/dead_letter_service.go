@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+)
+
+// DeadLetterServiceImpl stores messages consumers gave up on, publishes them to
+// the dead-letter topic and lets operators reprocess or discard them
+type DeadLetterServiceImpl struct {
+	repo      ports.Repository
+	publisher ports.HeaderPublisher
+	metrics   ports.DeadLetterMetrics
+
+	mu     sync.Mutex
+	topics map[string]bool
+}
+
+// NewDeadLetterService creates a new dead-letter service instance
+func NewDeadLetterService(
+	repo ports.Repository,
+	publisher ports.HeaderPublisher,
+	metrics ports.DeadLetterMetrics,
+) *DeadLetterServiceImpl {
+	return &DeadLetterServiceImpl{
+		repo:      repo,
+		publisher: publisher,
+		metrics:   metrics,
+		topics:    make(map[string]bool),
+	}
+}
+
+// DeadLetter records a failed message and publishes it with its failure metadata
+// to the dead-letter topic. The record is stored first and is idempotent, so a
+// caller can retry until the publish succeeds without duplicating the record.
+func (s *DeadLetterServiceImpl) DeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error {
+	if deadLetter.Topic == "" {
+		deadLetter.Topic = domain.DeadLetterTopic(deadLetter.OriginalTopic)
+	}
+	if deadLetter.Status == "" {
+		deadLetter.Status = string(domain.DeadLetterStatusPending)
+	}
+	if deadLetter.FailedAt.IsZero() {
+		deadLetter.FailedAt = time.Now().UTC()
+	}
+
+	if err := s.repo.CreateDeadLetter(ctx, deadLetter); err != nil {
+		return fmt.Errorf("failed to record dead letter: %w", err)
+	}
+
+	headers := make(map[string]string, len(deadLetter.Headers)+7)
+	for k, v := range deadLetter.Headers {
+		headers[k] = v
+	}
+	headers[domain.DeadLetterHeaderOriginalTopic] = deadLetter.OriginalTopic
+	headers[domain.DeadLetterHeaderOriginalPartition] = strconv.Itoa(deadLetter.Partition)
+	headers[domain.DeadLetterHeaderOriginalOffset] = strconv.FormatInt(deadLetter.Offset, 10)
+	headers[domain.DeadLetterHeaderConsumerGroup] = deadLetter.ConsumerGroup
+	headers[domain.DeadLetterHeaderError] = deadLetter.Error
+	headers[domain.DeadLetterHeaderAttempts] = strconv.Itoa(deadLetter.Attempts)
+	headers[domain.DeadLetterHeaderFailedAt] = deadLetter.FailedAt.Format(time.RFC3339)
+
+	if err := s.publisher.PublishWithHeaders(ctx, deadLetter.Topic, deadLetter.MessageKey, []byte(deadLetter.Payload), headers); err != nil {
+		return fmt.Errorf("failed to publish to dead-letter topic %s: %w", deadLetter.Topic, err)
+	}
+
+	if s.metrics != nil {
+		s.metrics.IncDeadLettered(deadLetter.Topic)
+	}
+	_ = s.RefreshDepth(ctx)
+
+	return nil
+}
+
+// GetDeadLetters returns a paginated list of dead letters, optionally filtered by status
+func (s *DeadLetterServiceImpl) GetDeadLetters(ctx context.Context, status string, page, pageSize int) (*domain.PaginatedResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	deadLetters, err := s.repo.GetDeadLetters(ctx, status, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letters: %w", err)
+	}
+
+	total, err := s.repo.CountDeadLetters(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPaginatedResponse(deadLetters, page, pageSize, total), nil
+}
+
+// GetDeadLetterByID returns a specific dead letter by ID
+func (s *DeadLetterServiceImpl) GetDeadLetterByID(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	return s.repo.GetDeadLetterByID(ctx, id)
+}
+
+// ReprocessDeadLetter republishes a pending dead letter to its original topic so
+// the consumer handles it again. If it fails again it is dead-lettered anew.
+func (s *DeadLetterServiceImpl) ReprocessDeadLetter(ctx context.Context, id, userID string) (*domain.DeadLetter, error) {
+	deadLetter, err := s.pendingDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string, len(deadLetter.Headers)+1)
+	for k, v := range deadLetter.Headers {
+		headers[k] = v
+	}
+	headers[domain.DeadLetterHeaderReprocessedFrom] = deadLetter.ID
+
+	if err := s.publisher.PublishWithHeaders(ctx, deadLetter.OriginalTopic, deadLetter.MessageKey, []byte(deadLetter.Payload), headers); err != nil {
+		return nil, fmt.Errorf("failed to republish dead letter to %s: %w", deadLetter.OriginalTopic, err)
+	}
+
+	if err := s.resolve(ctx, deadLetter, domain.DeadLetterStatusReprocessed, userID); err != nil {
+		return nil, err
+	}
+	if s.metrics != nil {
+		s.metrics.IncReprocessed(deadLetter.Topic)
+	}
+
+	return deadLetter, nil
+}
+
+// DiscardDeadLetter marks a pending dead letter as not to be reprocessed
+func (s *DeadLetterServiceImpl) DiscardDeadLetter(ctx context.Context, id, userID string) (*domain.DeadLetter, error) {
+	deadLetter, err := s.pendingDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resolve(ctx, deadLetter, domain.DeadLetterStatusDiscarded, userID); err != nil {
+		return nil, err
+	}
+
+	return deadLetter, nil
+}
+
+// RefreshDepth reports the number of pending dead letters per dead-letter topic.
+// Topics that have been drained are reported as empty.
+func (s *DeadLetterServiceImpl) RefreshDepth(ctx context.Context) error {
+	if s.metrics == nil {
+		return nil
+	}
+
+	depths, err := s.repo.CountPendingDeadLettersByTopic(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for topic := range s.topics {
+		if _, ok := depths[topic]; !ok {
+			s.metrics.SetDepth(topic, 0)
+		}
+	}
+	for topic, depth := range depths {
+		s.topics[topic] = true
+		s.metrics.SetDepth(topic, depth)
+	}
+
+	return nil
+}
+
+// StartDepthMonitor refreshes the dead-letter depth metrics until ctx is
+// cancelled, so dead letters handled by other instances are reflected too
+func (s *DeadLetterServiceImpl) StartDepthMonitor(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RefreshDepth(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DeadLetterServiceImpl) pendingDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	deadLetter, err := s.repo.GetDeadLetterByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter.Status != string(domain.DeadLetterStatusPending) {
+		return nil, fmt.Errorf("%w: %s is %s", domain.ErrDeadLetterNotPending, id, deadLetter.Status)
+	}
+	return deadLetter, nil
+}
+
+func (s *DeadLetterServiceImpl) resolve(ctx context.Context, deadLetter *domain.DeadLetter, status domain.DeadLetterStatus, userID string) error {
+	deadLetter.Status = string(status)
+	deadLetter.ResolvedBy = userID
+	deadLetter.ResolvedAt = time.Now().UTC().Format(time.RFC3339)
+
+	if err := s.repo.UpdateDeadLetter(ctx, deadLetter); err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+	_ = s.RefreshDepth(ctx)
+
+	return nil
+}
+
+// Ensure the DeadLetterServiceImpl implements the DeadLetterService interface
+var _ ports.DeadLetterService = (*DeadLetterServiceImpl)(nil)
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+)
+
+// EmergencyHaltServiceImpl keeps the emergency stops in force, rebuilt from the
+// control layer's emergency events, and decides which requests they block
+type EmergencyHaltServiceImpl struct {
+	mu    sync.RWMutex
+	stops map[string]*domain.EmergencyStop
+}
+
+// NewEmergencyHaltService creates a new emergency halt service instance
+func NewEmergencyHaltService() *EmergencyHaltServiceImpl {
+	return &EmergencyHaltServiceImpl{
+		stops: make(map[string]*domain.EmergencyStop),
+	}
+}
+
+// HandleEmergencyEvent records a halt or removes a resumed stop. Events are
+// idempotent, so replaying the topic from the start rebuilds the same state.
+func (s *EmergencyHaltServiceImpl) HandleEmergencyEvent(ctx context.Context, event *domain.EmergencyEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch event.Type {
+	case domain.EmergencyEventHalt:
+		s.stops[event.Stop.ID] = event.Stop
+	case domain.EmergencyEventResume:
+		delete(s.stops, event.Stop.ID)
+	}
+	return nil
+}
+
+// ActiveStops returns the emergency stops in force, oldest first
+func (s *EmergencyHaltServiceImpl) ActiveStops() []*domain.EmergencyStop {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stops := make([]*domain.EmergencyStop, 0, len(s.stops))
+	for _, stop := range s.stops {
+		stops = append(stops, stop)
+	}
+	sort.Slice(stops, func(i, j int) bool {
+		return stops[i].CreatedAt.Before(stops[j].CreatedAt)
+	})
+	return stops
+}
+
+// BlockingStop returns the stop that blocks a request, or nil if the request
+// may proceed. Only mutating requests are ever blocked.
+func (s *EmergencyHaltServiceImpl) BlockingStop(check *domain.HaltCheck) *domain.EmergencyStop {
+	if !isMutating(check.Method) {
+		return nil
+	}
+
+	for _, stop := range s.ActiveStops() {
+		if blocks(stop, check) {
+			return stop
+		}
+	}
+	return nil
+}
+
+// blocks reports whether a stop covers a mutating request
+func blocks(stop *domain.EmergencyStop, check *domain.HaltCheck) bool {
+	switch stop.Scope {
+	case domain.EmergencyScopeGlobal:
+		return true
+	case domain.EmergencyScopeTransaction:
+		return hasPathSegment(check.Path, "transactions") || hasPathSegment(check.Path, "transfers")
+	case domain.EmergencyScopeExchange:
+		return strings.EqualFold(check.ExchangeID, stop.Target) || strings.EqualFold(pathSegmentAfter(check.Path, "exchanges"), stop.Target)
+	case domain.EmergencyScopeRegion:
+		return strings.EqualFold(check.Region, stop.Target)
+	default:
+		return false
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func hasPathSegment(path, segment string) bool {
+	for _, s := range strings.Split(path, "/") {
+		if s == segment {
+			return true
+		}
+	}
+	return false
+}
+
+// pathSegmentAfter returns the path segment following segment, such as the
+// exchange ID in /api/v1/exchanges/{id}/suspend
+func pathSegmentAfter(path, segment string) string {
+	segments := strings.Split(path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == segment {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
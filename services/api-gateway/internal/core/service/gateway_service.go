@@ -3,11 +3,14 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/core/domain"
 	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/csic-platform/shared/query"
 	"github.com/google/uuid"
 )
 
@@ -17,6 +20,7 @@ type GatewayServiceImpl struct {
 	cache   ports.CacheRepository
 	producer ports.MessageProducer
 	auth    ports.AuthService
+	conflicts ports.ConflictMetrics
 }
 
 // NewGatewayService creates a new gateway service instance
@@ -25,12 +29,14 @@ func NewGatewayService(
 	cache ports.CacheRepository,
 	producer ports.MessageProducer,
 	auth ports.AuthService,
+	conflicts ports.ConflictMetrics,
 ) *GatewayServiceImpl {
 	return &GatewayServiceImpl{
 		repo:    repo,
 		cache:   cache,
 		producer: producer,
 		auth:    auth,
+		conflicts: conflicts,
 	}
 }
 
@@ -72,21 +78,13 @@ func (s *GatewayServiceImpl) GetDashboardStats(ctx context.Context) (*domain.Das
 }
 
 // GetExchanges returns a paginated list of exchanges
-func (s *GatewayServiceImpl) GetExchanges(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
-
-	exchanges, err := s.repo.GetExchanges(ctx, page, pageSize)
+func (s *GatewayServiceImpl) GetExchanges(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error) {
+	exchanges, info, err := s.repo.GetExchanges(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get exchanges: %w", err)
 	}
 
-	total := int64(len(exchanges)) * int64(page) // Mock total for demo
-	return domain.NewPaginatedResponse(exchanges, page, pageSize, total), nil
+	return pageResponse(exchanges, params, info), nil
 }
 
 // GetExchangeByID returns a specific exchange by ID
@@ -94,6 +92,44 @@ func (s *GatewayServiceImpl) GetExchangeByID(ctx context.Context, id string) (*d
 	return s.repo.GetExchangeByID(ctx, id)
 }
 
+// UpdateExchange applies an update to an exchange. expectedVersion is the
+// version the caller last read; if the exchange has changed since, nothing is
+// written and domain.ErrVersionConflict is returned.
+func (s *GatewayServiceImpl) UpdateExchange(ctx context.Context, id string, expectedVersion int, update domain.ExchangeUpdate, userID string) (*domain.Exchange, error) {
+	exchange, err := s.repo.GetExchangeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if exchange.Version != expectedVersion {
+		s.conflicts.IncVersionConflict("exchange")
+		return nil, domain.ErrVersionConflict
+	}
+
+	update.Apply(exchange)
+
+	if err := s.repo.UpdateExchange(ctx, exchange); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			s.conflicts.IncVersionConflict("exchange")
+		}
+		return nil, fmt.Errorf("failed to update exchange: %w", err)
+	}
+
+	// Publish event
+	event := map[string]interface{}{
+		"event_type":  "EXCHANGE_UPDATED",
+		"exchange_id": id,
+		"version":     exchange.Version,
+		"updated_by":  userID,
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	data, _ := json.Marshal(event)
+	_ = s.producer.Publish(ctx, "csic.exchange_events", data)
+
+	return exchange, nil
+}
+
 // SuspendExchange suspends an exchange
 func (s *GatewayServiceImpl) SuspendExchange(ctx context.Context, id, reason, userID string) error {
 	if err := s.repo.SuspendExchange(ctx, id, reason, userID); err != nil {
@@ -121,21 +157,13 @@ func (s *GatewayServiceImpl) SuspendExchange(ctx context.Context, id, reason, us
 }
 
 // GetWallets returns a paginated list of wallets
-func (s *GatewayServiceImpl) GetWallets(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
-
-	wallets, err := s.repo.GetWallets(ctx, page, pageSize)
+func (s *GatewayServiceImpl) GetWallets(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error) {
+	wallets, info, err := s.repo.GetWallets(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get wallets: %w", err)
 	}
 
-	total := int64(len(wallets)) * int64(page) // Mock total for demo
-	return domain.NewPaginatedResponse(wallets, page, pageSize, total), nil
+	return pageResponse(wallets, params, info), nil
 }
 
 // GetWalletByID returns a specific wallet by ID
@@ -165,21 +193,13 @@ func (s *GatewayServiceImpl) FreezeWallet(ctx context.Context, id, reason, userI
 }
 
 // GetMiners returns a paginated list of miners
-func (s *GatewayServiceImpl) GetMiners(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
-
-	miners, err := s.repo.GetMiners(ctx, page, pageSize)
+func (s *GatewayServiceImpl) GetMiners(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error) {
+	miners, info, err := s.repo.GetMiners(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get miners: %w", err)
 	}
 
-	total := int64(len(miners)) * int64(page) // Mock total for demo
-	return domain.NewPaginatedResponse(miners, page, pageSize, total), nil
+	return pageResponse(miners, params, info), nil
 }
 
 // GetMinerByID returns a specific miner by ID
@@ -188,21 +208,13 @@ func (s *GatewayServiceImpl) GetMinerByID(ctx context.Context, id string) (*doma
 }
 
 // GetAlerts returns a paginated list of alerts
-func (s *GatewayServiceImpl) GetAlerts(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
-
-	alerts, err := s.repo.GetAlerts(ctx, page, pageSize)
+func (s *GatewayServiceImpl) GetAlerts(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error) {
+	alerts, info, err := s.repo.GetAlerts(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get alerts: %w", err)
 	}
 
-	total := int64(len(alerts)) * int64(page) // Mock total for demo
-	return domain.NewPaginatedResponse(alerts, page, pageSize, total), nil
+	return pageResponse(alerts, params, info), nil
 }
 
 // GetAlertByID returns a specific alert by ID
@@ -231,25 +243,22 @@ func (s *GatewayServiceImpl) AcknowledgeAlert(ctx context.Context, id, userID st
 }
 
 // GetComplianceReports returns a paginated list of compliance reports
-func (s *GatewayServiceImpl) GetComplianceReports(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
-	}
-
-	reports, err := s.repo.GetComplianceReports(ctx, page, pageSize)
+func (s *GatewayServiceImpl) GetComplianceReports(ctx context.Context, params *query.Params) (*domain.PaginatedResponse, error) {
+	reports, info, err := s.repo.GetComplianceReports(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get compliance reports: %w", err)
 	}
 
-	total := int64(len(reports)) * int64(page) // Mock total for demo
-	return domain.NewPaginatedResponse(reports, page, pageSize, total), nil
+	return pageResponse(reports, params, info), nil
 }
 
-// GenerateComplianceReport generates a new compliance report
-func (s *GatewayServiceImpl) GenerateComplianceReport(ctx context.Context, entityType, entityID, period string) (*domain.ComplianceReport, error) {
+// GenerateComplianceReport generates a new compliance report. Its documents
+// are generated in the given locale, or the request's locale if none is given.
+func (s *GatewayServiceImpl) GenerateComplianceReport(ctx context.Context, entityType, entityID, period, locale string) (*domain.ComplianceReport, error) {
+	if locale == "" {
+		locale = i18n.FromContext(ctx)
+	}
+
 	report := &domain.ComplianceReport{
 		BaseEntity: domain.BaseEntity{
 			ID:        uuid.New().String(),
@@ -260,6 +269,7 @@ func (s *GatewayServiceImpl) GenerateComplianceReport(ctx context.Context, entit
 		EntityID:    entityID,
 		Period:      period,
 		Status:      "GENERATING",
+		Locale:      locale,
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 	}
 
@@ -350,10 +360,17 @@ func (s *GatewayServiceImpl) GetUsers(ctx context.Context, page, pageSize int) (
 	return domain.NewPaginatedResponse(users, page, pageSize, total), nil
 }
 
+// pageResponse wraps a page of list results with its pagination metadata
+func pageResponse(items interface{}, params *query.Params, info *query.PageInfo) *domain.PaginatedResponse {
+	resp := domain.NewPaginatedResponse(items, params.Page, params.PageSize, info.Total)
+	resp.NextCursor = info.NextCursor
+	return resp
+}
+
 // HealthCheck performs a health check
 func (s *GatewayServiceImpl) HealthCheck(ctx context.Context) error {
 	// Check database connection
-	_, err := s.repo.GetExchanges(ctx, 1, 1)
+	_, _, err := s.repo.GetExchanges(ctx, &query.Params{Page: 1, PageSize: 1})
 	if err != nil {
 		return fmt.Errorf("database health check failed: %w", err)
 	}
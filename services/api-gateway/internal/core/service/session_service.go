@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// SessionLimits bounds the lifetime of sessions and their access tokens
+type SessionLimits struct {
+	AccessTokenTTL  time.Duration
+	IdleTimeout     time.Duration
+	AbsoluteTimeout time.Duration
+	// ConcurrentLimit is the most live sessions a user may have; signing in
+	// beyond it ends the least recently used session. 0 is unlimited.
+	ConcurrentLimit int
+}
+
+// SessionServiceImpl signs users in and manages their sessions. Each sign-in
+// creates a session and returns a short-lived access token naming it with a
+// refresh token of the form <session ID>.<secret>. Refresh tokens are single
+// use and only a SHA-256 hash of the current one is stored; presenting an
+// already used refresh token revokes the session, as it means the token was
+// copied.
+type SessionServiceImpl struct {
+	auth   ports.AuthService
+	store  ports.SessionStore
+	limits SessionLimits
+}
+
+// NewSessionService creates a new session service instance
+func NewSessionService(auth ports.AuthService, store ports.SessionStore, limits SessionLimits) *SessionServiceImpl {
+	return &SessionServiceImpl{
+		auth:   auth,
+		store:  store,
+		limits: limits,
+	}
+}
+
+// Login authenticates a user and starts a session on the client's device
+func (s *SessionServiceImpl) Login(ctx context.Context, username, password string, client domain.SessionClient) (*domain.SessionTokens, error) {
+	user, err := s.auth.Authenticate(username, password)
+	if err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if err := s.enforceConcurrentLimit(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	sess := &domain.Session{
+		ID:          uuid.New().String(),
+		UserID:      user.ID,
+		Username:    user.Username,
+		Role:        user.Role,
+		Permissions: user.Permissions,
+		Device:      client.Device,
+		UserAgent:   client.UserAgent,
+		IPAddress:   client.IPAddress,
+		CreatedAt:   now,
+		LastSeenAt:  now,
+		ExpiresAt:   now.Add(s.limits.AbsoluteTimeout),
+	}
+	if sess.Device == "" {
+		sess.Device = client.UserAgent
+	}
+
+	refreshToken, refreshHash, err := newRefreshToken(sess.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Create(ctx, sess, refreshHash, s.limits.IdleTimeout); err != nil {
+		return nil, err
+	}
+
+	return s.issue(sess, refreshToken)
+}
+
+// Refresh exchanges a refresh token for a new access token and refresh token
+func (s *SessionServiceImpl) Refresh(ctx context.Context, refreshToken string, client domain.SessionClient) (*domain.SessionTokens, error) {
+	sessionID, _, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	newToken, newHash, err := newRefreshToken(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	err = s.store.RotateRefresh(ctx, sessionID, hashRefreshToken(refreshToken), newHash, now, s.limits.IdleTimeout)
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Touch(ctx, sessionID, client.IPAddress, now, s.limits.IdleTimeout); err != nil {
+		return nil, err
+	}
+	sess, err := s.store.Get(ctx, sessionID)
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(sess, newToken)
+}
+
+// ListSessions lists a user's live sessions, most recently used first
+func (s *SessionServiceImpl) ListSessions(ctx context.Context, userID, currentSessionID string) ([]*domain.Session, error) {
+	sessions, err := s.store.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sess := range sessions {
+		sess.Current = sess.ID == currentSessionID
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+// RevokeSession ends a session, revoking its access and refresh tokens. A
+// session of another user is reported as not found unless userID is empty.
+func (s *SessionServiceImpl) RevokeSession(ctx context.Context, sessionID, userID string) error {
+	if userID != "" {
+		sess, err := s.store.Get(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if sess.UserID != userID {
+			return domain.ErrSessionNotFound
+		}
+	}
+	return s.store.Delete(ctx, sessionID)
+}
+
+// RevokeOtherSessions ends every session of the user except the current one
+func (s *SessionServiceImpl) RevokeOtherSessions(ctx context.Context, userID, currentSessionID string) (int, error) {
+	sessions, err := s.store.ListByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, sess := range sessions {
+		if sess.ID == currentSessionID {
+			continue
+		}
+		if err := s.store.Delete(ctx, sess.ID); err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// Touch records a request made with the session's access token
+func (s *SessionServiceImpl) Touch(ctx context.Context, sessionID, ipAddress string) (bool, error) {
+	return s.store.Touch(ctx, sessionID, ipAddress, time.Now().UTC(), s.limits.IdleTimeout)
+}
+
+// enforceConcurrentLimit ends the user's least recently used sessions so a
+// new one stays within the limit
+func (s *SessionServiceImpl) enforceConcurrentLimit(ctx context.Context, userID string) error {
+	if s.limits.ConcurrentLimit <= 0 {
+		return nil
+	}
+
+	sessions, err := s.store.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.Before(sessions[j].LastSeenAt)
+	})
+
+	for i := 0; len(sessions)-i >= s.limits.ConcurrentLimit; i++ {
+		if err := s.store.Delete(ctx, sessions[i].ID); err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// issue signs an access token for the session
+func (s *SessionServiceImpl) issue(sess *domain.Session, refreshToken string) (*domain.SessionTokens, error) {
+	accessToken, err := s.auth.GenerateSessionToken(sess.UserID, sess.Username, sess.Role, sess.Permissions, sess.ID, s.limits.AccessTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	return &domain.SessionTokens{
+		SessionID:    sess.ID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.limits.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// newRefreshToken generates a refresh token for a session and its hash
+func newRefreshToken(sessionID string) (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token := sessionID + "." + base64.RawURLEncoding.EncodeToString(secret)
+	return token, hashRefreshToken(token), nil
+}
+
+// hashRefreshToken returns the stored form of a refresh token
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ensure SessionServiceImpl implements ports.SessionService
+var _ ports.SessionService = (*SessionServiceImpl)(nil)
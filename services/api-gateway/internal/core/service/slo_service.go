@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// sloLookbacks are the windows burn rates are reported over. A fast burn must
+// show over both the hour and the last five minutes, so it stops alerting
+// soon after the cause is fixed.
+var sloLookbacks = []time.Duration{5 * time.Minute, time.Hour, 6 * time.Hour}
+
+// SLOOptions holds the objectives tracked and when they raise alerts
+type SLOOptions struct {
+	Targets []domain.SLOTarget
+	// Window is the period each error budget covers
+	Window time.Duration
+	// FastBurnRate is the burn rate, over both the last hour and five
+	// minutes, at which a warning is raised before the budget runs out
+	FastBurnRate float64
+	// MinRequests is the number of requests an objective needs in a window
+	// before it can raise alerts
+	MinRequests int64
+}
+
+// SLOServiceImpl counts the requests of each route group in one-minute
+// buckets, computes the burn rates of their objectives and raises a platform
+// alert when an error budget burns too fast or is exhausted. Counts are kept
+// per instance and start empty on restart.
+type SLOServiceImpl struct {
+	repo     ports.Repository
+	producer ports.MessageProducer
+	metrics  ports.SLOMetrics
+	options  SLOOptions
+	since    time.Time
+
+	groups map[string]*sloGroup
+
+	mu     sync.Mutex
+	states map[string]domain.SLOState
+}
+
+// sloGroup holds the request counts of a route group for the SLO window
+type sloGroup struct {
+	mu               sync.Mutex
+	latencyThreshold time.Duration
+	buckets          []sloBucket
+}
+
+// sloBucket counts the requests answered in one minute
+type sloBucket struct {
+	minute   int64
+	requests int64
+	errors   int64
+	slow     int64
+}
+
+// NewSLOService creates a new SLO service instance
+func NewSLOService(
+	repo ports.Repository,
+	producer ports.MessageProducer,
+	metrics ports.SLOMetrics,
+	options SLOOptions,
+) *SLOServiceImpl {
+	if options.Window <= 0 {
+		options.Window = 30 * 24 * time.Hour
+	}
+
+	minutes := int((options.Window + time.Minute - 1) / time.Minute)
+	groups := make(map[string]*sloGroup)
+	for _, target := range options.Targets {
+		group, ok := groups[target.Group]
+		if !ok {
+			group = &sloGroup{buckets: make([]sloBucket, minutes)}
+			groups[target.Group] = group
+		}
+		if target.Objective == domain.SLOObjectiveLatency {
+			group.latencyThreshold = target.LatencyThreshold
+		}
+	}
+
+	return &SLOServiceImpl{
+		repo:     repo,
+		producer: producer,
+		metrics:  metrics,
+		options:  options,
+		since:    time.Now().UTC(),
+		groups:   groups,
+		states:   make(map[string]domain.SLOState),
+	}
+}
+
+// Record counts a request answered by a route group. Requests of groups
+// without objectives are ignored.
+func (s *SLOServiceImpl) Record(group string, status int, duration time.Duration) {
+	g, ok := s.groups[group]
+	if !ok {
+		return
+	}
+
+	minute := time.Now().Unix() / 60
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b := &g.buckets[minute%int64(len(g.buckets))]
+	if b.minute != minute {
+		*b = sloBucket{minute: minute}
+	}
+	b.requests++
+	if status >= 500 {
+		b.errors++
+	}
+	if g.latencyThreshold > 0 && duration > g.latencyThreshold {
+		b.slow++
+	}
+}
+
+// GetSLOStatus returns the current status of every objective
+func (s *SLOServiceImpl) GetSLOStatus() []*domain.SLOStatus {
+	now := time.Now().UTC()
+	statuses := make([]*domain.SLOStatus, 0, len(s.options.Targets))
+	for _, target := range s.options.Targets {
+		statuses = append(statuses, s.status(target, now))
+	}
+	return statuses
+}
+
+// Evaluate updates the error budget metrics and raises an alert for each
+// objective that has started burning fast or exhausted its budget. An
+// objective alerts again only after recovering, and instances sharing the
+// alert store raise at most one alert per objective and state each day.
+func (s *SLOServiceImpl) Evaluate(ctx context.Context) error {
+	var errs []error
+	for _, status := range s.GetSLOStatus() {
+		if s.metrics != nil {
+			s.metrics.SetBudgetRemaining(status.Group, status.Objective, status.BudgetRemaining)
+			for _, rate := range status.BurnRates {
+				s.metrics.SetBurnRate(status.Group, status.Objective, rate.Window, rate.BurnRate)
+			}
+		}
+
+		key := status.Group + "/" + status.Objective
+		s.mu.Lock()
+		previous := s.states[key]
+		s.mu.Unlock()
+
+		if sloSeverity(status.State) > sloSeverity(previous) {
+			if err := s.raiseAlert(ctx, status); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		s.mu.Lock()
+		s.states[key] = status.State
+		s.mu.Unlock()
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to raise %d SLO alerts, first error: %w", len(errs), errs[0])
+	}
+	return nil
+}
+
+// Run evaluates the objectives every interval until ctx is cancelled
+func (s *SLOServiceImpl) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Evaluate(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// status computes an objective's SLI, remaining budget and burn rates
+func (s *SLOServiceImpl) status(target domain.SLOTarget, now time.Time) *domain.SLOStatus {
+	g := s.groups[target.Group]
+	budget := target.ErrorBudget()
+
+	since := now.Add(-s.options.Window)
+	if since.Before(s.since) {
+		since = s.since
+	}
+
+	status := &domain.SLOStatus{
+		Group:           target.Group,
+		Objective:       target.Objective,
+		Target:          target.Target,
+		Window:          formatSLOWindow(s.options.Window),
+		SLI:             100,
+		BudgetRemaining: 1,
+		State:           domain.SLOStateOK,
+		Since:           since,
+		EvaluatedAt:     now,
+	}
+	if target.Objective == domain.SLOObjectiveLatency {
+		status.LatencyThresholdMs = target.LatencyThreshold.Milliseconds()
+	}
+
+	status.Requests, status.BadRequests = g.count(target.Objective, s.options.Window, now)
+	if status.Requests > 0 {
+		badRatio := float64(status.BadRequests) / float64(status.Requests)
+		status.SLI = 100 * (1 - badRatio)
+		status.BudgetRemaining = 1 - badRatio/budget
+	}
+
+	fastBurn := s.options.FastBurnRate > 0
+	for _, lookback := range sloLookbacks {
+		rate := domain.SLOBurnRate{Window: formatSLOWindow(lookback)}
+		rate.Requests, rate.BadRequests = g.count(target.Objective, lookback, now)
+		if rate.Requests > 0 {
+			rate.BurnRate = float64(rate.BadRequests) / float64(rate.Requests) / budget
+		}
+		status.BurnRates = append(status.BurnRates, rate)
+
+		if lookback <= time.Hour && rate.BurnRate < s.options.FastBurnRate {
+			fastBurn = false
+		}
+		if lookback == time.Hour && rate.Requests < s.options.MinRequests {
+			fastBurn = false
+		}
+	}
+
+	switch {
+	case status.Requests < s.options.MinRequests:
+		// Too few requests to judge the objective
+	case status.BudgetRemaining <= 0:
+		status.State = domain.SLOStateExhausted
+	case fastBurn:
+		status.State = domain.SLOStateBurning
+	}
+
+	return status
+}
+
+// raiseAlert records and publishes the alert for an objective's new state,
+// unless another instance already raised it today
+func (s *SLOServiceImpl) raiseAlert(ctx context.Context, status *domain.SLOStatus) error {
+	ref := fmt.Sprintf("%s/%s/%s/%s", status.Group, status.Objective, status.State, status.EvaluatedAt.Format("2006-01-02"))
+	existing, err := s.repo.FindAlertByEvidence(ctx, domain.EvidenceTypeSLO, ref)
+	if err != nil {
+		return fmt.Errorf("failed to check for existing SLO alert: %w", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	alert := newSLOAlert(status, ref)
+	if err := s.repo.CreateAlert(ctx, alert); err != nil {
+		return fmt.Errorf("failed to create SLO alert: %w", err)
+	}
+	_ = s.producer.PublishAlert(ctx, alert)
+
+	return nil
+}
+
+// count returns the requests of the last window and how many of them were bad
+// for the objective
+func (g *sloGroup) count(objective string, window time.Duration, now time.Time) (requests, bad int64) {
+	minutes := int64(window / time.Minute)
+	if size := int64(len(g.buckets)); minutes > size {
+		minutes = size
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for minute := now.Unix() / 60; minutes > 0; minute, minutes = minute-1, minutes-1 {
+		b := g.buckets[minute%int64(len(g.buckets))]
+		if b.minute != minute {
+			continue
+		}
+		requests += b.requests
+		if objective == domain.SLOObjectiveLatency {
+			bad += b.slow
+		} else {
+			bad += b.errors
+		}
+	}
+	return requests, bad
+}
+
+// newSLOAlert builds the alert for an objective burning fast or out of budget
+func newSLOAlert(status *domain.SLOStatus, ref string) *domain.Alert {
+	title := fmt.Sprintf("%s error budget of the %s route group exhausted", status.Objective, status.Group)
+	severity := domain.AlertSeverityCritical
+	if status.State == domain.SLOStateBurning {
+		title = fmt.Sprintf("%s error budget of the %s route group burning fast", status.Objective, status.Group)
+		severity = domain.AlertSeverityWarning
+	}
+
+	objective := "answered without a server error"
+	if status.Objective == domain.SLOObjectiveLatency {
+		objective = fmt.Sprintf("answered within %dms", status.LatencyThresholdMs)
+	}
+
+	evidence := []domain.AlertEvidence{
+		{Type: domain.EvidenceTypeSLO, Value: ref},
+		{Type: "sli", Value: status.SLI, Threshold: status.Target},
+		{Type: "budget_remaining", Value: status.BudgetRemaining},
+	}
+	for _, rate := range status.BurnRates {
+		evidence = append(evidence, domain.AlertEvidence{Type: "burn_rate_" + rate.Window, Value: rate.BurnRate})
+	}
+
+	return &domain.Alert{
+		BaseEntity: domain.BaseEntity{
+			ID: uuid.New().String(),
+		},
+		Title: title,
+		Description: fmt.Sprintf("%.3f%% of %d requests over the last %s were %s, against an objective of %g%%",
+			status.SLI, status.Requests, status.Window, objective, status.Target),
+		Severity: string(severity),
+		Status:   string(domain.AlertStatusActive),
+		Category: domain.AlertCategorySLO,
+		Source:   "api-gateway/slo",
+		Evidence: evidence,
+	}
+}
+
+// sloSeverity orders SLO states from healthy to exhausted
+func sloSeverity(state domain.SLOState) int {
+	switch state {
+	case domain.SLOStateBurning:
+		return 1
+	case domain.SLOStateExhausted:
+		return 2
+	}
+	return 0
+}
+
+// formatSLOWindow formats a window in whole days, hours or minutes
+func formatSLOWindow(window time.Duration) string {
+	switch {
+	case window%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", window/(24*time.Hour))
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%dh", window/time.Hour)
+	}
+	return fmt.Sprintf("%dm", window/time.Minute)
+}
+
+// Ensure the SLOServiceImpl implements the SLOService interface
+var _ ports.SLOService = (*SLOServiceImpl)(nil)
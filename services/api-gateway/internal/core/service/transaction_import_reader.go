@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+)
+
+// maxImportLineBytes bounds a single NDJSON line so a malformed upload cannot
+// make the gateway buffer it whole
+const maxImportLineBytes = 1 << 20
+
+// rowReader streams the rows of a transaction import
+type rowReader interface {
+	// Next returns the fields of the next row and its line number, or io.EOF
+	// after the last row. A *malformedRowError affects only that row; any
+	// other error means the rest of the upload cannot be read.
+	Next() (map[string]string, int, error)
+}
+
+// malformedRowError reports a row that could not be split into fields
+type malformedRowError struct {
+	message string
+}
+
+func (e *malformedRowError) Error() string {
+	return e.message
+}
+
+func newRowReader(format string, r io.Reader) (rowReader, error) {
+	switch format {
+	case domain.ImportFormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+		return &ndjsonReader{scanner: scanner}, nil
+	case domain.ImportFormatCSV:
+		return newCSVReader(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedImportFormat, format)
+	}
+}
+
+// ndjsonReader reads one JSON object per line. Blank lines are skipped.
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonReader) Next() (map[string]string, int, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil || decoder.More() {
+			return nil, r.line, &malformedRowError{message: "line is not a JSON object"}
+		}
+
+		fields := make(map[string]string, len(object))
+		for key, value := range object {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				fields[key] = v
+			case json.Number:
+				fields[key] = v.String()
+			case bool:
+				fields[key] = strconv.FormatBool(v)
+			default:
+				return nil, r.line, &malformedRowError{message: key + " must be a string or number"}
+			}
+		}
+		return fields, r.line, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, r.line + 1, fmt.Errorf("%w: line %d is longer than %d bytes", domain.ErrInvalidImport, r.line+1, maxImportLineBytes)
+		}
+		return nil, r.line, err
+	}
+	return nil, r.line, io.EOF
+}
+
+// csvReader reads a CSV file whose header row names the columns
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: CSV file is empty", domain.ErrInvalidImport)
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImport, parseErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(header))
+	present := make(map[string]bool, len(header))
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(name))
+		present[columns[i]] = true
+	}
+	for _, required := range domain.RequiredTransactionColumns {
+		if !present[required] {
+			return nil, fmt.Errorf("%w: CSV header has no %s column", domain.ErrInvalidImport, required)
+		}
+	}
+
+	return &csvReader{reader: reader, header: columns}, nil
+}
+
+func (r *csvReader) Next() (map[string]string, int, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, 0, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		if errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			return nil, parseErr.StartLine, &malformedRowError{
+				message: fmt.Sprintf("row has %d fields, header has %d", len(record), len(r.header)),
+			}
+		}
+		return nil, parseErr.StartLine, fmt.Errorf("%w: %v", domain.ErrInvalidImport, parseErr)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	fields := make(map[string]string, len(r.header))
+	for i, value := range record {
+		fields[r.header[i]] = value
+	}
+	return fields, line, nil
+}
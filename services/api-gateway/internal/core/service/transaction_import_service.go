@@ -0,0 +1,427 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/shopspring/decimal"
+)
+
+// TransactionImportOptions contains the limits and compliance thresholds for
+// transaction imports
+type TransactionImportOptions struct {
+	MaxRows             int           // rows allowed in one upload
+	MaxRowErrors        int           // row errors kept per import; further rejected rows are only counted
+	BatchSize           int           // rows stored or checked at a time
+	Workers             int           // concurrent check workers
+	MaxCheckAttempts    int           // attempts before a row's checks are reported as failed
+	CheckLease          time.Duration // how long a claimed row is left to a worker before another may take it
+	PollInterval        time.Duration // how often workers look for imports left by other instances
+	LargeTransactionUSD float64       // rows at or above this USD value are flagged; 0 disables the check
+}
+
+// TransactionImportServiceImpl receives exchanges' transaction reports and runs
+// each accepted transaction through compliance checks in the background
+type TransactionImportServiceImpl struct {
+	repo     ports.Repository
+	screener ports.SanctionsScreener
+	producer ports.MessageProducer
+	opts     TransactionImportOptions
+	wake     chan struct{}
+}
+
+// NewTransactionImportService creates a new transaction import service instance
+func NewTransactionImportService(
+	repo ports.Repository,
+	screener ports.SanctionsScreener,
+	producer ports.MessageProducer,
+	opts TransactionImportOptions,
+) *TransactionImportServiceImpl {
+	return &TransactionImportServiceImpl{
+		repo:     repo,
+		screener: screener,
+		producer: producer,
+		opts:     opts,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Import reads an upload row by row, storing the valid rows and recording a
+// row error for each invalid one. Nothing is kept if the upload as a whole
+// cannot be read. Accepted rows are checked after Import returns.
+func (s *TransactionImportServiceImpl) Import(ctx context.Context, exchangeID, format string, body io.Reader, submittedBy string) (*domain.TransactionImport, error) {
+	if _, err := s.repo.GetExchangeByID(ctx, exchangeID); err != nil {
+		return nil, err
+	}
+
+	reader, err := newRowReader(format, body)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &domain.TransactionImport{
+		ExchangeID:  exchangeID,
+		Format:      format,
+		Status:      string(domain.TransactionImportStatusReceiving),
+		SubmittedBy: submittedBy,
+	}
+	if err := s.repo.CreateTransactionImport(ctx, imp); err != nil {
+		return nil, fmt.Errorf("failed to create transaction import: %w", err)
+	}
+
+	if err := s.receive(ctx, imp, reader); err != nil {
+		// The client may be gone, but the import must still be closed
+		cleanupCtx := context.WithoutCancel(ctx)
+		imp.Status = string(domain.TransactionImportStatusFailed)
+		imp.Error = err.Error()
+		imp.AcceptedRows = 0
+		if delErr := s.repo.DeleteReportedTransactions(cleanupCtx, imp.ID); delErr != nil {
+			return imp, fmt.Errorf("%w (and failed to discard stored rows: %v)", err, delErr)
+		}
+		_ = s.repo.UpdateTransactionImport(cleanupCtx, imp)
+		return imp, err
+	}
+
+	imp.Status = string(domain.TransactionImportStatusChecking)
+	if err := s.repo.UpdateTransactionImport(ctx, imp); err != nil {
+		return nil, fmt.Errorf("failed to update transaction import: %w", err)
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return imp, nil
+}
+
+// receive streams the upload into the import, flushing accepted rows and row
+// errors in batches
+func (s *TransactionImportServiceImpl) receive(ctx context.Context, imp *domain.TransactionImport, reader rowReader) error {
+	batch := make([]*domain.ReportedTransaction, 0, s.opts.BatchSize)
+	var rowErrors []*domain.ImportRowError
+	keptErrors := 0
+
+	reject := func(row int, errs ...*domain.ImportRowError) {
+		imp.RejectedRows++
+		for _, rowErr := range errs {
+			if keptErrors >= s.opts.MaxRowErrors {
+				return
+			}
+			rowErr.ImportID = imp.ID
+			rowErr.Row = row
+			rowErrors = append(rowErrors, rowErr)
+			keptErrors++
+		}
+	}
+
+	flush := func() error {
+		if len(batch) > 0 {
+			stored, err := s.repo.CreateReportedTransactions(ctx, batch)
+			if err != nil {
+				return fmt.Errorf("failed to store transactions: %w", err)
+			}
+			imp.AcceptedRows += len(stored)
+
+			storedRows := make(map[int]bool, len(stored))
+			for _, row := range stored {
+				storedRows[row] = true
+			}
+			for _, tx := range batch {
+				if !storedRows[tx.RowNumber] {
+					reject(tx.RowNumber, &domain.ImportRowError{
+						Stage:   domain.ImportStageValidation,
+						Field:   "transaction_id",
+						Code:    domain.ImportErrorDuplicate,
+						Message: "transaction " + tx.TransactionID + " was already reported",
+					})
+				}
+			}
+			batch = batch[:0]
+		}
+
+		if len(rowErrors) > 0 {
+			if err := s.repo.CreateImportRowErrors(ctx, rowErrors); err != nil {
+				return fmt.Errorf("failed to store row errors: %w", err)
+			}
+			rowErrors = rowErrors[:0]
+		}
+		return nil
+	}
+
+	now := time.Now()
+	for {
+		fields, row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		var malformed *malformedRowError
+		if err != nil && !errors.As(err, &malformed) {
+			return err
+		}
+
+		imp.TotalRows++
+		if s.opts.MaxRows > 0 && imp.TotalRows > s.opts.MaxRows {
+			return fmt.Errorf("%w: more than %d rows", domain.ErrImportTooLarge, s.opts.MaxRows)
+		}
+
+		if malformed != nil {
+			reject(row, &domain.ImportRowError{
+				Stage:   domain.ImportStageValidation,
+				Code:    domain.ImportErrorMalformedRow,
+				Message: malformed.Error(),
+			})
+		} else if tx, errs := domain.ParseReportedTransaction(fields, now); len(errs) > 0 {
+			reject(row, errs...)
+		} else {
+			tx.ImportID = imp.ID
+			tx.ExchangeID = imp.ExchangeID
+			tx.RowNumber = row
+			batch = append(batch, tx)
+		}
+
+		if len(batch) >= s.opts.BatchSize || len(rowErrors) >= s.opts.BatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush()
+}
+
+// GetImport returns an import of the exchange
+func (s *TransactionImportServiceImpl) GetImport(ctx context.Context, exchangeID, importID string) (*domain.TransactionImport, error) {
+	imp, err := s.repo.GetTransactionImport(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	if imp.ExchangeID != exchangeID {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTransactionImportNotFound, importID)
+	}
+	return imp, nil
+}
+
+// GetImportErrors returns a page of an import's row errors, ordered by row
+func (s *TransactionImportServiceImpl) GetImportErrors(ctx context.Context, exchangeID, importID string, page, pageSize int) (*domain.PaginatedResponse, error) {
+	if _, err := s.GetImport(ctx, exchangeID, importID); err != nil {
+		return nil, err
+	}
+
+	rowErrors, err := s.repo.GetImportRowErrors(ctx, importID, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get row errors: %w", err)
+	}
+
+	total, err := s.repo.CountImportRowErrors(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPaginatedResponse(rowErrors, page, pageSize, total), nil
+}
+
+// StartWorkers runs the compliance check workers until ctx is cancelled. Rows
+// are claimed with a lease, so imports received by other instances, or left
+// behind by an instance that stopped, are checked too.
+func (s *TransactionImportServiceImpl) StartWorkers(ctx context.Context, onError func(error)) {
+	for i := 0; i < s.opts.Workers; i++ {
+		go s.runWorker(ctx, onError)
+	}
+}
+
+func (s *TransactionImportServiceImpl) runWorker(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.checkPending(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkPending checks claimed batches of every checking import until no rows
+// are left to claim. An import with a row released for another attempt is left
+// until the next round, so retries are spaced by the poll interval.
+func (s *TransactionImportServiceImpl) checkPending(ctx context.Context) error {
+	imports, err := s.repo.GetTransactionImportsByStatus(ctx, string(domain.TransactionImportStatusChecking), 100)
+	if err != nil {
+		return fmt.Errorf("failed to get checking imports: %w", err)
+	}
+
+	for _, imp := range imports {
+		for ctx.Err() == nil {
+			txs, err := s.repo.ClaimReportedTransactions(ctx, imp.ID, s.opts.BatchSize, s.opts.CheckLease)
+			if err != nil {
+				return fmt.Errorf("failed to claim transactions of import %s: %w", imp.ID, err)
+			}
+
+			released := false
+			for _, tx := range txs {
+				retry, err := s.checkTransaction(ctx, tx)
+				if err != nil {
+					return err
+				}
+				released = released || retry
+			}
+
+			completed, err := s.repo.SyncTransactionImportChecks(ctx, imp.ID)
+			if err != nil {
+				return fmt.Errorf("failed to update import %s: %w", imp.ID, err)
+			}
+			if completed {
+				s.publishCompleted(ctx, imp.ID)
+			}
+			if len(txs) == 0 || released {
+				break
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+// checkTransaction runs a claimed transaction through the compliance checks
+// and records the outcome. A transaction whose checks fail is released for
+// another attempt until MaxCheckAttempts is reached; retry reports whether it
+// was released.
+func (s *TransactionImportServiceImpl) checkTransaction(ctx context.Context, tx *domain.ReportedTransaction) (retry bool, err error) {
+	findings, checkErr := s.runChecks(ctx, tx)
+	tx.CheckAttempts++
+
+	switch {
+	case checkErr != nil && tx.CheckAttempts < s.opts.MaxCheckAttempts:
+		tx.CheckStatus = string(domain.CheckStatusPending)
+		findings = nil
+	case checkErr != nil:
+		tx.CheckStatus = string(domain.CheckStatusFailed)
+		findings = []*domain.ImportRowError{{
+			Code:    domain.ImportErrorCheckFailed,
+			Message: "compliance checks could not be completed: " + checkErr.Error(),
+		}}
+	case len(findings) > 0:
+		tx.CheckStatus = string(domain.CheckStatusFlagged)
+	default:
+		tx.CheckStatus = string(domain.CheckStatusPassed)
+	}
+
+	if tx.CheckStatus != string(domain.CheckStatusPending) {
+		now := time.Now().UTC()
+		tx.CheckedAt = &now
+	}
+	for _, finding := range findings {
+		finding.ImportID = tx.ImportID
+		finding.Row = tx.RowNumber
+		finding.Stage = domain.ImportStageCompliance
+	}
+
+	if err := s.repo.SaveTransactionCheck(ctx, tx, findings); err != nil {
+		return false, fmt.Errorf("failed to save checks of transaction %s: %w", tx.ID, err)
+	}
+
+	if tx.CheckStatus == string(domain.CheckStatusPassed) || tx.CheckStatus == string(domain.CheckStatusFlagged) {
+		_ = s.producer.PublishTransaction(ctx, tx)
+	}
+	return tx.CheckStatus == string(domain.CheckStatusPending), nil
+}
+
+// runChecks screens the transaction's addresses, checks the status of wallets
+// known to the platform and flags large transactions
+func (s *TransactionImportServiceImpl) runChecks(ctx context.Context, tx *domain.ReportedTransaction) ([]*domain.ImportRowError, error) {
+	var findings []*domain.ImportRowError
+
+	addresses := []struct{ field, address string }{
+		{"from_address", tx.FromAddress},
+		{"to_address", tx.ToAddress},
+	}
+	for _, a := range addresses {
+		if a.address == "" {
+			continue
+		}
+
+		sanctioned, err := s.screener.IsSanctioned(ctx, a.address)
+		if err != nil {
+			return nil, fmt.Errorf("sanctions screening failed: %w", err)
+		}
+		if sanctioned {
+			findings = append(findings, &domain.ImportRowError{
+				Field:   a.field,
+				Code:    domain.ImportErrorSanctionedAddress,
+				Message: a.address + " is on a sanctions list",
+			})
+		}
+
+		wallet, err := s.repo.GetWalletByAddress(ctx, a.address)
+		if err != nil {
+			return nil, err
+		}
+		if wallet == nil {
+			continue
+		}
+		switch domain.WalletStatus(wallet.Status) {
+		case domain.WalletStatusBlacklisted:
+			findings = append(findings, &domain.ImportRowError{
+				Field:   a.field,
+				Code:    domain.ImportErrorBlacklistedWallet,
+				Message: a.address + " is blacklisted",
+			})
+		case domain.WalletStatusFrozen:
+			findings = append(findings, &domain.ImportRowError{
+				Field:   a.field,
+				Code:    domain.ImportErrorFrozenWallet,
+				Message: a.address + " is frozen",
+			})
+		}
+	}
+
+	threshold := decimal.NewFromFloat(s.opts.LargeTransactionUSD)
+	if threshold.IsPositive() && tx.AmountUSD.GreaterThanOrEqual(threshold) {
+		findings = append(findings, &domain.ImportRowError{
+			Field:   "amount_usd",
+			Code:    domain.ImportErrorLargeTransaction,
+			Message: fmt.Sprintf("amount of %s USD is at or above the %s USD reporting threshold", tx.AmountUSD.StringFixed(2), threshold.StringFixed(2)),
+		})
+	}
+
+	return findings, nil
+}
+
+func (s *TransactionImportServiceImpl) publishCompleted(ctx context.Context, importID string) {
+	imp, err := s.repo.GetTransactionImport(ctx, importID)
+	if err != nil {
+		return
+	}
+
+	event := map[string]interface{}{
+		"event_type":    "TRANSACTION_IMPORT_COMPLETED",
+		"import_id":     imp.ID,
+		"exchange_id":   imp.ExchangeID,
+		"total_rows":    imp.TotalRows,
+		"accepted_rows": imp.AcceptedRows,
+		"rejected_rows": imp.RejectedRows,
+		"flagged_rows":  imp.FlaggedRows,
+		"failed_rows":   imp.FailedRows,
+		"timestamp":     time.Now().UTC().Format(time.RFC3339),
+	}
+
+	data, _ := json.Marshal(event)
+	_ = s.producer.Publish(ctx, "csic.exchange_events", data)
+}
+
+// Ensure the TransactionImportServiceImpl implements the TransactionImportService interface
+var _ ports.TransactionImportService = (*TransactionImportServiceImpl)(nil)
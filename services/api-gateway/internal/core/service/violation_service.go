@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// AutoFreezePolicy decides which violations trigger automatic freeze orders
+type AutoFreezePolicy struct {
+	Enabled          bool
+	Severities       []string
+	ViolationTypes   []string
+	SuspendExchanges bool
+	ActorID          string
+}
+
+// Applies reports whether the policy requires a freeze order for the event.
+// An empty violation type list applies the policy to every violation type.
+func (p AutoFreezePolicy) Applies(event *domain.ComplianceViolationEvent) bool {
+	if !p.Enabled || !containsFold(p.Severities, event.Severity) {
+		return false
+	}
+	return len(p.ViolationTypes) == 0 || containsFold(p.ViolationTypes, event.ViolationType)
+}
+
+// ViolationServiceImpl turns compliance violation events into alerts linked to
+// the affected wallets and exchanges
+type ViolationServiceImpl struct {
+	repo     ports.Repository
+	gateway  ports.GatewayService
+	producer ports.MessageProducer
+	policy   AutoFreezePolicy
+}
+
+// NewViolationService creates a new violation service instance
+func NewViolationService(
+	repo ports.Repository,
+	gateway ports.GatewayService,
+	producer ports.MessageProducer,
+	policy AutoFreezePolicy,
+) *ViolationServiceImpl {
+	if policy.ActorID == "" {
+		policy.ActorID = "system:compliance"
+	}
+	return &ViolationServiceImpl{
+		repo:     repo,
+		gateway:  gateway,
+		producer: producer,
+		policy:   policy,
+	}
+}
+
+// HandleViolation creates an alert for a violation event. Events are delivered at
+// least once, so a violation that already has an alert reuses it, only retrying
+// freeze orders that a previous delivery failed to record.
+func (s *ViolationServiceImpl) HandleViolation(ctx context.Context, event *domain.ComplianceViolationEvent) (*domain.Alert, error) {
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	alert, err := s.repo.FindAlertByEvidence(ctx, domain.EvidenceTypeViolation, event.ViolationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing alert: %w", err)
+	}
+	freeze := s.policy.Applies(event)
+	if alert != nil && (!freeze || hasEvidence(alert, domain.EvidenceTypeFreezeOrder)) {
+		return alert, nil
+	}
+
+	wallets, err := s.linkedWallets(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	exchange := s.linkedExchange(ctx, event)
+
+	if alert == nil {
+		alert = newViolationAlert(event)
+		for _, wallet := range wallets {
+			alert.Evidence = append(alert.Evidence, domain.AlertEvidence{
+				Type:  domain.EvidenceTypeWallet,
+				Value: wallet.ID,
+			})
+		}
+		if exchange != nil {
+			alert.Evidence = append(alert.Evidence, domain.AlertEvidence{
+				Type:  domain.EvidenceTypeExchange,
+				Value: exchange.ID,
+			})
+		}
+
+		if err := s.repo.CreateAlert(ctx, alert); err != nil {
+			return nil, fmt.Errorf("failed to create alert: %w", err)
+		}
+		_ = s.producer.PublishAlert(ctx, alert)
+	}
+
+	if freeze {
+		if err := s.issueFreezeOrders(ctx, alert, event, wallets, exchange); err != nil {
+			return alert, err
+		}
+	}
+
+	return alert, nil
+}
+
+// issueFreezeOrders freezes the linked wallets, and suspends the linked exchange when
+// the policy allows it, recording each action as evidence on the alert
+func (s *ViolationServiceImpl) issueFreezeOrders(
+	ctx context.Context,
+	alert *domain.Alert,
+	event *domain.ComplianceViolationEvent,
+	wallets []*domain.Wallet,
+	exchange *domain.Exchange,
+) error {
+	reason := fmt.Sprintf("Automatic freeze for %s violation %s", event.Severity, violationRef(event))
+	var orders []domain.AlertEvidence
+
+	for _, wallet := range wallets {
+		if wallet.Status == string(domain.WalletStatusFrozen) {
+			continue
+		}
+		if err := s.gateway.FreezeWallet(ctx, wallet.ID, reason, s.policy.ActorID); err != nil {
+			return fmt.Errorf("failed to freeze wallet %s: %w", wallet.ID, err)
+		}
+		orders = append(orders, freezeOrderEvidence(domain.EvidenceTypeWallet, wallet.ID, reason))
+	}
+
+	if exchange != nil && s.policy.SuspendExchanges && exchange.Status != string(domain.ExchangeStatusSuspended) {
+		if err := s.gateway.SuspendExchange(ctx, exchange.ID, reason, s.policy.ActorID); err != nil {
+			return fmt.Errorf("failed to suspend exchange %s: %w", exchange.ID, err)
+		}
+		orders = append(orders, freezeOrderEvidence(domain.EvidenceTypeExchange, exchange.ID, reason))
+	}
+
+	if len(orders) == 0 {
+		return nil
+	}
+
+	alert.Evidence = append(alert.Evidence, orders...)
+	if err := s.repo.UpdateAlert(ctx, alert); err != nil {
+		return fmt.Errorf("failed to record freeze orders on alert: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"event_type":   "FREEZE_ORDERS_ISSUED",
+		"alert_id":     alert.ID,
+		"violation_id": event.ViolationID,
+		"orders":       orders,
+		"issued_by":    s.policy.ActorID,
+		"timestamp":    time.Now().UTC().Format(time.RFC3339),
+	}
+
+	data, _ := json.Marshal(payload)
+	_ = s.producer.Publish(ctx, "csic.enforcement_events", data)
+
+	return nil
+}
+
+// linkedWallets resolves the violation's wallet addresses, skipping unknown ones
+func (s *ViolationServiceImpl) linkedWallets(ctx context.Context, event *domain.ComplianceViolationEvent) ([]*domain.Wallet, error) {
+	var wallets []*domain.Wallet
+	seen := make(map[string]bool)
+
+	for _, address := range event.WalletAddresses {
+		address = strings.TrimSpace(address)
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		wallet, err := s.repo.GetWalletByAddress(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up wallet %s: %w", address, err)
+		}
+		if wallet != nil {
+			wallets = append(wallets, wallet)
+		}
+	}
+
+	return wallets, nil
+}
+
+// linkedExchange resolves the exchange the violation concerns. The repository
+// reports a missing exchange as an error, so lookup failures leave the alert unlinked.
+func (s *ViolationServiceImpl) linkedExchange(ctx context.Context, event *domain.ComplianceViolationEvent) *domain.Exchange {
+	exchangeID := event.LinkedExchangeID()
+	if exchangeID == "" {
+		return nil
+	}
+
+	exchange, err := s.repo.GetExchangeByID(ctx, exchangeID)
+	if err != nil {
+		return nil
+	}
+	return exchange
+}
+
+// newViolationAlert builds the alert for a violation event
+func newViolationAlert(event *domain.ComplianceViolationEvent) *domain.Alert {
+	title := event.Title
+	if title == "" {
+		title = fmt.Sprintf("%s compliance violation", event.ViolationType)
+	}
+	if event.EntityName != "" {
+		title = fmt.Sprintf("%s: %s", event.EntityName, title)
+	}
+
+	source := "compliance"
+	if event.DetectionSource != "" {
+		source = "compliance/" + event.DetectionSource
+	}
+
+	evidence := []domain.AlertEvidence{
+		{Type: domain.EvidenceTypeViolation, Value: event.ViolationID},
+		{Type: "violation_severity", Value: event.Severity},
+	}
+	if event.ViolationNumber != "" {
+		evidence = append(evidence, domain.AlertEvidence{Type: "violation_number", Value: event.ViolationNumber})
+	}
+	if event.ViolationType != "" {
+		evidence = append(evidence, domain.AlertEvidence{Type: "violation_type", Value: event.ViolationType})
+	}
+	if event.LicenseID != "" {
+		evidence = append(evidence, domain.AlertEvidence{Type: "license", Value: event.LicenseID})
+	}
+	if event.RegulatoryRef != "" {
+		evidence = append(evidence, domain.AlertEvidence{Type: "regulatory_ref", Value: event.RegulatoryRef})
+	}
+
+	return &domain.Alert{
+		BaseEntity: domain.BaseEntity{
+			ID: uuid.New().String(),
+		},
+		Title:       title,
+		Description: event.Description,
+		Severity:    string(event.AlertSeverity()),
+		Status:      string(domain.AlertStatusActive),
+		Category:    domain.AlertCategoryComplianceViolation,
+		Source:      source,
+		Evidence:    evidence,
+	}
+}
+
+func freezeOrderEvidence(targetType, targetID, reason string) domain.AlertEvidence {
+	return domain.AlertEvidence{
+		Type: domain.EvidenceTypeFreezeOrder,
+		Value: map[string]interface{}{
+			"target_type": targetType,
+			"target_id":   targetID,
+			"reason":      reason,
+			"issued_at":   time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+func hasEvidence(alert *domain.Alert, evidenceType string) bool {
+	for _, e := range alert.Evidence {
+		if e.Type == evidenceType {
+			return true
+		}
+	}
+	return false
+}
+
+func violationRef(event *domain.ComplianceViolationEvent) string {
+	if event.ViolationNumber != "" {
+		return event.ViolationNumber
+	}
+	return event.ViolationID
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure the ViolationServiceImpl implements the ViolationEventHandler interface
+var _ ports.ViolationEventHandler = (*ViolationServiceImpl)(nil)
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// webhookSecretPrefix marks generated webhook signing secrets
+const webhookSecretPrefix = "whsec"
+
+// minWebhookSecretLength is the shortest signing secret a partner may supply
+const minWebhookSecretLength = 16
+
+// Headers sent with every webhook delivery
+const (
+	WebhookHeaderSignature = "X-CSIC-Signature"
+	WebhookHeaderEvent     = "X-CSIC-Event"
+	WebhookHeaderEventID   = "X-CSIC-Event-ID"
+	WebhookHeaderDelivery  = "X-CSIC-Delivery"
+)
+
+// WebhookOptions contains the delivery and retry settings for webhooks
+type WebhookOptions struct {
+	Workers        int           // concurrent delivery workers
+	BatchSize      int           // deliveries claimed at a time
+	MaxAttempts    int           // attempts before a delivery is marked failed
+	InitialBackoff time.Duration // delay before the first retry, doubled on each further retry
+	MaxBackoff     time.Duration // longest delay between retries
+	Lease          time.Duration // how long a claimed delivery is left to a worker before another may take it
+	PollInterval   time.Duration // how often workers look for due retries
+
+	// AllowHTTP accepts plain http endpoint URLs. It is for development and
+	// tests only; otherwise endpoints must use https.
+	AllowHTTP bool
+}
+
+// WebhookServiceImpl notifies partner agencies' endpoints of events such as
+// license revocations and wallet freezes. Each event is queued once per
+// subscribed endpoint and delivered in the background with an HMAC-SHA256
+// signature, so a slow or failing endpoint never holds up the event source.
+type WebhookServiceImpl struct {
+	repo   ports.Repository
+	sender ports.WebhookSender
+	opts   WebhookOptions
+	wake   chan struct{}
+}
+
+// NewWebhookService creates a new webhook service instance
+func NewWebhookService(repo ports.Repository, sender ports.WebhookSender, opts WebhookOptions) *WebhookServiceImpl {
+	return &WebhookServiceImpl{
+		repo:   repo,
+		sender: sender,
+		opts:   opts,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// CreateEndpoint registers a webhook endpoint and returns its signing secret.
+// A secret is generated unless the partner supplied one.
+func (s *WebhookServiceImpl) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, userID string) (string, error) {
+	if endpoint.Name == "" {
+		return "", fmt.Errorf("%w: name is required", domain.ErrInvalidWebhookSpec)
+	}
+	if err := domain.ValidateWebhookURL(endpoint.URL, s.opts.AllowHTTP); err != nil {
+		return "", err
+	}
+	if len(endpoint.EventTypes) == 0 {
+		return "", fmt.Errorf("%w: at least one event type is required", domain.ErrInvalidWebhookSpec)
+	}
+	if err := domain.ValidateWebhookEventTypes(endpoint.EventTypes); err != nil {
+		return "", err
+	}
+
+	if endpoint.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return "", err
+		}
+		endpoint.Secret = secret
+	} else if len(endpoint.Secret) < minWebhookSecretLength {
+		return "", fmt.Errorf("%w: secret must be at least %d characters", domain.ErrInvalidWebhookSpec, minWebhookSecretLength)
+	}
+
+	endpoint.Active = true
+	endpoint.CreatedBy = userID
+	if err := s.repo.CreateWebhookEndpoint(ctx, endpoint); err != nil {
+		return "", fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return endpoint.Secret, nil
+}
+
+// GetEndpoints returns a paginated list of webhook endpoints
+func (s *WebhookServiceImpl) GetEndpoints(ctx context.Context, page, pageSize int) (*domain.PaginatedResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	endpoints, err := s.repo.GetWebhookEndpoints(ctx, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook endpoints: %w", err)
+	}
+
+	total, err := s.repo.CountWebhookEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPaginatedResponse(endpoints, page, pageSize, total), nil
+}
+
+// GetEndpointByID returns a specific webhook endpoint by ID
+func (s *WebhookServiceImpl) GetEndpointByID(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	return s.repo.GetWebhookEndpointByID(ctx, id)
+}
+
+// UpdateEndpoint changes an endpoint's name, URL or subscriptions, or pauses
+// and resumes it. Deliveries already queued are still sent to a paused endpoint.
+func (s *WebhookServiceImpl) UpdateEndpoint(ctx context.Context, id string, update *domain.WebhookEndpointUpdate) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetWebhookEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		if *update.Name == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", domain.ErrInvalidWebhookSpec)
+		}
+		endpoint.Name = *update.Name
+	}
+	if update.URL != nil {
+		if err := domain.ValidateWebhookURL(*update.URL, s.opts.AllowHTTP); err != nil {
+			return nil, err
+		}
+		endpoint.URL = *update.URL
+	}
+	if update.EventTypes != nil {
+		if len(update.EventTypes) == 0 {
+			return nil, fmt.Errorf("%w: at least one event type is required", domain.ErrInvalidWebhookSpec)
+		}
+		if err := domain.ValidateWebhookEventTypes(update.EventTypes); err != nil {
+			return nil, err
+		}
+		endpoint.EventTypes = update.EventTypes
+	}
+	if update.Active != nil {
+		endpoint.Active = *update.Active
+	}
+
+	if err := s.repo.UpdateWebhookEndpoint(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// RotateEndpointSecret replaces an endpoint's signing secret with a generated
+// one. Deliveries are signed with the new secret from their next attempt.
+func (s *WebhookServiceImpl) RotateEndpointSecret(ctx context.Context, id string) (*domain.WebhookEndpoint, string, error) {
+	endpoint, err := s.repo.GetWebhookEndpointByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	endpoint.Secret = secret
+
+	if err := s.repo.UpdateWebhookEndpoint(ctx, endpoint); err != nil {
+		return nil, "", fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+	return endpoint, secret, nil
+}
+
+// DeleteEndpoint removes an endpoint together with its delivery log
+func (s *WebhookServiceImpl) DeleteEndpoint(ctx context.Context, id string) error {
+	return s.repo.DeleteWebhookEndpoint(ctx, id)
+}
+
+// GetDeliveries returns a page of the delivery log, newest first, optionally
+// filtered by endpoint and status
+func (s *WebhookServiceImpl) GetDeliveries(ctx context.Context, endpointID, status string, page, pageSize int) (*domain.PaginatedResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	deliveries, err := s.repo.GetWebhookDeliveries(ctx, endpointID, status, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	total, err := s.repo.CountWebhookDeliveries(ctx, endpointID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPaginatedResponse(deliveries, page, pageSize, total), nil
+}
+
+// GetDeliveryByID returns a specific webhook delivery by ID
+func (s *WebhookServiceImpl) GetDeliveryByID(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	return s.repo.GetWebhookDeliveryByID(ctx, id)
+}
+
+// RedeliverDelivery queues a finished delivery to be sent again with a fresh
+// set of attempts
+func (s *WebhookServiceImpl) RedeliverDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	delivery, err := s.repo.GetWebhookDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch domain.WebhookDeliveryStatus(delivery.Status) {
+	case domain.WebhookDeliverySucceeded, domain.WebhookDeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: %s is %s", domain.ErrWebhookDeliveryInProgress, id, delivery.Status)
+	}
+
+	now := time.Now()
+	delivery.Status = string(domain.WebhookDeliveryPending)
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	delivery.DeliveredAt = nil
+
+	if err := s.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	s.notify()
+
+	return delivery, nil
+}
+
+// HandleWebhookEvent queues an event for every active endpoint subscribed to
+// its type. Handling the same event again queues nothing new.
+func (s *WebhookServiceImpl) HandleWebhookEvent(ctx context.Context, event *domain.WebhookEvent) error {
+	if err := domain.ValidateWebhookEventTypes([]string{event.Type}); err != nil {
+		return err
+	}
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	endpoints, err := s.repo.GetWebhookSubscribers(ctx, event.Type)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook subscribers: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]*domain.WebhookDelivery, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			ID:            uuid.New().String(),
+			EndpointID:    endpoint.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        string(domain.WebhookDeliveryPending),
+			NextAttemptAt: &now,
+		})
+	}
+	if err := s.repo.CreateWebhookDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	s.notify()
+
+	return nil
+}
+
+// StartDispatcher starts the delivery workers; they stop when ctx is cancelled
+func (s *WebhookServiceImpl) StartDispatcher(ctx context.Context, onError func(error)) {
+	for i := 0; i < s.opts.Workers; i++ {
+		go s.runWorker(ctx, onError)
+	}
+}
+
+func (s *WebhookServiceImpl) runWorker(ctx context.Context, onError func(error)) {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.deliverDue(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue sends claimed batches of due deliveries until none are left
+func (s *WebhookServiceImpl) deliverDue(ctx context.Context) error {
+	for ctx.Err() == nil {
+		deliveries, err := s.repo.ClaimWebhookDeliveries(ctx, s.opts.BatchSize, s.opts.Lease)
+		if err != nil {
+			return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		var errs []error
+		for _, delivery := range deliveries {
+			if err := s.deliver(ctx, delivery); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver makes one attempt at a delivery and records the outcome, scheduling
+// a retry if attempts remain
+func (s *WebhookServiceImpl) deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	endpoint, err := s.repo.GetWebhookEndpointByID(ctx, delivery.EndpointID)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint of webhook delivery %s: %w", delivery.ID, err)
+	}
+
+	timestamp := time.Now().Unix()
+	headers := map[string]string{
+		"Content-Type":         "application/json",
+		WebhookHeaderSignature: SignWebhookPayload(endpoint.Secret, timestamp, delivery.Payload),
+		WebhookHeaderEvent:     delivery.EventType,
+		WebhookHeaderEventID:   delivery.EventID,
+		WebhookHeaderDelivery:  delivery.ID,
+	}
+	result := s.sender.Send(ctx, endpoint.URL, delivery.Payload, headers)
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = result.StatusCode
+	delivery.LastDurationMs = result.Duration.Milliseconds()
+	delivery.LastError = ""
+	switch {
+	case result.Succeeded():
+		delivery.Status = string(domain.WebhookDeliverySucceeded)
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.opts.MaxAttempts:
+		delivery.Status = string(domain.WebhookDeliveryFailed)
+		delivery.NextAttemptAt = nil
+	default:
+		retryAt := now.Add(s.backoff(delivery.Attempts))
+		delivery.Status = string(domain.WebhookDeliveryPending)
+		delivery.NextAttemptAt = &retryAt
+	}
+	if !result.Succeeded() {
+		if result.Err != nil {
+			delivery.LastError = result.Err.Error()
+		} else {
+			delivery.LastError = fmt.Sprintf("endpoint responded with status %d", result.StatusCode)
+		}
+	}
+
+	if err := s.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to update webhook delivery %s: %w", delivery.ID, err)
+	}
+	return nil
+}
+
+// backoff returns the delay before the retry following the given attempt
+func (s *WebhookServiceImpl) backoff(attempts int) time.Duration {
+	delay := s.opts.InitialBackoff
+	for i := 1; i < attempts && delay < s.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.opts.MaxBackoff {
+		delay = s.opts.MaxBackoff
+	}
+	return delay
+}
+
+func (s *WebhookServiceImpl) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// SignWebhookPayload returns the signature header value for a delivery body:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">". Receivers recompute
+// the HMAC with their secret and should reject stale timestamps to prevent replays.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	ts := strconv.FormatInt(timestamp, 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateWebhookSecret returns a new random signing secret
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + "_" + base64.RawURLEncoding.EncodeToString(secret), nil
+}
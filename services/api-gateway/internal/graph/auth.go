@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// ErrForbidden is returned for fields the caller may not read. The field
+// resolves to null and the rest of the query is still answered.
+var ErrForbidden = errors.New("forbidden")
+
+// Viewer is the authenticated caller of a GraphQL request
+type Viewer struct {
+	UserID string
+	Role   string
+	// APIKey is set for machine clients, which are limited to Scopes
+	APIKey bool
+	Scopes []string
+}
+
+type viewerKey struct{}
+
+// WithViewer returns a context carrying the caller
+func WithViewer(ctx context.Context, viewer Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer)
+}
+
+// ViewerFromContext returns the caller of the request, if authenticated
+func ViewerFromContext(ctx context.Context) (Viewer, bool) {
+	viewer, ok := ctx.Value(viewerKey{}).(Viewer)
+	return viewer, ok
+}
+
+// HasRole implements @hasRole: the field resolves only for callers with one
+// of the roles. API keys carry no user role and never pass.
+func HasRole(ctx context.Context, obj interface{}, next graphql.Resolver, roles []string) (interface{}, error) {
+	viewer, ok := ViewerFromContext(ctx)
+	if !ok || viewer.APIKey {
+		return nil, fieldForbidden(ctx)
+	}
+	for _, role := range roles {
+		if viewer.Role == role {
+			return next(ctx)
+		}
+	}
+	return nil, fieldForbidden(ctx)
+}
+
+// HasScope implements @hasScope: for API keys the field resolves only if the
+// key was granted the scope. Other callers are left to role checks.
+func HasScope(ctx context.Context, obj interface{}, next graphql.Resolver, scope string) (interface{}, error) {
+	viewer, ok := ViewerFromContext(ctx)
+	if !ok {
+		return nil, fieldForbidden(ctx)
+	}
+	if !viewer.APIKey {
+		return next(ctx)
+	}
+	for _, s := range viewer.Scopes {
+		if s == scope {
+			return next(ctx)
+		}
+	}
+	return nil, fieldForbidden(ctx)
+}
+
+// fieldForbidden names the field the caller may not read
+func fieldForbidden(ctx context.Context) error {
+	if field := graphql.GetFieldContext(ctx); field != nil {
+		return fmt.Errorf("%w: %s", ErrForbidden, field.Path())
+	}
+	return ErrForbidden
+}
@@ -0,0 +1,164 @@
+// Package loader batches and caches the lookups of a single GraphQL request,
+// so resolving a field of every item in a list costs one backend query
+// instead of one per item.
+package loader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FetchFunc loads the values with the given keys. Keys without a value are
+// left out of the result.
+type FetchFunc[V any] func(ctx context.Context, keys []string) (map[string]V, error)
+
+// Loader collects the keys requested within a short wait into one fetch and
+// remembers the results. A Loader serves a single request; create a new one
+// for each.
+type Loader[V any] struct {
+	fetch    FetchFunc[V]
+	delay    time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[string]*result[V]
+	batch   *batch[V]
+}
+
+// result is the outcome of loading one key
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+// batch is a set of keys waiting to be fetched together
+type batch[V any] struct {
+	keys    []string
+	results []*result[V]
+	full    chan struct{}
+}
+
+// New creates a loader that fetches up to maxBatch keys at once, waiting for
+// further keys for up to wait after the first
+func New[V any](fetch FetchFunc[V], wait time.Duration, maxBatch int) *Loader[V] {
+	if maxBatch <= 0 {
+		maxBatch = 100
+	}
+	return &Loader[V]{
+		fetch:    fetch,
+		delay:    wait,
+		maxBatch: maxBatch,
+		results:  make(map[string]*result[V]),
+	}
+}
+
+// Load returns the value with the given key, or the zero value if there is
+// none
+func (l *Loader[V]) Load(ctx context.Context, key string) (V, error) {
+	r, err := l.wait(ctx, l.load(ctx, key))
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return r.value, nil
+}
+
+// LoadAll returns the values with the given keys, in order, skipping keys
+// without a value. The keys are fetched in the same batch.
+func (l *Loader[V]) LoadAll(ctx context.Context, keys []string) ([]V, error) {
+	pending := make([]*result[V], len(keys))
+	for i, key := range keys {
+		pending[i] = l.load(ctx, key)
+	}
+
+	values := make([]V, 0, len(keys))
+	for _, r := range pending {
+		r, err := l.wait(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		if r.found {
+			values = append(values, r.value)
+		}
+	}
+	return values, nil
+}
+
+// load returns the cached or pending result for a key, queueing the key if
+// it has not been requested before
+func (l *Loader[V]) load(ctx context.Context, key string) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+		l.enqueue(ctx, key, r)
+	}
+	return r
+}
+
+// wait blocks until a result has been fetched or the request is cancelled
+func (l *Loader[V]) wait(ctx context.Context, r *result[V]) (*result[V], error) {
+	select {
+	case <-r.done:
+		return r, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// enqueue adds a key to the pending batch, starting one if there is none.
+// The caller holds l.mu.
+func (l *Loader[V]) enqueue(ctx context.Context, key string, r *result[V]) {
+	if l.batch == nil {
+		l.batch = &batch[V]{full: make(chan struct{})}
+		go l.dispatch(ctx, l.batch)
+	}
+
+	b := l.batch
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, r)
+	if len(b.keys) >= l.maxBatch {
+		l.batch = nil
+		close(b.full)
+	}
+}
+
+// dispatch fetches a batch once the wait has passed or the batch is full
+func (l *Loader[V]) dispatch(ctx context.Context, b *batch[V]) {
+	timer := time.NewTimer(l.delay)
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		if l.batch == b {
+			l.batch = nil
+		}
+		l.mu.Unlock()
+	case <-b.full:
+		timer.Stop()
+	}
+
+	values, err := l.fetch(ctx, b.keys)
+	for i, key := range b.keys {
+		r := b.results[i]
+		r.value, r.found = values[key]
+		r.err = err
+		close(r.done)
+	}
+
+	// Failed lookups are not cached, so a later field can retry them
+	if err != nil {
+		l.mu.Lock()
+		for i, key := range b.keys {
+			if l.results[key] == b.results[i] {
+				delete(l.results, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
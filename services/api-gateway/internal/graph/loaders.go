@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/services/api-gateway/internal/graph/loader"
+	"github.com/csic-platform/shared/query"
+)
+
+// Loaders batch the entity lookups of one request, so resolving the wallets
+// of every alert in a page or the entity of every report costs one list
+// query per entity type rather than one lookup per item
+type Loaders struct {
+	Exchanges *loader.Loader[*domain.Exchange]
+	Wallets   *loader.Loader[*domain.Wallet]
+	Miners    *loader.Loader[*domain.Miner]
+	Alerts    *loader.Loader[*domain.Alert]
+}
+
+// NewLoaders creates the loaders for one request. Each batch is fetched from
+// the gateway service as a list filtered by ID.
+func NewLoaders(service ports.GatewayService, wait time.Duration, maxBatch int) *Loaders {
+	return &Loaders{
+		Exchanges: loader.New(func(ctx context.Context, ids []string) (map[string]*domain.Exchange, error) {
+			resp, err := service.GetExchanges(ctx, batchParams(ids))
+			if err != nil {
+				return nil, err
+			}
+			return byID(resp, func(e *domain.Exchange) string { return e.ID })
+		}, wait, maxBatch),
+		Wallets: loader.New(func(ctx context.Context, ids []string) (map[string]*domain.Wallet, error) {
+			resp, err := service.GetWallets(ctx, batchParams(ids))
+			if err != nil {
+				return nil, err
+			}
+			return byID(resp, func(w *domain.Wallet) string { return w.ID })
+		}, wait, maxBatch),
+		Miners: loader.New(func(ctx context.Context, ids []string) (map[string]*domain.Miner, error) {
+			resp, err := service.GetMiners(ctx, batchParams(ids))
+			if err != nil {
+				return nil, err
+			}
+			return byID(resp, func(m *domain.Miner) string { return m.ID })
+		}, wait, maxBatch),
+		Alerts: loader.New(func(ctx context.Context, ids []string) (map[string]*domain.Alert, error) {
+			resp, err := service.GetAlerts(ctx, batchParams(ids))
+			if err != nil {
+				return nil, err
+			}
+			return byID(resp, func(a *domain.Alert) string { return a.ID })
+		}, wait, maxBatch),
+	}
+}
+
+type loadersKey struct{}
+
+// WithLoaders returns a context carrying the request's loaders
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// LoadersFromContext returns the request's loaders
+func LoadersFromContext(ctx context.Context) *Loaders {
+	return ctx.Value(loadersKey{}).(*Loaders)
+}
+
+// batchParams lists the entities with the given IDs on a single page
+func batchParams(ids []string) *query.Params {
+	params := &query.Params{
+		Page:     1,
+		PageSize: len(ids),
+		Sorts:    []query.Sort{{Field: "created_at", Direction: query.Desc}},
+	}
+	params.Where("id", query.OpIn, ids...)
+	return params
+}
+
+// byID indexes the items of a list response by ID
+func byID[T any](resp *domain.PaginatedResponse, id func(T) string) (map[string]T, error) {
+	items, err := pageItems[T](resp)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]T, len(items))
+	for _, item := range items {
+		found[id(item)] = item
+	}
+	return found, nil
+}
@@ -0,0 +1,75 @@
+// Package model holds the GraphQL types that are not domain entities
+package model
+
+import (
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+)
+
+// ListInput is the sorting, filtering and pagination of a list
+type ListInput struct {
+	Page     *int           `json:"page,omitempty"`
+	PageSize *int           `json:"pageSize,omitempty"`
+	Cursor   *string        `json:"cursor,omitempty"`
+	Sort     *string        `json:"sort,omitempty"`
+	Filters  []*FilterInput `json:"filters,omitempty"`
+}
+
+// FilterInput restricts a list to items whose field compares to the values
+type FilterInput struct {
+	Field  string   `json:"field"`
+	Op     *string  `json:"op,omitempty"`
+	Values []string `json:"values"`
+}
+
+// PageInfo describes where a page sits in the full list
+type PageInfo struct {
+	Page       int     `json:"page"`
+	PageSize   int     `json:"pageSize"`
+	Total      int64   `json:"total"`
+	TotalPages int     `json:"totalPages"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// NewPageInfo describes the page of a paginated response
+func NewPageInfo(resp *domain.PaginatedResponse) *PageInfo {
+	info := &PageInfo{
+		Page:       resp.Page,
+		PageSize:   resp.PageSize,
+		Total:      resp.Total,
+		TotalPages: resp.TotalPages,
+	}
+	if resp.NextCursor != "" {
+		info.NextCursor = &resp.NextCursor
+	}
+	return info
+}
+
+// ExchangePage is a page of exchanges
+type ExchangePage struct {
+	Items    []*domain.Exchange `json:"items"`
+	PageInfo *PageInfo          `json:"pageInfo"`
+}
+
+// WalletPage is a page of wallets
+type WalletPage struct {
+	Items    []*domain.Wallet `json:"items"`
+	PageInfo *PageInfo        `json:"pageInfo"`
+}
+
+// MinerPage is a page of miners
+type MinerPage struct {
+	Items    []*domain.Miner `json:"items"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+// AlertPage is a page of alerts
+type AlertPage struct {
+	Items    []*domain.Alert `json:"items"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+// ComplianceReportPage is a page of compliance reports
+type ComplianceReportPage struct {
+	Items    []*domain.ComplianceReport `json:"items"`
+	PageInfo *PageInfo                  `json:"pageInfo"`
+}
@@ -0,0 +1,58 @@
+// Package graph serves a read-only GraphQL schema over the gateway service.
+// The executable schema in generated.go is generated by gqlgen from
+// schema.graphqls and gqlgen.yml; the resolvers are written by hand.
+package graph
+
+//go:generate go run github.com/99designs/gqlgen generate --config ../../gqlgen.yml
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/services/api-gateway/internal/graph/model"
+	"github.com/csic-platform/shared/query"
+)
+
+// Resolver resolves queries against the gateway service
+type Resolver struct {
+	service ports.GatewayService
+}
+
+// NewResolver creates a new resolver
+func NewResolver(service ports.GatewayService) *Resolver {
+	return &Resolver{service: service}
+}
+
+// listParams turns a list input into the parameters of the REST list
+// endpoints, so both accept the same fields, operators and limits
+func listParams(list *model.ListInput) (*query.Params, error) {
+	values := url.Values{}
+	if list != nil {
+		if list.Page != nil {
+			values.Set("page", strconv.Itoa(*list.Page))
+		}
+		if list.PageSize != nil {
+			values.Set("page_size", strconv.Itoa(*list.PageSize))
+		}
+		if list.Cursor != nil {
+			values.Set("cursor", *list.Cursor)
+		}
+		if list.Sort != nil {
+			values.Set("sort", *list.Sort)
+		}
+		for _, filter := range list.Filters {
+			if len(filter.Values) == 0 {
+				return nil, fmt.Errorf("%w: filter by %s has no values", query.ErrInvalidQuery, filter.Field)
+			}
+			key := "filter[" + filter.Field + "]"
+			if filter.Op != nil && *filter.Op != string(query.OpEq) {
+				key += "[" + *filter.Op + "]"
+			}
+			values.Set(key, strings.Join(filter.Values, ","))
+		}
+	}
+	return query.Parse(values, query.DefaultOptions())
+}
@@ -0,0 +1,182 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/graph/model"
+)
+
+// Wallets is the resolver for the wallets field.
+func (r *alertResolver) Wallets(ctx context.Context, obj *domain.Alert) ([]*domain.Wallet, error) {
+	return LoadersFromContext(ctx).Wallets.LoadAll(ctx, evidenceIDs(obj, domain.EvidenceTypeWallet))
+}
+
+// Exchanges is the resolver for the exchanges field.
+func (r *alertResolver) Exchanges(ctx context.Context, obj *domain.Alert) ([]*domain.Exchange, error) {
+	return LoadersFromContext(ctx).Exchanges.LoadAll(ctx, evidenceIDs(obj, domain.EvidenceTypeExchange))
+}
+
+// Exchange is the resolver for the exchange field.
+func (r *complianceReportResolver) Exchange(ctx context.Context, obj *domain.ComplianceReport) (*domain.Exchange, error) {
+	if !strings.EqualFold(obj.EntityType, "EXCHANGE") {
+		return nil, nil
+	}
+	return LoadersFromContext(ctx).Exchanges.Load(ctx, obj.EntityID)
+}
+
+// Miner is the resolver for the miner field.
+func (r *complianceReportResolver) Miner(ctx context.Context, obj *domain.ComplianceReport) (*domain.Miner, error) {
+	if !strings.EqualFold(obj.EntityType, "MINER") {
+		return nil, nil
+	}
+	return LoadersFromContext(ctx).Miners.Load(ctx, obj.EntityID)
+}
+
+// Dashboard is the resolver for the dashboard field.
+func (r *queryResolver) Dashboard(ctx context.Context) (*domain.DashboardStats, error) {
+	return r.service.GetDashboardStats(ctx)
+}
+
+// Exchange is the resolver for the exchange field.
+func (r *queryResolver) Exchange(ctx context.Context, id string) (*domain.Exchange, error) {
+	return LoadersFromContext(ctx).Exchanges.Load(ctx, id)
+}
+
+// Exchanges is the resolver for the exchanges field.
+func (r *queryResolver) Exchanges(ctx context.Context, list *model.ListInput) (*model.ExchangePage, error) {
+	params, err := listParams(list)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.service.GetExchanges(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pageItems[*domain.Exchange](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ExchangePage{Items: items, PageInfo: model.NewPageInfo(resp)}, nil
+}
+
+// Wallet is the resolver for the wallet field.
+func (r *queryResolver) Wallet(ctx context.Context, id string) (*domain.Wallet, error) {
+	return LoadersFromContext(ctx).Wallets.Load(ctx, id)
+}
+
+// Wallets is the resolver for the wallets field.
+func (r *queryResolver) Wallets(ctx context.Context, list *model.ListInput) (*model.WalletPage, error) {
+	params, err := listParams(list)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.service.GetWallets(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pageItems[*domain.Wallet](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &model.WalletPage{Items: items, PageInfo: model.NewPageInfo(resp)}, nil
+}
+
+// Miner is the resolver for the miner field.
+func (r *queryResolver) Miner(ctx context.Context, id string) (*domain.Miner, error) {
+	return LoadersFromContext(ctx).Miners.Load(ctx, id)
+}
+
+// Miners is the resolver for the miners field.
+func (r *queryResolver) Miners(ctx context.Context, list *model.ListInput) (*model.MinerPage, error) {
+	params, err := listParams(list)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.service.GetMiners(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pageItems[*domain.Miner](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &model.MinerPage{Items: items, PageInfo: model.NewPageInfo(resp)}, nil
+}
+
+// Alert is the resolver for the alert field.
+func (r *queryResolver) Alert(ctx context.Context, id string) (*domain.Alert, error) {
+	return LoadersFromContext(ctx).Alerts.Load(ctx, id)
+}
+
+// Alerts is the resolver for the alerts field.
+func (r *queryResolver) Alerts(ctx context.Context, list *model.ListInput) (*model.AlertPage, error) {
+	params, err := listParams(list)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.service.GetAlerts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pageItems[*domain.Alert](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AlertPage{Items: items, PageInfo: model.NewPageInfo(resp)}, nil
+}
+
+// ComplianceReports is the resolver for the complianceReports field.
+func (r *queryResolver) ComplianceReports(ctx context.Context, list *model.ListInput) (*model.ComplianceReportPage, error) {
+	params, err := listParams(list)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.service.GetComplianceReports(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pageItems[*domain.ComplianceReport](resp)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ComplianceReportPage{Items: items, PageInfo: model.NewPageInfo(resp)}, nil
+}
+
+// Alert returns AlertResolver implementation.
+func (r *Resolver) Alert() AlertResolver { return &alertResolver{r} }
+
+// ComplianceReport returns ComplianceReportResolver implementation.
+func (r *Resolver) ComplianceReport() ComplianceReportResolver { return &complianceReportResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type alertResolver struct{ *Resolver }
+type complianceReportResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+
+// evidenceIDs returns the IDs of the entities of a type named in an alert's evidence
+func evidenceIDs(alert *domain.Alert, evidenceType string) []string {
+	var ids []string
+	for _, evidence := range alert.Evidence {
+		if id, ok := evidence.Value.(string); ok && evidence.Type == evidenceType {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// pageItems returns the items of a list response
+func pageItems[T any](resp *domain.PaginatedResponse) ([]T, error) {
+	items, ok := resp.Items.([]T)
+	if !ok {
+		return nil, fmt.Errorf("unexpected list item type %T", resp.Items)
+	}
+	return items, nil
+}
@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+)
+
+// Options configures the GraphQL server
+type Options struct {
+	// Introspection exposes the schema to clients such as GraphiQL
+	Introspection bool
+	// ComplexityLimit rejects queries whose estimated cost is higher
+	ComplexityLimit int
+	// BatchWait is how long loaders wait to collect lookups into one batch
+	BatchWait time.Duration
+	// MaxBatch is the most IDs a loader fetches at once
+	MaxBatch int
+}
+
+// NewServer creates an HTTP handler for GraphQL queries sent as POST
+// requests. The caller must be attached to each request with WithViewer.
+func NewServer(service ports.GatewayService, opts Options) http.Handler {
+	srv := handler.New(NewExecutableSchema(Config{
+		Resolvers: NewResolver(service),
+		Directives: DirectiveRoot{
+			HasRole:  HasRole,
+			HasScope: HasScope,
+		},
+	}))
+	srv.AddTransport(transport.POST{})
+	if opts.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+	if opts.ComplexityLimit > 0 {
+		srv.Use(extension.FixedComplexityLimit(opts.ComplexityLimit))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loaders := NewLoaders(service, opts.BatchWait, opts.MaxBatch)
+		srv.ServeHTTP(w, r.WithContext(WithLoaders(r.Context(), loaders)))
+	})
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/csic-platform/shared/startup"
+	"github.com/gin-gonic/gin"
+)
+
+// BuildInfo identifies the running build
+type BuildInfo struct {
+	Service   string `json:"service"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// RuntimeInfo describes a running gateway instance
+type RuntimeInfo struct {
+	Build             BuildInfo               `json:"build"`
+	StartedAt         time.Time               `json:"started_at"`
+	UptimeSeconds     int64                   `json:"uptime_seconds"`
+	ConfigFingerprint string                  `json:"config_fingerprint"`
+	Maintenance       *domain.MaintenanceMode `json:"maintenance"`
+	Dependencies      *startup.Report         `json:"dependencies"`
+}
+
+// AdminHandler contains the HTTP handlers for the admin console
+type AdminHandler struct {
+	service           ports.AdminService
+	build             BuildInfo
+	configFingerprint string
+	startedAt         time.Time
+	readiness         func(ctx context.Context) *startup.Report
+}
+
+// NewAdminHandler creates a new admin handler instance. The config
+// fingerprint lets operators tell whether instances run the same config.
+func NewAdminHandler(service ports.AdminService, build BuildInfo, configFingerprint string, readiness func(ctx context.Context) *startup.Report) *AdminHandler {
+	build.GoVersion = runtime.Version()
+	return &AdminHandler{
+		service:           service,
+		build:             build,
+		configFingerprint: configFingerprint,
+		startedAt:         time.Now().UTC(),
+		readiness:         readiness,
+	}
+}
+
+// SetMaintenanceRequest represents a request to turn maintenance mode on or off
+type SetMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	Message string `json:"message"`
+}
+
+// GetRuntimeInfo returns the instance's build, config fingerprint and dependency health
+func (h *AdminHandler) GetRuntimeInfo(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data: RuntimeInfo{
+			Build:             h.build,
+			StartedAt:         h.startedAt,
+			UptimeSeconds:     int64(time.Since(h.startedAt).Seconds()),
+			ConfigFingerprint: h.configFingerprint,
+			Maintenance:       h.service.GetMaintenanceMode(),
+			Dependencies:      h.readiness(c.Request.Context()),
+		},
+	})
+}
+
+// GetFeatureFlags returns every feature flag
+func (h *AdminHandler) GetFeatureFlags(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.service.GetFeatureFlags(),
+	})
+}
+
+// GetFeatureFlag returns a feature flag by key
+func (h *AdminHandler) GetFeatureFlag(c *gin.Context) {
+	flag, err := h.service.GetFeatureFlag(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		h.writeError(c, err, "Failed to get feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    flag,
+	})
+}
+
+// SetFeatureFlag creates a feature flag or changes it
+func (h *AdminHandler) SetFeatureFlag(c *gin.Context) {
+	var update domain.FeatureFlagUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		writeInvalidRequest(c, err)
+		return
+	}
+
+	flag, err := h.service.SetFeatureFlag(c.Request.Context(), c.Param("key"), &update, c.GetString("user_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to save feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    flag,
+	})
+}
+
+// DeleteFeatureFlag deletes a feature flag, turning its feature off
+func (h *AdminHandler) DeleteFeatureFlag(c *gin.Context) {
+	if err := h.service.DeleteFeatureFlag(c.Request.Context(), c.Param("key")); err != nil {
+		h.writeError(c, err, "Failed to delete feature flag")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+	})
+}
+
+// GetMaintenanceMode returns the maintenance mode switch
+func (h *AdminHandler) GetMaintenanceMode(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.service.GetMaintenanceMode(),
+	})
+}
+
+// SetMaintenanceMode turns maintenance mode on or off
+func (h *AdminHandler) SetMaintenanceMode(c *gin.Context) {
+	var req SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeInvalidRequest(c, err)
+		return
+	}
+
+	mode, err := h.service.SetMaintenanceMode(c.Request.Context(), *req.Enabled, req.Message, c.GetString("user_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to set maintenance mode")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    mode,
+	})
+}
+
+// GetEnabledFeatures returns the keys of the feature flags on for the caller
+func (h *AdminHandler) GetEnabledFeatures(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.service.EnabledFeatures(c.GetString("user_id"), c.GetString("role")),
+	})
+}
+
+func (h *AdminHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrFeatureFlagNotFound):
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Feature flag not found"),
+			},
+		})
+	case errors.Is(err, domain.ErrInvalidFeatureFlag):
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_REQUEST",
+				Message: i18n.T(c, message),
+				Details: err.Error(),
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: i18n.T(c, message),
+			},
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler contains the HTTP handlers for managing API keys
+type APIKeyHandler struct {
+	service            ports.APIKeyService
+	defaultGracePeriod time.Duration
+}
+
+// NewAPIKeyHandler creates a new API key handler instance
+func NewAPIKeyHandler(service ports.APIKeyService, defaultGracePeriod time.Duration) *APIKeyHandler {
+	return &APIKeyHandler{
+		service:            service,
+		defaultGracePeriod: defaultGracePeriod,
+	}
+}
+
+// CreateAPIKeyRequest represents a request to issue an API key
+type CreateAPIKeyRequest struct {
+	Name       string     `json:"name" binding:"required"`
+	ClientID   string     `json:"client_id" binding:"required"`
+	Scopes     []string   `json:"scopes" binding:"required"`
+	RateLimit  int        `json:"rate_limit"`
+	DailyQuota int        `json:"daily_quota"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+// RotateAPIKeyRequest represents a request to rotate an API key
+type RotateAPIKeyRequest struct {
+	GracePeriodHours *int `json:"grace_period_hours"`
+}
+
+// IssuedAPIKey is returned when a key is created or rotated. Key holds the
+// plain text key, which is shown only once.
+type IssuedAPIKey struct {
+	APIKey *domain.ClientAPIKey `json:"api_key"`
+	Key    string               `json:"key"`
+}
+
+// CreateAPIKey issues a new API key to a machine client
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeInvalidRequest(c, err)
+		return
+	}
+
+	key := &domain.ClientAPIKey{
+		Name:       req.Name,
+		ClientID:   req.ClientID,
+		Scopes:     req.Scopes,
+		RateLimit:  req.RateLimit,
+		DailyQuota: req.DailyQuota,
+		ExpiresAt:  req.ExpiresAt,
+	}
+
+	rawKey, err := h.service.CreateAPIKey(c.Request.Context(), key, c.GetString("user_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to create API key")
+		return
+	}
+
+	c.JSON(http.StatusCreated, Response{
+		Success: true,
+		Data:    IssuedAPIKey{APIKey: key, Key: rawKey},
+	})
+}
+
+// GetAPIKeys returns a paginated list of API keys, optionally filtered by client
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	page, pageSize := paginationParams(c)
+
+	keys, err := h.service.GetAPIKeys(c.Request.Context(), c.Query("client_id"), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: i18n.T(c, "Failed to get API keys"),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    keys.Items,
+		Meta: &MetaInfo{
+			Page:       keys.Page,
+			PageSize:   keys.PageSize,
+			Total:      keys.Total,
+			TotalPages: keys.TotalPages,
+		},
+	})
+}
+
+// GetAPIKeyByID returns a specific API key by ID
+func (h *APIKeyHandler) GetAPIKeyByID(c *gin.Context) {
+	key, err := h.service.GetAPIKeyByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    key,
+	})
+}
+
+// UpdateAPIKey changes the name, scopes or limits of an API key
+func (h *APIKeyHandler) UpdateAPIKey(c *gin.Context) {
+	var update domain.APIKeyUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		writeInvalidRequest(c, err)
+		return
+	}
+
+	key, err := h.service.UpdateAPIKey(c.Request.Context(), c.Param("id"), &update)
+	if err != nil {
+		h.writeError(c, err, "Failed to update API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    key,
+	})
+}
+
+// RotateAPIKey issues a replacement key; the old key stays valid for the grace period
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	var req RotateAPIKeyRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeInvalidRequest(c, err)
+			return
+		}
+	}
+
+	gracePeriod := h.defaultGracePeriod
+	if req.GracePeriodHours != nil {
+		gracePeriod = time.Duration(*req.GracePeriodHours) * time.Hour
+	}
+
+	key, rawKey, err := h.service.RotateAPIKey(c.Request.Context(), c.Param("id"), gracePeriod, c.GetString("user_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to rotate API key")
+		return
+	}
+
+	c.JSON(http.StatusCreated, Response{
+		Success: true,
+		Data:    IssuedAPIKey{APIKey: key, Key: rawKey},
+	})
+}
+
+// RevokeAPIKey immediately revokes an API key
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	key, err := h.service.RevokeAPIKey(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    key,
+	})
+}
+
+// GetAPIKeyUsage returns an API key's usage of its rate limit and daily quota
+func (h *APIKeyHandler) GetAPIKeyUsage(c *gin.Context) {
+	usage, err := h.service.GetAPIKeyUsage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to get API key usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    usage,
+	})
+}
+
+func (h *APIKeyHandler) writeNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "NOT_FOUND",
+			Message: i18n.T(c, "API key not found"),
+		},
+	})
+}
+
+func (h *APIKeyHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		h.writeNotFound(c)
+	case errors.Is(err, domain.ErrInvalidAPIKeySpec), errors.Is(err, domain.ErrInvalidAPIScope):
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_REQUEST",
+				Message: i18n.T(c, message),
+				Details: err.Error(),
+			},
+		})
+	case errors.Is(err, domain.ErrAPIKeyNotActive):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "CONFLICT",
+				Message: i18n.T(c, message),
+				Details: err.Error(),
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: i18n.T(c, message),
+			},
+		})
+	}
+}
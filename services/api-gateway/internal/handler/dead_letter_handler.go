@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// DeadLetterHandler contains the HTTP handlers for browsing and reprocessing dead letters
+type DeadLetterHandler struct {
+	service ports.DeadLetterService
+}
+
+// NewDeadLetterHandler creates a new dead-letter handler instance
+func NewDeadLetterHandler(service ports.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{service: service}
+}
+
+// GetDeadLetters returns a paginated list of dead letters, optionally filtered by status
+func (h *DeadLetterHandler) GetDeadLetters(c *gin.Context) {
+	page, pageSize := paginationParams(c)
+	status := strings.ToUpper(c.Query("status"))
+
+	switch domain.DeadLetterStatus(status) {
+	case "", domain.DeadLetterStatusPending, domain.DeadLetterStatusReprocessed, domain.DeadLetterStatusDiscarded:
+	default:
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_REQUEST",
+				Message: i18n.T(c, "Invalid dead letter status"),
+				Details: status,
+			},
+		})
+		return
+	}
+
+	deadLetters, err := h.service.GetDeadLetters(c.Request.Context(), status, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: i18n.T(c, "Failed to get dead letters"),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    deadLetters.Items,
+		Meta: &MetaInfo{
+			Page:       deadLetters.Page,
+			PageSize:   deadLetters.PageSize,
+			Total:      deadLetters.Total,
+			TotalPages: deadLetters.TotalPages,
+		},
+	})
+}
+
+// GetDeadLetterByID returns a specific dead letter by ID
+func (h *DeadLetterHandler) GetDeadLetterByID(c *gin.Context) {
+	deadLetter, err := h.service.GetDeadLetterByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Dead letter not found"),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    deadLetter,
+	})
+}
+
+// ReprocessDeadLetter republishes a dead letter to its original topic
+func (h *DeadLetterHandler) ReprocessDeadLetter(c *gin.Context) {
+	deadLetter, err := h.service.ReprocessDeadLetter(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		h.writeResolveError(c, err, "Failed to reprocess dead letter")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    deadLetter,
+	})
+}
+
+// DiscardDeadLetter marks a dead letter as not to be reprocessed
+func (h *DeadLetterHandler) DiscardDeadLetter(c *gin.Context) {
+	deadLetter, err := h.service.DiscardDeadLetter(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		h.writeResolveError(c, err, "Failed to discard dead letter")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    deadLetter,
+	})
+}
+
+func (h *DeadLetterHandler) writeResolveError(c *gin.Context, err error, message string) {
+	if errors.Is(err, domain.ErrDeadLetterNotPending) {
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "CONFLICT",
+				Message: i18n.T(c, "Dead letter has already been resolved"),
+				Details: err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "INTERNAL_ERROR",
+			Message: i18n.T(c, message),
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/csic-platform/services/api-gateway/internal/graph"
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler serves the read-only GraphQL schema
+type GraphQLHandler struct {
+	server http.Handler
+}
+
+// NewGraphQLHandler creates a new GraphQL handler instance
+func NewGraphQLHandler(server http.Handler) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// Query executes a GraphQL query as the authenticated caller, whose role or
+// API key scopes decide which fields resolve
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	viewer := graph.Viewer{
+		UserID: c.GetString("user_id"),
+		Role:   c.GetString("role"),
+		Scopes: c.GetStringSlice("scopes"),
+	}
+	_, viewer.APIKey = c.Get("api_key_id")
+
+	c.Request = c.Request.WithContext(graph.WithViewer(c.Request.Context(), viewer))
+	h.server.ServeHTTP(c.Writer, c.Request)
+}
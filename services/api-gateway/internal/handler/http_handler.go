@@ -1,14 +1,20 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/config"
 	"github.com/csic-platform/services/api-gateway/internal/core/domain"
 	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/query"
+	"github.com/csic-platform/shared/validation"
 	"github.com/gin-gonic/gin"
 )
 
@@ -39,14 +45,60 @@ type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	// Fields lists the request fields that failed validation
+	Fields []problem.FieldError `json:"fields,omitempty"`
+}
+
+// writeInvalidRequest responds to a request body that could not be bound,
+// listing the fields that failed validation
+func writeInvalidRequest(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "INVALID_REQUEST",
+			Message: i18n.T(c, "Invalid request body"),
+			Details: err.Error(),
+			Fields:  validation.FieldErrors(err),
+		},
+	})
+}
+
+// AcknowledgeAlertRequest represents a request to acknowledge an alert
+type AcknowledgeAlertRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// UpdateExchangeRequest represents an amendment to an exchange at a known
+// version, which may instead come from the If-Match header
+type UpdateExchangeRequest struct {
+	domain.ExchangeUpdate
+	Version int    `json:"version"`
+	UserID  string `json:"user_id" binding:"required"`
+}
+
+// ActionRequest represents an enforcement action, such as suspending an
+// exchange or freezing a wallet, and the reason for it
+type ActionRequest struct {
+	Reason string `json:"reason" binding:"required"`
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// GenerateComplianceReportRequest represents a request for a compliance
+// report; Locale defaults to the caller's negotiated locale
+type GenerateComplianceReportRequest struct {
+	EntityType string `json:"entity_type" binding:"required"`
+	EntityID   string `json:"entity_id" binding:"required"`
+	Period     string `json:"period" binding:"required"`
+	Locale     string `json:"locale"`
 }
 
 // MetaInfo represents pagination metadata
 type MetaInfo struct {
-	Page       int   `json:"page"`
-	PageSize   int   `json:"page_size"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // HealthCheck returns the health status of the service
@@ -69,7 +121,7 @@ func (h *HTTPHandler) GetDashboardStats(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get dashboard stats",
+				Message: i18n.T(c, "Failed to get dashboard stats"),
 			},
 		})
 		return
@@ -83,17 +135,14 @@ func (h *HTTPHandler) GetDashboardStats(c *gin.Context) {
 
 // GetAlerts returns a paginated list of alerts
 func (h *HTTPHandler) GetAlerts(c *gin.Context) {
-	page, pageSize := h.getPaginationParams(c)
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
 
-	alerts, err := h.service.GetAlerts(c.Request.Context(), page, pageSize)
+	alerts, err := h.service.GetAlerts(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get alerts",
-			},
-		})
+		listError(c, err, "Failed to get alerts")
 		return
 	}
 
@@ -105,6 +154,7 @@ func (h *HTTPHandler) GetAlerts(c *gin.Context) {
 			PageSize:   alerts.PageSize,
 			Total:      alerts.Total,
 			TotalPages: alerts.TotalPages,
+			NextCursor: alerts.NextCursor,
 		},
 	})
 }
@@ -119,7 +169,7 @@ func (h *HTTPHandler) GetAlertByID(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "NOT_FOUND",
-				Message: "Alert not found",
+				Message: i18n.T(c, "Alert not found"),
 			},
 		})
 		return
@@ -135,17 +185,9 @@ func (h *HTTPHandler) GetAlertByID(c *gin.Context) {
 func (h *HTTPHandler) AcknowledgeAlert(c *gin.Context) {
 	alertID := c.Param("id")
 
-	var req struct {
-		UserID string `json:"user_id" binding:"required"`
-	}
+	var req AcknowledgeAlertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INVALID_REQUEST",
-				Message: "Invalid request body",
-			},
-		})
+		writeInvalidRequest(c, err)
 		return
 	}
 
@@ -154,7 +196,7 @@ func (h *HTTPHandler) AcknowledgeAlert(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to acknowledge alert",
+				Message: i18n.T(c, "Failed to acknowledge alert"),
 			},
 		})
 		return
@@ -173,17 +215,14 @@ func (h *HTTPHandler) AcknowledgeAlert(c *gin.Context) {
 
 // GetExchanges returns a paginated list of exchanges
 func (h *HTTPHandler) GetExchanges(c *gin.Context) {
-	page, pageSize := h.getPaginationParams(c)
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
 
-	exchanges, err := h.service.GetExchanges(c.Request.Context(), page, pageSize)
+	exchanges, err := h.service.GetExchanges(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get exchanges",
-			},
-		})
+		listError(c, err, "Failed to get exchanges")
 		return
 	}
 
@@ -195,6 +234,7 @@ func (h *HTTPHandler) GetExchanges(c *gin.Context) {
 			PageSize:   exchanges.PageSize,
 			Total:      exchanges.Total,
 			TotalPages: exchanges.TotalPages,
+			NextCursor: exchanges.NextCursor,
 		},
 	})
 }
@@ -209,43 +249,102 @@ func (h *HTTPHandler) GetExchangeByID(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "NOT_FOUND",
-				Message: "Exchange not found",
+				Message: i18n.T(c, "Exchange not found"),
 			},
 		})
 		return
 	}
 
+	setETag(c, exchange.Version)
 	c.JSON(http.StatusOK, Response{
 		Success: true,
 		Data:    exchange,
 	})
 }
 
-// SuspendExchange suspends an exchange
-func (h *HTTPHandler) SuspendExchange(c *gin.Context) {
+// UpdateExchange updates an exchange. The version being updated comes from
+// the If-Match header or the version field; a stale version gets 409 Conflict
+// with the current exchange.
+func (h *HTTPHandler) UpdateExchange(c *gin.Context) {
 	exchangeID := c.Param("id")
 
-	var req struct {
-		Reason string `json:"reason" binding:"required"`
-		UserID string `json:"user_id" binding:"required"`
-	}
+	var req UpdateExchangeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
+		writeInvalidRequest(c, err)
+		return
+	}
+
+	version, ok := expectedVersion(c, req.Version)
+	if !ok {
+		c.JSON(http.StatusPreconditionRequired, Response{
 			Success: false,
 			Error: &ErrorInfo{
-				Code:    "INVALID_REQUEST",
-				Message: "Invalid request body",
+				Code:    "PRECONDITION_REQUIRED",
+				Message: i18n.T(c, "If-Match header or version field is required"),
 			},
 		})
 		return
 	}
 
+	exchange, err := h.service.UpdateExchange(c.Request.Context(), exchangeID, version, req.ExchangeUpdate, req.UserID)
+	switch {
+	case errors.Is(err, domain.ErrVersionConflict):
+		response := Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "VERSION_CONFLICT",
+				Message: i18n.T(c, "Exchange was modified by another request"),
+			},
+		}
+		if current, getErr := h.service.GetExchangeByID(c.Request.Context(), exchangeID); getErr == nil {
+			setETag(c, current.Version)
+			response.Data = current
+		}
+		c.JSON(http.StatusConflict, response)
+		return
+	case errors.Is(err, domain.ErrExchangeNotFound):
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Exchange not found"),
+			},
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: i18n.T(c, "Failed to update exchange"),
+			},
+		})
+		return
+	}
+
+	setETag(c, exchange.Version)
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    exchange,
+	})
+}
+
+// SuspendExchange suspends an exchange
+func (h *HTTPHandler) SuspendExchange(c *gin.Context) {
+	exchangeID := c.Param("id")
+
+	var req ActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeInvalidRequest(c, err)
+		return
+	}
+
 	if err := h.service.SuspendExchange(c.Request.Context(), exchangeID, req.Reason, req.UserID); err != nil {
 		c.JSON(http.StatusInternalServerError, Response{
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to suspend exchange",
+				Message: i18n.T(c, "Failed to suspend exchange"),
 			},
 		})
 		return
@@ -265,17 +364,14 @@ func (h *HTTPHandler) SuspendExchange(c *gin.Context) {
 
 // GetWallets returns a paginated list of wallets
 func (h *HTTPHandler) GetWallets(c *gin.Context) {
-	page, pageSize := h.getPaginationParams(c)
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
 
-	wallets, err := h.service.GetWallets(c.Request.Context(), page, pageSize)
+	wallets, err := h.service.GetWallets(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get wallets",
-			},
-		})
+		listError(c, err, "Failed to get wallets")
 		return
 	}
 
@@ -287,6 +383,7 @@ func (h *HTTPHandler) GetWallets(c *gin.Context) {
 			PageSize:   wallets.PageSize,
 			Total:      wallets.Total,
 			TotalPages: wallets.TotalPages,
+			NextCursor: wallets.NextCursor,
 		},
 	})
 }
@@ -301,7 +398,7 @@ func (h *HTTPHandler) GetWalletByID(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "NOT_FOUND",
-				Message: "Wallet not found",
+				Message: i18n.T(c, "Wallet not found"),
 			},
 		})
 		return
@@ -317,18 +414,9 @@ func (h *HTTPHandler) GetWalletByID(c *gin.Context) {
 func (h *HTTPHandler) FreezeWallet(c *gin.Context) {
 	walletID := c.Param("id")
 
-	var req struct {
-		Reason string `json:"reason" binding:"required"`
-		UserID string `json:"user_id" binding:"required"`
-	}
+	var req ActionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INVALID_REQUEST",
-				Message: "Invalid request body",
-			},
-		})
+		writeInvalidRequest(c, err)
 		return
 	}
 
@@ -337,7 +425,7 @@ func (h *HTTPHandler) FreezeWallet(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to freeze wallet",
+				Message: i18n.T(c, "Failed to freeze wallet"),
 			},
 		})
 		return
@@ -357,17 +445,14 @@ func (h *HTTPHandler) FreezeWallet(c *gin.Context) {
 
 // GetMiners returns a paginated list of miners
 func (h *HTTPHandler) GetMiners(c *gin.Context) {
-	page, pageSize := h.getPaginationParams(c)
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
 
-	miners, err := h.service.GetMiners(c.Request.Context(), page, pageSize)
+	miners, err := h.service.GetMiners(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get miners",
-			},
-		})
+		listError(c, err, "Failed to get miners")
 		return
 	}
 
@@ -379,6 +464,7 @@ func (h *HTTPHandler) GetMiners(c *gin.Context) {
 			PageSize:   miners.PageSize,
 			Total:      miners.Total,
 			TotalPages: miners.TotalPages,
+			NextCursor: miners.NextCursor,
 		},
 	})
 }
@@ -393,7 +479,7 @@ func (h *HTTPHandler) GetMinerByID(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "NOT_FOUND",
-				Message: "Miner not found",
+				Message: i18n.T(c, "Miner not found"),
 			},
 		})
 		return
@@ -407,17 +493,14 @@ func (h *HTTPHandler) GetMinerByID(c *gin.Context) {
 
 // GetComplianceReports returns compliance reports
 func (h *HTTPHandler) GetComplianceReports(c *gin.Context) {
-	page, pageSize := h.getPaginationParams(c)
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
 
-	reports, err := h.service.GetComplianceReports(c.Request.Context(), page, pageSize)
+	reports, err := h.service.GetComplianceReports(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get compliance reports",
-			},
-		})
+		listError(c, err, "Failed to get compliance reports")
 		return
 	}
 
@@ -429,35 +512,43 @@ func (h *HTTPHandler) GetComplianceReports(c *gin.Context) {
 			PageSize:   reports.PageSize,
 			Total:      reports.Total,
 			TotalPages: reports.TotalPages,
+			NextCursor: reports.NextCursor,
 		},
 	})
 }
 
 // GenerateComplianceReport generates a new compliance report
 func (h *HTTPHandler) GenerateComplianceReport(c *gin.Context) {
-	var req struct {
-		EntityType string `json:"entity_type" binding:"required"`
-		EntityID   string `json:"entity_id" binding:"required"`
-		Period     string `json:"period" binding:"required"`
-	}
+	var req GenerateComplianceReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INVALID_REQUEST",
-				Message: "Invalid request body",
-			},
-		})
+		writeInvalidRequest(c, err)
 		return
 	}
 
-	report, err := h.service.GenerateComplianceReport(c.Request.Context(), req.EntityType, req.EntityID, req.Period)
+	// The report's documents default to the caller's negotiated locale
+	locale := i18n.FromContext(c)
+	if req.Locale != "" {
+		locale = i18n.Normalize(req.Locale)
+		if locale == "" {
+			c.JSON(http.StatusBadRequest, Response{
+				Success: false,
+				Error: &ErrorInfo{
+					Code:    "UNSUPPORTED_LOCALE",
+					Message: i18n.T(c, "Unsupported report locale"),
+					Details: req.Locale,
+				},
+			})
+			return
+		}
+	}
+
+	report, err := h.service.GenerateComplianceReport(c.Request.Context(), req.EntityType, req.EntityID, req.Period, locale)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Response{
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to generate compliance report",
+				Message: i18n.T(c, "Failed to generate compliance report"),
 			},
 		})
 		return
@@ -479,7 +570,7 @@ func (h *HTTPHandler) GetAuditLogs(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get audit logs",
+				Message: i18n.T(c, "Failed to get audit logs"),
 			},
 		})
 		return
@@ -505,7 +596,7 @@ func (h *HTTPHandler) GetBlockchainStatus(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get blockchain status",
+				Message: i18n.T(c, "Failed to get blockchain status"),
 			},
 		})
 		return
@@ -527,7 +618,7 @@ func (h *HTTPHandler) GetCurrentUser(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "NOT_FOUND",
-				Message: "User not found",
+				Message: i18n.T(c, "User not found"),
 			},
 		})
 		return
@@ -549,7 +640,7 @@ func (h *HTTPHandler) GetUsers(c *gin.Context) {
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INTERNAL_ERROR",
-				Message: "Failed to get users",
+				Message: i18n.T(c, "Failed to get users"),
 			},
 		})
 		return
@@ -569,6 +660,11 @@ func (h *HTTPHandler) GetUsers(c *gin.Context) {
 
 // getPaginationParams extracts pagination parameters from the request
 func (h *HTTPHandler) getPaginationParams(c *gin.Context) (int, int) {
+	return paginationParams(c)
+}
+
+// paginationParams extracts pagination parameters from the request
+func paginationParams(c *gin.Context) (int, int) {
 	page := 1
 	pageSize := 20
 
@@ -587,6 +683,47 @@ func (h *HTTPHandler) getPaginationParams(c *gin.Context) (int, int) {
 	return page, pageSize
 }
 
+// listParams parses the sort, filter and pagination parameters of a list
+// request, responding with 400 if they are malformed
+func listParams(c *gin.Context) (*query.Params, bool) {
+	params, err := query.Parse(c.Request.URL.Query(), query.DefaultOptions())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_QUERY",
+				Message: err.Error(),
+			},
+		})
+		return nil, false
+	}
+	return params, true
+}
+
+// listError responds to a failed list request. Sort or filter fields the
+// endpoint does not allow are the client's error.
+func listError(c *gin.Context, err error, message string) {
+	if errors.Is(err, query.ErrInvalidQuery) {
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_QUERY",
+				Message: i18n.T(c, message),
+				Details: err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "INTERNAL_ERROR",
+			Message: i18n.T(c, message),
+		},
+	})
+}
+
 // ErrorHandler handles panics and returns proper error responses
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -596,7 +733,7 @@ func ErrorHandler() gin.HandlerFunc {
 					Success: false,
 					Error: &ErrorInfo{
 						Code:    "INTERNAL_ERROR",
-						Message: "An internal error occurred",
+						Message: i18n.T(c, "An internal error occurred"),
 					},
 				})
 			}
@@ -620,65 +757,6 @@ func RequestLogger() gin.HandlerFunc {
 	}
 }
 
-// RateLimiter implements rate limiting middleware
-type RateLimiter struct {
-	requests map[string][]time.Time
-	limit    int
-	window   time.Duration
-	mu       sync.RWMutex
-}
-
-// NewRateLimiter creates a new rate limiter
-func NewRateLimiter(requestsPerMinute int) *RateLimiter {
-	return &RateLimiter{
-		requests: make(map[string][]time.Time),
-		limit:    requestsPerMinute,
-		window:   time.Minute,
-	}
-}
-
-// Middleware returns the rate limiting middleware
-func (rl *RateLimiter) Middleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		ip := c.ClientIP()
-
-		rl.mu.Lock()
-		defer rl.mu.Unlock()
-
-		now := time.Now()
-		windowStart := now.Add(-rl.window)
-
-		// Filter out old requests
-		requests := rl.requests[ip]
-		rl.requests[ip] = filterRequests(requests, windowStart)
-
-		if len(rl.requests[ip]) >= rl.limit {
-			c.JSON(http.StatusTooManyRequests, Response{
-				Success: false,
-				Error: &ErrorInfo{
-					Code:    "RATE_LIMIT_EXCEEDED",
-					Message: "Too many requests",
-				},
-			})
-			c.Abort()
-			return
-		}
-
-		rl.requests[ip] = append(rl.requests[ip], now)
-		c.Next()
-	}
-}
-
-func filterRequests(requests []time.Time, since time.Time) []time.Time {
-	filtered := make([]time.Time, 0, len(requests))
-	for _, req := range requests {
-		if req.After(since) {
-			filtered = append(filtered, req)
-		}
-	}
-	return filtered
-}
-
 // SecurityHeaders adds security headers to all responses
 func SecurityHeaders() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -708,3 +786,18 @@ func CORS() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// expectedVersion returns the version a conditional update applies to, taken
+// from the If-Match header or, without one, from the request body
+func expectedVersion(c *gin.Context, bodyVersion int) (int, bool) {
+	if match := c.GetHeader("If-Match"); match != "" {
+		version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(match, "W/"), `"`))
+		return version, err == nil && version > 0
+	}
+	return bodyVersion, bodyVersion > 0
+}
+
+// setETag exposes a resource's version for use in If-Match
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, version))
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/adapter/upstream"
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/csic-platform/shared/openapi"
+	"github.com/csic-platform/shared/problem"
+	"github.com/csic-platform/shared/resilience"
+	"github.com/gin-gonic/gin"
+)
+
+// Envelope documents a successful Response whose data is a T
+type Envelope[T any] struct {
+	Success bool      `json:"success"`
+	Data    T         `json:"data"`
+	Meta    *MetaInfo `json:"meta,omitempty"`
+}
+
+// Response data the handlers write as gin.H
+type (
+	AcknowledgedAlert struct {
+		ID             string    `json:"id"`
+		Status         string    `json:"status"`
+		AcknowledgedBy string    `json:"acknowledged_by"`
+		AcknowledgedAt time.Time `json:"acknowledged_at"`
+	}
+	SuspendedExchange struct {
+		ID          string    `json:"id"`
+		Status      string    `json:"status"`
+		SuspendedBy string    `json:"suspended_by"`
+		SuspendedAt time.Time `json:"suspended_at"`
+	}
+	FrozenWallet struct {
+		ID       string    `json:"id"`
+		Status   string    `json:"status"`
+		FrozenBy string    `json:"frozen_by"`
+		FrozenAt time.Time `json:"frozen_at"`
+	}
+	RevokedSessions struct {
+		Revoked int `json:"revoked"`
+	}
+)
+
+type pageQuery struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type sessionQuery struct {
+	UserID string `form:"user_id" description:"Another user's sessions, for administrators"`
+}
+
+type apiKeyQuery struct {
+	pageQuery
+	ClientID string `form:"client_id"`
+}
+
+type deadLetterQuery struct {
+	pageQuery
+	Status string `form:"status" description:"PENDING, REPROCESSED or DISCARDED"`
+}
+
+type deliveryQuery struct {
+	pageQuery
+	Status     string `form:"status" description:"PENDING, SENDING, SUCCEEDED or FAILED"`
+	EndpointID string `form:"endpoint_id"`
+}
+
+type importQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=ndjson csv" description:"Taken from the content type or file name when omitted"`
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document
+func (h *HTTPHandler) Describe(spec *openapi.Spec) {
+	spec.Describe(h.GetDashboardStats, openapi.Operation{Summary: "Get dashboard statistics", Tags: []string{"dashboard"}, Response: Envelope[*domain.DashboardStats]{}})
+
+	// Alerts
+	tags := []string{"alerts"}
+	spec.Describe(h.GetAlerts, openapi.Operation{Summary: "List alerts", Description: "Filters take the form filter[field].", Tags: tags, Query: openapi.ListQuery{}, Response: Envelope[[]*domain.Alert]{}})
+	spec.Describe(h.GetAlertByID, openapi.Operation{Summary: "Get an alert", Tags: tags, Response: Envelope[*domain.Alert]{}})
+	spec.Describe(h.AcknowledgeAlert, openapi.Operation{Summary: "Acknowledge an alert", Tags: tags, Request: AcknowledgeAlertRequest{}, Response: Envelope[AcknowledgedAlert]{}})
+
+	// Exchanges
+	tags = []string{"exchanges"}
+	spec.Describe(h.GetExchanges, openapi.Operation{Summary: "List exchanges", Description: "Filters take the form filter[field].", Tags: tags, Query: openapi.ListQuery{}, Response: Envelope[[]*domain.Exchange]{}})
+	spec.Describe(h.GetExchangeByID, openapi.Operation{Summary: "Get an exchange", Description: "The ETag header carries the exchange version for If-Match.", Tags: tags, Response: Envelope[*domain.Exchange]{}})
+	spec.Describe(h.UpdateExchange, openapi.Operation{Summary: "Amend an exchange", Description: "A stale version is answered with 409 Conflict.", Tags: tags, Request: UpdateExchangeRequest{}, Response: Envelope[*domain.Exchange]{}})
+	spec.Describe(h.SuspendExchange, openapi.Operation{Summary: "Suspend an exchange", Tags: tags, Request: ActionRequest{}, Response: Envelope[SuspendedExchange]{}})
+
+	// Wallets
+	tags = []string{"wallets"}
+	spec.Describe(h.GetWallets, openapi.Operation{Summary: "List wallets", Description: "Filters take the form filter[field].", Tags: tags, Query: openapi.ListQuery{}, Response: Envelope[[]*domain.Wallet]{}})
+	spec.Describe(h.GetWalletByID, openapi.Operation{Summary: "Get a wallet", Tags: tags, Response: Envelope[*domain.Wallet]{}})
+	spec.Describe(h.FreezeWallet, openapi.Operation{Summary: "Freeze a wallet", Tags: tags, Request: ActionRequest{}, Response: Envelope[FrozenWallet]{}})
+
+	// Miners
+	tags = []string{"miners"}
+	spec.Describe(h.GetMiners, openapi.Operation{Summary: "List miners", Description: "Filters take the form filter[field].", Tags: tags, Query: openapi.ListQuery{}, Response: Envelope[[]*domain.Miner]{}})
+	spec.Describe(h.GetMinerByID, openapi.Operation{Summary: "Get a miner", Tags: tags, Response: Envelope[*domain.Miner]{}})
+
+	// Compliance, audit and blockchain
+	spec.Describe(h.GetComplianceReports, openapi.Operation{Summary: "List compliance reports", Tags: []string{"compliance"}, Query: openapi.ListQuery{}, Response: Envelope[[]*domain.ComplianceReport]{}})
+	spec.Describe(h.GenerateComplianceReport, openapi.Operation{Summary: "Generate a compliance report", Tags: []string{"compliance"}, Request: GenerateComplianceReportRequest{}, Response: Envelope[*domain.ComplianceReport]{}})
+	spec.Describe(h.GetAuditLogs, openapi.Operation{Summary: "List audit logs", Tags: []string{"audit"}, Query: pageQuery{}, Response: Envelope[[]*domain.AuditLog]{}})
+	spec.Describe(h.GetBlockchainStatus, openapi.Operation{Summary: "Get the status of each blockchain node", Tags: []string{"blockchain"}, Response: Envelope[map[string]*domain.BlockchainStatus]{}})
+
+	// Users
+	spec.Describe(h.GetCurrentUser, openapi.Operation{Summary: "Get the signed-in user", Tags: []string{"users"}, Response: Envelope[*domain.User]{}})
+	spec.Describe(h.GetUsers, openapi.Operation{Summary: "List users", Tags: []string{"users"}, Query: pageQuery{}, Response: Envelope[[]*domain.User]{}})
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document
+func (h *SessionHandler) Describe(spec *openapi.Spec) {
+	tags := []string{"sessions"}
+	spec.Describe(h.Login, openapi.Operation{Summary: "Sign in", Tags: tags, Request: LoginRequest{}, Response: Envelope[*domain.SessionTokens]{}})
+	spec.Describe(h.Refresh, openapi.Operation{Summary: "Exchange a refresh token", Tags: tags, Request: RefreshRequest{}, Response: Envelope[*domain.SessionTokens]{}})
+	spec.Describe(h.Logout, openapi.Operation{Summary: "Sign out of the current session", Tags: tags})
+	spec.Describe(h.GetSessions, openapi.Operation{Summary: "List live sessions", Tags: tags, Query: sessionQuery{}, Response: Envelope[[]*domain.Session]{}})
+	spec.Describe(h.RevokeSession, openapi.Operation{Summary: "Revoke a session", Tags: tags})
+	spec.Describe(h.RevokeOtherSessions, openapi.Operation{Summary: "Revoke every other session of the caller", Tags: tags, Response: Envelope[RevokedSessions]{}})
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document
+func (h *APIKeyHandler) Describe(spec *openapi.Spec) {
+	tags := []string{"api-keys"}
+	spec.Describe(h.CreateAPIKey, openapi.Operation{Summary: "Issue an API key", Tags: tags, Request: CreateAPIKeyRequest{}, Response: Envelope[IssuedAPIKey]{}, Status: http.StatusCreated})
+	spec.Describe(h.GetAPIKeys, openapi.Operation{Summary: "List API keys", Tags: tags, Query: apiKeyQuery{}, Response: Envelope[[]*domain.ClientAPIKey]{}})
+	spec.Describe(h.GetAPIKeyByID, openapi.Operation{Summary: "Get an API key", Tags: tags, Response: Envelope[*domain.ClientAPIKey]{}})
+	spec.Describe(h.UpdateAPIKey, openapi.Operation{Summary: "Update an API key", Tags: tags, Request: domain.APIKeyUpdate{}, Response: Envelope[*domain.ClientAPIKey]{}})
+	spec.Describe(h.RotateAPIKey, openapi.Operation{Summary: "Rotate an API key", Tags: tags, Request: RotateAPIKeyRequest{}, RequestOptional: true, Response: Envelope[IssuedAPIKey]{}, Status: http.StatusCreated})
+	spec.Describe(h.RevokeAPIKey, openapi.Operation{Summary: "Revoke an API key", Tags: tags, Response: Envelope[*domain.ClientAPIKey]{}})
+	spec.Describe(h.GetAPIKeyUsage, openapi.Operation{Summary: "Get an API key's usage", Tags: tags, Response: Envelope[*domain.APIKeyUsage]{}})
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document
+func (h *WebhookHandler) Describe(spec *openapi.Spec) {
+	tags := []string{"webhooks"}
+	spec.Describe(h.CreateWebhook, openapi.Operation{Summary: "Register a webhook endpoint", Tags: tags, Request: CreateWebhookRequest{}, Response: Envelope[RegisteredWebhook]{}, Status: http.StatusCreated})
+	spec.Describe(h.GetWebhooks, openapi.Operation{Summary: "List webhook endpoints", Tags: tags, Query: pageQuery{}, Response: Envelope[[]*domain.WebhookEndpoint]{}})
+	spec.Describe(h.GetWebhookByID, openapi.Operation{Summary: "Get a webhook endpoint", Tags: tags, Response: Envelope[*domain.WebhookEndpoint]{}})
+	spec.Describe(h.UpdateWebhook, openapi.Operation{Summary: "Update a webhook endpoint", Tags: tags, Request: domain.WebhookEndpointUpdate{}, Response: Envelope[*domain.WebhookEndpoint]{}})
+	spec.Describe(h.RotateWebhookSecret, openapi.Operation{Summary: "Rotate a webhook endpoint's signing secret", Tags: tags, Response: Envelope[RegisteredWebhook]{}})
+	spec.Describe(h.DeleteWebhook, openapi.Operation{Summary: "Delete a webhook endpoint", Tags: tags})
+	spec.Describe(h.GetWebhookDeliveries, openapi.Operation{Summary: "List webhook deliveries, newest first", Tags: tags, Query: deliveryQuery{}, Response: Envelope[[]*domain.WebhookDelivery]{}})
+	spec.Describe(h.GetWebhookDeliveryByID, openapi.Operation{Summary: "Get a webhook delivery", Tags: tags, Response: Envelope[*domain.WebhookDelivery]{}})
+	spec.Describe(h.RedeliverWebhookDelivery, openapi.Operation{Summary: "Redeliver a webhook delivery", Tags: tags, Response: Envelope[*domain.WebhookDelivery]{}, Status: http.StatusAccepted})
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document
+func (h *AdminHandler) Describe(spec *openapi.Spec) {
+	tags := []string{"admin"}
+	spec.Describe(h.GetRuntimeInfo, openapi.Operation{Summary: "Get the instance's build and dependency health", Tags: tags, Response: Envelope[RuntimeInfo]{}})
+	spec.Describe(h.GetFeatureFlags, openapi.Operation{Summary: "List feature flags", Tags: tags, Response: Envelope[[]*domain.FeatureFlag]{}})
+	spec.Describe(h.GetFeatureFlag, openapi.Operation{Summary: "Get a feature flag", Tags: tags, Response: Envelope[*domain.FeatureFlag]{}})
+	spec.Describe(h.SetFeatureFlag, openapi.Operation{Summary: "Create or update a feature flag", Tags: tags, Request: domain.FeatureFlagUpdate{}, Response: Envelope[*domain.FeatureFlag]{}})
+	spec.Describe(h.DeleteFeatureFlag, openapi.Operation{Summary: "Delete a feature flag", Tags: tags})
+	spec.Describe(h.GetMaintenanceMode, openapi.Operation{Summary: "Get maintenance mode", Tags: tags, Response: Envelope[*domain.MaintenanceMode]{}})
+	spec.Describe(h.SetMaintenanceMode, openapi.Operation{Summary: "Turn maintenance mode on or off", Tags: tags, Request: SetMaintenanceRequest{}, Response: Envelope[*domain.MaintenanceMode]{}})
+	spec.Describe(h.GetEnabledFeatures, openapi.Operation{Summary: "List the features enabled for the caller", Tags: []string{"features"}, Response: Envelope[[]string]{}})
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document
+func (h *DeadLetterHandler) Describe(spec *openapi.Spec) {
+	tags := []string{"dead-letters"}
+	spec.Describe(h.GetDeadLetters, openapi.Operation{Summary: "List dead letters", Tags: tags, Query: deadLetterQuery{}, Response: Envelope[[]*domain.DeadLetter]{}})
+	spec.Describe(h.GetDeadLetterByID, openapi.Operation{Summary: "Get a dead letter", Tags: tags, Response: Envelope[*domain.DeadLetter]{}})
+	spec.Describe(h.ReprocessDeadLetter, openapi.Operation{Summary: "Reprocess a dead letter", Tags: tags, Response: Envelope[*domain.DeadLetter]{}})
+	spec.Describe(h.DiscardDeadLetter, openapi.Operation{Summary: "Discard a dead letter", Tags: tags, Response: Envelope[*domain.DeadLetter]{}})
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document. Uploads
+// are NDJSON or CSV files rather than JSON bodies, so they are not validated.
+func (h *TransactionImportHandler) Describe(spec *openapi.Spec) {
+	tags := []string{"transactions"}
+	spec.Describe(h.ImportTransactions, openapi.Operation{Summary: "Upload an exchange's transaction report", Description: "The file is sent as the body or as the file part of a multipart form.", Tags: tags, Query: importQuery{}, Response: Envelope[*domain.TransactionImport]{}, Status: http.StatusAccepted})
+	spec.Describe(h.GetTransactionImport, openapi.Operation{Summary: "Get the progress of a transaction import", Tags: tags, Response: Envelope[*domain.TransactionImport]{}})
+	spec.Describe(h.GetTransactionImportErrors, openapi.Operation{Summary: "List the rejected rows of a transaction import", Tags: tags, Query: pageQuery{}, Response: Envelope[[]*domain.ImportRowError]{}})
+}
+
+// Describe annotates the handlers for the gateway's OpenAPI document
+func (h *UpstreamHandler) Describe(spec *openapi.Spec) {
+	spec.Describe(h.GetUpstreams, openapi.Operation{Summary: "Get the state of every upstream and target", Tags: []string{"routing"}, Response: Envelope[[]upstream.UpstreamStatus]{}})
+	spec.Describe(h.GetDownstreams, openapi.Operation{Summary: "Get the circuit state of services the gateway calls", Tags: []string{"routing"}, Response: Envelope[[]resilience.UpstreamState]{}})
+}
+
+// Describe annotates the handler for the gateway's OpenAPI document
+func (h *SLOHandler) Describe(spec *openapi.Spec) {
+	spec.Describe(h.GetSLOStatus, openapi.Operation{Summary: "Get the error budgets of the route groups' SLOs", Tags: []string{"dashboard"}, Response: Envelope[[]*domain.SLOStatus]{}})
+}
+
+// Describe annotates the handler for the gateway's OpenAPI document. Queries
+// are described by the GraphQL schema itself.
+func (h *GraphQLHandler) Describe(spec *openapi.Spec) {
+	spec.Describe(h.Query, openapi.Operation{Summary: "Execute a read-only GraphQL query", Tags: []string{"graphql"}})
+}
+
+// RejectInvalid answers requests that do not match the OpenAPI document in
+// the gateway's error format
+func RejectInvalid(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "INVALID_REQUEST",
+			Message: i18n.T(c, "Invalid request"),
+			Details: err.Error(),
+			Fields:  problem.Classify(err).Fields,
+		},
+	})
+}
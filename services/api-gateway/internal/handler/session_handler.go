@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler contains the HTTP handlers for signing in and managing sessions
+type SessionHandler struct {
+	service ports.SessionService
+}
+
+// NewSessionHandler creates a new session handler instance
+func NewSessionHandler(service ports.SessionService) *SessionHandler {
+	return &SessionHandler{service: service}
+}
+
+// LoginRequest represents a sign-in request. Device is a name for the
+// client shown in the session list; the User-Agent is used when it is empty.
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Device   string `json:"device"`
+}
+
+// RefreshRequest represents a request to exchange a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Login signs a user in and returns an access token and refresh token
+func (h *SessionHandler) Login(c *gin.Context) {
+	var req LoginRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	tokens, err := h.service.Login(c.Request.Context(), req.Username, req.Password, sessionClient(c, req.Device))
+	if err != nil {
+		h.writeError(c, err, "Failed to sign in")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    tokens,
+	})
+}
+
+// Refresh exchanges a refresh token for a new access token and refresh token
+func (h *SessionHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	tokens, err := h.service.Refresh(c.Request.Context(), req.RefreshToken, sessionClient(c, ""))
+	if err != nil {
+		h.writeError(c, err, "Failed to refresh session")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    tokens,
+	})
+}
+
+// Logout ends the current session
+func (h *SessionHandler) Logout(c *gin.Context) {
+	if err := h.service.RevokeSession(c.Request.Context(), c.GetString("session_id"), c.GetString("user_id")); err != nil {
+		h.writeError(c, err, "Failed to sign out")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+	})
+}
+
+// GetSessions lists the caller's live sessions. Administrators may list
+// another user's sessions with ?user_id=.
+func (h *SessionHandler) GetSessions(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if other := c.Query("user_id"); other != "" && other != userID {
+		if c.GetString("role") != "ADMIN" {
+			h.writeForbidden(c)
+			return
+		}
+		userID = other
+	}
+
+	sessions, err := h.service.ListSessions(c.Request.Context(), userID, c.GetString("session_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to get sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    sessions,
+	})
+}
+
+// RevokeSession ends one session. Users may revoke their own sessions and
+// administrators any session.
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if c.GetString("role") == "ADMIN" {
+		userID = ""
+	}
+
+	if err := h.service.RevokeSession(c.Request.Context(), c.Param("id"), userID); err != nil {
+		h.writeError(c, err, "Failed to revoke session")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+	})
+}
+
+// RevokeOtherSessions ends every session of the caller except the current one
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	revoked, err := h.service.RevokeOtherSessions(c.Request.Context(), c.GetString("user_id"), c.GetString("session_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to revoke sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    gin.H{"revoked": revoked},
+	})
+}
+
+// bind decodes the request body, writing a 400 response if it is invalid
+func (h *SessionHandler) bind(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		writeInvalidRequest(c, err)
+		return false
+	}
+	return true
+}
+
+// sessionClient describes the device making the request
+func sessionClient(c *gin.Context, device string) domain.SessionClient {
+	return domain.SessionClient{
+		Device:    device,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+func (h *SessionHandler) writeForbidden(c *gin.Context) {
+	c.JSON(http.StatusForbidden, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "FORBIDDEN",
+			Message: i18n.T(c, "Insufficient permissions"),
+		},
+	})
+}
+
+func (h *SessionHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_CREDENTIALS",
+				Message: i18n.T(c, "Invalid username or password"),
+			},
+		})
+	case errors.Is(err, domain.ErrInvalidRefreshToken), errors.Is(err, domain.ErrRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_REFRESH_TOKEN",
+				Message: i18n.T(c, message),
+				Details: err.Error(),
+			},
+		})
+	case errors.Is(err, domain.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Session not found"),
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: i18n.T(c, message),
+			},
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/gin-gonic/gin"
+)
+
+// SLOHandler contains the HTTP handler reporting route groups' SLO error budgets
+type SLOHandler struct {
+	service ports.SLOService
+}
+
+// NewSLOHandler creates a new SLO handler instance
+func NewSLOHandler(service ports.SLOService) *SLOHandler {
+	return &SLOHandler{service: service}
+}
+
+// GetSLOStatus returns the error budget and burn rates of every objective, as
+// measured by the instance answering
+func (h *SLOHandler) GetSLOStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.service.GetSLOStatus(),
+	})
+}
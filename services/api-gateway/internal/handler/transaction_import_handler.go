@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// importContentTypes maps the media types accepted for transaction uploads to
+// their import format
+var importContentTypes = map[string]string{
+	"text/csv":                domain.ImportFormatCSV,
+	"application/csv":         domain.ImportFormatCSV,
+	"application/x-ndjson":    domain.ImportFormatNDJSON,
+	"application/ndjson":      domain.ImportFormatNDJSON,
+	"application/jsonl":       domain.ImportFormatNDJSON,
+	"application/x-jsonlines": domain.ImportFormatNDJSON,
+}
+
+// importExtensions maps upload file extensions to their import format
+var importExtensions = map[string]string{
+	".csv":    domain.ImportFormatCSV,
+	".ndjson": domain.ImportFormatNDJSON,
+	".jsonl":  domain.ImportFormatNDJSON,
+}
+
+// TransactionImportHandler contains the HTTP handlers for exchanges' transaction reports
+type TransactionImportHandler struct {
+	service        ports.TransactionImportService
+	maxUploadBytes int64
+}
+
+// NewTransactionImportHandler creates a new transaction import handler instance
+func NewTransactionImportHandler(service ports.TransactionImportService, maxUploadBytes int64) *TransactionImportHandler {
+	return &TransactionImportHandler{
+		service:        service,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+// ImportTransactions receives an exchange's transaction report, sent either as
+// the request body or as the "file" part of a multipart form. The upload is
+// validated as it is read; compliance checks run after the response.
+func (h *TransactionImportHandler) ImportTransactions(c *gin.Context) {
+	exchangeID := c.Param("id")
+	if !h.canAccessExchange(c, exchangeID) {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes)
+
+	body, format, err := h.uploadBody(c)
+	if err != nil {
+		h.writeImportError(c, nil, err)
+		return
+	}
+
+	imp, err := h.service.Import(c.Request.Context(), exchangeID, format, body, c.GetString("user_id"))
+	if err != nil {
+		h.writeImportError(c, imp, err)
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/import")+"/imports/"+imp.ID)
+	c.JSON(http.StatusAccepted, Response{
+		Success: true,
+		Data:    imp,
+	})
+}
+
+// GetTransactionImport returns the progress and totals of a transaction import
+func (h *TransactionImportHandler) GetTransactionImport(c *gin.Context) {
+	exchangeID := c.Param("id")
+	if !h.canAccessExchange(c, exchangeID) {
+		return
+	}
+
+	imp, err := h.service.GetImport(c.Request.Context(), exchangeID, c.Param("importId"))
+	if err != nil {
+		h.writeLookupError(c, err, "Failed to get transaction import")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    imp,
+	})
+}
+
+// GetTransactionImportErrors returns a paginated report of the rows of an
+// import that were rejected or flagged, ordered by row
+func (h *TransactionImportHandler) GetTransactionImportErrors(c *gin.Context) {
+	exchangeID := c.Param("id")
+	if !h.canAccessExchange(c, exchangeID) {
+		return
+	}
+
+	page, pageSize := paginationParams(c)
+	rowErrors, err := h.service.GetImportErrors(c.Request.Context(), exchangeID, c.Param("importId"), page, pageSize)
+	if err != nil {
+		h.writeLookupError(c, err, "Failed to get transaction import errors")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    rowErrors.Items,
+		Meta: &MetaInfo{
+			Page:       rowErrors.Page,
+			PageSize:   rowErrors.PageSize,
+			Total:      rowErrors.Total,
+			TotalPages: rowErrors.TotalPages,
+		},
+	})
+}
+
+// canAccessExchange responds with 403 if the caller authenticated with an API
+// key issued to a different exchange. JWT users may access any exchange.
+func (h *TransactionImportHandler) canAccessExchange(c *gin.Context, exchangeID string) bool {
+	if _, isAPIKey := c.Get("api_key_id"); !isAPIKey || c.GetString("client_id") == exchangeID {
+		return true
+	}
+
+	c.JSON(http.StatusForbidden, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "FORBIDDEN",
+			Message: i18n.T(c, "API key is not issued to this exchange"),
+		},
+	})
+	return false
+}
+
+// uploadBody returns the uploaded file and its format. The format is taken
+// from the format query parameter, then the content type, then the file
+// extension.
+func (h *TransactionImportHandler) uploadBody(c *gin.Context) (io.Reader, string, error) {
+	format := strings.ToLower(c.Query("format"))
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if format == "" {
+			format = importContentTypes[mediaType]
+		}
+		return c.Request.Body, format, nil
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, "", fmt.Errorf("%w: multipart form has no file part", domain.ErrInvalidImport)
+		}
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, "", err
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		if format == "" {
+			partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			format = importContentTypes[partType]
+		}
+		if format == "" {
+			format = importExtensions[strings.ToLower(path.Ext(part.FileName()))]
+		}
+		return part, format, nil
+	}
+}
+
+// writeImportError maps a failed upload to its response. An import that was
+// started but aborted is included so the client can look up its row errors.
+func (h *TransactionImportHandler) writeImportError(c *gin.Context, imp *domain.TransactionImport, err error) {
+	var maxBytesErr *http.MaxBytesError
+	status, code, message := http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to import transactions"
+	switch {
+	case errors.Is(err, domain.ErrExchangeNotFound):
+		status, code, message = http.StatusNotFound, "NOT_FOUND", "Exchange not found"
+	case errors.Is(err, domain.ErrUnsupportedImportFormat):
+		status, code, message = http.StatusUnsupportedMediaType, "UNSUPPORTED_FORMAT", "Upload must be NDJSON or CSV"
+	case errors.Is(err, domain.ErrImportTooLarge), errors.As(err, &maxBytesErr):
+		status, code, message = http.StatusRequestEntityTooLarge, "IMPORT_TOO_LARGE", "Upload is too large"
+	case errors.Is(err, domain.ErrInvalidImport):
+		status, code, message = http.StatusBadRequest, "INVALID_IMPORT", "Upload could not be read"
+	}
+
+	details := ""
+	if status != http.StatusInternalServerError {
+		details = err.Error()
+	}
+
+	response := Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: i18n.T(c, message),
+			Details: details,
+		},
+	}
+	if imp != nil {
+		response.Data = imp
+	}
+	c.JSON(status, response)
+}
+
+func (h *TransactionImportHandler) writeLookupError(c *gin.Context, err error, message string) {
+	if errors.Is(err, domain.ErrTransactionImportNotFound) {
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Transaction import not found"),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "INTERNAL_ERROR",
+			Message: i18n.T(c, message),
+		},
+	})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/csic-platform/services/api-gateway/internal/adapter/upstream"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/csic-platform/shared/resilience"
+	"github.com/gin-gonic/gin"
+)
+
+// upstreamContextKey is the gin context key holding the matched upstream
+const upstreamContextKey = "upstream"
+
+// UpstreamHandler contains the HTTP handlers that proxy requests to upstream
+// services and report on the services the gateway calls
+type UpstreamHandler struct {
+	router     *upstream.Router
+	downstream *resilience.Breakers
+}
+
+// NewUpstreamHandler creates a new upstream handler instance
+func NewUpstreamHandler(router *upstream.Router, downstream *resilience.Breakers) *UpstreamHandler {
+	return &UpstreamHandler{router: router, downstream: downstream}
+}
+
+// Match resolves the upstream for the request path, responding 404 if none
+// serves it. It runs before authentication so unknown paths are not reported
+// as unauthorized.
+func (h *UpstreamHandler) Match(c *gin.Context) {
+	u := h.router.Match(c.Request.URL.Path)
+	if u == nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Route not found"),
+			},
+		})
+		return
+	}
+
+	c.Set(upstreamContextKey, u)
+	c.Next()
+}
+
+// Proxy forwards the request to the upstream resolved by Match
+func (h *UpstreamHandler) Proxy(c *gin.Context) {
+	u, ok := c.MustGet(upstreamContextKey).(*upstream.Upstream)
+	if !ok {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	err := u.Proxy(c.Writer, c.Request)
+	if err == nil {
+		return
+	}
+
+	switch {
+	case errors.Is(err, upstream.ErrNoAvailableTarget):
+		c.JSON(http.StatusServiceUnavailable, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "UPSTREAM_UNAVAILABLE",
+				Message: i18n.Tf(c, "No healthy instance of %s is available", u.Name()),
+			},
+		})
+	case upstream.IsTimeout(err):
+		c.JSON(http.StatusGatewayTimeout, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "UPSTREAM_TIMEOUT",
+				Message: i18n.Tf(c, "Upstream %s did not respond in time", u.Name()),
+			},
+		})
+	case c.Request.Context().Err() != nil:
+		// The client went away; there is nobody to respond to
+		c.Abort()
+	default:
+		c.JSON(http.StatusBadGateway, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "BAD_GATEWAY",
+				Message: i18n.Tf(c, "Upstream %s request failed", u.Name()),
+			},
+		})
+	}
+}
+
+// GetUpstreams returns the routing table with target health and circuit state
+func (h *UpstreamHandler) GetUpstreams(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.router.Status(),
+	})
+}
+
+// GetDownstreams returns the circuit state of each service the gateway has called itself
+func (h *UpstreamHandler) GetDownstreams(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    h.downstream.States(),
+	})
+}
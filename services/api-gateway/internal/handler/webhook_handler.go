@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler contains the HTTP handlers for partner webhook endpoints and
+// their delivery log
+type WebhookHandler struct {
+	service ports.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(service ports.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// CreateWebhookRequest represents a request to register a webhook endpoint
+type CreateWebhookRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	Secret     string   `json:"secret"`
+}
+
+// RegisteredWebhook is returned when an endpoint is registered or its secret
+// rotated. Secret is shown only then.
+type RegisteredWebhook struct {
+	Endpoint *domain.WebhookEndpoint `json:"endpoint"`
+	Secret   string                  `json:"secret"`
+}
+
+// CreateWebhook registers a partner agency's webhook endpoint
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeInvalidRequest(c, err)
+		return
+	}
+
+	endpoint := &domain.WebhookEndpoint{
+		Name:       req.Name,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	}
+
+	secret, err := h.service.CreateEndpoint(c.Request.Context(), endpoint, c.GetString("user_id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, Response{
+		Success: true,
+		Data:    RegisteredWebhook{Endpoint: endpoint, Secret: secret},
+	})
+}
+
+// GetWebhooks returns a paginated list of webhook endpoints
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	page, pageSize := paginationParams(c)
+
+	endpoints, err := h.service.GetEndpoints(c.Request.Context(), page, pageSize)
+	if err != nil {
+		h.writeError(c, err, "Failed to get webhooks")
+		return
+	}
+
+	h.writePage(c, endpoints)
+}
+
+// GetWebhookByID returns a specific webhook endpoint by ID
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	endpoint, err := h.service.GetEndpointByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to get webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    endpoint,
+	})
+}
+
+// UpdateWebhook changes a webhook endpoint's URL or subscriptions, or pauses it
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var update domain.WebhookEndpointUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		writeInvalidRequest(c, err)
+		return
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(c.Request.Context(), c.Param("id"), &update)
+	if err != nil {
+		h.writeError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    endpoint,
+	})
+}
+
+// RotateWebhookSecret replaces a webhook endpoint's signing secret
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	endpoint, secret, err := h.service.RotateEndpointSecret(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to rotate webhook secret")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    RegisteredWebhook{Endpoint: endpoint, Secret: secret},
+	})
+}
+
+// DeleteWebhook removes a webhook endpoint and its delivery log
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.service.DeleteEndpoint(c.Request.Context(), c.Param("id")); err != nil {
+		h.writeError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+	})
+}
+
+// GetWebhookDeliveries returns the delivery log, newest first, optionally
+// filtered by endpoint and status. Under /webhooks/:id/deliveries the
+// endpoint is taken from the path.
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	page, pageSize := paginationParams(c)
+	status := strings.ToUpper(c.Query("status"))
+
+	switch domain.WebhookDeliveryStatus(status) {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliverySending,
+		domain.WebhookDeliverySucceeded, domain.WebhookDeliveryFailed:
+	default:
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_REQUEST",
+				Message: i18n.T(c, "Invalid webhook delivery status"),
+				Details: status,
+			},
+		})
+		return
+	}
+
+	endpointID := c.Param("id")
+	if endpointID == "" {
+		endpointID = c.Query("endpoint_id")
+	} else if _, err := h.service.GetEndpointByID(c.Request.Context(), endpointID); err != nil {
+		h.writeError(c, err, "Failed to get webhook deliveries")
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), endpointID, status, page, pageSize)
+	if err != nil {
+		h.writeError(c, err, "Failed to get webhook deliveries")
+		return
+	}
+
+	h.writePage(c, deliveries)
+}
+
+// GetWebhookDeliveryByID returns a specific delivery, including its payload
+// and the outcome of its last attempt
+func (h *WebhookHandler) GetWebhookDeliveryByID(c *gin.Context) {
+	delivery, err := h.service.GetDeliveryByID(c.Request.Context(), c.Param("deliveryId"))
+	if err != nil {
+		h.writeError(c, err, "Failed to get webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    delivery,
+	})
+}
+
+// RedeliverWebhookDelivery queues a finished delivery to be sent again
+func (h *WebhookHandler) RedeliverWebhookDelivery(c *gin.Context) {
+	delivery, err := h.service.RedeliverDelivery(c.Request.Context(), c.Param("deliveryId"))
+	if err != nil {
+		h.writeError(c, err, "Failed to redeliver webhook")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, Response{
+		Success: true,
+		Data:    delivery,
+	})
+}
+
+func (h *WebhookHandler) writePage(c *gin.Context, page *domain.PaginatedResponse) {
+	c.JSON(http.StatusOK, Response{
+		Success: true,
+		Data:    page.Items,
+		Meta: &MetaInfo{
+			Page:       page.Page,
+			PageSize:   page.PageSize,
+			Total:      page.Total,
+			TotalPages: page.TotalPages,
+		},
+	})
+}
+
+func (h *WebhookHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookEndpointNotFound):
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Webhook not found"),
+			},
+		})
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: i18n.T(c, "Webhook delivery not found"),
+			},
+		})
+	case errors.Is(err, domain.ErrInvalidWebhookSpec), errors.Is(err, domain.ErrInvalidWebhookEventType):
+		c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INVALID_REQUEST",
+				Message: i18n.T(c, message),
+				Details: err.Error(),
+			},
+		})
+	case errors.Is(err, domain.ErrWebhookDeliveryInProgress):
+		c.JSON(http.StatusConflict, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "CONFLICT",
+				Message: i18n.T(c, message),
+				Details: err.Error(),
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, Response{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "INTERNAL_ERROR",
+				Message: i18n.T(c, message),
+			},
+		})
+	}
+}
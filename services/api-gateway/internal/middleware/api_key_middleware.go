@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the request header carrying an API key
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyRole is the role given to requests authenticated with an API key, so
+// that routes guarded by RequireRole stay closed to machine clients
+const APIKeyRole = "SERVICE"
+
+// APIKeyMiddleware authenticates machine clients by API key and enforces the
+// key's scopes, rate limit and daily quota
+type APIKeyMiddleware struct {
+	logger  Logger
+	service ports.APIKeyService
+}
+
+// NewAPIKeyMiddleware creates a new API key middleware instance
+func NewAPIKeyMiddleware(logger Logger, service ports.APIKeyService) *APIKeyMiddleware {
+	return &APIKeyMiddleware{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// Authenticate returns a middleware that authenticates requests carrying an
+// X-API-Key header and hands every other request to fallback, typically JWT
+// authentication
+func (m *APIKeyMiddleware) Authenticate(fallback gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" {
+			fallback(c)
+			return
+		}
+
+		key, usage, err := m.service.Authenticate(c.Request.Context(), rawKey)
+		if usage != nil {
+			setUsageHeaders(c, usage)
+		}
+		if err != nil {
+			m.reject(c, key, err)
+			return
+		}
+
+		c.Set("user_id", "apikey:"+key.ID)
+		c.Set("username", key.Name)
+		c.Set("role", APIKeyRole)
+		c.Set("api_key_id", key.ID)
+		c.Set("client_id", key.ClientID)
+		c.Set("scopes", key.Scopes)
+
+		c.Next()
+	}
+}
+
+// RequireScope returns a middleware that checks that a request authenticated
+// with an API key has been granted the scope. Requests authenticated otherwise
+// are left to the route's role checks.
+func (m *APIKeyMiddleware) RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, isAPIKey := c.Get("api_key_id"); !isAPIKey {
+			c.Next()
+			return
+		}
+
+		scopes, _ := c.Get("scopes")
+		granted, _ := scopes.([]string)
+		for _, s := range granted {
+			if s == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INSUFFICIENT_SCOPE",
+				"message": i18n.Tf(c, "API key does not have the %s scope", scope),
+			},
+		})
+	}
+}
+
+// reject responds to a request whose API key could not be used
+func (m *APIKeyMiddleware) reject(c *gin.Context, key *domain.ClientAPIKey, err error) {
+	status, code, message := http.StatusUnauthorized, "INVALID_API_KEY", "Invalid API key"
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidAPIKey):
+		m.logger.Warn("invalid API key", "client_ip", c.ClientIP())
+	case errors.Is(err, domain.ErrAPIKeyExpired):
+		code, message = "API_KEY_EXPIRED", "API key has expired"
+	case errors.Is(err, domain.ErrAPIKeyNotActive):
+		code, message = "API_KEY_REVOKED", "API key has been revoked"
+	case errors.Is(err, domain.ErrRateLimitExceeded):
+		status, code, message = http.StatusTooManyRequests, "RATE_LIMITED", "API key rate limit exceeded"
+		c.Header("Retry-After", strconv.Itoa(60-time.Now().Second()))
+	case errors.Is(err, domain.ErrDailyQuotaExceeded):
+		status, code, message = http.StatusTooManyRequests, "QUOTA_EXCEEDED", "API key daily quota exceeded"
+	default:
+		// Limits cannot be enforced without the usage store, so fail closed
+		m.logger.Error("failed to authenticate API key", "error", err)
+		status, code, message = http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "API key authentication is unavailable"
+	}
+
+	if key != nil && status == http.StatusTooManyRequests {
+		m.logger.Warn("API key limit exceeded", "api_key_id", key.ID, "client_id", key.ClientID, "error", err)
+	}
+
+	c.AbortWithStatusJSON(status, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    code,
+			"message": i18n.T(c, message),
+		},
+	})
+}
+
+// setUsageHeaders reports the key's remaining rate limit and daily quota
+func setUsageHeaders(c *gin.Context, usage *domain.APIKeyUsage) {
+	if usage.RateLimit > 0 {
+		remaining := int64(usage.RateLimit) - usage.MinuteRequests
+		if remaining < 0 {
+			remaining = 0
+		}
+		c.Header("X-RateLimit-Limit", strconv.Itoa(usage.RateLimit))
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+	}
+	if usage.DailyQuota > 0 {
+		c.Header("X-Quota-Limit", strconv.Itoa(usage.DailyQuota))
+		c.Header("X-Quota-Remaining", strconv.FormatInt(usage.RemainingQuota(), 10))
+		c.Header("X-Quota-Reset", usage.QuotaResetsAt)
+	}
+}
@@ -1,19 +1,23 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/csic-platform/services/api-gateway/internal/adapter/auth"
 	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/i18n"
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware handles JWT authentication
+// AuthMiddleware handles JWT authentication. With a session service, tokens
+// must name a live session, so revoking a session revokes its tokens.
 type AuthMiddleware struct {
 	logger    Logger
 	authService *auth.AuthServiceImpl
+	sessions  ports.SessionService
 	secret    string
 }
 
@@ -24,11 +28,13 @@ type Logger interface {
 	Warn(msg string, fields ...interface{})
 }
 
-// NewAuthMiddleware creates a new auth middleware instance
-func NewAuthMiddleware(logger Logger, secret string) *AuthMiddleware {
+// NewAuthMiddleware creates a new auth middleware instance; sessions may be
+// nil, in which case tokens are checked on their signature and expiry alone
+func NewAuthMiddleware(logger Logger, secret string, sessions ports.SessionService) *AuthMiddleware {
 	return &AuthMiddleware{
 		logger:    logger,
 		authService: auth.NewAuthService(secret),
+		sessions:  sessions,
 		secret:    secret,
 	}
 }
@@ -42,7 +48,7 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "UNAUTHORIZED",
-					"message": "Authorization header is required",
+					"message": i18n.T(c, "Authorization header is required"),
 				},
 			})
 			return
@@ -55,7 +61,7 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "UNAUTHORIZED",
-					"message": "Invalid authorization header format",
+					"message": i18n.T(c, "Invalid authorization header format"),
 				},
 			})
 			return
@@ -66,13 +72,13 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		// Validate token
 		claims, err := m.authService.ValidateToken(tokenString)
 		if err != nil {
-			if err == ports.ErrExpiredToken {
+			if errors.Is(err, auth.ErrExpiredToken) {
 				m.logger.Warn("token expired", "error", err)
 				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 					"success": false,
 					"error": gin.H{
 						"code":    "TOKEN_EXPIRED",
-						"message": "Token has expired",
+						"message": i18n.T(c, "Token has expired"),
 					},
 				})
 				return
@@ -83,18 +89,48 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "INVALID_TOKEN",
-					"message": "Invalid token",
+					"message": i18n.T(c, "Invalid token"),
 				},
 			})
 			return
 		}
 
+		// Reject tokens of revoked or expired sessions, recording activity on live ones
+		if m.sessions != nil {
+			live := false
+			if claims.SessionID != "" {
+				live, err = m.sessions.Touch(c.Request.Context(), claims.SessionID, c.ClientIP())
+				if err != nil {
+					m.logger.Error("session check failed", "error", err)
+					c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+						"success": false,
+						"error": gin.H{
+							"code":    "SERVICE_UNAVAILABLE",
+							"message": i18n.T(c, "Failed to check session"),
+						},
+					})
+					return
+				}
+			}
+			if !live {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"success": false,
+					"error": gin.H{
+						"code":    "SESSION_REVOKED",
+						"message": i18n.T(c, "Session has been revoked or has expired"),
+					},
+				})
+				return
+			}
+		}
+
 		// Set user info in context
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
 		c.Set("role", claims.Role)
 		c.Set("permissions", claims.Permissions)
 		c.Set("token_id", claims.TokenID)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
@@ -109,7 +145,7 @@ func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "FORBIDDEN",
-					"message": "User role not found",
+					"message": i18n.T(c, "User role not found"),
 				},
 			})
 			return
@@ -127,7 +163,7 @@ func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 			"success": false,
 			"error": gin.H{
 				"code":    "FORBIDDEN",
-				"message": "Insufficient permissions",
+				"message": i18n.T(c, "Insufficient permissions"),
 			},
 		})
 	}
@@ -142,7 +178,7 @@ func (m *AuthMiddleware) RequirePermission(permissions ...string) gin.HandlerFun
 				"success": false,
 				"error": gin.H{
 					"code":    "FORBIDDEN",
-					"message": "User permissions not found",
+					"message": i18n.T(c, "User permissions not found"),
 				},
 			})
 			return
@@ -162,7 +198,7 @@ func (m *AuthMiddleware) RequirePermission(permissions ...string) gin.HandlerFun
 			"success": false,
 			"error": gin.H{
 				"code":    "FORBIDDEN",
-				"message": "Insufficient permissions",
+				"message": i18n.T(c, "Insufficient permissions"),
 			},
 		})
 	}
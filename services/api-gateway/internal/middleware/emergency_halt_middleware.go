@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/domain"
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/csic-platform/shared/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// Request headers used to scope emergency stops
+const (
+	ExchangeIDHeader = "X-Exchange-ID"
+	RegionHeader     = "X-Region"
+)
+
+// EmergencyHaltMiddleware rejects mutating requests covered by an active
+// emergency stop
+type EmergencyHaltMiddleware struct {
+	logger      Logger
+	service     ports.EmergencyHaltService
+	exemptPaths []string
+}
+
+// NewEmergencyHaltMiddleware creates a new emergency halt middleware instance.
+// Requests under an exempt path prefix are never blocked, so operators can
+// still reach health checks and the emergency controls themselves.
+func NewEmergencyHaltMiddleware(logger Logger, service ports.EmergencyHaltService, exemptPaths []string) *EmergencyHaltMiddleware {
+	return &EmergencyHaltMiddleware{
+		logger:      logger,
+		service:     service,
+		exemptPaths: exemptPaths,
+	}
+}
+
+// Enforce returns the emergency halt middleware
+func (m *EmergencyHaltMiddleware) Enforce() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		for _, prefix := range m.exemptPaths {
+			if strings.HasPrefix(path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		stop := m.service.BlockingStop(&domain.HaltCheck{
+			Method:     c.Request.Method,
+			Path:       path,
+			ExchangeID: c.GetHeader(ExchangeIDHeader),
+			Region:     c.GetHeader(RegionHeader),
+		})
+		if stop == nil {
+			c.Next()
+			return
+		}
+
+		m.logger.Warn("request rejected by emergency stop",
+			logger.String("stop_id", stop.ID),
+			logger.String("scope", stop.Scope),
+			logger.String("method", c.Request.Method),
+			logger.String("path", path),
+		)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error":          "operations halted by emergency stop",
+			"emergency_stop": stop,
+		})
+	}
+}
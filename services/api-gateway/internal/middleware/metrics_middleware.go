@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/csic-platform/services/api-gateway/internal/core/ports"
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware measures the requests of each route group, exporting them
+// to Prometheus and counting them against the group's SLO objectives
+type MetricsMiddleware struct {
+	metrics ports.RequestMetrics
+	slo     ports.SLOService
+}
+
+// NewMetricsMiddleware creates a new metrics middleware instance. slo may be
+// nil when SLO tracking is disabled.
+func NewMetricsMiddleware(metrics ports.RequestMetrics, slo ports.SLOService) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		metrics: metrics,
+		slo:     slo,
+	}
+}
+
+// Track returns the middleware measuring requests of a route group. It goes
+// first in the group, so time spent authenticating and rate limiting counts
+// towards the group's latency.
+func (m *MetricsMiddleware) Track(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		duration := time.Since(start)
+		status := c.Writer.Status()
+		m.metrics.ObserveRequest(group, c.Request.Method, status, duration)
+		if m.slo != nil {
+			m.slo.Record(group, status, duration)
+		}
+	}
+}
@@ -31,6 +31,7 @@ func main() {
 		cfg = getDefaultConfig()
 		fmt.Printf("Warning: Failed to load config file, using defaults: %v\n", err)
 	}
+	defer configLoader.Close()
 
 	// Initialize logger using shared logger package
 	appLogger, err := logger.NewLogger(logger.Config{
@@ -0,0 +1,4 @@
+-- CSIC Platform - API Gateway Database Schema
+-- Index alert evidence so compliance violation alerts can be looked up by violation ID
+
+CREATE INDEX IF NOT EXISTS idx_alerts_evidence ON alerts USING GIN (evidence jsonb_path_ops);
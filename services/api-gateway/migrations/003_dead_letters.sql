@@ -0,0 +1,26 @@
+-- CSIC Platform - API Gateway Database Schema
+-- Dead letters: messages consumers gave up on, kept for browsing and reprocessing
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id VARCHAR(36) PRIMARY KEY DEFAULT uuid_generate_v4(),
+    topic VARCHAR(255) NOT NULL,
+    original_topic VARCHAR(255) NOT NULL,
+    partition INTEGER NOT NULL,
+    "offset" BIGINT NOT NULL,
+    consumer_group VARCHAR(255) NOT NULL,
+    message_key TEXT,
+    payload BYTEA NOT NULL,
+    headers JSONB DEFAULT '{}',
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    failed_at TIMESTAMP NOT NULL,
+    resolved_by VARCHAR(36),
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_dead_letters_source UNIQUE (original_topic, partition, "offset", consumer_group)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letters_status ON dead_letters(status);
+CREATE INDEX IF NOT EXISTS idx_dead_letters_failed_at ON dead_letters(failed_at DESC);
@@ -0,0 +1,5 @@
+-- CSIC Platform - API Gateway Database Schema
+-- Exchange version: bumped on every update so concurrent edits are detected
+-- instead of overwriting each other.
+
+ALTER TABLE exchanges ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,61 @@
+-- CSIC Platform - API Gateway Database Schema
+-- Transaction imports: exchanges' daily transaction uploads, the reported
+-- transactions and the per-row errors found validating and checking them
+
+CREATE TABLE IF NOT EXISTS transaction_imports (
+    id VARCHAR(36) PRIMARY KEY DEFAULT uuid_generate_v4(),
+    exchange_id VARCHAR(36) NOT NULL REFERENCES exchanges(id),
+    format VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'RECEIVING',
+    submitted_by VARCHAR(255) NOT NULL,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    accepted_rows INTEGER NOT NULL DEFAULT 0,
+    rejected_rows INTEGER NOT NULL DEFAULT 0,
+    checked_rows INTEGER NOT NULL DEFAULT 0,
+    flagged_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_imports_exchange ON transaction_imports(exchange_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_imports_status ON transaction_imports(status, created_at);
+
+CREATE TABLE IF NOT EXISTS reported_transactions (
+    id VARCHAR(36) PRIMARY KEY DEFAULT uuid_generate_v4(),
+    import_id VARCHAR(36) NOT NULL REFERENCES transaction_imports(id) ON DELETE CASCADE,
+    exchange_id VARCHAR(36) NOT NULL REFERENCES exchanges(id),
+    row_num INTEGER NOT NULL,
+    transaction_id VARCHAR(128) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    asset VARCHAR(20) NOT NULL,
+    amount NUMERIC(36, 18) NOT NULL,
+    amount_usd NUMERIC(24, 2) NOT NULL,
+    customer_id VARCHAR(255) NOT NULL,
+    from_address VARCHAR(255),
+    to_address VARCHAR(255),
+    occurred_at TIMESTAMP NOT NULL,
+    check_status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    check_attempts INTEGER NOT NULL DEFAULT 0,
+    claimed_at TIMESTAMP,
+    checked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_reported_transactions_exchange UNIQUE (exchange_id, transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reported_transactions_import ON reported_transactions(import_id, check_status, row_num);
+
+CREATE TABLE IF NOT EXISTS import_row_errors (
+    id BIGSERIAL PRIMARY KEY,
+    import_id VARCHAR(36) NOT NULL REFERENCES transaction_imports(id) ON DELETE CASCADE,
+    row_num INTEGER NOT NULL,
+    stage VARCHAR(20) NOT NULL,
+    field VARCHAR(50),
+    code VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_row_errors_import ON import_row_errors(import_id, row_num);
@@ -0,0 +1,4 @@
+-- CSIC Platform - API Gateway Database Schema
+-- Report locale: the language a compliance report's documents are generated in
+
+ALTER TABLE compliance_reports ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT 'en-US';
@@ -0,0 +1,26 @@
+-- CSIC Platform - API Gateway Database Schema
+-- Admin console: feature flags and the maintenance mode switch shared by
+-- every gateway instance
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(100) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    roles JSONB NOT NULL DEFAULT '[]',
+    percentage INTEGER NOT NULL DEFAULT 100 CHECK (percentage BETWEEN 0 AND 100),
+    updated_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A single row holds the maintenance mode switch
+CREATE TABLE IF NOT EXISTS maintenance_mode (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP,
+    updated_by VARCHAR(36) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO maintenance_mode (id) VALUES (1) ON CONFLICT (id) DO NOTHING;
@@ -14,22 +14,29 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/csic-platform/services/audit-log/replication"
+	"github.com/csic-platform/services/audit-log/siem"
 	"github.com/csic-platform/shared/config"
 	"github.com/csic-platform/shared/logger"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // AuditLogService provides immutable, tamper-evident audit logging
 type AuditLogService struct {
-	writer    *AuditLogWriter
-	sealer    *AuditLogSealer
-	verifier  *AuditLogVerifier
-	logger    *logger.Logger
-	config    *AuditConfig
-	mu        sync.RWMutex
-	running   bool
+	writer     *AuditLogWriter
+	sealer     *AuditLogSealer
+	verifier   *AuditLogVerifier
+	replicator *replication.Replicator
+	exports    *exportManager
+	forwarder  *siem.Forwarder
+	logger     *logger.Logger
+	config     *AuditConfig
+	mu         sync.RWMutex
+	running    bool
 }
 
 // AuditConfig holds configuration for the audit log service
@@ -40,6 +47,13 @@ type AuditConfig struct {
 	VerificationPath string `yaml:"verification_path"`
 	RetentionDays    int    `yaml:"retention_days"`
 	EnableWORM       bool   `yaml:"enable_worm"` // Write Once Read Many
+	MaxFileSize      int64  `yaml:"max_file_size"`   // segment size in bytes
+	FsyncPerBatch    bool   `yaml:"fsync_per_batch"` // fsync every written batch
+	Version          string `yaml:"-"`               // service version reported to the SIEM
+
+	Replication config.AuditReplicationConfig `yaml:"replication"`
+	Export      config.AuditExportConfig      `yaml:"export"`
+	SIEM        config.AuditSIEMConfig        `yaml:"siem"`
 }
 
 // AuditLogEntry represents a single audit log entry
@@ -85,6 +99,10 @@ type AuditLogEntry struct {
 	// Additional context
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 	TraceID  string `json:"trace_id,omitempty"`
+
+	// CorrelationID ties together the entries written by every service while
+	// handling one request; it is covered by the entry hash
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 // AuditChain represents a chain of sealed audit log entries
@@ -137,14 +155,88 @@ func NewAuditLogService(cfg *AuditConfig, logConfig logger.Config) (*AuditLogSer
 	}
 
 	// Initialize components
-	service.writer = NewAuditLogWriter(cfg.StoragePath, cfg.EnableWORM)
+	service.writer = NewAuditLogWriter(cfg.StoragePath, SegmentOptions{
+		SegmentSize:  cfg.MaxFileSize,
+		SyncPerBatch: cfg.FsyncPerBatch,
+		EnableWORM:   cfg.EnableWORM,
+	})
 	service.sealer = NewAuditLogSealer(cfg.ChainFilePath, cfg.SealInterval)
 	service.verifier = NewAuditLogVerifier(cfg.StoragePath, cfg.ChainFilePath)
 
+	// Replicate sealed segments to object storage
+	if cfg.Replication.Enabled {
+		store, err := replication.NewS3Store(context.Background(), replication.S3Config{
+			Endpoint:  cfg.Replication.Endpoint,
+			Region:    cfg.Replication.Region,
+			Bucket:    cfg.Replication.Bucket,
+			AccessKey: cfg.Replication.AccessKey,
+			SecretKey: cfg.Replication.SecretKey,
+			UseSSL:    cfg.Replication.UseSSL,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize replication storage: %w", err)
+		}
+		service.replicator = replication.NewReplicator(store, replication.Options{
+			StoragePath:   cfg.StoragePath,
+			Prefix:        cfg.Replication.Prefix,
+			Interval:      time.Duration(cfg.Replication.Interval) * time.Second,
+			RetentionDays: cfg.Replication.RetentionDays,
+		}, replication.NewMetrics(prometheus.DefaultRegisterer), appLogger)
+	}
+
+	// Export jobs write below the storage path unless configured otherwise
+	exportPath := cfg.Export.Path
+	if exportPath == "" {
+		exportPath = filepath.Join(cfg.StoragePath, "exports")
+	}
+	service.exports, err = newExportManager(exportPath, cfg.Export.RetentionHours, cfg.Export.MaxConcurrent, service.writer, appLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize exports: %w", err)
+	}
+
+	// Forward every entry to the national SIEM
+	if cfg.SIEM.Enabled {
+		tlsConfig, err := siem.NewTLSConfig(cfg.SIEM.CAFile, cfg.SIEM.CertFile, cfg.SIEM.KeyFile, cfg.SIEM.ServerName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize SIEM TLS: %w", err)
+		}
+
+		hostname := cfg.SIEM.Hostname
+		if hostname == "" {
+			hostname, _ = os.Hostname()
+		}
+		appName := cfg.SIEM.AppName
+		if appName == "" {
+			appName = "csic-audit-log"
+		}
+		facility := cfg.SIEM.Facility
+		if facility == 0 {
+			facility = siem.DefaultFacility
+		}
+
+		formatter, err := siem.NewFormatter(cfg.SIEM.Format, hostname, appName, facility, cfg.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize SIEM format: %w", err)
+		}
+		service.forwarder = siem.NewForwarder(formatter, siem.Options{
+			StoragePath:  cfg.StoragePath,
+			Address:      cfg.SIEM.Address,
+			TLS:          tlsConfig,
+			QueueSize:    cfg.SIEM.QueueSize,
+			PollInterval: time.Duration(cfg.SIEM.PollInterval) * time.Millisecond,
+			WriteTimeout: time.Duration(cfg.SIEM.WriteTimeout) * time.Second,
+			MaxBackoff:   time.Duration(cfg.SIEM.MaxBackoff) * time.Second,
+			Head:         service.writer.GetSequenceNumber,
+		}, siem.NewMetrics(prometheus.DefaultRegisterer), appLogger)
+	}
+
 	appLogger.Info("audit log service initialized",
 		logger.WithFields(
 			logger.String("storage_path", cfg.StoragePath),
 			logger.Bool("worm_enabled", cfg.EnableWORM),
+			logger.Bool("fsync_per_batch", cfg.FsyncPerBatch),
+			logger.Bool("replication_enabled", cfg.Replication.Enabled),
+			logger.Bool("siem_enabled", cfg.SIEM.Enabled),
 		),
 	)
 
@@ -166,11 +258,25 @@ func (s *AuditLogService) Start(ctx context.Context) error {
 	// Start the sealing routine
 	go s.sealingRoutine(ctx)
 
+	// Start replicating sealed segments
+	if s.replicator != nil {
+		go s.replicator.Run(ctx)
+	}
+
+	// Start deleting expired exports
+	go s.exports.cleanupRoutine(ctx)
+
+	// Start forwarding entries to the SIEM
+	if s.forwarder != nil {
+		go s.forwarder.Run(ctx)
+	}
+
 	s.logger.Info("audit log service started")
 	return nil
 }
 
-// Stop gracefully stops the audit log service
+// Stop seals the entries written since the last seal, so the WORM chain is
+// complete, and closes storage. It returns an error if either failed.
 func (s *AuditLogService) Stop() error {
 	s.mu.Lock()
 	if !s.running {
@@ -183,12 +289,19 @@ func (s *AuditLogService) Stop() error {
 	s.logger.Info("stopping audit log service")
 
 	// Seal any pending entries
+	var errs []error
 	if err := s.sealer.SealPending(s.writer.GetSequenceNumber()); err != nil {
 		s.logger.Error("failed to seal pending entries", logger.WithFields(logger.Error(err)))
+		errs = append(errs, fmt.Errorf("failed to seal pending entries: %w", err))
+	}
+
+	if err := s.writer.Close(); err != nil {
+		s.logger.Error("failed to close audit log storage", logger.WithFields(logger.Error(err)))
+		errs = append(errs, fmt.Errorf("failed to close audit log storage: %w", err))
 	}
 
 	s.logger.Info("audit log service stopped")
-	return nil
+	return errors.Join(errs...)
 }
 
 // WriteLog writes a new audit log entry
@@ -207,10 +320,8 @@ func (s *AuditLogService) WriteBatch(ctx context.Context, entries []*AuditLogEnt
 		return errors.New("audit log service is not running")
 	}
 
-	for _, entry := range entries {
-		if err := s.writer.Write(ctx, entry); err != nil {
-			return fmt.Errorf("failed to write entry %s: %w", entry.EntryID, err)
-		}
+	if err := s.writer.WriteBatch(ctx, entries); err != nil {
+		return fmt.Errorf("failed to write batch of %d entries: %w", len(entries), err)
 	}
 
 	return nil
@@ -236,11 +347,103 @@ func (s *AuditLogService) QueryEntries(ctx context.Context, query *AuditQuery) (
 	return s.writer.Query(ctx, query)
 }
 
+// TraceCorrelation reconstructs what every service did for one correlation
+// ID: the matching entries in time order, and a per-service summary in the
+// order the services were first involved. At most limit entries are returned.
+func (s *AuditLogService) TraceCorrelation(ctx context.Context, correlationID string, limit int) (*AuditTrace, error) {
+	// One extra entry is fetched to detect truncation
+	entries, err := s.writer.Query(ctx, &AuditQuery{CorrelationID: correlationID, Limit: limit + 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entries for correlation %s: %w", correlationID, err)
+	}
+
+	trace := &AuditTrace{CorrelationID: correlationID}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		trace.Truncated = true
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].SequenceNum < entries[j].SequenceNum
+		}
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	trace.Entries = entries
+	if len(entries) == 0 {
+		return trace, nil
+	}
+
+	trace.StartedAt = entries[0].Timestamp
+	trace.EndedAt = entries[len(entries)-1].Timestamp
+	trace.DurationMs = trace.EndedAt.Sub(trace.StartedAt).Milliseconds()
+
+	index := make(map[string]int)
+	for _, entry := range entries {
+		i, ok := index[entry.Service]
+		if !ok {
+			i = len(trace.Services)
+			index[entry.Service] = i
+			trace.Services = append(trace.Services, ServiceTrace{
+				Service:   entry.Service,
+				FirstSeen: entry.Timestamp,
+			})
+		}
+
+		svc := &trace.Services[i]
+		svc.LastSeen = entry.Timestamp
+		svc.EntryCount++
+		if entry.Result == "failure" {
+			svc.Failures++
+		}
+		if !containsString(svc.Operations, entry.Operation) {
+			svc.Operations = append(svc.Operations, entry.Operation)
+		}
+	}
+
+	return trace, nil
+}
+
 // ExportChain exports a sealed audit chain for legal discovery
 func (s *AuditLogService) ExportChain(ctx context.Context, chainID string) ([]byte, error) {
 	return s.sealer.ExportChain(chainID)
 }
 
+// CreateExport starts a background export of the entries matching the
+// request's filter
+func (s *AuditLogService) CreateExport(ctx context.Context, req *ExportRequest) (*ExportJob, error) {
+	if !s.running {
+		return nil, errors.New("audit log service is not running")
+	}
+
+	return s.exports.create(req)
+}
+
+// ListExports returns the export jobs, newest first
+func (s *AuditLogService) ListExports(ctx context.Context) []*ExportJob {
+	return s.exports.list()
+}
+
+// GetExport returns an export job
+func (s *AuditLogService) GetExport(ctx context.Context, id string) (*ExportJob, error) {
+	return s.exports.get(id)
+}
+
+// ExportFile returns the path of a completed export's file
+func (s *AuditLogService) ExportFile(job *ExportJob) string {
+	return s.exports.filePath(job)
+}
+
+// SIEMStatus returns the progress of SIEM forwarding, or nil when it is
+// disabled
+func (s *AuditLogService) SIEMStatus() *siem.Status {
+	if s.forwarder == nil {
+		return nil
+	}
+	status := s.forwarder.Status()
+	return &status
+}
+
 // sealingRoutine periodically seals the audit log chain
 func (s *AuditLogService) sealingRoutine(ctx context.Context) {
 	ticker := time.NewTicker(time.Duration(s.config.SealInterval) * time.Second)
@@ -260,19 +463,51 @@ func (s *AuditLogService) sealingRoutine(ctx context.Context) {
 
 // AuditQuery represents a query for audit log entries
 type AuditQuery struct {
-	StartTime    time.Time
-	EndTime      time.Time
-	ActorID      string
-	Service      string
-	Operation    string
-	ActionType   string
-	Resource     string
-	Result       string
-	RiskLevel    string
-	SequenceFrom uint64
-	SequenceTo   uint64
-	Limit        int
-	Offset       int
+	StartTime     time.Time
+	EndTime       time.Time
+	ActorID       string
+	Service       string
+	Operation     string
+	ActionType    string
+	Resource      string
+	Result        string
+	RiskLevel     string
+	SequenceFrom  uint64
+	SequenceTo    uint64
+	CorrelationID string
+	Limit         int
+	Offset        int
+}
+
+// AuditTrace is the cross-service story of one correlation ID
+type AuditTrace struct {
+	CorrelationID string           `json:"correlation_id"`
+	StartedAt     time.Time        `json:"started_at,omitempty"`
+	EndedAt       time.Time        `json:"ended_at,omitempty"`
+	DurationMs    int64            `json:"duration_ms"`
+	Services      []ServiceTrace   `json:"services"`
+	Entries       []*AuditLogEntry `json:"entries"`
+	Truncated     bool             `json:"truncated"`
+}
+
+// ServiceTrace summarizes what one service did within a trace
+type ServiceTrace struct {
+	Service    string    `json:"service"`
+	FirstSeen  time.Time `json:"first_seen"`
+	LastSeen   time.Time `json:"last_seen"`
+	EntryCount int       `json:"entry_count"`
+	Failures   int       `json:"failures"`
+	Operations []string  `json:"operations"`
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
 // generateEntryID generates a unique entry identifier
@@ -0,0 +1,253 @@
+// Audit Log Backup - Verified Restore
+// Restores a snapshot only after its signature, checksums and chain
+// continuity have been verified
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/csic-platform/services/audit-log"
+	"github.com/csic-platform/services/audit-log/replication"
+	"github.com/csic-platform/services/audit-log/verifier"
+	"github.com/csic-platform/services/audit-log/writer"
+)
+
+// genesisHash is the previous hash of the first entry
+const genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+var (
+	// ErrChecksumMismatch is returned when a snapshot file does not match the manifest
+	ErrChecksumMismatch = errors.New("snapshot checksum mismatch")
+
+	// ErrChainBroken is returned when the snapshot's segments do not form one
+	// continuous chain ending at the recorded head
+	ErrChainBroken = errors.New("snapshot chain is broken")
+
+	// ErrTargetNotEmpty is returned when restoring over existing audit log state
+	ErrTargetNotEmpty = errors.New("restore target is not empty")
+)
+
+// RestoreResult is the result of restoring a snapshot
+type RestoreResult struct {
+	SnapshotID       string                `json:"snapshot_id"`
+	Head             replication.ChainHead `json:"head"`
+	Segments         int                   `json:"segments"`
+	ChainRecords     int                   `json:"chain_records"`
+	DatabaseRestored bool                  `json:"database_restored"`
+	RestoredAt       time.Time             `json:"restored_at"`
+}
+
+// VerifySnapshot verifies the snapshot in dir: the manifest signature, the
+// checksum of every file and the continuity of the chain from the genesis
+// hash to the recorded chain head
+func VerifySnapshot(ctx context.Context, dir string, signer Signer) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+
+	if manifest.KeyID != signer.KeyID() || manifest.Algorithm != signer.Algorithm() {
+		return nil, fmt.Errorf("%w: signed with %s (%s), expected %s (%s)", ErrInvalidSignature,
+			manifest.KeyID, manifest.Algorithm, signer.KeyID(), signer.Algorithm())
+	}
+	digest, err := manifest.digest()
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.Verify(ctx, digest, manifest.Signature); err != nil {
+		return nil, err
+	}
+
+	files, err := checksumFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) != len(manifest.Files) {
+		return nil, fmt.Errorf("%w: %d files in snapshot, %d in manifest", ErrChecksumMismatch, len(files), len(manifest.Files))
+	}
+	for i, file := range files {
+		if file != manifest.Files[i] {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, file.Path)
+		}
+	}
+
+	head, err := verifyChain(dir, manifest.Segments)
+	if err != nil {
+		return nil, err
+	}
+	if *head != manifest.Head {
+		return nil, fmt.Errorf("%w: chain ends at %d/%s, manifest head is %d/%s",
+			ErrChainBroken, head.Sequence, head.Hash, manifest.Head.Sequence, manifest.Head.Hash)
+	}
+
+	var recorded replication.ChainHead
+	data, err = os.ReadFile(filepath.Join(dir, ChainHeadFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain head: %w", err)
+	}
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal chain head: %w", err)
+	}
+	if recorded != manifest.Head {
+		return nil, fmt.Errorf("%w: %s does not match the manifest head", ErrChainBroken, ChainHeadFileName)
+	}
+
+	return &manifest, nil
+}
+
+// Restore verifies the snapshot in dir and restores it into an empty audit
+// log: the segments below opts.StoragePath, the seal records next to
+// opts.ChainFilePath and, unless skipDatabase is set, the rows of the audit
+// tables, whose schema must already have been migrated. Nothing is written
+// unless the snapshot verifies. A failed restore leaves partial state that
+// must be removed before retrying.
+func Restore(ctx context.Context, dir string, opts Options, signer Signer, skipDatabase bool) (*RestoreResult, error) {
+	manifest, err := VerifySnapshot(ctx, dir, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	targetDir := filepath.Join(opts.StoragePath, writer.SegmentDirName)
+	existing, err := writer.ListSegments(targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%w: %s holds %d segments", ErrTargetNotEmpty, targetDir, len(existing))
+	}
+	chainsDir := filepath.Join(filepath.Dir(opts.ChainFilePath), chainsDirName)
+	records, err := filepath.Glob(filepath.Join(chainsDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 {
+		return nil, fmt.Errorf("%w: %s holds %d chain records", ErrTargetNotEmpty, chainsDir, len(records))
+	}
+
+	result := &RestoreResult{
+		SnapshotID: manifest.SnapshotID,
+		Head:       manifest.Head,
+	}
+
+	if err := os.MkdirAll(targetDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create segment directory: %w", err)
+	}
+	perm := os.FileMode(0600)
+	if opts.EnableWORM {
+		perm = 0400
+	}
+	for _, segment := range manifest.Segments {
+		src := writer.SegmentPath(filepath.Join(dir, writer.SegmentDirName), segment.Segment)
+		if err := copyFile(src, writer.SegmentPath(targetDir, segment.Segment), perm); err != nil {
+			return nil, fmt.Errorf("failed to restore segment %d: %w", segment.Segment, err)
+		}
+		result.Segments++
+	}
+
+	// The segment log appends to its last segment; an empty one after the
+	// restored segments keeps them sealed and matching their replicas
+	next := writer.SegmentPath(targetDir, manifest.Head.Segment+1)
+	if err := os.WriteFile(next, nil, 0600); err != nil {
+		return nil, fmt.Errorf("failed to create active segment: %w", err)
+	}
+
+	snapshotRecords, err := filepath.Glob(filepath.Join(dir, chainsDirName, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if err := copyChainRecords(filepath.Join(dir, chainsDirName), chainsDir); err != nil {
+		return nil, err
+	}
+	result.ChainRecords = len(snapshotRecords)
+
+	if !skipDatabase {
+		if err := restoreDatabase(ctx, opts, filepath.Join(dir, DatabaseDumpFileName)); err != nil {
+			return nil, err
+		}
+		result.DatabaseRestored = true
+	}
+
+	result.RestoredAt = time.Now().UTC()
+	return result, nil
+}
+
+// verifyChain verifies that the segments below dir form one continuous chain
+// starting at the genesis hash and match their manifests, and returns its head
+func verifyChain(dir string, segments []*replication.Manifest) (*replication.ChainHead, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("%w: the snapshot holds no segments", ErrChainBroken)
+	}
+
+	entryVerifier := verifier.NewAuditLogVerifier(dir, "")
+	segmentDir := filepath.Join(dir, writer.SegmentDirName)
+	head := &replication.ChainHead{Hash: genesisHash}
+
+	for i, segment := range segments {
+		if segment.Segment != uint32(i+1) {
+			return nil, fmt.Errorf("%w: expected segment %d, found segment %d", ErrChainBroken, i+1, segment.Segment)
+		}
+
+		built, err := replication.BuildManifest(dir, segment.Segment)
+		if err != nil {
+			return nil, err
+		}
+		if built.SHA256 != segment.SHA256 || built.Size != segment.Size || built.EntryCount != segment.EntryCount ||
+			built.FirstSequence != segment.FirstSequence || built.LastSequence != segment.LastSequence ||
+			built.PreviousHash != segment.PreviousHash || built.LastHash != segment.LastHash {
+			return nil, fmt.Errorf("%w: segment %d does not match its manifest", ErrChecksumMismatch, segment.Segment)
+		}
+
+		err = writer.ScanSegment(segmentDir, segment.Segment, func(loc writer.Location, data []byte) error {
+			var entry audit.AuditLogEntry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("failed to unmarshal entry at %d:%d: %w", loc.Segment, loc.Offset, err)
+			}
+			if valid, reason := entryVerifier.VerifyEntry(&entry); !valid {
+				return fmt.Errorf("%w: entry %d: %s", ErrChainBroken, entry.SequenceNum, reason)
+			}
+			if entry.SequenceNum != head.Sequence+1 {
+				return fmt.Errorf("%w: expected sequence %d, found %d", ErrChainBroken, head.Sequence+1, entry.SequenceNum)
+			}
+			if entry.PreviousHash != head.Hash {
+				return fmt.Errorf("%w: entry %d does not link to entry %d", ErrChainBroken, entry.SequenceNum, head.Sequence)
+			}
+
+			head.Sequence = entry.SequenceNum
+			head.Hash = entry.CurrentHash
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		head.Segment = segment.Segment
+	}
+
+	return head, nil
+}
+
+// restoreDatabase loads a dump of the audit tables in a single transaction
+func restoreDatabase(ctx context.Context, opts Options, path string) error {
+	psql := opts.PSQLPath
+	if psql == "" {
+		psql = "psql"
+	}
+
+	cmd := exec.CommandContext(ctx, psql, "--single-transaction", "--set", "ON_ERROR_STOP=1", "--quiet", "--file", path)
+	cmd.Env = pgEnv(opts.Database)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("psql failed: %w: %s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Audit Log Backup - Snapshot Manifest Signing
+// Signs snapshot manifests with a key held in a hardware security module
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/csic-platform/shared/config"
+	"github.com/csic-platform/shared/secrets"
+)
+
+// ProviderAWSKMS is the HSM provider signing with AWS KMS keys, which are
+// generated and kept in FIPS 140-2 validated HSMs
+const ProviderAWSKMS = "aws-kms"
+
+// ErrInvalidSignature is returned when a manifest signature does not verify
+var ErrInvalidSignature = errors.New("invalid manifest signature")
+
+// Signer signs and verifies SHA-256 digests with a key that never leaves the HSM
+type Signer interface {
+	// Sign signs a SHA-256 digest
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+	// Verify returns ErrInvalidSignature when signature is not a signature of digest
+	Verify(ctx context.Context, digest, signature []byte) error
+	// KeyID identifies the signing key
+	KeyID() string
+	// Algorithm names the signature algorithm
+	Algorithm() string
+}
+
+// NewSigner creates the signer of the configured HSM
+func NewSigner(ctx context.Context, cfg config.HSMConfig) (Signer, error) {
+	switch cfg.Provider {
+	case ProviderAWSKMS:
+		awsCfg, err := secrets.LoadAWSConfig(ctx, cfg.Region)
+		if err != nil {
+			return nil, err
+		}
+		return NewKMSSigner(kms.NewFromConfig(awsCfg), cfg.KeyLabel, cfg.KeyType)
+	case "":
+		return nil, errors.New("no HSM provider is configured")
+	default:
+		return nil, fmt.Errorf("unsupported HSM provider %q", cfg.Provider)
+	}
+}
+
+// KMSSigner signs digests with an asymmetric AWS KMS key
+type KMSSigner struct {
+	client    *kms.Client
+	keyID     string
+	algorithm types.SigningAlgorithmSpec
+}
+
+// NewKMSSigner creates a signer for the KMS key with the given ID, ARN or
+// alias. keyType selects the algorithm: "ECDSA" for ECC_NIST_P256 keys,
+// otherwise RSA PKCS#1 v1.5.
+func NewKMSSigner(client *kms.Client, keyID, keyType string) (*KMSSigner, error) {
+	if keyID == "" {
+		return nil, errors.New("no HSM signing key is configured")
+	}
+
+	algorithm := types.SigningAlgorithmSpecRsassaPkcs1V15Sha256
+	if strings.EqualFold(keyType, "ECDSA") {
+		algorithm = types.SigningAlgorithmSpecEcdsaSha256
+	}
+
+	return &KMSSigner{
+		client:    client,
+		keyID:     keyID,
+		algorithm: algorithm,
+	}, nil
+}
+
+// Sign signs a SHA-256 digest
+func (s *KMSSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	out, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: s.algorithm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with KMS key %s: %w", s.keyID, err)
+	}
+	return out.Signature, nil
+}
+
+// Verify checks a signature of a SHA-256 digest
+func (s *KMSSigner) Verify(ctx context.Context, digest, signature []byte) error {
+	out, err := s.client.Verify(ctx, &kms.VerifyInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		Signature:        signature,
+		SigningAlgorithm: s.algorithm,
+	})
+	var invalid *types.KMSInvalidSignatureException
+	if errors.As(err, &invalid) {
+		return ErrInvalidSignature
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify with KMS key %s: %w", s.keyID, err)
+	}
+	if !out.SignatureValid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// KeyID returns the KMS key ID
+func (s *KMSSigner) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm returns the KMS signing algorithm
+func (s *KMSSigner) Algorithm() string {
+	return string(s.algorithm)
+}
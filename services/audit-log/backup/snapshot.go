@@ -0,0 +1,329 @@
+// Audit Log Backup - Consistent Snapshots for Disaster Recovery
+// Copies the sealed segments, chain head, seal records and audit tables into a
+// snapshot directory described by an HSM-signed checksum manifest
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/csic-platform/services/audit-log/replication"
+	"github.com/csic-platform/services/audit-log/writer"
+	"github.com/csic-platform/shared/config"
+)
+
+const (
+	// ManifestFileName is the signed manifest of a snapshot
+	ManifestFileName = "manifest.json"
+
+	// ChainHeadFileName records the last entry of the snapshot's chain
+	ChainHeadFileName = "chain_head.json"
+
+	// DatabaseDumpFileName is the pg_dump of the audit tables
+	DatabaseDumpFileName = "audit.sql"
+
+	// chainsDirName holds the seal records, as next to the chain file
+	chainsDirName = "chains"
+)
+
+// AuditTables are the database tables included in snapshots
+var AuditTables = []string{"audit_entries", "audit_chains"}
+
+// Options locates the audit log state to snapshot or restore
+type Options struct {
+	// StoragePath is the audit log storage path holding the segment log
+	StoragePath string
+	// ChainFilePath is the sealer's chain file; seal records are kept next to it
+	ChainFilePath string
+	// Database is the database holding the audit tables
+	Database config.DatabaseConfig
+	// EnableWORM makes restored segments read-only
+	EnableWORM bool
+	// PGDumpPath and PSQLPath locate the PostgreSQL client tools
+	PGDumpPath string
+	PSQLPath   string
+}
+
+// FileChecksum is the checksum of a file of a snapshot
+type FileChecksum struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Manifest describes a snapshot. The signature covers the JSON encoding of
+// the manifest without the signature.
+type Manifest struct {
+	SnapshotID string                `json:"snapshot_id"`
+	CreatedAt  time.Time             `json:"created_at"`
+	Head       replication.ChainHead `json:"head"`
+	// Segments describes the chain of every sealed segment in the snapshot
+	Segments  []*replication.Manifest `json:"segments"`
+	Files     []FileChecksum          `json:"files"`
+	KeyID     string                  `json:"key_id"`
+	Algorithm string                  `json:"algorithm"`
+	Signature []byte                  `json:"signature,omitempty"`
+}
+
+// Snapshot copies the sealed segments below opts.StoragePath, their chain
+// head, the seal records and a dump of the audit tables into dir, which must
+// not exist, and signs a manifest of their checksums. Sealed segments never
+// change, so the segments are consistent without stopping the service; the
+// dump is taken afterwards and may hold entries written since. The chain of
+// the copies is verified before the manifest is signed, so a broken chain is
+// never backed up as valid. The sealer's private key is not included.
+func Snapshot(ctx context.Context, opts Options, dir string, signer Signer) (*Manifest, error) {
+	sourceDir := filepath.Join(opts.StoragePath, writer.SegmentDirName)
+	segments, err := writer.ListSegments(sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+	// The last segment is still being written
+	if len(segments) <= 1 {
+		return nil, errors.New("there are no sealed segments to snapshot")
+	}
+	sealed := segments[:len(segments)-1]
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.Mkdir(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	manifest, err := takeSnapshot(ctx, opts, dir, sourceDir, sealed, signer)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// takeSnapshot fills a new snapshot directory
+func takeSnapshot(ctx context.Context, opts Options, dir, sourceDir string, sealed []uint32, signer Signer) (*Manifest, error) {
+	manifest := &Manifest{
+		SnapshotID: filepath.Base(dir),
+		CreatedAt:  time.Now().UTC(),
+		KeyID:      signer.KeyID(),
+		Algorithm:  signer.Algorithm(),
+	}
+
+	segmentDir := filepath.Join(dir, writer.SegmentDirName)
+	if err := os.Mkdir(segmentDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	for _, num := range sealed {
+		if err := copyFile(writer.SegmentPath(sourceDir, num), writer.SegmentPath(segmentDir, num), 0400); err != nil {
+			return nil, fmt.Errorf("failed to copy segment %d: %w", num, err)
+		}
+		segment, err := replication.BuildManifest(dir, num)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Segments = append(manifest.Segments, segment)
+	}
+
+	head, err := verifyChain(dir, manifest.Segments)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Head = *head
+	if err := writeJSON(filepath.Join(dir, ChainHeadFileName), head); err != nil {
+		return nil, err
+	}
+
+	if err := copyChainRecords(filepath.Join(filepath.Dir(opts.ChainFilePath), chainsDirName), filepath.Join(dir, chainsDirName)); err != nil {
+		return nil, err
+	}
+
+	if err := dumpDatabase(ctx, opts, filepath.Join(dir, DatabaseDumpFileName)); err != nil {
+		return nil, err
+	}
+
+	manifest.Files, err = checksumFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := manifest.digest()
+	if err != nil {
+		return nil, err
+	}
+	manifest.Signature, err = signer.Sign(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeJSON(filepath.Join(dir, ManifestFileName), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// digest returns the SHA-256 digest of the manifest without its signature
+func (m *Manifest) digest() ([]byte, error) {
+	unsigned := *m
+	unsigned.Signature = nil
+
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
+
+// copyChainRecords copies the seal records in src, if any, to dst
+func copyChainRecords(src, dst string) error {
+	records, err := filepath.Glob(filepath.Join(src, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return fmt.Errorf("failed to create chains directory: %w", err)
+	}
+	for _, record := range records {
+		if err := copyFile(record, filepath.Join(dst, filepath.Base(record)), 0600); err != nil {
+			return fmt.Errorf("failed to copy chain record %s: %w", filepath.Base(record), err)
+		}
+	}
+	return nil
+}
+
+// dumpDatabase dumps the rows of the audit tables to path with pg_dump. The
+// dump disables the tables' triggers while loading, so the rows are restored
+// as they were without the insert checks and modification guards firing.
+func dumpDatabase(ctx context.Context, opts Options, path string) error {
+	pgDump := opts.PGDumpPath
+	if pgDump == "" {
+		pgDump = "pg_dump"
+	}
+
+	args := []string{"--data-only", "--disable-triggers", "--no-owner", "--no-privileges", "--file", path}
+	for _, table := range AuditTables {
+		args = append(args, "--table", table)
+	}
+
+	cmd := exec.CommandContext(ctx, pgDump, args...)
+	cmd.Env = pgEnv(opts.Database)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("pg_dump failed: %w: %s", err, output)
+	}
+	return nil
+}
+
+// pgEnv passes the connection settings to the PostgreSQL client tools through
+// the environment, which keeps the password out of the process list
+func pgEnv(db config.DatabaseConfig) []string {
+	sslMode := db.SSLMode
+	if sslMode == "" {
+		sslMode = "require"
+	}
+	return append(os.Environ(),
+		"PGHOST="+db.Host,
+		"PGPORT="+strconv.Itoa(db.Port),
+		"PGUSER="+db.Username,
+		"PGPASSWORD="+db.Password,
+		"PGDATABASE="+db.Name,
+		"PGSSLMODE="+sslMode,
+	)
+}
+
+// checksumFiles returns the checksums of the files below dir other than the
+// manifest, ordered by path
+func checksumFiles(dir string) ([]FileChecksum, error) {
+	var files []FileChecksum
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == ManifestFileName {
+			return nil
+		}
+
+		sum, size, err := checksumFile(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, FileChecksum{Path: filepath.ToSlash(rel), SHA256: sum, Size: size})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum snapshot: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// checksumFile returns the SHA-256 checksum and size of a file
+func checksumFile(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), size, nil
+}
+
+// copyFile copies src to dst, which must not exist, and syncs it
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// writeJSON writes v as indented JSON to a new file
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -16,9 +16,15 @@ import (
 
 	"github.com/csic-platform/services/audit-log/handlers"
 	"github.com/csic-platform/shared/config"
+	"github.com/csic-platform/shared/lifecycle"
 	"github.com/csic-platform/shared/logger"
+	"github.com/csic-platform/shared/openapi"
+	"github.com/csic-platform/shared/ratelimit"
 	_ "github.com/lib/pq"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -33,6 +39,7 @@ func main() {
 		fmt.Printf("Warning: Failed to load config file, using defaults: %v\n", err)
 		cfg = getDefaultConfig()
 	}
+	defer configLoader.Close()
 
 	// Initialize audit log service
 	auditConfig := &AuditConfig{
@@ -42,15 +49,24 @@ func main() {
 		VerificationPath: cfg.AuditLog.VerificationPath,
 		RetentionDays:    cfg.AuditLog.RetentionDays,
 		EnableWORM:       cfg.AuditLog.EnableWORM,
+		MaxFileSize:      cfg.AuditLog.MaxFileSize,
+		FsyncPerBatch:    cfg.AuditLog.FsyncPerBatch,
+		Replication:      cfg.AuditLog.Replication,
+		Export:           cfg.AuditLog.Export,
+		SIEM:             cfg.AuditLog.SIEM,
+		Version:          cfg.App.Version,
 	}
 
 	logConfig := logger.Config{
-		ServiceName:  "audit-log-service",
-		LogLevel:     cfg.Logging.Level,
-		OutputPath:   cfg.Logging.Output,
-		AuditLogPath: cfg.Logging.Path,
-		Development:  cfg.App.Environment == "development",
-		JSONOutput:   cfg.Logging.Format == "json",
+		ServiceName:        "audit-log-service",
+		Environment:        cfg.App.Environment,
+		LogLevel:           cfg.Logging.Level,
+		OutputPath:         cfg.Logging.Output,
+		AuditLogPath:       cfg.Logging.Path,
+		Development:        cfg.App.Environment == "development",
+		JSONOutput:         cfg.Logging.Format == "json",
+		SamplingInitial:    cfg.Logging.SamplingInitial,
+		SamplingThereafter: cfg.Logging.SamplingThereafter,
 	}
 
 	auditService, err := NewAuditLogService(auditConfig, logConfig)
@@ -58,7 +74,23 @@ func main() {
 		fmt.Printf("Fatal: Failed to initialize audit log service: %v\n", err)
 		os.Exit(1)
 	}
-	defer auditService.Stop()
+
+	appLogger, err := logger.NewLogger(logConfig)
+	if err != nil {
+		fmt.Printf("Fatal: Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Shutdown stops intake, drains in-flight writes, then seals the WORM
+	// chain so no acknowledged entry is left unsealed
+	shutdown := lifecycle.New(func(step lifecycle.Step) {
+		appLogger.Error("Shutdown step incomplete",
+			logger.String("phase", step.Phase),
+			logger.String("step", step.Name),
+			logger.Error(step.Err),
+		)
+	})
+	shutdown.OnShutdown(lifecycle.PhaseFlush, "audit-chain", lifecycle.ErrorFunc(auditService.Stop))
 
 	// Start the service
 	ctx, cancel := context.WithCancel(context.Background())
@@ -81,36 +113,87 @@ func main() {
 	// Initialize HTTP handlers
 	httpHandler := handlers.NewAuditLogHandler(auditService)
 
+	// Initialize rate limiting; buckets live in Redis so limits hold across instances
+	rateLimit := func(group string) gin.HandlerFunc {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if cfg.RateLimit.Enabled {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.GetRedisAddr(),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+			PoolSize: cfg.Redis.PoolSize,
+		})
+		defer redisClient.Close()
+
+		rateLimiter := ratelimit.NewMiddleware(ratelimit.NewLimiter(redisClient, "ratelimit"), "audit-log", appLogger, prometheus.DefaultRegisterer)
+		rateLimit = func(group string) gin.HandlerFunc {
+			rule := cfg.RateLimit.GetRule(group)
+			return rateLimiter.Limit(group, ratelimit.Rule{Rate: rule.RequestsPerSecond, Burst: rule.Burst})
+		}
+	}
+
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(httpHandler.LoggingMiddleware())
 
+	// OpenAPI document of the routes below. Requests to described handlers are
+	// checked against it before binding; in development responses are too.
+	spec := openapi.New(openapi.Config{
+		Title:            "Audit Log API",
+		Version:          cfg.App.Version,
+		Error:            handlers.ErrorResponse{},
+		ErrorContentType: "application/json",
+		Exclude:          []string{"/health", "/ready", "/metrics"},
+	})
+	httpHandler.Describe(spec)
+	validatorOptions := openapi.ValidatorOptions{Reject: handlers.RejectInvalid}
+	if cfg.App.Environment == "development" {
+		if devLogger, err := logger.NewLogger(logConfig); err == nil {
+			validatorOptions.Responses = true
+			validatorOptions.Logger = devLogger.Logger
+		}
+	}
+	router.Use(spec.Validator(validatorOptions))
+	router.GET(openapi.Path, spec.Handler(router))
+
 	// Health check endpoints
 	router.GET("/health", httpHandler.HealthCheck)
 	router.GET("/ready", httpHandler.ReadinessCheck)
 
+	// Prometheus metrics, including throttled requests
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// Audit log API endpoints
 	api := router.Group("/api/v1/audit")
 	{
 		// Write endpoints
-		api.POST("/entries", httpHandler.WriteEntry)
-		api.POST("/entries/batch", httpHandler.WriteBatch)
+		api.POST("/entries", rateLimit("write"), httpHandler.WriteEntry)
+		api.POST("/entries/batch", rateLimit("write"), httpHandler.WriteBatch)
 
 		// Query endpoints
-		api.GET("/entries", httpHandler.QueryEntries)
-		api.GET("/entries/:id", httpHandler.GetEntry)
+		api.GET("/entries", rateLimit("default"), httpHandler.QueryEntries)
+		api.GET("/entries/:id", rateLimit("default"), httpHandler.GetEntry)
+		api.GET("/trace/:correlation_id", rateLimit("default"), httpHandler.TraceCorrelation)
 
 		// Verification endpoints
-		api.GET("/verify", httpHandler.VerifyChain)
-		api.GET("/verify/report", httpHandler.GetVerificationReport)
-		api.GET("/chains", httpHandler.ListChains)
-		api.GET("/chains/:id", httpHandler.GetChain)
-		api.GET("/chains/:id/export", httpHandler.ExportChain)
+		api.GET("/verify", rateLimit("default"), httpHandler.VerifyChain)
+		api.GET("/verify/report", rateLimit("default"), httpHandler.GetVerificationReport)
+		api.GET("/chains", rateLimit("default"), httpHandler.ListChains)
+		api.GET("/chains/:id", rateLimit("default"), httpHandler.GetChain)
+		api.GET("/chains/:id/export", rateLimit("default"), httpHandler.ExportChain)
+
+		// Export endpoints
+		api.POST("/exports", rateLimit("write"), httpHandler.CreateExport)
+		api.GET("/exports", rateLimit("default"), httpHandler.ListExports)
+		api.GET("/exports/:id", rateLimit("default"), httpHandler.GetExport)
+		api.GET("/exports/:id/download", rateLimit("default"), httpHandler.DownloadExport)
+		api.GET("/siem/status", rateLimit("default"), httpHandler.GetSIEMStatus)
 
 		// Summary endpoints
-		api.GET("/summary", httpHandler.GetSummary)
+		api.GET("/summary", rateLimit("default"), httpHandler.GetSummary)
 	}
 
 	// Create HTTP server
@@ -122,6 +205,9 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	shutdown.OnShutdown(lifecycle.PhaseIntake, "http", srv.Shutdown)
+	shutdown.OnShutdown(lifecycle.PhaseDrain, "background-routines", lifecycle.Func(cancel))
+
 	// Start server in goroutine
 	go func() {
 		fmt.Printf("Audit Log Service starting on port %d\n", cfg.Server.Port)
@@ -141,9 +227,7 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		fmt.Printf("Server forced to shutdown: %v\n", err)
-	}
+	shutdown.Shutdown(shutdownCtx)
 
 	fmt.Println("Audit Log Service exited")
 }
@@ -197,6 +281,8 @@ func getDefaultConfig() *config.Config {
 			VerificationPath: "/var/lib/csic/audit-verification",
 			RetentionDays:    2555, // 7 years
 			EnableWORM:       true,
+			MaxFileSize:      100 * 1024 * 1024,
+			FsyncPerBatch:    true,
 		},
 	}
 }
@@ -0,0 +1,35 @@
+// Audit Log Storage Migration Tool
+// Migrates audit log storage from the per-file layout to the segment log.
+// Run it while the audit log service is stopped.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/csic-platform/services/audit-log/writer"
+)
+
+func main() {
+	storagePath := flag.String("storage", "/var/lib/csic/audit-logs", "Audit log storage path")
+	segmentSize := flag.Int64("segment-size", writer.DefaultSegmentSize, "Segment size in bytes")
+	fsync := flag.Bool("fsync", true, "Fsync the segment log after every batch")
+	worm := flag.Bool("worm", true, "Make sealed segments read-only")
+	batchSize := flag.Int("batch-size", 1000, "Entries appended per batch")
+	flag.Parse()
+
+	result, err := writer.MigrateLegacyFiles(*storagePath, writer.SegmentOptions{
+		SegmentSize:  *segmentSize,
+		SyncPerBatch: *fsync,
+		EnableWORM:   *worm,
+	}, *batchSize)
+	if err != nil {
+		fmt.Printf("Fatal: Migration failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Migrated %d of %d entries from %d files (%d already present)\n",
+		result.Migrated, result.Entries, result.Files, result.Skipped)
+}
@@ -0,0 +1,76 @@
+// Audit Log Restore Tool
+// Verifies a snapshot's HSM signature, checksums and chain continuity and
+// restores it into an empty audit log. Run it while the audit log service is
+// stopped and start the service only when it exits zero.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/csic-platform/services/audit-log/backup"
+	"github.com/csic-platform/shared/config"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	snapshotDir := flag.String("snapshot", "", "Snapshot directory to restore")
+	verifyOnly := flag.Bool("verify-only", false, "Verify the snapshot without restoring it")
+	skipDatabase := flag.Bool("skip-database", false, "Do not restore the audit tables")
+	psql := flag.String("psql", "psql", "Path to psql")
+	timeout := flag.Duration("timeout", time.Hour, "Restore timeout")
+	flag.Parse()
+
+	if *snapshotDir == "" {
+		fmt.Println("Fatal: -snapshot is required")
+		os.Exit(1)
+	}
+
+	configLoader := config.NewConfigLoader(*configPath)
+	cfg, err := configLoader.Load()
+	if err != nil {
+		fmt.Printf("Fatal: Failed to load config file: %v\n", err)
+		os.Exit(1)
+	}
+	defer configLoader.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	signer, err := backup.NewSigner(ctx, cfg.Security.HSM)
+	if err != nil {
+		fmt.Printf("Fatal: Failed to initialize HSM signer: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *verifyOnly {
+		manifest, err := backup.VerifySnapshot(ctx, *snapshotDir, signer)
+		if err != nil {
+			fmt.Printf("Fatal: Verification failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Snapshot %s verified: %d segments, head at sequence %d (%s)\n",
+			manifest.SnapshotID, len(manifest.Segments), manifest.Head.Sequence, manifest.Head.Hash)
+		return
+	}
+
+	result, err := backup.Restore(ctx, *snapshotDir, backup.Options{
+		StoragePath:   cfg.AuditLog.StoragePath,
+		ChainFilePath: cfg.AuditLog.ChainFilePath,
+		Database:      cfg.Database,
+		EnableWORM:    cfg.AuditLog.EnableWORM,
+		PSQLPath:      *psql,
+	}, signer, *skipDatabase)
+	if err != nil {
+		fmt.Printf("Fatal: Restore failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	output, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(output))
+}
@@ -0,0 +1,519 @@
+// Audit Log Export - Streaming CSV and JSONL Export Jobs
+// Exports the entries matching a query to a file in the background, streaming
+// them from the segment log so exports of any size run in constant memory
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/shared/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// ExportFormatCSV exports entries as CSV with a header row
+	ExportFormatCSV = "csv"
+	// ExportFormatJSONL exports entries as one JSON object per line
+	ExportFormatJSONL = "jsonl"
+
+	defaultExportRetention     = 24 * time.Hour
+	defaultExportMaxConcurrent = 2
+)
+
+// ExportStatus is the state of an export job
+type ExportStatus string
+
+const (
+	ExportPending   ExportStatus = "pending"
+	ExportRunning   ExportStatus = "running"
+	ExportCompleted ExportStatus = "completed"
+	ExportFailed    ExportStatus = "failed"
+)
+
+// ErrExportNotFound is returned for unknown or expired export jobs
+var ErrExportNotFound = errors.New("export not found")
+
+// ExportFilter selects the entries of an export job
+type ExportFilter struct {
+	StartTime     *time.Time `json:"start_time,omitempty"`
+	EndTime       *time.Time `json:"end_time,omitempty"`
+	ActorID       string     `json:"actor_id,omitempty"`
+	Service       string     `json:"service,omitempty"`
+	Operation     string     `json:"operation,omitempty"`
+	ActionType    string     `json:"action_type,omitempty"`
+	Resource      string     `json:"resource,omitempty"`
+	Result        string     `json:"result,omitempty"`
+	RiskLevel     string     `json:"risk_level,omitempty"`
+	CorrelationID string     `json:"correlation_id,omitempty"`
+	SequenceFrom  uint64     `json:"sequence_from,omitempty"`
+	SequenceTo    uint64     `json:"sequence_to,omitempty"`
+}
+
+// ExportRequest is a request to export audit log entries
+type ExportRequest struct {
+	Format string       `json:"format" binding:"required,oneof=csv jsonl"`
+	Filter ExportFilter `json:"filter"`
+}
+
+// ExportJob is a background export of audit log entries to a file
+type ExportJob struct {
+	ID          string       `json:"id"`
+	Format      string       `json:"format"`
+	Filter      ExportFilter `json:"filter"`
+	Status      ExportStatus `json:"status"`
+	Entries     int64        `json:"entries"`
+	Bytes       int64        `json:"bytes"`
+	SHA256      string       `json:"sha256,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	StartedAt   *time.Time   `json:"started_at,omitempty"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+}
+
+// exportColumns is the header row of CSV exports
+var exportColumns = []string{
+	"sequence_num", "entry_id", "timestamp", "chain_id",
+	"actor_id", "actor_type", "actor_role", "session_id", "ip_address", "user_agent",
+	"service", "operation", "action_type", "resource", "resource_id", "description",
+	"result", "error_code", "risk_level", "compliance_tags", "regulatory_ref",
+	"correlation_id", "previous_hash", "current_hash",
+}
+
+// exportManager runs export jobs and keeps their files and metadata in one
+// directory: <id>.json for the job and <id>.csv or <id>.jsonl for the
+// finished export
+type exportManager struct {
+	path      string
+	retention time.Duration
+	writer    *AuditLogWriter
+	logger    *logger.Logger
+	slots     chan struct{}
+
+	mu   sync.RWMutex
+	ctx  context.Context
+	jobs map[string]*ExportJob
+}
+
+// newExportManager creates an export manager and loads the jobs of previous
+// runs. Jobs that were interrupted by a restart are marked failed.
+func newExportManager(path string, retentionHours, maxConcurrent int, w *AuditLogWriter, log *logger.Logger) (*exportManager, error) {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	retention := defaultExportRetention
+	if retentionHours > 0 {
+		retention = time.Duration(retentionHours) * time.Hour
+	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultExportMaxConcurrent
+	}
+
+	m := &exportManager{
+		path:      path,
+		retention: retention,
+		writer:    w,
+		logger:    log,
+		slots:     make(chan struct{}, maxConcurrent),
+		ctx:       context.Background(),
+		jobs:      make(map[string]*ExportJob),
+	}
+
+	metaFiles, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export jobs: %w", err)
+	}
+	for _, metaFile := range metaFiles {
+		data, err := os.ReadFile(metaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read export job: %w", err)
+		}
+		var job ExportJob
+		if err := json.Unmarshal(data, &job); err != nil {
+			return nil, fmt.Errorf("failed to parse export job %s: %w", metaFile, err)
+		}
+
+		if job.Status == ExportPending || job.Status == ExportRunning {
+			now := time.Now().UTC()
+			expiresAt := now.Add(retention)
+			job.Status = ExportFailed
+			job.Error = "interrupted by service restart"
+			job.CompletedAt = &now
+			job.ExpiresAt = &expiresAt
+			os.Remove(m.tmpPath(&job))
+			if err := m.save(&job); err != nil {
+				return nil, err
+			}
+		}
+		m.jobs[job.ID] = &job
+	}
+
+	return m, nil
+}
+
+// create queues a new export job. The job runs detached from the request
+// that created it and only stops with the service.
+func (m *exportManager) create(req *ExportRequest) (*ExportJob, error) {
+	if req.Format != ExportFormatCSV && req.Format != ExportFormatJSONL {
+		return nil, fmt.Errorf("unknown export format %q", req.Format)
+	}
+
+	id, err := newExportID()
+	if err != nil {
+		return nil, err
+	}
+
+	job := &ExportJob{
+		ID:        id,
+		Format:    req.Format,
+		Filter:    req.Filter,
+		Status:    ExportPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := m.save(job); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.jobs[job.ID] = job
+	snapshot := *job
+	ctx := m.ctx
+	m.mu.Unlock()
+
+	go m.run(ctx, job)
+
+	return &snapshot, nil
+}
+
+// get returns a copy of an export job
+func (m *exportManager) get(id string) (*ExportJob, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, ErrExportNotFound
+	}
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// list returns copies of all export jobs, newest first
+func (m *exportManager) list() []*ExportJob {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]*ExportJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		snapshot := *job
+		jobs = append(jobs, &snapshot)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	return jobs
+}
+
+// filePath returns the path of a completed export's file
+func (m *exportManager) filePath(job *ExportJob) string {
+	return filepath.Join(m.path, job.ID+"."+job.Format)
+}
+
+// tmpPath returns the path an export is written to before it completes
+func (m *exportManager) tmpPath(job *ExportJob) string {
+	return m.filePath(job) + ".tmp"
+}
+
+// run waits for a free slot and then writes the export
+func (m *exportManager) run(ctx context.Context, job *ExportJob) {
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		m.finish(job, 0, 0, "", ctx.Err())
+		return
+	}
+	defer func() { <-m.slots }()
+
+	m.mu.Lock()
+	now := time.Now().UTC()
+	job.Status = ExportRunning
+	job.StartedAt = &now
+	m.mu.Unlock()
+	if err := m.saveJob(job); err != nil {
+		m.logger.Error("failed to save export job", zap.String("export_id", job.ID), zap.Error(err))
+	}
+
+	entries, size, sum, err := m.write(ctx, job)
+	m.finish(job, entries, size, sum, err)
+}
+
+// write streams the matching entries to the job's temporary file and moves
+// it into place once complete
+func (m *exportManager) write(ctx context.Context, job *ExportJob) (int64, int64, string, error) {
+	tmpPath := m.tmpPath(job)
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(tmpPath)
+	}()
+
+	digest := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(file, digest)}
+	buf := bufio.NewWriterSize(counter, 64*1024)
+
+	encode, flush := m.encoder(job.Format, buf)
+	if job.Format == ExportFormatCSV {
+		if err := encode(nil); err != nil {
+			return 0, 0, "", err
+		}
+	}
+
+	var entries int64
+	err = m.writer.Stream(ctx, job.Filter.query(), func(entry *AuditLogEntry) error {
+		if err := encode(entry); err != nil {
+			return err
+		}
+		entries++
+		if entries%1000 == 0 {
+			m.mu.Lock()
+			job.Entries = entries
+			m.mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return entries, 0, "", fmt.Errorf("failed to stream entries: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return entries, 0, "", fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := buf.Flush(); err != nil {
+		return entries, 0, "", fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return entries, 0, "", fmt.Errorf("failed to sync export file: %w", err)
+	}
+	if err := os.Rename(tmpPath, m.filePath(job)); err != nil {
+		return entries, 0, "", fmt.Errorf("failed to finalize export file: %w", err)
+	}
+
+	return entries, counter.n, hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// encoder returns a function writing one entry in the given format, or the
+// CSV header row for a nil entry, and a function flushing buffered output
+func (m *exportManager) encoder(format string, w io.Writer) (func(*AuditLogEntry) error, func() error) {
+	if format == ExportFormatJSONL {
+		enc := json.NewEncoder(w)
+		return func(entry *AuditLogEntry) error {
+			return enc.Encode(entry)
+		}, func() error { return nil }
+	}
+
+	cw := csv.NewWriter(w)
+	return func(entry *AuditLogEntry) error {
+			if entry == nil {
+				return cw.Write(exportColumns)
+			}
+			return cw.Write([]string{
+				strconv.FormatUint(entry.SequenceNum, 10),
+				entry.EntryID,
+				entry.Timestamp.UTC().Format(time.RFC3339Nano),
+				entry.ChainID,
+				entry.ActorID,
+				entry.ActorType,
+				entry.ActorRole,
+				entry.SessionID,
+				entry.IPAddress,
+				entry.UserAgent,
+				entry.Service,
+				entry.Operation,
+				entry.ActionType,
+				entry.Resource,
+				entry.ResourceID,
+				entry.Description,
+				entry.Result,
+				entry.ErrorCode,
+				entry.RiskLevel,
+				strings.Join(entry.ComplianceTags, ";"),
+				entry.RegulatoryRef,
+				entry.CorrelationID,
+				entry.PreviousHash,
+				entry.CurrentHash,
+			})
+		}, func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+}
+
+// finish records the outcome of an export job
+func (m *exportManager) finish(job *ExportJob, entries, size int64, sum string, err error) {
+	m.mu.Lock()
+	now := time.Now().UTC()
+	expiresAt := now.Add(m.retention)
+	job.Entries = entries
+	job.CompletedAt = &now
+	job.ExpiresAt = &expiresAt
+	if err != nil {
+		job.Status = ExportFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = ExportCompleted
+		job.Bytes = size
+		job.SHA256 = sum
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		m.logger.Error("audit export failed", zap.String("export_id", job.ID), zap.Error(err))
+	} else {
+		m.logger.Info("audit export completed",
+			zap.String("export_id", job.ID),
+			zap.Int64("entries", entries),
+			zap.Int64("bytes", size),
+		)
+	}
+
+	if err := m.saveJob(job); err != nil {
+		m.logger.Error("failed to save export job", zap.String("export_id", job.ID), zap.Error(err))
+	}
+}
+
+// cleanupRoutine runs later jobs in ctx and periodically deletes expired
+// exports until ctx is cancelled
+func (m *exportManager) cleanupRoutine(ctx context.Context) {
+	m.mu.Lock()
+	m.ctx = ctx
+	m.mu.Unlock()
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		m.cleanup(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanup deletes the files and metadata of exports expired at now
+func (m *exportManager) cleanup(now time.Time) {
+	m.mu.Lock()
+	var expired []*ExportJob
+	for id, job := range m.jobs {
+		if job.ExpiresAt != nil && now.After(*job.ExpiresAt) {
+			expired = append(expired, job)
+			delete(m.jobs, id)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, job := range expired {
+		for _, path := range []string{m.filePath(job), m.metaPath(job.ID)} {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				m.logger.Error("failed to delete expired export", zap.String("path", path), zap.Error(err))
+			}
+		}
+	}
+}
+
+// saveJob saves a copy of a job taken under the lock
+func (m *exportManager) saveJob(job *ExportJob) error {
+	m.mu.RLock()
+	snapshot := *job
+	m.mu.RUnlock()
+	return m.save(&snapshot)
+}
+
+// save atomically writes a job's metadata file
+func (m *exportManager) save(job *ExportJob) error {
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode export job: %w", err)
+	}
+
+	path := m.metaPath(job.ID)
+	if err := os.WriteFile(path+".tmp", data, 0600); err != nil {
+		return fmt.Errorf("failed to write export job: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write export job: %w", err)
+	}
+	return nil
+}
+
+// metaPath returns the path of a job's metadata file
+func (m *exportManager) metaPath(id string) string {
+	return filepath.Join(m.path, id+".json")
+}
+
+// query converts the filter to an unlimited audit query
+func (f ExportFilter) query() *AuditQuery {
+	query := &AuditQuery{
+		ActorID:       f.ActorID,
+		Service:       f.Service,
+		Operation:     f.Operation,
+		ActionType:    f.ActionType,
+		Resource:      f.Resource,
+		Result:        f.Result,
+		RiskLevel:     f.RiskLevel,
+		CorrelationID: f.CorrelationID,
+		SequenceFrom:  f.SequenceFrom,
+		SequenceTo:    f.SequenceTo,
+	}
+	if f.StartTime != nil {
+		query.StartTime = *f.StartTime
+	}
+	if f.EndTime != nil {
+		query.EndTime = *f.EndTime
+	}
+	return query
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// newExportID generates a random export job identifier
+func newExportID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate export ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
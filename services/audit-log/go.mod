@@ -3,8 +3,15 @@ module github.com/csic-platform/services/audit-log
 go 1.21
 
 require (
+	github.com/aws/aws-sdk-go-v2 v1.26.0
+	github.com/aws/aws-sdk-go-v2/config v1.26.1
+	github.com/aws/aws-sdk-go-v2/service/kms v1.30.0
+	github.com/aws/aws-sdk-go-v2/service/ssm v1.44.7
 	github.com/csic-platform/shared v0.0.0
 	github.com/gin-gonic/gin v1.9.1
+	github.com/minio/minio-go/v7 v7.0.69
+	github.com/prometheus/client_golang v1.17.0
+	github.com/redis/go-redis/v9 v9.3.0
 	go.uber.org/zap v1.26.0
 	gopkg.in/yaml.v3 v3.0.1
 )
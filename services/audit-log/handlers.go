@@ -9,9 +9,13 @@ import (
 	"time"
 
 	"github.com/csic-platform/services/audit-log"
+	"github.com/csic-platform/shared/correlation"
 	"github.com/gin-gonic/gin"
 )
 
+// maxTraceEntries bounds the entries returned for one correlation ID
+const maxTraceEntries = 5000
+
 // AuditLogHandler handles HTTP requests for audit log operations
 type AuditLogHandler struct {
 	service *AuditLogService
@@ -78,6 +82,12 @@ func (h *AuditLogHandler) WriteEntry(c *gin.Context) {
 		return
 	}
 
+	// Entries from callers that pass the correlation ID only as a header are
+	// still attached to their request's trace
+	if entry.CorrelationID == "" {
+		entry.CorrelationID = c.GetHeader(correlation.Header)
+	}
+
 	if err := h.service.WriteLog(c.Request.Context(), &entry); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "failed to write entry",
@@ -119,6 +129,14 @@ func (h *AuditLogHandler) WriteBatch(c *gin.Context) {
 		return
 	}
 
+	if correlationID := c.GetHeader(correlation.Header); correlationID != "" {
+		for _, entry := range entries {
+			if entry.CorrelationID == "" {
+				entry.CorrelationID = correlationID
+			}
+		}
+	}
+
 	if err := h.service.WriteBatch(c.Request.Context(), entries); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "failed to write batch",
@@ -161,6 +179,7 @@ func (h *AuditLogHandler) QueryEntries(c *gin.Context) {
 	query.Resource = c.Query("resource")
 	query.Result = c.Query("result")
 	query.RiskLevel = c.Query("risk_level")
+	query.CorrelationID = c.Query("correlation_id")
 
 	if seqFrom := c.Query("sequence_from"); seqFrom != "" {
 		if v, err := strconv.ParseUint(seqFrom, 10, 64); err == nil {
@@ -205,6 +224,38 @@ func (h *AuditLogHandler) QueryEntries(c *gin.Context) {
 	})
 }
 
+// TraceCorrelation handles reconstructing the cross-service story of one
+// correlation ID
+func (h *AuditLogHandler) TraceCorrelation(c *gin.Context) {
+	correlationID := c.Param("correlation_id")
+
+	limit := maxTraceEntries
+	if l := c.Query("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 && v <= maxTraceEntries {
+			limit = v
+		}
+	}
+
+	trace, err := h.service.TraceCorrelation(c.Request.Context(), correlationID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed to trace correlation",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(trace.Entries) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":          "no entries for correlation",
+			"correlation_id": correlationID,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, trace)
+}
+
 // GetEntry handles retrieving a specific audit log entry
 func (h *AuditLogHandler) GetEntry(c *gin.Context) {
 	entryID := c.Param("id")
@@ -330,3 +381,84 @@ func (h *AuditLogHandler) GetSummary(c *gin.Context) {
 		"service_running":  h.service.running,
 	})
 }
+
+// CreateExport handles starting a CSV or JSONL export job
+func (h *AuditLogHandler) CreateExport(c *gin.Context) {
+	var req audit.ExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	job, err := h.service.CreateExport(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed to create export",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ListExports handles listing export jobs
+func (h *AuditLogHandler) ListExports(c *gin.Context) {
+	jobs := h.service.ListExports(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"exports": jobs,
+		"count":   len(jobs),
+	})
+}
+
+// GetExport handles retrieving the status of an export job
+func (h *AuditLogHandler) GetExport(c *gin.Context) {
+	job, err := h.service.GetExport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "export not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// DownloadExport handles downloading the file of a completed export job
+func (h *AuditLogHandler) DownloadExport(c *gin.Context) {
+	job, err := h.service.GetExport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "export not found",
+		})
+		return
+	}
+
+	if job.Status != audit.ExportCompleted {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":  "export is not completed",
+			"status": job.Status,
+		})
+		return
+	}
+
+	c.Header("Digest", "sha-256="+job.SHA256)
+	c.FileAttachment(h.service.ExportFile(job), "audit-export-"+job.ID+"."+job.Format)
+}
+
+// GetSIEMStatus handles retrieving the progress of SIEM forwarding
+func (h *AuditLogHandler) GetSIEMStatus(c *gin.Context) {
+	status := h.service.SIEMStatus()
+	if status == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "SIEM forwarding is not enabled",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
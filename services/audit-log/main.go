@@ -31,6 +31,7 @@ func main() {
 		fmt.Printf("Warning: Failed to load config file, using defaults: %v\n", err)
 		cfg = getDefaultConfig()
 	}
+	defer configLoader.Close()
 
 	// Initialize audit log service
 	auditConfig := &AuditConfig{
@@ -40,15 +41,24 @@ func main() {
 		VerificationPath: cfg.AuditLog.VerificationPath,
 		RetentionDays:    cfg.AuditLog.RetentionDays,
 		EnableWORM:       cfg.AuditLog.EnableWORM,
+		MaxFileSize:      cfg.AuditLog.MaxFileSize,
+		FsyncPerBatch:    cfg.AuditLog.FsyncPerBatch,
+		Replication:      cfg.AuditLog.Replication,
+		Export:           cfg.AuditLog.Export,
+		SIEM:             cfg.AuditLog.SIEM,
+		Version:          cfg.App.Version,
 	}
 
 	logConfig := logger.Config{
-		ServiceName:  "audit-log-service",
-		LogLevel:     cfg.Logging.Level,
-		OutputPath:   cfg.Logging.Output,
-		AuditLogPath: cfg.Logging.Path,
-		Development:  cfg.App.Environment == "development",
-		JSONOutput:   cfg.Logging.Format == "json",
+		ServiceName:        "audit-log-service",
+		Environment:        cfg.App.Environment,
+		LogLevel:           cfg.Logging.Level,
+		OutputPath:         cfg.Logging.Output,
+		AuditLogPath:       cfg.Logging.Path,
+		Development:        cfg.App.Environment == "development",
+		JSONOutput:         cfg.Logging.Format == "json",
+		SamplingInitial:    cfg.Logging.SamplingInitial,
+		SamplingThereafter: cfg.Logging.SamplingThereafter,
 	}
 
 	auditService, err := NewAuditLogService(auditConfig, logConfig)
@@ -90,6 +100,7 @@ func main() {
 		// Query endpoints
 		api.GET("/entries", httpHandler.QueryEntries)
 		api.GET("/entries/:id", httpHandler.GetEntry)
+		api.GET("/trace/:correlation_id", httpHandler.TraceCorrelation)
 
 		// Verification endpoints
 		api.GET("/verify", httpHandler.VerifyChain)
@@ -98,6 +109,13 @@ func main() {
 		api.GET("/chains/:id", httpHandler.GetChain)
 		api.GET("/chains/:id/export", httpHandler.ExportChain)
 
+		// Export endpoints
+		api.POST("/exports", httpHandler.CreateExport)
+		api.GET("/exports", httpHandler.ListExports)
+		api.GET("/exports/:id", httpHandler.GetExport)
+		api.GET("/exports/:id/download", httpHandler.DownloadExport)
+		api.GET("/siem/status", httpHandler.GetSIEMStatus)
+
 		// Summary endpoints
 		api.GET("/summary", httpHandler.GetSummary)
 	}
@@ -163,6 +181,8 @@ func getDefaultConfig() *config.Config {
 			VerificationPath: "/var/lib/csic/audit-verification",
 			RetentionDays:    2555, // 7 years
 			EnableWORM:       true,
+			MaxFileSize:      100 * 1024 * 1024,
+			FsyncPerBatch:    true,
 		},
 	}
 }
@@ -0,0 +1,115 @@
+// Audit Log API Description
+// OpenAPI annotations for the Audit Log Service REST API
+
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/csic-platform/services/audit-log"
+	"github.com/csic-platform/services/audit-log/siem"
+	"github.com/csic-platform/services/audit-log/verifier"
+	"github.com/csic-platform/shared/openapi"
+	"github.com/csic-platform/shared/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse is the body of failed requests
+type ErrorResponse struct {
+	Error   string               `json:"error"`
+	Details string               `json:"details,omitempty"`
+	Fields  []problem.FieldError `json:"fields,omitempty"`
+}
+
+// Response bodies the handlers write as gin.H
+type (
+	WriteEntryResponse struct {
+		Message   string    `json:"message"`
+		EntryID   string    `json:"entry_id"`
+		Sequence  uint64    `json:"sequence"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+	WriteBatchResponse struct {
+		Message  string `json:"message"`
+		Entries  int    `json:"entries"`
+		FirstSeq uint64 `json:"first_seq"`
+		LastSeq  uint64 `json:"last_seq"`
+	}
+	EntryList struct {
+		Entries []*audit.AuditLogEntry `json:"entries"`
+		Count   int                    `json:"count"`
+		Limit   int                    `json:"limit"`
+		Offset  int                    `json:"offset"`
+	}
+	ChainList struct {
+		Chains []verifier.ChainSummary `json:"chains"`
+		Count  int                     `json:"count"`
+	}
+	ExportList struct {
+		Exports []*audit.ExportJob `json:"exports"`
+		Count   int                `json:"count"`
+	}
+	Summary struct {
+		TotalEntries    uint64 `json:"total_entries"`
+		CurrentSequence uint64 `json:"current_sequence"`
+		ServiceRunning  bool   `json:"service_running"`
+	}
+)
+
+type entryQuery struct {
+	StartTime     string `form:"start_time" description:"RFC 3339 time of the earliest entry"`
+	EndTime       string `form:"end_time" description:"RFC 3339 time of the latest entry"`
+	ActorID       string `form:"actor_id"`
+	Service       string `form:"service"`
+	Operation     string `form:"operation"`
+	ActionType    string `form:"action_type"`
+	Resource      string `form:"resource"`
+	Result        string `form:"result"`
+	RiskLevel     string `form:"risk_level"`
+	CorrelationID string `form:"correlation_id"`
+	SequenceFrom  uint64 `form:"sequence_from"`
+	SequenceTo    uint64 `form:"sequence_to"`
+	Limit         int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset        int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+type traceQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=5000"`
+}
+
+// Describe annotates the handlers for the service's OpenAPI document
+func (h *AuditLogHandler) Describe(spec *openapi.Spec) {
+	tags := []string{"audit"}
+	spec.Describe(h.WriteEntry, openapi.Operation{Summary: "Append an audit log entry", Tags: tags, Request: audit.AuditLogEntry{}, Response: WriteEntryResponse{}, Status: http.StatusCreated})
+	spec.Describe(h.WriteBatch, openapi.Operation{Summary: "Append up to 1000 audit log entries", Tags: tags, Request: []*audit.AuditLogEntry{}, Response: WriteBatchResponse{}, Status: http.StatusCreated})
+	spec.Describe(h.QueryEntries, openapi.Operation{Summary: "Query audit log entries", Tags: tags, Query: entryQuery{}, Response: EntryList{}})
+	spec.Describe(h.GetEntry, openapi.Operation{Summary: "Get an audit log entry", Tags: tags, Response: audit.AuditLogEntry{}})
+	spec.Describe(h.TraceCorrelation, openapi.Operation{Summary: "Trace a correlation ID across services", Tags: tags, Query: traceQuery{}, Response: audit.AuditTrace{}})
+
+	tags = []string{"verification"}
+	spec.Describe(h.VerifyChain, openapi.Operation{Summary: "Verify the audit log chain", Description: "An invalid chain is answered with 422 and the same body.", Tags: tags, Response: audit.VerificationResult{}})
+	spec.Describe(h.GetVerificationReport, openapi.Operation{Summary: "Get a detailed verification report", Tags: tags, Response: verifier.VerificationReport{}})
+	spec.Describe(h.ListChains, openapi.Operation{Summary: "List sealed chains", Tags: tags, Response: ChainList{}})
+	spec.Describe(h.GetChain, openapi.Operation{Summary: "Get a sealed chain", Tags: tags, Response: audit.AuditChain{}})
+	spec.Describe(h.ExportChain, openapi.Operation{Summary: "Export a sealed chain for legal discovery", Tags: tags})
+
+	tags = []string{"export"}
+	spec.Describe(h.CreateExport, openapi.Operation{Summary: "Start a CSV or JSONL export job", Tags: tags, Request: audit.ExportRequest{}, Response: audit.ExportJob{}, Status: http.StatusAccepted})
+	spec.Describe(h.ListExports, openapi.Operation{Summary: "List export jobs", Tags: tags, Response: ExportList{}})
+	spec.Describe(h.GetExport, openapi.Operation{Summary: "Get an export job", Tags: tags, Response: audit.ExportJob{}})
+	spec.Describe(h.DownloadExport, openapi.Operation{Summary: "Download a completed export", Description: "Exports that are not completed are answered with 409.", Tags: tags})
+	spec.Describe(h.GetSIEMStatus, openapi.Operation{Summary: "Get SIEM forwarding progress and lag", Tags: tags, Response: siem.Status{}})
+
+	spec.Describe(h.GetSummary, openapi.Operation{Summary: "Get audit log statistics", Tags: []string{"audit"}, Response: Summary{}})
+}
+
+// RejectInvalid answers requests that do not match the OpenAPI document in
+// the service's error format
+func RejectInvalid(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+		Error:   "invalid request",
+		Details: err.Error(),
+		Fields:  problem.Classify(err).Fields,
+	})
+}
@@ -0,0 +1,48 @@
+package replication
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds the Prometheus metrics of segment replication
+type Metrics struct {
+	LagSegments       prometheus.Gauge
+	LagSeconds        prometheus.Gauge
+	LastSegment       prometheus.Gauge
+	LastSuccess       prometheus.Gauge
+	ReplicatedBytes   prometheus.Counter
+	ReplicationErrors prometheus.Counter
+}
+
+// NewMetrics creates and registers the replication metrics
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	factory := promauto.With(registerer)
+
+	return &Metrics{
+		LagSegments: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_replication_lag_segments",
+			Help: "Number of sealed segments not yet replicated to object storage",
+		}),
+		LagSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_replication_lag_seconds",
+			Help: "Age of the oldest sealed segment not yet replicated to object storage",
+		}),
+		LastSegment: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_replication_last_segment",
+			Help: "Number of the last segment replicated to object storage",
+		}),
+		LastSuccess: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_replication_last_success_timestamp_seconds",
+			Help: "Unix time of the last replication run that completed without errors",
+		}),
+		ReplicatedBytes: factory.NewCounter(prometheus.CounterOpts{
+			Name: "audit_log_replication_bytes_total",
+			Help: "Total bytes of segments replicated to object storage",
+		}),
+		ReplicationErrors: factory.NewCounter(prometheus.CounterOpts{
+			Name: "audit_log_replication_errors_total",
+			Help: "Total number of failed segment replications",
+		}),
+	}
+}
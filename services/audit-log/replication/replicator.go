@@ -0,0 +1,432 @@
+// Audit Log Replication - Object Storage Copies of Sealed Segments
+// Replicates sealed segments of the segment log to S3-compatible storage under
+// compliance-mode object lock
+
+package replication
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/audit-log/writer"
+	"github.com/csic-platform/shared/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// StateFileName is the file below the storage path recording the last
+	// replicated segment
+	StateFileName = "replication_state.json"
+
+	// DefaultInterval is the replication interval used when none is configured
+	DefaultInterval = time.Minute
+
+	segmentContentType  = "application/octet-stream"
+	manifestContentType = "application/json"
+)
+
+// ErrObjectNotFound is returned when an object is not in the object store
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore is an object store holding the replicated segments
+type ObjectStore interface {
+	// Put uploads an object that cannot be overwritten or deleted before retainUntil
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string, retainUntil time.Time) error
+	// Get opens an object for reading
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// List returns the keys of the objects below prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Options configures the replicator
+type Options struct {
+	// StoragePath is the audit log storage path holding the segment log
+	StoragePath string
+	// Prefix is prepended to the keys of replicated objects
+	Prefix string
+	// Interval is the time between replication runs
+	Interval time.Duration
+	// RetentionDays is the object-lock retention of replicated objects
+	RetentionDays int
+}
+
+// Manifest describes a replicated segment. It is uploaded after the segment,
+// so a segment without a manifest was not completely replicated.
+type Manifest struct {
+	Segment       uint32 `json:"segment"`
+	SHA256        string `json:"sha256"`
+	Size          int64  `json:"size"`
+	EntryCount    int    `json:"entry_count"`
+	FirstSequence uint64 `json:"first_sequence"`
+	LastSequence  uint64 `json:"last_sequence"`
+	// PreviousHash is the chain hash preceding the first entry of the segment
+	PreviousHash string    `json:"previous_hash"`
+	LastHash     string    `json:"last_hash"`
+	SealedAt     time.Time `json:"sealed_at"`
+	ReplicatedAt time.Time `json:"replicated_at,omitempty"`
+	RetainUntil  time.Time `json:"retain_until,omitempty"`
+}
+
+// State records the replication progress
+type State struct {
+	LastSegment  uint32    `json:"last_segment"`
+	LastSequence uint64    `json:"last_sequence"`
+	LastHash     string    `json:"last_hash"`
+	ReplicatedAt time.Time `json:"replicated_at"`
+}
+
+// chainEntry holds the chain fields of a stored audit log entry
+type chainEntry struct {
+	SequenceNum  uint64 `json:"sequence_num"`
+	PreviousHash string `json:"previous_hash"`
+	CurrentHash  string `json:"current_hash"`
+}
+
+// Replicator asynchronously copies sealed segments, which no longer change,
+// to an object store in segment order. Each segment is followed by its
+// manifest, and both are locked for the retention period.
+type Replicator struct {
+	store      ObjectStore
+	opts       Options
+	metrics    *Metrics
+	logger     *logger.Logger
+	segmentDir string
+	statePath  string
+
+	mu     sync.Mutex
+	state  State
+	loaded bool
+}
+
+// NewReplicator creates a new segment replicator
+func NewReplicator(store ObjectStore, opts Options, metrics *Metrics, log *logger.Logger) *Replicator {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+
+	return &Replicator{
+		store:      store,
+		opts:       opts,
+		metrics:    metrics,
+		logger:     log,
+		segmentDir: filepath.Join(opts.StoragePath, writer.SegmentDirName),
+		statePath:  filepath.Join(opts.StoragePath, StateFileName),
+	}
+}
+
+// Run replicates sealed segments every interval until ctx is cancelled
+func (r *Replicator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if replicated, err := r.ReplicateOnce(ctx); err != nil {
+			r.logger.Error("segment replication failed", zap.Error(err), zap.Int("replicated", replicated))
+		} else if replicated > 0 {
+			r.logger.Info("replicated sealed segments", zap.Int("replicated", replicated))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReplicateOnce replicates the sealed segments after the last replicated one
+// and returns how many were replicated. Replication stops at the first
+// failure so that segments are always replicated in order.
+func (r *Replicator) ReplicateOnce(ctx context.Context) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.loaded {
+		if err := r.loadState(ctx); err != nil {
+			r.metrics.ReplicationErrors.Inc()
+			return 0, err
+		}
+		r.loaded = true
+	}
+
+	segments, err := writer.ListSegments(r.segmentDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list segments: %w", err)
+	}
+
+	var pending []uint32
+	for _, num := range sealedSegments(segments) {
+		if num > r.state.LastSegment {
+			pending = append(pending, num)
+		}
+	}
+	r.updateLag(pending)
+
+	for i, num := range pending {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		if err := r.replicateSegment(ctx, num); err != nil {
+			r.metrics.ReplicationErrors.Inc()
+			return i, fmt.Errorf("failed to replicate segment %d: %w", num, err)
+		}
+		r.updateLag(pending[i+1:])
+	}
+
+	r.metrics.LastSuccess.SetToCurrentTime()
+	return len(pending), nil
+}
+
+// Status returns the replication progress
+func (r *Replicator) Status() State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.state
+}
+
+// replicateSegment uploads a sealed segment and its manifest and records it
+// as replicated
+func (r *Replicator) replicateSegment(ctx context.Context, num uint32) error {
+	manifest, err := BuildManifest(r.opts.StoragePath, num)
+	if err != nil {
+		return err
+	}
+
+	// Never extend a remote chain with a segment that does not continue it
+	if r.state.LastHash != "" && manifest.EntryCount > 0 && manifest.PreviousHash != r.state.LastHash {
+		return fmt.Errorf("segment does not continue the replicated chain: previous hash %s, replicated head %s",
+			manifest.PreviousHash, r.state.LastHash)
+	}
+
+	now := time.Now().UTC()
+	manifest.ReplicatedAt = now
+	manifest.RetainUntil = now.AddDate(0, 0, r.opts.RetentionDays)
+
+	file, err := os.Open(writer.SegmentPath(r.segmentDir, num))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := r.store.Put(ctx, SegmentKey(r.opts.Prefix, num), file, manifest.Size, segmentContentType, manifest.RetainUntil); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := r.store.Put(ctx, ManifestKey(r.opts.Prefix, num), bytes.NewReader(data), int64(len(data)), manifestContentType, manifest.RetainUntil); err != nil {
+		return err
+	}
+
+	state := State{
+		LastSegment:  num,
+		LastSequence: r.state.LastSequence,
+		LastHash:     r.state.LastHash,
+		ReplicatedAt: now,
+	}
+	if manifest.EntryCount > 0 {
+		state.LastSequence = manifest.LastSequence
+		state.LastHash = manifest.LastHash
+	}
+	if err := r.saveState(state); err != nil {
+		return err
+	}
+
+	r.metrics.ReplicatedBytes.Add(float64(manifest.Size))
+	r.metrics.LastSegment.Set(float64(num))
+	return nil
+}
+
+// updateLag sets the lag metrics from the sealed segments not yet replicated
+func (r *Replicator) updateLag(pending []uint32) {
+	r.metrics.LagSegments.Set(float64(len(pending)))
+	if len(pending) == 0 {
+		r.metrics.LagSeconds.Set(0)
+		return
+	}
+
+	info, err := os.Stat(writer.SegmentPath(r.segmentDir, pending[0]))
+	if err != nil {
+		return
+	}
+	r.metrics.LagSeconds.Set(time.Since(info.ModTime()).Seconds())
+}
+
+// loadState loads the replication progress. Without a local state file, for
+// example after the storage volume was restored, it is taken from the last
+// manifest in the object store.
+func (r *Replicator) loadState(ctx context.Context) error {
+	data, err := os.ReadFile(r.statePath)
+	if err == nil {
+		if err := json.Unmarshal(data, &r.state); err != nil {
+			return fmt.Errorf("failed to unmarshal replication state: %w", err)
+		}
+		r.metrics.LastSegment.Set(float64(r.state.LastSegment))
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read replication state: %w", err)
+	}
+
+	head, err := RemoteHead(ctx, r.store, r.opts.Prefix)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		return nil
+	}
+	r.state = State{
+		LastSegment:  head.Segment,
+		LastSequence: head.LastSequence,
+		LastHash:     head.LastHash,
+		ReplicatedAt: head.ReplicatedAt,
+	}
+	r.metrics.LastSegment.Set(float64(head.Segment))
+	return r.saveState(r.state)
+}
+
+// saveState atomically replaces the state file
+func (r *Replicator) saveState(state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal replication state: %w", err)
+	}
+
+	tmp := r.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write replication state: %w", err)
+	}
+	if err := os.Rename(tmp, r.statePath); err != nil {
+		return fmt.Errorf("failed to replace replication state: %w", err)
+	}
+
+	r.state = state
+	return nil
+}
+
+// BuildManifest describes a local segment of the segment log below storagePath
+func BuildManifest(storagePath string, num uint32) (*Manifest, error) {
+	dir := filepath.Join(storagePath, writer.SegmentDirName)
+	segmentPath := writer.SegmentPath(dir, num)
+
+	file, err := os.Open(segmentPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash segment %d: %w", num, err)
+	}
+
+	manifest := &Manifest{
+		Segment:  num,
+		SHA256:   hex.EncodeToString(hasher.Sum(nil)),
+		Size:     size,
+		SealedAt: info.ModTime().UTC(),
+	}
+	err = writer.ScanSegment(dir, num, func(loc writer.Location, data []byte) error {
+		var entry chainEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to unmarshal entry at offset %d: %w", loc.Offset, err)
+		}
+		if manifest.EntryCount == 0 {
+			manifest.FirstSequence = entry.SequenceNum
+			manifest.PreviousHash = entry.PreviousHash
+		}
+		manifest.EntryCount++
+		manifest.LastSequence = entry.SequenceNum
+		manifest.LastHash = entry.CurrentHash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// RemoteHead returns the manifest of the last replicated segment, or nil if no
+// segment has been replicated
+func RemoteHead(ctx context.Context, store ObjectStore, prefix string) (*Manifest, error) {
+	segments, err := remoteSegments(ctx, store, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, nil
+	}
+	return getManifest(ctx, store, prefix, segments[len(segments)-1])
+}
+
+// SegmentKey returns the object key of a replicated segment
+func SegmentKey(prefix string, num uint32) string {
+	return path.Join(prefix, "segments", fmt.Sprintf("segment_%08d.log", num))
+}
+
+// ManifestKey returns the object key of the manifest of a replicated segment
+func ManifestKey(prefix string, num uint32) string {
+	return path.Join(prefix, "manifests", fmt.Sprintf("segment_%08d.json", num))
+}
+
+// remoteSegments returns the numbers of the segments with a manifest in the
+// object store in ascending order
+func remoteSegments(ctx context.Context, store ObjectStore, prefix string) ([]uint32, error) {
+	keys, err := store.List(ctx, path.Join(prefix, "manifests")+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []uint32
+	for _, key := range keys {
+		var num uint32
+		if _, err := fmt.Sscanf(path.Base(key), "segment_%08d.json", &num); err == nil {
+			segments = append(segments, num)
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+// getManifest downloads the manifest of a replicated segment
+func getManifest(ctx context.Context, store ObjectStore, prefix string, num uint32) (*Manifest, error) {
+	reader, err := store.Get(ctx, ManifestKey(prefix, num))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest of segment %d: %w", num, err)
+	}
+	return &manifest, nil
+}
+
+// sealedSegments returns all segments but the last, which is still being written
+func sealedSegments(segments []uint32) []uint32 {
+	if len(segments) <= 1 {
+		return nil
+	}
+	return segments[:len(segments)-1]
+}
@@ -0,0 +1,109 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config holds the connection settings of an S3-compatible object store
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+// S3Store implements ObjectStore on S3 or MinIO. Objects are written with an
+// object-lock retention in compliance mode, which no user, including the root
+// account, can shorten or remove before it expires.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Store creates an S3 store and makes sure the bucket exists with object
+// locking enabled. Object locking can only be enabled when a bucket is
+// created, so an existing bucket without it is rejected.
+func NewS3Store(ctx context.Context, cfg S3Config) (*S3Store, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region, ObjectLocking: true}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket: %w", err)
+		}
+	} else {
+		objectLock, _, _, _, err := client.GetObjectLockConfig(ctx, cfg.Bucket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get object lock configuration of bucket %s: %w", cfg.Bucket, err)
+		}
+		if objectLock != "Enabled" {
+			return nil, fmt.Errorf("bucket %s does not have object locking enabled", cfg.Bucket)
+		}
+	}
+
+	return &S3Store{
+		client: client,
+		bucket: cfg.Bucket,
+	}, nil
+}
+
+// Put uploads an object locked in compliance mode until retainUntil
+func (s *S3Store) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string, retainUntil time.Time) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, reader, size, minio.PutObjectOptions{
+		ContentType:     contentType,
+		Mode:            minio.Compliance,
+		RetainUntilDate: retainUntil.UTC(),
+		SendContentMd5:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get opens an object for reading
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	// GetObject is lazy; stat the object so a missing key fails here
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	return object, nil
+}
+
+// List returns the keys of the objects below prefix
+func (s *S3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", prefix, object.Err)
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,138 @@
+package replication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/csic-platform/services/audit-log/writer"
+)
+
+// ChainHead identifies the last entry of a chain of segments
+type ChainHead struct {
+	Segment  uint32 `json:"segment"`
+	Sequence uint64 `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+// VerificationReport is the result of comparing the local segment log with
+// its replica
+type VerificationReport struct {
+	// LocalHead is the head of the local sealed segments
+	LocalHead ChainHead `json:"local_head"`
+	// RemoteHead is the head of the replicated segments
+	RemoteHead         ChainHead `json:"remote_head"`
+	SealedSegments     int       `json:"sealed_segments"`
+	ReplicatedSegments int       `json:"replicated_segments"`
+	LagSegments        int       `json:"lag_segments"`
+	// Consistent is set when every replicated segment matches its local copy
+	Consistent bool `json:"consistent"`
+	// InSync is set when the replica is consistent and has every sealed segment
+	InSync     bool      `json:"in_sync"`
+	Mismatches []string  `json:"mismatches,omitempty"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// Verify compares the sealed segments below storagePath with their replicas.
+// Every remote manifest is checked against the local segment it describes and
+// the local and remote chain heads are compared. With downloadSegments the
+// replicated segments are also downloaded and their checksums recomputed.
+func Verify(ctx context.Context, store ObjectStore, storagePath, prefix string, downloadSegments bool) (*VerificationReport, error) {
+	dir := filepath.Join(storagePath, writer.SegmentDirName)
+	segments, err := writer.ListSegments(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+	sealed := sealedSegments(segments)
+	isSealed := make(map[uint32]bool, len(sealed))
+	for _, num := range sealed {
+		isSealed[num] = true
+	}
+
+	remote, err := remoteSegments(ctx, store, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VerificationReport{
+		SealedSegments:     len(sealed),
+		ReplicatedSegments: len(remote),
+		VerifiedAt:         time.Now().UTC(),
+	}
+	mismatch := func(format string, args ...interface{}) {
+		report.Mismatches = append(report.Mismatches, fmt.Sprintf(format, args...))
+	}
+
+	var local *Manifest
+	for _, num := range remote {
+		manifest, err := getManifest(ctx, store, prefix, num)
+		if err != nil {
+			return nil, err
+		}
+		report.RemoteHead = ChainHead{Segment: num, Sequence: manifest.LastSequence, Hash: manifest.LastHash}
+
+		if !isSealed[num] {
+			mismatch("segment %d is replicated but not sealed locally", num)
+			continue
+		}
+		local, err = BuildManifest(storagePath, num)
+		if err != nil {
+			return nil, err
+		}
+		if local.SHA256 != manifest.SHA256 || local.Size != manifest.Size {
+			mismatch("segment %d: local checksum %s (%d bytes), replicated %s (%d bytes)",
+				num, local.SHA256, local.Size, manifest.SHA256, manifest.Size)
+		}
+		if local.LastHash != manifest.LastHash || local.LastSequence != manifest.LastSequence {
+			mismatch("segment %d: local head %d/%s, replicated head %d/%s",
+				num, local.LastSequence, local.LastHash, manifest.LastSequence, manifest.LastHash)
+		}
+
+		if downloadSegments {
+			checksum, err := remoteChecksum(ctx, store, SegmentKey(prefix, num))
+			if err != nil {
+				mismatch("segment %d: %v", num, err)
+			} else if checksum != manifest.SHA256 {
+				mismatch("segment %d: replicated object checksum %s does not match manifest %s", num, checksum, manifest.SHA256)
+			}
+		}
+	}
+
+	if len(sealed) > 0 {
+		last := sealed[len(sealed)-1]
+		if local == nil || local.Segment != last {
+			if local, err = BuildManifest(storagePath, last); err != nil {
+				return nil, err
+			}
+		}
+		report.LocalHead = ChainHead{Segment: last, Sequence: local.LastSequence, Hash: local.LastHash}
+	}
+	for _, num := range sealed {
+		if num > report.RemoteHead.Segment {
+			report.LagSegments++
+		}
+	}
+
+	report.Consistent = len(report.Mismatches) == 0
+	report.InSync = report.Consistent && report.LocalHead == report.RemoteHead
+	return report, nil
+}
+
+// remoteChecksum downloads an object and returns its SHA-256 checksum
+func remoteChecksum(ctx context.Context, store ObjectStore, key string) (string, error) {
+	reader, err := store.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,489 @@
+// Audit Log SIEM Forwarder - Continuous Shipping of Audit Entries
+// Tails the segment log and ships every entry to the national SIEM as a
+// syslog message over TLS, resuming after the last entry the SIEM accepted
+
+package siem
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/audit-log/writer"
+	"github.com/csic-platform/shared/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// StateFileName is the file below the storage path recording the last
+	// forwarded entry
+	StateFileName = "siem_state.json"
+
+	// DefaultQueueSize is the queue size used when none is configured
+	DefaultQueueSize = 1000
+	// DefaultPollInterval is the log polling interval used when none is configured
+	DefaultPollInterval = 500 * time.Millisecond
+	// DefaultWriteTimeout is the write timeout used when none is configured
+	DefaultWriteTimeout = 10 * time.Second
+	// DefaultMaxBackoff is the reconnection backoff limit used when none is configured
+	DefaultMaxBackoff = time.Minute
+
+	// stateSaveEvery bounds the entries forwarded between state saves, and so
+	// the entries forwarded again after a crash
+	stateSaveEvery = 100
+
+	dialTimeout = 10 * time.Second
+	minBackoff  = time.Second
+)
+
+// Options configures the forwarder
+type Options struct {
+	// StoragePath is the audit log storage path holding the segment log
+	StoragePath string
+	// Address is the host:port of the SIEM's syslog over TLS receiver
+	Address string
+	// TLS configures the connection to the SIEM
+	TLS *tls.Config
+	// QueueSize is the number of entries read ahead of the connection. When
+	// the queue is full, reading the log pauses until the SIEM catches up.
+	QueueSize int
+	// PollInterval is the time between reads of the log once caught up
+	PollInterval time.Duration
+	// WriteTimeout bounds each write to the SIEM; a receiver that stops
+	// reading for longer is reconnected
+	WriteTimeout time.Duration
+	// MaxBackoff bounds the time between reconnection attempts
+	MaxBackoff time.Duration
+	// Head returns the sequence number of the last written entry
+	Head func() uint64
+}
+
+// State records the forwarding progress. Location is the record of the last
+// forwarded entry, from which reading resumes.
+type State struct {
+	Location     writer.Location `json:"location"`
+	LastSequence uint64          `json:"last_sequence"`
+	LastEntryAt  time.Time       `json:"last_entry_at,omitempty"`
+	ForwardedAt  time.Time       `json:"forwarded_at,omitempty"`
+}
+
+// Status reports the forwarder's progress
+type Status struct {
+	State
+	Address    string  `json:"address"`
+	Connected  bool    `json:"connected"`
+	Queued     int     `json:"queued"`
+	LagEntries uint64  `json:"lag_entries"`
+	LagSeconds float64 `json:"lag_seconds"`
+	LastError  string  `json:"last_error,omitempty"`
+}
+
+// queuedEntry is an entry read from the log with its location
+type queuedEntry struct {
+	entry Entry
+	loc   writer.Location
+}
+
+// Forwarder ships audit entries to a SIEM in sequence order. Entries are
+// read from the segment log into a bounded queue and written to the SIEM as
+// RFC 5425 octet-counted frames; the log is never blocked by a slow or
+// unavailable SIEM. Delivery is at least once: after a restart, up to
+// stateSaveEvery entries may be sent again.
+type Forwarder struct {
+	formatter  *Formatter
+	opts       Options
+	metrics    *Metrics
+	logger     *logger.Logger
+	segmentDir string
+	statePath  string
+	queue      chan queuedEntry
+
+	mu        sync.Mutex
+	state     State
+	conn      net.Conn
+	lastError string
+	oldestAt  time.Time
+}
+
+// NewForwarder creates a new SIEM forwarder
+func NewForwarder(formatter *Formatter, opts Options, metrics *Metrics, log *logger.Logger) *Forwarder {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = DefaultWriteTimeout
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+
+	return &Forwarder{
+		formatter:  formatter,
+		opts:       opts,
+		metrics:    metrics,
+		logger:     log,
+		segmentDir: filepath.Join(opts.StoragePath, writer.SegmentDirName),
+		statePath:  filepath.Join(opts.StoragePath, StateFileName),
+		queue:      make(chan queuedEntry, opts.QueueSize),
+	}
+}
+
+// Run forwards entries until ctx is cancelled
+func (f *Forwarder) Run(ctx context.Context) {
+	if err := f.loadState(); err != nil {
+		f.logger.Error("failed to load SIEM forwarding state", zap.Error(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		f.read(ctx)
+	}()
+
+	f.send(ctx)
+	wg.Wait()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.disconnect()
+	if err := f.saveState(); err != nil {
+		f.logger.Error("failed to save SIEM forwarding state", zap.Error(err))
+	}
+}
+
+// Status returns the forwarding progress
+func (f *Forwarder) Status() Status {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	status := Status{
+		State:     f.state,
+		Address:   f.opts.Address,
+		Connected: f.conn != nil,
+		Queued:    len(f.queue),
+		LastError: f.lastError,
+	}
+	status.LagEntries, status.LagSeconds = f.lag()
+	return status
+}
+
+// read tails the segment log from the last forwarded entry and queues every
+// later entry, pausing while the queue is full
+func (f *Forwarder) read(ctx context.Context) {
+	defer close(f.queue)
+
+	f.mu.Lock()
+	loc, lastSequence := f.state.Location, f.state.LastSequence
+	f.mu.Unlock()
+
+	for {
+		segments, err := writer.ListSegments(f.segmentDir)
+		if err != nil {
+			f.logger.Error("failed to list segments", zap.Error(err))
+		}
+
+		if i := firstSegmentFrom(segments, loc.Segment); err == nil && i >= 0 {
+			if segments[i] != loc.Segment {
+				loc = writer.Location{Segment: segments[i]}
+			}
+
+			loc.Offset, err = writer.ScanSegmentFrom(f.segmentDir, loc.Segment, loc.Offset, func(at writer.Location, data []byte) error {
+				var entry Entry
+				if err := json.Unmarshal(data, &entry); err != nil {
+					return fmt.Errorf("failed to unmarshal entry at %d:%d: %w", at.Segment, at.Offset, err)
+				}
+				// The record of the last forwarded entry is read again on resume
+				if entry.SequenceNum <= lastSequence {
+					return nil
+				}
+				if err := f.enqueue(ctx, queuedEntry{entry: entry, loc: at}); err != nil {
+					return err
+				}
+				lastSequence = entry.SequenceNum
+				return nil
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				f.logger.Error("failed to read segment log", zap.Error(err), zap.Uint32("segment", loc.Segment))
+			}
+
+			// A segment followed by another is sealed and complete, so the
+			// next one is read without waiting
+			if err == nil && i < len(segments)-1 {
+				loc = writer.Location{Segment: segments[i+1]}
+				continue
+			}
+		}
+
+		f.updateLag()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.opts.PollInterval):
+		}
+	}
+}
+
+// enqueue queues an entry, waiting while the queue is full
+func (f *Forwarder) enqueue(ctx context.Context, queued queuedEntry) error {
+	select {
+	case f.queue <- queued:
+		f.metrics.QueueDepth.Set(float64(len(f.queue)))
+		return nil
+	default:
+	}
+
+	blockedAt := time.Now()
+	defer func() { f.metrics.BlockedSeconds.Add(time.Since(blockedAt).Seconds()) }()
+
+	select {
+	case f.queue <- queued:
+		f.metrics.QueueDepth.Set(float64(len(f.queue)))
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// send writes queued entries to the SIEM, retrying each until it is accepted
+func (f *Forwarder) send(ctx context.Context) {
+	backoff := minBackoff
+	unsaved := 0
+
+	for queued := range f.queue {
+		f.metrics.QueueDepth.Set(float64(len(f.queue)))
+		f.mu.Lock()
+		f.oldestAt = queued.entry.Timestamp
+		f.mu.Unlock()
+
+		frame := frame(f.formatter.Format(&queued.entry))
+		for {
+			err := f.write(frame)
+			if err == nil {
+				backoff = minBackoff
+				break
+			}
+
+			f.metrics.ForwardErrors.Inc()
+			f.logger.Warn("failed to forward audit entry to SIEM", zap.Error(err),
+				zap.Uint64("sequence", queued.entry.SequenceNum), zap.Duration("retry_in", backoff))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > f.opts.MaxBackoff {
+				backoff = f.opts.MaxBackoff
+			}
+		}
+
+		f.mu.Lock()
+		f.state = State{
+			Location:     queued.loc,
+			LastSequence: queued.entry.SequenceNum,
+			LastEntryAt:  queued.entry.Timestamp,
+			ForwardedAt:  time.Now().UTC(),
+		}
+		f.oldestAt = time.Time{}
+		unsaved++
+		if unsaved >= stateSaveEvery || len(f.queue) == 0 {
+			if err := f.saveState(); err != nil {
+				f.logger.Error("failed to save SIEM forwarding state", zap.Error(err))
+			}
+			unsaved = 0
+		}
+		f.mu.Unlock()
+
+		f.metrics.ForwardedEntries.Inc()
+		f.metrics.ForwardedBytes.Add(float64(len(frame)))
+		f.metrics.LastSequence.Set(float64(queued.entry.SequenceNum))
+		f.updateLag()
+	}
+}
+
+// write sends a frame, connecting first if needed. The connection is closed
+// on failure so the next attempt reconnects.
+func (f *Forwarder) write(frame []byte) error {
+	f.mu.Lock()
+	conn := f.conn
+	f.mu.Unlock()
+
+	if conn == nil {
+		dialer := &net.Dialer{Timeout: dialTimeout}
+		c, err := tls.DialWithDialer(dialer, "tcp", f.opts.Address, f.opts.TLS)
+		if err != nil {
+			f.fail(fmt.Errorf("failed to connect to SIEM: %w", err))
+			return err
+		}
+		conn = c
+
+		f.mu.Lock()
+		f.conn = conn
+		f.mu.Unlock()
+		f.metrics.Connected.Set(1)
+		f.logger.Info("connected to SIEM", zap.String("address", f.opts.Address))
+	}
+
+	if err := conn.SetWriteDeadline(time.Now().Add(f.opts.WriteTimeout)); err != nil {
+		f.fail(err)
+		return err
+	}
+	if _, err := conn.Write(frame); err != nil {
+		f.fail(fmt.Errorf("failed to write to SIEM: %w", err))
+		return err
+	}
+	return nil
+}
+
+// fail records an error and drops the connection
+func (f *Forwarder) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastError = err.Error()
+	f.disconnect()
+}
+
+// disconnect closes the connection, if any
+func (f *Forwarder) disconnect() {
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+	f.metrics.Connected.Set(0)
+}
+
+// updateLag sets the lag metrics
+func (f *Forwarder) updateLag() {
+	f.mu.Lock()
+	entries, seconds := f.lag()
+	f.mu.Unlock()
+
+	f.metrics.LagEntries.Set(float64(entries))
+	f.metrics.LagSeconds.Set(seconds)
+}
+
+// lag returns the number of entries not yet forwarded and the age of the
+// oldest of them. The caller must hold f.mu.
+func (f *Forwarder) lag() (uint64, float64) {
+	var entries uint64
+	if f.opts.Head != nil {
+		if head := f.opts.Head(); head > f.state.LastSequence {
+			entries = head - f.state.LastSequence
+		}
+	}
+	if entries == 0 {
+		return 0, 0
+	}
+
+	oldest := f.oldestAt
+	if oldest.IsZero() {
+		// Not yet read from the log; it was written after the last forwarded entry
+		oldest = f.state.LastEntryAt
+	}
+	if oldest.IsZero() {
+		return entries, 0
+	}
+	return entries, time.Since(oldest).Seconds()
+}
+
+// loadState loads the forwarding progress. Without a state file forwarding
+// starts at the beginning of the log.
+func (f *Forwarder) loadState() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.statePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read SIEM forwarding state: %w", err)
+	}
+	if err := json.Unmarshal(data, &f.state); err != nil {
+		return fmt.Errorf("failed to unmarshal SIEM forwarding state: %w", err)
+	}
+	f.metrics.LastSequence.Set(float64(f.state.LastSequence))
+	return nil
+}
+
+// saveState atomically replaces the state file. The caller must hold f.mu.
+func (f *Forwarder) saveState() error {
+	data, err := json.Marshal(f.state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SIEM forwarding state: %w", err)
+	}
+
+	tmp := f.statePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write SIEM forwarding state: %w", err)
+	}
+	if err := os.Rename(tmp, f.statePath); err != nil {
+		return fmt.Errorf("failed to replace SIEM forwarding state: %w", err)
+	}
+	return nil
+}
+
+// NewTLSConfig builds the TLS configuration of the SIEM connection. The SIEM
+// certificate is verified against caFile, or the system roots if it is
+// empty; certFile and keyFile, if given, authenticate the forwarder.
+func NewTLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SIEM CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in SIEM CA file %s", caFile)
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SIEM client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// firstSegmentFrom returns the index of the first segment numbered current
+// or later, or -1 if there is none
+func firstSegmentFrom(segments []uint32, current uint32) int {
+	for i, num := range segments {
+		if num >= current {
+			return i
+		}
+	}
+	return -1
+}
+
+// frame prefixes a syslog message with its length, as octet counting in
+// RFC 5425 requires
+func frame(message []byte) []byte {
+	prefix := strconv.Itoa(len(message)) + " "
+	return append([]byte(prefix), message...)
+}
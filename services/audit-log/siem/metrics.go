@@ -0,0 +1,63 @@
+package siem
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds the Prometheus metrics of SIEM forwarding
+type Metrics struct {
+	LagEntries       prometheus.Gauge
+	LagSeconds       prometheus.Gauge
+	QueueDepth       prometheus.Gauge
+	Connected        prometheus.Gauge
+	LastSequence     prometheus.Gauge
+	ForwardedEntries prometheus.Counter
+	ForwardedBytes   prometheus.Counter
+	ForwardErrors    prometheus.Counter
+	BlockedSeconds   prometheus.Counter
+}
+
+// NewMetrics creates and registers the SIEM forwarding metrics
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	factory := promauto.With(registerer)
+
+	return &Metrics{
+		LagEntries: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_siem_lag_entries",
+			Help: "Number of audit entries written but not yet forwarded to the SIEM",
+		}),
+		LagSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_siem_lag_seconds",
+			Help: "Age of the oldest audit entry not yet forwarded to the SIEM",
+		}),
+		QueueDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_siem_queue_depth",
+			Help: "Number of audit entries read from the log and waiting for the SIEM connection",
+		}),
+		Connected: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_siem_connected",
+			Help: "Whether the forwarder is connected to the SIEM (1) or not (0)",
+		}),
+		LastSequence: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "audit_log_siem_last_sequence",
+			Help: "Sequence number of the last audit entry forwarded to the SIEM",
+		}),
+		ForwardedEntries: factory.NewCounter(prometheus.CounterOpts{
+			Name: "audit_log_siem_forwarded_entries_total",
+			Help: "Total number of audit entries forwarded to the SIEM",
+		}),
+		ForwardedBytes: factory.NewCounter(prometheus.CounterOpts{
+			Name: "audit_log_siem_forwarded_bytes_total",
+			Help: "Total bytes of syslog messages sent to the SIEM",
+		}),
+		ForwardErrors: factory.NewCounter(prometheus.CounterOpts{
+			Name: "audit_log_siem_errors_total",
+			Help: "Total number of failed connections and writes to the SIEM",
+		}),
+		BlockedSeconds: factory.NewCounter(prometheus.CounterOpts{
+			Name: "audit_log_siem_blocked_seconds_total",
+			Help: "Total time reading the log was paused because the forwarding queue was full",
+		}),
+	}
+}
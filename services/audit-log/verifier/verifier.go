@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/csic-platform/services/audit-log"
+	"github.com/csic-platform/services/audit-log/writer"
 )
 
 // AuditLogVerifier verifies the integrity of audit log chains
@@ -197,20 +198,9 @@ func (v *AuditLogVerifier) verifyChains() (bool, error) {
 
 // verifyHashChain verifies the hash chain continuity
 func (v *AuditLogVerifier) verifyHashChain() (bool, error) {
-	// Get all log files
-	logFiles, err := filepath.Glob(filepath.Join(v.storagePath, "audit_*.log"))
+	allEntries, err := v.loadAllEntries()
 	if err != nil {
-		return false, err
-	}
-
-	var allEntries []*audit.AuditLogEntry
-
-	for _, logFile := range logFiles {
-		entries, err := v.loadEntriesFromFile(logFile)
-		if err != nil {
-			return false, fmt.Errorf("failed to load entries from %s: %w", logFile, err)
-		}
-		allEntries = append(allEntries, entries...)
+		return false, fmt.Errorf("failed to load entries: %w", err)
 	}
 
 	// Sort by sequence number
@@ -320,20 +310,11 @@ func (v *AuditLogVerifier) verifySealSignature(chain *audit.AuditChain) (bool, e
 
 // loadEntriesInRange loads entries within a sequence range
 func (v *AuditLogVerifier) loadEntriesInRange(startSeq, endSeq uint64) ([]*audit.AuditLogEntry, error) {
-	logFiles, err := filepath.Glob(filepath.Join(v.storagePath, "audit_*.log"))
+	allEntries, err := v.loadAllEntries()
 	if err != nil {
 		return nil, err
 	}
 
-	var allEntries []*audit.AuditLogEntry
-	for _, logFile := range logFiles {
-		entries, err := v.loadEntriesFromFile(logFile)
-		if err != nil {
-			return nil, err
-		}
-		allEntries = append(allEntries, entries...)
-	}
-
 	var result []*audit.AuditLogEntry
 	for _, entry := range allEntries {
 		if entry.SequenceNum >= startSeq && entry.SequenceNum <= endSeq {
@@ -344,45 +325,20 @@ func (v *AuditLogVerifier) loadEntriesInRange(startSeq, endSeq uint64) ([]*audit
 	return sortBySequence(result), nil
 }
 
-// loadEntriesFromFile loads all entries from a log file
-func (v *AuditLogVerifier) loadEntriesFromFile(filePath string) ([]*audit.AuditLogEntry, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
+// loadAllEntries loads all entries from the segment log
+func (v *AuditLogVerifier) loadAllEntries() ([]*audit.AuditLogEntry, error) {
 	var entries []*audit.AuditLogEntry
 
-	for {
-		// Read length prefix
-		lengthBuf := make([]byte, 4)
-		n, err := file.Read(lengthBuf)
-		if n == 0 && err != nil {
-			break
-		}
-		if n < 4 {
-			break
-		}
-
-		length := uint32(0)
-		for i := 0; i < 4; i++ {
-			length = (length << 8) | uint32(lengthBuf[i])
-		}
-
-		// Read data
-		data := make([]byte, length)
-		n, err = file.Read(data)
-		if n < int(length) && err != nil {
-			break
-		}
-
+	err := writer.ScanSegments(filepath.Join(v.storagePath, writer.SegmentDirName), func(loc writer.Location, data []byte) error {
 		var entry audit.AuditLogEntry
 		if err := json.Unmarshal(data, &entry); err != nil {
-			continue
+			return fmt.Errorf("failed to unmarshal entry at %d:%d: %w", loc.Segment, loc.Offset, err)
 		}
-
 		entries = append(entries, &entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return entries, nil
@@ -390,21 +346,12 @@ func (v *AuditLogVerifier) loadEntriesFromFile(filePath string) ([]*audit.AuditL
 
 // countVerifiedEntries counts total verified entries
 func (v *AuditLogVerifier) countVerifiedEntries() (int, error) {
-	logFiles, err := filepath.Glob(filepath.Join(v.storagePath, "audit_*.log"))
+	entries, err := v.loadAllEntries()
 	if err != nil {
 		return 0, err
 	}
 
-	total := 0
-	for _, logFile := range logFiles {
-		entries, err := v.loadEntriesFromFile(logFile)
-		if err != nil {
-			continue
-		}
-		total += len(entries)
-	}
-
-	return total, nil
+	return len(entries), nil
 }
 
 // calculateHash calculates the hash of an entry
@@ -422,18 +369,21 @@ func (v *AuditLogVerifier) calculateHash(entry *audit.AuditLogEntry) string {
 		Resource     string
 		Result       string
 		PreviousHash string
+		// Matches the writer: omitted when empty
+		CorrelationID string `json:",omitempty"`
 	}{
-		EntryID:      entry.EntryID,
-		SequenceNum:  entry.SequenceNum,
-		Timestamp:    entry.Timestamp,
-		ChainID:      entry.ChainID,
-		ActorID:      entry.ActorID,
-		Service:      entry.Service,
-		Operation:    entry.Operation,
-		ActionType:   entry.ActionType,
-		Resource:     entry.Resource,
-		Result:       entry.Result,
-		PreviousHash: entry.PreviousHash,
+		EntryID:       entry.EntryID,
+		SequenceNum:   entry.SequenceNum,
+		Timestamp:     entry.Timestamp,
+		ChainID:       entry.ChainID,
+		ActorID:       entry.ActorID,
+		Service:       entry.Service,
+		Operation:     entry.Operation,
+		ActionType:    entry.ActionType,
+		Resource:      entry.Resource,
+		Result:        entry.Result,
+		PreviousHash:  entry.PreviousHash,
+		CorrelationID: entry.CorrelationID,
 	}
 
 	data, err := json.Marshal(canonical)
@@ -0,0 +1,152 @@
+// Audit Log Migration - Per-File Layout to Segment Log
+// Copies entries from the legacy audit_*.log storage files into the segment log
+
+package writer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LegacyDirName is the directory below the storage path that migrated legacy
+// storage files are moved to
+const LegacyDirName = "legacy"
+
+// MigrationResult summarizes a migration from the per-file layout
+type MigrationResult struct {
+	Files    int `json:"files"`
+	Entries  int `json:"entries"`
+	Migrated int `json:"migrated"`
+	// Skipped counts entries already present in the segment log
+	Skipped int `json:"skipped"`
+}
+
+// legacyEntry is a legacy entry with the keys needed to index it
+type legacyEntry struct {
+	EntryID     string `json:"entry_id"`
+	SequenceNum uint64 `json:"sequence_num"`
+	CurrentHash string `json:"current_hash"`
+	data        []byte
+}
+
+// MigrateLegacyFiles copies the entries of the legacy audit_*.log storage
+// files in storagePath into the segment log in sequence order, keeping their
+// hashes, and moves the migrated files to the legacy directory. Entries
+// already in the segment log are skipped, so an interrupted migration can be
+// rerun. The audit log service must not be running.
+func MigrateLegacyFiles(storagePath string, opts SegmentOptions, batchSize int) (*MigrationResult, error) {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	files, err := filepath.Glob(filepath.Join(storagePath, "audit_*.log"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	result := &MigrationResult{Files: len(files)}
+	if len(files) == 0 {
+		return result, nil
+	}
+
+	var entries []*legacyEntry
+	for _, file := range files {
+		fileEntries, err := readLegacyFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		entries = append(entries, fileEntries...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].SequenceNum < entries[j].SequenceNum
+	})
+	result.Entries = len(entries)
+
+	log, err := OpenSegmentLog(filepath.Join(storagePath, SegmentDirName), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer log.Close()
+
+	batch := make([]SegmentRecord, 0, batchSize)
+	for _, entry := range entries {
+		if _, exists := log.LookupHash(entry.CurrentHash); exists {
+			result.Skipped++
+			continue
+		}
+		batch = append(batch, SegmentRecord{Hash: entry.CurrentHash, EntryID: entry.EntryID, Data: entry.data})
+		if len(batch) == batchSize {
+			if _, err := log.AppendBatch(batch); err != nil {
+				return nil, err
+			}
+			result.Migrated += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if _, err := log.AppendBatch(batch); err != nil {
+			return nil, err
+		}
+		result.Migrated += len(batch)
+	}
+
+	// Move the legacy files only once every entry is in the segment log
+	legacyDir := filepath.Join(storagePath, LegacyDirName)
+	if err := os.MkdirAll(legacyDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create legacy directory: %w", err)
+	}
+	for _, file := range files {
+		if err := os.Rename(file, filepath.Join(legacyDir, filepath.Base(file))); err != nil {
+			return nil, fmt.Errorf("failed to move %s: %w", file, err)
+		}
+	}
+
+	return result, nil
+}
+
+// readLegacyFile reads the length-prefixed JSON entries of a legacy storage file
+func readLegacyFile(path string) ([]*legacyEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var entries []*legacyEntry
+	for {
+		lengthBuf := make([]byte, 4)
+		if _, err := io.ReadFull(reader, lengthBuf); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return entries, nil
+			}
+			return nil, err
+		}
+
+		length := binary.BigEndian.Uint32(lengthBuf)
+		if length > maxRecordSize {
+			return nil, fmt.Errorf("invalid entry length %d", length)
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			// A torn final entry was never acknowledged
+			return entries, nil
+		}
+
+		entry := &legacyEntry{data: data}
+		if err := json.Unmarshal(data, entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal entry: %w", err)
+		}
+		if entry.CurrentHash == "" {
+			return nil, fmt.Errorf("entry %s has no hash", entry.EntryID)
+		}
+		entries = append(entries, entry)
+	}
+}
@@ -0,0 +1,618 @@
+// Audit Log Segment Log - Append-Only Segmented Storage
+// Stores audit entries as checksummed records in size-bounded segment files
+// with a hash index for direct lookups
+
+package writer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	// DefaultSegmentSize is the segment size used when none is configured
+	DefaultSegmentSize int64 = 64 * 1024 * 1024
+
+	// SegmentDirName is the directory below the storage path holding segments
+	SegmentDirName = "segments"
+
+	indexFileName = "index.log"
+
+	// recordHeaderSize is the size of the length and checksum preceding each record body
+	recordHeaderSize = 8
+
+	// maxRecordSize bounds the size of a record body read from disk
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+// ErrRecordNotFound is returned when a record is not in the segment log
+var ErrRecordNotFound = errors.New("record not found")
+
+// errTornRecord marks an incomplete or corrupt record at the end of a segment
+var errTornRecord = errors.New("torn segment record")
+
+// SegmentOptions configures the segment log
+type SegmentOptions struct {
+	// SegmentSize is the size in bytes after which a segment is sealed and a
+	// new one started
+	SegmentSize int64
+	// SyncPerBatch fsyncs the segment and the index after every appended batch
+	SyncPerBatch bool
+	// EnableWORM makes sealed segments read-only
+	EnableWORM bool
+}
+
+// Location identifies a record in the segment log
+type Location struct {
+	Segment uint32 `json:"segment"`
+	Offset  int64  `json:"offset"`
+}
+
+// SegmentRecord is a record to append together with its index keys
+type SegmentRecord struct {
+	Hash    string
+	EntryID string
+	Data    []byte
+}
+
+// indexEntry is a line of the index file
+type indexEntry struct {
+	Hash    string `json:"hash"`
+	EntryID string `json:"entry_id"`
+	Segment uint32 `json:"segment"`
+	Offset  int64  `json:"offset"`
+}
+
+// SegmentLog is an append-only log of records split over segment files.
+// Each record is stored as a 4-byte body length, a 4-byte CRC-32 of the body
+// and the body, which holds the record's hash, entry ID and data. The index
+// file maps hashes and entry IDs to record locations; it is an accelerator
+// only and is rebuilt from the segments when it lags behind them.
+type SegmentLog struct {
+	dir  string
+	opts SegmentOptions
+
+	mu         sync.RWMutex
+	active     *os.File
+	activeNum  uint32
+	activeSize int64
+	index      *os.File
+	byHash     map[string]Location
+	byEntryID  map[string]Location
+}
+
+// OpenSegmentLog opens or creates the segment log in dir. A torn record at the
+// end of the last segment, left by a crash during an append, is truncated.
+func OpenSegmentLog(dir string, opts SegmentOptions) (*SegmentLog, error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = DefaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create segment directory: %w", err)
+	}
+
+	l := &SegmentLog{
+		dir:       dir,
+		opts:      opts,
+		byHash:    make(map[string]Location),
+		byEntryID: make(map[string]Location),
+	}
+
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	l.activeNum = 1
+	if len(segments) > 0 {
+		l.activeNum = segments[len(segments)-1]
+	}
+
+	if err := l.recoverActive(); err != nil {
+		return nil, err
+	}
+	if err := l.loadIndex(segments); err != nil {
+		l.active.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// recoverActive truncates a torn tail of the active segment and opens it for appending
+func (l *SegmentLog) recoverActive() error {
+	path := segmentPath(l.dir, l.activeNum)
+
+	size, err := scanSegment(path, l.activeNum, nil)
+	if err != nil && !errors.Is(err, errTornRecord) && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to scan segment %d: %w", l.activeNum, err)
+	}
+	if errors.Is(err, errTornRecord) {
+		if err := os.Truncate(path, size); err != nil {
+			return fmt.Errorf("failed to truncate torn record in segment %d: %w", l.activeNum, err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open segment %d: %w", l.activeNum, err)
+	}
+	l.active = file
+	l.activeSize = size
+	return nil
+}
+
+// loadIndex loads the index file and indexes records appended after the last
+// indexed record, which happens when the process stopped between writing a
+// batch and indexing it
+func (l *SegmentLog) loadIndex(segments []uint32) error {
+	path := filepath.Join(l.dir, indexFileName)
+
+	var loaded []indexEntry
+	var last *Location
+	stale := false
+	if data, err := os.ReadFile(path); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var entry indexEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				// A partially written final line; the records after it are
+				// re-indexed from the segments below
+				stale = true
+				break
+			}
+			loc := Location{Segment: entry.Segment, Offset: entry.Offset}
+			if loc.Segment > l.activeNum || (loc.Segment == l.activeNum && loc.Offset >= l.activeSize) {
+				// The record was lost with the torn tail of the active segment
+				stale = true
+				continue
+			}
+			loaded = append(loaded, entry)
+			l.byHash[entry.Hash] = loc
+			l.byEntryID[entry.EntryID] = loc
+			last = &loc
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read segment index: %w", err)
+	}
+
+	// Collect the records after the last indexed one
+	var missing []indexEntry
+	for _, num := range segments {
+		if last != nil && num < last.Segment {
+			continue
+		}
+		_, err := scanSegment(segmentPath(l.dir, num), num, func(loc Location, hash, entryID string, data []byte) error {
+			if last != nil && num == last.Segment && loc.Offset <= last.Offset {
+				return nil
+			}
+			missing = append(missing, indexEntry{Hash: hash, EntryID: entryID, Segment: loc.Segment, Offset: loc.Offset})
+			return nil
+		})
+		if err != nil && !errors.Is(err, errTornRecord) {
+			return fmt.Errorf("failed to scan segment %d: %w", num, err)
+		}
+	}
+
+	if stale {
+		// Rewrite the index without lost records and partial lines
+		if err := writeIndexFile(path, loaded); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open segment index: %w", err)
+	}
+	l.index = file
+
+	return l.appendIndex(missing, true)
+}
+
+// AppendBatch appends records in order and returns their locations. The batch
+// is written with one write per segment and, with SyncPerBatch, made durable
+// with one fsync of the segment and of the index.
+func (l *SegmentLog) AppendBatch(records []SegmentRecord) ([]Location, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	locations := make([]Location, 0, len(records))
+	entries := make([]indexEntry, 0, len(records))
+	var buf bytes.Buffer
+
+	for _, record := range records {
+		frame, err := encodeRecord(record)
+		if err != nil {
+			return nil, err
+		}
+
+		pending := int64(buf.Len())
+		if l.activeSize+pending > 0 && l.activeSize+pending+int64(len(frame)) > l.opts.SegmentSize {
+			if err := l.writeActive(buf.Bytes()); err != nil {
+				return nil, err
+			}
+			buf.Reset()
+			if err := l.rotate(); err != nil {
+				return nil, err
+			}
+		}
+
+		loc := Location{Segment: l.activeNum, Offset: l.activeSize + int64(buf.Len())}
+		buf.Write(frame)
+		locations = append(locations, loc)
+		entries = append(entries, indexEntry{Hash: record.Hash, EntryID: record.EntryID, Segment: loc.Segment, Offset: loc.Offset})
+	}
+
+	if err := l.writeActive(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	if l.opts.SyncPerBatch {
+		if err := l.active.Sync(); err != nil {
+			return nil, fmt.Errorf("failed to sync segment %d: %w", l.activeNum, err)
+		}
+	}
+
+	if err := l.appendIndex(entries, l.opts.SyncPerBatch); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// writeActive appends data to the active segment
+func (l *SegmentLog) writeActive(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if _, err := l.active.Write(data); err != nil {
+		// Drop a partial write so later records follow the last complete one
+		l.active.Truncate(l.activeSize)
+		return fmt.Errorf("failed to write segment %d: %w", l.activeNum, err)
+	}
+	l.activeSize += int64(len(data))
+	return nil
+}
+
+// rotate seals the active segment and starts the next one. Sealed segments are
+// always synced and, with WORM enabled, made read-only.
+func (l *SegmentLog) rotate() error {
+	if err := l.active.Sync(); err != nil {
+		return fmt.Errorf("failed to sync segment %d: %w", l.activeNum, err)
+	}
+	if err := l.active.Close(); err != nil {
+		return fmt.Errorf("failed to close segment %d: %w", l.activeNum, err)
+	}
+	if l.opts.EnableWORM {
+		if err := os.Chmod(segmentPath(l.dir, l.activeNum), 0400); err != nil {
+			return fmt.Errorf("failed to set WORM permissions on segment %d: %w", l.activeNum, err)
+		}
+	}
+
+	l.activeNum++
+	file, err := os.OpenFile(segmentPath(l.dir, l.activeNum), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create segment %d: %w", l.activeNum, err)
+	}
+	l.active = file
+	l.activeSize = 0
+	return nil
+}
+
+// appendIndex appends entries to the index file and the in-memory index
+func (l *SegmentLog) appendIndex(entries []indexEntry, sync bool) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode index entry: %w", err)
+		}
+	}
+	if _, err := l.index.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write segment index: %w", err)
+	}
+	if sync {
+		if err := l.index.Sync(); err != nil {
+			return fmt.Errorf("failed to sync segment index: %w", err)
+		}
+	}
+
+	for _, entry := range entries {
+		loc := Location{Segment: entry.Segment, Offset: entry.Offset}
+		l.byHash[entry.Hash] = loc
+		l.byEntryID[entry.EntryID] = loc
+	}
+	return nil
+}
+
+// Read reads the data of the record at loc
+func (l *SegmentLog) Read(loc Location) ([]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if loc.Segment > l.activeNum || (loc.Segment == l.activeNum && loc.Offset >= l.activeSize) {
+		return nil, ErrRecordNotFound
+	}
+
+	file, err := os.Open(segmentPath(l.dir, loc.Segment))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open segment %d: %w", loc.Segment, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(loc.Offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek segment %d: %w", loc.Segment, err)
+	}
+	_, _, data, _, err := readRecord(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record at %d:%d: %w", loc.Segment, loc.Offset, err)
+	}
+	return data, nil
+}
+
+// LookupHash returns the location of the record with the given hash
+func (l *SegmentLog) LookupHash(hash string) (Location, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	loc, ok := l.byHash[hash]
+	return loc, ok
+}
+
+// LookupEntry returns the location of the record with the given entry ID
+func (l *SegmentLog) LookupEntry(entryID string) (Location, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	loc, ok := l.byEntryID[entryID]
+	return loc, ok
+}
+
+// Scan calls fn for every record in append order
+func (l *SegmentLog) Scan(fn func(loc Location, data []byte) error) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return ScanSegments(l.dir, fn)
+}
+
+// Close syncs and closes the active segment and the index
+func (l *SegmentLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.active.Sync(); err != nil {
+		return fmt.Errorf("failed to sync segment %d: %w", l.activeNum, err)
+	}
+	if err := l.index.Sync(); err != nil {
+		return fmt.Errorf("failed to sync segment index: %w", err)
+	}
+	if err := l.active.Close(); err != nil {
+		return err
+	}
+	return l.index.Close()
+}
+
+// ScanSegments calls fn for every record of the segment log in dir in append
+// order, without opening the log for writing. A torn record at the end of the
+// last segment ends the scan.
+func ScanSegments(dir string, fn func(loc Location, data []byte) error) error {
+	segments, err := listSegments(dir)
+	if err != nil {
+		return err
+	}
+
+	for i, num := range segments {
+		_, err := scanSegment(segmentPath(dir, num), num, func(loc Location, hash, entryID string, data []byte) error {
+			return fn(loc, data)
+		})
+		if errors.Is(err, errTornRecord) && i == len(segments)-1 {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to scan segment %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
+// ListSegments returns the numbers of the segments of the segment log in dir
+// in ascending order. The last one is the active segment; all others are
+// sealed and no longer change.
+func ListSegments(dir string) ([]uint32, error) {
+	return listSegments(dir)
+}
+
+// SegmentPath returns the path of a segment of the segment log in dir
+func SegmentPath(dir string, num uint32) string {
+	return segmentPath(dir, num)
+}
+
+// ScanSegment calls fn for every record of one segment of the segment log in
+// dir in append order
+func ScanSegment(dir string, num uint32, fn func(loc Location, data []byte) error) error {
+	_, err := scanSegment(segmentPath(dir, num), num, func(loc Location, hash, entryID string, data []byte) error {
+		return fn(loc, data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan segment %d: %w", num, err)
+	}
+	return nil
+}
+
+// ScanSegmentFrom calls fn for every record of one segment of the segment log
+// in dir from offset on, in append order, and returns the offset after the
+// last complete record. A torn record ends the scan, as it may be an append
+// still in progress on the active segment.
+func ScanSegmentFrom(dir string, num uint32, offset int64, fn func(loc Location, data []byte) error) (int64, error) {
+	file, err := os.Open(segmentPath(dir, num))
+	if err != nil {
+		return offset, err
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, fmt.Errorf("failed to seek segment %d: %w", num, err)
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		_, _, data, size, err := readRecord(reader)
+		if err == io.EOF || errors.Is(err, errTornRecord) {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, fmt.Errorf("failed to scan segment %d: %w", num, err)
+		}
+		if err := fn(Location{Segment: num, Offset: offset}, data); err != nil {
+			return offset, err
+		}
+		offset += size
+	}
+}
+
+// scanSegment calls fn for every record of a segment file and returns the size
+// of its valid prefix. fn may be nil.
+func scanSegment(path string, num uint32, fn func(loc Location, hash, entryID string, data []byte) error) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		hash, entryID, data, size, err := readRecord(reader)
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, err
+		}
+		if fn != nil {
+			if err := fn(Location{Segment: num, Offset: offset}, hash, entryID, data); err != nil {
+				return offset, err
+			}
+		}
+		offset += size
+	}
+}
+
+// encodeRecord encodes a record as a checksummed frame
+func encodeRecord(record SegmentRecord) ([]byte, error) {
+	if len(record.Hash) > 0xFFFF || len(record.EntryID) > 0xFFFF {
+		return nil, fmt.Errorf("record keys too long")
+	}
+
+	bodySize := 4 + len(record.Hash) + len(record.EntryID) + len(record.Data)
+	if bodySize > maxRecordSize {
+		return nil, fmt.Errorf("record too large: %d bytes", bodySize)
+	}
+
+	frame := make([]byte, recordHeaderSize+bodySize)
+	body := frame[recordHeaderSize:]
+	binary.BigEndian.PutUint16(body[0:2], uint16(len(record.Hash)))
+	n := 2 + copy(body[2:], record.Hash)
+	binary.BigEndian.PutUint16(body[n:n+2], uint16(len(record.EntryID)))
+	n += 2 + copy(body[n+2:], record.EntryID)
+	copy(body[n:], record.Data)
+
+	binary.BigEndian.PutUint32(frame[0:4], uint32(bodySize))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(body))
+	return frame, nil
+}
+
+// readRecord reads one frame and returns its keys, data and total size. It
+// returns io.EOF at a clean end of segment and errTornRecord for an
+// incomplete or corrupt frame.
+func readRecord(reader io.Reader) (string, string, []byte, int64, error) {
+	header := make([]byte, recordHeaderSize)
+	if n, err := io.ReadFull(reader, header); err != nil {
+		if err == io.EOF && n == 0 {
+			return "", "", nil, 0, io.EOF
+		}
+		return "", "", nil, 0, errTornRecord
+	}
+
+	bodySize := binary.BigEndian.Uint32(header[0:4])
+	if bodySize < 4 || bodySize > maxRecordSize {
+		return "", "", nil, 0, errTornRecord
+	}
+	body := make([]byte, bodySize)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return "", "", nil, 0, errTornRecord
+	}
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(header[4:8]) {
+		return "", "", nil, 0, errTornRecord
+	}
+
+	hashLen := int(binary.BigEndian.Uint16(body[0:2]))
+	if 2+hashLen+2 > len(body) {
+		return "", "", nil, 0, errTornRecord
+	}
+	hash := string(body[2 : 2+hashLen])
+	n := 2 + hashLen
+	idLen := int(binary.BigEndian.Uint16(body[n : n+2]))
+	if n+2+idLen > len(body) {
+		return "", "", nil, 0, errTornRecord
+	}
+	entryID := string(body[n+2 : n+2+idLen])
+	data := body[n+2+idLen:]
+
+	return hash, entryID, data, int64(recordHeaderSize) + int64(bodySize), nil
+}
+
+// writeIndexFile atomically replaces the index file with entries
+func writeIndexFile(path string, entries []indexEntry) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode index entry: %w", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write segment index: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace segment index: %w", err)
+	}
+	return nil
+}
+
+// listSegments returns the segment numbers in dir in ascending order
+func listSegments(dir string) ([]uint32, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "segment_*.log"))
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []uint32
+	for _, path := range paths {
+		var num uint32
+		if _, err := fmt.Sscanf(filepath.Base(path), "segment_%08d.log", &num); err == nil {
+			segments = append(segments, num)
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+// segmentPath returns the path of a segment file
+func segmentPath(dir string, num uint32) string {
+	return filepath.Join(dir, fmt.Sprintf("segment_%08d.log", num))
+}
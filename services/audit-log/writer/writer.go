@@ -5,6 +5,7 @@ package writer
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
@@ -18,28 +19,29 @@ import (
 	"github.com/csic-platform/services/audit-log"
 )
 
+// genesisHash is the previous hash of the first entry
+const genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
 // AuditLogWriter handles immutable writing of audit log entries
 type AuditLogWriter struct {
 	storagePath    string
-	enableWORM     bool
-	currentFile    *os.File
-	currentFileNum uint32
-	fileMu         sync.Mutex
+	log            *SegmentLog
 	mu             sync.RWMutex
 	sequenceNum    uint64
+	lastHash       string
 	writtenEntries map[string]*writerEntry
 }
 
 // writerEntry represents an entry in the writer's memory
 type writerEntry struct {
 	entry     *audit.AuditLogEntry
-	fileNum   uint32
-	fileOffset int64
+	location  Location
 	writtenAt time.Time
 }
 
-// NewAuditLogWriter creates a new audit log writer
-func NewAuditLogWriter(storagePath string, enableWORM bool) *AuditLogWriter {
+// NewAuditLogWriter creates a new audit log writer storing entries in the
+// segment log below storagePath
+func NewAuditLogWriter(storagePath string, opts SegmentOptions) *AuditLogWriter {
 	// Ensure storage directory exists
 	if err := os.MkdirAll(storagePath, 0700); err != nil {
 		panic(fmt.Sprintf("failed to create storage directory: %v", err))
@@ -51,82 +53,145 @@ func NewAuditLogWriter(storagePath string, enableWORM bool) *AuditLogWriter {
 		panic(fmt.Sprintf("failed to create sequences directory: %v", err))
 	}
 
+	log, err := OpenSegmentLog(filepath.Join(storagePath, SegmentDirName), opts)
+	if err != nil {
+		panic(fmt.Sprintf("failed to open segment log: %v", err))
+	}
+
 	writer := &AuditLogWriter{
 		storagePath:    storagePath,
-		enableWORM:     enableWORM,
+		log:            log,
+		lastHash:       genesisHash,
 		writtenEntries: make(map[string]*writerEntry),
 	}
 
 	// Initialize sequence number from disk
 	writer.loadSequenceNumber()
 
+	// Load the stored entries
+	if err := writer.loadEntries(); err != nil {
+		panic(fmt.Sprintf("failed to load audit log entries: %v", err))
+	}
+
 	return writer
 }
 
+// loadEntries loads the entries of the segment log into memory and resumes
+// the hash chain and sequence from the last entry
+func (w *AuditLogWriter) loadEntries() error {
+	return w.log.Scan(func(loc Location, data []byte) error {
+		var entry audit.AuditLogEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to unmarshal entry at %d:%d: %w", loc.Segment, loc.Offset, err)
+		}
+
+		w.writtenEntries[entry.EntryID] = &writerEntry{
+			entry:     &entry,
+			location:  loc,
+			writtenAt: entry.Timestamp,
+		}
+		w.lastHash = entry.CurrentHash
+		if entry.SequenceNum > w.sequenceNum {
+			w.sequenceNum = entry.SequenceNum
+		}
+		return nil
+	})
+}
+
+// Close closes the segment log
+func (w *AuditLogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.log.Close()
+}
+
 // Write writes a new audit log entry
 func (w *AuditLogWriter) Write(ctx context.Context, entry *audit.AuditLogEntry) error {
+	return w.WriteBatch(ctx, []*audit.AuditLogEntry{entry})
+}
+
+// WriteBatch writes multiple audit log entries as one append to the segment
+// log, so a batch costs a single fsync when syncing per batch
+func (w *AuditLogWriter) WriteBatch(ctx context.Context, entries []*audit.AuditLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	// Generate entry ID if not provided
-	if entry.EntryID == "" {
-		entry.EntryID = generateEntryID()
-	}
+	sequenceNum := w.sequenceNum
+	prevHash := w.lastHash
+	now := time.Now().UTC()
 
-	// Assign sequence number
-	entry.SequenceNum = w.nextSequenceNumber()
-	entry.Timestamp = time.Now().UTC()
+	records := make([]SegmentRecord, 0, len(entries))
+	for _, entry := range entries {
+		// Generate entry ID if not provided
+		if entry.EntryID == "" {
+			entry.EntryID = generateEntryID()
+		}
 
-	// Generate chain ID (could be configurable per environment)
-	if entry.ChainID == "" {
-		entry.ChainID = "csic-main-chain"
-	}
+		// Assign sequence number
+		sequenceNum++
+		entry.SequenceNum = sequenceNum
+		entry.Timestamp = now
 
-	// Calculate and set the hash
-	w.mu.RLock()
-	prevHash := w.getLastHash()
-	w.mu.RUnlock()
+		// Generate chain ID (could be configurable per environment)
+		if entry.ChainID == "" {
+			entry.ChainID = "csic-main-chain"
+		}
 
-	entry.PreviousHash = prevHash
-	entry.CurrentHash = w.calculateHash(entry)
+		// Calculate and set the hash
+		entry.PreviousHash = prevHash
+		entry.CurrentHash = w.calculateHash(entry)
+		prevHash = entry.CurrentHash
 
-	// Write to storage
-	if err := w.writeToStorage(entry); err != nil {
-		return fmt.Errorf("failed to write entry to storage: %w", err)
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal entry: %w", err)
+		}
+		records = append(records, SegmentRecord{Hash: entry.CurrentHash, EntryID: entry.EntryID, Data: data})
 	}
 
-	// Track in memory
-	w.writtenEntries[entry.EntryID] = &writerEntry{
-		entry:     entry,
-		fileNum:   w.currentFileNum,
-		writtenAt: entry.Timestamp,
+	// Write to storage
+	locations, err := w.log.AppendBatch(records)
+	if err != nil {
+		return fmt.Errorf("failed to write entries to storage: %w", err)
 	}
 
-	return nil
-}
-
-// WriteBatch writes multiple audit log entries
-func (w *AuditLogWriter) WriteBatch(ctx context.Context, entries []*audit.AuditLogEntry) error {
-	for _, entry := range entries {
-		if err := w.Write(ctx, entry); err != nil {
-			return err
+	// Track in memory
+	for i, entry := range entries {
+		w.writtenEntries[entry.EntryID] = &writerEntry{
+			entry:     entry,
+			location:  locations[i],
+			writtenAt: entry.Timestamp,
 		}
 	}
+	w.sequenceNum = sequenceNum
+	w.lastHash = prevHash
+	w.saveSequenceNumber()
+
 	return nil
 }
 
 // Read reads a specific audit log entry
 func (w *AuditLogWriter) Read(ctx context.Context, entryID string) (*audit.AuditLogEntry, error) {
-	w.mu.RLock()
-	we, exists := w.writtenEntries[entryID]
-	w.mu.RUnlock()
-
+	loc, exists := w.log.LookupEntry(entryID)
 	if !exists {
 		return nil, fmt.Errorf("entry not found: %s", entryID)
 	}
 
-	// Read from file
-	return w.readFromStorage(we.fileNum, we.fileOffset)
+	return w.readFromStorage(loc)
+}
+
+// ReadByHash reads the audit log entry with the given hash
+func (w *AuditLogWriter) ReadByHash(ctx context.Context, hash string) (*audit.AuditLogEntry, error) {
+	loc, exists := w.log.LookupHash(hash)
+	if !exists {
+		return nil, fmt.Errorf("entry not found for hash: %s", hash)
+	}
+
+	return w.readFromStorage(loc)
 }
 
 // Query queries audit log entries with filters
@@ -158,6 +223,45 @@ func (w *AuditLogWriter) Query(ctx context.Context, query *audit.AuditQuery) ([]
 	return results, nil
 }
 
+// Stream calls fn for every entry matching the query in sequence order,
+// reading the segment log from disk so that large exports neither hold the
+// writer lock nor the matching entries in memory. Offset and Limit apply as
+// in Query.
+func (w *AuditLogWriter) Stream(ctx context.Context, query *audit.AuditQuery, fn func(entry *audit.AuditLogEntry) error) error {
+	skipped, streamed := 0, 0
+	errLimit := errors.New("limit reached")
+
+	err := ScanSegments(w.log.dir, func(loc Location, data []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var entry audit.AuditLogEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to unmarshal entry at %d:%d: %w", loc.Segment, loc.Offset, err)
+		}
+		if !w.matchesQuery(&entry, query) {
+			return nil
+		}
+		if skipped < query.Offset {
+			skipped++
+			return nil
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+		streamed++
+		if query.Limit > 0 && streamed >= query.Limit {
+			return errLimit
+		}
+		return nil
+	})
+	if err == errLimit {
+		return nil
+	}
+	return err
+}
+
 // GetSequenceNumber returns the current sequence number
 func (w *AuditLogWriter) GetSequenceNumber() uint64 {
 	w.mu.RLock()
@@ -174,26 +278,7 @@ func (w *AuditLogWriter) GetLastHash() string {
 
 // getLastHash returns the hash without locking
 func (w *AuditLogWriter) getLastHash() string {
-	if len(w.writtenEntries) == 0 {
-		return "0000000000000000000000000000000000000000000000000000000000000000"
-	}
-
-	// Find the entry with the highest sequence number
-	var lastEntry *audit.AuditLogEntry
-	for _, we := range w.writtenEntries {
-		if lastEntry == nil || we.entry.SequenceNum > lastEntry.SequenceNum {
-			lastEntry = we.entry
-		}
-	}
-
-	return lastEntry.CurrentHash
-}
-
-// nextSequenceNumber increments and returns the next sequence number
-func (w *AuditLogWriter) nextSequenceNumber() uint64 {
-	w.sequenceNum++
-	w.saveSequenceNumber()
-	return w.sequenceNum
+	return w.lastHash
 }
 
 // loadSequenceNumber loads the sequence number from disk
@@ -230,93 +315,11 @@ func (w *AuditLogWriter) saveSequenceNumber() {
 	}
 }
 
-// writeToStorage writes an entry to the storage file
-func (w *AuditLogWriter) writeToStorage(entry *audit.AuditLogEntry) error {
-	w.fileMu.Lock()
-	defer w.fileMu.Unlock()
-
-	// Rotate file if needed (every 10000 entries or 100MB)
-	if w.shouldRotateFile() {
-		if err := w.rotateFile(); err != nil {
-			return fmt.Errorf("failed to rotate file: %w", err)
-		}
-	}
-
-	// Open file for appending
-	filePath := w.getCurrentFilePath()
-	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
-
-	// Get offset before writing
-	offset, err := file.Seek(0, os.SEEK_CUR)
-	if err != nil {
-		return fmt.Errorf("failed to get file offset: %w", err)
-	}
-
-	// Marshal entry to JSON
-	data, err := json.Marshal(entry)
+// readFromStorage reads an entry from the segment log
+func (w *AuditLogWriter) readFromStorage(loc Location) (*audit.AuditLogEntry, error) {
+	data, err := w.log.Read(loc)
 	if err != nil {
-		return fmt.Errorf("failed to marshal entry: %w", err)
-	}
-
-	// Write length prefix for record boundaries
-	lengthBuf := make([]byte, 4)
-	binary.BigEndian.PutUint32(lengthBuf, uint32(len(data)))
-
-	if _, err := file.Write(lengthBuf); err != nil {
-		return fmt.Errorf("failed to write length: %w", err)
-	}
-
-	if _, err := file.Write(data); err != nil {
-		return fmt.Errorf("failed to write data: %w", err)
-	}
-
-	if err := file.Sync(); err != nil {
-		return fmt.Errorf("failed to sync file: %w", err)
-	}
-
-	if w.enableWORM {
-		// Make file read-only after write
-		if err := os.Chmod(filePath, 0400); err != nil {
-			return fmt.Errorf("failed to set WORM permissions: %w", err)
-		}
-	}
-
-	return nil
-}
-
-// readFromStorage reads an entry from the storage file
-func (w *AuditLogWriter) readFromStorage(fileNum uint32, offset int64) (*audit.AuditLogEntry, error) {
-	w.fileMu.Lock()
-	defer w.fileMu.Unlock()
-
-	filePath := w.getFilePath(fileNum)
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
-
-	// Seek to offset
-	if _, err := file.Seek(offset+4, os.SEEK_SET); err != nil {
-		return nil, fmt.Errorf("failed to seek: %w", err)
-	}
-
-	// Read length
-	lengthBuf := make([]byte, 4)
-	if _, err := file.Read(lengthBuf); err != nil {
-		return nil, fmt.Errorf("failed to read length: %w", err)
-	}
-
-	length := binary.BigEndian.Uint32(lengthBuf)
-
-	// Read data
-	data := make([]byte, length)
-	if _, err := file.Read(data); err != nil {
-		return nil, fmt.Errorf("failed to read data: %w", err)
+		return nil, err
 	}
 
 	var entry audit.AuditLogEntry
@@ -327,73 +330,37 @@ func (w *AuditLogWriter) readFromStorage(fileNum uint32, offset int64) (*audit.A
 	return &entry, nil
 }
 
-// shouldRotateFile determines if the current file should be rotated
-func (w *AuditLogWriter) shouldRotateFile() bool {
-	if w.currentFileNum == 0 {
-		return true
-	}
-
-	filePath := w.getCurrentFilePath()
-	info, err := os.Stat(filePath)
-	if err != nil {
-		return true
-	}
-
-	// Rotate if file is larger than 100MB
-	return info.Size() > 100*1024*1024
-}
-
-// rotateFile rotates to a new storage file
-func (w *AuditLogWriter) rotateFile() error {
-	if w.currentFile != nil {
-		if err := w.currentFile.Close(); err != nil {
-			return fmt.Errorf("failed to close current file: %w", err)
-		}
-	}
-
-	w.currentFileNum++
-	w.currentFile = nil
-
-	return nil
-}
-
-// getCurrentFilePath returns the path to the current storage file
-func (w *AuditLogWriter) getCurrentFilePath() string {
-	return w.getFilePath(w.currentFileNum)
-}
-
-// getFilePath returns the path to a specific storage file
-func (w *AuditLogWriter) getFilePath(fileNum uint32) string {
-	return filepath.Join(w.storagePath, fmt.Sprintf("audit_%08d.log", fileNum))
-}
-
 // calculateHash calculates the SHA-256 hash of an entry
 func (w *AuditLogWriter) calculateHash(entry *audit.AuditLogEntry) string {
 	// Create canonical representation for hashing
 	canonical := struct {
-		EntryID     string
-		SequenceNum uint64
-		Timestamp   time.Time
-		ChainID     string
-		ActorID     string
-		Service     string
-		Operation   string
-		ActionType  string
-		Resource    string
-		Result      string
+		EntryID      string
+		SequenceNum  uint64
+		Timestamp    time.Time
+		ChainID      string
+		ActorID      string
+		Service      string
+		Operation    string
+		ActionType   string
+		Resource     string
+		Result       string
 		PreviousHash string
+		// Omitted when empty so entries written before correlation IDs
+		// existed keep their hash
+		CorrelationID string `json:",omitempty"`
 	}{
-		EntryID:      entry.EntryID,
-		SequenceNum:  entry.SequenceNum,
-		Timestamp:    entry.Timestamp,
-		ChainID:      entry.ChainID,
-		ActorID:      entry.ActorID,
-		Service:      entry.Service,
-		Operation:    entry.Operation,
-		ActionType:   entry.ActionType,
-		Resource:     entry.Resource,
-		Result:       entry.Result,
-		PreviousHash: entry.PreviousHash,
+		EntryID:       entry.EntryID,
+		SequenceNum:   entry.SequenceNum,
+		Timestamp:     entry.Timestamp,
+		ChainID:       entry.ChainID,
+		ActorID:       entry.ActorID,
+		Service:       entry.Service,
+		Operation:     entry.Operation,
+		ActionType:    entry.ActionType,
+		Resource:      entry.Resource,
+		Result:        entry.Result,
+		PreviousHash:  entry.PreviousHash,
+		CorrelationID: entry.CorrelationID,
 	}
 
 	data, err := json.Marshal(canonical)
@@ -412,7 +379,7 @@ func (w *AuditLogWriter) matchesQuery(entry *audit.AuditLogEntry, query *audit.A
 		query.Operation == "" && query.ActionType == "" &&
 		query.Resource == "" && query.Result == "" &&
 		query.RiskLevel == "" && query.SequenceFrom == 0 &&
-		query.SequenceTo == 0 {
+		query.SequenceTo == 0 && query.CorrelationID == "" {
 		return true
 	}
 
@@ -454,6 +421,9 @@ func (w *AuditLogWriter) matchesQuery(entry *audit.AuditLogEntry, query *audit.A
 	if query.RiskLevel != "" && entry.RiskLevel != query.RiskLevel {
 		return false
 	}
+	if query.CorrelationID != "" && entry.CorrelationID != query.CorrelationID {
+		return false
+	}
 
 	return true
 }
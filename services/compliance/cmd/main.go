@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
@@ -9,8 +10,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/csic-platform/services/compliance/internal/adapters/audit"
 	"github.com/csic-platform/services/compliance/internal/adapters/handler/http"
+	"github.com/csic-platform/services/compliance/internal/adapters/ocr"
 	"github.com/csic-platform/services/compliance/internal/adapters/repository/postgres"
+	"github.com/csic-platform/services/compliance/internal/adapters/vault"
 	"github.com/csic-platform/services/compliance/internal/core/ports"
 	"github.com/csic-platform/services/compliance/internal/core/services"
 	"github.com/spf13/viper"
@@ -48,11 +52,50 @@ func main() {
 	obligationService := services.NewObligationService(repo, logger)
 	auditService := services.NewAuditService(repo, logger)
 
+	// Initialize KYC document vault
+	vaultKey, err := base64.StdEncoding.DecodeString(viper.GetString("documents.encryption_key"))
+	if err != nil {
+		logger.Fatal("Invalid document encryption key", zap.Error(err))
+	}
+	documentStore, err := vault.NewEncryptedFileStore(viper.GetString("documents.storage_path"), vaultKey)
+	if err != nil {
+		logger.Fatal("Failed to initialize document vault", zap.Error(err))
+	}
+	documentService := services.NewDocumentService(repo, repo, documentStore,
+		ocr.NewExtractor(viper.GetString("documents.ocr_url"), viper.GetDuration("documents.ocr_timeout")),
+		services.DocumentServiceConfig{
+			MaxSizeBytes:          viper.GetInt64("documents.max_size_bytes"),
+			MaxExtractionAttempts: viper.GetInt("documents.extraction_attempts"),
+			ExtractionBatchSize:   viper.GetInt("documents.extraction_batch_size"),
+			ExpiryWarningDays:     viper.GetInt("documents.expiry_warning_days"),
+			ExtractionTimeout:     viper.GetDuration("documents.ocr_timeout"),
+		}, logger)
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go documentService.RunJobs(jobCtx, viper.GetDuration("documents.job_interval"))
+
+	// Initialize audit recorder
+	var auditRecorder *services.AuditRecorder
+	if viper.GetBool("audit.enabled") {
+		var archive ports.AuditArchive
+		if wormURL := viper.GetString("audit.worm_url"); wormURL != "" {
+			archive = audit.NewWORMArchive(wormURL, viper.GetDuration("audit.write_timeout"))
+		}
+		auditRecorder = services.NewAuditRecorder(repo, archive, services.AuditRecorderConfig{
+			QueueSize:    viper.GetInt("audit.queue_size"),
+			Workers:      viper.GetInt("audit.workers"),
+			WriteTimeout: viper.GetDuration("audit.write_timeout"),
+		}, logger)
+		auditRecorder.Start()
+		defer auditRecorder.Stop()
+	}
+
 	// Initialize handlers
-	handlers := http.NewHandlers(licenseService, complianceService, obligationService, auditService, logger)
+	handlers := http.NewHandlers(licenseService, complianceService, obligationService, auditService, documentService, logger)
 
 	// Initialize router
-	router := http.NewRouter(handlers, logger)
+	router := http.NewRouter(handlers, auditRecorder, logger)
 
 	// Start server
 	srv := &http.Server{
@@ -103,6 +146,18 @@ func initConfig() error {
 	viper.SetDefault("database.host", "postgres")
 	viper.SetDefault("database.port", 5432)
 	viper.SetDefault("scoring.base_score", 100.0)
+	viper.SetDefault("audit.enabled", true)
+	viper.SetDefault("audit.queue_size", 1000)
+	viper.SetDefault("audit.workers", 2)
+	viper.SetDefault("audit.write_timeout", 5*time.Second)
+	viper.SetDefault("documents.storage_path", "/var/lib/compliance-api/documents")
+	viper.SetDefault("documents.max_size_bytes", 20<<20)
+	viper.SetDefault("documents.ocr_timeout", time.Minute)
+	viper.SetDefault("documents.extraction_attempts", 3)
+	viper.SetDefault("documents.extraction_batch_size", 20)
+	viper.SetDefault("documents.expiry_warning_days", 30)
+	viper.SetDefault("documents.job_interval", time.Hour)
+	viper.BindEnv("documents.encryption_key", "DOCUMENT_ENCRYPTION_KEY")
 
 	// Environment variable overrides
 	viper.AutomaticEnv()
@@ -124,3 +179,4 @@ var _ ports.LicenseRepository = (*postgres.Repository)(nil)
 var _ ports.ComplianceRepository = (*postgres.Repository)(nil)
 var _ ports.ObligationRepository = (*postgres.Repository)(nil)
 var _ ports.AuditRepository = (*postgres.Repository)(nil)
+var _ ports.DocumentRepository = (*postgres.Repository)(nil)
@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/domain"
+	"github.com/csic-platform/services/services/compliance/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// WORMArchive appends audit records to the audit log service's WORM chain
+type WORMArchive struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewWORMArchive creates a WORM archive for the audit log service at baseURL
+func NewWORMArchive(baseURL string, timeout time.Duration) *WORMArchive {
+	return &WORMArchive{
+		endpoint: strings.TrimRight(baseURL, "/") + "/api/v1/audit/entries",
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// entry is the subset of the audit log service's entry format written for
+// compliance audit records
+type entry struct {
+	ActorID        string                 `json:"actor_id"`
+	ActorType      string                 `json:"actor_type"`
+	IPAddress      string                 `json:"ip_address,omitempty"`
+	UserAgent      string                 `json:"user_agent,omitempty"`
+	Service        string                 `json:"service"`
+	Operation      string                 `json:"operation"`
+	ActionType     string                 `json:"action_type"`
+	Resource       string                 `json:"resource"`
+	ResourceID     string                 `json:"resource_id,omitempty"`
+	Description    string                 `json:"description"`
+	Result         string                 `json:"result"`
+	ComplianceTags []string               `json:"compliance_tags"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	CorrelationID  string                 `json:"correlation_id,omitempty"`
+}
+
+// Append appends an audit record to the audit chain
+func (a *WORMArchive) Append(ctx context.Context, record *domain.AuditRecord) error {
+	metadata := map[string]interface{}{}
+	if record.Metadata != "" {
+		if err := json.Unmarshal([]byte(record.Metadata), &metadata); err != nil {
+			metadata = map[string]interface{}{"raw": record.Metadata}
+		}
+	}
+	metadata["record_id"] = record.ID.String()
+	metadata["timestamp"] = record.Timestamp.Format(time.RFC3339Nano)
+	if record.EntityID != uuid.Nil {
+		metadata["entity_id"] = record.EntityID.String()
+	}
+
+	resourceID := ""
+	if record.ResourceID != uuid.Nil {
+		resourceID = record.ResourceID.String()
+	}
+
+	body, err := json.Marshal(entry{
+		ActorID:        record.ActorID.String(),
+		ActorType:      record.ActorType,
+		IPAddress:      record.IPAddress,
+		UserAgent:      record.UserAgent,
+		Service:        "compliance",
+		Operation:      record.ActionType,
+		ActionType:     actionType(metadata["method"]),
+		Resource:       record.ResourceType,
+		ResourceID:     resourceID,
+		Description:    fmt.Sprintf("%s on %s", record.ActionType, record.ResourceType),
+		Result:         result(metadata["status_code"]),
+		ComplianceTags: []string{"COMPLIANCE"},
+		Metadata:       metadata,
+		CorrelationID:  record.CorrelationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if record.CorrelationID != "" {
+		req.Header.Set("X-Correlation-ID", record.CorrelationID)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to archive audit record: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("audit log rejected audit record %s: status %d", record.ID, resp.StatusCode)
+	}
+	return nil
+}
+
+// actionType maps an HTTP method to the audit log service's action types
+func actionType(method interface{}) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return "read"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "execute"
+	}
+}
+
+// result maps an HTTP status code to the audit log service's results
+func result(statusCode interface{}) string {
+	code, ok := statusCode.(float64)
+	if !ok {
+		return "success"
+	}
+	if code >= 400 {
+		return "failure"
+	}
+	return "success"
+}
+
+// Ensure WORMArchive implements the AuditArchive interface
+var _ ports.AuditArchive = (*WORMArchive)(nil)
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 	"time"
@@ -18,6 +20,7 @@ type Handlers struct {
 	complianceService  ports.ComplianceService
 	obligationService  ports.ObligationService
 	auditService       ports.AuditService
+	documentService    ports.DocumentService
 	log                *zap.Logger
 }
 
@@ -27,6 +30,7 @@ func NewHandlers(
 	complianceService ports.ComplianceService,
 	obligationService ports.ObligationService,
 	auditService ports.AuditService,
+	documentService ports.DocumentService,
 	log *zap.Logger,
 ) *Handlers {
 	return &Handlers{
@@ -34,6 +38,7 @@ func NewHandlers(
 		complianceService: complianceService,
 		obligationService: obligationService,
 		auditService:      auditService,
+		documentService:   documentService,
 		log:               log,
 	}
 }
@@ -608,6 +613,239 @@ func (h *Handlers) GetEntityAuditTrail(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"entity_id": id, "audit_trail": trail})
 }
 
+// ===== KYC Document Handlers =====
+
+// UploadDocument handles POST /api/v1/documents as a multipart form with the
+// file in "file" and entity_id, license_id, application_id and document_type
+// fields
+func (h *Handlers) UploadDocument(c *gin.Context) {
+	entityID, err := uuid.Parse(c.PostForm("entity_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+		return
+	}
+
+	req := ports.UploadDocumentRequest{
+		EntityID:     entityID,
+		DeclaredType: domain.DocumentType(c.PostForm("document_type")),
+		UploadedBy:   c.GetHeader("X-User-ID"),
+	}
+	if req.LicenseID, err = parseOptionalUUID(c.PostForm("license_id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid license ID"})
+		return
+	}
+	if req.ApplicationID, err = parseOptionalUUID(c.PostForm("application_id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	req.Data, err = io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	req.FileName = fileHeader.Filename
+	req.ContentType = fileHeader.Header.Get("Content-Type")
+	if _, _, err := mime.ParseMediaType(req.ContentType); err != nil || req.ContentType == "application/octet-stream" {
+		req.ContentType = http.DetectContentType(req.Data)
+	}
+
+	doc, err := h.documentService.UploadDocument(c.Request.Context(), req)
+	if err != nil {
+		h.log.Error("Failed to upload document", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to upload document", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Document stored", "document": doc})
+}
+
+// GetDocument handles GET /api/v1/documents/:id
+func (h *Handlers) GetDocument(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	doc, err := h.documentService.GetDocument(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to get document", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get document"})
+		return
+	}
+
+	if doc == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+// GetDocumentContent handles GET /api/v1/documents/:id/content
+func (h *Handlers) GetDocumentContent(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	doc, data, err := h.documentService.GetDocumentContent(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to get document content", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get document content"})
+		return
+	}
+
+	if doc == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, doc.ContentType, data)
+}
+
+// LinkDocument handles PATCH /api/v1/documents/:id/links
+func (h *Handlers) LinkDocument(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	var req ports.LinkDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.LicenseID == nil && req.ApplicationID == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "license_id or application_id required"})
+		return
+	}
+
+	doc, err := h.documentService.LinkDocument(c.Request.Context(), id, req)
+	if err != nil {
+		h.log.Error("Failed to link document", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to link document", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Document linked", "document": doc})
+}
+
+// ExtractDocument handles POST /api/v1/documents/:id/extract
+func (h *Handlers) ExtractDocument(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	doc, err := h.documentService.ExtractMetadata(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to extract document metadata", zap.Error(err))
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Failed to extract document metadata", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Metadata extracted", "document": doc})
+}
+
+// GetExpiringDocuments handles GET /api/v1/documents/expiring
+func (h *Handlers) GetExpiringDocuments(c *gin.Context) {
+	days := 30
+	if d := c.Query("days"); d != "" {
+		if parsed, err := strconv.Atoi(d); err == nil && parsed > 0 {
+			days = parsed
+		}
+	}
+
+	alerts, err := h.documentService.GetExpiringDocuments(c.Request.Context(), days)
+	if err != nil {
+		h.log.Error("Failed to get expiring documents", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get expiring documents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"days": days, "documents": alerts})
+}
+
+// CheckExpiringDocuments handles POST /api/v1/documents/check-expiring
+func (h *Handlers) CheckExpiringDocuments(c *gin.Context) {
+	alerts, err := h.documentService.CheckExpiringDocuments(c.Request.Context())
+	if err != nil {
+		h.log.Error("Failed to check expiring documents", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check expiring documents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Expiry check completed", "alerts": alerts})
+}
+
+// GetEntityDocuments handles GET /api/v1/entities/:id/documents
+func (h *Handlers) GetEntityDocuments(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+		return
+	}
+
+	docs, err := h.documentService.GetEntityDocuments(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to get entity documents", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get documents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entity_id": id, "documents": docs})
+}
+
+// GetLicenseDocuments handles GET /api/v1/licenses/:id/documents
+func (h *Handlers) GetLicenseDocuments(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid license ID"})
+		return
+	}
+
+	docs, err := h.documentService.GetLicenseDocuments(c.Request.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to get license documents", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get documents"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"license_id": id, "documents": docs})
+}
+
+func parseOptionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
 // ===== Health Check =====
 
 func (h *Handlers) HealthCheck(c *gin.Context) {
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/domain"
+	"github.com/csic-platform/services/services/compliance/internal/core/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxAuditBodySize bounds how much of a request body is captured for auditing
+const maxAuditBodySize = 64 << 10
+
+const redactedValue = "[REDACTED]"
+
+// sensitiveFields are request body fields whose values are redacted. A field
+// is sensitive if its lowercased name contains any of these.
+var sensitiveFields = []string{
+	"password", "secret", "token", "api_key", "apikey", "authorization",
+	"private_key", "credential", "ssn", "national_id", "card_number",
+}
+
+// Audit returns a middleware that records an audit entry for every routed
+// request: the actor, the action derived from the route, the resource type and
+// ID, the redacted request body and the response code. Entries are handed to
+// the recorder, which writes them asynchronously.
+func Audit(recorder *services.AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body := captureBody(c)
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		resourceType, action := auditAction(c.Request.Method, route)
+
+		resourceID, _ := uuid.Parse(c.Param("id"))
+		entityID := uuid.Nil
+		if resourceType == "entities" {
+			entityID = resourceID
+		}
+
+		actor := auditActor(c)
+		actorID, err := uuid.Parse(actor)
+		actorType := "user"
+		if actor == "" {
+			actorType = "anonymous"
+		}
+
+		metadata := map[string]interface{}{
+			"method":      c.Request.Method,
+			"path":        c.Request.URL.Path,
+			"route":       route,
+			"status_code": c.Writer.Status(),
+			"request_id":  c.GetString("RequestID"),
+		}
+		if actor != "" && err != nil {
+			metadata["actor"] = actor
+		}
+		if body != nil {
+			metadata["request_body"] = body
+		}
+		metadataJSON, _ := json.Marshal(metadata)
+
+		recorder.Record(&domain.AuditRecord{
+			EntityID:      entityID,
+			ActionType:    action,
+			ActorID:       actorID,
+			ActorType:     actorType,
+			ResourceID:    resourceID,
+			ResourceType:  resourceType,
+			Metadata:      string(metadataJSON),
+			IPAddress:     c.ClientIP(),
+			UserAgent:     c.Request.UserAgent(),
+			CorrelationID: c.GetString("CorrelationID"),
+		})
+	}
+}
+
+// auditActor returns the authenticated user, falling back to the user header
+// set by the API gateway
+func auditActor(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	return c.GetHeader("X-User-ID")
+}
+
+// auditAction derives the resource type and action from a route. The resource
+// type is the first path segment after the API version; the action is the last
+// literal segment after it or, failing that, the verb implied by the method.
+// For example POST /api/v1/licenses/:id/suspend yields licenses and
+// licenses.suspend, and GET /api/v1/entities/:id yields entities and
+// entities.read.
+func auditAction(method, route string) (string, string) {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(route, "/"), "/") {
+		if segment == "" || segment == "api" || segment == "v1" {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return "", ""
+	}
+
+	resourceType := segments[0]
+	for i := len(segments) - 1; i > 0; i-- {
+		if !strings.HasPrefix(segments[i], ":") && !strings.HasPrefix(segments[i], "*") {
+			return resourceType, resourceType + "." + segments[i]
+		}
+	}
+
+	switch method {
+	case "POST":
+		return resourceType, resourceType + ".create"
+	case "PUT", "PATCH":
+		return resourceType, resourceType + ".update"
+	case "DELETE":
+		return resourceType, resourceType + ".delete"
+	default:
+		return resourceType, resourceType + ".read"
+	}
+}
+
+// captureBody reads the request body for auditing and restores it for the
+// handler. JSON bodies are returned with sensitive fields redacted; other
+// bodies are not captured.
+func captureBody(c *gin.Context) interface{} {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return nil
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	c.Request.Body.Close()
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	if len(data) > maxAuditBodySize {
+		return "[body too large]"
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return "[non-JSON body]"
+	}
+	return redact(body)
+}
+
+// redact replaces the values of sensitive fields in a decoded JSON value
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redact(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isSensitiveField reports whether a field name denotes sensitive data
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range sensitiveFields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"time"
 
+	"github.com/csic-platform/services/services/compliance/internal/core/services"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -49,6 +50,23 @@ func RequestID() gin.HandlerFunc {
 	}
 }
 
+// Correlation returns a middleware that adopts the correlation ID set by the
+// API gateway, falling back to the request ID, and stores it in the request
+// context so audit records and compliance results carry it. It must run after
+// RequestID.
+func Correlation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader("X-Correlation-ID")
+		if correlationID == "" || len(correlationID) > 128 {
+			correlationID = c.GetString("RequestID")
+		}
+		c.Set("CorrelationID", correlationID)
+		c.Header("X-Correlation-ID", correlationID)
+		c.Request = c.Request.WithContext(services.WithCorrelationID(c.Request.Context(), correlationID))
+		c.Next()
+	}
+}
+
 // generateRequestID generates a simple unique request ID
 func generateRequestID() string {
 	return time.Now().Format("20060102150405") + "-" + randomString(8)
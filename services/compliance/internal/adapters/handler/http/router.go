@@ -3,24 +3,27 @@ package http
 import (
 	"time"
 
+	"github.com/csic-platform/services/services/compliance/internal/core/services"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-// NewRouter creates a new Gin router with all routes configured
-func NewRouter(handlers *Handlers, log *zap.Logger) *gin.Engine {
+// NewRouter creates a new Gin router with all routes configured. API requests
+// are audited when auditRecorder is not nil.
+func NewRouter(handlers *Handlers, auditRecorder *services.AuditRecorder, log *zap.Logger) *gin.Engine {
 	router := gin.New()
 
 	// Add middleware
 	router.Use(gin.Recovery())
 	router.Use(Logger(log))
 	router.Use(RequestID())
+	router.Use(Correlation())
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-Request-ID"},
-		ExposeHeaders:    []string{"Content-Length", "X-Request-ID"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-Request-ID", "X-Correlation-ID"},
+		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "X-Correlation-ID"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -30,6 +33,9 @@ func NewRouter(handlers *Handlers, log *zap.Logger) *gin.Engine {
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	if auditRecorder != nil {
+		v1.Use(Audit(auditRecorder))
+	}
 	{
 		// License Application routes
 		applications := v1.Group("/licenses/applications")
@@ -48,6 +54,7 @@ func NewRouter(handlers *Handlers, log *zap.Logger) *gin.Engine {
 			licenses.POST("/:id/suspend", handlers.SuspendLicense)
 			licenses.POST("/:id/revoke", handlers.RevokeLicense)
 			licenses.GET("/expiring", handlers.GetExpiringLicenses)
+			licenses.GET("/:id/documents", handlers.GetLicenseDocuments)
 		}
 
 		// Entity routes
@@ -61,6 +68,7 @@ func NewRouter(handlers *Handlers, log *zap.Logger) *gin.Engine {
 			entities.POST("/:id/compliance/score/recalculate", handlers.RecalculateScore)
 			entities.GET("/:id/compliance/score/history", handlers.GetScoreHistory)
 			entities.GET("/:id/audit-trail", handlers.GetEntityAuditTrail)
+			entities.GET("/:id/documents", handlers.GetEntityDocuments)
 		}
 
 		// Compliance routes
@@ -79,6 +87,18 @@ func NewRouter(handlers *Handlers, log *zap.Logger) *gin.Engine {
 			obligations.POST("/check-overdue", handlers.CheckOverdueObligations)
 		}
 
+		// KYC document vault routes
+		documents := v1.Group("/documents")
+		{
+			documents.POST("", handlers.UploadDocument)
+			documents.GET("/expiring", handlers.GetExpiringDocuments)
+			documents.POST("/check-expiring", handlers.CheckExpiringDocuments)
+			documents.GET("/:id", handlers.GetDocument)
+			documents.GET("/:id/content", handlers.GetDocumentContent)
+			documents.PATCH("/:id/links", handlers.LinkDocument)
+			documents.POST("/:id/extract", handlers.ExtractDocument)
+		}
+
 		// Audit routes
 		audit := v1.Group("/audit-logs")
 		{
@@ -0,0 +1,84 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/ports"
+)
+
+// maxResponseBytes bounds the text read back from the OCR service
+const maxResponseBytes = 4 << 20
+
+// Extractor extracts the text of KYC documents. Plain text documents are read
+// directly; images and PDFs are sent to an OCR service that accepts the raw
+// file and responds with {"text": "..."}.
+type Extractor struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewExtractor creates an extractor for the OCR service at url. An empty url
+// limits extraction to plain text documents.
+func NewExtractor(url string, timeout time.Duration) *Extractor {
+	return &Extractor{
+		endpoint: strings.TrimRight(url, "/"),
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// ExtractText returns the text of a document
+func (e *Extractor) ExtractText(ctx context.Context, contentType string, data []byte) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = http.DetectContentType(data)
+		mediaType, _, _ = mime.ParseMediaType(mediaType)
+	}
+
+	if strings.HasPrefix(mediaType, "text/") {
+		if !utf8.Valid(data) {
+			return "", fmt.Errorf("text document is not valid UTF-8")
+		}
+		return string(data), nil
+	}
+
+	if e.endpoint == "" {
+		return "", fmt.Errorf("no OCR service configured for %s documents", mediaType)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mediaType)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call OCR service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("OCR service returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode OCR response: %w", err)
+	}
+	return result.Text, nil
+}
+
+// Ensure Extractor implements the TextExtractor interface
+var _ ports.TextExtractor = (*Extractor)(nil)
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// The tests and benchmarks below run against the database at TEST_DATABASE_URL
+// and are skipped when it is unset. The database must have the service's
+// migrations applied. Plan tests pass on an empty database; benchmarks need
+// audit records to read.
+
+func testConnection(tb testing.TB) *connection {
+	tb.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		tb.Skip("TEST_DATABASE_URL not set")
+	}
+
+	pool, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		tb.Fatalf("failed to connect: %v", err)
+	}
+	tb.Cleanup(pool.Close)
+	return &connection{pool: pool, log: zap.NewNop()}
+}
+
+// planNode is a node of a plan in EXPLAIN (FORMAT JSON) output
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	IndexName    string     `json:"Index Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// assertPlan explains query with sequential scans disabled and checks that
+// table is only read through index. With sequential scans disabled the planner
+// only picks one when no index can serve the query, so a missing or unusable
+// index shows as a sequential scan however little data the table holds.
+func assertPlan(t *testing.T, conn *connection, query string, args []interface{}, table, index string) {
+	t.Helper()
+	ctx := context.Background()
+
+	tx, err := conn.pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+		t.Fatalf("failed to disable sequential scans: %v", err)
+	}
+
+	var output []byte
+	if err := tx.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&output); err != nil {
+		t.Fatalf("failed to explain query: %v", err)
+	}
+	var explained []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(output, &explained); err != nil || len(explained) == 0 {
+		t.Fatalf("failed to parse plan: %v", err)
+	}
+
+	usesIndex := false
+	var walk func(planNode)
+	walk = func(n planNode) {
+		if n.RelationName == table && n.NodeType == "Seq Scan" {
+			t.Errorf("plan scans %s sequentially:\n%s", table, output)
+		}
+		if n.IndexName == index {
+			usesIndex = true
+		}
+		for _, child := range n.Plans {
+			walk(child)
+		}
+	}
+	walk(explained[0].Plan)
+
+	if !usesIndex {
+		t.Errorf("plan does not use %s:\n%s", index, output)
+	}
+}
+
+func TestAuditRecordPlans(t *testing.T) {
+	conn := testConnection(t)
+
+	t.Run("by resource", func(t *testing.T) {
+		assertPlan(t, conn, auditRecordsByResourceQuery, []interface{}{"license", uuid.New()},
+			"compliance_audit_records", "idx_audit_resource_timestamp")
+	})
+	t.Run("by entity", func(t *testing.T) {
+		assertPlan(t, conn, auditRecordsByEntityQuery, []interface{}{uuid.New(), 50, 0},
+			"compliance_audit_records", "idx_audit_entity_timestamp")
+	})
+}
+
+func BenchmarkGetAuditRecordsByResource(b *testing.B) {
+	conn := testConnection(b)
+	repo := NewRepository(conn, zap.NewNop())
+	ctx := context.Background()
+
+	// The resource with the longest audit trail
+	var resourceType string
+	var resourceID uuid.UUID
+	err := conn.pool.QueryRow(ctx, `
+		SELECT resource_type, resource_id FROM compliance_audit_records
+		GROUP BY resource_type, resource_id
+		ORDER BY COUNT(*) DESC
+		LIMIT 1
+	`).Scan(&resourceType, &resourceID)
+	if err != nil {
+		b.Skipf("no audit records to read: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetAuditRecordsByResource(ctx, resourceType, resourceID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetAuditRecordsByEntity(b *testing.B) {
+	conn := testConnection(b)
+	repo := NewRepository(conn, zap.NewNop())
+	ctx := context.Background()
+
+	// The entity with the longest audit trail
+	var entityID uuid.UUID
+	err := conn.pool.QueryRow(ctx, `
+		SELECT entity_id FROM compliance_audit_records
+		GROUP BY entity_id
+		ORDER BY COUNT(*) DESC
+		LIMIT 1
+	`).Scan(&entityID)
+	if err != nil {
+		b.Skipf("no audit records to read: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetAuditRecordsByEntity(ctx, entityID, 50, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
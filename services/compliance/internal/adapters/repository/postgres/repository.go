@@ -2,12 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/csic-platform/services/services/compliance/internal/core/domain"
 	"github.com/csic-platform/services/services/compliance/internal/core/ports"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -82,13 +84,18 @@ func scanRegulation(row RowScanner) (*domain.Regulation, error) {
 
 func scanComplianceScore(row RowScanner) (*domain.ComplianceScore, error) {
 	s := &domain.ComplianceScore{}
+	var correlationID *string
 	err := row.Scan(
 		&s.ID, &s.EntityID, &s.TotalScore, &s.Tier, &s.Breakdown,
 		&s.PeriodStart, &s.PeriodEnd, &s.CalculatedAt, &s.CalculationDetails,
+		&correlationID,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if correlationID != nil {
+		s.CorrelationID = *correlationID
+	}
 	return s, nil
 }
 
@@ -108,18 +115,48 @@ func scanObligation(row RowScanner) (*domain.Obligation, error) {
 
 func scanAuditRecord(row RowScanner) (*domain.AuditRecord, error) {
 	a := &domain.AuditRecord{}
+	var correlationID *string
 	err := row.Scan(
 		&a.ID, &a.EntityID, &a.ActionType, &a.ActorID, &a.ActorType,
 		&a.ResourceID, &a.ResourceType, &a.Timestamp,
 		&a.OldValue, &a.NewValue, &a.Changes, &a.Metadata,
-		&a.IPAddress, &a.UserAgent,
+		&a.IPAddress, &a.UserAgent, &correlationID,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if correlationID != nil {
+		a.CorrelationID = *correlationID
+	}
 	return a, nil
 }
 
+// documentColumns lists the columns read by scanDocument, in order
+const documentColumns = `
+	id, entity_id, license_id, application_id, file_name, content_type, size_bytes,
+	sha256, storage_key, declared_type, document_type, extracted_entity_name,
+	document_number, expiry_date, extraction_status, extraction_error,
+	extraction_attempts, extracted_at, name_mismatch, expiry_alerted_at,
+	uploaded_by, created_at, updated_at
+`
+
+func scanDocument(row RowScanner) (*domain.KYCDocument, error) {
+	d := &domain.KYCDocument{}
+	err := row.Scan(
+		&d.ID, &d.EntityID, &d.LicenseID, &d.ApplicationID, &d.FileName,
+		&d.ContentType, &d.SizeBytes, &d.SHA256, &d.StorageKey,
+		&d.DeclaredType, &d.DocumentType, &d.ExtractedEntityName,
+		&d.DocumentNumber, &d.ExpiryDate, &d.ExtractionStatus,
+		&d.ExtractionError, &d.ExtractionAttempts, &d.ExtractedAt,
+		&d.NameMismatch, &d.ExpiryAlertedAt, &d.UploadedBy,
+		&d.CreatedAt, &d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
 // License Repository Methods
 
 func (r *Repository) CreateLicense(ctx context.Context, license *domain.License) error {
@@ -200,10 +237,10 @@ func (r *Repository) UpdateLicenseStatus(ctx context.Context, id uuid.UUID, stat
 func (r *Repository) GetLicensesExpiringSoon(ctx context.Context, days int) ([]domain.License, error) {
 	query := `
 		SELECT * FROM compliance_licenses
-		WHERE status = 'ACTIVE' AND expiry_date <= NOW() + INTERVAL '%d days'
+		WHERE status = 'ACTIVE' AND expiry_date <= NOW() + make_interval(days => $1)
 		ORDER BY expiry_date ASC
 	`
-	rows, err := r.conn.Query(ctx, fmt.Sprintf(query, days))
+	rows, err := r.conn.Query(ctx, query, days)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query expiring licenses: %w", err)
 	}
@@ -447,12 +484,13 @@ func (r *Repository) CreateScore(ctx context.Context, score *domain.ComplianceSc
 	query := `
 		INSERT INTO compliance_scores (
 			id, entity_id, total_score, tier, breakdown, period_start, period_end,
-			calculated_at, calculation_details
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			calculated_at, calculation_details, correlation_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 	_, err := r.conn.Exec(ctx, query,
 		score.ID, score.EntityID, score.TotalScore, score.Tier, score.Breakdown,
 		score.PeriodStart, score.PeriodEnd, score.CalculatedAt, score.CalculationDetails,
+		score.CorrelationID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create score: %w", err)
@@ -660,10 +698,10 @@ func (r *Repository) MarkObligationFulfilled(ctx context.Context, id uuid.UUID,
 func (r *Repository) GetUpcomingObligations(ctx context.Context, days int) ([]domain.Obligation, error) {
 	query := `
 		SELECT * FROM compliance_obligations
-		WHERE status = 'PENDING' AND due_date <= NOW() + INTERVAL '%d days'
+		WHERE status = 'PENDING' AND due_date <= NOW() + make_interval(days => $1)
 		ORDER BY due_date ASC
 	`
-	rows, err := r.conn.Query(ctx, fmt.Sprintf(query, days))
+	rows, err := r.conn.Query(ctx, query, days)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query upcoming obligations: %w", err)
 	}
@@ -686,13 +724,15 @@ func (r *Repository) CreateAuditRecord(ctx context.Context, record *domain.Audit
 	query := `
 		INSERT INTO compliance_audit_records (
 			id, entity_id, action_type, actor_id, actor_type, resource_id, resource_type,
-			timestamp, old_value, new_value, changes, metadata, ip_address, user_agent
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			timestamp, old_value, new_value, changes, metadata, ip_address, user_agent,
+			correlation_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 	_, err := r.conn.Exec(ctx, query,
 		record.ID, record.EntityID, record.ActionType, record.ActorID, record.ActorType,
 		record.ResourceID, record.ResourceType, record.Timestamp, record.OldValue,
 		record.NewValue, record.Changes, record.Metadata, record.IPAddress, record.UserAgent,
+		record.CorrelationID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create audit record: %w", err)
@@ -706,14 +746,24 @@ func (r *Repository) GetAuditRecord(ctx context.Context, id uuid.UUID) (*domain.
 	return scanAuditRecord(row)
 }
 
-func (r *Repository) GetAuditRecordsByEntity(ctx context.Context, entityID uuid.UUID, limit, offset int) ([]domain.AuditRecord, error) {
-	query := `
+// Audit trail queries of an entity and of a resource, newest first. Each is
+// served in order by an index on its key and timestamp.
+const (
+	auditRecordsByEntityQuery = `
 		SELECT * FROM compliance_audit_records
 		WHERE entity_id = $1
 		ORDER BY timestamp DESC
 		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.conn.Query(ctx, query, entityID, limit, offset)
+	auditRecordsByResourceQuery = `
+		SELECT * FROM compliance_audit_records
+		WHERE resource_type = $1 AND resource_id = $2
+		ORDER BY timestamp DESC
+	`
+)
+
+func (r *Repository) GetAuditRecordsByEntity(ctx context.Context, entityID uuid.UUID, limit, offset int) ([]domain.AuditRecord, error) {
+	rows, err := r.conn.Query(ctx, auditRecordsByEntityQuery, entityID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query audit records: %w", err)
 	}
@@ -731,12 +781,7 @@ func (r *Repository) GetAuditRecordsByEntity(ctx context.Context, entityID uuid.
 }
 
 func (r *Repository) GetAuditRecordsByResource(ctx context.Context, resourceType string, resourceID uuid.UUID) ([]domain.AuditRecord, error) {
-	query := `
-		SELECT * FROM compliance_audit_records
-		WHERE resource_type = $1 AND resource_id = $2
-		ORDER BY timestamp DESC
-	`
-	rows, err := r.conn.Query(ctx, query, resourceType, resourceID)
+	rows, err := r.conn.Query(ctx, auditRecordsByResourceQuery, resourceType, resourceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query audit records: %w", err)
 	}
@@ -891,3 +936,121 @@ func (r *Repository) CountAuditRecords(ctx context.Context, filter ports.AuditFi
 	}
 	return count, nil
 }
+
+// Document Repository Methods
+
+func (r *Repository) CreateDocument(ctx context.Context, doc *domain.KYCDocument) error {
+	query := `
+		INSERT INTO compliance_documents (
+			id, entity_id, license_id, application_id, file_name, content_type, size_bytes,
+			sha256, storage_key, declared_type, document_type, extraction_status,
+			uploaded_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+	_, err := r.conn.Exec(ctx, query,
+		doc.ID, doc.EntityID, doc.LicenseID, doc.ApplicationID, doc.FileName,
+		doc.ContentType, doc.SizeBytes, doc.SHA256, doc.StorageKey,
+		doc.DeclaredType, doc.DocumentType, doc.ExtractionStatus,
+		doc.UploadedBy, doc.CreatedAt, doc.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create document: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetDocument(ctx context.Context, id uuid.UUID) (*domain.KYCDocument, error) {
+	query := `SELECT ` + documentColumns + ` FROM compliance_documents WHERE id = $1`
+	doc, err := scanDocument(r.conn.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return doc, err
+}
+
+func (r *Repository) GetDocumentsByEntity(ctx context.Context, entityID uuid.UUID) ([]domain.KYCDocument, error) {
+	query := `SELECT ` + documentColumns + ` FROM compliance_documents WHERE entity_id = $1 ORDER BY created_at DESC`
+	return r.queryDocuments(ctx, query, entityID)
+}
+
+func (r *Repository) GetDocumentsByLicense(ctx context.Context, licenseID uuid.UUID) ([]domain.KYCDocument, error) {
+	query := `SELECT ` + documentColumns + ` FROM compliance_documents WHERE license_id = $1 ORDER BY created_at DESC`
+	return r.queryDocuments(ctx, query, licenseID)
+}
+
+func (r *Repository) UpdateDocumentLinks(ctx context.Context, id uuid.UUID, licenseID, applicationID *uuid.UUID) error {
+	query := `UPDATE compliance_documents SET license_id = $1, application_id = $2, updated_at = $3 WHERE id = $4`
+	_, err := r.conn.Exec(ctx, query, licenseID, applicationID, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update document links: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) UpdateDocumentExtraction(ctx context.Context, doc *domain.KYCDocument) error {
+	query := `
+		UPDATE compliance_documents SET
+			document_type = $1, extracted_entity_name = $2, document_number = $3,
+			expiry_date = $4, extraction_status = $5, extraction_error = $6,
+			extraction_attempts = $7, extracted_at = $8, name_mismatch = $9,
+			expiry_alerted_at = CASE WHEN expiry_date IS DISTINCT FROM $4 THEN NULL ELSE expiry_alerted_at END,
+			updated_at = $10
+		WHERE id = $11
+	`
+	_, err := r.conn.Exec(ctx, query,
+		doc.DocumentType, doc.ExtractedEntityName, doc.DocumentNumber,
+		doc.ExpiryDate, doc.ExtractionStatus, doc.ExtractionError,
+		doc.ExtractionAttempts, doc.ExtractedAt, doc.NameMismatch,
+		time.Now().UTC(), doc.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update document extraction: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetDocumentsPendingExtraction(ctx context.Context, maxAttempts, limit int) ([]domain.KYCDocument, error) {
+	query := `
+		SELECT ` + documentColumns + ` FROM compliance_documents
+		WHERE extraction_status = 'PENDING' AND extraction_attempts < $1
+		ORDER BY created_at ASC
+		LIMIT $2
+	`
+	return r.queryDocuments(ctx, query, maxAttempts, limit)
+}
+
+func (r *Repository) GetDocumentsExpiringSoon(ctx context.Context, days int) ([]domain.KYCDocument, error) {
+	query := `
+		SELECT ` + documentColumns + ` FROM compliance_documents
+		WHERE expiry_date IS NOT NULL AND expiry_date <= NOW() + make_interval(days => $1)
+		ORDER BY expiry_date ASC
+	`
+	return r.queryDocuments(ctx, query, days)
+}
+
+func (r *Repository) MarkDocumentExpiryAlerted(ctx context.Context, id uuid.UUID, alertedAt time.Time) error {
+	query := `UPDATE compliance_documents SET expiry_alerted_at = $1 WHERE id = $2`
+	_, err := r.conn.Exec(ctx, query, alertedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark document expiry alerted: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) queryDocuments(ctx context.Context, query string, args ...interface{}) ([]domain.KYCDocument, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []domain.KYCDocument
+	for rows.Next() {
+		d, err := scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, *d)
+	}
+	return docs, nil
+}
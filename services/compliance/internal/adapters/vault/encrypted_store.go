@@ -0,0 +1,155 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/ports"
+)
+
+// formatVersion prefixes every stored file so the format can change later
+const formatVersion byte = 1
+
+// ErrDocumentNotFound is returned for a key with no stored document
+var ErrDocumentNotFound = errors.New("document not found in vault")
+
+// EncryptedFileStore stores documents on disk encrypted with AES-256-GCM.
+// Each file is sealed with a random nonce and its key as additional data, so
+// a file moved to another key fails authentication instead of decrypting.
+type EncryptedFileStore struct {
+	dir  string
+	aead cipher.AEAD
+}
+
+// NewEncryptedFileStore creates a store under dir using a 32 byte key
+func NewEncryptedFileStore(dir string, key []byte) (*EncryptedFileStore, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("vault key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create vault directory: %w", err)
+	}
+
+	return &EncryptedFileStore{dir: dir, aead: aead}, nil
+}
+
+// Put encrypts data and writes it under key, replacing any existing file
+func (s *EncryptedFileStore) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := make([]byte, 0, 1+len(nonce)+len(data)+s.aead.Overhead())
+	sealed = append(sealed, formatVersion)
+	sealed = append(sealed, nonce...)
+	sealed = s.aead.Seal(sealed, nonce, data, []byte(key))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create vault directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create vault file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(sealed); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write vault file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync vault file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close vault file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store vault file: %w", err)
+	}
+	return nil
+}
+
+// Get reads and decrypts the document stored under key
+func (s *EncryptedFileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault file: %w", err)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < 1+nonceSize+s.aead.Overhead() || sealed[0] != formatVersion {
+		return nil, fmt.Errorf("vault file %s is corrupt", key)
+	}
+
+	data, err := s.aead.Open(nil, sealed[1:1+nonceSize], sealed[1+nonceSize:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt vault file %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// Delete removes the document stored under key
+func (s *EncryptedFileStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete vault file: %w", err)
+	}
+	return nil
+}
+
+// path maps a key to a file inside the vault directory, rejecting keys that
+// would escape it
+func (s *EncryptedFileStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid vault key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", fmt.Errorf("invalid vault key %q", key)
+		}
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)+".enc"), nil
+}
+
+// Ensure EncryptedFileStore implements the DocumentStore interface
+var _ ports.DocumentStore = (*EncryptedFileStore)(nil)
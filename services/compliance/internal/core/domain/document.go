@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentType classifies a KYC document
+type DocumentType string
+
+const (
+	DocTypePassport           DocumentType = "PASSPORT"
+	DocTypeNationalID         DocumentType = "NATIONAL_ID"
+	DocTypeDriversLicense     DocumentType = "DRIVERS_LICENSE"
+	DocTypeIncorporation      DocumentType = "CERTIFICATE_OF_INCORPORATION"
+	DocTypeProofOfAddress     DocumentType = "PROOF_OF_ADDRESS"
+	DocTypeRegulatoryLicense  DocumentType = "REGULATORY_LICENSE"
+	DocTypeFinancialStatement DocumentType = "FINANCIAL_STATEMENT"
+	DocTypeUnknown            DocumentType = "UNKNOWN"
+)
+
+// ExtractionStatus tracks metadata extraction for a KYC document
+type ExtractionStatus string
+
+const (
+	ExtractionPending   ExtractionStatus = "PENDING"
+	ExtractionCompleted ExtractionStatus = "COMPLETED"
+	ExtractionFailed    ExtractionStatus = "FAILED"
+)
+
+// KYCDocument is a document held in the encrypted vault. The file itself is
+// stored encrypted under StorageKey; only its metadata is kept here.
+type KYCDocument struct {
+	ID                  uuid.UUID        `json:"id" db:"id"`
+	EntityID            uuid.UUID        `json:"entity_id" db:"entity_id"`
+	LicenseID           *uuid.UUID       `json:"license_id,omitempty" db:"license_id"`
+	ApplicationID       *uuid.UUID       `json:"application_id,omitempty" db:"application_id"`
+	FileName            string           `json:"file_name" db:"file_name"`
+	ContentType         string           `json:"content_type" db:"content_type"`
+	SizeBytes           int64            `json:"size_bytes" db:"size_bytes"`
+	SHA256              string           `json:"sha256" db:"sha256"`
+	StorageKey          string           `json:"-" db:"storage_key"`
+	DeclaredType        DocumentType     `json:"declared_type,omitempty" db:"declared_type"`
+	DocumentType        DocumentType     `json:"document_type" db:"document_type"`
+	ExtractedEntityName string           `json:"extracted_entity_name,omitempty" db:"extracted_entity_name"`
+	DocumentNumber      string           `json:"document_number,omitempty" db:"document_number"`
+	ExpiryDate          *time.Time       `json:"expiry_date,omitempty" db:"expiry_date"`
+	ExtractionStatus    ExtractionStatus `json:"extraction_status" db:"extraction_status"`
+	ExtractionError     string           `json:"extraction_error,omitempty" db:"extraction_error"`
+	ExtractionAttempts  int              `json:"extraction_attempts" db:"extraction_attempts"`
+	ExtractedAt         *time.Time       `json:"extracted_at,omitempty" db:"extracted_at"`
+	NameMismatch        bool             `json:"name_mismatch" db:"name_mismatch"`
+	ExpiryAlertedAt     *time.Time       `json:"expiry_alerted_at,omitempty" db:"expiry_alerted_at"`
+	UploadedBy          string           `json:"uploaded_by" db:"uploaded_by"`
+	CreatedAt           time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// IsExpired reports whether the document's extracted expiry date has passed
+func (d *KYCDocument) IsExpired(now time.Time) bool {
+	return d.ExpiryDate != nil && d.ExpiryDate.Before(now)
+}
+
+// DocumentMetadata is the metadata extracted from a document's text
+type DocumentMetadata struct {
+	DocumentType   DocumentType `json:"document_type"`
+	EntityName     string       `json:"entity_name,omitempty"`
+	DocumentNumber string       `json:"document_number,omitempty"`
+	ExpiryDate     *time.Time   `json:"expiry_date,omitempty"`
+}
+
+// DocumentExpiryAlert is raised for a document that has expired or expires
+// within the warning period
+type DocumentExpiryAlert struct {
+	DocumentID      uuid.UUID    `json:"document_id"`
+	EntityID        uuid.UUID    `json:"entity_id"`
+	LicenseID       *uuid.UUID   `json:"license_id,omitempty"`
+	DocumentType    DocumentType `json:"document_type"`
+	FileName        string       `json:"file_name"`
+	ExpiryDate      time.Time    `json:"expiry_date"`
+	DaysUntilExpiry int          `json:"days_until_expiry"`
+	Expired         bool         `json:"expired"`
+}
@@ -136,6 +136,7 @@ type ComplianceScore struct {
 	PeriodEnd     time.Time     `json:"period_end" db:"period_end"`
 	CalculatedAt  time.Time     `json:"calculated_at" db:"calculated_at"`
 	CalculationDetails string   `json:"calculation_details" db:"calculation_details"`
+	CorrelationID string        `json:"correlation_id,omitempty" db:"correlation_id"`
 }
 
 // Obligation represents a regulatory obligation for an entity
@@ -172,6 +173,7 @@ type AuditRecord struct {
 	Metadata      string    `json:"metadata,omitempty" db:"metadata"`
 	IPAddress     string    `json:"ip_address,omitempty" db:"ip_address"`
 	UserAgent     string    `json:"user_agent,omitempty" db:"user_agent"`
+	CorrelationID string    `json:"correlation_id,omitempty" db:"correlation_id"`
 }
 
 // Entity represents a regulated entity
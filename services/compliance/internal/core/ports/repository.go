@@ -73,6 +73,11 @@ type AuditRepository interface {
 	CountAuditRecords(ctx context.Context, filter AuditFilter) (int64, error)
 }
 
+// AuditArchive defines the output port for the write-once audit store
+type AuditArchive interface {
+	Append(ctx context.Context, record *domain.AuditRecord) error
+}
+
 // AuditFilter defines filter criteria for audit records
 type AuditFilter struct {
 	EntityID     *uuid.UUID
@@ -86,5 +91,30 @@ type AuditFilter struct {
 	Offset       int
 }
 
+// DocumentRepository defines the output port for KYC document metadata
+type DocumentRepository interface {
+	CreateDocument(ctx context.Context, doc *domain.KYCDocument) error
+	GetDocument(ctx context.Context, id uuid.UUID) (*domain.KYCDocument, error)
+	GetDocumentsByEntity(ctx context.Context, entityID uuid.UUID) ([]domain.KYCDocument, error)
+	GetDocumentsByLicense(ctx context.Context, licenseID uuid.UUID) ([]domain.KYCDocument, error)
+	UpdateDocumentLinks(ctx context.Context, id uuid.UUID, licenseID, applicationID *uuid.UUID) error
+	UpdateDocumentExtraction(ctx context.Context, doc *domain.KYCDocument) error
+	GetDocumentsPendingExtraction(ctx context.Context, maxAttempts, limit int) ([]domain.KYCDocument, error)
+	GetDocumentsExpiringSoon(ctx context.Context, days int) ([]domain.KYCDocument, error)
+	MarkDocumentExpiryAlerted(ctx context.Context, id uuid.UUID, alertedAt time.Time) error
+}
+
+// DocumentStore defines the output port for the encrypted document vault
+type DocumentStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// TextExtractor defines the output port for OCR and text extraction
+type TextExtractor interface {
+	ExtractText(ctx context.Context, contentType string, data []byte) (string, error)
+}
+
 // Time is needed for audit repository operations
 import "time"
@@ -67,6 +67,20 @@ type AuditService interface {
 	CountAuditLogs(ctx context.Context, filter AuditLogFilter) (int64, error)
 }
 
+// DocumentService defines the input port for the KYC document vault
+type DocumentService interface {
+	UploadDocument(ctx context.Context, req UploadDocumentRequest) (*domain.KYCDocument, error)
+	GetDocument(ctx context.Context, docID uuid.UUID) (*domain.KYCDocument, error)
+	GetDocumentContent(ctx context.Context, docID uuid.UUID) (*domain.KYCDocument, []byte, error)
+	GetEntityDocuments(ctx context.Context, entityID uuid.UUID) ([]domain.KYCDocument, error)
+	GetLicenseDocuments(ctx context.Context, licenseID uuid.UUID) ([]domain.KYCDocument, error)
+	LinkDocument(ctx context.Context, docID uuid.UUID, req LinkDocumentRequest) (*domain.KYCDocument, error)
+	ExtractMetadata(ctx context.Context, docID uuid.UUID) (*domain.KYCDocument, error)
+	ProcessPendingExtractions(ctx context.Context) error
+	GetExpiringDocuments(ctx context.Context, days int) ([]domain.DocumentExpiryAlert, error)
+	CheckExpiringDocuments(ctx context.Context) ([]domain.DocumentExpiryAlert, error)
+}
+
 // DTOs for service operations
 
 // SubmitApplicationRequest represents a license application submission
@@ -160,6 +174,25 @@ type CreateAuditRequest struct {
 	UserAgent    string   `json:"user_agent,omitempty"`
 }
 
+// UploadDocumentRequest represents a KYC document upload. Data holds the
+// plaintext file, which is encrypted before it is stored.
+type UploadDocumentRequest struct {
+	EntityID      uuid.UUID           `json:"entity_id"`
+	LicenseID     *uuid.UUID          `json:"license_id,omitempty"`
+	ApplicationID *uuid.UUID          `json:"application_id,omitempty"`
+	FileName      string              `json:"file_name"`
+	ContentType   string              `json:"content_type"`
+	DeclaredType  domain.DocumentType `json:"declared_type,omitempty"`
+	UploadedBy    string              `json:"uploaded_by"`
+	Data          []byte              `json:"-"`
+}
+
+// LinkDocumentRequest links a document to a license or license application
+type LinkDocumentRequest struct {
+	LicenseID     *uuid.UUID `json:"license_id,omitempty"`
+	ApplicationID *uuid.UUID `json:"application_id,omitempty"`
+}
+
 // AuditLogFilter represents filter criteria for audit logs
 type AuditLogFilter struct {
 	EntityID     *uuid.UUID
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/domain"
+	"github.com/csic-platform/services/services/compliance/internal/core/ports"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AuditRecorderConfig holds the queueing configuration for the audit recorder
+type AuditRecorderConfig struct {
+	QueueSize    int
+	Workers      int
+	WriteTimeout time.Duration
+}
+
+// AuditRecorder writes audit records to the database and the WORM archive in
+// the background. Records are queued in a bounded queue so request handling
+// never blocks on audit storage; records are dropped when the queue is full.
+type AuditRecorder struct {
+	repo    ports.AuditRepository
+	archive ports.AuditArchive
+	config  AuditRecorderConfig
+	log     *zap.Logger
+
+	queue   chan *domain.AuditRecord
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+	wg      sync.WaitGroup
+}
+
+// NewAuditRecorder creates a new AuditRecorder instance. archive may be nil,
+// in which case records are only written to the database.
+func NewAuditRecorder(repo ports.AuditRepository, archive ports.AuditArchive, config AuditRecorderConfig, log *zap.Logger) *AuditRecorder {
+	if config.QueueSize < 1 {
+		config.QueueSize = 1000
+	}
+	if config.Workers < 1 {
+		config.Workers = 2
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 5 * time.Second
+	}
+
+	return &AuditRecorder{
+		repo:    repo,
+		archive: archive,
+		config:  config,
+		log:     log,
+		queue:   make(chan *domain.AuditRecord, config.QueueSize),
+	}
+}
+
+// Start starts the background workers
+func (r *AuditRecorder) Start() {
+	for i := 0; i < r.config.Workers; i++ {
+		r.wg.Add(1)
+		go r.worker()
+	}
+	r.log.Info("Audit recorder started",
+		zap.Int("workers", r.config.Workers),
+		zap.Int("queue_size", r.config.QueueSize),
+	)
+}
+
+// Stop stops accepting records and waits until the queued records are written
+func (r *AuditRecorder) Stop() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	close(r.queue)
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	r.log.Info("Audit recorder stopped", zap.Int64("dropped", r.dropped.Load()))
+}
+
+// Record queues an audit record for writing. It returns false if the record
+// was dropped because the queue is full or the recorder is stopped.
+func (r *AuditRecorder) Record(record *domain.AuditRecord) bool {
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.closed {
+		select {
+		case r.queue <- record:
+			return true
+		default:
+		}
+	}
+
+	r.dropped.Add(1)
+	r.log.Warn("Audit record dropped",
+		zap.String("action", record.ActionType),
+		zap.String("resource", record.ResourceType),
+	)
+	return false
+}
+
+// Dropped returns the number of records dropped since the recorder was created
+func (r *AuditRecorder) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// worker writes queued records until the queue is closed and drained
+func (r *AuditRecorder) worker() {
+	defer r.wg.Done()
+
+	for record := range r.queue {
+		r.write(record)
+	}
+}
+
+// write stores a record in the database and appends it to the WORM archive.
+// A failure in one store does not prevent the write to the other.
+func (r *AuditRecorder) write(record *domain.AuditRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.WriteTimeout)
+	defer cancel()
+
+	if err := r.repo.CreateAuditRecord(ctx, record); err != nil {
+		r.log.Error("Failed to store audit record",
+			zap.String("record_id", record.ID.String()),
+			zap.Error(err),
+		)
+	}
+
+	if r.archive == nil {
+		return
+	}
+	if err := r.archive.Append(ctx, record); err != nil {
+		r.log.Error("Failed to archive audit record",
+			zap.String("record_id", record.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// MockAuditArchive records appended audit records for testing
+type MockAuditArchive struct {
+	mu      sync.Mutex
+	records []*domain.AuditRecord
+}
+
+func (m *MockAuditArchive) Append(ctx context.Context, record *domain.AuditRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, record)
+	return nil
+}
+
+func TestAuditRecorder_WritesToRepositoryAndArchive(t *testing.T) {
+	repo := NewMockRepository()
+	archive := &MockAuditArchive{}
+	recorder := NewAuditRecorder(repo, archive, AuditRecorderConfig{QueueSize: 10, Workers: 1}, zap.NewNop())
+	recorder.Start()
+
+	for i := 0; i < 3; i++ {
+		if !recorder.Record(&domain.AuditRecord{ActionType: "licenses.suspend", ResourceType: "licenses"}) {
+			t.Fatal("Expected record to be queued")
+		}
+	}
+	recorder.Stop()
+
+	if len(repo.auditRecords) != 3 {
+		t.Errorf("Expected 3 stored audit records, got: %d", len(repo.auditRecords))
+	}
+	if len(archive.records) != 3 {
+		t.Errorf("Expected 3 archived audit records, got: %d", len(archive.records))
+	}
+	for _, record := range archive.records {
+		if record.Timestamp.IsZero() {
+			t.Error("Expected audit record timestamp to be set")
+		}
+	}
+}
+
+func TestAuditRecorder_DropsWhenQueueFull(t *testing.T) {
+	repo := NewMockRepository()
+	recorder := NewAuditRecorder(repo, nil, AuditRecorderConfig{QueueSize: 1, Workers: 1}, zap.NewNop())
+
+	// The recorder is not started, so the queue fills up
+	if !recorder.Record(&domain.AuditRecord{ActionType: "entities.create"}) {
+		t.Fatal("Expected first record to be queued")
+	}
+	if recorder.Record(&domain.AuditRecord{ActionType: "entities.create"}) {
+		t.Error("Expected second record to be dropped")
+	}
+	if recorder.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped record, got: %d", recorder.Dropped())
+	}
+
+	recorder.Stop()
+	if recorder.Record(&domain.AuditRecord{ActionType: "entities.create"}) {
+		t.Error("Expected record to be dropped after stop")
+	}
+}
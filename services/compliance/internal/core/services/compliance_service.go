@@ -116,6 +116,7 @@ func (s *ComplianceService) CalculateScore(ctx context.Context, entityID uuid.UU
 		PeriodEnd:        periodEnd,
 		CalculatedAt:     now,
 		CalculationDetails: calcDetails,
+		CorrelationID:    CorrelationIDFromContext(ctx),
 	}
 
 	if err := s.repo.CreateScore(ctx, complianceScore); err != nil {
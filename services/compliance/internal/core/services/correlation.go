@@ -0,0 +1,20 @@
+package services
+
+import "context"
+
+type correlationKey struct{}
+
+// WithCorrelationID returns a context carrying the correlation ID of the
+// request being served, so the results it produces can be traced back to it
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by ctx, or ""
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/domain"
+)
+
+// documentTypeKeywords maps phrases found in OCR text to document types. They
+// are checked in order, so more specific documents come first: a driving
+// licence mentions a "licence number" just like a regulatory licence does.
+var documentTypeKeywords = []struct {
+	docType  domain.DocumentType
+	keywords []string
+}{
+	{domain.DocTypePassport, []string{"passport", "passeport", "pasaporte"}},
+	{domain.DocTypeDriversLicense, []string{"driving licence", "driving license", "driver's license", "driver license", "drivers license"}},
+	{domain.DocTypeNationalID, []string{"national identity card", "national id", "identity card", "identification card"}},
+	{domain.DocTypeIncorporation, []string{"certificate of incorporation", "articles of incorporation", "certificate of registration", "registrar of companies"}},
+	{domain.DocTypeRegulatoryLicense, []string{"virtual asset service provider", "is hereby licensed", "licence number", "license number", "regulatory licence", "regulatory license"}},
+	{domain.DocTypeProofOfAddress, []string{"proof of address", "utility bill", "bank statement", "council tax"}},
+	{domain.DocTypeFinancialStatement, []string{"balance sheet", "income statement", "audited financial statements", "statement of financial position"}},
+}
+
+var (
+	// expiryPattern matches an expiry label followed by the date it introduces
+	expiryPattern = regexp.MustCompile(`(?i)(?:date of expiry|expiry date|expiration date|date of expiration|expires on|expires|valid until|valid thru|valid to|expiry)\s*[:\-]?\s*([0-9]{1,4}[./\- ][0-9A-Za-z]{1,9}\.?[./\- ,]{1,2}[0-9]{2,4}|[A-Za-z]{3,9}\.? [0-9]{1,2},? [0-9]{4})`)
+
+	// entityNamePatterns match labelled name lines, in order of preference
+	entityNamePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?im)^\s*(?:company name|name of company|entity name|legal name|registered name|name of licensee|licensee)\s*[:\-]\s*(.+?)\s*$`),
+		regexp.MustCompile(`(?im)^\s*(?:full name|name of holder|holder|surname and given names)\s*[:\-]\s*(.+?)\s*$`),
+		regexp.MustCompile(`(?im)^\s*name\s*[:\-]\s*(.+?)\s*$`),
+		regexp.MustCompile(`(?i)this is to certify that\s+(.+?)\s+(?:is|was|has been)\s`),
+	}
+
+	// documentNumberPattern matches a labelled document or registration number
+	documentNumberPattern = regexp.MustCompile(`(?i)(?:passport no\.?|passport number|document no\.?|document number|licen[cs]e no\.?|licen[cs]e number|registration no\.?|registration number|company number|company no\.?|id no\.?|id number)\s*[:\-]?\s*([A-Z0-9][A-Z0-9\-/]{3,})`)
+)
+
+// expiryDateLayouts are tried in order. Numeric dates are read day first, as
+// on passports and most non-US identity documents.
+var expiryDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"02/01/2006",
+	"2/1/2006",
+	"02.01.2006",
+	"2.1.2006",
+	"02-01-2006",
+	"02 Jan 2006",
+	"2 Jan 2006",
+	"02 January 2006",
+	"2 January 2006",
+	"02-Jan-2006",
+	"2-Jan-2006",
+	"02 Jan. 2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2 2006",
+	"January 2 2006",
+	"02/01/06",
+	"02.01.06",
+}
+
+// ParseDocumentMetadata extracts the document type, entity name, document
+// number and expiry date from a document's OCR text. Fields that cannot be
+// found are left empty and the type is UNKNOWN.
+func ParseDocumentMetadata(text string) domain.DocumentMetadata {
+	meta := domain.DocumentMetadata{DocumentType: detectDocumentType(text)}
+
+	for _, pattern := range entityNamePatterns {
+		if m := pattern.FindStringSubmatch(text); m != nil {
+			if name := cleanEntityName(m[1]); name != "" {
+				meta.EntityName = name
+				break
+			}
+		}
+	}
+
+	if m := documentNumberPattern.FindStringSubmatch(text); m != nil {
+		meta.DocumentNumber = strings.ToUpper(m[1])
+	}
+
+	for _, m := range expiryPattern.FindAllStringSubmatch(text, -1) {
+		if expiry, ok := parseExpiryDate(m[1]); ok {
+			meta.ExpiryDate = &expiry
+			break
+		}
+	}
+
+	return meta
+}
+
+// detectDocumentType returns the first document type whose keywords occur in
+// text. A machine readable zone starting with "P<" also marks a passport.
+func detectDocumentType(text string) domain.DocumentType {
+	lower := strings.ToLower(text)
+	for _, candidate := range documentTypeKeywords {
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(lower, keyword) {
+				return candidate.docType
+			}
+		}
+	}
+	if strings.Contains(text, "P<") {
+		return domain.DocTypePassport
+	}
+	return domain.DocTypeUnknown
+}
+
+// parseExpiryDate parses a date matched by expiryPattern
+func parseExpiryDate(value string) (time.Time, bool) {
+	value = strings.Join(strings.Fields(strings.TrimRight(value, ".,")), " ")
+	for _, layout := range expiryDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// cleanEntityName trims OCR noise around a name
+func cleanEntityName(name string) string {
+	name = strings.TrimFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ')' && r != '.'
+	})
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// entityNameMatches reports whether a name extracted from a document matches
+// one of the registered names of an entity. Case, punctuation and common
+// company suffixes are ignored.
+func entityNameMatches(extracted string, entity *domain.Entity) bool {
+	got := normalizeEntityName(extracted)
+	if got == "" {
+		return true
+	}
+	for _, name := range []string{entity.Name, entity.LegalName} {
+		want := normalizeEntityName(name)
+		if want != "" && (got == want || strings.Contains(got, want) || strings.Contains(want, got)) {
+			return true
+		}
+	}
+	return false
+}
+
+var companySuffixes = map[string]bool{
+	"ltd": true, "limited": true, "inc": true, "incorporated": true, "llc": true,
+	"plc": true, "corp": true, "corporation": true, "co": true, "company": true,
+	"gmbh": true, "ag": true, "sa": true, "bv": true, "pte": true, "the": true,
+}
+
+func normalizeEntityName(name string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !companySuffixes[word] {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/domain"
+	"github.com/csic-platform/services/services/compliance/internal/core/ports"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DocumentServiceConfig holds the configuration of the KYC document vault
+type DocumentServiceConfig struct {
+	// MaxSizeBytes is the largest document accepted for upload
+	MaxSizeBytes int64
+	// MaxExtractionAttempts is how often extraction is tried before a
+	// document is marked as failed
+	MaxExtractionAttempts int
+	// ExtractionBatchSize is the number of documents extracted per job run
+	ExtractionBatchSize int
+	// ExpiryWarningDays is how long before expiry a document is alerted on
+	ExpiryWarningDays int
+	// ExtractionTimeout bounds the OCR of a single document
+	ExtractionTimeout time.Duration
+}
+
+// DocumentService implements the DocumentService interface
+type DocumentService struct {
+	repo      ports.DocumentRepository
+	licenses  ports.LicenseRepository
+	store     ports.DocumentStore
+	extractor ports.TextExtractor
+	config    DocumentServiceConfig
+	log       *zap.Logger
+}
+
+// NewDocumentService creates a new DocumentService instance
+func NewDocumentService(
+	repo ports.DocumentRepository,
+	licenses ports.LicenseRepository,
+	store ports.DocumentStore,
+	extractor ports.TextExtractor,
+	config DocumentServiceConfig,
+	log *zap.Logger,
+) *DocumentService {
+	if config.MaxSizeBytes <= 0 {
+		config.MaxSizeBytes = 20 << 20
+	}
+	if config.MaxExtractionAttempts < 1 {
+		config.MaxExtractionAttempts = 3
+	}
+	if config.ExtractionBatchSize < 1 {
+		config.ExtractionBatchSize = 20
+	}
+	if config.ExpiryWarningDays < 1 {
+		config.ExpiryWarningDays = 30
+	}
+	if config.ExtractionTimeout <= 0 {
+		config.ExtractionTimeout = time.Minute
+	}
+
+	return &DocumentService{
+		repo:      repo,
+		licenses:  licenses,
+		store:     store,
+		extractor: extractor,
+		config:    config,
+		log:       log,
+	}
+}
+
+// UploadDocument encrypts a document into the vault and records its metadata.
+// Metadata extraction runs later in the extraction job.
+func (s *DocumentService) UploadDocument(ctx context.Context, req ports.UploadDocumentRequest) (*domain.KYCDocument, error) {
+	s.log.Info("Uploading KYC document",
+		zap.String("entity_id", req.EntityID.String()),
+		zap.String("file_name", req.FileName),
+		zap.Int("size", len(req.Data)),
+	)
+
+	if len(req.Data) == 0 {
+		return nil, fmt.Errorf("document is empty")
+	}
+	if int64(len(req.Data)) > s.config.MaxSizeBytes {
+		return nil, fmt.Errorf("document exceeds the maximum size of %d bytes", s.config.MaxSizeBytes)
+	}
+	fileName := path.Base(strings.ReplaceAll(req.FileName, "\\", "/"))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, fmt.Errorf("file name is required")
+	}
+
+	if err := s.verifyLinks(ctx, req.EntityID, req.LicenseID, req.ApplicationID); err != nil {
+		return nil, err
+	}
+
+	docType := req.DeclaredType
+	if docType == "" {
+		docType = domain.DocTypeUnknown
+	}
+
+	sum := sha256.Sum256(req.Data)
+	now := time.Now().UTC()
+	doc := &domain.KYCDocument{
+		ID:               uuid.New(),
+		EntityID:         req.EntityID,
+		LicenseID:        req.LicenseID,
+		ApplicationID:    req.ApplicationID,
+		FileName:         fileName,
+		ContentType:      req.ContentType,
+		SizeBytes:        int64(len(req.Data)),
+		SHA256:           hex.EncodeToString(sum[:]),
+		DeclaredType:     req.DeclaredType,
+		DocumentType:     docType,
+		ExtractionStatus: domain.ExtractionPending,
+		UploadedBy:       req.UploadedBy,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	doc.StorageKey = req.EntityID.String() + "/" + doc.ID.String()
+
+	if err := s.store.Put(ctx, doc.StorageKey, req.Data); err != nil {
+		return nil, fmt.Errorf("failed to store document: %w", err)
+	}
+
+	if err := s.repo.CreateDocument(ctx, doc); err != nil {
+		if delErr := s.store.Delete(ctx, doc.StorageKey); delErr != nil {
+			s.log.Error("Failed to remove orphaned document",
+				zap.String("storage_key", doc.StorageKey),
+				zap.Error(delErr),
+			)
+		}
+		return nil, fmt.Errorf("failed to create document: %w", err)
+	}
+
+	s.log.Info("KYC document stored", zap.String("document_id", doc.ID.String()))
+	return doc, nil
+}
+
+// GetDocument retrieves a document's metadata by ID
+func (s *DocumentService) GetDocument(ctx context.Context, docID uuid.UUID) (*domain.KYCDocument, error) {
+	return s.repo.GetDocument(ctx, docID)
+}
+
+// GetDocumentContent retrieves and decrypts a document. The checksum recorded
+// at upload is verified so a tampered file is never served.
+func (s *DocumentService) GetDocumentContent(ctx context.Context, docID uuid.UUID) (*domain.KYCDocument, []byte, error) {
+	doc, err := s.repo.GetDocument(ctx, docID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return nil, nil, nil
+	}
+
+	data, err := s.readDocument(ctx, doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, data, nil
+}
+
+// GetEntityDocuments retrieves all documents of an entity
+func (s *DocumentService) GetEntityDocuments(ctx context.Context, entityID uuid.UUID) ([]domain.KYCDocument, error) {
+	return s.repo.GetDocumentsByEntity(ctx, entityID)
+}
+
+// GetLicenseDocuments retrieves all documents linked to a license
+func (s *DocumentService) GetLicenseDocuments(ctx context.Context, licenseID uuid.UUID) ([]domain.KYCDocument, error) {
+	return s.repo.GetDocumentsByLicense(ctx, licenseID)
+}
+
+// LinkDocument links a document to a license or license application of the
+// entity it belongs to
+func (s *DocumentService) LinkDocument(ctx context.Context, docID uuid.UUID, req ports.LinkDocumentRequest) (*domain.KYCDocument, error) {
+	doc, err := s.repo.GetDocument(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("document not found: %s", docID.String())
+	}
+
+	if err := s.verifyLinks(ctx, doc.EntityID, req.LicenseID, req.ApplicationID); err != nil {
+		return nil, err
+	}
+
+	if req.LicenseID != nil {
+		doc.LicenseID = req.LicenseID
+	}
+	if req.ApplicationID != nil {
+		doc.ApplicationID = req.ApplicationID
+	}
+
+	if err := s.repo.UpdateDocumentLinks(ctx, doc.ID, doc.LicenseID, doc.ApplicationID); err != nil {
+		return nil, fmt.Errorf("failed to link document: %w", err)
+	}
+	return doc, nil
+}
+
+// ExtractMetadata runs OCR on a document and stores the document type,
+// entity name, document number and expiry date found in its text
+func (s *DocumentService) ExtractMetadata(ctx context.Context, docID uuid.UUID) (*domain.KYCDocument, error) {
+	doc, err := s.repo.GetDocument(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("document not found: %s", docID.String())
+	}
+
+	if err := s.extract(ctx, doc); err != nil {
+		return doc, err
+	}
+	return doc, nil
+}
+
+// ProcessPendingExtractions extracts the metadata of documents still pending
+// extraction
+func (s *DocumentService) ProcessPendingExtractions(ctx context.Context) error {
+	docs, err := s.repo.GetDocumentsPendingExtraction(ctx, s.config.MaxExtractionAttempts, s.config.ExtractionBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get pending documents: %w", err)
+	}
+
+	failed := 0
+	for i := range docs {
+		if err := s.extract(ctx, &docs[i]); err != nil {
+			failed++
+		}
+	}
+
+	if len(docs) > 0 {
+		s.log.Info("Document extraction completed",
+			zap.Int("processed", len(docs)),
+			zap.Int("failed", failed),
+		)
+	}
+	return nil
+}
+
+// GetExpiringDocuments returns alerts for documents that have expired or
+// expire within days
+func (s *DocumentService) GetExpiringDocuments(ctx context.Context, days int) ([]domain.DocumentExpiryAlert, error) {
+	docs, err := s.repo.GetDocumentsExpiringSoon(ctx, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring documents: %w", err)
+	}
+
+	now := time.Now().UTC()
+	alerts := make([]domain.DocumentExpiryAlert, 0, len(docs))
+	for i := range docs {
+		alerts = append(alerts, newExpiryAlert(&docs[i], now))
+	}
+	return alerts, nil
+}
+
+// CheckExpiringDocuments raises an alert for each document entering the
+// expiry warning period, and again once it has expired. Alerts already
+// raised are not repeated.
+func (s *DocumentService) CheckExpiringDocuments(ctx context.Context) ([]domain.DocumentExpiryAlert, error) {
+	s.log.Info("Checking for expiring KYC documents")
+
+	docs, err := s.repo.GetDocumentsExpiringSoon(ctx, s.config.ExpiryWarningDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring documents: %w", err)
+	}
+
+	now := time.Now().UTC()
+	var alerts []domain.DocumentExpiryAlert
+	for i := range docs {
+		doc := &docs[i]
+		if doc.ExpiryAlertedAt != nil && !(doc.IsExpired(now) && doc.ExpiryAlertedAt.Before(*doc.ExpiryDate)) {
+			continue
+		}
+
+		alert := newExpiryAlert(doc, now)
+		s.log.Warn("KYC document expiring",
+			zap.String("document_id", doc.ID.String()),
+			zap.String("entity_id", doc.EntityID.String()),
+			zap.String("document_type", string(doc.DocumentType)),
+			zap.Time("expiry_date", alert.ExpiryDate),
+			zap.Bool("expired", alert.Expired),
+		)
+
+		if err := s.repo.MarkDocumentExpiryAlerted(ctx, doc.ID, now); err != nil {
+			s.log.Error("Failed to mark document expiry alerted",
+				zap.String("document_id", doc.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	s.log.Info("Expiring document check completed", zap.Int("alerts", len(alerts)))
+	return alerts, nil
+}
+
+// RunJobs runs metadata extraction and the expiry check at each interval
+// until ctx is done
+func (s *DocumentService) RunJobs(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessPendingExtractions(ctx); err != nil {
+			s.log.Error("Document extraction failed", zap.Error(err))
+		}
+		if _, err := s.CheckExpiringDocuments(ctx); err != nil {
+			s.log.Error("Expiring document check failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// extract runs OCR on doc and stores the outcome. A failed attempt leaves the
+// document pending until it has used up its attempts.
+func (s *DocumentService) extract(ctx context.Context, doc *domain.KYCDocument) error {
+	extractCtx, cancel := context.WithTimeout(ctx, s.config.ExtractionTimeout)
+	defer cancel()
+
+	doc.ExtractionAttempts++
+
+	meta, err := s.extractText(extractCtx, doc)
+	if err != nil {
+		doc.ExtractionError = err.Error()
+		if doc.ExtractionAttempts >= s.config.MaxExtractionAttempts {
+			doc.ExtractionStatus = domain.ExtractionFailed
+		}
+		s.log.Warn("Document extraction failed",
+			zap.String("document_id", doc.ID.String()),
+			zap.Int("attempt", doc.ExtractionAttempts),
+			zap.Error(err),
+		)
+		if updErr := s.repo.UpdateDocumentExtraction(ctx, doc); updErr != nil {
+			s.log.Error("Failed to save extraction failure",
+				zap.String("document_id", doc.ID.String()),
+				zap.Error(updErr),
+			)
+		}
+		return err
+	}
+
+	now := time.Now().UTC()
+	if meta.DocumentType != domain.DocTypeUnknown {
+		doc.DocumentType = meta.DocumentType
+	}
+	doc.ExtractedEntityName = meta.EntityName
+	doc.DocumentNumber = meta.DocumentNumber
+	doc.ExpiryDate = meta.ExpiryDate
+	doc.ExtractionStatus = domain.ExtractionCompleted
+	doc.ExtractionError = ""
+	doc.ExtractedAt = &now
+
+	entity, err := s.licenses.GetEntity(ctx, doc.EntityID)
+	if err == nil && entity != nil {
+		doc.NameMismatch = !entityNameMatches(meta.EntityName, entity)
+	}
+	if doc.NameMismatch {
+		s.log.Warn("Document name does not match entity",
+			zap.String("document_id", doc.ID.String()),
+			zap.String("extracted_name", meta.EntityName),
+		)
+	}
+
+	if err := s.repo.UpdateDocumentExtraction(ctx, doc); err != nil {
+		return fmt.Errorf("failed to save extracted metadata: %w", err)
+	}
+	return nil
+}
+
+func (s *DocumentService) extractText(ctx context.Context, doc *domain.KYCDocument) (domain.DocumentMetadata, error) {
+	data, err := s.readDocument(ctx, doc)
+	if err != nil {
+		return domain.DocumentMetadata{}, err
+	}
+
+	text, err := s.extractor.ExtractText(ctx, doc.ContentType, data)
+	if err != nil {
+		return domain.DocumentMetadata{}, fmt.Errorf("failed to extract text: %w", err)
+	}
+	return ParseDocumentMetadata(text), nil
+}
+
+func (s *DocumentService) readDocument(ctx context.Context, doc *domain.KYCDocument) ([]byte, error) {
+	data, err := s.store.Get(ctx, doc.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != doc.SHA256 {
+		return nil, fmt.Errorf("document %s failed checksum verification", doc.ID.String())
+	}
+	return data, nil
+}
+
+// verifyLinks checks that the entity exists and that a linked license or
+// application belongs to it
+func (s *DocumentService) verifyLinks(ctx context.Context, entityID uuid.UUID, licenseID, applicationID *uuid.UUID) error {
+	entity, err := s.licenses.GetEntity(ctx, entityID)
+	if err != nil {
+		return fmt.Errorf("failed to verify entity: %w", err)
+	}
+	if entity == nil {
+		return fmt.Errorf("entity not found: %s", entityID.String())
+	}
+
+	if licenseID != nil {
+		license, err := s.licenses.GetLicense(ctx, *licenseID)
+		if err != nil {
+			return fmt.Errorf("failed to verify license: %w", err)
+		}
+		if license == nil || license.EntityID != entityID {
+			return fmt.Errorf("license not found for entity: %s", licenseID.String())
+		}
+	}
+
+	if applicationID != nil {
+		app, err := s.licenses.GetApplication(ctx, *applicationID)
+		if err != nil {
+			return fmt.Errorf("failed to verify application: %w", err)
+		}
+		if app == nil || app.EntityID != entityID {
+			return fmt.Errorf("application not found for entity: %s", applicationID.String())
+		}
+	}
+
+	return nil
+}
+
+func newExpiryAlert(doc *domain.KYCDocument, now time.Time) domain.DocumentExpiryAlert {
+	return domain.DocumentExpiryAlert{
+		DocumentID:      doc.ID,
+		EntityID:        doc.EntityID,
+		LicenseID:       doc.LicenseID,
+		DocumentType:    doc.DocumentType,
+		FileName:        doc.FileName,
+		ExpiryDate:      *doc.ExpiryDate,
+		DaysUntilExpiry: int(math.Ceil(doc.ExpiryDate.Sub(now).Hours() / 24)),
+		Expired:         doc.IsExpired(now),
+	}
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/csic-platform/services/services/compliance/internal/core/domain"
+	"github.com/csic-platform/services/services/compliance/internal/core/ports"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MockDocumentRepository implements the DocumentRepository interface for testing
+type MockDocumentRepository struct {
+	documents map[uuid.UUID]*domain.KYCDocument
+}
+
+func NewMockDocumentRepository() *MockDocumentRepository {
+	return &MockDocumentRepository{documents: make(map[uuid.UUID]*domain.KYCDocument)}
+}
+
+func (m *MockDocumentRepository) CreateDocument(ctx context.Context, doc *domain.KYCDocument) error {
+	stored := *doc
+	m.documents[doc.ID] = &stored
+	return nil
+}
+
+func (m *MockDocumentRepository) GetDocument(ctx context.Context, id uuid.UUID) (*domain.KYCDocument, error) {
+	doc, ok := m.documents[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *doc
+	return &copied, nil
+}
+
+func (m *MockDocumentRepository) GetDocumentsByEntity(ctx context.Context, entityID uuid.UUID) ([]domain.KYCDocument, error) {
+	var docs []domain.KYCDocument
+	for _, doc := range m.documents {
+		if doc.EntityID == entityID {
+			docs = append(docs, *doc)
+		}
+	}
+	return docs, nil
+}
+
+func (m *MockDocumentRepository) GetDocumentsByLicense(ctx context.Context, licenseID uuid.UUID) ([]domain.KYCDocument, error) {
+	var docs []domain.KYCDocument
+	for _, doc := range m.documents {
+		if doc.LicenseID != nil && *doc.LicenseID == licenseID {
+			docs = append(docs, *doc)
+		}
+	}
+	return docs, nil
+}
+
+func (m *MockDocumentRepository) UpdateDocumentLinks(ctx context.Context, id uuid.UUID, licenseID, applicationID *uuid.UUID) error {
+	m.documents[id].LicenseID = licenseID
+	m.documents[id].ApplicationID = applicationID
+	return nil
+}
+
+func (m *MockDocumentRepository) UpdateDocumentExtraction(ctx context.Context, doc *domain.KYCDocument) error {
+	stored := *doc
+	m.documents[doc.ID] = &stored
+	return nil
+}
+
+func (m *MockDocumentRepository) GetDocumentsPendingExtraction(ctx context.Context, maxAttempts, limit int) ([]domain.KYCDocument, error) {
+	var docs []domain.KYCDocument
+	for _, doc := range m.documents {
+		if doc.ExtractionStatus == domain.ExtractionPending && doc.ExtractionAttempts < maxAttempts && len(docs) < limit {
+			docs = append(docs, *doc)
+		}
+	}
+	return docs, nil
+}
+
+func (m *MockDocumentRepository) GetDocumentsExpiringSoon(ctx context.Context, days int) ([]domain.KYCDocument, error) {
+	cutoff := time.Now().AddDate(0, 0, days)
+	var docs []domain.KYCDocument
+	for _, doc := range m.documents {
+		if doc.ExpiryDate != nil && !doc.ExpiryDate.After(cutoff) {
+			docs = append(docs, *doc)
+		}
+	}
+	return docs, nil
+}
+
+func (m *MockDocumentRepository) MarkDocumentExpiryAlerted(ctx context.Context, id uuid.UUID, alertedAt time.Time) error {
+	m.documents[id].ExpiryAlertedAt = &alertedAt
+	return nil
+}
+
+// MockDocumentStore keeps documents in memory for testing
+type MockDocumentStore struct {
+	files map[string][]byte
+}
+
+func (m *MockDocumentStore) Put(ctx context.Context, key string, data []byte) error {
+	m.files[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *MockDocumentStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, ok := m.files[key]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", key)
+	}
+	return data, nil
+}
+
+func (m *MockDocumentStore) Delete(ctx context.Context, key string) error {
+	delete(m.files, key)
+	return nil
+}
+
+// MockTextExtractor returns the document itself as its text
+type MockTextExtractor struct {
+	err error
+}
+
+func (m *MockTextExtractor) ExtractText(ctx context.Context, contentType string, data []byte) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	return string(data), nil
+}
+
+func newTestDocumentService(t *testing.T) (*DocumentService, *MockRepository, *MockDocumentRepository, *MockDocumentStore, *domain.Entity) {
+	t.Helper()
+
+	repo := NewMockRepository()
+	entity := &domain.Entity{ID: uuid.New(), Name: "Acme Exchange", LegalName: "Acme Exchange Ltd"}
+	repo.entities[entity.ID] = entity
+
+	docs := NewMockDocumentRepository()
+	store := &MockDocumentStore{files: make(map[string][]byte)}
+	service := NewDocumentService(docs, repo, store, &MockTextExtractor{}, DocumentServiceConfig{MaxExtractionAttempts: 2}, zap.NewNop())
+	return service, repo, docs, store, entity
+}
+
+func TestParseDocumentMetadata_Passport(t *testing.T) {
+	text := `REPUBLIC OF EXAMPLE
+PASSPORT
+Passport No: X1234567
+Full Name: Jane Q. Citizen
+Date of Expiry: 14/05/2030
+P<EXACITIZEN<<JANE<Q<<<<<<<<<<<<<<<<<<<<<<<<`
+
+	meta := ParseDocumentMetadata(text)
+
+	if meta.DocumentType != domain.DocTypePassport {
+		t.Errorf("Expected PASSPORT, got: %s", meta.DocumentType)
+	}
+	if meta.EntityName != "Jane Q. Citizen" {
+		t.Errorf("Expected entity name 'Jane Q. Citizen', got: %q", meta.EntityName)
+	}
+	if meta.DocumentNumber != "X1234567" {
+		t.Errorf("Expected document number X1234567, got: %q", meta.DocumentNumber)
+	}
+	if meta.ExpiryDate == nil || !meta.ExpiryDate.Equal(time.Date(2030, 5, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected expiry 2030-05-14, got: %v", meta.ExpiryDate)
+	}
+}
+
+func TestParseDocumentMetadata_RegulatoryLicense(t *testing.T) {
+	text := `FINANCIAL SERVICES AUTHORITY
+Licensee: Acme Exchange Limited
+Licence Number: VASP-2024-0042
+Acme Exchange Limited is hereby licensed as a Virtual Asset Service Provider.
+Valid until 31 December 2026`
+
+	meta := ParseDocumentMetadata(text)
+
+	if meta.DocumentType != domain.DocTypeRegulatoryLicense {
+		t.Errorf("Expected REGULATORY_LICENSE, got: %s", meta.DocumentType)
+	}
+	if meta.EntityName != "Acme Exchange Limited" {
+		t.Errorf("Expected entity name 'Acme Exchange Limited', got: %q", meta.EntityName)
+	}
+	if meta.DocumentNumber != "VASP-2024-0042" {
+		t.Errorf("Expected document number VASP-2024-0042, got: %q", meta.DocumentNumber)
+	}
+	if meta.ExpiryDate == nil || !meta.ExpiryDate.Equal(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected expiry 2026-12-31, got: %v", meta.ExpiryDate)
+	}
+}
+
+func TestParseDocumentMetadata_Unrecognised(t *testing.T) {
+	meta := ParseDocumentMetadata("lorem ipsum dolor sit amet")
+
+	if meta.DocumentType != domain.DocTypeUnknown {
+		t.Errorf("Expected UNKNOWN, got: %s", meta.DocumentType)
+	}
+	if meta.EntityName != "" || meta.DocumentNumber != "" || meta.ExpiryDate != nil {
+		t.Errorf("Expected no metadata, got: %+v", meta)
+	}
+}
+
+func TestUploadDocument_Success(t *testing.T) {
+	service, _, docs, store, entity := newTestDocumentService(t)
+	ctx := context.Background()
+
+	doc, err := service.UploadDocument(ctx, ports.UploadDocumentRequest{
+		EntityID:    entity.ID,
+		FileName:    "../../certificate.txt",
+		ContentType: "text/plain",
+		Data:        []byte("Certificate of Incorporation"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if doc.FileName != "certificate.txt" {
+		t.Errorf("Expected path to be stripped from file name, got: %q", doc.FileName)
+	}
+	if doc.ExtractionStatus != domain.ExtractionPending {
+		t.Errorf("Expected PENDING extraction, got: %s", doc.ExtractionStatus)
+	}
+	if _, ok := store.files[doc.StorageKey]; !ok {
+		t.Error("Expected document to be stored in the vault")
+	}
+	if docs.documents[doc.ID] == nil {
+		t.Error("Expected document metadata to be saved")
+	}
+}
+
+func TestUploadDocument_LicenseOfOtherEntity(t *testing.T) {
+	service, repo, _, store, entity := newTestDocumentService(t)
+	ctx := context.Background()
+
+	license := &domain.License{ID: uuid.New(), EntityID: uuid.New()}
+	repo.licenses[license.ID] = license
+
+	_, err := service.UploadDocument(ctx, ports.UploadDocumentRequest{
+		EntityID:  entity.ID,
+		LicenseID: &license.ID,
+		FileName:  "license.pdf",
+		Data:      []byte("%PDF-1.7"),
+	})
+	if err == nil {
+		t.Error("Expected error for a license of another entity")
+	}
+	if len(store.files) != 0 {
+		t.Error("Expected nothing to be stored")
+	}
+}
+
+func TestProcessPendingExtractions_DetectsNameMismatch(t *testing.T) {
+	service, _, docs, _, entity := newTestDocumentService(t)
+	ctx := context.Background()
+
+	matching, _ := service.UploadDocument(ctx, ports.UploadDocumentRequest{
+		EntityID: entity.ID,
+		FileName: "incorporation.txt",
+		Data:     []byte("CERTIFICATE OF INCORPORATION\nCompany Name: ACME EXCHANGE LIMITED\nCompany Number: 09876543"),
+	})
+	mismatched, _ := service.UploadDocument(ctx, ports.UploadDocumentRequest{
+		EntityID: entity.ID,
+		FileName: "statement.txt",
+		Data:     []byte("Bank statement\nName: Other Holdings Inc"),
+	})
+
+	if err := service.ProcessPendingExtractions(ctx); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	got := docs.documents[matching.ID]
+	if got.ExtractionStatus != domain.ExtractionCompleted {
+		t.Errorf("Expected COMPLETED extraction, got: %s", got.ExtractionStatus)
+	}
+	if got.DocumentType != domain.DocTypeIncorporation {
+		t.Errorf("Expected CERTIFICATE_OF_INCORPORATION, got: %s", got.DocumentType)
+	}
+	if got.NameMismatch {
+		t.Error("Expected extracted name to match the entity")
+	}
+
+	if !docs.documents[mismatched.ID].NameMismatch {
+		t.Error("Expected extracted name not to match the entity")
+	}
+}
+
+func TestExtractMetadata_FailsAfterMaxAttempts(t *testing.T) {
+	service, _, docs, _, entity := newTestDocumentService(t)
+	service.extractor = &MockTextExtractor{err: fmt.Errorf("OCR unavailable")}
+	ctx := context.Background()
+
+	doc, _ := service.UploadDocument(ctx, ports.UploadDocumentRequest{
+		EntityID: entity.ID,
+		FileName: "passport.png",
+		Data:     []byte{0x89, 'P', 'N', 'G'},
+	})
+
+	if _, err := service.ExtractMetadata(ctx, doc.ID); err == nil {
+		t.Fatal("Expected extraction error")
+	}
+	if docs.documents[doc.ID].ExtractionStatus != domain.ExtractionPending {
+		t.Errorf("Expected document to stay PENDING after the first attempt, got: %s", docs.documents[doc.ID].ExtractionStatus)
+	}
+
+	service.ExtractMetadata(ctx, doc.ID)
+	if docs.documents[doc.ID].ExtractionStatus != domain.ExtractionFailed {
+		t.Errorf("Expected FAILED after the last attempt, got: %s", docs.documents[doc.ID].ExtractionStatus)
+	}
+}
+
+func TestCheckExpiringDocuments_AlertsOnce(t *testing.T) {
+	service, _, docs, _, entity := newTestDocumentService(t)
+	ctx := context.Background()
+
+	soon := time.Now().AddDate(0, 0, 10)
+	later := time.Now().AddDate(1, 0, 0)
+	expiring := &domain.KYCDocument{ID: uuid.New(), EntityID: entity.ID, ExpiryDate: &soon}
+	valid := &domain.KYCDocument{ID: uuid.New(), EntityID: entity.ID, ExpiryDate: &later}
+	docs.documents[expiring.ID] = expiring
+	docs.documents[valid.ID] = valid
+
+	alerts, err := service.CheckExpiringDocuments(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].DocumentID != expiring.ID {
+		t.Fatalf("Expected one alert for the expiring document, got: %+v", alerts)
+	}
+	if alerts[0].Expired || alerts[0].DaysUntilExpiry != 10 {
+		t.Errorf("Expected document to expire in 10 days, got: %+v", alerts[0])
+	}
+
+	alerts, _ = service.CheckExpiringDocuments(ctx)
+	if len(alerts) != 0 {
+		t.Errorf("Expected no repeated alert, got: %d", len(alerts))
+	}
+
+	// Once expired, the document is alerted on again
+	past := time.Now().AddDate(0, 0, -1)
+	alertedAt := past.AddDate(0, 0, -10)
+	docs.documents[expiring.ID].ExpiryDate = &past
+	docs.documents[expiring.ID].ExpiryAlertedAt = &alertedAt
+
+	alerts, _ = service.CheckExpiringDocuments(ctx)
+	if len(alerts) != 1 || !alerts[0].Expired {
+		t.Errorf("Expected an expired alert, got: %+v", alerts)
+	}
+}
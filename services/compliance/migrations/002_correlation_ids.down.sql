@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_scores_correlation;
+DROP INDEX IF EXISTS idx_audit_correlation;
+
+ALTER TABLE compliance_scores DROP COLUMN IF EXISTS correlation_id;
+ALTER TABLE compliance_audit_records DROP COLUMN IF EXISTS correlation_id;
@@ -0,0 +1,8 @@
+-- Correlation IDs tie audit records and compliance scores to the request,
+-- across services, that produced them
+
+ALTER TABLE compliance_audit_records ADD COLUMN IF NOT EXISTS correlation_id VARCHAR(128);
+ALTER TABLE compliance_scores ADD COLUMN IF NOT EXISTS correlation_id VARCHAR(128);
+
+CREATE INDEX IF NOT EXISTS idx_audit_correlation ON compliance_audit_records(correlation_id);
+CREATE INDEX IF NOT EXISTS idx_scores_correlation ON compliance_scores(correlation_id);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_documents_updated_at ON compliance_documents;
+DROP TABLE IF EXISTS compliance_documents CASCADE;
@@ -0,0 +1,43 @@
+-- KYC document vault. Files are stored encrypted outside the database; this
+-- table holds their metadata, the fields extracted by OCR and their links to
+-- entities, licenses and license applications.
+
+CREATE TABLE IF NOT EXISTS compliance_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity_id UUID NOT NULL,
+    license_id UUID,
+    application_id UUID,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    declared_type VARCHAR(50) NOT NULL DEFAULT '',
+    document_type VARCHAR(50) NOT NULL DEFAULT 'UNKNOWN',
+    extracted_entity_name VARCHAR(500) NOT NULL DEFAULT '',
+    document_number VARCHAR(100) NOT NULL DEFAULT '',
+    expiry_date TIMESTAMPTZ,
+    extraction_status VARCHAR(50) NOT NULL DEFAULT 'PENDING',
+    extraction_error TEXT NOT NULL DEFAULT '',
+    extraction_attempts INTEGER NOT NULL DEFAULT 0,
+    extracted_at TIMESTAMPTZ,
+    name_mismatch BOOLEAN NOT NULL DEFAULT false,
+    expiry_alerted_at TIMESTAMPTZ,
+    uploaded_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT fk_document_entity FOREIGN KEY (entity_id) REFERENCES compliance_entities(id) ON DELETE CASCADE,
+    CONSTRAINT fk_document_license FOREIGN KEY (license_id) REFERENCES compliance_licenses(id) ON DELETE SET NULL,
+    CONSTRAINT fk_document_application FOREIGN KEY (application_id) REFERENCES compliance_applications(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_documents_entity ON compliance_documents(entity_id);
+CREATE INDEX IF NOT EXISTS idx_documents_license ON compliance_documents(license_id);
+CREATE INDEX IF NOT EXISTS idx_documents_application ON compliance_documents(application_id);
+CREATE INDEX IF NOT EXISTS idx_documents_expiry ON compliance_documents(expiry_date) WHERE expiry_date IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_documents_pending ON compliance_documents(created_at) WHERE extraction_status = 'PENDING';
+
+CREATE TRIGGER update_documents_updated_at
+    BEFORE UPDATE ON compliance_documents
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_audit_entity ON compliance_audit_records(entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_resource ON compliance_audit_records(resource_type, resource_id);
+
+DROP INDEX IF EXISTS idx_audit_entity_timestamp;
+DROP INDEX IF EXISTS idx_audit_resource_timestamp;
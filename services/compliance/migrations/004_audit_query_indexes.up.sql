@@ -0,0 +1,13 @@
+-- Indexes for the audit trail queries of an entity and of a resource, as
+-- asserted by the query plan tests of the postgres repository package. With
+-- the timestamp in the index, the newest records are read in order instead of
+-- fetching every record of the entity or resource and sorting them.
+
+CREATE INDEX IF NOT EXISTS idx_audit_entity_timestamp
+    ON compliance_audit_records(entity_id, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_resource_timestamp
+    ON compliance_audit_records(resource_type, resource_id, timestamp DESC);
+
+-- Superseded by the indexes above, which serve the same lookups
+DROP INDEX IF EXISTS idx_audit_entity;
+DROP INDEX IF EXISTS idx_audit_resource;
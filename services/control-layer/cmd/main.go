@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,11 +16,19 @@ import (
 	"csic-platform/control-layer/internal/adapters/messaging"
 	"csic-platform/control-layer/internal/adapters/storage"
 	"csic-platform/control-layer/internal/config"
+	"csic-platform/control-layer/internal/core/domain"
 	"csic-platform/control-layer/internal/core/ports"
 	"csic-platform/control-layer/internal/core/services"
 	"csic-platform/control-layer/pkg/logger"
 	"csic-platform/control-layer/pkg/metrics"
 
+	"github.com/csic-platform/shared/lifecycle"
+	sharedlogger "github.com/csic-platform/shared/logger"
+	"github.com/csic-platform/shared/pdp"
+	"github.com/csic-platform/shared/queue"
+	"github.com/csic-platform/shared/secrets"
+	"github.com/csic-platform/shared/session"
+	"github.com/csic-platform/shared/tlsreload"
 	"go.uber.org/zap"
 )
 
@@ -46,12 +56,21 @@ func main() {
 		zapLogger.Fatal("Failed to load configuration", logger.Error(err))
 	}
 
-	// Update log level from config
-	zapLogger, err = logger.NewZapLogger(cfg.LogLevel)
+	// Replace the startup logger with the platform logger at the configured
+	// level. Its entries carry the standard service and environment fields,
+	// and remaining standard library log output is routed through it.
+	appLogger, err := sharedlogger.NewLogger(sharedlogger.Config{
+		ServiceName: "control-layer",
+		Environment: cfg.Environment,
+		LogLevel:    cfg.LogLevel,
+		JSONOutput:  true,
+	})
 	if err != nil {
 		zapLogger.Fatal("Failed to create logger with configured level", logger.Error(err))
 	}
-	defer zapLogger.Sync()
+	defer appLogger.Close()
+	defer appLogger.RedirectStdLog()()
+	zapLogger = appLogger.Logger
 
 	zapLogger.Info("Configuration loaded successfully",
 		logger.String("environment", cfg.Environment),
@@ -81,6 +100,21 @@ func main() {
 	}
 	defer interventionRepo.Close()
 
+	emergencyRepo, err := storage.NewPostgresEmergencyStopRepository(cfg.DatabaseURL)
+	if err != nil {
+		zapLogger.Fatal("Failed to connect to PostgreSQL for emergency stops", logger.Error(err))
+	}
+
+	playbookRepo, err := storage.NewPostgresPlaybookRepository(cfg.DatabaseURL)
+	if err != nil {
+		zapLogger.Fatal("Failed to connect to PostgreSQL for playbooks", logger.Error(err))
+	}
+
+	legalBasisRepo, err := storage.NewPostgresLegalBasisRepository(cfg.DatabaseURL)
+	if err != nil {
+		zapLogger.Fatal("Failed to connect to PostgreSQL for the legal basis catalogue", logger.Error(err))
+	}
+
 	// Initialize Redis client
 	redisClient, err := storage.NewRedisClient(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
 	if err != nil {
@@ -93,10 +127,9 @@ func main() {
 	if err != nil {
 		zapLogger.Fatal("Failed to create Kafka producer", logger.Error(err))
 	}
-	defer kafkaProducer.Close()
 
 	// Initialize Kafka consumer for policy updates
-	kafkaConsumer, err := messaging.NewKafkaConsumer(cfg.KafkaBrokers, cfg.KafkaConsumerGroup)
+	kafkaConsumer, err := messaging.NewKafkaConsumer(cfg.KafkaBrokers, cfg.KafkaConsumerGroup, zapLogger)
 	if err != nil {
 		zapLogger.Fatal("Failed to create Kafka consumer", logger.Error(err))
 	}
@@ -104,9 +137,12 @@ func main() {
 
 	// Initialize repositories ports
 	repositories := ports.Repositories{
-		PolicyRepository:       policyRepo,
-		EnforcementRepository:  enforcementRepo,
-		InterventionRepository: interventionRepo,
+		PolicyRepository:        policyRepo,
+		EnforcementRepository:   enforcementRepo,
+		InterventionRepository:  interventionRepo,
+		EmergencyStopRepository: emergencyRepo,
+		PlaybookRepository:      playbookRepo,
+		LegalBasisRepository:    legalBasisRepo,
 	}
 
 	// Initialize cache port
@@ -121,10 +157,16 @@ func main() {
 	}
 
 	// Initialize services
-	policyEngine := services.NewPolicyEngine(repositories, cachePort, messagingPort, zapLogger, metricsCollector)
+	policyEngine := services.NewPolicyEngine(repositories, cachePort, messagingPort, zapLogger, metricsCollector, domain.ConflictMode(cfg.PolicyConflictMode))
 	enforcementHandler := services.NewEnforcementHandler(repositories, messagingPort, zapLogger, metricsCollector)
 	stateRegistry := services.NewStateRegistry(repositories, cachePort, zapLogger, metricsCollector)
 	interventionService := services.NewInterventionService(repositories, messagingPort, zapLogger, metricsCollector, policyEngine)
+	emergencyService := services.NewEmergencyService(repositories, messagingPort, zapLogger, metricsCollector, cfg.GetEmergencyResumeCheckInterval())
+	playbookService := services.NewPlaybookService(repositories, messagingPort, zapLogger)
+	legalBasisService := services.NewLegalBasisService(repositories, zapLogger)
+
+	// Recent access requests answered over gRPC, replayed by policy simulations
+	accessLog := handlers.NewAccessRequestLog(cfg.PDPAccessLogSize)
 
 	// Initialize HTTP handler
 	httpHandler := handlers.NewHTTPHandler(
@@ -132,10 +174,36 @@ func main() {
 		enforcementHandler,
 		stateRegistry,
 		interventionService,
+		emergencyService,
+		playbookService,
+		legalBasisService,
 		metricsCollector,
+		accessLog,
 		zapLogger,
 	)
 
+	// Load gRPC TLS material; rotations are picked up once the reloaders start
+	var grpcTLS *tls.Config
+	var certReloader *tlsreload.Reloader
+	var revocationChecker *tlsreload.RevocationChecker
+	if cfg.GRPCTLSEnabled {
+		certReloader, revocationChecker, err = newGRPCTLS(cfg, zapLogger)
+		if err != nil {
+			zapLogger.Fatal("Failed to load gRPC TLS configuration", logger.Error(err))
+		}
+		grpcTLS = certReloader.ServerConfig(revocationChecker)
+	}
+
+	// gRPC callers present gateway access tokens; a session revoked at the
+	// gateway is deleted from Redis and its tokens are refused here at once
+	var grpcAuth *session.AuthInterceptor
+	if cfg.EnableAuth {
+		grpcAuth = session.NewAuthInterceptor(cfg.JWTSecret, session.CheckerFunc(func(ctx context.Context, sessionID string) (bool, error) {
+			n, err := redisClient.GetClient().Exists(ctx, session.Key(sessionID)).Result()
+			return n == 1, err
+		}))
+	}
+
 	// Initialize gRPC handler
 	grpcHandler := handlers.NewGRPCHandler(
 		policyEngine,
@@ -143,19 +211,123 @@ func main() {
 		stateRegistry,
 		interventionService,
 		metricsCollector,
+		handlers.GRPCConfig{
+			ReflectionEnabled:   cfg.GRPCReflectionEnabled,
+			HealthCheckInterval: cfg.GetGRPCHealthCheckInterval(),
+			DecisionTTL:         cfg.GetPDPDecisionTTL(),
+			AccessLog:           accessLog,
+			TLSConfig:           grpcTLS,
+			Auth:                grpcAuth,
+		},
 		zapLogger,
 	)
 
+	// Tie gRPC health statuses to dependency readiness
+	grpcHealth := grpcHandler.Health()
+	grpcHealth.RegisterDependency(handlers.DependencyPostgres, func(ctx context.Context) error {
+		if err := policyRepo.Ping(ctx); err != nil {
+			return err
+		}
+		if err := enforcementRepo.Ping(ctx); err != nil {
+			return err
+		}
+		if err := interventionRepo.Ping(ctx); err != nil {
+			return err
+		}
+		if err := emergencyRepo.Ping(ctx); err != nil {
+			return err
+		}
+		if err := playbookRepo.Ping(ctx); err != nil {
+			return err
+		}
+		return legalBasisRepo.Ping(ctx)
+	})
+	grpcHealth.RegisterDependency(handlers.DependencyRedis, redisClient.Ping)
+	grpcHealth.RegisterDependency(handlers.DependencyKafka, kafkaProducer.Ping)
+
 	// Start policy update consumer in background
 	go policyEngine.StartPolicyUpdateConsumer(zapLogger)
 
 	// Start intervention monitor in background
 	go interventionService.StartInterventionMonitor(zapLogger)
 
+	// Start emergency stop resume monitor in background
+	emergencyService.StartResumeMonitor(zapLogger)
+
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Shutdown stops intake first, then drains in-flight work, then flushes
+	// queued events, all within the shutdown timeout
+	shutdown := lifecycle.New(func(step lifecycle.Step) {
+		zapLogger.Error("Shutdown step incomplete",
+			logger.String("phase", step.Phase),
+			logger.String("step", step.Name),
+			logger.Error(step.Err),
+		)
+	})
+	shutdown.OnShutdown(lifecycle.PhaseIntake, "background-context", lifecycle.Func(cancel))
+	shutdown.OnShutdown(lifecycle.PhaseIntake, "http", httpHandler.Shutdown)
+	shutdown.OnShutdown(lifecycle.PhaseIntake, "grpc", grpcHandler.Shutdown)
+	shutdown.OnShutdown(lifecycle.PhaseDrain, "intervention-monitor", lifecycle.Func(interventionService.StopInterventionMonitor))
+	shutdown.OnShutdown(lifecycle.PhaseDrain, "emergency-resume-monitor", lifecycle.Func(emergencyService.StopResumeMonitor))
+	shutdown.OnShutdown(lifecycle.PhaseFlush, "kafka-producer", lifecycle.ErrorFunc(kafkaProducer.Close))
+
+	// Run playbooks triggered by alert rules
+	if topics := cfg.GetPlaybookAlertTopics(); len(topics) > 0 {
+		alertConsumer, err := queue.NewConsumer(queue.Config{
+			Brokers:       strings.Split(cfg.KafkaBrokers, ","),
+			ConsumerGroup: cfg.KafkaConsumerGroup + "-playbooks",
+			ClientID:      "control-layer-playbooks",
+		}, zapLogger)
+		if err != nil {
+			zapLogger.Fatal("Failed to create playbook alert consumer", logger.Error(err))
+		}
+		for _, topic := range topics {
+			alertConsumer.RegisterHandler(topic, playbookService.HandleAlert)
+		}
+		go func() {
+			if err := alertConsumer.Start(ctx); err != nil {
+				zapLogger.Error("Playbook alert consumer error", logger.Error(err))
+			}
+		}()
+		// Playbooks already triggered finish before queued events are flushed
+		shutdown.OnShutdown(lifecycle.PhaseDrain, "playbook-alert-consumer", lifecycle.ErrorFunc(alertConsumer.Stop))
+	}
+
+	// Reload the policy index on policy changes made by any instance. Every
+	// instance needs every change, so the consumer group is the instance's own.
+	hostname, _ := os.Hostname()
+	policyConsumer, err := queue.NewConsumer(queue.Config{
+		Brokers:       strings.Split(cfg.KafkaBrokers, ","),
+		ConsumerGroup: fmt.Sprintf("%s-policy-index-%s", cfg.KafkaConsumerGroup, hostname),
+		ClientID:      "control-layer-policy-index",
+	}, zapLogger)
+	if err != nil {
+		zapLogger.Fatal("Failed to create policy update consumer", logger.Error(err))
+	}
+	policyConsumer.RegisterHandler(pdp.PolicyUpdatesTopic, policyEngine.HandlePolicyUpdate)
+	go func() {
+		if err := policyConsumer.Start(ctx); err != nil {
+			zapLogger.Error("Policy update consumer error", logger.Error(err))
+		}
+	}()
+	shutdown.OnShutdown(lifecycle.PhaseDrain, "policy-update-consumer", lifecycle.ErrorFunc(policyConsumer.Stop))
+
+	// Watch for rotated certificates and refreshed CRLs
+	if certReloader != nil {
+		certReloader.Start(ctx)
+		shutdown.OnShutdown(lifecycle.PhaseRelease, "tls-reloader", lifecycle.Func(certReloader.Stop))
+	}
+	if revocationChecker != nil {
+		revocationChecker.Start(ctx)
+		shutdown.OnShutdown(lifecycle.PhaseRelease, "tls-revocation-checker", lifecycle.Func(revocationChecker.Stop))
+	}
+
+	// Start gRPC health monitor in background
+	go grpcHealth.Start(ctx)
+
 	// Start HTTP server
 	go func() {
 		if err := httpHandler.Start(cfg.HTTPPort, zapLogger); err != nil {
@@ -182,25 +354,77 @@ func main() {
 	sig := <-sigChan
 	zapLogger.Info("Received shutdown signal", logger.String("signal", sig.String()))
 
-	// Cancel context to stop background workers
-	cancel()
-
-	// Give background workers time to gracefully shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.GetShutdownTimeout())
 	defer shutdownCancel()
 
-	// Shutdown HTTP server
-	shutdownErr := httpHandler.Shutdown(shutdownCtx)
-	if shutdownErr != nil {
-		zapLogger.Error("Error shutting down HTTP server", logger.Error(shutdownErr))
+	report := shutdown.Shutdown(shutdownCtx)
+	for _, step := range report.Steps {
+		zapLogger.Info("Shutdown step finished",
+			logger.String("phase", step.Phase),
+			logger.String("step", step.Name),
+			zap.Duration("duration", step.Duration),
+		)
 	}
 
-	// Shutdown gRPC server
-	grpcHandler.Shutdown()
-
 	zapLogger.Info("Control Layer Service shutdown complete")
 }
 
+// newGRPCTLS loads the gRPC server certificate, from files or Vault, and the
+// revocation checker for client certificates when CRLs or OCSP are configured
+func newGRPCTLS(cfg *config.Config, zapLogger *zap.Logger) (*tlsreload.Reloader, *tlsreload.RevocationChecker, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var source tlsreload.Source = &tlsreload.FileSource{
+		CertFile:     cfg.GRPCTLSCert,
+		KeyFile:      cfg.GRPCTLSKey,
+		ClientCAFile: cfg.GRPCTLSClientCA,
+	}
+	if cfg.GRPCTLSUsesVault() {
+		vault, err := secrets.NewVaultProvider(secrets.VaultConfig{
+			Address:   cfg.VaultAddr,
+			Token:     cfg.VaultToken,
+			Namespace: cfg.VaultNamespace,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		// Cached no longer than a reload interval, so rotations are seen promptly
+		manager := secrets.NewManager(cfg.GetGRPCTLSReloadInterval(), nil)
+		manager.Register(secrets.ProviderVault, vault)
+
+		source = &tlsreload.SecretSource{
+			Manager:     manager,
+			CertRef:     cfg.GRPCTLSCert,
+			KeyRef:      cfg.GRPCTLSKey,
+			ClientCARef: cfg.GRPCTLSClientCA,
+		}
+	}
+
+	reloader, err := tlsreload.NewReloader(ctx, source, cfg.GetGRPCTLSReloadInterval(), zapLogger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	crlFiles, crlURLs := cfg.GetGRPCTLSCRLFiles(), cfg.GetGRPCTLSCRLURLs()
+	if len(crlFiles) == 0 && len(crlURLs) == 0 && !cfg.GRPCTLSOCSP {
+		return reloader, nil, nil
+	}
+
+	checker, err := tlsreload.NewRevocationChecker(ctx, tlsreload.RevocationConfig{
+		CRLFiles:        crlFiles,
+		CRLURLs:         crlURLs,
+		OCSP:            cfg.GRPCTLSOCSP,
+		SoftFail:        cfg.GRPCTLSOCSPSoftFail,
+		RefreshInterval: cfg.GetGRPCTLSRevocationRefresh(),
+	}, zapLogger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return reloader, checker, nil
+}
+
 // WaitForShutdown waits for shutdown signal
 func WaitForShutdown(zapLogger *zap.Logger) {
 	sigChan := make(chan os.Signal, 1)
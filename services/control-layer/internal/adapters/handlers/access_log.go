@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"sync"
+
+	"github.com/csic-platform/shared/pdp"
+)
+
+// AccessRequestLog keeps the most recent access requests answered by the
+// policy decision point, so policy simulations can replay real traffic
+type AccessRequestLog struct {
+	mu      sync.Mutex
+	entries []*pdp.AccessRequest
+	next    int
+	full    bool
+}
+
+// NewAccessRequestLog creates a log holding up to size requests. A size of
+// zero or less records nothing.
+func NewAccessRequestLog(size int) *AccessRequestLog {
+	if size < 0 {
+		size = 0
+	}
+	return &AccessRequestLog{entries: make([]*pdp.AccessRequest, size)}
+}
+
+// Record adds a request, overwriting the oldest once the log is full
+func (l *AccessRequestLog) Record(req *pdp.AccessRequest) {
+	if l == nil || len(l.entries) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[l.next] = req
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Recent returns up to n requests, newest first
+func (l *AccessRequestLog) Recent(n int) []*pdp.AccessRequest {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+	if l.full {
+		count = len(l.entries)
+	}
+	if n <= 0 || n > count {
+		n = count
+	}
+
+	recent := make([]*pdp.AccessRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		idx := (l.next - i + len(l.entries)) % len(l.entries)
+		recent = append(recent, l.entries[idx])
+	}
+	return recent
+}
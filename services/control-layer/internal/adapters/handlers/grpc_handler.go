@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net"
@@ -16,15 +17,41 @@ import (
 	"csic-platform/control-layer/internal/core/services"
 	"csic-platform/control-layer/pkg/metrics"
 
+	"github.com/csic-platform/shared/correlation"
+	"github.com/csic-platform/shared/pdp"
+	"github.com/csic-platform/shared/session"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
+// GRPCConfig holds the gRPC server configuration
+type GRPCConfig struct {
+	// ReflectionEnabled registers the reflection service, for use by tools
+	// such as grpcurl outside production
+	ReflectionEnabled bool
+
+	// HealthCheckInterval is how often dependency readiness is checked
+	HealthCheckInterval time.Duration
+
+	// DecisionTTL is how long callers may cache policy decision point answers
+	DecisionTTL time.Duration
+
+	// AccessLog records answered access requests for policy simulations
+	AccessLog *AccessRequestLog
+
+	// TLSConfig, when set, serves TLS. Its certificate callbacks are consulted
+	// on every handshake, so rotated certificates apply without a restart.
+	TLSConfig *tls.Config
+
+	// Auth, when set, requires a gateway access token on every call other
+	// than health checks and rejects tokens of revoked sessions
+	Auth *session.AuthInterceptor
+}
+
 // GRPCHandler handles gRPC requests
 type GRPCHandler struct {
 	policyEngine        services.PolicyEngine
@@ -33,8 +60,9 @@ type GRPCHandler struct {
 	interventionService services.InterventionService
 	metricsCollector    *metrics.MetricsCollector
 	logger              *zap.Logger
+	config              GRPCConfig
 	server              *grpc.Server
-	healthServer        *health.Server
+	health              *HealthMonitor
 	mu                  sync.Mutex
 }
 
@@ -45,6 +73,7 @@ func NewGRPCHandler(
 	stateRegistry services.StateRegistry,
 	interventionService services.InterventionService,
 	metricsCollector *metrics.MetricsCollector,
+	config GRPCConfig,
 	logger *zap.Logger,
 ) *GRPCHandler {
 	return &GRPCHandler{
@@ -53,45 +82,88 @@ func NewGRPCHandler(
 		stateRegistry:       stateRegistry,
 		interventionService: interventionService,
 		metricsCollector:    metricsCollector,
+		config:              config,
 		logger:              logger,
-		healthServer:        health.NewServer(),
+		health:              NewHealthMonitor(config.HealthCheckInterval, logger),
 	}
 }
 
+// Health returns the monitor backing the gRPC health service
+func (h *GRPCHandler) Health() *HealthMonitor {
+	return h.health
+}
+
 // Start starts the gRPC server
 func (h *GRPCHandler) Start(port int, logger *zap.Logger) error {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	addr := ":" + strconv.Itoa(port)
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	// Create gRPC server with optional TLS
-	var server *grpc.Server
+	// Create gRPC server with optional TLS and authentication; calls run under
+	// the caller's correlation ID
+	unary := []grpc.UnaryServerInterceptor{correlation.UnaryServerInterceptor()}
+	stream := []grpc.StreamServerInterceptor{correlation.StreamServerInterceptor()}
+	if h.config.Auth != nil {
+		unary = append(unary, h.config.Auth.Unary())
+		stream = append(stream, h.config.Auth.Stream())
+	} else {
+		logger.Warn("gRPC server running without authentication")
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	if h.config.TLSConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(h.config.TLSConfig)))
+	} else {
+		logger.Warn("gRPC server running without TLS")
+	}
+	server := grpc.NewServer(opts...)
 
-	// Register health server
-	grpc_health_v1.RegisterHealthServer(server, h.healthServer)
-	reflection.Register(server)
+	// Register health server; statuses follow dependency readiness
+	grpc_health_v1.RegisterHealthServer(server, h.health.Server())
+	pdp.RegisterServer(server, NewPDPServer(h.policyEngine, h.metricsCollector, h.config.DecisionTTL, h.config.AccessLog, logger))
+	if h.config.ReflectionEnabled {
+		reflection.Register(server)
+		logger.Warn("gRPC reflection enabled")
+	}
 
+	h.mu.Lock()
 	h.server = server
-	h.healthServer.SetServingStatus("control-layer", grpc_health_v1.HealthCheckResponse_SERVING)
+	h.mu.Unlock()
 
-	logger.Info("Starting gRPC server", zap.String("addr", addr))
+	logger.Info("Starting gRPC server", zap.String("addr", addr), zap.Bool("tls", h.config.TLSConfig != nil))
 
 	return server.Serve(lis)
 }
 
-// Shutdown gracefully shuts down the gRPC server
-func (h *GRPCHandler) Shutdown() {
+// Shutdown reports the server as NOT_SERVING, stops accepting connections and
+// waits for in-flight calls. Calls still running when ctx is done are
+// cancelled.
+func (h *GRPCHandler) Shutdown(ctx context.Context) error {
 	h.mu.Lock()
-	defer h.mu.Unlock()
+	server := h.server
+	h.mu.Unlock()
+
+	h.health.Shutdown()
+	if server == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
 
-	if h.server != nil {
-		h.healthServer.SetServingStatus("control-layer", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
-		h.server.GracefulStop()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
 	}
 }
 
@@ -175,7 +247,7 @@ func (s *PolicyServiceServer) CreatePolicy(ctx context.Context, req *CreatePolic
 		IsActive:    req.IsActive,
 	}
 
-	policy, err := s.policyEngine.CreatePolicy(ctx, createReq)
+	policy, _, err := s.policyEngine.CreatePolicy(ctx, createReq, false)
 	if err != nil {
 		s.metrics.RecordGRPCCall("CreatePolicy", "error", float64(time.Since(start).Milliseconds()))
 		return nil, status.Error(codes.Internal, err.Error())
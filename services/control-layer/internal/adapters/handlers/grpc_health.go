@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Dependencies reported on by the gRPC health service
+const (
+	DependencyPostgres = "postgres"
+	DependencyRedis    = "redis"
+	DependencyKafka    = "kafka"
+)
+
+// Names under which the gRPC services report their health. The empty name is
+// the overall server status, as defined by the gRPC health checking protocol.
+const (
+	HealthServiceOverall      = ""
+	HealthServiceControlLayer = "control-layer"
+	HealthServicePolicy       = "control-layer.PolicyService"
+	HealthServiceEnforcement  = "control-layer.EnforcementService"
+	HealthServiceState        = "control-layer.StateService"
+	HealthServiceIntervention = "control-layer.InterventionService"
+)
+
+// serviceDependencies lists the dependencies each gRPC service needs to serve
+// requests. The overall and control-layer statuses need every dependency.
+var serviceDependencies = map[string][]string{
+	HealthServicePolicy:       {DependencyPostgres, DependencyKafka},
+	HealthServiceEnforcement:  {DependencyPostgres, DependencyKafka},
+	HealthServiceState:        {DependencyRedis},
+	HealthServiceIntervention: {DependencyPostgres, DependencyKafka},
+}
+
+// DependencyCheck reports whether a dependency is ready, returning an error if not
+type DependencyCheck func(ctx context.Context) error
+
+// HealthMonitor keeps the gRPC health service in line with the readiness of
+// the dependencies each service relies on
+type HealthMonitor struct {
+	server   *health.Server
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu     sync.RWMutex
+	checks map[string]DependencyCheck
+	ready  map[string]bool
+}
+
+// NewHealthMonitor creates a health monitor in which every service starts as
+// NOT_SERVING until its dependencies have been checked
+func NewHealthMonitor(interval time.Duration, logger *zap.Logger) *HealthMonitor {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	m := &HealthMonitor{
+		server:   health.NewServer(),
+		interval: interval,
+		logger:   logger,
+		checks:   make(map[string]DependencyCheck),
+		ready:    make(map[string]bool),
+	}
+	for _, service := range healthServices() {
+		m.server.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return m
+}
+
+// Server returns the health server to register with the gRPC server
+func (m *HealthMonitor) Server() *health.Server {
+	return m.server
+}
+
+// RegisterDependency adds a readiness check for a dependency
+func (m *HealthMonitor) RegisterDependency(name string, check DependencyCheck) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checks[name] = check
+}
+
+// Start checks the dependencies every interval until ctx is cancelled
+func (m *HealthMonitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.CheckNow(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNow runs every dependency check once and updates the service statuses.
+// Each check is bounded by the monitor interval.
+func (m *HealthMonitor) CheckNow(ctx context.Context) {
+	m.mu.RLock()
+	checks := make(map[string]DependencyCheck, len(m.checks))
+	for name, check := range m.checks {
+		checks[name] = check
+	}
+	m.mu.RUnlock()
+
+	ready := make(map[string]bool, len(checks))
+	for name, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, m.interval)
+		err := check(checkCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ready[name] = err == nil
+		m.logTransition(name, err)
+	}
+
+	m.mu.Lock()
+	m.ready = ready
+	m.mu.Unlock()
+
+	all := make([]string, 0, len(ready))
+	for name := range ready {
+		all = append(all, name)
+	}
+	m.setStatus(HealthServiceOverall, ready, all)
+	m.setStatus(HealthServiceControlLayer, ready, all)
+	for service, dependencies := range serviceDependencies {
+		m.setStatus(service, ready, dependencies)
+	}
+}
+
+// Shutdown marks every service as NOT_SERVING and ignores later updates
+func (m *HealthMonitor) Shutdown() {
+	m.server.Shutdown()
+}
+
+// setStatus reports a service as SERVING only if all of its dependencies are ready
+func (m *HealthMonitor) setStatus(service string, ready map[string]bool, dependencies []string) {
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	for _, dependency := range dependencies {
+		if !ready[dependency] {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			break
+		}
+	}
+	m.server.SetServingStatus(service, status)
+}
+
+// logTransition logs when a dependency becomes ready or unready
+func (m *HealthMonitor) logTransition(name string, err error) {
+	m.mu.RLock()
+	wasReady, seen := m.ready[name]
+	m.mu.RUnlock()
+
+	switch {
+	case err != nil && (wasReady || !seen):
+		m.logger.Warn("Dependency not ready", zap.String("dependency", name), zap.Error(err))
+	case err == nil && !wasReady:
+		m.logger.Info("Dependency ready", zap.String("dependency", name))
+	}
+}
+
+// healthServices returns every service name reported by the health service
+func healthServices() []string {
+	services := []string{HealthServiceOverall, HealthServiceControlLayer}
+	for service := range serviceDependencies {
+		services = append(services, service)
+	}
+	return services
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"csic-platform/control-layer/internal/core/domain"
+	"csic-platform/control-layer/internal/core/services"
+	"csic-platform/control-layer/pkg/metrics"
+
+	"github.com/csic-platform/shared/pdp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBulkAccessRequests bounds a BulkCheckAccess call
+const maxBulkAccessRequests = 500
+
+// bulkAccessWorkers bounds how many requests of a BulkCheckAccess call are
+// evaluated at once
+const bulkAccessWorkers = 16
+
+// PDPServer answers access checks from other services by evaluating the active
+// policies. A request is denied when any policy it matches is violated.
+type PDPServer struct {
+	policyEngine services.PolicyEngine
+	metrics      *metrics.MetricsCollector
+	decisionTTL  time.Duration
+	accessLog    *AccessRequestLog
+	logger       *zap.Logger
+}
+
+// NewPDPServer creates a new policy decision point server. decisionTTL is how
+// long callers may cache a decision; answered requests are recorded in
+// accessLog when it is not nil.
+func NewPDPServer(policyEngine services.PolicyEngine, metrics *metrics.MetricsCollector, decisionTTL time.Duration, accessLog *AccessRequestLog, logger *zap.Logger) *PDPServer {
+	return &PDPServer{
+		policyEngine: policyEngine,
+		metrics:      metrics,
+		decisionTTL:  decisionTTL,
+		accessLog:    accessLog,
+		logger:       logger,
+	}
+}
+
+// CheckAccess decides a single access request
+func (s *PDPServer) CheckAccess(ctx context.Context, req *pdp.AccessRequest) (*pdp.AccessDecision, error) {
+	start := time.Now()
+
+	if err := validateAccessRequest(req); err != nil {
+		s.metrics.RecordGRPCCall("CheckAccess", "invalid", float64(time.Since(start).Milliseconds()))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	decision, err := s.decide(ctx, req)
+	if err != nil {
+		s.metrics.RecordGRPCCall("CheckAccess", "error", float64(time.Since(start).Milliseconds()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	s.metrics.RecordGRPCCall("CheckAccess", "success", float64(time.Since(start).Milliseconds()))
+	return decision, nil
+}
+
+// BulkCheckAccess decides several access requests, answering in request order
+func (s *PDPServer) BulkCheckAccess(ctx context.Context, req *pdp.BulkCheckAccessRequest) (*pdp.BulkCheckAccessResponse, error) {
+	start := time.Now()
+
+	if len(req.Requests) > maxBulkAccessRequests {
+		s.metrics.RecordGRPCCall("BulkCheckAccess", "invalid", float64(time.Since(start).Milliseconds()))
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d requests are allowed", maxBulkAccessRequests)
+	}
+	for i, r := range req.Requests {
+		if err := validateAccessRequest(r); err != nil {
+			s.metrics.RecordGRPCCall("BulkCheckAccess", "invalid", float64(time.Since(start).Milliseconds()))
+			return nil, status.Errorf(codes.InvalidArgument, "request %d: %v", i, err)
+		}
+	}
+
+	decisions, err := s.decideBulk(ctx, req.Requests)
+	if err != nil {
+		s.metrics.RecordGRPCCall("BulkCheckAccess", "error", float64(time.Since(start).Milliseconds()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	s.metrics.RecordGRPCCall("BulkCheckAccess", "success", float64(time.Since(start).Milliseconds()))
+	return &pdp.BulkCheckAccessResponse{Decisions: decisions}, nil
+}
+
+// applicablePolicies is the policy fetch shared by the requests of a bulk call
+// with the same resource type and action
+type applicablePolicies struct {
+	once     sync.Once
+	policies []*domain.Policy
+	err      error
+}
+
+// decideBulk decides requests on a bounded pool of workers, answering in
+// request order. Requests with the same resource type and action share one
+// fetch of their applicable policies. The first failure cancels the rest.
+func (s *PDPServer) decideBulk(ctx context.Context, reqs []*pdp.AccessRequest) ([]*pdp.AccessDecision, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	groups := make(map[string]*applicablePolicies)
+	for _, req := range reqs {
+		key := policyGroupKey(req)
+		if _, ok := groups[key]; !ok {
+			groups[key] = &applicablePolicies{}
+		}
+	}
+
+	workers := bulkAccessWorkers
+	if len(reqs) < workers {
+		workers = len(reqs)
+	}
+
+	decisions := make([]*pdp.AccessDecision, len(reqs))
+	indexes := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				req := reqs[i]
+				group := groups[policyGroupKey(req)]
+				group.once.Do(func() {
+					group.policies, group.err = s.policyEngine.ApplicablePolicies(ctx, req.Resource.Type, req.Action)
+				})
+				if group.err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("failed to evaluate policies: %w", group.err)
+						cancel()
+					})
+					continue
+				}
+
+				results := s.policyEngine.EvaluatePolicies(group.policies, accessRequestData(req))
+				decisions[i] = s.decision(req, results)
+			}
+		}()
+	}
+
+feed:
+	for i := range reqs {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+// decide evaluates the active policies applicable to a request against it.
+// Policy rule targets address the request with dot notation, e.g.
+// subject.type, resource.type, action or attributes.amount.
+func (s *PDPServer) decide(ctx context.Context, req *pdp.AccessRequest) (*pdp.AccessDecision, error) {
+	policies, err := s.policyEngine.ApplicablePolicies(ctx, req.Resource.Type, req.Action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate policies: %w", err)
+	}
+	results := s.policyEngine.EvaluatePolicies(policies, accessRequestData(req))
+	return s.decision(req, results), nil
+}
+
+// decision builds the decision for a request from its policy results and
+// records the request as answered
+func (s *PDPServer) decision(req *pdp.AccessRequest, results []*domain.PolicyResult) *pdp.AccessDecision {
+	s.accessLog.Record(req)
+
+	decision := &pdp.AccessDecision{
+		Decision:   pdp.DecisionAllow,
+		TTLSeconds: int(s.decisionTTL / time.Second),
+	}
+
+	var reasons []string
+	for _, result := range results {
+		if result.Compliant {
+			continue
+		}
+		decision.PolicyIDs = append(decision.PolicyIDs, result.PolicyID)
+		if message, ok := result.Details["message"].(string); ok {
+			reasons = append(reasons, message)
+		}
+	}
+
+	if len(decision.PolicyIDs) > 0 {
+		decision.Decision = pdp.DecisionDeny
+		decision.Reason = strings.Join(reasons, "; ")
+
+		s.logger.Debug("Access denied",
+			zap.String("subject_id", req.Subject.ID),
+			zap.String("action", req.Action),
+			zap.String("resource_type", req.Resource.Type),
+			zap.Strings("policy_ids", decision.PolicyIDs))
+	}
+
+	return decision
+}
+
+// policyGroupKey identifies the requests sharing applicable policies
+func policyGroupKey(req *pdp.AccessRequest) string {
+	return req.Resource.Type + "\x00" + req.Action
+}
+
+// accessRequestData lays a request out as the data map policies evaluate
+func accessRequestData(req *pdp.AccessRequest) map[string]interface{} {
+	roles := make([]interface{}, len(req.Subject.Roles))
+	for i, role := range req.Subject.Roles {
+		roles[i] = role
+	}
+
+	attributes := req.Attributes
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"subject": map[string]interface{}{
+			"id":    req.Subject.ID,
+			"type":  req.Subject.Type,
+			"roles": roles,
+		},
+		"action": req.Action,
+		"resource": map[string]interface{}{
+			"type": req.Resource.Type,
+			"id":   req.Resource.ID,
+		},
+		"attributes": attributes,
+	}
+}
+
+func validateAccessRequest(req *pdp.AccessRequest) error {
+	if req == nil {
+		return fmt.Errorf("request is required")
+	}
+	if req.Subject.ID == "" {
+		return fmt.Errorf("subject.id is required")
+	}
+	if req.Action == "" {
+		return fmt.Errorf("action is required")
+	}
+	if req.Resource.Type == "" {
+		return fmt.Errorf("resource.type is required")
+	}
+	return nil
+}
+
+// Ensure the PDPServer implements the pdp.Server interface
+var _ pdp.Server = (*PDPServer)(nil)
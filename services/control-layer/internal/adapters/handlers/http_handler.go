@@ -3,8 +3,11 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +17,11 @@ import (
 	"csic-platform/control-layer/internal/core/ports"
 	"csic-platform/control-layer/internal/core/services"
 	"csic-platform/control-layer/pkg/metrics"
+
+	"github.com/csic-platform/shared/constants"
+	"github.com/csic-platform/shared/correlation"
+	"github.com/csic-platform/shared/pdp"
+	"github.com/csic-platform/shared/tenancy"
 )
 
 // HTTPHandler handles HTTP requests
@@ -22,8 +30,14 @@ type HTTPHandler struct {
 	enforcementHandler  services.EnforcementHandler
 	stateRegistry       services.StateRegistry
 	interventionService services.InterventionService
+	emergencyService    services.EmergencyService
+	playbookService     services.PlaybookService
+	legalBasisService   services.LegalBasisService
 	metricsCollector    *metrics.MetricsCollector
+	accessLog           *AccessRequestLog
 	logger              *zap.Logger
+	server              *http.Server
+	mu                  sync.Mutex
 }
 
 // NewHTTPHandler creates a new HTTP handler
@@ -32,7 +46,11 @@ func NewHTTPHandler(
 	enforcementHandler services.EnforcementHandler,
 	stateRegistry services.StateRegistry,
 	interventionService services.InterventionService,
+	emergencyService services.EmergencyService,
+	playbookService services.PlaybookService,
+	legalBasisService services.LegalBasisService,
 	metricsCollector *metrics.MetricsCollector,
+	accessLog *AccessRequestLog,
 	logger *zap.Logger,
 ) *HTTPHandler {
 	return &HTTPHandler{
@@ -40,7 +58,11 @@ func NewHTTPHandler(
 		enforcementHandler:  enforcementHandler,
 		stateRegistry:       stateRegistry,
 		interventionService: interventionService,
+		emergencyService:    emergencyService,
+		playbookService:     playbookService,
+		legalBasisService:   legalBasisService,
 		metricsCollector:    metricsCollector,
+		accessLog:           accessLog,
 		logger:              logger,
 	}
 }
@@ -50,6 +72,7 @@ func (h *HTTPHandler) Start(port int, logger *zap.Logger) error {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(correlation.Middleware())
 	router.Use(h.loggingMiddleware())
 	router.Use(h.metricsMiddleware())
 
@@ -66,6 +89,7 @@ func (h *HTTPHandler) Start(port int, logger *zap.Logger) error {
 			policies.GET("", h.ListPolicies)
 			policies.GET("/:id", h.GetPolicy)
 			policies.POST("", h.CreatePolicy)
+			policies.POST("/simulate", h.SimulatePolicy)
 			policies.PUT("/:id", h.UpdatePolicy)
 			policies.DELETE("/:id", h.DeletePolicy)
 		}
@@ -89,6 +113,38 @@ func (h *HTTPHandler) Start(port int, logger *zap.Logger) error {
 			interventions.POST("/:id/resolve", h.ResolveIntervention)
 		}
 
+		// Emergency stop endpoints
+		emergency := v1.Group("/emergency/stops")
+		{
+			emergency.GET("", h.ListEmergencyStops)
+			emergency.GET("/:id", h.GetEmergencyStop)
+			emergency.POST("", h.IssueEmergencyStop)
+			emergency.POST("/:id/resume", h.ResumeEmergencyStop)
+		}
+
+		// Playbook endpoints
+		playbooks := v1.Group("/playbooks")
+		{
+			playbooks.GET("", h.ListPlaybooks)
+			playbooks.GET("/:id", h.GetPlaybook)
+			playbooks.POST("", h.CreatePlaybook)
+			playbooks.DELETE("/:id", h.DeletePlaybook)
+			playbooks.POST("/:id/execute", h.ExecutePlaybook)
+			playbooks.GET("/:id/executions", h.ListPlaybookExecutions)
+		}
+		v1.GET("/playbook-executions/:id", h.GetPlaybookExecution)
+
+		// Legal basis catalogue endpoints
+		legalBases := v1.Group("/legal-bases")
+		{
+			legalBases.GET("", h.ListLegalBases)
+			legalBases.GET("/report", h.GetLegalBasisReport)
+			legalBases.GET("/:id", h.GetLegalBasis)
+			legalBases.POST("", h.CreateLegalBasis)
+			legalBases.PATCH("/:id", h.UpdateLegalBasis)
+			legalBases.GET("/:id/check", h.CheckLegalBasisCitation)
+		}
+
 		// State endpoints
 		states := v1.Group("/states")
 		{
@@ -111,13 +167,28 @@ func (h *HTTPHandler) Start(port int, logger *zap.Logger) error {
 	addr := ":" + strconv.Itoa(port)
 	h.logger.Info("Starting HTTP server", zap.String("addr", addr))
 
-	return router.Run(addr)
+	server := &http.Server{Addr: addr, Handler: router}
+	h.mu.Lock()
+	h.server = server
+	h.mu.Unlock()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
-// Shutdown gracefully shuts down the HTTP server
+// Shutdown stops accepting connections and waits for in-flight requests
+// until ctx is done
 func (h *HTTPHandler) Shutdown(ctx context.Context) error {
-	// In production, use http.Server.Shutdown()
-	return nil
+	h.mu.Lock()
+	server := h.server
+	h.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
 }
 
 // HealthCheck returns the health status
@@ -156,6 +227,17 @@ func (h *HTTPHandler) ListPolicies(c *gin.Context) {
 		return
 	}
 
+	scope, scoped := jurisdictionScope(c)
+	if scoped {
+		visible := policies[:0]
+		for _, policy := range policies {
+			if policyVisible(scope, policy) {
+				visible = append(visible, policy)
+			}
+		}
+		policies = visible
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"policies": policies,
 		"count":    len(policies),
@@ -173,7 +255,7 @@ func (h *HTTPHandler) GetPolicy(c *gin.Context) {
 		return
 	}
 
-	if policy == nil {
+	if scope, scoped := jurisdictionScope(c); policy == nil || scoped && !policyVisible(scope, policy) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "policy not found"})
 		return
 	}
@@ -190,14 +272,29 @@ func (h *HTTPHandler) CreatePolicy(c *gin.Context) {
 		return
 	}
 
+	if scope, scoped := jurisdictionScope(c); scoped && !scope.Allows(req.Jurisdiction) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "policy jurisdiction is outside the caller's jurisdictions"})
+		return
+	}
+
+	override, ok := h.conflictOverride(c)
+	if !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
-	policy, err := h.policyEngine.CreatePolicy(ctx, &req)
+	policy, conflicts, err := h.policyEngine.CreatePolicy(ctx, &req, override)
+	if errors.Is(err, domain.ErrPolicyConflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflicts})
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to create policy", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	setConflictWarnings(c, conflicts)
 	c.JSON(http.StatusCreated, policy)
 }
 
@@ -211,20 +308,102 @@ func (h *HTTPHandler) UpdatePolicy(c *gin.Context) {
 		return
 	}
 
+	if !h.canChangePolicy(c, id) {
+		return
+	}
+
+	override, ok := h.conflictOverride(c)
+	if !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
-	policy, err := h.policyEngine.UpdatePolicy(ctx, id, &req)
+	policy, conflicts, err := h.policyEngine.UpdatePolicy(ctx, id, &req, override)
+	if errors.Is(err, domain.ErrPolicyConflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflicts})
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to update policy", zap.String("id", id), zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	setConflictWarnings(c, conflicts)
 	c.JSON(http.StatusOK, policy)
 }
 
+// conflictOverride reads the override_conflicts query flag, which only admins
+// may set. It writes a 403 and returns false for anyone else.
+func (h *HTTPHandler) conflictOverride(c *gin.Context) (bool, bool) {
+	if c.Query("override_conflicts") != "true" {
+		return false, true
+	}
+	if c.GetHeader(constants.HeaderXRole) != constants.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins may override policy conflicts"})
+		return false, false
+	}
+	return true, true
+}
+
+// setConflictWarnings reports conflicts of a saved policy as Warning headers
+func setConflictWarnings(c *gin.Context, conflicts []domain.PolicyConflict) {
+	for _, conflict := range conflicts {
+		c.Writer.Header().Add("Warning", fmt.Sprintf("299 control-layer %q", "conflicts with policy "+conflict.PolicyID+": "+conflict.Explanation))
+	}
+}
+
+// jurisdictionScope returns the caller's jurisdictions, which the gateway
+// forwards in the X-Jurisdictions header; scoped is false for callers without
+// the header or granted every jurisdiction.
+func jurisdictionScope(c *gin.Context) (scope tenancy.Scope, scoped bool) {
+	header := c.GetHeader(constants.HeaderXJurisdictions)
+	if header == "" {
+		return tenancy.Scope{}, false
+	}
+	scope = tenancy.ParseScope(header)
+	return scope, !scope.All()
+}
+
+// policyVisible reports whether a scoped caller may see the policy. Policies
+// without a jurisdiction apply everywhere and are visible to everyone.
+func policyVisible(scope tenancy.Scope, policy *domain.Policy) bool {
+	return policy.Jurisdiction == "" || scope.Allows(policy.Jurisdiction)
+}
+
+// canChangePolicy checks that the caller's jurisdictions cover the policy.
+// Policies without a jurisdiction may only be changed by unscoped callers. It
+// writes the error response and returns false otherwise.
+func (h *HTTPHandler) canChangePolicy(c *gin.Context, id string) bool {
+	scope, scoped := jurisdictionScope(c)
+	if !scoped {
+		return true
+	}
+
+	policy, err := h.policyEngine.GetPolicy(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get policy", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if policy == nil || !policyVisible(scope, policy) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "policy not found"})
+		return false
+	}
+	if !scope.Allows(policy.Jurisdiction) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "policy jurisdiction is outside the caller's jurisdictions"})
+		return false
+	}
+	return true
+}
+
 // DeletePolicy deletes a policy
 func (h *HTTPHandler) DeletePolicy(c *gin.Context) {
 	id := c.Param("id")
+	if !h.canChangePolicy(c, id) {
+		return
+	}
+
 	ctx := c.Request.Context()
 	if err := h.policyEngine.DeletePolicy(ctx, id); err != nil {
 		h.logger.Error("Failed to delete policy", zap.String("id", id), zap.Error(err))
@@ -405,6 +584,402 @@ func (h *HTTPHandler) ResolveIntervention(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "intervention resolved"})
 }
 
+// ListEmergencyStops lists the emergency stops in force
+func (h *HTTPHandler) ListEmergencyStops(c *gin.Context) {
+	ctx := c.Request.Context()
+	stops, err := h.emergencyService.ListActiveStops(ctx)
+	if err != nil {
+		h.logger.Error("Failed to list emergency stops", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"stops": stops,
+		"count": len(stops),
+	})
+}
+
+// GetEmergencyStop gets an emergency stop by ID
+func (h *HTTPHandler) GetEmergencyStop(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+	stop, err := h.emergencyService.GetStop(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get emergency stop", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if stop == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "emergency stop not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stop)
+}
+
+// IssueEmergencyStop issues an emergency stop and fans out enforcement
+func (h *HTTPHandler) IssueEmergencyStop(c *gin.Context) {
+	var req domain.CreateEmergencyStopRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	stop, err := h.emergencyService.IssueStop(ctx, &req)
+	if err != nil {
+		h.logger.Error("Failed to issue emergency stop", zap.Error(err))
+		c.JSON(emergencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, stop)
+}
+
+// ResumeEmergencyStop lifts an emergency stop
+func (h *HTTPHandler) ResumeEmergencyStop(c *gin.Context) {
+	id := c.Param("id")
+	var req domain.ResumeEmergencyStopRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	stop, err := h.emergencyService.ResumeStop(ctx, id, &req)
+	if err != nil {
+		h.logger.Error("Failed to resume emergency stop", zap.String("id", id), zap.Error(err))
+		c.JSON(emergencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stop)
+}
+
+// emergencyErrorStatus maps emergency stop errors to HTTP status codes
+func emergencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidEmergencyScope):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrInvalidCitation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrEmergencyStopNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrEmergencyStopNotActive):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListPlaybooks lists all playbooks
+func (h *HTTPHandler) ListPlaybooks(c *gin.Context) {
+	ctx := c.Request.Context()
+	playbooks, err := h.playbookService.ListPlaybooks(ctx)
+	if err != nil {
+		h.logger.Error("Failed to list playbooks", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"playbooks": playbooks,
+		"count":     len(playbooks),
+	})
+}
+
+// GetPlaybook gets a playbook by ID
+func (h *HTTPHandler) GetPlaybook(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+	playbook, err := h.playbookService.GetPlaybook(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get playbook", zap.String("id", id), zap.Error(err))
+		c.JSON(playbookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, playbook)
+}
+
+// CreatePlaybook creates a playbook
+func (h *HTTPHandler) CreatePlaybook(c *gin.Context) {
+	var req domain.CreatePlaybookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.CreatedBy == "" {
+		req.CreatedBy = c.GetHeader(constants.HeaderXUserID)
+	}
+
+	ctx := c.Request.Context()
+	playbook, err := h.playbookService.CreatePlaybook(ctx, &req)
+	if err != nil {
+		h.logger.Error("Failed to create playbook", zap.Error(err))
+		c.JSON(playbookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, playbook)
+}
+
+// DeletePlaybook deletes a playbook and its execution history
+func (h *HTTPHandler) DeletePlaybook(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+	if err := h.playbookService.DeletePlaybook(ctx, id); err != nil {
+		h.logger.Error("Failed to delete playbook", zap.String("id", id), zap.Error(err))
+		c.JSON(playbookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "playbook deleted"})
+}
+
+// ExecutePlaybook runs a playbook against a target. The response is the
+// finished execution; a failed step shows up in its status, not as an error.
+func (h *HTTPHandler) ExecutePlaybook(c *gin.Context) {
+	id := c.Param("id")
+	var req domain.ExecutePlaybookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ExecutedBy == "" {
+		req.ExecutedBy = c.GetHeader(constants.HeaderXUserID)
+	}
+
+	ctx := c.Request.Context()
+	execution, err := h.playbookService.ExecutePlaybook(ctx, id, &req)
+	if err != nil {
+		h.logger.Error("Failed to execute playbook", zap.String("id", id), zap.Error(err))
+		c.JSON(playbookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, execution)
+}
+
+// ListPlaybookExecutions lists the execution history of a playbook
+func (h *HTTPHandler) ListPlaybookExecutions(c *gin.Context) {
+	id := c.Param("id")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > maxPlaybookExecutions {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxPlaybookExecutions)})
+		return
+	}
+
+	ctx := c.Request.Context()
+	executions, err := h.playbookService.ListExecutions(ctx, id, limit)
+	if err != nil {
+		h.logger.Error("Failed to list playbook executions", zap.String("id", id), zap.Error(err))
+		c.JSON(playbookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"executions": executions,
+		"count":      len(executions),
+	})
+}
+
+// GetPlaybookExecution gets a playbook execution with its step states
+func (h *HTTPHandler) GetPlaybookExecution(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+	execution, err := h.playbookService.GetExecution(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get playbook execution", zap.String("id", id), zap.Error(err))
+		c.JSON(playbookErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, execution)
+}
+
+// maxPlaybookExecutions bounds the execution history returned at once
+const maxPlaybookExecutions = 500
+
+// playbookErrorStatus maps playbook errors to HTTP status codes
+func playbookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPlaybook):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrPlaybookNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrPlaybookDisabled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListLegalBases lists the legal basis catalogue. With ?in_force=true only the
+// entries that can be cited now are listed.
+func (h *HTTPHandler) ListLegalBases(c *gin.Context) {
+	var inForceAt *time.Time
+	if c.Query("in_force") == "true" {
+		now := time.Now().UTC()
+		inForceAt = &now
+	}
+
+	ctx := c.Request.Context()
+	bases, err := h.legalBasisService.ListLegalBases(ctx, inForceAt)
+	if err != nil {
+		h.logger.Error("Failed to list legal bases", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"legal_bases": bases,
+		"count":       len(bases),
+	})
+}
+
+// GetLegalBasis gets a catalogue entry by ID
+func (h *HTTPHandler) GetLegalBasis(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+	basis, err := h.legalBasisService.GetLegalBasis(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get legal basis", zap.String("id", id), zap.Error(err))
+		c.JSON(legalBasisErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, basis)
+}
+
+// CreateLegalBasis adds an entry to the catalogue
+func (h *HTTPHandler) CreateLegalBasis(c *gin.Context) {
+	var req domain.CreateLegalBasisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.CreatedBy == "" {
+		req.CreatedBy = c.GetHeader(constants.HeaderXUserID)
+	}
+
+	ctx := c.Request.Context()
+	basis, err := h.legalBasisService.CreateLegalBasis(ctx, &req)
+	if err != nil {
+		h.logger.Error("Failed to create legal basis", zap.Error(err))
+		c.JSON(legalBasisErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, basis)
+}
+
+// UpdateLegalBasis changes a catalogue entry
+func (h *HTTPHandler) UpdateLegalBasis(c *gin.Context) {
+	id := c.Param("id")
+	var req domain.UpdateLegalBasisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	basis, err := h.legalBasisService.UpdateLegalBasis(ctx, id, &req)
+	if err != nil {
+		h.logger.Error("Failed to update legal basis", zap.String("id", id), zap.Error(err))
+		c.JSON(legalBasisErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, basis)
+}
+
+// CheckLegalBasisCitation checks whether a catalogue entry can be cited for an
+// action, now or at ?at=<RFC 3339 time>. Other services call it before taking
+// an enforcement action.
+func (h *HTTPHandler) CheckLegalBasisCitation(c *gin.Context) {
+	id := c.Param("id")
+	action := domain.LegalAction(c.Query("action"))
+	if !action.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown action %q", action)})
+		return
+	}
+
+	at := time.Now().UTC()
+	if v := c.Query("at"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at must be an RFC 3339 time"})
+			return
+		}
+		at = parsed
+	}
+
+	ctx := c.Request.Context()
+	basis, err := h.legalBasisService.CheckCitation(ctx, id, action, at)
+	if err != nil {
+		c.JSON(legalBasisErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, basis)
+}
+
+// GetLegalBasisReport aggregates the emergency stops issued between ?from and
+// ?to (RFC 3339, default the last 30 days) by the legal basis they cite
+func (h *HTTPHandler) GetLegalBasisReport(c *gin.Context) {
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -30)
+	for name, value := range map[string]*time.Time{"from": &from, "to": &to} {
+		if v := c.Query(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be an RFC 3339 time"})
+				return
+			}
+			*value = parsed
+		}
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.legalBasisService.Report(ctx, from, to)
+	if err != nil {
+		h.logger.Error("Failed to build legal basis report", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// legalBasisErrorStatus maps legal basis errors to HTTP status codes
+func legalBasisErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidLegalBasis):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrLegalBasisNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrLegalBasisCodeTaken):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidCitation):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // ListStates lists all states
 func (h *HTTPHandler) ListStates(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -474,6 +1049,89 @@ func (h *HTTPHandler) DeleteState(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// maxSimulationSamples bounds the access requests one simulation evaluates
+const maxSimulationSamples = 1000
+
+// SimulatePolicyRequest is the body of a policy simulation. Requests are the
+// samples to evaluate; when none are given, the most recent RecentSamples
+// requests answered by the policy decision point are replayed instead.
+type SimulatePolicyRequest struct {
+	Policy           domain.CreatePolicyRequest `json:"policy" binding:"required"`
+	ReplacesPolicyID string                     `json:"replaces_policy_id,omitempty"`
+	Requests         []*pdp.AccessRequest       `json:"requests,omitempty"`
+	RecentSamples    int                        `json:"recent_samples,omitempty"`
+}
+
+// SimulatedDecisionChange is a sample whose decision the draft policy changes
+type SimulatedDecisionChange struct {
+	*domain.PolicySimulationResult
+	Request *pdp.AccessRequest `json:"request"`
+}
+
+// SimulatePolicy reports which access decisions a draft policy would change
+// compared with the active policy set. Nothing is saved.
+func (h *HTTPHandler) SimulatePolicy(c *gin.Context) {
+	var req SimulatePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	samples := req.Requests
+	source := "provided"
+	if len(samples) == 0 {
+		n := req.RecentSamples
+		if n <= 0 || n > maxSimulationSamples {
+			n = maxSimulationSamples
+		}
+		samples = h.accessLog.Recent(n)
+		source = "recent"
+	}
+	if len(samples) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no sample requests given and none recorded recently"})
+		return
+	}
+	if len(samples) > maxSimulationSamples {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "too many sample requests", "max": maxSimulationSamples})
+		return
+	}
+
+	data := make([]map[string]interface{}, len(samples))
+	for i, sample := range samples {
+		if err := validateAccessRequest(sample); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "index": i})
+			return
+		}
+		data[i] = accessRequestData(sample)
+	}
+
+	ctx := c.Request.Context()
+	results, err := h.policyEngine.SimulatePolicy(ctx, req.ReplacesPolicyID, &req.Policy, data)
+	if err != nil {
+		h.logger.Error("Failed to simulate policy", zap.String("replaces_policy_id", req.ReplacesPolicyID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes := make([]SimulatedDecisionChange, 0)
+	for _, result := range results {
+		if result.Changed() {
+			changes = append(changes, SimulatedDecisionChange{
+				PolicySimulationResult: result,
+				Request:                samples[result.Index],
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sample_source": source,
+		"evaluated":     len(results),
+		"changed":       len(changes),
+		"changes":       changes,
+	})
+}
+
 // EvaluatePolicy evaluates a policy against provided data
 func (h *HTTPHandler) EvaluatePolicy(c *gin.Context) {
 	var req domain.EvaluatePolicyRequest
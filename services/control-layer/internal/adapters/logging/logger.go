@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"csic-platform/control-layer/internal/core/ports"
+	sharedlogger "github.com/csic-platform/shared/logger"
+)
+
+// Logger implements ports.Logger on the platform's structured logger. Every
+// entry carries the correlation ID and user ID of its context.
+type Logger struct {
+	base *zap.Logger
+}
+
+var _ ports.Logger = (*Logger)(nil)
+
+// NewLogger creates a new ports.Logger writing to base
+func NewLogger(base *zap.Logger) *Logger {
+	return &Logger{base: base.WithOptions(zap.AddCallerSkip(1))}
+}
+
+// Debug logs a debug message
+func (l *Logger) Debug(ctx context.Context, message string, fields map[string]interface{}) {
+	l.base.Debug(message, l.fields(ctx, fields)...)
+}
+
+// Info logs an info message
+func (l *Logger) Info(ctx context.Context, message string, fields map[string]interface{}) {
+	l.base.Info(message, l.fields(ctx, fields)...)
+}
+
+// Warn logs a warning message
+func (l *Logger) Warn(ctx context.Context, message string, fields map[string]interface{}) {
+	l.base.Warn(message, l.fields(ctx, fields)...)
+}
+
+// Error logs an error message
+func (l *Logger) Error(ctx context.Context, message string, fields map[string]interface{}) {
+	l.base.Error(message, l.fields(ctx, fields)...)
+}
+
+// Fatal logs a fatal message and exits
+func (l *Logger) Fatal(ctx context.Context, message string, fields map[string]interface{}) {
+	l.base.Fatal(message, l.fields(ctx, fields)...)
+}
+
+// WithFields returns a new logger with additional fields
+func (l *Logger) WithFields(fields map[string]interface{}) ports.Logger {
+	return &Logger{base: l.base.With(mapFields(fields)...)}
+}
+
+// WithError returns a new logger with an error field
+func (l *Logger) WithError(err error) ports.Logger {
+	return &Logger{base: l.base.With(zap.Error(err))}
+}
+
+// WithRequestID returns a new logger with a request ID field
+func (l *Logger) WithRequestID(requestID string) ports.Logger {
+	return &Logger{base: l.base.With(zap.String("request_id", requestID))}
+}
+
+// fields returns the context's standard fields followed by the given ones
+func (l *Logger) fields(ctx context.Context, fields map[string]interface{}) []zap.Field {
+	return append(sharedlogger.ContextFields(ctx), mapFields(fields)...)
+}
+
+// mapFields converts a field map to zap fields in key order, so entries with
+// the same fields render the same way
+func mapFields(fields map[string]interface{}) []zap.Field {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	zapFields := make([]zap.Field, 0, len(keys))
+	for _, key := range keys {
+		zapFields = append(zapFields, zap.Any(key, fields[key]))
+	}
+	return zapFields
+}
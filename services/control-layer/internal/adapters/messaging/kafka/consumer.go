@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+
+	sharedlogger "github.com/csic-platform/shared/logger"
 
 	"csic-platform/control-layer/internal/core/domain"
 )
@@ -16,6 +19,7 @@ import (
 type KafkaConsumer struct {
 	consumerGroup sarama.ConsumerGroup
 	handler       MessageHandler
+	logger        *zap.Logger
 	cancel        context.CancelFunc
 	wg            sync.WaitGroup
 }
@@ -28,7 +32,7 @@ type MessageHandler interface {
 }
 
 // NewKafkaConsumer creates a new Kafka consumer
-func NewKafkaConsumer(brokers, groupID string) (*KafkaConsumer, error) {
+func NewKafkaConsumer(brokers, groupID string, logger *zap.Logger) (*KafkaConsumer, error) {
 	config := sarama.NewConfig()
 	config.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{sarama.NewBalanceStrategyRoundRobin()}
 	config.Consumer.Offsets.Initial = sarama.OffsetNewest
@@ -40,6 +44,7 @@ func NewKafkaConsumer(brokers, groupID string) (*KafkaConsumer, error) {
 
 	return &KafkaConsumer{
 		consumerGroup: consumerGroup,
+		logger:        logger,
 	}, nil
 }
 
@@ -71,16 +76,20 @@ func (c *KafkaConsumer) StartConsuming(ctx context.Context, topics []string) err
 				return
 			}
 
-			// Create consumer group handler
+			// Create consumer group handler. Unrecognised messages are
+			// logged per message, so they are sampled to keep a busy topic
+			// from flooding the log.
 			handler := &consumerGroupHandler{
-				handler:   c.handler,
-				ctx:       ctx,
-				ready:     make(chan bool),
+				handler: c.handler,
+				logger:  c.logger,
+				sampled: sharedlogger.Sample(c.logger, time.Second, 10, 100),
+				ctx:     ctx,
+				ready:   make(chan bool),
 			}
 
 			// Start consuming
 			if err := c.consumerGroup.Consume(ctx, topics, handler); err != nil {
-				log.Printf("Error from consumer: %v", err)
+				c.logger.Error("Kafka consumer error", zap.Error(err))
 			}
 
 			// Check if context is cancelled
@@ -99,6 +108,8 @@ func (c *KafkaConsumer) StartConsuming(ctx context.Context, topics []string) err
 // consumerGroupHandler implements sarama.ConsumerGroupHandler
 type consumerGroupHandler struct {
 	handler MessageHandler
+	logger  *zap.Logger
+	sampled *zap.Logger
 	ctx     context.Context
 	ready   chan bool
 }
@@ -126,7 +137,12 @@ func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 			}
 
 			if err := h.processMessage(h.ctx, message); err != nil {
-				log.Printf("Error processing message: %v", err)
+				h.logger.Error("Failed to process Kafka message",
+					zap.String("topic", message.Topic),
+					zap.Int32("partition", message.Partition),
+					zap.Int64("offset", message.Offset),
+					zap.Error(err),
+				)
 			}
 
 			session.MarkMessage(message, "")
@@ -196,7 +212,10 @@ func (h *consumerGroupHandler) handleStateUpdate(ctx context.Context, message *s
 
 // handleGenericEvent handles generic events
 func (h *consumerGroupHandler) handleGenericEvent(ctx context.Context, message *sarama.ConsumerMessage) error {
-	log.Printf("Received message from topic %s: %s", message.Topic, string(message.Value))
+	h.sampled.Info("Received message from unhandled topic",
+		zap.String("topic", message.Topic),
+		zap.ByteString("value", message.Value),
+	)
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package messaging
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 // KafkaProducer sends messages to Kafka
 type KafkaProducer struct {
+	client   sarama.Client
 	producer sarama.SyncProducer
 	topic    string
 }
@@ -23,12 +25,19 @@ func NewKafkaProducer(brokers string) (*KafkaProducer, error) {
 	config.Producer.Retry.Max = 5
 	config.Producer.Return.Successes = true
 
-	producer, err := sarama.NewSyncProducer([]string{brokers}, config)
+	client, err := sarama.NewClient([]string{brokers}, config)
 	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
 	}
 
 	return &KafkaProducer{
+		client:   client,
 		producer: producer,
 		topic:    "control-layer",
 	}, nil
@@ -36,7 +45,29 @@ func NewKafkaProducer(brokers string) (*KafkaProducer, error) {
 
 // Close closes the Kafka producer
 func (p *KafkaProducer) Close() error {
-	return p.producer.Close()
+	if err := p.producer.Close(); err != nil {
+		return err
+	}
+	return p.client.Close()
+}
+
+// Ping checks that the Kafka cluster is reachable by refreshing its metadata
+func (p *KafkaProducer) Ping(ctx context.Context) error {
+	if p.client.Closed() {
+		return fmt.Errorf("kafka client is closed")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.client.RefreshMetadata()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // PublishEnforcement publishes an enforcement action to Kafka
@@ -93,6 +124,80 @@ func (p *KafkaProducer) PublishIntervention(intervention *domain.Intervention) e
 	return nil
 }
 
+// PublishEmergencyEvent broadcasts an emergency halt or resume to all services.
+// Events are keyed by stop ID so that, on a compacted topic, consumers that
+// start late still see the latest event of every stop.
+func (p *KafkaProducer) PublishEmergencyEvent(event *domain.EmergencyEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal emergency event: %w", err)
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic: fmt.Sprintf("%s.emergency.events", p.topic),
+		Key:   sarama.StringEncoder(event.Stop.ID.String()),
+		Value: sarama.ByteEncoder(data),
+	}
+
+	_, _, err = p.producer.SendMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to send emergency event: %w", err)
+	}
+
+	return nil
+}
+
+// PublishEnforcementCommand sends an enforcement command, such as a freeze
+// order or a throttle command, to the services owning the target
+func (p *KafkaProducer) PublishEnforcementCommand(command *domain.EnforcementCommand) error {
+	data, err := json.Marshal(command)
+	if err != nil {
+		return fmt.Errorf("failed to marshal enforcement command: %w", err)
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic: fmt.Sprintf("%s.enforcement.commands", p.topic),
+		Key:   sarama.StringEncoder(command.TargetID),
+		Value: sarama.ByteEncoder(data),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("target_type"), Value: []byte(command.TargetType)},
+		},
+	}
+
+	_, _, err = p.producer.SendMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to send enforcement command: %w", err)
+	}
+
+	return nil
+}
+
+// PublishPlaybookAction hands a playbook step, such as opening a case or
+// generating a report, to the service that carries it out. Actions are keyed
+// by execution ID so the steps of one run are delivered in order.
+func (p *KafkaProducer) PublishPlaybookAction(action *domain.PlaybookAction) error {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("failed to marshal playbook action: %w", err)
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic: fmt.Sprintf("%s.playbook.actions", p.topic),
+		Key:   sarama.StringEncoder(action.ExecutionID),
+		Value: sarama.ByteEncoder(data),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("action_type"), Value: []byte(action.Type)},
+		},
+	}
+
+	_, _, err = p.producer.SendMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to send playbook action: %w", err)
+	}
+
+	return nil
+}
+
 // PublishAlert publishes an alert to Kafka
 func (p *KafkaProducer) PublishAlert(alert *domain.ControlAlert) error {
 	data, err := json.Marshal(alert)
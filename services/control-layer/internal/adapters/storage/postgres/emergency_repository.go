@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"csic-platform/control-layer/internal/core/domain"
+	"csic-platform/control-layer/internal/core/ports"
+)
+
+// PostgresEmergencyStopRepository implements EmergencyStopRepository using PostgreSQL
+type PostgresEmergencyStopRepository struct {
+	db          *sql.DB
+	tablePrefix string
+}
+
+// NewPostgresEmergencyStopRepository creates a new PostgreSQL emergency stop repository
+func NewPostgresEmergencyStopRepository(databaseURL string) (ports.EmergencyStopRepository, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresEmergencyStopRepository{
+		db:          db,
+		tablePrefix: "control_layer_",
+	}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresEmergencyStopRepository) Close() error {
+	return r.db.Close()
+}
+
+// Ping checks that the database is reachable
+func (r *PostgresEmergencyStopRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// tableName returns the prefixed table name
+func (r *PostgresEmergencyStopRepository) tableName(name string) string {
+	return r.tablePrefix + name
+}
+
+// CreateEmergencyStop stores a new emergency stop
+func (r *PostgresEmergencyStopRepository) CreateEmergencyStop(ctx context.Context, stop *domain.EmergencyStop) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, scope, target, reason, legal_basis_id, legal_basis_code,
+		                status, throttle_percent, initiated_by, resume_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, r.tableName("emergency_stops"))
+
+	_, err := r.db.ExecContext(ctx, query,
+		stop.ID,
+		stop.Scope,
+		stop.Target,
+		stop.Reason,
+		stop.LegalBasisID,
+		stop.LegalBasisCode,
+		stop.Status,
+		stop.ThrottlePercent,
+		stop.InitiatedBy,
+		stop.ResumeAt,
+		stop.CreatedAt,
+		stop.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create emergency stop: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmergencyStopByID retrieves an emergency stop by ID
+func (r *PostgresEmergencyStopRepository) GetEmergencyStopByID(ctx context.Context, id uuid.UUID) (*domain.EmergencyStop, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1
+	`, emergencyStopColumns, r.tableName("emergency_stops"))
+
+	stop, err := scanEmergencyStop(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency stop: %w", err)
+	}
+
+	return stop, nil
+}
+
+// GetActiveEmergencyStops retrieves all stops that have not been resumed
+func (r *PostgresEmergencyStopRepository) GetActiveEmergencyStops(ctx context.Context) ([]*domain.EmergencyStop, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE status = $1
+		ORDER BY created_at DESC
+	`, emergencyStopColumns, r.tableName("emergency_stops"))
+
+	return r.queryEmergencyStops(ctx, query, domain.EmergencyStopActive)
+}
+
+// GetEmergencyStopsDueForResume retrieves active stops whose resume time has passed
+func (r *PostgresEmergencyStopRepository) GetEmergencyStopsDueForResume(ctx context.Context, now time.Time) ([]*domain.EmergencyStop, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE status = $1 AND resume_at IS NOT NULL AND resume_at <= $2
+		ORDER BY resume_at ASC
+	`, emergencyStopColumns, r.tableName("emergency_stops"))
+
+	return r.queryEmergencyStops(ctx, query, domain.EmergencyStopActive, now)
+}
+
+// ResumeEmergencyStop marks an active stop as resumed
+func (r *PostgresEmergencyStopRepository) ResumeEmergencyStop(ctx context.Context, id uuid.UUID, resumedBy, reason string, resumedAt time.Time) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $2, resumed_by = $3, resume_reason = $4, resumed_at = $5, updated_at = $5
+		WHERE id = $1 AND status = $6
+	`, r.tableName("emergency_stops"))
+
+	result, err := r.db.ExecContext(ctx, query, id, domain.EmergencyStopResumed, resumedBy, reason, resumedAt, domain.EmergencyStopActive)
+	if err != nil {
+		return fmt.Errorf("failed to resume emergency stop: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrEmergencyStopNotActive
+	}
+
+	return nil
+}
+
+// queryEmergencyStops runs a query returning emergency stop rows
+func (r *PostgresEmergencyStopRepository) queryEmergencyStops(ctx context.Context, query string, args ...interface{}) ([]*domain.EmergencyStop, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query emergency stops: %w", err)
+	}
+	defer rows.Close()
+
+	var stops []*domain.EmergencyStop
+	for rows.Next() {
+		stop, err := scanEmergencyStop(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan emergency stop: %w", err)
+		}
+		stops = append(stops, stop)
+	}
+
+	return stops, rows.Err()
+}
+
+const emergencyStopColumns = `id, scope, target, reason, legal_basis_id, legal_basis_code, status, throttle_percent, initiated_by,
+		       resume_at, resumed_by, resumed_at, resume_reason, created_at, updated_at`
+
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEmergencyStop scans an emergency stop row
+func scanEmergencyStop(row rowScanner) (*domain.EmergencyStop, error) {
+	var stop domain.EmergencyStop
+	var target, legalBasisCode, resumedBy, resumeReason sql.NullString
+	var legalBasisID uuid.NullUUID
+	var resumeAt, resumedAt sql.NullTime
+
+	if err := row.Scan(
+		&stop.ID,
+		&stop.Scope,
+		&target,
+		&stop.Reason,
+		&legalBasisID,
+		&legalBasisCode,
+		&stop.Status,
+		&stop.ThrottlePercent,
+		&stop.InitiatedBy,
+		&resumeAt,
+		&resumedBy,
+		&resumedAt,
+		&resumeReason,
+		&stop.CreatedAt,
+		&stop.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	stop.Target = target.String
+	stop.LegalBasisCode = legalBasisCode.String
+	if legalBasisID.Valid {
+		stop.LegalBasisID = &legalBasisID.UUID
+	}
+	stop.ResumedBy = resumedBy.String
+	stop.ResumeReason = resumeReason.String
+	if resumeAt.Valid {
+		stop.ResumeAt = &resumeAt.Time
+	}
+	if resumedAt.Valid {
+		stop.ResumedAt = &resumedAt.Time
+	}
+
+	return &stop, nil
+}
@@ -46,6 +46,11 @@ func (r *PostgresInterventionRepository) Close() error {
 	return r.db.Close()
 }
 
+// Ping checks that the database is reachable
+func (r *PostgresInterventionRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
 // tableName returns the prefixed table name
 func (r *PostgresInterventionRepository) tableName(name string) string {
 	return r.tablePrefix + name
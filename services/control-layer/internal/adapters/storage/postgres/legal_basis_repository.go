@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"csic-platform/control-layer/internal/core/domain"
+	"csic-platform/control-layer/internal/core/ports"
+)
+
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// PostgresLegalBasisRepository implements LegalBasisRepository using PostgreSQL
+type PostgresLegalBasisRepository struct {
+	db          *sql.DB
+	tablePrefix string
+}
+
+// NewPostgresLegalBasisRepository creates a new PostgreSQL legal basis repository
+func NewPostgresLegalBasisRepository(databaseURL string) (ports.LegalBasisRepository, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresLegalBasisRepository{
+		db:          db,
+		tablePrefix: "control_layer_",
+	}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresLegalBasisRepository) Close() error {
+	return r.db.Close()
+}
+
+// Ping checks that the database is reachable
+func (r *PostgresLegalBasisRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// tableName returns the prefixed table name
+func (r *PostgresLegalBasisRepository) tableName(name string) string {
+	return r.tablePrefix + name
+}
+
+// CreateLegalBasis stores a new catalogue entry
+func (r *PostgresLegalBasisRepository) CreateLegalBasis(ctx context.Context, basis *domain.LegalBasis) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, code, statute, section, title, description, actions,
+		                valid_from, valid_to, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, r.tableName("legal_bases"))
+
+	_, err := r.db.ExecContext(ctx, query,
+		basis.ID,
+		basis.Code,
+		basis.Statute,
+		basis.Section,
+		basis.Title,
+		basis.Description,
+		pq.Array(actionStrings(basis.Actions)),
+		basis.ValidFrom,
+		basis.ValidTo,
+		basis.CreatedBy,
+		basis.CreatedAt,
+		basis.UpdatedAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return domain.ErrLegalBasisCodeTaken
+		}
+		return fmt.Errorf("failed to create legal basis: %w", err)
+	}
+
+	return nil
+}
+
+// GetLegalBasisByID retrieves a catalogue entry by ID
+func (r *PostgresLegalBasisRepository) GetLegalBasisByID(ctx context.Context, id uuid.UUID) (*domain.LegalBasis, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1
+	`, legalBasisColumns, r.tableName("legal_bases"))
+
+	basis, err := scanLegalBasis(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legal basis: %w", err)
+	}
+
+	return basis, nil
+}
+
+// ListLegalBases retrieves all catalogue entries ordered by code
+func (r *PostgresLegalBasisRepository) ListLegalBases(ctx context.Context) ([]*domain.LegalBasis, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		ORDER BY code ASC
+	`, legalBasisColumns, r.tableName("legal_bases"))
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query legal bases: %w", err)
+	}
+	defer rows.Close()
+
+	var bases []*domain.LegalBasis
+	for rows.Next() {
+		basis, err := scanLegalBasis(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan legal basis: %w", err)
+		}
+		bases = append(bases, basis)
+	}
+
+	return bases, rows.Err()
+}
+
+// UpdateLegalBasis stores the mutable fields of a catalogue entry
+func (r *PostgresLegalBasisRepository) UpdateLegalBasis(ctx context.Context, basis *domain.LegalBasis) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET title = $2, description = $3, actions = $4, valid_to = $5, updated_at = $6
+		WHERE id = $1
+	`, r.tableName("legal_bases"))
+
+	result, err := r.db.ExecContext(ctx, query,
+		basis.ID,
+		basis.Title,
+		basis.Description,
+		pq.Array(actionStrings(basis.Actions)),
+		basis.ValidTo,
+		basis.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update legal basis: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrLegalBasisNotFound
+	}
+
+	return nil
+}
+
+// CountActionsByLegalBasis counts the emergency stops citing each catalogue
+// entry that were issued in [from, to)
+func (r *PostgresLegalBasisRepository) CountActionsByLegalBasis(ctx context.Context, from, to time.Time) ([]*domain.LegalBasisActionCount, error) {
+	query := fmt.Sprintf(`
+		SELECT b.id, b.code, b.title,
+		       COUNT(s.id),
+		       COUNT(s.id) FILTER (WHERE s.status = $3)
+		FROM %s b
+		JOIN %s s ON s.legal_basis_id = b.id
+		WHERE s.created_at >= $1 AND s.created_at < $2
+		GROUP BY b.id, b.code, b.title
+		ORDER BY COUNT(s.id) DESC, b.code ASC
+	`, r.tableName("legal_bases"), r.tableName("emergency_stops"))
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, domain.EmergencyStopActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count actions by legal basis: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*domain.LegalBasisActionCount
+	for rows.Next() {
+		count := &domain.LegalBasisActionCount{Action: domain.LegalActionEmergencyStop}
+		if err := rows.Scan(&count.LegalBasisID, &count.Code, &count.Title, &count.Count, &count.Active); err != nil {
+			return nil, fmt.Errorf("failed to scan legal basis action count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+const legalBasisColumns = `id, code, statute, section, title, description, actions,
+		       valid_from, valid_to, created_by, created_at, updated_at`
+
+// scanLegalBasis scans a legal basis row
+func scanLegalBasis(row rowScanner) (*domain.LegalBasis, error) {
+	var basis domain.LegalBasis
+	var description sql.NullString
+	var actions pq.StringArray
+	var validTo sql.NullTime
+
+	if err := row.Scan(
+		&basis.ID,
+		&basis.Code,
+		&basis.Statute,
+		&basis.Section,
+		&basis.Title,
+		&description,
+		&actions,
+		&basis.ValidFrom,
+		&validTo,
+		&basis.CreatedBy,
+		&basis.CreatedAt,
+		&basis.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	basis.Description = description.String
+	for _, action := range actions {
+		basis.Actions = append(basis.Actions, domain.LegalAction(action))
+	}
+	if validTo.Valid {
+		basis.ValidTo = &validTo.Time
+	}
+
+	return &basis, nil
+}
+
+// actionStrings converts legal actions for storage in a text array
+func actionStrings(actions []domain.LegalAction) []string {
+	values := make([]string, len(actions))
+	for i, action := range actions {
+		values[i] = string(action)
+	}
+	return values
+}
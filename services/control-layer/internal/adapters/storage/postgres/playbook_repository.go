@@ -0,0 +1,362 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"csic-platform/control-layer/internal/core/domain"
+	"csic-platform/control-layer/internal/core/ports"
+)
+
+// PostgresPlaybookRepository implements PlaybookRepository using PostgreSQL.
+// Playbook steps and triggers, and execution step states, are stored as JSONB.
+type PostgresPlaybookRepository struct {
+	db          *sql.DB
+	tablePrefix string
+}
+
+// NewPostgresPlaybookRepository creates a new PostgreSQL playbook repository
+func NewPostgresPlaybookRepository(databaseURL string) (ports.PlaybookRepository, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresPlaybookRepository{
+		db:          db,
+		tablePrefix: "control_layer_",
+	}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresPlaybookRepository) Close() error {
+	return r.db.Close()
+}
+
+// Ping checks that the database is reachable
+func (r *PostgresPlaybookRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// tableName returns the prefixed table name
+func (r *PostgresPlaybookRepository) tableName(name string) string {
+	return r.tablePrefix + name
+}
+
+// CreatePlaybook stores a new playbook
+func (r *PostgresPlaybookRepository) CreatePlaybook(ctx context.Context, playbook *domain.Playbook) error {
+	steps, err := json.Marshal(playbook.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal playbook steps: %w", err)
+	}
+
+	var trigger []byte
+	if playbook.Trigger != nil {
+		if trigger, err = json.Marshal(playbook.Trigger); err != nil {
+			return fmt.Errorf("failed to marshal playbook trigger: %w", err)
+		}
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, name, description, steps, alert_trigger, enabled,
+		                created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, r.tableName("playbooks"))
+
+	_, err = r.db.ExecContext(ctx, query,
+		playbook.ID,
+		playbook.Name,
+		playbook.Description,
+		steps,
+		trigger,
+		playbook.Enabled,
+		playbook.CreatedBy,
+		playbook.CreatedAt,
+		playbook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create playbook: %w", err)
+	}
+
+	return nil
+}
+
+// GetPlaybookByID retrieves a playbook by ID
+func (r *PostgresPlaybookRepository) GetPlaybookByID(ctx context.Context, id uuid.UUID) (*domain.Playbook, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1
+	`, playbookColumns, r.tableName("playbooks"))
+
+	playbook, err := scanPlaybook(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playbook: %w", err)
+	}
+
+	return playbook, nil
+}
+
+// ListPlaybooks retrieves all playbooks
+func (r *PostgresPlaybookRepository) ListPlaybooks(ctx context.Context) ([]*domain.Playbook, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		ORDER BY created_at DESC
+	`, playbookColumns, r.tableName("playbooks"))
+
+	return r.queryPlaybooks(ctx, query)
+}
+
+// GetTriggeredPlaybooks retrieves the enabled playbooks with an alert trigger
+func (r *PostgresPlaybookRepository) GetTriggeredPlaybooks(ctx context.Context) ([]*domain.Playbook, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE enabled AND alert_trigger IS NOT NULL
+		ORDER BY created_at DESC
+	`, playbookColumns, r.tableName("playbooks"))
+
+	return r.queryPlaybooks(ctx, query)
+}
+
+// DeletePlaybook removes a playbook; its executions are removed by cascade
+func (r *PostgresPlaybookRepository) DeletePlaybook(ctx context.Context, id uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, r.tableName("playbooks"))
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete playbook: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrPlaybookNotFound
+	}
+
+	return nil
+}
+
+// CreateExecution stores a new playbook execution
+func (r *PostgresPlaybookRepository) CreateExecution(ctx context.Context, execution *domain.PlaybookExecution) error {
+	steps, err := json.Marshal(execution.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution steps: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, playbook_id, target_id, target_type, triggered_by,
+		                status, steps, error, started_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, r.tableName("playbook_executions"))
+
+	_, err = r.db.ExecContext(ctx, query,
+		execution.ID,
+		execution.PlaybookID,
+		execution.TargetID,
+		execution.TargetType,
+		execution.TriggeredBy,
+		execution.Status,
+		steps,
+		nullString(execution.Error),
+		execution.StartedAt,
+		execution.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create playbook execution: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateExecution stores the status and step states of an execution
+func (r *PostgresPlaybookRepository) UpdateExecution(ctx context.Context, execution *domain.PlaybookExecution) error {
+	steps, err := json.Marshal(execution.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution steps: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $2, steps = $3, error = $4, completed_at = $5
+		WHERE id = $1
+	`, r.tableName("playbook_executions"))
+
+	_, err = r.db.ExecContext(ctx, query,
+		execution.ID,
+		execution.Status,
+		steps,
+		nullString(execution.Error),
+		execution.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update playbook execution: %w", err)
+	}
+
+	return nil
+}
+
+// GetExecutionByID retrieves a playbook execution by ID
+func (r *PostgresPlaybookRepository) GetExecutionByID(ctx context.Context, id uuid.UUID) (*domain.PlaybookExecution, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1
+	`, playbookExecutionColumns, r.tableName("playbook_executions"))
+
+	execution, err := scanPlaybookExecution(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playbook execution: %w", err)
+	}
+
+	return execution, nil
+}
+
+// ListExecutions retrieves the most recent executions of a playbook
+func (r *PostgresPlaybookRepository) ListExecutions(ctx context.Context, playbookID uuid.UUID, limit int) ([]*domain.PlaybookExecution, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE playbook_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2
+	`, playbookExecutionColumns, r.tableName("playbook_executions"))
+
+	rows, err := r.db.QueryContext(ctx, query, playbookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query playbook executions: %w", err)
+	}
+	defer rows.Close()
+
+	var executions []*domain.PlaybookExecution
+	for rows.Next() {
+		execution, err := scanPlaybookExecution(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan playbook execution: %w", err)
+		}
+		executions = append(executions, execution)
+	}
+
+	return executions, rows.Err()
+}
+
+// queryPlaybooks runs a query returning playbook rows
+func (r *PostgresPlaybookRepository) queryPlaybooks(ctx context.Context, query string, args ...interface{}) ([]*domain.Playbook, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query playbooks: %w", err)
+	}
+	defer rows.Close()
+
+	var playbooks []*domain.Playbook
+	for rows.Next() {
+		playbook, err := scanPlaybook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan playbook: %w", err)
+		}
+		playbooks = append(playbooks, playbook)
+	}
+
+	return playbooks, rows.Err()
+}
+
+const playbookColumns = `id, name, description, steps, alert_trigger, enabled, created_by,
+		       created_at, updated_at`
+
+const playbookExecutionColumns = `id, playbook_id, target_id, target_type, triggered_by, status,
+		       steps, error, started_at, completed_at`
+
+// scanPlaybook scans a playbook row
+func scanPlaybook(row rowScanner) (*domain.Playbook, error) {
+	var playbook domain.Playbook
+	var description sql.NullString
+	var steps, trigger []byte
+
+	if err := row.Scan(
+		&playbook.ID,
+		&playbook.Name,
+		&description,
+		&steps,
+		&trigger,
+		&playbook.Enabled,
+		&playbook.CreatedBy,
+		&playbook.CreatedAt,
+		&playbook.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	playbook.Description = description.String
+	if err := json.Unmarshal(steps, &playbook.Steps); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal playbook steps: %w", err)
+	}
+	if len(trigger) > 0 {
+		playbook.Trigger = &domain.PlaybookTrigger{}
+		if err := json.Unmarshal(trigger, playbook.Trigger); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal playbook trigger: %w", err)
+		}
+	}
+
+	return &playbook, nil
+}
+
+// scanPlaybookExecution scans a playbook execution row
+func scanPlaybookExecution(row rowScanner) (*domain.PlaybookExecution, error) {
+	var execution domain.PlaybookExecution
+	var steps []byte
+	var execErr sql.NullString
+	var completedAt sql.NullTime
+
+	if err := row.Scan(
+		&execution.ID,
+		&execution.PlaybookID,
+		&execution.TargetID,
+		&execution.TargetType,
+		&execution.TriggeredBy,
+		&execution.Status,
+		&steps,
+		&execErr,
+		&execution.StartedAt,
+		&completedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	execution.Error = execErr.String
+	if completedAt.Valid {
+		execution.CompletedAt = &completedAt.Time
+	}
+	if err := json.Unmarshal(steps, &execution.Steps); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal execution steps: %w", err)
+	}
+
+	return &execution, nil
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
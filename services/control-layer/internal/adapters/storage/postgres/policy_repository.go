@@ -48,6 +48,11 @@ func (r *PostgresPolicyRepository) Close() error {
 	return r.db.Close()
 }
 
+// Ping checks that the database is reachable
+func (r *PostgresPolicyRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
 // tableName returns the prefixed table name
 func (r *PostgresPolicyRepository) tableName(name string) string {
 	return r.tablePrefix + name
@@ -56,7 +61,7 @@ func (r *PostgresPolicyRepository) tableName(name string) string {
 // GetPolicyByID retrieves a policy by its ID
 func (r *PostgresPolicyRepository) GetPolicyByID(ctx context.Context, id uuid.UUID) (*domain.Policy, error) {
 	query := fmt.Sprintf(`
-		SELECT id, name, description, rule_json, priority, is_active, created_at, updated_at
+		SELECT id, name, description, jurisdiction, rule_json, priority, is_active, created_at, updated_at
 		FROM %s
 		WHERE id = $1
 	`, r.tableName("policies"))
@@ -69,6 +74,7 @@ func (r *PostgresPolicyRepository) GetPolicyByID(ctx context.Context, id uuid.UU
 		&policy.ID,
 		&policy.Name,
 		&description,
+		&policy.Jurisdiction,
 		&ruleJSON,
 		&policy.Priority,
 		&policy.IsActive,
@@ -98,7 +104,7 @@ func (r *PostgresPolicyRepository) GetPolicyByID(ctx context.Context, id uuid.UU
 // GetAllPolicies retrieves all policies
 func (r *PostgresPolicyRepository) GetAllPolicies(ctx context.Context) ([]*domain.Policy, error) {
 	query := fmt.Sprintf(`
-		SELECT id, name, description, rule_json, priority, is_active, created_at, updated_at
+		SELECT id, name, description, jurisdiction, rule_json, priority, is_active, created_at, updated_at
 		FROM %s
 		ORDER BY priority DESC, created_at ASC
 	`, r.tableName("policies"))
@@ -120,6 +126,7 @@ func (r *PostgresPolicyRepository) GetAllPolicies(ctx context.Context) ([]*domai
 			&policy.ID,
 			&policy.Name,
 			&description,
+			&policy.Jurisdiction,
 			&ruleJSON,
 			&policy.Priority,
 			&policy.IsActive,
@@ -151,7 +158,7 @@ func (r *PostgresPolicyRepository) GetAllPolicies(ctx context.Context) ([]*domai
 // GetActivePolicies retrieves all active policies
 func (r *PostgresPolicyRepository) GetActivePolicies(ctx context.Context) ([]*domain.Policy, error) {
 	query := fmt.Sprintf(`
-		SELECT id, name, description, rule_json, priority, is_active, created_at, updated_at
+		SELECT id, name, description, jurisdiction, rule_json, priority, is_active, created_at, updated_at
 		FROM %s
 		WHERE is_active = true
 		ORDER BY priority DESC, created_at ASC
@@ -174,6 +181,7 @@ func (r *PostgresPolicyRepository) GetActivePolicies(ctx context.Context) ([]*do
 			&policy.ID,
 			&policy.Name,
 			&description,
+			&policy.Jurisdiction,
 			&ruleJSON,
 			&policy.Priority,
 			&policy.IsActive,
@@ -210,14 +218,15 @@ func (r *PostgresPolicyRepository) CreatePolicy(ctx context.Context, policy *dom
 	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO %s (id, name, description, rule_json, priority, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO %s (id, name, description, jurisdiction, rule_json, priority, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`, r.tableName("policies"))
 
 	_, err = r.db.ExecContext(ctx, query,
 		policy.ID,
 		policy.Name,
 		policy.Description,
+		policy.Jurisdiction,
 		ruleJSON,
 		policy.Priority,
 		policy.IsActive,
@@ -295,7 +304,7 @@ func (r *PostgresPolicyRepository) DeletePolicy(ctx context.Context, id uuid.UUI
 // GetPoliciesByTarget retrieves policies targeting a specific service
 func (r *PostgresPolicyRepository) GetPoliciesByTarget(ctx context.Context, target string) ([]*domain.Policy, error) {
 	query := fmt.Sprintf(`
-		SELECT id, name, description, rule_json, priority, is_active, created_at, updated_at
+		SELECT id, name, description, jurisdiction, rule_json, priority, is_active, created_at, updated_at
 		FROM %s
 		WHERE is_active = true AND rule_json::text LIKE $1
 		ORDER BY priority DESC
@@ -319,6 +328,7 @@ func (r *PostgresPolicyRepository) GetPoliciesByTarget(ctx context.Context, targ
 			&policy.ID,
 			&policy.Name,
 			&description,
+			&policy.Jurisdiction,
 			&ruleJSON,
 			&policy.Priority,
 			&policy.IsActive,
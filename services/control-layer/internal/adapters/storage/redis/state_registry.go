@@ -39,6 +39,11 @@ func (c *RedisClient) Close() error {
 	return c.client.Close()
 }
 
+// Ping checks that Redis is reachable
+func (c *RedisClient) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
 // GetClient returns the underlying Redis client
 func (c *RedisClient) GetClient() *redis.Client {
 	return c.client
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -16,8 +17,35 @@ type Config struct {
 	// HTTP Server
 	HTTPPort int `mapstructure:"http_port"`
 
+	// ShutdownTimeout bounds draining in-flight work at shutdown, in seconds
+	ShutdownTimeout int `mapstructure:"shutdown_timeout"`
+
 	// gRPC Server
-	GRPCPort int `mapstructure:"grpc_port"`
+	GRPCPort                int  `mapstructure:"grpc_port"`
+	GRPCReflectionEnabled   bool `mapstructure:"grpc_reflection_enabled"`
+	GRPCHealthCheckInterval int  `mapstructure:"grpc_health_check_interval"`
+
+	// gRPC TLS. Cert, key and client CA are file paths or vault:// references
+	// and are reloaded while running, so certificates rotate without a restart.
+	GRPCTLSEnabled           bool   `mapstructure:"grpc_tls_enabled"`
+	GRPCTLSCert              string `mapstructure:"grpc_tls_cert"`
+	GRPCTLSKey               string `mapstructure:"grpc_tls_key"`
+	GRPCTLSClientCA          string `mapstructure:"grpc_tls_client_ca"`
+	GRPCTLSReloadInterval    int    `mapstructure:"grpc_tls_reload_interval"`
+	GRPCTLSCRLFiles          string `mapstructure:"grpc_tls_crl_files"`
+	GRPCTLSCRLURLs           string `mapstructure:"grpc_tls_crl_urls"`
+	GRPCTLSOCSP              bool   `mapstructure:"grpc_tls_ocsp"`
+	GRPCTLSOCSPSoftFail      bool   `mapstructure:"grpc_tls_ocsp_soft_fail"`
+	GRPCTLSRevocationRefresh int    `mapstructure:"grpc_tls_revocation_refresh"`
+
+	// Vault, for vault:// references
+	VaultAddr      string `mapstructure:"vault_addr"`
+	VaultToken     string `mapstructure:"vault_token"`
+	VaultNamespace string `mapstructure:"vault_namespace"`
+
+	// Policy Decision Point
+	PDPDecisionTTL   int `mapstructure:"pdp_decision_ttl"`
+	PDPAccessLogSize int `mapstructure:"pdp_access_log_size"`
 
 	// Database
 	DatabaseURL     string `mapstructure:"database_url"`
@@ -38,11 +66,18 @@ type Config struct {
 	PolicyCacheTTL     int  `mapstructure:"policy_cache_ttl"`
 	PolicyHotReload    bool `mapstructure:"policy_hot_reload"`
 	EvaluationTimeout  int  `mapstructure:"evaluation_timeout_ms"`
+	PolicyConflictMode string `mapstructure:"policy_conflict_mode"`
 
 	// Enforcement
 	EnforcementRetryAttempts int `mapstructure:"enforcement_retry_attempts"`
 	EnforcementRetryDelay    int `mapstructure:"enforcement_retry_delay_ms"`
 
+	// Emergency Stops
+	EmergencyResumeCheckInterval int `mapstructure:"emergency_resume_check_interval"`
+
+	// Playbooks
+	PlaybookAlertTopics string `mapstructure:"playbook_alert_topics"`
+
 	// Monitoring
 	MetricsEnabled bool   `mapstructure:"metrics_enabled"`
 	MetricsPort    int    `mapstructure:"metrics_port"`
@@ -94,7 +129,25 @@ func loadFromDefaults() *Config {
 		ServiceName:         viper.GetString("service_name"),
 		LogLevel:            viper.GetString("log_level"),
 		HTTPPort:            viper.GetInt("http_port"),
+		ShutdownTimeout:     viper.GetInt("shutdown_timeout"),
 		GRPCPort:            viper.GetInt("grpc_port"),
+		GRPCReflectionEnabled:   viper.GetBool("grpc_reflection_enabled"),
+		GRPCHealthCheckInterval: viper.GetInt("grpc_health_check_interval"),
+		GRPCTLSEnabled:           viper.GetBool("grpc_tls_enabled"),
+		GRPCTLSCert:              viper.GetString("grpc_tls_cert"),
+		GRPCTLSKey:               viper.GetString("grpc_tls_key"),
+		GRPCTLSClientCA:          viper.GetString("grpc_tls_client_ca"),
+		GRPCTLSReloadInterval:    viper.GetInt("grpc_tls_reload_interval"),
+		GRPCTLSCRLFiles:          viper.GetString("grpc_tls_crl_files"),
+		GRPCTLSCRLURLs:           viper.GetString("grpc_tls_crl_urls"),
+		GRPCTLSOCSP:              viper.GetBool("grpc_tls_ocsp"),
+		GRPCTLSOCSPSoftFail:      viper.GetBool("grpc_tls_ocsp_soft_fail"),
+		GRPCTLSRevocationRefresh: viper.GetInt("grpc_tls_revocation_refresh"),
+		VaultAddr:                viper.GetString("vault_addr"),
+		VaultToken:               viper.GetString("vault_token"),
+		VaultNamespace:           viper.GetString("vault_namespace"),
+		PDPDecisionTTL:          viper.GetInt("pdp_decision_ttl"),
+		PDPAccessLogSize:        viper.GetInt("pdp_access_log_size"),
 		DatabaseURL:         viper.GetString("database_url"),
 		MaxOpenConn:         viper.GetInt("max_open_conn"),
 		MaxIdleConn:         viper.GetInt("max_idle_conn"),
@@ -107,8 +160,11 @@ func loadFromDefaults() *Config {
 		PolicyCacheTTL:      viper.GetInt("policy_cache_ttl"),
 		PolicyHotReload:     viper.GetBool("policy_hot_reload"),
 		EvaluationTimeout:   viper.GetInt("evaluation_timeout_ms"),
+		PolicyConflictMode:  viper.GetString("policy_conflict_mode"),
 		EnforcementRetryAttempts: viper.GetInt("enforcement_retry_attempts"),
 		EnforcementRetryDelay:    viper.GetInt("enforcement_retry_delay_ms"),
+		EmergencyResumeCheckInterval: viper.GetInt("emergency_resume_check_interval"),
+		PlaybookAlertTopics: viper.GetString("playbook_alert_topics"),
 		MetricsEnabled:      viper.GetBool("metrics_enabled"),
 		MetricsPort:         viper.GetInt("metrics_port"),
 		HealthCheckTTL:      viper.GetInt("health_check_ttl"),
@@ -125,7 +181,17 @@ func setDefaults() {
 	viper.SetDefault("service_name", "control-layer")
 	viper.SetDefault("log_level", "info")
 	viper.SetDefault("http_port", 8080)
+	viper.SetDefault("shutdown_timeout", 30)
 	viper.SetDefault("grpc_port", 9090)
+	viper.SetDefault("grpc_reflection_enabled", false)
+	viper.SetDefault("grpc_health_check_interval", 10)
+	viper.SetDefault("grpc_tls_enabled", false)
+	viper.SetDefault("grpc_tls_reload_interval", 60)
+	viper.SetDefault("grpc_tls_ocsp", false)
+	viper.SetDefault("grpc_tls_ocsp_soft_fail", false)
+	viper.SetDefault("grpc_tls_revocation_refresh", 3600)
+	viper.SetDefault("pdp_decision_ttl", 30)
+	viper.SetDefault("pdp_access_log_size", 1000)
 	viper.SetDefault("max_open_conn", 25)
 	viper.SetDefault("max_idle_conn", 5)
 	viper.SetDefault("conn_max_lifetime", 300)
@@ -135,8 +201,11 @@ func setDefaults() {
 	viper.SetDefault("policy_cache_ttl", 300)
 	viper.SetDefault("policy_hot_reload", true)
 	viper.SetDefault("evaluation_timeout_ms", 100)
+	viper.SetDefault("policy_conflict_mode", "block")
 	viper.SetDefault("enforcement_retry_attempts", 3)
 	viper.SetDefault("enforcement_retry_delay_ms", 1000)
+	viper.SetDefault("emergency_resume_check_interval", 15)
+	viper.SetDefault("playbook_alert_topics", "risk.alerts")
 	viper.SetDefault("metrics_enabled", true)
 	viper.SetDefault("metrics_port", 9090)
 	viper.SetDefault("health_check_ttl", 30)
@@ -157,6 +226,33 @@ func validateConfig(cfg *Config) error {
 	if cfg.GRPCPort <= 0 || cfg.GRPCPort > 65535 {
 		return fmt.Errorf("invalid grpc_port: %d", cfg.GRPCPort)
 	}
+	if cfg.EnableAuth && cfg.JWTSecret == "" {
+		return fmt.Errorf("jwt_secret is required when enable_auth is set")
+	}
+	if cfg.GRPCReflectionEnabled && cfg.Environment == "production" {
+		return fmt.Errorf("grpc_reflection_enabled must not be set in production")
+	}
+	if cfg.GRPCTLSEnabled {
+		if cfg.GRPCTLSCert == "" || cfg.GRPCTLSKey == "" {
+			return fmt.Errorf("grpc_tls_cert and grpc_tls_key are required when grpc_tls_enabled is set")
+		}
+		if cfg.GRPCTLSUsesVault() {
+			if cfg.VaultAddr == "" || cfg.VaultToken == "" {
+				return fmt.Errorf("vault_addr and vault_token are required for vault:// TLS references")
+			}
+			for _, value := range []string{cfg.GRPCTLSCert, cfg.GRPCTLSKey, cfg.GRPCTLSClientCA} {
+				if value != "" && !strings.HasPrefix(value, "vault://") {
+					return fmt.Errorf("gRPC TLS material must all come from files or all from Vault, got %q", value)
+				}
+			}
+		}
+		if cfg.GRPCTLSClientCA == "" && (cfg.GRPCTLSCRLFiles != "" || cfg.GRPCTLSCRLURLs != "" || cfg.GRPCTLSOCSP) {
+			return fmt.Errorf("grpc_tls_client_ca is required for CRL or OCSP checking of client certificates")
+		}
+	}
+	if cfg.PolicyConflictMode != "block" && cfg.PolicyConflictMode != "warn" {
+		return fmt.Errorf("invalid policy_conflict_mode: %s (must be block or warn)", cfg.PolicyConflictMode)
+	}
 	return nil
 }
 
@@ -168,6 +264,90 @@ func (c *Config) GetAllowedOrigins() []string {
 	return strings.Split(c.AllowedOrigins, ",")
 }
 
+// GetShutdownTimeout returns how long in-flight work may take to drain at shutdown
+func (c *Config) GetShutdownTimeout() time.Duration {
+	if c.ShutdownTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.ShutdownTimeout) * time.Second
+}
+
+// GetGRPCHealthCheckInterval returns how often gRPC health dependencies are checked
+func (c *Config) GetGRPCHealthCheckInterval() time.Duration {
+	if c.GRPCHealthCheckInterval <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.GRPCHealthCheckInterval) * time.Second
+}
+
+// GRPCTLSUsesVault reports whether any gRPC TLS material is read from Vault
+func (c *Config) GRPCTLSUsesVault() bool {
+	for _, value := range []string{c.GRPCTLSCert, c.GRPCTLSKey, c.GRPCTLSClientCA} {
+		if strings.HasPrefix(value, "vault://") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGRPCTLSReloadInterval returns how often gRPC TLS material is checked for rotation
+func (c *Config) GetGRPCTLSReloadInterval() time.Duration {
+	if c.GRPCTLSReloadInterval <= 0 {
+		return time.Minute
+	}
+	return time.Duration(c.GRPCTLSReloadInterval) * time.Second
+}
+
+// GetGRPCTLSRevocationRefresh returns how often CRLs are reloaded
+func (c *Config) GetGRPCTLSRevocationRefresh() time.Duration {
+	if c.GRPCTLSRevocationRefresh <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.GRPCTLSRevocationRefresh) * time.Second
+}
+
+// GetGRPCTLSCRLFiles returns the CRL files client certificates are checked against
+func (c *Config) GetGRPCTLSCRLFiles() []string {
+	return splitList(c.GRPCTLSCRLFiles)
+}
+
+// GetGRPCTLSCRLURLs returns the CRL URLs client certificates are checked against
+func (c *Config) GetGRPCTLSCRLURLs() []string {
+	return splitList(c.GRPCTLSCRLURLs)
+}
+
+// GetPDPDecisionTTL returns how long callers may cache access decisions
+func (c *Config) GetPDPDecisionTTL() time.Duration {
+	if c.PDPDecisionTTL < 0 {
+		return 0
+	}
+	return time.Duration(c.PDPDecisionTTL) * time.Second
+}
+
+// GetEmergencyResumeCheckInterval returns how often scheduled emergency stop resumes are checked
+func (c *Config) GetEmergencyResumeCheckInterval() time.Duration {
+	if c.EmergencyResumeCheckInterval <= 0 {
+		return 15 * time.Second
+	}
+	return time.Duration(c.EmergencyResumeCheckInterval) * time.Second
+}
+
+// GetPlaybookAlertTopics returns the alert topics that may trigger playbooks
+func (c *Config) GetPlaybookAlertTopics() []string {
+	return splitList(c.PlaybookAlertTopics)
+}
+
+// splitList splits a comma-separated setting, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // GetRedisAddr returns the Redis address
 func (c *Config) GetRedisAddr() string {
 	return c.RedisAddr
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmergencyStop represents an emergency halt of platform activity within a scope
+type EmergencyStop struct {
+	ID              uuid.UUID           `json:"id" db:"id"`
+	Scope           EmergencyScope      `json:"scope" db:"scope"`
+	Target          string              `json:"target,omitempty" db:"target"`
+	Reason          string              `json:"reason" db:"reason"`
+	LegalBasisID    *uuid.UUID          `json:"legal_basis_id,omitempty" db:"legal_basis_id"`
+	LegalBasisCode  string              `json:"legal_basis_code,omitempty" db:"legal_basis_code"` // empty for stops issued before the catalogue
+	Status          EmergencyStopStatus `json:"status" db:"status"`
+	ThrottlePercent int                 `json:"throttle_percent" db:"throttle_percent"`
+	InitiatedBy     string              `json:"initiated_by" db:"initiated_by"`
+	ResumeAt        *time.Time          `json:"resume_at,omitempty" db:"resume_at"`
+	ResumedBy       string              `json:"resumed_by,omitempty" db:"resumed_by"`
+	ResumedAt       *time.Time          `json:"resumed_at,omitempty" db:"resumed_at"`
+	ResumeReason    string              `json:"resume_reason,omitempty" db:"resume_reason"`
+	CreatedAt       time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// EmergencyScope represents what an emergency stop halts
+type EmergencyScope string
+
+const (
+	// EmergencyScopeGlobal halts all mutating activity on the platform
+	EmergencyScopeGlobal EmergencyScope = "GLOBAL"
+	// EmergencyScopeRegion halts exchanges and miners in one region
+	EmergencyScopeRegion EmergencyScope = "REGION"
+	// EmergencyScopeExchange halts a single exchange
+	EmergencyScopeExchange EmergencyScope = "EXCHANGE"
+	// EmergencyScopeTransaction halts transaction submission and transfers
+	EmergencyScopeTransaction EmergencyScope = "TRANSACTION"
+)
+
+// EmergencyStopStatus represents the status of an emergency stop
+type EmergencyStopStatus string
+
+const (
+	EmergencyStopActive  EmergencyStopStatus = "active"
+	EmergencyStopResumed EmergencyStopStatus = "resumed"
+)
+
+// Enforcement command actions issued by emergency stops
+const (
+	EnforcementUnfreeze   EnforcementType = "unfreeze"
+	EnforcementUnthrottle EnforcementType = "unthrottle"
+)
+
+var (
+	// ErrInvalidEmergencyScope is returned for an unknown scope or a scoped
+	// stop without a target
+	ErrInvalidEmergencyScope = errors.New("invalid emergency stop scope")
+	// ErrEmergencyStopNotFound is returned when an emergency stop does not exist
+	ErrEmergencyStopNotFound = errors.New("emergency stop not found")
+	// ErrEmergencyStopNotActive is returned when resuming a stop that is not active
+	ErrEmergencyStopNotActive = errors.New("emergency stop is not active")
+)
+
+// Validate checks the scope and target of an emergency stop
+func (s EmergencyScope) Validate(target string) error {
+	switch s {
+	case EmergencyScopeGlobal, EmergencyScopeTransaction:
+		return nil
+	case EmergencyScopeRegion, EmergencyScopeExchange:
+		if target == "" {
+			return ErrInvalidEmergencyScope
+		}
+		return nil
+	default:
+		return ErrInvalidEmergencyScope
+	}
+}
+
+// CreateEmergencyStopRequest represents a request to issue an emergency stop
+type CreateEmergencyStopRequest struct {
+	Scope  EmergencyScope `json:"scope" binding:"required"`
+	Target string         `json:"target"`
+	Reason string         `json:"reason" binding:"required"`
+	// LegalBasisID cites the catalogue entry empowering the stop
+	LegalBasisID uuid.UUID `json:"legal_basis_id" binding:"required"`
+	// ThrottlePercent is the share of capacity miners keep; 0 stops them
+	ThrottlePercent int `json:"throttle_percent" binding:"min=0,max=100"`
+	// ResumeAfterSeconds schedules an automatic resume; 0 keeps the stop
+	// until it is resumed manually
+	ResumeAfterSeconds int    `json:"resume_after_seconds"`
+	InitiatedBy        string `json:"initiated_by"`
+}
+
+// ResumeEmergencyStopRequest represents a request to lift an emergency stop
+type ResumeEmergencyStopRequest struct {
+	Reason    string `json:"reason" binding:"required"`
+	ResumedBy string `json:"resumed_by"`
+}
+
+// EmergencyEventType represents the type of an emergency event
+type EmergencyEventType string
+
+const (
+	EmergencyEventHalt   EmergencyEventType = "halt"
+	EmergencyEventResume EmergencyEventType = "resume"
+)
+
+// EmergencyEvent is broadcast to all services when an emergency stop is issued
+// or lifted. Events are keyed by stop ID so a compacted topic keeps the latest
+// event of every stop.
+type EmergencyEvent struct {
+	EventID   string             `json:"event_id"`
+	Type      EmergencyEventType `json:"type"`
+	Stop      *EmergencyStop     `json:"stop"`
+	Timestamp time.Time          `json:"timestamp"`
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LegalAction identifies the kind of enforcement action citing a legal basis
+type LegalAction string
+
+const (
+	LegalActionEmergencyStop LegalAction = "emergency_stop"
+	LegalActionWalletFreeze  LegalAction = "wallet_freeze"
+)
+
+// Valid reports whether the action is known
+func (a LegalAction) Valid() bool {
+	switch a {
+	case LegalActionEmergencyStop, LegalActionWalletFreeze:
+		return true
+	}
+	return false
+}
+
+// LegalBasis is a catalogue entry for a statute section that empowers
+// enforcement actions. Entries are cited by ID and are only citable within
+// their validity window.
+type LegalBasis struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"` // short citation, e.g. "VASP-2023 s.41(2)"
+	Statute     string    `json:"statute"`
+	Section     string    `json:"section"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	// Actions lists the enforcement actions the entry may be cited for; empty
+	// allows all
+	Actions   []LegalAction `json:"actions,omitempty"`
+	ValidFrom time.Time     `json:"valid_from"`
+	ValidTo   *time.Time    `json:"valid_to,omitempty"`
+	CreatedBy string        `json:"created_by"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+var (
+	// ErrLegalBasisNotFound is returned when a catalogue entry does not exist
+	ErrLegalBasisNotFound = errors.New("legal basis not found")
+	// ErrInvalidLegalBasis is returned for a catalogue entry that fails validation
+	ErrInvalidLegalBasis = errors.New("invalid legal basis")
+	// ErrLegalBasisCodeTaken is returned when another entry has the same code
+	ErrLegalBasisCodeTaken = errors.New("legal basis code already exists")
+	// ErrInvalidCitation is returned when an enforcement action cites a legal
+	// basis that is missing, not in force or does not cover the action
+	ErrInvalidCitation = errors.New("invalid legal basis citation")
+)
+
+// InForce reports whether the entry can be cited at the given time
+func (b *LegalBasis) InForce(at time.Time) bool {
+	if at.Before(b.ValidFrom) {
+		return false
+	}
+	return b.ValidTo == nil || at.Before(*b.ValidTo)
+}
+
+// Covers reports whether the entry may be cited for the action
+func (b *LegalBasis) Covers(action LegalAction) bool {
+	if len(b.Actions) == 0 {
+		return true
+	}
+	for _, a := range b.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCitation returns an ErrInvalidCitation error explaining why the entry
+// cannot be cited for the action at the given time, if it cannot
+func (b *LegalBasis) CheckCitation(action LegalAction, at time.Time) error {
+	switch {
+	case at.Before(b.ValidFrom):
+		return fmt.Errorf("%w: %s is not in force until %s", ErrInvalidCitation, b.Code, b.ValidFrom.UTC().Format(time.RFC3339))
+	case b.ValidTo != nil && !at.Before(*b.ValidTo):
+		return fmt.Errorf("%w: %s ceased to be in force at %s", ErrInvalidCitation, b.Code, b.ValidTo.UTC().Format(time.RFC3339))
+	case !b.Covers(action):
+		return fmt.Errorf("%w: %s does not cover %s", ErrInvalidCitation, b.Code, action)
+	}
+	return nil
+}
+
+// CreateLegalBasisRequest represents a request to add a catalogue entry
+type CreateLegalBasisRequest struct {
+	Code        string        `json:"code" binding:"required"`
+	Statute     string        `json:"statute" binding:"required"`
+	Section     string        `json:"section" binding:"required"`
+	Title       string        `json:"title" binding:"required"`
+	Description string        `json:"description"`
+	Actions     []LegalAction `json:"actions"`
+	ValidFrom   time.Time     `json:"valid_from" binding:"required"`
+	ValidTo     *time.Time    `json:"valid_to"`
+	CreatedBy   string        `json:"created_by"`
+}
+
+// UpdateLegalBasisRequest represents a change to a catalogue entry. The code
+// and statute reference are fixed once created so past citations keep their
+// meaning; an amended section is a new entry.
+type UpdateLegalBasisRequest struct {
+	Title       *string       `json:"title"`
+	Description *string       `json:"description"`
+	Actions     []LegalAction `json:"actions"`
+	ValidTo     *time.Time    `json:"valid_to"`
+}
+
+// Validate checks a new catalogue entry
+func (r *CreateLegalBasisRequest) Validate() error {
+	if strings.TrimSpace(r.Code) == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidLegalBasis)
+	}
+	return validateLegalBasisWindow(r.ValidFrom, r.ValidTo, r.Actions)
+}
+
+// validateLegalBasisWindow checks an entry's validity window and actions
+func validateLegalBasisWindow(validFrom time.Time, validTo *time.Time, actions []LegalAction) error {
+	if validTo != nil && !validTo.After(validFrom) {
+		return fmt.Errorf("%w: valid_to must be after valid_from", ErrInvalidLegalBasis)
+	}
+	for _, action := range actions {
+		if !action.Valid() {
+			return fmt.Errorf("%w: unknown action %q", ErrInvalidLegalBasis, action)
+		}
+	}
+	return nil
+}
+
+// Apply applies an update to the entry and validates the result
+func (r *UpdateLegalBasisRequest) Apply(b *LegalBasis) error {
+	if r.Title != nil {
+		if strings.TrimSpace(*r.Title) == "" {
+			return fmt.Errorf("%w: title cannot be empty", ErrInvalidLegalBasis)
+		}
+		b.Title = *r.Title
+	}
+	if r.Description != nil {
+		b.Description = *r.Description
+	}
+	if r.Actions != nil {
+		b.Actions = r.Actions
+	}
+	if r.ValidTo != nil {
+		b.ValidTo = r.ValidTo
+	}
+	return validateLegalBasisWindow(b.ValidFrom, b.ValidTo, b.Actions)
+}
+
+// LegalBasisActionCount aggregates the enforcement actions citing a legal
+// basis over a reporting period
+type LegalBasisActionCount struct {
+	LegalBasisID uuid.UUID   `json:"legal_basis_id"`
+	Code         string      `json:"code"`
+	Title        string      `json:"title"`
+	Action       LegalAction `json:"action"`
+	Count        int         `json:"count"`
+	Active       int         `json:"active"` // actions still in force
+}
+
+// LegalBasisReport aggregates enforcement actions by legal basis
+type LegalBasisReport struct {
+	From    time.Time                `json:"from"`
+	To      time.Time                `json:"to"`
+	Entries []*LegalBasisActionCount `json:"entries"`
+	Total   int                      `json:"total"`
+}
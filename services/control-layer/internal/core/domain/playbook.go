@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlaybookStepType identifies what a playbook step does
+type PlaybookStepType string
+
+const (
+	PlaybookStepFreezeWallet   PlaybookStepType = "freeze_wallet"
+	PlaybookStepNotifyExchange PlaybookStepType = "notify_exchange"
+	PlaybookStepOpenCase       PlaybookStepType = "open_case"
+	PlaybookStepGenerateReport PlaybookStepType = "generate_report"
+)
+
+// Valid reports whether the step type is supported
+func (t PlaybookStepType) Valid() bool {
+	switch t {
+	case PlaybookStepFreezeWallet, PlaybookStepNotifyExchange, PlaybookStepOpenCase, PlaybookStepGenerateReport:
+		return true
+	}
+	return false
+}
+
+// Playbook is a reusable, ordered sequence of intervention steps
+type Playbook struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Steps       []PlaybookStep   `json:"steps"`
+	Trigger     *PlaybookTrigger `json:"trigger,omitempty"`
+	Enabled     bool             `json:"enabled"`
+	CreatedBy   string           `json:"created_by"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// PlaybookStep is one step of a playbook. Parameters are passed to the
+// services carrying the step out, e.g. a report template or case priority.
+type PlaybookStep struct {
+	Name       string           `json:"name"`
+	Type       PlaybookStepType `json:"type"`
+	Parameters json.RawMessage  `json:"parameters,omitempty"`
+	// ContinueOnError runs the remaining steps when this one fails instead of
+	// rolling the execution back
+	ContinueOnError bool `json:"continue_on_error,omitempty"`
+}
+
+// PlaybookTrigger runs a playbook automatically when an alert from one of the
+// listed rules arrives. The target is read from the alert field TargetField.
+type PlaybookTrigger struct {
+	AlertRules  []string   `json:"alert_rules"` // rule IDs or names
+	TargetField string     `json:"target_field"`
+	TargetType  EntityType `json:"target_type"`
+}
+
+// Matches reports whether an alert raised by the rule triggers the playbook
+func (t *PlaybookTrigger) Matches(ruleID, ruleName string) bool {
+	for _, rule := range t.AlertRules {
+		if (ruleID != "" && rule == ruleID) || (ruleName != "" && rule == ruleName) {
+			return true
+		}
+	}
+	return false
+}
+
+// PlaybookExecutionStatus is the state of a playbook run
+type PlaybookExecutionStatus string
+
+const (
+	PlaybookExecutionRunning    PlaybookExecutionStatus = "running"
+	PlaybookExecutionCompleted  PlaybookExecutionStatus = "completed"
+	PlaybookExecutionFailed     PlaybookExecutionStatus = "failed"
+	PlaybookExecutionRolledBack PlaybookExecutionStatus = "rolled_back"
+)
+
+// PlaybookStepStatus is the state of one step in a playbook run
+type PlaybookStepStatus string
+
+const (
+	PlaybookStepPending        PlaybookStepStatus = "pending"
+	PlaybookStepRunning        PlaybookStepStatus = "running"
+	PlaybookStepCompleted      PlaybookStepStatus = "completed"
+	PlaybookStepFailed         PlaybookStepStatus = "failed"
+	PlaybookStepSkipped        PlaybookStepStatus = "skipped"
+	PlaybookStepRolledBack     PlaybookStepStatus = "rolled_back"
+	PlaybookStepRollbackFailed PlaybookStepStatus = "rollback_failed"
+)
+
+// PlaybookExecution is one run of a playbook against a target
+type PlaybookExecution struct {
+	ID          uuid.UUID               `json:"id"`
+	PlaybookID  uuid.UUID               `json:"playbook_id"`
+	TargetID    string                  `json:"target_id"`
+	TargetType  EntityType              `json:"target_type"`
+	TriggeredBy string                  `json:"triggered_by"` // user ID, or alert:<alert id>
+	Status      PlaybookExecutionStatus `json:"status"`
+	Steps       []PlaybookStepExecution `json:"steps"`
+	Error       string                  `json:"error,omitempty"`
+	StartedAt   time.Time               `json:"started_at"`
+	CompletedAt *time.Time              `json:"completed_at,omitempty"`
+}
+
+// PlaybookStepExecution records what happened to one step in a run
+type PlaybookStepExecution struct {
+	Name        string             `json:"name"`
+	Type        PlaybookStepType   `json:"type"`
+	Status      PlaybookStepStatus `json:"status"`
+	Result      json.RawMessage    `json:"result,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	StartedAt   *time.Time         `json:"started_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+}
+
+// PlaybookAction asks another service to carry out a playbook step, such as
+// opening a case or generating a report, or to undo one
+type PlaybookAction struct {
+	ActionID    string          `json:"action_id"`
+	Type        string          `json:"type"` // a PlaybookStepType, or cancel_case when rolling back
+	ExecutionID string          `json:"execution_id"`
+	PlaybookID  string          `json:"playbook_id"`
+	TargetID    string          `json:"target_id"`
+	TargetType  EntityType      `json:"target_type"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	RelatedID   string          `json:"related_id,omitempty"` // action being undone
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// CreatePlaybookRequest is the body of a playbook creation
+type CreatePlaybookRequest struct {
+	Name        string           `json:"name" binding:"required"`
+	Description string           `json:"description"`
+	Steps       []PlaybookStep   `json:"steps" binding:"required"`
+	Trigger     *PlaybookTrigger `json:"trigger,omitempty"`
+	Enabled     bool             `json:"enabled"`
+	CreatedBy   string           `json:"created_by"`
+}
+
+// Validate checks the steps and trigger of a playbook
+func (r *CreatePlaybookRequest) Validate() error {
+	if len(r.Steps) == 0 {
+		return fmt.Errorf("%w: at least one step is required", ErrInvalidPlaybook)
+	}
+	for i, step := range r.Steps {
+		if !step.Type.Valid() {
+			return fmt.Errorf("%w: step %d has unknown type %q", ErrInvalidPlaybook, i, step.Type)
+		}
+		if len(step.Parameters) > 0 && !json.Valid(step.Parameters) {
+			return fmt.Errorf("%w: step %d parameters are not valid JSON", ErrInvalidPlaybook, i)
+		}
+	}
+	if r.Trigger != nil {
+		if len(r.Trigger.AlertRules) == 0 {
+			return fmt.Errorf("%w: trigger requires alert_rules", ErrInvalidPlaybook)
+		}
+		if r.Trigger.TargetField == "" || r.Trigger.TargetType == "" {
+			return fmt.Errorf("%w: trigger requires target_field and target_type", ErrInvalidPlaybook)
+		}
+	}
+	return nil
+}
+
+// ExecutePlaybookRequest runs a playbook manually against a target
+type ExecutePlaybookRequest struct {
+	TargetID   string     `json:"target_id" binding:"required"`
+	TargetType EntityType `json:"target_type" binding:"required"`
+	ExecutedBy string     `json:"executed_by"`
+}
+
+var (
+	// ErrInvalidPlaybook is returned for a playbook with unknown steps or an
+	// incomplete trigger
+	ErrInvalidPlaybook = errors.New("invalid playbook")
+	// ErrPlaybookNotFound is returned when a playbook or execution does not exist
+	ErrPlaybookNotFound = errors.New("playbook not found")
+	// ErrPlaybookDisabled is returned when executing a disabled playbook
+	ErrPlaybookDisabled = errors.New("playbook is disabled")
+)
@@ -2,7 +2,10 @@ package domain
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Policy represents a regulatory policy rule
@@ -10,6 +13,7 @@ type Policy struct {
 	ID               string            `json:"id" db:"id"`
 	Name             string            `json:"name" db:"name"`
 	Description      string            `json:"description" db:"description"`
+	Jurisdiction     string            `json:"jurisdiction" db:"jurisdiction"`
 	RuleSet          json.RawMessage   `json:"rule_set" db:"rule_set"`
 	Version          int               `json:"version" db:"version"`
 	Severity         PolicySeverity    `json:"severity" db:"severity"`
@@ -107,10 +111,10 @@ type TimeRange struct {
 
 // ValueThresholds represents value thresholds for policy conditions
 type ValueThresholds struct {
-	MinValue      *float64 `json:"min_value,omitempty"`
-	MaxValue      *float64 `json:"max_value,omitempty"`
-	MaxDailyValue *float64 `json:"max_daily_value,omitempty"`
-	MaxMonthlyValue *float64 `json:"max_monthly_value,omitempty"`
+	MinValue      *decimal.Decimal `json:"min_value,omitempty"`
+	MaxValue      *decimal.Decimal `json:"max_value,omitempty"`
+	MaxDailyValue *decimal.Decimal `json:"max_daily_value,omitempty"`
+	MaxMonthlyValue *decimal.Decimal `json:"max_monthly_value,omitempty"`
 	Currency      string   `json:"currency,omitempty"`
 }
 
@@ -202,7 +206,7 @@ type PolicyEvaluationContext struct {
 type TransactionContext struct {
 	ID              string   `json:"id"`
 	Type            string   `json:"type"`
-	Amount          float64  `json:"amount"`
+	Amount          decimal.Decimal `json:"amount"`
 	Currency        string   `json:"currency"`
 	SourceWallet    string   `json:"source_wallet"`
 	DestWallet      string   `json:"dest_wallet"`
@@ -225,3 +229,38 @@ type EnergyContext struct {
 	GridRegion      string    `json:"grid_region"`
 	Timestamp       time.Time `json:"timestamp"`
 }
+
+// PolicySimulationResult compares the decision for one sample under the active
+// policy set with the decision once a draft policy is applied
+type PolicySimulationResult struct {
+	Index               int            `json:"index"`
+	CurrentDecision     DecisionResult `json:"current_decision"`
+	SimulatedDecision   DecisionResult `json:"simulated_decision"`
+	CurrentViolations   []string       `json:"current_violations,omitempty"`
+	SimulatedViolations []string       `json:"simulated_violations,omitempty"`
+}
+
+// Changed reports whether the draft policy changes the decision
+func (r *PolicySimulationResult) Changed() bool {
+	return r.CurrentDecision != r.SimulatedDecision
+}
+
+// ConflictMode controls how a policy write that contradicts an active policy
+// is handled
+type ConflictMode string
+
+const (
+	ConflictModeBlock ConflictMode = "block"
+	ConflictModeWarn  ConflictMode = "warn"
+)
+
+// ErrPolicyConflict is returned when a policy write is blocked because it
+// contradicts active policies
+var ErrPolicyConflict = errors.New("policy conflicts with active policies")
+
+// PolicyConflict is an active policy that contradicts a new or updated one
+type PolicyConflict struct {
+	PolicyID    string `json:"policy_id"`
+	PolicyName  string `json:"policy_name"`
+	Explanation string `json:"explanation"`
+}
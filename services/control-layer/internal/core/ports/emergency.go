@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// EmergencyStopRepository defines the interface for emergency stop persistence.
+type EmergencyStopRepository interface {
+	// CreateEmergencyStop stores a new emergency stop
+	CreateEmergencyStop(ctx context.Context, stop *domain.EmergencyStop) error
+
+	// GetEmergencyStopByID retrieves an emergency stop, or nil if it does not exist
+	GetEmergencyStopByID(ctx context.Context, id uuid.UUID) (*domain.EmergencyStop, error)
+
+	// GetActiveEmergencyStops retrieves all stops that have not been resumed
+	GetActiveEmergencyStops(ctx context.Context) ([]*domain.EmergencyStop, error)
+
+	// GetEmergencyStopsDueForResume retrieves active stops whose resume time has passed
+	GetEmergencyStopsDueForResume(ctx context.Context, now time.Time) ([]*domain.EmergencyStop, error)
+
+	// ResumeEmergencyStop marks an active stop as resumed. It returns
+	// domain.ErrEmergencyStopNotActive if the stop was already resumed.
+	ResumeEmergencyStop(ctx context.Context, id uuid.UUID, resumedBy, reason string, resumedAt time.Time) error
+
+	// Ping checks that the underlying database is reachable
+	Ping(ctx context.Context) error
+}
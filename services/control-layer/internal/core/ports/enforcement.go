@@ -74,6 +74,9 @@ type EnforcementRepository interface {
 
 	// BulkAssign updates the assignee for multiple enforcement actions
 	BulkAssign(ctx context.Context, ids []string, assigneeID string) error
+
+	// Ping checks that the underlying database is reachable
+	Ping(ctx context.Context) error
 }
 
 // EnforcementService defines the interface for enforcement business logic operations.
@@ -107,6 +107,9 @@ type InterventionRepository interface {
 
 	// GetEffectivenessMetrics retrieves effectiveness metrics for interventions
 	GetEffectivenessMetrics(ctx context.Context, filter *domain.EffectivenessFilter) (*domain.EffectivenessMetrics, error)
+
+	// Ping checks that the underlying database is reachable
+	Ping(ctx context.Context) error
 }
 
 // InterventionService defines the interface for intervention business logic operations.
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// LegalBasisRepository defines the interface for legal basis catalogue persistence.
+type LegalBasisRepository interface {
+	// CreateLegalBasis stores a new catalogue entry. It returns
+	// domain.ErrLegalBasisCodeTaken if another entry has the same code.
+	CreateLegalBasis(ctx context.Context, basis *domain.LegalBasis) error
+
+	// GetLegalBasisByID retrieves a catalogue entry, or nil if it does not exist
+	GetLegalBasisByID(ctx context.Context, id uuid.UUID) (*domain.LegalBasis, error)
+
+	// ListLegalBases retrieves all catalogue entries ordered by code
+	ListLegalBases(ctx context.Context) ([]*domain.LegalBasis, error)
+
+	// UpdateLegalBasis stores the title, description, actions and validity end of an entry
+	UpdateLegalBasis(ctx context.Context, basis *domain.LegalBasis) error
+
+	// CountActionsByLegalBasis counts the enforcement actions recorded by this
+	// service that cite each catalogue entry and were taken in [from, to)
+	CountActionsByLegalBasis(ctx context.Context, from, to time.Time) ([]*domain.LegalBasisActionCount, error)
+
+	// Ping checks that the underlying database is reachable
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// PlaybookRepository defines the interface for playbook and playbook execution persistence.
+type PlaybookRepository interface {
+	// CreatePlaybook stores a new playbook
+	CreatePlaybook(ctx context.Context, playbook *domain.Playbook) error
+
+	// GetPlaybookByID retrieves a playbook, or nil if it does not exist
+	GetPlaybookByID(ctx context.Context, id uuid.UUID) (*domain.Playbook, error)
+
+	// ListPlaybooks retrieves all playbooks, newest first
+	ListPlaybooks(ctx context.Context) ([]*domain.Playbook, error)
+
+	// GetTriggeredPlaybooks retrieves the enabled playbooks that have an alert trigger
+	GetTriggeredPlaybooks(ctx context.Context) ([]*domain.Playbook, error)
+
+	// DeletePlaybook removes a playbook and its execution history. It returns
+	// domain.ErrPlaybookNotFound if the playbook does not exist.
+	DeletePlaybook(ctx context.Context, id uuid.UUID) error
+
+	// CreateExecution stores a new playbook execution
+	CreateExecution(ctx context.Context, execution *domain.PlaybookExecution) error
+
+	// UpdateExecution stores the status and step states of an execution
+	UpdateExecution(ctx context.Context, execution *domain.PlaybookExecution) error
+
+	// GetExecutionByID retrieves an execution, or nil if it does not exist
+	GetExecutionByID(ctx context.Context, id uuid.UUID) (*domain.PlaybookExecution, error)
+
+	// ListExecutions retrieves the executions of a playbook, newest first
+	ListExecutions(ctx context.Context, playbookID uuid.UUID, limit int) ([]*domain.PlaybookExecution, error)
+
+	// Ping checks that the underlying database is reachable
+	Ping(ctx context.Context) error
+}
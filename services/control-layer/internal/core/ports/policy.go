@@ -57,6 +57,9 @@ type PolicyRepository interface {
 
 	// CreateVersion creates a new version of an existing policy
 	CreateVersion(ctx context.Context, policyID string, version *domain.PolicyVersion) error
+
+	// Ping checks that the underlying database is reachable
+	Ping(ctx context.Context) error
 }
 
 // PolicyService defines the interface for policy business logic operations.
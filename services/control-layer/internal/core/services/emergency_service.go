@@ -0,0 +1,381 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"csic-platform/control-layer/internal/core/domain"
+	"csic-platform/control-layer/internal/core/ports"
+	"csic-platform/control-layer/pkg/metrics"
+)
+
+// allEntities targets every entity of a type in an enforcement command
+const allEntities = "*"
+
+// EmergencyService issues and lifts emergency stops
+type EmergencyService interface {
+	ListActiveStops(ctx context.Context) ([]*domain.EmergencyStop, error)
+	GetStop(ctx context.Context, id string) (*domain.EmergencyStop, error)
+	IssueStop(ctx context.Context, req *domain.CreateEmergencyStopRequest) (*domain.EmergencyStop, error)
+	ResumeStop(ctx context.Context, id string, req *domain.ResumeEmergencyStopRequest) (*domain.EmergencyStop, error)
+	StartResumeMonitor(logger *zap.Logger)
+	StopResumeMonitor()
+}
+
+// EmergencyServiceService implements the EmergencyService interface
+type EmergencyServiceService struct {
+	repositories  ports.Repositories
+	messagingPort ports.MessagingPort
+	logger        *zap.Logger
+	metrics       *metrics.MetricsCollector
+	checkInterval time.Duration
+	stopCh        chan struct{}
+	monitor       sync.WaitGroup
+}
+
+// NewEmergencyService creates a new emergency service
+func NewEmergencyService(
+	repositories ports.Repositories,
+	messagingPort ports.MessagingPort,
+	logger *zap.Logger,
+	metricsCollector *metrics.MetricsCollector,
+	checkInterval time.Duration,
+) EmergencyService {
+	if checkInterval <= 0 {
+		checkInterval = 15 * time.Second
+	}
+
+	return &EmergencyServiceService{
+		repositories:  repositories,
+		messagingPort: messagingPort,
+		logger:        logger,
+		metrics:       metricsCollector,
+		checkInterval: checkInterval,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// ListActiveStops lists the emergency stops in force
+func (s *EmergencyServiceService) ListActiveStops(ctx context.Context) ([]*domain.EmergencyStop, error) {
+	return s.repositories.EmergencyStopRepository.GetActiveEmergencyStops(ctx)
+}
+
+// GetStop gets an emergency stop by ID
+func (s *EmergencyServiceService) GetStop(ctx context.Context, id string) (*domain.EmergencyStop, error) {
+	stopUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emergency stop ID: %w", err)
+	}
+
+	return s.repositories.EmergencyStopRepository.GetEmergencyStopByID(ctx, stopUUID)
+}
+
+// IssueStop records an emergency stop and fans it out: a halt event is
+// broadcast to all services, and exchanges and miners in scope receive freeze
+// orders and throttle commands. The stop must cite a legal basis in force.
+func (s *EmergencyServiceService) IssueStop(ctx context.Context, req *domain.CreateEmergencyStopRequest) (*domain.EmergencyStop, error) {
+	if err := req.Scope.Validate(req.Target); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	basis, err := resolveCitation(ctx, s.repositories.LegalBasisRepository, req.LegalBasisID, domain.LegalActionEmergencyStop, now)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := &domain.EmergencyStop{
+		ID:              uuid.New(),
+		Scope:           req.Scope,
+		Target:          req.Target,
+		Reason:          req.Reason,
+		LegalBasisID:    &basis.ID,
+		LegalBasisCode:  basis.Code,
+		Status:          domain.EmergencyStopActive,
+		ThrottlePercent: req.ThrottlePercent,
+		InitiatedBy:     req.InitiatedBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if stop.Scope == domain.EmergencyScopeGlobal || stop.Scope == domain.EmergencyScopeTransaction {
+		stop.Target = ""
+	}
+	if req.ResumeAfterSeconds > 0 {
+		resumeAt := now.Add(time.Duration(req.ResumeAfterSeconds) * time.Second)
+		stop.ResumeAt = &resumeAt
+	}
+
+	if err := s.repositories.EmergencyStopRepository.CreateEmergencyStop(ctx, stop); err != nil {
+		return nil, fmt.Errorf("failed to create emergency stop: %w", err)
+	}
+
+	// The stop is recorded; fan-out failures are logged rather than returned
+	// so the caller does not retry and issue a second stop
+	s.publishEvent(domain.EmergencyEventHalt, stop)
+	s.publishCommands(s.haltCommands(stop))
+	s.updateActiveMetrics(ctx)
+
+	s.logger.Warn("Emergency stop issued",
+		zap.String("stop_id", stop.ID.String()),
+		zap.String("scope", string(stop.Scope)),
+		zap.String("target", stop.Target),
+		zap.String("initiated_by", stop.InitiatedBy),
+		zap.String("reason", stop.Reason),
+		zap.String("legal_basis", stop.LegalBasisCode),
+	)
+
+	return stop, nil
+}
+
+// ResumeStop lifts an emergency stop. Freeze orders and throttle commands are
+// only reversed for entities not still covered by another active stop.
+func (s *EmergencyServiceService) ResumeStop(ctx context.Context, id string, req *domain.ResumeEmergencyStopRequest) (*domain.EmergencyStop, error) {
+	stop, err := s.GetStop(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stop == nil {
+		return nil, domain.ErrEmergencyStopNotFound
+	}
+
+	return s.resume(ctx, stop, req.ResumedBy, req.Reason)
+}
+
+// StartResumeMonitor starts the background monitor that resumes stops whose
+// resume time has passed
+func (s *EmergencyServiceService) StartResumeMonitor(logger *zap.Logger) {
+	s.monitor.Add(1)
+	go func() {
+		defer s.monitor.Done()
+		ticker := time.NewTicker(s.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.resumeDueStops()
+			case <-s.stopCh:
+				logger.Info("Emergency resume monitor stopped")
+				return
+			}
+		}
+	}()
+
+	logger.Info("Emergency resume monitor started", zap.Duration("interval", s.checkInterval))
+}
+
+// StopResumeMonitor stops the background resume monitor, waiting for resumes
+// in progress to finish
+func (s *EmergencyServiceService) StopResumeMonitor() {
+	close(s.stopCh)
+	s.monitor.Wait()
+}
+
+// resume marks a stop as resumed and fans out the resume
+func (s *EmergencyServiceService) resume(ctx context.Context, stop *domain.EmergencyStop, resumedBy, reason string) (*domain.EmergencyStop, error) {
+	if stop.Status != domain.EmergencyStopActive {
+		return nil, domain.ErrEmergencyStopNotActive
+	}
+
+	now := time.Now().UTC()
+	if err := s.repositories.EmergencyStopRepository.ResumeEmergencyStop(ctx, stop.ID, resumedBy, reason, now); err != nil {
+		return nil, err
+	}
+	stop.Status = domain.EmergencyStopResumed
+	stop.ResumedBy = resumedBy
+	stop.ResumeReason = reason
+	stop.ResumedAt = &now
+	stop.UpdatedAt = now
+
+	s.publishEvent(domain.EmergencyEventResume, stop)
+
+	remaining, err := s.repositories.EmergencyStopRepository.GetActiveEmergencyStops(ctx)
+	if err != nil {
+		// Without the remaining stops it is unknown what is safe to lift;
+		// the halt event is resumed but freezes stay until lifted manually
+		s.logger.Error("Failed to load active emergency stops, not reversing enforcement",
+			zap.String("stop_id", stop.ID.String()),
+			zap.Error(err),
+		)
+	} else {
+		s.publishCommands(s.resumeCommands(stop, remaining))
+	}
+	s.updateActiveMetrics(ctx)
+
+	s.logger.Info("Emergency stop resumed",
+		zap.String("stop_id", stop.ID.String()),
+		zap.String("scope", string(stop.Scope)),
+		zap.String("target", stop.Target),
+		zap.String("resumed_by", resumedBy),
+		zap.String("reason", reason),
+	)
+
+	return stop, nil
+}
+
+// resumeDueStops resumes the stops whose resume time has passed
+func (s *EmergencyServiceService) resumeDueStops() {
+	ctx := context.Background()
+
+	stops, err := s.repositories.EmergencyStopRepository.GetEmergencyStopsDueForResume(ctx, time.Now().UTC())
+	if err != nil {
+		s.logger.Error("Failed to get emergency stops due for resume", zap.Error(err))
+		return
+	}
+
+	for _, stop := range stops {
+		if _, err := s.resume(ctx, stop, "system:auto-resume", "scheduled resume time reached"); err != nil {
+			s.logger.Error("Failed to auto-resume emergency stop",
+				zap.String("stop_id", stop.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// haltCommands returns the enforcement commands that put a stop in force
+func (s *EmergencyServiceService) haltCommands(stop *domain.EmergencyStop) []*domain.EnforcementCommand {
+	switch stop.Scope {
+	case domain.EmergencyScopeGlobal:
+		return []*domain.EnforcementCommand{
+			s.command(stop, domain.EnforcementFreeze, domain.EntityTypeExchange, allEntities),
+			s.command(stop, domain.EnforcementThrottle, domain.EntityTypeMiner, allEntities),
+		}
+	case domain.EmergencyScopeRegion:
+		return []*domain.EnforcementCommand{
+			s.command(stop, domain.EnforcementFreeze, domain.EntityTypeExchange, allEntities),
+			s.command(stop, domain.EnforcementThrottle, domain.EntityTypeMiner, allEntities),
+		}
+	case domain.EmergencyScopeExchange:
+		return []*domain.EnforcementCommand{
+			s.command(stop, domain.EnforcementFreeze, domain.EntityTypeExchange, stop.Target),
+		}
+	case domain.EmergencyScopeTransaction:
+		// Transaction stops are enforced by the halt event alone: the gateway
+		// and transaction services reject new submissions and transfers
+		return nil
+	default:
+		return nil
+	}
+}
+
+// resumeCommands returns the enforcement commands that reverse a stop's halt
+// commands, skipping those still required by another active stop
+func (s *EmergencyServiceService) resumeCommands(stop *domain.EmergencyStop, active []*domain.EmergencyStop) []*domain.EnforcementCommand {
+	for _, other := range active {
+		if other.ID != stop.ID && covers(other, stop) {
+			return nil
+		}
+	}
+
+	var commands []*domain.EnforcementCommand
+	for _, halt := range s.haltCommands(stop) {
+		action := domain.EnforcementUnfreeze
+		if halt.Action == domain.EnforcementThrottle {
+			action = domain.EnforcementUnthrottle
+		}
+		commands = append(commands, s.command(stop, action, halt.TargetType, halt.TargetID))
+	}
+	return commands
+}
+
+// covers reports whether every entity halted by stop is also halted by other
+func covers(other, stop *domain.EmergencyStop) bool {
+	switch other.Scope {
+	case domain.EmergencyScopeGlobal:
+		return stop.Scope != domain.EmergencyScopeTransaction
+	case domain.EmergencyScopeRegion, domain.EmergencyScopeExchange:
+		return other.Scope == stop.Scope && other.Target == stop.Target
+	default:
+		return false
+	}
+}
+
+// command builds an enforcement command issued by an emergency stop. Region
+// stops address all entities of a type and carry the region as a parameter.
+func (s *EmergencyServiceService) command(stop *domain.EmergencyStop, action domain.EnforcementType, targetType domain.EntityType, targetID string) *domain.EnforcementCommand {
+	params := map[string]interface{}{
+		"emergency_stop_id": stop.ID.String(),
+		"scope":             stop.Scope,
+	}
+	if stop.LegalBasisCode != "" {
+		params["legal_basis"] = stop.LegalBasisCode
+	}
+	if stop.Scope == domain.EmergencyScopeRegion {
+		params["region"] = stop.Target
+	}
+	if action == domain.EnforcementThrottle {
+		params["throttle_percent"] = stop.ThrottlePercent
+	}
+	parameters, _ := json.Marshal(params)
+
+	return &domain.EnforcementCommand{
+		CommandID:      uuid.New().String(),
+		Action:         action,
+		TargetID:       targetID,
+		TargetType:     targetType,
+		Reason:         stop.Reason,
+		Parameters:     parameters,
+		Priority:       100,
+		IdempotencyKey: fmt.Sprintf("emergency:%s:%s:%s:%s", stop.ID, action, targetType, targetID),
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// publishEvent broadcasts an emergency event
+func (s *EmergencyServiceService) publishEvent(eventType domain.EmergencyEventType, stop *domain.EmergencyStop) {
+	event := &domain.EmergencyEvent{
+		EventID:   uuid.New().String(),
+		Type:      eventType,
+		Stop:      stop,
+		Timestamp: time.Now().UTC(),
+	}
+	if err := s.messagingPort.Producer.PublishEmergencyEvent(event); err != nil {
+		s.logger.Error("Failed to publish emergency event",
+			zap.String("stop_id", stop.ID.String()),
+			zap.String("type", string(eventType)),
+			zap.Error(err),
+		)
+	}
+}
+
+// publishCommands sends enforcement commands
+func (s *EmergencyServiceService) publishCommands(commands []*domain.EnforcementCommand) {
+	for _, command := range commands {
+		if err := s.messagingPort.Producer.PublishEnforcementCommand(command); err != nil {
+			s.logger.Error("Failed to publish emergency enforcement command",
+				zap.String("action", string(command.Action)),
+				zap.String("target_type", string(command.TargetType)),
+				zap.String("target_id", command.TargetID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// updateActiveMetrics sets the active stop gauges by scope
+func (s *EmergencyServiceService) updateActiveMetrics(ctx context.Context) {
+	stops, err := s.repositories.EmergencyStopRepository.GetActiveEmergencyStops(ctx)
+	if err != nil {
+		return
+	}
+
+	counts := map[domain.EmergencyScope]int{
+		domain.EmergencyScopeGlobal:      0,
+		domain.EmergencyScopeRegion:      0,
+		domain.EmergencyScopeExchange:    0,
+		domain.EmergencyScopeTransaction: 0,
+	}
+	for _, stop := range stops {
+		counts[stop.Scope]++
+	}
+	for scope, count := range counts {
+		s.metrics.SetActiveEmergencyStops(string(scope), float64(count))
+	}
+}
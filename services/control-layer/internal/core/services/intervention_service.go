@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +22,7 @@ type InterventionService interface {
 	UpdateStatus(ctx context.Context, id string, status domain.InterventionStatus) error
 	Resolve(ctx context.Context, id string, resolution string) error
 	StartInterventionMonitor(logger *zap.Logger)
+	StopInterventionMonitor()
 	TriggerIntervention(ctx context.Context, policyID uuid.UUID, target, reason string, severity string, data map[string]interface{}) (*domain.Intervention, error)
 }
 
@@ -32,6 +34,7 @@ type InterventionServiceService struct {
 	logger        *zap.Logger
 	metrics       *metrics.MetricsCollector
 	stopCh        chan struct{}
+	monitor       sync.WaitGroup
 }
 
 // NewInterventionService creates a new intervention service
@@ -159,7 +162,9 @@ func (s *InterventionServiceService) Resolve(ctx context.Context, id string, res
 
 // StartInterventionMonitor starts the background intervention monitor
 func (s *InterventionServiceService) StartInterventionMonitor(logger *zap.Logger) {
+	s.monitor.Add(1)
 	go func() {
+		defer s.monitor.Done()
 		ticker := time.NewTicker(30 * time.Second)
 		defer ticker.Stop()
 
@@ -177,6 +182,13 @@ func (s *InterventionServiceService) StartInterventionMonitor(logger *zap.Logger
 	logger.Info("Intervention monitor started")
 }
 
+// StopInterventionMonitor stops the background intervention monitor, waiting
+// for a check in progress to finish
+func (s *InterventionServiceService) StopInterventionMonitor() {
+	close(s.stopCh)
+	s.monitor.Wait()
+}
+
 // TriggerIntervention triggers an intervention based on policy violation
 func (s *InterventionServiceService) TriggerIntervention(ctx context.Context, policyID uuid.UUID, target, reason string, severity string, data map[string]interface{}) (*domain.Intervention, error) {
 	req := &domain.CreateInterventionRequest{
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"csic-platform/control-layer/internal/core/domain"
+	"csic-platform/control-layer/internal/core/ports"
+)
+
+// LegalBasisService manages the legal basis catalogue and checks the citations
+// of enforcement actions against it
+type LegalBasisService interface {
+	CreateLegalBasis(ctx context.Context, req *domain.CreateLegalBasisRequest) (*domain.LegalBasis, error)
+	ListLegalBases(ctx context.Context, inForceAt *time.Time) ([]*domain.LegalBasis, error)
+	GetLegalBasis(ctx context.Context, id string) (*domain.LegalBasis, error)
+	UpdateLegalBasis(ctx context.Context, id string, req *domain.UpdateLegalBasisRequest) (*domain.LegalBasis, error)
+	CheckCitation(ctx context.Context, id string, action domain.LegalAction, at time.Time) (*domain.LegalBasis, error)
+	Report(ctx context.Context, from, to time.Time) (*domain.LegalBasisReport, error)
+}
+
+// LegalBasisServiceService implements the LegalBasisService interface
+type LegalBasisServiceService struct {
+	repositories ports.Repositories
+	logger       *zap.Logger
+}
+
+// NewLegalBasisService creates a new legal basis service
+func NewLegalBasisService(repositories ports.Repositories, logger *zap.Logger) LegalBasisService {
+	return &LegalBasisServiceService{
+		repositories: repositories,
+		logger:       logger,
+	}
+}
+
+// CreateLegalBasis validates and stores a catalogue entry
+func (s *LegalBasisServiceService) CreateLegalBasis(ctx context.Context, req *domain.CreateLegalBasisRequest) (*domain.LegalBasis, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	basis := &domain.LegalBasis{
+		ID:          uuid.New(),
+		Code:        strings.TrimSpace(req.Code),
+		Statute:     req.Statute,
+		Section:     req.Section,
+		Title:       req.Title,
+		Description: req.Description,
+		Actions:     req.Actions,
+		ValidFrom:   req.ValidFrom,
+		ValidTo:     req.ValidTo,
+		CreatedBy:   req.CreatedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repositories.LegalBasisRepository.CreateLegalBasis(ctx, basis); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Legal basis added to catalogue",
+		zap.String("legal_basis_id", basis.ID.String()),
+		zap.String("code", basis.Code),
+		zap.Time("valid_from", basis.ValidFrom),
+	)
+
+	return basis, nil
+}
+
+// ListLegalBases lists the catalogue, optionally only the entries in force at
+// the given time
+func (s *LegalBasisServiceService) ListLegalBases(ctx context.Context, inForceAt *time.Time) ([]*domain.LegalBasis, error) {
+	bases, err := s.repositories.LegalBasisRepository.ListLegalBases(ctx)
+	if err != nil || inForceAt == nil {
+		return bases, err
+	}
+
+	inForce := make([]*domain.LegalBasis, 0, len(bases))
+	for _, basis := range bases {
+		if basis.InForce(*inForceAt) {
+			inForce = append(inForce, basis)
+		}
+	}
+	return inForce, nil
+}
+
+// GetLegalBasis gets a catalogue entry by ID
+func (s *LegalBasisServiceService) GetLegalBasis(ctx context.Context, id string) (*domain.LegalBasis, error) {
+	basisUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrLegalBasisNotFound
+	}
+
+	basis, err := s.repositories.LegalBasisRepository.GetLegalBasisByID(ctx, basisUUID)
+	if err != nil {
+		return nil, err
+	}
+	if basis == nil {
+		return nil, domain.ErrLegalBasisNotFound
+	}
+
+	return basis, nil
+}
+
+// UpdateLegalBasis changes the title, description, covered actions or end of
+// validity of a catalogue entry. Setting valid_to repeals the entry from then.
+func (s *LegalBasisServiceService) UpdateLegalBasis(ctx context.Context, id string, req *domain.UpdateLegalBasisRequest) (*domain.LegalBasis, error) {
+	basis, err := s.GetLegalBasis(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Apply(basis); err != nil {
+		return nil, err
+	}
+	basis.UpdatedAt = time.Now().UTC()
+
+	if err := s.repositories.LegalBasisRepository.UpdateLegalBasis(ctx, basis); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Legal basis updated",
+		zap.String("legal_basis_id", basis.ID.String()),
+		zap.String("code", basis.Code),
+	)
+
+	return basis, nil
+}
+
+// CheckCitation returns the catalogue entry cited by an enforcement action, or
+// an ErrInvalidCitation error if it does not exist, is not in force at the
+// given time or does not cover the action
+func (s *LegalBasisServiceService) CheckCitation(ctx context.Context, id string, action domain.LegalAction, at time.Time) (*domain.LegalBasis, error) {
+	basisUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is not a legal basis ID", domain.ErrInvalidCitation, id)
+	}
+
+	return resolveCitation(ctx, s.repositories.LegalBasisRepository, basisUUID, action, at)
+}
+
+// Report aggregates the enforcement actions issued by this service in
+// [from, to) by the legal basis they cite
+func (s *LegalBasisServiceService) Report(ctx context.Context, from, to time.Time) (*domain.LegalBasisReport, error) {
+	counts, err := s.repositories.LegalBasisRepository.CountActionsByLegalBasis(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.LegalBasisReport{
+		From:    from,
+		To:      to,
+		Entries: counts,
+	}
+	if report.Entries == nil {
+		report.Entries = []*domain.LegalBasisActionCount{}
+	}
+	for _, count := range counts {
+		report.Total += count.Count
+	}
+
+	return report, nil
+}
+
+// resolveCitation loads a cited catalogue entry and checks it may be cited for
+// the action at the given time
+func resolveCitation(ctx context.Context, repo ports.LegalBasisRepository, id uuid.UUID, action domain.LegalAction, at time.Time) (*domain.LegalBasis, error) {
+	basis, err := repo.GetLegalBasisByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load legal basis: %w", err)
+	}
+	if basis == nil {
+		return nil, fmt.Errorf("%w: legal basis %s is not in the catalogue", domain.ErrInvalidCitation, id)
+	}
+
+	if err := basis.CheckCitation(action, at); err != nil {
+		return nil, err
+	}
+	return basis, nil
+}
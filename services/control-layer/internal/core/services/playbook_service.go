@@ -0,0 +1,479 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"csic-platform/control-layer/internal/core/domain"
+	"csic-platform/control-layer/internal/core/ports"
+
+	"github.com/csic-platform/shared/queue"
+)
+
+// cancelCaseAction undoes an open_case step
+const cancelCaseAction = "cancel_case"
+
+// PlaybookService manages intervention playbooks and runs them
+type PlaybookService interface {
+	CreatePlaybook(ctx context.Context, req *domain.CreatePlaybookRequest) (*domain.Playbook, error)
+	ListPlaybooks(ctx context.Context) ([]*domain.Playbook, error)
+	GetPlaybook(ctx context.Context, id string) (*domain.Playbook, error)
+	DeletePlaybook(ctx context.Context, id string) error
+	ExecutePlaybook(ctx context.Context, id string, req *domain.ExecutePlaybookRequest) (*domain.PlaybookExecution, error)
+	ListExecutions(ctx context.Context, playbookID string, limit int) ([]*domain.PlaybookExecution, error)
+	GetExecution(ctx context.Context, id string) (*domain.PlaybookExecution, error)
+	HandleAlert(ctx context.Context, msg *queue.Message) error
+}
+
+// PlaybookServiceService implements the PlaybookService interface
+type PlaybookServiceService struct {
+	repositories  ports.Repositories
+	messagingPort ports.MessagingPort
+	logger        *zap.Logger
+}
+
+// NewPlaybookService creates a new playbook service
+func NewPlaybookService(
+	repositories ports.Repositories,
+	messagingPort ports.MessagingPort,
+	logger *zap.Logger,
+) PlaybookService {
+	return &PlaybookServiceService{
+		repositories:  repositories,
+		messagingPort: messagingPort,
+		logger:        logger,
+	}
+}
+
+// stepResult is what a completed step records, and what its rollback reads
+type stepResult struct {
+	CommandID string `json:"command_id,omitempty"`
+	ActionID  string `json:"action_id,omitempty"`
+	TargetID  string `json:"target_id,omitempty"`
+}
+
+// CreatePlaybook validates and stores a playbook
+func (s *PlaybookServiceService) CreatePlaybook(ctx context.Context, req *domain.CreatePlaybookRequest) (*domain.Playbook, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	playbook := &domain.Playbook{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Description: req.Description,
+		Steps:       req.Steps,
+		Trigger:     req.Trigger,
+		Enabled:     req.Enabled,
+		CreatedBy:   req.CreatedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for i := range playbook.Steps {
+		if playbook.Steps[i].Name == "" {
+			playbook.Steps[i].Name = string(playbook.Steps[i].Type)
+		}
+	}
+
+	if err := s.repositories.PlaybookRepository.CreatePlaybook(ctx, playbook); err != nil {
+		return nil, fmt.Errorf("failed to create playbook: %w", err)
+	}
+
+	s.logger.Info("Playbook created",
+		zap.String("playbook_id", playbook.ID.String()),
+		zap.String("name", playbook.Name),
+		zap.Int("steps", len(playbook.Steps)),
+		zap.Bool("triggered", playbook.Trigger != nil),
+	)
+
+	return playbook, nil
+}
+
+// ListPlaybooks lists all playbooks
+func (s *PlaybookServiceService) ListPlaybooks(ctx context.Context) ([]*domain.Playbook, error) {
+	return s.repositories.PlaybookRepository.ListPlaybooks(ctx)
+}
+
+// GetPlaybook gets a playbook by ID
+func (s *PlaybookServiceService) GetPlaybook(ctx context.Context, id string) (*domain.Playbook, error) {
+	playbookUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrPlaybookNotFound
+	}
+
+	playbook, err := s.repositories.PlaybookRepository.GetPlaybookByID(ctx, playbookUUID)
+	if err != nil {
+		return nil, err
+	}
+	if playbook == nil {
+		return nil, domain.ErrPlaybookNotFound
+	}
+
+	return playbook, nil
+}
+
+// DeletePlaybook deletes a playbook together with its execution history
+func (s *PlaybookServiceService) DeletePlaybook(ctx context.Context, id string) error {
+	playbookUUID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.ErrPlaybookNotFound
+	}
+
+	return s.repositories.PlaybookRepository.DeletePlaybook(ctx, playbookUUID)
+}
+
+// ExecutePlaybook runs a playbook manually against a target
+func (s *PlaybookServiceService) ExecutePlaybook(ctx context.Context, id string, req *domain.ExecutePlaybookRequest) (*domain.PlaybookExecution, error) {
+	playbook, err := s.GetPlaybook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !playbook.Enabled {
+		return nil, domain.ErrPlaybookDisabled
+	}
+
+	return s.run(ctx, playbook, req.TargetID, req.TargetType, req.ExecutedBy)
+}
+
+// ListExecutions lists the most recent executions of a playbook
+func (s *PlaybookServiceService) ListExecutions(ctx context.Context, playbookID string, limit int) ([]*domain.PlaybookExecution, error) {
+	playbook, err := s.GetPlaybook(ctx, playbookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repositories.PlaybookRepository.ListExecutions(ctx, playbook.ID, limit)
+}
+
+// GetExecution gets a playbook execution by ID
+func (s *PlaybookServiceService) GetExecution(ctx context.Context, id string) (*domain.PlaybookExecution, error) {
+	executionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrPlaybookNotFound
+	}
+
+	execution, err := s.repositories.PlaybookRepository.GetExecutionByID(ctx, executionUUID)
+	if err != nil {
+		return nil, err
+	}
+	if execution == nil {
+		return nil, domain.ErrPlaybookNotFound
+	}
+
+	return execution, nil
+}
+
+// HandleAlert runs the enabled playbooks triggered by an alert's rule. Alerts
+// that match no playbook, or lack the target field a playbook reads, are
+// skipped; only a failure to load playbooks is returned, so the alert is
+// redelivered.
+func (s *PlaybookServiceService) HandleAlert(ctx context.Context, msg *queue.Message) error {
+	alert, ok := msg.Value.(map[string]interface{})
+	if !ok {
+		s.logger.Warn("Ignoring malformed alert", zap.String("topic", msg.Topic))
+		return nil
+	}
+
+	ruleID, _ := alert["rule_id"].(string)
+	ruleName, _ := alert["rule_name"].(string)
+	if ruleID == "" && ruleName == "" {
+		return nil
+	}
+
+	playbooks, err := s.repositories.PlaybookRepository.GetTriggeredPlaybooks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get triggered playbooks: %w", err)
+	}
+
+	alertID, _ := alert["id"].(string)
+	for _, playbook := range playbooks {
+		if !playbook.Trigger.Matches(ruleID, ruleName) {
+			continue
+		}
+
+		targetID := alertField(alert, playbook.Trigger.TargetField)
+		if targetID == "" {
+			s.logger.Warn("Alert has no target for triggered playbook",
+				zap.String("playbook_id", playbook.ID.String()),
+				zap.String("alert_id", alertID),
+				zap.String("target_field", playbook.Trigger.TargetField),
+			)
+			continue
+		}
+
+		if _, err := s.run(ctx, playbook, targetID, playbook.Trigger.TargetType, "alert:"+alertID); err != nil {
+			s.logger.Error("Failed to run triggered playbook",
+				zap.String("playbook_id", playbook.ID.String()),
+				zap.String("alert_id", alertID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// run executes the steps of a playbook in order, recording each step's state
+// as it goes. When a step fails, unless it continues on error, the remaining
+// steps are skipped and the completed ones are rolled back in reverse order.
+func (s *PlaybookServiceService) run(ctx context.Context, playbook *domain.Playbook, targetID string, targetType domain.EntityType, triggeredBy string) (*domain.PlaybookExecution, error) {
+	execution := &domain.PlaybookExecution{
+		ID:          uuid.New(),
+		PlaybookID:  playbook.ID,
+		TargetID:    targetID,
+		TargetType:  targetType,
+		TriggeredBy: triggeredBy,
+		Status:      domain.PlaybookExecutionRunning,
+		Steps:       make([]domain.PlaybookStepExecution, len(playbook.Steps)),
+		StartedAt:   time.Now().UTC(),
+	}
+	for i, step := range playbook.Steps {
+		execution.Steps[i] = domain.PlaybookStepExecution{
+			Name:   step.Name,
+			Type:   step.Type,
+			Status: domain.PlaybookStepPending,
+		}
+	}
+
+	if err := s.repositories.PlaybookRepository.CreateExecution(ctx, execution); err != nil {
+		return nil, fmt.Errorf("failed to create playbook execution: %w", err)
+	}
+
+	s.logger.Info("Playbook execution started",
+		zap.String("execution_id", execution.ID.String()),
+		zap.String("playbook_id", playbook.ID.String()),
+		zap.String("target_id", targetID),
+		zap.String("triggered_by", triggeredBy),
+	)
+
+	for i, step := range playbook.Steps {
+		record := &execution.Steps[i]
+		startedAt := time.Now().UTC()
+		record.Status = domain.PlaybookStepRunning
+		record.StartedAt = &startedAt
+		s.save(ctx, execution)
+
+		result, err := s.runStep(execution, i, &step)
+		completedAt := time.Now().UTC()
+		record.CompletedAt = &completedAt
+
+		if err == nil {
+			record.Status = domain.PlaybookStepCompleted
+			record.Result, _ = json.Marshal(result)
+			s.save(ctx, execution)
+			continue
+		}
+
+		record.Status = domain.PlaybookStepFailed
+		record.Error = err.Error()
+		s.logger.Error("Playbook step failed",
+			zap.String("execution_id", execution.ID.String()),
+			zap.Int("step", i),
+			zap.String("type", string(step.Type)),
+			zap.Error(err),
+		)
+
+		if step.ContinueOnError {
+			s.save(ctx, execution)
+			continue
+		}
+
+		for j := i + 1; j < len(execution.Steps); j++ {
+			execution.Steps[j].Status = domain.PlaybookStepSkipped
+		}
+		execution.Error = fmt.Sprintf("step %d (%s) failed: %v", i, step.Name, err)
+		execution.Status = domain.PlaybookExecutionRolledBack
+		if !s.rollback(execution, playbook, i) {
+			execution.Status = domain.PlaybookExecutionFailed
+		}
+		break
+	}
+
+	if execution.Status == domain.PlaybookExecutionRunning {
+		execution.Status = domain.PlaybookExecutionCompleted
+	}
+	completedAt := time.Now().UTC()
+	execution.CompletedAt = &completedAt
+	s.save(ctx, execution)
+
+	s.logger.Info("Playbook execution finished",
+		zap.String("execution_id", execution.ID.String()),
+		zap.String("status", string(execution.Status)),
+	)
+
+	return execution, nil
+}
+
+// runStep carries out one step. Wallet freezes are issued as enforcement
+// commands; the other steps are handed to the owning services as playbook
+// actions.
+func (s *PlaybookServiceService) runStep(execution *domain.PlaybookExecution, index int, step *domain.PlaybookStep) (*stepResult, error) {
+	switch step.Type {
+	case domain.PlaybookStepFreezeWallet:
+		walletID := stepTarget(step, "wallet_id", execution, domain.EntityTypeWallet)
+		if walletID == "" {
+			return nil, fmt.Errorf("no wallet to freeze: set parameters.wallet_id or target a wallet")
+		}
+		command := s.command(execution, index, domain.EnforcementFreeze, walletID, step.Parameters)
+		if err := s.messagingPort.Producer.PublishEnforcementCommand(command); err != nil {
+			return nil, err
+		}
+		return &stepResult{CommandID: command.CommandID, TargetID: walletID}, nil
+
+	case domain.PlaybookStepNotifyExchange:
+		exchangeID := stepTarget(step, "exchange_id", execution, domain.EntityTypeExchange)
+		if exchangeID == "" {
+			return nil, fmt.Errorf("no exchange to notify: set parameters.exchange_id or target an exchange")
+		}
+		action := s.action(execution, string(step.Type), exchangeID, domain.EntityTypeExchange, step.Parameters, "")
+		if err := s.messagingPort.Producer.PublishPlaybookAction(action); err != nil {
+			return nil, err
+		}
+		return &stepResult{ActionID: action.ActionID, TargetID: exchangeID}, nil
+
+	case domain.PlaybookStepOpenCase, domain.PlaybookStepGenerateReport:
+		action := s.action(execution, string(step.Type), execution.TargetID, execution.TargetType, step.Parameters, "")
+		if err := s.messagingPort.Producer.PublishPlaybookAction(action); err != nil {
+			return nil, err
+		}
+		return &stepResult{ActionID: action.ActionID, TargetID: execution.TargetID}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unknown step type %q", domain.ErrInvalidPlaybook, step.Type)
+	}
+}
+
+// rollback undoes the completed steps before failed, newest first. Frozen
+// wallets are unfrozen and opened cases cancelled; notifications and reports
+// cannot be recalled and are left as they are. It reports whether every
+// rollback succeeded.
+func (s *PlaybookServiceService) rollback(execution *domain.PlaybookExecution, playbook *domain.Playbook, failed int) bool {
+	ok := true
+	for i := failed - 1; i >= 0; i-- {
+		record := &execution.Steps[i]
+		if record.Status != domain.PlaybookStepCompleted {
+			continue
+		}
+
+		var result stepResult
+		_ = json.Unmarshal(record.Result, &result)
+
+		var err error
+		switch record.Type {
+		case domain.PlaybookStepFreezeWallet:
+			command := s.command(execution, i, domain.EnforcementUnfreeze, result.TargetID, playbook.Steps[i].Parameters)
+			err = s.messagingPort.Producer.PublishEnforcementCommand(command)
+		case domain.PlaybookStepOpenCase:
+			action := s.action(execution, cancelCaseAction, result.TargetID, execution.TargetType, nil, result.ActionID)
+			err = s.messagingPort.Producer.PublishPlaybookAction(action)
+		default:
+			continue
+		}
+
+		if err != nil {
+			ok = false
+			record.Status = domain.PlaybookStepRollbackFailed
+			record.Error = fmt.Sprintf("rollback failed: %v", err)
+			s.logger.Error("Playbook step rollback failed",
+				zap.String("execution_id", execution.ID.String()),
+				zap.Int("step", i),
+				zap.Error(err),
+			)
+			continue
+		}
+		record.Status = domain.PlaybookStepRolledBack
+	}
+	return ok
+}
+
+// command builds an enforcement command for a step. The idempotency key ties
+// it to the execution and step, so a redelivered command is applied once.
+func (s *PlaybookServiceService) command(execution *domain.PlaybookExecution, index int, action domain.EnforcementType, walletID string, parameters json.RawMessage) *domain.EnforcementCommand {
+	params := map[string]interface{}{
+		"playbook_id":           execution.PlaybookID.String(),
+		"playbook_execution_id": execution.ID.String(),
+		"step":                  index,
+	}
+	if len(parameters) > 0 {
+		params["step_parameters"] = parameters
+	}
+	data, _ := json.Marshal(params)
+
+	return &domain.EnforcementCommand{
+		CommandID:      uuid.New().String(),
+		Action:         action,
+		TargetID:       walletID,
+		TargetType:     domain.EntityTypeWallet,
+		Reason:         fmt.Sprintf("playbook execution %s", execution.ID),
+		Parameters:     data,
+		Priority:       50,
+		IdempotencyKey: fmt.Sprintf("playbook:%s:%d:%s", execution.ID, index, action),
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// action builds a playbook action for the service owning a step
+func (s *PlaybookServiceService) action(execution *domain.PlaybookExecution, actionType, targetID string, targetType domain.EntityType, parameters json.RawMessage, relatedID string) *domain.PlaybookAction {
+	return &domain.PlaybookAction{
+		ActionID:    uuid.New().String(),
+		Type:        actionType,
+		ExecutionID: execution.ID.String(),
+		PlaybookID:  execution.PlaybookID.String(),
+		TargetID:    targetID,
+		TargetType:  targetType,
+		Parameters:  parameters,
+		RelatedID:   relatedID,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// save persists an execution's progress. A failure is logged rather than
+// returned: the step has already taken effect, so stopping would only leave
+// it unrecorded.
+func (s *PlaybookServiceService) save(ctx context.Context, execution *domain.PlaybookExecution) {
+	if err := s.repositories.PlaybookRepository.UpdateExecution(ctx, execution); err != nil {
+		s.logger.Error("Failed to save playbook execution",
+			zap.String("execution_id", execution.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// stepTarget returns the entity a step acts on: the ID named by the step
+// parameter key, or else the execution target when it has the wanted type
+func stepTarget(step *domain.PlaybookStep, key string, execution *domain.PlaybookExecution, entityType domain.EntityType) string {
+	if len(step.Parameters) > 0 {
+		var params map[string]interface{}
+		if err := json.Unmarshal(step.Parameters, &params); err == nil {
+			if id, ok := params[key].(string); ok && id != "" {
+				return id
+			}
+		}
+	}
+	if execution.TargetType == entityType {
+		return execution.TargetID
+	}
+	return ""
+}
+
+// alertField reads a string field of an alert by dot-separated path, e.g.
+// wallet_id or context.address
+func alertField(alert map[string]interface{}, path string) string {
+	var value interface{} = alert
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = m[key]
+	}
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"csic-platform/control-layer/internal/core/domain"
+)
+
+// checkConflicts finds the active policies that contradict policy. In block
+// mode any conflict fails the write unless override is set; otherwise the
+// conflicts are logged and returned as warnings.
+func (e *PolicyEngineService) checkConflicts(ctx context.Context, policy *domain.Policy, override bool) ([]domain.PolicyConflict, error) {
+	conflicts, err := e.detectConflicts(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		ids[i] = conflict.PolicyID
+	}
+
+	if e.conflictMode == domain.ConflictModeBlock && !override {
+		e.logger.Warn("Blocked conflicting policy write",
+			zap.String("policy_id", policy.ID.String()),
+			zap.Strings("conflicting_policy_ids", ids))
+		return conflicts, domain.ErrPolicyConflict
+	}
+
+	e.logger.Warn("Saving policy that conflicts with active policies",
+		zap.String("policy_id", policy.ID.String()),
+		zap.Strings("conflicting_policy_ids", ids),
+		zap.Bool("override", override))
+	return conflicts, nil
+}
+
+// detectConflicts returns the active policies that check the same target as
+// policy at the same priority with a rule no value can satisfy together with
+// policy's. Every request reaching that target would then be denied by one of
+// the two, whatever its value.
+func (e *PolicyEngineService) detectConflicts(ctx context.Context, policy *domain.Policy) ([]domain.PolicyConflict, error) {
+	if !policy.IsActive {
+		return nil, nil
+	}
+
+	active, err := e.repositories.PolicyRepository.GetActivePolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active policies: %w", err)
+	}
+
+	var conflicts []domain.PolicyConflict
+	for _, other := range active {
+		if other.ID == policy.ID || other.Priority != policy.Priority || other.Rule.Target != policy.Rule.Target {
+			continue
+		}
+		// Policies of two different jurisdictions never apply to the same request
+		if policy.Jurisdiction != "" && other.Jurisdiction != "" && policy.Jurisdiction != other.Jurisdiction {
+			continue
+		}
+		if !e.rulesContradict(&policy.Rule, &other.Rule) {
+			continue
+		}
+		conflicts = append(conflicts, domain.PolicyConflict{
+			PolicyID:   other.ID.String(),
+			PolicyName: other.Name,
+			Explanation: fmt.Sprintf("both check %s at priority %d, but no value satisfies both %s %v and %s %v",
+				policy.Rule.Target, policy.Priority,
+				policy.Rule.Operator, policy.Rule.Threshold,
+				other.Rule.Operator, other.Rule.Threshold),
+		})
+	}
+
+	return conflicts, nil
+}
+
+// rulesContradict reports whether no value satisfies both rules, judged the way
+// the engine evaluates them. A rule allowing a finite set of values (eq, in)
+// contradicts another when none of those values satisfies it; two range rules
+// contradict when their bounds leave no value between them.
+func (e *PolicyEngineService) rulesContradict(a, b *domain.PolicyRule) bool {
+	if values, ok := allowedValues(a); ok {
+		return !e.anySatisfies(values, b)
+	}
+	if values, ok := allowedValues(b); ok {
+		return !e.anySatisfies(values, a)
+	}
+
+	lower, upper := a, b
+	if isUpperBound(a.Operator) {
+		lower, upper = b, a
+	}
+	if !isLowerBound(lower.Operator) || !isUpperBound(upper.Operator) {
+		return false
+	}
+
+	lo, hi := parseDecimal(lower.Threshold), parseDecimal(upper.Threshold)
+	if !lo.Equal(hi) {
+		return lo.GreaterThan(hi)
+	}
+	// Equal bounds leave exactly that value unless either side excludes it
+	return lower.Operator == "gt" || lower.Operator == ">" || upper.Operator == "lt" || upper.Operator == "<"
+}
+
+func (e *PolicyEngineService) anySatisfies(values []interface{}, rule *domain.PolicyRule) bool {
+	for _, value := range values {
+		if e.compareValues(value, rule.Threshold, rule.Operator) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedValues returns the only values a rule accepts, when there are finitely many
+func allowedValues(rule *domain.PolicyRule) ([]interface{}, bool) {
+	switch rule.Operator {
+	case "eq", "==":
+		return []interface{}{rule.Threshold}, true
+	case "in":
+		list, ok := rule.Threshold.([]interface{})
+		return list, ok
+	default:
+		return nil, false
+	}
+}
+
+func isLowerBound(operator string) bool {
+	switch operator {
+	case "gt", ">", "gte", ">=":
+		return true
+	}
+	return false
+}
+
+func isUpperBound(operator string) bool {
+	switch operator {
+	case "lt", "<", "lte", "<=":
+		return true
+	}
+	return false
+}
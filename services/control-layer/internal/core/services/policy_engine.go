@@ -8,11 +8,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 
 	"csic-platform/control-layer/internal/core/domain"
 	"csic-platform/control-layer/internal/core/ports"
 	"csic-platform/control-layer/pkg/metrics"
+
+	"github.com/csic-platform/shared/queue"
+	"github.com/csic-platform/shared/tenancy"
 )
 
 // PolicyEngine evaluates and manages policies
@@ -20,16 +24,27 @@ type PolicyEngine interface {
 	// Policy CRUD operations
 	ListPolicies(ctx context.Context) ([]*domain.Policy, error)
 	GetPolicy(ctx context.Context, id string) (*domain.Policy, error)
-	CreatePolicy(ctx context.Context, req *domain.CreatePolicyRequest) (*domain.Policy, error)
-	UpdatePolicy(ctx context.Context, id string, req *domain.UpdatePolicyRequest) (*domain.Policy, error)
+	// CreatePolicy and UpdatePolicy also return the active policies the result
+	// contradicts. In block mode a conflicting write fails with
+	// domain.ErrPolicyConflict unless overrideConflicts is set.
+	CreatePolicy(ctx context.Context, req *domain.CreatePolicyRequest, overrideConflicts bool) (*domain.Policy, []domain.PolicyConflict, error)
+	UpdatePolicy(ctx context.Context, id string, req *domain.UpdatePolicyRequest, overrideConflicts bool) (*domain.Policy, []domain.PolicyConflict, error)
 	DeletePolicy(ctx context.Context, id string) error
 
 	// Policy evaluation
 	EvaluatePolicy(ctx context.Context, policyID string, data map[string]interface{}) (*domain.PolicyResult, error)
 	EvaluateAllPolicies(ctx context.Context, data map[string]interface{}) ([]*domain.PolicyResult, error)
+	// ApplicablePolicies and EvaluatePolicies split EvaluateAllPolicies, so
+	// requests for the same resource type and action share one lookup.
+	// ApplicablePolicies answers from an in-memory index of the active
+	// policies, loaded again after each policy change.
+	ApplicablePolicies(ctx context.Context, resourceType, action string) ([]*domain.Policy, error)
+	EvaluatePolicies(policies []*domain.Policy, data map[string]interface{}) []*domain.PolicyResult
+	SimulatePolicy(ctx context.Context, replacesID string, draft *domain.CreatePolicyRequest, samples []map[string]interface{}) ([]*domain.PolicySimulationResult, error)
 
 	// Lifecycle
 	StartPolicyUpdateConsumer(logger *zap.Logger)
+	HandlePolicyUpdate(ctx context.Context, msg *queue.Message) error
 	IsReady() bool
 }
 
@@ -40,12 +55,16 @@ type PolicyEngineService struct {
 	messagingPort ports.MessagingPort
 	logger        *zap.Logger
 	metrics       *metrics.MetricsCollector
+	conflictMode  domain.ConflictMode
 
 	// Internal state
 	mu           sync.RWMutex
 	policyCache  map[string]*domain.Policy
 	cacheExpiry  time.Time
 	ready        bool
+
+	// index holds the active policies by resource type and action
+	index *policyIndex
 }
 
 // NewPolicyEngine creates a new policy engine
@@ -55,16 +74,20 @@ func NewPolicyEngine(
 	messagingPort ports.MessagingPort,
 	logger *zap.Logger,
 	metricsCollector *metrics.MetricsCollector,
+	conflictMode domain.ConflictMode,
 ) PolicyEngine {
-	return &PolicyEngineService{
+	e := &PolicyEngineService{
 		repositories:  repositories,
 		cachePort:     cachePort,
 		messagingPort: messagingPort,
 		logger:        logger,
 		metrics:       metricsCollector,
+		conflictMode:  conflictMode,
 		policyCache:   make(map[string]*domain.Policy),
 		cacheExpiry:   time.Now(),
 	}
+	e.index = newPolicyIndex(e.activePolicies, e.applicable)
+	return e
 }
 
 // ListPolicies lists all policies
@@ -83,21 +106,27 @@ func (e *PolicyEngineService) GetPolicy(ctx context.Context, id string) (*domain
 }
 
 // CreatePolicy creates a new policy
-func (e *PolicyEngineService) CreatePolicy(ctx context.Context, req *domain.CreatePolicyRequest) (*domain.Policy, error) {
+func (e *PolicyEngineService) CreatePolicy(ctx context.Context, req *domain.CreatePolicyRequest, overrideConflicts bool) (*domain.Policy, []domain.PolicyConflict, error) {
 	now := time.Now()
 	policy := &domain.Policy{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Description: req.Description,
-		Rule:        *req.Rule,
-		Priority:    req.Priority,
-		IsActive:    req.IsActive,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:           uuid.New(),
+		Name:         req.Name,
+		Description:  req.Description,
+		Jurisdiction: tenancy.Normalize(req.Jurisdiction),
+		Rule:         *req.Rule,
+		Priority:     req.Priority,
+		IsActive:     req.IsActive,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	conflicts, err := e.checkConflicts(ctx, policy, overrideConflicts)
+	if err != nil {
+		return nil, conflicts, err
 	}
 
 	if err := e.repositories.PolicyRepository.CreatePolicy(ctx, policy); err != nil {
-		return nil, fmt.Errorf("failed to create policy: %w", err)
+		return nil, nil, fmt.Errorf("failed to create policy: %w", err)
 	}
 
 	// Invalidate cache
@@ -111,24 +140,24 @@ func (e *PolicyEngineService) CreatePolicy(ctx context.Context, req *domain.Crea
 		logger.String("name", policy.Name),
 	)
 
-	return policy, nil
+	return policy, conflicts, nil
 }
 
 // UpdatePolicy updates an existing policy
-func (e *PolicyEngineService) UpdatePolicy(ctx context.Context, id string, req *domain.UpdatePolicyRequest) (*domain.Policy, error) {
+func (e *PolicyEngineService) UpdatePolicy(ctx context.Context, id string, req *domain.UpdatePolicyRequest, overrideConflicts bool) (*domain.Policy, []domain.PolicyConflict, error) {
 	policyUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid policy ID: %w", err)
+		return nil, nil, fmt.Errorf("invalid policy ID: %w", err)
 	}
 
 	// Get existing policy
 	policy, err := e.repositories.PolicyRepository.GetPolicyByID(ctx, policyUUID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get policy: %w", err)
+		return nil, nil, fmt.Errorf("failed to get policy: %w", err)
 	}
 
 	if policy == nil {
-		return nil, fmt.Errorf("policy not found: %s", id)
+		return nil, nil, fmt.Errorf("policy not found: %s", id)
 	}
 
 	// Update fields
@@ -149,8 +178,13 @@ func (e *PolicyEngineService) UpdatePolicy(ctx context.Context, id string, req *
 	}
 	policy.UpdatedAt = time.Now()
 
+	conflicts, err := e.checkConflicts(ctx, policy, overrideConflicts)
+	if err != nil {
+		return nil, conflicts, err
+	}
+
 	if err := e.repositories.PolicyRepository.UpdatePolicy(ctx, policy); err != nil {
-		return nil, fmt.Errorf("failed to update policy: %w", err)
+		return nil, nil, fmt.Errorf("failed to update policy: %w", err)
 	}
 
 	// Invalidate cache
@@ -164,7 +198,7 @@ func (e *PolicyEngineService) UpdatePolicy(ctx context.Context, id string, req *
 		logger.String("name", policy.Name),
 	)
 
-	return policy, nil
+	return policy, conflicts, nil
 }
 
 // DeletePolicy deletes a policy
@@ -181,6 +215,9 @@ func (e *PolicyEngineService) DeletePolicy(ctx context.Context, id string) error
 	// Invalidate cache
 	e.invalidateCache()
 
+	// Publish policy update event so cached decisions are dropped
+	e.messagingPort.Producer.PublishPolicyUpdate(id)
+
 	e.logger.Info("Deleted policy", logger.String("policy_id", id))
 
 	return nil
@@ -230,17 +267,134 @@ func (e *PolicyEngineService) EvaluateAllPolicies(ctx context.Context, data map[
 		return nil, fmt.Errorf("failed to get active policies: %w", err)
 	}
 
-	var results []*domain.PolicyResult
+	return e.EvaluatePolicies(policies, data), nil
+}
+
+// ApplicablePolicies returns the active policies a request for resourceType
+// and action may violate, by descending priority. Policies whose rule targets
+// only the resource type or the action are decided by the pair alone, so those
+// it satisfies are left out and every request of the pair skips them.
+func (e *PolicyEngineService) ApplicablePolicies(ctx context.Context, resourceType, action string) ([]*domain.Policy, error) {
+	return e.index.Lookup(ctx, resourceType, action)
+}
+
+// activePolicies loads the active policies for the index
+func (e *PolicyEngineService) activePolicies(ctx context.Context) ([]*domain.Policy, error) {
+	policies, err := e.repositories.PolicyRepository.GetActivePolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active policies: %w", err)
+	}
+	return policies, nil
+}
+
+// applicable picks the policies applicable to resourceType and action, keeping
+// their order
+func (e *PolicyEngineService) applicable(policies []*domain.Policy, resourceType, action string) []*domain.Policy {
+	pair := map[string]interface{}{
+		"action":   action,
+		"resource": map[string]interface{}{"type": resourceType},
+	}
+
+	applicable := make([]*domain.Policy, 0, len(policies))
+	for _, policy := range policies {
+		switch policy.Rule.Target {
+		case "action", "resource.type":
+			if e.evaluateRule(&policy.Rule, pair).Compliant {
+				continue
+			}
+		}
+		applicable = append(applicable, policy)
+	}
+	return applicable
+}
+
+// EvaluatePolicies evaluates the given policies against the provided data. It
+// does not fetch anything and is safe to call concurrently.
+func (e *PolicyEngineService) EvaluatePolicies(policies []*domain.Policy, data map[string]interface{}) []*domain.PolicyResult {
+	results := make([]*domain.PolicyResult, 0, len(policies))
 	for _, policy := range policies {
 		result := e.evaluateRule(&policy.Rule, data)
 		result.PolicyID = policy.ID.String()
 
 		results = append(results, result)
 	}
+	return results
+}
+
+// SimulatePolicy evaluates each sample against the active policies and against
+// the active policies with the draft applied, without saving anything. The
+// draft replaces the active policy with ID replacesID, or is added when
+// replacesID is empty; an inactive draft replacing a policy simulates
+// deactivating it.
+func (e *PolicyEngineService) SimulatePolicy(ctx context.Context, replacesID string, draft *domain.CreatePolicyRequest, samples []map[string]interface{}) ([]*domain.PolicySimulationResult, error) {
+	if draft.Rule == nil {
+		return nil, fmt.Errorf("draft policy rule is required")
+	}
+
+	draftID := uuid.New()
+	if replacesID != "" {
+		id, err := uuid.Parse(replacesID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy ID: %w", err)
+		}
+		draftID = id
+	}
+
+	active, err := e.repositories.PolicyRepository.GetActivePolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active policies: %w", err)
+	}
+
+	simulated := make([]*domain.Policy, 0, len(active)+1)
+	for _, policy := range active {
+		if policy.ID != draftID {
+			simulated = append(simulated, policy)
+		}
+	}
+	if draft.IsActive {
+		simulated = append(simulated, &domain.Policy{
+			ID:       draftID,
+			Name:     draft.Name,
+			Rule:     *draft.Rule,
+			Priority: draft.Priority,
+			IsActive: true,
+		})
+	}
+
+	results := make([]*domain.PolicySimulationResult, len(samples))
+	for i, data := range samples {
+		result := &domain.PolicySimulationResult{
+			Index:               i,
+			CurrentViolations:   e.violations(active, data),
+			SimulatedViolations: e.violations(simulated, data),
+		}
+		result.CurrentDecision = decisionFor(result.CurrentViolations)
+		result.SimulatedDecision = decisionFor(result.SimulatedViolations)
+		results[i] = result
+	}
 
 	return results, nil
 }
 
+// violations returns the IDs of the policies the data violates
+func (e *PolicyEngineService) violations(policies []*domain.Policy, data map[string]interface{}) []string {
+	var ids []string
+	for _, policy := range policies {
+		if !e.evaluateRule(&policy.Rule, data).Compliant {
+			ids = append(ids, policy.ID.String())
+		}
+	}
+	return ids
+}
+
+// decisionFor denies when any policy is violated
+func decisionFor(violations []string) domain.DecisionResult {
+	if len(violations) > 0 {
+		return domain.DecisionDenied
+	}
+	return domain.DecisionAllowed
+}
+
 // evaluateRule evaluates a policy rule against the provided data
 func (e *PolicyEngineService) evaluateRule(rule *domain.PolicyRule, data map[string]interface{}) *domain.PolicyResult {
 	result := &domain.PolicyResult{
@@ -324,20 +478,12 @@ func (e *PolicyEngineService) compareValues(value interface{}, threshold interfa
 	}
 }
 
+// Numeric comparisons are made on exact decimals, so amounts sent as decimal
+// strings keep their full precision. A value that is not a number never passes
+// an ordering check; a threshold that is not a number counts as zero.
 func (e *PolicyEngineService) greaterThan(value, threshold interface{}) bool {
-	switch v := value.(type) {
-	case float64:
-		t, ok := threshold.(float64)
-		if !ok {
-			t = parseFloat(threshold)
-		}
-		return v > t
-	case int:
-		t := parseFloat(threshold)
-		return float64(v) > t
-	default:
-		return false
-	}
+	v, ok := toDecimal(value)
+	return ok && v.GreaterThan(parseDecimal(threshold))
 }
 
 func (e *PolicyEngineService) greaterThanOrEqual(value, threshold interface{}) bool {
@@ -345,19 +491,8 @@ func (e *PolicyEngineService) greaterThanOrEqual(value, threshold interface{}) b
 }
 
 func (e *PolicyEngineService) lessThan(value, threshold interface{}) bool {
-	switch v := value.(type) {
-	case float64:
-		t, ok := threshold.(float64)
-		if !ok {
-			t = parseFloat(threshold)
-		}
-		return v < t
-	case int:
-		t := parseFloat(threshold)
-		return float64(v) < t
-	default:
-		return false
-	}
+	v, ok := toDecimal(value)
+	return ok && v.LessThan(parseDecimal(threshold))
 }
 
 func (e *PolicyEngineService) lessThanOrEqual(value, threshold interface{}) bool {
@@ -365,10 +500,7 @@ func (e *PolicyEngineService) lessThanOrEqual(value, threshold interface{}) bool
 }
 
 func (e *PolicyEngineService) equal(value, threshold interface{}) bool {
-	// Convert both to float64 for comparison if possible
-	v := parseFloat(value)
-	t := parseFloat(threshold)
-	return v == t
+	return parseDecimal(value).Equal(parseDecimal(threshold))
 }
 
 func (e *PolicyEngineService) contains(value, threshold interface{}) bool {
@@ -393,20 +525,41 @@ func (e *PolicyEngineService) inList(value, threshold interface{}) bool {
 	return false
 }
 
-func parseFloat(v interface{}) float64 {
+// toDecimal converts a number, or a string holding one, to a decimal
+func toDecimal(v interface{}) (decimal.Decimal, bool) {
 	switch val := v.(type) {
+	case decimal.Decimal:
+		return val, true
+	case *decimal.Decimal:
+		if val == nil {
+			return decimal.Zero, false
+		}
+		return *val, true
 	case float64:
-		return val
+		return decimal.NewFromFloat(val), true
+	case float32:
+		return decimal.NewFromFloat32(val), true
 	case int:
-		return float64(val)
+		return decimal.NewFromInt(int64(val)), true
+	case int64:
+		return decimal.NewFromInt(val), true
 	case json.Number:
-		f, _ := val.Float64()
-		return f
+		d, err := decimal.NewFromString(val.String())
+		return d, err == nil
+	case string:
+		d, err := decimal.NewFromString(val)
+		return d, err == nil
 	default:
-		return 0
+		return decimal.Zero, false
 	}
 }
 
+// parseDecimal is toDecimal with zero for anything that is not a number
+func parseDecimal(v interface{}) decimal.Decimal {
+	d, _ := toDecimal(v)
+	return d
+}
+
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && len(substr) > 0 && findSubstring(s, substr))
 }
@@ -476,17 +629,24 @@ func (e *PolicyEngineService) getCachedPolicy(ctx context.Context, policyID stri
 	return policy, nil
 }
 
-// invalidateCache clears the policy cache
+// invalidateCache clears the policy cache and marks the policy index stale
 func (e *PolicyEngineService) invalidateCache() {
 	e.mu.Lock()
 	e.policyCache = make(map[string]*domain.Policy)
 	e.cacheExpiry = time.Time{}
 	e.mu.Unlock()
+
+	e.index.Invalidate()
 }
 
-// StartPolicyUpdateConsumer starts consuming policy update events
+// StartPolicyUpdateConsumer loads the policy index ahead of the first access
+// check. Changes are then applied by HandlePolicyUpdate.
 func (e *PolicyEngineService) StartPolicyUpdateConsumer(logger *zap.Logger) {
-	// This would consume from Kafka in production
+	if _, err := e.index.Refresh(context.Background()); err != nil {
+		// Access checks load the index themselves once the database is back
+		logger.Warn("Failed to load policy index", zap.Error(err))
+	}
+
 	e.mu.Lock()
 	e.ready = true
 	e.mu.Unlock()
@@ -494,6 +654,25 @@ func (e *PolicyEngineService) StartPolicyUpdateConsumer(logger *zap.Logger) {
 	logger.Info("Policy update consumer started")
 }
 
+// HandlePolicyUpdate is a queue.Handler for the policy updates topic. Changes
+// made on any instance mark the policy index stale, and it is loaded again
+// here so access checks do not wait for the load. Consume the topic on a
+// consumer group unique to the instance, so every instance sees every change.
+func (e *PolicyEngineService) HandlePolicyUpdate(ctx context.Context, msg *queue.Message) error {
+	e.invalidateCache()
+
+	if _, err := e.index.Refresh(ctx); err != nil {
+		// The index stays stale, so the next access check loads it
+		e.logger.Warn("Failed to reload policy index",
+			zap.Uint64("generation", e.index.Generation()),
+			zap.Error(err))
+		return nil
+	}
+
+	e.logger.Debug("Reloaded policy index", zap.Uint64("generation", e.index.Generation()))
+	return nil
+}
+
 // IsReady returns true if the service is ready
 func (e *PolicyEngineService) IsReady() bool {
 	e.mu.RLock()
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"csic-platform/control-layer/internal/core/domain"
+)
+
+// maxIndexedPairs bounds how many resource type and action pairs a policy
+// index compiles; lookups for further pairs are filtered on every call
+const maxIndexedPairs = 10000
+
+// policyIndex answers which active policies apply to a resource type and
+// action from memory. It holds the active policies, sorted by descending
+// priority, and compiles the applicable subset of each pair on first use.
+//
+// Every policy change bumps the index's generation. A snapshot is only served
+// while it was loaded at the current generation, so a lookup never answers
+// from policies older than the last change it could have seen, and a load
+// that raced a change is not installed over a newer one.
+type policyIndex struct {
+	load   func(ctx context.Context) ([]*domain.Policy, error)
+	filter func(policies []*domain.Policy, resourceType, action string) []*domain.Policy
+
+	generation atomic.Uint64
+	current    atomic.Pointer[policySnapshot]
+	loading    sync.Mutex
+}
+
+// policySnapshot is the index at one generation
+type policySnapshot struct {
+	generation uint64
+	policies   []*domain.Policy
+
+	mu    sync.RWMutex
+	pairs map[string]map[string][]*domain.Policy
+	size  int
+}
+
+// newPolicyIndex creates an empty policy index. load fetches the active
+// policies and filter picks those applicable to a pair.
+func newPolicyIndex(
+	load func(ctx context.Context) ([]*domain.Policy, error),
+	filter func(policies []*domain.Policy, resourceType, action string) []*domain.Policy,
+) *policyIndex {
+	return &policyIndex{load: load, filter: filter}
+}
+
+// Lookup returns the active policies applicable to resourceType and action,
+// loading them first when a policy changed since the last load
+func (x *policyIndex) Lookup(ctx context.Context, resourceType, action string) ([]*domain.Policy, error) {
+	snapshot := x.current.Load()
+	if snapshot == nil || snapshot.generation != x.generation.Load() {
+		var err error
+		if snapshot, err = x.Refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot.lookup(resourceType, action, x.filter), nil
+}
+
+// Invalidate records a policy change. Lookups load the policies again before
+// answering.
+func (x *policyIndex) Invalidate() {
+	x.generation.Add(1)
+}
+
+// Generation returns the generation of the last policy change recorded
+func (x *policyIndex) Generation() uint64 {
+	return x.generation.Load()
+}
+
+// Refresh loads the active policies unless the current snapshot is up to
+// date, and returns the snapshot. Concurrent callers share one load.
+func (x *policyIndex) Refresh(ctx context.Context) (*policySnapshot, error) {
+	x.loading.Lock()
+	defer x.loading.Unlock()
+
+	// The generation is read before loading, so a change made during the
+	// load leaves the snapshot stale and the next lookup loads again
+	generation := x.generation.Load()
+	if snapshot := x.current.Load(); snapshot != nil && snapshot.generation == generation {
+		return snapshot, nil
+	}
+
+	policies, err := x.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*domain.Policy, len(policies))
+	copy(sorted, policies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].ID.String() < sorted[j].ID.String()
+	})
+
+	snapshot := &policySnapshot{
+		generation: generation,
+		policies:   sorted,
+		pairs:      make(map[string]map[string][]*domain.Policy),
+	}
+	x.current.Store(snapshot)
+	return snapshot, nil
+}
+
+// lookup returns the compiled policies of a pair, compiling them on first use
+func (s *policySnapshot) lookup(resourceType, action string, filter func([]*domain.Policy, string, string) []*domain.Policy) []*domain.Policy {
+	s.mu.RLock()
+	policies, ok := s.pairs[resourceType][action]
+	s.mu.RUnlock()
+	if ok {
+		return policies
+	}
+
+	policies = filter(s.policies, resourceType, action)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if compiled, ok := s.pairs[resourceType][action]; ok {
+		return compiled
+	}
+	if s.size < maxIndexedPairs {
+		actions, ok := s.pairs[resourceType]
+		if !ok {
+			actions = make(map[string][]*domain.Policy)
+			s.pairs[resourceType] = actions
+		}
+		actions[action] = policies
+		s.size++
+	}
+	return policies
+}
@@ -0,0 +1,28 @@
+-- Control Layer Service Database Schema
+-- Emergency stops and their automatic resume schedule
+
+CREATE TABLE IF NOT EXISTS control_layer_emergency_stops (
+    id UUID PRIMARY KEY,
+    scope VARCHAR(20) NOT NULL,
+    target VARCHAR(255),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    throttle_percent INT NOT NULL DEFAULT 0,
+    initiated_by VARCHAR(255) NOT NULL,
+    resume_at TIMESTAMPTZ,
+    resumed_by VARCHAR(255),
+    resumed_at TIMESTAMPTZ,
+    resume_reason TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT chk_control_layer_emergency_stops_scope
+        CHECK (scope IN ('GLOBAL', 'REGION', 'EXCHANGE', 'TRANSACTION')),
+    CONSTRAINT chk_control_layer_emergency_stops_throttle
+        CHECK (throttle_percent BETWEEN 0 AND 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_layer_emergency_stops_active 
+ON control_layer_emergency_stops(created_at DESC) WHERE status = 'active';
+
+CREATE INDEX IF NOT EXISTS idx_control_layer_emergency_stops_resume_at 
+ON control_layer_emergency_stops(resume_at) WHERE status = 'active' AND resume_at IS NOT NULL;
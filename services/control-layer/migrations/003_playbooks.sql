@@ -0,0 +1,38 @@
+-- Control Layer Service Database Schema
+-- Intervention playbooks and their execution history
+
+CREATE TABLE IF NOT EXISTS control_layer_playbooks (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    steps JSONB NOT NULL,
+    alert_trigger JSONB,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_layer_playbooks_triggered 
+ON control_layer_playbooks(created_at DESC) WHERE enabled AND alert_trigger IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS control_layer_playbook_executions (
+    id UUID PRIMARY KEY,
+    playbook_id UUID NOT NULL REFERENCES control_layer_playbooks(id) ON DELETE CASCADE,
+    target_id VARCHAR(255) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    triggered_by VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    steps JSONB NOT NULL,
+    error TEXT,
+    started_at TIMESTAMPTZ DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT chk_control_layer_playbook_executions_status
+        CHECK (status IN ('running', 'completed', 'failed', 'rolled_back'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_control_layer_playbook_executions_playbook 
+ON control_layer_playbook_executions(playbook_id, started_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_control_layer_playbook_executions_target 
+ON control_layer_playbook_executions(target_type, target_id);
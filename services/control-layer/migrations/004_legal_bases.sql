@@ -0,0 +1,28 @@
+-- Control Layer Service Database Schema
+-- Legal basis catalogue cited by enforcement actions
+
+CREATE TABLE IF NOT EXISTS control_layer_legal_bases (
+    id UUID PRIMARY KEY,
+    code VARCHAR(100) NOT NULL,
+    statute VARCHAR(255) NOT NULL,
+    section VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    actions TEXT[] NOT NULL DEFAULT '{}',
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT uq_control_layer_legal_bases_code UNIQUE (code),
+    CONSTRAINT chk_control_layer_legal_bases_validity
+        CHECK (valid_to IS NULL OR valid_to > valid_from)
+);
+
+-- Stops issued before the catalogue existed have no legal basis
+ALTER TABLE control_layer_emergency_stops
+    ADD COLUMN IF NOT EXISTS legal_basis_id UUID REFERENCES control_layer_legal_bases(id),
+    ADD COLUMN IF NOT EXISTS legal_basis_code VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_control_layer_emergency_stops_legal_basis 
+ON control_layer_emergency_stops(legal_basis_id, created_at) WHERE legal_basis_id IS NOT NULL;
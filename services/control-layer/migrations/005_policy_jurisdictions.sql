@@ -0,0 +1,7 @@
+-- Control Layer Service Database Schema
+-- Jurisdiction of each policy; an empty jurisdiction applies everywhere
+
+ALTER TABLE control_layer_policies ADD COLUMN IF NOT EXISTS jurisdiction VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_control_layer_policies_jurisdiction
+ON control_layer_policies(jurisdiction);
@@ -28,6 +28,9 @@ type MetricsCollector struct {
 	ActiveInterventions       prometheus.Gauge
 	InterventionDuration      *prometheus.HistogramVec
 
+	// Emergency stop metrics
+	ActiveEmergencyStops      *prometheus.GaugeVec
+
 	// State registry metrics
 	StateUpdatesTotal         *prometheus.CounterVec
 	StateCacheHitTotal        prometheus.Counter
@@ -213,6 +216,13 @@ func NewPrometheusCollector(prefix string) *MetricsCollector {
 				Help: "Number of gRPC calls currently in progress",
 			},
 		),
+		ActiveEmergencyStops: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: fmt.Sprintf("%s_active_emergency_stops", prefix),
+				Help: "Number of active emergency stops by scope",
+			},
+			[]string{"scope"},
+		),
 	}
 
 	return m
@@ -313,6 +323,11 @@ func (m *MetricsCollector) SetActiveInterventions(count float64) {
 	m.ActiveInterventions.Set(count)
 }
 
+// SetActiveEmergencyStops sets the number of active emergency stops of a scope
+func (m *MetricsCollector) SetActiveEmergencyStops(scope string, count float64) {
+	m.ActiveEmergencyStops.WithLabelValues(scope).Set(count)
+}
+
 // MetricsCollectorOption is a function that modifies MetricsCollector
 type MetricsCollectorOption func(*MetricsCollector)
 
@@ -400,8 +400,8 @@ func (r *PostgresEnergyRepository) GetSummary(ctx context.Context, startTime, en
 			COALESCE(SUM(CASE WHEN offset_status = 'full' THEN carbon_value ELSE 0 END), 0) as offset_carbon_grams
 		FROM %s
 		WHERE timestamp >= $1 AND timestamp <= $2
-		%s
-	`, r.footprintTable, getChainFilter(chainID))
+		AND ($3 = '' OR chain_id = $3)
+	`, r.footprintTable)
 
 	var summary domain.CarbonFootprintSummary
 	summary.PeriodStart = startTime
@@ -409,7 +409,7 @@ func (r *PostgresEnergyRepository) GetSummary(ctx context.Context, startTime, en
 
 	var totalTx, totalEnergy, totalCarbon, offsetCarbon float64
 
-	err := r.pool.QueryRow(ctx, query, startTime, endTime).Scan(
+	err := r.pool.QueryRow(ctx, query, startTime, endTime, chainID).Scan(
 		&totalTx, &totalEnergy, &totalCarbon, &offsetCarbon,
 	)
 	if err != nil {
@@ -713,13 +713,6 @@ func (r *PostgresEnergyRepository) GetEnergyMix(ctx context.Context, regionCode
 
 // ==================== Helper Functions ====================
 
-func getChainFilter(chainID string) string {
-	if chainID == "" {
-		return ""
-	}
-	return fmt.Sprintf("AND chain_id = '%s'", chainID)
-}
-
 func join(items []string, sep string) string {
 	if len(items) == 0 {
 		return ""
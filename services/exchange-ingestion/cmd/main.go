@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/csic-platform/services/exchange-ingestion/internal/adapter/connector"
+	"github.com/csic-platform/services/exchange-ingestion/internal/adapter/onchain"
 	"github.com/csic-platform/services/exchange-ingestion/internal/adapter/publisher"
 	"github.com/csic-platform/services/exchange-ingestion/internal/adapter/repository"
 	"github.com/csic-platform/services/exchange-ingestion/internal/config"
@@ -59,6 +60,21 @@ func main() {
 	dataSourceRepo := repository.NewPostgresDataSourceRepository(db)
 	marketDataRepo := repository.NewPostgresMarketDataRepository(db)
 	statsRepo := repository.NewPostgresIngestionStatsRepository(db)
+	metricsRepo := repository.NewPostgresExchangeMetricsRepository(db)
+	abuseAlertRepo := repository.NewPostgresMarketAbuseAlertRepository(db)
+	accountClusterRepo := repository.NewPostgresAccountClusterRepository(db)
+
+	// Maintain daily exchange_metrics partitions
+	partitionCtx, stopPartitions := context.WithCancel(context.Background())
+	defer stopPartitions()
+	metricsPartitions := repository.NewPartitionMaintainer(
+		db,
+		"exchange_metrics",
+		cfg.MetricsPartitionPremakeDays,
+		cfg.MetricsRetentionDays,
+		logger,
+	)
+	go metricsPartitions.Run(partitionCtx, cfg.PartitionCheckInterval)
 
 	// Initialize Kafka publisher
 	kafkaPublisher := publisher.NewKafkaPublisher(cfg.KafkaBrokers, cfg.KafkaTopicPrefix, logger)
@@ -68,6 +84,24 @@ func main() {
 	// Initialize connector factory
 	connectorFactory := connector.NewConnectorFactory(logger)
 
+	// Initialize order-book surveillance and keep account clusters up to date
+	surveillanceCtx, stopSurveillance := context.WithCancel(context.Background())
+	defer stopSurveillance()
+	surveillance := service.NewMarketSurveillance(
+		abuseAlertRepo,
+		accountClusterRepo,
+		kafkaPublisher,
+		service.SurveillanceConfig{
+			Window:            cfg.SurveillanceWindow,
+			SpoofMaxLifetime:  cfg.SpoofMaxLifetime,
+			SpoofSizeMultiple: cfg.SpoofSizeMultiple,
+			AlertCooldown:     cfg.SurveillanceAlertCooldown,
+			ClusterRefresh:    cfg.SurveillanceClusterRefresh,
+		},
+		logger,
+	)
+	go surveillance.Start(surveillanceCtx)
+
 	// Initialize services
 	dataSourceService := service.NewDataSourceService(dataSourceRepo, connectorFactory, logger)
 	ingestionService := service.NewIngestionService(
@@ -76,11 +110,28 @@ func main() {
 		statsRepo,
 		kafkaPublisher,
 		connectorFactory,
+		surveillance,
 		logger,
 	)
 
+	// Initialize exchange metrics collection
+	flowClient := onchain.NewFlowClient(cfg.OnChainFlowURL, cfg.DefaultTimeout)
+	metricsCollector := service.NewMetricsCollector(
+		dataSourceRepo,
+		metricsRepo,
+		connectorFactory,
+		flowClient,
+		kafkaPublisher,
+		cfg.MetricsInterval,
+		cfg.VolumeDivergenceThreshold,
+		logger,
+	)
+	if err := metricsCollector.Start(context.Background()); err != nil {
+		logger.Error("Failed to start exchange metrics collection", zap.Error(err))
+	}
+
 	// Initialize HTTP handler
-	httpHandler := handler.NewHTTPHandler(dataSourceService, ingestionService, logger)
+	httpHandler := handler.NewHTTPHandler(dataSourceService, ingestionService, surveillance, logger)
 
 	// Setup router
 	router := chi.NewRouter()
@@ -124,6 +175,10 @@ func main() {
 		logger.Error("Error stopping ingestion", zap.Error(err))
 	}
 
+	if err := metricsCollector.Stop(); err != nil {
+		logger.Error("Error stopping metrics collection", zap.Error(err))
+	}
+
 	// Graceful shutdown of HTTP server
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Error("HTTP server shutdown error", zap.Error(err))
@@ -45,6 +45,26 @@ func (f *ConnectorFactory) CreateConnector(config *domain.DataSourceConfig) (por
 	return connector, nil
 }
 
+// CreateMetricsAdapter creates a metrics adapter for the specified exchange type.
+// Sources with WebSocket enabled stream order books and poll REST for volume.
+func (f *ConnectorFactory) CreateMetricsAdapter(config *domain.DataSourceConfig) (ports.MetricsAdapter, error) {
+	rest, err := NewRESTMetricsAdapter(config, f.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.WSEnabled {
+		return rest, nil
+	}
+
+	connector, err := f.CreateConnector(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStreamingMetricsAdapter(rest, connector, f.logger), nil
+}
+
 // GetSupportedExchangeTypes returns the list of supported exchange types
 func (f *ConnectorFactory) GetSupportedExchangeTypes() []domain.ExchangeType {
 	return []domain.ExchangeType{
@@ -57,5 +77,8 @@ func (f *ConnectorFactory) GetSupportedExchangeTypes() []domain.ExchangeType {
 	}
 }
 
-// Ensure ConnectorFactory implements ExchangeConnectorFactory
-var _ ports.ExchangeConnectorFactory = (*ConnectorFactory)(nil)
+// Ensure ConnectorFactory implements ExchangeConnectorFactory and MetricsAdapterFactory
+var (
+	_ ports.ExchangeConnectorFactory = (*ConnectorFactory)(nil)
+	_ ports.MetricsAdapterFactory    = (*ConnectorFactory)(nil)
+)
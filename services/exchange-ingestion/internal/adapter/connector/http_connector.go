@@ -271,6 +271,12 @@ func (c *HTTPConnector) SubscribeOrderBook(ctx context.Context, symbols []string
 	return nil
 }
 
+// SubscribeOrders is not implemented for HTTP connector
+func (c *HTTPConnector) SubscribeOrders(ctx context.Context, symbols []string, orderChan chan<- domain.OrderEvent) error {
+	c.logger.Info("Order subscription not supported for HTTP connector")
+	return nil
+}
+
 // GetSupportedSymbols returns the list of symbols supported by this connector
 func (c *HTTPConnector) GetSupportedSymbols() []string {
 	return []string{
@@ -0,0 +1,543 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/domain"
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/ports"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// orderBookDepthLevels is the number of price levels requested from depth endpoints
+const orderBookDepthLevels = 50
+
+// metricsDialect describes how to query and parse a specific exchange's REST API
+type metricsDialect interface {
+	tickerURL(endpoint, symbol string) string
+	depthURL(endpoint, symbol string) string
+	parseTicker(body []byte, metrics *domain.ExchangeMetrics) error
+	parseDepth(body []byte) (bids, asks [][]json.RawMessage, err error)
+	// authenticate adds the exchange's credentials to a request
+	authenticate(req *http.Request, config *domain.DataSourceConfig)
+}
+
+// RESTMetricsAdapter implements MetricsAdapter by polling an exchange's REST API
+type RESTMetricsAdapter struct {
+	config     *domain.DataSourceConfig
+	dialect    metricsDialect
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewRESTMetricsAdapter creates a new RESTMetricsAdapter for the configured exchange
+func NewRESTMetricsAdapter(config *domain.DataSourceConfig, logger *zap.Logger) (*RESTMetricsAdapter, error) {
+	dialect, err := dialectFor(config.ExchangeType)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &RESTMetricsAdapter{
+		config:     config,
+		dialect:    dialect,
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+	}, nil
+}
+
+// dialectFor returns the REST dialect for an exchange type
+func dialectFor(exchangeType domain.ExchangeType) (metricsDialect, error) {
+	switch exchangeType {
+	case domain.ExchangeTypeBinance:
+		return binanceDialect{}, nil
+	case domain.ExchangeTypeCoinbase:
+		return coinbaseDialect{}, nil
+	case domain.ExchangeTypeKraken:
+		return krakenDialect{}, nil
+	case domain.ExchangeTypeGeneric, domain.ExchangeTypeCME:
+		return genericDialect{}, nil
+	default:
+		return nil, fmt.Errorf("no metrics adapter for exchange type: %s", exchangeType)
+	}
+}
+
+// GetSourceID returns the data source this adapter collects for
+func (a *RESTMetricsAdapter) GetSourceID() string {
+	return a.config.ID.String()
+}
+
+// GetExchangeType returns the exchange this adapter understands
+func (a *RESTMetricsAdapter) GetExchangeType() domain.ExchangeType {
+	return a.config.ExchangeType
+}
+
+// Start is a no-op for REST adapters, which poll on every collection
+func (a *RESTMetricsAdapter) Start(ctx context.Context, symbols []string) error {
+	return nil
+}
+
+// CollectMetrics fetches ticker and depth data for each symbol
+func (a *RESTMetricsAdapter) CollectMetrics(ctx context.Context, symbols []string) ([]*domain.ExchangeMetrics, error) {
+	if symbols == nil {
+		symbols = a.config.Symbols
+	}
+
+	results := make([]*domain.ExchangeMetrics, 0, len(symbols))
+	for _, symbol := range symbols {
+		metrics, err := a.collectSymbol(ctx, symbol)
+		if err != nil {
+			a.logger.Warn("Failed to collect metrics for symbol",
+				zap.String("source_id", a.GetSourceID()),
+				zap.String("symbol", symbol),
+				zap.Error(err))
+			continue
+		}
+		results = append(results, metrics)
+	}
+
+	if len(results) == 0 && len(symbols) > 0 {
+		return nil, domain.ErrConnectionFailed
+	}
+
+	return results, nil
+}
+
+// collectSymbol fetches and normalizes metrics for a single symbol
+func (a *RESTMetricsAdapter) collectSymbol(ctx context.Context, symbol string) (*domain.ExchangeMetrics, error) {
+	now := time.Now()
+	metrics := &domain.ExchangeMetrics{
+		ID:           uuid.New(),
+		SourceID:     a.GetSourceID(),
+		ExchangeType: a.config.ExchangeType,
+		Symbol:       symbol,
+		BaseSymbol:   extractBaseSymbol(symbol),
+		QuoteSymbol:  extractQuoteSymbol(symbol),
+		Transport:    domain.MetricsTransportREST,
+		CollectedAt:  now,
+		CreatedAt:    now,
+	}
+
+	body, err := a.get(ctx, a.dialect.tickerURL(a.config.Endpoint, symbol))
+	if err != nil {
+		return nil, err
+	}
+	if err := a.dialect.parseTicker(body, metrics); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDataParseFailed, err)
+	}
+
+	if url := a.dialect.depthURL(a.config.Endpoint, symbol); url != "" {
+		body, err := a.get(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		bids, asks, err := a.dialect.parseDepth(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrDataParseFailed, err)
+		}
+		applyDepth(metrics, parseLevels(bids), parseLevels(asks))
+	}
+
+	metrics.ComputeSpread()
+	return metrics, nil
+}
+
+// get performs an authenticated GET request and returns the response body
+func (a *RESTMetricsAdapter) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	a.dialect.authenticate(req, a.config)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, domain.ErrRateLimited
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return raw, nil
+}
+
+// Close releases resources held by the adapter
+func (a *RESTMetricsAdapter) Close() error {
+	a.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// StreamingMetricsAdapter implements MetricsAdapter using a WebSocket order book
+// subscription for book metrics and REST polling for rolling volume
+type StreamingMetricsAdapter struct {
+	rest      *RESTMetricsAdapter
+	connector ports.ExchangeConnector
+	logger    *zap.Logger
+
+	books  map[string]domain.OrderBook
+	mu     sync.RWMutex
+	cancel context.CancelFunc
+}
+
+// NewStreamingMetricsAdapter creates an adapter that keeps the latest streamed order books
+func NewStreamingMetricsAdapter(rest *RESTMetricsAdapter, connector ports.ExchangeConnector, logger *zap.Logger) *StreamingMetricsAdapter {
+	return &StreamingMetricsAdapter{
+		rest:      rest,
+		connector: connector,
+		logger:    logger,
+		books:     make(map[string]domain.OrderBook),
+	}
+}
+
+// Start connects the underlying connector and subscribes to order book updates
+func (a *StreamingMetricsAdapter) Start(ctx context.Context, symbols []string) error {
+	if err := a.connector.Connect(ctx); err != nil {
+		return err
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	a.cancel = cancel
+
+	updates := make(chan domain.OrderBook, 1000)
+	if err := a.connector.SubscribeOrderBook(streamCtx, symbols, updates); err != nil {
+		cancel()
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-streamCtx.Done():
+				return
+			case book := <-updates:
+				a.mu.Lock()
+				a.books[book.Symbol] = book
+				a.mu.Unlock()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// GetSourceID returns the data source this adapter collects for
+func (a *StreamingMetricsAdapter) GetSourceID() string {
+	return a.rest.GetSourceID()
+}
+
+// GetExchangeType returns the exchange this adapter understands
+func (a *StreamingMetricsAdapter) GetExchangeType() domain.ExchangeType {
+	return a.rest.GetExchangeType()
+}
+
+// CollectMetrics combines REST volume with the latest streamed order book when available
+func (a *StreamingMetricsAdapter) CollectMetrics(ctx context.Context, symbols []string) ([]*domain.ExchangeMetrics, error) {
+	results, err := a.rest.CollectMetrics(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, metrics := range results {
+		book, ok := a.books[metrics.Symbol]
+		if !ok {
+			continue
+		}
+		applyDepth(metrics, book.Bids, book.Asks)
+		metrics.Transport = domain.MetricsTransportWebSocket
+		metrics.ComputeSpread()
+	}
+
+	return results, nil
+}
+
+// Close stops the order book subscription and disconnects
+func (a *StreamingMetricsAdapter) Close() error {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.rest.Close()
+	return a.connector.Disconnect(context.Background())
+}
+
+// applyDepth sets best bid/ask and depth totals from sorted book levels
+func applyDepth(metrics *domain.ExchangeMetrics, bids, asks []domain.OrderBookEntry) {
+	if len(bids) > 0 {
+		metrics.BestBid = bids[0].Price
+	}
+	if len(asks) > 0 {
+		metrics.BestAsk = asks[0].Price
+	}
+
+	metrics.BidDepth = decimal.Zero
+	for _, level := range bids {
+		metrics.BidDepth = metrics.BidDepth.Add(level.Quantity)
+	}
+	metrics.AskDepth = decimal.Zero
+	for _, level := range asks {
+		metrics.AskDepth = metrics.AskDepth.Add(level.Quantity)
+	}
+}
+
+// parseLevels converts [price, quantity, ...] arrays into order book entries
+func parseLevels(levels [][]json.RawMessage) []domain.OrderBookEntry {
+	entries := make([]domain.OrderBookEntry, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		price, err := rawDecimal(level[0])
+		if err != nil {
+			continue
+		}
+		quantity, err := rawDecimal(level[1])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, domain.OrderBookEntry{Price: price, Quantity: quantity})
+	}
+	return entries
+}
+
+// rawDecimal parses a JSON string or number into a decimal
+func rawDecimal(raw json.RawMessage) (decimal.Decimal, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return decimal.NewFromString(s)
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromString(n.String())
+}
+
+// stringDecimal parses a decimal string, treating empty values as zero
+func stringDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
+
+// binanceDialect parses Binance spot REST responses
+type binanceDialect struct{}
+
+func (binanceDialect) tickerURL(endpoint, symbol string) string {
+	return fmt.Sprintf("%s/api/v3/ticker/24hr?symbol=%s", endpoint, symbol)
+}
+
+func (binanceDialect) depthURL(endpoint, symbol string) string {
+	return fmt.Sprintf("%s/api/v3/depth?symbol=%s&limit=%d", endpoint, symbol, orderBookDepthLevels)
+}
+
+func (binanceDialect) parseTicker(body []byte, metrics *domain.ExchangeMetrics) error {
+	var ticker TickerResponse
+	var extra struct {
+		QuoteVolume string `json:"quoteVolume"`
+	}
+	if err := json.Unmarshal(body, &ticker); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, &extra); err != nil {
+		return err
+	}
+	metrics.LastPrice = stringDecimal(ticker.LastPrice)
+	metrics.BestBid = stringDecimal(ticker.BidPrice)
+	metrics.BestAsk = stringDecimal(ticker.AskPrice)
+	metrics.Volume24h = stringDecimal(ticker.Volume)
+	metrics.QuoteVolume = stringDecimal(extra.QuoteVolume)
+	return nil
+}
+
+func (binanceDialect) parseDepth(body []byte) ([][]json.RawMessage, [][]json.RawMessage, error) {
+	var depth struct {
+		Bids [][]json.RawMessage `json:"bids"`
+		Asks [][]json.RawMessage `json:"asks"`
+	}
+	err := json.Unmarshal(body, &depth)
+	return depth.Bids, depth.Asks, err
+}
+
+func (binanceDialect) authenticate(req *http.Request, config *domain.DataSourceConfig) {
+	if config.AuthType == domain.AuthTypeAPIKey {
+		req.Header.Set("X-MBX-APIKEY", config.APIKey)
+	}
+}
+
+// coinbaseDialect parses Coinbase Exchange REST responses
+type coinbaseDialect struct{}
+
+func (coinbaseDialect) tickerURL(endpoint, symbol string) string {
+	return fmt.Sprintf("%s/products/%s/ticker", endpoint, symbol)
+}
+
+func (coinbaseDialect) depthURL(endpoint, symbol string) string {
+	return fmt.Sprintf("%s/products/%s/book?level=2", endpoint, symbol)
+}
+
+func (coinbaseDialect) parseTicker(body []byte, metrics *domain.ExchangeMetrics) error {
+	var ticker struct {
+		Price  string `json:"price"`
+		Bid    string `json:"bid"`
+		Ask    string `json:"ask"`
+		Volume string `json:"volume"`
+	}
+	if err := json.Unmarshal(body, &ticker); err != nil {
+		return err
+	}
+	metrics.LastPrice = stringDecimal(ticker.Price)
+	metrics.BestBid = stringDecimal(ticker.Bid)
+	metrics.BestAsk = stringDecimal(ticker.Ask)
+	metrics.Volume24h = stringDecimal(ticker.Volume)
+	metrics.QuoteVolume = metrics.Volume24h.Mul(metrics.LastPrice)
+	return nil
+}
+
+func (coinbaseDialect) parseDepth(body []byte) ([][]json.RawMessage, [][]json.RawMessage, error) {
+	return binanceDialect{}.parseDepth(body)
+}
+
+// authenticate is a no-op: ticker and book are public endpoints, and Coinbase
+// rejects a CB-ACCESS-KEY header sent without its request signature
+func (coinbaseDialect) authenticate(req *http.Request, config *domain.DataSourceConfig) {}
+
+// krakenDialect parses Kraken public REST responses
+type krakenDialect struct{}
+
+func (krakenDialect) tickerURL(endpoint, symbol string) string {
+	return fmt.Sprintf("%s/0/public/Ticker?pair=%s", endpoint, symbol)
+}
+
+func (krakenDialect) depthURL(endpoint, symbol string) string {
+	return fmt.Sprintf("%s/0/public/Depth?pair=%s&count=%d", endpoint, symbol, orderBookDepthLevels)
+}
+
+func (krakenDialect) parseTicker(body []byte, metrics *domain.ExchangeMetrics) error {
+	var resp struct {
+		Error  []string `json:"error"`
+		Result map[string]struct {
+			Ask    []string `json:"a"`
+			Bid    []string `json:"b"`
+			Last   []string `json:"c"`
+			Volume []string `json:"v"`
+			VWAP   []string `json:"p"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return err
+	}
+	if len(resp.Error) > 0 {
+		return fmt.Errorf("kraken error: %v", resp.Error)
+	}
+	for _, ticker := range resp.Result {
+		if len(ticker.Last) > 0 {
+			metrics.LastPrice = stringDecimal(ticker.Last[0])
+		}
+		if len(ticker.Bid) > 0 {
+			metrics.BestBid = stringDecimal(ticker.Bid[0])
+		}
+		if len(ticker.Ask) > 0 {
+			metrics.BestAsk = stringDecimal(ticker.Ask[0])
+		}
+		// Index 1 holds the rolling 24 hour values
+		if len(ticker.Volume) > 1 {
+			metrics.Volume24h = stringDecimal(ticker.Volume[1])
+		}
+		if len(ticker.VWAP) > 1 {
+			metrics.QuoteVolume = metrics.Volume24h.Mul(stringDecimal(ticker.VWAP[1]))
+		}
+		return nil
+	}
+	return fmt.Errorf("kraken ticker result is empty")
+}
+
+func (krakenDialect) parseDepth(body []byte) ([][]json.RawMessage, [][]json.RawMessage, error) {
+	var resp struct {
+		Error  []string `json:"error"`
+		Result map[string]struct {
+			Bids [][]json.RawMessage `json:"bids"`
+			Asks [][]json.RawMessage `json:"asks"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Error) > 0 {
+		return nil, nil, fmt.Errorf("kraken error: %v", resp.Error)
+	}
+	for _, book := range resp.Result {
+		return book.Bids, book.Asks, nil
+	}
+	return nil, nil, fmt.Errorf("kraken depth result is empty")
+}
+
+// authenticate is a no-op: Kraken's public endpoints take no credentials, and
+// its API-Key header is only accepted on signed private requests
+func (krakenDialect) authenticate(req *http.Request, config *domain.DataSourceConfig) {}
+
+// genericDialect parses the platform's generic ticker format; depth is not available
+type genericDialect struct{}
+
+func (genericDialect) tickerURL(endpoint, symbol string) string {
+	return fmt.Sprintf("%s/ticker?symbol=%s", endpoint, symbol)
+}
+
+func (genericDialect) depthURL(endpoint, symbol string) string {
+	return ""
+}
+
+func (genericDialect) parseTicker(body []byte, metrics *domain.ExchangeMetrics) error {
+	var ticker TickerResponse
+	if err := json.Unmarshal(body, &ticker); err != nil {
+		return err
+	}
+	metrics.LastPrice = stringDecimal(ticker.LastPrice)
+	if metrics.LastPrice.IsZero() {
+		metrics.LastPrice = stringDecimal(ticker.Price)
+	}
+	metrics.BestBid = stringDecimal(ticker.BidPrice)
+	metrics.BestAsk = stringDecimal(ticker.AskPrice)
+	metrics.Volume24h = stringDecimal(ticker.Volume)
+	metrics.QuoteVolume = metrics.Volume24h.Mul(metrics.LastPrice)
+	return nil
+}
+
+func (genericDialect) parseDepth(body []byte) ([][]json.RawMessage, [][]json.RawMessage, error) {
+	return nil, nil, nil
+}
+
+func (genericDialect) authenticate(req *http.Request, config *domain.DataSourceConfig) {
+	if config.AuthType == domain.AuthTypeAPIKey {
+		req.Header.Set("X-API-Key", config.APIKey)
+	}
+}
+
+// Ensure adapters implement MetricsAdapter
+var (
+	_ ports.MetricsAdapter = (*RESTMetricsAdapter)(nil)
+	_ ports.MetricsAdapter = (*StreamingMetricsAdapter)(nil)
+)
@@ -204,6 +204,12 @@ func (c *MockConnector) SubscribeOrderBook(ctx context.Context, symbols []string
 	return nil
 }
 
+// SubscribeOrders is not implemented for mock connector
+func (c *MockConnector) SubscribeOrders(ctx context.Context, symbols []string, orderChan chan<- domain.OrderEvent) error {
+	c.logger.Info("Order subscription not supported for mock connector")
+	return nil
+}
+
 // GetSupportedSymbols returns the list of symbols supported by this connector
 func (c *MockConnector) GetSupportedSymbols() []string {
 	return []string{
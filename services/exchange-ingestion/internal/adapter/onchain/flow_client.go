@@ -0,0 +1,76 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/ports"
+	"github.com/shopspring/decimal"
+)
+
+// FlowClient implements OnChainFlowProvider against the blockchain indexer's exchange flow API
+type FlowClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewFlowClient creates a new FlowClient
+func NewFlowClient(baseURL string, timeout time.Duration) *FlowClient {
+	return &FlowClient{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// flowResponse is the indexer response for an exchange flow query
+type flowResponse struct {
+	InflowBTC  string `json:"inflow_btc"`
+	OutflowBTC string `json:"outflow_btc"`
+}
+
+// GetExchangeFlowBTC returns total inbound plus outbound BTC-equivalent flow in the window
+func (c *FlowClient) GetExchangeFlowBTC(ctx context.Context, sourceID string, start, end time.Time) (decimal.Decimal, error) {
+	query := url.Values{}
+	query.Set("start", start.UTC().Format(time.RFC3339))
+	query.Set("end", end.UTC().Format(time.RFC3339))
+
+	endpoint := fmt.Sprintf("%s/api/v1/exchanges/%s/flows?%s", c.baseURL, url.PathEscape(sourceID), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("flow request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decimal.Zero, fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+
+	var flows flowResponse
+	if err := json.NewDecoder(resp.Body).Decode(&flows); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to decode flow response: %w", err)
+	}
+
+	inflow, err := decimal.NewFromString(flows.InflowBTC)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid inflow value: %w", err)
+	}
+	outflow, err := decimal.NewFromString(flows.OutflowBTC)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid outflow value: %w", err)
+	}
+
+	return inflow.Add(outflow), nil
+}
+
+// Ensure FlowClient implements OnChainFlowProvider
+var _ ports.OnChainFlowProvider = (*FlowClient)(nil)
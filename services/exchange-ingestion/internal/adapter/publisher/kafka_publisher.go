@@ -186,6 +186,69 @@ func (p *KafkaPublisher) PublishBatch(ctx context.Context, data []*domain.Market
 	return nil
 }
 
+// PublishVolumeAnomaly publishes a reported-vs-on-chain volume divergence alert
+func (p *KafkaPublisher) PublishVolumeAnomaly(ctx context.Context, anomaly *domain.VolumeAnomaly) error {
+	topic := p.getTopicName("volume_anomalies")
+
+	value, err := json.Marshal(anomaly)
+	if err != nil {
+		return fmt.Errorf("failed to marshal volume anomaly: %w", err)
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(anomaly.SourceID),
+		Value: value,
+		Time:  time.Now(),
+	}
+
+	if err := p.getWriter(topic).WriteMessages(ctx, msg); err != nil {
+		p.logger.Error("Failed to publish volume anomaly",
+			zap.String("topic", topic),
+			zap.String("source_id", anomaly.SourceID),
+			zap.Error(err))
+		return fmt.Errorf("failed to publish volume anomaly: %w", err)
+	}
+
+	p.logger.Info("Published volume anomaly",
+		zap.String("topic", topic),
+		zap.String("source_id", anomaly.SourceID),
+		zap.String("ratio", anomaly.DivergenceRatio.StringFixed(2)))
+
+	return nil
+}
+
+// PublishMarketAbuseAlert publishes a wash trading or spoofing alert with its evidence
+func (p *KafkaPublisher) PublishMarketAbuseAlert(ctx context.Context, alert *domain.MarketAbuseAlert) error {
+	topic := p.getTopicName("market_abuse_alerts")
+
+	value, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal market abuse alert: %w", err)
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(alert.SourceID + ":" + alert.Symbol),
+		Value: value,
+		Time:  time.Now(),
+	}
+
+	if err := p.getWriter(topic).WriteMessages(ctx, msg); err != nil {
+		p.logger.Error("Failed to publish market abuse alert",
+			zap.String("topic", topic),
+			zap.String("alert_id", alert.ID.String()),
+			zap.Error(err))
+		return fmt.Errorf("failed to publish market abuse alert: %w", err)
+	}
+
+	p.logger.Info("Published market abuse alert",
+		zap.String("topic", topic),
+		zap.String("pattern", string(alert.Pattern)),
+		zap.String("source_id", alert.SourceID),
+		zap.String("symbol", alert.Symbol))
+
+	return nil
+}
+
 // Close closes all Kafka writers
 func (p *KafkaPublisher) Close() error {
 	p.logger.Info("Closing Kafka publishers")
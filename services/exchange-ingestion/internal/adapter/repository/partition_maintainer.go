@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// partitionDayLayout is the date suffix of daily partition names
+const partitionDayLayout = "20060102"
+
+// PartitionMaintainer manages the daily partitions of a table range-partitioned
+// on a timestamp column. Partitions are named <table>_p<YYYYMMDD> and cover
+// one UTC day each.
+type PartitionMaintainer struct {
+	db        *sql.DB
+	table     string
+	premake   int
+	retention int
+	logger    *zap.Logger
+}
+
+// NewPartitionMaintainer creates a new PartitionMaintainer. premake is how many
+// days after today get a partition in advance; retention is how many days
+// before today stay attached, with zero keeping every partition.
+func NewPartitionMaintainer(db *sql.DB, table string, premake, retention int, logger *zap.Logger) *PartitionMaintainer {
+	return &PartitionMaintainer{
+		db:        db,
+		table:     table,
+		premake:   premake,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// Run maintains partitions now and then every interval until ctx is cancelled
+func (m *PartitionMaintainer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Maintain(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			m.logger.Error("Failed to maintain partitions",
+				zap.String("table", m.table),
+				zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Maintain creates the partitions for today and the premake days after it, then
+// detaches partitions older than the retention window
+func (m *PartitionMaintainer) Maintain(ctx context.Context, now time.Time) error {
+	today := startOfDay(now)
+
+	for i := 0; i <= m.premake; i++ {
+		day := today.AddDate(0, 0, i)
+		name := m.partitionName(day)
+
+		query := fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')`,
+			pq.QuoteIdentifier(name),
+			pq.QuoteIdentifier(m.table),
+			day.Format(time.RFC3339),
+			day.AddDate(0, 0, 1).Format(time.RFC3339),
+		)
+		if _, err := m.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create partition %s: %w", name, err)
+		}
+	}
+
+	if m.retention <= 0 {
+		return nil
+	}
+	return m.detachBefore(ctx, today.AddDate(0, 0, -m.retention))
+}
+
+// detachBefore detaches partitions for days that end on or before cutoff.
+// Detached tables are kept so they can be archived or dropped separately.
+func (m *PartitionMaintainer) detachBefore(ctx context.Context, cutoff time.Time) error {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = to_regclass($1)
+	`, m.table)
+	if err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	var expired []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan partition: %w", err)
+		}
+		// The default partition and tables not named by the maintainer are skipped
+		day, err := time.Parse(partitionDayLayout, strings.TrimPrefix(name, m.table+"_p"))
+		if err != nil || !strings.HasPrefix(name, m.table+"_p") {
+			continue
+		}
+		if !day.AddDate(0, 0, 1).After(cutoff) {
+			expired = append(expired, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	for _, name := range expired {
+		query := fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`, pq.QuoteIdentifier(m.table), pq.QuoteIdentifier(name))
+		if _, err := m.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to detach partition %s: %w", name, err)
+		}
+		m.logger.Info("Detached expired partition",
+			zap.String("table", m.table),
+			zap.String("partition", name))
+	}
+
+	return nil
+}
+
+func (m *PartitionMaintainer) partitionName(day time.Time) string {
+	return m.table + "_p" + day.Format(partitionDayLayout)
+}
+
+// startOfDay returns midnight UTC of the day containing t
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
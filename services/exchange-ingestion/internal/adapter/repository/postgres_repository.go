@@ -643,3 +643,140 @@ func (r *PostgresIngestionStatsRepository) RecordLatency(ctx context.Context, so
 var _ ports.DataSourceRepository = (*PostgresDataSourceRepository)(nil)
 var _ ports.MarketDataRepository = (*PostgresMarketDataRepository)(nil)
 var _ ports.IngestionStatsRepository = (*PostgresIngestionStatsRepository)(nil)
+var _ ports.ExchangeMetricsRepository = (*PostgresExchangeMetricsRepository)(nil)
+
+// PostgresExchangeMetricsRepository implements ExchangeMetricsRepository using PostgreSQL
+type PostgresExchangeMetricsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresExchangeMetricsRepository creates a new PostgresExchangeMetricsRepository
+func NewPostgresExchangeMetricsRepository(db *sql.DB) *PostgresExchangeMetricsRepository {
+	return &PostgresExchangeMetricsRepository{db: db}
+}
+
+// latestMetricsWindow limits FindLatest to recent daily partitions of
+// exchange_metrics; symbols not collected within it are omitted
+const latestMetricsWindow = 24 * time.Hour
+
+const exchangeMetricsColumns = `id, source_id, exchange_type, symbol, base_symbol, quote_symbol,
+		       last_price, best_bid, best_ask, spread_bps, bid_depth, ask_depth,
+		       volume_24h, quote_volume_24h, volume_btc, transport, collected_at, created_at`
+
+// StoreBatch saves multiple exchange metrics rows in a single operation
+func (r *PostgresExchangeMetricsRepository) StoreBatch(ctx context.Context, metrics []*domain.ExchangeMetrics) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO exchange_metrics (`+exchangeMetricsColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, m := range metrics {
+		_, err := stmt.ExecContext(ctx,
+			m.ID,
+			m.SourceID,
+			m.ExchangeType,
+			m.Symbol,
+			m.BaseSymbol,
+			m.QuoteSymbol,
+			m.LastPrice,
+			m.BestBid,
+			m.BestAsk,
+			m.SpreadBPS,
+			m.BidDepth,
+			m.AskDepth,
+			m.Volume24h,
+			m.QuoteVolume,
+			m.VolumeBTC,
+			m.Transport,
+			m.CollectedAt,
+			m.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert exchange metrics: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByTimeRange retrieves metrics for a source and symbol within a time range
+func (r *PostgresExchangeMetricsRepository) FindByTimeRange(
+	ctx context.Context,
+	sourceID, symbol string,
+	start, end time.Time,
+) ([]*domain.ExchangeMetrics, error) {
+	query := `
+		SELECT ` + exchangeMetricsColumns + `
+		FROM exchange_metrics
+		WHERE source_id = $1 AND symbol = $2 AND collected_at >= $3 AND collected_at <= $4
+		ORDER BY collected_at ASC
+	`
+
+	return r.query(ctx, query, sourceID, symbol, start, end)
+}
+
+// FindLatest retrieves the most recent metrics row per symbol for a source,
+// looking back at most latestMetricsWindow
+func (r *PostgresExchangeMetricsRepository) FindLatest(ctx context.Context, sourceID string) ([]*domain.ExchangeMetrics, error) {
+	query := `
+		SELECT DISTINCT ON (symbol) ` + exchangeMetricsColumns + `
+		FROM exchange_metrics
+		WHERE source_id = $1 AND collected_at >= $2
+		ORDER BY symbol, collected_at DESC
+	`
+
+	return r.query(ctx, query, sourceID, time.Now().Add(-latestMetricsWindow))
+}
+
+// query runs a metrics query and scans all rows
+func (r *PostgresExchangeMetricsRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.ExchangeMetrics, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exchange metrics: %w", err)
+	}
+	defer rows.Close()
+
+	var metricsList []*domain.ExchangeMetrics
+	for rows.Next() {
+		var m domain.ExchangeMetrics
+		if err := rows.Scan(
+			&m.ID,
+			&m.SourceID,
+			&m.ExchangeType,
+			&m.Symbol,
+			&m.BaseSymbol,
+			&m.QuoteSymbol,
+			&m.LastPrice,
+			&m.BestBid,
+			&m.BestAsk,
+			&m.SpreadBPS,
+			&m.BidDepth,
+			&m.AskDepth,
+			&m.Volume24h,
+			&m.QuoteVolume,
+			&m.VolumeBTC,
+			&m.Transport,
+			&m.CollectedAt,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan exchange metrics: %w", err)
+		}
+		metricsList = append(metricsList, &m)
+	}
+
+	return metricsList, rows.Err()
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/domain"
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/ports"
+	"github.com/lib/pq"
+)
+
+// Ensure ports interfaces are implemented
+var _ ports.MarketAbuseAlertRepository = (*PostgresMarketAbuseAlertRepository)(nil)
+var _ ports.AccountClusterRepository = (*PostgresAccountClusterRepository)(nil)
+
+// PostgresMarketAbuseAlertRepository implements MarketAbuseAlertRepository using PostgreSQL
+type PostgresMarketAbuseAlertRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresMarketAbuseAlertRepository creates a new PostgresMarketAbuseAlertRepository
+func NewPostgresMarketAbuseAlertRepository(db *sql.DB) *PostgresMarketAbuseAlertRepository {
+	return &PostgresMarketAbuseAlertRepository{db: db}
+}
+
+const marketAbuseAlertColumns = `id, alert_type, pattern, severity, source_id, symbol, accounts,
+		       cluster_id, description, window_start, window_end, trades, orders, detected_at`
+
+// Store saves an alert together with its evidence
+func (r *PostgresMarketAbuseAlertRepository) Store(ctx context.Context, alert *domain.MarketAbuseAlert) error {
+	trades, err := json.Marshal(alert.Trades)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert trades: %w", err)
+	}
+	orders, err := json.Marshal(alert.Orders)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert orders: %w", err)
+	}
+
+	query := `
+		INSERT INTO market_abuse_alerts (` + marketAbuseAlertColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12, $13, $14)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		alert.ID,
+		alert.AlertType,
+		alert.Pattern,
+		alert.Severity,
+		alert.SourceID,
+		alert.Symbol,
+		pq.Array(alert.Accounts),
+		alert.ClusterID,
+		alert.Description,
+		alert.WindowStart,
+		alert.WindowEnd,
+		trades,
+		orders,
+		alert.DetectedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store market abuse alert: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves an alert by its unique identifier
+func (r *PostgresMarketAbuseAlertRepository) FindByID(ctx context.Context, id string) (*domain.MarketAbuseAlert, error) {
+	query := `SELECT ` + marketAbuseAlertColumns + ` FROM market_abuse_alerts WHERE id = $1`
+
+	alert, err := scanMarketAbuseAlert(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrMarketAbuseAlertNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market abuse alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// Find retrieves the most recent alerts matching the filter
+func (r *PostgresMarketAbuseAlertRepository) Find(ctx context.Context, filter domain.MarketAbuseAlertFilter) ([]*domain.MarketAbuseAlert, error) {
+	conditions := []string{"TRUE"}
+	var args []interface{}
+	if filter.SourceID != "" {
+		args = append(args, filter.SourceID)
+		conditions = append(conditions, fmt.Sprintf("source_id = $%d", len(args)))
+	}
+	if filter.Symbol != "" {
+		args = append(args, filter.Symbol)
+		conditions = append(conditions, fmt.Sprintf("symbol = $%d", len(args)))
+	}
+	if filter.Pattern != "" {
+		args = append(args, filter.Pattern)
+		conditions = append(conditions, fmt.Sprintf("pattern = $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM market_abuse_alerts
+		WHERE %s
+		ORDER BY detected_at DESC
+		LIMIT $%d
+	`, marketAbuseAlertColumns, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market abuse alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []*domain.MarketAbuseAlert{}
+	for rows.Next() {
+		alert, err := scanMarketAbuseAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan market abuse alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanMarketAbuseAlert scans a market_abuse_alerts row and decodes its evidence
+func scanMarketAbuseAlert(row rowScanner) (*domain.MarketAbuseAlert, error) {
+	var a domain.MarketAbuseAlert
+	var clusterID sql.NullString
+	var trades, orders []byte
+	if err := row.Scan(
+		&a.ID,
+		&a.AlertType,
+		&a.Pattern,
+		&a.Severity,
+		&a.SourceID,
+		&a.Symbol,
+		pq.Array(&a.Accounts),
+		&clusterID,
+		&a.Description,
+		&a.WindowStart,
+		&a.WindowEnd,
+		&trades,
+		&orders,
+		&a.DetectedAt,
+	); err != nil {
+		return nil, err
+	}
+	a.ClusterID = clusterID.String
+
+	if err := json.Unmarshal(trades, &a.Trades); err != nil {
+		return nil, fmt.Errorf("failed to decode alert trades: %w", err)
+	}
+	if err := json.Unmarshal(orders, &a.Orders); err != nil {
+		return nil, fmt.Errorf("failed to decode alert orders: %w", err)
+	}
+
+	return &a, nil
+}
+
+// PostgresAccountClusterRepository implements AccountClusterRepository using PostgreSQL
+type PostgresAccountClusterRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAccountClusterRepository creates a new PostgresAccountClusterRepository
+func NewPostgresAccountClusterRepository(db *sql.DB) *PostgresAccountClusterRepository {
+	return &PostgresAccountClusterRepository{db: db}
+}
+
+// FindBySource retrieves the cluster links of all accounts on a data source
+func (r *PostgresAccountClusterRepository) FindBySource(ctx context.Context, sourceID string) ([]*domain.AccountCluster, error) {
+	return r.query(ctx, `
+		SELECT source_id, account_id, cluster_id, COALESCE(reason, ''), updated_at
+		FROM exchange_account_clusters
+		WHERE source_id = $1
+		ORDER BY cluster_id, account_id
+	`, sourceID)
+}
+
+// FindAll retrieves the cluster links of every data source
+func (r *PostgresAccountClusterRepository) FindAll(ctx context.Context) ([]*domain.AccountCluster, error) {
+	return r.query(ctx, `
+		SELECT source_id, account_id, cluster_id, COALESCE(reason, ''), updated_at
+		FROM exchange_account_clusters
+	`)
+}
+
+// Save creates or replaces an account's cluster link
+func (r *PostgresAccountClusterRepository) Save(ctx context.Context, cluster *domain.AccountCluster) error {
+	query := `
+		INSERT INTO exchange_account_clusters (source_id, account_id, cluster_id, reason, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		ON CONFLICT (source_id, account_id) DO UPDATE SET
+			cluster_id = EXCLUDED.cluster_id,
+			reason = EXCLUDED.reason,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		cluster.SourceID,
+		cluster.AccountID,
+		cluster.ClusterID,
+		cluster.Reason,
+		cluster.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save account cluster: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an account's cluster link
+func (r *PostgresAccountClusterRepository) Delete(ctx context.Context, sourceID, accountID string) error {
+	query := `DELETE FROM exchange_account_clusters WHERE source_id = $1 AND account_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, sourceID, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to delete account cluster: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrRepositoryNotFound
+	}
+
+	return nil
+}
+
+// query runs an account cluster query and scans all rows
+func (r *PostgresAccountClusterRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.AccountCluster, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account clusters: %w", err)
+	}
+	defer rows.Close()
+
+	clusters := []*domain.AccountCluster{}
+	for rows.Next() {
+		var c domain.AccountCluster
+		if err := rows.Scan(&c.SourceID, &c.AccountID, &c.ClusterID, &c.Reason, &c.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan account cluster: %w", err)
+		}
+		clusters = append(clusters, &c)
+	}
+
+	return clusters, rows.Err()
+}
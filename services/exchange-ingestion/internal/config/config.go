@@ -32,6 +32,23 @@ type Config struct {
 	DefaultTimeout     time.Duration `envconfig:"DEFAULT_TIMEOUT" default:"30s"`
 	MaxRetryAttempts   int           `envconfig:"MAX_RETRY_ATTEMPTS" default:"3"`
 
+	// Exchange metrics settings
+	MetricsInterval           time.Duration `envconfig:"METRICS_INTERVAL" default:"1m"`
+	VolumeDivergenceThreshold float64       `envconfig:"VOLUME_DIVERGENCE_THRESHOLD" default:"20"`
+	OnChainFlowURL            string        `envconfig:"ONCHAIN_FLOW_URL" default:"http://localhost:8090"`
+
+	// Market surveillance settings
+	SurveillanceWindow         time.Duration `envconfig:"SURVEILLANCE_WINDOW" default:"5m"`
+	SpoofMaxLifetime           time.Duration `envconfig:"SPOOF_MAX_LIFETIME" default:"10s"`
+	SpoofSizeMultiple          float64       `envconfig:"SPOOF_SIZE_MULTIPLE" default:"10"`
+	SurveillanceAlertCooldown  time.Duration `envconfig:"SURVEILLANCE_ALERT_COOLDOWN" default:"15m"`
+	SurveillanceClusterRefresh time.Duration `envconfig:"SURVEILLANCE_CLUSTER_REFRESH" default:"5m"`
+
+	// Partitioning settings (exchange_metrics is partitioned by day)
+	MetricsPartitionPremakeDays int           `envconfig:"METRICS_PARTITION_PREMAKE_DAYS" default:"7"`
+	MetricsRetentionDays        int           `envconfig:"METRICS_RETENTION_DAYS" default:"90"`
+	PartitionCheckInterval      time.Duration `envconfig:"PARTITION_CHECK_INTERVAL" default:"1h"`
+
 	// Logging settings
 	LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
 }
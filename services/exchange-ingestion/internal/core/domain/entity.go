@@ -35,6 +35,12 @@ type Trade struct {
 	Side          TradeSide       `json:"side" db:"side"`
 	Timestamp     time.Time       `json:"timestamp" db:"timestamp"`
 	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+
+	// Counterparty attribution, only present on regulatory feeds
+	BuyerAccount  string `json:"buyer_account,omitempty" db:"buyer_account"`
+	SellerAccount string `json:"seller_account,omitempty" db:"seller_account"`
+	BuyOrderID    string `json:"buy_order_id,omitempty" db:"buy_order_id"`
+	SellOrderID   string `json:"sell_order_id,omitempty" db:"sell_order_id"`
 }
 
 // TradeSide represents the direction of a trade
@@ -42,6 +42,10 @@ var (
 	ErrIngestionAlreadyRunning  = errors.New("ingestion is already running")
 	ErrIngestionOverflow        = errors.New("ingestion buffer overflow")
 	ErrProcessingTimeout        = errors.New("data processing timed out")
+
+	// Surveillance errors
+	ErrMarketAbuseAlertNotFound = errors.New("market abuse alert not found")
+	ErrInvalidAccountCluster    = errors.New("invalid account cluster")
 )
 
 // dataOutOfOrderError is returned when data is received out of expected order
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeMetrics represents normalized order book and volume metrics for a symbol
+// collected from an exchange over a collection window
+type ExchangeMetrics struct {
+	ID           uuid.UUID       `json:"id" db:"id"`
+	SourceID     string          `json:"source_id" db:"source_id"`
+	ExchangeType ExchangeType    `json:"exchange_type" db:"exchange_type"`
+	Symbol       string          `json:"symbol" db:"symbol"`
+	BaseSymbol   string          `json:"base_symbol" db:"base_symbol"`
+	QuoteSymbol  string          `json:"quote_symbol" db:"quote_symbol"`
+	LastPrice    decimal.Decimal `json:"last_price" db:"last_price"`
+	BestBid      decimal.Decimal `json:"best_bid" db:"best_bid"`
+	BestAsk      decimal.Decimal `json:"best_ask" db:"best_ask"`
+	SpreadBPS    decimal.Decimal `json:"spread_bps" db:"spread_bps"`
+	BidDepth     decimal.Decimal `json:"bid_depth" db:"bid_depth"`
+	AskDepth     decimal.Decimal `json:"ask_depth" db:"ask_depth"`
+	Volume24h    decimal.Decimal `json:"volume_24h" db:"volume_24h"`
+	QuoteVolume  decimal.Decimal `json:"quote_volume_24h" db:"quote_volume_24h"`
+	VolumeBTC    decimal.Decimal `json:"volume_btc" db:"volume_btc"`
+	Transport    string          `json:"transport" db:"transport"`
+	CollectedAt  time.Time       `json:"collected_at" db:"collected_at"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Metrics transports describe how a metrics sample was obtained
+const (
+	MetricsTransportREST      = "REST"
+	MetricsTransportWebSocket = "WEBSOCKET"
+)
+
+// Mid returns the mid price between the best bid and ask
+func (m *ExchangeMetrics) Mid() decimal.Decimal {
+	if m.BestBid.IsZero() || m.BestAsk.IsZero() {
+		return m.LastPrice
+	}
+	return m.BestBid.Add(m.BestAsk).Div(decimal.NewFromInt(2))
+}
+
+// ComputeSpread sets SpreadBPS from the best bid and ask
+func (m *ExchangeMetrics) ComputeSpread() {
+	mid := m.Mid()
+	if mid.IsZero() || m.BestAsk.LessThan(m.BestBid) {
+		m.SpreadBPS = decimal.Zero
+		return
+	}
+	m.SpreadBPS = m.BestAsk.Sub(m.BestBid).Div(mid).Mul(decimal.NewFromInt(10000))
+}
+
+// VolumeAnomaly is raised when exchange-reported volume diverges from on-chain flows
+type VolumeAnomaly struct {
+	ID              uuid.UUID       `json:"id"`
+	SourceID        string          `json:"source_id"`
+	ExchangeType    ExchangeType    `json:"exchange_type"`
+	ReportedVolume  decimal.Decimal `json:"reported_volume_btc"`
+	OnChainVolume   decimal.Decimal `json:"on_chain_volume_btc"`
+	DivergenceRatio decimal.Decimal `json:"divergence_ratio"`
+	Threshold       decimal.Decimal `json:"threshold"`
+	WindowStart     time.Time       `json:"window_start"`
+	WindowEnd       time.Time       `json:"window_end"`
+	DetectedAt      time.Time       `json:"detected_at"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// OrderAction is the lifecycle event reported for an order
+type OrderAction string
+
+const (
+	OrderActionPlaced    OrderAction = "PLACED"
+	OrderActionCancelled OrderAction = "CANCELLED"
+	OrderActionFilled    OrderAction = "FILLED"
+)
+
+// OrderEvent is a single order lifecycle event from an exchange's order feed.
+// Only regulatory feeds carry the account placing the order.
+type OrderEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	SourceID  string          `json:"source_id"`
+	Symbol    string          `json:"symbol"`
+	OrderID   string          `json:"order_id"`
+	Account   string          `json:"account,omitempty"`
+	Side      TradeSide       `json:"side"`
+	Action    OrderAction     `json:"action"`
+	Price     decimal.Decimal `json:"price"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// AlertTypeMarketAbuse is the alert type raised by order-book surveillance
+const AlertTypeMarketAbuse = "MARKET_ABUSE"
+
+// AbusePattern identifies the manipulation pattern behind a market abuse alert
+type AbusePattern string
+
+const (
+	// AbusePatternSelfTrade is a trade where one account is both buyer and seller
+	AbusePatternSelfTrade AbusePattern = "SELF_TRADE"
+
+	// AbusePatternCircularTrade is a chain of trades between accounts of one
+	// cluster that returns the traded quantity to where it started
+	AbusePatternCircularTrade AbusePattern = "CIRCULAR_TRADE"
+
+	// AbusePatternSpoofing is a large order cancelled shortly after placement
+	// while the same account traded on the opposite side
+	AbusePatternSpoofing AbusePattern = "SPOOFING"
+)
+
+// Valid reports whether p is a known abuse pattern
+func (p AbusePattern) Valid() bool {
+	switch p {
+	case AbusePatternSelfTrade, AbusePatternCircularTrade, AbusePatternSpoofing:
+		return true
+	}
+	return false
+}
+
+// MarketAbuseAlert is raised by order-book surveillance. The trades and order
+// events that make up the pattern are attached as evidence.
+type MarketAbuseAlert struct {
+	ID          uuid.UUID    `json:"id"`
+	AlertType   string       `json:"alert_type"`
+	Pattern     AbusePattern `json:"pattern"`
+	Severity    string       `json:"severity"`
+	SourceID    string       `json:"source_id"`
+	Symbol      string       `json:"symbol"`
+	Accounts    []string     `json:"accounts"`
+	ClusterID   string       `json:"cluster_id,omitempty"`
+	Description string       `json:"description"`
+	WindowStart time.Time    `json:"window_start"`
+	WindowEnd   time.Time    `json:"window_end"`
+	Trades      []Trade      `json:"trades"`
+	Orders      []OrderEvent `json:"orders,omitempty"`
+	DetectedAt  time.Time    `json:"detected_at"`
+}
+
+// MarketAbuseAlertFilter selects stored market abuse alerts
+type MarketAbuseAlertFilter struct {
+	SourceID string       `json:"source_id,omitempty"`
+	Symbol   string       `json:"symbol,omitempty"`
+	Pattern  AbusePattern `json:"pattern,omitempty"`
+	Limit    int          `json:"limit,omitempty"`
+}
+
+// AccountCluster links an exchange account to a cluster of accounts believed
+// to be under common control, e.g. from KYC or on-chain analysis
+type AccountCluster struct {
+	SourceID  string    `json:"source_id"`
+	AccountID string    `json:"account_id"`
+	ClusterID string    `json:"cluster_id"`
+	Reason    string    `json:"reason,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -2,8 +2,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/csic-platform/services/exchange-ingestion/internal/core/domain"
+	"github.com/shopspring/decimal"
 )
 
 // ExchangeConnector defines the interface for connecting to exchanges
@@ -37,6 +39,9 @@ type ExchangeConnector interface {
 	// SubscribeOrderBook subscribes to order book updates for specific symbols
 	SubscribeOrderBook(ctx context.Context, symbols []string, orderbookChan chan<- domain.OrderBook) error
 
+	// SubscribeOrders subscribes to order lifecycle events for specific symbols
+	SubscribeOrders(ctx context.Context, symbols []string, orderChan chan<- domain.OrderEvent) error
+
 	// GetSupportedSymbols returns the list of symbols supported by this connector
 	GetSupportedSymbols() []string
 
@@ -53,6 +58,37 @@ type ExchangeConnectorFactory interface {
 	GetSupportedExchangeTypes() []domain.ExchangeType
 }
 
+// MetricsAdapter collects order book and volume metrics from a single exchange.
+// Each supported exchange provides its own adapter to normalize its API responses.
+type MetricsAdapter interface {
+	// GetSourceID returns the data source this adapter collects for
+	GetSourceID() string
+
+	// GetExchangeType returns the exchange this adapter understands
+	GetExchangeType() domain.ExchangeType
+
+	// Start prepares the adapter, opening any streaming subscriptions
+	Start(ctx context.Context, symbols []string) error
+
+	// CollectMetrics fetches current metrics for the given symbols
+	CollectMetrics(ctx context.Context, symbols []string) ([]*domain.ExchangeMetrics, error)
+
+	// Close releases any streaming resources held by the adapter
+	Close() error
+}
+
+// MetricsAdapterFactory creates MetricsAdapter instances based on exchange type
+type MetricsAdapterFactory interface {
+	// CreateMetricsAdapter creates a metrics adapter for the given data source
+	CreateMetricsAdapter(config *domain.DataSourceConfig) (MetricsAdapter, error)
+}
+
+// OnChainFlowProvider reports the volume observed on-chain for an exchange's known addresses
+type OnChainFlowProvider interface {
+	// GetExchangeFlowBTC returns total inbound plus outbound BTC-equivalent flow in the window
+	GetExchangeFlowBTC(ctx context.Context, sourceID string, start, end time.Time) (decimal.Decimal, error)
+}
+
 // EventPublisher defines the interface for publishing events to message brokers
 // This enables communication with other services like Risk Engine and Reporting
 type EventPublisher interface {
@@ -68,6 +104,12 @@ type EventPublisher interface {
 	// PublishBatch publishes multiple market data records in a batch
 	PublishBatch(ctx context.Context, data []*domain.MarketData) error
 
+	// PublishVolumeAnomaly publishes a reported-vs-on-chain volume divergence alert
+	PublishVolumeAnomaly(ctx context.Context, anomaly *domain.VolumeAnomaly) error
+
+	// PublishMarketAbuseAlert publishes a wash trading or spoofing alert
+	PublishMarketAbuseAlert(ctx context.Context, alert *domain.MarketAbuseAlert) error
+
 	// Close closes the publisher connection
 	Close() error
 }
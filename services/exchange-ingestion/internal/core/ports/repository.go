@@ -87,3 +87,42 @@ type IngestionStatsRepository interface {
 	// RecordLatency records the processing latency
 	RecordLatency(ctx context.Context, sourceID string, latency time.Duration) error
 }
+
+// ExchangeMetricsRepository defines the interface for storing normalized exchange metrics
+type ExchangeMetricsRepository interface {
+	// StoreBatch saves multiple exchange metrics rows in a single operation
+	StoreBatch(ctx context.Context, metrics []*domain.ExchangeMetrics) error
+
+	// FindByTimeRange retrieves metrics for a source and symbol within a time range
+	FindByTimeRange(ctx context.Context, sourceID, symbol string, start, end time.Time) ([]*domain.ExchangeMetrics, error)
+
+	// FindLatest retrieves the most recent metrics row per symbol for a source
+	FindLatest(ctx context.Context, sourceID string) ([]*domain.ExchangeMetrics, error)
+}
+
+// MarketAbuseAlertRepository defines the interface for storing market abuse alerts
+type MarketAbuseAlertRepository interface {
+	// Store saves an alert together with its evidence
+	Store(ctx context.Context, alert *domain.MarketAbuseAlert) error
+
+	// FindByID retrieves an alert by its unique identifier
+	FindByID(ctx context.Context, id string) (*domain.MarketAbuseAlert, error)
+
+	// Find retrieves the most recent alerts matching the filter
+	Find(ctx context.Context, filter domain.MarketAbuseAlertFilter) ([]*domain.MarketAbuseAlert, error)
+}
+
+// AccountClusterRepository defines the interface for managing account clusters
+type AccountClusterRepository interface {
+	// FindBySource retrieves the cluster links of all accounts on a data source
+	FindBySource(ctx context.Context, sourceID string) ([]*domain.AccountCluster, error)
+
+	// FindAll retrieves the cluster links of every data source
+	FindAll(ctx context.Context) ([]*domain.AccountCluster, error)
+
+	// Save creates or replaces an account's cluster link
+	Save(ctx context.Context, cluster *domain.AccountCluster) error
+
+	// Delete removes an account's cluster link
+	Delete(ctx context.Context, sourceID, accountID string) error
+}
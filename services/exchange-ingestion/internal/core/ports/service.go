@@ -57,6 +57,30 @@ type IngestionService interface {
 	GetStats(ctx context.Context, sourceID string) (*domain.IngestionStats, error)
 }
 
+// MarketSurveillanceService defines the business logic for order-book surveillance
+type MarketSurveillanceService interface {
+	// ObserveTrade checks an ingested trade for self-trades and circular trades
+	ObserveTrade(ctx context.Context, trade *domain.Trade)
+
+	// ObserveOrder checks an ingested order event for spoofing
+	ObserveOrder(ctx context.Context, order *domain.OrderEvent)
+
+	// ListAlerts lists the most recent market abuse alerts matching the filter
+	ListAlerts(ctx context.Context, filter domain.MarketAbuseAlertFilter) ([]*domain.MarketAbuseAlert, error)
+
+	// GetAlert retrieves a market abuse alert with its evidence
+	GetAlert(ctx context.Context, id string) (*domain.MarketAbuseAlert, error)
+
+	// ListAccountClusters lists the account cluster links of a data source
+	ListAccountClusters(ctx context.Context, sourceID string) ([]*domain.AccountCluster, error)
+
+	// SetAccountCluster links an account to a cluster
+	SetAccountCluster(ctx context.Context, cluster *domain.AccountCluster) error
+
+	// RemoveAccountCluster unlinks an account from its cluster
+	RemoveAccountCluster(ctx context.Context, sourceID, accountID string) error
+}
+
 // Request and response types for DataSourceService
 
 type RegisterDataSourceRequest struct {
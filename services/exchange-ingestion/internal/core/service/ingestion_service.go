@@ -17,6 +17,7 @@ type IngestionServiceImpl struct {
 	statsRepo       ports.IngestionStatsRepository
 	publisher       ports.EventPublisher
 	factory         ports.ExchangeConnectorFactory
+	surveillance    ports.MarketSurveillanceService
 	logger          *zap.Logger
 
 	// Runtime state
@@ -33,6 +34,7 @@ func NewIngestionService(
 	statsRepo ports.IngestionStatsRepository,
 	publisher ports.EventPublisher,
 	factory ports.ExchangeConnectorFactory,
+	surveillance ports.MarketSurveillanceService,
 	logger *zap.Logger,
 ) *IngestionServiceImpl {
 	return &IngestionServiceImpl{
@@ -41,6 +43,7 @@ func NewIngestionService(
 		statsRepo:      statsRepo,
 		publisher:      publisher,
 		factory:        factory,
+		surveillance:   surveillance,
 		logger:         logger,
 		connectors:     make(map[string]ports.ExchangeConnector),
 	}
@@ -164,13 +167,17 @@ func (s *IngestionServiceImpl) startSource(ctx context.Context, config *domain.D
 	// Create channels for data streaming
 	dataChan := make(chan domain.MarketData, 1000)
 	tradeChan := make(chan domain.Trade, 1000)
+	orderChan := make(chan domain.OrderEvent, 1000)
 
 	// Start streaming data in a goroutine
-	go s.streamData(ctx, config, connector, dataChan, tradeChan)
+	go s.streamData(ctx, config, connector, dataChan, tradeChan, orderChan)
 
-	// Subscribe to trades if supported
+	// Subscribe to trades and order events if supported
 	if len(config.Symbols) > 0 {
 		connector.SubscribeTrades(ctx, config.Symbols, tradeChan)
+		if s.surveillance != nil {
+			connector.SubscribeOrders(ctx, config.Symbols, orderChan)
+		}
 	}
 
 	s.connectors[config.ID.String()] = connector
@@ -215,6 +222,7 @@ func (s *IngestionServiceImpl) streamData(
 	connector ports.ExchangeConnector,
 	dataChan <-chan domain.MarketData,
 	tradeChan <-chan domain.Trade,
+	orderChan <-chan domain.OrderEvent,
 ) {
 	for {
 		select {
@@ -236,6 +244,13 @@ func (s *IngestionServiceImpl) streamData(
 				return
 			}
 			s.processTrade(ctx, config, &trade)
+		case order, ok := <-orderChan:
+			if !ok {
+				s.logger.Warn("Order channel closed",
+					zap.String("source_id", config.ID.String()))
+				return
+			}
+			s.processOrder(ctx, config, &order)
 		}
 	}
 }
@@ -300,6 +315,26 @@ func (s *IngestionServiceImpl) processTrade(
 			zap.Error(err))
 	}
 
+	// Check for wash trading
+	if s.surveillance != nil {
+		s.surveillance.ObserveTrade(ctx, trade)
+	}
+
+	s.recordSuccess(ctx, config.ID.String(), start)
+}
+
+// processOrder passes an order lifecycle event to surveillance
+func (s *IngestionServiceImpl) processOrder(
+	ctx context.Context,
+	config *domain.DataSourceConfig,
+	order *domain.OrderEvent,
+) {
+	start := time.Now()
+
+	if s.surveillance != nil {
+		s.surveillance.ObserveOrder(ctx, order)
+	}
+
 	s.recordSuccess(ctx, config.ID.String(), start)
 }
 
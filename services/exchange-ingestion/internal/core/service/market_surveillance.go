@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/domain"
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/ports"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// SurveillanceConfig tunes order-book surveillance
+type SurveillanceConfig struct {
+	// Window is how much trade history is kept per symbol; alerts attach the
+	// part of it that makes up the pattern
+	Window time.Duration
+
+	// SpoofMaxLifetime is the longest an order may rest before it is
+	// cancelled and still be considered a spoof
+	SpoofMaxLifetime time.Duration
+
+	// SpoofSizeMultiple is how many times the window's average trade quantity
+	// a cancelled order must be to be considered a spoof
+	SpoofSizeMultiple float64
+
+	// AlertCooldown limits repeated alerts for the same pattern and accounts
+	AlertCooldown time.Duration
+
+	// ClusterRefresh is how often account clusters are reloaded
+	ClusterRefresh time.Duration
+}
+
+// MarketSurveillance watches attributed exchange trade and order feeds for
+// wash trading and spoofing. Self-trades and spoofs are detected per account;
+// circular trades only between accounts linked to the same cluster.
+type MarketSurveillance struct {
+	alertRepo   ports.MarketAbuseAlertRepository
+	clusterRepo ports.AccountClusterRepository
+	publisher   ports.EventPublisher
+	config      SurveillanceConfig
+	logger      *zap.Logger
+
+	// Runtime state
+	mu        sync.Mutex
+	books     map[string]*surveillanceBook
+	clusters  map[string]string
+	lastAlert map[string]time.Time
+}
+
+// surveillanceBook is the recent activity of one symbol on one data source
+type surveillanceBook struct {
+	trades []domain.Trade
+	orders map[string]*restingOrder
+}
+
+// restingOrder is an open order young enough to still become a spoof
+type restingOrder struct {
+	placed domain.OrderEvent
+	filled bool
+}
+
+// NewMarketSurveillance creates a new MarketSurveillance
+func NewMarketSurveillance(
+	alertRepo ports.MarketAbuseAlertRepository,
+	clusterRepo ports.AccountClusterRepository,
+	publisher ports.EventPublisher,
+	config SurveillanceConfig,
+	logger *zap.Logger,
+) *MarketSurveillance {
+	return &MarketSurveillance{
+		alertRepo:   alertRepo,
+		clusterRepo: clusterRepo,
+		publisher:   publisher,
+		config:      config,
+		logger:      logger,
+		books:       make(map[string]*surveillanceBook),
+		clusters:    make(map[string]string),
+		lastAlert:   make(map[string]time.Time),
+	}
+}
+
+// Start reloads account clusters every ClusterRefresh until ctx is cancelled
+func (s *MarketSurveillance) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.ClusterRefresh)
+	defer ticker.Stop()
+
+	for {
+		if err := s.loadClusters(ctx); err != nil {
+			s.logger.Error("Failed to load account clusters", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadClusters replaces the in-memory account clusters from the repository
+func (s *MarketSurveillance) loadClusters(ctx context.Context) error {
+	links, err := s.clusterRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	clusters := make(map[string]string, len(links))
+	for _, link := range links {
+		clusters[accountKey(link.SourceID, link.AccountID)] = link.ClusterID
+	}
+
+	s.mu.Lock()
+	s.clusters = clusters
+	s.mu.Unlock()
+	return nil
+}
+
+// ObserveTrade adds a trade to its symbol's window and checks it for a
+// self-trade or for closing a circular trade. Unattributed trades only count
+// towards the window's average trade size.
+func (s *MarketSurveillance) ObserveTrade(ctx context.Context, trade *domain.Trade) {
+	s.mu.Lock()
+	book := s.book(trade.SourceID, trade.Symbol)
+	book.prune(trade.Timestamp, s.config.Window, s.config.SpoofMaxLifetime)
+	book.trades = append(book.trades, *trade)
+
+	var alert *domain.MarketAbuseAlert
+	switch {
+	case trade.BuyerAccount == "" || trade.SellerAccount == "":
+	case trade.BuyerAccount == trade.SellerAccount:
+		alert = s.selfTrade(book, trade)
+	default:
+		alert = s.circularTrade(book, trade)
+	}
+	s.mu.Unlock()
+
+	if alert != nil {
+		s.raise(ctx, alert)
+	}
+}
+
+// ObserveOrder tracks an order's lifecycle and checks a cancellation for spoofing
+func (s *MarketSurveillance) ObserveOrder(ctx context.Context, order *domain.OrderEvent) {
+	if order.OrderID == "" || order.Account == "" {
+		return
+	}
+
+	s.mu.Lock()
+	book := s.book(order.SourceID, order.Symbol)
+	book.prune(order.Timestamp, s.config.Window, s.config.SpoofMaxLifetime)
+
+	var alert *domain.MarketAbuseAlert
+	switch order.Action {
+	case domain.OrderActionPlaced:
+		book.orders[order.OrderID] = &restingOrder{placed: *order}
+	case domain.OrderActionFilled:
+		if resting, ok := book.orders[order.OrderID]; ok {
+			resting.filled = true
+		}
+	case domain.OrderActionCancelled:
+		if resting, ok := book.orders[order.OrderID]; ok {
+			delete(book.orders, order.OrderID)
+			if !resting.filled {
+				alert = s.spoofing(book, &resting.placed, order)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if alert != nil {
+		s.raise(ctx, alert)
+	}
+}
+
+// selfTrade raises an alert for an account trading with itself. All of the
+// account's self-trades in the window are attached.
+func (s *MarketSurveillance) selfTrade(book *surveillanceBook, trade *domain.Trade) *domain.MarketAbuseAlert {
+	account := trade.BuyerAccount
+	if !s.cooldownElapsed(domain.AbusePatternSelfTrade, trade.SourceID, trade.Symbol, account) {
+		return nil
+	}
+
+	var evidence []domain.Trade
+	volume := decimal.Zero
+	for _, t := range book.trades {
+		if t.BuyerAccount == account && t.SellerAccount == account {
+			evidence = append(evidence, t)
+			volume = volume.Add(t.Quantity)
+		}
+	}
+
+	return newMarketAbuseAlert(domain.AbusePatternSelfTrade, "HIGH", trade.SourceID, trade.Symbol,
+		[]string{account}, "",
+		fmt.Sprintf("Account %s traded %s %s with itself in %d trades", account, volume.String(), trade.Symbol, len(evidence)),
+		evidence, nil)
+}
+
+// circularTrade raises an alert when a trade between two accounts of the same
+// cluster closes a cycle of intra-cluster trades within the window: the
+// quantity sold by the seller has travelled back to it through the cluster.
+func (s *MarketSurveillance) circularTrade(book *surveillanceBook, trade *domain.Trade) *domain.MarketAbuseAlert {
+	cluster := s.clusters[accountKey(trade.SourceID, trade.SellerAccount)]
+	if cluster == "" || s.clusters[accountKey(trade.SourceID, trade.BuyerAccount)] != cluster {
+		return nil
+	}
+
+	// Breadth-first search from the buyer back to the seller, following the
+	// direction the asset moved in earlier intra-cluster trades
+	via := map[string]int{trade.BuyerAccount: -1}
+	queue := []string{trade.BuyerAccount}
+	for len(queue) > 0 && via[trade.SellerAccount] == 0 {
+		from := queue[0]
+		queue = queue[1:]
+		for i := 0; i < len(book.trades)-1; i++ {
+			t := &book.trades[i]
+			if t.SellerAccount != from || t.BuyerAccount == t.SellerAccount {
+				continue
+			}
+			if _, seen := via[t.BuyerAccount]; seen {
+				continue
+			}
+			if s.clusters[accountKey(t.SourceID, t.BuyerAccount)] != cluster {
+				continue
+			}
+			via[t.BuyerAccount] = i + 1
+			queue = append(queue, t.BuyerAccount)
+		}
+	}
+	if via[trade.SellerAccount] <= 0 {
+		return nil
+	}
+
+	evidence := []domain.Trade{*trade}
+	accounts := []string{trade.SellerAccount}
+	for account := trade.SellerAccount; account != trade.BuyerAccount; {
+		leg := book.trades[via[account]-1]
+		evidence = append([]domain.Trade{leg}, evidence...)
+		account = leg.SellerAccount
+		accounts = append([]string{account}, accounts...)
+	}
+
+	if !s.cooldownElapsed(domain.AbusePatternCircularTrade, trade.SourceID, trade.Symbol, cluster) {
+		return nil
+	}
+
+	return newMarketAbuseAlert(domain.AbusePatternCircularTrade, "HIGH", trade.SourceID, trade.Symbol,
+		accounts, cluster,
+		fmt.Sprintf("%d trades of %s circulated between accounts of cluster %s: %s",
+			len(evidence), trade.Symbol, cluster, strings.Join(append(accounts, trade.BuyerAccount), " -> ")),
+		evidence, nil)
+}
+
+// spoofing raises an alert when a large order is cancelled unfilled shortly
+// after placement while its account traded on the opposite side
+func (s *MarketSurveillance) spoofing(book *surveillanceBook, placed, cancelled *domain.OrderEvent) *domain.MarketAbuseAlert {
+	if cancelled.Timestamp.Sub(placed.Timestamp) > s.config.SpoofMaxLifetime {
+		return nil
+	}
+
+	average := book.averageQuantity()
+	if average.IsZero() || placed.Quantity.LessThan(average.Mul(decimal.NewFromFloat(s.config.SpoofSizeMultiple))) {
+		return nil
+	}
+
+	var evidence []domain.Trade
+	for _, t := range book.trades {
+		if t.Timestamp.Before(placed.Timestamp) || t.Timestamp.After(cancelled.Timestamp) {
+			continue
+		}
+		if (placed.Side == domain.TradeSideSell && t.BuyerAccount == placed.Account) ||
+			(placed.Side == domain.TradeSideBuy && t.SellerAccount == placed.Account) {
+			evidence = append(evidence, t)
+		}
+	}
+	if len(evidence) == 0 {
+		return nil
+	}
+
+	if !s.cooldownElapsed(domain.AbusePatternSpoofing, placed.SourceID, placed.Symbol, placed.Account) {
+		return nil
+	}
+
+	return newMarketAbuseAlert(domain.AbusePatternSpoofing, "MEDIUM", placed.SourceID, placed.Symbol,
+		[]string{placed.Account}, s.clusters[accountKey(placed.SourceID, placed.Account)],
+		fmt.Sprintf("Account %s cancelled a %s order for %s %s after %s while trading %d times on the opposite side",
+			placed.Account, placed.Side, placed.Quantity.String(), placed.Symbol,
+			cancelled.Timestamp.Sub(placed.Timestamp).Round(time.Millisecond), len(evidence)),
+		evidence, []domain.OrderEvent{*placed, *cancelled})
+}
+
+// raise stores and publishes an alert; failures are logged so that
+// ingestion is never held up
+func (s *MarketSurveillance) raise(ctx context.Context, alert *domain.MarketAbuseAlert) {
+	s.logger.Warn("Market abuse detected",
+		zap.String("pattern", string(alert.Pattern)),
+		zap.String("source_id", alert.SourceID),
+		zap.String("symbol", alert.Symbol),
+		zap.Strings("accounts", alert.Accounts),
+		zap.Int("trades", len(alert.Trades)))
+
+	if err := s.alertRepo.Store(ctx, alert); err != nil {
+		s.logger.Error("Failed to store market abuse alert",
+			zap.String("alert_id", alert.ID.String()),
+			zap.Error(err))
+	}
+	if err := s.publisher.PublishMarketAbuseAlert(ctx, alert); err != nil {
+		s.logger.Error("Failed to publish market abuse alert",
+			zap.String("alert_id", alert.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// cooldownElapsed reports whether an alert may be raised for the pattern and
+// subject, and if so starts a new cooldown
+func (s *MarketSurveillance) cooldownElapsed(pattern domain.AbusePattern, sourceID, symbol, subject string) bool {
+	key := strings.Join([]string{string(pattern), sourceID, symbol, subject}, "|")
+	now := time.Now()
+	if last, ok := s.lastAlert[key]; ok && now.Sub(last) < s.config.AlertCooldown {
+		return false
+	}
+	s.lastAlert[key] = now
+	return true
+}
+
+// book returns the activity of a symbol on a data source, creating it if needed
+func (s *MarketSurveillance) book(sourceID, symbol string) *surveillanceBook {
+	key := sourceID + "|" + symbol
+	book, ok := s.books[key]
+	if !ok {
+		book = &surveillanceBook{orders: make(map[string]*restingOrder)}
+		s.books[key] = book
+	}
+	return book
+}
+
+// ListAlerts lists the most recent market abuse alerts matching the filter
+func (s *MarketSurveillance) ListAlerts(ctx context.Context, filter domain.MarketAbuseAlertFilter) ([]*domain.MarketAbuseAlert, error) {
+	if filter.Limit <= 0 || filter.Limit > 500 {
+		filter.Limit = 100
+	}
+	return s.alertRepo.Find(ctx, filter)
+}
+
+// GetAlert retrieves a market abuse alert with its evidence
+func (s *MarketSurveillance) GetAlert(ctx context.Context, id string) (*domain.MarketAbuseAlert, error) {
+	return s.alertRepo.FindByID(ctx, id)
+}
+
+// ListAccountClusters lists the account cluster links of a data source
+func (s *MarketSurveillance) ListAccountClusters(ctx context.Context, sourceID string) ([]*domain.AccountCluster, error) {
+	return s.clusterRepo.FindBySource(ctx, sourceID)
+}
+
+// SetAccountCluster links an account to a cluster. The link applies to
+// trades observed from now on.
+func (s *MarketSurveillance) SetAccountCluster(ctx context.Context, cluster *domain.AccountCluster) error {
+	if cluster.SourceID == "" || cluster.AccountID == "" || cluster.ClusterID == "" {
+		return fmt.Errorf("%w: source_id, account_id and cluster_id are required", domain.ErrInvalidAccountCluster)
+	}
+
+	cluster.UpdatedAt = time.Now()
+	if err := s.clusterRepo.Save(ctx, cluster); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.clusters[accountKey(cluster.SourceID, cluster.AccountID)] = cluster.ClusterID
+	s.mu.Unlock()
+	return nil
+}
+
+// RemoveAccountCluster unlinks an account from its cluster
+func (s *MarketSurveillance) RemoveAccountCluster(ctx context.Context, sourceID, accountID string) error {
+	if err := s.clusterRepo.Delete(ctx, sourceID, accountID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.clusters, accountKey(sourceID, accountID))
+	s.mu.Unlock()
+	return nil
+}
+
+// prune drops trades older than the window and resting orders too old to be
+// spoofs, relative to the time of the latest event
+func (b *surveillanceBook) prune(now time.Time, window, spoofLifetime time.Duration) {
+	cutoff := now.Add(-window)
+	keep := 0
+	for keep < len(b.trades) && b.trades[keep].Timestamp.Before(cutoff) {
+		keep++
+	}
+	if keep > 0 {
+		b.trades = append(b.trades[:0], b.trades[keep:]...)
+	}
+
+	orderCutoff := now.Add(-spoofLifetime)
+	for id, resting := range b.orders {
+		if resting.placed.Timestamp.Before(orderCutoff) {
+			delete(b.orders, id)
+		}
+	}
+}
+
+// averageQuantity returns the average trade quantity in the window
+func (b *surveillanceBook) averageQuantity() decimal.Decimal {
+	if len(b.trades) == 0 {
+		return decimal.Zero
+	}
+	total := decimal.Zero
+	for _, t := range b.trades {
+		total = total.Add(t.Quantity)
+	}
+	return total.Div(decimal.NewFromInt(int64(len(b.trades))))
+}
+
+// newMarketAbuseAlert builds an alert whose window spans its evidence
+func newMarketAbuseAlert(
+	pattern domain.AbusePattern,
+	severity, sourceID, symbol string,
+	accounts []string,
+	clusterID, description string,
+	trades []domain.Trade,
+	orders []domain.OrderEvent,
+) *domain.MarketAbuseAlert {
+	alert := &domain.MarketAbuseAlert{
+		ID:          uuid.New(),
+		AlertType:   domain.AlertTypeMarketAbuse,
+		Pattern:     pattern,
+		Severity:    severity,
+		SourceID:    sourceID,
+		Symbol:      symbol,
+		Accounts:    accounts,
+		ClusterID:   clusterID,
+		Description: description,
+		Trades:      trades,
+		Orders:      orders,
+		DetectedAt:  time.Now(),
+	}
+
+	for _, t := range trades {
+		widenWindow(alert, t.Timestamp)
+	}
+	for _, o := range orders {
+		widenWindow(alert, o.Timestamp)
+	}
+	return alert
+}
+
+// widenWindow extends an alert's window to include ts
+func widenWindow(alert *domain.MarketAbuseAlert, ts time.Time) {
+	if alert.WindowStart.IsZero() || ts.Before(alert.WindowStart) {
+		alert.WindowStart = ts
+	}
+	if ts.After(alert.WindowEnd) {
+		alert.WindowEnd = ts
+	}
+}
+
+// accountKey identifies an account on a data source
+func accountKey(sourceID, accountID string) string {
+	return sourceID + "|" + accountID
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/domain"
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/ports"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	// volumeComparisonWindow matches the rolling 24h volume reported by exchanges
+	volumeComparisonWindow = 24 * time.Hour
+
+	// anomalyCooldown limits how often a divergence alert is raised per source
+	anomalyCooldown = time.Hour
+)
+
+// minOnChainFlowBTC floors the on-chain denominator so that exchanges with
+// no observed flows still produce a finite divergence ratio
+var minOnChainFlowBTC = decimal.NewFromInt(1)
+
+// MetricsCollector periodically collects exchange metrics, stores them as
+// exchange_metrics rows and raises alerts when reported volume diverges from on-chain flows
+type MetricsCollector struct {
+	dataSourceRepo ports.DataSourceRepository
+	metricsRepo    ports.ExchangeMetricsRepository
+	factory        ports.MetricsAdapterFactory
+	flows          ports.OnChainFlowProvider
+	publisher      ports.EventPublisher
+	logger         *zap.Logger
+
+	interval            time.Duration
+	divergenceThreshold decimal.Decimal
+
+	// Runtime state
+	adapters  map[string]ports.MetricsAdapter
+	lastAlert map[string]time.Time
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewMetricsCollector creates a new MetricsCollector
+func NewMetricsCollector(
+	dataSourceRepo ports.DataSourceRepository,
+	metricsRepo ports.ExchangeMetricsRepository,
+	factory ports.MetricsAdapterFactory,
+	flows ports.OnChainFlowProvider,
+	publisher ports.EventPublisher,
+	interval time.Duration,
+	divergenceThreshold float64,
+	logger *zap.Logger,
+) *MetricsCollector {
+	return &MetricsCollector{
+		dataSourceRepo:      dataSourceRepo,
+		metricsRepo:         metricsRepo,
+		factory:             factory,
+		flows:               flows,
+		publisher:           publisher,
+		logger:              logger,
+		interval:            interval,
+		divergenceThreshold: decimal.NewFromFloat(divergenceThreshold),
+		adapters:            make(map[string]ports.MetricsAdapter),
+		lastAlert:           make(map[string]time.Time),
+	}
+}
+
+// Start begins scheduled collection for all enabled data sources
+func (c *MetricsCollector) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		return domain.ErrIngestionAlreadyRunning
+	}
+
+	sources, err := c.dataSourceRepo.FindEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	for _, config := range sources {
+		adapter, err := c.factory.CreateMetricsAdapter(config)
+		if err != nil {
+			c.logger.Warn("No metrics adapter for data source",
+				zap.String("source_id", config.ID.String()),
+				zap.String("exchange_type", string(config.ExchangeType)),
+				zap.Error(err))
+			continue
+		}
+
+		if err := adapter.Start(runCtx, config.Symbols); err != nil {
+			c.logger.Error("Failed to start metrics adapter",
+				zap.String("source_id", config.ID.String()),
+				zap.Error(err))
+			continue
+		}
+
+		c.adapters[config.ID.String()] = adapter
+		c.wg.Add(1)
+		go c.run(runCtx, config, adapter)
+	}
+
+	c.logger.Info("Exchange metrics collection started",
+		zap.Int("sources", len(c.adapters)),
+		zap.Duration("interval", c.interval))
+
+	return nil
+}
+
+// Stop halts collection and closes all adapters
+func (c *MetricsCollector) Stop() error {
+	c.mu.Lock()
+	if c.cancel == nil {
+		c.mu.Unlock()
+		return domain.ErrIngestionNotRunning
+	}
+	c.cancel()
+	c.cancel = nil
+	adapters := c.adapters
+	c.adapters = make(map[string]ports.MetricsAdapter)
+	c.mu.Unlock()
+
+	c.wg.Wait()
+
+	for id, adapter := range adapters {
+		if err := adapter.Close(); err != nil {
+			c.logger.Error("Error closing metrics adapter",
+				zap.String("source_id", id),
+				zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// run collects metrics for a single source on every tick
+func (c *MetricsCollector) run(ctx context.Context, config *domain.DataSourceConfig, adapter ports.MetricsAdapter) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.CollectOnce(ctx, config, adapter); err != nil {
+			c.logger.Error("Metrics collection failed",
+				zap.String("source_id", config.ID.String()),
+				zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CollectOnce collects, normalizes and stores metrics for a source, then checks for volume divergence
+func (c *MetricsCollector) CollectOnce(ctx context.Context, config *domain.DataSourceConfig, adapter ports.MetricsAdapter) error {
+	metrics, err := adapter.CollectMetrics(ctx, config.Symbols)
+	if err != nil {
+		return err
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	NormalizeVolumeBTC(metrics)
+
+	if err := c.metricsRepo.StoreBatch(ctx, metrics); err != nil {
+		return err
+	}
+
+	return c.checkDivergence(ctx, config, metrics)
+}
+
+// checkDivergence compares total reported BTC volume with on-chain exchange flows
+func (c *MetricsCollector) checkDivergence(ctx context.Context, config *domain.DataSourceConfig, metrics []*domain.ExchangeMetrics) error {
+	if c.flows == nil {
+		return nil
+	}
+
+	reported := decimal.Zero
+	for _, m := range metrics {
+		reported = reported.Add(m.VolumeBTC)
+	}
+	if reported.IsZero() {
+		return nil
+	}
+
+	sourceID := config.ID.String()
+	end := time.Now()
+	start := end.Add(-volumeComparisonWindow)
+
+	onChain, err := c.flows.GetExchangeFlowBTC(ctx, sourceID, start, end)
+	if err != nil {
+		return err
+	}
+
+	ratio := reported.Div(decimal.Max(onChain, minOnChainFlowBTC))
+	if ratio.LessThanOrEqual(c.divergenceThreshold) {
+		return nil
+	}
+
+	c.mu.Lock()
+	if last, ok := c.lastAlert[sourceID]; ok && end.Sub(last) < anomalyCooldown {
+		c.mu.Unlock()
+		return nil
+	}
+	c.lastAlert[sourceID] = end
+	c.mu.Unlock()
+
+	anomaly := &domain.VolumeAnomaly{
+		ID:              uuid.New(),
+		SourceID:        sourceID,
+		ExchangeType:    config.ExchangeType,
+		ReportedVolume:  reported,
+		OnChainVolume:   onChain,
+		DivergenceRatio: ratio,
+		Threshold:       c.divergenceThreshold,
+		WindowStart:     start,
+		WindowEnd:       end,
+		DetectedAt:      end,
+	}
+
+	c.logger.Warn("Reported volume diverges from on-chain flows",
+		zap.String("source_id", sourceID),
+		zap.String("reported_btc", reported.String()),
+		zap.String("on_chain_btc", onChain.String()),
+		zap.String("ratio", ratio.StringFixed(2)))
+
+	return c.publisher.PublishVolumeAnomaly(ctx, anomaly)
+}
+
+// NormalizeVolumeBTC fills VolumeBTC for each row. Pairs quoted or based in BTC are
+// converted directly; other pairs use the BTC price in the same quote currency
+// from the batch when one is available.
+func NormalizeVolumeBTC(metrics []*domain.ExchangeMetrics) {
+	btcPrices := make(map[string]decimal.Decimal)
+	for _, m := range metrics {
+		if m.BaseSymbol == "BTC" && m.LastPrice.IsPositive() {
+			btcPrices[m.QuoteSymbol] = m.LastPrice
+		}
+	}
+
+	for _, m := range metrics {
+		switch {
+		case m.BaseSymbol == "BTC":
+			m.VolumeBTC = m.Volume24h
+		case m.QuoteSymbol == "BTC":
+			m.VolumeBTC = m.QuoteVolume
+		default:
+			if price, ok := btcPrices[m.QuoteSymbol]; ok {
+				m.VolumeBTC = m.QuoteVolume.Div(price)
+			}
+		}
+	}
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/csic-platform/services/exchange-ingestion/internal/core/domain"
 	"github.com/csic-platform/services/exchange-ingestion/internal/core/ports"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
@@ -13,6 +16,7 @@ import (
 type HTTPHandler struct {
 	dataSourceService ports.DataSourceService
 	ingestionService  ports.IngestionService
+	surveillance      ports.MarketSurveillanceService
 	logger            *zap.Logger
 }
 
@@ -20,11 +24,13 @@ type HTTPHandler struct {
 func NewHTTPHandler(
 	dataSourceService ports.DataSourceService,
 	ingestionService ports.IngestionService,
+	surveillance ports.MarketSurveillanceService,
 	logger *zap.Logger,
 ) *HTTPHandler {
 	return &HTTPHandler{
 		dataSourceService: dataSourceService,
 		ingestionService:  ingestionService,
+		surveillance:      surveillance,
 		logger:            logger,
 	}
 }
@@ -51,6 +57,13 @@ func (h *HTTPHandler) RegisterRoutes(r *chi.Mux) {
 	r.Post("/api/v1/ingestion/source/{id}/sync", h.ForceSync)
 	r.Get("/api/v1/ingestion/source/{id}/stats", h.GetSourceStats)
 
+	// Market surveillance routes
+	r.Get("/api/v1/surveillance/alerts", h.ListMarketAbuseAlerts)
+	r.Get("/api/v1/surveillance/alerts/{id}", h.GetMarketAbuseAlert)
+	r.Get("/api/v1/surveillance/clusters/{source_id}", h.ListAccountClusters)
+	r.Put("/api/v1/surveillance/clusters/{source_id}/{account_id}", h.SetAccountCluster)
+	r.Delete("/api/v1/surveillance/clusters/{source_id}/{account_id}", h.RemoveAccountCluster)
+
 	// Health check
 	r.Get("/health", h.HealthCheck)
 }
@@ -258,6 +271,106 @@ func (h *HTTPHandler) GetSourceStats(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, stats)
 }
 
+// ListMarketAbuseAlerts lists the most recent market abuse alerts
+func (h *HTTPHandler) ListMarketAbuseAlerts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.MarketAbuseAlertFilter{
+		SourceID: query.Get("source_id"),
+		Symbol:   query.Get("symbol"),
+		Pattern:  domain.AbusePattern(query.Get("pattern")),
+	}
+	if filter.Pattern != "" && !filter.Pattern.Valid() {
+		h.writeError(w, http.StatusBadRequest, "Invalid pattern", errors.New("unknown pattern "+string(filter.Pattern)))
+		return
+	}
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+	alerts, err := h.surveillance.ListAlerts(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to list market abuse alerts", err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"alerts": alerts,
+		"count":  len(alerts),
+	})
+}
+
+// GetMarketAbuseAlert returns a market abuse alert with its evidence
+func (h *HTTPHandler) GetMarketAbuseAlert(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	alert, err := h.surveillance.GetAlert(r.Context(), id)
+	if errors.Is(err, domain.ErrMarketAbuseAlertNotFound) {
+		h.writeError(w, http.StatusNotFound, "Market abuse alert not found", err)
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get market abuse alert", err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, alert)
+}
+
+// ListAccountClusters lists the account cluster links of a data source
+func (h *HTTPHandler) ListAccountClusters(w http.ResponseWriter, r *http.Request) {
+	sourceID := chi.URLParam(r, "source_id")
+
+	clusters, err := h.surveillance.ListAccountClusters(r.Context(), sourceID)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to list account clusters", err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"clusters": clusters,
+		"count":    len(clusters),
+	})
+}
+
+// SetAccountCluster links an account to a cluster
+func (h *HTTPHandler) SetAccountCluster(w http.ResponseWriter, r *http.Request) {
+	var cluster domain.AccountCluster
+	if err := json.NewDecoder(r.Body).Decode(&cluster); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	cluster.SourceID = chi.URLParam(r, "source_id")
+	cluster.AccountID = chi.URLParam(r, "account_id")
+
+	err := h.surveillance.SetAccountCluster(r.Context(), &cluster)
+	if errors.Is(err, domain.ErrInvalidAccountCluster) {
+		h.writeError(w, http.StatusBadRequest, "Invalid account cluster", err)
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to set account cluster", err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, cluster)
+}
+
+// RemoveAccountCluster unlinks an account from its cluster
+func (h *HTTPHandler) RemoveAccountCluster(w http.ResponseWriter, r *http.Request) {
+	sourceID := chi.URLParam(r, "source_id")
+	accountID := chi.URLParam(r, "account_id")
+
+	err := h.surveillance.RemoveAccountCluster(r.Context(), sourceID, accountID)
+	if errors.Is(err, domain.ErrRepositoryNotFound) {
+		h.writeError(w, http.StatusNotFound, "Account cluster not found", err)
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to remove account cluster", err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{"message": "Account cluster removed"})
+}
+
 // HealthCheck returns the health status of the service
 func (h *HTTPHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, map[string]string{
@@ -0,0 +1,33 @@
+-- Exchange Ingestion Service Database Schema
+-- Exchange metrics collected by per-exchange metrics adapters
+
+-- Exchange Metrics table
+CREATE TABLE IF NOT EXISTS exchange_metrics (
+    id UUID PRIMARY KEY,
+    source_id VARCHAR(50) NOT NULL,
+    exchange_type VARCHAR(50) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    base_symbol VARCHAR(10) NOT NULL,
+    quote_symbol VARCHAR(10) NOT NULL,
+    last_price DECIMAL(24, 8) NOT NULL,
+    best_bid DECIMAL(24, 8) NOT NULL,
+    best_ask DECIMAL(24, 8) NOT NULL,
+    spread_bps DECIMAL(12, 4) NOT NULL,
+    bid_depth DECIMAL(24, 8) NOT NULL,
+    ask_depth DECIMAL(24, 8) NOT NULL,
+    volume_24h DECIMAL(32, 8) NOT NULL,
+    quote_volume_24h DECIMAL(32, 8) NOT NULL,
+    volume_btc DECIMAL(32, 8) NOT NULL,
+    transport VARCHAR(20) NOT NULL,
+    collected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Indexes for exchange metrics queries
+CREATE INDEX IF NOT EXISTS idx_exchange_metrics_source_symbol_time
+    ON exchange_metrics(source_id, symbol, collected_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_metrics_collected_at
+    ON exchange_metrics(collected_at DESC);
+
+COMMENT ON TABLE exchange_metrics IS 'Normalized order book and volume metrics per exchange and symbol';
@@ -0,0 +1,66 @@
+-- Exchange Ingestion Service Database Schema
+-- Daily range partitioning of exchange metrics on collected_at
+
+-- Partitions after today are created by the service's partition maintainer,
+-- which also detaches days past METRICS_RETENTION_DAYS. The primary key must
+-- include the partition key, so it becomes (id, collected_at).
+
+ALTER TABLE exchange_metrics RENAME TO exchange_metrics_unpartitioned;
+
+CREATE TABLE exchange_metrics (
+    id UUID NOT NULL,
+    source_id VARCHAR(50) NOT NULL,
+    exchange_type VARCHAR(50) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    base_symbol VARCHAR(10) NOT NULL,
+    quote_symbol VARCHAR(10) NOT NULL,
+    last_price DECIMAL(24, 8) NOT NULL,
+    best_bid DECIMAL(24, 8) NOT NULL,
+    best_ask DECIMAL(24, 8) NOT NULL,
+    spread_bps DECIMAL(12, 4) NOT NULL,
+    bid_depth DECIMAL(24, 8) NOT NULL,
+    ask_depth DECIMAL(24, 8) NOT NULL,
+    volume_24h DECIMAL(32, 8) NOT NULL,
+    quote_volume_24h DECIMAL(32, 8) NOT NULL,
+    volume_btc DECIMAL(32, 8) NOT NULL,
+    transport VARCHAR(20) NOT NULL,
+    collected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, collected_at)
+) PARTITION BY RANGE (collected_at);
+
+-- Catches rows outside every daily partition; it should stay empty
+CREATE TABLE exchange_metrics_default PARTITION OF exchange_metrics DEFAULT;
+
+-- Daily partitions for the existing rows and today
+DO $$
+DECLARE
+    day_start TIMESTAMP WITH TIME ZONE;
+    last_day TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT date_trunc('day', COALESCE(MIN(collected_at), NOW()) AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+      INTO day_start
+      FROM exchange_metrics_unpartitioned;
+    last_day := date_trunc('day', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';
+
+    WHILE day_start <= last_day LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF exchange_metrics FOR VALUES FROM (%L) TO (%L)',
+            'exchange_metrics_p' || to_char(day_start AT TIME ZONE 'UTC', 'YYYYMMDD'),
+            day_start,
+            day_start + INTERVAL '1 day'
+        );
+        day_start := day_start + INTERVAL '1 day';
+    END LOOP;
+END $$;
+
+INSERT INTO exchange_metrics SELECT * FROM exchange_metrics_unpartitioned;
+DROP TABLE exchange_metrics_unpartitioned;
+
+CREATE INDEX IF NOT EXISTS idx_exchange_metrics_source_symbol_time
+    ON exchange_metrics(source_id, symbol, collected_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_metrics_collected_at
+    ON exchange_metrics(collected_at DESC);
+
+COMMENT ON TABLE exchange_metrics IS 'Normalized order book and volume metrics per exchange and symbol, partitioned by day';
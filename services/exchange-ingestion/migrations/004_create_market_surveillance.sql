@@ -0,0 +1,44 @@
+-- Exchange Ingestion Service Database Schema
+-- Order-book surveillance: account clusters and market abuse alerts
+
+-- Exchange accounts believed to be under common control. Circular trades are
+-- only detected between accounts of the same cluster.
+CREATE TABLE IF NOT EXISTS exchange_account_clusters (
+    source_id VARCHAR(50) NOT NULL,
+    account_id VARCHAR(100) NOT NULL,
+    cluster_id VARCHAR(100) NOT NULL,
+    reason TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_account_clusters_cluster
+    ON exchange_account_clusters(source_id, cluster_id);
+
+-- Market abuse alerts with the trades and order events that make up the
+-- pattern attached as evidence
+CREATE TABLE IF NOT EXISTS market_abuse_alerts (
+    id UUID PRIMARY KEY,
+    alert_type VARCHAR(30) NOT NULL,
+    pattern VARCHAR(30) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    source_id VARCHAR(50) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    accounts TEXT[] NOT NULL,
+    cluster_id VARCHAR(100),
+    description TEXT NOT NULL,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    trades JSONB NOT NULL,
+    orders JSONB NOT NULL DEFAULT 'null',
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_abuse_alerts_source_symbol_time
+    ON market_abuse_alerts(source_id, symbol, detected_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_market_abuse_alerts_pattern_time
+    ON market_abuse_alerts(pattern, detected_at DESC);
+
+COMMENT ON TABLE market_abuse_alerts IS 'Wash trading and spoofing alerts raised by order-book surveillance';
@@ -8,11 +8,15 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/csic-platform/internal/adapters/audit"
 	"github.com/csic-platform/internal/adapters/handler/http"
+	"github.com/csic-platform/internal/adapters/pki"
 	"github.com/csic-platform/internal/adapters/repository/postgres"
+	"github.com/csic-platform/internal/core/domain"
 	"github.com/csic-platform/internal/core/ports"
 	"github.com/csic-platform/internal/core/services"
 	"github.com/go-chi/chi/v5"
@@ -29,6 +33,7 @@ type Config struct {
 	Elasticsearch ElasticsearchConfig `yaml:"elasticsearch"`
 	Graph         GraphConfig         `yaml:"graph"`
 	Evidence      EvidenceConfig      `yaml:"evidence"`
+	Retention     RetentionConfig     `yaml:"retention"`
 	Security      SecurityConfig      `yaml:"security"`
 	Health        HealthConfig        `yaml:"health"`
 }
@@ -88,6 +93,34 @@ type EvidenceConfig struct {
 	MaxEvidenceSizeMB        int    `yaml:"max_evidence_size_mb"`
 	HashAlgorithm            string `yaml:"hash_algorithm"`
 	ChainOfCustodyEnabled    bool   `yaml:"chain_of_custody_enabled"`
+	CustodySigning           CustodySigningConfig `yaml:"custody_signing"`
+}
+
+// CustodySigningConfig holds chain of custody signature settings
+type CustodySigningConfig struct {
+	Enabled           bool   `yaml:"enabled"`
+	RequireSignatures bool   `yaml:"require_signatures"`
+	CAFile            string `yaml:"ca_file"`
+	CertFile          string `yaml:"cert_file"`
+	KeyFile           string `yaml:"key_file"`
+}
+
+// RetentionConfig holds retention policy settings
+type RetentionConfig struct {
+	Enabled         bool                   `yaml:"enabled"`
+	PurgeInterval   string                 `yaml:"purge_interval"`
+	BatchSize       int                    `yaml:"batch_size"`
+	AuditLogURL     string                 `yaml:"audit_log_url"`
+	AuditLogTimeout string                 `yaml:"audit_log_timeout"`
+	Classes         []RetentionClassConfig `yaml:"classes"`
+}
+
+// RetentionClassConfig holds the retention settings for one resource type
+type RetentionClassConfig struct {
+	Name            string `yaml:"name"`
+	ResourceType    string `yaml:"resource_type"`
+	RetainDays      int    `yaml:"retain_days"`
+	DeleteGraceDays int    `yaml:"delete_grace_days"`
 }
 
 // SecurityConfig holds security settings
@@ -137,14 +170,46 @@ func main() {
 
 	// Initialize services
 	graphService := services.NewGraphAnalysisService(repo)
-	evidenceService := services.NewEvidenceManagementService(repo, repo)
+	custodySigner, custodyVerifier, err := initCustodySigning(config.Evidence.CustodySigning)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize custody signing")
+	}
+	evidenceService := services.NewEvidenceManagementService(repo, repo, custodySigner, custodyVerifier, config.Evidence.CustodySigning.RequireSignatures)
 
-	// Initialize HTTP handler
+	// Initialize retention; purged resources leave a tombstone on the audit log's WORM chain
+	retentionClasses, err := buildRetentionClasses(config)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid retention configuration")
+	}
+	auditLogTimeout, err := time.ParseDuration(config.Retention.AuditLogTimeout)
+	if err != nil {
+		auditLogTimeout = 10 * time.Second
+	}
+	tombstoneWriter := audit.NewTombstoneWriter(config.Retention.AuditLogURL, auditLogTimeout)
+	retentionService := services.NewRetentionService(repo, tombstoneWriter, retentionClasses, config.Retention.BatchSize)
+
+	// Initialize HTTP handlers
+	http.RegisterErrors()
 	handler := http.NewForensicHandler(graphService, evidenceService)
+	retentionHandler := http.NewRetentionHandler(retentionService, config.Security.APIKeys)
+	searchHandler := http.NewSearchHandler(services.NewSearchService(repo))
 
 	// Setup router
 	router := chi.NewRouter()
 	handler.RegisterRoutes(router)
+	retentionHandler.RegisterRoutes(router)
+	searchHandler.RegisterRoutes(router)
+
+	// Start scheduled purges
+	purgeCtx, stopPurges := context.WithCancel(context.Background())
+	defer stopPurges()
+	if config.Retention.Enabled {
+		purgeInterval, err := time.ParseDuration(config.Retention.PurgeInterval)
+		if err != nil {
+			purgeInterval = 24 * time.Hour
+		}
+		go retentionService.StartPurgeScheduler(purgeCtx, purgeInterval)
+	}
 
 	// Add health check endpoint
 	if config.Health.Enabled {
@@ -174,6 +239,7 @@ func main() {
 	<-quit
 
 	logger.Info().Msg("Shutting down server...")
+	stopPurges()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -215,6 +281,66 @@ func applyEnvOverrides(config *Config) {
 	}
 }
 
+// initCustodySigning loads the platform CA used to verify custody record
+// signatures and the service certificate used to sign the service's own
+// records. It returns nil values when custody signing is disabled.
+func initCustodySigning(cfg CustodySigningConfig) (ports.CustodySigner, ports.CustodyVerifier, error) {
+	if !cfg.Enabled {
+		return nil, nil, nil
+	}
+
+	verifier, err := pki.NewCertificateVerifier(cfg.CAFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signer, err := pki.NewCertificateSigner(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signer, verifier, nil
+}
+
+// buildRetentionClasses converts the configured retention classes. Evidence
+// falls back to evidence.retention_days when it has no class of its own.
+func buildRetentionClasses(config *Config) ([]domain.RetentionClass, error) {
+	seen := make(map[domain.ResourceType]bool)
+	var classes []domain.RetentionClass
+
+	for _, c := range config.Retention.Classes {
+		resourceType := domain.ResourceType(strings.ToUpper(c.ResourceType))
+		if resourceType != domain.ResourceTypeEvidence && resourceType != domain.ResourceTypeReport {
+			return nil, fmt.Errorf("retention class %s: unknown resource type %q", c.Name, c.ResourceType)
+		}
+		if seen[resourceType] {
+			return nil, fmt.Errorf("retention class %s: resource type %s already has a class", c.Name, resourceType)
+		}
+		if c.RetainDays < 0 || c.DeleteGraceDays < 0 {
+			return nil, fmt.Errorf("retention class %s: days must not be negative", c.Name)
+		}
+		seen[resourceType] = true
+
+		classes = append(classes, domain.RetentionClass{
+			Name:            c.Name,
+			ResourceType:    resourceType,
+			RetainDays:      c.RetainDays,
+			DeleteGraceDays: c.DeleteGraceDays,
+		})
+	}
+
+	if !seen[domain.ResourceTypeEvidence] {
+		classes = append(classes, domain.RetentionClass{
+			Name:            "evidence-default",
+			ResourceType:    domain.ResourceTypeEvidence,
+			RetainDays:      config.Evidence.RetentionDays,
+			DeleteGraceDays: 30,
+		})
+	}
+
+	return classes, nil
+}
+
 func initDatabase(cfg DatabaseConfig) (*sql.DB, error) {
 	connStr := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -333,6 +459,65 @@ func runMigrations(db *sql.DB, tablePrefix string) error {
 				created_at BIGINT
 			)
 		`, tablePrefix),
+		// Soft deletion columns used by the retention service
+		fmt.Sprintf(`ALTER TABLE %sevidence ADD COLUMN IF NOT EXISTS deleted_at BIGINT`, tablePrefix),
+		fmt.Sprintf(`ALTER TABLE %sevidence ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(255)`, tablePrefix),
+		fmt.Sprintf(`ALTER TABLE %sforensic_reports ADD COLUMN IF NOT EXISTS deleted_at BIGINT`, tablePrefix),
+		fmt.Sprintf(`ALTER TABLE %sforensic_reports ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(255)`, tablePrefix),
+		// Custody record signatures
+		fmt.Sprintf(`ALTER TABLE %schain_of_custody ADD COLUMN IF NOT EXISTS signature TEXT`, tablePrefix),
+		fmt.Sprintf(`ALTER TABLE %schain_of_custody ADD COLUMN IF NOT EXISTS signer_certificate TEXT`, tablePrefix),
+		// Legal holds table
+		fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %slegal_holds (
+				id VARCHAR(255) PRIMARY KEY,
+				resource_type VARCHAR(50),
+				resource_id VARCHAR(255),
+				case_id VARCHAR(255),
+				reason TEXT NOT NULL,
+				placed_by VARCHAR(255) NOT NULL,
+				placed_at BIGINT NOT NULL,
+				released_by VARCHAR(255),
+				released_at BIGINT
+			)
+		`, tablePrefix),
+		// Full-text search vectors, kept current by triggers
+		fmt.Sprintf(`ALTER TABLE %sevidence ADD COLUMN IF NOT EXISTS search_vector tsvector`, tablePrefix),
+		fmt.Sprintf(`
+			CREATE OR REPLACE FUNCTION %[1]sevidence_search_vector() RETURNS trigger AS $$
+			BEGIN
+				NEW.search_vector :=
+					setweight(to_tsvector('english', COALESCE(NEW.title, '')), 'A') ||
+					setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'B') ||
+					setweight(to_tsvector('english', COALESCE(NEW.content, '{}'::jsonb)), 'C');
+				RETURN NEW;
+			END
+			$$ LANGUAGE plpgsql
+		`, tablePrefix),
+		fmt.Sprintf(`
+			CREATE TRIGGER %[1]sevidence_search_vector_update
+			BEFORE INSERT OR UPDATE OF title, description, content ON %[1]sevidence
+			FOR EACH ROW EXECUTE PROCEDURE %[1]sevidence_search_vector()
+		`, tablePrefix),
+		fmt.Sprintf(`UPDATE %sevidence SET title = title WHERE search_vector IS NULL`, tablePrefix),
+		fmt.Sprintf(`ALTER TABLE %schain_of_custody ADD COLUMN IF NOT EXISTS search_vector tsvector`, tablePrefix),
+		fmt.Sprintf(`
+			CREATE OR REPLACE FUNCTION %[1]scustody_search_vector() RETURNS trigger AS $$
+			BEGIN
+				NEW.search_vector :=
+					setweight(to_tsvector('english', COALESCE(NEW.action, '')), 'A') ||
+					setweight(to_tsvector('english', COALESCE(NEW.handler, '') || ' ' || COALESCE(NEW.location, '')), 'B') ||
+					setweight(to_tsvector('english', COALESCE(NEW.notes, '')), 'C');
+				RETURN NEW;
+			END
+			$$ LANGUAGE plpgsql
+		`, tablePrefix),
+		fmt.Sprintf(`
+			CREATE TRIGGER %[1]scustody_search_vector_update
+			BEFORE INSERT OR UPDATE OF handler, action, location, notes ON %[1]schain_of_custody
+			FOR EACH ROW EXECUTE PROCEDURE %[1]scustody_search_vector()
+		`, tablePrefix),
+		fmt.Sprintf(`UPDATE %schain_of_custody SET notes = notes WHERE search_vector IS NULL`, tablePrefix),
 		// Create indexes
 		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_graph_nodes_type ON %sgraph_nodes(node_type)`, tablePrefix, tablePrefix),
 		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_graph_edges_source ON %sgraph_edges(source_id)`, tablePrefix, tablePrefix),
@@ -340,6 +525,11 @@ func runMigrations(db *sql.DB, tablePrefix string) error {
 		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_evidence_case ON %sevidence(case_id)`, tablePrefix, tablePrefix),
 		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_evidence_type ON %sevidence(evidence_type)`, tablePrefix, tablePrefix),
 		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_custody_evidence ON %schain_of_custody(evidence_id)`, tablePrefix, tablePrefix),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_evidence_deleted ON %sevidence(deleted_at) WHERE deleted_at IS NOT NULL`, tablePrefix, tablePrefix),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_reports_deleted ON %sforensic_reports(deleted_at) WHERE deleted_at IS NOT NULL`, tablePrefix, tablePrefix),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_legal_holds_active ON %slegal_holds(resource_type, resource_id, case_id) WHERE released_at IS NULL`, tablePrefix, tablePrefix),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_evidence_search ON %sevidence USING GIN (search_vector)`, tablePrefix, tablePrefix),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %sidx_custody_search ON %schain_of_custody USING GIN (search_vector)`, tablePrefix, tablePrefix),
 	}
 
 	for _, migration := range migrations {
@@ -366,3 +556,5 @@ var _ ports.GraphRepository = (*postgres.PostgresRepository)(nil)
 var _ ports.EvidenceRepository = (*postgres.PostgresRepository)(nil)
 var _ ports.ReportRepository = (*postgres.PostgresRepository)(nil)
 var _ ports.ClusterRepository = (*postgres.PostgresRepository)(nil)
+var _ ports.RetentionRepository = (*postgres.PostgresRepository)(nil)
+var _ ports.SearchRepository = (*postgres.PostgresRepository)(nil)
@@ -3,10 +3,12 @@ module github.com/csic-platform/services/forensic-tools
 go 1.21
 
 require (
+	github.com/csic-platform/shared v0.0.0
 	github.com/go-chi/chi/v5 v5.0.11
 	github.com/go-chi/cors v1.5.0
 	github.com/go-chi/httplog v1.6.0
 	github.com/lib/pq v1.10.9
+	github.com/shopspring/decimal v1.3.1
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -16,3 +18,5 @@ require (
 	golang.org/x/sys v0.17.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 )
+
+replace github.com/csic-platform/shared => ../../shared
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/csic-platform/internal/core/ports"
+)
+
+// TombstoneWriter records purged resources as entries on the audit log
+// service's WORM chain
+type TombstoneWriter struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewTombstoneWriter creates a tombstone writer for the audit log service at baseURL
+func NewTombstoneWriter(baseURL string, timeout time.Duration) *TombstoneWriter {
+	return &TombstoneWriter{
+		endpoint: strings.TrimRight(baseURL, "/") + "/api/v1/audit/entries",
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// entry is the subset of the audit log service's entry format written for tombstones
+type entry struct {
+	ActorID        string                 `json:"actor_id"`
+	ActorType      string                 `json:"actor_type"`
+	ActorRole      string                 `json:"actor_role"`
+	Service        string                 `json:"service"`
+	Operation      string                 `json:"operation"`
+	ActionType     string                 `json:"action_type"`
+	Resource       string                 `json:"resource"`
+	ResourceID     string                 `json:"resource_id"`
+	Description    string                 `json:"description"`
+	Result         string                 `json:"result"`
+	ComplianceTags []string               `json:"compliance_tags"`
+	RiskLevel      string                 `json:"risk_level"`
+	Metadata       map[string]interface{} `json:"metadata"`
+}
+
+// WriteTombstone appends a tombstone entry to the audit chain
+func (w *TombstoneWriter) WriteTombstone(ctx context.Context, tombstone *domain.Tombstone) error {
+	metadata := map[string]interface{}{
+		"tombstone":       true,
+		"case_id":         tombstone.CaseID,
+		"content_hash":    tombstone.Hash,
+		"retention_class": tombstone.RetentionClass,
+		"reason":          string(tombstone.Reason),
+		"created_at":      tombstone.CreatedAt.Format(time.RFC3339),
+		"purged_at":       tombstone.PurgedAt.Format(time.RFC3339),
+	}
+	if tombstone.DeletedAt != nil {
+		metadata["deleted_at"] = tombstone.DeletedAt.Format(time.RFC3339)
+	}
+
+	body, err := json.Marshal(entry{
+		ActorID:        "forensic-tools-retention",
+		ActorType:      "system",
+		ActorRole:      "retention",
+		Service:        "forensic-tools",
+		Operation:      "retention.purge",
+		ActionType:     "delete",
+		Resource:       strings.ToLower(string(tombstone.ResourceType)),
+		ResourceID:     tombstone.ResourceID,
+		Description:    fmt.Sprintf("Purged %s %s under retention class %s", tombstone.ResourceType, tombstone.ResourceID, tombstone.RetentionClass),
+		Result:         "success",
+		ComplianceTags: []string{"RETENTION"},
+		RiskLevel:      "high",
+		Metadata:       metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tombstone: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to write tombstone: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("audit log rejected tombstone for %s %s: status %d", tombstone.ResourceType, tombstone.ResourceID, resp.StatusCode)
+	}
+	return nil
+}
+
+// Ensure TombstoneWriter implements the TombstoneWriter interface
+var _ ports.TombstoneWriter = (*TombstoneWriter)(nil)
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/csic-platform/shared/problem"
+)
+
+// RegisterErrors classifies the forensic domain errors. It is called once at
+// startup, before the router serves requests.
+func RegisterErrors() {
+	problem.Register(problem.NotFound, domain.ErrResourceNotFound, domain.ErrLegalHoldNotFound)
+	problem.Register(problem.Conflict, domain.ErrUnderLegalHold)
+	problem.Register(problem.FailedPrecondition, domain.ErrInvalidCustodySignature)
+	problem.Register(problem.InvalidArgument,
+		domain.ErrUnknownResourceType,
+		domain.ErrInvalidLegalHold,
+		domain.ErrEmptySearchQuery,
+		domain.ErrUnknownSearchScope,
+		domain.ErrCustodySignatureRequired,
+	)
+}
+
+// writeError responds with the problem document for err. Invalid requests
+// keep the full message of the error, which says what is wrong with them.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var classified *problem.Error
+	if !errors.As(err, &classified) && problem.CodeOf(err) == problem.InvalidArgument {
+		err = problem.Invalid(err.Error())
+	}
+	problem.WriteHTTP(w, r, err)
+}
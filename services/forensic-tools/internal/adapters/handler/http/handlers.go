@@ -2,10 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/csic-platform/internal/core/domain"
 	"github.com/csic-platform/internal/core/services"
+	"github.com/csic-platform/shared/problem"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 )
@@ -54,6 +56,8 @@ func (h *ForensicHandler) RegisterRoutes(r chi.Router) {
 	r.Post("/api/v1/evidence/cluster", h.CollectClusterEvidenceHandler)
 	r.Get("/api/v1/evidence/{id}", h.GetEvidenceHandler)
 	r.Get("/api/v1/evidence/{id}/timeline", h.GetEvidenceTimelineHandler)
+	r.Post("/api/v1/evidence/{id}/custody", h.AddCustodyRecordHandler)
+	r.Get("/api/v1/evidence/{id}/custody/verify", h.VerifyCustodyChainHandler)
 	r.Post("/api/v1/evidence/{id}/verify", h.VerifyEvidenceHandler)
 	r.Post("/api/v1/evidence/{id}/export", h.ExportEvidenceHandler)
 	r.Post("/api/v1/evidence/package", h.PackageEvidenceHandler)
@@ -70,18 +74,18 @@ func (h *ForensicHandler) BuildGraphHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	if len(request.TxHashes) == 0 {
-		http.Error(w, "At least one transaction hash is required", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("At least one transaction hash is required"))
 		return
 	}
 
 	rootNode, edges, err := h.graphService.BuildTransactionGraph(r.Context(), request.TxHashes)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -103,12 +107,12 @@ func (h *ForensicHandler) ExpandGraphHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	if request.NodeID == "" {
-		http.Error(w, "Node ID is required", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Node ID is required"))
 		return
 	}
 
@@ -118,7 +122,7 @@ func (h *ForensicHandler) ExpandGraphHandler(w http.ResponseWriter, r *http.Requ
 
 	nodes, edges, err := h.graphService.ExpandGraph(r.Context(), request.NodeID, request.Depth)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -140,13 +144,13 @@ func (h *ForensicHandler) DetectCommunitiesHandler(w http.ResponseWriter, r *htt
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	clusters, err := h.graphService.DetectCommunities(r.Context(), request.NodeIDs)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -167,7 +171,7 @@ func (h *ForensicHandler) FindCentralNodesHandler(w http.ResponseWriter, r *http
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
@@ -177,7 +181,7 @@ func (h *ForensicHandler) FindCentralNodesHandler(w http.ResponseWriter, r *http
 
 	nodes, err := h.graphService.FindCentralNodes(r.Context(), request.NodeIDs, request.Limit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -198,13 +202,13 @@ func (h *ForensicHandler) FindPathHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	nodes, edges, err := h.graphService.FindPath(r.Context(), request.SourceID, request.TargetID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -225,7 +229,7 @@ func (h *ForensicHandler) CalculateCentralityHandler(w http.ResponseWriter, r *h
 
 	centrality, err := h.graphService.CalculateCentrality(r.Context(), nodeID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -244,7 +248,7 @@ func (h *ForensicHandler) CalculateClusteringHandler(w http.ResponseWriter, r *h
 
 	coefficient, err := h.graphService.CalculateClusteringCoefficient(r.Context(), nodeID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -264,13 +268,13 @@ func (h *ForensicHandler) GetConnectedComponentsHandler(w http.ResponseWriter, r
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	components, err := h.graphService.GetConnectedComponents(r.Context(), request.NodeIDs)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -290,18 +294,18 @@ func (h *ForensicHandler) CollectTransactionEvidenceHandler(w http.ResponseWrite
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	if request.TxHash == "" {
-		http.Error(w, "Transaction hash is required", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Transaction hash is required"))
 		return
 	}
 
 	evidence, err := h.evidenceService.CollectTransactionEvidence(r.Context(), request.TxHash)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -316,18 +320,18 @@ func (h *ForensicHandler) CollectWalletEvidenceHandler(w http.ResponseWriter, r
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	if request.Address == "" {
-		http.Error(w, "Wallet address is required", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Wallet address is required"))
 		return
 	}
 
 	evidence, err := h.evidenceService.CollectWalletEvidence(r.Context(), request.Address)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -342,18 +346,18 @@ func (h *ForensicHandler) CollectClusterEvidenceHandler(w http.ResponseWriter, r
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	if request.ClusterID == "" {
-		http.Error(w, "Cluster ID is required", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Cluster ID is required"))
 		return
 	}
 
 	evidence, err := h.evidenceService.CollectClusterEvidence(r.Context(), request.ClusterID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -367,7 +371,7 @@ func (h *ForensicHandler) GetEvidenceHandler(w http.ResponseWriter, r *http.Requ
 
 	evidence, err := h.evidenceService.VerifyEvidence(r.Context(), evidenceID)
 	if err != nil {
-		http.Error(w, "Evidence not found", http.StatusNotFound)
+		writeError(w, r, problem.New(problem.NotFound, "Evidence not found"))
 		return
 	}
 
@@ -381,7 +385,7 @@ func (h *ForensicHandler) GetEvidenceTimelineHandler(w http.ResponseWriter, r *h
 
 	timeline, err := h.evidenceService.GetEvidenceTimeline(r.Context(), evidenceID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -395,13 +399,50 @@ func (h *ForensicHandler) GetEvidenceTimelineHandler(w http.ResponseWriter, r *h
 	json.NewEncoder(w).Encode(response)
 }
 
+// AddCustodyRecordHandler handles signed custody record submissions
+func (h *ForensicHandler) AddCustodyRecordHandler(w http.ResponseWriter, r *http.Request) {
+	evidenceID := chi.URLParam(r, "id")
+
+	var record domain.ChainOfCustody
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+		writeError(w, r, problem.Invalid("Invalid request body"))
+		return
+	}
+
+	if err := h.evidenceService.AddCustodyRecord(r.Context(), evidenceID, &record); err != nil {
+		if !errors.Is(err, domain.ErrInvalidCustodySignature) {
+			err = problem.Invalid(err.Error())
+		}
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(record)
+}
+
+// VerifyCustodyChainHandler handles chain of custody signature verification requests
+func (h *ForensicHandler) VerifyCustodyChainHandler(w http.ResponseWriter, r *http.Request) {
+	evidenceID := chi.URLParam(r, "id")
+
+	report, err := h.evidenceService.VerifyCustodyChain(r.Context(), evidenceID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
 // VerifyEvidenceHandler handles evidence verification requests
 func (h *ForensicHandler) VerifyEvidenceHandler(w http.ResponseWriter, r *http.Request) {
 	evidenceID := chi.URLParam(r, "id")
 
 	valid, err := h.evidenceService.VerifyHashIntegrity(r.Context(), evidenceID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -429,7 +470,7 @@ func (h *ForensicHandler) ExportEvidenceHandler(w http.ResponseWriter, r *http.R
 
 	data, err := h.evidenceService.ExportEvidence(r.Context(), evidenceID, request.Format)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -444,13 +485,13 @@ func (h *ForensicHandler) PackageEvidenceHandler(w http.ResponseWriter, r *http.
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	report, err := h.evidenceService.PackageForLegal(r.Context(), request.EvidenceIDs)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -466,18 +507,18 @@ func (h *ForensicHandler) CreateCaseEvidenceHandler(w http.ResponseWriter, r *ht
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	if request.CaseID == "" {
-		http.Error(w, "Case ID is required", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Case ID is required"))
 		return
 	}
 
 	evidence, err := h.evidenceService.CreateCaseEvidence(r.Context(), request.CaseID, request.EvidenceIDs)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -492,12 +533,12 @@ func (h *ForensicHandler) GenerateLegalReportHandler(w http.ResponseWriter, r *h
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Invalid request body"))
 		return
 	}
 
 	if request.CaseID == "" {
-		http.Error(w, "Case ID is required", http.StatusBadRequest)
+		writeError(w, r, problem.Invalid("Case ID is required"))
 		return
 	}
 
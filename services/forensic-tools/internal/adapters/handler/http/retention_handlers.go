@@ -0,0 +1,225 @@
+package http
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/csic-platform/internal/core/services"
+	"github.com/csic-platform/shared/problem"
+	"github.com/go-chi/chi/v5"
+)
+
+// actorHeader identifies the administrator making a retention request
+const actorHeader = "X-Actor-ID"
+
+// RetentionHandler handles HTTP requests for retention administration
+type RetentionHandler struct {
+	retentionService *services.RetentionService
+	adminAPIKeys     []string
+}
+
+// NewRetentionHandler creates a new retention HTTP handler. Every route
+// requires one of adminAPIKeys in the X-API-Key header.
+func NewRetentionHandler(retentionService *services.RetentionService, adminAPIKeys []string) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+		adminAPIKeys:     adminAPIKeys,
+	}
+}
+
+// RegisterRoutes registers the retention admin API routes
+func (h *RetentionHandler) RegisterRoutes(r chi.Router) {
+	r.With(h.requireAdminKey).Delete("/api/v1/evidence/{id}", h.DeleteEvidenceHandler)
+
+	r.Route("/api/v1/retention", func(r chi.Router) {
+		r.Use(h.requireAdminKey)
+
+		r.Get("/classes", h.ListClassesHandler)
+
+		r.Delete("/resources/{type}/{id}", h.DeleteResourceHandler)
+		r.Post("/resources/{type}/{id}/restore", h.RestoreResourceHandler)
+
+		r.Get("/holds", h.ListLegalHoldsHandler)
+		r.Post("/holds", h.PlaceLegalHoldHandler)
+		r.Post("/holds/{id}/release", h.ReleaseLegalHoldHandler)
+
+		r.Post("/purge", h.RunPurgeHandler)
+		r.Get("/purge/last", h.LastPurgeHandler)
+	})
+}
+
+// ListClassesHandler returns the configured retention classes
+func (h *RetentionHandler) ListClassesHandler(w http.ResponseWriter, r *http.Request) {
+	classes := h.retentionService.Classes()
+
+	response := map[string]interface{}{
+		"classes": classes,
+		"count":   len(classes),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DeleteEvidenceHandler soft-deletes evidence
+func (h *RetentionHandler) DeleteEvidenceHandler(w http.ResponseWriter, r *http.Request) {
+	h.deleteResource(w, r, domain.ResourceTypeEvidence, chi.URLParam(r, "id"))
+}
+
+// DeleteResourceHandler soft-deletes a resource of any retained type
+func (h *RetentionHandler) DeleteResourceHandler(w http.ResponseWriter, r *http.Request) {
+	h.deleteResource(w, r, resourceTypeParam(r), chi.URLParam(r, "id"))
+}
+
+// RestoreResourceHandler restores a soft-deleted resource before it is purged
+func (h *RetentionHandler) RestoreResourceHandler(w http.ResponseWriter, r *http.Request) {
+	resourceType := resourceTypeParam(r)
+	id := chi.URLParam(r, "id")
+
+	if err := h.retentionService.RestoreResource(r.Context(), resourceType, id); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"resource_type": resourceType,
+		"resource_id":   id,
+		"restored":      true,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListLegalHoldsHandler lists legal holds; pass active=true for active holds only
+func (h *RetentionHandler) ListLegalHoldsHandler(w http.ResponseWriter, r *http.Request) {
+	activeOnly := r.URL.Query().Get("active") == "true"
+
+	holds, err := h.retentionService.ListLegalHolds(r.Context(), activeOnly)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"holds": holds,
+		"count": len(holds),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// PlaceLegalHoldHandler places a legal hold on a resource or a case
+func (h *RetentionHandler) PlaceLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ResourceType string `json:"resource_type"`
+		ResourceID   string `json:"resource_id"`
+		CaseID       string `json:"case_id"`
+		Reason       string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, r, problem.Invalid("Invalid request body"))
+		return
+	}
+
+	hold := &domain.LegalHold{
+		ResourceType: domain.ResourceType(strings.ToUpper(request.ResourceType)),
+		ResourceID:   request.ResourceID,
+		CaseID:       request.CaseID,
+		Reason:       request.Reason,
+		PlacedBy:     r.Header.Get(actorHeader),
+	}
+
+	if err := h.retentionService.PlaceLegalHold(r.Context(), hold); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(hold)
+}
+
+// ReleaseLegalHoldHandler releases a legal hold
+func (h *RetentionHandler) ReleaseLegalHoldHandler(w http.ResponseWriter, r *http.Request) {
+	actorID := r.Header.Get(actorHeader)
+	if actorID == "" {
+		writeError(w, r, problem.Invalid(actorHeader+" header is required"))
+		return
+	}
+
+	hold, err := h.retentionService.ReleaseLegalHold(r.Context(), chi.URLParam(r, "id"), actorID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hold)
+}
+
+// RunPurgeHandler runs a purge immediately and returns its report
+func (h *RetentionHandler) RunPurgeHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.retentionService.RunPurge(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// LastPurgeHandler returns the report of the most recent purge run
+func (h *RetentionHandler) LastPurgeHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.retentionService.LastPurge()
+	if report == nil {
+		writeError(w, r, problem.New(problem.NotFound, "No purge has run yet"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (h *RetentionHandler) deleteResource(w http.ResponseWriter, r *http.Request, resourceType domain.ResourceType, id string) {
+	actorID := r.Header.Get(actorHeader)
+	if actorID == "" {
+		writeError(w, r, problem.Invalid(actorHeader+" header is required"))
+		return
+	}
+
+	if err := h.retentionService.DeleteResource(r.Context(), resourceType, id, actorID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"resource_type": resourceType,
+		"resource_id":   id,
+		"deleted":       true,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// requireAdminKey rejects requests without a configured admin API key
+func (h *RetentionHandler) requireAdminKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-API-Key")
+		for _, allowed := range h.adminAPIKeys {
+			if key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(allowed)) == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		writeError(w, r, problem.New(problem.Unauthenticated, "A valid X-API-Key header is required"))
+	})
+}
+
+// resourceTypeParam reads the resource type path parameter, e.g. "evidence"
+func resourceTypeParam(r *http.Request) domain.ResourceType {
+	return domain.ResourceType(strings.ToUpper(chi.URLParam(r, "type")))
+}
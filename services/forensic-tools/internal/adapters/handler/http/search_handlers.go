@@ -0,0 +1,59 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/csic-platform/internal/core/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// SearchHandler handles full-text search requests
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+// NewSearchHandler creates a new search HTTP handler
+func NewSearchHandler(searchService *services.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// RegisterRoutes registers the search API routes
+func (h *SearchHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/api/v1/search", h.SearchHandler)
+	r.Get("/api/v1/evidence/search", h.SearchEvidenceHandler)
+}
+
+// SearchHandler searches evidence and custody records. Query parameters:
+// q, scope (all, evidence or custody), case_id, limit and offset.
+func (h *SearchHandler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	h.search(w, r, domain.SearchScope(r.URL.Query().Get("scope")))
+}
+
+// SearchEvidenceHandler searches evidence only
+func (h *SearchHandler) SearchEvidenceHandler(w http.ResponseWriter, r *http.Request) {
+	h.search(w, r, domain.SearchScopeEvidence)
+}
+
+func (h *SearchHandler) search(w http.ResponseWriter, r *http.Request, scope domain.SearchScope) {
+	params := r.URL.Query()
+	limit, _ := strconv.Atoi(params.Get("limit"))
+	offset, _ := strconv.Atoi(params.Get("offset"))
+
+	results, err := h.searchService.Search(r.Context(), domain.SearchQuery{
+		Query:  params.Get("q"),
+		Scope:  scope,
+		CaseID: params.Get("case_id"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
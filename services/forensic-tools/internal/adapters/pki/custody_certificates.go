@@ -0,0 +1,147 @@
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CertificateSigner signs custody records with the service's own certificate,
+// issued by the platform CA
+type CertificateSigner struct {
+	key         crypto.Signer
+	certificate string
+	identity    string
+}
+
+// NewCertificateSigner loads the service certificate and private key
+func NewCertificateSigner(certFile, keyFile string) (*CertificateSigner, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing certificate: %w", err)
+	}
+
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("signing key does not support signing")
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+
+	return &CertificateSigner{
+		key:         key,
+		certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pair.Certificate[0]})),
+		identity:    cert.Subject.CommonName,
+	}, nil
+}
+
+// Identity returns the common name of the signing certificate
+func (s *CertificateSigner) Identity() string {
+	return s.identity
+}
+
+// Sign signs payload and returns the base64 signature and the PEM certificate
+func (s *CertificateSigner) Sign(payload []byte) (string, string, error) {
+	digest := payload
+	var opts crypto.SignerOpts = crypto.Hash(0)
+	if _, ok := s.key.Public().(ed25519.PublicKey); !ok {
+		sum := sha256.Sum256(payload)
+		digest = sum[:]
+		opts = crypto.SHA256
+	}
+
+	signature, err := s.key.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign custody record: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), s.certificate, nil
+}
+
+// CertificateVerifier verifies custody record signatures against user
+// certificates issued by the platform CA
+type CertificateVerifier struct {
+	roots *x509.CertPool
+}
+
+// NewCertificateVerifier loads the platform CA bundle
+func NewCertificateVerifier(caFile string) (*CertificateVerifier, error) {
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("no certificates found in CA file")
+	}
+
+	return &CertificateVerifier{roots: roots}, nil
+}
+
+// Verify checks that certificatePEM chains to the platform CA and was valid at
+// signedAt, and that signature is its signature over payload. It returns the
+// certificate's common name.
+func (v *CertificateVerifier) Verify(payload []byte, signature, certificatePEM string, signedAt time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(certificatePEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.New("signer certificate is not a PEM certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse signer certificate: %w", err)
+	}
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:       v.roots,
+		CurrentTime: signedAt,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return "", fmt.Errorf("signer certificate not trusted: %w", err)
+	}
+
+	rawSignature, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return "", fmt.Errorf("signature is not base64: %w", err)
+	}
+
+	algorithm, err := signatureAlgorithm(cert.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	if err := cert.CheckSignature(algorithm, payload, rawSignature); err != nil {
+		return "", fmt.Errorf("signature mismatch: %w", err)
+	}
+
+	return cert.Subject.CommonName, nil
+}
+
+// signatureAlgorithm returns the algorithm custody records are signed with
+// for a public key type
+func signatureAlgorithm(publicKey interface{}) (x509.SignatureAlgorithm, error) {
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return x509.ECDSAWithSHA256, nil
+	case *rsa.PublicKey:
+		return x509.SHA256WithRSA, nil
+	case ed25519.PublicKey:
+		return x509.PureEd25519, nil
+	default:
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signer key type %T", publicKey)
+	}
+}
@@ -378,7 +378,7 @@ func (r *PostgresRepository) StoreEvidence(ctx context.Context, evidence *domain
 
 // GetEvidence retrieves evidence by ID
 func (r *PostgresRepository) GetEvidence(ctx context.Context, id string) (*domain.Evidence, error) {
-	query := `SELECT id, case_id, evidence_type, title, description, content, tags, hash, collected_at, collected_by, status FROM evidence WHERE id = $1`
+	query := `SELECT id, case_id, evidence_type, title, description, content, tags, hash, collected_at, collected_by, status FROM evidence WHERE id = $1 AND deleted_at IS NULL`
 
 	var evidence domain.Evidence
 	var contentJSON, tagsJSON string
@@ -410,7 +410,7 @@ func (r *PostgresRepository) GetEvidence(ctx context.Context, id string) (*domai
 
 // ListEvidence retrieves evidence for a case with pagination
 func (r *PostgresRepository) ListEvidence(ctx context.Context, caseID string, limit, offset int) ([]*domain.Evidence, error) {
-	query := `SELECT id, case_id, evidence_type, title, description, content, tags, hash, collected_at, collected_by, status FROM evidence WHERE case_id = $1 ORDER BY collected_at DESC LIMIT $2 OFFSET $3`
+	query := `SELECT id, case_id, evidence_type, title, description, content, tags, hash, collected_at, collected_by, status FROM evidence WHERE case_id = $1 AND deleted_at IS NULL ORDER BY collected_at DESC LIMIT $2 OFFSET $3`
 
 	rows, err := r.db.QueryContext(ctx, query, caseID, limit, offset)
 	if err != nil {
@@ -435,16 +435,14 @@ func (r *PostgresRepository) ListEvidence(ctx context.Context, caseID string, li
 	return evidenceList, rows.Err()
 }
 
-// DeleteEvidence removes evidence from the database
+// DeleteEvidence soft-deletes evidence; it is purged by the retention service
 func (r *PostgresRepository) DeleteEvidence(ctx context.Context, id string) error {
-	query := `DELETE FROM evidence WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+	return r.SoftDeleteResource(ctx, domain.ResourceTypeEvidence, id, "")
 }
 
 // AppendCustodyRecord adds a chain of custody record
 func (r *PostgresRepository) AppendCustodyRecord(ctx context.Context, evidenceID string, record *domain.ChainOfCustody) error {
-	query := `INSERT INTO chain_of_custody (evidence_id, timestamp, handler, action, location, integrity_hash, notes) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	query := `INSERT INTO chain_of_custody (evidence_id, timestamp, handler, action, location, integrity_hash, notes, signature, signer_certificate) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
 	_, err := r.db.ExecContext(ctx, query,
 		evidenceID,
@@ -454,13 +452,15 @@ func (r *PostgresRepository) AppendCustodyRecord(ctx context.Context, evidenceID
 		record.Location,
 		record.IntegrityHash,
 		record.Notes,
+		record.Signature,
+		record.SignerCertificate,
 	)
 	return err
 }
 
 // GetCustodyHistory retrieves the complete chain of custody for evidence
 func (r *PostgresRepository) GetCustodyHistory(ctx context.Context, evidenceID string) ([]*domain.ChainOfCustody, error) {
-	query := `SELECT timestamp, handler, action, location, integrity_hash, notes FROM chain_of_custody WHERE evidence_id = $1 ORDER BY timestamp ASC`
+	query := `SELECT timestamp, handler, action, location, integrity_hash, notes, signature, signer_certificate FROM chain_of_custody WHERE evidence_id = $1 ORDER BY timestamp ASC, id ASC`
 
 	rows, err := r.db.QueryContext(ctx, query, evidenceID)
 	if err != nil {
@@ -471,10 +471,13 @@ func (r *PostgresRepository) GetCustodyHistory(ctx context.Context, evidenceID s
 	var history []*domain.ChainOfCustody
 	for rows.Next() {
 		var record domain.ChainOfCustody
+		var signature, signerCertificate sql.NullString
 
-		if err := rows.Scan(&record.Timestamp, &record.Handler, &record.Action, &record.Location, &record.IntegrityHash, &record.Notes); err != nil {
+		if err := rows.Scan(&record.Timestamp, &record.Handler, &record.Action, &record.Location, &record.IntegrityHash, &record.Notes, &signature, &signerCertificate); err != nil {
 			return nil, err
 		}
+		record.Signature = signature.String
+		record.SignerCertificate = signerCertificate.String
 
 		history = append(history, &record)
 	}
@@ -484,7 +487,7 @@ func (r *PostgresRepository) GetCustodyHistory(ctx context.Context, evidenceID s
 
 // VerifyEvidence checks if evidence hash matches stored hash
 func (r *PostgresRepository) VerifyEvidence(ctx context.Context, id string) (bool, error) {
-	query := `SELECT hash FROM evidence WHERE id = $1`
+	query := `SELECT hash FROM evidence WHERE id = $1 AND deleted_at IS NULL`
 	var storedHash string
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(&storedHash)
@@ -536,7 +539,7 @@ func (r *PostgresRepository) StoreReport(ctx context.Context, report *domain.Rep
 
 // GetReport retrieves a report by ID
 func (r *PostgresRepository) GetReport(ctx context.Context, id string) (*domain.Report, error) {
-	query := `SELECT id, case_id, report_type, title, description, content, hash, generated_at, generated_by, status, version FROM forensic_reports WHERE id = $1`
+	query := `SELECT id, case_id, report_type, title, description, content, hash, generated_at, generated_by, status, version FROM forensic_reports WHERE id = $1 AND deleted_at IS NULL`
 
 	var report domain.Report
 	var contentJSON string
@@ -567,7 +570,7 @@ func (r *PostgresRepository) GetReport(ctx context.Context, id string) (*domain.
 
 // ListReports retrieves reports for a case with pagination
 func (r *PostgresRepository) ListReports(ctx context.Context, caseID string, limit, offset int) ([]*domain.Report, error) {
-	query := `SELECT id, case_id, report_type, title, description, content, hash, generated_at, generated_by, status, version FROM forensic_reports WHERE case_id = $1 ORDER BY generated_at DESC LIMIT $2 OFFSET $3`
+	query := `SELECT id, case_id, report_type, title, description, content, hash, generated_at, generated_by, status, version FROM forensic_reports WHERE case_id = $1 AND deleted_at IS NULL ORDER BY generated_at DESC LIMIT $2 OFFSET $3`
 
 	rows, err := r.db.QueryContext(ctx, query, caseID, limit, offset)
 	if err != nil {
@@ -591,11 +594,9 @@ func (r *PostgresRepository) ListReports(ctx context.Context, caseID string, lim
 	return reports, rows.Err()
 }
 
-// DeleteReport removes a report from the database
+// DeleteReport soft-deletes a report; it is purged by the retention service
 func (r *PostgresRepository) DeleteReport(ctx context.Context, id string) error {
-	query := `DELETE FROM forensic_reports WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+	return r.SoftDeleteResource(ctx, domain.ResourceTypeReport, id, "")
 }
 
 // GenerateReport generates a report for a case
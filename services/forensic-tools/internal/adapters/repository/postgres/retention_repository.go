@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/internal/core/domain"
+)
+
+// retentionTable describes how a retained resource type is stored
+type retentionTable struct {
+	table     string
+	createdAt string
+	caseID    string
+	hash      string
+}
+
+var retentionTables = map[domain.ResourceType]retentionTable{
+	domain.ResourceTypeEvidence: {table: "evidence", createdAt: "collected_at", caseID: "case_id", hash: "hash"},
+	domain.ResourceTypeReport:   {table: "forensic_reports", createdAt: "generated_at", caseID: "case_id", hash: "hash"},
+}
+
+func lookupRetentionTable(resourceType domain.ResourceType) (retentionTable, error) {
+	t, ok := retentionTables[resourceType]
+	if !ok {
+		return retentionTable{}, fmt.Errorf("%w: %s", domain.ErrUnknownResourceType, resourceType)
+	}
+	return t, nil
+}
+
+// activeHoldCondition matches rows of t covered by an active legal hold, either
+// on the row itself or on its case. $1 must be the resource type.
+func activeHoldCondition(t retentionTable) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM legal_holds h
+		WHERE h.released_at IS NULL
+		AND ((h.resource_type = $1 AND h.resource_id = %[1]s.id)
+			OR (h.case_id <> '' AND h.case_id = %[1]s.%[2]s))
+	)`, t.table, t.caseID)
+}
+
+// SoftDeleteResource marks a resource as deleted. It stays restorable until
+// its retention class's grace period ends.
+func (r *PostgresRepository) SoftDeleteResource(ctx context.Context, resourceType domain.ResourceType, id, deletedBy string) error {
+	t, err := lookupRetentionTable(resourceType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s SET deleted_at = $2, deleted_by = $3
+		WHERE id = $4 AND deleted_at IS NULL AND NOT %[2]s
+	`, t.table, activeHoldCondition(t))
+
+	result, err := r.db.ExecContext(ctx, query, string(resourceType), time.Now().Unix(), deletedBy, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	// Nothing was updated; tell a held resource apart from a missing one
+	held, err := r.HasActiveLegalHold(ctx, resourceType, id, "")
+	if err != nil {
+		return err
+	}
+	if held {
+		return fmt.Errorf("%w: %s %s", domain.ErrUnderLegalHold, resourceType, id)
+	}
+	caseID, err := r.GetResourceCaseID(ctx, resourceType, id)
+	if err != nil {
+		return err
+	}
+	if caseID != "" {
+		if held, err = r.HasActiveLegalHold(ctx, resourceType, "", caseID); err != nil {
+			return err
+		}
+		if held {
+			return fmt.Errorf("%w: case %s", domain.ErrUnderLegalHold, caseID)
+		}
+	}
+	return fmt.Errorf("%w: %s %s", domain.ErrResourceNotFound, resourceType, id)
+}
+
+// RestoreResource clears the deletion mark of a soft-deleted resource that has
+// not been purged yet
+func (r *PostgresRepository) RestoreResource(ctx context.Context, resourceType domain.ResourceType, id string) error {
+	t, err := lookupRetentionTable(resourceType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET deleted_at = NULL, deleted_by = NULL WHERE id = $1 AND deleted_at IS NOT NULL`, t.table)
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: no deleted %s %s", domain.ErrResourceNotFound, resourceType, id)
+	}
+	return nil
+}
+
+// GetResourceCaseID returns the case a resource belongs to, including
+// soft-deleted resources
+func (r *PostgresRepository) GetResourceCaseID(ctx context.Context, resourceType domain.ResourceType, id string) (string, error) {
+	t, err := lookupRetentionTable(resourceType)
+	if err != nil {
+		return "", err
+	}
+
+	var caseID sql.NullString
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, t.caseID, t.table)
+	err = r.db.QueryRowContext(ctx, query, id).Scan(&caseID)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("%w: %s %s", domain.ErrResourceNotFound, resourceType, id)
+	}
+	if err != nil {
+		return "", err
+	}
+	return caseID.String, nil
+}
+
+// CreateLegalHold stores a new legal hold
+func (r *PostgresRepository) CreateLegalHold(ctx context.Context, hold *domain.LegalHold) error {
+	query := `
+		INSERT INTO legal_holds (id, resource_type, resource_id, case_id, reason, placed_by, placed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		hold.ID,
+		string(hold.ResourceType),
+		hold.ResourceID,
+		hold.CaseID,
+		hold.Reason,
+		hold.PlacedBy,
+		hold.PlacedAt.Unix(),
+	)
+	return err
+}
+
+// ReleaseLegalHold releases an active legal hold
+func (r *PostgresRepository) ReleaseLegalHold(ctx context.Context, id, releasedBy string) (*domain.LegalHold, error) {
+	query := `
+		UPDATE legal_holds SET released_by = $1, released_at = $2
+		WHERE id = $3 AND released_at IS NULL
+		RETURNING id, resource_type, resource_id, case_id, reason, placed_by, placed_at, released_by, released_at
+	`
+
+	hold, err := scanLegalHold(r.db.QueryRowContext(ctx, query, releasedBy, time.Now().Unix(), id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", domain.ErrLegalHoldNotFound, id)
+	}
+	return hold, err
+}
+
+// ListLegalHolds returns legal holds, newest first
+func (r *PostgresRepository) ListLegalHolds(ctx context.Context, activeOnly bool) ([]*domain.LegalHold, error) {
+	query := `SELECT id, resource_type, resource_id, case_id, reason, placed_by, placed_at, released_by, released_at FROM legal_holds`
+	if activeOnly {
+		query += ` WHERE released_at IS NULL`
+	}
+	query += ` ORDER BY placed_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []*domain.LegalHold
+	for rows.Next() {
+		hold, err := scanLegalHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, rows.Err()
+}
+
+// HasActiveLegalHold reports whether a resource, or its case, is under an
+// active legal hold
+func (r *PostgresRepository) HasActiveLegalHold(ctx context.Context, resourceType domain.ResourceType, id, caseID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM legal_holds
+			WHERE released_at IS NULL
+			AND ((resource_type = $1 AND resource_id = $2 AND $2 <> '')
+				OR (case_id = $3 AND $3 <> ''))
+		)
+	`
+
+	var held bool
+	err := r.db.QueryRowContext(ctx, query, string(resourceType), id, caseID).Scan(&held)
+	return held, err
+}
+
+// ListPurgeCandidates returns resources soft-deleted before deletedBefore or,
+// if createdBefore is set, created before it. Resources under legal hold are
+// never returned.
+func (r *PostgresRepository) ListPurgeCandidates(ctx context.Context, resourceType domain.ResourceType, deletedBefore, createdBefore time.Time, limit int) ([]*domain.PurgeCandidate, error) {
+	t, err := lookupRetentionTable(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiry int64
+	if !createdBefore.IsZero() {
+		expiry = createdBefore.Unix()
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, %[2]s, %[3]s, %[4]s, deleted_at FROM %[1]s
+		WHERE ((deleted_at IS NOT NULL AND deleted_at < $2) OR ($3 > 0 AND %[4]s < $3))
+		AND NOT %[5]s
+		ORDER BY %[4]s ASC
+		LIMIT $4
+	`, t.table, t.caseID, t.hash, t.createdAt, activeHoldCondition(t))
+
+	rows, err := r.db.QueryContext(ctx, query, string(resourceType), deletedBefore.Unix(), expiry, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*domain.PurgeCandidate
+	for rows.Next() {
+		var candidate domain.PurgeCandidate
+		var caseID, hash sql.NullString
+		var createdAt int64
+		var deletedAt sql.NullInt64
+
+		if err := rows.Scan(&candidate.ResourceID, &caseID, &hash, &createdAt, &deletedAt); err != nil {
+			return nil, err
+		}
+
+		candidate.ResourceType = resourceType
+		candidate.CaseID = caseID.String
+		candidate.Hash = hash.String
+		candidate.CreatedAt = time.Unix(createdAt, 0).UTC()
+		if deletedAt.Valid {
+			deleted := time.Unix(deletedAt.Int64, 0).UTC()
+			candidate.DeletedAt = &deleted
+		}
+		candidates = append(candidates, &candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// PurgeResource permanently removes a resource that is not under legal hold.
+// The row stays locked while record runs, and the removal is only committed if
+// record succeeds.
+func (r *PostgresRepository) PurgeResource(ctx context.Context, resourceType domain.ResourceType, id string, record func(ctx context.Context) error) error {
+	t, err := lookupRetentionTable(resourceType)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Evidence custody records are removed by ON DELETE CASCADE
+	query := fmt.Sprintf(`DELETE FROM %[1]s WHERE id = $2 AND NOT %[2]s`, t.table, activeHoldCondition(t))
+	result, err := tx.ExecContext(ctx, query, string(resourceType), id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s %s is under legal hold or already purged", resourceType, id)
+	}
+
+	if err := record(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLegalHold(row rowScanner) (*domain.LegalHold, error) {
+	var hold domain.LegalHold
+	var resourceType, resourceID, caseID, releasedBy sql.NullString
+	var placedAt int64
+	var releasedAt sql.NullInt64
+
+	if err := row.Scan(&hold.ID, &resourceType, &resourceID, &caseID, &hold.Reason, &hold.PlacedBy, &placedAt, &releasedBy, &releasedAt); err != nil {
+		return nil, err
+	}
+
+	hold.ResourceType = domain.ResourceType(resourceType.String)
+	hold.ResourceID = resourceID.String
+	hold.CaseID = caseID.String
+	hold.PlacedAt = time.Unix(placedAt, 0).UTC()
+	hold.ReleasedBy = releasedBy.String
+	if releasedAt.Valid {
+		released := time.Unix(releasedAt.Int64, 0).UTC()
+		hold.ReleasedAt = &released
+	}
+
+	return &hold, nil
+}
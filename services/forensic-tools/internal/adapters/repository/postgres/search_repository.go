@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/lib/pq"
+)
+
+// searchConfig is the text search configuration used by the search vector
+// triggers; queries must use the same one
+const searchConfig = "english"
+
+// headlineOptions marks matched terms in ts_headline fragments
+const headlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" ... "`
+
+// maxFallbackTerms bounds the number of terms matched by the substring fallback
+const maxFallbackTerms = 8
+
+// searchSource describes a searchable table
+type searchSource struct {
+	scope domain.SearchScope
+	// from is the FROM clause; the searched table is aliased s
+	from      string
+	id        string
+	evidence  string
+	caseID    string
+	title     string
+	timestamp string
+	// fields are the highlighted columns, by response field name
+	fields    []searchField
+	condition string
+}
+
+type searchField struct {
+	name string
+	expr string
+}
+
+var evidenceSearch = searchSource{
+	scope:     domain.SearchScopeEvidence,
+	from:      "evidence s",
+	id:        "s.id",
+	evidence:  "s.id",
+	caseID:    "COALESCE(s.case_id, '')",
+	title:     "COALESCE(s.title, '')",
+	timestamp: "COALESCE(s.collected_at, 0)",
+	fields: []searchField{
+		{name: "title", expr: "COALESCE(s.title, '')"},
+		{name: "description", expr: "COALESCE(s.description, '')"},
+		{name: "content", expr: "COALESCE(s.content::text, '')"},
+	},
+	condition: "s.deleted_at IS NULL",
+}
+
+var custodySearch = searchSource{
+	scope:     domain.SearchScopeCustody,
+	from:      "chain_of_custody s JOIN evidence e ON e.id = s.evidence_id",
+	id:        "s.id::text",
+	evidence:  "s.evidence_id",
+	caseID:    "COALESCE(e.case_id, '')",
+	title:     "s.action",
+	timestamp: "s.timestamp",
+	fields: []searchField{
+		{name: "handler", expr: "s.handler"},
+		{name: "action", expr: "s.action"},
+		{name: "location", expr: "COALESCE(s.location, '')"},
+		{name: "notes", expr: "COALESCE(s.notes, '')"},
+	},
+	condition: "e.deleted_at IS NULL",
+}
+
+// SearchEvidence ranks evidence by how well its title, description and
+// extracted content match the query
+func (r *PostgresRepository) SearchEvidence(ctx context.Context, query *domain.SearchQuery, limit, offset int) ([]*domain.SearchHit, bool, error) {
+	return r.search(ctx, evidenceSearch, query, limit, offset)
+}
+
+// SearchCustodyRecords ranks chain of custody records by how well their
+// handler, action, location and notes match the query
+func (r *PostgresRepository) SearchCustodyRecords(ctx context.Context, query *domain.SearchQuery, limit, offset int) ([]*domain.SearchHit, bool, error) {
+	return r.search(ctx, custodySearch, query, limit, offset)
+}
+
+// search runs a ranked full-text search, falling back to substring matching
+// on databases without the search_vector column or websearch_to_tsquery
+func (r *PostgresRepository) search(ctx context.Context, src searchSource, query *domain.SearchQuery, limit, offset int) ([]*domain.SearchHit, bool, error) {
+	hits, err := r.fullTextSearch(ctx, src, query, limit, offset)
+	if err == nil {
+		return hits, true, nil
+	}
+
+	var pqErr *pq.Error
+	// 42703 undefined_column, 42883 undefined_function
+	if !errors.As(err, &pqErr) || (pqErr.Code != "42703" && pqErr.Code != "42883") {
+		return nil, false, err
+	}
+
+	hits, err = r.substringSearch(ctx, src, query, limit, offset)
+	return hits, false, err
+}
+
+func (r *PostgresRepository) fullTextSearch(ctx context.Context, src searchSource, query *domain.SearchQuery, limit, offset int) ([]*domain.SearchHit, error) {
+	headlines := make([]string, len(src.fields))
+	for i, field := range src.fields {
+		headlines[i] = fmt.Sprintf("ts_headline('%s', %s, q, '%s')", searchConfig, field.expr, headlineOptions)
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT %s, %s, %s, %s, %s, ts_rank_cd(s.search_vector, q) AS rank, %s
+		FROM %s, websearch_to_tsquery('%s', $1) q
+		WHERE s.search_vector @@ q AND %s AND ($2 = '' OR %s = $2)
+		ORDER BY rank DESC, %s DESC
+		LIMIT $3 OFFSET $4
+	`, src.id, src.evidence, src.caseID, src.title, src.timestamp, strings.Join(headlines, ", "),
+		src.from, searchConfig, src.condition, src.caseID, src.timestamp)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, query.Query, query.CaseID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSearchHits(rows, src, func(hit *domain.SearchHit, values []string) {
+		for i, field := range src.fields {
+			// ts_headline returns the start of fields without a match too
+			if strings.Contains(values[i], "<mark>") {
+				hit.Highlights[field.name] = values[i]
+			}
+		}
+	})
+}
+
+func (r *PostgresRepository) substringSearch(ctx context.Context, src searchSource, query *domain.SearchQuery, limit, offset int) ([]*domain.SearchHit, error) {
+	terms := searchTerms(query.Query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	exprs := make([]string, len(src.fields))
+	for i, field := range src.fields {
+		exprs[i] = field.expr
+	}
+	document := strings.Join(exprs, " || ' ' || ")
+
+	args := []interface{}{query.CaseID, limit, offset}
+	conditions := make([]string, len(terms))
+	for i, term := range terms {
+		args = append(args, "%"+escapeLike(term)+"%")
+		conditions[i] = fmt.Sprintf(`(%s) ILIKE $%d ESCAPE '\'`, document, len(args))
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT %s, %s, %s, %s, %s, 0, %s
+		FROM %s
+		WHERE %s AND %s AND ($1 = '' OR %s = $1)
+		ORDER BY %s DESC
+		LIMIT $2 OFFSET $3
+	`, src.id, src.evidence, src.caseID, src.title, src.timestamp, strings.Join(exprs, ", "),
+		src.from, strings.Join(conditions, " AND "), src.condition, src.caseID, src.timestamp)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSearchHits(rows, src, func(hit *domain.SearchHit, values []string) {
+		for i, field := range src.fields {
+			if fragment, ok := highlightTerms(values[i], terms); ok {
+				hit.Highlights[field.name] = fragment
+			}
+		}
+	})
+}
+
+// scanSearchHits scans rows of id, evidence id, case id, title, timestamp,
+// rank and one value per highlighted field
+func scanSearchHits(rows *sql.Rows, src searchSource, highlight func(hit *domain.SearchHit, values []string)) ([]*domain.SearchHit, error) {
+	var hits []*domain.SearchHit
+	for rows.Next() {
+		hit := &domain.SearchHit{Scope: src.scope, Highlights: make(map[string]string)}
+		values := make([]string, len(src.fields))
+
+		dest := []interface{}{&hit.ID, &hit.EvidenceID, &hit.CaseID, &hit.Title, &hit.Timestamp, &hit.Rank}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		highlight(hit, values)
+		hits = append(hits, hit)
+	}
+
+	return hits, rows.Err()
+}
+
+// searchTerms splits a web search style query into the plain terms matched by
+// the substring fallback, dropping operators and excluded terms
+func searchTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if strings.HasPrefix(word, "-") || strings.EqualFold(word, "or") {
+			continue
+		}
+		terms = append(terms, word)
+		if len(terms) == maxFallbackTerms {
+			break
+		}
+	}
+	return terms
+}
+
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
+
+// highlightTerms returns a fragment of text around the first matching term,
+// with every matching term in it wrapped in <mark> tags
+func highlightTerms(text string, terms []string) (string, bool) {
+	// ASCII lowering keeps byte offsets in lower valid for text
+	lower := lowerASCII(text)
+	lowerTerms := make([]string, len(terms))
+	for i, term := range terms {
+		lowerTerms[i] = lowerASCII(term)
+	}
+
+	first := -1
+	for _, term := range lowerTerms {
+		if i := strings.Index(lower, term); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	// Cut a window of about 200 bytes around the match on word boundaries
+	start, end := first-80, first+120
+	if start < 0 {
+		start = 0
+	} else if i := strings.IndexByte(text[start:first], ' '); i >= 0 {
+		start += i + 1
+	}
+	if end >= len(text) {
+		end = len(text)
+	} else if i := strings.LastIndexByte(text[first:end], ' '); i > 0 {
+		end = first + i
+	}
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	fragment := text[start:end]
+	lowerFragment := lower[start:end]
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("... ")
+	}
+	for i := 0; i < len(fragment); {
+		matched := 0
+		for _, term := range lowerTerms {
+			if strings.HasPrefix(lowerFragment[i:], term) && len(term) > matched {
+				matched = len(term)
+			}
+		}
+		if matched == 0 {
+			b.WriteByte(fragment[i])
+			i++
+			continue
+		}
+		b.WriteString("<mark>")
+		b.WriteString(fragment[i : i+matched])
+		b.WriteString("</mark>")
+		i += matched
+	}
+	if end < len(text) {
+		b.WriteString(" ...")
+	}
+
+	return b.String(), true
+}
+
+func lowerASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SystemHandler is the handler name of custody records made by the service itself
+const SystemHandler = "system"
+
+var (
+	// ErrCustodySignatureRequired is returned when an unsigned custody record is
+	// added while signatures are required
+	ErrCustodySignatureRequired = errors.New("custody record signature required")
+	// ErrInvalidCustodySignature is returned when a custody record signature or
+	// its certificate fails verification
+	ErrInvalidCustodySignature = errors.New("invalid custody record signature")
+)
+
+// ChainOfCustody is a single record in the chain of custody of a piece of
+// evidence. Signed records carry a base64 signature over SigningPayload and
+// the PEM certificate of the signer, issued by the platform CA.
+type ChainOfCustody struct {
+	Timestamp         int64  `json:"timestamp"`
+	Handler           string `json:"handler"`
+	Action            string `json:"action"`
+	Location          string `json:"location"`
+	IntegrityHash     string `json:"integrity_hash"`
+	Notes             string `json:"notes"`
+	Signature         string `json:"signature,omitempty"`
+	SignerCertificate string `json:"signer_certificate,omitempty"`
+}
+
+// IsSigned reports whether the record carries a signature
+func (c *ChainOfCustody) IsSigned() bool {
+	return c.Signature != ""
+}
+
+// SigningPayload returns the bytes a handler signs for this record. It covers
+// the previous record's signature, so removing or reordering records breaks
+// every signature after the change.
+func (c *ChainOfCustody) SigningPayload(evidenceID, previousSignature string) []byte {
+	payload, _ := json.Marshal(struct {
+		EvidenceID        string `json:"evidence_id"`
+		Timestamp         int64  `json:"timestamp"`
+		Handler           string `json:"handler"`
+		Action            string `json:"action"`
+		Location          string `json:"location"`
+		IntegrityHash     string `json:"integrity_hash"`
+		Notes             string `json:"notes"`
+		PreviousSignature string `json:"previous_signature"`
+	}{
+		EvidenceID:        evidenceID,
+		Timestamp:         c.Timestamp,
+		Handler:           c.Handler,
+		Action:            c.Action,
+		Location:          c.Location,
+		IntegrityHash:     c.IntegrityHash,
+		Notes:             c.Notes,
+		PreviousSignature: previousSignature,
+	})
+	return payload
+}
+
+// CustodyRecordVerification is the verification result of one custody record
+type CustodyRecordVerification struct {
+	Index     int    `json:"index"`
+	Timestamp int64  `json:"timestamp"`
+	Handler   string `json:"handler"`
+	Action    string `json:"action"`
+	Signed    bool   `json:"signed"`
+	Signer    string `json:"signer,omitempty"`
+	Valid     bool   `json:"valid"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CustodyChainReport is the signature verification report of a whole chain of
+// custody. The chain is valid only if every record is signed and verifies.
+type CustodyChainReport struct {
+	EvidenceID   string                       `json:"evidence_id"`
+	Valid        bool                         `json:"valid"`
+	RecordCount  int                          `json:"record_count"`
+	SignedCount  int                          `json:"signed_count"`
+	InvalidCount int                          `json:"invalid_count"`
+	Records      []*CustodyRecordVerification `json:"records"`
+	VerifiedAt   time.Time                    `json:"verified_at"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // CaseStatus represents the status of a forensic case
@@ -117,65 +118,65 @@ type CustodyEntry struct {
 
 // GraphNode represents a node in a transaction graph
 type GraphNode struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	Address      string    `json:"address" db:"address"`
-	AddressType  string    `json:"address_type" db:"address_type"`
-	EntityLabel  string    `json:"entity_label,omitempty" db:"entity_label"`
-	EntityType   string    `json:"entity_type,omitempty" db:"entity_type"`
-	Balance      float64   `json:"balance" db:"balance"`
-	FirstSeen    time.Time `json:"first_seen" db:"first_seen"`
-	LastSeen     time.Time `json:"last_seen" db:"last_seen"`
-	Labels       []string  `json:"labels,omitempty" db:"labels"`
-	ClusterID    *uuid.UUID `json:"cluster_id,omitempty" db:"cluster_id"`
-	RiskScore    float64   `json:"risk_score" db:"risk_score"`
-	KnownEntity  bool      `json:"known_entity" db:"known_entity"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ID          uuid.UUID       `json:"id" db:"id"`
+	Address     string          `json:"address" db:"address"`
+	AddressType string          `json:"address_type" db:"address_type"`
+	EntityLabel string          `json:"entity_label,omitempty" db:"entity_label"`
+	EntityType  string          `json:"entity_type,omitempty" db:"entity_type"`
+	Balance     decimal.Decimal `json:"balance" db:"balance"`
+	FirstSeen   time.Time       `json:"first_seen" db:"first_seen"`
+	LastSeen    time.Time       `json:"last_seen" db:"last_seen"`
+	Labels      []string        `json:"labels,omitempty" db:"labels"`
+	ClusterID   *uuid.UUID      `json:"cluster_id,omitempty" db:"cluster_id"`
+	RiskScore   float64         `json:"risk_score" db:"risk_score"`
+	KnownEntity bool            `json:"known_entity" db:"known_entity"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
 }
 
 // GraphEdge represents an edge in a transaction graph
 type GraphEdge struct {
-	ID            uuid.UUID `json:"id" db:"id"`
-	TransactionID uuid.UUID `json:"transaction_id" db:"transaction_id"`
-	SourceNode    string    `json:"source_node" db:"source_node"`
-	TargetNode    string    `json:"target_node" db:"target_node"`
-	Value         float64   `json:"value" db:"value"`
-	AssetType     string    `json:"asset_type" db:"asset_type"`
-	Timestamp     time.Time `json:"timestamp" db:"timestamp"`
-	BlockNumber   uint64    `json:"block_number" db:"block_number"`
-	TxHash        string    `json:"tx_hash" db:"tx_hash"`
-	GasFee        float64   `json:"gas_fee" db:"gas_fee"`
-	Direction     string    `json:"direction" db:"direction"`
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	ID            uuid.UUID       `json:"id" db:"id"`
+	TransactionID uuid.UUID       `json:"transaction_id" db:"transaction_id"`
+	SourceNode    string          `json:"source_node" db:"source_node"`
+	TargetNode    string          `json:"target_node" db:"target_node"`
+	Value         decimal.Decimal `json:"value" db:"value"`
+	AssetType     string          `json:"asset_type" db:"asset_type"`
+	Timestamp     time.Time       `json:"timestamp" db:"timestamp"`
+	BlockNumber   uint64          `json:"block_number" db:"block_number"`
+	TxHash        string          `json:"tx_hash" db:"tx_hash"`
+	GasFee        decimal.Decimal `json:"gas_fee" db:"gas_fee"`
+	Direction     string          `json:"direction" db:"direction"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
 }
 
 // TransactionGraph represents a complete transaction graph
 type TransactionGraph struct {
-	ID          uuid.UUID   `json:"id" db:"id"`
-	CaseID      uuid.UUID   `json:"case_id,omitempty" db:"case_id"`
-	RootAddress string      `json:"root_address" db:"root_address"`
-	Depth       int         `json:"depth" db:"depth"`
-	Nodes       []GraphNode `json:"nodes" db:"nodes"`
-	Edges       []GraphEdge `json:"edges" db:"edges"`
-	PathCount   int         `json:"path_count" db:"path_count"`
-	NodeCount   int         `json:"node_count" db:"node_count"`
-	EdgeCount   int         `json:"edge_count" db:"edge_count"`
-	TotalVolume float64     `json:"total_volume" db:"total_volume"`
-	GeneratedAt time.Time   `json:"generated_at" db:"generated_at"`
+	ID          uuid.UUID       `json:"id" db:"id"`
+	CaseID      uuid.UUID       `json:"case_id,omitempty" db:"case_id"`
+	RootAddress string          `json:"root_address" db:"root_address"`
+	Depth       int             `json:"depth" db:"depth"`
+	Nodes       []GraphNode     `json:"nodes" db:"nodes"`
+	Edges       []GraphEdge     `json:"edges" db:"edges"`
+	PathCount   int             `json:"path_count" db:"path_count"`
+	NodeCount   int             `json:"node_count" db:"node_count"`
+	EdgeCount   int             `json:"edge_count" db:"edge_count"`
+	TotalVolume decimal.Decimal `json:"total_volume" db:"total_volume"`
+	GeneratedAt time.Time       `json:"generated_at" db:"generated_at"`
 }
 
 // WalletCluster represents a cluster of related wallets
 type WalletCluster struct {
-	ID            uuid.UUID   `json:"id" db:"id"`
-	ClusterType   string      `json:"cluster_type" db:"cluster_type"`
-	WalletCount   int         `json:"wallet_count" db:"wallet_count"`
-	WalletIDs     []string    `json:"wallet_addresses" db:"wallet_addresses"`
-	CommonOwner   bool        `json:"common_owner" db:"common_owner"`
-	RiskIndicators []string   `json:"risk_indicators" db:"risk_indicators"`
-	TotalVolume   float64     `json:"total_volume" db:"total_volume"`
-	FirstActivity time.Time   `json:"first_activity" db:"first_activity"`
-	LastActivity  time.Time   `json:"last_activity" db:"last_activity"`
-	Labels        []string    `json:"labels,omitempty" db:"labels"`
-	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+	ID             uuid.UUID       `json:"id" db:"id"`
+	ClusterType    string          `json:"cluster_type" db:"cluster_type"`
+	WalletCount    int             `json:"wallet_count" db:"wallet_count"`
+	WalletIDs      []string        `json:"wallet_addresses" db:"wallet_addresses"`
+	CommonOwner    bool            `json:"common_owner" db:"common_owner"`
+	RiskIndicators []string        `json:"risk_indicators" db:"risk_indicators"`
+	TotalVolume    decimal.Decimal `json:"total_volume" db:"total_volume"`
+	FirstActivity  time.Time       `json:"first_activity" db:"first_activity"`
+	LastActivity   time.Time       `json:"last_activity" db:"last_activity"`
+	Labels         []string        `json:"labels,omitempty" db:"labels"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
 }
 
 // ReportType represents the type of forensic report
@@ -227,13 +228,13 @@ type ForensicReport struct {
 
 // GraphTraceRequest represents a request to trace a transaction graph
 type GraphTraceRequest struct {
-	Address       string `json:"address" db:"address"`
-	StartTxHash   string `json:"start_tx_hash,omitempty"`
-	MaxDepth      int    `json:"max_depth"`
-	MaxNodes      int    `json:"max_nodes"`
-	IncludeLabels bool   `json:"include_labels"`
-	FilterByValue bool   `json:"filter_by_value"`
-	MinValue      float64 `json:"min_value"`
+	Address       string          `json:"address" db:"address"`
+	StartTxHash   string          `json:"start_tx_hash,omitempty"`
+	MaxDepth      int             `json:"max_depth"`
+	MaxNodes      int             `json:"max_nodes"`
+	IncludeLabels bool            `json:"include_labels"`
+	FilterByValue bool            `json:"filter_by_value"`
+	MinValue      decimal.Decimal `json:"min_value"`
 }
 
 // GraphTraceResult represents the result of a graph trace
@@ -249,15 +250,15 @@ type GraphTraceResult struct {
 
 // GraphPath represents a path through the transaction graph
 type GraphPath struct {
-	PathID        uuid.UUID   `json:"path_id"`
-	Path          []string    `json:"path"`
-	PathLength    int         `json:"path_length"`
-	TotalValue    float64     `json:"total_value"`
-	AssetType     string      `json:"asset_type"`
-	StartTime     time.Time   `json:"start_time"`
-	EndTime       time.Time   `json:"end_time"`
-	ContainsKnown bool        `json:"contains_known_entity"`
-	KnownEntities []string    `json:"known_entities"`
+	PathID        uuid.UUID       `json:"path_id"`
+	Path          []string        `json:"path"`
+	PathLength    int             `json:"path_length"`
+	TotalValue    decimal.Decimal `json:"total_value"`
+	AssetType     string          `json:"asset_type"`
+	StartTime     time.Time       `json:"start_time"`
+	EndTime       time.Time       `json:"end_time"`
+	ContainsKnown bool            `json:"contains_known_entity"`
+	KnownEntities []string        `json:"known_entities"`
 }
 
 // TimelineEvent represents an event in a forensic timeline
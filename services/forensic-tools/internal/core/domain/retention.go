@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ResourceType identifies a kind of record governed by retention
+type ResourceType string
+
+const (
+	ResourceTypeEvidence ResourceType = "EVIDENCE"
+	ResourceTypeReport   ResourceType = "REPORT"
+)
+
+// PurgeReason records why a resource was purged
+type PurgeReason string
+
+const (
+	// PurgeReasonDeleted means the resource was soft-deleted and its grace period ended
+	PurgeReasonDeleted PurgeReason = "DELETED"
+	// PurgeReasonExpired means the resource outlived its retention period
+	PurgeReasonExpired PurgeReason = "EXPIRED"
+)
+
+var (
+	// ErrUnderLegalHold is returned when deleting or purging a resource under legal hold
+	ErrUnderLegalHold = errors.New("resource is under legal hold")
+	// ErrResourceNotFound is returned when a resource does not exist or is already deleted
+	ErrResourceNotFound = errors.New("resource not found")
+	// ErrUnknownResourceType is returned for resource types without a retention class
+	ErrUnknownResourceType = errors.New("unknown resource type")
+	// ErrLegalHoldNotFound is returned when a legal hold does not exist or is already released
+	ErrLegalHoldNotFound = errors.New("legal hold not found")
+	// ErrInvalidLegalHold is returned when a legal hold is missing required fields
+	ErrInvalidLegalHold = errors.New("invalid legal hold")
+)
+
+// RetentionClass defines how long a resource type is kept
+type RetentionClass struct {
+	Name         string       `json:"name"`
+	ResourceType ResourceType `json:"resource_type"`
+	// RetainDays is how long a resource is kept after it is created; 0 keeps it
+	// until it is deleted
+	RetainDays int `json:"retain_days"`
+	// DeleteGraceDays is how long a soft-deleted resource can be restored before
+	// it is purged
+	DeleteGraceDays int `json:"delete_grace_days"`
+}
+
+// ExpiresBefore returns the creation time before which resources have outlived
+// the class, or the zero time if they never expire
+func (c RetentionClass) ExpiresBefore(now time.Time) time.Time {
+	if c.RetainDays <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -c.RetainDays)
+}
+
+// DeletedBefore returns the deletion time before which soft-deleted resources
+// are purged
+func (c RetentionClass) DeletedBefore(now time.Time) time.Time {
+	return now.AddDate(0, 0, -c.DeleteGraceDays)
+}
+
+// LegalHold stops a resource, or every resource of a case, from being deleted
+// or purged until the hold is released
+type LegalHold struct {
+	ID           string       `json:"id"`
+	ResourceType ResourceType `json:"resource_type,omitempty"`
+	ResourceID   string       `json:"resource_id,omitempty"`
+	CaseID       string       `json:"case_id,omitempty"`
+	Reason       string       `json:"reason"`
+	PlacedBy     string       `json:"placed_by"`
+	PlacedAt     time.Time    `json:"placed_at"`
+	ReleasedBy   string       `json:"released_by,omitempty"`
+	ReleasedAt   *time.Time   `json:"released_at,omitempty"`
+}
+
+// IsActive reports whether the hold has not been released
+func (h *LegalHold) IsActive() bool {
+	return h.ReleasedAt == nil
+}
+
+// PurgeCandidate is a resource due to be purged
+type PurgeCandidate struct {
+	ResourceType ResourceType `json:"resource_type"`
+	ResourceID   string       `json:"resource_id"`
+	CaseID       string       `json:"case_id,omitempty"`
+	Hash         string       `json:"hash,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	DeletedAt    *time.Time   `json:"deleted_at,omitempty"`
+}
+
+// Tombstone is the permanent record of a purged resource, written to the
+// audit log's WORM chain before the resource is removed
+type Tombstone struct {
+	ResourceType   ResourceType `json:"resource_type"`
+	ResourceID     string       `json:"resource_id"`
+	CaseID         string       `json:"case_id,omitempty"`
+	Hash           string       `json:"hash,omitempty"`
+	RetentionClass string       `json:"retention_class"`
+	Reason         PurgeReason  `json:"reason"`
+	CreatedAt      time.Time    `json:"created_at"`
+	DeletedAt      *time.Time   `json:"deleted_at,omitempty"`
+	PurgedAt       time.Time    `json:"purged_at"`
+}
+
+// PurgeReport summarises a purge run
+type PurgeReport struct {
+	StartedAt  time.Time            `json:"started_at"`
+	FinishedAt time.Time            `json:"finished_at"`
+	Purged     map[ResourceType]int `json:"purged"`
+	Errors     []string             `json:"errors,omitempty"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "errors"
+
+var (
+	ErrEmptySearchQuery   = errors.New("search query is required")
+	ErrUnknownSearchScope = errors.New("unknown search scope")
+)
+
+// SearchScope selects which records a search covers
+type SearchScope string
+
+const (
+	SearchScopeAll      SearchScope = "all"
+	SearchScopeEvidence SearchScope = "evidence"
+	// SearchScopeCustody searches the chain of custody audit trail
+	SearchScopeCustody SearchScope = "custody"
+)
+
+// SearchQuery describes a full-text search. Query accepts web search syntax:
+// quoted phrases, "or" and a leading "-" to exclude a term.
+type SearchQuery struct {
+	Query  string      `json:"query"`
+	Scope  SearchScope `json:"scope"`
+	CaseID string      `json:"case_id,omitempty"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// SearchHit is a single matching evidence item or custody record
+type SearchHit struct {
+	Scope      SearchScope `json:"scope"`
+	ID         string      `json:"id"`
+	EvidenceID string      `json:"evidence_id"`
+	CaseID     string      `json:"case_id,omitempty"`
+	Title      string      `json:"title"`
+	Rank       float64     `json:"rank"`
+	// Highlights holds a fragment of each matching field with the matched
+	// terms wrapped in <mark> tags
+	Highlights map[string]string `json:"highlights,omitempty"`
+	Timestamp  int64             `json:"timestamp"`
+}
+
+// SearchResults is a page of search hits ordered by rank
+type SearchResults struct {
+	Query SearchQuery  `json:"query"`
+	Hits  []*SearchHit `json:"hits"`
+	// FullText is false when the search vector columns are missing and the
+	// search fell back to unranked substring matching
+	FullText bool `json:"full_text"`
+}
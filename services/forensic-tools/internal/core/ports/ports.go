@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/csic-platform/internal/core/domain"
 )
@@ -67,6 +68,54 @@ type ClusterRepository interface {
 	RemoveClusterMembers(ctx context.Context, clusterID string, nodeIDs []string) error
 }
 
+// RetentionRepository defines the interface for soft deletion, legal holds and
+// purging of retained resources
+type RetentionRepository interface {
+	// Soft deletion
+	SoftDeleteResource(ctx context.Context, resourceType domain.ResourceType, id, deletedBy string) error
+	RestoreResource(ctx context.Context, resourceType domain.ResourceType, id string) error
+	GetResourceCaseID(ctx context.Context, resourceType domain.ResourceType, id string) (string, error)
+
+	// Legal holds
+	CreateLegalHold(ctx context.Context, hold *domain.LegalHold) error
+	ReleaseLegalHold(ctx context.Context, id, releasedBy string) (*domain.LegalHold, error)
+	ListLegalHolds(ctx context.Context, activeOnly bool) ([]*domain.LegalHold, error)
+	HasActiveLegalHold(ctx context.Context, resourceType domain.ResourceType, id, caseID string) (bool, error)
+
+	// Purging. Candidates exclude resources under legal hold. PurgeResource
+	// calls record before committing the removal and rolls back if it fails.
+	ListPurgeCandidates(ctx context.Context, resourceType domain.ResourceType, deletedBefore, createdBefore time.Time, limit int) ([]*domain.PurgeCandidate, error)
+	PurgeResource(ctx context.Context, resourceType domain.ResourceType, id string, record func(ctx context.Context) error) error
+}
+
+// SearchRepository defines the interface for full-text search over evidence
+// and the chain of custody. fullText is false when the search fell back to
+// substring matching because the search vector columns are missing.
+type SearchRepository interface {
+	SearchEvidence(ctx context.Context, query *domain.SearchQuery, limit, offset int) (hits []*domain.SearchHit, fullText bool, err error)
+	SearchCustodyRecords(ctx context.Context, query *domain.SearchQuery, limit, offset int) (hits []*domain.SearchHit, fullText bool, err error)
+}
+
+// CustodySigner signs custody records made by the service itself
+type CustodySigner interface {
+	// Identity returns the common name of the signing certificate
+	Identity() string
+	// Sign returns a base64 signature over payload and the PEM signer certificate
+	Sign(payload []byte) (signature string, certificatePEM string, err error)
+}
+
+// CustodyVerifier verifies custody record signatures against certificates
+// issued by the platform CA
+type CustodyVerifier interface {
+	// Verify returns the common name of the signer if signature is valid
+	Verify(payload []byte, signature, certificatePEM string, signedAt time.Time) (signer string, err error)
+}
+
+// TombstoneWriter records purged resources on the WORM audit chain
+type TombstoneWriter interface {
+	WriteTombstone(ctx context.Context, tombstone *domain.Tombstone) error
+}
+
 // GraphService defines the input port for graph analysis operations
 type GraphService interface {
 	// Transaction graph construction
@@ -97,7 +146,9 @@ type EvidenceService interface {
 
 	// Chain of custody
 	RecordCustody(ctx context.Context, evidenceID string, handler string, action string) error
+	AddCustodyRecord(ctx context.Context, evidenceID string, record *domain.ChainOfCustody) error
 	GetEvidenceTimeline(ctx context.Context, evidenceID string) ([]*domain.ChainOfCustody, error)
+	VerifyCustodyChain(ctx context.Context, evidenceID string) (*domain.CustodyChainReport, error)
 
 	// Evidence export
 	ExportEvidence(ctx context.Context, evidenceID string, format string) ([]byte, error)
@@ -18,13 +18,22 @@ type EvidenceManagementService struct {
 	repo       ports.EvidenceRepository
 	reportRepo ports.ReportRepository
 	mu         sync.RWMutex
+
+	// Custody signing is disabled when verifier is nil
+	signer            ports.CustodySigner
+	verifier          ports.CustodyVerifier
+	requireSignatures bool
 }
 
-// NewEvidenceManagementService creates a new evidence management service instance
-func NewEvidenceManagementService(repo ports.EvidenceRepository, reportRepo ports.ReportRepository) *EvidenceManagementService {
+// NewEvidenceManagementService creates a new evidence management service instance.
+// signer and verifier may be nil to disable custody record signatures.
+func NewEvidenceManagementService(repo ports.EvidenceRepository, reportRepo ports.ReportRepository, signer ports.CustodySigner, verifier ports.CustodyVerifier, requireSignatures bool) *EvidenceManagementService {
 	return &EvidenceManagementService{
-		repo:       repo,
-		reportRepo: reportRepo,
+		repo:              repo,
+		reportRepo:        reportRepo,
+		signer:            signer,
+		verifier:          verifier,
+		requireSignatures: requireSignatures,
 	}
 }
 
@@ -71,7 +80,7 @@ func (s *EvidenceManagementService) CollectTransactionEvidence(ctx context.Conte
 		Location:    "automated",
 		IntegrityHash: hash,
 	}
-	if err := s.repo.AppendCustodyRecord(ctx, evidence.ID, custody); err != nil {
+	if err := s.appendCustody(ctx, evidence.ID, custody); err != nil {
 		return nil, fmt.Errorf("failed to record chain of custody: %w", err)
 	}
 
@@ -121,7 +130,7 @@ func (s *EvidenceManagementService) CollectWalletEvidence(ctx context.Context, a
 		Location:      "automated",
 		IntegrityHash: hash,
 	}
-	if err := s.repo.AppendCustodyRecord(ctx, evidence.ID, custody); err != nil {
+	if err := s.appendCustody(ctx, evidence.ID, custody); err != nil {
 		return nil, fmt.Errorf("failed to record chain of custody: %w", err)
 	}
 
@@ -171,7 +180,7 @@ func (s *EvidenceManagementService) CollectClusterEvidence(ctx context.Context,
 		Location:      "automated",
 		IntegrityHash: hash,
 	}
-	if err := s.repo.AppendCustodyRecord(ctx, evidence.ID, custody); err != nil {
+	if err := s.appendCustody(ctx, evidence.ID, custody); err != nil {
 		return nil, fmt.Errorf("failed to record chain of custody: %w", err)
 	}
 
@@ -240,18 +249,174 @@ func (s *EvidenceManagementService) RecordCustody(ctx context.Context, evidenceI
 	}
 
 	// Append to chain of custody
-	if err := s.repo.AppendCustodyRecord(ctx, evidenceID, custody); err != nil {
+	if err := s.appendCustody(ctx, evidenceID, custody); err != nil {
+		return fmt.Errorf("failed to record custody: %w", err)
+	}
+
+	return nil
+}
+
+// AddCustodyRecord adds a custody record made by a handler. When custody
+// signing is enabled the record must be signed with the handler's certificate
+// over record.SigningPayload(evidenceID, <signature of the latest record>).
+func (s *EvidenceManagementService) AddCustodyRecord(ctx context.Context, evidenceID string, record *domain.ChainOfCustody) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record.Handler == "" || record.Action == "" {
+		return fmt.Errorf("handler and action are required")
+	}
+	if record.Handler == domain.SystemHandler {
+		return fmt.Errorf("handler %q is reserved for the service", domain.SystemHandler)
+	}
+
+	evidence, err := s.repo.GetEvidence(ctx, evidenceID)
+	if err != nil {
+		return fmt.Errorf("evidence not found: %w", err)
+	}
+
+	if !record.IsSigned() {
+		if record.Timestamp == 0 {
+			record.Timestamp = time.Now().Unix()
+		}
+		if record.IntegrityHash == "" {
+			record.IntegrityHash = evidence.Hash
+		}
+	}
+	if record.Timestamp > time.Now().Add(5*time.Minute).Unix() {
+		return fmt.Errorf("custody record timestamp is in the future")
+	}
+
+	if err := s.appendCustody(ctx, evidenceID, record); err != nil {
 		return fmt.Errorf("failed to record custody: %w", err)
 	}
 
 	return nil
 }
 
+// VerifyCustodyChain verifies the signature of every record in the chain of
+// custody of evidence, including the links between records
+func (s *EvidenceManagementService) VerifyCustodyChain(ctx context.Context, evidenceID string) (*domain.CustodyChainReport, error) {
+	if s.verifier == nil {
+		return nil, fmt.Errorf("custody signing is not enabled")
+	}
+
+	history, err := s.repo.GetCustodyHistory(ctx, evidenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custody history: %w", err)
+	}
+
+	report := &domain.CustodyChainReport{
+		EvidenceID:  evidenceID,
+		Valid:       true,
+		RecordCount: len(history),
+		Records:     make([]*domain.CustodyRecordVerification, 0, len(history)),
+		VerifiedAt:  time.Now().UTC(),
+	}
+
+	previousSignature := ""
+	for i, record := range history {
+		result := &domain.CustodyRecordVerification{
+			Index:     i,
+			Timestamp: record.Timestamp,
+			Handler:   record.Handler,
+			Action:    record.Action,
+			Signed:    record.IsSigned(),
+		}
+
+		if record.IsSigned() {
+			report.SignedCount++
+			signer, err := s.verifyCustodyRecord(record, record.SigningPayload(evidenceID, previousSignature))
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Signer = signer
+				result.Valid = true
+			}
+		} else {
+			result.Error = domain.ErrCustodySignatureRequired.Error()
+		}
+
+		if !result.Valid {
+			report.InvalidCount++
+			report.Valid = false
+		}
+		report.Records = append(report.Records, result)
+		previousSignature = record.Signature
+	}
+
+	return report, nil
+}
+
 // GetEvidenceTimeline retrieves the complete chain of custody timeline for evidence
 func (s *EvidenceManagementService) GetEvidenceTimeline(ctx context.Context, evidenceID string) ([]*domain.ChainOfCustody, error) {
 	return s.repo.GetCustodyHistory(ctx, evidenceID)
 }
 
+// appendCustody stores a custody record, chained to the latest record of the
+// evidence. When signing is enabled, signed records are verified and the
+// service signs its own records. The caller must hold s.mu.
+func (s *EvidenceManagementService) appendCustody(ctx context.Context, evidenceID string, record *domain.ChainOfCustody) error {
+	if s.verifier == nil {
+		if record.IsSigned() {
+			return fmt.Errorf("custody signing is not enabled")
+		}
+		return s.repo.AppendCustodyRecord(ctx, evidenceID, record)
+	}
+
+	history, err := s.repo.GetCustodyHistory(ctx, evidenceID)
+	if err != nil {
+		return fmt.Errorf("failed to get custody history: %w", err)
+	}
+	previousSignature := ""
+	if len(history) > 0 {
+		latest := history[len(history)-1]
+		if record.Timestamp < latest.Timestamp {
+			return fmt.Errorf("custody record is older than the latest record")
+		}
+		previousSignature = latest.Signature
+	}
+
+	payload := record.SigningPayload(evidenceID, previousSignature)
+	switch {
+	case record.IsSigned():
+		if _, err := s.verifyCustodyRecord(record, payload); err != nil {
+			return err
+		}
+	case record.Handler == domain.SystemHandler && s.signer != nil:
+		signature, certificate, err := s.signer.Sign(payload)
+		if err != nil {
+			return err
+		}
+		record.Signature = signature
+		record.SignerCertificate = certificate
+	case s.requireSignatures:
+		return fmt.Errorf("%w: handler %s", domain.ErrCustodySignatureRequired, record.Handler)
+	}
+
+	return s.repo.AppendCustodyRecord(ctx, evidenceID, record)
+}
+
+// verifyCustodyRecord verifies a record's signature against payload and checks
+// that the certificate belongs to the record's handler. Service records must be
+// signed by the service certificate.
+func (s *EvidenceManagementService) verifyCustodyRecord(record *domain.ChainOfCustody, payload []byte) (string, error) {
+	signer, err := s.verifier.Verify(payload, record.Signature, record.SignerCertificate, time.Unix(record.Timestamp, 0))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidCustodySignature, err)
+	}
+
+	expected := record.Handler
+	if record.Handler == domain.SystemHandler && s.signer != nil {
+		expected = s.signer.Identity()
+	}
+	if signer != expected {
+		return "", fmt.Errorf("%w: signed by %s, not by handler %s", domain.ErrInvalidCustodySignature, signer, record.Handler)
+	}
+
+	return signer, nil
+}
+
 // ExportEvidence exports evidence in the specified format
 func (s *EvidenceManagementService) ExportEvidence(ctx context.Context, evidenceID string, format string) ([]byte, error) {
 	// Get the evidence
@@ -395,7 +560,7 @@ func (s *EvidenceManagementService) CreateCaseEvidence(ctx context.Context, case
 		Location:      "automated",
 		IntegrityHash: hash,
 	}
-	s.repo.AppendCustodyRecord(ctx, evidence.ID, custody)
+	s.appendCustody(ctx, evidence.ID, custody)
 
 	return evidence, nil
 }
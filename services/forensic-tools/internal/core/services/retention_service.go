@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/csic-platform/internal/core/ports"
+)
+
+// RetentionService applies retention classes to forensic resources. Deleting a
+// resource only marks it; purge runs remove resources whose deletion grace
+// period or retention period has ended, after writing a tombstone to the WORM
+// audit chain. Legal holds block both deletion and purging.
+type RetentionService struct {
+	repo       ports.RetentionRepository
+	tombstones ports.TombstoneWriter
+	classes    map[domain.ResourceType]domain.RetentionClass
+	batchSize  int
+
+	purgeMu   sync.Mutex
+	mu        sync.RWMutex
+	lastPurge *domain.PurgeReport
+}
+
+// NewRetentionService creates a new retention service instance
+func NewRetentionService(repo ports.RetentionRepository, tombstones ports.TombstoneWriter, classes []domain.RetentionClass, batchSize int) *RetentionService {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	byType := make(map[domain.ResourceType]domain.RetentionClass, len(classes))
+	for _, class := range classes {
+		byType[class.ResourceType] = class
+	}
+
+	return &RetentionService{
+		repo:       repo,
+		tombstones: tombstones,
+		classes:    byType,
+		batchSize:  batchSize,
+	}
+}
+
+// Classes returns the configured retention classes ordered by resource type
+func (s *RetentionService) Classes() []domain.RetentionClass {
+	classes := make([]domain.RetentionClass, 0, len(s.classes))
+	for _, class := range s.classes {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].ResourceType < classes[j].ResourceType
+	})
+	return classes
+}
+
+// DeleteResource soft-deletes a resource unless it is under legal hold
+func (s *RetentionService) DeleteResource(ctx context.Context, resourceType domain.ResourceType, id, actorID string) error {
+	if _, err := s.class(resourceType); err != nil {
+		return err
+	}
+	return s.repo.SoftDeleteResource(ctx, resourceType, id, actorID)
+}
+
+// RestoreResource undoes a soft delete that has not been purged yet
+func (s *RetentionService) RestoreResource(ctx context.Context, resourceType domain.ResourceType, id string) error {
+	if _, err := s.class(resourceType); err != nil {
+		return err
+	}
+	return s.repo.RestoreResource(ctx, resourceType, id)
+}
+
+// PlaceLegalHold places a hold on a single resource or on every resource of a case
+func (s *RetentionService) PlaceLegalHold(ctx context.Context, hold *domain.LegalHold) error {
+	if hold.Reason == "" || hold.PlacedBy == "" {
+		return fmt.Errorf("%w: reason and placed_by are required", domain.ErrInvalidLegalHold)
+	}
+
+	switch {
+	case hold.ResourceID != "":
+		if _, err := s.class(hold.ResourceType); err != nil {
+			return err
+		}
+	case hold.CaseID != "":
+		hold.ResourceType = ""
+	default:
+		return fmt.Errorf("%w: either resource_id or case_id is required", domain.ErrInvalidLegalHold)
+	}
+
+	hold.ID = fmt.Sprintf("hold-%d", time.Now().UnixNano())
+	hold.PlacedAt = time.Now().UTC()
+	hold.ReleasedBy = ""
+	hold.ReleasedAt = nil
+
+	if err := s.repo.CreateLegalHold(ctx, hold); err != nil {
+		return fmt.Errorf("failed to store legal hold: %w", err)
+	}
+	return nil
+}
+
+// ReleaseLegalHold releases an active legal hold
+func (s *RetentionService) ReleaseLegalHold(ctx context.Context, id, actorID string) (*domain.LegalHold, error) {
+	return s.repo.ReleaseLegalHold(ctx, id, actorID)
+}
+
+// ListLegalHolds returns legal holds, optionally only active ones
+func (s *RetentionService) ListLegalHolds(ctx context.Context, activeOnly bool) ([]*domain.LegalHold, error) {
+	return s.repo.ListLegalHolds(ctx, activeOnly)
+}
+
+// LastPurge returns the report of the most recent purge run, or nil
+func (s *RetentionService) LastPurge() *domain.PurgeReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastPurge
+}
+
+// RunPurge purges every resource that is due under its retention class. A
+// resource is only removed once its tombstone has been written; failures are
+// recorded in the report and retried on the next run.
+func (s *RetentionService) RunPurge(ctx context.Context) *domain.PurgeReport {
+	s.purgeMu.Lock()
+	defer s.purgeMu.Unlock()
+
+	report := &domain.PurgeReport{
+		StartedAt: time.Now().UTC(),
+		Purged:    make(map[domain.ResourceType]int),
+	}
+
+	for _, class := range s.Classes() {
+		purged, errs := s.purgeClass(ctx, class)
+		report.Purged[class.ResourceType] = purged
+		report.Errors = append(report.Errors, errs...)
+	}
+
+	report.FinishedAt = time.Now().UTC()
+
+	s.mu.Lock()
+	s.lastPurge = report
+	s.mu.Unlock()
+
+	return report
+}
+
+// StartPurgeScheduler runs RunPurge every interval until ctx is cancelled
+func (s *RetentionService) StartPurgeScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunPurge(ctx)
+		}
+	}
+}
+
+// purgeClass purges the due resources of one retention class in batches
+func (s *RetentionService) purgeClass(ctx context.Context, class domain.RetentionClass) (int, []string) {
+	now := time.Now().UTC()
+	deletedBefore := class.DeletedBefore(now)
+	createdBefore := class.ExpiresBefore(now)
+
+	purged := 0
+	var errs []string
+	for {
+		candidates, err := s.repo.ListPurgeCandidates(ctx, class.ResourceType, deletedBefore, createdBefore, s.batchSize)
+		if err != nil {
+			return purged, append(errs, fmt.Sprintf("failed to list %s purge candidates: %v", class.ResourceType, err))
+		}
+
+		batchPurged := 0
+		for _, candidate := range candidates {
+			tombstone := &domain.Tombstone{
+				ResourceType:   candidate.ResourceType,
+				ResourceID:     candidate.ResourceID,
+				CaseID:         candidate.CaseID,
+				Hash:           candidate.Hash,
+				RetentionClass: class.Name,
+				Reason:         domain.PurgeReasonExpired,
+				CreatedAt:      candidate.CreatedAt,
+				DeletedAt:      candidate.DeletedAt,
+				PurgedAt:       now,
+			}
+			if candidate.DeletedAt != nil && candidate.DeletedAt.Before(deletedBefore) {
+				tombstone.Reason = domain.PurgeReasonDeleted
+			}
+
+			err := s.repo.PurgeResource(ctx, candidate.ResourceType, candidate.ResourceID, func(ctx context.Context) error {
+				return s.tombstones.WriteTombstone(ctx, tombstone)
+			})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("failed to purge %s %s: %v", candidate.ResourceType, candidate.ResourceID, err))
+				continue
+			}
+			batchPurged++
+		}
+		purged += batchPurged
+
+		// Stop when the backlog is cleared, or when a whole batch failed and
+		// would only be listed again
+		if len(candidates) < s.batchSize || batchPurged == 0 {
+			return purged, errs
+		}
+	}
+}
+
+// class returns the retention class of a resource type
+func (s *RetentionService) class(resourceType domain.ResourceType) (domain.RetentionClass, error) {
+	class, ok := s.classes[resourceType]
+	if !ok {
+		return domain.RetentionClass{}, fmt.Errorf("%w: %s", domain.ErrUnknownResourceType, resourceType)
+	}
+	return class, nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/csic-platform/internal/core/domain"
+	"github.com/csic-platform/internal/core/ports"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchService provides ranked full-text search over evidence and the chain
+// of custody audit trail
+type SearchService struct {
+	repo ports.SearchRepository
+}
+
+// NewSearchService creates a new search service instance
+func NewSearchService(repo ports.SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search returns a page of hits ordered by rank. Searching all scopes merges
+// the evidence and custody hits by rank.
+func (s *SearchService) Search(ctx context.Context, query domain.SearchQuery) (*domain.SearchResults, error) {
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return nil, domain.ErrEmptySearchQuery
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	if query.Scope == "" {
+		query.Scope = domain.SearchScopeAll
+	}
+
+	results := &domain.SearchResults{Query: query, FullText: true}
+
+	switch query.Scope {
+	case domain.SearchScopeEvidence:
+		hits, fullText, err := s.repo.SearchEvidence(ctx, &query, query.Limit, query.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search evidence: %w", err)
+		}
+		results.Hits, results.FullText = hits, fullText
+
+	case domain.SearchScopeCustody:
+		hits, fullText, err := s.repo.SearchCustodyRecords(ctx, &query, query.Limit, query.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search custody records: %w", err)
+		}
+		results.Hits, results.FullText = hits, fullText
+
+	case domain.SearchScopeAll:
+		// Each scope's first offset+limit hits include every hit of the
+		// merged page
+		window := query.Offset + query.Limit
+		evidenceHits, evidenceFullText, err := s.repo.SearchEvidence(ctx, &query, window, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search evidence: %w", err)
+		}
+		custodyHits, custodyFullText, err := s.repo.SearchCustodyRecords(ctx, &query, window, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search custody records: %w", err)
+		}
+
+		hits := append(evidenceHits, custodyHits...)
+		sort.SliceStable(hits, func(i, j int) bool {
+			if hits[i].Rank != hits[j].Rank {
+				return hits[i].Rank > hits[j].Rank
+			}
+			return hits[i].Timestamp > hits[j].Timestamp
+		})
+		if query.Offset >= len(hits) {
+			hits = nil
+		} else {
+			hits = hits[query.Offset:]
+		}
+		if len(hits) > query.Limit {
+			hits = hits[:query.Limit]
+		}
+		results.Hits = hits
+		results.FullText = evidenceFullText && custodyFullText
+
+	default:
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnknownSearchScope, query.Scope)
+	}
+
+	if results.Hits == nil {
+		results.Hits = []*domain.SearchHit{}
+	}
+	return results, nil
+}
@@ -79,6 +79,18 @@ func main() {
 	}
 	defer outageRepo.Close()
 
+	alertRepo, err := storage.NewPostgresAlertRepository(cfg.DatabaseURL)
+	if err != nil {
+		zapLogger.Fatal("Failed to connect to PostgreSQL for alerts", logger.Error(err))
+	}
+	defer alertRepo.Close()
+
+	incidentRepo, err := storage.NewPostgresIncidentRepository(cfg.DatabaseURL)
+	if err != nil {
+		zapLogger.Fatal("Failed to connect to PostgreSQL for incidents", logger.Error(err))
+	}
+	defer incidentRepo.Close()
+
 	// Initialize Redis client
 	redisClient, err := storage.NewRedisClient(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
 	if err != nil {
@@ -104,7 +116,9 @@ func main() {
 	repositories := ports.Repositories{
 		ServiceStatusRepository: serviceStatusRepo,
 		AlertRuleRepository:     alertRuleRepo,
+		AlertRepository:         alertRepo,
 		OutageRepository:        outageRepo,
+		IncidentRepository:      incidentRepo,
 	}
 
 	// Initialize cache port
@@ -120,7 +134,10 @@ func main() {
 
 	// Initialize services
 	monitorService := services.NewMonitorService(repositories, cachePort, messagingPort, zapLogger, metricsCollector)
-	alertService := services.NewAlertService(repositories, cachePort, messagingPort, zapLogger, metricsCollector)
+	alertService := services.NewAlertService(repositories, cachePort, messagingPort, zapLogger, metricsCollector, services.CorrelationConfig{
+		DedupWindow:    time.Duration(cfg.AlertDedupWindow) * time.Second,
+		IncidentWindow: time.Duration(cfg.IncidentCorrelationWindow) * time.Second,
+	})
 
 	// Initialize HTTP handler
 	httpHandler := handlers.NewHTTPHandler(
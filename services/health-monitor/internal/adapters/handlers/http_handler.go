@@ -94,6 +94,15 @@ func (h *HTTPHandler) Start(port int, logger *zap.Logger) error {
 			outages.POST("/:id/resolve", h.ResolveOutage)
 		}
 
+		// Incident endpoints
+		incidents := v1.Group("/incidents")
+		{
+			incidents.GET("", h.ListIncidents)
+			incidents.GET("/:id", h.GetIncident)
+			incidents.GET("/:id/timeline", h.GetIncidentTimeline)
+			incidents.POST("/:id/resolve", h.ResolveIncident)
+		}
+
 		// Heartbeat endpoint
 		v1.POST("/heartbeat", h.SubmitHeartbeat)
 	}
@@ -391,6 +400,89 @@ func (h *HTTPHandler) ResolveAlert(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "alert resolved"})
 }
 
+// ListIncidents lists incidents
+func (h *HTTPHandler) ListIncidents(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	filter := ports.IncidentFilter{
+		ServiceName: c.Query("service"),
+		Status:      c.Query("status"),
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			filter.Limit = l
+		}
+	}
+
+	incidents, err := h.alertService.GetIncidents(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to list incidents", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"incidents": incidents,
+		"count":     len(incidents),
+	})
+}
+
+// GetIncident gets an incident with its alerts and merged timeline
+func (h *HTTPHandler) GetIncident(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	incident, err := h.alertService.GetIncident(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get incident", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if incident == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, incident)
+}
+
+// GetIncidentTimeline gets the merged timeline of an incident
+func (h *HTTPHandler) GetIncidentTimeline(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+
+	incident, err := h.alertService.GetIncident(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get incident timeline", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if incident == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"incident_id": incident.ID,
+		"status":      incident.Status,
+		"timeline":    incident.Timeline,
+		"count":       len(incident.Timeline),
+	})
+}
+
+// ResolveIncident resolves an incident and its firing alerts
+func (h *HTTPHandler) ResolveIncident(c *gin.Context) {
+	id := c.Param("id")
+	ctx := c.Request.Context()
+	if err := h.alertService.ResolveIncident(ctx, id); err != nil {
+		h.logger.Error("Failed to resolve incident", zap.String("id", id), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "incident resolved"})
+}
+
 // ListOutages lists outages
 func (h *HTTPHandler) ListOutages(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -0,0 +1,389 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"csic-platform/health-monitor/internal/core/domain"
+	"csic-platform/health-monitor/internal/core/ports"
+)
+
+// PostgresAlertRepository implements AlertRepository using PostgreSQL
+type PostgresAlertRepository struct {
+	db          *sql.DB
+	tablePrefix string
+}
+
+// NewPostgresAlertRepository creates a new PostgreSQL alert repository
+func NewPostgresAlertRepository(databaseURL string) (ports.AlertRepository, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresAlertRepository{
+		db:          db,
+		tablePrefix: "health_monitor_",
+	}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresAlertRepository) Close() error {
+	return r.db.Close()
+}
+
+// tableName returns the prefixed table name
+func (r *PostgresAlertRepository) tableName(name string) string {
+	return r.tablePrefix + name
+}
+
+const alertColumns = `id, rule_id, service_name, severity, condition, current_value, threshold,
+		       status, message, fired_at, resolved_at, metadata, category, fingerprint,
+		       occurrence_count, last_seen_at, incident_id`
+
+// GetAlert retrieves an alert by ID
+func (r *PostgresAlertRepository) GetAlert(ctx context.Context, id string) (*domain.Alert, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1
+	`, alertColumns, r.tableName("alerts"))
+
+	alert, err := scanAlert(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// GetAlerts retrieves alerts based on filter
+func (r *PostgresAlertRepository) GetAlerts(ctx context.Context, filter ports.AlertFilter) ([]*domain.Alert, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.ServiceName != "" {
+		args = append(args, filter.ServiceName)
+		conditions = append(conditions, fmt.Sprintf("service_name = $%d", len(args)))
+	}
+	if filter.Severity != "" {
+		args = append(args, filter.Severity)
+		conditions = append(conditions, fmt.Sprintf("severity = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if !filter.Since.IsZero() {
+		args = append(args, filter.Since)
+		conditions = append(conditions, fmt.Sprintf("fired_at >= $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		%s
+		ORDER BY fired_at DESC
+		LIMIT $%d
+	`, alertColumns, r.tableName("alerts"), where, len(args))
+
+	return r.queryAlerts(ctx, query, args...)
+}
+
+// GetFiringAlerts retrieves all currently firing alerts
+func (r *PostgresAlertRepository) GetFiringAlerts(ctx context.Context) ([]*domain.Alert, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE status = $1
+		ORDER BY last_seen_at DESC
+	`, alertColumns, r.tableName("alerts"))
+
+	return r.queryAlerts(ctx, query, domain.AlertStatusFiring)
+}
+
+// GetAlertsByService retrieves alerts for a specific service
+func (r *PostgresAlertRepository) GetAlertsByService(ctx context.Context, serviceName string, limit int) ([]*domain.Alert, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE service_name = $1
+		ORDER BY fired_at DESC
+		LIMIT $2
+	`, alertColumns, r.tableName("alerts"))
+
+	return r.queryAlerts(ctx, query, serviceName, limit)
+}
+
+// GetAlertsByIncident retrieves the alerts correlated into an incident
+func (r *PostgresAlertRepository) GetAlertsByIncident(ctx context.Context, incidentID string) ([]*domain.Alert, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE incident_id = $1
+		ORDER BY fired_at ASC
+	`, alertColumns, r.tableName("alerts"))
+
+	return r.queryAlerts(ctx, query, incidentID)
+}
+
+// FindFiringAlertByFingerprint retrieves the firing alert with the given
+// fingerprint last seen at or after since, or nil if there is none
+func (r *PostgresAlertRepository) FindFiringAlertByFingerprint(ctx context.Context, fingerprint string, since time.Time) (*domain.Alert, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE fingerprint = $1 AND status = $2 AND last_seen_at >= $3
+		ORDER BY last_seen_at DESC
+		LIMIT 1
+	`, alertColumns, r.tableName("alerts"))
+
+	alert, err := scanAlert(r.db.QueryRowContext(ctx, query, fingerprint, domain.AlertStatusFiring, since))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find alert by fingerprint: %w", err)
+	}
+
+	return alert, nil
+}
+
+// CreateAlert creates a new alert
+func (r *PostgresAlertRepository) CreateAlert(ctx context.Context, alert *domain.Alert) error {
+	metadata, err := json.Marshal(alert.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert metadata: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, rule_id, service_name, severity, condition, current_value, threshold,
+		                status, message, fired_at, metadata, category, fingerprint,
+		                occurrence_count, last_seen_at, incident_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`, r.tableName("alerts"))
+
+	now := time.Now()
+	_, err = r.db.ExecContext(ctx, query,
+		alert.ID,
+		alert.RuleID,
+		alert.ServiceName,
+		alert.Severity,
+		alert.Condition,
+		alert.CurrentValue,
+		alert.Threshold,
+		alert.Status,
+		alert.Message,
+		alert.FiredAt,
+		metadata,
+		alert.Category,
+		alert.Fingerprint,
+		alert.OccurrenceCount,
+		alert.LastSeenAt,
+		nullString(alert.IncidentID),
+		now,
+		now,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create alert: %w", err)
+	}
+
+	return nil
+}
+
+// RecordAlertOccurrence stores a repeated firing of an alert: its occurrence
+// count, last seen time, latest value, message and severity
+func (r *PostgresAlertRepository) RecordAlertOccurrence(ctx context.Context, alert *domain.Alert) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET occurrence_count = $1, last_seen_at = $2, current_value = $3, message = $4,
+		    severity = $5, updated_at = $6
+		WHERE id = $7
+	`, r.tableName("alerts"))
+
+	result, err := r.db.ExecContext(ctx, query,
+		alert.OccurrenceCount,
+		alert.LastSeenAt,
+		alert.CurrentValue,
+		alert.Message,
+		alert.Severity,
+		time.Now(),
+		alert.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record alert occurrence: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("alert not found: %s", alert.ID)
+	}
+
+	return nil
+}
+
+// UpdateAlertStatus updates the status of an alert
+func (r *PostgresAlertRepository) UpdateAlertStatus(ctx context.Context, id string, status string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $1, updated_at = $2
+		WHERE id = $3
+	`, r.tableName("alerts"))
+
+	result, err := r.db.ExecContext(ctx, query, status, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update alert status: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("alert not found: %s", id)
+	}
+
+	return nil
+}
+
+// ResolveAlert resolves an alert
+func (r *PostgresAlertRepository) ResolveAlert(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $1, resolved_at = $2, updated_at = $2
+		WHERE id = $3
+	`, r.tableName("alerts"))
+
+	result, err := r.db.ExecContext(ctx, query, domain.AlertStatusResolved, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to resolve alert: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("alert not found: %s", id)
+	}
+
+	return nil
+}
+
+// DeleteResolvedAlerts deletes resolved alerts older than specified time
+func (r *PostgresAlertRepository) DeleteResolvedAlerts(ctx context.Context, olderThan time.Time) error {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE status = $1 AND resolved_at < $2
+	`, r.tableName("alerts"))
+
+	if _, err := r.db.ExecContext(ctx, query, domain.AlertStatusResolved, olderThan); err != nil {
+		return fmt.Errorf("failed to delete resolved alerts: %w", err)
+	}
+
+	return nil
+}
+
+// queryAlerts runs a query selecting alertColumns
+func (r *PostgresAlertRepository) queryAlerts(ctx context.Context, query string, args ...interface{}) ([]*domain.Alert, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []*domain.Alert
+
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAlert scans a row selecting alertColumns
+func scanAlert(row rowScanner) (*domain.Alert, error) {
+	var alert domain.Alert
+	var message, incidentID sql.NullString
+	var resolvedAt, lastSeenAt sql.NullTime
+	var metadata []byte
+
+	if err := row.Scan(
+		&alert.ID,
+		&alert.RuleID,
+		&alert.ServiceName,
+		&alert.Severity,
+		&alert.Condition,
+		&alert.CurrentValue,
+		&alert.Threshold,
+		&alert.Status,
+		&message,
+		&alert.FiredAt,
+		&resolvedAt,
+		&metadata,
+		&alert.Category,
+		&alert.Fingerprint,
+		&alert.OccurrenceCount,
+		&lastSeenAt,
+		&incidentID,
+	); err != nil {
+		return nil, err
+	}
+
+	if message.Valid {
+		alert.Message = message.String
+	}
+	if resolvedAt.Valid {
+		alert.ResolvedAt = resolvedAt.Time
+	}
+	if lastSeenAt.Valid {
+		alert.LastSeenAt = lastSeenAt.Time
+	} else {
+		alert.LastSeenAt = alert.FiredAt
+	}
+	if incidentID.Valid {
+		alert.IncidentID = incidentID.String
+	}
+	if len(metadata) > 0 {
+		_ = json.Unmarshal(metadata, &alert.Metadata)
+	}
+
+	return &alert, nil
+}
+
+// nullString maps an empty string to NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
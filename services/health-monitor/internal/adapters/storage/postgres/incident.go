@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"csic-platform/health-monitor/internal/core/domain"
+	"csic-platform/health-monitor/internal/core/ports"
+)
+
+// PostgresIncidentRepository implements IncidentRepository using PostgreSQL
+type PostgresIncidentRepository struct {
+	db          *sql.DB
+	tablePrefix string
+}
+
+// NewPostgresIncidentRepository creates a new PostgreSQL incident repository
+func NewPostgresIncidentRepository(databaseURL string) (ports.IncidentRepository, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresIncidentRepository{
+		db:          db,
+		tablePrefix: "health_monitor_",
+	}, nil
+}
+
+// Close closes the database connection
+func (r *PostgresIncidentRepository) Close() error {
+	return r.db.Close()
+}
+
+// tableName returns the prefixed table name
+func (r *PostgresIncidentRepository) tableName(name string) string {
+	return r.tablePrefix + name
+}
+
+const incidentColumns = `id, title, status, severity, services, categories, alert_count,
+		       occurrence_count, opened_at, last_activity_at, resolved_at, created_at, updated_at`
+
+// GetIncident retrieves an incident by ID
+func (r *PostgresIncidentRepository) GetIncident(ctx context.Context, id string) (*domain.Incident, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1
+	`, incidentColumns, r.tableName("incidents"))
+
+	incident, err := scanIncident(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	return incident, nil
+}
+
+// GetIncidents retrieves incidents based on filter, most recently active first
+func (r *PostgresIncidentRepository) GetIncidents(ctx context.Context, filter ports.IncidentFilter) ([]*domain.Incident, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.ServiceName != "" {
+		services, _ := json.Marshal([]string{filter.ServiceName})
+		args = append(args, string(services))
+		conditions = append(conditions, fmt.Sprintf("services @> $%d::jsonb", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		%s
+		ORDER BY last_activity_at DESC
+		LIMIT $%d
+	`, incidentColumns, r.tableName("incidents"), where, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*domain.Incident
+
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, rows.Err()
+}
+
+// FindOpenIncident retrieves the open incident, active at or after since, that
+// involves the service or the category, preferring one on the same service
+func (r *PostgresIncidentRepository) FindOpenIncident(ctx context.Context, serviceName string, category string, since time.Time) (*domain.Incident, error) {
+	services, _ := json.Marshal([]string{serviceName})
+	categories, _ := json.Marshal([]string{category})
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE status = $1 AND last_activity_at >= $2
+		  AND (services @> $3::jsonb OR categories @> $4::jsonb)
+		ORDER BY (services @> $3::jsonb) DESC, last_activity_at DESC
+		LIMIT 1
+	`, incidentColumns, r.tableName("incidents"))
+
+	incident, err := scanIncident(r.db.QueryRowContext(ctx, query,
+		domain.IncidentStatusOpen, since, string(services), string(categories)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open incident: %w", err)
+	}
+
+	return incident, nil
+}
+
+// CreateIncident creates a new incident
+func (r *PostgresIncidentRepository) CreateIncident(ctx context.Context, incident *domain.Incident) error {
+	services, categories, err := marshalIncidentLists(incident)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, title, status, severity, services, categories, alert_count,
+		                occurrence_count, opened_at, last_activity_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, r.tableName("incidents"))
+
+	_, err = r.db.ExecContext(ctx, query,
+		incident.ID,
+		incident.Title,
+		incident.Status,
+		incident.Severity,
+		services,
+		categories,
+		incident.AlertCount,
+		incident.OccurrenceCount,
+		incident.OpenedAt,
+		incident.LastActivityAt,
+		incident.CreatedAt,
+		incident.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIncident stores an incident's correlated services, categories,
+// counters, severity and last activity
+func (r *PostgresIncidentRepository) UpdateIncident(ctx context.Context, incident *domain.Incident) error {
+	services, categories, err := marshalIncidentLists(incident)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET title = $1, severity = $2, services = $3, categories = $4, alert_count = $5,
+		    occurrence_count = $6, last_activity_at = $7, updated_at = $8
+		WHERE id = $9
+	`, r.tableName("incidents"))
+
+	result, err := r.db.ExecContext(ctx, query,
+		incident.Title,
+		incident.Severity,
+		services,
+		categories,
+		incident.AlertCount,
+		incident.OccurrenceCount,
+		incident.LastActivityAt,
+		time.Now(),
+		incident.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("incident not found: %s", incident.ID)
+	}
+
+	return nil
+}
+
+// ResolveIncident resolves an incident
+func (r *PostgresIncidentRepository) ResolveIncident(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = $1, resolved_at = $2, updated_at = $2
+		WHERE id = $3 AND status != $1
+	`, r.tableName("incidents"))
+
+	result, err := r.db.ExecContext(ctx, query, domain.IncidentStatusResolved, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to resolve incident: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("open incident not found: %s", id)
+	}
+
+	return nil
+}
+
+// scanIncident scans a row selecting incidentColumns
+func scanIncident(row rowScanner) (*domain.Incident, error) {
+	var incident domain.Incident
+	var services, categories []byte
+	var resolvedAt sql.NullTime
+
+	if err := row.Scan(
+		&incident.ID,
+		&incident.Title,
+		&incident.Status,
+		&incident.Severity,
+		&services,
+		&categories,
+		&incident.AlertCount,
+		&incident.OccurrenceCount,
+		&incident.OpenedAt,
+		&incident.LastActivityAt,
+		&resolvedAt,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(services, &incident.Services); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident services: %w", err)
+	}
+	if err := json.Unmarshal(categories, &incident.Categories); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident categories: %w", err)
+	}
+	if resolvedAt.Valid {
+		incident.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &incident, nil
+}
+
+func marshalIncidentLists(incident *domain.Incident) ([]byte, []byte, error) {
+	services, err := json.Marshal(nonNil(incident.Services))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal incident services: %w", err)
+	}
+	categories, err := json.Marshal(nonNil(incident.Categories))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal incident categories: %w", err)
+	}
+	return services, categories, nil
+}
+
+// nonNil keeps an empty list from being stored as JSON null
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
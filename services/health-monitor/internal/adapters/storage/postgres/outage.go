@@ -385,43 +385,3 @@ func (r *PostgresOutageRepository) ResolveOutage(ctx context.Context, id string,
 
 	return nil
 }
-
-// GetOutage retrieves an outage by ID
-func (r *PostgresOutageRepository) GetAlert(ctx context.Context, id string) (*domain.Alert, error) {
-	return nil, nil
-}
-
-// GetAlerts retrieves alerts based on filter
-func (r *PostgresOutageRepository) GetAlerts(ctx context.Context, filter ports.AlertFilter) ([]*domain.Alert, error) {
-	return nil, nil
-}
-
-// GetFiringAlerts retrieves all currently firing alerts
-func (r *PostgresOutageRepository) GetFiringAlerts(ctx context.Context) ([]*domain.Alert, error) {
-	return nil, nil
-}
-
-// GetAlertsByService retrieves alerts for a specific service
-func (r *PostgresOutageRepository) GetAlertsByService(ctx context.Context, serviceName string, limit int) ([]*domain.Alert, error) {
-	return nil, nil
-}
-
-// CreateAlert creates a new alert
-func (r *PostgresOutageRepository) CreateAlert(ctx context.Context, alert *domain.Alert) error {
-	return nil
-}
-
-// UpdateAlertStatus updates the status of an alert
-func (r *PostgresOutageRepository) UpdateAlertStatus(ctx context.Context, id string, status string) error {
-	return nil
-}
-
-// ResolveAlert resolves an alert
-func (r *PostgresOutageRepository) ResolveAlert(ctx context.Context, id string) error {
-	return nil
-}
-
-// DeleteResolvedAlerts deletes resolved alerts older than specified time
-func (r *PostgresOutageRepository) DeleteResolvedAlerts(ctx context.Context, olderThan time.Time) error {
-	return nil
-}
@@ -35,6 +35,10 @@ type Config struct {
 	HeartbeatTTL       int  `mapstructure:"heartbeat_ttl"`
 	HealthCheckInterval int `mapstructure:"health_check_interval"`
 	AlertCooldown      int  `mapstructure:"alert_cooldown"`
+	// Repeated firings within AlertDedupWindow seconds update one alert;
+	// alerts within IncidentCorrelationWindow seconds share an incident
+	AlertDedupWindow          int `mapstructure:"alert_dedup_window"`
+	IncidentCorrelationWindow int `mapstructure:"incident_correlation_window"`
 
 	// Monitoring
 	MetricsEnabled bool   `mapstructure:"metrics_enabled"`
@@ -99,6 +103,8 @@ func loadFromDefaults() *Config {
 		HeartbeatTTL:       viper.GetInt("heartbeat_ttl"),
 		HealthCheckInterval: viper.GetInt("health_check_interval"),
 		AlertCooldown:      viper.GetInt("alert_cooldown"),
+		AlertDedupWindow:   viper.GetInt("alert_dedup_window"),
+		IncidentCorrelationWindow: viper.GetInt("incident_correlation_window"),
 		MetricsEnabled:     viper.GetBool("metrics_enabled"),
 		MetricsPort:        viper.GetInt("metrics_port"),
 		HealthCheckTTL:     viper.GetInt("health_check_ttl"),
@@ -124,6 +130,8 @@ func setDefaults() {
 	viper.SetDefault("heartbeat_ttl", 60)
 	viper.SetDefault("health_check_interval", 30)
 	viper.SetDefault("alert_cooldown", 300)
+	viper.SetDefault("alert_dedup_window", 900)
+	viper.SetDefault("incident_correlation_window", 1800)
 	viper.SetDefault("metrics_enabled", true)
 	viper.SetDefault("metrics_port", 9091)
 	viper.SetDefault("health_check_ttl", 30)
@@ -138,6 +146,9 @@ func validateConfig(cfg *Config) error {
 	if cfg.HTTPPort <= 0 || cfg.HTTPPort > 65535 {
 		return fmt.Errorf("invalid http_port: %d", cfg.HTTPPort)
 	}
+	if cfg.AlertDedupWindow < 0 || cfg.IncidentCorrelationWindow < 0 {
+		return fmt.Errorf("alert_dedup_window and incident_correlation_window must not be negative")
+	}
 	return nil
 }
 
@@ -93,6 +93,14 @@ type Alert struct {
 	FiredAt      time.Time `json:"fired_at"`
 	ResolvedAt   time.Time `json:"resolved_at,omitempty"`
 	Metadata     Metadata  `json:"metadata,omitempty"`
+
+	// Deduplication: repeated firings of the same fingerprint within the
+	// dedup window update this alert instead of creating new ones
+	Category        string    `json:"category"`
+	Fingerprint     string    `json:"fingerprint"`
+	OccurrenceCount int       `json:"occurrence_count"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+	IncidentID      string    `json:"incident_id,omitempty"`
 }
 
 // AlertStatus constants
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// AlertCategory returns the category of an alert raised by a rule condition,
+// the metric it tests, e.g. "cpu" for "cpu > 80"
+func AlertCategory(condition string) string {
+	fields := strings.Fields(condition)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	return fields[0]
+}
+
+// AlertFingerprint identifies repeated firings of the same problem: the same
+// rule, on the same service, for the same category
+func AlertFingerprint(category, serviceName, ruleID string) string {
+	sum := sha256.Sum256([]byte(category + "\x00" + serviceName + "\x00" + ruleID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// SeverityRank orders alert severities; unknown severities rank lowest
+func SeverityRank(severity string) int {
+	switch severity {
+	case AlertSeverityCritical:
+		return 3
+	case AlertSeverityWarning:
+		return 2
+	case AlertSeverityInfo:
+		return 1
+	}
+	return 0
+}
+
+// Incident groups related alerts, those on the same service or of the same
+// category arriving within the correlation window, so an alert storm is
+// handled as one problem
+type Incident struct {
+	ID              string     `json:"id"`
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	Severity        string     `json:"severity"` // highest severity of its alerts
+	Services        []string   `json:"services"`
+	Categories      []string   `json:"categories"`
+	AlertCount      int        `json:"alert_count"`
+	OccurrenceCount int        `json:"occurrence_count"`
+	OpenedAt        time.Time  `json:"opened_at"`
+	LastActivityAt  time.Time  `json:"last_activity_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Populated when a single incident is fetched
+	Alerts   []*Alert        `json:"alerts,omitempty"`
+	Timeline []IncidentEvent `json:"timeline,omitempty"`
+}
+
+// IncidentStatus constants
+const (
+	IncidentStatusOpen     = "open"
+	IncidentStatusResolved = "resolved"
+)
+
+// IncidentEvent is one entry of an incident's timeline, merged from the
+// incident and all of its alerts
+type IncidentEvent struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Type        string    `json:"type"`
+	AlertID     string    `json:"alert_id,omitempty"`
+	ServiceName string    `json:"service_name,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Severity    string    `json:"severity,omitempty"`
+	Message     string    `json:"message"`
+	Occurrences int       `json:"occurrences,omitempty"`
+}
+
+// IncidentEvent types
+const (
+	IncidentEventOpened        = "incident_opened"
+	IncidentEventAlertFired    = "alert_fired"
+	IncidentEventAlertRepeated = "alert_repeated"
+	IncidentEventAlertResolved = "alert_resolved"
+	IncidentEventResolved      = "incident_resolved"
+)
+
+// AddAlert records a new alert in the incident
+func (i *Incident) AddAlert(alert *Alert) {
+	i.AlertCount++
+	i.OccurrenceCount += alert.OccurrenceCount
+	i.Services = appendUnique(i.Services, alert.ServiceName)
+	i.Categories = appendUnique(i.Categories, alert.Category)
+	i.raise(alert.Severity, alert.LastSeenAt)
+}
+
+// AddOccurrence records a repeated firing of one of the incident's alerts
+func (i *Incident) AddOccurrence(severity string, seenAt time.Time) {
+	i.OccurrenceCount++
+	i.raise(severity, seenAt)
+}
+
+func (i *Incident) raise(severity string, at time.Time) {
+	if SeverityRank(severity) > SeverityRank(i.Severity) {
+		i.Severity = severity
+	}
+	if at.After(i.LastActivityAt) {
+		i.LastActivityAt = at
+	}
+	i.UpdatedAt = time.Now()
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -39,6 +39,21 @@ type AlertRepository interface {
 	UpdateAlertStatus(ctx context.Context, id string, status string) error
 	ResolveAlert(ctx context.Context, id string) error
 	DeleteResolvedAlerts(ctx context.Context, olderThan time.Time) error
+
+	// Deduplication
+	FindFiringAlertByFingerprint(ctx context.Context, fingerprint string, since time.Time) (*domain.Alert, error)
+	RecordAlertOccurrence(ctx context.Context, alert *domain.Alert) error
+	GetAlertsByIncident(ctx context.Context, incidentID string) ([]*domain.Alert, error)
+}
+
+// IncidentRepository manages incidents correlating related alerts
+type IncidentRepository interface {
+	GetIncident(ctx context.Context, id string) (*domain.Incident, error)
+	GetIncidents(ctx context.Context, filter IncidentFilter) ([]*domain.Incident, error)
+	FindOpenIncident(ctx context.Context, serviceName string, category string, since time.Time) (*domain.Incident, error)
+	CreateIncident(ctx context.Context, incident *domain.Incident) error
+	UpdateIncident(ctx context.Context, incident *domain.Incident) error
+	ResolveIncident(ctx context.Context, id string) error
 }
 
 // OutageRepository manages outages
@@ -61,6 +76,13 @@ type AlertFilter struct {
 	Limit       int
 }
 
+// IncidentFilter defines filters for querying incidents
+type IncidentFilter struct {
+	ServiceName string
+	Status      string
+	Limit       int
+}
+
 // CachePort defines the interface for cache operations
 type CachePort interface {
 	// Heartbeat operations
@@ -51,6 +51,11 @@ type AlertService interface {
 	GetOutages(ctx context.Context, serviceName string, limit int) ([]*domain.Outage, error)
 	CreateOutage(ctx context.Context, outage *domain.Outage) (*domain.Outage, error)
 	ResolveOutage(ctx context.Context, id string, rootCause string) error
+
+	// Incidents
+	GetIncidents(ctx context.Context, filter IncidentFilter) ([]*domain.Incident, error)
+	GetIncident(ctx context.Context, id string) (*domain.Incident, error)
+	ResolveIncident(ctx context.Context, id string) error
 }
 
 // HealthSummaryService generates health summaries
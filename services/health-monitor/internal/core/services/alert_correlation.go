@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"csic-platform/health-monitor/internal/core/domain"
+	"csic-platform/health-monitor/internal/core/ports"
+)
+
+// CorrelationConfig controls alert deduplication and incident correlation
+type CorrelationConfig struct {
+	// DedupWindow is how long after its last firing an alert absorbs repeats
+	// of the same fingerprint instead of a new alert being raised
+	DedupWindow time.Duration
+	// IncidentWindow is how long after its last activity an open incident
+	// absorbs new alerts on the same service or of the same category
+	IncidentWindow time.Duration
+}
+
+// deduplicate folds alert into the firing alert with the same fingerprint seen
+// within the dedup window, reporting whether it did. The existing alert's
+// occurrence counter, last seen time and latest reading are updated; nothing
+// is published so a flapping metric does not flood subscribers.
+func (s *AlertServiceService) deduplicate(ctx context.Context, alert *domain.Alert) bool {
+	if s.correlation.DedupWindow <= 0 {
+		return false
+	}
+
+	existing, err := s.repositories.AlertRepository.FindFiringAlertByFingerprint(ctx, alert.Fingerprint, alert.FiredAt.Add(-s.correlation.DedupWindow))
+	if err != nil {
+		s.logger.Warn("Failed to look up duplicate alert", logger.Error(err))
+		return false
+	}
+	if existing == nil {
+		return false
+	}
+
+	existing.OccurrenceCount++
+	existing.LastSeenAt = alert.FiredAt
+	existing.CurrentValue = alert.CurrentValue
+	existing.Message = alert.Message
+	existing.Severity = alert.Severity
+
+	if err := s.repositories.AlertRepository.RecordAlertOccurrence(ctx, existing); err != nil {
+		s.logger.Warn("Failed to record alert occurrence", logger.Error(err))
+		return false
+	}
+
+	s.metrics.RecordAlertDeduplicated(existing.Severity, existing.ServiceName)
+
+	if existing.IncidentID != "" {
+		incident, err := s.repositories.IncidentRepository.GetIncident(ctx, existing.IncidentID)
+		if err != nil {
+			s.logger.Warn("Failed to get incident", logger.String("incident_id", existing.IncidentID), logger.Error(err))
+		} else if incident != nil && incident.Status == domain.IncidentStatusOpen {
+			incident.AddOccurrence(existing.Severity, existing.LastSeenAt)
+			if err := s.repositories.IncidentRepository.UpdateIncident(ctx, incident); err != nil {
+				s.logger.Warn("Failed to update incident", logger.String("incident_id", incident.ID), logger.Error(err))
+			}
+		}
+	}
+
+	s.logger.Debug("Alert deduplicated",
+		logger.String("alert_id", existing.ID),
+		logger.String("fingerprint", existing.Fingerprint),
+		logger.Int("occurrences", existing.OccurrenceCount),
+	)
+
+	return true
+}
+
+// correlate attaches alert to the open incident active within the incident
+// window that shares its service or category, or starts a new incident.
+// The incident is returned for saving once the alert has been stored, with
+// opened set if it is new.
+func (s *AlertServiceService) correlate(ctx context.Context, alert *domain.Alert) (*domain.Incident, bool) {
+	var incident *domain.Incident
+	if s.correlation.IncidentWindow > 0 {
+		found, err := s.repositories.IncidentRepository.FindOpenIncident(ctx, alert.ServiceName, alert.Category, alert.FiredAt.Add(-s.correlation.IncidentWindow))
+		if err != nil {
+			s.logger.Warn("Failed to look up open incident", logger.Error(err))
+		}
+		incident = found
+	}
+
+	opened := incident == nil
+	if opened {
+		incident = &domain.Incident{
+			ID:             uuid.New().String(),
+			Title:          fmt.Sprintf("%s: %s", alert.ServiceName, alert.Category),
+			Status:         domain.IncidentStatusOpen,
+			Severity:       alert.Severity,
+			OpenedAt:       alert.FiredAt,
+			LastActivityAt: alert.FiredAt,
+			CreatedAt:      time.Now(),
+		}
+	}
+
+	incident.AddAlert(alert)
+	if !opened && len(incident.Services) > 1 {
+		incident.Title = fmt.Sprintf("%s across %d services", incident.Categories[0], len(incident.Services))
+	}
+	alert.IncidentID = incident.ID
+
+	return incident, opened
+}
+
+// saveIncident stores an incident returned by correlate
+func (s *AlertServiceService) saveIncident(ctx context.Context, incident *domain.Incident, opened bool) {
+	if !opened {
+		if err := s.repositories.IncidentRepository.UpdateIncident(ctx, incident); err != nil {
+			s.logger.Warn("Failed to update incident", logger.String("incident_id", incident.ID), logger.Error(err))
+		}
+		return
+	}
+
+	if err := s.repositories.IncidentRepository.CreateIncident(ctx, incident); err != nil {
+		s.logger.Warn("Failed to create incident", logger.String("incident_id", incident.ID), logger.Error(err))
+		return
+	}
+
+	s.metrics.RecordIncidentOpened(incident.Severity)
+	s.logger.Warn("Incident opened",
+		logger.String("incident_id", incident.ID),
+		logger.String("title", incident.Title),
+		logger.String("severity", incident.Severity),
+	)
+}
+
+// resolveIncidentIfQuiet resolves an open incident none of whose alerts is
+// still firing
+func (s *AlertServiceService) resolveIncidentIfQuiet(ctx context.Context, incidentID string) {
+	alerts, err := s.repositories.AlertRepository.GetAlertsByIncident(ctx, incidentID)
+	if err != nil {
+		s.logger.Warn("Failed to get incident alerts", logger.String("incident_id", incidentID), logger.Error(err))
+		return
+	}
+	for _, alert := range alerts {
+		if alert.Status == domain.AlertStatusFiring {
+			return
+		}
+	}
+
+	incident, err := s.repositories.IncidentRepository.GetIncident(ctx, incidentID)
+	if err != nil || incident == nil || incident.Status != domain.IncidentStatusOpen {
+		return
+	}
+
+	if err := s.repositories.IncidentRepository.ResolveIncident(ctx, incidentID); err != nil {
+		s.logger.Warn("Failed to resolve incident", logger.String("incident_id", incidentID), logger.Error(err))
+		return
+	}
+
+	s.logger.Info("Incident resolved", logger.String("incident_id", incidentID))
+}
+
+// GetIncidents gets incidents based on filter
+func (s *AlertServiceService) GetIncidents(ctx context.Context, filter ports.IncidentFilter) ([]*domain.Incident, error) {
+	return s.repositories.IncidentRepository.GetIncidents(ctx, filter)
+}
+
+// GetIncident gets an incident with its alerts and their merged timeline
+func (s *AlertServiceService) GetIncident(ctx context.Context, id string) (*domain.Incident, error) {
+	incident, err := s.repositories.IncidentRepository.GetIncident(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if incident == nil {
+		return nil, nil
+	}
+
+	alerts, err := s.repositories.AlertRepository.GetAlertsByIncident(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident alerts: %w", err)
+	}
+
+	incident.Alerts = alerts
+	incident.Timeline = buildIncidentTimeline(incident, alerts)
+
+	return incident, nil
+}
+
+// ResolveIncident resolves an incident and all of its firing alerts
+func (s *AlertServiceService) ResolveIncident(ctx context.Context, id string) error {
+	alerts, err := s.repositories.AlertRepository.GetAlertsByIncident(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get incident alerts: %w", err)
+	}
+
+	for _, alert := range alerts {
+		if alert.Status != domain.AlertStatusFiring {
+			continue
+		}
+		if err := s.repositories.AlertRepository.ResolveAlert(ctx, alert.ID); err != nil {
+			return fmt.Errorf("failed to resolve alert %s: %w", alert.ID, err)
+		}
+		s.metrics.RecordAlertResolved(alert.Severity, alert.ServiceName)
+	}
+
+	if err := s.repositories.IncidentRepository.ResolveIncident(ctx, id); err != nil {
+		return fmt.Errorf("failed to resolve incident: %w", err)
+	}
+
+	s.logger.Info("Incident resolved",
+		logger.String("incident_id", id),
+		logger.Int("alerts", len(alerts)),
+	)
+
+	return nil
+}
+
+// buildIncidentTimeline merges the lifecycle of an incident and each of its
+// alerts into one chronological timeline
+func buildIncidentTimeline(incident *domain.Incident, alerts []*domain.Alert) []domain.IncidentEvent {
+	timeline := []domain.IncidentEvent{{
+		Timestamp: incident.OpenedAt,
+		Type:      domain.IncidentEventOpened,
+		Severity:  incident.Severity,
+		Message:   incident.Title,
+	}}
+
+	for _, alert := range alerts {
+		timeline = append(timeline, domain.IncidentEvent{
+			Timestamp:   alert.FiredAt,
+			Type:        domain.IncidentEventAlertFired,
+			AlertID:     alert.ID,
+			ServiceName: alert.ServiceName,
+			Category:    alert.Category,
+			Severity:    alert.Severity,
+			Message:     alert.Message,
+			Occurrences: 1,
+		})
+
+		if alert.OccurrenceCount > 1 {
+			timeline = append(timeline, domain.IncidentEvent{
+				Timestamp:   alert.LastSeenAt,
+				Type:        domain.IncidentEventAlertRepeated,
+				AlertID:     alert.ID,
+				ServiceName: alert.ServiceName,
+				Category:    alert.Category,
+				Severity:    alert.Severity,
+				Message:     fmt.Sprintf("fired %d times, last: %s", alert.OccurrenceCount, alert.Message),
+				Occurrences: alert.OccurrenceCount,
+			})
+		}
+
+		if alert.Status == domain.AlertStatusResolved && !alert.ResolvedAt.IsZero() {
+			timeline = append(timeline, domain.IncidentEvent{
+				Timestamp:   alert.ResolvedAt,
+				Type:        domain.IncidentEventAlertResolved,
+				AlertID:     alert.ID,
+				ServiceName: alert.ServiceName,
+				Category:    alert.Category,
+				Message:     "alert resolved",
+			})
+		}
+	}
+
+	if incident.ResolvedAt != nil {
+		timeline = append(timeline, domain.IncidentEvent{
+			Timestamp: *incident.ResolvedAt,
+			Type:      domain.IncidentEventResolved,
+			Message:   "incident resolved",
+		})
+	}
+
+	// Stable so an incident opening sorts before its first alert
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Timestamp.Before(timeline[j].Timestamp)
+	})
+
+	return timeline
+}
@@ -31,6 +31,9 @@ type AlertService interface {
 	GetOutages(ctx context.Context, serviceName string, limit int) ([]*domain.Outage, error)
 	CreateOutage(ctx context.Context, outage *domain.Outage) (*domain.Outage, error)
 	ResolveOutage(ctx context.Context, id string, rootCause string) error
+	GetIncidents(ctx context.Context, filter ports.IncidentFilter) ([]*domain.Incident, error)
+	GetIncident(ctx context.Context, id string) (*domain.Incident, error)
+	ResolveIncident(ctx context.Context, id string) error
 }
 
 // AlertServiceService implements AlertService
@@ -40,6 +43,7 @@ type AlertServiceService struct {
 	messagingPort ports.MessagingPort
 	logger        *zap.Logger
 	metrics       *metrics.MetricsCollector
+	correlation   CorrelationConfig
 }
 
 // NewAlertService creates a new alert service
@@ -49,6 +53,7 @@ func NewAlertService(
 	messagingPort ports.MessagingPort,
 	logger *zap.Logger,
 	metricsCollector *metrics.MetricsCollector,
+	correlation CorrelationConfig,
 ) AlertService {
 	return &AlertServiceService{
 		repositories:  repositories,
@@ -56,6 +61,7 @@ func NewAlertService(
 		messagingPort: messagingPort,
 		logger:        logger,
 		metrics:       metricsCollector,
+		correlation:   correlation,
 	}
 }
 
@@ -121,14 +127,28 @@ func (s *AlertServiceService) GetFiringAlerts(ctx context.Context) ([]*domain.Al
 	return s.repositories.AlertRepository.GetFiringAlerts(ctx)
 }
 
-// ResolveAlert resolves an alert
+// ResolveAlert resolves an alert, and its incident once no alert of the
+// incident is still firing
 func (s *AlertServiceService) ResolveAlert(ctx context.Context, id string) error {
+	alert, err := s.repositories.AlertRepository.GetAlert(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get alert: %w", err)
+	}
+	if alert == nil {
+		return fmt.Errorf("alert not found: %s", id)
+	}
+
 	if err := s.repositories.AlertRepository.ResolveAlert(ctx, id); err != nil {
 		return fmt.Errorf("failed to resolve alert: %w", err)
 	}
 
+	s.metrics.RecordAlertResolved(alert.Severity, alert.ServiceName)
 	s.logger.Info("Resolved alert", logger.String("alert_id", id))
 
+	if alert.IncidentID != "" {
+		s.resolveIncidentIfQuiet(ctx, alert.IncidentID)
+	}
+
 	return nil
 }
 
@@ -147,15 +167,23 @@ func (s *AlertServiceService) EvaluateAlerts(ctx context.Context, serviceName st
 			continue
 		}
 
-		// Check if alert is in cooldown
-		inCooldown, err := s.cachePort.IsAlertInCooldown(ctx, rule.ID)
-		if err == nil && inCooldown {
-			continue
-		}
-
 		// Evaluate the rule
 		alert := s.evaluateRule(rule, metrics)
 		if alert != nil {
+			// Collapse a repeat of a firing alert into it
+			if s.deduplicate(ctx, alert) {
+				continue
+			}
+
+			// Check if alert is in cooldown
+			inCooldown, err := s.cachePort.IsAlertInCooldown(ctx, rule.ID)
+			if err == nil && inCooldown {
+				continue
+			}
+
+			// Correlate the alert into an incident
+			incident, opened := s.correlate(ctx, alert)
+
 			alerts = append(alerts, alert)
 
 			// Save the alert
@@ -164,6 +192,8 @@ func (s *AlertServiceService) EvaluateAlerts(ctx context.Context, serviceName st
 				continue
 			}
 
+			s.saveIncident(ctx, incident, opened)
+
 			// Set cooldown
 			if rule.Cooldown > 0 {
 				until := time.Now().Add(time.Duration(rule.Cooldown) * time.Second)
@@ -184,6 +214,7 @@ func (s *AlertServiceService) EvaluateAlerts(ctx context.Context, serviceName st
 				logger.String("rule_name", rule.Name),
 				logger.String("service", serviceName),
 				logger.String("severity", rule.Severity),
+				logger.String("incident_id", alert.IncidentID),
 			)
 		}
 	}
@@ -231,6 +262,8 @@ func (s *AlertServiceService) evaluateRule(rule *domain.AlertRule, metrics map[s
 	}
 
 	// Create the alert
+	now := time.Now()
+	category := domain.AlertCategory(rule.Condition)
 	alert := &domain.Alert{
 		ID:           uuid.New().String(),
 		RuleID:       rule.ID,
@@ -241,8 +274,13 @@ func (s *AlertServiceService) evaluateRule(rule *domain.AlertRule, metrics map[s
 		Threshold:    threshold,
 		Status:       domain.AlertStatusFiring,
 		Message:      fmt.Sprintf("%s is %s (threshold: %s %s)", metricName, strconv.FormatFloat(value, 'f', 2, 64), operator, strconv.FormatFloat(threshold, 'f', 2, 64)),
-		FiredAt:      time.Now(),
+		FiredAt:      now,
 		Metadata:     domain.Metadata{"rule_name": rule.Name},
+
+		Category:        category,
+		Fingerprint:     domain.AlertFingerprint(category, rule.ServiceName, rule.ID),
+		OccurrenceCount: 1,
+		LastSeenAt:      now,
 	}
 
 	return alert
@@ -0,0 +1,44 @@
+-- Health Monitor Service Database Schema
+-- Alert deduplication and incident correlation
+
+-- Track repeated firings of the same alert instead of storing duplicates
+ALTER TABLE health_monitor_alerts ADD COLUMN IF NOT EXISTS category VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE health_monitor_alerts ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE health_monitor_alerts ADD COLUMN IF NOT EXISTS occurrence_count INT NOT NULL DEFAULT 1;
+ALTER TABLE health_monitor_alerts ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMPTZ;
+ALTER TABLE health_monitor_alerts ADD COLUMN IF NOT EXISTS incident_id VARCHAR(255);
+
+UPDATE health_monitor_alerts SET last_seen_at = fired_at WHERE last_seen_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_health_monitor_alerts_fingerprint_status
+ON health_monitor_alerts(fingerprint, status, last_seen_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_health_monitor_alerts_incident_id
+ON health_monitor_alerts(incident_id) WHERE incident_id IS NOT NULL;
+
+-- Create incidents table
+CREATE TABLE IF NOT EXISTS health_monitor_incidents (
+    id VARCHAR(255) PRIMARY KEY,
+    title VARCHAR(500) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'open',
+    severity VARCHAR(50) NOT NULL,
+    services JSONB NOT NULL DEFAULT '[]',
+    categories JSONB NOT NULL DEFAULT '[]',
+    alert_count INT NOT NULL DEFAULT 0,
+    occurrence_count INT NOT NULL DEFAULT 0,
+    opened_at TIMESTAMPTZ NOT NULL,
+    last_activity_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create indexes for incidents
+CREATE INDEX IF NOT EXISTS idx_health_monitor_incidents_status
+ON health_monitor_incidents(status, last_activity_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_health_monitor_incidents_services
+ON health_monitor_incidents USING GIN (services);
+
+CREATE INDEX IF NOT EXISTS idx_health_monitor_incidents_categories
+ON health_monitor_incidents USING GIN (categories);
@@ -35,6 +35,8 @@ type MetricsCollector struct {
 	AlertsResolved        *prometheus.CounterVec
 	ActiveAlerts          *prometheus.GaugeVec
 	AlertCooldowns        *prometheus.GaugeVec
+	AlertsDeduplicated    *prometheus.CounterVec
+	IncidentsOpened       *prometheus.CounterVec
 
 	// Outage metrics
 	OutagesDetected       *prometheus.CounterVec
@@ -181,6 +183,22 @@ func NewPrometheusCollector(prefix string) *MetricsCollector {
 			},
 			[]string{"rule_id"},
 		),
+		AlertsDeduplicated: promauto.NewCounterVec(
+			registry,
+			prometheus.CounterOpts{
+				Name: fmt.Sprintf("%s_alerts_deduplicated_total", prefix),
+				Help: "Total number of repeated alert firings collapsed into an existing alert",
+			},
+			[]string{"severity", "service"},
+		),
+		IncidentsOpened: promauto.NewCounterVec(
+			registry,
+			prometheus.CounterOpts{
+				Name: fmt.Sprintf("%s_incidents_opened_total", prefix),
+				Help: "Total number of incidents opened",
+			},
+			[]string{"severity"},
+		),
 		OutagesDetected: promauto.NewCounterVec(
 			registry,
 			prometheus.CounterOpts{
@@ -313,6 +331,16 @@ func (m *MetricsCollector) RecordAlertResolved(severity, serviceName string) {
 	m.ActiveAlerts.WithLabelValues(severity).Dec()
 }
 
+// RecordAlertDeduplicated records a repeated firing of an existing alert
+func (m *MetricsCollector) RecordAlertDeduplicated(severity, serviceName string) {
+	m.AlertsDeduplicated.WithLabelValues(severity, serviceName).Inc()
+}
+
+// RecordIncidentOpened records an opened incident
+func (m *MetricsCollector) RecordIncidentOpened(severity string) {
+	m.IncidentsOpened.WithLabelValues(severity).Inc()
+}
+
 // RecordOutageDetected records a detected outage
 func (m *MetricsCollector) RecordOutageDetected(serviceName, severity string) {
 	m.OutagesDetected.WithLabelValues(serviceName, severity).Inc()
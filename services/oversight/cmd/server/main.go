@@ -102,6 +102,11 @@ func main() {
 	// Start Kafka consumer in background
 	go startKafkaConsumer(context.Background(), kafkaReader, ingestionService, logger)
 
+	// Persist daily exchange scorecards in background
+	scorecardCtx, stopScorecards := context.WithCancel(context.Background())
+	defer stopScorecards()
+	go oversightService.RunScorecardSnapshots(scorecardCtx, time.Hour)
+
 	// Start HTTP server in goroutine
 	go func() {
 		logger.Info("HTTP server starting",
@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/csic/oversight/internal/core/domain"
 	"github.com/csic/oversight/internal/core/ports"
+	"github.com/csic/oversight/internal/core/services"
 	"go.uber.org/zap"
 )
 
@@ -58,6 +60,8 @@ func (a *HTTPServerAdapter) setupRoutes() {
 		r.Get("/", a.listExchanges)
 		r.Get("/{id}", a.getExchange)
 		r.Get("/{id}/health", a.getExchangeHealth)
+		r.Get("/{id}/scorecard", a.getExchangeScorecard)
+		r.Post("/{id}/compliance-events", a.recordComplianceEvent)
 		r.Put("/{id}/status", a.updateExchangeStatus)
 	})
 	
@@ -148,6 +152,74 @@ func (a *HTTPServerAdapter) getExchangeHealth(w http.ResponseWriter, r *http.Req
 	})
 }
 
+func (a *HTTPServerAdapter) getExchangeScorecard(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -29)
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := parseScorecardDate(v)
+		if err != nil {
+			a.respondError(w, http.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD or RFC3339")
+			return
+		}
+		from = parsed
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		parsed, err := parseScorecardDate(v)
+		if err != nil {
+			a.respondError(w, http.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD or RFC3339")
+			return
+		}
+		to = parsed
+	}
+	
+	report, err := a.service.GetScorecard(r.Context(), id, from, to)
+	if errors.Is(err, services.ErrInvalidScorecardPeriod) {
+		a.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		a.logger.Error("Failed to get scorecard", zap.String("exchange_id", id), zap.Error(err))
+		a.respondError(w, http.StatusInternalServerError, "Failed to get scorecard")
+		return
+	}
+	if report == nil {
+		a.respondError(w, http.StatusNotFound, "Exchange not found")
+		return
+	}
+	a.respondJSON(w, http.StatusOK, report)
+}
+
+func (a *HTTPServerAdapter) recordComplianceEvent(w http.ResponseWriter, r *http.Request) {
+	var event domain.ComplianceEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		a.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	event.ExchangeID = chi.URLParam(r, "id")
+	
+	err := a.service.RecordComplianceEvent(r.Context(), &event)
+	if errors.Is(err, services.ErrInvalidComplianceEvent) {
+		a.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		a.logger.Error("Failed to record compliance event", zap.String("exchange_id", event.ExchangeID), zap.Error(err))
+		a.respondError(w, http.StatusInternalServerError, "Failed to record compliance event")
+		return
+	}
+	a.respondJSON(w, http.StatusCreated, event)
+}
+
+// parseScorecardDate accepts a calendar date or an RFC3339 timestamp
+func parseScorecardDate(v string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
 func (a *HTTPServerAdapter) updateExchangeStatus(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	var req struct {
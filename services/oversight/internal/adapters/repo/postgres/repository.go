@@ -86,11 +86,36 @@ func (r *PostgresRepository) InitSchema(ctx context.Context) error {
 			timestamp TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,
 		
+		// Compliance events (SAR filings, audit findings)
+		`CREATE TABLE IF NOT EXISTS oversight_compliance_events (
+			id VARCHAR(36) PRIMARY KEY,
+			exchange_id VARCHAR(36) REFERENCES oversight_exchanges(id),
+			event_type VARCHAR(30) NOT NULL,
+			reference VARCHAR(255),
+			occurred_at TIMESTAMP NOT NULL,
+			recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		
+		// Daily compliance scorecards
+		`CREATE TABLE IF NOT EXISTS oversight_exchange_scorecards (
+			exchange_id VARCHAR(36) REFERENCES oversight_exchanges(id),
+			day DATE NOT NULL,
+			violation_count INTEGER DEFAULT 0,
+			sar_filings INTEGER DEFAULT 0,
+			audit_findings INTEGER DEFAULT 0,
+			uptime_percent DECIMAL(5,2) DEFAULT 100,
+			avg_latency_ms DECIMAL(10,2) DEFAULT 0,
+			compliance_score DECIMAL(5,2) DEFAULT 0,
+			computed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (exchange_id, day)
+		)`,
+		
 		// Indexes
 		`CREATE INDEX IF NOT EXISTS idx_health_exchange ON oversight_health_metrics(exchange_id, timestamp DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_anomalies_exchange ON oversight_anomalies(exchange_id, detected_at DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_anomalies_status ON oversight_anomalies(status)`,
 		`CREATE INDEX IF NOT EXISTS idx_trades_exchange_symbol ON oversight_trades(exchange_id, symbol, timestamp DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_compliance_events_exchange ON oversight_compliance_events(exchange_id, occurred_at DESC)`,
 	}
 	
 	for _, query := range queries {
@@ -431,3 +456,104 @@ func (r *PostgresRepository) GetMarketDepth(ctx context.Context, exchangeID, sym
 	
 	return &depth, nil
 }
+
+// Scorecard operations
+func (r *PostgresRepository) UpsertScorecard(ctx context.Context, scorecard *domain.ExchangeScorecard) error {
+	query := `INSERT INTO oversight_exchange_scorecards
+	(exchange_id, day, violation_count, sar_filings, audit_findings, uptime_percent, avg_latency_ms, compliance_score, computed_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (exchange_id, day) DO UPDATE SET
+		violation_count = EXCLUDED.violation_count,
+		sar_filings = EXCLUDED.sar_filings,
+		audit_findings = EXCLUDED.audit_findings,
+		uptime_percent = EXCLUDED.uptime_percent,
+		avg_latency_ms = EXCLUDED.avg_latency_ms,
+		compliance_score = EXCLUDED.compliance_score,
+		computed_at = EXCLUDED.computed_at`
+	
+	_, err := r.db.ExecContext(ctx, query,
+		scorecard.ExchangeID,
+		scorecard.Day,
+		scorecard.ViolationCount,
+		scorecard.SARFilings,
+		scorecard.AuditFindings,
+		scorecard.UptimePercent,
+		scorecard.AvgLatencyMs,
+		scorecard.ComplianceScore,
+		scorecard.ComputedAt,
+	)
+	return err
+}
+
+func (r *PostgresRepository) GetScorecards(ctx context.Context, exchangeID string, from, to time.Time) ([]*domain.ExchangeScorecard, error) {
+	query := `SELECT exchange_id, day, violation_count, sar_filings, audit_findings, uptime_percent,
+		avg_latency_ms, compliance_score, computed_at
+	FROM oversight_exchange_scorecards
+	WHERE exchange_id = $1 AND day BETWEEN $2 AND $3
+	ORDER BY day ASC`
+	
+	rows, err := r.db.QueryContext(ctx, query, exchangeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	var scorecards []*domain.ExchangeScorecard
+	for rows.Next() {
+		var sc domain.ExchangeScorecard
+		if err := rows.Scan(
+			&sc.ExchangeID,
+			&sc.Day,
+			&sc.ViolationCount,
+			&sc.SARFilings,
+			&sc.AuditFindings,
+			&sc.UptimePercent,
+			&sc.AvgLatencyMs,
+			&sc.ComplianceScore,
+			&sc.ComputedAt,
+		); err != nil {
+			return nil, err
+		}
+		scorecards = append(scorecards, &sc)
+	}
+	return scorecards, rows.Err()
+}
+
+func (r *PostgresRepository) RecordComplianceEvent(ctx context.Context, event *domain.ComplianceEvent) error {
+	query := `INSERT INTO oversight_compliance_events
+	(id, exchange_id, event_type, reference, occurred_at, recorded_at)
+	VALUES ($1, $2, $3, $4, $5, $6)`
+	
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.ExchangeID,
+		event.EventType,
+		event.Reference,
+		event.OccurredAt,
+		event.RecordedAt,
+	)
+	return err
+}
+
+func (r *PostgresRepository) CountComplianceEvents(ctx context.Context, exchangeID string, from, to time.Time) (map[domain.ComplianceEventType]int, error) {
+	query := `SELECT event_type, COUNT(*) FROM oversight_compliance_events
+	WHERE exchange_id = $1 AND occurred_at BETWEEN $2 AND $3
+	GROUP BY event_type`
+	
+	rows, err := r.db.QueryContext(ctx, query, exchangeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	counts := make(map[domain.ComplianceEventType]int)
+	for rows.Next() {
+		var eventType domain.ComplianceEventType
+		var count int
+		if err := rows.Scan(&eventType, &count); err != nil {
+			return nil, err
+		}
+		counts[eventType] = count
+	}
+	return counts, rows.Err()
+}
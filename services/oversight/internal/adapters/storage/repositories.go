@@ -698,11 +698,11 @@ func (r *HealthRepository) GetHealthStats(ctx context.Context, exchangeID string
 			AVG(error_rate) as avg_error,
 			AVG(uptime_percent) as avg_uptime
 		FROM %s
-		WHERE exchange_id = $1 AND updated_at >= NOW() - INTERVAL '%d seconds'
+		WHERE exchange_id = $1 AND updated_at >= NOW() - make_interval(secs => $2)
 		GROUP BY exchange_id
-	`, r.table, int(period.Seconds()))
+	`, r.table)
 
-	row := r.pool.QueryRow(ctx, query, exchangeID)
+	row := r.pool.QueryRow(ctx, query, exchangeID, period.Seconds())
 
 	var stats ports.HealthStats
 	var minScore, maxScore, avgLatency, avgError, avgUptime *float64
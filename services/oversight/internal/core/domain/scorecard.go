@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// ComplianceEventType identifies a compliance event counted in scorecards
+type ComplianceEventType string
+
+const (
+	ComplianceEventSARFiling    ComplianceEventType = "sar_filing"
+	ComplianceEventAuditFinding ComplianceEventType = "audit_finding"
+)
+
+// IsValid reports whether the event type is known
+func (t ComplianceEventType) IsValid() bool {
+	return t == ComplianceEventSARFiling || t == ComplianceEventAuditFinding
+}
+
+// ComplianceEvent records a SAR filing or audit finding against an exchange
+type ComplianceEvent struct {
+	ID         string              `json:"id" db:"id"`
+	ExchangeID string              `json:"exchange_id" db:"exchange_id"`
+	EventType  ComplianceEventType `json:"event_type" db:"event_type"`
+	Reference  string              `json:"reference,omitempty" db:"reference"`
+	OccurredAt time.Time           `json:"occurred_at" db:"occurred_at"`
+	RecordedAt time.Time           `json:"recorded_at" db:"recorded_at"`
+}
+
+// ExchangeScorecard is the compliance scorecard of an exchange for one UTC day
+type ExchangeScorecard struct {
+	ExchangeID      string    `json:"exchange_id" db:"exchange_id"`
+	Day             time.Time `json:"day" db:"day"`
+	ViolationCount  int       `json:"violation_count" db:"violation_count"`
+	SARFilings      int       `json:"sar_filings" db:"sar_filings"`
+	AuditFindings   int       `json:"audit_findings" db:"audit_findings"`
+	UptimePercent   float64   `json:"uptime_percent" db:"uptime_percent"`
+	AvgLatencyMs    float64   `json:"avg_latency_ms" db:"avg_latency_ms"`
+	ComplianceScore float64   `json:"compliance_score" db:"compliance_score"`
+	ComputedAt      time.Time `json:"computed_at" db:"computed_at"`
+}
+
+// ScorecardDay truncates t to the start of its UTC day
+func ScorecardDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CalculateScore derives the compliance score from the day's counters.
+// Each kind of deduction is capped so a single bad signal cannot zero the
+// score on its own.
+func (sc *ExchangeScorecard) CalculateScore() {
+	score := 100.0
+
+	// Market abuse violations
+	score -= math.Min(float64(sc.ViolationCount)*5, 40)
+
+	// Suspicious activity reports filed against the exchange
+	score -= math.Min(float64(sc.SARFilings)*10, 30)
+
+	// Audit findings
+	score -= math.Min(float64(sc.AuditFindings)*5, 20)
+
+	// Downtime, two points per percent below full uptime
+	score -= math.Min((100-sc.UptimePercent)*2, 30)
+
+	if score < 0 {
+		score = 0
+	}
+	if score > 100 {
+		score = 100
+	}
+
+	sc.ComplianceScore = math.Round(score*100) / 100
+}
+
+// TrendDirection describes how a score moved over a period
+type TrendDirection string
+
+const (
+	TrendImproving TrendDirection = "improving"
+	TrendStable    TrendDirection = "stable"
+	TrendDeclining TrendDirection = "declining"
+)
+
+// ScorecardTrend summarises the change of the compliance score over a period
+type ScorecardTrend struct {
+	Direction   TrendDirection `json:"direction"`
+	Change      float64        `json:"change"`        // last score minus first score
+	SlopePerDay float64        `json:"slope_per_day"` // least-squares slope
+}
+
+// ScorecardAggregates summarises the counters over a period
+type ScorecardAggregates struct {
+	Days               int     `json:"days"`
+	AverageScore       float64 `json:"average_score"`
+	MinScore           float64 `json:"min_score"`
+	MaxScore           float64 `json:"max_score"`
+	LatestScore        float64 `json:"latest_score"`
+	TotalViolations    int     `json:"total_violations"`
+	TotalSARFilings    int     `json:"total_sar_filings"`
+	TotalAuditFindings int     `json:"total_audit_findings"`
+	AverageUptime      float64 `json:"average_uptime_percent"`
+}
+
+// ScorecardSeries holds one value per day, index-aligned with Days, so each
+// field can be drawn directly as a sparkline
+type ScorecardSeries struct {
+	Days       []string  `json:"days"`
+	Scores     []float64 `json:"scores"`
+	Violations []int     `json:"violations"`
+	SARFilings []int     `json:"sar_filings"`
+	Findings   []int     `json:"audit_findings"`
+	Uptime     []float64 `json:"uptime_percent"`
+}
+
+// ScorecardReport is the scorecard history of an exchange over a period
+type ScorecardReport struct {
+	ExchangeID string               `json:"exchange_id"`
+	From       time.Time            `json:"from"`
+	To         time.Time            `json:"to"`
+	Trend      ScorecardTrend       `json:"trend"`
+	Aggregates ScorecardAggregates  `json:"aggregates"`
+	Series     ScorecardSeries      `json:"series"`
+	Scorecards []*ExchangeScorecard `json:"scorecards"`
+}
+
+// trendThreshold is the daily slope below which a score counts as stable
+const trendThreshold = 0.5
+
+// NewScorecardReport builds the trend, aggregates and series of scorecards,
+// which must be ordered by day
+func NewScorecardReport(exchangeID string, from, to time.Time, scorecards []*ExchangeScorecard) *ScorecardReport {
+	report := &ScorecardReport{
+		ExchangeID: exchangeID,
+		From:       from,
+		To:         to,
+		Trend:      ScorecardTrend{Direction: TrendStable},
+		Series: ScorecardSeries{
+			Days:       make([]string, 0, len(scorecards)),
+			Scores:     make([]float64, 0, len(scorecards)),
+			Violations: make([]int, 0, len(scorecards)),
+			SARFilings: make([]int, 0, len(scorecards)),
+			Findings:   make([]int, 0, len(scorecards)),
+			Uptime:     make([]float64, 0, len(scorecards)),
+		},
+		Scorecards: scorecards,
+	}
+	if len(scorecards) == 0 {
+		return report
+	}
+
+	agg := &report.Aggregates
+	agg.Days = len(scorecards)
+	agg.MinScore = scorecards[0].ComplianceScore
+	agg.MaxScore = scorecards[0].ComplianceScore
+
+	var scoreSum, uptimeSum float64
+	for _, sc := range scorecards {
+		report.Series.Days = append(report.Series.Days, sc.Day.Format("2006-01-02"))
+		report.Series.Scores = append(report.Series.Scores, sc.ComplianceScore)
+		report.Series.Violations = append(report.Series.Violations, sc.ViolationCount)
+		report.Series.SARFilings = append(report.Series.SARFilings, sc.SARFilings)
+		report.Series.Findings = append(report.Series.Findings, sc.AuditFindings)
+		report.Series.Uptime = append(report.Series.Uptime, sc.UptimePercent)
+
+		scoreSum += sc.ComplianceScore
+		uptimeSum += sc.UptimePercent
+		agg.MinScore = math.Min(agg.MinScore, sc.ComplianceScore)
+		agg.MaxScore = math.Max(agg.MaxScore, sc.ComplianceScore)
+		agg.TotalViolations += sc.ViolationCount
+		agg.TotalSARFilings += sc.SARFilings
+		agg.TotalAuditFindings += sc.AuditFindings
+	}
+
+	n := float64(len(scorecards))
+	agg.AverageScore = roundTo2(scoreSum / n)
+	agg.AverageUptime = roundTo2(uptimeSum / n)
+	agg.LatestScore = scorecards[len(scorecards)-1].ComplianceScore
+
+	report.Trend.Change = roundTo2(agg.LatestScore - scorecards[0].ComplianceScore)
+	report.Trend.SlopePerDay = roundTo2(scoreSlope(scorecards))
+	switch {
+	case report.Trend.SlopePerDay >= trendThreshold:
+		report.Trend.Direction = TrendImproving
+	case report.Trend.SlopePerDay <= -trendThreshold:
+		report.Trend.Direction = TrendDeclining
+	}
+
+	return report
+}
+
+// scoreSlope returns the least-squares slope of the score per day
+func scoreSlope(scorecards []*ExchangeScorecard) float64 {
+	if len(scorecards) < 2 {
+		return 0
+	}
+
+	origin := scorecards[0].Day
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sc := range scorecards {
+		x := sc.Day.Sub(origin).Hours() / 24
+		y := sc.ComplianceScore
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	n := float64(len(scorecards))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func roundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -63,6 +63,13 @@ type OversightService interface {
 
 	// GenerateRegulatoryReport generates a regulatory report for the specified period
 	GenerateRegulatoryReport(ctx context.Context, start, end time.Time, exchangeID string) (*domain.RegulatoryReport, error)
+
+	// GetScorecard retrieves the daily compliance scorecards of an exchange
+	// for a period, with their trend and aggregates
+	GetScorecard(ctx context.Context, exchangeID string, from, to time.Time) (*domain.ScorecardReport, error)
+
+	// RecordComplianceEvent records a SAR filing or audit finding against an exchange
+	RecordComplianceEvent(ctx context.Context, event *domain.ComplianceEvent) error
 }
 
 // AlertRepository is the output port for persisting alerts
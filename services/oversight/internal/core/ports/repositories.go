@@ -31,6 +31,12 @@ type OversightRepository interface {
 	GetRecentTrades(ctx context.Context, exchangeID, symbol string, window time.Duration) ([]*domain.Trade, error)
 	RecordMarketDepth(ctx context.Context, depth *domain.MarketDepth) error
 	GetMarketDepth(ctx context.Context, exchangeID, symbol string, timestamp time.Time) (*domain.MarketDepth, error)
+
+	// Scorecard operations
+	UpsertScorecard(ctx context.Context, scorecard *domain.ExchangeScorecard) error
+	GetScorecards(ctx context.Context, exchangeID string, from, to time.Time) ([]*domain.ExchangeScorecard, error)
+	RecordComplianceEvent(ctx context.Context, event *domain.ComplianceEvent) error
+	CountComplianceEvents(ctx context.Context, exchangeID string, from, to time.Time) (map[domain.ComplianceEventType]int, error)
 }
 
 // OversightEventPort defines the event publishing operations
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/csic/oversight/internal/core/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxScorecardDays bounds the period of a scorecard request
+const MaxScorecardDays = 366
+
+// ErrInvalidScorecardPeriod is returned for an empty or overlong period
+var ErrInvalidScorecardPeriod = errors.New("invalid scorecard period")
+
+// ErrInvalidComplianceEvent is returned for a compliance event that cannot be recorded
+var ErrInvalidComplianceEvent = errors.New("invalid compliance event")
+
+// BuildDailyScorecard computes and persists the scorecard of an exchange for
+// the UTC day containing day. Today's scorecard is provisional and is
+// recomputed on every call until the day is over.
+func (s *OversightServiceImpl) BuildDailyScorecard(ctx context.Context, exchangeID string, day time.Time) (*domain.ExchangeScorecard, error) {
+	start := domain.ScorecardDay(day)
+	end := start.Add(24*time.Hour - time.Nanosecond)
+
+	anomalies, err := s.repo.GetAnomalies(ctx, exchangeID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anomalies: %w", err)
+	}
+
+	events, err := s.repo.CountComplianceEvents(ctx, exchangeID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count compliance events: %w", err)
+	}
+
+	metrics, err := s.repo.GetHealthMetrics(ctx, exchangeID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health metrics: %w", err)
+	}
+
+	scorecard := &domain.ExchangeScorecard{
+		ExchangeID:    exchangeID,
+		Day:           start,
+		SARFilings:    events[domain.ComplianceEventSARFiling],
+		AuditFindings: events[domain.ComplianceEventAuditFinding],
+		UptimePercent: 100,
+		ComputedAt:    time.Now().UTC(),
+	}
+
+	// Dismissed anomalies were false positives and are not violations
+	for _, anomaly := range anomalies {
+		if anomaly.Status != "dismissed" {
+			scorecard.ViolationCount++
+		}
+	}
+
+	if len(metrics) > 0 {
+		var uptime, latency float64
+		for _, m := range metrics {
+			uptime += m.UptimePercent
+			latency += float64(m.LatencyMs)
+		}
+		scorecard.UptimePercent = roundTo2(uptime / float64(len(metrics)))
+		scorecard.AvgLatencyMs = roundTo2(latency / float64(len(metrics)))
+	}
+
+	scorecard.CalculateScore()
+
+	if err := s.repo.UpsertScorecard(ctx, scorecard); err != nil {
+		return nil, fmt.Errorf("failed to save scorecard: %w", err)
+	}
+
+	return scorecard, nil
+}
+
+// GetScorecard retrieves the daily scorecards of an exchange between from and
+// to, both inclusive UTC days. Days without a stored scorecard are computed
+// and stored first, so the series has one point per day since the exchange
+// was registered.
+func (s *OversightServiceImpl) GetScorecard(ctx context.Context, exchangeID string, from, to time.Time) (*domain.ScorecardReport, error) {
+	from = domain.ScorecardDay(from)
+	to = domain.ScorecardDay(to)
+	today := domain.ScorecardDay(time.Now())
+	if to.After(today) {
+		to = today
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidScorecardPeriod)
+	}
+	if int(to.Sub(from).Hours()/24)+1 > MaxScorecardDays {
+		return nil, fmt.Errorf("%w: at most %d days", ErrInvalidScorecardPeriod, MaxScorecardDays)
+	}
+
+	exchange, err := s.repo.GetExchange(ctx, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	if exchange == nil {
+		return nil, nil
+	}
+
+	stored, err := s.repo.GetScorecards(ctx, exchangeID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scorecards: %w", err)
+	}
+	byDay := make(map[time.Time]*domain.ExchangeScorecard, len(stored))
+	for _, sc := range stored {
+		byDay[domain.ScorecardDay(sc.Day)] = sc
+	}
+
+	first := from
+	if registered := domain.ScorecardDay(exchange.CreatedAt); !exchange.CreatedAt.IsZero() && registered.After(first) {
+		first = registered
+	}
+
+	scorecards := make([]*domain.ExchangeScorecard, 0, int(to.Sub(first).Hours()/24)+1)
+	for day := first; !day.After(to); day = day.AddDate(0, 0, 1) {
+		sc, ok := byDay[day]
+		if !ok || day.Equal(today) {
+			sc, err = s.BuildDailyScorecard(ctx, exchangeID, day)
+			if err != nil {
+				return nil, err
+			}
+		}
+		scorecards = append(scorecards, sc)
+	}
+
+	return domain.NewScorecardReport(exchangeID, from, to, scorecards), nil
+}
+
+// RecordComplianceEvent records a SAR filing or audit finding against an
+// exchange and refreshes the scorecard of the day it occurred
+func (s *OversightServiceImpl) RecordComplianceEvent(ctx context.Context, event *domain.ComplianceEvent) error {
+	if event.ExchangeID == "" {
+		return fmt.Errorf("%w: exchange_id is required", ErrInvalidComplianceEvent)
+	}
+	if !event.EventType.IsValid() {
+		return fmt.Errorf("%w: unknown event_type %q", ErrInvalidComplianceEvent, event.EventType)
+	}
+
+	now := time.Now().UTC()
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+	if event.OccurredAt.After(now) {
+		return fmt.Errorf("%w: occurred_at is in the future", ErrInvalidComplianceEvent)
+	}
+	event.ID = uuid.New().String()
+	event.RecordedAt = now
+
+	if err := s.repo.RecordComplianceEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to record compliance event: %w", err)
+	}
+
+	if _, err := s.BuildDailyScorecard(ctx, event.ExchangeID, event.OccurredAt); err != nil {
+		s.logger.Warn("Failed to refresh scorecard",
+			zap.String("exchange_id", event.ExchangeID),
+			zap.Error(err),
+		)
+	}
+
+	return nil
+}
+
+// RunScorecardSnapshots persists the scorecards of every exchange at each
+// interval until ctx is done. Yesterday is rebuilt as well as today so the
+// final counts of a day are captured once it closes.
+func (s *OversightServiceImpl) RunScorecardSnapshots(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.snapshotScorecards(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *OversightServiceImpl) snapshotScorecards(ctx context.Context) {
+	exchanges, err := s.repo.ListExchanges(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list exchanges for scorecards", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	for _, exchange := range exchanges {
+		for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+			if _, err := s.BuildDailyScorecard(ctx, exchange.ID, day); err != nil {
+				s.logger.Warn("Failed to build scorecard",
+					zap.String("exchange_id", exchange.ID),
+					zap.Time("day", domain.ScorecardDay(day)),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+func roundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
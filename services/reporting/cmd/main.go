@@ -11,12 +11,12 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/csic-platform/services/reporting/internal/adapter/formatter"
-	"github.com/csic-platform/services/reporting/internal/adapter/generator"
-	"github.com/csic-platform/services/reporting/internal/adapter/repository"
-	"github.com/csic-platform/services/reporting/internal/adapter/storage"
-	"github.com/csic-platform/services/reporting/internal/core/service"
-	"github.com/csic-platform/services/reporting/internal/handler"
+	"github.com/reporting-service/reporting/internal/adapter/formatter"
+	"github.com/reporting-service/reporting/internal/adapter/generator"
+	"github.com/reporting-service/reporting/internal/adapter/repository"
+	"github.com/reporting-service/reporting/internal/adapter/storage"
+	"github.com/reporting-service/reporting/internal/core/service"
+	"github.com/reporting-service/reporting/internal/handler"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	_ "github.com/lib/pq"
@@ -10,7 +10,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/reporting-service/reporting/internal/adapters/fiu"
 	"github.com/reporting-service/reporting/internal/adapters/handler/httpHandler"
+	"github.com/reporting-service/reporting/internal/adapters/notify"
 	"github.com/reporting-service/reporting/internal/core/services"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -23,6 +25,7 @@ type Config struct {
 	App      AppConfig      `mapstructure:"app"`
 	Logging  LoggingConfig  `mapstructure:"logging"`
 	Auth     AuthConfig     `mapstructure:"auth"`
+	Filing   FilingConfig   `mapstructure:"filing"`
 }
 
 // AppConfig contains application-level settings.
@@ -49,6 +52,33 @@ type AuthConfig struct {
 	Audience   string `mapstructure:"audience"`
 }
 
+// FilingConfig contains regulatory filing settings.
+type FilingConfig struct {
+	SAR SARFilingConfig `mapstructure:"sar"`
+}
+
+// SARFilingConfig contains SAR deadline, reminder and FIU format settings.
+type SARFilingConfig struct {
+	FilingPeriodDays int            `mapstructure:"filing_period_days"`
+	ReminderDays     []int          `mapstructure:"reminder_days"`
+	Reminders        ReminderConfig `mapstructure:"reminders"`
+	FIU              FIUConfig      `mapstructure:"fiu"`
+}
+
+// ReminderConfig contains settings for SAR deadline reminders.
+type ReminderConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Interval   int    `mapstructure:"interval"` // minutes
+	WebhookURL string `mapstructure:"webhook_url"`
+}
+
+// FIUConfig identifies the reporting entity in goAML reports.
+type FIUConfig struct {
+	ReportingEntityID string `mapstructure:"reporting_entity_id"`
+	ReportCode        string `mapstructure:"report_code"`
+	CurrencyCode      string `mapstructure:"currency_code"`
+}
+
 func main() {
 	logger, err := initLogger()
 	if err != nil {
@@ -74,10 +104,39 @@ func main() {
 		nil, // filingRepo
 		nil, // transactionRepo
 		nil, // screeningRepo
+		nil, // emissionsRepo
+	)
+
+	reminderOffsets := make([]time.Duration, 0, len(cfg.Filing.SAR.ReminderDays))
+	for _, days := range cfg.Filing.SAR.ReminderDays {
+		reminderOffsets = append(reminderOffsets, time.Duration(days)*24*time.Hour)
+	}
+	sarWorkflowService := services.NewSARWorkflowService(
+		reportingService,
+		nil, // flagged activity provider
+		fiu.NewGoAMLRenderer(fiu.GoAMLConfig{
+			ReportingEntityID: cfg.Filing.SAR.FIU.ReportingEntityID,
+			ReportCode:        cfg.Filing.SAR.FIU.ReportCode,
+			CurrencyCode:      cfg.Filing.SAR.FIU.CurrencyCode,
+		}),
+		notify.NewWebhookNotifier(cfg.Filing.SAR.Reminders.WebhookURL, 10*time.Second),
+		services.SARWorkflowOptions{
+			FilingPeriod:    time.Duration(cfg.Filing.SAR.FilingPeriodDays) * 24 * time.Hour,
+			ReminderOffsets: reminderOffsets,
+		},
 	)
 
+	reminderCtx, stopReminders := context.WithCancel(context.Background())
+	defer stopReminders()
+	if cfg.Filing.SAR.Reminders.Enabled {
+		go sarWorkflowService.StartReminders(reminderCtx, time.Duration(cfg.Filing.SAR.Reminders.Interval)*time.Minute, func(err error) {
+			logger.Error("Failed to send SAR deadline reminders", zap.Error(err))
+		})
+	}
+
 	reportingHandler := httpHandler.NewReportingHandler(reportingService)
-	router := initRouter(reportingHandler, logger)
+	sarWorkflowHandler := httpHandler.NewSARWorkflowHandler(sarWorkflowService)
+	router := initRouter(reportingHandler, sarWorkflowHandler, logger)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.App.Host, cfg.App.Port),
@@ -153,6 +212,13 @@ func loadConfig() (*Config, error) {
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.output", "stdout")
 
+	v.SetDefault("filing.sar.filing_period_days", 30)
+	v.SetDefault("filing.sar.reminder_days", []int{7, 3, 1})
+	v.SetDefault("filing.sar.reminders.enabled", false)
+	v.SetDefault("filing.sar.reminders.interval", 60)
+	v.SetDefault("filing.sar.fiu.report_code", "STR")
+	v.SetDefault("filing.sar.fiu.currency_code", "USD")
+
 	v.SetEnvPrefix("REPORTING")
 	v.AutomaticEnv()
 
@@ -175,7 +241,7 @@ func loadConfig() (*Config, error) {
 	return &cfg, nil
 }
 
-func initRouter(handler *httpHandler.ReportingHandler, logger *zap.Logger) *gin.Engine {
+func initRouter(handler *httpHandler.ReportingHandler, sarHandler *httpHandler.SARWorkflowHandler, logger *zap.Logger) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
@@ -189,6 +255,7 @@ func initRouter(handler *httpHandler.ReportingHandler, logger *zap.Logger) *gin.
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	handler.RegisterRoutes(router)
+	sarHandler.RegisterRoutes(router)
 
 	return router
 }
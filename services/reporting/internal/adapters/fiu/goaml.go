@@ -0,0 +1,145 @@
+package fiu
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/ports"
+)
+
+// goAMLDateFormat is the date-time format of goAML reports.
+const goAMLDateFormat = "2006-01-02T15:04:05"
+
+// GoAMLConfig identifies the reporting entity to the FIU.
+type GoAMLConfig struct {
+	ReportingEntityID string // rentity_id assigned by the FIU
+	ReportCode        string // STR or SAR, depending on the FIU
+	SubmissionCode    string // E for electronic submission
+	CurrencyCode      string // local currency of amount_local
+}
+
+// GoAMLRenderer renders SARs as goAML XML reports, the format used by most
+// FIUs running the UNODC goAML system.
+type GoAMLRenderer struct {
+	cfg GoAMLConfig
+	now func() time.Time
+}
+
+// NewGoAMLRenderer creates a new GoAMLRenderer.
+func NewGoAMLRenderer(cfg GoAMLConfig) *GoAMLRenderer {
+	if cfg.ReportCode == "" {
+		cfg.ReportCode = "STR"
+	}
+	if cfg.SubmissionCode == "" {
+		cfg.SubmissionCode = "E"
+	}
+	if cfg.CurrencyCode == "" {
+		cfg.CurrencyCode = "USD"
+	}
+	return &GoAMLRenderer{cfg: cfg, now: time.Now}
+}
+
+type goAMLReport struct {
+	XMLName           xml.Name           `xml:"report"`
+	RentityID         string             `xml:"rentity_id"`
+	SubmissionCode    string             `xml:"submission_code"`
+	ReportCode        string             `xml:"report_code"`
+	EntityReference   string             `xml:"entity_reference"`
+	SubmissionDate    string             `xml:"submission_date"`
+	CurrencyCodeLocal string             `xml:"currency_code_local"`
+	Reason            string             `xml:"reason"`
+	Action            string             `xml:"action,omitempty"`
+	Transactions      []goAMLTransaction `xml:"transaction"`
+	Activity          *goAMLActivity     `xml:"activity,omitempty"`
+	Indicators        []string           `xml:"report_indicators>indicator"`
+}
+
+type goAMLTransaction struct {
+	TransactionNumber      string `xml:"transactionnumber"`
+	TransactionDescription string `xml:"transaction_description,omitempty"`
+	DateTransaction        string `xml:"date_transaction"`
+	AmountLocal            string `xml:"amount_local"`
+	Comments               string `xml:"comments,omitempty"`
+}
+
+type goAMLActivity struct {
+	ReportParties []goAMLReportParty `xml:"report_parties>report_party"`
+}
+
+type goAMLReportParty struct {
+	Entity       goAMLEntity `xml:"entity"`
+	Significance int         `xml:"significance"`
+	Reason       string      `xml:"reason,omitempty"`
+}
+
+type goAMLEntity struct {
+	Name                string `xml:"name"`
+	IncorporationNumber string `xml:"incorporation_number,omitempty"`
+	Comments            string `xml:"comments,omitempty"`
+}
+
+// Render renders the SAR as a goAML report. Flagged transactions become
+// transactions; a SAR drafted only from violations reports the subject as
+// the party of an activity instead.
+func (r *GoAMLRenderer) Render(sar *domain.SAR) ([]byte, error) {
+	report := goAMLReport{
+		RentityID:         r.cfg.ReportingEntityID,
+		SubmissionCode:    r.cfg.SubmissionCode,
+		ReportCode:        r.cfg.ReportCode,
+		EntityReference:   sar.ReportNumber,
+		SubmissionDate:    r.now().UTC().Format(goAMLDateFormat),
+		CurrencyCodeLocal: r.cfg.CurrencyCode,
+		Reason:            sar.Narrative,
+		Indicators:        sar.RiskIndicators,
+	}
+	if sar.NarrativeFields != nil {
+		report.Action = sar.NarrativeFields.ActionsTaken
+	}
+
+	for _, source := range sar.Sources {
+		if source.Type != domain.SARSourceTransaction {
+			continue
+		}
+		comments := ""
+		if source.Asset != "" {
+			comments = fmt.Sprintf("%s %s", source.Amount, source.Asset)
+		}
+		report.Transactions = append(report.Transactions, goAMLTransaction{
+			TransactionNumber:      source.ID,
+			TransactionDescription: source.Description,
+			DateTransaction:        source.OccurredAt.UTC().Format(goAMLDateFormat),
+			AmountLocal:            source.AmountUSD.StringFixed(2),
+			Comments:               comments,
+		})
+	}
+
+	// goAML reports carry either transactions or an activity, not both
+	if len(report.Transactions) == 0 {
+		report.Activity = &goAMLActivity{
+			ReportParties: []goAMLReportParty{{
+				Entity: goAMLEntity{
+					Name:                sar.SubjectName,
+					IncorporationNumber: sar.SubjectID,
+					Comments:            sar.SubjectType,
+				},
+				Significance: 10,
+				Reason:       sar.SuspiciousActivity,
+			}},
+		}
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// ContentType returns the media type of rendered reports.
+func (r *GoAMLRenderer) ContentType() string {
+	return "application/xml"
+}
+
+var _ ports.FIUReportRenderer = (*GoAMLRenderer)(nil)
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,6 +11,7 @@ import (
 	"github.com/reporting-service/reporting/internal/core/ports"
 	"github.com/reporting-service/reporting/internal/core/services"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 // ReportingHandler handles HTTP requests for reporting operations.
@@ -61,6 +64,7 @@ func (h *ReportingHandler) RegisterRoutes(router *gin.Engine) {
 
 		// Report generation endpoints
 		v1.POST("/reports", h.GenerateComplianceReport)
+		v1.POST("/reports/emissions/quarterly", h.GenerateQuarterlyEmissionsReport)
 		v1.GET("/reports", h.ListReports)
 		v1.GET("/reports/:id", h.GetReport)
 
@@ -91,20 +95,20 @@ func (h *ReportingHandler) ReadinessCheck(c *gin.Context) {
 
 // CreateSARRequest represents the request body for creating a SAR.
 type CreateSARRequest struct {
-	SubjectID         string   `json:"subject_id" binding:"required"`
-	SubjectType       string   `json:"subject_type" binding:"required"`
-	SubjectName       string   `json:"subject_name" binding:"required"`
-	SuspiciousActivity string  `json:"suspicious_activity" binding:"required"`
-	ActivityDate      string   `json:"activity_date" binding:"required"`
-	DollarAmount      float64  `json:"dollar_amount" binding:"required,gt=0"`
-	Currency          string   `json:"currency"`
-	TransactionCount  int      `json:"transaction_count"`
-	Narrative         string   `json:"narrative" binding:"required"`
-	RiskIndicators    []string `json:"risk_indicators"`
-	FilingInstitution string   `json:"filing_institution" binding:"required"`
-	ReporterID        string   `json:"reporter_id" binding:"required"`
-	FieldOffice       string   `json:"field_office"`
-	OriginatingAgency string   `json:"originating_agency"`
+	SubjectID          string          `json:"subject_id" binding:"required"`
+	SubjectType        string          `json:"subject_type" binding:"required"`
+	SubjectName        string          `json:"subject_name" binding:"required"`
+	SuspiciousActivity string          `json:"suspicious_activity" binding:"required"`
+	ActivityDate       string          `json:"activity_date" binding:"required"`
+	DollarAmount       decimal.Decimal `json:"dollar_amount"`
+	Currency           string          `json:"currency"`
+	TransactionCount   int             `json:"transaction_count"`
+	Narrative          string          `json:"narrative" binding:"required"`
+	RiskIndicators     []string        `json:"risk_indicators"`
+	FilingInstitution  string          `json:"filing_institution" binding:"required"`
+	ReporterID         string          `json:"reporter_id" binding:"required"`
+	FieldOffice        string          `json:"field_office"`
+	OriginatingAgency  string          `json:"originating_agency"`
 }
 
 // CreateSAR handles POST /api/v1/sar
@@ -115,6 +119,11 @@ func (h *ReportingHandler) CreateSAR(c *gin.Context) {
 		return
 	}
 
+	if !req.DollarAmount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dollar_amount must be a positive number"})
+		return
+	}
+
 	activityDate, err := time.Parse(time.RFC3339, req.ActivityDate)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity_date format"})
@@ -162,6 +171,13 @@ func (h *ReportingHandler) ListSARs(c *gin.Context) {
 		filter.ReporterID = reporterID
 	}
 
+	minAmount, maxAmount, err := amountRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.MinAmount, filter.MaxAmount = minAmount, maxAmount
+
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil {
 			filter.Limit = limit
@@ -272,8 +288,8 @@ type CreateCTRRequest struct {
 	PersonAddress   domain.Address  `json:"person_address" binding:"required"`
 	AccountNumber   string          `json:"account_number" binding:"required"`
 	TransactionType string          `json:"transaction_type" binding:"required"`
-	CashInAmount    float64         `json:"cash_in_amount"`
-	CashOutAmount   float64         `json:"cash_out_amount"`
+	CashInAmount    decimal.Decimal `json:"cash_in_amount"`
+	CashOutAmount   decimal.Decimal `json:"cash_out_amount"`
 	Currency        string          `json:"currency"`
 	TransactionDate string          `json:"transaction_date" binding:"required"`
 	MethodReceived  string          `json:"method_received" binding:"required"`
@@ -305,7 +321,7 @@ func (h *ReportingHandler) CreateCTR(c *gin.Context) {
 		TransactionType:  req.TransactionType,
 		CashInAmount:     req.CashInAmount,
 		CashOutAmount:    req.CashOutAmount,
-		TotalAmount:      req.CashInAmount + req.CashOutAmount,
+		TotalAmount:      req.CashInAmount.Add(req.CashOutAmount),
 		Currency:         req.Currency,
 		TransactionDate:  transactionDate,
 		MethodReceived:   req.MethodReceived,
@@ -334,6 +350,13 @@ func (h *ReportingHandler) ListCTRs(c *gin.Context) {
 		filter.PersonID = personID
 	}
 
+	minAmount, maxAmount, err := amountRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.MinAmount, filter.MaxAmount = minAmount, maxAmount
+
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil {
 			filter.Limit = limit
@@ -683,6 +706,41 @@ func (h *ReportingHandler) GenerateComplianceReport(c *gin.Context) {
 	c.JSON(http.StatusCreated, report)
 }
 
+// GenerateQuarterlyEmissionsReportRequest represents the request body for generating an emissions report.
+type GenerateQuarterlyEmissionsReportRequest struct {
+	Year        int    `json:"year" binding:"required"`
+	Quarter     int    `json:"quarter" binding:"required,min=1,max=4"`
+	GeneratedBy string `json:"generated_by" binding:"required"`
+}
+
+// GenerateQuarterlyEmissionsReport handles POST /api/v1/reports/emissions/quarterly
+func (h *ReportingHandler) GenerateQuarterlyEmissionsReport(c *gin.Context) {
+	var req GenerateQuarterlyEmissionsReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.reportingService.GenerateQuarterlyEmissionsReport(
+		c.Request.Context(),
+		req.Year,
+		req.Quarter,
+		req.GeneratedBy,
+	)
+
+	if err != nil {
+		switch err {
+		case services.ErrInvalidQuarter:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
 // ListReports handles GET /api/v1/reports
 func (h *ReportingHandler) ListReports(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
@@ -699,3 +757,30 @@ func (h *ReportingHandler) GetReport(c *gin.Context) {
 func (h *ReportingHandler) ListFilings(c *gin.Context) {
 	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
 }
+
+// amountRange parses the optional min_amount and max_amount query parameters
+// as exact decimals. A bound that is not given is nil.
+func amountRange(c *gin.Context) (min, max *decimal.Decimal, err error) {
+	if min, err = queryAmount(c, "min_amount"); err != nil {
+		return nil, nil, err
+	}
+	if max, err = queryAmount(c, "max_amount"); err != nil {
+		return nil, nil, err
+	}
+	if min != nil && max != nil && min.GreaterThan(*max) {
+		return nil, nil, errors.New("min_amount must not exceed max_amount")
+	}
+	return min, max, nil
+}
+
+func queryAmount(c *gin.Context, param string) (*decimal.Decimal, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return nil, nil
+	}
+	amount, err := decimal.NewFromString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a decimal number", param)
+	}
+	return &amount, nil
+}
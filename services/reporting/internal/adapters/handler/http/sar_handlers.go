@@ -0,0 +1,179 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/services"
+)
+
+// SARWorkflowHandler handles HTTP requests for the SAR filing workflow.
+type SARWorkflowHandler struct {
+	workflowService *services.SARWorkflowService
+}
+
+// NewSARWorkflowHandler creates a new SARWorkflowHandler.
+func NewSARWorkflowHandler(workflowService *services.SARWorkflowService) *SARWorkflowHandler {
+	return &SARWorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+// RegisterRoutes registers the SAR workflow routes.
+func (h *SARWorkflowHandler) RegisterRoutes(router *gin.Engine) {
+	sar := router.Group("/api/v1/sar")
+	{
+		sar.POST("/drafts", h.CreateDraftFromFlags)
+		sar.GET("/deadlines", h.ListDeadlines)
+		sar.PUT("/:id/narrative", h.UpdateNarrative)
+		sar.GET("/:id/fiu-report", h.GetFIUReport)
+		sar.POST("/:id/acknowledge", h.AcknowledgeSAR)
+	}
+}
+
+// CreateDraftSARRequest represents the request body for drafting a SAR from flagged activity.
+type CreateDraftSARRequest struct {
+	Sources           []services.SARSourceRef `json:"sources" binding:"required,min=1,dive"`
+	SubjectID         string                  `json:"subject_id"`
+	SubjectType       string                  `json:"subject_type" binding:"required"`
+	SubjectName       string                  `json:"subject_name"`
+	FilingInstitution string                  `json:"filing_institution" binding:"required"`
+	ReporterID        string                  `json:"reporter_id" binding:"required"`
+}
+
+// CreateDraftFromFlags handles POST /api/v1/sar/drafts
+func (h *SARWorkflowHandler) CreateDraftFromFlags(c *gin.Context) {
+	var req CreateDraftSARRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sar, err := h.workflowService.CreateDraftFromFlags(c.Request.Context(), services.DraftSARRequest{
+		Sources:           req.Sources,
+		SubjectID:         req.SubjectID,
+		SubjectType:       req.SubjectType,
+		SubjectName:       req.SubjectName,
+		FilingInstitution: req.FilingInstitution,
+		ReporterID:        req.ReporterID,
+	})
+	if err != nil {
+		writeSARWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, sar)
+}
+
+// ListDeadlines handles GET /api/v1/sar/deadlines
+func (h *SARWorkflowHandler) ListDeadlines(c *gin.Context) {
+	withinDays := 7
+	if daysStr := c.Query("within_days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "within_days must be a non-negative integer"})
+			return
+		}
+		withinDays = days
+	}
+
+	sars, err := h.workflowService.ListDeadlines(c.Request.Context(), time.Duration(withinDays)*24*time.Hour)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":        sars,
+		"total":       len(sars),
+		"within_days": withinDays,
+	})
+}
+
+// UpdateNarrative handles PUT /api/v1/sar/:id/narrative
+func (h *SARWorkflowHandler) UpdateNarrative(c *gin.Context) {
+	var narrative domain.SARNarrative
+	if err := c.ShouldBindJSON(&narrative); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sar, err := h.workflowService.UpdateNarrative(c.Request.Context(), c.Param("id"), narrative)
+	if err != nil {
+		writeSARWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sar":     sar,
+		"missing": sar.NarrativeFields.Missing(),
+	})
+}
+
+// GetFIUReport handles GET /api/v1/sar/:id/fiu-report
+func (h *SARWorkflowHandler) GetFIUReport(c *gin.Context) {
+	id := c.Param("id")
+
+	data, contentType, err := h.workflowService.RenderFIUReport(c.Request.Context(), id)
+	if err != nil {
+		writeSARWorkflowError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "sar-"+id+".xml"))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// AcknowledgeSARRequest represents the request body for recording an FIU acknowledgement.
+type AcknowledgeSARRequest struct {
+	Reference      string `json:"reference" binding:"required"`
+	AcknowledgedAt string `json:"acknowledged_at"`
+}
+
+// AcknowledgeSAR handles POST /api/v1/sar/:id/acknowledge
+func (h *SARWorkflowHandler) AcknowledgeSAR(c *gin.Context) {
+	var req AcknowledgeSARRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	acknowledgedAt := time.Now().UTC()
+	if req.AcknowledgedAt != "" {
+		parsed, err := time.Parse(time.RFC3339, req.AcknowledgedAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid acknowledged_at format"})
+			return
+		}
+		acknowledgedAt = parsed
+	}
+
+	sar, err := h.workflowService.Acknowledge(c.Request.Context(), c.Param("id"), req.Reference, acknowledgedAt)
+	if err != nil {
+		writeSARWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sar)
+}
+
+// writeSARWorkflowError maps SAR workflow errors to HTTP responses.
+func writeSARWorkflowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrReportNotFound), errors.Is(err, services.ErrSARSourceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNoSARSources), errors.Is(err, services.ErrInvalidSARSource):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSARNotEditable), errors.Is(err, services.ErrInvalidReportStatus):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrIncompleteNarrative):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
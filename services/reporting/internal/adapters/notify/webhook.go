@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/ports"
+)
+
+// WebhookNotifier posts SAR deadline reminders as JSON to a webhook, such as
+// a chat integration watched by the compliance team.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier.
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// NotifySARDeadline posts the reminder to the webhook.
+func (n *WebhookNotifier) NotifySARDeadline(ctx context.Context, reminder *domain.SARReminder) error {
+	body, err := json.Marshal(struct {
+		Event string `json:"event"`
+		*domain.SARReminder
+	}{
+		Event:       "sar.deadline_reminder",
+		SARReminder: reminder,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("reminder webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+var _ ports.SARReminderNotifier = (*WebhookNotifier)(nil)
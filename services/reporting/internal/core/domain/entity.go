@@ -129,8 +129,9 @@ type ScheduledReport struct {
 	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
 }
 
-// ComplianceReport represents a compliance-specific report
-type ComplianceReport struct {
+// ComplianceReportData holds the checks, violations and obligations behind a
+// compliance report
+type ComplianceReportData struct {
 	ReportID         uuid.UUID              `json:"report_id"`
 	GeneratedAt      time.Time              `json:"generated_at"`
 	Period           TimeRange              `json:"period"`
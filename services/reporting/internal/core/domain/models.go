@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // EntityID is a type alias for entity identifiers.
@@ -12,81 +15,185 @@ func NewEntityID() EntityID {
 	return EntityID(generateReportUUID())
 }
 
-// ReportStatus represents the status of a regulatory report.
-type ReportStatus string
-
+// Statuses of a regulatory report as it moves through review and filing
 const (
-	ReportStatusDraft     ReportStatus = "draft"
-	ReportStatusPending   ReportStatus = "pending_review"
-	ReportStatusApproved  ReportStatus = "approved"
-	ReportStatusSubmitted ReportStatus = "submitted"
-	ReportStatusRejected  ReportStatus = "rejected"
-	ReportStatusClosed    ReportStatus = "closed"
+	ReportStatusDraft         ReportStatus = "draft"
+	ReportStatusPendingReview ReportStatus = "pending_review"
+	ReportStatusApproved      ReportStatus = "approved"
+	ReportStatusSubmitted     ReportStatus = "submitted"
+	ReportStatusAcknowledged  ReportStatus = "acknowledged"
+	ReportStatusRejected      ReportStatus = "rejected"
+	ReportStatusClosed        ReportStatus = "closed"
 )
 
-// ReportType represents the type of regulatory report.
-type ReportType string
-
+// Types of regulatory report
 const (
-	ReportTypeSAR          ReportType = "sar"          // Suspicious Activity Report
-	ReportTypeCTR          ReportType = "ctr"          // Currency Transaction Report
-	ReportTypeFBAR         ReportType = "fbar"         // Foreign Bank Account Report
-	ReportTypeDOJ          ReportType = "doj"          // Department of Justice Referral
-	ReportTypeFinCEN       ReportType = "fincen"       // FinCEN Request
-	ReportTypeInternalAlert ReportType = "internal_alert" // Internal compliance alert
+	ReportTypeSAR                ReportType = "sar"                 // Suspicious Activity Report
+	ReportTypeCTR                ReportType = "ctr"                 // Currency Transaction Report
+	ReportTypeFBAR               ReportType = "fbar"                // Foreign Bank Account Report
+	ReportTypeDOJ                ReportType = "doj"                 // Department of Justice Referral
+	ReportTypeFinCEN             ReportType = "fincen"              // FinCEN Request
+	ReportTypeInternalAlert      ReportType = "internal_alert"      // Internal compliance alert
+	ReportTypeQuarterlyEmissions ReportType = "quarterly_emissions" // Quarterly mining carbon emissions
 )
 
 // SAR represents a Suspicious Activity Report.
 type SAR struct {
-	ID                EntityID            `json:"id"`
-	ReportNumber      string              `json:"report_number"`
-	SubjectID         string              `json:"subject_id"`
-	SubjectType       string              `json:"subject_type"` // individual, organization, account
-	SubjectName       string              `json:"subject_name"`
-	SuspiciousActivity string             `json:"suspicious_activity"`
-	ActivityDate      time.Time           `json:"activity_date"`
-	DollarAmount      float64             `json:"dollar_amount"`
-	Currency          string              `json:"currency"`
-	TransactionCount  int                 `json:"transaction_count"`
-	Narrative         string              `json:"narrative"`
-	RiskIndicators    []string            `json:"risk_indicators"`
-	FilingInstitution string              `json:"filing_institution"`
-	ReporterID        string              `json:"reporter_id"`
-	ReviewerID        string              `json:"reviewer_id,omitempty"`
-	Status            ReportStatus        `json:"status"`
-	SupportingDocs    []SupportingDocument `json:"supporting_docs,omitempty"`
-	FieldOffice       string              `json:"field_office"`
-	OriginatingAgency string              `json:"originating_agency"`
-	SubmittedAt       *time.Time          `json:"submitted_at,omitempty"`
-	CreatedAt         time.Time           `json:"created_at"`
-	UpdatedAt         time.Time           `json:"updated_at"`
+	ID                 EntityID             `json:"id"`
+	ReportNumber       string               `json:"report_number"`
+	SubjectID          string               `json:"subject_id"`
+	SubjectType        string               `json:"subject_type"` // individual, organization, account
+	SubjectName        string               `json:"subject_name"`
+	SuspiciousActivity string               `json:"suspicious_activity"`
+	ActivityDate       time.Time            `json:"activity_date"`
+	DollarAmount       decimal.Decimal      `json:"dollar_amount"`
+	Currency           string               `json:"currency"`
+	TransactionCount   int                  `json:"transaction_count"`
+	Narrative          string               `json:"narrative"`
+	RiskIndicators     []string             `json:"risk_indicators"`
+	FilingInstitution  string               `json:"filing_institution"`
+	ReporterID         string               `json:"reporter_id"`
+	ReviewerID         string               `json:"reviewer_id,omitempty"`
+	Status             ReportStatus         `json:"status"`
+	SupportingDocs     []SupportingDocument `json:"supporting_docs,omitempty"`
+	FieldOffice        string               `json:"field_office"`
+	OriginatingAgency  string               `json:"originating_agency"`
+	Sources            []SARSource          `json:"sources,omitempty"`
+	NarrativeFields    *SARNarrative        `json:"narrative_fields,omitempty"`
+	FilingDeadline     *time.Time           `json:"filing_deadline,omitempty"`
+	RemindersSent      int                  `json:"reminders_sent"`
+	SubmittedAt        *time.Time           `json:"submitted_at,omitempty"`
+	AcknowledgedAt     *time.Time           `json:"acknowledged_at,omitempty"`
+	AcknowledgementRef string               `json:"acknowledgement_ref,omitempty"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+}
+
+// SARSourceType represents the kind of flagged activity a SAR is drafted from.
+type SARSourceType string
+
+const (
+	SARSourceTransaction SARSourceType = "transaction" // transaction flagged by monitoring
+	SARSourceViolation   SARSourceType = "violation"   // compliance violation
+)
+
+// SARSource is a snapshot of a flagged transaction or violation included in a SAR.
+type SARSource struct {
+	Type           SARSourceType   `json:"type"`
+	ID             string          `json:"id"`
+	SubjectID      string          `json:"subject_id"`
+	SubjectName    string          `json:"subject_name,omitempty"`
+	Description    string          `json:"description"`
+	Asset          string          `json:"asset,omitempty"`
+	Amount         decimal.Decimal `json:"amount,omitempty"`
+	AmountUSD      decimal.Decimal `json:"amount_usd"`
+	RiskIndicators []string        `json:"risk_indicators,omitempty"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	FlaggedAt      time.Time       `json:"flagged_at"` // when the activity was detected
+}
+
+// SARNarrative holds the structured narrative of a SAR, following the
+// who/what/when/where/why/how outline FIUs expect.
+type SARNarrative struct {
+	Subjects     string `json:"subjects"`  // who conducted the activity
+	Activity     string `json:"activity"`  // what instruments and transactions were involved
+	Timeline     string `json:"timeline"`  // when the activity took place
+	Locations    string `json:"locations"` // where: jurisdictions, venues, wallets
+	Reason       string `json:"reason"`    // why the activity is suspicious
+	Method       string `json:"method"`    // how the activity was carried out
+	ActionsTaken string `json:"actions_taken,omitempty"`
+}
+
+// Missing returns the names of the required narrative fields that are empty.
+func (n *SARNarrative) Missing() []string {
+	if n == nil {
+		return []string{"subjects", "activity", "timeline", "locations", "reason", "method"}
+	}
+
+	var missing []string
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"subjects", n.Subjects},
+		{"activity", n.Activity},
+		{"timeline", n.Timeline},
+		{"locations", n.Locations},
+		{"reason", n.Reason},
+		{"method", n.Method},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	return missing
+}
+
+// Compose renders the structured narrative as the free-text narrative of the SAR.
+func (n *SARNarrative) Compose() string {
+	sections := []struct {
+		title string
+		text  string
+	}{
+		{"Subjects", n.Subjects},
+		{"Activity", n.Activity},
+		{"Timeline", n.Timeline},
+		{"Locations", n.Locations},
+		{"Reason for suspicion", n.Reason},
+		{"Method", n.Method},
+		{"Actions taken", n.ActionsTaken},
+	}
+
+	var b strings.Builder
+	for _, section := range sections {
+		text := strings.TrimSpace(section.text)
+		if text == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(section.title)
+		b.WriteString(": ")
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// SARReminder describes an approaching or missed SAR filing deadline.
+type SARReminder struct {
+	SARID          EntityID     `json:"sar_id"`
+	ReportNumber   string       `json:"report_number"`
+	Status         ReportStatus `json:"status"`
+	FilingDeadline time.Time    `json:"filing_deadline"`
+	Overdue        bool         `json:"overdue"`
 }
 
 // CTR represents a Currency Transaction Report.
 type CTR struct {
-	ID                   EntityID            `json:"id"`
-	ReportNumber         string              `json:"report_number"`
-	PersonName           string              `json:"person_name"`
-	PersonIDType         string              `json:"person_id_type"` // SSN, Passport, etc.
-	PersonIDNumber       string              `json:"person_id_number"`
-	PersonAddress        Address             `json:"person_address"`
-	AccountNumber        string              `json:"account_number"`
-	TransactionType      string              `json:"transaction_type"` // deposit, withdrawal, transfer
-	CashInAmount         float64             `json:"cash_in_amount"`
-	CashOutAmount        float64             `json:"cash_out_amount"`
-	TotalAmount          float64             `json:"total_amount"`
-	Currency             string              `json:"currency"`
-	TransactionDate      time.Time           `json:"transaction_date"`
-	MethodReceived       string              `json:"method_received"` // in_person, mail, wire
-	InstitutionID        string              `json:"institution_id"`
-	BranchID             string              `json:"branch_id"`
-	TellerID             string              `json:"teller_id"`
-	ReviewerID           string              `json:"reviewer_id,omitempty"`
-	Status               ReportStatus        `json:"status"`
-	SupportingDocs       []SupportingDocument `json:"supporting_docs,omitempty"`
-	SubmittedAt          *time.Time          `json:"submitted_at,omitempty"`
-	CreatedAt            time.Time           `json:"created_at"`
-	UpdatedAt            time.Time           `json:"updated_at"`
+	ID              EntityID             `json:"id"`
+	ReportNumber    string               `json:"report_number"`
+	PersonName      string               `json:"person_name"`
+	PersonIDType    string               `json:"person_id_type"` // SSN, Passport, etc.
+	PersonIDNumber  string               `json:"person_id_number"`
+	PersonAddress   Address              `json:"person_address"`
+	AccountNumber   string               `json:"account_number"`
+	TransactionType string               `json:"transaction_type"` // deposit, withdrawal, transfer
+	CashInAmount    decimal.Decimal      `json:"cash_in_amount"`
+	CashOutAmount   decimal.Decimal      `json:"cash_out_amount"`
+	TotalAmount     decimal.Decimal      `json:"total_amount"`
+	Currency        string               `json:"currency"`
+	TransactionDate time.Time            `json:"transaction_date"`
+	MethodReceived  string               `json:"method_received"` // in_person, mail, wire
+	InstitutionID   string               `json:"institution_id"`
+	BranchID        string               `json:"branch_id"`
+	TellerID        string               `json:"teller_id"`
+	ReviewerID      string               `json:"reviewer_id,omitempty"`
+	Status          ReportStatus         `json:"status"`
+	SupportingDocs  []SupportingDocument `json:"supporting_docs,omitempty"`
+	SubmittedAt     *time.Time           `json:"submitted_at,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
 }
 
 // Address represents a physical address.
@@ -194,17 +301,36 @@ type ComplianceReport struct {
 
 // ReportSummary represents a summary of report data.
 type ReportSummary struct {
-	TotalTransactions     int64   `json:"total_transactions"`
-	TotalVolume           float64 `json:"total_volume"`
-	Currency              string  `json:"currency"`
-	FlaggedTransactions   int64   `json:"flagged_transactions"`
-	SuspiciousActivity    int64   `json:"suspicious_activity"`
-	AlertsGenerated       int64   `json:"alerts_generated"`
-	AlertsResolved        int64   `json:"alerts_resolved"`
-	AverageRiskScore      float64 `json:"average_risk_score"`
-	HighRiskCount         int64   `json:"high_risk_count"`
-	MediumRiskCount       int64   `json:"medium_risk_count"`
-	LowRiskCount          int64   `json:"low_risk_count"`
+	TotalTransactions   int64           `json:"total_transactions"`
+	TotalVolume         decimal.Decimal `json:"total_volume"`
+	Currency            string          `json:"currency"`
+	FlaggedTransactions int64           `json:"flagged_transactions"`
+	SuspiciousActivity  int64           `json:"suspicious_activity"`
+	AlertsGenerated     int64           `json:"alerts_generated"`
+	AlertsResolved      int64           `json:"alerts_resolved"`
+	AverageRiskScore    float64         `json:"average_risk_score"`
+	HighRiskCount       int64           `json:"high_risk_count"`
+	MediumRiskCount     int64           `json:"medium_risk_count"`
+	LowRiskCount        int64           `json:"low_risk_count"`
+	TotalEnergyKWh      float64         `json:"total_energy_kwh,omitempty"`
+	TotalEmissionsKG    float64         `json:"total_emissions_kg,omitempty"`
+}
+
+// EmissionsBreakdown represents the carbon emissions of one mining operator or region.
+type EmissionsBreakdown struct {
+	Key          string             `json:"key"`
+	Name         string             `json:"name"`
+	EnergyKWh    float64            `json:"energy_kwh"`
+	EmissionsKG  float64            `json:"emissions_kg"`
+	BySource     map[string]float64 `json:"emissions_by_source_kg,omitempty"`
+	PoolCount    int64              `json:"pool_count"`
+	MachineCount int64              `json:"machine_count"`
+}
+
+// EmissionsReportDetails represents the details of a quarterly emissions report.
+type EmissionsReportDetails struct {
+	ByOperator []*EmissionsBreakdown `json:"by_operator"`
+	ByRegion   []*EmissionsBreakdown `json:"by_region"`
 }
 
 // FilingRecord represents a record of a regulatory filing.
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/reporting-service/reporting/internal/core/domain"
+)
+
+// FlaggedActivityProvider looks up flagged transactions and compliance
+// violations so they can be included in a SAR.
+type FlaggedActivityProvider interface {
+	// GetFlaggedTransaction returns the flagged transaction, or nil if there is none.
+	GetFlaggedTransaction(ctx context.Context, id string) (*domain.SARSource, error)
+	// GetViolation returns the compliance violation, or nil if there is none.
+	GetViolation(ctx context.Context, id string) (*domain.SARSource, error)
+}
+
+// FIUReportRenderer renders a SAR in the format the financial intelligence unit accepts.
+type FIUReportRenderer interface {
+	Render(sar *domain.SAR) ([]byte, error)
+	ContentType() string
+}
+
+// SARReminderNotifier notifies analysts of approaching and missed SAR filing deadlines.
+type SARReminderNotifier interface {
+	NotifySARDeadline(ctx context.Context, reminder *domain.SARReminder) error
+}
@@ -3,7 +3,7 @@ package ports
 import (
 	"context"
 
-	"github.com/csic-platform/services/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/domain"
 )
 
 // Scheduler defines the interface for job scheduling
@@ -68,7 +68,7 @@ type WebhookNotificationRequest struct {
 // DataProvider defines the interface for fetching report data
 type DataProvider interface {
 	// FetchComplianceData fetches compliance data
-	FetchComplianceData(ctx context.Context, req *ComplianceReportRequest) (*domain.ComplianceReport, error)
+	FetchComplianceData(ctx context.Context, req *ComplianceReportRequest) (*domain.ComplianceReportData, error)
 
 	// FetchRiskData fetches risk data
 	FetchRiskData(ctx context.Context, req *RiskReportRequest) (*domain.RiskReport, error)
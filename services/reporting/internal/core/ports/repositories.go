@@ -2,8 +2,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/shopspring/decimal"
 )
 
 // SARRepository defines the interface for SAR persistence.
@@ -19,16 +21,17 @@ type SARRepository interface {
 
 // SARFilter represents filtering criteria for SAR queries.
 type SARFilter struct {
-	Status      []domain.ReportStatus
-	SubjectID   string
-	ReporterID  string
-	StartDate   *time.Time
-	EndDate     *time.Time
-	MinAmount   float64
-	MaxAmount   float64
-	FieldOffice string
-	Limit       int
-	Offset      int
+	Status         []domain.ReportStatus
+	SubjectID      string
+	ReporterID     string
+	StartDate      *time.Time
+	EndDate        *time.Time
+	MinAmount      *decimal.Decimal // nil for no lower bound
+	MaxAmount      *decimal.Decimal // nil for no upper bound
+	FieldOffice    string
+	DeadlineBefore *time.Time // filing deadline earlier than this
+	Limit          int
+	Offset         int
 }
 
 // CTRRepository defines the interface for CTR persistence.
@@ -44,17 +47,17 @@ type CTRRepository interface {
 
 // CTRFilter represents filtering criteria for CTR queries.
 type CTRFilter struct {
-	Status           []domain.ReportStatus
-	PersonID         string
-	AccountNumber    string
-	TransactionType  string
-	MinAmount        float64
-	MaxAmount        float64
-	StartDate        *time.Time
-	EndDate          *time.Time
-	InstitutionID    string
-	Limit            int
-	Offset           int
+	Status          []domain.ReportStatus
+	PersonID        string
+	AccountNumber   string
+	TransactionType string
+	MinAmount       *decimal.Decimal // nil for no lower bound
+	MaxAmount       *decimal.Decimal // nil for no upper bound
+	StartDate       *time.Time
+	EndDate         *time.Time
+	InstitutionID   string
+	Limit           int
+	Offset          int
 }
 
 // ComplianceRuleRepository defines the interface for compliance rule persistence.
@@ -183,3 +186,9 @@ type ScreeningRepository interface {
 	AddToList(ctx context.Context, listName string, entity interface{}) error
 	RemoveFromList(ctx context.Context, listName string, entityID string) error
 }
+
+// EmissionsRepository defines the interface for mining emissions data access.
+type EmissionsRepository interface {
+	GetEmissionsByOperator(ctx context.Context, startDate, endDate time.Time) ([]*domain.EmissionsBreakdown, error)
+	GetEmissionsByRegion(ctx context.Context, startDate, endDate time.Time) ([]*domain.EmissionsBreakdown, error)
+}
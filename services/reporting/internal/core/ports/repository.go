@@ -2,8 +2,9 @@ package ports
 
 import (
 	"context"
+	"time"
 
-	"github.com/csic-platform/services/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/domain"
 )
 
 // ReportRepository defines the interface for managing reports
@@ -3,8 +3,10 @@ package ports
 import (
 	"context"
 	"io"
+	"time"
 
-	"github.com/csic-platform/services/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/shopspring/decimal"
 )
 
 // ReportService defines the business logic for report management
@@ -34,7 +36,7 @@ type ReportService interface {
 // ReportGenerator defines the interface for generating report content
 type ReportGenerator interface {
 	// GenerateComplianceReport generates a compliance report
-	GenerateComplianceReport(ctx context.Context, req *ComplianceReportRequest) (*domain.ComplianceReport, error)
+	GenerateComplianceReport(ctx context.Context, req *ComplianceReportRequest) (*domain.ComplianceReportData, error)
 
 	// GenerateRiskReport generates a risk report
 	GenerateRiskReport(ctx context.Context, req *RiskReportRequest) (*domain.RiskReport, error)
@@ -230,7 +232,7 @@ type CreateTemplateRequest struct {
 	Type        domain.ReportType     `json:"type" validate:"required"`
 	Format      domain.OutputFormat   `json:"format" validate:"required"`
 	Content     string                `json:"content" validate:"required"`
-	Parameters  []TemplateParameter   `json:"parameters"`
+	Parameters  []domain.TemplateParameter   `json:"parameters"`
 }
 
 type CreateTemplateResponse struct {
@@ -257,7 +259,7 @@ type UpdateTemplateRequest struct {
 	Name        *string `json:"name,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Content     *string `json:"content,omitempty"`
-	Parameters  []TemplateParameter `json:"parameters,omitempty"`
+	Parameters  []domain.TemplateParameter `json:"parameters,omitempty"`
 }
 
 // Request/Response types for SchedulerService
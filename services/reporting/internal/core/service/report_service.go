@@ -6,8 +6,8 @@ import (
 	"io"
 	"time"
 
-	"github.com/csic-platform/services/reporting/internal/core/domain"
-	"github.com/csic-platform/services/reporting/internal/core/ports"
+	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/ports"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -19,7 +19,8 @@ var (
 	ErrRuleNotFound          = errors.New("compliance rule not found")
 	ErrFilingNotFound        = errors.New("filing record not found")
 	ErrFilingFailed          = errors.New("filing submission failed")
-	ErrSubjectNot Screened   = errors.New("subject has not been screened")
+	ErrSubjectNotScreened    = errors.New("subject has not been screened")
+	ErrInvalidQuarter        = errors.New("quarter must be between 1 and 4")
 )
 
 // ReportingService provides the core business logic for regulatory reporting.
@@ -33,6 +34,7 @@ type ReportingService struct {
 	filingRepo    ports.FilingRecordRepository
 	transactionRepo ports.TransactionRepository
 	screeningRepo ports.ScreeningRepository
+	emissionsRepo ports.EmissionsRepository
 }
 
 // NewReportingService creates a new ReportingService with the required dependencies.
@@ -46,6 +48,7 @@ func NewReportingService(
 	filingRepo ports.FilingRecordRepository,
 	transactionRepo ports.TransactionRepository,
 	screeningRepo ports.ScreeningRepository,
+	emissionsRepo ports.EmissionsRepository,
 ) *ReportingService {
 	return &ReportingService{
 		sarRepo:        sarRepo,
@@ -57,6 +60,7 @@ func NewReportingService(
 		filingRepo:     filingRepo,
 		transactionRepo: transactionRepo,
 		screeningRepo:  screeningRepo,
+		emissionsRepo:  emissionsRepo,
 	}
 }
 
@@ -388,7 +392,7 @@ func (s *ReportingService) GenerateComplianceReport(
 		})
 		summary.TotalTransactions = int64(len(ctrs))
 		for _, ctr := range ctrs {
-			summary.TotalVolume += ctr.TotalAmount
+			summary.TotalVolume = summary.TotalVolume.Add(ctr.TotalAmount)
 		}
 		details = ctrs
 
@@ -413,6 +417,24 @@ func (s *ReportingService) GenerateComplianceReport(
 			}
 		}
 		details = alerts
+
+	case domain.ReportTypeQuarterlyEmissions:
+		byOperator, err := s.emissionsRepo.GetEmissionsByOperator(ctx, periodStart, periodEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get emissions by operator: %w", err)
+		}
+		byRegion, err := s.emissionsRepo.GetEmissionsByRegion(ctx, periodStart, periodEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get emissions by region: %w", err)
+		}
+		for _, operator := range byOperator {
+			summary.TotalEnergyKWh += operator.EnergyKWh
+			summary.TotalEmissionsKG += operator.EmissionsKG
+		}
+		details = &domain.EmissionsReportDetails{
+			ByOperator: byOperator,
+			ByRegion:   byRegion,
+		}
 	}
 
 	report := &domain.ComplianceReport{
@@ -435,17 +457,34 @@ func (s *ReportingService) GenerateComplianceReport(
 	return report, nil
 }
 
+// GenerateQuarterlyEmissionsReport generates the carbon emissions report for a calendar quarter.
+func (s *ReportingService) GenerateQuarterlyEmissionsReport(
+	ctx context.Context,
+	year, quarter int,
+	generatedBy string,
+) (*domain.ComplianceReport, error) {
+	if quarter < 1 || quarter > 4 {
+		return nil, ErrInvalidQuarter
+	}
+
+	periodStart := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 3, 0)
+
+	return s.GenerateComplianceReport(ctx, domain.ReportTypeQuarterlyEmissions, periodStart, periodEnd, generatedBy)
+}
+
 // ==================== Helper Methods ====================
 
 // validateStatusTransition validates that a status transition is allowed.
 func (s *ReportingService) validateStatusTransition(current, new domain.ReportStatus) error {
 	allowedTransitions := map[domain.ReportStatus][]domain.ReportStatus{
-		domain.ReportStatusDraft:     {domain.ReportStatusPending},
-		domain.ReportStatusPending:   {domain.ReportStatusApproved, domain.ReportStatusRejected},
-		domain.ReportStatusApproved:  {domain.ReportStatusSubmitted, domain.ReportStatusRejected},
-		domain.ReportStatusRejected:  {domain.ReportStatusDraft, domain.ReportStatusClosed},
-		domain.ReportStatusSubmitted: {domain.ReportStatusClosed},
-		domain.ReportStatusClosed:    {},
+		domain.ReportStatusDraft:         {domain.ReportStatusPendingReview},
+		domain.ReportStatusPendingReview: {domain.ReportStatusApproved, domain.ReportStatusRejected},
+		domain.ReportStatusApproved:      {domain.ReportStatusSubmitted, domain.ReportStatusRejected},
+		domain.ReportStatusRejected:      {domain.ReportStatusDraft, domain.ReportStatusClosed},
+		domain.ReportStatusSubmitted:     {domain.ReportStatusAcknowledged, domain.ReportStatusClosed},
+		domain.ReportStatusAcknowledged:  {domain.ReportStatusClosed},
+		domain.ReportStatusClosed:        {},
 	}
 
 	allowed, ok := allowedTransitions[current]
@@ -7,6 +7,7 @@ import (
 
 	"github.com/reporting-service/reporting/internal/core/domain"
 	"github.com/reporting-service/reporting/internal/core/ports"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -202,6 +203,81 @@ func (m *MockAlertRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+// MockComplianceCheckRepository is a mock implementation of ComplianceCheckRepository.
+type MockComplianceCheckRepository struct {
+	mock.Mock
+}
+
+func (m *MockComplianceCheckRepository) Create(ctx context.Context, check *domain.ComplianceCheck) error {
+	args := m.Called(ctx, check)
+	return args.Error(0)
+}
+
+func (m *MockComplianceCheckRepository) GetByID(ctx context.Context, id string) (*domain.ComplianceCheck, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ComplianceCheck), args.Error(1)
+}
+
+func (m *MockComplianceCheckRepository) GetLatestBySubject(ctx context.Context, subjectID, checkType string) (*domain.ComplianceCheck, error) {
+	args := m.Called(ctx, subjectID, checkType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ComplianceCheck), args.Error(1)
+}
+
+func (m *MockComplianceCheckRepository) List(ctx context.Context, filter ports.ComplianceCheckFilter) ([]*domain.ComplianceCheck, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ComplianceCheck), args.Error(1)
+}
+
+func (m *MockComplianceCheckRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockComplianceReportRepository is a mock implementation of ComplianceReportRepository.
+type MockComplianceReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockComplianceReportRepository) Create(ctx context.Context, report *domain.ComplianceReport) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *MockComplianceReportRepository) GetByID(ctx context.Context, id string) (*domain.ComplianceReport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ComplianceReport), args.Error(1)
+}
+
+func (m *MockComplianceReportRepository) Update(ctx context.Context, report *domain.ComplianceReport) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *MockComplianceReportRepository) List(ctx context.Context, filter ports.ComplianceReportFilter) ([]*domain.ComplianceReport, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ComplianceReport), args.Error(1)
+}
+
+func (m *MockComplianceReportRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 // MockFilingRecordRepository is a mock implementation of FilingRecordRepository.
 type MockFilingRecordRepository struct {
 	mock.Mock
@@ -316,6 +392,27 @@ func (m *MockScreeningRepository) RemoveFromList(ctx context.Context, listName s
 	return args.Error(0)
 }
 
+// MockEmissionsRepository is a mock implementation of EmissionsRepository.
+type MockEmissionsRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmissionsRepository) GetEmissionsByOperator(ctx context.Context, startDate, endDate time.Time) ([]*domain.EmissionsBreakdown, error) {
+	args := m.Called(ctx, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmissionsBreakdown), args.Error(1)
+}
+
+func (m *MockEmissionsRepository) GetEmissionsByRegion(ctx context.Context, startDate, endDate time.Time) ([]*domain.EmissionsBreakdown, error) {
+	args := m.Called(ctx, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EmissionsBreakdown), args.Error(1)
+}
+
 // Helper function to create a test SAR.
 func createTestSAR() *domain.SAR {
 	return &domain.SAR{
@@ -324,7 +421,7 @@ func createTestSAR() *domain.SAR {
 		SubjectName:         "John Doe",
 		SuspiciousActivity:  "Unusual wire transfer patterns",
 		ActivityDate:        time.Now().UTC(),
-		DollarAmount:        50000.00,
+		DollarAmount:        decimal.NewFromInt(50000),
 		TransactionCount:    5,
 		Narrative:           "Multiple wire transfers to high-risk jurisdictions",
 		RiskIndicators:      []string{"structuring", "high-risk jurisdiction"},
@@ -344,9 +441,9 @@ func createTestCTR() *domain.CTR {
 		PersonAddress:    domain.Address{Street1: "123 Main St", City: "New York", State: "NY", PostalCode: "10001", Country: "USA"},
 		AccountNumber:    "ACC-001",
 		TransactionType:  "deposit",
-		CashInAmount:     15000.00,
-		CashOutAmount:    0,
-		TotalAmount:      15000.00,
+		CashInAmount:     decimal.NewFromInt(15000),
+		CashOutAmount:    decimal.Zero,
+		TotalAmount:      decimal.NewFromInt(15000),
 		Currency:         "USD",
 		TransactionDate:  time.Now().UTC(),
 		MethodReceived:   "in_person",
@@ -382,9 +479,10 @@ func TestReportingService_CreateSAR_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	sar := createTestSAR()
@@ -409,9 +507,10 @@ func TestReportingService_GetSAR_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	expectedSAR := createTestSAR()
@@ -436,9 +535,10 @@ func TestReportingService_GetSAR_NotFound(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	sarRepo.On("GetByID", ctx, "nonexistent").Return(nil, nil)
@@ -461,9 +561,10 @@ func TestReportingService_UpdateSARStatus_ValidTransition(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	sar := createTestSAR()
@@ -471,10 +572,10 @@ func TestReportingService_UpdateSARStatus_ValidTransition(t *testing.T) {
 	sarRepo.On("GetByID", ctx, "sar-001").Return(sar, nil)
 	sarRepo.On("Update", ctx, mock.AnythingOfType("*domain.SAR")).Return(nil)
 
-	err := service.UpdateSARStatus(ctx, "sar-001", domain.ReportStatusPending, "reviewer-001")
+	err := service.UpdateSARStatus(ctx, "sar-001", domain.ReportStatusPendingReview, "reviewer-001")
 
 	assert.NoError(t, err)
-	assert.Equal(t, domain.ReportStatusPending, sar.Status)
+	assert.Equal(t, domain.ReportStatusPendingReview, sar.Status)
 	assert.Equal(t, "reviewer-001", sar.ReviewerID)
 	sarRepo.AssertExpectations(t)
 }
@@ -490,9 +591,10 @@ func TestReportingService_UpdateSARStatus_InvalidTransition(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	sar := createTestSAR()
@@ -517,9 +619,10 @@ func TestReportingService_SubmitSAR_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	sar := createTestSAR()
@@ -548,9 +651,10 @@ func TestReportingService_SubmitSAR_NotApproved(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	sar := createTestSAR()
@@ -575,9 +679,10 @@ func TestReportingService_CreateCTR_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	ctr := createTestCTR()
@@ -602,9 +707,10 @@ func TestReportingService_CreateComplianceRule_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	rule := &domain.ComplianceRule{
@@ -638,9 +744,10 @@ func TestReportingService_CreateComplianceRule_AlreadyExists(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	existingRule := &domain.ComplianceRule{RuleCode: "AML-001"}
@@ -665,9 +772,10 @@ func TestReportingService_CreateAlert_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	alert := createTestAlert()
@@ -692,9 +800,10 @@ func TestReportingService_ResolveAlert_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	alert := createTestAlert()
@@ -722,9 +831,10 @@ func TestReportingService_EscalateAlert_Success(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	alert := createTestAlert()
@@ -750,9 +860,10 @@ func TestReportingService_PerformComplianceCheck_NoMatch(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	screeningRepo.On("Search", ctx, "subject-001", mock.Anything).Return([]interface{}{}, nil)
@@ -779,9 +890,10 @@ func TestReportingService_PerformComplianceCheck_MatchFound(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	matchedEntity := map[string]interface{}{"name": "Blocked Entity"}
@@ -810,9 +922,10 @@ func TestReportingService_GenerateComplianceReport_SAR(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	sars := []*domain.SAR{createTestSAR(), createTestSAR()}
@@ -843,9 +956,10 @@ func TestReportingService_GenerateComplianceReport_CTR(t *testing.T) {
 	filingRepo := new(MockFilingRecordRepository)
 	transactionRepo := new(MockTransactionRepository)
 	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
 
 	service := NewReportingService(
-		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo,
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
 	)
 
 	ctrs := []*domain.CTR{createTestCTR(), createTestCTR(), createTestCTR()}
@@ -860,8 +974,68 @@ func TestReportingService_GenerateComplianceReport_CTR(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, report)
 	assert.Equal(t, domain.ReportTypeCTR, report.ReportType)
-	assert.Equal(t, 3, int(report.Summary.TotalTransactions)
-	assert.Equal(t, 45000.0, report.Summary.TotalVolume) // 3 CTRs × $15,000 each
+	assert.Equal(t, 3, int(report.Summary.TotalTransactions))
+	assert.True(t, decimal.NewFromInt(45000).Equal(report.Summary.TotalVolume)) // 3 CTRs × $15,000 each
 	ctrRepo.AssertExpectations(t)
 	reportRepo.AssertExpectations(t)
 }
+
+func TestReportingService_GenerateQuarterlyEmissionsReport(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	ctrRepo := new(MockCTRRepository)
+	ruleRepo := new(MockComplianceRuleRepository)
+	alertRepo := new(MockAlertRepository)
+	checkRepo := new(MockComplianceCheckRepository)
+	reportRepo := new(MockComplianceReportRepository)
+	filingRepo := new(MockFilingRecordRepository)
+	transactionRepo := new(MockTransactionRepository)
+	screeningRepo := new(MockScreeningRepository)
+	emissionsRepo := new(MockEmissionsRepository)
+
+	service := NewReportingService(
+		sarRepo, ctrRepo, ruleRepo, alertRepo, checkRepo, reportRepo, filingRepo, transactionRepo, screeningRepo, emissionsRepo,
+	)
+
+	periodStart := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	byOperator := []*domain.EmissionsBreakdown{
+		{Key: "operator-001", Name: "Operator One", EnergyKWh: 1000, EmissionsKG: 450},
+		{Key: "operator-002", Name: "Operator Two", EnergyKWh: 500, EmissionsKG: 50},
+	}
+	byRegion := []*domain.EmissionsBreakdown{
+		{Key: "NORTH", Name: "NORTH", EnergyKWh: 1500, EmissionsKG: 500},
+	}
+	emissionsRepo.On("GetEmissionsByOperator", ctx, periodStart, periodEnd).Return(byOperator, nil)
+	emissionsRepo.On("GetEmissionsByRegion", ctx, periodStart, periodEnd).Return(byRegion, nil)
+	reportRepo.On("Create", ctx, mock.AnythingOfType("*domain.ComplianceReport")).Return(nil)
+
+	report, err := service.GenerateQuarterlyEmissionsReport(ctx, 2024, 2, "analyst-001")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, report)
+	assert.Equal(t, domain.ReportTypeQuarterlyEmissions, report.ReportType)
+	assert.Equal(t, periodStart, report.PeriodStart)
+	assert.Equal(t, periodEnd, report.PeriodEnd)
+	assert.Equal(t, 1500.0, report.Summary.TotalEnergyKWh)
+	assert.Equal(t, 500.0, report.Summary.TotalEmissionsKG)
+	emissionsRepo.AssertExpectations(t)
+	reportRepo.AssertExpectations(t)
+}
+
+func TestReportingService_GenerateQuarterlyEmissionsReport_InvalidQuarter(t *testing.T) {
+	ctx := context.Background()
+	emissionsRepo := new(MockEmissionsRepository)
+	reportRepo := new(MockComplianceReportRepository)
+
+	service := NewReportingService(
+		nil, nil, nil, nil, nil, reportRepo, nil, nil, nil, emissionsRepo,
+	)
+
+	report, err := service.GenerateQuarterlyEmissionsReport(ctx, 2024, 5, "analyst-001")
+
+	assert.ErrorIs(t, err, ErrInvalidQuarter)
+	assert.Nil(t, report)
+	emissionsRepo.AssertNotCalled(t, "GetEmissionsByOperator", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/reporting-service/reporting/internal/core/ports"
+)
+
+// Errors for the SAR filing workflow.
+var (
+	ErrNoSARSources        = errors.New("at least one flagged transaction or violation is required")
+	ErrInvalidSARSource    = errors.New("invalid SAR source type")
+	ErrSARSourceNotFound   = errors.New("flagged activity not found")
+	ErrSARNotEditable      = errors.New("only draft SARs can be edited")
+	ErrIncompleteNarrative = errors.New("SAR narrative is incomplete")
+)
+
+// openSARStatuses are the statuses of SARs that still have to be filed.
+var openSARStatuses = []domain.ReportStatus{
+	domain.ReportStatusDraft,
+	domain.ReportStatusPendingReview,
+	domain.ReportStatusApproved,
+	domain.ReportStatusRejected,
+}
+
+// SARWorkflowOptions configures SAR filing deadlines and reminders.
+type SARWorkflowOptions struct {
+	FilingPeriod    time.Duration   // time from detection of the activity to the filing deadline
+	ReminderOffsets []time.Duration // reminders are sent this long before the deadline
+}
+
+// DraftSARRequest describes the flagged activity an analyst converts into a SAR.
+type DraftSARRequest struct {
+	Sources           []SARSourceRef
+	SubjectID         string
+	SubjectType       string
+	SubjectName       string
+	FilingInstitution string
+	ReporterID        string
+}
+
+// SARSourceRef identifies a flagged transaction or violation.
+type SARSourceRef struct {
+	Type domain.SARSourceType `json:"type" binding:"required"`
+	ID   string               `json:"id" binding:"required"`
+}
+
+// SARWorkflowService takes SARs from flagged activity through drafting,
+// rendering for the FIU and acknowledgement, and reminds analysts of filing
+// deadlines.
+type SARWorkflowService struct {
+	reporting *ReportingService
+	sources   ports.FlaggedActivityProvider
+	renderer  ports.FIUReportRenderer
+	notifier  ports.SARReminderNotifier
+	opts      SARWorkflowOptions
+}
+
+// NewSARWorkflowService creates a new SARWorkflowService.
+func NewSARWorkflowService(
+	reporting *ReportingService,
+	sources ports.FlaggedActivityProvider,
+	renderer ports.FIUReportRenderer,
+	notifier ports.SARReminderNotifier,
+	opts SARWorkflowOptions,
+) *SARWorkflowService {
+	offsets := append([]time.Duration(nil), opts.ReminderOffsets...)
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	opts.ReminderOffsets = offsets
+
+	return &SARWorkflowService{
+		reporting: reporting,
+		sources:   sources,
+		renderer:  renderer,
+		notifier:  notifier,
+		opts:      opts,
+	}
+}
+
+// CreateDraftFromFlags creates a draft SAR from flagged transactions and
+// violations. Amounts, dates and risk indicators are taken from the sources;
+// the filing deadline runs from the earliest detection.
+func (s *SARWorkflowService) CreateDraftFromFlags(ctx context.Context, req DraftSARRequest) (*domain.SAR, error) {
+	if len(req.Sources) == 0 {
+		return nil, ErrNoSARSources
+	}
+
+	var sources []domain.SARSource
+	seen := make(map[SARSourceRef]bool, len(req.Sources))
+	for _, ref := range req.Sources {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+
+		source, err := s.lookupSource(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, *source)
+	}
+
+	sar := &domain.SAR{
+		ID:                domain.NewEntityID(),
+		SubjectID:         req.SubjectID,
+		SubjectType:       req.SubjectType,
+		SubjectName:       req.SubjectName,
+		Currency:          "USD",
+		FilingInstitution: req.FilingInstitution,
+		ReporterID:        req.ReporterID,
+		Sources:           sources,
+	}
+	if sar.SubjectID == "" {
+		sar.SubjectID = sources[0].SubjectID
+	}
+	if sar.SubjectName == "" {
+		sar.SubjectName = sources[0].SubjectName
+	}
+
+	var descriptions, indicators []string
+	seenText := make(map[string]bool)
+	detectedAt := sources[0].FlaggedAt
+	for _, source := range sources {
+		sar.DollarAmount = sar.DollarAmount.Add(source.AmountUSD)
+		if source.Type == domain.SARSourceTransaction {
+			sar.TransactionCount++
+		}
+		if sar.ActivityDate.IsZero() || source.OccurredAt.Before(sar.ActivityDate) {
+			sar.ActivityDate = source.OccurredAt
+		}
+		if source.FlaggedAt.Before(detectedAt) {
+			detectedAt = source.FlaggedAt
+		}
+		if source.Description != "" && !seenText["d:"+source.Description] {
+			seenText["d:"+source.Description] = true
+			descriptions = append(descriptions, source.Description)
+		}
+		for _, indicator := range source.RiskIndicators {
+			if !seenText["i:"+indicator] {
+				seenText["i:"+indicator] = true
+				indicators = append(indicators, indicator)
+			}
+		}
+	}
+	sort.Strings(indicators)
+	sar.SuspiciousActivity = strings.Join(descriptions, "; ")
+	sar.RiskIndicators = indicators
+
+	if detectedAt.IsZero() {
+		detectedAt = time.Now().UTC()
+	}
+	deadline := detectedAt.Add(s.opts.FilingPeriod)
+	sar.FilingDeadline = &deadline
+
+	if err := s.reporting.CreateSAR(ctx, sar); err != nil {
+		return nil, err
+	}
+	return sar, nil
+}
+
+// UpdateNarrative replaces the structured narrative of a draft SAR and
+// recomposes its narrative text.
+func (s *SARWorkflowService) UpdateNarrative(ctx context.Context, id string, narrative domain.SARNarrative) (*domain.SAR, error) {
+	sar, err := s.reporting.GetSAR(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sar.Status != domain.ReportStatusDraft {
+		return nil, ErrSARNotEditable
+	}
+
+	sar.NarrativeFields = &narrative
+	sar.Narrative = narrative.Compose()
+	sar.UpdatedAt = time.Now().UTC()
+
+	if err := s.reporting.sarRepo.Update(ctx, sar); err != nil {
+		return nil, fmt.Errorf("failed to update SAR: %w", err)
+	}
+	return sar, nil
+}
+
+// RenderFIUReport renders a SAR in the FIU's filing format. The structured
+// narrative must be complete.
+func (s *SARWorkflowService) RenderFIUReport(ctx context.Context, id string) ([]byte, string, error) {
+	sar, err := s.reporting.GetSAR(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if missing := sar.NarrativeFields.Missing(); len(missing) > 0 {
+		return nil, "", fmt.Errorf("%w: missing %s", ErrIncompleteNarrative, strings.Join(missing, ", "))
+	}
+
+	data, err := s.renderer.Render(sar)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render SAR: %w", err)
+	}
+	return data, s.renderer.ContentType(), nil
+}
+
+// Acknowledge records the FIU's acknowledgement of a submitted SAR and
+// marks its filing records as accepted.
+func (s *SARWorkflowService) Acknowledge(ctx context.Context, id, reference string, acknowledgedAt time.Time) (*domain.SAR, error) {
+	sar, err := s.reporting.GetSAR(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reporting.validateStatusTransition(sar.Status, domain.ReportStatusAcknowledged); err != nil {
+		return nil, err
+	}
+
+	filings, err := s.reporting.filingRepo.GetByReportID(ctx, string(sar.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filing records: %w", err)
+	}
+	for _, filing := range filings {
+		if filing.FilingStatus != "submitted" {
+			continue
+		}
+		filing.FilingStatus = "accepted"
+		filing.Confirmation = reference
+		filing.AcceptedAt = &acknowledgedAt
+		if err := s.reporting.filingRepo.Update(ctx, filing); err != nil {
+			return nil, fmt.Errorf("failed to update filing record: %w", err)
+		}
+	}
+
+	sar.Status = domain.ReportStatusAcknowledged
+	sar.AcknowledgedAt = &acknowledgedAt
+	sar.AcknowledgementRef = reference
+	sar.UpdatedAt = time.Now().UTC()
+
+	if err := s.reporting.sarRepo.Update(ctx, sar); err != nil {
+		return nil, fmt.Errorf("failed to update SAR: %w", err)
+	}
+	return sar, nil
+}
+
+// ListDeadlines lists unfiled SARs whose filing deadline falls within the
+// given period, including overdue ones, soonest first.
+func (s *SARWorkflowService) ListDeadlines(ctx context.Context, within time.Duration) ([]*domain.SAR, error) {
+	before := time.Now().UTC().Add(within)
+	sars, err := s.reporting.sarRepo.List(ctx, ports.SARFilter{
+		Status:         openSARStatuses,
+		DeadlineBefore: &before,
+		Limit:          1000,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SARs: %w", err)
+	}
+
+	sort.Slice(sars, func(i, j int) bool {
+		return sars[i].FilingDeadline.Before(*sars[j].FilingDeadline)
+	})
+	return sars, nil
+}
+
+// SendReminders notifies analysts of unfiled SARs that have reached a
+// reminder offset before their deadline, or passed it. Each offset is
+// reminded of once. It returns the number of reminders sent.
+func (s *SARWorkflowService) SendReminders(ctx context.Context) (int, error) {
+	if len(s.opts.ReminderOffsets) == 0 {
+		return 0, nil
+	}
+
+	sars, err := s.ListDeadlines(ctx, s.opts.ReminderOffsets[0])
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	sent := 0
+	for _, sar := range sars {
+		due := 0
+		for _, offset := range s.opts.ReminderOffsets {
+			if !now.Before(sar.FilingDeadline.Add(-offset)) {
+				due++
+			}
+		}
+		overdue := now.After(*sar.FilingDeadline)
+		if overdue {
+			// One more reminder once the deadline has passed
+			due = len(s.opts.ReminderOffsets) + 1
+		}
+		if due <= sar.RemindersSent {
+			continue
+		}
+
+		if err := s.notifier.NotifySARDeadline(ctx, &domain.SARReminder{
+			SARID:          sar.ID,
+			ReportNumber:   sar.ReportNumber,
+			Status:         sar.Status,
+			FilingDeadline: *sar.FilingDeadline,
+			Overdue:        overdue,
+		}); err != nil {
+			return sent, fmt.Errorf("failed to send reminder for SAR %s: %w", sar.ReportNumber, err)
+		}
+
+		sar.RemindersSent = due
+		sar.UpdatedAt = time.Now().UTC()
+		if err := s.reporting.sarRepo.Update(ctx, sar); err != nil {
+			return sent, fmt.Errorf("failed to update SAR: %w", err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// StartReminders sends deadline reminders on the given interval until ctx is
+// cancelled.
+func (s *SARWorkflowService) StartReminders(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SendReminders(ctx); err != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lookupSource fetches a snapshot of a flagged transaction or violation.
+func (s *SARWorkflowService) lookupSource(ctx context.Context, ref SARSourceRef) (*domain.SARSource, error) {
+	var source *domain.SARSource
+	var err error
+	switch ref.Type {
+	case domain.SARSourceTransaction:
+		source, err = s.sources.GetFlaggedTransaction(ctx, ref.ID)
+	case domain.SARSourceViolation:
+		source, err = s.sources.GetViolation(ctx, ref.ID)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSARSource, ref.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", ref.Type, ref.ID, err)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrSARSourceNotFound, ref.Type, ref.ID)
+	}
+
+	source.Type = ref.Type
+	source.ID = ref.ID
+	return source, nil
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reporting-service/reporting/internal/core/domain"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFlaggedActivityProvider is a mock implementation of FlaggedActivityProvider.
+type MockFlaggedActivityProvider struct {
+	mock.Mock
+}
+
+func (m *MockFlaggedActivityProvider) GetFlaggedTransaction(ctx context.Context, id string) (*domain.SARSource, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SARSource), args.Error(1)
+}
+
+func (m *MockFlaggedActivityProvider) GetViolation(ctx context.Context, id string) (*domain.SARSource, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SARSource), args.Error(1)
+}
+
+// MockFIUReportRenderer is a mock implementation of FIUReportRenderer.
+type MockFIUReportRenderer struct {
+	mock.Mock
+}
+
+func (m *MockFIUReportRenderer) Render(sar *domain.SAR) ([]byte, error) {
+	args := m.Called(sar)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockFIUReportRenderer) ContentType() string {
+	return "application/xml"
+}
+
+// MockSARReminderNotifier is a mock implementation of SARReminderNotifier.
+type MockSARReminderNotifier struct {
+	mock.Mock
+}
+
+func (m *MockSARReminderNotifier) NotifySARDeadline(ctx context.Context, reminder *domain.SARReminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+// Helper function to create a complete SAR narrative.
+func createTestNarrative() domain.SARNarrative {
+	return domain.SARNarrative{
+		Subjects:  "John Doe, customer of Test Exchange",
+		Activity:  "Deposits of stablecoins from a mixer followed by immediate withdrawals",
+		Timeline:  "1 to 5 March 2024",
+		Locations: "Test Exchange; withdrawal wallets in a high-risk jurisdiction",
+		Reason:    "Funds were layered through a mixer with no business purpose",
+		Method:    "Five deposits just under the reporting threshold",
+	}
+}
+
+func newTestSARWorkflowService(
+	sarRepo *MockSARRepository,
+	filingRepo *MockFilingRecordRepository,
+	sources *MockFlaggedActivityProvider,
+	renderer *MockFIUReportRenderer,
+	notifier *MockSARReminderNotifier,
+) *SARWorkflowService {
+	reporting := NewReportingService(sarRepo, nil, nil, nil, nil, nil, filingRepo, nil, nil, nil)
+	return NewSARWorkflowService(reporting, sources, renderer, notifier, SARWorkflowOptions{
+		FilingPeriod:    30 * 24 * time.Hour,
+		ReminderOffsets: []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 3 * 24 * time.Hour},
+	})
+}
+
+func TestSARWorkflowService_CreateDraftFromFlags_AggregatesSources(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	sources := new(MockFlaggedActivityProvider)
+	service := newTestSARWorkflowService(sarRepo, nil, sources, nil, nil)
+
+	flaggedAt := time.Date(2024, time.March, 6, 9, 0, 0, 0, time.UTC)
+	sources.On("GetFlaggedTransaction", ctx, "tx-001").Return(&domain.SARSource{
+		SubjectID:      "customer-001",
+		SubjectName:    "John Doe",
+		Description:    "Deposit from mixer",
+		AmountUSD:      decimal.NewFromInt(9500),
+		RiskIndicators: []string{"mixer", "structuring"},
+		OccurredAt:     time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+		FlaggedAt:      flaggedAt,
+	}, nil)
+	sources.On("GetViolation", ctx, "vio-001").Return(&domain.SARSource{
+		SubjectID:      "customer-001",
+		Description:    "Travel rule data missing",
+		RiskIndicators: []string{"structuring"},
+		OccurredAt:     time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC),
+		FlaggedAt:      flaggedAt.Add(24 * time.Hour),
+	}, nil)
+	sarRepo.On("Create", ctx, mock.AnythingOfType("*domain.SAR")).Return(nil)
+
+	sar, err := service.CreateDraftFromFlags(ctx, DraftSARRequest{
+		Sources: []SARSourceRef{
+			{Type: domain.SARSourceTransaction, ID: "tx-001"},
+			{Type: domain.SARSourceViolation, ID: "vio-001"},
+			{Type: domain.SARSourceTransaction, ID: "tx-001"},
+		},
+		SubjectType:       "individual",
+		FilingInstitution: "Test Exchange",
+		ReporterID:        "analyst-001",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReportStatusDraft, sar.Status)
+	assert.Equal(t, "customer-001", sar.SubjectID)
+	assert.Equal(t, "John Doe", sar.SubjectName)
+	assert.Len(t, sar.Sources, 2)
+	assert.True(t, decimal.NewFromInt(9500).Equal(sar.DollarAmount))
+	assert.Equal(t, 1, sar.TransactionCount)
+	assert.Equal(t, time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC), sar.ActivityDate)
+	assert.Equal(t, []string{"mixer", "structuring"}, sar.RiskIndicators)
+	assert.Equal(t, "Deposit from mixer; Travel rule data missing", sar.SuspiciousActivity)
+	assert.Equal(t, flaggedAt.Add(30*24*time.Hour), *sar.FilingDeadline)
+	sources.AssertNumberOfCalls(t, "GetFlaggedTransaction", 1)
+	sarRepo.AssertExpectations(t)
+}
+
+func TestSARWorkflowService_CreateDraftFromFlags_SourceNotFound(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	sources := new(MockFlaggedActivityProvider)
+	service := newTestSARWorkflowService(sarRepo, nil, sources, nil, nil)
+
+	sources.On("GetViolation", ctx, "vio-404").Return(nil, nil)
+
+	sar, err := service.CreateDraftFromFlags(ctx, DraftSARRequest{
+		Sources: []SARSourceRef{{Type: domain.SARSourceViolation, ID: "vio-404"}},
+	})
+
+	assert.ErrorIs(t, err, ErrSARSourceNotFound)
+	assert.Nil(t, sar)
+	sarRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSARWorkflowService_CreateDraftFromFlags_InvalidSourceType(t *testing.T) {
+	service := newTestSARWorkflowService(new(MockSARRepository), nil, new(MockFlaggedActivityProvider), nil, nil)
+
+	_, err := service.CreateDraftFromFlags(context.Background(), DraftSARRequest{
+		Sources: []SARSourceRef{{Type: "alert", ID: "alert-001"}},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidSARSource)
+}
+
+func TestSARWorkflowService_UpdateNarrative_Success(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	service := newTestSARWorkflowService(sarRepo, nil, nil, nil, nil)
+
+	sar := createTestSAR()
+	sar.Status = domain.ReportStatusDraft
+	sarRepo.On("GetByID", ctx, "sar-001").Return(sar, nil)
+	sarRepo.On("Update", ctx, sar).Return(nil)
+
+	updated, err := service.UpdateNarrative(ctx, "sar-001", createTestNarrative())
+
+	assert.NoError(t, err)
+	assert.Empty(t, updated.NarrativeFields.Missing())
+	assert.Contains(t, updated.Narrative, "Reason for suspicion: Funds were layered")
+	sarRepo.AssertExpectations(t)
+}
+
+func TestSARWorkflowService_UpdateNarrative_NotDraft(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	service := newTestSARWorkflowService(sarRepo, nil, nil, nil, nil)
+
+	sar := createTestSAR()
+	sar.Status = domain.ReportStatusSubmitted
+	sarRepo.On("GetByID", ctx, "sar-001").Return(sar, nil)
+
+	_, err := service.UpdateNarrative(ctx, "sar-001", createTestNarrative())
+
+	assert.ErrorIs(t, err, ErrSARNotEditable)
+	sarRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSARWorkflowService_RenderFIUReport_IncompleteNarrative(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	renderer := new(MockFIUReportRenderer)
+	service := newTestSARWorkflowService(sarRepo, nil, nil, renderer, nil)
+
+	sar := createTestSAR()
+	narrative := createTestNarrative()
+	narrative.Reason = ""
+	sar.NarrativeFields = &narrative
+	sarRepo.On("GetByID", ctx, "sar-001").Return(sar, nil)
+
+	_, _, err := service.RenderFIUReport(ctx, "sar-001")
+
+	assert.ErrorIs(t, err, ErrIncompleteNarrative)
+	assert.Contains(t, err.Error(), "reason")
+	renderer.AssertNotCalled(t, "Render", mock.Anything)
+}
+
+func TestSARWorkflowService_RenderFIUReport_Success(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	renderer := new(MockFIUReportRenderer)
+	service := newTestSARWorkflowService(sarRepo, nil, nil, renderer, nil)
+
+	sar := createTestSAR()
+	narrative := createTestNarrative()
+	sar.NarrativeFields = &narrative
+	sarRepo.On("GetByID", ctx, "sar-001").Return(sar, nil)
+	renderer.On("Render", sar).Return([]byte("<report/>"), nil)
+
+	data, contentType, err := service.RenderFIUReport(ctx, "sar-001")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<report/>", string(data))
+	assert.Equal(t, "application/xml", contentType)
+	renderer.AssertExpectations(t)
+}
+
+func TestSARWorkflowService_Acknowledge_Success(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	filingRepo := new(MockFilingRecordRepository)
+	service := newTestSARWorkflowService(sarRepo, filingRepo, nil, nil, nil)
+
+	sar := createTestSAR()
+	sar.ID = "sar-001"
+	sar.Status = domain.ReportStatusSubmitted
+	filing := &domain.FilingRecord{ID: "filing-001", ReportID: "sar-001", FilingStatus: "submitted"}
+	acknowledgedAt := time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC)
+	sarRepo.On("GetByID", ctx, "sar-001").Return(sar, nil)
+	filingRepo.On("GetByReportID", ctx, "sar-001").Return([]*domain.FilingRecord{filing}, nil)
+	filingRepo.On("Update", ctx, filing).Return(nil)
+	sarRepo.On("Update", ctx, sar).Return(nil)
+
+	updated, err := service.Acknowledge(ctx, "sar-001", "FIU-2024-0042", acknowledgedAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReportStatusAcknowledged, updated.Status)
+	assert.Equal(t, "FIU-2024-0042", updated.AcknowledgementRef)
+	assert.Equal(t, acknowledgedAt, *updated.AcknowledgedAt)
+	assert.Equal(t, "accepted", filing.FilingStatus)
+	assert.Equal(t, "FIU-2024-0042", filing.Confirmation)
+	sarRepo.AssertExpectations(t)
+	filingRepo.AssertExpectations(t)
+}
+
+func TestSARWorkflowService_Acknowledge_NotSubmitted(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	service := newTestSARWorkflowService(sarRepo, nil, nil, nil, nil)
+
+	sar := createTestSAR()
+	sar.Status = domain.ReportStatusApproved
+	sarRepo.On("GetByID", ctx, "sar-001").Return(sar, nil)
+
+	_, err := service.Acknowledge(ctx, "sar-001", "FIU-2024-0042", time.Now())
+
+	assert.ErrorIs(t, err, ErrInvalidReportStatus)
+}
+
+func TestSARWorkflowService_SendReminders_OncePerOffset(t *testing.T) {
+	ctx := context.Background()
+	sarRepo := new(MockSARRepository)
+	notifier := new(MockSARReminderNotifier)
+	service := newTestSARWorkflowService(sarRepo, nil, nil, nil, notifier)
+
+	// Two days left: the 7 and 3 day reminders are due, the first already sent
+	dueSoon := createTestSAR()
+	dueSoon.ReportNumber = "SAR-1"
+	deadline := time.Now().UTC().Add(2 * 24 * time.Hour)
+	dueSoon.FilingDeadline = &deadline
+	dueSoon.RemindersSent = 1
+
+	// Already reminded of the missed deadline
+	overdue := createTestSAR()
+	overdue.ReportNumber = "SAR-2"
+	missed := time.Now().UTC().Add(-time.Hour)
+	overdue.FilingDeadline = &missed
+	overdue.RemindersSent = 4
+
+	sarRepo.On("List", ctx, mock.Anything).Return([]*domain.SAR{dueSoon, overdue}, nil)
+	notifier.On("NotifySARDeadline", ctx, mock.MatchedBy(func(r *domain.SARReminder) bool {
+		return r.ReportNumber == "SAR-1" && !r.Overdue
+	})).Return(nil).Once()
+	sarRepo.On("Update", ctx, dueSoon).Return(nil).Once()
+
+	sent, err := service.SendReminders(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 2, dueSoon.RemindersSent)
+	notifier.AssertExpectations(t)
+	sarRepo.AssertExpectations(t)
+}
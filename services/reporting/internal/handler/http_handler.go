@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/csic-platform/services/reporting/internal/core/ports"
+	"github.com/reporting-service/reporting/internal/core/ports"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
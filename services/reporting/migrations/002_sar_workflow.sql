@@ -0,0 +1,14 @@
+-- Regulatory Reporting Service Database Migrations
+-- SAR workflow: flagged activity sources, structured narratives, filing
+-- deadlines with reminders, and FIU acknowledgements
+
+ALTER TABLE sars ADD COLUMN IF NOT EXISTS sources JSONB DEFAULT '[]'::jsonb;
+ALTER TABLE sars ADD COLUMN IF NOT EXISTS narrative_fields JSONB;
+ALTER TABLE sars ADD COLUMN IF NOT EXISTS filing_deadline TIMESTAMP WITH TIME ZONE;
+ALTER TABLE sars ADD COLUMN IF NOT EXISTS reminders_sent INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sars ADD COLUMN IF NOT EXISTS acknowledged_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE sars ADD COLUMN IF NOT EXISTS acknowledgement_ref VARCHAR(128);
+
+-- Deadline lookups only concern SARs that have not been filed yet
+CREATE INDEX IF NOT EXISTS idx_sars_open_filing_deadline ON sars(filing_deadline)
+    WHERE status IN ('draft', 'pending_review', 'approved', 'rejected');
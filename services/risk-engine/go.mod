@@ -8,3 +8,5 @@ require (
 	github.com/stretchr/testify v1.8.4
 	go.uber.org/zap v1.26.0
 )
+
+require go.uber.org/multierr v1.10.0 // indirect
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ConditionOperator is the comparison an attribute condition applies
+type ConditionOperator string
+
+const (
+	ConditionOperatorEq         ConditionOperator = "eq"
+	ConditionOperatorIn         ConditionOperator = "in"
+	ConditionOperatorGt         ConditionOperator = "gt"
+	ConditionOperatorCIDRMatch  ConditionOperator = "cidr_match"
+	ConditionOperatorTimeWindow ConditionOperator = "time_window"
+	ConditionOperatorRegex      ConditionOperator = "regex"
+)
+
+// ErrInvalidCondition is returned when a policy condition fails validation
+var ErrInvalidCondition = errors.New("invalid policy condition")
+
+// AttributeCondition tests one attribute of an access request. Attributes are
+// addressed by path:
+//
+//	subject.id, subject.type, subject.roles, subject.groups, subject.attributes.<key>
+//	resource.id, resource.type, resource.path, resource.attributes.<key>
+//	action.name, action.method
+//	context.ip_address, context.user_agent, context.location, context.device,
+//	context.time, context.custom.<key>
+//
+// Each operator reads its own operand field, so a condition's value is typed:
+// eq and regex use Value, in and cidr_match use Values, gt uses Number and
+// time_window uses Window. A multi-valued attribute such as subject.roles
+// matches when any of its values does.
+type AttributeCondition struct {
+	Attribute string            `json:"attribute"`
+	Operator  ConditionOperator `json:"operator"`
+	Value     string            `json:"value,omitempty"`
+	Values    []string          `json:"values,omitempty"`
+	Number    *float64          `json:"number,omitempty"`
+	Window    *TimeWindow       `json:"window,omitempty"`
+}
+
+// TimeWindow is a daily window in a time zone, optionally limited to some days
+// of the week. A window whose end is before its start spans midnight.
+type TimeWindow struct {
+	Start      string `json:"start"`                  // HH:MM
+	End        string `json:"end"`                    // HH:MM
+	DaysOfWeek []int  `json:"days_of_week,omitempty"` // 0-6 (Sunday-Saturday)
+	Timezone   string `json:"timezone,omitempty"`     // IANA name, UTC when empty
+}
+
+// timeOfDayLayout is the format of TimeWindow bounds
+const timeOfDayLayout = "15:04"
+
+// attributeRoots are the fixed attribute paths; map-backed attributes are
+// matched by attributePrefixes
+var attributeRoots = map[string]bool{
+	"subject.id": true, "subject.type": true, "subject.roles": true, "subject.groups": true,
+	"resource.id": true, "resource.type": true, "resource.path": true,
+	"action.name": true, "action.method": true,
+	"context.ip_address": true, "context.user_agent": true, "context.location": true,
+	"context.device": true, "context.time": true,
+}
+
+var attributePrefixes = []string{"subject.attributes.", "resource.attributes.", "context.custom."}
+
+// Validate checks that the condition names a known attribute and carries a
+// well-formed operand for its operator
+func (c *AttributeCondition) Validate() error {
+	if !knownAttribute(c.Attribute) {
+		return fmt.Errorf("%w: unknown attribute %q", ErrInvalidCondition, c.Attribute)
+	}
+
+	switch c.Operator {
+	case ConditionOperatorEq:
+		if c.Value == "" {
+			return fmt.Errorf("%w: eq on %s requires value", ErrInvalidCondition, c.Attribute)
+		}
+	case ConditionOperatorIn:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%w: in on %s requires values", ErrInvalidCondition, c.Attribute)
+		}
+	case ConditionOperatorGt:
+		if c.Number == nil {
+			return fmt.Errorf("%w: gt on %s requires number", ErrInvalidCondition, c.Attribute)
+		}
+	case ConditionOperatorCIDRMatch:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%w: cidr_match on %s requires values", ErrInvalidCondition, c.Attribute)
+		}
+		for _, cidr := range c.Values {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("%w: invalid CIDR %q", ErrInvalidCondition, cidr)
+			}
+		}
+	case ConditionOperatorTimeWindow:
+		if c.Attribute != "context.time" {
+			return fmt.Errorf("%w: time_window applies to context.time only", ErrInvalidCondition)
+		}
+		if c.Window == nil {
+			return fmt.Errorf("%w: time_window requires window", ErrInvalidCondition)
+		}
+		return c.Window.Validate()
+	case ConditionOperatorRegex:
+		if c.Value == "" {
+			return fmt.Errorf("%w: regex on %s requires value", ErrInvalidCondition, c.Attribute)
+		}
+		if _, err := regexp.Compile(c.Value); err != nil {
+			return fmt.Errorf("%w: invalid regex %q: %v", ErrInvalidCondition, c.Value, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidCondition, c.Operator)
+	}
+
+	return nil
+}
+
+// Validate checks the window bounds, days and time zone
+func (w *TimeWindow) Validate() error {
+	if _, err := time.Parse(timeOfDayLayout, w.Start); err != nil {
+		return fmt.Errorf("%w: invalid window start %q (want HH:MM)", ErrInvalidCondition, w.Start)
+	}
+	if _, err := time.Parse(timeOfDayLayout, w.End); err != nil {
+		return fmt.Errorf("%w: invalid window end %q (want HH:MM)", ErrInvalidCondition, w.End)
+	}
+	if err := validateDaysOfWeek(w.DaysOfWeek); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidCondition, w.Timezone)
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the window. The window must have
+// passed Validate.
+func (w *TimeWindow) Contains(t time.Time) bool {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+
+	if len(w.DaysOfWeek) > 0 {
+		matched := false
+		for _, day := range w.DaysOfWeek {
+			if day == int(t.Weekday()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	start, err := time.Parse(timeOfDayLayout, w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(timeOfDayLayout, w.End)
+	if err != nil {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from <= to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
+
+// Validate checks the typed attribute conditions and the legacy IP ranges and
+// days of week, so malformed policies are rejected when saved rather than
+// silently failing to match
+func (c *PolicyConditions) Validate() error {
+	for i := range c.Attributes {
+		if err := c.Attributes[i].Validate(); err != nil {
+			return fmt.Errorf("attributes[%d]: %w", i, err)
+		}
+	}
+
+	for _, env := range c.Environment {
+		for _, ipRange := range env.IPRanges {
+			if net.ParseIP(ipRange) == nil {
+				if _, _, err := net.ParseCIDR(ipRange); err != nil {
+					return fmt.Errorf("%w: invalid IP range %q", ErrInvalidCondition, ipRange)
+				}
+			}
+		}
+		for _, tr := range env.TimeRanges {
+			if err := validateDaysOfWeek(tr.DaysOfWeek); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateDaysOfWeek(days []int) error {
+	for _, day := range days {
+		if day < 0 || day > 6 {
+			return fmt.Errorf("%w: day of week %d out of range 0-6", ErrInvalidCondition, day)
+		}
+	}
+	return nil
+}
+
+func knownAttribute(path string) bool {
+	if attributeRoots[path] {
+		return true
+	}
+	for _, prefix := range attributePrefixes {
+		if strings.HasPrefix(path, prefix) && len(path) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
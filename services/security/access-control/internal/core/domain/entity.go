@@ -34,6 +34,8 @@ type PolicyConditions struct {
 	Resources   []ResourceCondition `json:"resources,omitempty"`
 	Actions     []ActionCondition   `json:"actions,omitempty"`
 	Environment []EnvironmentCondition `json:"environment,omitempty"`
+	// Attributes are typed conditions that must all hold
+	Attributes  []AttributeCondition   `json:"attributes,omitempty"`
 }
 
 // SubjectCondition defines conditions for the subject (who)
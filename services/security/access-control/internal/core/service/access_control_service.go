@@ -16,6 +16,7 @@ type AccessControlServiceImpl struct {
 	policyRepo     ports.PolicyRepository
 	ownershipRepo  ports.ResourceOwnershipRepository
 	auditRepo      ports.AuditLogRepository
+	conditions     conditionEvaluator
 	logger         *zap.Logger
 }
 
@@ -136,6 +137,11 @@ func (s *AccessControlServiceImpl) evaluatePolicy(
 		return false
 	}
 
+	// Evaluate typed attribute conditions
+	if !s.conditions.matchAll(policy.Conditions.Attributes, req) {
+		return false
+	}
+
 	return true
 }
 
@@ -287,16 +293,9 @@ func (s *AccessControlServiceImpl) evaluateEnvironmentConditions(
 	}
 
 	for _, cond := range conditions {
-		// Check IP ranges (simplified - would need proper IP range checking)
+		// Check IP ranges; entries are single addresses or CIDRs
 		if len(cond.IPRanges) > 0 {
-			ipMatch := false
-			for _, ipRange := range cond.IPRanges {
-				if context.IPAddress == ipRange {
-					ipMatch = true
-					break
-				}
-			}
-			if !ipMatch {
+			if !ipInRanges(context.IPAddress, cond.IPRanges) {
 				continue
 			}
 		}
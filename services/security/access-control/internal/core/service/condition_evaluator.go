@@ -0,0 +1,159 @@
+package service
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/csic-platform/services/security/access-control/internal/core/domain"
+)
+
+// conditionEvaluator evaluates typed attribute conditions. It never panics on
+// malformed input: a condition that cannot be evaluated, such as a missing
+// attribute or an operand that fails to parse, does not match.
+type conditionEvaluator struct {
+	regexes sync.Map // pattern -> *regexp.Regexp
+}
+
+// matchAll reports whether every condition holds for the request
+func (e *conditionEvaluator) matchAll(conditions []domain.AttributeCondition, req *domain.AccessRequest) bool {
+	for i := range conditions {
+		if !e.match(&conditions[i], req) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *conditionEvaluator) match(cond *domain.AttributeCondition, req *domain.AccessRequest) bool {
+	if cond.Operator == domain.ConditionOperatorTimeWindow {
+		return cond.Window != nil && !req.Context.Time.IsZero() && cond.Window.Contains(req.Context.Time)
+	}
+
+	values, ok := attributeValues(cond.Attribute, req)
+	if !ok {
+		return false
+	}
+
+	for _, value := range values {
+		if e.matchValue(cond, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *conditionEvaluator) matchValue(cond *domain.AttributeCondition, value string) bool {
+	switch cond.Operator {
+	case domain.ConditionOperatorEq:
+		return value == cond.Value
+	case domain.ConditionOperatorIn:
+		for _, candidate := range cond.Values {
+			if value == candidate {
+				return true
+			}
+		}
+		return false
+	case domain.ConditionOperatorGt:
+		if cond.Number == nil {
+			return false
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		return err == nil && n > *cond.Number
+	case domain.ConditionOperatorCIDRMatch:
+		return ipInRanges(value, cond.Values)
+	case domain.ConditionOperatorRegex:
+		re, ok := e.regex(cond.Value)
+		return ok && re.MatchString(value)
+	default:
+		return false
+	}
+}
+
+// regex compiles a pattern once and reuses it
+func (e *conditionEvaluator) regex(pattern string) (*regexp.Regexp, bool) {
+	if cached, ok := e.regexes.Load(pattern); ok {
+		return cached.(*regexp.Regexp), true
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, false
+	}
+	e.regexes.Store(pattern, re)
+	return re, true
+}
+
+// ipInRanges reports whether ip lies in any of the ranges, each a CIDR or a
+// single address
+func ipInRanges(ip string, ranges []string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, r := range ranges {
+		if _, network, err := net.ParseCIDR(r); err == nil {
+			if network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if single := net.ParseIP(r); single != nil && single.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeValues resolves an attribute path against the request. It reports
+// false for unknown paths and absent map entries.
+func attributeValues(path string, req *domain.AccessRequest) ([]string, bool) {
+	switch path {
+	case "subject.id":
+		return []string{req.Subject.ID}, true
+	case "subject.type":
+		return []string{req.Subject.Type}, true
+	case "subject.roles":
+		return req.Subject.Roles, true
+	case "subject.groups":
+		return req.Subject.Groups, true
+	case "resource.id":
+		return []string{req.Resource.ID}, true
+	case "resource.type":
+		return []string{req.Resource.Type}, true
+	case "resource.path":
+		return []string{req.Resource.Path}, true
+	case "action.name":
+		return []string{req.Action.Name}, true
+	case "action.method":
+		return []string{req.Action.Method}, true
+	case "context.ip_address":
+		return []string{req.Context.IPAddress}, true
+	case "context.user_agent":
+		return []string{req.Context.UserAgent}, true
+	case "context.location":
+		return []string{req.Context.Location}, true
+	case "context.device":
+		return []string{req.Context.Device}, true
+	}
+
+	if key, ok := strings.CutPrefix(path, "subject.attributes."); ok {
+		return mapValue(req.Subject.Attributes, key)
+	}
+	if key, ok := strings.CutPrefix(path, "resource.attributes."); ok {
+		return mapValue(req.Resource.Attributes, key)
+	}
+	if key, ok := strings.CutPrefix(path, "context.custom."); ok {
+		return mapValue(req.Context.Custom, key)
+	}
+	return nil, false
+}
+
+func mapValue(m map[string]string, key string) ([]string, bool) {
+	value, ok := m[key]
+	if !ok {
+		return nil, false
+	}
+	return []string{value}, true
+}
@@ -225,6 +225,11 @@ func (h *Handler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := req.Conditions.Validate(); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid policy conditions", err)
+		return
+	}
+
 	response, err := h.policyService.CreatePolicy(r.Context(), &req)
 	if err != nil {
 		h.respondError(w, http.StatusInternalServerError, "Failed to create policy", err)
@@ -304,6 +309,13 @@ func (h *Handler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
 
 	req.ID = policyID
 
+	if req.Conditions != nil {
+		if err := req.Conditions.Validate(); err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid policy conditions", err)
+			return
+		}
+	}
+
 	if err := h.policyService.UpdatePolicy(r.Context(), &req); err != nil {
 		h.respondError(w, http.StatusInternalServerError, "Failed to update policy", err)
 		return
@@ -3,6 +3,9 @@ module github.com/csic-platform/services/security/forensic-tools
 go 1.21
 
 require (
+	github.com/aws/aws-sdk-go-v2 v1.26.0
+	github.com/aws/aws-sdk-go-v2/config v1.26.1
+	github.com/aws/aws-sdk-go-v2/service/kms v1.30.0
 	github.com/csic-platform/shared v0.0.0
 	github.com/google/uuid v1.4.0
 	github.com/lib/pq v1.10.9
@@ -10,6 +13,7 @@ require (
 	github.com/redis/go-redis/v9 v9.3.0
 	github.com/spf13/viper v1.17.0
 	github.com/stretchr/testify v1.8.4
+	github.com/tyler-smith/go-bip39 v1.1.0
 	go.uber.org/zap v1.26.0
 )
 
@@ -45,4 +49,4 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-replace github.com/csic-platform/shared => ../../shared
+replace github.com/csic-platform/shared => ../../../shared
@@ -0,0 +1,133 @@
+package analysis
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+)
+
+// maxIndicators caps how many distinct indicators a result lists
+const maxIndicators = 5000
+
+var (
+	ethAddressPattern = regexp.MustCompile(`\b0x[0-9a-fA-F]{40}\b`)
+	ethTxHashPattern  = regexp.MustCompile(`\b0x[0-9a-fA-F]{64}\b`)
+	btcTxIDPattern    = regexp.MustCompile(`\b[0-9a-fA-F]{64}\b`)
+	base58AddrPattern = regexp.MustCompile(`\b[13][1-9A-HJ-NP-Za-km-z]{25,34}\b`)
+	bech32AddrPattern = regexp.MustCompile(`\bbc1[02-9ac-hj-np-z]{11,71}\b`)
+)
+
+// indicatorSet collects the distinct addresses and transaction hashes found
+// across chunks, keeping the offset where each was first seen
+type indicatorSet struct {
+	txHashes   bool
+	seen       map[string]bool
+	indicators []domain.Indicator
+	dropped    int
+}
+
+// newIndicatorSet creates a set that collects addresses and, if txHashes is
+// set, transaction hashes
+func newIndicatorSet(txHashes bool) *indicatorSet {
+	return &indicatorSet{txHashes: txHashes, seen: make(map[string]bool)}
+}
+
+// add records an indicator unless it has been seen. Hex values are compared
+// case-insensitively but kept as found.
+func (s *indicatorSet) add(kind domain.IndicatorKind, chain, value string, offset int64) {
+	key := string(kind) + ":" + chain + ":" + value
+	if kind == domain.IndicatorKindTxHash || chain == domain.ChainEthereum {
+		key = strings.ToLower(key)
+	}
+	if s.seen[key] {
+		return
+	}
+	s.seen[key] = true
+
+	if len(s.indicators) >= maxIndicators {
+		s.dropped++
+		return
+	}
+	s.indicators = append(s.indicators, domain.Indicator{Kind: kind, Value: value, Chain: chain, Offset: offset})
+}
+
+// scan finds indicators in data that start before limit. Bitcoin addresses
+// must carry a valid Base58Check or bech32 checksum; hex values cannot be
+// checked and are left for the transaction store to confirm.
+func (s *indicatorSet) scan(data []byte, limit int, offset int64) {
+	s.scanPattern(data, limit, offset, ethAddressPattern, domain.IndicatorKindAddress, domain.ChainEthereum, nil)
+	s.scanPattern(data, limit, offset, base58AddrPattern, domain.IndicatorKindAddress, domain.ChainBitcoin, validBase58Check)
+	s.scanPattern(data, limit, offset, bech32AddrPattern, domain.IndicatorKindAddress, domain.ChainBitcoin, validBech32)
+	if s.txHashes {
+		s.scanPattern(data, limit, offset, ethTxHashPattern, domain.IndicatorKindTxHash, domain.ChainEthereum, nil)
+		s.scanPattern(data, limit, offset, btcTxIDPattern, domain.IndicatorKindTxHash, domain.ChainBitcoin, nil)
+	}
+}
+
+func (s *indicatorSet) scanPattern(data []byte, limit int, offset int64, pattern *regexp.Regexp, kind domain.IndicatorKind, chain string, valid func(string) bool) {
+	for _, loc := range pattern.FindAllIndex(data, -1) {
+		if loc[0] >= limit {
+			break
+		}
+		value := string(data[loc[0]:loc[1]])
+		if valid != nil && !valid(value) {
+			continue
+		}
+		s.add(kind, chain, value, offset+int64(loc[0]))
+	}
+}
+
+// resultData summarises the set for a result's data
+func (s *indicatorSet) resultData() map[string]interface{} {
+	return map[string]interface{}{
+		"count":     len(s.indicators),
+		"truncated": s.dropped,
+	}
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// validBech32 reports whether s carries a valid bech32 (BIP-173) or bech32m
+// (BIP-350) checksum
+func validBech32(s string) bool {
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return false
+	}
+	hrp, data := s[:sep], s[sep+1:]
+
+	values := make([]int, 0, len(hrp)*2+1+len(data))
+	for _, c := range hrp {
+		values = append(values, int(c)>>5)
+	}
+	values = append(values, 0)
+	for _, c := range hrp {
+		values = append(values, int(c)&31)
+	}
+	for _, c := range data {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return false
+		}
+		values = append(values, v)
+	}
+
+	check := bech32Polymod(values)
+	return check == 1 || check == 0x2bc830a3
+}
+
+func bech32Polymod(values []int) int {
+	generator := [5]int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := 1
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ v
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
@@ -0,0 +1,264 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+)
+
+// maxCarvedLineLength truncates carved lines stored in a timeline
+const maxCarvedLineLength = 512
+
+// timestampFormat is a log timestamp format the carver recognises
+type timestampFormat struct {
+	name    string
+	pattern *regexp.Regexp
+	layouts []string
+	// yearless formats, such as syslog, take the year from the evidence
+	yearless bool
+}
+
+var timestampFormats = []timestampFormat{
+	{
+		name:    "iso8601",
+		pattern: regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`),
+		layouts: []string{time.RFC3339Nano, "2006-01-02T15:04:05Z0700", "2006-01-02 15:04:05Z07:00", "2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05", "2006-01-02T15:04:05"},
+	},
+	{
+		name:    "clf",
+		pattern: regexp.MustCompile(`\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`),
+		layouts: []string{"02/Jan/2006:15:04:05 -0700"},
+	},
+	{
+		name:     "syslog",
+		pattern:  regexp.MustCompile(`^[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}`),
+		layouts:  []string{time.Stamp},
+		yearless: true,
+	},
+}
+
+// TimelineEntry is a carved log line with its parsed timestamp
+type TimelineEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Offset    int64     `json:"offset"`
+	Format    string    `json:"format"`
+	Line      string    `json:"line"`
+}
+
+// LogCarver carves timestamped log lines out of evidence files, including
+// unallocated space in disk images and memory dumps, and orders them into a
+// timeline. Addresses and transaction hashes in carved lines are reported as
+// indicators, whether or not the line has a timestamp.
+type LogCarver struct {
+	maxEntries    int
+	minLineLength int
+}
+
+// NewLogCarver creates a new log carver
+func NewLogCarver(cfg *config.LogCarverConfig) *LogCarver {
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	minLineLength := cfg.MinLineLength
+	if minLineLength <= 0 {
+		minLineLength = 16
+	}
+
+	return &LogCarver{
+		maxEntries:    maxEntries,
+		minLineLength: minLineLength,
+	}
+}
+
+// Name returns the analyzer name
+func (c *LogCarver) Name() string {
+	return "log_carver"
+}
+
+// Version returns the analyzer version
+func (c *LogCarver) Version() string {
+	return "1.0.0"
+}
+
+// IsAvailable reports whether the analyzer can run; it has no dependencies
+func (c *LogCarver) IsAvailable() bool {
+	return true
+}
+
+// GetSupportedEvidenceTypes returns the evidence types the analyzer handles
+func (c *LogCarver) GetSupportedEvidenceTypes() []domain.EvidenceType {
+	return []domain.EvidenceType{
+		domain.EvidenceTypeFile,
+		domain.EvidenceTypeDiskImage,
+		domain.EvidenceTypeMemoryDump,
+		domain.EvidenceTypeLogFile,
+		domain.EvidenceTypeNetworkCapture,
+	}
+}
+
+// Analyze carves timestamped lines from the evidence file
+func (c *LogCarver) Analyze(ctx context.Context, evidence *domain.Evidence, storage ports.BlobStorage, progress ports.ProgressFunc) (*domain.AnalysisResult, error) {
+	// Timestamps far outside the evidence's lifetime are carving noise
+	notBefore := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := evidence.UploadedAt.Add(24 * time.Hour)
+	if evidence.UploadedAt.IsZero() {
+		notAfter = time.Now().Add(24 * time.Hour)
+	}
+
+	var entries []TimelineEntry
+	formatCounts := make(map[string]int)
+	indicators := newIndicatorSet(true)
+	total := 0
+	// carvedUntil is the file offset up to which lines have been carved, so
+	// the tail of a line carved from the previous chunk is skipped
+	var carvedUntil int64
+
+	err := scanEvidence(ctx, evidence, storage, progress, func(data []byte, limit int, offset int64) {
+		start := -1
+		for i := 0; i <= len(data); i++ {
+			if i < len(data) && isPrintable(data[i]) {
+				if start < 0 {
+					start = i
+				}
+				continue
+			}
+			if start < 0 {
+				continue
+			}
+
+			lineStart := start
+			start = -1
+			if lineStart >= limit {
+				return
+			}
+			// A line running into the end of an unfinished chunk is carved
+			// from the next chunk instead
+			if i == len(data) && limit < len(data) {
+				return
+			}
+			if offset+int64(lineStart) < carvedUntil {
+				continue
+			}
+			carvedUntil = offset + int64(i)
+			if i-lineStart < c.minLineLength {
+				continue
+			}
+
+			line := data[lineStart:i]
+			indicators.scan(line, len(line), offset+int64(lineStart))
+
+			entry, ok := c.parseLine(line, notBefore, notAfter, evidence.UploadedAt)
+			if !ok {
+				continue
+			}
+
+			total++
+			formatCounts[entry.Format]++
+			if len(entries) < c.maxEntries {
+				entry.Offset = offset + int64(lineStart)
+				entries = append(entries, entry)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	resultData := map[string]interface{}{
+		"entries":       entries,
+		"total_entries": total,
+		"truncated":     total > len(entries),
+		"formats":       formatCounts,
+		"per_day":       entriesPerDay(entries),
+		"indicators":    indicators.resultData(),
+	}
+
+	summary := "No timestamped log lines found"
+	if len(entries) > 0 {
+		first, last := entries[0].Timestamp, entries[len(entries)-1].Timestamp
+		resultData["earliest"] = first
+		resultData["latest"] = last
+		summary = fmt.Sprintf("Carved %d timestamped lines from %s to %s",
+			total, first.Format(time.RFC3339), last.Format(time.RFC3339))
+	}
+
+	return &domain.AnalysisResult{
+		ResultType: "timeline",
+		ResultData: resultData,
+		Summary:    summary,
+		Severity:   "info",
+		Tags:       []string{"timeline", "log_carving"},
+		Indicators: indicators.indicators,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// parseLine finds and parses the first recognised timestamp in a line
+func (c *LogCarver) parseLine(line []byte, notBefore, notAfter, reference time.Time) (TimelineEntry, bool) {
+	for _, format := range timestampFormats {
+		match := format.pattern.Find(line)
+		if match == nil {
+			continue
+		}
+
+		for _, layout := range format.layouts {
+			ts, err := time.Parse(layout, string(match))
+			if err != nil {
+				continue
+			}
+			if format.yearless {
+				ts = withYear(ts, reference)
+			}
+			if ts.Before(notBefore) || ts.After(notAfter) {
+				break
+			}
+
+			text := string(line)
+			if len(text) > maxCarvedLineLength {
+				text = text[:maxCarvedLineLength]
+			}
+			return TimelineEntry{Timestamp: ts.UTC(), Format: format.name, Line: text}, true
+		}
+	}
+	return TimelineEntry{}, false
+}
+
+// withYear gives a yearless timestamp the reference year, or the year before
+// if that would place it after the reference
+func withYear(ts, reference time.Time) time.Time {
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	dated := time.Date(reference.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), 0, time.UTC)
+	if dated.After(reference.Add(24 * time.Hour)) {
+		dated = dated.AddDate(-1, 0, 0)
+	}
+	return dated
+}
+
+// entriesPerDay counts timeline entries per UTC day
+func entriesPerDay(entries []TimelineEntry) map[string]int {
+	days := make(map[string]int)
+	for _, entry := range entries {
+		days[entry.Timestamp.Format("2006-01-02")]++
+	}
+	return days
+}
+
+func isPrintable(b byte) bool {
+	return (b >= 0x20 && b < 0x7f) || b == '\t'
+}
+
+// Ensure LogCarver implements AnalysisEngine
+var _ ports.AnalysisEngine = (*LogCarver)(nil)
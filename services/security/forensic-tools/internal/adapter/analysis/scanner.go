@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+)
+
+const (
+	// chunkSize is how much of an evidence file is scanned at a time
+	chunkSize = 4 * 1024 * 1024
+	// chunkOverlap is carried into the next chunk so artifacts that straddle a
+	// chunk boundary are still matched whole
+	chunkOverlap = 64 * 1024
+)
+
+// chunkFunc scans data, which starts at file offset offset. Only matches that
+// start before limit belong to this chunk; later ones are scanned again with
+// the next chunk.
+type chunkFunc func(data []byte, limit int, offset int64)
+
+// scanEvidence streams an evidence file from storage in overlapping chunks,
+// reporting progress by bytes read
+func scanEvidence(ctx context.Context, evidence *domain.Evidence, storage ports.BlobStorage, progress ports.ProgressFunc, scan chunkFunc) error {
+	file, err := storage.GetFile(ctx, evidence.StoragePath)
+	if err != nil {
+		return fmt.Errorf("failed to open evidence: %w", err)
+	}
+	defer file.Close()
+
+	block := make([]byte, chunkSize)
+	var carry []byte
+	var offset, read int64
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		n, readErr := io.ReadFull(file, block)
+		eof := readErr == io.EOF || readErr == io.ErrUnexpectedEOF
+		if readErr != nil && !eof {
+			return fmt.Errorf("failed to read evidence: %w", readErr)
+		}
+		read += int64(n)
+
+		data := append(carry, block[:n]...)
+		limit := len(data)
+		if !eof && limit > chunkOverlap {
+			limit -= chunkOverlap
+		}
+		scan(data, limit, offset)
+
+		if evidence.SizeBytes > 0 && progress != nil {
+			progress(int(read * 100 / evidence.SizeBytes))
+		}
+		if eof {
+			return nil
+		}
+
+		carry = append([]byte(nil), data[limit:]...)
+		offset += int64(limit)
+	}
+}
@@ -0,0 +1,364 @@
+package analysis
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+// maxFindingsPerKind caps how many artifacts of one kind a result lists
+const maxFindingsPerKind = 1000
+
+var (
+	// bdbBtreeMagic is the Berkeley DB btree magic at offset 12 of a database
+	// file, as used by Bitcoin Core's wallet.dat
+	bdbBtreeMagic = []byte{0x62, 0x31, 0x05, 0x00}
+
+	// walletDatMarkers are record keys of Bitcoin Core wallet.dat files
+	walletDatMarkers = map[string][]byte{
+		"default_key":     []byte("defaultkey"),
+		"master_key":      []byte("\x04mkey"),
+		"encrypted_key":   []byte("\x04ckey"),
+		"unencrypted_key": []byte("\x03key"),
+		"key_metadata":    []byte("keymeta"),
+	}
+
+	keystoreCryptoPattern  = regexp.MustCompile(`"[Cc]rypto"\s*:\s*\{`)
+	keystoreAddressPattern = regexp.MustCompile(`"address"\s*:\s*"(?:0x)?([0-9a-fA-F]{40})"`)
+	keystoreKDFPattern     = regexp.MustCompile(`"kdf"\s*:\s*"(scrypt|pbkdf2)"`)
+	keystoreCipherPattern  = regexp.MustCompile(`"ciphertext"\s*:\s*"[0-9a-fA-F]+"`)
+
+	wifPattern         = regexp.MustCompile(`\b[5KL][1-9A-HJ-NP-Za-km-z]{50,51}\b`)
+	extendedKeyPattern = regexp.MustCompile(`\b[xyzt]prv[1-9A-HJ-NP-Za-km-z]{107,108}\b`)
+	wordPattern        = regexp.MustCompile(`[a-z]+`)
+
+	bip39Words = func() map[string]bool {
+		words := make(map[string]bool, len(wordlists.English))
+		for _, word := range wordlists.English {
+			words[word] = true
+		}
+		return words
+	}()
+
+	seedPhraseLengths = []int{24, 21, 18, 15, 12}
+)
+
+// WalletArtifactAnalyzer extracts cryptocurrency wallet artifacts: Bitcoin
+// Core wallet.dat databases, Ethereum keystore files, BIP-39 seed phrases and
+// WIF or extended private keys. Secrets are never stored; findings carry their
+// offset and a SHA-256 fingerprint instead.
+type WalletArtifactAnalyzer struct{}
+
+// NewWalletArtifactAnalyzer creates a new wallet artifact analyzer
+func NewWalletArtifactAnalyzer() *WalletArtifactAnalyzer {
+	return &WalletArtifactAnalyzer{}
+}
+
+// Name returns the analyzer name
+func (a *WalletArtifactAnalyzer) Name() string {
+	return "wallet_artifacts"
+}
+
+// Version returns the analyzer version
+func (a *WalletArtifactAnalyzer) Version() string {
+	return "1.0.0"
+}
+
+// IsAvailable reports whether the analyzer can run; it has no dependencies
+func (a *WalletArtifactAnalyzer) IsAvailable() bool {
+	return true
+}
+
+// GetSupportedEvidenceTypes returns the evidence types the analyzer handles
+func (a *WalletArtifactAnalyzer) GetSupportedEvidenceTypes() []domain.EvidenceType {
+	return []domain.EvidenceType{
+		domain.EvidenceTypeFile,
+		domain.EvidenceTypeDiskImage,
+		domain.EvidenceTypeMemoryDump,
+		domain.EvidenceTypeDocument,
+		domain.EvidenceTypeLogFile,
+	}
+}
+
+// walletFindings collects artifacts across chunks
+type walletFindings struct {
+	walletDatHeaders []map[string]interface{}
+	walletDatMarkers map[string]int
+	keystores        []map[string]interface{}
+	seedPhrases      []map[string]interface{}
+	privateKeys      []map[string]interface{}
+	unverifiedSeeds  int
+	indicators       *indicatorSet
+}
+
+func (f *walletFindings) add(list *[]map[string]interface{}, finding map[string]interface{}) {
+	if len(*list) < maxFindingsPerKind {
+		*list = append(*list, finding)
+	}
+}
+
+// Analyze scans the evidence file for wallet artifacts
+func (a *WalletArtifactAnalyzer) Analyze(ctx context.Context, evidence *domain.Evidence, storage ports.BlobStorage, progress ports.ProgressFunc) (*domain.AnalysisResult, error) {
+	findings := &walletFindings{walletDatMarkers: make(map[string]int), indicators: newIndicatorSet(false)}
+
+	err := scanEvidence(ctx, evidence, storage, progress, func(data []byte, limit int, offset int64) {
+		a.scanWalletDat(findings, data, limit, offset)
+		a.scanKeystores(findings, data, limit, offset)
+		a.scanPrivateKeys(findings, data, limit, offset)
+		a.scanSeedPhrases(findings, data, limit, offset)
+		findings.indicators.scan(data, limit, offset)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.buildResult(findings), nil
+}
+
+// scanWalletDat looks for Berkeley DB headers and wallet.dat record keys
+func (a *WalletArtifactAnalyzer) scanWalletDat(f *walletFindings, data []byte, limit int, offset int64) {
+	for i := 0; i < limit; {
+		idx := bytes.Index(data[i:], bdbBtreeMagic)
+		if idx < 0 || i+idx >= limit {
+			break
+		}
+		pos := int64(i+idx) + offset
+		// Database files start on a sector boundary, with the magic at offset 12
+		if pos >= 12 && (pos-12)%512 == 0 {
+			f.add(&f.walletDatHeaders, map[string]interface{}{"offset": pos - 12})
+		}
+		i += idx + 1
+	}
+
+	for name, marker := range walletDatMarkers {
+		f.walletDatMarkers[name] += countBefore(data, marker, limit)
+	}
+}
+
+// scanKeystores looks for Ethereum keystore (Web3 secret storage) JSON
+func (a *WalletArtifactAnalyzer) scanKeystores(f *walletFindings, data []byte, limit int, offset int64) {
+	for _, loc := range keystoreCryptoPattern.FindAllIndex(data, -1) {
+		if loc[0] >= limit {
+			break
+		}
+
+		window := data[max(0, loc[0]-1024):min(len(data), loc[0]+2048)]
+		if !keystoreCipherPattern.Match(window) {
+			continue
+		}
+
+		finding := map[string]interface{}{"offset": offset + int64(loc[0])}
+		if m := keystoreAddressPattern.FindSubmatch(window); m != nil {
+			address := "0x" + strings.ToLower(string(m[1]))
+			finding["address"] = address
+			f.indicators.add(domain.IndicatorKindAddress, domain.ChainEthereum, address, offset+int64(loc[0]))
+		}
+		if m := keystoreKDFPattern.FindSubmatch(window); m != nil {
+			finding["kdf"] = string(m[1])
+		}
+		f.add(&f.keystores, finding)
+	}
+}
+
+// scanPrivateKeys looks for Base58Check-valid WIF and extended private keys
+func (a *WalletArtifactAnalyzer) scanPrivateKeys(f *walletFindings, data []byte, limit int, offset int64) {
+	for kind, pattern := range map[string]*regexp.Regexp{"wif": wifPattern, "extended": extendedKeyPattern} {
+		for _, loc := range pattern.FindAllIndex(data, -1) {
+			if loc[0] >= limit {
+				break
+			}
+			key := string(data[loc[0]:loc[1]])
+			if !validBase58Check(key) {
+				continue
+			}
+			f.add(&f.privateKeys, map[string]interface{}{
+				"offset":      offset + int64(loc[0]),
+				"kind":        kind,
+				"prefix":      key[:4],
+				"fingerprint": fingerprint(key),
+			})
+		}
+	}
+}
+
+// scanSeedPhrases looks for runs of BIP-39 words that form a mnemonic with a
+// valid checksum
+func (a *WalletArtifactAnalyzer) scanSeedPhrases(f *walletFindings, data []byte, limit int, offset int64) {
+	words := wordPattern.FindAllIndex(data, -1)
+
+	for start := 0; start < len(words); {
+		// Extend a run of BIP-39 words separated only by whitespace
+		end := start
+		for end < len(words) && bip39Words[string(data[words[end][0]:words[end][1]])] {
+			if end > start && !isWhitespace(data[words[end-1][1]:words[end][0]]) {
+				break
+			}
+			end++
+		}
+
+		if end-start < 12 || words[start][0] >= limit {
+			start = max(end, start+1)
+			continue
+		}
+
+		found := false
+		for i := start; i+12 <= end && !found; i++ {
+			for _, length := range seedPhraseLengths {
+				if i+length > end {
+					continue
+				}
+				phrase := make([]string, length)
+				for j := 0; j < length; j++ {
+					phrase[j] = string(data[words[i+j][0]:words[i+j][1]])
+				}
+				mnemonic := strings.Join(phrase, " ")
+				if bip39.IsMnemonicValid(mnemonic) {
+					f.add(&f.seedPhrases, map[string]interface{}{
+						"offset":      offset + int64(words[i][0]),
+						"word_count":  length,
+						"fingerprint": fingerprint(mnemonic),
+					})
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			f.unverifiedSeeds++
+		}
+		start = end
+	}
+}
+
+// buildResult summarises the findings
+func (a *WalletArtifactAnalyzer) buildResult(f *walletFindings) *domain.AnalysisResult {
+	// A wallet.dat needs both a database header and wallet record keys
+	walletDats := f.walletDatHeaders
+	hasWalletRecords := f.walletDatMarkers["default_key"]+f.walletDatMarkers["master_key"]+f.walletDatMarkers["encrypted_key"]+f.walletDatMarkers["key_metadata"] > 0
+	if !hasWalletRecords {
+		walletDats = nil
+	}
+
+	severity := "info"
+	var tags []string
+	if len(walletDats) > 0 || len(f.keystores) > 0 {
+		severity = "warning"
+	}
+	if len(walletDats) > 0 {
+		tags = append(tags, "wallet_dat")
+	}
+	if len(f.keystores) > 0 {
+		tags = append(tags, "ethereum_keystore")
+	}
+	if len(f.seedPhrases) > 0 {
+		tags = append(tags, "seed_phrase")
+		severity = "critical"
+	}
+	if len(f.privateKeys) > 0 {
+		tags = append(tags, "private_key")
+		severity = "critical"
+	}
+	if len(walletDats) > 0 && f.walletDatMarkers["master_key"] == 0 && f.walletDatMarkers["unencrypted_key"] > 0 {
+		tags = append(tags, "unencrypted_wallet")
+		severity = "critical"
+	}
+
+	return &domain.AnalysisResult{
+		ResultType: "wallet_artifacts",
+		ResultData: map[string]interface{}{
+			"wallet_dat":            walletDats,
+			"wallet_dat_records":    f.walletDatMarkers,
+			"keystores":             f.keystores,
+			"seed_phrases":          f.seedPhrases,
+			"private_keys":          f.privateKeys,
+			"unverified_word_runs":  f.unverifiedSeeds,
+			"max_findings_per_kind": maxFindingsPerKind,
+			"indicators":            f.indicators.resultData(),
+		},
+		Summary: fmt.Sprintf("Found %d wallet.dat files, %d keystores, %d seed phrases and %d private keys",
+			len(walletDats), len(f.keystores), len(f.seedPhrases), len(f.privateKeys)),
+		Severity:   severity,
+		Tags:       tags,
+		Indicators: f.indicators.indicators,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// countBefore counts occurrences of sep in data that start before limit
+func countBefore(data, sep []byte, limit int) int {
+	count := 0
+	for i := 0; i < limit; {
+		idx := bytes.Index(data[i:], sep)
+		if idx < 0 || i+idx >= limit {
+			break
+		}
+		count++
+		i += idx + len(sep)
+	}
+	return count
+}
+
+func isWhitespace(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	for _, c := range b {
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+// fingerprint identifies a secret without storing it
+func fingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// validBase58Check reports whether s decodes as Base58 with a valid
+// double-SHA-256 checksum
+func validBase58Check(s string) bool {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return false
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	decoded := n.Bytes()
+	for _, c := range s {
+		if c != '1' {
+			break
+		}
+		decoded = append([]byte{0}, decoded...)
+	}
+	if len(decoded) < 5 {
+		return false
+	}
+
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return bytes.Equal(second[:4], checksum)
+}
+
+// Ensure WalletArtifactAnalyzer implements AnalysisEngine
+var _ ports.AnalysisEngine = (*WalletArtifactAnalyzer)(nil)
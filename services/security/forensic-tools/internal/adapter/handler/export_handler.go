@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/service"
+	"github.com/csic-platform/shared/problem"
+)
+
+const caseBasePath = "/api/v1/forensic/cases"
+
+// maxExportRequestSize bounds the size of an export request body
+const maxExportRequestSize = 1 << 20
+
+// ExportHandler serves the court evidence package export API
+type ExportHandler struct {
+	exports ports.ExportService
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(exports ports.ExportService) *ExportHandler {
+	return &ExportHandler{exports: exports}
+}
+
+// RegisterRoutes registers the export routes:
+//
+//	POST /api/v1/forensic/cases/{id}/export  build a signed evidence package (ZIP)
+func (h *ExportHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc(caseBasePath+"/", h.exportCase)
+}
+
+// exportCase builds an evidence package for a case and streams it back
+func (h *ExportHandler) exportCase(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, caseBasePath+"/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "export" {
+		writeError(w, r, problem.New(problem.NotFound, "not found"))
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, r, problem.New(problem.MethodNotAllowed, "method not allowed"))
+		return
+	}
+
+	actorID := r.Header.Get("X-Actor-ID")
+	if actorID == "" {
+		writeError(w, r, problem.New(problem.Unauthenticated, "missing actor"))
+		return
+	}
+
+	var req domain.CaseExportRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxExportRequestSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil && err != io.EOF {
+		writeError(w, r, problem.Invalid("invalid export request: "+err.Error()))
+		return
+	}
+
+	pkg, archive, err := h.exports.ExportCase(r.Context(), parts[0], &req, actorID, clientIP(r))
+	if err != nil {
+		writeExportError(w, r, err)
+		return
+	}
+	defer archive.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", pkg.FileName))
+	w.Header().Set("Content-Length", strconv.FormatInt(pkg.SizeBytes, 10))
+	w.Header().Set("X-Package-ID", pkg.ID)
+	w.Header().Set("X-Package-SHA256", pkg.SHA256)
+	w.Header().Set("X-Manifest-SHA256", pkg.Signature.ManifestSHA256)
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, archive)
+}
+
+func writeExportError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrCaseNotFound):
+		err = problem.Wrap(err, problem.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidExportRequest):
+		err = problem.Invalid(err.Error())
+	case errors.Is(err, service.ErrInvalidHash):
+		err = problem.Wrap(err, problem.Conflict, err.Error())
+	default:
+		err = problem.Wrap(err, problem.Internal, "failed to export case")
+	}
+	writeError(w, r, err)
+}
+
+// clientIP returns the address of the client, preferring the first
+// X-Forwarded-For entry set by the gateway
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/service"
+	"github.com/csic-platform/shared/problem"
+)
+
+const importBasePath = "/api/v1/forensic/evidence/import"
+
+// maxManifestSize bounds the size of an import manifest request body
+const maxManifestSize = 32 << 20
+
+// ImportHandler serves the bulk evidence import API
+type ImportHandler struct {
+	imports ports.ImportService
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(imports ports.ImportService) *ImportHandler {
+	return &ImportHandler{imports: imports}
+}
+
+// RegisterRoutes registers the import routes:
+//
+//	POST /api/v1/forensic/evidence/import             start an import from a manifest
+//	GET  /api/v1/forensic/evidence/import/{id}        import job status and counts
+//	GET  /api/v1/forensic/evidence/import/{id}/items  import items, ?status=MISMATCH
+func (h *ImportHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc(importBasePath, h.startImport)
+	mux.HandleFunc(importBasePath+"/", h.getImport)
+}
+
+// startImport queues an import job for the posted manifest
+func (h *ImportHandler) startImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, r, problem.New(problem.MethodNotAllowed, "method not allowed"))
+		return
+	}
+
+	actorID := r.Header.Get("X-Actor-ID")
+	if actorID == "" {
+		writeError(w, r, problem.New(problem.Unauthenticated, "missing actor"))
+		return
+	}
+
+	var manifest domain.ImportManifest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxManifestSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		writeError(w, r, problem.Invalid("invalid manifest: "+err.Error()))
+		return
+	}
+
+	job, err := h.imports.StartImport(r.Context(), &manifest, actorID)
+	if errors.Is(err, service.ErrInvalidManifest) {
+		writeError(w, r, problem.Invalid(err.Error()))
+		return
+	}
+	if err != nil {
+		writeError(w, r, problem.Wrap(err, problem.Internal, "failed to start import"))
+		return
+	}
+
+	w.Header().Set("Location", importBasePath+"/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// getImport returns an import job or, under /items, its items
+func (h *ImportHandler) getImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, problem.New(problem.MethodNotAllowed, "method not allowed"))
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, importBasePath+"/"), "/")
+	jobID := parts[0]
+	switch {
+	case len(parts) == 1 && jobID != "":
+		job, err := h.imports.GetImportJob(r.Context(), jobID)
+		if err != nil {
+			writeServiceError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, job)
+
+	case len(parts) == 2 && jobID != "" && parts[1] == "items":
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		pageSize, _ := strconv.Atoi(query.Get("page_size"))
+		status := domain.ImportItemStatus(strings.ToUpper(query.Get("status")))
+
+		items, err := h.imports.ListImportItems(r.Context(), jobID, status, page, pageSize)
+		if err != nil {
+			writeServiceError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, items)
+
+	default:
+		writeError(w, r, problem.New(problem.NotFound, "not found"))
+	}
+}
+
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrImportJobNotFound) {
+		err = problem.Wrap(err, problem.NotFound, err.Error())
+	}
+	writeError(w, r, err)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError responds with the problem+json document for err
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	problem.WriteHTTP(w, r, err)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/service"
+	"github.com/csic-platform/shared/problem"
+)
+
+const (
+	evidenceBasePath    = "/api/v1/forensic/evidence"
+	transactionBasePath = "/api/v1/forensic/transactions"
+)
+
+// LinkHandler serves the evidence-to-transaction link API
+type LinkHandler struct {
+	links ports.LinkService
+}
+
+// NewLinkHandler creates a new link handler
+func NewLinkHandler(links ports.LinkService) *LinkHandler {
+	return &LinkHandler{links: links}
+}
+
+// RegisterRoutes registers the link routes:
+//
+//	GET /api/v1/forensic/evidence/{id}/linked-transactions  transactions whose hashes appear in the evidence
+//	GET /api/v1/forensic/evidence/{id}/linked-wallets       wallets whose addresses appear in the evidence
+//	GET /api/v1/forensic/transactions/{id}/evidence         evidence naming the transaction or its wallets
+func (h *LinkHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc(evidenceBasePath+"/", h.getEvidenceLinks)
+	mux.HandleFunc(transactionBasePath+"/", h.getTransactionEvidence)
+}
+
+// getEvidenceLinks returns the transactions or wallets linked to evidence
+func (h *LinkHandler) getEvidenceLinks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, problem.New(problem.MethodNotAllowed, "method not allowed"))
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, evidenceBasePath+"/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		writeError(w, r, problem.New(problem.NotFound, "not found"))
+		return
+	}
+	evidenceID := parts[0]
+	page, pageSize := pageParams(r)
+
+	switch parts[1] {
+	case "linked-transactions":
+		transactions, err := h.links.GetLinkedTransactions(r.Context(), evidenceID, page, pageSize)
+		if err != nil {
+			writeLinkError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, transactions)
+
+	case "linked-wallets":
+		wallets, err := h.links.GetLinkedWallets(r.Context(), evidenceID, page, pageSize)
+		if err != nil {
+			writeLinkError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, wallets)
+
+	default:
+		writeError(w, r, problem.New(problem.NotFound, "not found"))
+	}
+}
+
+// getTransactionEvidence returns the evidence linked to a transaction
+func (h *LinkHandler) getTransactionEvidence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, problem.New(problem.MethodNotAllowed, "method not allowed"))
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, transactionBasePath+"/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "evidence" {
+		writeError(w, r, problem.New(problem.NotFound, "not found"))
+		return
+	}
+	page, pageSize := pageParams(r)
+
+	evidence, err := h.links.GetTransactionEvidence(r.Context(), parts[0], page, pageSize)
+	if err != nil {
+		writeLinkError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, evidence)
+}
+
+func pageParams(r *http.Request) (int, int) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+	return page, pageSize
+}
+
+func writeLinkError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrEvidenceNotFound) || errors.Is(err, service.ErrTransactionNotFound) {
+		err = problem.Wrap(err, problem.NotFound, err.Error())
+	}
+	writeError(w, r, err)
+}
@@ -0,0 +1,251 @@
+package importsource
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+)
+
+var (
+	ErrSourceNotAllowed    = errors.New("import source is outside the allowed roots")
+	ErrUnsupportedArchive  = errors.New("unsupported archive format")
+	ErrUnsupportedSource   = errors.New("unsupported import source type")
+	ErrNoAllowedImportRoot = errors.New("no import roots are configured")
+)
+
+// Opener opens directories and archives under a fixed set of allowed roots,
+// such as mounted evidence drives
+type Opener struct {
+	allowedRoots []string
+}
+
+// NewOpener creates an opener restricted to the given root directories
+func NewOpener(allowedRoots []string) *Opener {
+	roots := make([]string, 0, len(allowedRoots))
+	for _, root := range allowedRoots {
+		if resolved, err := filepath.EvalSymlinks(root); err == nil {
+			roots = append(roots, filepath.Clean(resolved))
+		}
+	}
+	return &Opener{allowedRoots: roots}
+}
+
+// Open opens an import source. Archives are recognised by their extension:
+// .zip, .tar, .tar.gz and .tgz.
+func (o *Opener) Open(spec domain.ImportSourceSpec) (ports.ImportSource, error) {
+	path, err := o.resolve(spec.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch spec.Type {
+	case domain.ImportSourceDirectory:
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open import source: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("import source %s is not a directory", spec.Path)
+		}
+		return &directorySource{root: path}, nil
+
+	case domain.ImportSourceArchive:
+		lower := strings.ToLower(path)
+		switch {
+		case strings.HasSuffix(lower, ".zip"):
+			reader, err := zip.OpenReader(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open zip archive: %w", err)
+			}
+			return &zipSource{reader: reader}, nil
+		case strings.HasSuffix(lower, ".tar"):
+			return openTarSource(path, false)
+		case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+			return openTarSource(path, true)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedArchive, filepath.Base(path))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedSource, spec.Type)
+}
+
+// resolve resolves symlinks in a source path and checks it lies under an
+// allowed root
+func (o *Opener) resolve(path string) (string, error) {
+	if len(o.allowedRoots) == 0 {
+		return "", ErrNoAllowedImportRoot
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("import source path must be absolute: %s", path)
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open import source: %w", err)
+	}
+
+	for _, root := range o.allowedRoots {
+		rel, err := filepath.Rel(root, resolved)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+	return "", ErrSourceNotAllowed
+}
+
+// directorySource walks a directory tree in lexical order. Symlinks and other
+// non-regular files are skipped so an import cannot leave the source.
+type directorySource struct {
+	root string
+}
+
+func (s *directorySource) Walk(ctx context.Context, fn ports.ImportFileFunc) error {
+	return filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+
+		return fn(filepath.ToSlash(rel), info.Size(), func() (io.ReadCloser, error) {
+			return os.Open(path)
+		})
+	})
+}
+
+func (s *directorySource) Close() error {
+	return nil
+}
+
+// zipSource walks a zip archive in name order
+type zipSource struct {
+	reader *zip.ReadCloser
+}
+
+func (s *zipSource) Walk(ctx context.Context, fn ports.ImportFileFunc) error {
+	files := make([]*zip.File, 0, len(s.reader.File))
+	for _, file := range s.reader.File {
+		if file.Mode().IsRegular() {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		path, err := domain.CleanImportPath(file.Name)
+		if err != nil {
+			return fmt.Errorf("%w in archive: %s", err, file.Name)
+		}
+		if err := fn(path, int64(file.UncompressedSize64), file.Open); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *zipSource) Close() error {
+	return s.reader.Close()
+}
+
+// tarSource walks a tar archive, optionally gzip-compressed, in archive order.
+// Tar archives can only be read sequentially, so each walk reopens the file.
+type tarSource struct {
+	path       string
+	compressed bool
+}
+
+func openTarSource(path string, compressed bool) (*tarSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tar archive: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("import source %s is not a file", path)
+	}
+	return &tarSource{path: path, compressed: compressed}, nil
+}
+
+func (s *tarSource) Walk(ctx context.Context, fn ports.ImportFileFunc) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open tar archive: %w", err)
+	}
+	defer file.Close()
+
+	var archive io.Reader = file
+	if s.compressed {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		archive = gz
+	}
+
+	reader := tar.NewReader(archive)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		path, err := domain.CleanImportPath(header.Name)
+		if err != nil {
+			return fmt.Errorf("%w in archive: %s", err, header.Name)
+		}
+		err = fn(path, header.Size, func() (io.ReadCloser, error) {
+			return io.NopCloser(reader), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *tarSource) Close() error {
+	return nil
+}
+
+// Ensure Opener implements ImportSourceOpener
+var _ ports.ImportSourceOpener = (*Opener)(nil)
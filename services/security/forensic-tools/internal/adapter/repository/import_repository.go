@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+)
+
+// ErrImportJobNotFound is returned when an import job does not exist
+var ErrImportJobNotFound = errors.New("import job not found")
+
+const importJobColumns = `
+	id, case_id, source_type, source_path, status, total_items, imported_items,
+	mismatched_items, missing_items, failed_items, duplicate_items, unlisted_items,
+	requested_by, submitted_at, started_at, completed_at, error_message,
+	lease_owner, lease_until
+`
+
+const importItemColumns = `
+	id, job_id, path, expected_hash, actual_hash, evidence_type, metadata, status,
+	evidence_id, error_message, updated_at
+`
+
+// CreateImportJob creates an import job and its manifest items in one transaction
+func (r *PostgresRepository) CreateImportJob(ctx context.Context, job *domain.ImportJob, items []*domain.ImportItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO import_jobs (id, case_id, source_type, source_path, status, total_items,
+		                         requested_by, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	if _, err := tx.ExecContext(ctx, query,
+		job.ID, job.CaseID, job.Source.Type, job.Source.Path, job.Status, job.Counts.Total,
+		job.RequestedBy, job.SubmittedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO import_items (id, job_id, path, expected_hash, evidence_type, metadata, status, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare import items: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, item := range items {
+		metadata, _ := json.Marshal(item.Metadata)
+		if _, err := stmt.ExecContext(ctx,
+			item.ID, item.JobID, item.Path, item.ExpectedHash, item.EvidenceType,
+			metadata, item.Status, item.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to create import item %s: %w", item.Path, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetImportJob retrieves an import job by ID
+func (r *PostgresRepository) GetImportJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	query := `SELECT ` + importJobColumns + ` FROM import_jobs WHERE id = $1`
+
+	job, err := scanImportJob(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrImportJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	return job, nil
+}
+
+// ListResumableImportJobs retrieves pending jobs and running jobs whose lease
+// has expired, oldest first
+func (r *PostgresRepository) ListResumableImportJobs(ctx context.Context, now time.Time, limit int) ([]*domain.ImportJob, error) {
+	query := `
+		SELECT ` + importJobColumns + ` FROM import_jobs
+		WHERE status = 'PENDING' OR (status = 'RUNNING' AND (lease_until IS NULL OR lease_until < $1))
+		ORDER BY submitted_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resumable import jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*domain.ImportJob
+	for rows.Next() {
+		job, err := scanImportJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// ClaimImportJob takes the lease of a pending job or a running job whose
+// lease has expired
+func (r *PostgresRepository) ClaimImportJob(ctx context.Context, id, owner string, now, leaseUntil time.Time) (bool, error) {
+	query := `
+		UPDATE import_jobs SET status='RUNNING', lease_owner=$1, lease_until=$2,
+		       started_at=COALESCE(started_at, $3)
+		WHERE id=$4 AND (status = 'PENDING' OR (status = 'RUNNING' AND (lease_until IS NULL OR lease_until < $3)))
+	`
+
+	result, err := r.db.ExecContext(ctx, query, owner, leaseUntil, now, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim import job: %w", err)
+	}
+
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim import job: %w", err)
+	}
+	return claimed == 1, nil
+}
+
+// UpdateImportJob saves an import job's status, counts and lease while owner
+// holds the lease
+func (r *PostgresRepository) UpdateImportJob(ctx context.Context, job *domain.ImportJob, owner string) (bool, error) {
+	query := `
+		UPDATE import_jobs SET status=$1, imported_items=$2, mismatched_items=$3, missing_items=$4,
+		       failed_items=$5, duplicate_items=$6, unlisted_items=$7, completed_at=$8,
+		       error_message=$9, lease_until=$10
+		WHERE id=$11 AND lease_owner=$12
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		job.Status, job.Counts.Imported, job.Counts.Mismatched, job.Counts.Missing,
+		job.Counts.Failed, job.Counts.Duplicates, job.Counts.Unlisted, job.CompletedAt,
+		job.ErrorMessage, job.LeaseUntil, job.ID, owner,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update import job: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update import job: %w", err)
+	}
+	return updated == 1, nil
+}
+
+// AddImportItem records a file found during import, such as an unlisted file.
+// Items already recorded for the same path are left unchanged.
+func (r *PostgresRepository) AddImportItem(ctx context.Context, item *domain.ImportItem) (bool, error) {
+	metadata, _ := json.Marshal(item.Metadata)
+
+	query := `
+		INSERT INTO import_items (id, job_id, path, expected_hash, actual_hash, evidence_type,
+		                          metadata, status, evidence_id, error_message, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (job_id, path) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		item.ID, item.JobID, item.Path, item.ExpectedHash, item.ActualHash, item.EvidenceType,
+		metadata, item.Status, item.EvidenceID, item.ErrorMessage, item.UpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to add import item: %w", err)
+	}
+
+	added, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to add import item: %w", err)
+	}
+	return added == 1, nil
+}
+
+// UpdateImportItem records the outcome of importing an item
+func (r *PostgresRepository) UpdateImportItem(ctx context.Context, item *domain.ImportItem) error {
+	query := `
+		UPDATE import_items SET actual_hash=$1, status=$2, evidence_id=$3, error_message=$4, updated_at=$5
+		WHERE id=$6
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		item.ActualHash, item.Status, item.EvidenceID, item.ErrorMessage, item.UpdatedAt, item.ID,
+	)
+	return err
+}
+
+// ListPendingImportItems retrieves the items of a job that have not been processed
+func (r *PostgresRepository) ListPendingImportItems(ctx context.Context, jobID string) ([]*domain.ImportItem, error) {
+	query := `
+		SELECT ` + importItemColumns + ` FROM import_items
+		WHERE job_id = $1 AND status = 'PENDING'
+		ORDER BY path ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending import items: %w", err)
+	}
+	defer rows.Close()
+
+	return scanImportItems(rows)
+}
+
+// ListImportItems retrieves the items of a job, optionally filtered by status
+func (r *PostgresRepository) ListImportItems(ctx context.Context, jobID string, status domain.ImportItemStatus, page, pageSize int) ([]*domain.ImportItem, int64, error) {
+	offset := (page - 1) * pageSize
+
+	// Get total count
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM import_items WHERE job_id = $1 AND ($2 = '' OR status = $2)"
+	if err := r.db.QueryRowContext(ctx, countQuery, jobID, string(status)).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count import items: %w", err)
+	}
+
+	query := `
+		SELECT ` + importItemColumns + ` FROM import_items
+		WHERE job_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY path ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, jobID, string(status), pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list import items: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := scanImportItems(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanImportJob(row rowScanner) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	var startedAt, completedAt, leaseUntil sql.NullTime
+	var errorMessage, leaseOwner sql.NullString
+
+	if err := row.Scan(
+		&job.ID, &job.CaseID, &job.Source.Type, &job.Source.Path, &job.Status, &job.Counts.Total,
+		&job.Counts.Imported, &job.Counts.Mismatched, &job.Counts.Missing, &job.Counts.Failed,
+		&job.Counts.Duplicates, &job.Counts.Unlisted, &job.RequestedBy, &job.SubmittedAt,
+		&startedAt, &completedAt, &errorMessage, &leaseOwner, &leaseUntil,
+	); err != nil {
+		return nil, err
+	}
+
+	job.ErrorMessage = errorMessage.String
+	job.LeaseOwner = leaseOwner.String
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+	if leaseUntil.Valid {
+		job.LeaseUntil = &leaseUntil.Time
+	}
+
+	return &job, nil
+}
+
+func scanImportItems(rows *sql.Rows) ([]*domain.ImportItem, error) {
+	var items []*domain.ImportItem
+	for rows.Next() {
+		var item domain.ImportItem
+		var metadata []byte
+		var actualHash, evidenceID, errorMessage sql.NullString
+
+		if err := rows.Scan(
+			&item.ID, &item.JobID, &item.Path, &item.ExpectedHash, &actualHash, &item.EvidenceType,
+			&metadata, &item.Status, &evidenceID, &errorMessage, &item.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan import item: %w", err)
+		}
+
+		json.Unmarshal(metadata, &item.Metadata)
+		item.ActualHash = actualHash.String
+		item.EvidenceID = evidenceID.String
+		item.ErrorMessage = errorMessage.String
+		items = append(items, &item)
+	}
+
+	return items, rows.Err()
+}
+
+// Ensure PostgresRepository implements ImportRepository
+var _ ports.ImportRepository = (*PostgresRepository)(nil)
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/lib/pq"
+)
+
+// ErrTransactionNotFound is returned when a transaction is not in the
+// transaction store
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+const evidenceLinkColumns = `
+	l.id, l.evidence_id, l.case_id, l.target_type, l.target_id, l.indicator_kind,
+	l.indicator_value, l.indicator_chain, l.indicator_offset, l.result_id, l.tool_name,
+	l.created_at
+`
+
+// transactionColumns reads the platform transactions table
+const transactionColumns = `
+	id, tx_hash, network, COALESCE(block_number, 0), COALESCE(sender, ''),
+	COALESCE(receiver, ''), amount::text, asset, timestamp
+`
+
+// walletColumns reads the platform wallets table
+const walletColumns = `id, address, network, COALESCE(label, ''), COALESCE(risk_score, 0)`
+
+// CreateEvidenceLinks stores links in one transaction, skipping duplicates
+func (r *PostgresRepository) CreateEvidenceLinks(ctx context.Context, links []*domain.EvidenceLink) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO evidence_links (id, evidence_id, case_id, target_type, target_id, indicator_kind,
+		                            indicator_value, indicator_chain, indicator_offset, result_id,
+		                            tool_name, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (evidence_id, target_type, target_id, indicator_value) DO NOTHING
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare evidence links: %w", err)
+	}
+	defer stmt.Close()
+
+	created := 0
+	for _, link := range links {
+		res, err := stmt.ExecContext(ctx,
+			link.ID, link.EvidenceID, link.CaseID, link.TargetType, link.TargetID,
+			link.Indicator.Kind, link.Indicator.Value, link.Indicator.Chain, link.Indicator.Offset,
+			link.ResultID, link.ToolName, link.CreatedAt,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create evidence link: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			created++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit evidence links: %w", err)
+	}
+	return created, nil
+}
+
+// ListEvidenceLinks retrieves evidence's links of one target type, in the order
+// they were made
+func (r *PostgresRepository) ListEvidenceLinks(ctx context.Context, evidenceID string, targetType domain.LinkTargetType, page, pageSize int) ([]*domain.EvidenceLink, int64, error) {
+	offset := (page - 1) * pageSize
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM evidence_links WHERE evidence_id = $1 AND target_type = $2"
+	if err := r.db.QueryRowContext(ctx, countQuery, evidenceID, targetType).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count evidence links: %w", err)
+	}
+
+	query := `SELECT ` + evidenceLinkColumns + ` FROM evidence_links l
+		WHERE l.evidence_id = $1 AND l.target_type = $2
+		ORDER BY l.created_at, l.indicator_offset LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, evidenceID, targetType, pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list evidence links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*domain.EvidenceLink{}
+	for rows.Next() {
+		var link domain.EvidenceLink
+		if err := rows.Scan(evidenceLinkFields(&link)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan evidence link: %w", err)
+		}
+		links = append(links, &link)
+	}
+	return links, total, rows.Err()
+}
+
+// ListTargetLinks retrieves the links to any of the targets with their
+// evidence, newest evidence first
+func (r *PostgresRepository) ListTargetLinks(ctx context.Context, targets []domain.LinkTarget, page, pageSize int) ([]*domain.LinkedEvidence, int64, error) {
+	offset := (page - 1) * pageSize
+
+	var transactionIDs, walletIDs []string
+	for _, target := range targets {
+		if target.Type == domain.LinkTargetWallet {
+			walletIDs = append(walletIDs, target.ID)
+		} else {
+			transactionIDs = append(transactionIDs, target.ID)
+		}
+	}
+
+	from := `
+		FROM evidence_links l
+		JOIN evidence e ON e.id::text = l.evidence_id
+		WHERE e.deleted_at IS NULL
+		  AND ((l.target_type = 'TRANSACTION' AND l.target_id = ANY($1))
+		    OR (l.target_type = 'WALLET' AND l.target_id = ANY($2)))
+	`
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+from,
+		pq.Array(transactionIDs), pq.Array(walletIDs),
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count target links: %w", err)
+	}
+
+	query := `SELECT ` + evidenceLinkColumns + `,
+		       e.id, e.case_id, e.file_name, e.file_hash, e.file_type, e.size_bytes,
+		       e.evidence_type, e.status, e.uploaded_by, e.uploaded_at, e.updated_at
+	` + from + ` ORDER BY e.uploaded_at DESC, l.created_at LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(transactionIDs), pq.Array(walletIDs), pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list target links: %w", err)
+	}
+	defer rows.Close()
+
+	linked := []*domain.LinkedEvidence{}
+	for rows.Next() {
+		var link domain.EvidenceLink
+		var evidence domain.Evidence
+		fields := append(evidenceLinkFields(&link),
+			&evidence.ID, &evidence.CaseID, &evidence.FileName, &evidence.FileHash,
+			&evidence.FileType, &evidence.SizeBytes, &evidence.EvidenceType, &evidence.Status,
+			&evidence.UploadedBy, &evidence.UploadedAt, &evidence.UpdatedAt,
+		)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan target link: %w", err)
+		}
+		linked = append(linked, &domain.LinkedEvidence{Link: &link, Evidence: &evidence})
+	}
+	return linked, total, rows.Err()
+}
+
+// GetTransaction retrieves a transaction from the transaction store
+func (r *PostgresRepository) GetTransaction(ctx context.Context, id string) (*domain.TransactionRecord, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id = $1`
+
+	tx, err := scanTransactionRecord(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// GetTransactions retrieves transactions from the transaction store by ID
+func (r *PostgresRepository) GetTransactions(ctx context.Context, ids []string) ([]*domain.TransactionRecord, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id = ANY($1)`
+	return r.queryTransactionRecords(ctx, query, pq.Array(ids))
+}
+
+// FindTransactionsByHash retrieves the transactions with any of the hashes
+func (r *PostgresRepository) FindTransactionsByHash(ctx context.Context, hashes []string) ([]*domain.TransactionRecord, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE tx_hash = ANY($1)`
+	return r.queryTransactionRecords(ctx, query, pq.Array(hashes))
+}
+
+// GetWallets retrieves wallets from the transaction store by ID
+func (r *PostgresRepository) GetWallets(ctx context.Context, ids []string) ([]*domain.WalletRecord, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE id = ANY($1)`
+	return r.queryWalletRecords(ctx, query, pq.Array(ids))
+}
+
+// FindWalletsByAddress retrieves the wallets with any of the addresses, on
+// every network they are known on
+func (r *PostgresRepository) FindWalletsByAddress(ctx context.Context, addresses []string) ([]*domain.WalletRecord, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE address = ANY($1)`
+	return r.queryWalletRecords(ctx, query, pq.Array(addresses))
+}
+
+func (r *PostgresRepository) queryTransactionRecords(ctx context.Context, query string, args ...interface{}) ([]*domain.TransactionRecord, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []*domain.TransactionRecord{}
+	for rows.Next() {
+		tx, err := scanTransactionRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, rows.Err()
+}
+
+func (r *PostgresRepository) queryWalletRecords(ctx context.Context, query string, args ...interface{}) ([]*domain.WalletRecord, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query wallets: %w", err)
+	}
+	defer rows.Close()
+
+	wallets := []*domain.WalletRecord{}
+	for rows.Next() {
+		var wallet domain.WalletRecord
+		if err := rows.Scan(&wallet.ID, &wallet.Address, &wallet.Network, &wallet.Label, &wallet.RiskScore); err != nil {
+			return nil, fmt.Errorf("failed to scan wallet: %w", err)
+		}
+		wallets = append(wallets, &wallet)
+	}
+	return wallets, rows.Err()
+}
+
+func scanTransactionRecord(row rowScanner) (*domain.TransactionRecord, error) {
+	var tx domain.TransactionRecord
+	err := row.Scan(
+		&tx.ID, &tx.TxHash, &tx.Network, &tx.BlockNumber, &tx.Sender,
+		&tx.Receiver, &tx.Amount, &tx.Asset, &tx.Timestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func evidenceLinkFields(link *domain.EvidenceLink) []interface{} {
+	return []interface{}{
+		&link.ID, &link.EvidenceID, &link.CaseID, &link.TargetType, &link.TargetID,
+		&link.Indicator.Kind, &link.Indicator.Value, &link.Indicator.Chain, &link.Indicator.Offset,
+		&link.ResultID, &link.ToolName, &link.CreatedAt,
+	}
+}
+
+// Ensure PostgresRepository implements LinkRepository and TransactionStore
+var (
+	_ ports.LinkRepository   = (*PostgresRepository)(nil)
+	_ ports.TransactionStore = (*PostgresRepository)(nil)
+)
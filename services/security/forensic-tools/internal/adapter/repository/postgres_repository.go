@@ -4,15 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/csic-platform/services/security/forensic-tools/internal/config"
 	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
-	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 )
 
+// Errors returned when a record does not exist
+var (
+	ErrEvidenceNotFound    = errors.New("evidence not found")
+	ErrAnalysisJobNotFound = errors.New("analysis job not found")
+)
+
 // PostgresRepository implements the EvidenceRepository interface using PostgreSQL
 type PostgresRepository struct {
 	db *sql.DB
@@ -141,6 +147,44 @@ func (r *PostgresRepository) GetEvidenceByHash(ctx context.Context, hash string)
 	return &evidence, nil
 }
 
+// GetCaseEvidenceByHash retrieves evidence of a case by file hash
+func (r *PostgresRepository) GetCaseEvidenceByHash(ctx context.Context, caseID, hash string) (*domain.Evidence, error) {
+	query := `
+		SELECT id, case_id, file_name, file_hash, file_type, size_bytes, storage_path,
+		       evidence_type, status, metadata, uploaded_by, uploaded_at, updated_at,
+		       archived_at, deleted_at
+		FROM evidence WHERE case_id = $1 AND file_hash = $2 AND deleted_at IS NULL
+	`
+
+	var evidence domain.Evidence
+	var metadata []byte
+	var archivedAt, deletedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, caseID, hash).Scan(
+		&evidence.ID, &evidence.CaseID, &evidence.FileName, &evidence.FileHash,
+		&evidence.FileType, &evidence.SizeBytes, &evidence.StoragePath, &evidence.EvidenceType,
+		&evidence.Status, &metadata, &evidence.UploadedBy, &evidence.UploadedAt, &evidence.UpdatedAt,
+		&archivedAt, &deletedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get case evidence by hash: %w", err)
+	}
+
+	json.Unmarshal(metadata, &evidence.Metadata)
+
+	if archivedAt.Valid {
+		evidence.ArchivedAt = &archivedAt.Time
+	}
+	if deletedAt.Valid {
+		evidence.DeletedAt = &deletedAt.Time
+	}
+
+	return &evidence, nil
+}
+
 // ListEvidence retrieves a paginated list of evidence
 func (r *PostgresRepository) ListEvidence(ctx context.Context, caseID string, page, pageSize int) ([]*domain.Evidence, int64, error) {
 	offset := (page - 1) * pageSize
@@ -344,6 +388,34 @@ func (r *PostgresRepository) UpdateAnalysisJob(ctx context.Context, job *domain.
 	return err
 }
 
+// ClaimAnalysisJob marks a pending or queued job as processing. It returns
+// false if another worker claimed or cancelled the job first.
+func (r *PostgresRepository) ClaimAnalysisJob(ctx context.Context, id string, startedAt time.Time) (bool, error) {
+	query := `
+		UPDATE analysis_jobs SET status=$1, started_at=$2, progress=0
+		WHERE id=$3 AND status IN ('PENDING', 'QUEUED')
+	`
+
+	result, err := r.db.ExecContext(ctx, query, domain.AnalysisJobStatusProcessing, startedAt, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim analysis job: %w", err)
+	}
+
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim analysis job: %w", err)
+	}
+	return claimed == 1, nil
+}
+
+// UpdateAnalysisJobProgress updates the progress of a processing job
+func (r *PostgresRepository) UpdateAnalysisJobProgress(ctx context.Context, id string, progress int) error {
+	query := `UPDATE analysis_jobs SET progress=$1 WHERE id=$2 AND status=$3`
+
+	_, err := r.db.ExecContext(ctx, query, progress, id, domain.AnalysisJobStatusProcessing)
+	return err
+}
+
 // ListAnalysisJobs retrieves analysis jobs for evidence
 func (r *PostgresRepository) ListAnalysisJobs(ctx context.Context, evidenceID string, page, pageSize int) ([]*domain.AnalysisJob, int64, error) {
 	offset := (page - 1) * pageSize
@@ -0,0 +1,93 @@
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+)
+
+// ProviderAWSKMS is the HSM provider signing with AWS KMS keys, which are
+// generated and kept in FIPS 140-2 validated HSMs
+const ProviderAWSKMS = "aws-kms"
+
+// NewPackageSigner creates the package signer of the configured HSM
+func NewPackageSigner(ctx context.Context, cfg config.HSMConfig) (ports.PackageSigner, error) {
+	switch cfg.Provider {
+	case ProviderAWSKMS:
+		var opts []func(*awsconfig.LoadOptions) error
+		if cfg.Region != "" {
+			opts = append(opts, awsconfig.WithRegion(cfg.Region))
+		}
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		return NewKMSSigner(kms.NewFromConfig(awsCfg), cfg.KeyID, cfg.KeyType)
+	case "":
+		return nil, errors.New("no HSM provider is configured")
+	default:
+		return nil, fmt.Errorf("unsupported HSM provider %q", cfg.Provider)
+	}
+}
+
+// KMSSigner signs package manifest digests with an asymmetric AWS KMS key
+type KMSSigner struct {
+	client    *kms.Client
+	keyID     string
+	algorithm types.SigningAlgorithmSpec
+}
+
+// NewKMSSigner creates a signer for the KMS key with the given ID, ARN or
+// alias. keyType selects the algorithm: "ECDSA" for ECC_NIST_P256 keys,
+// otherwise RSA PKCS#1 v1.5.
+func NewKMSSigner(client *kms.Client, keyID, keyType string) (*KMSSigner, error) {
+	if keyID == "" {
+		return nil, errors.New("no HSM signing key is configured")
+	}
+
+	algorithm := types.SigningAlgorithmSpecRsassaPkcs1V15Sha256
+	if strings.EqualFold(keyType, "ECDSA") {
+		algorithm = types.SigningAlgorithmSpecEcdsaSha256
+	}
+
+	return &KMSSigner{
+		client:    client,
+		keyID:     keyID,
+		algorithm: algorithm,
+	}, nil
+}
+
+// Sign signs a SHA-256 digest
+func (s *KMSSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	out, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: s.algorithm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with KMS key %s: %w", s.keyID, err)
+	}
+	return out.Signature, nil
+}
+
+// KeyID returns the KMS key ID
+func (s *KMSSigner) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm returns the KMS signing algorithm
+func (s *KMSSigner) Algorithm() string {
+	return string(s.algorithm)
+}
+
+// Ensure KMSSigner implements PackageSigner
+var _ ports.PackageSigner = (*KMSSigner)(nil)
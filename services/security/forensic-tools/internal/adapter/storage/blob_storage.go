@@ -12,6 +12,18 @@ import (
 	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
 )
 
+// NewBlobStorage creates the blob storage selected by cfg.Type
+func NewBlobStorage(ctx context.Context, cfg *config.StorageConfig) (ports.BlobStorage, error) {
+	switch cfg.Type {
+	case "local":
+		return NewLocalStorage(&cfg.Local)
+	case "s3", "minio":
+		return NewS3Storage(ctx, &cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %s", cfg.Type)
+	}
+}
+
 // LocalStorage implements BlobStorage using local filesystem
 type LocalStorage struct {
 	basePath   string
@@ -47,17 +59,26 @@ func (s *LocalStorage) Upload(ctx context.Context, objectName string, reader io.
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Create file
-	file, err := os.Create(fullPath)
+	// Write to a temporary file and rename it, so a failed upload never leaves
+	// a partial object behind
+	file, err := os.CreateTemp(dir, ".upload-*")
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
+	defer os.Remove(file.Name())
 	defer file.Close()
 
 	// Copy data
 	if _, err := io.Copy(file, reader); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), fullPath); err != nil {
+		return fmt.Errorf("failed to store file: %w", err)
+	}
 
 	return nil
 }
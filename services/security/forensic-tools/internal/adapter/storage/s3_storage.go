@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// Server-side encryption modes for S3 storage
+const (
+	SSEModeNone = ""
+	SSEModeS3   = "SSE-S3"
+	SSEModeKMS  = "SSE-KMS"
+)
+
+// S3Storage implements BlobStorage using S3 or MinIO
+type S3Storage struct {
+	client      *minio.Client
+	bucket      string
+	sse         encrypt.ServerSide
+	partSize    uint64
+	threads     uint
+	maxFileSize int64
+}
+
+// NewS3Storage creates a new S3/MinIO storage instance and makes sure the
+// evidence bucket exists
+func NewS3Storage(ctx context.Context, cfg *config.S3Storage) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	sse, err := newServerSideEncryption(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket: %w", err)
+		}
+	}
+
+	return &S3Storage{
+		client:      client,
+		bucket:      cfg.Bucket,
+		sse:         sse,
+		partSize:    uint64(cfg.PartSizeMB) * 1024 * 1024,
+		threads:     uint(cfg.UploadThreads),
+		maxFileSize: cfg.MaxFileSize,
+	}, nil
+}
+
+// newServerSideEncryption returns the encryption applied to every uploaded object
+func newServerSideEncryption(cfg *config.S3Storage) (encrypt.ServerSide, error) {
+	switch strings.ToUpper(cfg.ServerSideEncryption) {
+	case SSEModeNone:
+		return nil, nil
+	case SSEModeS3:
+		return encrypt.NewSSE(), nil
+	case SSEModeKMS:
+		if cfg.KMSKeyID == "" {
+			return nil, fmt.Errorf("kms_key_id is required for %s", SSEModeKMS)
+		}
+		return encrypt.NewSSEKMS(cfg.KMSKeyID, nil)
+	default:
+		return nil, fmt.Errorf("unsupported server-side encryption %q", cfg.ServerSideEncryption)
+	}
+}
+
+// Upload uploads an object. Objects larger than the part size, or of unknown
+// size (-1), are sent as a multipart upload.
+func (s *S3Storage) Upload(ctx context.Context, objectName string, reader io.Reader, size int64, contentType string) error {
+	if s.maxFileSize > 0 && size > s.maxFileSize {
+		return fmt.Errorf("file size %d exceeds maximum allowed size %d", size, s.maxFileSize)
+	}
+
+	if _, err := s.client.PutObject(ctx, s.bucket, objectName, reader, size, s.putOptions(contentType)); err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return nil
+}
+
+// UploadFromFile uploads a local file, using a multipart upload for large files
+func (s *S3Storage) UploadFromFile(ctx context.Context, sourcePath, objectName string) error {
+	if _, err := s.client.FPutObject(ctx, s.bucket, objectName, sourcePath, s.putOptions("")); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return nil
+}
+
+// Download downloads an object to writer
+func (s *S3Storage) Download(ctx context.Context, objectName string, writer io.Writer) error {
+	object, err := s.GetFile(ctx, objectName)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	_, err = io.Copy(writer, object)
+	return err
+}
+
+// GetFile returns a reader for the given object
+func (s *S3Storage) GetFile(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	// GetObject is lazy; stat it so a missing object fails here
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	return object, nil
+}
+
+// Delete deletes an object
+func (s *S3Storage) Delete(ctx context.Context, objectName string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMultiple deletes multiple objects in bulk
+func (s *S3Storage) DeleteMultiple(ctx context.Context, objectNames []string) error {
+	objects := make(chan minio.ObjectInfo, len(objectNames))
+	for _, name := range objectNames {
+		objects <- minio.ObjectInfo{Key: name}
+	}
+	close(objects)
+
+	for result := range s.client.RemoveObjects(ctx, s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if result.Err != nil {
+			return fmt.Errorf("failed to delete object %s: %w", result.ObjectName, result.Err)
+		}
+	}
+
+	return nil
+}
+
+// GetObjectInfo returns information about a stored object
+func (s *S3Storage) GetObjectInfo(ctx context.Context, objectName string) (*ports.BlobObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object info: %w", err)
+	}
+
+	return &ports.BlobObjectInfo{
+		Name:        objectName,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		CreatedAt:   info.LastModified,
+		ModifiedAt:  info.LastModified,
+		ETag:        info.ETag,
+	}, nil
+}
+
+// ObjectExists checks if an object exists
+func (s *S3Storage) ObjectExists(ctx context.Context, objectName string) (bool, error) {
+	_, err := s.client.StatObject(ctx, s.bucket, objectName, minio.StatObjectOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return false, nil
+	}
+	return false, err
+}
+
+// GetSignedURL returns a presigned download URL for an object
+func (s *S3Storage) GetSignedURL(ctx context.Context, objectName string, expiry time.Duration) (string, error) {
+	signedURL, err := s.client.PresignedGetObject(ctx, s.bucket, objectName, expiry, url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object: %w", err)
+	}
+
+	return signedURL.String(), nil
+}
+
+// putOptions returns the upload options shared by every upload
+func (s *S3Storage) putOptions(contentType string) minio.PutObjectOptions {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return minio.PutObjectOptions{
+		ContentType:          contentType,
+		ServerSideEncryption: s.sse,
+		PartSize:             s.partSize,
+		NumThreads:           s.threads,
+	}
+}
+
+// Ensure S3Storage implements BlobStorage
+var _ ports.BlobStorage = (*S3Storage)(nil)
@@ -15,6 +15,8 @@ type Config struct {
 	Storage   StorageConfig   `mapstructure:"storage"`
 	Kafka     KafkaConfig     `mapstructure:"kafka"`
 	Analysis  AnalysisConfig  `mapstructure:"analysis"`
+	Import    ImportConfig    `mapstructure:"import"`
+	Export    ExportConfig    `mapstructure:"export"`
 	Logging   LoggingConfig   `mapstructure:"logging"`
 	Monitoring MonitoringConfig `mapstructure:"monitoring"`
 }
@@ -63,12 +65,19 @@ type LocalStorage struct {
 
 // S3Storage contains S3-compatible storage settings
 type S3Storage struct {
-	Endpoint  string `mapstructure:"endpoint"`
-	Bucket    string `mapstructure:"bucket"`
-	AccessKey string `mapstructure:"access_key"`
-	SecretKey string `mapstructure:"secret_key"`
-	UseSSL    bool   `mapstructure:"use_ssl"`
-	Region    string `mapstructure:"region"`
+	Endpoint    string `mapstructure:"endpoint"`
+	Bucket      string `mapstructure:"bucket"`
+	AccessKey   string `mapstructure:"access_key"`
+	SecretKey   string `mapstructure:"secret_key"`
+	UseSSL      bool   `mapstructure:"use_ssl"`
+	Region      string `mapstructure:"region"`
+	MaxFileSize int64  `mapstructure:"max_file_size"`
+	// ServerSideEncryption is "", "SSE-S3" or "SSE-KMS"
+	ServerSideEncryption string `mapstructure:"server_side_encryption"`
+	KMSKeyID             string `mapstructure:"kms_key_id"`
+	// PartSizeMB is the multipart upload part size; larger uploads are split
+	PartSizeMB    int `mapstructure:"part_size_mb"`
+	UploadThreads int `mapstructure:"upload_threads"`
 }
 
 // KafkaConfig contains Kafka broker settings
@@ -96,6 +105,14 @@ type AnalysisConfig struct {
 	Tools            []AnalysisTool `mapstructure:"tools"`
 	MaxConcurrentJobs int           `mapstructure:"max_concurrent_jobs"`
 	JobTimeout       int           `mapstructure:"job_timeout"`
+	PollInterval     int           `mapstructure:"poll_interval"`
+	LogCarver        LogCarverConfig `mapstructure:"log_carver"`
+}
+
+// LogCarverConfig contains timeline and log carving settings
+type LogCarverConfig struct {
+	MaxEntries    int `mapstructure:"max_entries"`
+	MinLineLength int `mapstructure:"min_line_length"`
 }
 
 // AnalysisTool represents an analysis tool configuration
@@ -105,6 +122,32 @@ type AnalysisTool struct {
 	Path    string `mapstructure:"path"`
 }
 
+// ImportConfig contains bulk evidence import settings
+type ImportConfig struct {
+	// AllowedRoots are the directories, such as evidence drive mount points,
+	// that import sources must lie under
+	AllowedRoots  []string `mapstructure:"allowed_roots"`
+	PollInterval  int      `mapstructure:"poll_interval"`
+	LeaseDuration int      `mapstructure:"lease_duration"`
+	// MaxUnlisted caps how many files missing from the manifest are recorded
+	MaxUnlisted int `mapstructure:"max_unlisted"`
+}
+
+// ExportConfig contains court evidence package export settings
+type ExportConfig struct {
+	// TempDir is where packages are built; the system temp directory if empty
+	TempDir string    `mapstructure:"temp_dir"`
+	HSM     HSMConfig `mapstructure:"hsm"`
+}
+
+// HSMConfig contains the HSM key that signs evidence package manifests
+type HSMConfig struct {
+	Provider string `mapstructure:"provider"` // aws-kms
+	KeyID    string `mapstructure:"key_id"`
+	KeyType  string `mapstructure:"key_type"` // RSA, ECDSA
+	Region   string `mapstructure:"region"`
+}
+
 // LoggingConfig contains logging settings
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
@@ -168,6 +211,9 @@ func (c *Config) Validate() error {
 	if c.Storage.Type == "" {
 		return fmt.Errorf("storage type is required")
 	}
+	if (c.Storage.Type == "s3" || c.Storage.Type == "minio") && c.Storage.S3.Bucket == "" {
+		return fmt.Errorf("s3 bucket is required")
+	}
 	return nil
 }
 
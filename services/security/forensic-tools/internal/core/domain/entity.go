@@ -110,6 +110,9 @@ type AnalysisResult struct {
 	Summary      string                 `json:"summary"`
 	Severity     string                 `json:"severity,omitempty"` // info, warning, critical
 	Tags         []string               `json:"tags,omitempty"`
+	// Indicators are addresses and transaction hashes found in the evidence;
+	// they are linked to the transaction store rather than stored with the result
+	Indicators   []Indicator            `json:"indicators,omitempty"`
 	CreatedAt    time.Time              `json:"created_at"`
 }
 
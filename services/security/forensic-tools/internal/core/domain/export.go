@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+)
+
+// Evidence package format written to manifest.json
+const (
+	PackageFormat        = "csic-evidence-package"
+	PackageFormatVersion = 1
+)
+
+// PackageFileType is the kind of file in an evidence package
+type PackageFileType string
+
+const (
+	PackageFileEvidence     PackageFileType = "EVIDENCE"
+	PackageFileCustody      PackageFileType = "CUSTODY"
+	PackageFileAnalysis     PackageFileType = "ANALYSIS"
+	PackageFileTransactions PackageFileType = "TRANSACTIONS"
+)
+
+// CaseExportRequest selects the evidence of a case to export. All of the
+// case's evidence is exported when EvidenceIDs is empty.
+type CaseExportRequest struct {
+	EvidenceIDs []string `json:"evidence_ids"`
+	Reason      string   `json:"reason"`
+}
+
+// PackageManifest lists every file in an evidence package with its SHA-256
+// hash. It is stored as manifest.json and is what the package signature covers.
+type PackageManifest struct {
+	Format        string             `json:"format"`
+	Version       int                `json:"version"`
+	PackageID     string             `json:"package_id"`
+	CaseID        string             `json:"case_id"`
+	Reason        string             `json:"reason,omitempty"`
+	CreatedBy     string             `json:"created_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	HashAlgorithm string             `json:"hash_algorithm"`
+	Evidence      []*PackageEvidence `json:"evidence"`
+	Files         []*PackageFile     `json:"files"`
+}
+
+// PackageEvidence describes an evidence item in a package. CustodyVerified
+// reports whether its chain of custody was intact when exported.
+type PackageEvidence struct {
+	ID              string       `json:"id"`
+	FileName        string       `json:"file_name"`
+	FileHash        string       `json:"file_hash"`
+	SizeBytes       int64        `json:"size_bytes"`
+	EvidenceType    EvidenceType `json:"evidence_type"`
+	UploadedBy      string       `json:"uploaded_by"`
+	UploadedAt      time.Time    `json:"uploaded_at"`
+	CustodyVerified bool         `json:"custody_verified"`
+}
+
+// PackageFile is a file in an evidence package
+type PackageFile struct {
+	Path       string          `json:"path"`
+	Type       PackageFileType `json:"type"`
+	EvidenceID string          `json:"evidence_id"`
+	SHA256     string          `json:"sha256"`
+	SizeBytes  int64           `json:"size_bytes"`
+}
+
+// PackageSignature is the HSM signature over the SHA-256 digest of
+// manifest.json, stored as signature.json
+type PackageSignature struct {
+	Algorithm      string    `json:"algorithm"`
+	KeyID          string    `json:"key_id"`
+	ManifestSHA256 string    `json:"manifest_sha256"`
+	Signature      string    `json:"signature"` // base64
+	SignedAt       time.Time `json:"signed_at"`
+}
+
+// EvidencePackage describes a built evidence package
+type EvidencePackage struct {
+	ID        string            `json:"id"`
+	CaseID    string            `json:"case_id"`
+	FileName  string            `json:"file_name"`
+	SizeBytes int64             `json:"size_bytes"`
+	SHA256    string            `json:"sha256"`
+	Signature *PackageSignature `json:"signature"`
+}
+
+// AnalysisReport is an analysis job with its results, as exported in a package
+type AnalysisReport struct {
+	Job     *AnalysisJob      `json:"job"`
+	Results []*AnalysisResult `json:"results"`
+}
+
+// TransactionExtract is the transactions and wallets linked to an evidence
+// item, as exported in a package
+type TransactionExtract struct {
+	EvidenceID   string               `json:"evidence_id"`
+	Transactions []*LinkedTransaction `json:"transactions"`
+	Wallets      []*LinkedWallet      `json:"wallets"`
+}
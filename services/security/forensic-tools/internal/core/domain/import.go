@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrInvalidImportPath is returned for manifest or archive paths that are
+// absolute or escape the import source
+var ErrInvalidImportPath = errors.New("invalid import path")
+
+// ImportSourceType represents where bulk-imported evidence is read from
+type ImportSourceType string
+
+const (
+	ImportSourceDirectory ImportSourceType = "directory"
+	ImportSourceArchive   ImportSourceType = "archive"
+)
+
+// ImportJobStatus represents the status of a bulk import job
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending   ImportJobStatus = "PENDING"
+	ImportJobStatusRunning   ImportJobStatus = "RUNNING"
+	ImportJobStatusCompleted ImportJobStatus = "COMPLETED"
+	// ImportJobStatusCompletedWithErrors means the source was fully processed
+	// but some items were missing, mismatched or failed
+	ImportJobStatusCompletedWithErrors ImportJobStatus = "COMPLETED_WITH_ERRORS"
+	ImportJobStatusFailed              ImportJobStatus = "FAILED"
+)
+
+// ImportItemStatus represents the outcome of importing a single file
+type ImportItemStatus string
+
+const (
+	ImportItemStatusPending  ImportItemStatus = "PENDING"
+	ImportItemStatusImported ImportItemStatus = "IMPORTED"
+	// ImportItemStatusMismatch means the file's hash differs from the manifest
+	ImportItemStatusMismatch ImportItemStatus = "MISMATCH"
+	// ImportItemStatusMissing means a manifest entry was not found in the source
+	ImportItemStatusMissing ImportItemStatus = "MISSING"
+	ImportItemStatusFailed  ImportItemStatus = "FAILED"
+	// ImportItemStatusDuplicate means the case already holds the file
+	ImportItemStatusDuplicate ImportItemStatus = "DUPLICATE"
+	// ImportItemStatusUnlisted means a file in the source is not in the manifest
+	ImportItemStatusUnlisted ImportItemStatus = "UNLISTED"
+)
+
+// ImportSourceSpec identifies the directory or archive to import from
+type ImportSourceSpec struct {
+	Type ImportSourceType `json:"type"`
+	Path string           `json:"path"`
+}
+
+// ImportManifest lists the files to import and their expected hashes
+type ImportManifest struct {
+	CaseID string               `json:"case_id"`
+	Source ImportSourceSpec     `json:"source"`
+	Items  []ImportManifestItem `json:"items"`
+}
+
+// ImportManifestItem is a single manifest entry. Path is relative to the
+// source root.
+type ImportManifestItem struct {
+	Path         string            `json:"path"`
+	SHA256       string            `json:"sha256"`
+	EvidenceType EvidenceType      `json:"evidence_type,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// ImportCounts tallies import items by outcome
+type ImportCounts struct {
+	Total      int `json:"total"`
+	Imported   int `json:"imported"`
+	Mismatched int `json:"mismatched"`
+	Missing    int `json:"missing"`
+	Failed     int `json:"failed"`
+	Duplicates int `json:"duplicates"`
+	Unlisted   int `json:"unlisted"`
+}
+
+// Add counts an item that reached the given status
+func (c *ImportCounts) Add(status ImportItemStatus) {
+	switch status {
+	case ImportItemStatusImported:
+		c.Imported++
+	case ImportItemStatusMismatch:
+		c.Mismatched++
+	case ImportItemStatusMissing:
+		c.Missing++
+	case ImportItemStatusFailed:
+		c.Failed++
+	case ImportItemStatusDuplicate:
+		c.Duplicates++
+	case ImportItemStatusUnlisted:
+		c.Unlisted++
+	}
+}
+
+// Processed returns how many manifest items have an outcome
+func (c *ImportCounts) Processed() int {
+	return c.Imported + c.Mismatched + c.Missing + c.Failed + c.Duplicates
+}
+
+// HasErrors reports whether any manifest item was not imported cleanly
+func (c *ImportCounts) HasErrors() bool {
+	return c.Mismatched+c.Missing+c.Failed > 0
+}
+
+// ImportJob represents a bulk evidence import. Jobs are processed by a
+// background worker holding a lease, so an interrupted job is resumed by the
+// next worker once the lease expires.
+type ImportJob struct {
+	ID           string           `json:"id"`
+	CaseID       string           `json:"case_id"`
+	Source       ImportSourceSpec `json:"source"`
+	Status       ImportJobStatus  `json:"status"`
+	Counts       ImportCounts     `json:"counts"`
+	RequestedBy  string           `json:"requested_by"`
+	SubmittedAt  time.Time        `json:"submitted_at"`
+	StartedAt    *time.Time       `json:"started_at,omitempty"`
+	CompletedAt  *time.Time       `json:"completed_at,omitempty"`
+	ErrorMessage string           `json:"error_message,omitempty"`
+	LeaseOwner   string           `json:"-"`
+	LeaseUntil   *time.Time       `json:"-"`
+}
+
+// ImportItem is the state of one file of an import job
+type ImportItem struct {
+	ID           string            `json:"id"`
+	JobID        string            `json:"job_id"`
+	Path         string            `json:"path"`
+	ExpectedHash string            `json:"expected_hash,omitempty"`
+	ActualHash   string            `json:"actual_hash,omitempty"`
+	EvidenceType EvidenceType      `json:"evidence_type"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Status       ImportItemStatus  `json:"status"`
+	EvidenceID   string            `json:"evidence_id,omitempty"`
+	ErrorMessage string            `json:"error_message,omitempty"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// ImportItemListResponse represents a paginated list of import items
+type ImportItemListResponse struct {
+	Items      []*ImportItem `json:"items"`
+	Total      int64         `json:"total"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	TotalPages int           `json:"total_pages"`
+}
+
+// CleanImportPath normalises a path relative to an import source, rejecting
+// absolute paths and paths that escape the source
+func CleanImportPath(p string) (string, error) {
+	p = strings.ReplaceAll(p, "\\", "/")
+	if p == "" || strings.HasPrefix(p, "/") {
+		return "", ErrInvalidImportPath
+	}
+
+	cleaned := path.Clean(p)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidImportPath
+	}
+	return cleaned, nil
+}
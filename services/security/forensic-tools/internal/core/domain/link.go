@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"time"
+)
+
+// IndicatorKind is the kind of on-chain identifier an analyzer found
+type IndicatorKind string
+
+const (
+	IndicatorKindAddress IndicatorKind = "ADDRESS"
+	IndicatorKindTxHash  IndicatorKind = "TX_HASH"
+)
+
+// Chains indicators are recognised on
+const (
+	ChainBitcoin  = "bitcoin"
+	ChainEthereum = "ethereum"
+)
+
+// Indicator is an address or transaction hash found in evidence. Offset is
+// where it was first found in the evidence file.
+type Indicator struct {
+	Kind   IndicatorKind `json:"kind"`
+	Value  string        `json:"value"`
+	Chain  string        `json:"chain"`
+	Offset int64         `json:"offset"`
+}
+
+// LinkTargetType is the kind of record evidence is linked to
+type LinkTargetType string
+
+const (
+	LinkTargetTransaction LinkTargetType = "TRANSACTION"
+	LinkTargetWallet      LinkTargetType = "WALLET"
+)
+
+// EvidenceLink records that an indicator found in evidence matched a
+// transaction or wallet in the transaction store
+type EvidenceLink struct {
+	ID         string         `json:"id"`
+	EvidenceID string         `json:"evidence_id"`
+	CaseID     string         `json:"case_id"`
+	TargetType LinkTargetType `json:"target_type"`
+	TargetID   string         `json:"target_id"`
+	Indicator  Indicator      `json:"indicator"`
+	ResultID   string         `json:"result_id"`
+	ToolName   string         `json:"tool_name"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// TransactionRecord is a transaction in the platform transaction store
+type TransactionRecord struct {
+	ID          string    `json:"id"`
+	TxHash      string    `json:"tx_hash"`
+	Network     string    `json:"network"`
+	BlockNumber int64     `json:"block_number,omitempty"`
+	Sender      string    `json:"sender,omitempty"`
+	Receiver    string    `json:"receiver,omitempty"`
+	Amount      string    `json:"amount"`
+	Asset       string    `json:"asset"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// WalletRecord is a wallet in the platform transaction store
+type WalletRecord struct {
+	ID        string  `json:"id"`
+	Address   string  `json:"address"`
+	Network   string  `json:"network"`
+	Label     string  `json:"label,omitempty"`
+	RiskScore float64 `json:"risk_score"`
+}
+
+// LinkTarget identifies a linked transaction or wallet
+type LinkTarget struct {
+	Type LinkTargetType
+	ID   string
+}
+
+// LinkedTransaction is a transaction linked to evidence. Transaction is nil if
+// the transaction has since left the store.
+type LinkedTransaction struct {
+	Link        *EvidenceLink      `json:"link"`
+	Transaction *TransactionRecord `json:"transaction"`
+}
+
+// LinkedWallet is a wallet linked to evidence. Wallet is nil if the wallet
+// has since left the store.
+type LinkedWallet struct {
+	Link   *EvidenceLink `json:"link"`
+	Wallet *WalletRecord `json:"wallet"`
+}
+
+// LinkedEvidence is evidence linked to a transaction, either directly by its
+// hash or through the wallet of its sender or receiver
+type LinkedEvidence struct {
+	Link     *EvidenceLink `json:"link"`
+	Evidence *Evidence     `json:"evidence"`
+}
+
+// LinkedTransactionListResponse represents a paginated list of linked transactions
+type LinkedTransactionListResponse struct {
+	Transactions []*LinkedTransaction `json:"transactions"`
+	Total        int64                `json:"total"`
+	Page         int                  `json:"page"`
+	PageSize     int                  `json:"page_size"`
+	TotalPages   int                  `json:"total_pages"`
+}
+
+// LinkedWalletListResponse represents a paginated list of linked wallets
+type LinkedWalletListResponse struct {
+	Wallets    []*LinkedWallet `json:"wallets"`
+	Total      int64           `json:"total"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// LinkedEvidenceListResponse represents a paginated list of linked evidence
+type LinkedEvidenceListResponse struct {
+	Evidence   []*LinkedEvidence `json:"evidence"`
+	Total      int64             `json:"total"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int               `json:"total_pages"`
+}
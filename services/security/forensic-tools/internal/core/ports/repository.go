@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
 )
@@ -13,6 +14,7 @@ type EvidenceRepository interface {
 	CreateEvidence(ctx context.Context, evidence *domain.Evidence) error
 	GetEvidence(ctx context.Context, id string) (*domain.Evidence, error)
 	GetEvidenceByHash(ctx context.Context, hash string) (*domain.Evidence, error)
+	GetCaseEvidenceByHash(ctx context.Context, caseID, hash string) (*domain.Evidence, error)
 	ListEvidence(ctx context.Context, caseID string, page, pageSize int) ([]*domain.Evidence, int64, error)
 	UpdateEvidence(ctx context.Context, evidence *domain.Evidence) error
 	DeleteEvidence(ctx context.Context, id string) error
@@ -25,6 +27,8 @@ type EvidenceRepository interface {
 	CreateAnalysisJob(ctx context.Context, job *domain.AnalysisJob) error
 	GetAnalysisJob(ctx context.Context, id string) (*domain.AnalysisJob, error)
 	UpdateAnalysisJob(ctx context.Context, job *domain.AnalysisJob) error
+	ClaimAnalysisJob(ctx context.Context, id string, startedAt time.Time) (bool, error)
+	UpdateAnalysisJobProgress(ctx context.Context, id string, progress int) error
 	ListAnalysisJobs(ctx context.Context, evidenceID string, page, pageSize int) ([]*domain.AnalysisJob, int64, error)
 	GetPendingJobs(ctx context.Context, limit int) ([]*domain.AnalysisJob, error)
 
@@ -33,6 +37,66 @@ type EvidenceRepository interface {
 	GetAnalysisResults(ctx context.Context, jobID string) ([]*domain.AnalysisResult, error)
 }
 
+// ImportRepository defines the interface for bulk import job data access
+type ImportRepository interface {
+	// CreateImportJob stores a job together with its manifest items
+	CreateImportJob(ctx context.Context, job *domain.ImportJob, items []*domain.ImportItem) error
+	GetImportJob(ctx context.Context, id string) (*domain.ImportJob, error)
+	// ListResumableImportJobs returns pending jobs and running jobs whose lease
+	// has expired
+	ListResumableImportJobs(ctx context.Context, now time.Time, limit int) ([]*domain.ImportJob, error)
+	// ClaimImportJob takes the lease of a resumable job. It returns false if
+	// another worker holds the lease or the job has finished.
+	ClaimImportJob(ctx context.Context, id, owner string, now, leaseUntil time.Time) (bool, error)
+	// UpdateImportJob saves a job; it returns false if owner no longer holds
+	// the lease
+	UpdateImportJob(ctx context.Context, job *domain.ImportJob, owner string) (bool, error)
+
+	// AddImportItem records a file found in the source; it returns false if
+	// the job already has an item with the same path
+	AddImportItem(ctx context.Context, item *domain.ImportItem) (bool, error)
+	UpdateImportItem(ctx context.Context, item *domain.ImportItem) error
+	ListPendingImportItems(ctx context.Context, jobID string) ([]*domain.ImportItem, error)
+	ListImportItems(ctx context.Context, jobID string, status domain.ImportItemStatus, page, pageSize int) ([]*domain.ImportItem, int64, error)
+}
+
+// LinkRepository defines the interface for evidence link data access
+type LinkRepository interface {
+	// CreateEvidenceLinks stores links, skipping any the evidence already has
+	// to the same target and indicator, and returns how many were new
+	CreateEvidenceLinks(ctx context.Context, links []*domain.EvidenceLink) (int, error)
+	ListEvidenceLinks(ctx context.Context, evidenceID string, targetType domain.LinkTargetType, page, pageSize int) ([]*domain.EvidenceLink, int64, error)
+	// ListTargetLinks returns the links to any of the targets with their
+	// evidence, leaving out deleted evidence
+	ListTargetLinks(ctx context.Context, targets []domain.LinkTarget, page, pageSize int) ([]*domain.LinkedEvidence, int64, error)
+}
+
+// TransactionStore looks up records in the platform transaction store.
+// Addresses and hashes are matched exactly.
+type TransactionStore interface {
+	GetTransaction(ctx context.Context, id string) (*domain.TransactionRecord, error)
+	GetTransactions(ctx context.Context, ids []string) ([]*domain.TransactionRecord, error)
+	GetWallets(ctx context.Context, ids []string) ([]*domain.WalletRecord, error)
+	FindTransactionsByHash(ctx context.Context, hashes []string) ([]*domain.TransactionRecord, error)
+	FindWalletsByAddress(ctx context.Context, addresses []string) ([]*domain.WalletRecord, error)
+}
+
+// ImportSource walks the files of a directory or archive being imported
+type ImportSource interface {
+	// Walk calls fn for every regular file, with its path relative to the
+	// source root. The reader returned by open is only valid until fn returns.
+	Walk(ctx context.Context, fn ImportFileFunc) error
+	Close() error
+}
+
+// ImportFileFunc receives a file found while walking an import source
+type ImportFileFunc func(path string, size int64, open func() (io.ReadCloser, error)) error
+
+// ImportSourceOpener opens import sources
+type ImportSourceOpener interface {
+	Open(spec domain.ImportSourceSpec) (ImportSource, error)
+}
+
 // BlobStorage defines the interface for evidence file storage
 type BlobStorage interface {
 	// Upload operations
@@ -63,6 +127,16 @@ type BlobObjectInfo struct {
 	ETag        string    `json:"etag"`
 }
 
+// PackageSigner signs evidence package manifests with a key held in an HSM
+type PackageSigner interface {
+	// Sign signs a SHA-256 digest
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+	// KeyID identifies the signing key
+	KeyID() string
+	// Algorithm names the signature algorithm
+	Algorithm() string
+}
+
 // MessageProducer defines the interface for message publishing operations
 type MessageProducer interface {
 	PublishAnalysisJob(ctx context.Context, job *domain.AnalysisJob) error
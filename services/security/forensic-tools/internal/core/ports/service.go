@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
 )
@@ -36,6 +37,31 @@ type ForensicService interface {
 	HealthCheck(ctx context.Context) error
 }
 
+// ImportService defines the interface for bulk evidence import
+type ImportService interface {
+	StartImport(ctx context.Context, manifest *domain.ImportManifest, actorID string) (*domain.ImportJob, error)
+	GetImportJob(ctx context.Context, jobID string) (*domain.ImportJob, error)
+	ListImportItems(ctx context.Context, jobID string, status domain.ImportItemStatus, page, pageSize int) (*domain.ImportItemListResponse, error)
+}
+
+// LinkService defines the interface for linking evidence to the transactions
+// and wallets its analysis results mention
+type LinkService interface {
+	// LinkIndicators links a stored result's indicators to matching records
+	// and returns how many new links were made
+	LinkIndicators(ctx context.Context, evidence *domain.Evidence, result *domain.AnalysisResult) (int, error)
+	GetLinkedTransactions(ctx context.Context, evidenceID string, page, pageSize int) (*domain.LinkedTransactionListResponse, error)
+	GetLinkedWallets(ctx context.Context, evidenceID string, page, pageSize int) (*domain.LinkedWalletListResponse, error)
+	GetTransactionEvidence(ctx context.Context, transactionID string, page, pageSize int) (*domain.LinkedEvidenceListResponse, error)
+}
+
+// ExportService defines the interface for court-ready evidence package export
+type ExportService interface {
+	// ExportCase builds a signed package of a case's evidence. The returned
+	// reader yields the ZIP archive and must be closed.
+	ExportCase(ctx context.Context, caseID string, req *domain.CaseExportRequest, actorID, ipAddress string) (*domain.EvidencePackage, io.ReadCloser, error)
+}
+
 // AnalysisEngine defines the interface for forensic analysis engines
 type AnalysisEngine interface {
 	// Tool identification
@@ -43,11 +69,14 @@ type AnalysisEngine interface {
 	Version() string
 	IsAvailable() bool
 
-	// Analysis execution
-	Analyze(ctx context.Context, evidence *domain.Evidence, storage BlobStorage) (*domain.AnalysisResult, error)
+	// Analysis execution; progress is called with 0-100 as the file is processed
+	Analyze(ctx context.Context, evidence *domain.Evidence, storage BlobStorage, progress ProgressFunc) (*domain.AnalysisResult, error)
 	GetSupportedEvidenceTypes() []domain.EvidenceType
 }
 
+// ProgressFunc receives the completion percentage of a running analysis
+type ProgressFunc func(percent int)
+
 // HashCalculator defines the interface for hash calculation
 type HashCalculator interface {
 	Calculate(reader io.Reader) (string, error)
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// progressStep is the smallest progress change written to the job record
+const progressStep = 5
+
+// AnalysisWorkerPool runs pending analysis jobs in the background. Each job
+// runs its tools one after another; up to MaxConcurrentJobs jobs run at once.
+// Indicators in each stored result are linked to the transaction store.
+type AnalysisWorkerPool struct {
+	repo         ports.EvidenceRepository
+	storage      ports.BlobStorage
+	producer     ports.MessageProducer
+	registry     *AnalyzerRegistry
+	links        ports.LinkService
+	concurrency  int
+	jobTimeout   time.Duration
+	pollInterval time.Duration
+}
+
+// NewAnalysisWorkerPool creates a new analysis worker pool
+func NewAnalysisWorkerPool(
+	repo ports.EvidenceRepository,
+	storage ports.BlobStorage,
+	producer ports.MessageProducer,
+	registry *AnalyzerRegistry,
+	links ports.LinkService,
+	cfg *config.AnalysisConfig,
+) *AnalysisWorkerPool {
+	concurrency := cfg.MaxConcurrentJobs
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	jobTimeout := time.Duration(cfg.JobTimeout) * time.Second
+	if jobTimeout <= 0 {
+		jobTimeout = time.Hour
+	}
+	pollInterval := time.Duration(cfg.PollInterval) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	return &AnalysisWorkerPool{
+		repo:         repo,
+		storage:      storage,
+		producer:     producer,
+		registry:     registry,
+		links:        links,
+		concurrency:  concurrency,
+		jobTimeout:   jobTimeout,
+		pollInterval: pollInterval,
+	}
+}
+
+// Run polls for pending jobs until ctx is cancelled, then waits for running
+// jobs to stop
+func (p *AnalysisWorkerPool) Run(ctx context.Context) {
+	slots := make(chan struct{}, p.concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		free := p.concurrency - len(slots)
+		if free > 0 {
+			jobs, err := p.repo.GetPendingJobs(ctx, free)
+			if err == nil {
+				for _, job := range jobs {
+					claimed, err := p.repo.ClaimAnalysisJob(ctx, job.ID, time.Now())
+					if err != nil || !claimed {
+						continue
+					}
+
+					slots <- struct{}{}
+					wg.Add(1)
+					go func(job *domain.AnalysisJob) {
+						defer wg.Done()
+						defer func() { <-slots }()
+						p.runJob(ctx, job)
+					}(job)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob runs every tool of a claimed job and records the outcome
+func (p *AnalysisWorkerPool) runJob(ctx context.Context, job *domain.AnalysisJob) {
+	ctx, cancel := context.WithTimeout(ctx, p.jobTimeout)
+	defer cancel()
+
+	now := time.Now()
+	job.Status = domain.AnalysisJobStatusProcessing
+	job.StartedAt = &now
+
+	err := p.analyze(ctx, job)
+
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	if err != nil {
+		job.Status = domain.AnalysisJobStatusFailed
+		job.ErrorMessage = err.Error()
+	} else {
+		job.Status = domain.AnalysisJobStatusCompleted
+		job.Progress = 100
+	}
+
+	// The job context may have expired; record the outcome regardless
+	p.repo.UpdateAnalysisJob(context.Background(), job)
+}
+
+// analyze runs the job's tools against its evidence, storing each result.
+// Tools that fail do not stop the remaining tools.
+func (p *AnalysisWorkerPool) analyze(ctx context.Context, job *domain.AnalysisJob) error {
+	evidence, err := p.repo.GetEvidence(ctx, job.EvidenceID)
+	if err != nil {
+		return ErrEvidenceNotFound
+	}
+
+	tools := strings.Split(job.ToolName, ",")
+
+	custodyEntry := &domain.ChainOfCustody{
+		ID:         uuid.New().String(),
+		EvidenceID: evidence.ID,
+		ActorID:    job.RequestedBy,
+		Action:     domain.CoCActionAnalysis,
+		Details: map[string]interface{}{
+			"operation": "RUN_JOB",
+			"job_id":    job.ID,
+			"tools":     tools,
+		},
+		Timestamp: time.Now(),
+	}
+	p.repo.AddCustodyEntry(ctx, custodyEntry)
+
+	var failures []string
+	for i, name := range tools {
+		engine, err := p.registry.Get(name)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		progress := p.progressReporter(job, i, len(tools))
+		result, err := engine.Analyze(ctx, evidence, p.storage, progress)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+
+		result.ID = uuid.New().String()
+		result.JobID = job.ID
+		result.EvidenceID = evidence.ID
+		result.ToolName = name
+		if result.CreatedAt.IsZero() {
+			result.CreatedAt = time.Now()
+		}
+
+		if err := p.repo.CreateAnalysisResult(ctx, result); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: failed to store result: %v", name, err))
+			continue
+		}
+		p.producer.PublishAnalysisResult(ctx, result)
+
+		if len(result.Indicators) > 0 {
+			if _, err := p.links.LinkIndicators(ctx, evidence, result); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+		progress(100)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d tools failed: %s", len(failures), len(tools), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// progressReporter maps the progress of tool index of count onto the job's
+// overall progress, writing it when it has moved by at least progressStep
+func (p *AnalysisWorkerPool) progressReporter(job *domain.AnalysisJob, index, count int) ports.ProgressFunc {
+	return func(percent int) {
+		if percent < 0 {
+			percent = 0
+		}
+		if percent > 100 {
+			percent = 100
+		}
+
+		overall := (index*100 + percent) / count
+		if overall < job.Progress+progressStep && overall != 100 {
+			return
+		}
+
+		job.Progress = overall
+		p.repo.UpdateAnalysisJobProgress(context.Background(), job.ID, overall)
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+)
+
+var (
+	ErrUnknownAnalyzer     = errors.New("unknown analyzer")
+	ErrUnsupportedAnalyzer = errors.New("analyzer does not support this evidence type")
+	ErrNoAnalyzers         = errors.New("no analyzers available for this evidence type")
+)
+
+// AnalyzerRegistry holds the analysis engines that jobs can run
+type AnalyzerRegistry struct {
+	mu      sync.RWMutex
+	engines map[string]ports.AnalysisEngine
+}
+
+// NewAnalyzerRegistry creates a registry of the given engines. Engines listed
+// as disabled in analysis.tools are left out.
+func NewAnalyzerRegistry(tools []config.AnalysisTool, engines ...ports.AnalysisEngine) *AnalyzerRegistry {
+	disabled := make(map[string]bool)
+	for _, tool := range tools {
+		if !tool.Enabled {
+			disabled[tool.Name] = true
+		}
+	}
+
+	registry := &AnalyzerRegistry{engines: make(map[string]ports.AnalysisEngine)}
+	for _, engine := range engines {
+		if !disabled[engine.Name()] {
+			registry.Register(engine)
+		}
+	}
+	return registry
+}
+
+// Register adds an engine, replacing any engine with the same name
+func (r *AnalyzerRegistry) Register(engine ports.AnalysisEngine) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.engines[engine.Name()] = engine
+}
+
+// Get returns the available engine with the given name
+func (r *AnalyzerRegistry) Get(name string) (ports.AnalysisEngine, error) {
+	r.mu.RLock()
+	engine, ok := r.engines[name]
+	r.mu.RUnlock()
+
+	if !ok || !engine.IsAvailable() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAnalyzer, name)
+	}
+	return engine, nil
+}
+
+// Names returns the names of all available engines, sorted
+func (r *AnalyzerRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.engines))
+	for name, engine := range r.engines {
+		if engine.IsAvailable() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve validates the requested tools for an evidence type. With no tools
+// requested it returns every available engine that supports the type.
+func (r *AnalyzerRegistry) Resolve(evidenceType domain.EvidenceType, tools []string) ([]string, error) {
+	if len(tools) == 0 {
+		for _, name := range r.Names() {
+			engine, err := r.Get(name)
+			if err == nil && supportsEvidenceType(engine, evidenceType) {
+				tools = append(tools, name)
+			}
+		}
+		if len(tools) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoAnalyzers, evidenceType)
+		}
+		return tools, nil
+	}
+
+	seen := make(map[string]bool)
+	resolved := make([]string, 0, len(tools))
+	for _, name := range tools {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		engine, err := r.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if !supportsEvidenceType(engine, evidenceType) {
+			return nil, fmt.Errorf("%w: %s on %s", ErrUnsupportedAnalyzer, name, evidenceType)
+		}
+		resolved = append(resolved, name)
+	}
+	return resolved, nil
+}
+
+// supportsEvidenceType reports whether an engine can analyze an evidence type
+func supportsEvidenceType(engine ports.AnalysisEngine, evidenceType domain.EvidenceType) bool {
+	for _, supported := range engine.GetSupportedEvidenceTypes() {
+		if supported == evidenceType {
+			return true
+		}
+	}
+	return false
+}
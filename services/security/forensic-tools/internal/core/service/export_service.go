@@ -0,0 +1,416 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidExportRequest = errors.New("invalid export request")
+	ErrCaseNotFound         = errors.New("case not found")
+)
+
+// exportPageSize is the page size used to read a case's records for export
+const exportPageSize = 500
+
+// ExportServiceImpl builds court-ready evidence packages: a ZIP archive of the
+// selected evidence files with their custody chains, analysis reports and
+// linked transactions, and a manifest of every file's SHA-256 hash signed
+// with the HSM package key. Evidence files are checked against their recorded
+// hash as they are packaged, and every exported item gets an EXPORT custody
+// entry naming the package.
+type ExportServiceImpl struct {
+	repo     ports.EvidenceRepository
+	storage  ports.BlobStorage
+	forensic *ForensicServiceImpl
+	links    ports.LinkService
+	signer   ports.PackageSigner
+	tempDir  string
+}
+
+// NewExportService creates a new evidence package export service
+func NewExportService(
+	repo ports.EvidenceRepository,
+	storage ports.BlobStorage,
+	forensic *ForensicServiceImpl,
+	links ports.LinkService,
+	signer ports.PackageSigner,
+	cfg *config.ExportConfig,
+) *ExportServiceImpl {
+	return &ExportServiceImpl{
+		repo:     repo,
+		storage:  storage,
+		forensic: forensic,
+		links:    links,
+		signer:   signer,
+		tempDir:  cfg.TempDir,
+	}
+}
+
+// ExportCase builds and signs an evidence package for a case. The package is
+// written to a temporary file that is removed when the returned reader is
+// closed.
+func (s *ExportServiceImpl) ExportCase(
+	ctx context.Context,
+	caseID string,
+	req *domain.CaseExportRequest,
+	actorID, ipAddress string,
+) (*domain.EvidencePackage, io.ReadCloser, error) {
+	evidence, err := s.selectEvidence(ctx, caseID, req.EvidenceIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.CreateTemp(s.tempDir, "evidence-package-*.zip")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create package file: %w", err)
+	}
+	pkgFile := &packageFile{File: file}
+	closeOnError := func(err error) (*domain.EvidencePackage, io.ReadCloser, error) {
+		pkgFile.Close()
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	manifest := &domain.PackageManifest{
+		Format:        domain.PackageFormat,
+		Version:       domain.PackageFormatVersion,
+		PackageID:     uuid.New().String(),
+		CaseID:        caseID,
+		Reason:        req.Reason,
+		CreatedBy:     actorID,
+		CreatedAt:     now,
+		HashAlgorithm: "SHA-256",
+	}
+
+	packageHash := sha256.New()
+	w := &packageWriter{
+		zip:      zip.NewWriter(io.MultiWriter(file, packageHash)),
+		modified: now,
+	}
+
+	for _, ev := range evidence {
+		entry, err := s.writeEvidence(ctx, w, ev)
+		if err != nil {
+			return closeOnError(err)
+		}
+		manifest.Evidence = append(manifest.Evidence, entry)
+	}
+	manifest.Files = w.files
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return closeOnError(fmt.Errorf("failed to encode manifest: %w", err))
+	}
+	digest := sha256.Sum256(manifestData)
+	signature, err := s.signer.Sign(ctx, digest[:])
+	if err != nil {
+		return closeOnError(fmt.Errorf("failed to sign manifest: %w", err))
+	}
+	pkgSignature := &domain.PackageSignature{
+		Algorithm:      s.signer.Algorithm(),
+		KeyID:          s.signer.KeyID(),
+		ManifestSHA256: hex.EncodeToString(digest[:]),
+		Signature:      base64.StdEncoding.EncodeToString(signature),
+		SignedAt:       time.Now().UTC(),
+	}
+	signatureData, err := json.MarshalIndent(pkgSignature, "", "  ")
+	if err != nil {
+		return closeOnError(fmt.Errorf("failed to encode signature: %w", err))
+	}
+
+	if err := w.writeRaw("manifest.json", manifestData); err != nil {
+		return closeOnError(err)
+	}
+	if err := w.writeRaw("signature.json", signatureData); err != nil {
+		return closeOnError(err)
+	}
+	if err := w.zip.Close(); err != nil {
+		return closeOnError(fmt.Errorf("failed to finish package: %w", err))
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return closeOnError(fmt.Errorf("failed to stat package: %w", err))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return closeOnError(fmt.Errorf("failed to rewind package: %w", err))
+	}
+
+	pkg := &domain.EvidencePackage{
+		ID:        manifest.PackageID,
+		CaseID:    caseID,
+		FileName:  fmt.Sprintf("case-%s-%s.zip", safeFileName(caseID), manifest.PackageID),
+		SizeBytes: info.Size(),
+		SHA256:    hex.EncodeToString(packageHash.Sum(nil)),
+		Signature: pkgSignature,
+	}
+
+	for _, ev := range evidence {
+		custodyEntry := &domain.ChainOfCustody{
+			ID:         uuid.New().String(),
+			EvidenceID: ev.ID,
+			ActorID:    actorID,
+			Action:     domain.CoCActionExport,
+			Details: map[string]interface{}{
+				"package_id":      pkg.ID,
+				"package_sha256":  pkg.SHA256,
+				"manifest_sha256": pkgSignature.ManifestSHA256,
+				"reason":          req.Reason,
+			},
+			IPAddress: ipAddress,
+			Timestamp: time.Now(),
+		}
+		if err := s.repo.AddCustodyEntry(ctx, custodyEntry); err != nil {
+			return closeOnError(fmt.Errorf("failed to record export custody entry: %w", err))
+		}
+	}
+
+	return pkg, pkgFile, nil
+}
+
+// selectEvidence returns the requested evidence of a case, or all of it
+func (s *ExportServiceImpl) selectEvidence(ctx context.Context, caseID string, ids []string) ([]*domain.Evidence, error) {
+	if len(ids) == 0 {
+		var evidence []*domain.Evidence
+		for page := 1; ; page++ {
+			items, total, err := s.repo.ListEvidence(ctx, caseID, page, exportPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list case evidence: %w", err)
+			}
+			evidence = append(evidence, items...)
+			if len(items) == 0 || int64(len(evidence)) >= total {
+				break
+			}
+		}
+		if len(evidence) == 0 {
+			return nil, ErrCaseNotFound
+		}
+		return evidence, nil
+	}
+
+	seen := make(map[string]bool, len(ids))
+	evidence := make([]*domain.Evidence, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		ev, err := s.repo.GetEvidence(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: evidence %s not found", ErrInvalidExportRequest, id)
+		}
+		if ev.CaseID != caseID {
+			return nil, fmt.Errorf("%w: evidence %s is not in case %s", ErrInvalidExportRequest, id, caseID)
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence, nil
+}
+
+// writeEvidence adds an evidence file and its custody chain, analysis reports
+// and linked transactions to the package
+func (s *ExportServiceImpl) writeEvidence(ctx context.Context, w *packageWriter, ev *domain.Evidence) (*domain.PackageEvidence, error) {
+	dir := "evidence/" + ev.ID + "/"
+
+	reader, err := s.storage.GetFile(ctx, ev.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open evidence %s: %w", ev.ID, err)
+	}
+	file, err := w.writeFile(dir+"original/"+safeFileName(ev.FileName), domain.PackageFileEvidence, ev.ID, zip.Store, reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(file.SHA256, ev.FileHash) {
+		return nil, fmt.Errorf("evidence %s: %w", ev.ID, &HashMismatchError{Expected: ev.FileHash, Actual: file.SHA256})
+	}
+
+	custody, err := s.repo.GetCustodyHistory(ctx, ev.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custody history of %s: %w", ev.ID, err)
+	}
+	if err := w.writeJSON(dir+"custody.json", domain.PackageFileCustody, ev.ID, custody); err != nil {
+		return nil, err
+	}
+	verified, err := s.forensic.VerifyChainOfCustody(ctx, ev.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify custody of %s: %w", ev.ID, err)
+	}
+
+	reports, err := s.analysisReports(ctx, ev.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.writeJSON(dir+"analysis.json", domain.PackageFileAnalysis, ev.ID, reports); err != nil {
+		return nil, err
+	}
+
+	extract, err := s.transactionExtract(ctx, ev.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.writeJSON(dir+"transactions.json", domain.PackageFileTransactions, ev.ID, extract); err != nil {
+		return nil, err
+	}
+
+	return &domain.PackageEvidence{
+		ID:              ev.ID,
+		FileName:        ev.FileName,
+		FileHash:        ev.FileHash,
+		SizeBytes:       ev.SizeBytes,
+		EvidenceType:    ev.EvidenceType,
+		UploadedBy:      ev.UploadedBy,
+		UploadedAt:      ev.UploadedAt,
+		CustodyVerified: verified,
+	}, nil
+}
+
+// analysisReports returns every analysis job of evidence with its results
+func (s *ExportServiceImpl) analysisReports(ctx context.Context, evidenceID string) ([]*domain.AnalysisReport, error) {
+	reports := []*domain.AnalysisReport{}
+	for page := 1; ; page++ {
+		jobs, total, err := s.repo.ListAnalysisJobs(ctx, evidenceID, page, exportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list analysis jobs of %s: %w", evidenceID, err)
+		}
+		for _, job := range jobs {
+			results, err := s.repo.GetAnalysisResults(ctx, job.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get results of analysis job %s: %w", job.ID, err)
+			}
+			reports = append(reports, &domain.AnalysisReport{Job: job, Results: results})
+		}
+		if len(jobs) == 0 || int64(len(reports)) >= total {
+			return reports, nil
+		}
+	}
+}
+
+// transactionExtract returns the transactions and wallets linked to evidence
+func (s *ExportServiceImpl) transactionExtract(ctx context.Context, evidenceID string) (*domain.TransactionExtract, error) {
+	extract := &domain.TransactionExtract{
+		EvidenceID:   evidenceID,
+		Transactions: []*domain.LinkedTransaction{},
+		Wallets:      []*domain.LinkedWallet{},
+	}
+
+	for page := 1; ; page++ {
+		transactions, err := s.links.GetLinkedTransactions(ctx, evidenceID, page, exportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get linked transactions of %s: %w", evidenceID, err)
+		}
+		extract.Transactions = append(extract.Transactions, transactions.Transactions...)
+		if page >= transactions.TotalPages {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		wallets, err := s.links.GetLinkedWallets(ctx, evidenceID, page, exportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get linked wallets of %s: %w", evidenceID, err)
+		}
+		extract.Wallets = append(extract.Wallets, wallets.Wallets...)
+		if page >= wallets.TotalPages {
+			break
+		}
+	}
+
+	return extract, nil
+}
+
+// packageWriter writes files to a package archive and records their hashes
+type packageWriter struct {
+	zip      *zip.Writer
+	modified time.Time
+	files    []*domain.PackageFile
+}
+
+// writeFile adds a file to the archive and to the manifest file list
+func (w *packageWriter) writeFile(name string, fileType domain.PackageFileType, evidenceID string, method uint16, r io.Reader) (*domain.PackageFile, error) {
+	out, err := w.zip.CreateHeader(&zip.FileHeader{Name: name, Method: method, Modified: w.modified})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s to package: %w", name, err)
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(out, h), r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s to package: %w", name, err)
+	}
+
+	file := &domain.PackageFile{
+		Path:       name,
+		Type:       fileType,
+		EvidenceID: evidenceID,
+		SHA256:     hex.EncodeToString(h.Sum(nil)),
+		SizeBytes:  n,
+	}
+	w.files = append(w.files, file)
+	return file, nil
+}
+
+// writeJSON adds a JSON document to the archive and to the manifest file list
+func (w *packageWriter) writeJSON(name string, fileType domain.PackageFileType, evidenceID string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	_, err = w.writeFile(name, fileType, evidenceID, zip.Deflate, bytes.NewReader(data))
+	return err
+}
+
+// writeRaw adds a file that is not listed in the manifest
+func (w *packageWriter) writeRaw(name string, data []byte) error {
+	out, err := w.zip.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: w.modified})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to package: %w", name, err)
+	}
+	if _, err := out.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to package: %w", name, err)
+	}
+	return nil
+}
+
+// packageFile is a temporary package file that is removed when closed
+type packageFile struct {
+	*os.File
+}
+
+func (f *packageFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.File.Name())
+	return err
+}
+
+// safeFileName reduces a name to a single path element that is safe to use in
+// an archive
+func safeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+	return name
+}
+
+// Ensure ExportServiceImpl implements ExportService
+var _ ports.ExportService = (*ExportServiceImpl)(nil)
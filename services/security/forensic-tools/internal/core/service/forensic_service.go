@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/csic-platform/services/security/forensic-tools/internal/config"
@@ -29,6 +31,7 @@ type ForensicServiceImpl struct {
 	storage   ports.BlobStorage
 	producer  ports.MessageProducer
 	hashCalc  ports.HashCalculator
+	analyzers *AnalyzerRegistry
 	cfg       *config.Config
 }
 
@@ -38,14 +41,16 @@ func NewForensicService(
 	storage ports.BlobStorage,
 	producer ports.MessageProducer,
 	hashCalc ports.HashCalculator,
+	analyzers *AnalyzerRegistry,
 	cfg *config.Config,
 ) *ForensicServiceImpl {
 	return &ForensicServiceImpl{
-		repo:     repo,
-		storage:  storage,
-		producer: producer,
-		hashCalc: hashCalc,
-		cfg:      cfg,
+		repo:      repo,
+		storage:   storage,
+		producer:  producer,
+		hashCalc:  hashCalc,
+		analyzers: analyzers,
+		cfg:       cfg,
 	}
 }
 
@@ -59,29 +64,83 @@ func (s *ForensicServiceImpl) UploadEvidence(
 	metadata map[string]string,
 	actorID string,
 ) (*domain.Evidence, error) {
-	// Calculate file hash
-	fileHash, err := s.hashCalc.Calculate(reader)
+	return s.storeEvidence(ctx, caseID, fileName, reader, size, evidenceType, metadata, actorID, "", nil)
+}
+
+// ImportEvidence stores evidence from a bulk import. The file is rejected with
+// a *HashMismatchError unless its SHA-256 hash equals expectedHash; the
+// custody entry records custodyDetails alongside the usual upload details.
+func (s *ForensicServiceImpl) ImportEvidence(
+	ctx context.Context,
+	caseID, fileName string,
+	reader io.Reader,
+	size int64,
+	evidenceType domain.EvidenceType,
+	metadata map[string]string,
+	actorID string,
+	expectedHash string,
+	custodyDetails map[string]interface{},
+) (*domain.Evidence, error) {
+	return s.storeEvidence(ctx, caseID, fileName, reader, size, evidenceType, metadata, actorID, expectedHash, custodyDetails)
+}
+
+// HashMismatchError reports a file whose hash differs from the expected one
+type HashMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *HashMismatchError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", ErrInvalidHash, e.Expected, e.Actual)
+}
+
+// Is makes errors.Is(err, ErrInvalidHash) match hash mismatches
+func (e *HashMismatchError) Is(target error) bool {
+	return target == ErrInvalidHash
+}
+
+// storeEvidence hashes, stores and registers an evidence file
+func (s *ForensicServiceImpl) storeEvidence(
+	ctx context.Context,
+	caseID, fileName string,
+	reader io.Reader,
+	size int64,
+	evidenceType domain.EvidenceType,
+	metadata map[string]string,
+	actorID string,
+	expectedHash string,
+	custodyDetails map[string]interface{},
+) (*domain.Evidence, error) {
+	// Calculate file hash; readers that cannot be rewound are spooled to disk
+	content, fileHash, cleanup, err := s.hashUpload(reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate file hash: %w", err)
 	}
+	defer cleanup()
 
-	// Check if evidence with same hash already exists
-	existing, err := s.repo.GetEvidenceByHash(ctx, fileHash)
+	if expectedHash != "" && !strings.EqualFold(fileHash, expectedHash) {
+		return nil, &HashMismatchError{Expected: strings.ToLower(expectedHash), Actual: fileHash}
+	}
+
+	// Check if the case already holds this evidence
+	existing, err := s.repo.GetCaseEvidenceByHash(ctx, caseID, fileHash)
 	if err == nil && existing != nil {
 		return nil, ErrEvidenceAlreadyExists
 	}
 
-	// Generate storage path
-	objectName := fmt.Sprintf("evidence/%s/%s/%s", caseID, time.Now().Format("2006/01/02"), uuid.New().String())
-
-	// Reset reader position if possible
-	if seeker, ok := reader.(io.Seeker); ok {
-		seeker.Seek(0, 0)
+	// Files are stored by content, so identical evidence in several cases
+	// shares a single object
+	objectName := EvidenceObjectName(fileHash)
+	stored, err := s.storage.ObjectExists(ctx, objectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check storage: %w", err)
 	}
 
 	// Upload to storage
-	if err := s.storage.Upload(ctx, objectName, reader, size, "application/octet-stream"); err != nil {
-		return nil, fmt.Errorf("failed to upload to storage: %w", err)
+	if !stored {
+		if err := s.storage.Upload(ctx, objectName, content, size, "application/octet-stream"); err != nil {
+			return nil, fmt.Errorf("failed to upload to storage: %w", err)
+		}
 	}
 
 	// Create evidence record
@@ -101,31 +160,78 @@ func (s *ForensicServiceImpl) UploadEvidence(
 		UpdatedAt:    time.Now(),
 	}
 
+	// The object is kept on failure: other evidence may share it, and an
+	// unreferenced object is reused by the next upload of the same file
 	if err := s.repo.CreateEvidence(ctx, evidence); err != nil {
-		// Cleanup storage on failure
-		s.storage.Delete(ctx, objectName)
 		return nil, fmt.Errorf("failed to create evidence record: %w", err)
 	}
 
 	// Add chain of custody entry
+	details := map[string]interface{}{
+		"file_name":  fileName,
+		"file_size":  size,
+		"file_hash":  fileHash,
+		"file_type":  evidence.FileType,
+		"deduplicated": stored,
+	}
+	for key, value := range custodyDetails {
+		details[key] = value
+	}
 	custodyEntry := &domain.ChainOfCustody{
 		ID:         uuid.New().String(),
 		EvidenceID: evidence.ID,
 		ActorID:    actorID,
 		Action:     domain.CoCActionUpload,
-		Details: map[string]interface{}{
-			"file_name":  fileName,
-			"file_size":  size,
-			"file_hash":  fileHash,
-			"file_type":  evidence.FileType,
-		},
-		Timestamp: time.Now(),
+		Details:    details,
+		Timestamp:  time.Now(),
 	}
 	s.repo.AddCustodyEntry(ctx, custodyEntry)
 
 	return evidence, nil
 }
 
+// EvidenceObjectName returns the content-addressed storage path of a file
+// with the given SHA-256 hash
+func EvidenceObjectName(fileHash string) string {
+	return fmt.Sprintf("evidence/sha256/%s/%s/%s", fileHash[:2], fileHash[2:4], fileHash)
+}
+
+// hashUpload hashes an upload and returns a reader positioned at its start.
+// Readers that cannot seek, such as request bodies, are spooled to a temporary
+// file while hashing so large disk images are never held in memory.
+func (s *ForensicServiceImpl) hashUpload(reader io.Reader) (io.Reader, string, func(), error) {
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		fileHash, err := s.hashCalc.Calculate(seeker)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, "", nil, err
+		}
+		return seeker, fileHash, func() {}, nil
+	}
+
+	spool, err := os.CreateTemp("", "evidence-upload-*")
+	if err != nil {
+		return nil, "", nil, err
+	}
+	cleanup := func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}
+
+	fileHash, err := s.hashCalc.Calculate(io.TeeReader(reader, spool))
+	if err == nil {
+		_, err = spool.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		cleanup()
+		return nil, "", nil, err
+	}
+
+	return spool, fileHash, cleanup, nil
+}
+
 // GetEvidence retrieves evidence by ID
 func (s *ForensicServiceImpl) GetEvidence(ctx context.Context, id string, actorID string) (*domain.Evidence, error) {
 	evidence, err := s.repo.GetEvidence(ctx, id)
@@ -281,11 +387,17 @@ func (s *ForensicServiceImpl) RequestAnalysis(ctx context.Context, req *domain.A
 		return nil, ErrEvidenceNotFound
 	}
 
-	// Create analysis job
+	// Validate the requested tools; none requested runs every applicable tool
+	tools, err := s.analyzers.Resolve(evidence.EvidenceType, req.Tools)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create analysis job; the worker pool runs its tools in order
 	job := &domain.AnalysisJob{
 		ID:          uuid.New().String(),
 		EvidenceID:  evidence.ID,
-		ToolName:    "multi-tool", // Default to running multiple tools
+		ToolName:    strings.Join(tools, ","),
 		Status:      domain.AnalysisJobStatusPending,
 		Priority:    req.Priority,
 		RequestedBy: actorID,
@@ -329,8 +441,7 @@ func (s *ForensicServiceImpl) GetAnalysisJob(ctx context.Context, jobID string,
 
 // GetAnalysisResults retrieves results for an analysis job
 func (s *ForensicServiceImpl) GetAnalysisResults(ctx context.Context, jobID string, actorID string) ([]*domain.AnalysisResult, error) {
-	job, err := s.repo.GetAnalysisJob(ctx, jobID)
-	if err != nil {
+	if _, err := s.repo.GetAnalysisJob(ctx, jobID); err != nil {
 		return nil, ErrAnalysisJobNotFound
 	}
 
@@ -389,7 +500,7 @@ func (s *ForensicServiceImpl) HealthCheck(ctx context.Context) error {
 	}
 
 	// Verify database connectivity
-	_, err = s.repo.ListEvidence(ctx, "", 1, 1)
+	_, _, err = s.repo.ListEvidence(ctx, "", 1, 1)
 	if err != nil {
 		return fmt.Errorf("database health check failed: %w", err)
 	}
@@ -416,14 +527,14 @@ func (s *ForensicServiceImpl) detectFileType(fileName string) string {
 	}
 }
 
-// hasExtension checks if a filename has the given extension
-func hasExtension(fileName string, extensions ...string) string {
+// hasExtension checks if a filename has one of the given extensions
+func hasExtension(fileName string, extensions ...string) bool {
 	for _, ext := range extensions {
 		if len(fileName) > len(ext) && fileName[len(fileName)-len(ext):] == ext {
-			return ext
+			return true
 		}
 	}
-	return ""
+	return false
 }
 
 // DefaultHashCalculator implements HashCalculator
@@ -0,0 +1,443 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidManifest   = errors.New("invalid import manifest")
+	ErrImportJobNotFound = errors.New("import job not found")
+	errImportLeaseLost   = errors.New("import lease lost")
+)
+
+// ImportServiceImpl imports evidence in bulk from a directory or archive
+// against a manifest of expected hashes. Jobs are stored with one item per
+// manifest entry and processed in the background; a job interrupted by a
+// restart is resumed from its pending items once its lease expires.
+type ImportServiceImpl struct {
+	repo          ports.ImportRepository
+	evidenceRepo  ports.EvidenceRepository
+	forensic      *ForensicServiceImpl
+	sources       ports.ImportSourceOpener
+	owner         string
+	pollInterval  time.Duration
+	leaseDuration time.Duration
+	maxUnlisted   int
+}
+
+// NewImportService creates a new bulk import service
+func NewImportService(
+	repo ports.ImportRepository,
+	evidenceRepo ports.EvidenceRepository,
+	forensic *ForensicServiceImpl,
+	sources ports.ImportSourceOpener,
+	cfg *config.ImportConfig,
+) *ImportServiceImpl {
+	pollInterval := time.Duration(cfg.PollInterval) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	leaseDuration := time.Duration(cfg.LeaseDuration) * time.Second
+	if leaseDuration <= 0 {
+		leaseDuration = 2 * time.Minute
+	}
+	maxUnlisted := cfg.MaxUnlisted
+	if maxUnlisted < 0 {
+		maxUnlisted = 0
+	}
+
+	hostname, _ := os.Hostname()
+
+	return &ImportServiceImpl{
+		repo:          repo,
+		evidenceRepo:  evidenceRepo,
+		forensic:      forensic,
+		sources:       sources,
+		owner:         fmt.Sprintf("%s-%s", hostname, uuid.New().String()),
+		pollInterval:  pollInterval,
+		leaseDuration: leaseDuration,
+		maxUnlisted:   maxUnlisted,
+	}
+}
+
+// StartImport validates a manifest and queues an import job for it
+func (s *ImportServiceImpl) StartImport(ctx context.Context, manifest *domain.ImportManifest, actorID string) (*domain.ImportJob, error) {
+	if err := validateManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	// Fail fast on sources that are missing or outside the allowed roots
+	source, err := s.sources.Open(manifest.Source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	source.Close()
+
+	now := time.Now()
+	job := &domain.ImportJob{
+		ID:          uuid.New().String(),
+		CaseID:      manifest.CaseID,
+		Source:      manifest.Source,
+		Status:      domain.ImportJobStatusPending,
+		Counts:      domain.ImportCounts{Total: len(manifest.Items)},
+		RequestedBy: actorID,
+		SubmittedAt: now,
+	}
+
+	items := make([]*domain.ImportItem, 0, len(manifest.Items))
+	for _, entry := range manifest.Items {
+		items = append(items, &domain.ImportItem{
+			ID:           uuid.New().String(),
+			JobID:        job.ID,
+			Path:         entry.Path,
+			ExpectedHash: entry.SHA256,
+			EvidenceType: entry.EvidenceType,
+			Metadata:     entry.Metadata,
+			Status:       domain.ImportItemStatusPending,
+			UpdatedAt:    now,
+		})
+	}
+
+	if err := s.repo.CreateImportJob(ctx, job, items); err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetImportJob retrieves an import job and its progress
+func (s *ImportServiceImpl) GetImportJob(ctx context.Context, jobID string) (*domain.ImportJob, error) {
+	job, err := s.repo.GetImportJob(ctx, jobID)
+	if err != nil {
+		return nil, ErrImportJobNotFound
+	}
+	return job, nil
+}
+
+// ListImportItems retrieves a paginated list of a job's items, optionally
+// filtered by status
+func (s *ImportServiceImpl) ListImportItems(ctx context.Context, jobID string, status domain.ImportItemStatus, page, pageSize int) (*domain.ImportItemListResponse, error) {
+	if _, err := s.repo.GetImportJob(ctx, jobID); err != nil {
+		return nil, ErrImportJobNotFound
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 500 {
+		pageSize = 100
+	}
+
+	items, total, err := s.repo.ListImportItems(ctx, jobID, status, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+	return &domain.ImportItemListResponse{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Run processes pending and abandoned import jobs one at a time until ctx is
+// cancelled
+func (s *ImportServiceImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.resumeNext(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resumeNext claims the oldest pending or abandoned job, if any, and
+// processes it. A job leased by another worker is left alone.
+func (s *ImportServiceImpl) resumeNext(ctx context.Context) {
+	jobs, err := s.repo.ListResumableImportJobs(ctx, time.Now(), 1)
+	if err != nil {
+		return
+	}
+	for _, job := range jobs {
+		now := time.Now()
+		claimed, err := s.repo.ClaimImportJob(ctx, job.ID, s.owner, now, now.Add(s.leaseDuration))
+		if err != nil || !claimed {
+			continue
+		}
+		s.runJob(ctx, job)
+	}
+}
+
+// runJob processes a claimed job and records its outcome
+func (s *ImportServiceImpl) runJob(ctx context.Context, job *domain.ImportJob) {
+	now := time.Now()
+	leaseUntil := now.Add(s.leaseDuration)
+	job.Status = domain.ImportJobStatusRunning
+	job.LeaseUntil = &leaseUntil
+	if job.StartedAt == nil {
+		job.StartedAt = &now
+	}
+
+	err := s.process(ctx, job)
+	if errors.Is(err, errImportLeaseLost) {
+		return
+	}
+	if err != nil && ctx.Err() != nil {
+		// Shutting down: release the lease so the job resumes on restart
+		job.LeaseUntil = &now
+		s.repo.UpdateImportJob(context.Background(), job, s.owner)
+		return
+	}
+
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	job.LeaseUntil = nil
+	switch {
+	case err != nil:
+		job.Status = domain.ImportJobStatusFailed
+		job.ErrorMessage = err.Error()
+	case job.Counts.HasErrors():
+		job.Status = domain.ImportJobStatusCompletedWithErrors
+	default:
+		job.Status = domain.ImportJobStatusCompleted
+	}
+
+	s.repo.UpdateImportJob(context.Background(), job, s.owner)
+}
+
+// process walks the job's source, importing pending manifest items in the
+// order the source yields them, then marks items that were never found as
+// missing
+func (s *ImportServiceImpl) process(ctx context.Context, job *domain.ImportJob) error {
+	items, err := s.repo.ListPendingImportItems(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load import items: %w", err)
+	}
+	pending := make(map[string]*domain.ImportItem, len(items))
+	for _, item := range items {
+		pending[item.Path] = item
+	}
+
+	source, err := s.sources.Open(job.Source)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	err = source.Walk(ctx, func(filePath string, size int64, open func() (io.ReadCloser, error)) error {
+		item, listed := pending[filePath]
+		if !listed {
+			return s.recordUnlisted(ctx, job, filePath)
+		}
+		delete(pending, filePath)
+
+		if err := s.importItem(ctx, job, item, size, open); err != nil {
+			return err
+		}
+		return s.saveProgress(ctx, job, item)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range pending {
+		item.Status = domain.ImportItemStatusMissing
+		item.ErrorMessage = "file not found in import source"
+		if err := s.saveProgress(ctx, job, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importItem hashes and stores one manifest item, setting its outcome. Only
+// errors that should stop the job are returned.
+func (s *ImportServiceImpl) importItem(ctx context.Context, job *domain.ImportJob, item *domain.ImportItem, size int64, open func() (io.ReadCloser, error)) error {
+	reader, err := open()
+	if err != nil {
+		item.Status = domain.ImportItemStatusFailed
+		item.ErrorMessage = err.Error()
+		return nil
+	}
+	defer reader.Close()
+
+	metadata := make(map[string]string, len(item.Metadata)+2)
+	for key, value := range item.Metadata {
+		metadata[key] = value
+	}
+	metadata["import_job_id"] = job.ID
+	metadata["import_path"] = item.Path
+
+	custodyDetails := map[string]interface{}{
+		"import_job_id":      job.ID,
+		"import_source_type": job.Source.Type,
+		"import_source":      job.Source.Path,
+		"import_path":        item.Path,
+		"manifest_hash":      item.ExpectedHash,
+	}
+
+	evidence, err := s.forensic.ImportEvidence(
+		ctx, job.CaseID, path.Base(item.Path), reader, size, item.EvidenceType,
+		metadata, job.RequestedBy, item.ExpectedHash, custodyDetails,
+	)
+
+	var mismatch *HashMismatchError
+	switch {
+	case err == nil:
+		item.Status = domain.ImportItemStatusImported
+		item.ActualHash = evidence.FileHash
+		item.EvidenceID = evidence.ID
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case errors.As(err, &mismatch):
+		item.Status = domain.ImportItemStatusMismatch
+		item.ActualHash = mismatch.Actual
+		item.ErrorMessage = err.Error()
+	case errors.Is(err, ErrEvidenceAlreadyExists):
+		item.Status = domain.ImportItemStatusDuplicate
+		item.ActualHash = item.ExpectedHash
+		if existing, err := s.evidenceRepo.GetCaseEvidenceByHash(ctx, job.CaseID, item.ExpectedHash); err == nil {
+			item.EvidenceID = existing.ID
+		}
+	default:
+		item.Status = domain.ImportItemStatusFailed
+		item.ErrorMessage = err.Error()
+	}
+	return nil
+}
+
+// recordUnlisted records a source file that is not in the manifest, up to
+// the configured limit. Every manifest item already has a row, so on a
+// resumed job files that were processed or recorded earlier are skipped.
+func (s *ImportServiceImpl) recordUnlisted(ctx context.Context, job *domain.ImportJob, filePath string) error {
+	if job.Counts.Unlisted >= s.maxUnlisted {
+		return nil
+	}
+
+	item := &domain.ImportItem{
+		ID:           uuid.New().String(),
+		JobID:        job.ID,
+		Path:         filePath,
+		Status:       domain.ImportItemStatusUnlisted,
+		ErrorMessage: "file is not listed in the manifest",
+		UpdatedAt:    time.Now(),
+	}
+	added, err := s.repo.AddImportItem(ctx, item)
+	if err != nil {
+		return fmt.Errorf("failed to record unlisted file: %w", err)
+	}
+	if !added {
+		return nil
+	}
+
+	job.Counts.Unlisted++
+	return s.renewLease(ctx, job)
+}
+
+// saveProgress stores an item's outcome and the job's updated counts
+func (s *ImportServiceImpl) saveProgress(ctx context.Context, job *domain.ImportJob, item *domain.ImportItem) error {
+	item.UpdatedAt = time.Now()
+	if err := s.repo.UpdateImportItem(ctx, item); err != nil {
+		return fmt.Errorf("failed to update import item: %w", err)
+	}
+
+	job.Counts.Add(item.Status)
+	return s.renewLease(ctx, job)
+}
+
+// renewLease saves the job's counts and extends its lease
+func (s *ImportServiceImpl) renewLease(ctx context.Context, job *domain.ImportJob) error {
+	leaseUntil := time.Now().Add(s.leaseDuration)
+	job.LeaseUntil = &leaseUntil
+
+	held, err := s.repo.UpdateImportJob(ctx, job, s.owner)
+	if err != nil {
+		return fmt.Errorf("failed to update import job: %w", err)
+	}
+	if !held {
+		return errImportLeaseLost
+	}
+	return nil
+}
+
+// validateManifest checks a manifest and normalises its paths, hashes and
+// evidence types
+func validateManifest(manifest *domain.ImportManifest) error {
+	if manifest.CaseID == "" {
+		return fmt.Errorf("%w: case_id is required", ErrInvalidManifest)
+	}
+	if manifest.Source.Type != domain.ImportSourceDirectory && manifest.Source.Type != domain.ImportSourceArchive {
+		return fmt.Errorf("%w: source type must be %q or %q", ErrInvalidManifest, domain.ImportSourceDirectory, domain.ImportSourceArchive)
+	}
+	if manifest.Source.Path == "" {
+		return fmt.Errorf("%w: source path is required", ErrInvalidManifest)
+	}
+	if len(manifest.Items) == 0 {
+		return fmt.Errorf("%w: manifest has no items", ErrInvalidManifest)
+	}
+
+	seen := make(map[string]bool, len(manifest.Items))
+	for i := range manifest.Items {
+		item := &manifest.Items[i]
+
+		cleaned, err := domain.CleanImportPath(item.Path)
+		if err != nil {
+			return fmt.Errorf("%w: item %d: %v: %q", ErrInvalidManifest, i, err, item.Path)
+		}
+		if seen[cleaned] {
+			return fmt.Errorf("%w: item %d: duplicate path %q", ErrInvalidManifest, i, cleaned)
+		}
+		seen[cleaned] = true
+		item.Path = cleaned
+
+		item.SHA256 = strings.ToLower(item.SHA256)
+		if decoded, err := hex.DecodeString(item.SHA256); err != nil || len(decoded) != 32 {
+			return fmt.Errorf("%w: item %d: sha256 must be 64 hex characters", ErrInvalidManifest, i)
+		}
+
+		if item.EvidenceType == "" {
+			item.EvidenceType = domain.EvidenceTypeFile
+		}
+		if !isValidEvidenceType(item.EvidenceType) {
+			return fmt.Errorf("%w: item %d: %v: %s", ErrInvalidManifest, i, ErrInvalidEvidenceType, item.EvidenceType)
+		}
+	}
+	return nil
+}
+
+func isValidEvidenceType(evidenceType domain.EvidenceType) bool {
+	switch evidenceType {
+	case domain.EvidenceTypeFile, domain.EvidenceTypeDiskImage, domain.EvidenceTypeMemoryDump,
+		domain.EvidenceTypeNetworkCapture, domain.EvidenceTypeLogFile, domain.EvidenceTypeDocument:
+		return true
+	}
+	return false
+}
+
+// Ensure ImportServiceImpl implements ImportService
+var _ ports.ImportService = (*ImportServiceImpl)(nil)
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/config"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+)
+
+// MockImportRepository implements ports.ImportRepository in memory, applying
+// the same lease rules as the PostgreSQL repository. Jobs are copied in and
+// out, as they would be by the database.
+type MockImportRepository struct {
+	mu    sync.Mutex
+	jobs  map[string]*domain.ImportJob
+	items map[string][]*domain.ImportItem
+}
+
+func NewMockImportRepository() *MockImportRepository {
+	return &MockImportRepository{
+		jobs:  make(map[string]*domain.ImportJob),
+		items: make(map[string][]*domain.ImportItem),
+	}
+}
+
+func (m *MockImportRepository) CreateImportJob(ctx context.Context, job *domain.ImportJob, items []*domain.ImportItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *job
+	m.jobs[job.ID] = &stored
+	for _, item := range items {
+		copied := *item
+		m.items[job.ID] = append(m.items[job.ID], &copied)
+	}
+	return nil
+}
+
+func (m *MockImportRepository) GetImportJob(ctx context.Context, id string) (*domain.ImportJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, errors.New("import job not found")
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (m *MockImportRepository) resumable(job *domain.ImportJob, now time.Time) bool {
+	return job.Status == domain.ImportJobStatusPending ||
+		(job.Status == domain.ImportJobStatusRunning && (job.LeaseUntil == nil || job.LeaseUntil.Before(now)))
+}
+
+func (m *MockImportRepository) ListResumableImportJobs(ctx context.Context, now time.Time, limit int) ([]*domain.ImportJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var jobs []*domain.ImportJob
+	for _, job := range m.jobs {
+		if m.resumable(job, now) {
+			copied := *job
+			jobs = append(jobs, &copied)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].SubmittedAt.Before(jobs[j].SubmittedAt) })
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+func (m *MockImportRepository) ClaimImportJob(ctx context.Context, id, owner string, now, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok || !m.resumable(job, now) {
+		return false, nil
+	}
+	job.Status = domain.ImportJobStatusRunning
+	job.LeaseOwner = owner
+	job.LeaseUntil = &leaseUntil
+	if job.StartedAt == nil {
+		job.StartedAt = &now
+	}
+	return true, nil
+}
+
+func (m *MockImportRepository) UpdateImportJob(ctx context.Context, job *domain.ImportJob, owner string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.jobs[job.ID]
+	if !ok || stored.LeaseOwner != owner {
+		return false, nil
+	}
+	stored.Status = job.Status
+	stored.Counts = job.Counts
+	stored.CompletedAt = job.CompletedAt
+	stored.ErrorMessage = job.ErrorMessage
+	stored.LeaseUntil = job.LeaseUntil
+	return true, nil
+}
+
+// stealLease hands the lease of a job to another worker
+func (m *MockImportRepository) stealLease(id, owner string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	leaseUntil := time.Now().Add(time.Hour)
+	m.jobs[id].LeaseOwner = owner
+	m.jobs[id].LeaseUntil = &leaseUntil
+}
+
+func (m *MockImportRepository) AddImportItem(ctx context.Context, item *domain.ImportItem) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.items[item.JobID] {
+		if existing.Path == item.Path {
+			return false, nil
+		}
+	}
+	copied := *item
+	m.items[item.JobID] = append(m.items[item.JobID], &copied)
+	return true, nil
+}
+
+func (m *MockImportRepository) UpdateImportItem(ctx context.Context, item *domain.ImportItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.items[item.JobID] {
+		if existing.ID == item.ID {
+			*existing = *item
+		}
+	}
+	return nil
+}
+
+func (m *MockImportRepository) ListPendingImportItems(ctx context.Context, jobID string) ([]*domain.ImportItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var items []*domain.ImportItem
+	for _, item := range m.items[jobID] {
+		if item.Status == domain.ImportItemStatusPending {
+			copied := *item
+			items = append(items, &copied)
+		}
+	}
+	return items, nil
+}
+
+func (m *MockImportRepository) ListImportItems(ctx context.Context, jobID string, status domain.ImportItemStatus, page, pageSize int) ([]*domain.ImportItem, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var items []*domain.ImportItem
+	for _, item := range m.items[jobID] {
+		if status == "" || item.Status == status {
+			copied := *item
+			items = append(items, &copied)
+		}
+	}
+	return items, int64(len(items)), nil
+}
+
+// MockImportSource yields files by path, calling onFile before each one. Only
+// files missing from the manifest are used, so no evidence is stored.
+type MockImportSource struct {
+	files  []string
+	onFile func(path string)
+}
+
+func (m *MockImportSource) Open(spec domain.ImportSourceSpec) (ports.ImportSource, error) {
+	return m, nil
+}
+
+func (m *MockImportSource) Walk(ctx context.Context, fn ports.ImportFileFunc) error {
+	for _, file := range m.files {
+		if m.onFile != nil {
+			m.onFile(file)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		open := func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("")), nil }
+		if err := fn(file, 0, open); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockImportSource) Close() error {
+	return nil
+}
+
+func newTestImportService(repo *MockImportRepository, source *MockImportSource) *ImportServiceImpl {
+	return NewImportService(repo, nil, nil, source, &config.ImportConfig{LeaseDuration: 60, MaxUnlisted: 10})
+}
+
+func startTestImport(t *testing.T, svc *ImportServiceImpl) *domain.ImportJob {
+	t.Helper()
+	job, err := svc.StartImport(context.Background(), &domain.ImportManifest{
+		CaseID: "case-1",
+		Source: domain.ImportSourceSpec{Type: domain.ImportSourceDirectory, Path: "/evidence"},
+		Items: []domain.ImportManifestItem{
+			{Path: "disk.img", SHA256: strings.Repeat("ab", 32)},
+		},
+	}, "investigator")
+	if err != nil {
+		t.Fatalf("StartImport failed: %v", err)
+	}
+	return job
+}
+
+func TestImportJobIsNotClaimedWhileLeased(t *testing.T) {
+	repo := NewMockImportRepository()
+	source := &MockImportSource{files: []string{"notes.txt"}}
+	first := newTestImportService(repo, source)
+	second := newTestImportService(repo, source)
+	job := startTestImport(t, first)
+
+	// While the first worker holds the lease the second finds nothing to do
+	var stored *domain.ImportJob
+	source.onFile = func(string) {
+		second.resumeNext(context.Background())
+		stored, _ = repo.GetImportJob(context.Background(), job.ID)
+	}
+	first.resumeNext(context.Background())
+
+	if stored.LeaseOwner != first.owner {
+		t.Errorf("expected lease to be held by the first worker during the walk, got %q", stored.LeaseOwner)
+	}
+
+	done, _ := repo.GetImportJob(context.Background(), job.ID)
+	if done.Status != domain.ImportJobStatusCompletedWithErrors {
+		t.Errorf("expected status %s, got %s", domain.ImportJobStatusCompletedWithErrors, done.Status)
+	}
+	if done.Counts.Missing != 1 || done.Counts.Unlisted != 1 {
+		t.Errorf("expected 1 missing and 1 unlisted item, got %+v", done.Counts)
+	}
+	if done.LeaseUntil != nil {
+		t.Error("expected lease to be cleared on completion")
+	}
+}
+
+func TestImportJobResumesAfterLeaseExpires(t *testing.T) {
+	repo := NewMockImportRepository()
+	source := &MockImportSource{}
+	svc := newTestImportService(repo, source)
+	job := startTestImport(t, svc)
+
+	// A worker that crashed while holding the lease
+	expired := time.Now().Add(-time.Minute)
+	if claimed, _ := repo.ClaimImportJob(context.Background(), job.ID, "crashed-worker", expired.Add(-time.Minute), expired); !claimed {
+		t.Fatal("expected crashed worker to claim the job")
+	}
+
+	svc.resumeNext(context.Background())
+
+	done, _ := repo.GetImportJob(context.Background(), job.ID)
+	if done.LeaseOwner != svc.owner {
+		t.Errorf("expected job to be taken over, lease owner is %q", done.LeaseOwner)
+	}
+	if done.Status != domain.ImportJobStatusCompletedWithErrors {
+		t.Errorf("expected status %s, got %s", domain.ImportJobStatusCompletedWithErrors, done.Status)
+	}
+}
+
+func TestImportJobStopsWhenLeaseIsLost(t *testing.T) {
+	repo := NewMockImportRepository()
+	source := &MockImportSource{files: []string{"notes.txt"}}
+	svc := newTestImportService(repo, source)
+	job := startTestImport(t, svc)
+
+	source.onFile = func(string) { repo.stealLease(job.ID, "other-worker") }
+	svc.resumeNext(context.Background())
+
+	stored, _ := repo.GetImportJob(context.Background(), job.ID)
+	if stored.Status != domain.ImportJobStatusRunning {
+		t.Errorf("expected job to stay %s for the new owner, got %s", domain.ImportJobStatusRunning, stored.Status)
+	}
+	if stored.CompletedAt != nil {
+		t.Error("expected a worker that lost its lease not to complete the job")
+	}
+	if stored.Counts.Unlisted != 0 {
+		t.Errorf("expected counts not to be saved after the lease was lost, got %+v", stored.Counts)
+	}
+}
+
+func TestImportLeaseReleasedOnShutdown(t *testing.T) {
+	repo := NewMockImportRepository()
+	source := &MockImportSource{files: []string{"notes.txt"}}
+	svc := newTestImportService(repo, source)
+	job := startTestImport(t, svc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	source.onFile = func(string) { cancel() }
+	svc.resumeNext(ctx)
+
+	stored, _ := repo.GetImportJob(context.Background(), job.ID)
+	if stored.CompletedAt != nil {
+		t.Error("expected an interrupted job not to be completed")
+	}
+
+	// The released job is picked up again straight away
+	resumable, _ := repo.ListResumableImportJobs(context.Background(), time.Now(), 10)
+	if len(resumable) != 1 || resumable[0].ID != job.ID {
+		t.Fatalf("expected the interrupted job to be resumable, got %d jobs", len(resumable))
+	}
+
+	source.onFile = nil
+	newTestImportService(repo, source).resumeNext(context.Background())
+
+	done, _ := repo.GetImportJob(context.Background(), job.ID)
+	if done.Status != domain.ImportJobStatusCompletedWithErrors {
+		t.Errorf("expected status %s after resuming, got %s", domain.ImportJobStatusCompletedWithErrors, done.Status)
+	}
+}
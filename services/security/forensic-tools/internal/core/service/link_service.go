@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/domain"
+	"github.com/csic-platform/services/security/forensic-tools/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// ErrTransactionNotFound is returned when a transaction is not in the
+// transaction store
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// lookupBatchSize bounds how many values are looked up in one store query
+const lookupBatchSize = 500
+
+// LinkServiceImpl links evidence to the transactions and wallets its analysis
+// results mention. Links are made when a result is stored, so a record added
+// to the transaction store later is linked when the evidence is next analyzed.
+type LinkServiceImpl struct {
+	repo  ports.EvidenceRepository
+	links ports.LinkRepository
+	store ports.TransactionStore
+}
+
+// NewLinkService creates a new evidence link service
+func NewLinkService(
+	repo ports.EvidenceRepository,
+	links ports.LinkRepository,
+	store ports.TransactionStore,
+) *LinkServiceImpl {
+	return &LinkServiceImpl{
+		repo:  repo,
+		links: links,
+		store: store,
+	}
+}
+
+// LinkIndicators links the transaction hashes in a stored result to matching
+// transactions and its addresses to matching wallets
+func (s *LinkServiceImpl) LinkIndicators(ctx context.Context, evidence *domain.Evidence, result *domain.AnalysisResult) (int, error) {
+	hashes := make(map[string]domain.Indicator)
+	addresses := make(map[string]domain.Indicator)
+	for _, indicator := range result.Indicators {
+		values := hashes
+		if indicator.Kind == domain.IndicatorKindAddress {
+			values = addresses
+		}
+		for _, value := range lookupValues(indicator.Value) {
+			if _, ok := values[value]; !ok {
+				values[value] = indicator
+			}
+		}
+	}
+
+	now := time.Now()
+	var links []*domain.EvidenceLink
+	link := func(targetType domain.LinkTargetType, targetID string, indicator domain.Indicator) {
+		links = append(links, &domain.EvidenceLink{
+			ID:         uuid.New().String(),
+			EvidenceID: evidence.ID,
+			CaseID:     evidence.CaseID,
+			TargetType: targetType,
+			TargetID:   targetID,
+			Indicator:  indicator,
+			ResultID:   result.ID,
+			ToolName:   result.ToolName,
+			CreatedAt:  now,
+		})
+	}
+
+	for _, batch := range batchValues(indicatorValues(hashes), lookupBatchSize) {
+		transactions, err := s.store.FindTransactionsByHash(ctx, batch)
+		if err != nil {
+			return 0, fmt.Errorf("failed to look up transactions: %w", err)
+		}
+		for _, tx := range transactions {
+			if indicator, ok := hashes[tx.TxHash]; ok {
+				link(domain.LinkTargetTransaction, tx.ID, indicator)
+			}
+		}
+	}
+
+	for _, batch := range batchValues(indicatorValues(addresses), lookupBatchSize) {
+		wallets, err := s.store.FindWalletsByAddress(ctx, batch)
+		if err != nil {
+			return 0, fmt.Errorf("failed to look up wallets: %w", err)
+		}
+		for _, wallet := range wallets {
+			if indicator, ok := addresses[wallet.Address]; ok {
+				link(domain.LinkTargetWallet, wallet.ID, indicator)
+			}
+		}
+	}
+
+	if len(links) == 0 {
+		return 0, nil
+	}
+	created, err := s.links.CreateEvidenceLinks(ctx, links)
+	if err != nil {
+		return 0, fmt.Errorf("failed to store evidence links: %w", err)
+	}
+	return created, nil
+}
+
+// GetLinkedTransactions retrieves a paginated list of the transactions linked
+// to evidence
+func (s *LinkServiceImpl) GetLinkedTransactions(ctx context.Context, evidenceID string, page, pageSize int) (*domain.LinkedTransactionListResponse, error) {
+	if _, err := s.repo.GetEvidence(ctx, evidenceID); err != nil {
+		return nil, ErrEvidenceNotFound
+	}
+	page, pageSize = linkPage(page, pageSize)
+
+	links, total, err := s.links.ListEvidenceLinks(ctx, evidenceID, domain.LinkTargetTransaction, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.store.GetTransactions(ctx, targetIDs(links))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked transactions: %w", err)
+	}
+	byID := make(map[string]*domain.TransactionRecord, len(transactions))
+	for _, tx := range transactions {
+		byID[tx.ID] = tx
+	}
+
+	linked := make([]*domain.LinkedTransaction, len(links))
+	for i, link := range links {
+		linked[i] = &domain.LinkedTransaction{Link: link, Transaction: byID[link.TargetID]}
+	}
+	return &domain.LinkedTransactionListResponse{
+		Transactions: linked,
+		Total:        total,
+		Page:         page,
+		PageSize:     pageSize,
+		TotalPages:   totalPages(total, pageSize),
+	}, nil
+}
+
+// GetLinkedWallets retrieves a paginated list of the wallets linked to evidence
+func (s *LinkServiceImpl) GetLinkedWallets(ctx context.Context, evidenceID string, page, pageSize int) (*domain.LinkedWalletListResponse, error) {
+	if _, err := s.repo.GetEvidence(ctx, evidenceID); err != nil {
+		return nil, ErrEvidenceNotFound
+	}
+	page, pageSize = linkPage(page, pageSize)
+
+	links, total, err := s.links.ListEvidenceLinks(ctx, evidenceID, domain.LinkTargetWallet, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.store.GetWallets(ctx, targetIDs(links))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked wallets: %w", err)
+	}
+	byID := make(map[string]*domain.WalletRecord, len(wallets))
+	for _, wallet := range wallets {
+		byID[wallet.ID] = wallet
+	}
+
+	linked := make([]*domain.LinkedWallet, len(links))
+	for i, link := range links {
+		linked[i] = &domain.LinkedWallet{Link: link, Wallet: byID[link.TargetID]}
+	}
+	return &domain.LinkedWalletListResponse{
+		Wallets:    linked,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages(total, pageSize),
+	}, nil
+}
+
+// GetTransactionEvidence retrieves a paginated list of the evidence linked to
+// a transaction, directly by its hash or through the wallets of its sender
+// and receiver
+func (s *LinkServiceImpl) GetTransactionEvidence(ctx context.Context, transactionID string, page, pageSize int) (*domain.LinkedEvidenceListResponse, error) {
+	tx, err := s.store.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, ErrTransactionNotFound
+	}
+	page, pageSize = linkPage(page, pageSize)
+
+	targets := []domain.LinkTarget{{Type: domain.LinkTargetTransaction, ID: tx.ID}}
+
+	var addresses []string
+	for _, address := range []string{tx.Sender, tx.Receiver} {
+		if address != "" {
+			addresses = append(addresses, lookupValues(address)...)
+		}
+	}
+	if len(addresses) > 0 {
+		wallets, err := s.store.FindWalletsByAddress(ctx, addresses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up wallets: %w", err)
+		}
+		for _, wallet := range wallets {
+			targets = append(targets, domain.LinkTarget{Type: domain.LinkTargetWallet, ID: wallet.ID})
+		}
+	}
+
+	evidence, total, err := s.links.ListTargetLinks(ctx, targets, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LinkedEvidenceListResponse{
+		Evidence:   evidence,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages(total, pageSize),
+	}, nil
+}
+
+// lookupValues returns the values an indicator is looked up by. Hex values are
+// case-insensitive, so they are also tried in lower case.
+func lookupValues(value string) []string {
+	lower := strings.ToLower(value)
+	if lower == value || !isHexValue(value) {
+		return []string{value}
+	}
+	return []string{value, lower}
+}
+
+func isHexValue(value string) bool {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	for _, c := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return value != ""
+}
+
+func linkPage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 500 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
+
+func totalPages(total int64, pageSize int) int {
+	pages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		pages++
+	}
+	return pages
+}
+
+func targetIDs(links []*domain.EvidenceLink) []string {
+	ids := make([]string, len(links))
+	for i, link := range links {
+		ids[i] = link.TargetID
+	}
+	return ids
+}
+
+func indicatorValues(values map[string]domain.Indicator) []string {
+	list := make([]string, 0, len(values))
+	for value := range values {
+		list = append(list, value)
+	}
+	return list
+}
+
+func batchValues(values []string, size int) [][]string {
+	var batches [][]string
+	for len(values) > size {
+		batches = append(batches, values[:size])
+		values = values[size:]
+	}
+	if len(values) > 0 {
+		batches = append(batches, values)
+	}
+	return batches
+}
+
+// Ensure LinkServiceImpl implements LinkService
+var _ ports.LinkService = (*LinkServiceImpl)(nil)
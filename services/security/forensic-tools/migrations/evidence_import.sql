@@ -0,0 +1,42 @@
+-- Bulk evidence import jobs and their per-file outcomes
+
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id               UUID PRIMARY KEY,
+    case_id          VARCHAR(255) NOT NULL,
+    source_type      VARCHAR(32) NOT NULL,
+    source_path      TEXT NOT NULL,
+    status           VARCHAR(32) NOT NULL,
+    total_items      INTEGER NOT NULL DEFAULT 0,
+    imported_items   INTEGER NOT NULL DEFAULT 0,
+    mismatched_items INTEGER NOT NULL DEFAULT 0,
+    missing_items    INTEGER NOT NULL DEFAULT 0,
+    failed_items     INTEGER NOT NULL DEFAULT 0,
+    duplicate_items  INTEGER NOT NULL DEFAULT 0,
+    unlisted_items   INTEGER NOT NULL DEFAULT 0,
+    requested_by     VARCHAR(255) NOT NULL,
+    submitted_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    started_at       TIMESTAMP WITH TIME ZONE,
+    completed_at     TIMESTAMP WITH TIME ZONE,
+    error_message    TEXT,
+    lease_owner      VARCHAR(255),
+    lease_until      TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_jobs (status, submitted_at);
+
+CREATE TABLE IF NOT EXISTS import_items (
+    id             UUID PRIMARY KEY,
+    job_id         UUID NOT NULL REFERENCES import_jobs (id) ON DELETE CASCADE,
+    path           TEXT NOT NULL,
+    expected_hash  VARCHAR(64) NOT NULL DEFAULT '',
+    actual_hash    VARCHAR(64),
+    evidence_type  VARCHAR(32) NOT NULL DEFAULT '',
+    metadata       JSONB,
+    status         VARCHAR(32) NOT NULL,
+    evidence_id    VARCHAR(255),
+    error_message  TEXT,
+    updated_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (job_id, path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_items_status ON import_items (job_id, status);
@@ -0,0 +1,19 @@
+-- Links from evidence to the transactions and wallets its analysis found
+
+CREATE TABLE IF NOT EXISTS evidence_links (
+    id               UUID PRIMARY KEY,
+    evidence_id      VARCHAR(255) NOT NULL,
+    case_id          VARCHAR(255) NOT NULL,
+    target_type      VARCHAR(32) NOT NULL,
+    target_id        VARCHAR(255) NOT NULL,
+    indicator_kind   VARCHAR(32) NOT NULL,
+    indicator_value  TEXT NOT NULL,
+    indicator_chain  VARCHAR(32) NOT NULL,
+    indicator_offset BIGINT NOT NULL DEFAULT 0,
+    result_id        VARCHAR(255) NOT NULL,
+    tool_name        VARCHAR(255) NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (evidence_id, target_type, target_id, indicator_value)
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_links_target ON evidence_links (target_type, target_id);
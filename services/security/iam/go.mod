@@ -10,3 +10,5 @@ require (
 	go.uber.org/zap v1.26.0
 	golang.org/x/crypto v0.17.0
 )
+
+require go.uber.org/multierr v1.10.0 // indirect
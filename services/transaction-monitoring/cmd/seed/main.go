@@ -0,0 +1,123 @@
+// Transaction Monitoring Seed Tool
+// Generates a deterministic synthetic data set (exchanges, clustered wallets,
+// power-law transaction amounts, alerts and sanctions) into Postgres and/or
+// Kafka, so search and risk scoring can be benchmarked at scale. The same
+// seed and flags always produce the same rows.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/repository/postgres"
+	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/seed"
+	"go.uber.org/zap"
+)
+
+func main() {
+	defaults := seed.DefaultOptions()
+
+	seedValue := flag.Int64("seed", defaults.Seed, "Random seed; the same seed generates the same data")
+	exchanges := flag.Int("exchanges", defaults.Exchanges, "Number of exchanges")
+	hotWallets := flag.Int("hot-wallets", defaults.HotWallets, "Hot wallets per exchange and chain")
+	wallets := flag.Int("wallets", defaults.Wallets, "Number of user wallets")
+	transactions := flag.Int64("transactions", defaults.Transactions, "Number of transactions")
+	start := flag.String("start", defaults.Start.Format("2006-01-02"), "First day of transactions (YYYY-MM-DD)")
+	end := flag.String("end", defaults.End.Format("2006-01-02"), "Day after the last transactions (YYYY-MM-DD)")
+	alpha := flag.Float64("amount-alpha", defaults.AmountAlpha, "Pareto shape of USD amounts; lower is heavier tailed")
+	minAmount := flag.Float64("min-amount-usd", defaults.MinAmountUSD, "Smallest USD amount")
+	threshold := flag.Int("alert-threshold", defaults.AlertThreshold, "Risk score at which transactions are flagged")
+	databaseURL := flag.String("database-url", "", "Postgres URL to copy the data set into")
+	reset := flag.Bool("reset", false, "Truncate transactions, alerts, wallet profiles and sanctions first")
+	brokers := flag.String("kafka-brokers", "", "Comma-separated Kafka brokers to publish transactions to")
+	topic := flag.String("kafka-topic", "csic.transactions", "Kafka topic to publish transactions to")
+	batchSize := flag.Int("batch-size", 10000, "Rows per COPY or Kafka batch")
+	workers := flag.Int("workers", 4, "Concurrent writers")
+	flag.Parse()
+
+	if *databaseURL == "" && *brokers == "" {
+		fmt.Println("Fatal: -database-url or -kafka-brokers is required")
+		os.Exit(1)
+	}
+
+	opts := defaults
+	opts.Seed = *seedValue
+	opts.Exchanges = *exchanges
+	opts.HotWallets = *hotWallets
+	opts.Wallets = *wallets
+	opts.Transactions = *transactions
+	opts.AmountAlpha = *alpha
+	opts.MinAmountUSD = *minAmount
+	opts.AlertThreshold = *threshold
+
+	var err error
+	if opts.Start, err = time.Parse("2006-01-02", *start); err != nil {
+		fmt.Printf("Fatal: Invalid start: %v\n", err)
+		os.Exit(1)
+	}
+	if opts.End, err = time.Parse("2006-01-02", *end); err != nil {
+		fmt.Printf("Fatal: Invalid end: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		fmt.Printf("Fatal: Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	generator, err := seed.NewGenerator(opts)
+	if err != nil {
+		fmt.Printf("Fatal: Invalid options: %v\n", err)
+		os.Exit(1)
+	}
+
+	var pgSink *seed.PostgresSink
+	if *databaseURL != "" {
+		conn, err := postgres.NewConnectionURL(*databaseURL, logger)
+		if err != nil {
+			fmt.Printf("Fatal: Failed to connect to database: %v\n", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		pgSink = seed.NewPostgresSink(conn, logger)
+		if *reset {
+			if err := pgSink.Reset(ctx); err != nil {
+				fmt.Printf("Fatal: Failed to reset tables: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	var kafkaSink *seed.KafkaSink
+	if *brokers != "" {
+		kafkaSink = seed.NewKafkaSink(*brokers, *topic)
+		defer kafkaSink.Close()
+	}
+
+	seeder, err := seed.NewSeeder(generator, pgSink, kafkaSink, *batchSize, *workers, logger)
+	if err != nil {
+		fmt.Printf("Fatal: %v\n", err)
+		os.Exit(1)
+	}
+
+	summary, err := seeder.Run(ctx)
+	if err != nil {
+		fmt.Printf("Fatal: Seeding failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Seeded %d transactions, %d alerts, %d wallets and %d sanctioned addresses with seed %d in %s\n",
+		summary.Transactions, summary.Alerts, summary.Wallets, summary.Sanctioned, opts.Seed, summary.Duration.Round(time.Second))
+}
@@ -9,9 +9,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/archive"
+	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/feeds"
 	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/handler/http"
+	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/notify"
 	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/repository/postgres"
 	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/events/kafka"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/services"
 	"github.com/spf13/viper"
@@ -46,6 +50,23 @@ func main() {
 	sanctionsRepo := postgres.NewSanctionsRepository(dbConnection, logger)
 	alertRepo := postgres.NewAlertRepository(dbConnection, logger)
 	ruleRepo := postgres.NewMonitoringRuleRepository(dbConnection, logger)
+	ruleVersionRepo := postgres.NewRuleVersionRepository(dbConnection, logger)
+	contractRepo := postgres.NewContractRegistryRepository(dbConnection, logger)
+	fxRateRepo := postgres.NewFXRateRepository(dbConnection, logger)
+	archiveRepo := postgres.NewArchiveRepository(dbConnection, logger)
+	subscriptionRepo := postgres.NewSubscriptionRepository(dbConnection, logger)
+	notificationRepo := postgres.NewNotificationRepository(dbConnection, logger)
+
+	// Keep monthly transaction partitions ahead of incoming rows
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	transactionPartitions := postgres.NewPartitionMaintainer(dbConnection, postgres.PartitionConfig{
+		Table:     "transactions",
+		Interval:  postgres.PartitionMonthly,
+		Premake:   viper.GetInt("partitioning.transactions.premake"),
+		Retention: viper.GetInt("partitioning.transactions.retention"),
+	}, logger)
+	go transactionPartitions.Start(backgroundCtx, time.Duration(viper.GetInt("partitioning.check_interval"))*time.Second)
 
 	// Initialize Kafka producer
 	kafkaProducer, err := kafka.NewProducer(logger)
@@ -54,18 +75,125 @@ func main() {
 		kafkaProducer = nil
 	}
 
+	// Initialize rule expression engine
+	expressionEngine, err := services.NewRuleExpressionEngine()
+	if err != nil {
+		logger.Fatal("Failed to initialize rule expression engine", zap.Error(err))
+	}
+
+	// Initialize exchange rates and keep the history up to date from the rate providers
+	var fxFeedConfigs []feeds.FXFeedConfig
+	if err := viper.UnmarshalKey("fx_rates.providers", &fxFeedConfigs); err != nil {
+		logger.Fatal("Invalid exchange rate provider configuration", zap.Error(err))
+	}
+	fxProviders := make([]ports.FXRateProvider, 0, len(fxFeedConfigs))
+	for _, feedConfig := range fxFeedConfigs {
+		fxProviders = append(fxProviders, feeds.NewHTTPRateFeed(feedConfig))
+	}
+	fxService := services.NewFXRateService(fxRateRepo, fxProviders, services.FXRateServiceConfig{
+		Pivot:  viper.GetString("fx_rates.pivot"),
+		MaxAge: time.Duration(viper.GetInt("fx_rates.max_age_days")) * 24 * time.Hour,
+	}, logger)
+	go fxService.Start(backgroundCtx, time.Duration(viper.GetInt("fx_rates.sync_interval"))*time.Second)
+
+	// Initialize pattern subscriptions and the channels their hits are delivered to
+	var webhookConfig notify.WebhookConfig
+	if err := viper.UnmarshalKey("subscriptions.webhook", &webhookConfig); err != nil {
+		logger.Fatal("Invalid subscription webhook configuration", zap.Error(err))
+	}
+	webhookNotifier := notify.NewWebhookNotifier(webhookConfig)
+	notifiers := map[domain.NotificationChannelType]ports.SubscriptionNotifier{
+		domain.ChannelWebhook: webhookNotifier,
+	}
+	digestNotifiers := map[domain.NotificationChannelType]ports.DigestNotifier{
+		domain.ChannelWebhook: webhookNotifier,
+	}
+	if viper.GetString("subscriptions.email.host") != "" {
+		var emailConfig notify.EmailConfig
+		if err := viper.UnmarshalKey("subscriptions.email", &emailConfig); err != nil {
+			logger.Fatal("Invalid subscription email configuration", zap.Error(err))
+		}
+		emailNotifier := notify.NewEmailNotifier(emailConfig)
+		notifiers[domain.ChannelEmail] = emailNotifier
+		digestNotifiers[domain.ChannelEmail] = emailNotifier
+	}
+
+	// Route hits by their owners' notification preferences and batch
+	// non-critical ones into hourly or daily digests
+	digestService := services.NewNotificationDigestService(notificationRepo, digestNotifiers, logger)
+	go digestService.Start(backgroundCtx, time.Duration(viper.GetInt("notifications.digest_interval"))*time.Second)
+
+	subscriptionService := services.NewPatternSubscriptionService(subscriptionRepo, notifiers, digestService, logger)
+	go subscriptionService.Start(backgroundCtx, time.Duration(viper.GetInt("subscriptions.refresh_interval"))*time.Second)
+
+	// Screen sanctions checks in memory, reloading the list to pick up changes
+	// made by other replicas
+	sanctionsScreen := services.NewSanctionsScreen(sanctionsRepo, services.SanctionsScreenConfig{
+		FalsePositiveRate: viper.GetFloat64("sanctions_screen.false_positive_rate"),
+		CacheSize:         viper.GetInt("sanctions_screen.cache_size"),
+	}, logger)
+	go sanctionsScreen.Start(backgroundCtx, time.Duration(viper.GetInt("sanctions_screen.refresh_interval"))*time.Second)
+
 	// Initialize services
 	transactionService := services.NewTransactionAnalysisService(
-		transactionRepo, walletProfileRepo, sanctionsRepo, ruleRepo, logger,
+		transactionRepo, walletProfileRepo, sanctionsScreen, ruleRepo, expressionEngine, fxService,
+		subscriptionService, logger,
 	)
 	walletService := services.NewWalletProfilingService(walletProfileRepo, transactionRepo, logger)
-	riskService := services.NewRiskScoringService(walletProfileRepo, transactionRepo, ruleRepo, logger)
+	riskService := services.NewRiskScoringService(walletProfileRepo, transactionRepo, ruleRepo, contractRepo, logger)
 	alertService := services.NewAlertService(alertRepo, kafkaProducer, logger)
-	ruleService := services.NewRuleEngineService(ruleRepo, logger)
+	ruleService := services.NewRuleEngineService(
+		ruleRepo, walletProfileRepo, transactionRepo, ruleVersionRepo, expressionEngine, fxService, logger,
+	)
+
+	// Initialize contract registry and keep it in sync with the curated feeds
+	var feedConfigs []feeds.ContractFeedConfig
+	if err := viper.UnmarshalKey("contract_registry.feeds", &feedConfigs); err != nil {
+		logger.Fatal("Invalid contract registry feed configuration", zap.Error(err))
+	}
+	contractFeeds := make([]ports.ContractFeed, 0, len(feedConfigs))
+	for _, feedConfig := range feedConfigs {
+		contractFeeds = append(contractFeeds, feeds.NewHTTPContractFeed(feedConfig))
+	}
+	contractService := services.NewContractRegistryService(contractRepo, contractFeeds, logger)
+	go contractService.Start(backgroundCtx, time.Duration(viper.GetInt("contract_registry.sync_interval"))*time.Second)
+
+	// Initialize archival and move transactions past retention to cold storage
+	var archiveStore ports.TransactionArchiveStore
+	if viper.GetBool("archival.enabled") {
+		var storeConfig archive.S3Config
+		if err := viper.UnmarshalKey("archival.storage", &storeConfig); err != nil {
+			logger.Fatal("Invalid archive storage configuration", zap.Error(err))
+		}
+		s3Store, err := archive.NewS3Store(backgroundCtx, storeConfig)
+		if err != nil {
+			logger.Fatal("Failed to initialize archive storage", zap.Error(err))
+		}
+		archiveStore = s3Store
+	}
+	archivalService := services.NewTransactionArchivalService(transactionRepo, archiveRepo, archiveStore, services.ArchivalConfig{
+		RetainDays:        viper.GetInt("archival.retain_days"),
+		BatchSize:         viper.GetInt("archival.batch_size"),
+		Prefix:            viper.GetString("archival.prefix"),
+		MaxSearchArchives: viper.GetInt("archival.max_search_archives"),
+	}, logger)
+	go archivalService.Start(backgroundCtx, time.Duration(viper.GetInt("archival.interval"))*time.Second)
+
+	// Initialize rule bundle exchange with other agencies
+	bundleService, err := services.NewRuleBundleService(ruleRepo, ruleVersionRepo, expressionEngine, services.RuleBundleConfig{
+		Issuer:      viper.GetString("rule_bundles.issuer"),
+		KeyID:       viper.GetString("rule_bundles.key_id"),
+		PrivateKey:  viper.GetString("rule_bundles.private_key"),
+		TrustedKeys: viper.GetStringMapString("rule_bundles.trusted_keys"),
+	}, logger)
+	if err != nil {
+		logger.Fatal("Invalid rule bundle configuration", zap.Error(err))
+	}
 
 	// Initialize handlers
 	handlers := http.NewHandlers(
-		transactionService, walletService, riskService, alertService, ruleService, logger,
+		transactionService, walletService, riskService, alertService, ruleService, contractService, fxService,
+		archivalService, bundleService, subscriptionService, digestService, sanctionsScreen, logger,
 	)
 
 	// Initialize router
@@ -128,6 +256,24 @@ func initConfig() error {
 	viper.SetDefault("monitoring.risk_threshold_high", 75)
 	viper.SetDefault("monitoring.risk_threshold_medium", 50)
 	viper.SetDefault("monitoring.max_transaction_value", 1000000.0)
+	viper.SetDefault("partitioning.check_interval", 3600)
+	viper.SetDefault("partitioning.transactions.premake", 3)
+	viper.SetDefault("partitioning.transactions.retention", 0)
+	viper.SetDefault("contract_registry.sync_interval", 21600)
+	viper.SetDefault("fx_rates.sync_interval", 86400)
+	viper.SetDefault("fx_rates.pivot", "USD")
+	viper.SetDefault("fx_rates.max_age_days", 7)
+	viper.SetDefault("archival.enabled", false)
+	viper.SetDefault("archival.interval", 86400)
+	viper.SetDefault("archival.retain_days", 365)
+	viper.SetDefault("archival.batch_size", 50000)
+	viper.SetDefault("archival.max_search_archives", 50)
+	viper.SetDefault("rule_bundles.issuer", "csic")
+	viper.SetDefault("subscriptions.refresh_interval", 30)
+	viper.SetDefault("notifications.digest_interval", 60)
+	viper.SetDefault("sanctions_screen.refresh_interval", 60)
+	viper.SetDefault("sanctions_screen.false_positive_rate", 0.001)
+	viper.SetDefault("sanctions_screen.cache_size", 100000)
 
 	// Environment variable overrides
 	viper.AutomaticEnv()
@@ -148,5 +294,18 @@ func initLogger() (*zap.Logger, error) {
 var _ ports.TransactionRepository = (*postgres.TransactionRepository)(nil)
 var _ ports.WalletProfileRepository = (*postgres.WalletProfileRepository)(nil)
 var _ ports.SanctionsRepository = (*postgres.SanctionsRepository)(nil)
+var _ ports.SanctionsRepository = (*services.SanctionsScreen)(nil)
 var _ ports.AlertRepository = (*postgres.AlertRepository)(nil)
 var _ ports.MonitoringRuleRepository = (*postgres.MonitoringRuleRepository)(nil)
+var _ ports.RuleVersionRepository = (*postgres.RuleVersionRepository)(nil)
+var _ ports.ContractRegistryRepository = (*postgres.ContractRegistryRepository)(nil)
+var _ ports.FXRateRepository = (*postgres.FXRateRepository)(nil)
+var _ ports.TransactionArchiveRepository = (*postgres.ArchiveRepository)(nil)
+var _ ports.PatternSubscriptionRepository = (*postgres.SubscriptionRepository)(nil)
+var _ ports.SubscriptionNotifier = (*notify.WebhookNotifier)(nil)
+var _ ports.SubscriptionNotifier = (*notify.EmailNotifier)(nil)
+var _ ports.NotificationPreferenceRepository = (*postgres.NotificationRepository)(nil)
+var _ ports.DigestNotifier = (*notify.WebhookNotifier)(nil)
+var _ ports.DigestNotifier = (*notify.EmailNotifier)(nil)
+var _ ports.NotificationRouter = (*services.NotificationDigestService)(nil)
+var _ ports.NotificationPreferenceService = (*services.NotificationDigestService)(nil)
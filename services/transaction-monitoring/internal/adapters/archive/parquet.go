@@ -0,0 +1,182 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/shopspring/decimal"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// transactionRow is the Parquet schema of an archived transaction. Amounts are
+// kept as decimal strings so no precision is lost; timestamps are microseconds
+// since the Unix epoch.
+type transactionRow struct {
+	ID           string  `parquet:"name=id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TxHash       string  `parquet:"name=tx_hash, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Chain        string  `parquet:"name=chain, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	BlockNumber  *int64  `parquet:"name=block_number, type=INT64, repetitiontype=OPTIONAL"`
+	FromAddress  string  `parquet:"name=from_address, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ToAddress    *string `parquet:"name=to_address, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	TokenAddress *string `parquet:"name=token_address, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Amount       string  `parquet:"name=amount, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AmountUSD    string  `parquet:"name=amount_usd, type=BYTE_ARRAY, convertedtype=UTF8"`
+	GasUsed      *int64  `parquet:"name=gas_used, type=INT64, repetitiontype=OPTIONAL"`
+	GasPrice     *string `parquet:"name=gas_price, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	GasFeeUSD    *string `parquet:"name=gas_fee_usd, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Nonce        *int64  `parquet:"name=nonce, type=INT64, repetitiontype=OPTIONAL"`
+	TxTimestamp  int64   `parquet:"name=tx_timestamp, type=INT64, convertedtype=TIMESTAMP_MICROS"`
+	RiskScore    int32   `parquet:"name=risk_score, type=INT32"`
+	Flagged      bool    `parquet:"name=flagged, type=BOOLEAN"`
+	FlagReason   *string `parquet:"name=flag_reason, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	ReviewedAt   *int64  `parquet:"name=reviewed_at, type=INT64, convertedtype=TIMESTAMP_MICROS, repetitiontype=OPTIONAL"`
+	ReviewedBy   *string `parquet:"name=reviewed_by, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	CreatedAt    int64   `parquet:"name=created_at, type=INT64, convertedtype=TIMESTAMP_MICROS"`
+}
+
+// encodeParquet writes transactions as a Snappy-compressed Parquet file
+func encodeParquet(txs []*domain.Transaction) ([]byte, error) {
+	var buf bytes.Buffer
+	pw, err := writer.NewParquetWriterFromWriter(&buf, new(transactionRow), 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	for _, tx := range txs {
+		if err := pw.Write(toRow(tx)); err != nil {
+			return nil, fmt.Errorf("failed to write transaction %s: %w", tx.ID, err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		return nil, fmt.Errorf("failed to finish parquet file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeParquet reads the transactions of a Parquet file written by encodeParquet
+func decodeParquet(data []byte) ([]*domain.Transaction, error) {
+	file, err := buffer.NewBufferFile(data)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := reader.NewParquetReader(file, new(transactionRow), 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open parquet file: %w", err)
+	}
+	defer pr.ReadStop()
+
+	rows := make([]transactionRow, pr.GetNumRows())
+	if err := pr.Read(&rows); err != nil {
+		return nil, fmt.Errorf("failed to read parquet rows: %w", err)
+	}
+
+	txs := make([]*domain.Transaction, 0, len(rows))
+	for i := range rows {
+		tx, err := fromRow(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func toRow(tx *domain.Transaction) transactionRow {
+	row := transactionRow{
+		ID:           tx.ID,
+		TxHash:       tx.TxHash,
+		Chain:        tx.Chain,
+		BlockNumber:  tx.BlockNumber,
+		FromAddress:  tx.FromAddress,
+		ToAddress:    tx.ToAddress,
+		TokenAddress: tx.TokenAddress,
+		Amount:       tx.Amount.String(),
+		AmountUSD:    tx.AmountUSD.String(),
+		GasUsed:      tx.GasUsed,
+		GasPrice:     decimalString(tx.GasPrice),
+		GasFeeUSD:    decimalString(tx.GasFeeUSD),
+		TxTimestamp:  tx.TxTimestamp.UnixMicro(),
+		RiskScore:    int32(tx.RiskScore),
+		Flagged:      tx.Flagged,
+		FlagReason:   tx.FlagReason,
+		ReviewedBy:   tx.ReviewedBy,
+		CreatedAt:    tx.CreatedAt.UnixMicro(),
+	}
+	if tx.Nonce != nil {
+		nonce := int64(*tx.Nonce)
+		row.Nonce = &nonce
+	}
+	if tx.ReviewedAt != nil {
+		reviewedAt := tx.ReviewedAt.UnixMicro()
+		row.ReviewedAt = &reviewedAt
+	}
+	return row
+}
+
+func fromRow(row *transactionRow) (*domain.Transaction, error) {
+	tx := &domain.Transaction{
+		ID:           row.ID,
+		TxHash:       row.TxHash,
+		Chain:        row.Chain,
+		BlockNumber:  row.BlockNumber,
+		FromAddress:  row.FromAddress,
+		ToAddress:    row.ToAddress,
+		TokenAddress: row.TokenAddress,
+		GasUsed:      row.GasUsed,
+		TxTimestamp:  time.UnixMicro(row.TxTimestamp).UTC(),
+		RiskScore:    int(row.RiskScore),
+		Flagged:      row.Flagged,
+		FlagReason:   row.FlagReason,
+		ReviewedBy:   row.ReviewedBy,
+		CreatedAt:    time.UnixMicro(row.CreatedAt).UTC(),
+	}
+
+	var err error
+	if tx.Amount, err = decimal.NewFromString(row.Amount); err != nil {
+		return nil, fmt.Errorf("transaction %s has invalid amount: %w", row.ID, err)
+	}
+	if tx.AmountUSD, err = decimal.NewFromString(row.AmountUSD); err != nil {
+		return nil, fmt.Errorf("transaction %s has invalid amount_usd: %w", row.ID, err)
+	}
+	if tx.GasPrice, err = parseDecimal(row.GasPrice); err != nil {
+		return nil, fmt.Errorf("transaction %s has invalid gas_price: %w", row.ID, err)
+	}
+	if tx.GasFeeUSD, err = parseDecimal(row.GasFeeUSD); err != nil {
+		return nil, fmt.Errorf("transaction %s has invalid gas_fee_usd: %w", row.ID, err)
+	}
+	if row.Nonce != nil {
+		nonce := int(*row.Nonce)
+		tx.Nonce = &nonce
+	}
+	if row.ReviewedAt != nil {
+		reviewedAt := time.UnixMicro(*row.ReviewedAt).UTC()
+		tx.ReviewedAt = &reviewedAt
+	}
+	return tx, nil
+}
+
+func decimalString(d *decimal.Decimal) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.String()
+	return &s
+}
+
+func parseDecimal(s *string) (*decimal.Decimal, error) {
+	if s == nil {
+		return nil, nil
+	}
+	d, err := decimal.NewFromString(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// parquetContentType is the media type of archive objects
+const parquetContentType = "application/vnd.apache.parquet"
+
+// S3Config holds the connection settings of an S3-compatible object store
+type S3Config struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	UseSSL    bool   `mapstructure:"use_ssl"`
+}
+
+// S3Store keeps transaction archives as Parquet objects on S3 or MinIO
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Store creates an S3 archive store, creating the bucket if it does not exist
+func NewS3Store(ctx context.Context, cfg S3Config) (*S3Store, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket: %w", err)
+		}
+	}
+
+	return &S3Store{
+		client: client,
+		bucket: cfg.Bucket,
+	}, nil
+}
+
+// WriteArchive encodes transactions as Parquet and uploads them under key. It
+// returns the object's size and SHA-256 checksum.
+func (s *S3Store) WriteArchive(ctx context.Context, key string, txs []*domain.Transaction) (int64, string, error) {
+	data, err := encodeParquet(txs)
+	if err != nil {
+		return 0, "", err
+	}
+	sum := sha256.Sum256(data)
+
+	_, err = s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType:    parquetContentType,
+		SendContentMd5: true,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+
+	return int64(len(data)), hex.EncodeToString(sum[:]), nil
+}
+
+// ReadArchive downloads an archive, verifies its checksum and decodes its transactions
+func (s *S3Store) ReadArchive(ctx context.Context, archive *domain.TransactionArchive) ([]*domain.Transaction, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, archive.ObjectKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", archive.ObjectKey, err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", archive.ObjectKey, err)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != archive.Checksum {
+		return nil, fmt.Errorf("%w: %s", domain.ErrArchiveCorrupt, archive.ObjectKey)
+	}
+
+	txs, err := decodeParquet(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", archive.ObjectKey, err)
+	}
+	return txs, nil
+}
+
+// Ensure S3Store implements the TransactionArchiveStore interface
+var _ ports.TransactionArchiveStore = (*S3Store)(nil)
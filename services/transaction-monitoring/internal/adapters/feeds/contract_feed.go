@@ -0,0 +1,134 @@
+package feeds
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+)
+
+// maxFeedBytes bounds the size of a feed document
+const maxFeedBytes = 32 << 20
+
+// ContractFeedConfig describes a curated contract feed
+type ContractFeedConfig struct {
+	Name    string            `mapstructure:"name"`
+	URL     string            `mapstructure:"url"`
+	Headers map[string]string `mapstructure:"headers"`
+	Timeout time.Duration     `mapstructure:"timeout"`
+}
+
+// feedEntry is one contract in a feed document. A missing risk_weight falls
+// back to the category default.
+type feedEntry struct {
+	Chain      string   `json:"chain"`
+	Address    string   `json:"address"`
+	Name       string   `json:"name"`
+	Protocol   string   `json:"protocol"`
+	Category   string   `json:"category"`
+	RiskWeight *float64 `json:"risk_weight"`
+	Tags       []string `json:"tags"`
+	Notes      string   `json:"notes"`
+}
+
+// HTTPContractFeed fetches a curated contract list published as JSON, either
+// a bare array of entries or an object with a "contracts" array
+type HTTPContractFeed struct {
+	config ContractFeedConfig
+	client *http.Client
+}
+
+// NewHTTPContractFeed creates a feed for the given configuration
+func NewHTTPContractFeed(config ContractFeedConfig) *HTTPContractFeed {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &HTTPContractFeed{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name returns the feed name, recorded as the source of its entries
+func (f *HTTPContractFeed) Name() string {
+	return f.config.Name
+}
+
+// FetchContracts downloads and decodes the feed
+func (f *HTTPContractFeed) FetchContracts(ctx context.Context) ([]*domain.RegisteredContract, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.config.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for key, value := range f.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contract feed %s: %w", f.config.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("contract feed %s returned status %d", f.config.Name, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract feed %s: %w", f.config.Name, err)
+	}
+
+	entries, err := decodeFeed(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract feed %s: %w", f.config.Name, err)
+	}
+
+	contracts := make([]*domain.RegisteredContract, 0, len(entries))
+	for _, entry := range entries {
+		contract := &domain.RegisteredContract{
+			Chain:    entry.Chain,
+			Address:  entry.Address,
+			Name:     entry.Name,
+			Protocol: entry.Protocol,
+			Category: domain.ContractCategory(entry.Category),
+			Tags:     entry.Tags,
+			Notes:    entry.Notes,
+		}
+		contract.Normalize()
+		if entry.RiskWeight != nil {
+			contract.RiskWeight = *entry.RiskWeight
+		} else {
+			contract.RiskWeight = contract.Category.DefaultRiskWeight()
+		}
+		contracts = append(contracts, contract)
+	}
+
+	return contracts, nil
+}
+
+func decodeFeed(body []byte) ([]feedEntry, error) {
+	var entries []feedEntry
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err := json.Unmarshal(trimmed, &entries)
+		return entries, err
+	}
+
+	var document struct {
+		Contracts []feedEntry `json:"contracts"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, err
+	}
+	return document.Contracts, nil
+}
+
+// Ensure HTTPContractFeed implements the ContractFeed interface
+var _ ports.ContractFeed = (*HTTPContractFeed)(nil)
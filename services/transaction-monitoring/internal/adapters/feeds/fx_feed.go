@@ -0,0 +1,109 @@
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"github.com/shopspring/decimal"
+)
+
+// FXFeedConfig describes a daily reference rate feed. A {date} placeholder in
+// the URL is replaced with the requested day as YYYY-MM-DD.
+type FXFeedConfig struct {
+	Name    string            `mapstructure:"name"`
+	URL     string            `mapstructure:"url"`
+	Headers map[string]string `mapstructure:"headers"`
+	Timeout time.Duration     `mapstructure:"timeout"`
+}
+
+// rateDocument is a feed's rates for one day, each the price of one unit of
+// base in the keyed currency. A missing date means the requested day.
+type rateDocument struct {
+	Base  string                     `json:"base"`
+	Date  string                     `json:"date"`
+	Rates map[string]decimal.Decimal `json:"rates"`
+}
+
+// HTTPRateFeed fetches daily reference rates published as JSON, in the form
+// {"base": "USD", "date": "2024-01-02", "rates": {"EUR": "0.91", "BTC": "0.0000233"}}
+type HTTPRateFeed struct {
+	config FXFeedConfig
+	client *http.Client
+}
+
+// NewHTTPRateFeed creates a rate feed for the given configuration
+func NewHTTPRateFeed(config FXFeedConfig) *HTTPRateFeed {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &HTTPRateFeed{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name returns the feed name, recorded as the source of its rates
+func (f *HTTPRateFeed) Name() string {
+	return f.config.Name
+}
+
+// FetchRates downloads and decodes the feed's rates for a day
+func (f *HTTPRateFeed) FetchRates(ctx context.Context, day time.Time) ([]*domain.FXRate, error) {
+	url := strings.ReplaceAll(f.config.URL, "{date}", day.UTC().Format("2006-01-02"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for key, value := range f.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rate feed %s: %w", f.config.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rate feed %s returned status %d", f.config.Name, resp.StatusCode)
+	}
+
+	var document rateDocument
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxFeedBytes)).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to decode rate feed %s: %w", f.config.Name, err)
+	}
+	if document.Base == "" {
+		return nil, fmt.Errorf("rate feed %s has no base currency", f.config.Name)
+	}
+
+	rateDate := day
+	if document.Date != "" {
+		rateDate, err = time.Parse("2006-01-02", document.Date)
+		if err != nil {
+			return nil, fmt.Errorf("rate feed %s has invalid date %q", f.config.Name, document.Date)
+		}
+	}
+
+	rates := make([]*domain.FXRate, 0, len(document.Rates))
+	for quote, rate := range document.Rates {
+		rates = append(rates, &domain.FXRate{
+			Base:     document.Base,
+			Quote:    quote,
+			Rate:     rate,
+			RateDate: rateDate,
+		})
+	}
+
+	return rates, nil
+}
+
+// Ensure HTTPRateFeed implements the FXRateProvider interface
+var _ ports.FXRateProvider = (*HTTPRateFeed)(nil)
@@ -2,42 +2,66 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
 // Handlers contains all HTTP handlers for the transaction monitoring service
 type Handlers struct {
-	transactionService ports.TransactionAnalysisService
-	walletService      ports.WalletProfilingService
-	riskService        ports.RiskScoringService
-	alertService       ports.AlertService
-	ruleService        ports.RuleEngineService
-	logger             *zap.Logger
+	transactionService  ports.TransactionAnalysisService
+	walletService       ports.WalletProfilingService
+	riskService         ports.RiskScoringService
+	alertService        ports.AlertService
+	ruleService         ports.RuleEngineService
+	contractService     ports.ContractRegistryService
+	fxService           ports.FXRateService
+	archivalService     ports.TransactionArchivalService
+	bundleService       ports.RuleBundleService
+	subscriptionService ports.PatternSubscriptionService
+	preferenceService   ports.NotificationPreferenceService
+	sanctionsScreen     ports.SanctionsScreen
+	logger              *zap.Logger
 }
 
 // NewHandlers creates a new handlers instance
 func NewHandlers(
-	transactionService ports.TransactionAnalysisService,
-	walletService ports.WalletProfilingService,
-	riskService ports.RiskScoringService,
-	alertService ports.AlertService,
-	ruleService ports.RuleEngineService,
-	logger *zap.Logger,
+	transactionService  ports.TransactionAnalysisService,
+	walletService       ports.WalletProfilingService,
+	riskService         ports.RiskScoringService,
+	alertService        ports.AlertService,
+	ruleService         ports.RuleEngineService,
+	contractService     ports.ContractRegistryService,
+	fxService           ports.FXRateService,
+	archivalService     ports.TransactionArchivalService,
+	bundleService       ports.RuleBundleService,
+	subscriptionService ports.PatternSubscriptionService,
+	preferenceService   ports.NotificationPreferenceService,
+	sanctionsScreen     ports.SanctionsScreen,
+	logger              *zap.Logger,
 ) *Handlers {
 	return &Handlers{
-		transactionService: transactionService,
-		walletService:      walletService,
-		riskService:        riskService,
-		alertService:       alertService,
-		ruleService:        ruleService,
-		logger:             logger,
+		transactionService:  transactionService,
+		walletService:       walletService,
+		riskService:         riskService,
+		alertService:        alertService,
+		ruleService:         ruleService,
+		contractService:     contractService,
+		fxService:           fxService,
+		archivalService:     archivalService,
+		bundleService:       bundleService,
+		subscriptionService: subscriptionService,
+		preferenceService:   preferenceService,
+		sanctionsScreen:     sanctionsScreen,
+		logger:              logger,
 	}
 }
 
@@ -202,13 +226,252 @@ func (h *Handlers) CreateMonitoringRule(c *gin.Context) {
 		return
 	}
 
-	// Note: Would need rule service method
+	if err := h.ruleService.CreateRule(c.Request.Context(), &rule, c.GetHeader("X-User-ID")); err != nil {
+		if errors.Is(err, domain.ErrInvalidRuleExpression) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to create monitoring rule", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create rule"})
+		return
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Monitoring rule created",
 		"rule":    rule,
 	})
 }
 
+// UpdateMonitoringRule records changes to a monitoring rule as a draft version
+func (h *Handlers) UpdateMonitoringRule(c *gin.Context) {
+	var req struct {
+		domain.MonitoringRule
+		ChangeNote string `json:"change_note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rule := req.MonitoringRule
+	rule.ID = c.Param("id")
+
+	version, err := h.ruleService.UpdateRule(c.Request.Context(), &rule, c.GetHeader("X-User-ID"), req.ChangeNote)
+	if err != nil {
+		h.writeRuleError(c, "Failed to update rule", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Draft rule version created",
+		"version": version,
+	})
+}
+
+// GetRuleVersions lists the version history of a monitoring rule
+func (h *Handlers) GetRuleVersions(c *gin.Context) {
+	versions, err := h.ruleService.ListRuleVersions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeRuleError(c, "Failed to list rule versions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+// DiffRuleVersions shows the field-level changes between two rule versions
+func (h *Handlers) DiffRuleVersions(c *gin.Context) {
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to version numbers are required"})
+		return
+	}
+
+	diff, err := h.ruleService.DiffRuleVersions(c.Request.Context(), c.Param("id"), from, to)
+	if err != nil {
+		h.writeRuleError(c, "Failed to diff rule versions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// SubmitRuleVersion submits a draft rule version for approval
+func (h *Handlers) SubmitRuleVersion(c *gin.Context) {
+	version, ok := h.parseRuleVersion(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.ruleService.SubmitRuleVersion(c.Request.Context(), c.Param("id"), version, c.GetHeader("X-User-ID"))
+	if err != nil {
+		h.writeRuleError(c, "Failed to submit rule version", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ApproveRuleVersion approves a pending rule version and makes it live
+func (h *Handlers) ApproveRuleVersion(c *gin.Context) {
+	version, ok := h.parseRuleVersion(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	result, err := h.ruleService.ApproveRuleVersion(c.Request.Context(), c.Param("id"), version, c.GetHeader("X-User-ID"), req.Comment)
+	if err != nil {
+		h.writeRuleError(c, "Failed to approve rule version", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RejectRuleVersion rejects a pending rule version
+func (h *Handlers) RejectRuleVersion(c *gin.Context) {
+	version, ok := h.parseRuleVersion(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	result, err := h.ruleService.RejectRuleVersion(c.Request.Context(), c.Param("id"), version, c.GetHeader("X-User-ID"), req.Comment)
+	if err != nil {
+		h.writeRuleError(c, "Failed to reject rule version", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RollbackMonitoringRule restores a previously active rule version
+func (h *Handlers) RollbackMonitoringRule(c *gin.Context) {
+	var req struct {
+		Version int `json:"version" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.ruleService.RollbackRule(c.Request.Context(), c.Param("id"), req.Version, c.GetHeader("X-User-ID"))
+	if err != nil {
+		h.writeRuleError(c, "Failed to roll back rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handlers) parseRuleVersion(c *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version number"})
+		return 0, false
+	}
+	return version, true
+}
+
+// writeRuleError maps rule and rule version errors to HTTP responses
+func (h *Handlers) writeRuleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRuleExpression), errors.Is(err, domain.ErrInvalidRuleTransition):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrRuleSelfApproval):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrRuleVersionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// BacktestMonitoringRule replays recent transactions against a rule without raising alerts
+func (h *Handlers) BacktestMonitoringRule(c *gin.Context) {
+	ruleID := c.Param("id")
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	samples, _ := strconv.Atoi(c.DefaultQuery("samples", "20"))
+
+	result, err := h.ruleService.BacktestRule(c.Request.Context(), ruleID, days, samples)
+	if err != nil {
+		h.logger.Error("Rule backtest failed", zap.String("rule_id", ruleID), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Backtest failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportRuleBundle exports monitoring rules as a signed bundle. The ids query
+// parameter lists the rules to export; without it every active rule is exported.
+func (h *Handlers) ExportRuleBundle(c *gin.Context) {
+	var ruleIDs []string
+	if ids := c.Query("ids"); ids != "" {
+		ruleIDs = strings.Split(ids, ",")
+	}
+
+	bundle, err := h.bundleService.ExportRules(c.Request.Context(), ruleIDs)
+	if err != nil {
+		h.writeRuleBundleError(c, "Failed to export rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// ImportRuleBundle verifies a signed rule bundle and diffs it against the
+// existing rules. Unless dry_run is set, the selected rules are applied.
+func (h *Handlers) ImportRuleBundle(c *gin.Context) {
+	var req struct {
+		Bundle domain.RuleBundle `json:"bundle" binding:"required"`
+		Rules  []string          `json:"rules"`
+		DryRun bool              `json:"dry_run"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.bundleService.ImportRules(c.Request.Context(), &req.Bundle, req.Rules, c.GetHeader("X-User-ID"), req.DryRun)
+	if err != nil {
+		h.writeRuleBundleError(c, "Failed to import rules", err)
+		return
+	}
+
+	status := http.StatusOK
+	if plan.AppliedAt != nil {
+		status = http.StatusCreated
+	}
+	c.JSON(status, plan)
+}
+
+// writeRuleBundleError maps rule bundle errors to HTTP responses
+func (h *Handlers) writeRuleBundleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRuleBundle), errors.Is(err, domain.ErrRuleBundleSchema),
+		errors.Is(err, domain.ErrInvalidRuleExpression):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrRuleBundleSignature), errors.Is(err, domain.ErrRuleBundleUntrusted):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrRuleBundleSigningKey):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
 // GetMonitoringRules retrieves all monitoring rules
 func (h *Handlers) GetMonitoringRules(c *gin.Context) {
 	ruleType := c.Query("type")
@@ -248,6 +511,520 @@ func (h *Handlers) ImportSanctions(c *gin.Context) {
 	})
 }
 
+// GetSanctionsScreenStats reports how sanctions checks were answered: from the
+// in-memory filter, the cache of possible hits or the database
+func (h *Handlers) GetSanctionsScreenStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"stats": h.sanctionsScreen.Stats(),
+	})
+}
+
+// contractRequest is the body for creating or replacing a registry entry
+type contractRequest struct {
+	Chain      string   `json:"chain" binding:"required"`
+	Address    string   `json:"address" binding:"required"`
+	Name       string   `json:"name"`
+	Protocol   string   `json:"protocol"`
+	Category   string   `json:"category" binding:"required"`
+	RiskWeight *float64 `json:"risk_weight"`
+	Tags       []string `json:"tags"`
+	Notes      string   `json:"notes"`
+	Active     *bool    `json:"active"`
+}
+
+// toContract builds a registry entry, defaulting the risk weight from the category
+func (req *contractRequest) toContract() *domain.RegisteredContract {
+	contract := &domain.RegisteredContract{
+		Chain:    req.Chain,
+		Address:  req.Address,
+		Name:     req.Name,
+		Protocol: req.Protocol,
+		Category: domain.ContractCategory(req.Category),
+		Tags:     req.Tags,
+		Notes:    req.Notes,
+		Active:   req.Active == nil || *req.Active,
+	}
+	contract.Normalize()
+	if req.RiskWeight != nil {
+		contract.RiskWeight = *req.RiskWeight
+	} else {
+		contract.RiskWeight = contract.Category.DefaultRiskWeight()
+	}
+	return contract
+}
+
+// ListContracts lists smart contract registry entries
+func (h *Handlers) ListContracts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	includeInactive, _ := strconv.ParseBool(c.Query("include_inactive"))
+
+	filter := domain.ContractFilter{
+		Chain:           c.Query("chain"),
+		Category:        domain.ContractCategory(c.Query("category")),
+		Source:          c.Query("source"),
+		Query:           c.Query("q"),
+		IncludeInactive: includeInactive,
+		Limit:           limit,
+		Offset:          offset,
+	}
+
+	contracts, total, err := h.contractService.ListContracts(c.Request.Context(), filter)
+	if err != nil {
+		h.writeContractError(c, "Failed to list contracts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"contracts": contracts,
+		"total":     total,
+		"limit":     filter.Limit,
+		"offset":    filter.Offset,
+	})
+}
+
+// CreateContract registers a smart contract
+func (h *Handlers) CreateContract(c *gin.Context) {
+	var req contractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contract := req.toContract()
+	if err := h.contractService.RegisterContract(c.Request.Context(), contract, c.GetHeader("X-User-ID")); err != nil {
+		h.writeContractError(c, "Failed to register contract", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contract)
+}
+
+// GetContract retrieves a smart contract registry entry
+func (h *Handlers) GetContract(c *gin.Context) {
+	contract, err := h.contractService.GetContract(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeContractError(c, "Failed to get contract", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contract)
+}
+
+// UpdateContract replaces a smart contract registry entry
+func (h *Handlers) UpdateContract(c *gin.Context) {
+	var req contractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contract := req.toContract()
+	contract.ID = c.Param("id")
+	if err := h.contractService.UpdateContract(c.Request.Context(), contract, c.GetHeader("X-User-ID")); err != nil {
+		h.writeContractError(c, "Failed to update contract", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contract)
+}
+
+// DeactivateContract stops a registry entry contributing to risk scores
+func (h *Handlers) DeactivateContract(c *gin.Context) {
+	contract, err := h.contractService.DeactivateContract(c.Request.Context(), c.Param("id"), c.GetHeader("X-User-ID"))
+	if err != nil {
+		h.writeContractError(c, "Failed to deactivate contract", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contract)
+}
+
+// LookupContract returns the registry entry for an address, if any
+func (h *Handlers) LookupContract(c *gin.Context) {
+	chain, address := c.Query("chain"), c.Query("address")
+	if chain == "" || address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "chain and address are required"})
+		return
+	}
+
+	contract, err := h.contractService.LookupAddress(c.Request.Context(), chain, address)
+	if errors.Is(err, domain.ErrContractNotFound) {
+		c.JSON(http.StatusOK, gin.H{"chain": chain, "address": address, "registered": false})
+		return
+	}
+	if err != nil {
+		h.writeContractError(c, "Failed to look up contract", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"chain": chain, "address": address, "registered": true, "contract": contract})
+}
+
+// SyncContractFeeds pulls the curated contract feeds immediately
+func (h *Handlers) SyncContractFeeds(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"results": h.contractService.SyncFeeds(c.Request.Context())})
+}
+
+// writeContractError maps contract registry errors to HTTP responses
+func (h *Handlers) writeContractError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidContract):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrContractNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrContractExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// subscriptionRequest is the body for creating or replacing a pattern subscription
+type subscriptionRequest struct {
+	Name        string                      `json:"name" binding:"required"`
+	Description string                      `json:"description"`
+	Filter      domain.SubscriptionFilter   `json:"filter"`
+	Channel     *domain.NotificationChannel `json:"channel"`
+}
+
+// toSubscription builds a subscription from the request
+func (req *subscriptionRequest) toSubscription() *domain.PatternSubscription {
+	return &domain.PatternSubscription{
+		Name:        req.Name,
+		Description: req.Description,
+		Filter:      req.Filter,
+		Channel:     req.Channel,
+	}
+}
+
+// ListSubscriptions lists pattern subscriptions, optionally by owner and status
+func (h *Handlers) ListSubscriptions(c *gin.Context) {
+	filter := domain.SubscriptionListFilter{
+		Owner:  c.Query("owner"),
+		Status: domain.SubscriptionStatus(strings.ToUpper(c.Query("status"))),
+	}
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "100"))
+	filter.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	subscriptions, total, err := h.subscriptionService.ListSubscriptions(c.Request.Context(), filter)
+	if err != nil {
+		h.writeSubscriptionError(c, "Failed to list subscriptions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscriptions": subscriptions, "count": len(subscriptions), "total": total})
+}
+
+// CreateSubscription saves a pattern subscription owned by the caller
+func (h *Handlers) CreateSubscription(c *gin.Context) {
+	var req subscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription := req.toSubscription()
+	if err := h.subscriptionService.CreateSubscription(c.Request.Context(), subscription, c.GetHeader("X-User-ID")); err != nil {
+		h.writeSubscriptionError(c, "Failed to create subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"subscription": subscription})
+}
+
+// GetSubscription retrieves a pattern subscription
+func (h *Handlers) GetSubscription(c *gin.Context) {
+	subscription, err := h.subscriptionService.GetSubscription(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeSubscriptionError(c, "Failed to get subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscription": subscription})
+}
+
+// UpdateSubscription replaces a pattern subscription's definition
+func (h *Handlers) UpdateSubscription(c *gin.Context) {
+	var req subscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription := req.toSubscription()
+	subscription.ID = c.Param("id")
+	if err := h.subscriptionService.UpdateSubscription(c.Request.Context(), subscription, c.GetHeader("X-User-ID")); err != nil {
+		h.writeSubscriptionError(c, "Failed to update subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscription": subscription})
+}
+
+// PauseSubscription stops evaluating a pattern subscription
+func (h *Handlers) PauseSubscription(c *gin.Context) {
+	subscription, err := h.subscriptionService.PauseSubscription(c.Request.Context(), c.Param("id"), c.GetHeader("X-User-ID"))
+	if err != nil {
+		h.writeSubscriptionError(c, "Failed to pause subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscription": subscription})
+}
+
+// ResumeSubscription evaluates a paused pattern subscription again
+func (h *Handlers) ResumeSubscription(c *gin.Context) {
+	subscription, err := h.subscriptionService.ResumeSubscription(c.Request.Context(), c.Param("id"), c.GetHeader("X-User-ID"))
+	if err != nil {
+		h.writeSubscriptionError(c, "Failed to resume subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscription": subscription})
+}
+
+// ArchiveSubscription retires a pattern subscription, keeping its hit log
+func (h *Handlers) ArchiveSubscription(c *gin.Context) {
+	subscription, err := h.subscriptionService.ArchiveSubscription(c.Request.Context(), c.Param("id"), c.GetHeader("X-User-ID"))
+	if err != nil {
+		h.writeSubscriptionError(c, "Failed to archive subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"subscription": subscription})
+}
+
+// ListSubscriptionHits lists a pattern subscription's hit log, most recent first
+func (h *Handlers) ListSubscriptionHits(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	hits, total, err := h.subscriptionService.ListHits(c.Request.Context(), c.Param("id"), limit, offset)
+	if err != nil {
+		h.writeSubscriptionError(c, "Failed to list subscription hits", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"hits": hits, "count": len(hits), "total": total})
+}
+
+// writeSubscriptionError maps pattern subscription errors to HTTP responses
+func (h *Handlers) writeSubscriptionError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSubscription):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrSubscriptionArchived):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// notificationPreferenceRequest is the body for replacing the caller's
+// notification preference
+type notificationPreferenceRequest struct {
+	Channels        []domain.NotificationChannel `json:"channels"`
+	Severities      []domain.RuleSeverity        `json:"severities"`
+	DigestFrequency domain.DigestFrequency       `json:"digest_frequency"`
+}
+
+// GetNotificationPreference retrieves the caller's notification preference
+func (h *Handlers) GetNotificationPreference(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "X-User-ID header is required"})
+		return
+	}
+
+	preference, err := h.preferenceService.GetPreference(c.Request.Context(), userID)
+	if err != nil {
+		h.writePreferenceError(c, "Failed to get notification preference", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preference": preference})
+}
+
+// UpdateNotificationPreference replaces the caller's channels, severities and
+// digest frequency
+func (h *Handlers) UpdateNotificationPreference(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "X-User-ID header is required"})
+		return
+	}
+	var req notificationPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preference := &domain.NotificationPreference{
+		UserID:          userID,
+		Channels:        req.Channels,
+		Severities:      req.Severities,
+		DigestFrequency: req.DigestFrequency,
+	}
+	if err := h.preferenceService.UpdatePreference(c.Request.Context(), preference, userID); err != nil {
+		h.writePreferenceError(c, "Failed to update notification preference", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preference": preference})
+}
+
+// PreviewNotificationDigest shows the digest the caller would receive now
+// from the hits queued so far
+func (h *Handlers) PreviewNotificationDigest(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "X-User-ID header is required"})
+		return
+	}
+
+	digest, err := h.preferenceService.PreviewDigest(c.Request.Context(), userID)
+	if err != nil {
+		h.writePreferenceError(c, "Failed to preview notification digest", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"digest": digest})
+}
+
+// writePreferenceError maps notification preference errors to HTTP responses
+func (h *Handlers) writePreferenceError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPreference):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// ListFXRates lists the stored reference rates for a pair between two days,
+// by default the last 30
+func (h *Handlers) ListFXRates(c *gin.Context) {
+	base, quote := c.Query("base"), c.Query("quote")
+	if base == "" || quote == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "base and quote are required"})
+		return
+	}
+
+	to, err := parseRateTime(c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to: " + err.Error()})
+		return
+	}
+	from, err := parseRateTime(c.Query("from"), to.AddDate(0, 0, -30))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from: " + err.Error()})
+		return
+	}
+
+	rates, err := h.fxService.ListRates(c.Request.Context(), base, quote, from, to)
+	if err != nil {
+		h.writeFXError(c, "Failed to list exchange rates", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rates": rates})
+}
+
+// ConvertAmount converts an amount between currencies at the rate of a day,
+// by default today
+func (h *Handlers) ConvertAmount(c *gin.Context) {
+	amount, err := decimal.NewFromString(c.Query("amount"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid amount"})
+		return
+	}
+	from, to := c.Query("from"), c.Query("to")
+	at, err := parseRateTime(c.Query("at"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid at: " + err.Error()})
+		return
+	}
+
+	rate, err := h.fxService.Rate(c.Request.Context(), from, to, at)
+	if err != nil {
+		h.writeFXError(c, "Failed to convert amount", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"amount":    amount,
+		"from":      domain.NormalizeCurrency(from),
+		"to":        domain.NormalizeCurrency(to),
+		"rate":      rate,
+		"rate_date": domain.RateDay(at),
+		"converted": amount.Mul(rate),
+	})
+}
+
+// SyncFXRates pulls today's rates from the configured providers immediately
+func (h *Handlers) SyncFXRates(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"results": h.fxService.SyncProviders(c.Request.Context(), time.Now())})
+}
+
+// parseRateTime parses an RFC 3339 time or a YYYY-MM-DD day, returning
+// fallback for an empty value
+func parseRateTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// writeFXError maps exchange rate errors to HTTP responses
+func (h *Handlers) writeFXError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrRateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// SearchArchive searches transactions across the transactions table and the
+// archives in cold storage
+func (h *Handlers) SearchArchive(c *gin.Context) {
+	var filter domain.TransactionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.archivalService.Search(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrArchiveRangeTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to search archive", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search archive"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RunArchival archives expired transactions immediately
+func (h *Handlers) RunArchival(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"result": h.archivalService.Archive(c.Request.Context(), time.Now())})
+}
+
 // GetMonitoringStats retrieves monitoring statistics
 func (h *Handlers) GetMonitoringStats(c *gin.Context) {
 	stats := domain.MonitoringStats{
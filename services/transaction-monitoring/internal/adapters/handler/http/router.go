@@ -68,6 +68,16 @@ func (r *Router) Setup() *gin.Engine {
 		{
 			rules.GET("", r.handlers.GetMonitoringRules)
 			rules.POST("", r.handlers.CreateMonitoringRule)
+			rules.GET("/export", r.handlers.ExportRuleBundle)
+			rules.POST("/import", r.handlers.ImportRuleBundle)
+			rules.PUT("/:id", r.handlers.UpdateMonitoringRule)
+			rules.POST("/:id/backtest", r.handlers.BacktestMonitoringRule)
+			rules.POST("/:id/rollback", r.handlers.RollbackMonitoringRule)
+			rules.GET("/:id/versions", r.handlers.GetRuleVersions)
+			rules.GET("/:id/versions/diff", r.handlers.DiffRuleVersions)
+			rules.POST("/:id/versions/:version/submit", r.handlers.SubmitRuleVersion)
+			rules.POST("/:id/versions/:version/approve", r.handlers.ApproveRuleVersion)
+			rules.POST("/:id/versions/:version/reject", r.handlers.RejectRuleVersion)
 		}
 
 		// Sanctions list
@@ -75,6 +85,55 @@ func (r *Router) Setup() *gin.Engine {
 		{
 			sanctions.POST("/check", r.handlers.CheckSanctions)
 			sanctions.POST("/import", r.handlers.ImportSanctions)
+			sanctions.GET("/screen/stats", r.handlers.GetSanctionsScreenStats)
+		}
+
+		// Smart contract registry
+		contracts := v1.Group("/contracts")
+		{
+			contracts.GET("", r.handlers.ListContracts)
+			contracts.POST("", r.handlers.CreateContract)
+			contracts.GET("/lookup", r.handlers.LookupContract)
+			contracts.POST("/sync", r.handlers.SyncContractFeeds)
+			contracts.GET("/:id", r.handlers.GetContract)
+			contracts.PUT("/:id", r.handlers.UpdateContract)
+			contracts.DELETE("/:id", r.handlers.DeactivateContract)
+		}
+
+		// Exchange rates
+		fx := v1.Group("/fx")
+		{
+			fx.GET("/rates", r.handlers.ListFXRates)
+			fx.GET("/convert", r.handlers.ConvertAmount)
+			fx.POST("/sync", r.handlers.SyncFXRates)
+		}
+
+		// Transaction archive
+		archive := v1.Group("/archive")
+		{
+			archive.GET("/search", r.handlers.SearchArchive)
+			archive.POST("/run", r.handlers.RunArchival)
+		}
+
+		// Pattern subscriptions
+		subscriptions := v1.Group("/subscriptions")
+		{
+			subscriptions.GET("", r.handlers.ListSubscriptions)
+			subscriptions.POST("", r.handlers.CreateSubscription)
+			subscriptions.GET("/:id", r.handlers.GetSubscription)
+			subscriptions.PUT("/:id", r.handlers.UpdateSubscription)
+			subscriptions.DELETE("/:id", r.handlers.ArchiveSubscription)
+			subscriptions.POST("/:id/pause", r.handlers.PauseSubscription)
+			subscriptions.POST("/:id/resume", r.handlers.ResumeSubscription)
+			subscriptions.GET("/:id/hits", r.handlers.ListSubscriptionHits)
+		}
+
+		// Notification preferences and digests of the calling user
+		notifications := v1.Group("/notifications")
+		{
+			notifications.GET("/preferences", r.handlers.GetNotificationPreference)
+			notifications.PUT("/preferences", r.handlers.UpdateNotificationPreference)
+			notifications.GET("/digest/preview", r.handlers.PreviewNotificationDigest)
 		}
 
 		// Statistics
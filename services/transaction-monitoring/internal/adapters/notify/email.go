@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+)
+
+// EmailConfig configures delivery of subscription hits and digests by email over SMTP.
+// Without a username the server is used without authentication.
+type EmailConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+// EmailNotifier mails subscription hits and digests to a channel's address
+type EmailNotifier struct {
+	config EmailConfig
+}
+
+// NewEmailNotifier creates an email notifier for the given configuration
+func NewEmailNotifier(config EmailConfig) *EmailNotifier {
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	return &EmailNotifier{config: config}
+}
+
+// Notify sends a plain-text summary of the hit
+func (n *EmailNotifier) Notify(ctx context.Context, channel *domain.NotificationChannel, subscription *domain.PatternSubscription, hit *domain.SubscriptionHit) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	to := channel.Target
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&body, "To: %s\r\n", to)
+	fmt.Fprintf(&body, "Subject: [CSIC] Subscription %q matched a %s transaction\r\n", subscription.Name, hit.Chain)
+	fmt.Fprintf(&body, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&body, "Subscription: %s (%s)\r\n", subscription.Name, subscription.ID)
+	fmt.Fprintf(&body, "Transaction:  %s on %s\r\n", hit.TxHash, hit.Chain)
+	if hit.MatchedAddress != "" {
+		fmt.Fprintf(&body, "Address:      %s (%s)\r\n", hit.MatchedAddress, hit.Direction)
+	}
+	fmt.Fprintf(&body, "Amount:       %s (USD %s)\r\n", hit.Amount, hit.AmountUSD.StringFixed(2))
+	fmt.Fprintf(&body, "Risk score:   %d (%s)\r\n", hit.RiskScore, hit.Severity)
+	fmt.Fprintf(&body, "Time:         %s\r\n", hit.TxTimestamp.UTC().Format(time.RFC3339))
+
+	return n.send(to, body.String())
+}
+
+// maxDigestLines bounds the hits listed in one digest email; the counts
+// always cover every hit
+const maxDigestLines = 200
+
+// NotifyDigest sends a plain-text summary of the digest: counts by severity
+// followed by one line per hit, most severe first
+func (n *EmailNotifier) NotifyDigest(ctx context.Context, channel *domain.NotificationChannel, digest *domain.NotificationDigest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	to := channel.Target
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&body, "To: %s\r\n", to)
+	fmt.Fprintf(&body, "Subject: [CSIC] Notification digest (%s): %d subscription hits\r\n",
+		digest.Frequency, len(digest.Entries))
+	fmt.Fprintf(&body, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&body, "Period: %s to %s\r\n\r\n",
+		digest.PeriodStart.UTC().Format(time.RFC3339), digest.PeriodEnd.UTC().Format(time.RFC3339))
+	for _, severity := range digestSeverities {
+		if count := digest.Counts[severity]; count > 0 {
+			fmt.Fprintf(&body, "%-8s %d\r\n", severity, count)
+		}
+	}
+	body.WriteString("\r\n")
+
+	entries := make([]*domain.DigestEntry, len(digest.Entries))
+	copy(entries, digest.Entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return severityRank(entries[i].Hit.Severity) < severityRank(entries[j].Hit.Severity)
+	})
+	for i, entry := range entries {
+		if i == maxDigestLines {
+			fmt.Fprintf(&body, "... and %d more\r\n", len(entries)-maxDigestLines)
+			break
+		}
+		hit := entry.Hit
+		fmt.Fprintf(&body, "[%s] %s: %s on %s, USD %s, risk %d\r\n",
+			hit.Severity, entry.SubscriptionName, hit.TxHash, hit.Chain, hit.AmountUSD.StringFixed(2), hit.RiskScore)
+	}
+
+	return n.send(to, body.String())
+}
+
+// digestSeverities orders severities from most to least severe
+var digestSeverities = []domain.RuleSeverity{
+	domain.RuleSeverityCritical, domain.RuleSeverityAlert, domain.RuleSeverityWarning, domain.RuleSeverityInfo,
+}
+
+func severityRank(severity domain.RuleSeverity) int {
+	for i, s := range digestSeverities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(digestSeverities)
+}
+
+// send delivers a message over SMTP
+func (n *EmailNotifier) send(to, message string) error {
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	if err := smtp.SendMail(addr, auth, n.config.From, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// Ensure EmailNotifier implements the SubscriptionNotifier and DigestNotifier interfaces
+var (
+	_ ports.SubscriptionNotifier = (*EmailNotifier)(nil)
+	_ ports.DigestNotifier       = (*EmailNotifier)(nil)
+)
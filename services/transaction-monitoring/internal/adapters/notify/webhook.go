@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+)
+
+// WebhookConfig configures delivery of subscription hits and digests to webhook URLs. With
+// a secret, each request carries the hex HMAC-SHA256 of its body in the
+// X-CSIC-Signature header.
+type WebhookConfig struct {
+	Secret  string        `mapstructure:"secret"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// hitEvent is the JSON body posted for a subscription hit
+type hitEvent struct {
+	Event        string                  `json:"event"`
+	Subscription subscriptionRef         `json:"subscription"`
+	Hit          *domain.SubscriptionHit `json:"hit"`
+}
+
+// subscriptionRef identifies the subscription a hit belongs to
+type subscriptionRef struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+}
+
+// digestEvent is the JSON body posted for a notification digest
+type digestEvent struct {
+	Event  string                     `json:"event"`
+	Digest *domain.NotificationDigest `json:"digest"`
+}
+
+// WebhookNotifier posts subscription hits and digests as JSON to a channel's URL
+type WebhookNotifier struct {
+	config WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a webhook notifier for the given configuration
+func NewWebhookNotifier(config WebhookConfig) *WebhookNotifier {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &WebhookNotifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Notify posts the hit; any status other than 2xx is a failed delivery
+func (n *WebhookNotifier) Notify(ctx context.Context, channel *domain.NotificationChannel, subscription *domain.PatternSubscription, hit *domain.SubscriptionHit) error {
+	body, err := json.Marshal(hitEvent{
+		Event:        "subscription.hit",
+		Subscription: subscriptionRef{ID: subscription.ID, Name: subscription.Name, Owner: subscription.Owner},
+		Hit:          hit,
+	})
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, channel.Target, body)
+}
+
+// NotifyDigest posts the digest; any status other than 2xx is a failed delivery
+func (n *WebhookNotifier) NotifyDigest(ctx context.Context, channel *domain.NotificationChannel, digest *domain.NotificationDigest) error {
+	body, err := json.Marshal(digestEvent{Event: "notification.digest", Digest: digest})
+	if err != nil {
+		return err
+	}
+	return n.post(ctx, channel.Target, body)
+}
+
+// post sends a signed JSON body to the target URL
+func (n *WebhookNotifier) post(ctx context.Context, target string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.config.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.config.Secret))
+		mac.Write(body)
+		req.Header.Set("X-CSIC-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Ensure WebhookNotifier implements the SubscriptionNotifier and DigestNotifier interfaces
+var (
+	_ ports.SubscriptionNotifier = (*WebhookNotifier)(nil)
+	_ ports.DigestNotifier       = (*WebhookNotifier)(nil)
+)
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// ArchiveRepository implements ports.TransactionArchiveRepository
+type ArchiveRepository struct {
+	conn   *Connection
+	logger *zap.Logger
+}
+
+// NewArchiveRepository creates a new transaction archive repository
+func NewArchiveRepository(conn *Connection, logger *zap.Logger) *ArchiveRepository {
+	return &ArchiveRepository{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// ListArchivable returns up to limit of the oldest transactions dated before
+// the cutoff
+func (r *ArchiveRepository) ListArchivable(ctx context.Context, before time.Time, limit int) ([]*domain.Transaction, error) {
+	query := `
+		SELECT * FROM transactions
+		WHERE tx_timestamp < $1
+		ORDER BY tx_timestamp, id
+		LIMIT $2
+	`
+
+	rows, err := r.conn.pool.Query(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archivable transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
+
+// CommitArchive catalogues an archive and deletes the transactions it holds in
+// a single transaction. The archive's CreatedAt must be set before the
+// transactions were read: if any of them changed or disappeared since, nothing
+// is committed, so a stale copy never replaces a live row.
+func (r *ArchiveRepository) CommitArchive(ctx context.Context, archive *domain.TransactionArchive, txs []*domain.Transaction) error {
+	tx, err := r.conn.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO transaction_archives (
+			object_key, row_count, size_bytes, checksum, first_tx_at, last_tx_at, chains, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`,
+		archive.ObjectKey, archive.RowCount, archive.SizeBytes, archive.Checksum,
+		archive.FirstTxAt, archive.LastTxAt, archive.Chains, archive.CreatedAt,
+	).Scan(&archive.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record archive %s: %w", archive.ObjectKey, err)
+	}
+
+	ids := make([]string, len(txs))
+	for i, t := range txs {
+		ids[i] = t.ID
+	}
+
+	// The timestamp bounds let the planner prune partitions outside the archive
+	result, err := tx.Exec(ctx, `
+		DELETE FROM transactions
+		WHERE id = ANY($1::uuid[])
+			AND tx_timestamp BETWEEN $2 AND $3
+			AND updated_at <= $4
+	`, ids, archive.FirstTxAt, archive.LastTxAt, archive.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete archived transactions: %w", err)
+	}
+	if deleted := int(result.RowsAffected()); deleted != len(txs) {
+		return fmt.Errorf("archive %s is stale: %d of %d transactions changed since they were read",
+			archive.ObjectKey, len(txs)-deleted, len(txs))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit archive: %w", err)
+	}
+
+	return nil
+}
+
+// FindArchives returns the archives holding transactions dated between start
+// and end, oldest first
+func (r *ArchiveRepository) FindArchives(ctx context.Context, start, end time.Time) ([]*domain.TransactionArchive, error) {
+	query := `
+		SELECT id, object_key, row_count, size_bytes, checksum, first_tx_at, last_tx_at, chains, created_at
+		FROM transaction_archives
+		WHERE last_tx_at >= $1 AND first_tx_at <= $2
+		ORDER BY first_tx_at
+	`
+
+	rows, err := r.conn.pool.Query(ctx, query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archives: %w", err)
+	}
+	defer rows.Close()
+
+	archives := []*domain.TransactionArchive{}
+	for rows.Next() {
+		var a domain.TransactionArchive
+		err := rows.Scan(
+			&a.ID, &a.ObjectKey, &a.RowCount, &a.SizeBytes, &a.Checksum,
+			&a.FirstTxAt, &a.LastTxAt, &a.Chains, &a.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archive: %w", err)
+		}
+		archives = append(archives, &a)
+	}
+	return archives, rows.Err()
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// registeredContractColumns lists registered_contracts columns in scan order
+const registeredContractColumns = `id, chain, address, name, COALESCE(protocol, ''), category, risk_weight,
+	tags, source, active, COALESCE(notes, ''), COALESCE(created_by, ''), COALESCE(updated_by, ''),
+	created_at, updated_at`
+
+// ContractRegistryRepository implements ports.ContractRegistryRepository
+type ContractRegistryRepository struct {
+	conn   *Connection
+	logger *zap.Logger
+}
+
+// NewContractRegistryRepository creates a new contract registry repository
+func NewContractRegistryRepository(conn *Connection, logger *zap.Logger) *ContractRegistryRepository {
+	return &ContractRegistryRepository{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// CreateContract inserts a registry entry, failing if the address is already registered on the chain
+func (r *ContractRegistryRepository) CreateContract(ctx context.Context, contract *domain.RegisteredContract) error {
+	query := `
+		INSERT INTO registered_contracts (
+			chain, address, name, protocol, category, risk_weight, tags, source, active,
+			notes, created_by, updated_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), $13, $14)
+		ON CONFLICT (chain, address) DO NOTHING
+		RETURNING id
+	`
+
+	err := r.conn.pool.QueryRow(ctx, query,
+		contract.Chain, contract.Address, contract.Name, contract.Protocol, contract.Category,
+		contract.RiskWeight, contractTags(contract.Tags), contract.Source, contract.Active,
+		contract.Notes, contract.CreatedBy, contract.UpdatedBy, contract.CreatedAt, contract.UpdatedAt,
+	).Scan(&contract.ID)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrContractExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create registered contract: %w", err)
+	}
+
+	return nil
+}
+
+// GetContract retrieves a registry entry by ID
+func (r *ContractRegistryRepository) GetContract(ctx context.Context, id string) (*domain.RegisteredContract, error) {
+	query := `SELECT ` + registeredContractColumns + ` FROM registered_contracts WHERE id = $1`
+
+	contract, err := scanRegisteredContract(r.conn.pool.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrContractNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registered contract: %w", err)
+	}
+
+	return contract, nil
+}
+
+// UpdateContract stores an entry's details
+func (r *ContractRegistryRepository) UpdateContract(ctx context.Context, contract *domain.RegisteredContract) error {
+	query := `
+		UPDATE registered_contracts SET
+			chain = $1, address = $2, name = $3, protocol = NULLIF($4, ''), category = $5,
+			risk_weight = $6, tags = $7, source = $8, active = $9, notes = NULLIF($10, ''),
+			updated_by = NULLIF($11, ''), updated_at = $12
+		WHERE id = $13
+	`
+
+	tag, err := r.conn.pool.Exec(ctx, query,
+		contract.Chain, contract.Address, contract.Name, contract.Protocol, contract.Category,
+		contract.RiskWeight, contractTags(contract.Tags), contract.Source, contract.Active,
+		contract.Notes, contract.UpdatedBy, contract.UpdatedAt, contract.ID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrContractExists
+		}
+		return fmt.Errorf("failed to update registered contract: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrContractNotFound
+	}
+
+	return nil
+}
+
+// ListContracts retrieves registry entries matching the filter
+func (r *ContractRegistryRepository) ListContracts(ctx context.Context, filter domain.ContractFilter) ([]*domain.RegisteredContract, int64, error) {
+	where, args := contractFilterClause(filter)
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM registered_contracts WHERE ` + where
+	if err := r.conn.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count registered contracts: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM registered_contracts
+		WHERE %s
+		ORDER BY risk_weight DESC, chain, address
+		LIMIT $%d OFFSET $%d
+	`, registeredContractColumns, where, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.conn.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query registered contracts: %w", err)
+	}
+	defer rows.Close()
+
+	contracts, err := scanRegisteredContracts(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return contracts, total, nil
+}
+
+// FindContracts retrieves the active entries for any of the addresses on a chain
+func (r *ContractRegistryRepository) FindContracts(ctx context.Context, chain string, addresses []string) ([]*domain.RegisteredContract, error) {
+	if len(addresses) == 0 {
+		return []*domain.RegisteredContract{}, nil
+	}
+
+	query := `SELECT ` + registeredContractColumns + ` FROM registered_contracts
+		WHERE chain = $1 AND address = ANY($2) AND active = true`
+
+	rows, err := r.conn.pool.Query(ctx, query, chain, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registered contracts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRegisteredContracts(rows)
+}
+
+// SyncFeedContracts upserts a feed's entries and deactivates the feed's entries
+// it no longer lists, in a single transaction. Entries owned by another source,
+// including manual ones, are never modified.
+func (r *ContractRegistryRepository) SyncFeedContracts(ctx context.Context, source string, contracts []*domain.RegisteredContract) (int, int, error) {
+	tx, err := r.conn.pool.Begin(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	upserted := 0
+	keys := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		keys = append(keys, contract.Chain+":"+contract.Address)
+
+		result, err := tx.Exec(ctx, `
+			INSERT INTO registered_contracts (
+				chain, address, name, protocol, category, risk_weight, tags, source, active,
+				notes, created_at, updated_at
+			)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, true, NULLIF($9, ''), $10, $10)
+			ON CONFLICT (chain, address) DO UPDATE SET
+				name = EXCLUDED.name, protocol = EXCLUDED.protocol, category = EXCLUDED.category,
+				risk_weight = EXCLUDED.risk_weight, tags = EXCLUDED.tags, active = true,
+				notes = EXCLUDED.notes, updated_at = EXCLUDED.updated_at
+			WHERE registered_contracts.source = EXCLUDED.source
+		`,
+			contract.Chain, contract.Address, contract.Name, contract.Protocol, contract.Category,
+			contract.RiskWeight, contractTags(contract.Tags), source, contract.Notes, contract.UpdatedAt,
+		)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to upsert registered contract %s: %w", contract.Address, err)
+		}
+		upserted += int(result.RowsAffected())
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE registered_contracts SET active = false, updated_at = NOW()
+		WHERE source = $1 AND active = true AND NOT (chain || ':' || address = ANY($2))
+	`, source, keys)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to deactivate delisted contracts: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit contract sync: %w", err)
+	}
+
+	return upserted, int(result.RowsAffected()), nil
+}
+
+// contractFilterClause builds the WHERE clause for a registry filter
+func contractFilterClause(filter domain.ContractFilter) (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "active = true")
+	}
+	if filter.Chain != "" {
+		add("chain = $%d", strings.ToLower(filter.Chain))
+	}
+	if filter.Category != "" {
+		add("category = $%d", filter.Category)
+	}
+	if filter.Source != "" {
+		add("source = $%d", filter.Source)
+	}
+	if filter.Query != "" {
+		add("(address ILIKE $%[1]d OR name ILIKE $%[1]d OR protocol ILIKE $%[1]d)", "%"+filter.Query+"%")
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// contractTags keeps tags non-null so the column never mixes NULL and '{}'
+func contractTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func scanRegisteredContracts(rows pgx.Rows) ([]*domain.RegisteredContract, error) {
+	contracts := []*domain.RegisteredContract{}
+	for rows.Next() {
+		contract, err := scanRegisteredContract(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan registered contract: %w", err)
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts, rows.Err()
+}
+
+func scanRegisteredContract(row pgx.Row) (*domain.RegisteredContract, error) {
+	var c domain.RegisteredContract
+	err := row.Scan(
+		&c.ID, &c.Chain, &c.Address, &c.Name, &c.Protocol, &c.Category, &c.RiskWeight,
+		&c.Tags, &c.Source, &c.Active, &c.Notes, &c.CreatedBy, &c.UpdatedBy,
+		&c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// fxRateColumns lists fx_rates columns in scan order
+const fxRateColumns = `base, quote, rate, rate_date, source, fetched_at`
+
+// FXRateRepository implements ports.FXRateRepository
+type FXRateRepository struct {
+	conn   *Connection
+	logger *zap.Logger
+}
+
+// NewFXRateRepository creates a new exchange rate repository
+func NewFXRateRepository(conn *Connection, logger *zap.Logger) *FXRateRepository {
+	return &FXRateRepository{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// UpsertRates stores rates in a single transaction. A provider's rate for a
+// pair and day replaces the one it published earlier.
+func (r *FXRateRepository) UpsertRates(ctx context.Context, rates []*domain.FXRate) (int, error) {
+	tx, err := r.conn.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	stored := 0
+	for _, rate := range rates {
+		result, err := tx.Exec(ctx, `
+			INSERT INTO fx_rates (base, quote, rate, rate_date, source, fetched_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (base, quote, rate_date, source) DO UPDATE SET
+				rate = EXCLUDED.rate, fetched_at = EXCLUDED.fetched_at
+		`, rate.Base, rate.Quote, rate.Rate, rate.RateDate, rate.Source, rate.FetchedAt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert rate %s/%s: %w", rate.Base, rate.Quote, err)
+		}
+		stored += int(result.RowsAffected())
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit rates: %w", err)
+	}
+
+	return stored, nil
+}
+
+// FindRate returns the latest rate for a pair dated between since and day,
+// preferring the most recently fetched when several providers publish the pair
+func (r *FXRateRepository) FindRate(ctx context.Context, base, quote string, since, day time.Time) (*domain.FXRate, error) {
+	query := `
+		SELECT ` + fxRateColumns + ` FROM fx_rates
+		WHERE base = $1 AND quote = $2 AND rate_date BETWEEN $3 AND $4
+		ORDER BY rate_date DESC, fetched_at DESC
+		LIMIT 1
+	`
+
+	rate, err := scanFXRate(r.conn.pool.QueryRow(ctx, query, base, quote, since, day))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrRateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// ListRates returns every stored rate for a pair between two days, newest first
+func (r *FXRateRepository) ListRates(ctx context.Context, base, quote string, from, to time.Time) ([]*domain.FXRate, error) {
+	query := `
+		SELECT ` + fxRateColumns + ` FROM fx_rates
+		WHERE base = $1 AND quote = $2 AND rate_date BETWEEN $3 AND $4
+		ORDER BY rate_date DESC, source
+	`
+
+	rows, err := r.conn.pool.Query(ctx, query, base, quote, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []*domain.FXRate{}
+	for rows.Next() {
+		rate, err := scanFXRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+func scanFXRate(row pgx.Row) (*domain.FXRate, error) {
+	var rate domain.FXRate
+	err := row.Scan(&rate.Base, &rate.Quote, &rate.Rate, &rate.RateDate, &rate.Source, &rate.FetchedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
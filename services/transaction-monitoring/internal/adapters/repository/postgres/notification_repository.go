@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// notificationPreferenceColumns lists notification_preferences columns in scan order
+const notificationPreferenceColumns = `user_id, channels, severities, digest_frequency, last_digest_at,
+	COALESCE(updated_by, ''), updated_at`
+
+// NotificationRepository implements ports.NotificationPreferenceRepository
+type NotificationRepository struct {
+	conn   *Connection
+	logger *zap.Logger
+}
+
+// NewNotificationRepository creates a new notification preference repository
+func NewNotificationRepository(conn *Connection, logger *zap.Logger) *NotificationRepository {
+	return &NotificationRepository{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// GetPreference retrieves a user's notification preference
+func (r *NotificationRepository) GetPreference(ctx context.Context, userID string) (*domain.NotificationPreference, error) {
+	query := `SELECT ` + notificationPreferenceColumns + ` FROM notification_preferences WHERE user_id = $1`
+
+	preference, err := scanNotificationPreference(r.conn.pool.QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrPreferenceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+
+	return preference, nil
+}
+
+// SavePreference inserts or replaces a user's notification preference
+func (r *NotificationRepository) SavePreference(ctx context.Context, preference *domain.NotificationPreference) error {
+	channels, err := json.Marshal(preference.Channels)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification channels: %w", err)
+	}
+	severities := make([]string, len(preference.Severities))
+	for i, severity := range preference.Severities {
+		severities[i] = string(severity)
+	}
+
+	_, err = r.conn.pool.Exec(ctx, `
+		INSERT INTO notification_preferences (
+			user_id, channels, severities, digest_frequency, last_digest_at, updated_by, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+		ON CONFLICT (user_id) DO UPDATE SET
+			channels = EXCLUDED.channels, severities = EXCLUDED.severities,
+			digest_frequency = EXCLUDED.digest_frequency, updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+	`,
+		preference.UserID, channels, severities, preference.DigestFrequency, preference.LastDigestAt,
+		preference.UpdatedBy, preference.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preference: %w", err)
+	}
+
+	return nil
+}
+
+// ListPreferences retrieves every stored notification preference
+func (r *NotificationRepository) ListPreferences(ctx context.Context) ([]*domain.NotificationPreference, error) {
+	query := `SELECT ` + notificationPreferenceColumns + ` FROM notification_preferences`
+
+	rows, err := r.conn.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	preferences := []*domain.NotificationPreference{}
+	for rows.Next() {
+		preference, err := scanNotificationPreference(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification preference: %w", err)
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, rows.Err()
+}
+
+// QueueDigestHit holds a hit for the user's next digest. A hit already queued
+// is left as it is.
+func (r *NotificationRepository) QueueDigestHit(ctx context.Context, userID string, hit *domain.SubscriptionHit) error {
+	_, err := r.conn.pool.Exec(ctx, `
+		INSERT INTO notification_digest_queue (hit_id, user_id, queued_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (hit_id) DO NOTHING
+	`, hit.ID, userID, hit.MatchedAt)
+	if err != nil {
+		return fmt.Errorf("failed to queue hit for digest: %w", err)
+	}
+	return nil
+}
+
+// ListDigestQueues summarises the queued hits of every user who has any
+func (r *NotificationRepository) ListDigestQueues(ctx context.Context) ([]*domain.DigestQueue, error) {
+	rows, err := r.conn.pool.Query(ctx, `
+		SELECT user_id, MIN(queued_at), COUNT(*)
+		FROM notification_digest_queue
+		GROUP BY user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query digest queues: %w", err)
+	}
+	defer rows.Close()
+
+	queues := []*domain.DigestQueue{}
+	for rows.Next() {
+		var q domain.DigestQueue
+		if err := rows.Scan(&q.UserID, &q.Oldest, &q.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan digest queue: %w", err)
+		}
+		queues = append(queues, &q)
+	}
+	return queues, rows.Err()
+}
+
+// ListQueuedHits retrieves the user's hits queued before the given time with
+// the subscriptions they matched, oldest first
+func (r *NotificationRepository) ListQueuedHits(ctx context.Context, userID string, before time.Time) ([]*domain.DigestEntry, error) {
+	rows, err := r.conn.pool.Query(ctx, `
+		SELECT s.id, s.name, q.queued_at,
+			h.id, h.subscription_id, h.transaction_id, h.tx_hash, h.chain,
+			COALESCE(h.matched_address, ''), h.direction, h.amount, h.amount_usd, h.risk_score, h.tx_timestamp,
+			h.matched_at, h.severity, COALESCE(h.delivery, ''), h.notified_at, COALESCE(h.notify_error, '')
+		FROM notification_digest_queue q
+		JOIN subscription_hits h ON h.id = q.hit_id
+		JOIN pattern_subscriptions s ON s.id = h.subscription_id
+		WHERE q.user_id = $1 AND q.queued_at < $2
+		ORDER BY q.queued_at
+	`, userID, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queued hits: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.DigestEntry{}
+	for rows.Next() {
+		entry := &domain.DigestEntry{Hit: &domain.SubscriptionHit{}}
+		fields := append([]interface{}{&entry.SubscriptionID, &entry.SubscriptionName, &entry.QueuedAt},
+			subscriptionHitFields(entry.Hit)...)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("failed to scan queued hit: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// CompleteDigest removes sent hits from the queue, marks them notified and
+// records when the user's digest went out, in a single transaction
+func (r *NotificationRepository) CompleteDigest(ctx context.Context, userID string, hitIDs []string, sentAt time.Time) error {
+	tx, err := r.conn.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM notification_digest_queue WHERE user_id = $1 AND hit_id = ANY($2::uuid[])
+	`, userID, hitIDs); err != nil {
+		return fmt.Errorf("failed to dequeue digest hits: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE subscription_hits SET notified_at = $2, notify_error = NULL
+		WHERE id = ANY($1::uuid[])
+	`, hitIDs, sentAt); err != nil {
+		return fmt.Errorf("failed to mark digest hits notified: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE notification_preferences SET last_digest_at = $2 WHERE user_id = $1
+	`, userID, sentAt); err != nil {
+		return fmt.Errorf("failed to record digest time: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit digest: %w", err)
+	}
+
+	return nil
+}
+
+func scanNotificationPreference(row pgx.Row) (*domain.NotificationPreference, error) {
+	var p domain.NotificationPreference
+	var channels []byte
+	var severities []string
+	err := row.Scan(
+		&p.UserID, &channels, &severities, &p.DigestFrequency, &p.LastDigestAt,
+		&p.UpdatedBy, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(channels, &p.Channels); err != nil {
+		return nil, fmt.Errorf("failed to decode notification channels: %w", err)
+	}
+	p.Severities = make([]domain.RuleSeverity, len(severities))
+	for i, severity := range severities {
+		p.Severities[i] = domain.RuleSeverity(severity)
+	}
+	return &p, nil
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// PartitionInterval is the span of time covered by one partition
+type PartitionInterval string
+
+const (
+	PartitionDaily   PartitionInterval = "daily"
+	PartitionMonthly PartitionInterval = "monthly"
+)
+
+// start returns the start of the partition containing t
+func (i PartitionInterval) start(t time.Time) time.Time {
+	t = t.UTC()
+	if i == PartitionDaily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// add moves a partition start n partitions forward, or backward when n is negative
+func (i PartitionInterval) add(t time.Time, n int) time.Time {
+	if i == PartitionDaily {
+		return t.AddDate(0, 0, n)
+	}
+	return t.AddDate(0, n, 0)
+}
+
+// layout is the time layout of the suffix in partition names
+func (i PartitionInterval) layout() string {
+	if i == PartitionDaily {
+		return "20060102"
+	}
+	return "200601"
+}
+
+// PartitionConfig describes a table range-partitioned on a timestamp column
+type PartitionConfig struct {
+	Table    string
+	Interval PartitionInterval
+	// Premake is how many partitions are kept ready after the current one
+	Premake int
+	// Retention is how many partitions before the current one stay attached.
+	// Older ones are detached but not dropped. Zero keeps every partition.
+	Retention int
+}
+
+// PartitionMaintainer creates a partitioned table's upcoming partitions before
+// rows arrive for them and detaches partitions past retention. Partitions are
+// named <table>_p<YYYYMM> or <table>_p<YYYYMMDD>.
+type PartitionMaintainer struct {
+	conn   *Connection
+	config PartitionConfig
+	logger *zap.Logger
+}
+
+// NewPartitionMaintainer creates a new partition maintainer
+func NewPartitionMaintainer(conn *Connection, config PartitionConfig, logger *zap.Logger) *PartitionMaintainer {
+	return &PartitionMaintainer{
+		conn:   conn,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Start maintains partitions immediately and then every interval until ctx is cancelled
+func (m *PartitionMaintainer) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Maintain(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			m.logger.Error("Failed to maintain partitions",
+				zap.String("table", m.config.Table),
+				zap.Error(err),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Maintain creates missing partitions up to Premake ahead of now and detaches expired ones
+func (m *PartitionMaintainer) Maintain(ctx context.Context, now time.Time) error {
+	if err := m.CreatePartitions(ctx, now); err != nil {
+		return err
+	}
+	_, err := m.DetachExpired(ctx, now)
+	return err
+}
+
+// CreatePartitions creates the partition containing now and the Premake after it
+func (m *PartitionMaintainer) CreatePartitions(ctx context.Context, now time.Time) error {
+	start := m.config.Interval.start(now)
+	for i := 0; i <= m.config.Premake; i++ {
+		end := m.config.Interval.add(start, 1)
+		name := m.partitionName(start)
+
+		query := fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')`,
+			pgx.Identifier{name}.Sanitize(),
+			pgx.Identifier{m.config.Table}.Sanitize(),
+			start.Format(time.RFC3339),
+			end.Format(time.RFC3339),
+		)
+		if _, err := m.conn.pool.Exec(ctx, query); err != nil {
+			return fmt.Errorf("failed to create partition %s: %w", name, err)
+		}
+		start = end
+	}
+	return nil
+}
+
+// DetachExpired detaches partitions that end before the retention window and
+// returns their names
+func (m *PartitionMaintainer) DetachExpired(ctx context.Context, now time.Time) ([]string, error) {
+	if m.config.Retention <= 0 {
+		return nil, nil
+	}
+	cutoff := m.config.Interval.add(m.config.Interval.start(now), -m.config.Retention)
+
+	partitions, err := m.partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var detached []string
+	for _, name := range partitions {
+		start, ok := m.partitionStart(name)
+		if !ok || m.config.Interval.add(start, 1).After(cutoff) {
+			continue
+		}
+
+		query := fmt.Sprintf(`ALTER TABLE %s DETACH PARTITION %s`,
+			pgx.Identifier{m.config.Table}.Sanitize(),
+			pgx.Identifier{name}.Sanitize(),
+		)
+		if _, err := m.conn.pool.Exec(ctx, query); err != nil {
+			return detached, fmt.Errorf("failed to detach partition %s: %w", name, err)
+		}
+
+		m.logger.Info("Detached expired partition",
+			zap.String("table", m.config.Table),
+			zap.String("partition", name),
+		)
+		detached = append(detached, name)
+	}
+	return detached, nil
+}
+
+// partitions lists the names of the table's attached partitions
+func (m *PartitionMaintainer) partitions(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = to_regclass($1)
+	`
+
+	rows, err := m.conn.pool.Query(ctx, query, m.config.Table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan partition: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func (m *PartitionMaintainer) partitionName(start time.Time) string {
+	return m.config.Table + "_p" + start.Format(m.config.Interval.layout())
+}
+
+// partitionStart parses the start of a partition from its name. It reports
+// false for partitions not named by the maintainer, such as the default one.
+func (m *PartitionMaintainer) partitionStart(name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, m.config.Table+"_p")
+	if !ok {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(m.config.Interval.layout(), suffix)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
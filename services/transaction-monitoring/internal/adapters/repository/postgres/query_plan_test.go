@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// The tests and benchmarks below run against the database at TEST_DATABASE_URL
+// and are skipped when it is unset. The database must have the service's
+// migrations applied. Plan tests pass on an empty database; benchmarks are
+// only meaningful on one loaded with cmd/seed.
+
+func testConnection(tb testing.TB) *Connection {
+	tb.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		tb.Skip("TEST_DATABASE_URL not set")
+	}
+
+	conn, err := NewConnectionURL(url, zap.NewNop())
+	if err != nil {
+		tb.Fatalf("failed to connect: %v", err)
+	}
+	tb.Cleanup(conn.Close)
+	return conn
+}
+
+// planNode is a node of a plan in EXPLAIN (FORMAT JSON) output
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	IndexName    string     `json:"Index Name"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// planExpectation is what the plan of a query must satisfy
+type planExpectation struct {
+	// Table must not be scanned sequentially, nor any of its partitions
+	Table string
+	// Index must be used, directly or through its partitions' indexes
+	Index string
+	// MaxPartitions bounds how many partitions of Table are scanned, the
+	// default partition included. Zero skips the check.
+	MaxPartitions int
+}
+
+// assertPlan explains query with sequential scans disabled and checks the plan
+// against want. With sequential scans disabled the planner only picks one when
+// no index can serve the query, so a missing or unusable index shows as a
+// sequential scan however little data the table holds.
+func assertPlan(t *testing.T, conn *Connection, query string, args []interface{}, want planExpectation) {
+	t.Helper()
+	ctx := context.Background()
+
+	tx, err := conn.pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+		t.Fatalf("failed to disable sequential scans: %v", err)
+	}
+
+	var output []byte
+	if err := tx.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&output); err != nil {
+		t.Fatalf("failed to explain query: %v", err)
+	}
+	var explained []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(output, &explained); err != nil || len(explained) == 0 {
+		t.Fatalf("failed to parse plan: %v", err)
+	}
+
+	var nodes []planNode
+	var walk func(planNode)
+	walk = func(n planNode) {
+		nodes = append(nodes, n)
+		for _, child := range n.Plans {
+			walk(child)
+		}
+	}
+	walk(explained[0].Plan)
+
+	var names []string
+	for _, n := range nodes {
+		names = append(names, n.RelationName, n.IndexName)
+	}
+	parents := parentRelations(t, tx, names)
+
+	partitions := make(map[string]bool)
+	usesIndex := false
+	for _, n := range nodes {
+		if n.RelationName != "" && parents[n.RelationName] == want.Table {
+			partitions[n.RelationName] = true
+			if n.NodeType == "Seq Scan" {
+				t.Errorf("plan scans %s sequentially:\n%s", n.RelationName, output)
+			}
+		}
+		if n.IndexName != "" && parents[n.IndexName] == want.Index {
+			usesIndex = true
+		}
+	}
+
+	if want.Index != "" && !usesIndex {
+		t.Errorf("plan does not use %s:\n%s", want.Index, output)
+	}
+	if want.MaxPartitions > 0 && len(partitions) > want.MaxPartitions {
+		t.Errorf("plan scans %d partitions of %s, want at most %d:\n%s",
+			len(partitions), want.Table, want.MaxPartitions, output)
+	}
+}
+
+// parentRelations maps each table or index to the partitioned table or index
+// it is a partition of, or to itself
+func parentRelations(t *testing.T, tx pgx.Tx, names []string) map[string]string {
+	t.Helper()
+	query := `
+		SELECT c.relname, COALESCE(p.relname, c.relname)
+		FROM pg_class c
+		LEFT JOIN pg_inherits i ON i.inhrelid = c.oid
+		LEFT JOIN pg_class p ON p.oid = i.inhparent
+		WHERE c.relname = ANY($1)
+	`
+
+	rows, err := tx.Query(context.Background(), query, names)
+	if err != nil {
+		t.Fatalf("failed to look up parent relations: %v", err)
+	}
+	defer rows.Close()
+
+	parents := make(map[string]string)
+	for rows.Next() {
+		var name, parent string
+		if err := rows.Scan(&name, &parent); err != nil {
+			t.Fatalf("failed to scan parent relation: %v", err)
+		}
+		parents[name] = parent
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to look up parent relations: %v", err)
+	}
+	return parents
+}
+
+func TestTransactionSearchPlans(t *testing.T) {
+	conn := testConnection(t)
+
+	now := time.Now().UTC()
+	lastMonth, lastQuarter := now.AddDate(0, 0, -30), now.AddDate(0, 0, -90)
+	flagged := true
+
+	tests := []struct {
+		name   string
+		filter domain.TransactionFilter
+		count  bool
+		want   planExpectation
+	}{
+		{
+			name:   "flagged review queue",
+			filter: domain.TransactionFilter{Flagged: &flagged, StartTime: &lastMonth, EndTime: &now},
+			want:   planExpectation{Table: "transactions", Index: "idx_transactions_flagged_timestamp", MaxPartitions: 3},
+		},
+		{
+			name:   "flagged review queue count",
+			filter: domain.TransactionFilter{Flagged: &flagged, StartTime: &lastMonth, EndTime: &now},
+			count:  true,
+			want:   planExpectation{Table: "transactions", Index: "idx_transactions_flagged_timestamp", MaxPartitions: 3},
+		},
+		{
+			name:   "by sender",
+			filter: domain.TransactionFilter{FromAddress: "0x0000000000000000000000000000000000000001", StartTime: &lastQuarter, EndTime: &now},
+			want:   planExpectation{Table: "transactions", Index: "idx_transactions_from_address_timestamp", MaxPartitions: 5},
+		},
+		{
+			name:   "by receiver",
+			filter: domain.TransactionFilter{ToAddress: "0x0000000000000000000000000000000000000001", StartTime: &lastQuarter, EndTime: &now},
+			want:   planExpectation{Table: "transactions", Index: "idx_transactions_to_address_timestamp", MaxPartitions: 5},
+		},
+		{
+			name:   "by chain",
+			filter: domain.TransactionFilter{Chain: "bitcoin", StartTime: &lastMonth, EndTime: &now},
+			want:   planExpectation{Table: "transactions", Index: "idx_transactions_chain_timestamp", MaxPartitions: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := listTransactionsQuery(tt.filter)
+			if tt.count {
+				query, args = countTransactionsQuery(tt.filter)
+			}
+			assertPlan(t, conn, query, args, tt.want)
+		})
+	}
+}
+
+func TestSanctionsSearchPlan(t *testing.T) {
+	conn := testConnection(t)
+
+	assertPlan(t, conn, searchSanctionsQuery, []interface{}{"%lazarus%"}, planExpectation{
+		Table: "sanctioned_addresses",
+		Index: "idx_sanctions_entity_name_trgm",
+	})
+}
+
+func BenchmarkListTransactions(b *testing.B) {
+	conn := testConnection(b)
+	repo := NewTransactionRepository(conn, zap.NewNop())
+	ctx := context.Background()
+
+	var sender string
+	err := conn.pool.QueryRow(ctx, `SELECT from_address FROM transactions ORDER BY tx_timestamp DESC LIMIT 1`).Scan(&sender)
+	if err != nil {
+		b.Skipf("no transactions to search, load the database with cmd/seed: %v", err)
+	}
+
+	flagged := true
+	lastMonth := time.Now().AddDate(0, 0, -30)
+	filters := map[string]domain.TransactionFilter{
+		"flagged":     {Flagged: &flagged},
+		"by_sender":   {FromAddress: sender},
+		"by_chain":    {Chain: "bitcoin", StartTime: &lastMonth},
+		"high_risk":   {MinRiskScore: 80, StartTime: &lastMonth},
+		"deep_page":   {Page: 100, PageSize: 50, StartTime: &lastMonth},
+		"large_value": {MinAmountUSD: decimal.NewFromInt(1_000_000)},
+	}
+
+	for name, filter := range filters {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := repo.ListTransactions(ctx, filter); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSearchSanctions(b *testing.B) {
+	conn := testConnection(b)
+	repo := NewSanctionsRepository(conn, zap.NewNop())
+	ctx := context.Background()
+
+	var name string
+	err := conn.pool.QueryRow(ctx, `SELECT entity_name FROM sanctioned_addresses WHERE is_active AND length(entity_name) > 3 LIMIT 1`).Scan(&name)
+	if err != nil {
+		b.Skipf("no sanctions to search, load the database with cmd/seed: %v", err)
+	}
+	// Search the middle of the name, as analysts rarely type it from the start
+	term := strings.ToLower(name[1 : len(name)-1])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.SearchSanctions(ctx, term); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
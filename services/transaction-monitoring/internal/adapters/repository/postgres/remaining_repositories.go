@@ -2,11 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -103,8 +106,8 @@ func (r *WalletProfileRepository) GetOrCreateWalletProfile(ctx context.Context,
 		FirstSeen:       time.Now(),
 		LastSeen:        time.Now(),
 		TxCount:         0,
-		TotalVolumeUSD:  0,
-		AvgTxValueUSD:   0,
+		TotalVolumeUSD:  decimal.Zero,
+		AvgTxValueUSD:   decimal.Zero,
 		CurrentRiskScore: 0,
 		RiskLevel:       domain.RiskLow,
 		IsSanctioned:    false,
@@ -212,7 +215,8 @@ func NewSanctionsRepository(conn *Connection, logger *zap.Logger) *SanctionsRepo
 	}
 }
 
-// CheckAddress checks if an address is sanctioned
+// CheckAddress checks if an address is sanctioned, returning
+// domain.ErrNotSanctioned when it is not
 func (r *SanctionsRepository) CheckAddress(ctx context.Context, address string) (*domain.SanctionedAddress, error) {
 	query := `SELECT * FROM sanctioned_addresses WHERE address = $1 AND is_active = true`
 	row := r.conn.pool.QueryRow(ctx, query, address)
@@ -223,8 +227,11 @@ func (r *SanctionsRepository) CheckAddress(ctx context.Context, address string)
 		&addr.EntityName, &addr.EntityType, &addr.Program, &addr.AddedAt, &addr.ExpiresAt,
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotSanctioned
+	}
 	if err != nil {
-		return nil, fmt.Errorf("address not found in sanctions list: %w", err)
+		return nil, fmt.Errorf("failed to check sanctions: %w", err)
 	}
 
 	return &addr, nil
@@ -310,11 +317,13 @@ func (r *SanctionsRepository) DeactivateSanction(ctx context.Context, id string)
 	return err
 }
 
+// searchSanctionsQuery matches active entries by entity name. The trigram
+// index on entity_name serves the leading wildcard.
+const searchSanctionsQuery = `SELECT * FROM sanctioned_addresses WHERE entity_name ILIKE $1 AND is_active = true`
+
 // SearchSanctions searches sanctions by entity name
 func (r *SanctionsRepository) SearchSanctions(ctx context.Context, query string) ([]*domain.SanctionedAddress, error) {
-	searchQuery := `%` + query + `%`
-	sqlQuery := `SELECT * FROM sanctioned_addresses WHERE entity_name ILIKE $1 AND is_active = true`
-	rows, err := r.conn.pool.Query(ctx, sqlQuery, searchQuery)
+	rows, err := r.conn.pool.Query(ctx, searchSanctionsQuery, `%`+query+`%`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search sanctions: %w", err)
 	}
@@ -457,9 +466,14 @@ func NewMonitoringRuleRepository(conn *Connection, logger *zap.Logger) *Monitori
 	}
 }
 
+// monitoringRuleColumns lists monitoring_rules columns in scan order
+const monitoringRuleColumns = `id, name, COALESCE(description, ''), rule_type, condition::text,
+	COALESCE(parameters::text, ''), COALESCE(expression, ''), risk_weight, severity,
+	is_active, priority, version, created_at, updated_at`
+
 // GetActiveRules retrieves all active monitoring rules
 func (r *MonitoringRuleRepository) GetActiveRules(ctx context.Context) ([]*domain.MonitoringRule, error) {
-	query := `SELECT * FROM monitoring_rules WHERE is_active = true ORDER BY priority DESC`
+	query := `SELECT ` + monitoringRuleColumns + ` FROM monitoring_rules WHERE is_active = true ORDER BY priority DESC`
 	rows, err := r.conn.pool.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query rules: %w", err)
@@ -471,8 +485,8 @@ func (r *MonitoringRuleRepository) GetActiveRules(ctx context.Context) ([]*domai
 		var rule domain.MonitoringRule
 		err := rows.Scan(
 			&rule.ID, &rule.Name, &rule.Description, &rule.RuleType,
-			&rule.Condition, &rule.Parameters, &rule.RiskWeight, &rule.Severity,
-			&rule.IsActive, &rule.Priority, &rule.CreatedAt, &rule.UpdatedAt,
+			&rule.Condition, &rule.Parameters, &rule.Expression, &rule.RiskWeight, &rule.Severity,
+			&rule.IsActive, &rule.Priority, &rule.Version, &rule.CreatedAt, &rule.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan rule: %w", err)
@@ -483,18 +497,96 @@ func (r *MonitoringRuleRepository) GetActiveRules(ctx context.Context) ([]*domai
 	return rules, nil
 }
 
+// ListRules retrieves all monitoring rules, active or not, by name
+func (r *MonitoringRuleRepository) ListRules(ctx context.Context) ([]*domain.MonitoringRule, error) {
+	query := `SELECT ` + monitoringRuleColumns + ` FROM monitoring_rules ORDER BY name, created_at`
+	rows, err := r.conn.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*domain.MonitoringRule{}
+	for rows.Next() {
+		var rule domain.MonitoringRule
+		err := rows.Scan(
+			&rule.ID, &rule.Name, &rule.Description, &rule.RuleType,
+			&rule.Condition, &rule.Parameters, &rule.Expression, &rule.RiskWeight, &rule.Severity,
+			&rule.IsActive, &rule.Priority, &rule.Version, &rule.CreatedAt, &rule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rule: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	return rules, rows.Err()
+}
+
 // GetRule retrieves a rule by ID
 func (r *MonitoringRuleRepository) GetRule(ctx context.Context, id string) (*domain.MonitoringRule, error) {
-	return nil, nil
+	query := `SELECT ` + monitoringRuleColumns + ` FROM monitoring_rules WHERE id = $1`
+	row := r.conn.pool.QueryRow(ctx, query, id)
+
+	var rule domain.MonitoringRule
+	err := row.Scan(
+		&rule.ID, &rule.Name, &rule.Description, &rule.RuleType,
+		&rule.Condition, &rule.Parameters, &rule.Expression, &rule.RiskWeight, &rule.Severity,
+		&rule.IsActive, &rule.Priority, &rule.Version, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("rule not found: %w", err)
+	}
+
+	return &rule, nil
 }
 
 // CreateRule creates a new monitoring rule
 func (r *MonitoringRuleRepository) CreateRule(ctx context.Context, rule *domain.MonitoringRule) error {
+	query := `
+		INSERT INTO monitoring_rules (
+			name, description, rule_type, condition, parameters, expression,
+			risk_weight, severity, is_active, priority, version, created_at, updated_at
+		) VALUES ($1, $2, $3, COALESCE(NULLIF($4, '')::jsonb, '{}'::jsonb), NULLIF($5, '')::jsonb,
+			NULLIF($6, ''), $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+
+	err := r.conn.pool.QueryRow(ctx, query,
+		rule.Name, rule.Description, rule.RuleType, rule.Condition, rule.Parameters, rule.Expression,
+		rule.RiskWeight, rule.Severity, rule.IsActive, rule.Priority, rule.Version, rule.CreatedAt, rule.UpdatedAt,
+	).Scan(&rule.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create rule: %w", err)
+	}
+
 	return nil
 }
 
 // UpdateRule updates an existing rule
 func (r *MonitoringRuleRepository) UpdateRule(ctx context.Context, rule *domain.MonitoringRule) error {
+	query := `
+		UPDATE monitoring_rules SET
+			name = $1, description = $2, rule_type = $3,
+			condition = COALESCE(NULLIF($4, '')::jsonb, '{}'::jsonb), parameters = NULLIF($5, '')::jsonb,
+			expression = NULLIF($6, ''), risk_weight = $7, severity = $8,
+			is_active = $9, priority = $10, version = $11, updated_at = $12
+		WHERE id = $13
+	`
+
+	result, err := r.conn.pool.Exec(ctx, query,
+		rule.Name, rule.Description, rule.RuleType, rule.Condition, rule.Parameters, rule.Expression,
+		rule.RiskWeight, rule.Severity, rule.IsActive, rule.Priority, rule.Version, rule.UpdatedAt, rule.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update rule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("rule not found: %s", rule.ID)
+	}
+
 	return nil
 }
 
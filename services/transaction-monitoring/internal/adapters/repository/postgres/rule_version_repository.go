@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// ruleVersionColumns lists rule_versions columns in scan order
+const ruleVersionColumns = `id, rule_id, version, status, name, COALESCE(description, ''), rule_type,
+	condition::text, COALESCE(parameters::text, ''), COALESCE(expression, ''), risk_weight, severity,
+	priority, COALESCE(change_note, ''), COALESCE(created_by, ''), COALESCE(submitted_by, ''), submitted_at,
+	COALESCE(reviewed_by, ''), reviewed_at, COALESCE(review_comment, ''), created_at`
+
+// RuleVersionRepository implements ports.RuleVersionRepository
+type RuleVersionRepository struct {
+	conn   *Connection
+	logger *zap.Logger
+}
+
+// NewRuleVersionRepository creates a new rule version repository
+func NewRuleVersionRepository(conn *Connection, logger *zap.Logger) *RuleVersionRepository {
+	return &RuleVersionRepository{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// CreateVersion stores a new version, assigning the next version number for the rule
+func (r *RuleVersionRepository) CreateVersion(ctx context.Context, version *domain.RuleVersion) error {
+	query := `
+		INSERT INTO rule_versions (
+			rule_id, version, status, name, description, rule_type, condition, parameters,
+			expression, risk_weight, severity, priority, change_note, created_by, created_at
+		)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5,
+			COALESCE(NULLIF($6, '')::jsonb, '{}'::jsonb), NULLIF($7, '')::jsonb,
+			NULLIF($8, ''), $9, $10, $11, $12, $13, $14
+		FROM rule_versions WHERE rule_id = $1
+		RETURNING id, version
+	`
+
+	err := r.conn.pool.QueryRow(ctx, query,
+		version.RuleID, version.Status, version.Name, version.Description, version.RuleType,
+		version.Condition, version.Parameters, version.Expression, version.RiskWeight,
+		version.Severity, version.Priority, version.ChangeNote, version.CreatedBy, version.CreatedAt,
+	).Scan(&version.ID, &version.Version)
+
+	if err != nil {
+		return fmt.Errorf("failed to create rule version: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersion retrieves a specific version of a rule
+func (r *RuleVersionRepository) GetVersion(ctx context.Context, ruleID string, version int) (*domain.RuleVersion, error) {
+	query := `SELECT ` + ruleVersionColumns + ` FROM rule_versions WHERE rule_id = $1 AND version = $2`
+	row := r.conn.pool.QueryRow(ctx, query, ruleID, version)
+
+	v, err := scanRuleVersion(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrRuleVersionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule version: %w", err)
+	}
+
+	return v, nil
+}
+
+// ListVersions retrieves the version history of a rule, newest first
+func (r *RuleVersionRepository) ListVersions(ctx context.Context, ruleID string) ([]*domain.RuleVersion, error) {
+	query := `SELECT ` + ruleVersionColumns + ` FROM rule_versions WHERE rule_id = $1 ORDER BY version DESC`
+	rows, err := r.conn.pool.Query(ctx, query, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rule versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*domain.RuleVersion{}
+	for rows.Next() {
+		v, err := scanRuleVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rule version: %w", err)
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
+
+// UpdateVersionStatus stores the status and review fields of a version
+func (r *RuleVersionRepository) UpdateVersionStatus(ctx context.Context, version *domain.RuleVersion) error {
+	query := `
+		UPDATE rule_versions SET
+			status = $1, submitted_by = NULLIF($2, ''), submitted_at = $3,
+			reviewed_by = NULLIF($4, ''), reviewed_at = $5, review_comment = NULLIF($6, '')
+		WHERE id = $7
+	`
+
+	_, err := r.conn.pool.Exec(ctx, query,
+		version.Status, version.SubmittedBy, version.SubmittedAt,
+		version.ReviewedBy, version.ReviewedAt, version.ReviewComment, version.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update rule version: %w", err)
+	}
+
+	return nil
+}
+
+// ActivateVersion supersedes the rule's current active version, marks this version
+// active and applies its definition to monitoring_rules in a single transaction
+func (r *RuleVersionRepository) ActivateVersion(ctx context.Context, version *domain.RuleVersion) error {
+	tx, err := r.conn.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx,
+		`UPDATE rule_versions SET status = $1 WHERE rule_id = $2 AND status = $3`,
+		domain.RuleVersionSuperseded, version.RuleID, domain.RuleVersionActive,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to supersede active version: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE rule_versions SET
+			status = $1, submitted_by = NULLIF($2, ''), submitted_at = $3,
+			reviewed_by = NULLIF($4, ''), reviewed_at = $5, review_comment = NULLIF($6, '')
+		WHERE id = $7
+	`,
+		domain.RuleVersionActive, version.SubmittedBy, version.SubmittedAt,
+		version.ReviewedBy, version.ReviewedAt, version.ReviewComment, version.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to activate version: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE monitoring_rules SET
+			name = $1, description = $2, rule_type = $3,
+			condition = COALESCE(NULLIF($4, '')::jsonb, '{}'::jsonb), parameters = NULLIF($5, '')::jsonb,
+			expression = NULLIF($6, ''), risk_weight = $7, severity = $8,
+			priority = $9, version = $10, updated_at = NOW()
+		WHERE id = $11
+	`,
+		version.Name, version.Description, version.RuleType, version.Condition, version.Parameters,
+		version.Expression, version.RiskWeight, version.Severity, version.Priority, version.Version,
+		version.RuleID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply version to rule: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit version activation: %w", err)
+	}
+
+	version.Status = domain.RuleVersionActive
+	return nil
+}
+
+// ImportVersions records imported rule versions in a single transaction, so
+// either all of them are stored or none. A version without a rule ID creates
+// the rule from its definition as version 1; any other version is appended to
+// its rule's history.
+func (r *RuleVersionRepository) ImportVersions(ctx context.Context, versions []*domain.RuleVersion) error {
+	tx, err := r.conn.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, version := range versions {
+		if version.RuleID == "" {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO monitoring_rules (
+					name, description, rule_type, condition, parameters, expression,
+					risk_weight, severity, is_active, priority, version, created_at, updated_at
+				) VALUES ($1, $2, $3, COALESCE(NULLIF($4, '')::jsonb, '{}'::jsonb), NULLIF($5, '')::jsonb,
+					NULLIF($6, ''), $7, $8, true, $9, 1, $10, $10)
+				RETURNING id
+			`,
+				version.Name, version.Description, version.RuleType, version.Condition, version.Parameters,
+				version.Expression, version.RiskWeight, version.Severity, version.Priority, version.CreatedAt,
+			).Scan(&version.RuleID)
+			if err != nil {
+				return fmt.Errorf("failed to create imported rule %q: %w", version.Name, err)
+			}
+		}
+
+		err := tx.QueryRow(ctx, `
+			INSERT INTO rule_versions (
+				rule_id, version, status, name, description, rule_type, condition, parameters,
+				expression, risk_weight, severity, priority, change_note, created_by,
+				submitted_by, submitted_at, created_at
+			)
+			SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5,
+				COALESCE(NULLIF($6, '')::jsonb, '{}'::jsonb), NULLIF($7, '')::jsonb,
+				NULLIF($8, ''), $9, $10, $11, $12, $13, NULLIF($14, ''), $15, $16
+			FROM rule_versions WHERE rule_id = $1
+			RETURNING id, version
+		`,
+			version.RuleID, version.Status, version.Name, version.Description, version.RuleType,
+			version.Condition, version.Parameters, version.Expression, version.RiskWeight,
+			version.Severity, version.Priority, version.ChangeNote, version.CreatedBy,
+			version.SubmittedBy, version.SubmittedAt, version.CreatedAt,
+		).Scan(&version.ID, &version.Version)
+		if err != nil {
+			return fmt.Errorf("failed to record imported version of rule %q: %w", version.Name, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit rule import: %w", err)
+	}
+
+	return nil
+}
+
+func scanRuleVersion(row pgx.Row) (*domain.RuleVersion, error) {
+	var v domain.RuleVersion
+	err := row.Scan(
+		&v.ID, &v.RuleID, &v.Version, &v.Status, &v.Name, &v.Description, &v.RuleType,
+		&v.Condition, &v.Parameters, &v.Expression, &v.RiskWeight, &v.Severity,
+		&v.Priority, &v.ChangeNote, &v.CreatedBy, &v.SubmittedBy, &v.SubmittedAt,
+		&v.ReviewedBy, &v.ReviewedAt, &v.ReviewComment, &v.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,310 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// patternSubscriptionColumns lists pattern_subscriptions columns in scan order
+const patternSubscriptionColumns = `id, name, COALESCE(description, ''), owner, filter,
+	COALESCE(channel_type, ''), COALESCE(channel_target, ''), status, hit_count, last_hit_at,
+	COALESCE(updated_by, ''), created_at, updated_at`
+
+// subscriptionHitColumns lists subscription_hits columns in scan order
+const subscriptionHitColumns = `id, subscription_id, transaction_id, tx_hash, chain,
+	COALESCE(matched_address, ''), direction, amount, amount_usd, risk_score, tx_timestamp,
+	matched_at, severity, COALESCE(delivery, ''), notified_at, COALESCE(notify_error, '')`
+
+// SubscriptionRepository implements ports.PatternSubscriptionRepository
+type SubscriptionRepository struct {
+	conn   *Connection
+	logger *zap.Logger
+}
+
+// NewSubscriptionRepository creates a new pattern subscription repository
+func NewSubscriptionRepository(conn *Connection, logger *zap.Logger) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// CreateSubscription inserts a subscription and sets its ID
+func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, subscription *domain.PatternSubscription) error {
+	filter, err := json.Marshal(subscription.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode subscription filter: %w", err)
+	}
+	channelType, channelTarget := subscriptionChannel(subscription.Channel)
+
+	query := `
+		INSERT INTO pattern_subscriptions (
+			name, description, owner, filter, channel_type, channel_target, status,
+			updated_by, created_at, updated_at
+		)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, NULLIF($8, ''), $9, $10)
+		RETURNING id
+	`
+
+	err = r.conn.pool.QueryRow(ctx, query,
+		subscription.Name, subscription.Description, subscription.Owner, filter, channelType, channelTarget,
+		subscription.Status, subscription.UpdatedBy, subscription.CreatedAt, subscription.UpdatedAt,
+	).Scan(&subscription.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create pattern subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (r *SubscriptionRepository) GetSubscription(ctx context.Context, id string) (*domain.PatternSubscription, error) {
+	query := `SELECT ` + patternSubscriptionColumns + ` FROM pattern_subscriptions WHERE id = $1`
+
+	subscription, err := scanPatternSubscription(r.conn.pool.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pattern subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// UpdateSubscription stores a subscription's definition and status. Hit
+// counters are maintained by RecordHit and left untouched.
+func (r *SubscriptionRepository) UpdateSubscription(ctx context.Context, subscription *domain.PatternSubscription) error {
+	filter, err := json.Marshal(subscription.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode subscription filter: %w", err)
+	}
+	channelType, channelTarget := subscriptionChannel(subscription.Channel)
+
+	query := `
+		UPDATE pattern_subscriptions SET
+			name = $1, description = NULLIF($2, ''), filter = $3, channel_type = NULLIF($4, ''),
+			channel_target = NULLIF($5, ''), status = $6, updated_by = NULLIF($7, ''), updated_at = $8
+		WHERE id = $9
+	`
+
+	tag, err := r.conn.pool.Exec(ctx, query,
+		subscription.Name, subscription.Description, filter, channelType, channelTarget,
+		subscription.Status, subscription.UpdatedBy, subscription.UpdatedAt, subscription.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update pattern subscription: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// ListSubscriptions retrieves subscriptions matching the filter, newest first
+func (r *SubscriptionRepository) ListSubscriptions(ctx context.Context, filter domain.SubscriptionListFilter) ([]*domain.PatternSubscription, int64, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	if filter.Owner != "" {
+		args = append(args, filter.Owner)
+		conditions = append(conditions, fmt.Sprintf("owner = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	} else {
+		conditions = append(conditions, "status <> 'ARCHIVED'")
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM pattern_subscriptions WHERE ` + where
+	if err := r.conn.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count pattern subscriptions: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM pattern_subscriptions
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, patternSubscriptionColumns, where, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.conn.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query pattern subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions, err := scanPatternSubscriptions(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return subscriptions, total, nil
+}
+
+// ListActiveSubscriptions retrieves every subscription evaluated against the stream
+func (r *SubscriptionRepository) ListActiveSubscriptions(ctx context.Context) ([]*domain.PatternSubscription, error) {
+	query := `SELECT ` + patternSubscriptionColumns + ` FROM pattern_subscriptions WHERE status = 'ACTIVE'`
+
+	rows, err := r.conn.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active pattern subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPatternSubscriptions(rows)
+}
+
+// RecordHit inserts a hit and bumps its subscription's counters in a single
+// transaction. A transaction already recorded for the subscription is skipped.
+func (r *SubscriptionRepository) RecordHit(ctx context.Context, hit *domain.SubscriptionHit) (bool, error) {
+	tx, err := r.conn.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO subscription_hits (
+			subscription_id, transaction_id, tx_hash, chain, matched_address, direction,
+			amount, amount_usd, risk_score, tx_timestamp, matched_at, severity
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (subscription_id, transaction_id) DO NOTHING
+		RETURNING id
+	`,
+		hit.SubscriptionID, hit.TransactionID, hit.TxHash, hit.Chain, hit.MatchedAddress, hit.Direction,
+		hit.Amount, hit.AmountUSD, hit.RiskScore, hit.TxTimestamp, hit.MatchedAt, hit.Severity,
+	).Scan(&hit.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record subscription hit: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE pattern_subscriptions
+		SET hit_count = hit_count + 1, last_hit_at = GREATEST(COALESCE(last_hit_at, $2), $2)
+		WHERE id = $1
+	`, hit.SubscriptionID, hit.MatchedAt); err != nil {
+		return false, fmt.Errorf("failed to count subscription hit: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit subscription hit: %w", err)
+	}
+
+	return true, nil
+}
+
+// UpdateHitDelivery stores how a hit was routed and the outcome of delivering it
+func (r *SubscriptionRepository) UpdateHitDelivery(ctx context.Context, hit *domain.SubscriptionHit) error {
+	_, err := r.conn.pool.Exec(ctx, `
+		UPDATE subscription_hits SET delivery = NULLIF($1, ''), notified_at = $2, notify_error = NULLIF($3, '')
+		WHERE id = $4
+	`, hit.Delivery, hit.NotifiedAt, hit.NotifyError, hit.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription hit delivery: %w", err)
+	}
+	return nil
+}
+
+// ListHits retrieves a subscription's hit log, most recent first
+func (r *SubscriptionRepository) ListHits(ctx context.Context, subscriptionID string, limit, offset int) ([]*domain.SubscriptionHit, int64, error) {
+	var total int64
+	if err := r.conn.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM subscription_hits WHERE subscription_id = $1`, subscriptionID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count subscription hits: %w", err)
+	}
+
+	query := `SELECT ` + subscriptionHitColumns + ` FROM subscription_hits
+		WHERE subscription_id = $1
+		ORDER BY matched_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.conn.pool.Query(ctx, query, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query subscription hits: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []*domain.SubscriptionHit{}
+	for rows.Next() {
+		var h domain.SubscriptionHit
+		if err := rows.Scan(subscriptionHitFields(&h)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan subscription hit: %w", err)
+		}
+		hits = append(hits, &h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return hits, total, nil
+}
+
+// subscriptionChannel flattens a channel binding into its columns
+func subscriptionChannel(channel *domain.NotificationChannel) (string, string) {
+	if channel == nil {
+		return "", ""
+	}
+	return string(channel.Type), channel.Target
+}
+
+// subscriptionHitFields returns the scan targets of subscriptionHitColumns
+func subscriptionHitFields(h *domain.SubscriptionHit) []interface{} {
+	return []interface{}{
+		&h.ID, &h.SubscriptionID, &h.TransactionID, &h.TxHash, &h.Chain,
+		&h.MatchedAddress, &h.Direction, &h.Amount, &h.AmountUSD, &h.RiskScore, &h.TxTimestamp,
+		&h.MatchedAt, &h.Severity, &h.Delivery, &h.NotifiedAt, &h.NotifyError,
+	}
+}
+
+func scanPatternSubscriptions(rows pgx.Rows) ([]*domain.PatternSubscription, error) {
+	subscriptions := []*domain.PatternSubscription{}
+	for rows.Next() {
+		subscription, err := scanPatternSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pattern subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+func scanPatternSubscription(row pgx.Row) (*domain.PatternSubscription, error) {
+	var s domain.PatternSubscription
+	var filter []byte
+	var channelType, channelTarget string
+	err := row.Scan(
+		&s.ID, &s.Name, &s.Description, &s.Owner, &filter,
+		&channelType, &channelTarget, &s.Status, &s.HitCount, &s.LastHitAt,
+		&s.UpdatedBy, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filter, &s.Filter); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription filter: %w", err)
+	}
+	if channelType != "" {
+		s.Channel = &domain.NotificationChannel{
+			Type:   domain.NotificationChannelType(channelType),
+			Target: channelTarget,
+		}
+	}
+	return &s, nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Like the plan tests, these run against the database at TEST_DATABASE_URL.
+
+func TestCreateTransactionRejectsReplay(t *testing.T) {
+	conn := testConnection(t)
+	repo := NewTransactionRepository(conn, zap.NewNop())
+	ctx := context.Background()
+
+	hash := "0xreplay" + uuid.New().String()
+	t.Cleanup(func() {
+		conn.pool.Exec(ctx, `DELETE FROM transactions WHERE tx_hash = $1`, hash)
+		conn.pool.Exec(ctx, `DELETE FROM transaction_keys WHERE tx_hash = $1`, hash)
+	})
+
+	seen := time.Now().UTC().Truncate(time.Second)
+	original := &domain.Transaction{
+		ID:          uuid.New().String(),
+		TxHash:      hash,
+		Chain:       "ethereum",
+		FromAddress: "0xsender",
+		Amount:      decimal.NewFromInt(5),
+		AmountUSD:   decimal.NewFromInt(10000),
+		TxTimestamp: seen,
+	}
+	if err := repo.CreateTransaction(ctx, original); err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	// A replay reported with another timestamp falls in another partition, so
+	// only the hash claim can catch it
+	replay := *original
+	replay.ID = uuid.New().String()
+	replay.TxTimestamp = seen.AddDate(0, -1, 0)
+	if err := repo.CreateTransaction(ctx, &replay); !errors.Is(err, domain.ErrTransactionExists) {
+		t.Fatalf("replay: got %v, want %v", err, domain.ErrTransactionExists)
+	}
+
+	var rows int
+	if err := conn.pool.QueryRow(ctx, `SELECT count(*) FROM transactions WHERE tx_hash = $1`, hash).Scan(&rows); err != nil {
+		t.Fatalf("failed to count transactions: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("stored %d rows for the hash, want 1", rows)
+	}
+
+	var keyID string
+	if err := conn.pool.QueryRow(ctx, `SELECT id FROM transaction_keys WHERE tx_hash = $1`, hash).Scan(&keyID); err != nil {
+		t.Fatalf("failed to read transaction key: %v", err)
+	}
+	if keyID != original.ID {
+		t.Errorf("key points at %s, want the original %s", keyID, original.ID)
+	}
+}
@@ -0,0 +1,577 @@
+package seed
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// Chains generated, with the share of clusters on each and the USD price used
+// to derive token amounts
+var chains = []struct {
+	name  string
+	share float64
+	price float64
+}{
+	{"ethereum", 0.75, 3000},
+	{"bitcoin", 0.25, 60000},
+}
+
+// Reference points for block numbers derived from transaction timestamps
+var (
+	blockEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	blockBase  = map[string]int64{"ethereum": 18_908_000, "bitcoin": 823_800}
+	blockTime  = map[string]int64{"ethereum": 12, "bitcoin": 600}
+)
+
+// maxAmountUSD caps the power-law tail of transaction amounts
+const maxAmountUSD = 50_000_000
+
+// Options controls the size and shape of the generated data set. The same
+// options always generate the same rows, IDs included.
+type Options struct {
+	Seed         int64
+	Exchanges    int
+	HotWallets   int // per exchange and chain
+	Wallets      int
+	Transactions int64
+	Start        time.Time
+	End          time.Time
+	// AmountAlpha is the Pareto shape of USD amounts; lower values give a
+	// heavier tail of large transfers
+	AmountAlpha  float64
+	MinAmountUSD float64
+	// AlertThreshold is the risk score at which a transaction is flagged and
+	// raises an alert
+	AlertThreshold int
+}
+
+// DefaultOptions returns the options of a 10 million transaction data set over
+// the last year
+func DefaultOptions() Options {
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	return Options{
+		Seed:           1,
+		Exchanges:      50,
+		HotWallets:     4,
+		Wallets:        1_000_000,
+		Transactions:   10_000_000,
+		Start:          end.AddDate(-1, 0, 0),
+		End:            end,
+		AmountAlpha:    1.16,
+		MinAmountUSD:   10,
+		AlertThreshold: 80,
+	}
+}
+
+// Validate checks the options describe a data set that can be generated
+func (o Options) Validate() error {
+	switch {
+	case o.Exchanges < 1 || o.HotWallets < 1:
+		return errors.New("at least one exchange with one hot wallet is required")
+	case o.Wallets < 2:
+		return errors.New("at least two wallets are required")
+	case o.Transactions < 1:
+		return errors.New("at least one transaction is required")
+	case !o.End.After(o.Start):
+		return errors.New("end must be after start")
+	case o.AmountAlpha <= 0 || o.MinAmountUSD <= 0:
+		return errors.New("amount alpha and minimum amount must be positive")
+	case o.AlertThreshold < 1 || o.AlertThreshold > 100:
+		return errors.New("alert threshold must be between 1 and 100")
+	}
+	return nil
+}
+
+// Record is a generated transaction and the alert it raised, if any
+type Record struct {
+	Transaction *domain.Transaction
+	Alert       *Alert
+}
+
+// Alert is a generated row of the alerts table
+type Alert struct {
+	ID            [16]byte
+	AlertType     string
+	TransactionID [16]byte
+	WalletAddress string
+	Severity      string
+	RiskScore     int
+	Status        string
+	Title         string
+	Description   string
+	ResolvedAt    *time.Time
+	Resolution    *string
+	CreatedAt     time.Time
+}
+
+// Wallet is a generated row of the wallet_profiles table, with the activity
+// of the transactions generated for it
+type Wallet struct {
+	ID              [16]byte
+	Address         string
+	Chain           string
+	FirstSeen       *time.Time
+	LastSeen        *time.Time
+	TxCount         int
+	TotalVolumeUSD  decimal.Decimal
+	AvgTxValueUSD   decimal.Decimal
+	RiskScore       int
+	RiskLevel       string
+	IsSanctioned    bool
+	FlagCount       int
+	Labels          []string
+	KnownEntityType *string
+	KnownEntityName *string
+}
+
+// cluster is a group of wallets controlled by one entity. Its wallets share a
+// chain and a base risk, and transfer among themselves.
+type cluster struct {
+	start int32
+	size  int32
+	chain uint8
+	risk  uint8
+}
+
+// walletStats accumulates the activity of a wallet
+type walletStats struct {
+	txCount   int32
+	flagCount int32
+	volumeUSD float64
+	firstSeen int64
+	lastSeen  int64
+}
+
+// Generator deterministically generates exchanges, clustered wallets and a
+// stream of transactions between them, in timestamp order. Wallet activity
+// follows a Zipf distribution and USD amounts a Pareto distribution.
+type Generator struct {
+	opts Options
+	rng  *rand.Rand
+	zipf *rand.Zipf
+
+	// Wallets [0, exchangeWallets) are exchange hot wallets; the rest are
+	// grouped into clusters of consecutive wallets
+	exchangeWallets int
+	clusters        []cluster
+	clusterOf       []int32
+	sanctioned      []bool
+	activity        []int32
+	stats           []walletStats
+
+	step  float64
+	count int64
+}
+
+// NewGenerator creates a generator for the options
+func NewGenerator(opts Options) (*Generator, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	g := &Generator{
+		opts:            opts,
+		rng:             rng,
+		exchangeWallets: opts.Exchanges * len(chains) * opts.HotWallets,
+		step:            float64(opts.End.Sub(opts.Start)) / float64(opts.Transactions),
+	}
+	total := g.exchangeWallets + opts.Wallets
+
+	// Cluster sizes follow a power law: most entities hold one or two
+	// wallets, a few hold thousands
+	g.clusterOf = make([]int32, total)
+	g.sanctioned = make([]bool, total)
+	for start := g.exchangeWallets; start < total; {
+		size := int(math.Min(math.Floor(math.Pow(1-rng.Float64(), -1/1.5)), 5000))
+		if start+size > total {
+			size = total - start
+		}
+		c := cluster{start: int32(start), size: int32(size), chain: g.pickChain(), risk: g.pickRisk()}
+		for i := start; i < start+size; i++ {
+			g.clusterOf[i] = int32(len(g.clusters))
+			// A share of the wallets of the riskiest clusters are sanctioned
+			g.sanctioned[i] = c.risk >= 70 && rng.Float64() < 0.05
+		}
+		g.clusters = append(g.clusters, c)
+		start += size
+	}
+
+	// The busiest wallets are spread over the clusters rather than being the
+	// first ones
+	g.activity = make([]int32, opts.Wallets)
+	for i, j := range rng.Perm(opts.Wallets) {
+		g.activity[i] = int32(g.exchangeWallets + j)
+	}
+	g.zipf = rand.NewZipf(rng, 1.07, 10, uint64(opts.Wallets-1))
+	g.stats = make([]walletStats, total)
+
+	return g, nil
+}
+
+// Next returns the next transaction, or false once all have been generated
+func (g *Generator) Next() (Record, bool) {
+	if g.count >= g.opts.Transactions {
+		return Record{}, false
+	}
+	offset := time.Duration(g.step*float64(g.count) + g.step*g.rng.Float64())
+	timestamp := g.opts.Start.Add(offset).Truncate(time.Second)
+	g.count++
+
+	from, to := g.pickCounterparties()
+	chain := g.walletChain(from)
+	usd := math.Min(g.opts.MinAmountUSD*math.Pow(1-g.rng.Float64(), -1/g.opts.AmountAlpha), maxAmountUSD)
+	amountUSD := decimal.NewFromFloat(usd).Round(2)
+	amount := decimal.NewFromFloat(usd / chains[chain].price).Round(8)
+
+	tx := &domain.Transaction{
+		ID:          g.uuidString(),
+		TxHash:      g.txHash(chain),
+		Chain:       chains[chain].name,
+		FromAddress: g.address(from),
+		Amount:      amount,
+		AmountUSD:   amountUSD,
+		TxTimestamp: timestamp,
+		CreatedAt:   timestamp,
+	}
+	toAddress := g.address(to)
+	tx.ToAddress = &toAddress
+	block := blockBase[tx.Chain] + int64(timestamp.Sub(blockEpoch).Seconds())/blockTime[tx.Chain]
+	tx.BlockNumber = &block
+	if tx.Chain == "ethereum" {
+		gasUsed := int64(21000)
+		gasPrice := decimal.New(int64(10+g.rng.Intn(40)), -9)
+		gasFee := gasPrice.Mul(decimal.NewFromInt(gasUsed)).Mul(decimal.NewFromFloat(chains[chain].price)).Round(8)
+		tx.GasUsed, tx.GasPrice, tx.GasFeeUSD = &gasUsed, &gasPrice, &gasFee
+	}
+
+	alertType, reason := g.score(tx, from, to, usd)
+	record := Record{Transaction: tx}
+	if tx.Flagged {
+		tx.FlagReason = &reason
+		record.Alert = g.alert(tx, alertType, reason)
+	}
+
+	g.track(from, tx, usd)
+	g.track(to, tx, usd)
+	return record, true
+}
+
+// Wallets returns every exchange and user wallet with the activity of the
+// transactions generated so far
+func (g *Generator) Wallets() []Wallet {
+	// Wallet IDs come from their own source so they do not depend on how
+	// many transactions were generated
+	rng := rand.New(rand.NewSource(g.opts.Seed + 1))
+	wallets := make([]Wallet, 0, len(g.stats))
+
+	for i := range g.stats {
+		s := g.stats[i]
+		w := Wallet{
+			ID:        newUUID(rng),
+			Address:   g.address(i),
+			Chain:     chains[g.walletChain(i)].name,
+			TxCount:   int(s.txCount),
+			FlagCount: int(s.flagCount),
+		}
+		if s.txCount > 0 {
+			first, last := time.Unix(s.firstSeen, 0).UTC(), time.Unix(s.lastSeen, 0).UTC()
+			w.FirstSeen, w.LastSeen = &first, &last
+			w.TotalVolumeUSD = decimal.NewFromFloat(s.volumeUSD).Round(2)
+			w.AvgTxValueUSD = decimal.NewFromFloat(s.volumeUSD / float64(s.txCount)).Round(2)
+		}
+
+		if i < g.exchangeWallets {
+			entityType := "EXCHANGE"
+			entityName := exchangeName(i / (len(chains) * g.opts.HotWallets))
+			w.KnownEntityType, w.KnownEntityName = &entityType, &entityName
+			w.Labels = []string{"exchange", "hot_wallet"}
+			w.RiskScore = 5
+		} else {
+			c := g.clusters[g.clusterOf[i]]
+			w.Labels = []string{fmt.Sprintf("cluster:%d", g.clusterOf[i])}
+			w.RiskScore = int(c.risk)
+			if g.sanctioned[i] {
+				w.IsSanctioned = true
+				w.RiskScore = 100
+				w.Labels = append(w.Labels, "sanctioned")
+			}
+		}
+		w.RiskLevel = riskLevel(w.RiskScore)
+		wallets = append(wallets, w)
+	}
+	return wallets
+}
+
+// SanctionedAddresses returns the sanctions list entries of the sanctioned wallets
+func (g *Generator) SanctionedAddresses() []domain.SanctionedAddress {
+	rng := rand.New(rand.NewSource(g.opts.Seed + 2))
+	var addresses []domain.SanctionedAddress
+	for i, sanctioned := range g.sanctioned {
+		if !sanctioned {
+			continue
+		}
+		addresses = append(addresses, domain.SanctionedAddress{
+			ID:         uuidString(newUUID(rng)),
+			Address:    g.address(i),
+			Chain:      chains[g.walletChain(i)].name,
+			SourceList: "SEED",
+			Reason:     fmt.Sprintf("Synthetic sanctioned entity of cluster %d", g.clusterOf[i]),
+			EntityName: fmt.Sprintf("Synthetic Entity %d", g.clusterOf[i]),
+			EntityType: "ORGANIZATION",
+			AddedAt:    g.opts.Start,
+		})
+	}
+	return addresses
+}
+
+// pickCounterparties picks the sender and receiver of the next transaction:
+// deposits to and withdrawals from exchanges, transfers within a cluster and
+// transfers between unrelated wallets on the same chain
+func (g *Generator) pickCounterparties() (from, to int) {
+	user := int(g.activity[g.zipf.Uint64()])
+	c := g.clusters[g.clusterOf[user]]
+
+	switch p := g.rng.Float64(); {
+	case p < 0.35:
+		return user, g.pickExchangeWallet(c.chain)
+	case p < 0.60:
+		return g.pickExchangeWallet(c.chain), user
+	case p < 0.85 && c.size > 1:
+		return user, g.pickInCluster(c, user)
+	}
+
+	other := int(g.activity[g.zipf.Uint64()])
+	if other == user || g.clusters[g.clusterOf[other]].chain != c.chain {
+		// Fall back to an exchange so both sides stay on the same chain
+		return user, g.pickExchangeWallet(c.chain)
+	}
+	return user, other
+}
+
+// pickExchangeWallet picks a hot wallet on the chain; larger exchanges, with
+// lower indexes, receive more traffic
+func (g *Generator) pickExchangeWallet(chain uint8) int {
+	exchange := int(float64(g.opts.Exchanges) * math.Pow(g.rng.Float64(), 2))
+	return (exchange*len(chains)+int(chain))*g.opts.HotWallets + g.rng.Intn(g.opts.HotWallets)
+}
+
+// pickInCluster picks another wallet of the cluster
+func (g *Generator) pickInCluster(c cluster, except int) int {
+	i := int(c.start) + g.rng.Intn(int(c.size)-1)
+	if i >= except {
+		i++
+	}
+	return i
+}
+
+// pickChain picks the chain of a new cluster
+func (g *Generator) pickChain() uint8 {
+	p := g.rng.Float64()
+	for i, chain := range chains {
+		if p < chain.share {
+			return uint8(i)
+		}
+		p -= chain.share
+	}
+	return 0
+}
+
+// pickRisk picks the base risk score of a new cluster: most are benign, some
+// are gambling or high-risk services and a few are mixers
+func (g *Generator) pickRisk() uint8 {
+	switch p := g.rng.Float64(); {
+	case p < 0.90:
+		return uint8(g.rng.Intn(16))
+	case p < 0.98:
+		return uint8(30 + g.rng.Intn(26))
+	}
+	return uint8(70 + g.rng.Intn(26))
+}
+
+// score sets the transaction's risk score from its counterparties and amount,
+// flagging it at the alert threshold, and returns the alert type and reason
+func (g *Generator) score(tx *domain.Transaction, from, to int, usd float64) (string, string) {
+	risk := math.Max(float64(g.walletRisk(from)), float64(g.walletRisk(to)))
+	alertType, reason := "MIXING_PATTERN", "Counterparty cluster linked to mixing services"
+
+	switch {
+	case usd >= 100_000:
+		risk += 25
+	case usd >= 10_000:
+		risk += 10
+	}
+	if usd >= 10_000 && risk < 70 {
+		alertType, reason = "AMOUNT_THRESHOLD", fmt.Sprintf("Transfer of %s USD above reporting threshold", tx.AmountUSD)
+	}
+	risk += float64(g.rng.Intn(6))
+
+	if g.sanctioned[from] || g.sanctioned[to] {
+		risk = 100
+		alertType, reason = "SANCTIONS_LIST", "Counterparty on sanctions list"
+	}
+
+	tx.RiskScore = int(math.Min(risk, 100))
+	tx.Flagged = tx.RiskScore >= g.opts.AlertThreshold
+	return alertType, reason
+}
+
+// alert builds the alert of a flagged transaction. Alerts older than 30 days
+// at the end of the period are mostly resolved.
+func (g *Generator) alert(tx *domain.Transaction, alertType, reason string) *Alert {
+	severity := "HIGH"
+	if tx.RiskScore >= 90 {
+		severity = "CRITICAL"
+	}
+
+	alert := &Alert{
+		ID:            g.uuid(),
+		AlertType:     alertType,
+		WalletAddress: tx.FromAddress,
+		Severity:      severity,
+		RiskScore:     tx.RiskScore,
+		Status:        "OPEN",
+		Title:         fmt.Sprintf("%s on %s transaction", alertType, tx.Chain),
+		Description:   reason,
+		CreatedAt:     tx.TxTimestamp,
+	}
+	alert.TransactionID = parseUUID(tx.ID)
+
+	if g.opts.End.Sub(tx.TxTimestamp) > 30*24*time.Hour && g.rng.Float64() < 0.7 {
+		resolvedAt := tx.TxTimestamp.Add(time.Duration(1+g.rng.Intn(14*24)) * time.Hour)
+		resolution := "Reviewed, no further action"
+		alert.Status, alert.ResolvedAt, alert.Resolution = "RESOLVED", &resolvedAt, &resolution
+	}
+	return alert
+}
+
+// track adds the transaction to a wallet's activity
+func (g *Generator) track(wallet int, tx *domain.Transaction, usd float64) {
+	s := &g.stats[wallet]
+	ts := tx.TxTimestamp.Unix()
+	if s.txCount == 0 || ts < s.firstSeen {
+		s.firstSeen = ts
+	}
+	if ts > s.lastSeen {
+		s.lastSeen = ts
+	}
+	s.txCount++
+	s.volumeUSD += usd
+	if tx.Flagged {
+		s.flagCount++
+	}
+}
+
+// walletRisk returns the base risk score of a wallet
+func (g *Generator) walletRisk(wallet int) int {
+	if wallet < g.exchangeWallets {
+		return 5
+	}
+	return int(g.clusters[g.clusterOf[wallet]].risk)
+}
+
+// walletChain returns the index of a wallet's chain
+func (g *Generator) walletChain(wallet int) uint8 {
+	if wallet < g.exchangeWallets {
+		return uint8(wallet / g.opts.HotWallets % len(chains))
+	}
+	return g.clusters[g.clusterOf[wallet]].chain
+}
+
+// address derives a wallet's address from the seed and its index, so
+// addresses need not be kept in memory
+func (g *Generator) address(wallet int) string {
+	var b [24]byte
+	x := uint64(g.opts.Seed)<<32 ^ uint64(wallet)
+	for i := 0; i < len(b); i += 8 {
+		x = splitmix64(x)
+		binary.BigEndian.PutUint64(b[i:], x)
+	}
+
+	if chains[g.walletChain(wallet)].name == "bitcoin" {
+		const bech32 = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+		out := make([]byte, 0, 42)
+		out = append(out, "bc1q"...)
+		for i := 0; i < 38; i++ {
+			out = append(out, bech32[b[i%len(b)]>>(i/len(b)*3)&31])
+		}
+		return string(out)
+	}
+	return "0x" + hex.EncodeToString(b[:20])
+}
+
+// txHash generates a transaction hash in the chain's format
+func (g *Generator) txHash(chain uint8) string {
+	var b [32]byte
+	g.rng.Read(b[:])
+	if chains[chain].name == "ethereum" {
+		return "0x" + hex.EncodeToString(b[:])
+	}
+	return hex.EncodeToString(b[:])
+}
+
+func (g *Generator) uuid() [16]byte {
+	return newUUID(g.rng)
+}
+
+func (g *Generator) uuidString() string {
+	return uuidString(g.uuid())
+}
+
+// exchangeName names the exchange with the given rank
+func exchangeName(exchange int) string {
+	return fmt.Sprintf("Exchange %03d", exchange+1)
+}
+
+// riskLevel maps a risk score to the risk scoring service's levels
+func riskLevel(score int) string {
+	switch {
+	case score >= 80:
+		return "CRITICAL"
+	case score >= 60:
+		return "HIGH"
+	case score >= 40:
+		return "MEDIUM"
+	case score >= 20:
+		return "LOW"
+	default:
+		return "MINIMAL"
+	}
+}
+
+// newUUID draws a version 4 UUID from the random source
+func newUUID(rng *rand.Rand) [16]byte {
+	var id [16]byte
+	rng.Read(id[:])
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return id
+}
+
+func uuidString(id [16]byte) string {
+	h := hex.EncodeToString(id[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// parseUUID parses a UUID formatted by uuidString
+func parseUUID(s string) [16]byte {
+	var id [16]byte
+	hex.Decode(id[:], []byte(s[0:8]+s[9:13]+s[14:18]+s[19:23]+s[24:]))
+	return id
+}
+
+// splitmix64 is a fast 64-bit mixing function, used to derive addresses
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
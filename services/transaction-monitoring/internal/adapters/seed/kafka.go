@@ -0,0 +1,64 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink publishes generated transactions to the topic the service
+// consumes, so they go through scoring like live traffic. Alerts are left to
+// the service to raise.
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink creates a new Kafka sink for a comma-separated broker list
+func NewKafkaSink(brokers, topic string) *KafkaSink {
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(strings.Split(brokers, ",")...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			BatchSize:    1000,
+			BatchTimeout: 50 * time.Millisecond,
+			RequiredAcks: kafka.RequireOne,
+			Compression:  kafka.Snappy,
+		},
+	}
+}
+
+// WriteBatch publishes a batch of transactions keyed by sender, so each
+// wallet's transactions stay in order on one partition
+func (s *KafkaSink) WriteBatch(ctx context.Context, records []Record) error {
+	messages := make([]kafka.Message, 0, len(records))
+	for _, r := range records {
+		tx := *r.Transaction
+		// Scores are left to the service
+		tx.RiskScore, tx.Flagged, tx.FlagReason = 0, false, nil
+
+		value, err := json.Marshal(&tx)
+		if err != nil {
+			return fmt.Errorf("failed to marshal transaction: %w", err)
+		}
+		messages = append(messages, kafka.Message{
+			Key:   []byte(tx.FromAddress),
+			Value: value,
+			Time:  tx.TxTimestamp,
+		})
+	}
+
+	if err := s.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("failed to publish %d transactions: %w", len(messages), err)
+	}
+	return nil
+}
+
+// Close flushes and closes the writer
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,173 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/adapters/repository/postgres"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// Columns written by the Postgres sink
+var (
+	transactionColumns = []string{
+		"id", "tx_hash", "chain", "block_number", "from_address", "to_address",
+		"amount", "amount_usd", "gas_used", "gas_price", "gas_fee_usd", "tx_timestamp",
+		"risk_score", "flagged", "flag_reason", "created_at", "updated_at",
+	}
+	transactionKeyColumns = []string{
+		"tx_hash", "id", "tx_timestamp",
+	}
+	alertColumns = []string{
+		"id", "alert_type", "transaction_id", "wallet_address", "severity", "risk_score",
+		"status", "title", "description", "resolved_at", "resolution", "created_at", "updated_at",
+	}
+	walletColumns = []string{
+		"id", "address", "chain", "first_seen", "last_seen", "tx_count", "total_volume_usd",
+		"avg_tx_value_usd", "risk_score", "risk_level", "is_sanctioned", "flag_count", "labels",
+		"known_entity_type", "known_entity_name", "created_at", "updated_at",
+	}
+	sanctionColumns = []string{
+		"id", "address", "chain", "source_list", "reason", "entity_name", "entity_type", "is_active", "added_at",
+	}
+)
+
+// seededTables are emptied by Reset. Risk assessments are included because
+// they reference the seeded transaction keys.
+var seededTables = []string{
+	"alerts", "risk_assessments", "transactions", "transaction_keys", "wallet_profiles", "sanctioned_addresses",
+}
+
+// PostgresSink writes generated data with COPY, bypassing the repositories so
+// millions of rows load in minutes
+type PostgresSink struct {
+	conn   *postgres.Connection
+	logger *zap.Logger
+}
+
+// NewPostgresSink creates a new Postgres sink
+func NewPostgresSink(conn *postgres.Connection, logger *zap.Logger) *PostgresSink {
+	return &PostgresSink{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// Prepare creates the monthly transaction partitions covering [start, end),
+// so seeded rows do not all land in the default partition
+func (s *PostgresSink) Prepare(ctx context.Context, start, end time.Time) error {
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	maintainer := postgres.NewPartitionMaintainer(s.conn, postgres.PartitionConfig{
+		Table:    "transactions",
+		Interval: postgres.PartitionMonthly,
+		Premake:  months,
+	}, s.logger)
+	return maintainer.CreatePartitions(ctx, start)
+}
+
+// Reset empties the tables the sink writes to. They are truncated in one
+// statement, as Postgres refuses to truncate transaction_keys on its own while
+// foreign keys reference it.
+func (s *PostgresSink) Reset(ctx context.Context) error {
+	tables := make([]string, len(seededTables))
+	for i, table := range seededTables {
+		tables[i] = pgx.Identifier{table}.Sanitize()
+	}
+	if _, err := s.conn.GetPool().Exec(ctx, "TRUNCATE "+strings.Join(tables, ", ")); err != nil {
+		return fmt.Errorf("failed to truncate seeded tables: %w", err)
+	}
+	return nil
+}
+
+// WriteBatch copies a batch of transactions, their keys and their alerts
+func (s *PostgresSink) WriteBatch(ctx context.Context, records []Record) error {
+	now := time.Now().UTC()
+	txRows := make([][]interface{}, 0, len(records))
+	keyRows := make([][]interface{}, 0, len(records))
+	var alertRows [][]interface{}
+
+	for _, r := range records {
+		tx := r.Transaction
+		txRows = append(txRows, []interface{}{
+			uuidValue(parseUUID(tx.ID)), tx.TxHash, tx.Chain, tx.BlockNumber, tx.FromAddress, tx.ToAddress,
+			numeric(tx.Amount), numeric(tx.AmountUSD), tx.GasUsed, nullableNumeric(tx.GasPrice),
+			nullableNumeric(tx.GasFeeUSD), tx.TxTimestamp, tx.RiskScore, tx.Flagged, tx.FlagReason,
+			tx.CreatedAt, now,
+		})
+		keyRows = append(keyRows, []interface{}{tx.TxHash, uuidValue(parseUUID(tx.ID)), tx.TxTimestamp})
+
+		if a := r.Alert; a != nil {
+			alertRows = append(alertRows, []interface{}{
+				uuidValue(a.ID), a.AlertType, uuidValue(a.TransactionID), a.WalletAddress, a.Severity,
+				a.RiskScore, a.Status, a.Title, a.Description, a.ResolvedAt, a.Resolution, a.CreatedAt, now,
+			})
+		}
+	}
+
+	if err := s.copy(ctx, "transaction_keys", transactionKeyColumns, keyRows); err != nil {
+		return err
+	}
+	if err := s.copy(ctx, "transactions", transactionColumns, txRows); err != nil {
+		return err
+	}
+	return s.copy(ctx, "alerts", alertColumns, alertRows)
+}
+
+// WriteWallets copies the wallet profiles
+func (s *PostgresSink) WriteWallets(ctx context.Context, wallets []Wallet) error {
+	now := time.Now().UTC()
+	rows := make([][]interface{}, 0, len(wallets))
+	for _, w := range wallets {
+		rows = append(rows, []interface{}{
+			uuidValue(w.ID), w.Address, w.Chain, w.FirstSeen, w.LastSeen, w.TxCount,
+			numeric(w.TotalVolumeUSD), numeric(w.AvgTxValueUSD), w.RiskScore, w.RiskLevel,
+			w.IsSanctioned, w.FlagCount, w.Labels, w.KnownEntityType, w.KnownEntityName, now, now,
+		})
+	}
+	return s.copy(ctx, "wallet_profiles", walletColumns, rows)
+}
+
+// WriteSanctions copies the sanctions list entries
+func (s *PostgresSink) WriteSanctions(ctx context.Context, addresses []domain.SanctionedAddress) error {
+	rows := make([][]interface{}, 0, len(addresses))
+	for _, a := range addresses {
+		rows = append(rows, []interface{}{
+			uuidValue(parseUUID(a.ID)), a.Address, a.Chain, a.SourceList, a.Reason, a.EntityName, a.EntityType,
+			true, a.AddedAt,
+		})
+	}
+	return s.copy(ctx, "sanctioned_addresses", sanctionColumns, rows)
+}
+
+func (s *PostgresSink) copy(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if _, err := s.conn.GetPool().CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows)); err != nil {
+		return fmt.Errorf("failed to copy %d rows into %s: %w", len(rows), table, err)
+	}
+	return nil
+}
+
+// numeric converts a decimal for the binary COPY protocol, which does not
+// accept decimals as text
+func numeric(d decimal.Decimal) pgtype.Numeric {
+	return pgtype.Numeric{Int: d.Coefficient(), Exp: d.Exponent(), Valid: true}
+}
+
+func nullableNumeric(d *decimal.Decimal) pgtype.Numeric {
+	if d == nil {
+		return pgtype.Numeric{}
+	}
+	return numeric(*d)
+}
+
+func uuidValue(id [16]byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
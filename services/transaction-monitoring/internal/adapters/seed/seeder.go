@@ -0,0 +1,175 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BatchSink receives batches of generated transactions
+type BatchSink interface {
+	WriteBatch(ctx context.Context, records []Record) error
+}
+
+// Summary counts what a seeding run wrote
+type Summary struct {
+	Transactions int64
+	Alerts       int64
+	Wallets      int
+	Sanctioned   int
+	Duration     time.Duration
+}
+
+// Seeder generates a data set and writes it to Postgres and/or Kafka.
+// Generation runs on one goroutine so the data only depends on the options;
+// writes are spread over workers.
+type Seeder struct {
+	generator *Generator
+	postgres  *PostgresSink
+	sinks     []BatchSink
+	batchSize int
+	workers   int
+	logger    *zap.Logger
+}
+
+// NewSeeder creates a new seeder. Either sink may be nil, but not both.
+// Wallet profiles and sanctions are only written to Postgres.
+func NewSeeder(generator *Generator, pg *PostgresSink, kafka *KafkaSink, batchSize, workers int, logger *zap.Logger) (*Seeder, error) {
+	s := &Seeder{
+		generator: generator,
+		postgres:  pg,
+		batchSize: batchSize,
+		workers:   workers,
+		logger:    logger,
+	}
+	if pg != nil {
+		s.sinks = append(s.sinks, pg)
+	}
+	if kafka != nil {
+		s.sinks = append(s.sinks, kafka)
+	}
+	if len(s.sinks) == 0 {
+		return nil, fmt.Errorf("no sink to seed")
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = 10000
+	}
+	if s.workers <= 0 {
+		s.workers = 1
+	}
+	return s, nil
+}
+
+// Run generates and writes the whole data set. It stops at the first failed
+// write; rows already written are kept.
+func (s *Seeder) Run(ctx context.Context) (*Summary, error) {
+	started := time.Now()
+	opts := s.generator.opts
+	summary := &Summary{}
+
+	if s.postgres != nil {
+		if err := s.postgres.Prepare(ctx, opts.Start, opts.End); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan []Record, s.workers)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		writeErr error
+	)
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				for _, sink := range s.sinks {
+					if err := sink.WriteBatch(ctx, batch); err != nil {
+						errOnce.Do(func() {
+							writeErr = err
+							cancel()
+						})
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	progressEvery := opts.Transactions / 20
+	if progressEvery == 0 {
+		progressEvery = 1
+	}
+	nextProgress := progressEvery
+
+generate:
+	for {
+		batch := make([]Record, 0, s.batchSize)
+		for len(batch) < s.batchSize {
+			r, ok := s.generator.Next()
+			if !ok {
+				break
+			}
+			if r.Alert != nil {
+				summary.Alerts++
+			}
+			batch = append(batch, r)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		summary.Transactions += int64(len(batch))
+
+		select {
+		case batches <- batch:
+		case <-ctx.Done():
+			break generate
+		}
+
+		if summary.Transactions >= nextProgress {
+			s.logger.Info("Seeding transactions",
+				zap.Int64("generated", summary.Transactions),
+				zap.Int64("total", opts.Transactions),
+				zap.Duration("elapsed", time.Since(started)),
+			)
+			nextProgress += progressEvery
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	if writeErr != nil {
+		return nil, writeErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if s.postgres != nil {
+		wallets := s.generator.Wallets()
+		for i := 0; i < len(wallets); i += s.batchSize {
+			end := i + s.batchSize
+			if end > len(wallets) {
+				end = len(wallets)
+			}
+			if err := s.postgres.WriteWallets(ctx, wallets[i:end]); err != nil {
+				return nil, err
+			}
+		}
+		sanctioned := s.generator.SanctionedAddresses()
+		if err := s.postgres.WriteSanctions(ctx, sanctioned); err != nil {
+			return nil, err
+		}
+		summary.Wallets, summary.Sanctioned = len(wallets), len(sanctioned)
+	}
+
+	summary.Duration = time.Since(started)
+	return summary, nil
+}
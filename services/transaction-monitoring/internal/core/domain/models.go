@@ -1,7 +1,15 @@
 package domain
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Transaction represents an on-chain transaction
@@ -13,11 +21,11 @@ type Transaction struct {
 	FromAddress    string                 `json:"from_address" db:"from_address"`
 	ToAddress      *string                `json:"to_address,omitempty" db:"to_address"`
 	TokenAddress   *string                `json:"token_address,omitempty" db:"token_address"`
-	Amount         float64                `json:"amount" db:"amount"`
-	AmountUSD      float64                `json:"amount_usd" db:"amount_usd"`
+	Amount         decimal.Decimal        `json:"amount" db:"amount"`
+	AmountUSD      decimal.Decimal        `json:"amount_usd" db:"amount_usd"`
 	GasUsed        *int64                 `json:"gas_used,omitempty" db:"gas_used"`
-	GasPrice       *float64               `json:"gas_price,omitempty" db:"gas_price"`
-	GasFeeUSD      *float64               `json:"gas_fee_usd,omitempty" db:"gas_fee_usd"`
+	GasPrice       *decimal.Decimal       `json:"gas_price,omitempty" db:"gas_price"`
+	GasFeeUSD      *decimal.Decimal       `json:"gas_fee_usd,omitempty" db:"gas_fee_usd"`
 	Nonce          *int                   `json:"nonce,omitempty" db:"nonce"`
 	TxTimestamp    time.Time              `json:"tx_timestamp" db:"tx_timestamp"`
 	RiskScore      int                    `json:"risk_score" db:"risk_score"`
@@ -30,6 +38,10 @@ type Transaction struct {
 	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
 }
 
+// ErrTransactionExists is returned when a transaction's hash is already
+// recorded, whatever timestamp it was recorded with
+var ErrTransactionExists = errors.New("transaction already recorded")
+
 // RiskFactor represents a specific risk indicator
 type RiskFactor struct {
 	Type       string  `json:"type"`
@@ -53,6 +65,25 @@ type SanctionedAddress struct {
 	ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
 }
 
+// ErrNotSanctioned is returned when an address is on no active sanctions list
+var ErrNotSanctioned = errors.New("address not found in sanctions list")
+
+// SanctionsScreenStats counts how address checks against the sanctions list
+// were answered. Only possible hits of the in-memory filter reach the cache or
+// the database; FalsePositives are those the database then cleared.
+type SanctionsScreenStats struct {
+	Entries           int       `json:"entries"`
+	LoadedAt          time.Time `json:"loaded_at"`
+	Checks            int64     `json:"checks"`
+	Negatives         int64     `json:"negatives"`
+	CacheHits         int64     `json:"cache_hits"`
+	DatabaseLookups   int64     `json:"database_lookups"`
+	Hits              int64     `json:"hits"`
+	FalsePositives    int64     `json:"false_positives"`
+	Errors            int64     `json:"errors"`
+	FalsePositiveRate float64   `json:"false_positive_rate"`
+}
+
 // WalletProfile represents a wallet's risk profile
 type WalletProfile struct {
 	ID              string                 `json:"id" db:"id"`
@@ -61,8 +92,8 @@ type WalletProfile struct {
 	FirstSeen       *time.Time             `json:"first_seen,omitempty" db:"first_seen"`
 	LastSeen        *time.Time             `json:"last_seen,omitempty" db:"last_seen"`
 	TxCount         int                    `json:"tx_count" db:"tx_count"`
-	TotalVolumeUSD  float64                `json:"total_volume_usd" db:"total_volume_usd"`
-	AvgTxValueUSD   float64                `json:"avg_tx_value_usd" db:"avg_tx_value_usd"`
+	TotalVolumeUSD  decimal.Decimal        `json:"total_volume_usd" db:"total_volume_usd"`
+	AvgTxValueUSD   decimal.Decimal        `json:"avg_tx_value_usd" db:"avg_tx_value_usd"`
 	ConnectedTags   []string               `json:"connected_tags" db:"connected_tags"`
 	RiskIndicators  []RiskIndicator        `json:"risk_indicators"`
 	WalletAgeHours  int                    `json:"wallet_age_hours" db:"wallet_age_hours"`
@@ -120,8 +151,8 @@ type TransactionFilter struct {
 	Chain        string     `form:"chain"`
 	FromAddress  string     `form:"from_address"`
 	ToAddress    string     `form:"to_address"`
-	MinAmountUSD float64    `form:"min_amount_usd"`
-	MaxAmountUSD float64    `form:"max_amount_usd"`
+	MinAmountUSD decimal.Decimal `form:"min_amount_usd"`
+	MaxAmountUSD decimal.Decimal `form:"max_amount_usd"`
 	Flagged      *bool      `form:"flagged"`
 	MinRiskScore int        `form:"min_risk_score"`
 	StartTime    *time.Time `form:"start_time"`
@@ -151,7 +182,7 @@ type RiskReport struct {
 	PeriodStart     time.Time              `json:"period_start"`
 	PeriodEnd       time.Time              `json:"period_end"`
 	TotalTransactions int64                `json:"total_transactions"`
-	TotalVolumeUSD  float64                `json:"total_volume_usd"`
+	TotalVolumeUSD  decimal.Decimal        `json:"total_volume_usd"`
 	FlaggedCount    int64                  `json:"flagged_count"`
 	HighRiskCount   int64                  `json:"high_risk_count"`
 	AverageRiskScore float64               `json:"average_risk_score"`
@@ -170,7 +201,1066 @@ type RiskFactorSummary struct {
 // ChainStats contains statistics for a specific chain
 type ChainStats struct {
 	Transactions   int64   `json:"transactions"`
-	VolumeUSD      float64 `json:"volume_usd"`
+	VolumeUSD      decimal.Decimal `json:"volume_usd"`
 	Flagged        int64   `json:"flagged"`
 	AverageRisk    float64 `json:"average_risk"`
 }
+
+// RuleType identifies how a monitoring rule is evaluated
+type RuleType string
+
+const (
+	RuleTypeThreshold  RuleType = "THRESHOLD"
+	RuleTypeVelocity   RuleType = "VELOCITY"
+	RuleTypePattern    RuleType = "PATTERN"
+	RuleTypeGeographic RuleType = "GEOGRAPHIC"
+	RuleTypeSanctions  RuleType = "SANCTIONS"
+	RuleTypeExpression RuleType = "EXPRESSION"
+)
+
+// RuleSeverity represents the severity assigned to a rule match
+type RuleSeverity string
+
+const (
+	RuleSeverityInfo     RuleSeverity = "INFO"
+	RuleSeverityWarning  RuleSeverity = "WARNING"
+	RuleSeverityAlert    RuleSeverity = "ALERT"
+	RuleSeverityCritical RuleSeverity = "CRITICAL"
+)
+
+// IsValid reports whether the rule type is known
+func (t RuleType) IsValid() bool {
+	switch t {
+	case RuleTypeThreshold, RuleTypeVelocity, RuleTypePattern, RuleTypeGeographic, RuleTypeSanctions, RuleTypeExpression:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the severity is known
+func (s RuleSeverity) IsValid() bool {
+	switch s {
+	case RuleSeverityInfo, RuleSeverityWarning, RuleSeverityAlert, RuleSeverityCritical:
+		return true
+	}
+	return false
+}
+
+// MonitoringRule represents a configurable transaction monitoring rule.
+// Expression rules are evaluated from Expression; all other types read Condition.
+type MonitoringRule struct {
+	ID          string       `json:"id" db:"id"`
+	Name        string       `json:"name" db:"name"`
+	Description string       `json:"description" db:"description"`
+	RuleType    RuleType     `json:"rule_type" db:"rule_type"`
+	Condition   string       `json:"condition" db:"condition"`
+	Parameters  string       `json:"parameters,omitempty" db:"parameters"`
+	Expression  string       `json:"expression,omitempty" db:"expression"`
+	RiskWeight  float64      `json:"risk_weight" db:"risk_weight"`
+	Severity    RuleSeverity `json:"severity" db:"severity"`
+	IsActive    bool         `json:"is_active" db:"is_active"`
+	Priority    int          `json:"priority" db:"priority"`
+	Version     int          `json:"version" db:"version"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Rule errors
+var (
+	ErrInvalidRuleExpression = errors.New("invalid rule expression")
+	ErrRuleVersionNotFound   = errors.New("rule version not found")
+	ErrInvalidRuleTransition = errors.New("invalid rule version status transition")
+	ErrRuleSelfApproval      = errors.New("rule version cannot be approved by its author")
+)
+
+// RuleBacktestMatch is a historical transaction a rule would have flagged
+type RuleBacktestMatch struct {
+	TransactionID string    `json:"transaction_id"`
+	TxHash        string    `json:"tx_hash"`
+	AmountUSD     decimal.Decimal `json:"amount_usd"`
+	TxTimestamp   time.Time `json:"tx_timestamp"`
+	MatchDetail   string    `json:"match_detail"`
+	Flagged       bool      `json:"flagged"`
+}
+
+// RuleBacktestResult summarizes a dry-run replay of a rule over historical transactions
+type RuleBacktestResult struct {
+	RuleID                     string              `json:"rule_id"`
+	RuleName                   string              `json:"rule_name"`
+	Days                       int                 `json:"days"`
+	WindowStart                time.Time           `json:"window_start"`
+	WindowEnd                  time.Time           `json:"window_end"`
+	TransactionsEvaluated      int                 `json:"transactions_evaluated"`
+	HitCount                   int                 `json:"hit_count"`
+	HitRate                    float64             `json:"hit_rate"`
+	ReviewedHits               int                 `json:"reviewed_hits"`
+	FalsePositives             int                 `json:"false_positives"`
+	EstimatedFalsePositiveRate float64             `json:"estimated_false_positive_rate"`
+	EvaluationErrors           int                 `json:"evaluation_errors"`
+	MatchedVolume              decimal.Decimal     `json:"matched_volume"`
+	VolumeCurrency             string              `json:"volume_currency"`
+	UnvaluedMatches            int                 `json:"unvalued_matches"`
+	SampleMatches              []RuleBacktestMatch `json:"sample_matches"`
+	CompletedAt                time.Time           `json:"completed_at"`
+}
+
+// RuleVersionStatus represents the lifecycle state of a rule version
+type RuleVersionStatus string
+
+const (
+	RuleVersionDraft           RuleVersionStatus = "draft"
+	RuleVersionPendingApproval RuleVersionStatus = "pending_approval"
+	RuleVersionActive          RuleVersionStatus = "active"
+	RuleVersionSuperseded      RuleVersionStatus = "superseded"
+	RuleVersionRejected        RuleVersionStatus = "rejected"
+)
+
+// ruleVersionTransitions lists the allowed status changes for a rule version
+var ruleVersionTransitions = map[RuleVersionStatus][]RuleVersionStatus{
+	RuleVersionDraft:           {RuleVersionPendingApproval},
+	RuleVersionPendingApproval: {RuleVersionActive, RuleVersionRejected},
+	RuleVersionActive:          {RuleVersionSuperseded},
+}
+
+// RuleVersion is an immutable snapshot of a monitoring rule definition and its approval state
+type RuleVersion struct {
+	ID            string            `json:"id" db:"id"`
+	RuleID        string            `json:"rule_id" db:"rule_id"`
+	Version       int               `json:"version" db:"version"`
+	Status        RuleVersionStatus `json:"status" db:"status"`
+	Name          string            `json:"name" db:"name"`
+	Description   string            `json:"description" db:"description"`
+	RuleType      RuleType          `json:"rule_type" db:"rule_type"`
+	Condition     string            `json:"condition" db:"condition"`
+	Parameters    string            `json:"parameters,omitempty" db:"parameters"`
+	Expression    string            `json:"expression,omitempty" db:"expression"`
+	RiskWeight    float64           `json:"risk_weight" db:"risk_weight"`
+	Severity      RuleSeverity      `json:"severity" db:"severity"`
+	Priority      int               `json:"priority" db:"priority"`
+	ChangeNote    string            `json:"change_note,omitempty" db:"change_note"`
+	CreatedBy     string            `json:"created_by,omitempty" db:"created_by"`
+	SubmittedBy   string            `json:"submitted_by,omitempty" db:"submitted_by"`
+	SubmittedAt   *time.Time        `json:"submitted_at,omitempty" db:"submitted_at"`
+	ReviewedBy    string            `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt    *time.Time        `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	ReviewComment string            `json:"review_comment,omitempty" db:"review_comment"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+}
+
+// CanTransitionTo reports whether the version may move to the given status
+func (v *RuleVersion) CanTransitionTo(status RuleVersionStatus) bool {
+	for _, allowed := range ruleVersionTransitions[v.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// NewRuleVersion snapshots a rule definition as a new version
+func NewRuleVersion(rule *MonitoringRule, status RuleVersionStatus, createdBy, changeNote string) *RuleVersion {
+	return &RuleVersion{
+		RuleID:      rule.ID,
+		Status:      status,
+		Name:        rule.Name,
+		Description: rule.Description,
+		RuleType:    rule.RuleType,
+		Condition:   rule.Condition,
+		Parameters:  rule.Parameters,
+		Expression:  rule.Expression,
+		RiskWeight:  rule.RiskWeight,
+		Severity:    rule.Severity,
+		Priority:    rule.Priority,
+		ChangeNote:  changeNote,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// ApplyTo copies the version's definition onto the live rule
+func (v *RuleVersion) ApplyTo(rule *MonitoringRule) {
+	rule.Name = v.Name
+	rule.Description = v.Description
+	rule.RuleType = v.RuleType
+	rule.Condition = v.Condition
+	rule.Parameters = v.Parameters
+	rule.Expression = v.Expression
+	rule.RiskWeight = v.RiskWeight
+	rule.Severity = v.Severity
+	rule.Priority = v.Priority
+	rule.Version = v.Version
+}
+
+// RuleFieldChange describes a single field that differs between two rule versions
+type RuleFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// RuleVersionDiff lists the field-level changes between two versions of a rule
+type RuleVersionDiff struct {
+	RuleID      string            `json:"rule_id"`
+	FromVersion int               `json:"from_version"`
+	ToVersion   int               `json:"to_version"`
+	Changes     []RuleFieldChange `json:"changes"`
+}
+
+// DiffRuleVersions compares the definitions of two rule versions
+func DiffRuleVersions(from, to *RuleVersion) *RuleVersionDiff {
+	diff := &RuleVersionDiff{
+		RuleID:      to.RuleID,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Changes:     []RuleFieldChange{},
+	}
+
+	add := func(field string, a, b interface{}) {
+		if a != b {
+			diff.Changes = append(diff.Changes, RuleFieldChange{Field: field, From: a, To: b})
+		}
+	}
+
+	add("name", from.Name, to.Name)
+	add("description", from.Description, to.Description)
+	add("rule_type", from.RuleType, to.RuleType)
+	add("condition", from.Condition, to.Condition)
+	add("parameters", from.Parameters, to.Parameters)
+	add("expression", from.Expression, to.Expression)
+	add("risk_weight", from.RiskWeight, to.RiskWeight)
+	add("severity", from.Severity, to.Severity)
+	add("priority", from.Priority, to.Priority)
+
+	return diff
+}
+
+// RuleBundleSchemaVersion is the rule bundle format written by exports
+const RuleBundleSchemaVersion = 1
+
+// SupportedRuleBundleSchemas lists the rule bundle formats imports accept
+var SupportedRuleBundleSchemas = []int{1}
+
+// Rule bundle errors
+var (
+	ErrInvalidRuleBundle    = errors.New("invalid rule bundle")
+	ErrRuleBundleSchema     = errors.New("unsupported rule bundle schema version")
+	ErrRuleBundleSignature  = errors.New("rule bundle signature is invalid")
+	ErrRuleBundleUntrusted  = errors.New("rule bundle is signed by an untrusted key")
+	ErrRuleBundleSigningKey = errors.New("no rule bundle signing key is configured")
+)
+
+// RuleBundle is a signed set of monitoring rule definitions shared between
+// agencies. The signature covers the JSON encoding of the bundle without it.
+type RuleBundle struct {
+	SchemaVersion int              `json:"schema_version"`
+	BundleID      string           `json:"bundle_id"`
+	Issuer        string           `json:"issuer"`
+	ExportedAt    time.Time        `json:"exported_at"`
+	Rules         []RuleBundleRule `json:"rules"`
+	KeyID         string           `json:"key_id"`
+	Signature     string           `json:"signature,omitempty"`
+}
+
+// RuleBundleRule is a rule definition in a bundle. Rule IDs differ between
+// agencies, so imported rules are matched to existing rules by name.
+type RuleBundleRule struct {
+	// Ref is the rule's ID at the issuer
+	Ref           string       `json:"ref"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description,omitempty"`
+	RuleType      RuleType     `json:"rule_type"`
+	Condition     string       `json:"condition,omitempty"`
+	Parameters    string       `json:"parameters,omitempty"`
+	Expression    string       `json:"expression,omitempty"`
+	RiskWeight    float64      `json:"risk_weight"`
+	Severity      RuleSeverity `json:"severity"`
+	Priority      int          `json:"priority"`
+	SourceVersion int          `json:"source_version"`
+}
+
+// NewRuleBundleRule copies the live definition of a rule into a bundle
+func NewRuleBundleRule(rule *MonitoringRule) RuleBundleRule {
+	return RuleBundleRule{
+		Ref:           rule.ID,
+		Name:          rule.Name,
+		Description:   rule.Description,
+		RuleType:      rule.RuleType,
+		Condition:     rule.Condition,
+		Parameters:    rule.Parameters,
+		Expression:    rule.Expression,
+		RiskWeight:    rule.RiskWeight,
+		Severity:      rule.Severity,
+		Priority:      rule.Priority,
+		SourceVersion: rule.Version,
+	}
+}
+
+// Validate checks the bundled definition against the rule schema. Expressions
+// are compiled separately by the rule engine.
+func (r *RuleBundleRule) Validate() error {
+	switch {
+	case r.Ref == "":
+		return fmt.Errorf("%w: rule %q has no ref", ErrInvalidRuleBundle, r.Name)
+	case strings.TrimSpace(r.Name) == "":
+		return fmt.Errorf("%w: rule %s has no name", ErrInvalidRuleBundle, r.Ref)
+	case !r.RuleType.IsValid():
+		return fmt.Errorf("%w: rule %q has unknown type %q", ErrInvalidRuleBundle, r.Name, r.RuleType)
+	case !r.Severity.IsValid():
+		return fmt.Errorf("%w: rule %q has unknown severity %q", ErrInvalidRuleBundle, r.Name, r.Severity)
+	case r.Condition != "" && !json.Valid([]byte(r.Condition)):
+		return fmt.Errorf("%w: rule %q has an invalid condition", ErrInvalidRuleBundle, r.Name)
+	case r.Parameters != "" && !json.Valid([]byte(r.Parameters)):
+		return fmt.Errorf("%w: rule %q has invalid parameters", ErrInvalidRuleBundle, r.Name)
+	case r.RuleType == RuleTypeExpression && r.Expression == "":
+		return fmt.Errorf("%w: expression rule %q has no expression", ErrInvalidRuleBundle, r.Name)
+	}
+	return nil
+}
+
+// NewVersion records the bundled definition as a version of the rule with the
+// given ID, which is empty for a rule that does not exist yet
+func (r *RuleBundleRule) NewVersion(ruleID string, status RuleVersionStatus, createdBy, changeNote string) *RuleVersion {
+	return NewRuleVersion(&MonitoringRule{
+		ID:          ruleID,
+		Name:        r.Name,
+		Description: r.Description,
+		RuleType:    r.RuleType,
+		Condition:   r.Condition,
+		Parameters:  r.Parameters,
+		Expression:  r.Expression,
+		RiskWeight:  r.RiskWeight,
+		Severity:    r.Severity,
+		Priority:    r.Priority,
+	}, status, createdBy, changeNote)
+}
+
+// RuleImportAction is the effect importing a bundled rule has
+type RuleImportAction string
+
+const (
+	// RuleImportCreate creates a new, active rule
+	RuleImportCreate RuleImportAction = "create"
+	// RuleImportUpdate records a new version of an existing rule pending approval
+	RuleImportUpdate RuleImportAction = "update"
+	// RuleImportUnchanged leaves an identical existing rule alone
+	RuleImportUnchanged RuleImportAction = "unchanged"
+)
+
+// RuleImportItem describes the import of one bundled rule
+type RuleImportItem struct {
+	Ref      string            `json:"ref"`
+	Name     string            `json:"name"`
+	Action   RuleImportAction  `json:"action"`
+	RuleID   string            `json:"rule_id,omitempty"`
+	Changes  []RuleFieldChange `json:"changes,omitempty"`
+	Selected bool              `json:"selected"`
+}
+
+// RuleImportPlan is the diff of a bundle against the existing rules and,
+// unless it is a dry run, the versions recorded by applying it
+type RuleImportPlan struct {
+	BundleID      string           `json:"bundle_id"`
+	Issuer        string           `json:"issuer"`
+	SchemaVersion int              `json:"schema_version"`
+	KeyID         string           `json:"key_id"`
+	DryRun        bool             `json:"dry_run"`
+	Items         []RuleImportItem `json:"items"`
+	Versions      []*RuleVersion   `json:"versions,omitempty"`
+	AppliedAt     *time.Time       `json:"applied_at,omitempty"`
+}
+
+// ContractCategory classifies a smart contract in the contract registry
+type ContractCategory string
+
+const (
+	ContractMixer              ContractCategory = "MIXER"
+	ContractBridge             ContractCategory = "BRIDGE"
+	ContractDEX                ContractCategory = "DEX"
+	ContractSanctionedProtocol ContractCategory = "SANCTIONED_PROTOCOL"
+	ContractOther              ContractCategory = "OTHER"
+)
+
+// contractRiskWeights are the risk weights used when an entry does not set its own
+var contractRiskWeights = map[ContractCategory]float64{
+	ContractMixer:              60,
+	ContractBridge:             15,
+	ContractDEX:                5,
+	ContractSanctionedProtocol: 100,
+	ContractOther:              0,
+}
+
+// IsValid reports whether the category is known
+func (c ContractCategory) IsValid() bool {
+	_, ok := contractRiskWeights[c]
+	return ok
+}
+
+// DefaultRiskWeight returns the risk weight for contracts of this category
+func (c ContractCategory) DefaultRiskWeight() float64 {
+	return contractRiskWeights[c]
+}
+
+// ContractSourceManual marks registry entries maintained through the API.
+// Feed syncs never modify them.
+const ContractSourceManual = "manual"
+
+// Contract registry errors
+var (
+	ErrContractNotFound = errors.New("registered contract not found")
+	ErrInvalidContract  = errors.New("invalid registered contract")
+	ErrContractExists   = errors.New("contract already registered")
+)
+
+// RegisteredContract is a known smart contract whose interactions carry risk
+type RegisteredContract struct {
+	ID         string           `json:"id" db:"id"`
+	Chain      string           `json:"chain" db:"chain"`
+	Address    string           `json:"address" db:"address"`
+	Name       string           `json:"name" db:"name"`
+	Protocol   string           `json:"protocol,omitempty" db:"protocol"`
+	Category   ContractCategory `json:"category" db:"category"`
+	RiskWeight float64          `json:"risk_weight" db:"risk_weight"`
+	Tags       []string         `json:"tags,omitempty" db:"tags"`
+	Source     string           `json:"source" db:"source"`
+	Active     bool             `json:"active" db:"active"`
+	Notes      string           `json:"notes,omitempty" db:"notes"`
+	CreatedBy  string           `json:"created_by,omitempty" db:"created_by"`
+	UpdatedBy  string           `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt  time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// Normalize canonicalises the chain and address so lookups match regardless of
+// case. Hex addresses are case-insensitive; other address formats are kept as is.
+func (c *RegisteredContract) Normalize() {
+	c.Chain = strings.ToLower(strings.TrimSpace(c.Chain))
+	c.Address = NormalizeContractAddress(c.Address)
+	c.Category = ContractCategory(strings.ToUpper(strings.TrimSpace(string(c.Category))))
+}
+
+// Validate checks the entry's required fields and risk weight
+func (c *RegisteredContract) Validate() error {
+	switch {
+	case c.Chain == "":
+		return fmt.Errorf("%w: chain is required", ErrInvalidContract)
+	case c.Address == "":
+		return fmt.Errorf("%w: address is required", ErrInvalidContract)
+	case !c.Category.IsValid():
+		return fmt.Errorf("%w: unknown category %q", ErrInvalidContract, c.Category)
+	case c.RiskWeight < 0 || c.RiskWeight > 100:
+		return fmt.Errorf("%w: risk weight must be between 0 and 100", ErrInvalidContract)
+	}
+	return nil
+}
+
+// NormalizeContractAddress lower-cases 0x-prefixed hex addresses
+func NormalizeContractAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// ContractFilter selects registry entries
+type ContractFilter struct {
+	Chain           string           `json:"chain,omitempty"`
+	Category        ContractCategory `json:"category,omitempty"`
+	Source          string           `json:"source,omitempty"`
+	Query           string           `json:"query,omitempty"`
+	IncludeInactive bool             `json:"include_inactive"`
+	Limit           int              `json:"limit"`
+	Offset          int              `json:"offset"`
+}
+
+// ContractSyncResult summarises one curated feed sync
+type ContractSyncResult struct {
+	Feed        string    `json:"feed"`
+	Fetched     int       `json:"fetched"`
+	Skipped     int       `json:"skipped"`
+	Upserted    int       `json:"upserted"`
+	Deactivated int       `json:"deactivated"`
+	Error       string    `json:"error,omitempty"`
+	SyncedAt    time.Time `json:"synced_at"`
+}
+
+// Exchange rate errors
+var (
+	ErrRateNotFound = errors.New("no exchange rate available")
+	ErrInvalidRate  = errors.New("invalid exchange rate")
+)
+
+// FXRate is a daily reference rate: the price of one unit of Base in Quote.
+// Bases and quotes are ISO 4217 currency codes or crypto asset tickers.
+type FXRate struct {
+	Base      string          `json:"base" db:"base"`
+	Quote     string          `json:"quote" db:"quote"`
+	Rate      decimal.Decimal `json:"rate" db:"rate"`
+	RateDate  time.Time       `json:"rate_date" db:"rate_date"`
+	Source    string          `json:"source" db:"source"`
+	FetchedAt time.Time       `json:"fetched_at" db:"fetched_at"`
+}
+
+// Normalize upper-cases the codes and truncates the date to its UTC day
+func (r *FXRate) Normalize() {
+	r.Base = NormalizeCurrency(r.Base)
+	r.Quote = NormalizeCurrency(r.Quote)
+	r.RateDate = RateDay(r.RateDate)
+}
+
+// Validate checks the pair and that the rate is a positive price
+func (r *FXRate) Validate() error {
+	switch {
+	case r.Base == "" || r.Quote == "":
+		return fmt.Errorf("%w: base and quote are required", ErrInvalidRate)
+	case r.Base == r.Quote:
+		return fmt.Errorf("%w: base and quote must differ", ErrInvalidRate)
+	case !r.Rate.IsPositive():
+		return fmt.Errorf("%w: rate must be positive", ErrInvalidRate)
+	case r.RateDate.IsZero():
+		return fmt.Errorf("%w: rate date is required", ErrInvalidRate)
+	}
+	return nil
+}
+
+// NormalizeCurrency canonicalises a currency code or asset ticker
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// RateDay returns the UTC day a rate dated t applies to
+func RateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// nativeAssets are the assets a chain's native transfers are denominated in
+var nativeAssets = map[string]string{
+	"bitcoin":   "BTC",
+	"ethereum":  "ETH",
+	"tron":      "TRX",
+	"polygon":   "MATIC",
+	"bsc":       "BNB",
+	"litecoin":  "LTC",
+	"solana":    "SOL",
+	"avalanche": "AVAX",
+}
+
+// NativeAsset returns the ticker of the chain's native asset
+func NativeAsset(chain string) (string, bool) {
+	asset, ok := nativeAssets[strings.ToLower(strings.TrimSpace(chain))]
+	return asset, ok
+}
+
+// FXSyncResult summarises one rate provider sync
+type FXSyncResult struct {
+	Provider string    `json:"provider"`
+	Day      time.Time `json:"day"`
+	Fetched  int       `json:"fetched"`
+	Skipped  int       `json:"skipped"`
+	Stored   int       `json:"stored"`
+	Error    string    `json:"error,omitempty"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// Archive errors
+var (
+	ErrArchiveRangeTooLarge = errors.New("search range covers too many archives")
+	ErrArchiveCorrupt       = errors.New("archive object does not match its checksum")
+)
+
+// Matches reports whether tx meets the filter's criteria, as the repository's
+// query would apply them. Paging is ignored.
+func (f TransactionFilter) Matches(tx *Transaction) bool {
+	switch {
+	case f.StartTime != nil && tx.TxTimestamp.Before(*f.StartTime):
+		return false
+	case f.EndTime != nil && tx.TxTimestamp.After(*f.EndTime):
+		return false
+	case f.Chain != "" && tx.Chain != f.Chain:
+		return false
+	case f.FromAddress != "" && tx.FromAddress != f.FromAddress:
+		return false
+	case f.ToAddress != "" && (tx.ToAddress == nil || *tx.ToAddress != f.ToAddress):
+		return false
+	case f.MinAmountUSD.IsPositive() && tx.AmountUSD.LessThan(f.MinAmountUSD):
+		return false
+	case f.MaxAmountUSD.IsPositive() && tx.AmountUSD.GreaterThan(f.MaxAmountUSD):
+		return false
+	case f.Flagged != nil && tx.Flagged != *f.Flagged:
+		return false
+	case f.MinRiskScore > 0 && tx.RiskScore < f.MinRiskScore:
+		return false
+	}
+	return true
+}
+
+// TransactionArchive is one compressed object in cold storage holding
+// transactions moved out of the transactions table
+type TransactionArchive struct {
+	ID        string    `json:"id" db:"id"`
+	ObjectKey string    `json:"object_key" db:"object_key"`
+	RowCount  int       `json:"row_count" db:"row_count"`
+	SizeBytes int64     `json:"size_bytes" db:"size_bytes"`
+	Checksum  string    `json:"checksum" db:"checksum"`
+	FirstTxAt time.Time `json:"first_tx_at" db:"first_tx_at"`
+	LastTxAt  time.Time `json:"last_tx_at" db:"last_tx_at"`
+	Chains    []string  `json:"chains" db:"chains"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// HasChain reports whether the archive holds transactions on chain
+func (a *TransactionArchive) HasChain(chain string) bool {
+	for _, c := range a.Chains {
+		if c == chain {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchivalResult summarises one archival run
+type ArchivalResult struct {
+	Cutoff      time.Time `json:"cutoff"`
+	Archives    int       `json:"archives"`
+	Rows        int       `json:"rows"`
+	Bytes       int64     `json:"bytes"`
+	Error       string    `json:"error,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// ArchiveSearchResult is a page of transactions searched across the
+// transactions table and the archives covering the requested range
+type ArchiveSearchResult struct {
+	Transactions    []*Transaction `json:"transactions"`
+	Total           int64          `json:"total"`
+	HotTotal        int64          `json:"hot_total"`
+	ArchivedTotal   int64          `json:"archived_total"`
+	ArchivesScanned int            `json:"archives_scanned"`
+	Page            int            `json:"page"`
+	PageSize        int            `json:"page_size"`
+}
+
+// SubscriptionStatus is the lifecycle state of a pattern subscription
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive   SubscriptionStatus = "ACTIVE"
+	SubscriptionPaused   SubscriptionStatus = "PAUSED"
+	SubscriptionArchived SubscriptionStatus = "ARCHIVED"
+)
+
+// SubscriptionDirection selects which side of a transaction the watched
+// addresses must be on
+type SubscriptionDirection string
+
+const (
+	DirectionAny      SubscriptionDirection = "ANY"
+	DirectionIncoming SubscriptionDirection = "IN"
+	DirectionOutgoing SubscriptionDirection = "OUT"
+)
+
+// NotificationChannelType is the kind of channel hits are delivered to
+type NotificationChannelType string
+
+const (
+	ChannelWebhook NotificationChannelType = "WEBHOOK"
+	ChannelEmail   NotificationChannelType = "EMAIL"
+)
+
+// MaxSubscriptionAddresses bounds the address set of one subscription
+const MaxSubscriptionAddresses = 10000
+
+// Pattern subscription errors
+var (
+	ErrSubscriptionNotFound = errors.New("pattern subscription not found")
+	ErrInvalidSubscription  = errors.New("invalid pattern subscription")
+	ErrSubscriptionArchived = errors.New("pattern subscription is archived")
+)
+
+// SubscriptionFilter is an analyst's standing query over ingested
+// transactions. Every criterion set must hold; at least one must be set.
+// Amounts are compared after risk scoring, on the token amount and its USD value.
+type SubscriptionFilter struct {
+	Chain        string                `json:"chain,omitempty"`
+	Addresses    []string              `json:"addresses,omitempty"`
+	Direction    SubscriptionDirection `json:"direction,omitempty"`
+	TokenAddress string                `json:"token_address,omitempty"`
+	MinAmount    decimal.Decimal       `json:"min_amount"`
+	MinAmountUSD decimal.Decimal       `json:"min_amount_usd"`
+	MinRiskScore int                   `json:"min_risk_score,omitempty"`
+}
+
+// Normalize canonicalises the chain, direction and addresses, dropping
+// duplicate addresses
+func (f *SubscriptionFilter) Normalize() {
+	f.Chain = strings.ToLower(strings.TrimSpace(f.Chain))
+	f.Direction = SubscriptionDirection(strings.ToUpper(strings.TrimSpace(string(f.Direction))))
+	if f.Direction == "" {
+		f.Direction = DirectionAny
+	}
+	f.TokenAddress = NormalizeContractAddress(f.TokenAddress)
+
+	seen := make(map[string]bool, len(f.Addresses))
+	addresses := make([]string, 0, len(f.Addresses))
+	for _, address := range f.Addresses {
+		address = NormalizeContractAddress(address)
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	f.Addresses = addresses
+}
+
+// Validate checks that the filter selects something and its bounds are sane
+func (f *SubscriptionFilter) Validate() error {
+	switch {
+	case f.Direction != DirectionAny && f.Direction != DirectionIncoming && f.Direction != DirectionOutgoing:
+		return fmt.Errorf("%w: unknown direction %q", ErrInvalidSubscription, f.Direction)
+	case len(f.Addresses) > MaxSubscriptionAddresses:
+		return fmt.Errorf("%w: at most %d addresses may be watched", ErrInvalidSubscription, MaxSubscriptionAddresses)
+	case f.MinAmount.IsNegative() || f.MinAmountUSD.IsNegative() || f.MinRiskScore < 0:
+		return fmt.Errorf("%w: minimums must not be negative", ErrInvalidSubscription)
+	case len(f.Addresses) == 0 && f.TokenAddress == "" && !f.MinAmount.IsPositive() &&
+		!f.MinAmountUSD.IsPositive() && f.MinRiskScore == 0:
+		return fmt.Errorf("%w: filter must watch addresses, a token, an amount or a risk score", ErrInvalidSubscription)
+	}
+	return nil
+}
+
+// Match reports whether tx meets the filter. address is the watched address
+// the transaction moved funds from or to, empty when no addresses are watched.
+func (f *SubscriptionFilter) Match(tx *Transaction) (address string, direction SubscriptionDirection, ok bool) {
+	switch {
+	case f.Chain != "" && !strings.EqualFold(tx.Chain, f.Chain):
+		return "", "", false
+	case f.TokenAddress != "" && (tx.TokenAddress == nil || NormalizeContractAddress(*tx.TokenAddress) != f.TokenAddress):
+		return "", "", false
+	case f.MinAmount.IsPositive() && !tx.Amount.GreaterThanOrEqual(f.MinAmount):
+		return "", "", false
+	case f.MinAmountUSD.IsPositive() && !tx.AmountUSD.GreaterThanOrEqual(f.MinAmountUSD):
+		return "", "", false
+	case f.MinRiskScore > 0 && tx.RiskScore < f.MinRiskScore:
+		return "", "", false
+	}
+	if len(f.Addresses) == 0 {
+		return "", f.Direction, true
+	}
+
+	if f.Direction != DirectionIncoming {
+		if from := NormalizeContractAddress(tx.FromAddress); f.watches(from) {
+			return from, DirectionOutgoing, true
+		}
+	}
+	if f.Direction != DirectionOutgoing && tx.ToAddress != nil {
+		if to := NormalizeContractAddress(*tx.ToAddress); f.watches(to) {
+			return to, DirectionIncoming, true
+		}
+	}
+	return "", "", false
+}
+
+func (f *SubscriptionFilter) watches(address string) bool {
+	for _, watched := range f.Addresses {
+		if watched == address {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationChannel is where a subscription's hits are delivered: a webhook
+// URL or an email address
+type NotificationChannel struct {
+	Type   NotificationChannelType `json:"type"`
+	Target string                  `json:"target"`
+}
+
+// Validate checks the channel type and its target
+func (c *NotificationChannel) Validate() error {
+	if err := c.check(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+	}
+	return nil
+}
+
+// check normalizes the channel and reports what is wrong with it, if anything
+func (c *NotificationChannel) check() error {
+	c.Type = NotificationChannelType(strings.ToUpper(strings.TrimSpace(string(c.Type))))
+	c.Target = strings.TrimSpace(c.Target)
+	switch c.Type {
+	case ChannelWebhook:
+		target, err := url.Parse(c.Target)
+		if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+			return errors.New("webhook target must be an http(s) URL")
+		}
+	case ChannelEmail:
+		address, err := mail.ParseAddress(c.Target)
+		if err != nil {
+			return errors.New("email target must be an email address")
+		}
+		c.Target = address.Address
+	default:
+		return fmt.Errorf("unknown channel type %q", c.Type)
+	}
+	return nil
+}
+
+// PatternSubscription is an analyst's saved filter evaluated against every
+// ingested transaction. Matches are kept in the subscription's hit log and,
+// when a channel is bound, delivered to it.
+type PatternSubscription struct {
+	ID          string               `json:"id" db:"id"`
+	Name        string               `json:"name" db:"name"`
+	Description string               `json:"description,omitempty" db:"description"`
+	Owner       string               `json:"owner" db:"owner"`
+	Filter      SubscriptionFilter   `json:"filter" db:"filter"`
+	Channel     *NotificationChannel `json:"channel,omitempty"`
+	Status      SubscriptionStatus   `json:"status" db:"status"`
+	HitCount    int64                `json:"hit_count" db:"hit_count"`
+	LastHitAt   *time.Time           `json:"last_hit_at,omitempty" db:"last_hit_at"`
+	UpdatedBy   string               `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// Validate normalizes and checks the subscription's name, filter and channel
+func (s *PatternSubscription) Validate() error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSubscription)
+	}
+	s.Filter.Normalize()
+	if err := s.Filter.Validate(); err != nil {
+		return err
+	}
+	if s.Channel != nil {
+		return s.Channel.Validate()
+	}
+	return nil
+}
+
+// SubscriptionListFilter selects pattern subscriptions
+type SubscriptionListFilter struct {
+	Owner  string             `json:"owner,omitempty"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// SubscriptionHit is one transaction matched by a subscription. A transaction
+// is recorded, and notified, at most once per subscription.
+type SubscriptionHit struct {
+	ID             string                `json:"id" db:"id"`
+	SubscriptionID string                `json:"subscription_id" db:"subscription_id"`
+	TransactionID  string                `json:"transaction_id" db:"transaction_id"`
+	TxHash         string                `json:"tx_hash" db:"tx_hash"`
+	Chain          string                `json:"chain" db:"chain"`
+	MatchedAddress string                `json:"matched_address,omitempty" db:"matched_address"`
+	Direction      SubscriptionDirection `json:"direction" db:"direction"`
+	Amount         decimal.Decimal       `json:"amount" db:"amount"`
+	AmountUSD      decimal.Decimal       `json:"amount_usd" db:"amount_usd"`
+	RiskScore      int                   `json:"risk_score" db:"risk_score"`
+	TxTimestamp    time.Time             `json:"tx_timestamp" db:"tx_timestamp"`
+	MatchedAt      time.Time             `json:"matched_at" db:"matched_at"`
+	Severity       RuleSeverity          `json:"severity" db:"severity"`
+	Delivery       HitDelivery           `json:"delivery,omitempty" db:"delivery"`
+	NotifiedAt     *time.Time            `json:"notified_at,omitempty" db:"notified_at"`
+	NotifyError    string                `json:"notify_error,omitempty" db:"notify_error"`
+}
+
+// HitDelivery records how a subscription hit was routed to its owner
+type HitDelivery string
+
+const (
+	DeliveryRealtime HitDelivery = "REALTIME"
+	DeliveryDigest   HitDelivery = "DIGEST"
+	DeliveryMuted    HitDelivery = "MUTED"
+)
+
+// HitSeverity grades a subscription hit by its risk score, on the scale used
+// for rule matches
+func HitSeverity(riskScore int) RuleSeverity {
+	switch {
+	case riskScore >= 80:
+		return RuleSeverityCritical
+	case riskScore >= 60:
+		return RuleSeverityAlert
+	case riskScore >= 40:
+		return RuleSeverityWarning
+	default:
+		return RuleSeverityInfo
+	}
+}
+
+// DigestFrequency is how often a user's non-critical notifications are
+// summarised
+type DigestFrequency string
+
+const (
+	DigestRealtime DigestFrequency = "REALTIME"
+	DigestHourly   DigestFrequency = "HOURLY"
+	DigestDaily    DigestFrequency = "DAILY"
+)
+
+// Period returns the length of one digest period, zero for real-time delivery
+func (f DigestFrequency) Period() time.Duration {
+	switch f {
+	case DigestHourly:
+		return time.Hour
+	case DigestDaily:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// Notification preference errors
+var (
+	ErrPreferenceNotFound = errors.New("notification preference not found")
+	ErrInvalidPreference  = errors.New("invalid notification preference")
+)
+
+// NotificationPreference is a user's choice of where their subscription hits
+// are sent, which severities they want and how often. CRITICAL hits are always
+// delivered as they happen; lower severities are batched into a digest unless
+// the frequency is REALTIME. An empty severity list receives every severity.
+type NotificationPreference struct {
+	UserID          string                `json:"user_id" db:"user_id"`
+	Channels        []NotificationChannel `json:"channels"`
+	Severities      []RuleSeverity        `json:"severities"`
+	DigestFrequency DigestFrequency       `json:"digest_frequency" db:"digest_frequency"`
+	LastDigestAt    *time.Time            `json:"last_digest_at,omitempty" db:"last_digest_at"`
+	UpdatedBy       string                `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt       time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultNotificationPreference is the preference of a user who never set
+// one: every hit is delivered as it happens to its subscription's channel
+func DefaultNotificationPreference(userID string) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:          userID,
+		Channels:        []NotificationChannel{},
+		Severities:      []RuleSeverity{},
+		DigestFrequency: DigestRealtime,
+	}
+}
+
+// Validate normalizes and checks the channels, severities and frequency. A
+// digest needs a channel to be sent to.
+func (p *NotificationPreference) Validate() error {
+	p.DigestFrequency = DigestFrequency(strings.ToUpper(strings.TrimSpace(string(p.DigestFrequency))))
+	if p.DigestFrequency == "" {
+		p.DigestFrequency = DigestRealtime
+	}
+	if p.DigestFrequency != DigestRealtime && p.DigestFrequency.Period() == 0 {
+		return fmt.Errorf("%w: unknown digest frequency %q", ErrInvalidPreference, p.DigestFrequency)
+	}
+
+	seen := make(map[RuleSeverity]bool, len(p.Severities))
+	severities := make([]RuleSeverity, 0, len(p.Severities))
+	for _, severity := range p.Severities {
+		severity = RuleSeverity(strings.ToUpper(strings.TrimSpace(string(severity))))
+		if !severity.IsValid() {
+			return fmt.Errorf("%w: unknown severity %q", ErrInvalidPreference, severity)
+		}
+		if !seen[severity] {
+			seen[severity] = true
+			severities = append(severities, severity)
+		}
+	}
+	p.Severities = severities
+
+	if p.Channels == nil {
+		p.Channels = []NotificationChannel{}
+	}
+	for i := range p.Channels {
+		if err := p.Channels[i].check(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPreference, err)
+		}
+	}
+	if p.DigestFrequency != DigestRealtime && len(p.Channels) == 0 {
+		return fmt.Errorf("%w: a digest needs at least one channel", ErrInvalidPreference)
+	}
+	return nil
+}
+
+// Receives reports whether the user wants hits of the given severity
+func (p *NotificationPreference) Receives(severity RuleSeverity) bool {
+	if len(p.Severities) == 0 {
+		return true
+	}
+	for _, s := range p.Severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// Digests reports whether hits of the given severity wait for the user's
+// digest rather than being delivered as they happen
+func (p *NotificationPreference) Digests(severity RuleSeverity) bool {
+	return severity != RuleSeverityCritical && p.DigestFrequency != DigestRealtime
+}
+
+// DigestDue reports whether hits queued since oldest are due to be sent at
+// now. Periods are aligned to the hour or to midnight UTC, so a digest covers
+// the periods that have ended; a user back on real-time delivery gets the
+// remaining queue straight away.
+func (p *NotificationPreference) DigestDue(oldest, now time.Time) bool {
+	period := p.DigestFrequency.Period()
+	if period == 0 {
+		return true
+	}
+	return oldest.Before(now.Truncate(period))
+}
+
+// DigestQueue summarises the hits queued for one user's next digest
+type DigestQueue struct {
+	UserID string    `json:"user_id"`
+	Oldest time.Time `json:"oldest"`
+	Count  int       `json:"count"`
+}
+
+// DigestEntry is a queued hit together with the subscription it matched
+type DigestEntry struct {
+	SubscriptionID   string           `json:"subscription_id"`
+	SubscriptionName string           `json:"subscription_name"`
+	Hit              *SubscriptionHit `json:"hit"`
+	QueuedAt         time.Time        `json:"queued_at"`
+}
+
+// NotificationDigest summarises a user's non-critical hits for one or more
+// digest periods
+type NotificationDigest struct {
+	UserID      string               `json:"user_id"`
+	Frequency   DigestFrequency      `json:"frequency"`
+	PeriodStart time.Time            `json:"period_start"`
+	PeriodEnd   time.Time            `json:"period_end"`
+	Counts      map[RuleSeverity]int `json:"counts"`
+	Entries     []*DigestEntry       `json:"entries"`
+}
+
+// NewNotificationDigest builds a user's digest of the entries queued before end
+func NewNotificationDigest(preference *NotificationPreference, entries []*DigestEntry, end time.Time) *NotificationDigest {
+	digest := &NotificationDigest{
+		UserID:    preference.UserID,
+		Frequency: preference.DigestFrequency,
+		PeriodEnd: end,
+		Counts:    make(map[RuleSeverity]int),
+		Entries:   entries,
+	}
+	for i, entry := range entries {
+		if i == 0 || entry.QueuedAt.Before(digest.PeriodStart) {
+			digest.PeriodStart = entry.QueuedAt
+		}
+		digest.Counts[entry.Hit.Severity]++
+	}
+	if period := preference.DigestFrequency.Period(); period > 0 {
+		digest.PeriodStart = digest.PeriodStart.Truncate(period)
+	}
+	return digest
+}
@@ -2,8 +2,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/shopspring/decimal"
 )
 
 // TransactionRepository interface for transaction data access
@@ -60,6 +62,118 @@ type MonitoringRuleRepository interface {
 	UpdateRule(ctx context.Context, rule *domain.MonitoringRule) error
 	DeleteRule(ctx context.Context, id string) error
 	GetRulesByType(ctx context.Context, ruleType string) ([]*domain.MonitoringRule, error)
+	ListRules(ctx context.Context) ([]*domain.MonitoringRule, error)
+}
+
+// RuleVersionRepository interface for monitoring rule version history
+type RuleVersionRepository interface {
+	CreateVersion(ctx context.Context, version *domain.RuleVersion) error
+	GetVersion(ctx context.Context, ruleID string, version int) (*domain.RuleVersion, error)
+	ListVersions(ctx context.Context, ruleID string) ([]*domain.RuleVersion, error)
+	UpdateVersionStatus(ctx context.Context, version *domain.RuleVersion) error
+	ActivateVersion(ctx context.Context, version *domain.RuleVersion) error
+	ImportVersions(ctx context.Context, versions []*domain.RuleVersion) error
+}
+
+// ContractRegistryRepository interface for smart contract registry data access
+type ContractRegistryRepository interface {
+	CreateContract(ctx context.Context, contract *domain.RegisteredContract) error
+	GetContract(ctx context.Context, id string) (*domain.RegisteredContract, error)
+	UpdateContract(ctx context.Context, contract *domain.RegisteredContract) error
+	ListContracts(ctx context.Context, filter domain.ContractFilter) ([]*domain.RegisteredContract, int64, error)
+	FindContracts(ctx context.Context, chain string, addresses []string) ([]*domain.RegisteredContract, error)
+	SyncFeedContracts(ctx context.Context, source string, contracts []*domain.RegisteredContract) (upserted, deactivated int, err error)
+}
+
+// ContractFeed supplies a curated list of smart contracts for the registry
+type ContractFeed interface {
+	Name() string
+	FetchContracts(ctx context.Context) ([]*domain.RegisteredContract, error)
+}
+
+// FXRateRepository interface for the history of daily reference rates
+type FXRateRepository interface {
+	// UpsertRates stores rates, replacing any from the same source for the same pair and day
+	UpsertRates(ctx context.Context, rates []*domain.FXRate) (int, error)
+	// FindRate returns the latest rate for the pair dated between since and day inclusive
+	FindRate(ctx context.Context, base, quote string, since, day time.Time) (*domain.FXRate, error)
+	ListRates(ctx context.Context, base, quote string, from, to time.Time) ([]*domain.FXRate, error)
+}
+
+// FXRateProvider supplies the reference rates for a day
+type FXRateProvider interface {
+	Name() string
+	FetchRates(ctx context.Context, day time.Time) ([]*domain.FXRate, error)
+}
+
+// TransactionArchiveRepository interface for moving transactions out of the
+// transactions table and cataloguing the archives that hold them
+type TransactionArchiveRepository interface {
+	ListArchivable(ctx context.Context, before time.Time, limit int) ([]*domain.Transaction, error)
+	CommitArchive(ctx context.Context, archive *domain.TransactionArchive, txs []*domain.Transaction) error
+	FindArchives(ctx context.Context, start, end time.Time) ([]*domain.TransactionArchive, error)
+}
+
+// TransactionArchiveStore interface for archive objects in cold storage
+type TransactionArchiveStore interface {
+	WriteArchive(ctx context.Context, key string, txs []*domain.Transaction) (size int64, checksum string, err error)
+	ReadArchive(ctx context.Context, archive *domain.TransactionArchive) ([]*domain.Transaction, error)
+}
+
+// PatternSubscriptionRepository interface for pattern subscriptions and their hit logs
+type PatternSubscriptionRepository interface {
+	CreateSubscription(ctx context.Context, subscription *domain.PatternSubscription) error
+	GetSubscription(ctx context.Context, id string) (*domain.PatternSubscription, error)
+	UpdateSubscription(ctx context.Context, subscription *domain.PatternSubscription) error
+	ListSubscriptions(ctx context.Context, filter domain.SubscriptionListFilter) ([]*domain.PatternSubscription, int64, error)
+	ListActiveSubscriptions(ctx context.Context) ([]*domain.PatternSubscription, error)
+	// RecordHit stores a hit and counts it on its subscription. It returns false,
+	// storing nothing, if the transaction was already recorded for the subscription.
+	RecordHit(ctx context.Context, hit *domain.SubscriptionHit) (bool, error)
+	UpdateHitDelivery(ctx context.Context, hit *domain.SubscriptionHit) error
+	ListHits(ctx context.Context, subscriptionID string, limit, offset int) ([]*domain.SubscriptionHit, int64, error)
+}
+
+// SubscriptionNotifier delivers subscription hits over one kind of channel
+type SubscriptionNotifier interface {
+	Notify(ctx context.Context, channel *domain.NotificationChannel, subscription *domain.PatternSubscription, hit *domain.SubscriptionHit) error
+}
+
+// NotificationPreferenceRepository interface for users' notification
+// preferences and the hits queued for their digests
+type NotificationPreferenceRepository interface {
+	GetPreference(ctx context.Context, userID string) (*domain.NotificationPreference, error)
+	SavePreference(ctx context.Context, preference *domain.NotificationPreference) error
+	ListPreferences(ctx context.Context) ([]*domain.NotificationPreference, error)
+	QueueDigestHit(ctx context.Context, userID string, hit *domain.SubscriptionHit) error
+	ListDigestQueues(ctx context.Context) ([]*domain.DigestQueue, error)
+	ListQueuedHits(ctx context.Context, userID string, before time.Time) ([]*domain.DigestEntry, error)
+	// CompleteDigest removes sent hits from the queue, marks them notified and
+	// records when the user's digest went out, in a single transaction
+	CompleteDigest(ctx context.Context, userID string, hitIDs []string, sentAt time.Time) error
+}
+
+// DigestNotifier delivers notification digests over one kind of channel
+type DigestNotifier interface {
+	NotifyDigest(ctx context.Context, channel *domain.NotificationChannel, digest *domain.NotificationDigest) error
+}
+
+// NotificationRouter applies users' notification preferences to the hits of
+// the subscriptions they own
+type NotificationRouter interface {
+	Preference(userID string) *domain.NotificationPreference
+	QueueDigest(ctx context.Context, userID string, hit *domain.SubscriptionHit) error
+}
+
+// SubscriptionEvaluator matches ingested transactions against the active
+// pattern subscriptions
+type SubscriptionEvaluator interface {
+	Evaluate(ctx context.Context, tx *domain.Transaction) []*domain.SubscriptionHit
+}
+
+// SanctionsScreen reports how sanctions checks were answered
+type SanctionsScreen interface {
+	Stats() domain.SanctionsScreenStats
 }
 
 // TransactionAnalysisService interface for transaction analysis
@@ -85,6 +199,51 @@ type RiskScoringService interface {
 	CalculatePatternRisk(ctx context.Context, tx *domain.Transaction) (float64, error)
 }
 
+// ContractRegistryService interface for smart contract registry management
+type ContractRegistryService interface {
+	RegisterContract(ctx context.Context, contract *domain.RegisteredContract, actor string) error
+	UpdateContract(ctx context.Context, contract *domain.RegisteredContract, actor string) error
+	DeactivateContract(ctx context.Context, id, actor string) (*domain.RegisteredContract, error)
+	GetContract(ctx context.Context, id string) (*domain.RegisteredContract, error)
+	ListContracts(ctx context.Context, filter domain.ContractFilter) ([]*domain.RegisteredContract, int64, error)
+	LookupAddress(ctx context.Context, chain, address string) (*domain.RegisteredContract, error)
+	SyncFeeds(ctx context.Context) []domain.ContractSyncResult
+}
+
+// PatternSubscriptionService interface for analysts' standing queries
+type PatternSubscriptionService interface {
+	CreateSubscription(ctx context.Context, subscription *domain.PatternSubscription, actor string) error
+	UpdateSubscription(ctx context.Context, subscription *domain.PatternSubscription, actor string) error
+	GetSubscription(ctx context.Context, id string) (*domain.PatternSubscription, error)
+	ListSubscriptions(ctx context.Context, filter domain.SubscriptionListFilter) ([]*domain.PatternSubscription, int64, error)
+	PauseSubscription(ctx context.Context, id, actor string) (*domain.PatternSubscription, error)
+	ResumeSubscription(ctx context.Context, id, actor string) (*domain.PatternSubscription, error)
+	ArchiveSubscription(ctx context.Context, id, actor string) (*domain.PatternSubscription, error)
+	ListHits(ctx context.Context, id string, limit, offset int) ([]*domain.SubscriptionHit, int64, error)
+}
+
+// NotificationPreferenceService interface for users' notification
+// preferences and digests
+type NotificationPreferenceService interface {
+	GetPreference(ctx context.Context, userID string) (*domain.NotificationPreference, error)
+	UpdatePreference(ctx context.Context, preference *domain.NotificationPreference, actor string) error
+	PreviewDigest(ctx context.Context, userID string) (*domain.NotificationDigest, error)
+}
+
+// FXRateService interface for reference rates and currency conversion
+type FXRateService interface {
+	Rate(ctx context.Context, base, quote string, at time.Time) (decimal.Decimal, error)
+	Convert(ctx context.Context, amount decimal.Decimal, from, to string, at time.Time) (decimal.Decimal, error)
+	ListRates(ctx context.Context, base, quote string, from, to time.Time) ([]*domain.FXRate, error)
+	SyncProviders(ctx context.Context, day time.Time) []domain.FXSyncResult
+}
+
+// TransactionArchivalService interface for transaction retention and archive search
+type TransactionArchivalService interface {
+	Archive(ctx context.Context, now time.Time) domain.ArchivalResult
+	Search(ctx context.Context, filter domain.TransactionFilter) (*domain.ArchiveSearchResult, error)
+}
+
 // AlertService interface for alert generation and management
 type AlertService interface {
 	GenerateAlert(ctx context.Context, alertType domain.AlertType, tx *domain.Transaction, riskScore float64, reason string) (*domain.Alert, error)
@@ -98,4 +257,19 @@ type RuleEngineService interface {
 	EvaluateRules(ctx context.Context, tx *domain.Transaction) ([]domain.RuleMatch, error)
 	GetApplicableRules(ctx context.Context, tx *domain.Transaction) ([]*domain.MonitoringRule, error)
 	ExecuteRule(ctx context.Context, rule *domain.MonitoringRule, tx *domain.Transaction) (bool, string, error)
+	CreateRule(ctx context.Context, rule *domain.MonitoringRule, actor string) error
+	UpdateRule(ctx context.Context, rule *domain.MonitoringRule, actor, changeNote string) (*domain.RuleVersion, error)
+	BacktestRule(ctx context.Context, ruleID string, days, samples int) (*domain.RuleBacktestResult, error)
+	ListRuleVersions(ctx context.Context, ruleID string) ([]*domain.RuleVersion, error)
+	DiffRuleVersions(ctx context.Context, ruleID string, from, to int) (*domain.RuleVersionDiff, error)
+	SubmitRuleVersion(ctx context.Context, ruleID string, version int, actor string) (*domain.RuleVersion, error)
+	ApproveRuleVersion(ctx context.Context, ruleID string, version int, actor, comment string) (*domain.RuleVersion, error)
+	RejectRuleVersion(ctx context.Context, ruleID string, version int, actor, comment string) (*domain.RuleVersion, error)
+	RollbackRule(ctx context.Context, ruleID string, version int, actor string) (*domain.RuleVersion, error)
+}
+
+// RuleBundleService interface for exchanging signed monitoring rule bundles
+type RuleBundleService interface {
+	ExportRules(ctx context.Context, ruleIDs []string) (*domain.RuleBundle, error)
+	ImportRules(ctx context.Context, bundle *domain.RuleBundle, refs []string, actor string, dryRun bool) (*domain.RuleImportPlan, error)
 }
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ArchivalConfig configures transaction retention and archive searches
+type ArchivalConfig struct {
+	// RetainDays is how long transactions stay in the transactions table
+	RetainDays int
+	// BatchSize is the number of transactions written to each archive object
+	BatchSize int
+	// Prefix is prepended to archive object keys
+	Prefix string
+	// MaxSearchArchives bounds the archives a single search may read
+	MaxSearchArchives int
+}
+
+// TransactionArchivalService moves transactions past the retention age out of
+// the transactions table into Parquet archives in object storage, keeping the
+// hot table lean, and searches both stores as one.
+type TransactionArchivalService struct {
+	transactionRepo ports.TransactionRepository
+	archiveRepo     ports.TransactionArchiveRepository
+	store           ports.TransactionArchiveStore
+	config          ArchivalConfig
+	logger          *zap.Logger
+}
+
+// NewTransactionArchivalService creates a new archival service. Without a
+// store nothing is archived and searches only cover the transactions table.
+func NewTransactionArchivalService(
+	transactionRepo ports.TransactionRepository,
+	archiveRepo ports.TransactionArchiveRepository,
+	store ports.TransactionArchiveStore,
+	config ArchivalConfig,
+	logger *zap.Logger,
+) *TransactionArchivalService {
+	if config.RetainDays <= 0 {
+		config.RetainDays = 365
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50000
+	}
+	if config.MaxSearchArchives <= 0 {
+		config.MaxSearchArchives = 50
+	}
+	return &TransactionArchivalService{
+		transactionRepo: transactionRepo,
+		archiveRepo:     archiveRepo,
+		store:           store,
+		config:          config,
+		logger:          logger,
+	}
+}
+
+// Start archives expired transactions immediately and then every interval until ctx is cancelled
+func (s *TransactionArchivalService) Start(ctx context.Context, interval time.Duration) {
+	if s.store == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result := s.Archive(ctx, time.Now())
+		if result.Error != "" && ctx.Err() == nil {
+			s.logger.Error("Transaction archival failed",
+				zap.Time("cutoff", result.Cutoff),
+				zap.Int("archived", result.Rows),
+				zap.String("error", result.Error),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Archive moves every transaction older than the retention age into archives,
+// one batch per object. Each batch is uploaded before it is catalogued and
+// deleted, so a failure leaves its transactions in the table to be archived
+// again by the next run.
+func (s *TransactionArchivalService) Archive(ctx context.Context, now time.Time) domain.ArchivalResult {
+	result := domain.ArchivalResult{
+		Cutoff:    now.UTC().AddDate(0, 0, -s.config.RetainDays),
+		StartedAt: time.Now().UTC(),
+	}
+
+	if s.store == nil {
+		result.Error = "archive storage is not configured"
+		result.CompletedAt = result.StartedAt
+		return result
+	}
+
+	for ctx.Err() == nil {
+		archive, err := s.archiveBatch(ctx, result.Cutoff)
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+		if archive == nil {
+			break
+		}
+		result.Archives++
+		result.Rows += archive.RowCount
+		result.Bytes += archive.SizeBytes
+	}
+	result.CompletedAt = time.Now().UTC()
+
+	if result.Rows > 0 {
+		s.logger.Info("Transactions archived",
+			zap.Time("cutoff", result.Cutoff),
+			zap.Int("archives", result.Archives),
+			zap.Int("rows", result.Rows),
+			zap.Int64("bytes", result.Bytes),
+		)
+	}
+	return result
+}
+
+// archiveBatch archives the oldest batch of expired transactions, returning
+// nil when there are none left
+func (s *TransactionArchivalService) archiveBatch(ctx context.Context, cutoff time.Time) (*domain.TransactionArchive, error) {
+	// Recorded before reading so rows changed while the batch is uploaded are kept
+	readAt := time.Now().UTC()
+
+	txs, err := s.archiveRepo.ListArchivable(ctx, cutoff, s.config.BatchSize)
+	if err != nil || len(txs) == 0 {
+		return nil, err
+	}
+
+	archive := &domain.TransactionArchive{
+		RowCount:  len(txs),
+		FirstTxAt: txs[0].TxTimestamp,
+		LastTxAt:  txs[len(txs)-1].TxTimestamp,
+		CreatedAt: readAt,
+	}
+	seen := make(map[string]bool)
+	for _, tx := range txs {
+		if !seen[tx.Chain] {
+			seen[tx.Chain] = true
+			archive.Chains = append(archive.Chains, tx.Chain)
+		}
+	}
+
+	first := archive.FirstTxAt.UTC()
+	archive.ObjectKey = path.Join(s.config.Prefix, "transactions", first.Format("2006/01/02"),
+		fmt.Sprintf("%d-%s.parquet", first.UnixMicro(), uuid.NewString()))
+
+	archive.SizeBytes, archive.Checksum, err = s.store.WriteArchive(ctx, archive.ObjectKey, txs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.archiveRepo.CommitArchive(ctx, archive, txs); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Search finds transactions matching the filter in the transactions table and
+// in the archives covering the filter's time range, newest first. Without a
+// start time every archive is in range, so the range may need narrowing to
+// stay within the archives a search may read.
+func (s *TransactionArchivalService) Search(ctx context.Context, filter domain.TransactionFilter) (*domain.ArchiveSearchResult, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 50
+	}
+	start, end := time.Unix(0, 0).UTC(), time.Now().UTC()
+	if filter.StartTime != nil {
+		start = *filter.StartTime
+	}
+	if filter.EndTime != nil {
+		end = *filter.EndTime
+	}
+	limit := filter.Page * filter.PageSize
+
+	// Enough of the newest hot rows to fill the requested page after merging
+	hotFilter := filter
+	hotFilter.StartTime = &start
+	hotFilter.Page = 1
+	hotFilter.PageSize = limit
+	transactions, hotTotal, err := s.transactionRepo.ListTransactions(ctx, hotFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ArchiveSearchResult{
+		HotTotal: hotTotal,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}
+
+	if s.store != nil {
+		archives, err := s.archiveRepo.FindArchives(ctx, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Chain != "" {
+			archives = filterArchives(archives, filter.Chain)
+		}
+		if len(archives) > s.config.MaxSearchArchives {
+			return nil, fmt.Errorf("%w: %d archives in range, at most %d may be searched",
+				domain.ErrArchiveRangeTooLarge, len(archives), s.config.MaxSearchArchives)
+		}
+
+		for _, archive := range archives {
+			archived, err := s.store.ReadArchive(ctx, archive)
+			if err != nil {
+				return nil, err
+			}
+			for _, tx := range archived {
+				if filter.Matches(tx) {
+					transactions = append(transactions, tx)
+					result.ArchivedTotal++
+				}
+			}
+			result.ArchivesScanned++
+		}
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].TxTimestamp.After(transactions[j].TxTimestamp)
+	})
+
+	offset := (filter.Page - 1) * filter.PageSize
+	switch {
+	case offset >= len(transactions):
+		transactions = []*domain.Transaction{}
+	case limit < len(transactions):
+		transactions = transactions[offset:limit]
+	default:
+		transactions = transactions[offset:]
+	}
+
+	result.Transactions = transactions
+	result.Total = result.HotTotal + result.ArchivedTotal
+	return result, nil
+}
+
+// filterArchives keeps the archives holding transactions on chain
+func filterArchives(archives []*domain.TransactionArchive, chain string) []*domain.TransactionArchive {
+	kept := archives[:0]
+	for _, archive := range archives {
+		if archive.HasChain(chain) {
+			kept = append(kept, archive)
+		}
+	}
+	return kept
+}
+
+// Ensure TransactionArchivalService implements the TransactionArchivalService interface
+var _ ports.TransactionArchivalService = (*TransactionArchivalService)(nil)
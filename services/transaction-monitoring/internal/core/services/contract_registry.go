@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// ContractRegistryService maintains the registry of known smart contracts
+// (mixers, bridges, DEXes, sanctioned protocols). Entries are managed through
+// the API or synced from curated feeds; manual entries always take precedence
+// over feed data.
+type ContractRegistryService struct {
+	repo   ports.ContractRegistryRepository
+	feeds  []ports.ContractFeed
+	logger *zap.Logger
+}
+
+// NewContractRegistryService creates a new contract registry service
+func NewContractRegistryService(
+	repo ports.ContractRegistryRepository,
+	feeds []ports.ContractFeed,
+	logger *zap.Logger,
+) *ContractRegistryService {
+	return &ContractRegistryService{
+		repo:   repo,
+		feeds:  feeds,
+		logger: logger,
+	}
+}
+
+// RegisterContract adds a manually maintained contract to the registry
+func (s *ContractRegistryService) RegisterContract(ctx context.Context, contract *domain.RegisteredContract, actor string) error {
+	contract.Normalize()
+	if err := contract.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	contract.Source = domain.ContractSourceManual
+	contract.Active = true
+	contract.CreatedBy = actor
+	contract.UpdatedBy = actor
+	contract.CreatedAt = now
+	contract.UpdatedAt = now
+
+	if err := s.repo.CreateContract(ctx, contract); err != nil {
+		return err
+	}
+
+	s.logger.Info("Contract registered",
+		zap.String("chain", contract.Chain),
+		zap.String("address", contract.Address),
+		zap.String("category", string(contract.Category)),
+		zap.String("actor", actor),
+	)
+
+	return nil
+}
+
+// UpdateContract replaces an entry's details. The entry becomes manually
+// maintained, so later feed syncs leave it untouched.
+func (s *ContractRegistryService) UpdateContract(ctx context.Context, contract *domain.RegisteredContract, actor string) error {
+	existing, err := s.repo.GetContract(ctx, contract.ID)
+	if err != nil {
+		return err
+	}
+
+	contract.Normalize()
+	if err := contract.Validate(); err != nil {
+		return err
+	}
+
+	contract.Source = domain.ContractSourceManual
+	contract.CreatedBy = existing.CreatedBy
+	contract.CreatedAt = existing.CreatedAt
+	contract.UpdatedBy = actor
+	contract.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.UpdateContract(ctx, contract); err != nil {
+		return err
+	}
+
+	s.logger.Info("Contract updated",
+		zap.String("id", contract.ID),
+		zap.String("category", string(contract.Category)),
+		zap.Bool("active", contract.Active),
+		zap.String("actor", actor),
+	)
+
+	return nil
+}
+
+// DeactivateContract stops an entry contributing to risk scores. The row is kept
+// as a manual entry so the feed that supplied it cannot re-activate it.
+func (s *ContractRegistryService) DeactivateContract(ctx context.Context, id, actor string) (*domain.RegisteredContract, error) {
+	contract, err := s.repo.GetContract(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	contract.Active = false
+	contract.Source = domain.ContractSourceManual
+	contract.UpdatedBy = actor
+	contract.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.UpdateContract(ctx, contract); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Contract deactivated", zap.String("id", id), zap.String("actor", actor))
+
+	return contract, nil
+}
+
+// GetContract retrieves a registry entry by ID
+func (s *ContractRegistryService) GetContract(ctx context.Context, id string) (*domain.RegisteredContract, error) {
+	return s.repo.GetContract(ctx, id)
+}
+
+// ListContracts lists registry entries matching the filter
+func (s *ContractRegistryService) ListContracts(ctx context.Context, filter domain.ContractFilter) ([]*domain.RegisteredContract, int64, error) {
+	if filter.Limit <= 0 || filter.Limit > 500 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.ListContracts(ctx, filter)
+}
+
+// LookupAddress returns the active registry entry for an address on a chain
+func (s *ContractRegistryService) LookupAddress(ctx context.Context, chain, address string) (*domain.RegisteredContract, error) {
+	lookup := domain.RegisteredContract{Chain: chain, Address: address}
+	lookup.Normalize()
+
+	contracts, err := s.repo.FindContracts(ctx, lookup.Chain, []string{lookup.Address})
+	if err != nil {
+		return nil, err
+	}
+	if len(contracts) == 0 {
+		return nil, domain.ErrContractNotFound
+	}
+	return contracts[0], nil
+}
+
+// Start syncs the curated feeds immediately and then every interval until ctx is cancelled
+func (s *ContractRegistryService) Start(ctx context.Context, interval time.Duration) {
+	if len(s.feeds) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.SyncFeeds(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncFeeds pulls every curated feed into the registry. Entries a feed no
+// longer lists are deactivated; a failing feed leaves its entries as they were.
+func (s *ContractRegistryService) SyncFeeds(ctx context.Context) []domain.ContractSyncResult {
+	results := make([]domain.ContractSyncResult, 0, len(s.feeds))
+	for _, feed := range s.feeds {
+		result := s.syncFeed(ctx, feed)
+		if result.Error != "" {
+			s.logger.Error("Contract feed sync failed",
+				zap.String("feed", result.Feed),
+				zap.String("error", result.Error),
+			)
+		} else {
+			s.logger.Info("Contract feed synced",
+				zap.String("feed", result.Feed),
+				zap.Int("fetched", result.Fetched),
+				zap.Int("skipped", result.Skipped),
+				zap.Int("upserted", result.Upserted),
+				zap.Int("deactivated", result.Deactivated),
+			)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (s *ContractRegistryService) syncFeed(ctx context.Context, feed ports.ContractFeed) domain.ContractSyncResult {
+	result := domain.ContractSyncResult{Feed: feed.Name(), SyncedAt: time.Now().UTC()}
+
+	fetched, err := feed.FetchContracts(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Fetched = len(fetched)
+
+	seen := make(map[string]bool, len(fetched))
+	contracts := make([]*domain.RegisteredContract, 0, len(fetched))
+	for _, contract := range fetched {
+		contract.Normalize()
+		key := contract.Chain + ":" + contract.Address
+		if err := contract.Validate(); err != nil || seen[key] {
+			result.Skipped++
+			continue
+		}
+		seen[key] = true
+
+		contract.Source = feed.Name()
+		contract.Active = true
+		contract.UpdatedAt = result.SyncedAt
+		contracts = append(contracts, contract)
+	}
+
+	// An empty feed is far more likely a broken upstream than a real delisting
+	// of everything, so it must not deactivate the feed's existing entries
+	if len(contracts) == 0 {
+		result.Error = "feed returned no valid contracts"
+		return result
+	}
+
+	result.Upserted, result.Deactivated, err = s.repo.SyncFeedContracts(ctx, feed.Name(), contracts)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// registeredContractsFor returns the active registry entries for the sender,
+// receiver and token contract of a transaction
+func registeredContractsFor(ctx context.Context, repo ports.ContractRegistryRepository, tx *domain.Transaction) ([]*domain.RegisteredContract, error) {
+	addresses := []string{domain.NormalizeContractAddress(tx.FromAddress)}
+	if tx.ToAddress != nil && *tx.ToAddress != "" {
+		addresses = append(addresses, domain.NormalizeContractAddress(*tx.ToAddress))
+	}
+	if tx.TokenAddress != nil && *tx.TokenAddress != "" {
+		addresses = append(addresses, domain.NormalizeContractAddress(*tx.TokenAddress))
+	}
+
+	lookup := domain.RegisteredContract{Chain: tx.Chain}
+	lookup.Normalize()
+
+	contracts, err := repo.FindContracts(ctx, lookup.Chain, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up registered contracts: %w", err)
+	}
+	return contracts, nil
+}
+
+// contractSeverity maps a registry risk weight to an indicator severity
+func contractSeverity(weight float64) string {
+	switch {
+	case weight >= 75:
+		return "CRITICAL"
+	case weight >= 40:
+		return "HIGH"
+	case weight >= 15:
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// rateScale is the number of decimal places kept when a rate is derived by
+// inverting or chaining stored rates
+const rateScale = 18
+
+// FXRateServiceConfig tunes rate lookups
+type FXRateServiceConfig struct {
+	// Pivot is the currency cross rates are derived through when a pair has
+	// no rate of its own
+	Pivot string
+	// MaxAge is how far back a lookup falls to the last published rate, to
+	// cover weekends and holidays when providers publish none
+	MaxAge time.Duration
+}
+
+// FXRateService keeps the history of daily reference rates from the configured
+// providers and converts amounts between currencies and crypto assets at the
+// rate of a given day. Historical conversions use the rate of the day the
+// amount applies to, so replaying old transactions gives the same result.
+type FXRateService struct {
+	repo      ports.FXRateRepository
+	providers []ports.FXRateProvider
+	config    FXRateServiceConfig
+	logger    *zap.Logger
+
+	mu    sync.RWMutex
+	cache map[string]cachedRate
+}
+
+// cachedRate is a stored rate lookup; found is false when there was none
+type cachedRate struct {
+	rate  decimal.Decimal
+	found bool
+}
+
+// NewFXRateService creates a new exchange rate service
+func NewFXRateService(
+	repo ports.FXRateRepository,
+	providers []ports.FXRateProvider,
+	config FXRateServiceConfig,
+	logger *zap.Logger,
+) *FXRateService {
+	config.Pivot = domain.NormalizeCurrency(config.Pivot)
+	if config.Pivot == "" {
+		config.Pivot = "USD"
+	}
+	if config.MaxAge <= 0 {
+		config.MaxAge = 7 * 24 * time.Hour
+	}
+	return &FXRateService{
+		repo:      repo,
+		providers: providers,
+		config:    config,
+		logger:    logger,
+		cache:     make(map[string]cachedRate),
+	}
+}
+
+// Rate returns the price of one unit of base in quote on the day of at. Pairs
+// without a stored rate are derived from the inverse pair or crossed through
+// the pivot currency.
+func (s *FXRateService) Rate(ctx context.Context, base, quote string, at time.Time) (decimal.Decimal, error) {
+	base, quote = domain.NormalizeCurrency(base), domain.NormalizeCurrency(quote)
+	if base == "" || quote == "" {
+		return decimal.Zero, fmt.Errorf("%w: base and quote are required", domain.ErrInvalidRate)
+	}
+	if base == quote {
+		return decimal.NewFromInt(1), nil
+	}
+	day := domain.RateDay(at)
+
+	rate, ok, err := s.pairRate(ctx, base, quote, day)
+	if err != nil || ok {
+		return rate, err
+	}
+
+	pivot := s.config.Pivot
+	if base != pivot && quote != pivot {
+		toPivot, ok, err := s.pairRate(ctx, base, pivot, day)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if ok {
+			fromPivot, ok, err := s.pairRate(ctx, pivot, quote, day)
+			if err != nil {
+				return decimal.Zero, err
+			}
+			if ok {
+				return toPivot.Mul(fromPivot).Round(rateScale), nil
+			}
+		}
+	}
+
+	return decimal.Zero, fmt.Errorf("%w: %s/%s on %s", domain.ErrRateNotFound, base, quote, day.Format("2006-01-02"))
+}
+
+// Convert returns amount, in from, expressed in to at the rate of the day of at
+func (s *FXRateService) Convert(ctx context.Context, amount decimal.Decimal, from, to string, at time.Time) (decimal.Decimal, error) {
+	rate, err := s.Rate(ctx, from, to, at)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(rate), nil
+}
+
+// ValueIn returns what a transaction was worth in currency on the day it took
+// place. The reported USD value is converted when there is one; otherwise the
+// amount is valued as the chain's native asset. Token transfers without a USD
+// value cannot be valued.
+func (s *FXRateService) ValueIn(ctx context.Context, tx *domain.Transaction, currency string) (decimal.Decimal, error) {
+	if tx.AmountUSD.IsPositive() {
+		return s.Convert(ctx, tx.AmountUSD, "USD", currency, tx.TxTimestamp)
+	}
+	if tx.TokenAddress != nil && *tx.TokenAddress != "" {
+		return decimal.Zero, fmt.Errorf("%w: token transfer %s has no USD value", domain.ErrRateNotFound, tx.TxHash)
+	}
+	asset, ok := domain.NativeAsset(tx.Chain)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: unknown native asset for chain %s", domain.ErrRateNotFound, tx.Chain)
+	}
+	return s.Convert(ctx, tx.Amount, asset, currency, tx.TxTimestamp)
+}
+
+// ListRates returns the stored rates for a pair between two days
+func (s *FXRateService) ListRates(ctx context.Context, base, quote string, from, to time.Time) ([]*domain.FXRate, error) {
+	return s.repo.ListRates(ctx, domain.NormalizeCurrency(base), domain.NormalizeCurrency(quote), domain.RateDay(from), domain.RateDay(to))
+}
+
+// pairRate looks up a pair directly or as the inverse of its reverse pair
+func (s *FXRateService) pairRate(ctx context.Context, base, quote string, day time.Time) (decimal.Decimal, bool, error) {
+	rate, ok, err := s.storedRate(ctx, base, quote, day)
+	if err != nil || ok {
+		return rate, ok, err
+	}
+	inverse, ok, err := s.storedRate(ctx, quote, base, day)
+	if err != nil || !ok {
+		return decimal.Zero, false, err
+	}
+	return decimal.NewFromInt(1).DivRound(inverse, rateScale), true, nil
+}
+
+// storedRate reads a pair's rate for a day through the cache
+func (s *FXRateService) storedRate(ctx context.Context, base, quote string, day time.Time) (decimal.Decimal, bool, error) {
+	key := base + "/" + quote + "/" + day.Format("2006-01-02")
+
+	s.mu.RLock()
+	cached, hit := s.cache[key]
+	s.mu.RUnlock()
+	if hit {
+		return cached.rate, cached.found, nil
+	}
+
+	stored, err := s.repo.FindRate(ctx, base, quote, day.Add(-s.config.MaxAge), day)
+	switch {
+	case errors.Is(err, domain.ErrRateNotFound):
+		cached = cachedRate{}
+	case err != nil:
+		return decimal.Zero, false, err
+	default:
+		cached = cachedRate{rate: stored.Rate, found: true}
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cached
+	s.mu.Unlock()
+	return cached.rate, cached.found, nil
+}
+
+// Start syncs today's rates immediately and then every interval until ctx is cancelled
+func (s *FXRateService) Start(ctx context.Context, interval time.Duration) {
+	if len(s.providers) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.SyncProviders(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncProviders stores every provider's rates for a day. A failing provider
+// leaves the rates already stored as they were.
+func (s *FXRateService) SyncProviders(ctx context.Context, day time.Time) []domain.FXSyncResult {
+	day = domain.RateDay(day)
+	results := make([]domain.FXSyncResult, 0, len(s.providers))
+	for _, provider := range s.providers {
+		result := s.syncProvider(ctx, provider, day)
+		if result.Error != "" {
+			s.logger.Error("FX rate sync failed",
+				zap.String("provider", result.Provider),
+				zap.Time("day", day),
+				zap.String("error", result.Error),
+			)
+		} else {
+			s.logger.Info("FX rates synced",
+				zap.String("provider", result.Provider),
+				zap.Time("day", day),
+				zap.Int("fetched", result.Fetched),
+				zap.Int("skipped", result.Skipped),
+				zap.Int("stored", result.Stored),
+			)
+		}
+		results = append(results, result)
+	}
+
+	// Lookups may have cached a miss, or an earlier day's rate, for this day
+	s.mu.Lock()
+	s.cache = make(map[string]cachedRate)
+	s.mu.Unlock()
+
+	return results
+}
+
+func (s *FXRateService) syncProvider(ctx context.Context, provider ports.FXRateProvider, day time.Time) domain.FXSyncResult {
+	result := domain.FXSyncResult{Provider: provider.Name(), Day: day, SyncedAt: time.Now().UTC()}
+
+	fetched, err := provider.FetchRates(ctx, day)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Fetched = len(fetched)
+
+	rates := make([]*domain.FXRate, 0, len(fetched))
+	for _, rate := range fetched {
+		if rate.RateDate.IsZero() {
+			rate.RateDate = day
+		}
+		rate.Normalize()
+		if err := rate.Validate(); err != nil {
+			result.Skipped++
+			continue
+		}
+		rate.Source = provider.Name()
+		rate.FetchedAt = result.SyncedAt
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		result.Error = "provider returned no valid rates"
+		return result
+	}
+
+	result.Stored, err = s.repo.UpsertRates(ctx, rates)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// thresholdAmount returns the amount a threshold rule compares against
+// max_amount, and the currency it is in. A rule naming a currency compares the
+// transaction's value in that currency on the day it took place; a rule
+// without one compares fallback as given.
+func thresholdAmount(ctx context.Context, fx *FXRateService, condition map[string]interface{}, tx *domain.Transaction, fallback decimal.Decimal) (decimal.Decimal, string, error) {
+	currency, _ := condition["currency"].(string)
+	currency = domain.NormalizeCurrency(currency)
+	if currency == "" {
+		return fallback, "", nil
+	}
+	if fx == nil {
+		return decimal.Zero, "", fmt.Errorf("%w: exchange rates are not configured", domain.ErrRateNotFound)
+	}
+	value, err := fx.ValueIn(ctx, tx, currency)
+	return value, currency, err
+}
+
+// Ensure FXRateService implements the FXRateService interface
+var _ ports.FXRateService = (*FXRateService)(nil)
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// NotificationDigestService keeps users' notification preferences and sends
+// the digests that batch their non-critical subscription hits. Preferences are
+// held in memory for routing hits on the ingestion path; the set is reloaded
+// on every run to pick up changes made by other replicas.
+type NotificationDigestService struct {
+	repo      ports.NotificationPreferenceRepository
+	notifiers map[domain.NotificationChannelType]ports.DigestNotifier
+	logger    *zap.Logger
+
+	mu          sync.RWMutex
+	preferences map[string]*domain.NotificationPreference
+}
+
+// NewNotificationDigestService creates a new notification digest service.
+// Preferences may only name channels that have a digest notifier.
+func NewNotificationDigestService(
+	repo ports.NotificationPreferenceRepository,
+	notifiers map[domain.NotificationChannelType]ports.DigestNotifier,
+	logger *zap.Logger,
+) *NotificationDigestService {
+	return &NotificationDigestService{
+		repo:        repo,
+		notifiers:   notifiers,
+		logger:      logger,
+		preferences: make(map[string]*domain.NotificationPreference),
+	}
+}
+
+// Start reloads preferences and sends the digests that are due every
+// interval until ctx is cancelled
+func (s *NotificationDigestService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			s.logger.Error("Failed to load notification preferences", zap.Error(err))
+		}
+		s.SendDueDigests(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh reloads the preferences used to route hits
+func (s *NotificationDigestService) Refresh(ctx context.Context) error {
+	list, err := s.repo.ListPreferences(ctx)
+	if err != nil {
+		return err
+	}
+
+	preferences := make(map[string]*domain.NotificationPreference, len(list))
+	for _, preference := range list {
+		preferences[preference.UserID] = preference
+	}
+
+	s.mu.Lock()
+	s.preferences = preferences
+	s.mu.Unlock()
+	return nil
+}
+
+// Preference returns the user's loaded preference, or the default for users
+// who never set one
+func (s *NotificationDigestService) Preference(userID string) *domain.NotificationPreference {
+	s.mu.RLock()
+	preference, ok := s.preferences[userID]
+	s.mu.RUnlock()
+	if !ok {
+		return domain.DefaultNotificationPreference(userID)
+	}
+	return preference
+}
+
+// QueueDigest holds a hit for the user's next digest
+func (s *NotificationDigestService) QueueDigest(ctx context.Context, userID string, hit *domain.SubscriptionHit) error {
+	return s.repo.QueueDigestHit(ctx, userID, hit)
+}
+
+// GetPreference retrieves a user's stored preference, or the default for
+// users who never set one
+func (s *NotificationDigestService) GetPreference(ctx context.Context, userID string) (*domain.NotificationPreference, error) {
+	preference, err := s.repo.GetPreference(ctx, userID)
+	if errors.Is(err, domain.ErrPreferenceNotFound) {
+		return domain.DefaultNotificationPreference(userID), nil
+	}
+	return preference, err
+}
+
+// UpdatePreference replaces a user's channels, severities and digest
+// frequency. The time of their last digest is kept.
+func (s *NotificationDigestService) UpdatePreference(ctx context.Context, preference *domain.NotificationPreference, actor string) error {
+	if preference.UserID == "" {
+		return fmt.Errorf("%w: user is required", domain.ErrInvalidPreference)
+	}
+	if err := preference.Validate(); err != nil {
+		return err
+	}
+	for _, channel := range preference.Channels {
+		if _, ok := s.notifiers[channel.Type]; !ok {
+			return fmt.Errorf("%w: %s channels are not configured", domain.ErrInvalidPreference, channel.Type)
+		}
+	}
+
+	existing, err := s.GetPreference(ctx, preference.UserID)
+	if err != nil {
+		return err
+	}
+	preference.LastDigestAt = existing.LastDigestAt
+	preference.UpdatedBy = actor
+	preference.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.SavePreference(ctx, preference); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.preferences[preference.UserID] = preference
+	s.mu.Unlock()
+
+	s.logger.Info("Notification preference updated",
+		zap.String("user_id", preference.UserID),
+		zap.String("digest_frequency", string(preference.DigestFrequency)),
+		zap.Int("channels", len(preference.Channels)),
+		zap.String("actor", actor),
+	)
+	return nil
+}
+
+// PreviewDigest builds the digest the user would receive now from every hit
+// queued so far, without sending it
+func (s *NotificationDigestService) PreviewDigest(ctx context.Context, userID string) (*domain.NotificationDigest, error) {
+	preference, err := s.GetPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	entries, err := s.repo.ListQueuedHits(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewNotificationDigest(preference, entries, now), nil
+}
+
+// SendDueDigests sends every digest whose period has ended by now and returns
+// how many were sent. A digest that no channel accepted stays queued and is
+// retried on the next run.
+func (s *NotificationDigestService) SendDueDigests(ctx context.Context, now time.Time) int {
+	queues, err := s.repo.ListDigestQueues(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list digest queues", zap.Error(err))
+		return 0
+	}
+
+	sent := 0
+	for _, queue := range queues {
+		if ctx.Err() != nil {
+			break
+		}
+		preference := s.Preference(queue.UserID)
+		if !preference.DigestDue(queue.Oldest, now) {
+			continue
+		}
+		if err := s.sendDigest(ctx, preference, now); err != nil {
+			s.logger.Warn("Failed to send notification digest",
+				zap.String("user_id", queue.UserID),
+				zap.Int("queued", queue.Count),
+				zap.Error(err))
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// sendDigest sends the user's hits queued in the periods ended by now to each
+// of their channels
+func (s *NotificationDigestService) sendDigest(ctx context.Context, preference *domain.NotificationPreference, now time.Time) error {
+	end := now
+	if period := preference.DigestFrequency.Period(); period > 0 {
+		end = now.Truncate(period)
+	}
+	entries, err := s.repo.ListQueuedHits(ctx, preference.UserID, end)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	if len(preference.Channels) == 0 {
+		return errors.New("no channels to send the digest to")
+	}
+
+	digest := domain.NewNotificationDigest(preference, entries, end)
+	var errs []error
+	delivered := false
+	for i := range preference.Channels {
+		channel := &preference.Channels[i]
+		notifier, ok := s.notifiers[channel.Type]
+		if !ok {
+			errs = append(errs, fmt.Errorf("no notifier for %s channels", channel.Type))
+			continue
+		}
+		if err := notifier.NotifyDigest(ctx, channel, digest); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered = true
+	}
+	if !delivered {
+		return errors.Join(errs...)
+	}
+	if len(errs) > 0 {
+		s.logger.Warn("Notification digest not delivered to every channel",
+			zap.String("user_id", preference.UserID),
+			zap.Error(errors.Join(errs...)))
+	}
+
+	hitIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		hitIDs = append(hitIDs, entry.Hit.ID)
+	}
+	if err := s.repo.CompleteDigest(ctx, preference.UserID, hitIDs, now); err != nil {
+		return err
+	}
+
+	s.logger.Info("Notification digest sent",
+		zap.String("user_id", preference.UserID),
+		zap.String("frequency", string(preference.DigestFrequency)),
+		zap.Int("hits", len(entries)),
+	)
+	return nil
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// PatternSubscriptionService manages analysts' standing queries and evaluates
+// them against the ingestion stream. Active subscriptions are held in memory;
+// the set is reloaded after every lifecycle change made through this service
+// and periodically, to pick up changes made by other replicas. Hits are routed
+// by their owner's notification preferences.
+type PatternSubscriptionService struct {
+	repo      ports.PatternSubscriptionRepository
+	notifiers map[domain.NotificationChannelType]ports.SubscriptionNotifier
+	router    ports.NotificationRouter
+	logger    *zap.Logger
+
+	mu     sync.RWMutex
+	active []*domain.PatternSubscription
+}
+
+// NewPatternSubscriptionService creates a new pattern subscription service.
+// Subscriptions may only bind channels that have a notifier.
+func NewPatternSubscriptionService(
+	repo ports.PatternSubscriptionRepository,
+	notifiers map[domain.NotificationChannelType]ports.SubscriptionNotifier,
+	router ports.NotificationRouter,
+	logger *zap.Logger,
+) *PatternSubscriptionService {
+	return &PatternSubscriptionService{
+		repo:      repo,
+		notifiers: notifiers,
+		router:    router,
+		logger:    logger,
+	}
+}
+
+// Start reloads the active subscriptions every interval until ctx is cancelled
+func (s *PatternSubscriptionService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			s.logger.Error("Failed to load pattern subscriptions", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh reloads the active subscriptions evaluated against the stream
+func (s *PatternSubscriptionService) Refresh(ctx context.Context) error {
+	active, err := s.repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.active = active
+	s.mu.Unlock()
+	return nil
+}
+
+// CreateSubscription saves a new active subscription owned by actor
+func (s *PatternSubscriptionService) CreateSubscription(ctx context.Context, subscription *domain.PatternSubscription, actor string) error {
+	if err := s.validate(subscription); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	subscription.Owner = actor
+	subscription.Status = domain.SubscriptionActive
+	subscription.HitCount = 0
+	subscription.LastHitAt = nil
+	subscription.UpdatedBy = actor
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return err
+	}
+
+	s.logger.Info("Pattern subscription created",
+		zap.String("id", subscription.ID),
+		zap.String("name", subscription.Name),
+		zap.Int("addresses", len(subscription.Filter.Addresses)),
+		zap.String("actor", actor),
+	)
+	s.refreshAfterChange(ctx)
+
+	return nil
+}
+
+// UpdateSubscription replaces a subscription's name, filter and channel. Its
+// status, owner and hit log are kept.
+func (s *PatternSubscriptionService) UpdateSubscription(ctx context.Context, subscription *domain.PatternSubscription, actor string) error {
+	existing, err := s.repo.GetSubscription(ctx, subscription.ID)
+	if err != nil {
+		return err
+	}
+	if existing.Status == domain.SubscriptionArchived {
+		return domain.ErrSubscriptionArchived
+	}
+	if err := s.validate(subscription); err != nil {
+		return err
+	}
+
+	subscription.Owner = existing.Owner
+	subscription.Status = existing.Status
+	subscription.HitCount = existing.HitCount
+	subscription.LastHitAt = existing.LastHitAt
+	subscription.CreatedAt = existing.CreatedAt
+	subscription.UpdatedBy = actor
+	subscription.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.UpdateSubscription(ctx, subscription); err != nil {
+		return err
+	}
+
+	s.logger.Info("Pattern subscription updated", zap.String("id", subscription.ID), zap.String("actor", actor))
+	s.refreshAfterChange(ctx)
+
+	return nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (s *PatternSubscriptionService) GetSubscription(ctx context.Context, id string) (*domain.PatternSubscription, error) {
+	return s.repo.GetSubscription(ctx, id)
+}
+
+// ListSubscriptions lists subscriptions matching the filter. Archived
+// subscriptions are only listed when asked for by status.
+func (s *PatternSubscriptionService) ListSubscriptions(ctx context.Context, filter domain.SubscriptionListFilter) ([]*domain.PatternSubscription, int64, error) {
+	if filter.Limit <= 0 || filter.Limit > 500 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.ListSubscriptions(ctx, filter)
+}
+
+// PauseSubscription stops evaluating a subscription until it is resumed
+func (s *PatternSubscriptionService) PauseSubscription(ctx context.Context, id, actor string) (*domain.PatternSubscription, error) {
+	return s.transition(ctx, id, actor, domain.SubscriptionPaused)
+}
+
+// ResumeSubscription evaluates a paused subscription again. Transactions
+// ingested while it was paused are not matched.
+func (s *PatternSubscriptionService) ResumeSubscription(ctx context.Context, id, actor string) (*domain.PatternSubscription, error) {
+	return s.transition(ctx, id, actor, domain.SubscriptionActive)
+}
+
+// ArchiveSubscription retires a subscription for good; its hit log is kept
+func (s *PatternSubscriptionService) ArchiveSubscription(ctx context.Context, id, actor string) (*domain.PatternSubscription, error) {
+	return s.transition(ctx, id, actor, domain.SubscriptionArchived)
+}
+
+// ListHits lists a subscription's hit log, most recent first
+func (s *PatternSubscriptionService) ListHits(ctx context.Context, id string, limit, offset int) ([]*domain.SubscriptionHit, int64, error) {
+	if _, err := s.repo.GetSubscription(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListHits(ctx, id, limit, offset)
+}
+
+// Evaluate matches an analysed transaction against the active subscriptions,
+// records each new hit and routes it to the subscription's owner. Failures
+// are logged; they never hold up ingestion.
+func (s *PatternSubscriptionService) Evaluate(ctx context.Context, tx *domain.Transaction) []*domain.SubscriptionHit {
+	s.mu.RLock()
+	active := s.active
+	s.mu.RUnlock()
+
+	var hits []*domain.SubscriptionHit
+	for _, subscription := range active {
+		address, direction, ok := subscription.Filter.Match(tx)
+		if !ok {
+			continue
+		}
+
+		hit := &domain.SubscriptionHit{
+			SubscriptionID: subscription.ID,
+			TransactionID:  tx.ID,
+			TxHash:         tx.TxHash,
+			Chain:          tx.Chain,
+			MatchedAddress: address,
+			Direction:      direction,
+			Amount:         tx.Amount,
+			AmountUSD:      tx.AmountUSD,
+			RiskScore:      tx.RiskScore,
+			TxTimestamp:    tx.TxTimestamp,
+			MatchedAt:      time.Now().UTC(),
+			Severity:       domain.HitSeverity(tx.RiskScore),
+		}
+		recorded, err := s.repo.RecordHit(ctx, hit)
+		if err != nil {
+			s.logger.Error("Failed to record subscription hit",
+				zap.String("subscription_id", subscription.ID),
+				zap.String("tx_hash", tx.TxHash),
+				zap.Error(err))
+			continue
+		}
+		if !recorded {
+			continue
+		}
+
+		s.deliver(ctx, subscription, hit)
+		hits = append(hits, hit)
+	}
+
+	return hits
+}
+
+// deliver routes a hit by its owner's preference and records the outcome.
+// Severities the owner does not want are muted and non-critical hits of an
+// owner on a digest are queued for it; the rest go out now to the
+// subscription's channel or, without one, to the owner's channels.
+func (s *PatternSubscriptionService) deliver(ctx context.Context, subscription *domain.PatternSubscription, hit *domain.SubscriptionHit) {
+	preference := domain.DefaultNotificationPreference(subscription.Owner)
+	if s.router != nil {
+		preference = s.router.Preference(subscription.Owner)
+	}
+
+	switch {
+	case !preference.Receives(hit.Severity):
+		hit.Delivery = domain.DeliveryMuted
+	case preference.Digests(hit.Severity):
+		hit.Delivery = domain.DeliveryDigest
+		if err := s.router.QueueDigest(ctx, subscription.Owner, hit); err != nil {
+			hit.NotifyError = "failed to queue for digest: " + err.Error()
+		}
+	default:
+		channels := preference.Channels
+		if subscription.Channel != nil {
+			channels = []domain.NotificationChannel{*subscription.Channel}
+		}
+		if len(channels) == 0 {
+			return
+		}
+		hit.Delivery = domain.DeliveryRealtime
+		s.notify(ctx, channels, subscription, hit)
+	}
+
+	if hit.NotifyError != "" {
+		s.logger.Warn("Failed to deliver subscription hit",
+			zap.String("subscription_id", subscription.ID),
+			zap.String("delivery", string(hit.Delivery)),
+			zap.String("error", hit.NotifyError))
+	}
+	if err := s.repo.UpdateHitDelivery(ctx, hit); err != nil {
+		s.logger.Error("Failed to record subscription hit delivery", zap.String("hit_id", hit.ID), zap.Error(err))
+	}
+}
+
+// notify sends a hit to each channel. The hit counts as notified if any
+// channel took it; failures on the others are kept in its notify error.
+func (s *PatternSubscriptionService) notify(ctx context.Context, channels []domain.NotificationChannel, subscription *domain.PatternSubscription, hit *domain.SubscriptionHit) {
+	var failures []string
+	for i := range channels {
+		channel := &channels[i]
+		notifier, ok := s.notifiers[channel.Type]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("no notifier for %s channels", channel.Type))
+			continue
+		}
+		if err := notifier.Notify(ctx, channel, subscription, hit); err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		if hit.NotifiedAt == nil {
+			now := time.Now().UTC()
+			hit.NotifiedAt = &now
+		}
+	}
+	hit.NotifyError = strings.Join(failures, "; ")
+}
+
+// transition moves a subscription to a new status. Archived subscriptions
+// cannot change status.
+func (s *PatternSubscriptionService) transition(ctx context.Context, id, actor string, status domain.SubscriptionStatus) (*domain.PatternSubscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.Status == domain.SubscriptionArchived {
+		return nil, domain.ErrSubscriptionArchived
+	}
+	if subscription.Status == status {
+		return subscription, nil
+	}
+
+	subscription.Status = status
+	subscription.UpdatedBy = actor
+	subscription.UpdatedAt = time.Now().UTC()
+	if err := s.repo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Pattern subscription status changed",
+		zap.String("id", id),
+		zap.String("status", string(status)),
+		zap.String("actor", actor),
+	)
+	s.refreshAfterChange(ctx)
+
+	return subscription, nil
+}
+
+// validate checks the subscription and that its channel can be delivered to
+func (s *PatternSubscriptionService) validate(subscription *domain.PatternSubscription) error {
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+	if subscription.Channel != nil {
+		if _, ok := s.notifiers[subscription.Channel.Type]; !ok {
+			return fmt.Errorf("%w: %s channels are not configured", domain.ErrInvalidSubscription, subscription.Channel.Type)
+		}
+	}
+	return nil
+}
+
+// refreshAfterChange reloads the active set after a change; on failure the
+// periodic refresh catches up
+func (s *PatternSubscriptionService) refreshAfterChange(ctx context.Context) {
+	if err := s.Refresh(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		s.logger.Warn("Failed to reload pattern subscriptions", zap.Error(err))
+	}
+}
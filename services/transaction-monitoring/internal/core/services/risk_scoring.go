@@ -7,6 +7,7 @@ import (
 
 	"github.com/csic/monitoring/internal/core/domain"
 	"github.com/csic/monitoring/internal/core/ports"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -118,25 +119,27 @@ func (s *RiskScoringService) checkSanctions(address, chain string) domain.RiskFa
 	}
 }
 
-func (s *RiskScoringService) checkAmountThresholds(amountUSD float64) domain.RiskFactor {
+// checkAmountThresholds compares the exact amount against the thresholds; the
+// factor reports it as a float for display only
+func (s *RiskScoringService) checkAmountThresholds(amountUSD decimal.Decimal) domain.RiskFactor {
 	factor := domain.RiskFactor{
 		Type:       "AMOUNT_THRESHOLD",
-		Observed:   amountUSD,
+		Observed:   amountUSD.InexactFloat64(),
 	}
 
-	if amountUSD >= 100000 {
+	if amountUSD.GreaterThanOrEqual(decimal.NewFromInt(100000)) {
 		factor.Score = 50
 		factor.Threshold = 100000
 		factor.Description = "Transaction amount exceeds $100,000 threshold"
-	} else if amountUSD >= 50000 {
+	} else if amountUSD.GreaterThanOrEqual(decimal.NewFromInt(50000)) {
 		factor.Score = 30
 		factor.Threshold = 50000
 		factor.Description = "Transaction amount exceeds $50,000 threshold"
-	} else if amountUSD >= 10000 {
+	} else if amountUSD.GreaterThanOrEqual(decimal.NewFromInt(10000)) {
 		factor.Score = 20
 		factor.Threshold = 10000
 		factor.Description = "Transaction amount exceeds $10,000 threshold (potential structuring)"
-	} else if amountUSD >= 1000 {
+	} else if amountUSD.GreaterThanOrEqual(decimal.NewFromInt(1000)) {
 		factor.Score = 5
 		factor.Threshold = 1000
 		factor.Description = "Transaction amount exceeds $1,000 threshold"
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/csic/monitoring/internal/core/domain"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -74,7 +75,7 @@ func TestRiskScoringService_CalculateRiskScore(t *testing.T) {
 				TxHash:    "0x123",
 				Chain:     "ethereum",
 				FromAddress: "0xabc",
-				AmountUSD: 100,
+				AmountUSD: decimal.NewFromInt(100),
 			},
 			expectedScore: 0,
 			expectedLevel: "MINIMAL",
@@ -86,7 +87,7 @@ func TestRiskScoringService_CalculateRiskScore(t *testing.T) {
 				TxHash:    "0x456",
 				Chain:     "ethereum",
 				FromAddress: "0xdef",
-				AmountUSD: 15000,
+				AmountUSD: decimal.NewFromInt(15000),
 			},
 			expectedScore: 20,
 			expectedLevel: "LOW",
@@ -98,7 +99,7 @@ func TestRiskScoringService_CalculateRiskScore(t *testing.T) {
 				TxHash:    "0x789",
 				Chain:     "bitcoin",
 				FromAddress: "0xghi",
-				AmountUSD: 150000,
+				AmountUSD: decimal.NewFromInt(150000),
 			},
 			expectedScore: 50,
 			expectedLevel: "MEDIUM",
@@ -212,8 +213,8 @@ func TestTransactionService_IngestTransaction(t *testing.T) {
 			Chain:      "ethereum",
 			FromAddress: "0xabcd",
 			ToAddress:   stringPtr("0xefgh"),
-			Amount:      1.5,
-			AmountUSD:   3000,
+			Amount:      decimal.RequireFromString("1.5"),
+			AmountUSD:   decimal.NewFromInt(3000),
 			TxTimestamp: time.Now(),
 		}
 
@@ -221,7 +222,7 @@ func TestTransactionService_IngestTransaction(t *testing.T) {
 			t.Error("Transaction should have a hash")
 		}
 
-		if tx.AmountUSD <= 0 {
+		if !tx.AmountUSD.IsPositive() {
 			t.Error("Transaction should have a positive USD amount")
 		}
 	})
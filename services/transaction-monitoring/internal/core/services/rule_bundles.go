@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RuleBundleConfig configures the signing and verification of rule bundles
+type RuleBundleConfig struct {
+	// Issuer names this agency in exported bundles
+	Issuer string
+	// KeyID identifies the signing key to importers
+	KeyID string
+	// PrivateKey is the base64 Ed25519 seed signing exports; without it
+	// bundles can be imported but not exported
+	PrivateKey string
+	// TrustedKeys maps the key IDs of other agencies to base64 Ed25519 public keys
+	TrustedKeys map[string]string
+}
+
+// RuleBundleService exports monitoring rules as signed bundles and imports
+// bundles signed by trusted agencies
+type RuleBundleService struct {
+	ruleRepo    ports.MonitoringRuleRepository
+	versionRepo ports.RuleVersionRepository
+	expressions *RuleExpressionEngine
+	issuer      string
+	keyID       string
+	privateKey  ed25519.PrivateKey
+	trustedKeys map[string]ed25519.PublicKey
+	logger      *zap.Logger
+}
+
+// NewRuleBundleService creates a new rule bundle service. The public key of
+// the signing key is trusted along with the configured keys.
+func NewRuleBundleService(
+	ruleRepo ports.MonitoringRuleRepository,
+	versionRepo ports.RuleVersionRepository,
+	expressions *RuleExpressionEngine,
+	config RuleBundleConfig,
+	logger *zap.Logger,
+) (*RuleBundleService, error) {
+	s := &RuleBundleService{
+		ruleRepo:    ruleRepo,
+		versionRepo: versionRepo,
+		expressions: expressions,
+		issuer:      config.Issuer,
+		keyID:       config.KeyID,
+		trustedKeys: make(map[string]ed25519.PublicKey),
+		logger:      logger,
+	}
+
+	for keyID, encoded := range config.TrustedKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("trusted rule bundle key %s is not a base64 Ed25519 public key", keyID)
+		}
+		s.trustedKeys[keyID] = ed25519.PublicKey(key)
+	}
+
+	if config.PrivateKey != "" {
+		seed, err := base64.StdEncoding.DecodeString(config.PrivateKey)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("rule bundle signing key is not a base64 Ed25519 seed")
+		}
+		if config.KeyID == "" {
+			return nil, fmt.Errorf("rule bundle signing key has no key ID")
+		}
+		s.privateKey = ed25519.NewKeyFromSeed(seed)
+		s.trustedKeys[config.KeyID] = s.privateKey.Public().(ed25519.PublicKey)
+	}
+
+	return s, nil
+}
+
+// ExportRules signs a bundle of the live definitions of the given rules, or
+// of every active rule when no IDs are given
+func (s *RuleBundleService) ExportRules(ctx context.Context, ruleIDs []string) (*domain.RuleBundle, error) {
+	if s.privateKey == nil {
+		return nil, domain.ErrRuleBundleSigningKey
+	}
+
+	var rules []*domain.MonitoringRule
+	if len(ruleIDs) == 0 {
+		active, err := s.ruleRepo.GetActiveRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rules = active
+	}
+	for _, id := range ruleIDs {
+		rule, err := s.ruleRepo.GetRule(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	bundle := &domain.RuleBundle{
+		SchemaVersion: domain.RuleBundleSchemaVersion,
+		BundleID:      uuid.NewString(),
+		Issuer:        s.issuer,
+		ExportedAt:    time.Now().UTC(),
+		Rules:         make([]domain.RuleBundleRule, 0, len(rules)),
+		KeyID:         s.keyID,
+	}
+	for _, rule := range rules {
+		bundle.Rules = append(bundle.Rules, domain.NewRuleBundleRule(rule))
+	}
+
+	payload, err := bundlePayload(bundle)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, payload))
+
+	s.logger.Info("Rule bundle exported",
+		zap.String("bundle_id", bundle.BundleID),
+		zap.Int("rules", len(bundle.Rules)))
+
+	return bundle, nil
+}
+
+// ImportRules verifies a bundle's signature and schema and diffs its rules
+// against the existing rules, matched by name. Unless dryRun is set, the
+// selected rules, or all of them when refs is empty, are applied in a single
+// transaction: new rules are created active and changed rules get a new
+// version pending approval, so imports go through the same review as edits.
+func (s *RuleBundleService) ImportRules(ctx context.Context, bundle *domain.RuleBundle, refs []string, actor string, dryRun bool) (*domain.RuleImportPlan, error) {
+	if err := s.verifyBundle(bundle); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		selected[ref] = true
+	}
+	for ref := range selected {
+		if !bundleHasRule(bundle, ref) {
+			return nil, fmt.Errorf("%w: rule %s is not in the bundle", domain.ErrInvalidRuleBundle, ref)
+		}
+	}
+
+	existing, err := s.ruleRepo.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.MonitoringRule, len(existing))
+	for _, rule := range existing {
+		if _, ok := byName[rule.Name]; !ok {
+			byName[rule.Name] = rule
+		}
+	}
+
+	plan := &domain.RuleImportPlan{
+		BundleID:      bundle.BundleID,
+		Issuer:        bundle.Issuer,
+		SchemaVersion: bundle.SchemaVersion,
+		KeyID:         bundle.KeyID,
+		DryRun:        dryRun,
+		Items:         make([]domain.RuleImportItem, 0, len(bundle.Rules)),
+	}
+	changeNote := fmt.Sprintf("Imported from %s bundle %s", bundle.Issuer, bundle.BundleID)
+	now := time.Now()
+
+	var versions []*domain.RuleVersion
+	for i := range bundle.Rules {
+		bundled := &bundle.Rules[i]
+		item := domain.RuleImportItem{
+			Ref:      bundled.Ref,
+			Name:     bundled.Name,
+			Action:   domain.RuleImportCreate,
+			Selected: len(selected) == 0 || selected[bundled.Ref],
+		}
+
+		status := domain.RuleVersionActive
+		ruleID := ""
+		if current, ok := byName[bundled.Name]; ok {
+			item.RuleID = current.ID
+			ruleID = current.ID
+			status = domain.RuleVersionPendingApproval
+
+			live := domain.NewRuleVersion(current, domain.RuleVersionActive, "", "")
+			live.Version = current.Version
+			item.Changes = domain.DiffRuleVersions(live, bundled.NewVersion(current.ID, status, actor, changeNote)).Changes
+			item.Action = domain.RuleImportUpdate
+			if len(item.Changes) == 0 {
+				item.Action = domain.RuleImportUnchanged
+			}
+		}
+		plan.Items = append(plan.Items, item)
+
+		if item.Selected && item.Action != domain.RuleImportUnchanged {
+			version := bundled.NewVersion(ruleID, status, actor, changeNote)
+			if status == domain.RuleVersionPendingApproval {
+				version.SubmittedBy = actor
+				version.SubmittedAt = &now
+			}
+			versions = append(versions, version)
+		}
+	}
+
+	if dryRun || len(versions) == 0 {
+		return plan, nil
+	}
+
+	if err := s.versionRepo.ImportVersions(ctx, versions); err != nil {
+		return nil, err
+	}
+	plan.Versions = versions
+	appliedAt := time.Now().UTC()
+	plan.AppliedAt = &appliedAt
+
+	s.logger.Info("Rule bundle imported",
+		zap.String("bundle_id", bundle.BundleID),
+		zap.String("issuer", bundle.Issuer),
+		zap.String("actor", actor),
+		zap.Int("versions", len(versions)))
+
+	return plan, nil
+}
+
+// verifyBundle checks a bundle's schema version, its signature by a trusted
+// key and every rule definition in it
+func (s *RuleBundleService) verifyBundle(bundle *domain.RuleBundle) error {
+	supported := false
+	for _, version := range domain.SupportedRuleBundleSchemas {
+		if bundle.SchemaVersion == version {
+			supported = true
+		}
+	}
+	if !supported {
+		return fmt.Errorf("%w: %d", domain.ErrRuleBundleSchema, bundle.SchemaVersion)
+	}
+
+	key, ok := s.trustedKeys[bundle.KeyID]
+	if !ok {
+		return fmt.Errorf("%w: %q", domain.ErrRuleBundleUntrusted, bundle.KeyID)
+	}
+	signature, err := base64.StdEncoding.DecodeString(bundle.Signature)
+	if err != nil {
+		return domain.ErrRuleBundleSignature
+	}
+	payload, err := bundlePayload(bundle)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(key, payload, signature) {
+		return domain.ErrRuleBundleSignature
+	}
+
+	refs := make(map[string]bool, len(bundle.Rules))
+	names := make(map[string]bool, len(bundle.Rules))
+	for i := range bundle.Rules {
+		rule := &bundle.Rules[i]
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if refs[rule.Ref] || names[rule.Name] {
+			return fmt.Errorf("%w: rule %q appears more than once", domain.ErrInvalidRuleBundle, rule.Name)
+		}
+		refs[rule.Ref] = true
+		names[rule.Name] = true
+
+		if rule.RuleType == domain.RuleTypeExpression {
+			if err := s.expressions.Validate(rule.Expression); err != nil {
+				return fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// bundlePayload returns the signed encoding of a bundle: its JSON without the signature
+func bundlePayload(bundle *domain.RuleBundle) ([]byte, error) {
+	unsigned := *bundle
+	unsigned.Signature = ""
+
+	payload, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rule bundle: %w", err)
+	}
+	return payload, nil
+}
+
+// bundleHasRule reports whether a bundle holds the rule with the given ref
+func bundleHasRule(bundle *domain.RuleBundle, ref string) bool {
+	for _, rule := range bundle.Rules {
+		if rule.Ref == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure RuleBundleService implements the RuleBundleService interface
+var _ ports.RuleBundleService = (*RuleBundleService)(nil)
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/google/cel-go/cel"
+)
+
+// compiledExpression caches a compiled program with the source it was built from
+type compiledExpression struct {
+	source  string
+	program cel.Program
+}
+
+// RuleExpressionEngine compiles and evaluates CEL expressions for EXPRESSION rules.
+// Expressions can reference tx.* (the transaction) and entity.* (the sender's wallet profile),
+// e.g. `tx.amount_usd > 50000.0 && tx.source_country in ["IR", "KP"] && entity.risk_score >= 70`.
+type RuleExpressionEngine struct {
+	env      *cel.Env
+	programs map[string]compiledExpression
+	mu       sync.RWMutex
+}
+
+// NewRuleExpressionEngine creates a new rule expression engine
+func NewRuleExpressionEngine() (*RuleExpressionEngine, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("tx", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("entity", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create expression environment: %w", err)
+	}
+
+	return &RuleExpressionEngine{
+		env:      env,
+		programs: make(map[string]compiledExpression),
+	}, nil
+}
+
+// Validate checks that an expression compiles and yields a boolean
+func (e *RuleExpressionEngine) Validate(expression string) error {
+	_, err := e.compile(expression)
+	return err
+}
+
+// Evaluate runs the rule's expression against a transaction and the sender's profile.
+// Compiled programs are cached per rule and rebuilt when the expression changes.
+func (e *RuleExpressionEngine) Evaluate(rule *domain.MonitoringRule, tx *domain.Transaction, profile *domain.WalletProfile) (bool, error) {
+	program, err := e.program(rule)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{
+		"tx":     transactionVariables(tx),
+		"entity": entityVariables(profile),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression: %w", err)
+	}
+
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: expression returned %T, expected bool", domain.ErrInvalidRuleExpression, out.Value())
+	}
+	return matched, nil
+}
+
+// Invalidate drops the cached program for a rule
+func (e *RuleExpressionEngine) Invalidate(ruleID string) {
+	e.mu.Lock()
+	delete(e.programs, ruleID)
+	e.mu.Unlock()
+}
+
+func (e *RuleExpressionEngine) program(rule *domain.MonitoringRule) (cel.Program, error) {
+	e.mu.RLock()
+	cached, ok := e.programs[rule.ID]
+	e.mu.RUnlock()
+	if ok && cached.source == rule.Expression {
+		return cached.program, nil
+	}
+
+	program, err := e.compile(rule.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.programs[rule.ID] = compiledExpression{source: rule.Expression, program: program}
+	e.mu.Unlock()
+
+	return program, nil
+}
+
+func (e *RuleExpressionEngine) compile(expression string) (cel.Program, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, fmt.Errorf("%w: expression is required", domain.ErrInvalidRuleExpression)
+	}
+
+	ast, issues := e.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidRuleExpression, issues.Err())
+	}
+
+	outputType := ast.OutputType()
+	if !outputType.IsExactType(cel.BoolType) && !outputType.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("%w: expression must return bool, got %s", domain.ErrInvalidRuleExpression, outputType)
+	}
+
+	program, err := e.env.Program(ast, cel.EvalOptions(cel.OptOptimize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidRuleExpression, err)
+	}
+	return program, nil
+}
+
+// transactionVariables exposes transaction fields to expressions. Every key is
+// always present so expressions never fail on a missing optional field. CEL has
+// no decimal type, so amounts are compared as doubles.
+func transactionVariables(tx *domain.Transaction) map[string]interface{} {
+	vars := map[string]interface{}{
+		"id":                  tx.ID,
+		"tx_hash":             tx.TxHash,
+		"chain":               tx.Chain,
+		"from_address":        tx.FromAddress,
+		"to_address":          "",
+		"token_address":       "",
+		"amount":              tx.Amount.InexactFloat64(),
+		"amount_usd":          tx.AmountUSD.InexactFloat64(),
+		"risk_score":          int64(tx.RiskScore),
+		"flagged":             tx.Flagged,
+		"timestamp":           tx.TxTimestamp,
+		"source_country":      "",
+		"destination_country": "",
+		"metadata":            map[string]interface{}{},
+	}
+
+	if tx.ToAddress != nil {
+		vars["to_address"] = *tx.ToAddress
+	}
+	if tx.TokenAddress != nil {
+		vars["token_address"] = *tx.TokenAddress
+	}
+	if tx.Metadata != nil {
+		vars["metadata"] = tx.Metadata
+		if country, ok := tx.Metadata["source_country"].(string); ok {
+			vars["source_country"] = country
+		}
+		if country, ok := tx.Metadata["destination_country"].(string); ok {
+			vars["destination_country"] = country
+		}
+	}
+
+	return vars
+}
+
+// entityVariables exposes the sender's wallet profile to expressions
+func entityVariables(profile *domain.WalletProfile) map[string]interface{} {
+	if profile == nil {
+		profile = &domain.WalletProfile{}
+	}
+
+	tags := profile.ConnectedTags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return map[string]interface{}{
+		"address":          profile.Address,
+		"chain":            profile.Chain,
+		"risk_score":       profile.CurrentRiskScore,
+		"tx_count":         int64(profile.TxCount),
+		"total_volume_usd": profile.TotalVolumeUSD.InexactFloat64(),
+		"avg_tx_value_usd": profile.AvgTxValueUSD.InexactFloat64(),
+		"wallet_age_hours": int64(profile.WalletAgeHours),
+		"is_contract":      profile.IsContract,
+		"tags":             tags,
+	}
+}
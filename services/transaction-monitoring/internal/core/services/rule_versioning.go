@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// ListRuleVersions returns the version history of a rule, newest first
+func (s *RuleEngineService) ListRuleVersions(ctx context.Context, ruleID string) ([]*domain.RuleVersion, error) {
+	return s.versionRepo.ListVersions(ctx, ruleID)
+}
+
+// DiffRuleVersions returns the field-level changes between two versions of a rule
+func (s *RuleEngineService) DiffRuleVersions(ctx context.Context, ruleID string, from, to int) (*domain.RuleVersionDiff, error) {
+	fromVersion, err := s.versionRepo.GetVersion(ctx, ruleID, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := s.versionRepo.GetVersion(ctx, ruleID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.DiffRuleVersions(fromVersion, toVersion), nil
+}
+
+// SubmitRuleVersion moves a draft version to pending approval
+func (s *RuleEngineService) SubmitRuleVersion(ctx context.Context, ruleID string, version int, actor string) (*domain.RuleVersion, error) {
+	v, err := s.transitionVersion(ctx, ruleID, version, domain.RuleVersionPendingApproval)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	v.Status = domain.RuleVersionPendingApproval
+	v.SubmittedBy = actor
+	v.SubmittedAt = &now
+
+	if err := s.versionRepo.UpdateVersionStatus(ctx, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ApproveRuleVersion activates a pending version and makes it the live rule definition.
+// A version cannot be approved by the analyst who authored or submitted it.
+func (s *RuleEngineService) ApproveRuleVersion(ctx context.Context, ruleID string, version int, actor, comment string) (*domain.RuleVersion, error) {
+	v, err := s.transitionVersion(ctx, ruleID, version, domain.RuleVersionActive)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor == "" || actor == v.CreatedBy || actor == v.SubmittedBy {
+		return nil, domain.ErrRuleSelfApproval
+	}
+
+	now := time.Now()
+	v.ReviewedBy = actor
+	v.ReviewedAt = &now
+	v.ReviewComment = comment
+
+	if err := s.activateVersion(ctx, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// RejectRuleVersion rejects a pending version, leaving the live rule unchanged
+func (s *RuleEngineService) RejectRuleVersion(ctx context.Context, ruleID string, version int, actor, comment string) (*domain.RuleVersion, error) {
+	v, err := s.transitionVersion(ctx, ruleID, version, domain.RuleVersionRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	v.Status = domain.RuleVersionRejected
+	v.ReviewedBy = actor
+	v.ReviewedAt = &now
+	v.ReviewComment = comment
+
+	if err := s.versionRepo.UpdateVersionStatus(ctx, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// RollbackRule restores a previously active version by recording it as a new active version
+func (s *RuleEngineService) RollbackRule(ctx context.Context, ruleID string, version int, actor string) (*domain.RuleVersion, error) {
+	target, err := s.versionRepo.GetVersion(ctx, ruleID, version)
+	if err != nil {
+		return nil, err
+	}
+	if target.Status != domain.RuleVersionActive && target.Status != domain.RuleVersionSuperseded {
+		return nil, fmt.Errorf("%w: cannot roll back to %s version %d", domain.ErrInvalidRuleTransition, target.Status, version)
+	}
+
+	rule := &domain.MonitoringRule{ID: ruleID}
+	target.ApplyTo(rule)
+
+	now := time.Now()
+	restored := domain.NewRuleVersion(rule, domain.RuleVersionPendingApproval, actor,
+		fmt.Sprintf("Rollback to version %d", target.Version))
+	restored.SubmittedBy = actor
+	restored.SubmittedAt = &now
+	restored.ReviewedBy = actor
+	restored.ReviewedAt = &now
+
+	if err := s.versionRepo.CreateVersion(ctx, restored); err != nil {
+		return nil, err
+	}
+	if err := s.activateVersion(ctx, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// transitionVersion loads a version and checks that it may move to the given status
+func (s *RuleEngineService) transitionVersion(ctx context.Context, ruleID string, version int, status domain.RuleVersionStatus) (*domain.RuleVersion, error) {
+	v, err := s.versionRepo.GetVersion(ctx, ruleID, version)
+	if err != nil {
+		return nil, err
+	}
+	if !v.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s to %s", domain.ErrInvalidRuleTransition, v.Status, status)
+	}
+	return v, nil
+}
+
+// activateVersion makes a version the live rule definition and drops its cached expression
+func (s *RuleEngineService) activateVersion(ctx context.Context, v *domain.RuleVersion) error {
+	if err := s.versionRepo.ActivateVersion(ctx, v); err != nil {
+		return err
+	}
+	s.expressions.Invalidate(v.RuleID)
+
+	s.logger.Info("Rule version activated",
+		zap.String("rule_id", v.RuleID),
+		zap.Int("version", v.Version),
+		zap.String("reviewed_by", v.ReviewedBy))
+
+	return nil
+}
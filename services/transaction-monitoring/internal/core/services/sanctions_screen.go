@@ -0,0 +1,393 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
+	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"go.uber.org/zap"
+)
+
+// SanctionsScreenConfig sizes the in-memory sanctions screen
+type SanctionsScreenConfig struct {
+	// FalsePositiveRate is the bloom filter's target rate of possible hits
+	// for addresses that are not sanctioned
+	FalsePositiveRate float64
+	// CacheSize bounds how many possible hits are remembered after the
+	// database answered them
+	CacheSize int
+}
+
+// SanctionsScreen is a ports.SanctionsRepository that keeps the hot path of
+// address checks off the database. Active sanctioned addresses are held in a
+// bloom filter: an address it rules out is answered from memory, and only
+// possible hits are looked up, first in an LRU of recent answers and then in
+// the database, which also clears the filter's false positives.
+//
+// Imports and deactivations made through the screen apply at once. The list is
+// reloaded periodically to pick up changes made by other replicas, so those
+// take up to the refresh interval to be screened.
+type SanctionsScreen struct {
+	ports.SanctionsRepository
+	cfg    SanctionsScreenConfig
+	logger *zap.Logger
+
+	refreshing sync.Mutex
+
+	mu       sync.RWMutex
+	filter   *bloomFilter
+	entries  int
+	loadedAt time.Time
+	// added holds addresses imported since the last reload started, so a
+	// reload racing an import does not drop them
+	added   []string
+	loading bool
+
+	cache *checkCache
+
+	checks, negatives, cacheHits, lookups, hits, falsePositives, failures atomic.Int64
+}
+
+// NewSanctionsScreen creates a sanctions screen over repo. It screens nothing
+// out until the first Refresh.
+func NewSanctionsScreen(repo ports.SanctionsRepository, cfg SanctionsScreenConfig, logger *zap.Logger) *SanctionsScreen {
+	if cfg.FalsePositiveRate <= 0 || cfg.FalsePositiveRate >= 1 {
+		cfg.FalsePositiveRate = 0.001
+	}
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = 100000
+	}
+	return &SanctionsScreen{
+		SanctionsRepository: repo,
+		cfg:                 cfg,
+		logger:              logger,
+		cache:               newCheckCache(cfg.CacheSize),
+	}
+}
+
+// Start reloads the sanctions list every interval until ctx is cancelled
+func (s *SanctionsScreen) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil {
+			s.logger.Error("Failed to load sanctions screen", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh rebuilds the filter from the active sanctions list and forgets the
+// cached answers
+func (s *SanctionsScreen) Refresh(ctx context.Context) error {
+	s.refreshing.Lock()
+	defer s.refreshing.Unlock()
+
+	s.mu.Lock()
+	s.loading = true
+	s.added = nil
+	s.mu.Unlock()
+
+	sanctions, err := s.SanctionsRepository.GetAllSanctions(ctx)
+	if err != nil {
+		s.mu.Lock()
+		s.loading = false
+		s.mu.Unlock()
+		return err
+	}
+
+	filter := newBloomFilter(len(sanctions), s.cfg.FalsePositiveRate)
+	for _, sanction := range sanctions {
+		filter.Add(sanction.Address)
+	}
+
+	s.mu.Lock()
+	for _, address := range s.added {
+		filter.Add(address)
+	}
+	s.filter = filter
+	s.entries = len(sanctions) + len(s.added)
+	s.loadedAt = time.Now().UTC()
+	s.added = nil
+	s.loading = false
+	s.cache.Purge()
+	s.mu.Unlock()
+
+	s.logger.Info("Sanctions screen loaded",
+		zap.Int("entries", len(sanctions)),
+		zap.Int("filter_bits", filter.Bits()),
+		zap.Int("filter_hashes", filter.hashes),
+	)
+	return nil
+}
+
+// CheckAddress returns the active sanctions entry for address, or
+// domain.ErrNotSanctioned
+func (s *SanctionsScreen) CheckAddress(ctx context.Context, address string) (*domain.SanctionedAddress, error) {
+	s.checks.Add(1)
+
+	s.mu.RLock()
+	possible := s.filter == nil || s.filter.Test(address)
+	s.mu.RUnlock()
+	if !possible {
+		s.negatives.Add(1)
+		return nil, domain.ErrNotSanctioned
+	}
+
+	if sanction, ok := s.cache.Get(address); ok {
+		s.cacheHits.Add(1)
+		if sanction == nil {
+			return nil, domain.ErrNotSanctioned
+		}
+		return sanction, nil
+	}
+
+	// An import or reload during the lookup may make its answer stale, and
+	// moves the cache past this epoch so the answer is not kept
+	epoch := s.cache.Epoch()
+
+	s.lookups.Add(1)
+	sanction, err := s.SanctionsRepository.CheckAddress(ctx, address)
+	switch {
+	case errors.Is(err, domain.ErrNotSanctioned):
+		s.falsePositives.Add(1)
+		s.cache.Put(address, nil, epoch)
+		return nil, err
+	case err != nil:
+		s.failures.Add(1)
+		return nil, err
+	}
+
+	s.hits.Add(1)
+	s.cache.Put(address, sanction, epoch)
+	return sanction, nil
+}
+
+// ImportSanctions imports a batch of sanctioned addresses and screens them at
+// once
+func (s *SanctionsScreen) ImportSanctions(ctx context.Context, addresses []domain.SanctionedAddress) error {
+	err := s.SanctionsRepository.ImportSanctions(ctx, addresses)
+
+	// Entries imported before a failure are in the database, so all are
+	// screened; a possible hit that is not there is cleared by the lookup
+	s.mu.Lock()
+	for _, addr := range addresses {
+		if s.filter != nil {
+			s.filter.Add(addr.Address)
+			s.entries++
+		}
+		if s.loading {
+			s.added = append(s.added, addr.Address)
+		}
+		s.cache.Remove(addr.Address)
+	}
+	s.mu.Unlock()
+
+	return err
+}
+
+// DeactivateSanction deactivates a sanctions entry. The filter keeps the
+// address until the next reload; its lookups are cleared by the database.
+func (s *SanctionsScreen) DeactivateSanction(ctx context.Context, id string) error {
+	if err := s.SanctionsRepository.DeactivateSanction(ctx, id); err != nil {
+		return err
+	}
+	s.cache.Purge()
+	return nil
+}
+
+// Stats returns the screen's counters since it was created
+func (s *SanctionsScreen) Stats() domain.SanctionsScreenStats {
+	s.mu.RLock()
+	stats := domain.SanctionsScreenStats{
+		Entries:  s.entries,
+		LoadedAt: s.loadedAt,
+	}
+	s.mu.RUnlock()
+
+	stats.Checks = s.checks.Load()
+	stats.Negatives = s.negatives.Load()
+	stats.CacheHits = s.cacheHits.Load()
+	stats.DatabaseLookups = s.lookups.Load()
+	stats.Hits = s.hits.Load()
+	stats.FalsePositives = s.falsePositives.Load()
+	stats.Errors = s.failures.Load()
+
+	// The observed rate counts each address once per database answer, as
+	// repeat checks are served by the cache
+	if clean := stats.Negatives + stats.FalsePositives; clean > 0 {
+		stats.FalsePositiveRate = float64(stats.FalsePositives) / float64(clean)
+	}
+	return stats
+}
+
+// bloomFilter is a fixed-size bloom filter over strings. Its indexes come from
+// double hashing one 64-bit FNV-1a hash.
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes int
+}
+
+// newBloomFilter sizes a filter for n entries at false positive rate p
+func newBloomFilter(n int, p float64) *bloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	size := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if size < 64 {
+		size = 64
+	}
+	hashes := int(math.Round(float64(size) / float64(n) * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+	return &bloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// Add adds value to the filter
+func (f *bloomFilter) Add(value string) {
+	h1, h2 := bloomHashes(value)
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Test reports whether value may have been added
+func (f *bloomFilter) Test(value string) bool {
+	h1, h2 := bloomHashes(value)
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bits returns the size of the filter in bits
+func (f *bloomFilter) Bits() int {
+	return int(f.size)
+}
+
+// bloomHashes derives the two hashes of double hashing. The second is mixed
+// from the first and odd, so it never repeats an index early.
+func bloomHashes(value string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	h1 := h.Sum64()
+
+	h2 := h1
+	h2 ^= h2 >> 33
+	h2 *= 0xff51afd7ed558ccd
+	h2 ^= h2 >> 33
+	return h1, h2 | 1
+}
+
+// checkCache is an LRU of sanctions check answers. A nil entry records an
+// address the database cleared. Every removal starts a new epoch, and answers
+// looked up in an earlier epoch are not cached.
+type checkCache struct {
+	mu       sync.Mutex
+	capacity int
+	epoch    uint64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type checkCacheEntry struct {
+	address  string
+	sanction *domain.SanctionedAddress
+}
+
+func newCheckCache(capacity int) *checkCache {
+	return &checkCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached answer for address
+func (c *checkCache) Get(address string) (*domain.SanctionedAddress, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[address]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*checkCacheEntry).sanction, true
+}
+
+// Epoch returns the current epoch, to pass to Put
+func (c *checkCache) Epoch() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch
+}
+
+// Put caches the answer for address looked up in epoch, evicting the least
+// recently used
+func (c *checkCache) Put(address string, sanction *domain.SanctionedAddress, epoch uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+
+	if element, ok := c.entries[address]; ok {
+		element.Value.(*checkCacheEntry).sanction = sanction
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[address] = c.order.PushFront(&checkCacheEntry{address: address, sanction: sanction})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*checkCacheEntry).address)
+	}
+}
+
+// Remove forgets the answer for address
+func (c *checkCache) Remove(address string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	if element, ok := c.entries[address]; ok {
+		c.order.Remove(element)
+		delete(c.entries, address)
+	}
+}
+
+// Purge forgets every answer
+func (c *checkCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +21,9 @@ type TransactionAnalysisService struct {
 	walletRepo       ports.WalletProfileRepository
 	sanctionsRepo    ports.SanctionsRepository
 	ruleRepo         ports.MonitoringRuleRepository
+	expressions      *RuleExpressionEngine
+	fx               *FXRateService
+	subscriptions    ports.SubscriptionEvaluator
 	logger           *zap.Logger
 }
 
@@ -29,6 +33,9 @@ func NewTransactionAnalysisService(
 	walletRepo ports.WalletProfileRepository,
 	sanctionsRepo ports.SanctionsRepository,
 	ruleRepo ports.MonitoringRuleRepository,
+	expressions *RuleExpressionEngine,
+	fx *FXRateService,
+	subscriptions ports.SubscriptionEvaluator,
 	logger *zap.Logger,
 ) *TransactionAnalysisService {
 	return &TransactionAnalysisService{
@@ -36,6 +43,9 @@ func NewTransactionAnalysisService(
 		walletRepo:      walletRepo,
 		sanctionsRepo:   sanctionsRepo,
 		ruleRepo:        ruleRepo,
+		expressions:     expressions,
+		fx:              fx,
+		subscriptions:   subscriptions,
 		logger:          logger,
 	}
 }
@@ -112,6 +122,11 @@ func (s *TransactionAnalysisService) AnalyzeTransaction(ctx context.Context, tx
 		s.logger.Error("Failed to update transaction", zap.Error(err))
 	}
 
+	// Step 8: Match analysts' pattern subscriptions against the scored transaction
+	if s.subscriptions != nil {
+		s.subscriptions.Evaluate(ctx, tx)
+	}
+
 	return result, nil
 }
 
@@ -188,6 +203,10 @@ func (s *TransactionAnalysisService) checkSanctions(ctx context.Context, tx *dom
 }
 
 func (s *TransactionAnalysisService) evaluateRule(ctx context.Context, rule *domain.MonitoringRule, tx *domain.Transaction) (bool, string, error) {
+	if rule.RuleType == domain.RuleTypeExpression {
+		return s.evaluateExpressionRule(ctx, rule, tx)
+	}
+
 	var condition map[string]interface{}
 	if err := json.Unmarshal([]byte(rule.Condition), &condition); err != nil {
 		return false, "", err
@@ -195,7 +214,7 @@ func (s *TransactionAnalysisService) evaluateRule(ctx context.Context, rule *dom
 
 	switch rule.RuleType {
 	case domain.RuleTypeThreshold:
-		return s.evaluateThresholdRule(condition, tx)
+		return s.evaluateThresholdRule(ctx, condition, tx)
 	case domain.RuleTypeVelocity:
 		return s.evaluateVelocityRule(condition, tx)
 	case domain.RuleTypePattern:
@@ -207,13 +226,25 @@ func (s *TransactionAnalysisService) evaluateRule(ctx context.Context, rule *dom
 	}
 }
 
-func (s *TransactionAnalysisService) evaluateThresholdRule(condition map[string]interface{}, tx *domain.Transaction) (bool, string, error) {
-	if maxAmount, ok := condition["max_amount"].(float64); ok {
-		if tx.Amount > maxAmount {
-			return true, fmt.Sprintf("Transaction amount %.2f exceeds threshold %.2f", tx.Amount, maxAmount), nil
-		}
+// evaluateThresholdRule compares the token amount against max_amount, or the
+// transaction's value on the day in the condition's currency when it names one
+func (s *TransactionAnalysisService) evaluateThresholdRule(ctx context.Context, condition map[string]interface{}, tx *domain.Transaction) (bool, string, error) {
+	maxAmount, ok := condition["max_amount"].(float64)
+	if !ok {
+		return false, "", nil
 	}
-	return false, "", nil
+	amount, currency, err := thresholdAmount(ctx, s.fx, condition, tx, tx.Amount)
+	if err != nil {
+		return false, "", err
+	}
+	threshold := decimal.NewFromFloat(maxAmount)
+	if !amount.GreaterThan(threshold) {
+		return false, "", nil
+	}
+	if currency != "" {
+		return true, fmt.Sprintf("Transaction value %s %s exceeds threshold %s %s", amount.StringFixed(2), currency, threshold.StringFixed(2), currency), nil
+	}
+	return true, fmt.Sprintf("Transaction amount %s exceeds threshold %s", amount, threshold), nil
 }
 
 func (s *TransactionAnalysisService) evaluateVelocityRule(condition map[string]interface{}, tx *domain.Transaction) (bool, string, error) {
@@ -228,6 +259,20 @@ func (s *TransactionAnalysisService) evaluateGeographicRule(condition map[string
 	return false, "", nil
 }
 
+func (s *TransactionAnalysisService) evaluateExpressionRule(ctx context.Context, rule *domain.MonitoringRule, tx *domain.Transaction) (bool, string, error) {
+	// A missing profile is not fatal; entity.* fields evaluate as zero values
+	profile, err := s.walletRepo.GetWalletProfile(ctx, tx.FromAddress)
+	if err != nil {
+		profile = nil
+	}
+
+	matched, err := s.expressions.Evaluate(rule, tx, profile)
+	if err != nil || !matched {
+		return false, "", err
+	}
+	return true, fmt.Sprintf("Expression matched: %s", rule.Expression), nil
+}
+
 func (s *TransactionAnalysisService) calculateRiskScore(tx *domain.Transaction, sanctions []domain.SanctionsMatch, rules []domain.RuleMatch) float64 {
 	score := 0.0
 
@@ -240,11 +285,12 @@ func (s *TransactionAnalysisService) calculateRiskScore(tx *domain.Transaction,
 	}
 
 	// Transaction amount risk
-	if tx.AmountUSD > 100000 {
+	switch {
+	case tx.AmountUSD.GreaterThan(decimal.NewFromInt(100000)):
 		score += 20
-	} else if tx.AmountUSD > 10000 {
+	case tx.AmountUSD.GreaterThan(decimal.NewFromInt(10000)):
 		score += 10
-	} else if tx.AmountUSD > 1000 {
+	case tx.AmountUSD.GreaterThan(decimal.NewFromInt(1000)):
 		score += 5
 	}
 
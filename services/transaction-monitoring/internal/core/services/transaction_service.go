@@ -7,6 +7,7 @@ import (
 
 	"github.com/csic/monitoring/internal/core/domain"
 	"github.com/csic/monitoring/internal/core/ports"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -174,8 +175,8 @@ func (s *TransactionService) ScanAddress(ctx context.Context, address, chain str
 		Address:        address,
 		Chain:          chain,
 		TxCount:        len(result.Items.([]*domain.Transaction)),
-		TotalVolumeUSD: 0,
-		AvgTxValueUSD:  0,
+		TotalVolumeUSD: decimal.Zero,
+		AvgTxValueUSD:  decimal.Zero,
 		RiskIndicators: make([]domain.RiskIndicator, 0),
 		CreatedAt:      time.Now().UTC(),
 		UpdatedAt:      time.Now().UTC(),
@@ -183,10 +184,10 @@ func (s *TransactionService) ScanAddress(ctx context.Context, address, chain str
 
 	// Calculate volume and risk indicators
 	if txs, ok := result.Items.([]*domain.Transaction); ok {
-		var totalUSD float64
+		totalUSD := decimal.Zero
 		highRiskCount := 0
 		for _, tx := range txs {
-			totalUSD += tx.AmountUSD
+			totalUSD = totalUSD.Add(tx.AmountUSD)
 			if tx.RiskScore >= 60 {
 				highRiskCount++
 			}
@@ -194,7 +195,7 @@ func (s *TransactionService) ScanAddress(ctx context.Context, address, chain str
 
 		profile.TotalVolumeUSD = totalUSD
 		if len(txs) > 0 {
-			profile.AvgTxValueUSD = totalUSD / float64(len(txs))
+			profile.AvgTxValueUSD = totalUSD.Div(decimal.NewFromInt(int64(len(txs))))
 		}
 
 		// Add risk indicators
@@ -257,7 +258,7 @@ func (s *TransactionService) GetSuspiciousActivityReport(ctx context.Context, st
 	riskFactorCount := make(map[string]int)
 
 	for _, tx := range txs {
-		report.TotalVolumeUSD += tx.AmountUSD
+		report.TotalVolumeUSD = report.TotalVolumeUSD.Add(tx.AmountUSD)
 		totalRiskScore += float64(tx.RiskScore)
 
 		// Count risk factors
@@ -270,13 +271,13 @@ func (s *TransactionService) GetSuspiciousActivityReport(ctx context.Context, st
 		if _, ok := report.ByChain[chain]; !ok {
 			report.ByChain[chain] = domain.ChainStats{
 				Transactions: 0,
-				VolumeUSD:    0,
+				VolumeUSD:    decimal.Zero,
 				Flagged:      0,
 			}
 		}
 		stats := report.ByChain[chain]
 		stats.Transactions++
-		stats.VolumeUSD += tx.AmountUSD
+		stats.VolumeUSD = stats.VolumeUSD.Add(tx.AmountUSD)
 		stats.Flagged++
 		report.ByChain[chain] = stats
 	}
@@ -331,7 +332,7 @@ func (s *TransactionService) GetRiskSummaryReport(ctx context.Context, startTime
 	riskFactorCount := make(map[string]int)
 
 	for _, tx := range txs {
-		report.TotalVolumeUSD += tx.AmountUSD
+		report.TotalVolumeUSD = report.TotalVolumeUSD.Add(tx.AmountUSD)
 		totalRiskScore += float64(tx.RiskScore)
 
 		if tx.RiskScore >= 60 {
@@ -348,12 +349,12 @@ func (s *TransactionService) GetRiskSummaryReport(ctx context.Context, startTime
 		if _, ok := report.ByChain[chain]; !ok {
 			report.ByChain[chain] = domain.ChainStats{
 				Transactions: 0,
-				VolumeUSD:    0,
+				VolumeUSD:    decimal.Zero,
 			}
 		}
 		stats := report.ByChain[chain]
 		stats.Transactions++
-		stats.VolumeUSD += tx.AmountUSD
+		stats.VolumeUSD = stats.VolumeUSD.Add(tx.AmountUSD)
 		if tx.Flagged {
 			stats.Flagged++
 		}
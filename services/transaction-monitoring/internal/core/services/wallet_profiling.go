@@ -9,6 +9,7 @@ import (
 
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/domain"
 	"github.com/csic-platform/services/transaction-monitoring/internal/core/ports"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -77,13 +78,13 @@ func (s *WalletProfilingService) GetWalletRiskProfile(ctx context.Context, addre
 
 	// Calculate velocity metrics
 	if len(recentTx) > 1 {
-		var totalVolume float64
+		totalVolume := decimal.Zero
 		var txCount int
 		for _, tx := range recentTx {
-			totalVolume += tx.AmountUSD
+			totalVolume = totalVolume.Add(tx.AmountUSD)
 			txCount++
 		}
-		profile.AverageTransactionValue = totalVolume / float64(txCount)
+		profile.AverageTransactionValue = totalVolume.Div(decimal.NewFromInt(int64(txCount)))
 		profile.TotalTransactions = int64(txCount)
 		profile.TotalVolume = totalVolume
 	}
@@ -110,6 +111,7 @@ type RiskScoringService struct {
 	walletRepo      ports.WalletProfileRepository
 	transactionRepo ports.TransactionRepository
 	ruleRepo        ports.MonitoringRuleRepository
+	contractRepo    ports.ContractRegistryRepository
 	logger          *zap.Logger
 }
 
@@ -118,12 +120,14 @@ func NewRiskScoringService(
 	walletRepo ports.WalletProfileRepository,
 	transactionRepo ports.TransactionRepository,
 	ruleRepo ports.MonitoringRuleRepository,
+	contractRepo ports.ContractRegistryRepository,
 	logger *zap.Logger,
 ) *RiskScoringService {
 	return &RiskScoringService{
 		walletRepo:      walletRepo,
 		transactionRepo: transactionRepo,
 		ruleRepo:        ruleRepo,
+		contractRepo:    contractRepo,
 		logger:          logger,
 	}
 }
@@ -134,24 +138,43 @@ func (s *RiskScoringService) CalculateTransactionRisk(ctx context.Context, tx *d
 	indicators := []domain.RiskIndicator{}
 
 	// Amount-based risk
-	if tx.AmountUSD > 100000 {
+	if tx.AmountUSD.GreaterThan(decimal.NewFromInt(100000)) {
 		score += 25
 		indicators = append(indicators, domain.RiskIndicator{
 			Indicator:   "LARGE_TRANSACTION",
 			Severity:    "HIGH",
-			Description: fmt.Sprintf("Transaction amount $%.2f exceeds $100,000", tx.AmountUSD),
+			Description: fmt.Sprintf("Transaction amount $%s exceeds $100,000", tx.AmountUSD.StringFixed(2)),
 			Count:       1,
 		})
-	} else if tx.AmountUSD > 10000 {
+	} else if tx.AmountUSD.GreaterThan(decimal.NewFromInt(10000)) {
 		score += 15
 		indicators = append(indicators, domain.RiskIndicator{
 			Indicator:   "MEDIUM_TRANSACTION",
 			Severity:    "MEDIUM",
-			Description: fmt.Sprintf("Transaction amount $%.2f exceeds $10,000", tx.AmountUSD),
+			Description: fmt.Sprintf("Transaction amount $%s exceeds $10,000", tx.AmountUSD.StringFixed(2)),
 			Count:       1,
 		})
 	}
 
+	// Registered contract risk (mixers, bridges, sanctioned protocols, ...)
+	contracts, err := registeredContractsFor(ctx, s.contractRepo, tx)
+	if err != nil {
+		s.logger.Warn("Contract registry lookup failed", zap.String("tx_hash", tx.TxHash), zap.Error(err))
+	}
+	for _, contract := range contracts {
+		if contract.RiskWeight <= 0 {
+			continue
+		}
+		score += contract.RiskWeight
+		indicators = append(indicators, domain.RiskIndicator{
+			Indicator:    string(contract.Category) + "_INTERACTION",
+			Severity:     contractSeverity(contract.RiskWeight),
+			Description:  fmt.Sprintf("Transaction touches %s contract %s (%s)", contract.Category, contract.Address, contract.Name),
+			LastObserved: time.Now(),
+			Count:        1,
+		})
+	}
+
 	// Wallet age risk
 	profile, err := s.walletRepo.GetOrCreateWalletProfile(ctx, tx.FromAddress)
 	if err == nil {
@@ -241,18 +264,18 @@ func (s *RiskScoringService) CalculateVelocityRisk(ctx context.Context, address
 	}
 
 	// Calculate velocity metrics
-	var totalAmount float64
+	totalAmount := decimal.Zero
 	for _, tx := range txs {
-		totalAmount += tx.AmountUSD
+		totalAmount = totalAmount.Add(tx.AmountUSD)
 	}
 
 	// High velocity threshold (e.g., > 50 transactions or > $1M in 24h)
-	if len(txs) > 50 || totalAmount > 1000000 {
+	if len(txs) > 50 || totalAmount.GreaterThan(decimal.NewFromInt(1000000)) {
 		indicators := []domain.RiskIndicator{
 			{
 				Indicator:    "HIGH_VELOCITY",
 				Severity:     "HIGH",
-				Description:  fmt.Sprintf("%d transactions totaling $%.2f in time window", len(txs), totalAmount),
+				Description:  fmt.Sprintf("%d transactions totaling $%s in time window", len(txs), totalAmount.StringFixed(2)),
 				FirstObserved: startTime,
 				LastObserved:  now,
 				Count:         len(txs),
@@ -267,9 +290,9 @@ func (s *RiskScoringService) CalculateVelocityRisk(ctx context.Context, address
 // CalculatePatternRisk calculates risk based on transaction patterns
 func (s *RiskScoringService) CalculatePatternRisk(ctx context.Context, tx *domain.Transaction) (float64, error) {
 	// Check for round number patterns (potential structuring)
-	if tx.AmountUSD > 0 {
+	if tx.AmountUSD.IsPositive() {
 		// Simple round number check
-		if tx.AmountUSD == float64(int(tx.AmountUSD)) {
+		if tx.AmountUSD.IsInteger() {
 			// Could be round number, but not necessarily suspicious
 			return 5, nil
 		}
@@ -396,15 +419,33 @@ func (s *AlertService) generateAlertTitle(alertType domain.AlertType, riskScore
 
 // RuleEngineService handles monitoring rule evaluation
 type RuleEngineService struct {
-	ruleRepo ports.MonitoringRuleRepository
-	logger   *zap.Logger
+	ruleRepo        ports.MonitoringRuleRepository
+	walletRepo      ports.WalletProfileRepository
+	transactionRepo ports.TransactionRepository
+	versionRepo     ports.RuleVersionRepository
+	expressions     *RuleExpressionEngine
+	fx              *FXRateService
+	logger          *zap.Logger
 }
 
 // NewRuleEngineService creates a new rule engine service
-func NewRuleEngineService(ruleRepo ports.MonitoringRuleRepository, logger *zap.Logger) *RuleEngineService {
+func NewRuleEngineService(
+	ruleRepo ports.MonitoringRuleRepository,
+	walletRepo ports.WalletProfileRepository,
+	transactionRepo ports.TransactionRepository,
+	versionRepo ports.RuleVersionRepository,
+	expressions *RuleExpressionEngine,
+	fx *FXRateService,
+	logger *zap.Logger,
+) *RuleEngineService {
 	return &RuleEngineService{
-		ruleRepo: ruleRepo,
-		logger:   logger,
+		ruleRepo:        ruleRepo,
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+		versionRepo:     versionRepo,
+		expressions:     expressions,
+		fx:              fx,
+		logger:          logger,
 	}
 }
 
@@ -437,6 +478,50 @@ func (s *RuleEngineService) EvaluateRules(ctx context.Context, tx *domain.Transa
 	return matches, nil
 }
 
+// CreateRule validates and stores a new monitoring rule along with its first active version
+func (s *RuleEngineService) CreateRule(ctx context.Context, rule *domain.MonitoringRule, actor string) error {
+	if err := s.validateRule(rule); err != nil {
+		return err
+	}
+
+	rule.Version = 1
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+	if err := s.ruleRepo.CreateRule(ctx, rule); err != nil {
+		return err
+	}
+
+	version := domain.NewRuleVersion(rule, domain.RuleVersionActive, actor, "Initial version")
+	return s.versionRepo.CreateVersion(ctx, version)
+}
+
+// UpdateRule records changes to a monitoring rule as a new draft version.
+// The live rule is unchanged until the draft is submitted and approved.
+func (s *RuleEngineService) UpdateRule(ctx context.Context, rule *domain.MonitoringRule, actor, changeNote string) (*domain.RuleVersion, error) {
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ruleRepo.GetRule(ctx, rule.ID); err != nil {
+		return nil, err
+	}
+
+	version := domain.NewRuleVersion(rule, domain.RuleVersionDraft, actor, changeNote)
+	if err := s.versionRepo.CreateVersion(ctx, version); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// validateRule compiles expression rules so invalid expressions are rejected before they are stored
+func (s *RuleEngineService) validateRule(rule *domain.MonitoringRule) error {
+	if rule.RuleType != domain.RuleTypeExpression {
+		return nil
+	}
+	return s.expressions.Validate(rule.Expression)
+}
+
 // GetApplicableRules returns rules applicable to a transaction
 func (s *RuleEngineService) GetApplicableRules(ctx context.Context, tx *domain.Transaction) ([]*domain.MonitoringRule, error) {
 	return s.ruleRepo.GetActiveRules(ctx)
@@ -444,6 +529,10 @@ func (s *RuleEngineService) GetApplicableRules(ctx context.Context, tx *domain.T
 
 // ExecuteRule executes a single rule against a transaction
 func (s *RuleEngineService) ExecuteRule(ctx context.Context, rule *domain.MonitoringRule, tx *domain.Transaction) (bool, string, error) {
+	if rule.RuleType == domain.RuleTypeExpression {
+		return s.executeExpressionRule(ctx, rule, tx)
+	}
+
 	var condition map[string]interface{}
 	if err := json.Unmarshal([]byte(rule.Condition), &condition); err != nil {
 		return false, "", err
@@ -451,7 +540,7 @@ func (s *RuleEngineService) ExecuteRule(ctx context.Context, rule *domain.Monito
 
 	switch rule.RuleType {
 	case domain.RuleTypeThreshold:
-		return s.executeThresholdRule(condition, tx)
+		return s.executeThresholdRule(ctx, condition, tx)
 	case domain.RuleTypeVelocity:
 		return s.executeVelocityRule(condition, tx)
 	case domain.RuleTypeSanctions:
@@ -461,13 +550,39 @@ func (s *RuleEngineService) ExecuteRule(ctx context.Context, rule *domain.Monito
 	}
 }
 
-func (s *RuleEngineService) executeThresholdRule(condition map[string]interface{}, tx *domain.Transaction) (bool, string, error) {
-	if maxAmount, ok := condition["max_amount"].(float64); ok {
-		if tx.AmountUSD > maxAmount {
-			return true, fmt.Sprintf("Amount $%.2f exceeds threshold $%.2f", tx.AmountUSD, maxAmount), nil
-		}
+// executeThresholdRule compares the USD amount against max_amount, or the
+// transaction's value on the day in the condition's currency when it names one
+func (s *RuleEngineService) executeThresholdRule(ctx context.Context, condition map[string]interface{}, tx *domain.Transaction) (bool, string, error) {
+	maxAmount, ok := condition["max_amount"].(float64)
+	if !ok {
+		return false, "", nil
 	}
-	return false, "", nil
+	amount, currency, err := thresholdAmount(ctx, s.fx, condition, tx, tx.AmountUSD)
+	if err != nil {
+		return false, "", err
+	}
+	threshold := decimal.NewFromFloat(maxAmount)
+	if !amount.GreaterThan(threshold) {
+		return false, "", nil
+	}
+	if currency != "" {
+		return true, fmt.Sprintf("Amount %s %s exceeds threshold %s %s", amount.StringFixed(2), currency, threshold.StringFixed(2), currency), nil
+	}
+	return true, fmt.Sprintf("Amount $%s exceeds threshold $%s", amount.StringFixed(2), threshold.StringFixed(2)), nil
+}
+
+func (s *RuleEngineService) executeExpressionRule(ctx context.Context, rule *domain.MonitoringRule, tx *domain.Transaction) (bool, string, error) {
+	// A missing profile is not fatal; entity.* fields evaluate as zero values
+	profile, err := s.walletRepo.GetWalletProfile(ctx, tx.FromAddress)
+	if err != nil {
+		profile = nil
+	}
+
+	matched, err := s.expressions.Evaluate(rule, tx, profile)
+	if err != nil || !matched {
+		return false, "", err
+	}
+	return true, fmt.Sprintf("Expression matched: %s", rule.Expression), nil
 }
 
 func (s *RuleEngineService) executeVelocityRule(condition map[string]interface{}, tx *domain.Transaction) (bool, string, error) {
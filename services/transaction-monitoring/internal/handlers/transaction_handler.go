@@ -10,6 +10,7 @@ import (
 	"github.com/csic/monitoring/internal/core/domain"
 	"github.com/csic/monitoring/internal/core/services"
 	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -48,7 +49,7 @@ func (h *TransactionHandler) IngestTransaction(w http.ResponseWriter, r *http.Re
 		h.respondError(w, http.StatusBadRequest, "MISSING_FIELD", "From address is required", "")
 		return
 	}
-	if tx.Amount <= 0 {
+	if !tx.Amount.IsPositive() {
 		h.respondError(w, http.StatusBadRequest, "INVALID_AMOUNT", "Amount must be greater than 0", "")
 		return
 	}
@@ -245,12 +246,12 @@ func (h *TransactionHandler) parseTransactionFilter(r *http.Request) *domain.Tra
 		filter.ToAddress = to
 	}
 	if minAmt := r.URL.Query().Get("min_amount_usd"); minAmt != "" {
-		if val, err := strconv.ParseFloat(minAmt, 64); err == nil {
+		if val, err := decimal.NewFromString(minAmt); err == nil {
 			filter.MinAmountUSD = val
 		}
 	}
 	if maxAmt := r.URL.Query().Get("max_amount_usd"); maxAmt != "" {
-		if val, err := strconv.ParseFloat(maxAmt, 64); err == nil {
+		if val, err := decimal.NewFromString(maxAmt); err == nil {
 			filter.MaxAmountUSD = val
 		}
 	}
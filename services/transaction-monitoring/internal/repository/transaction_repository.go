@@ -10,6 +10,7 @@ import (
 
 	"github.com/csic/monitoring/internal/core/domain"
 	"github.com/csic/monitoring/internal/core/ports"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -123,13 +124,13 @@ func (r *TransactionRepository) List(ctx context.Context, filter *domain.Transac
 		argIndex++
 	}
 
-	if filter.MinAmountUSD > 0 {
+	if filter.MinAmountUSD.IsPositive() {
 		baseQuery += fmt.Sprintf(` AND amount_usd >= $%d`, argIndex)
 		args = append(args, filter.MinAmountUSD)
 		argIndex++
 	}
 
-	if filter.MaxAmountUSD > 0 {
+	if filter.MaxAmountUSD.IsPositive() {
 		baseQuery += fmt.Sprintf(` AND amount_usd <= $%d`, argIndex)
 		args = append(args, filter.MaxAmountUSD)
 		argIndex++
@@ -264,7 +265,7 @@ func (r *TransactionRepository) scanTransactionRow(rows *sql.Rows) (*domain.Tran
 	var toAddress, flagReason sql.NullString
 	var blockNumber sql.NullInt64
 	var gasUsed sql.NullInt64
-	var gasPrice, gasFeeUSD sql.NullFloat64
+	var gasPrice, gasFeeUSD decimal.NullDecimal
 	var nonce sql.NullInt64
 	var reviewedAt sql.NullTime
 	var reviewedBy sql.NullString
@@ -291,10 +292,10 @@ func (r *TransactionRepository) scanTransactionRow(rows *sql.Rows) (*domain.Tran
 		tx.GasUsed = &gasUsed.Int64
 	}
 	if gasPrice.Valid {
-		tx.GasPrice = &gasPrice.Float64
+		tx.GasPrice = &gasPrice.Decimal
 	}
 	if gasFeeUSD.Valid {
-		tx.GasFeeUSD = &gasFeeUSD.Float64
+		tx.GasFeeUSD = &gasFeeUSD.Decimal
 	}
 	if nonce.Valid {
 		tx.Nonce = &nonce.Int
@@ -0,0 +1,7 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 002_add_rule_expressions
+
+-- CEL expression for EXPRESSION rules; other rule types keep using condition
+ALTER TABLE monitoring_rules ADD COLUMN IF NOT EXISTS expression TEXT;
+
+ALTER TABLE monitoring_rules ALTER COLUMN condition SET DEFAULT '{}'::jsonb;
@@ -0,0 +1,42 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 003_create_rule_versions
+
+ALTER TABLE monitoring_rules ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- Rule versions table (draft -> pending_approval -> active -> superseded)
+CREATE TABLE IF NOT EXISTS rule_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule_id UUID NOT NULL REFERENCES monitoring_rules(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'draft',
+    name VARCHAR(256) NOT NULL,
+    description TEXT,
+    rule_type VARCHAR(64) NOT NULL,
+    condition JSONB NOT NULL DEFAULT '{}'::jsonb,
+    parameters JSONB,
+    expression TEXT,
+    risk_weight DECIMAL(8, 2) DEFAULT 0,
+    severity VARCHAR(32) DEFAULT 'WARNING',
+    priority INTEGER DEFAULT 0,
+    change_note TEXT,
+    created_by VARCHAR(128),
+    submitted_by VARCHAR(128),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by VARCHAR(128),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (rule_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rule_versions_rule ON rule_versions(rule_id);
+CREATE INDEX IF NOT EXISTS idx_rule_versions_status ON rule_versions(status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rule_versions_active ON rule_versions(rule_id) WHERE status = 'active';
+
+-- Record the current definition of existing rules as their first active version
+INSERT INTO rule_versions (rule_id, version, status, name, description, rule_type, condition,
+    parameters, expression, risk_weight, severity, priority, change_note, created_at)
+SELECT id, version, 'active', name, description, rule_type, condition,
+    parameters, expression, risk_weight, severity, priority, 'Initial version', created_at
+FROM monitoring_rules
+ON CONFLICT (rule_id, version) DO NOTHING;
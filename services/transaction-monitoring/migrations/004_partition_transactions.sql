@@ -0,0 +1,108 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 004_partition_transactions
+
+-- The transactions table is range-partitioned by month on tx_timestamp so that
+-- time-bounded queries only scan the partitions they need and old months can
+-- be detached. Partitions ahead of the current month are created by the
+-- service's partition maintainer; this migration only covers existing rows.
+--
+-- A partitioned table's unique constraints must include the partition key, so
+-- the primary key becomes (id, tx_timestamp) and tx_hash can no longer be
+-- unique on it. transaction_keys keeps one row per hash instead: inserts claim
+-- the hash there first, and alerts and risk assessments reference its id in
+-- place of transactions(id). Keys outlive archived and detached partitions, so
+-- a replay of an archived transaction is still rejected.
+
+ALTER TABLE alerts DROP CONSTRAINT IF EXISTS alerts_transaction_id_fkey;
+ALTER TABLE risk_assessments DROP CONSTRAINT IF EXISTS risk_assessments_transaction_id_fkey;
+
+DROP TRIGGER IF EXISTS update_transactions_updated_at ON transactions;
+ALTER TABLE transactions RENAME TO transactions_unpartitioned;
+
+CREATE TABLE transactions (
+    id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    tx_hash VARCHAR(128) NOT NULL,
+    chain VARCHAR(64) NOT NULL,
+    block_number BIGINT,
+    from_address VARCHAR(128) NOT NULL,
+    to_address VARCHAR(128),
+    token_address VARCHAR(128),
+    amount DECIMAL(32, 8) NOT NULL,
+    amount_usd DECIMAL(32, 8),
+    gas_used BIGINT,
+    gas_price DECIMAL(32, 8),
+    gas_fee_usd DECIMAL(32, 8),
+    nonce BIGINT,
+    tx_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    risk_score INTEGER DEFAULT 0,
+    flagged BOOLEAN DEFAULT FALSE,
+    flag_reason TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by VARCHAR(128),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (id, tx_timestamp),
+    UNIQUE (tx_hash, tx_timestamp)
+) PARTITION BY RANGE (tx_timestamp);
+
+-- Catches rows outside every monthly partition; it should stay empty
+CREATE TABLE transactions_default PARTITION OF transactions DEFAULT;
+
+-- Monthly partitions for the existing rows and the current month
+DO $$
+DECLARE
+    month_start TIMESTAMP WITH TIME ZONE;
+    last_month TIMESTAMP WITH TIME ZONE;
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(tx_timestamp), NOW()) AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+      INTO month_start
+      FROM transactions_unpartitioned;
+    last_month := date_trunc('month', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';
+
+    WHILE month_start <= last_month LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF transactions FOR VALUES FROM (%L) TO (%L)',
+            'transactions_p' || to_char(month_start AT TIME ZONE 'UTC', 'YYYYMM'),
+            month_start,
+            month_start + INTERVAL '1 month'
+        );
+        month_start := month_start + INTERVAL '1 month';
+    END LOOP;
+END $$;
+
+INSERT INTO transactions (
+    id, tx_hash, chain, block_number, from_address, to_address, token_address,
+    amount, amount_usd, gas_used, gas_price, gas_fee_usd, nonce, tx_timestamp,
+    risk_score, flagged, flag_reason, reviewed_at, reviewed_by, created_at, updated_at
+)
+SELECT
+    id, tx_hash, chain, block_number, from_address, to_address, token_address,
+    amount, amount_usd, gas_used, gas_price, gas_fee_usd, nonce, tx_timestamp,
+    risk_score, flagged, flag_reason, reviewed_at, reviewed_by, created_at, updated_at
+FROM transactions_unpartitioned;
+
+CREATE TABLE transaction_keys (
+    tx_hash VARCHAR(128) PRIMARY KEY,
+    id UUID NOT NULL UNIQUE,
+    tx_timestamp TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+INSERT INTO transaction_keys (tx_hash, id, tx_timestamp)
+SELECT tx_hash, id, tx_timestamp FROM transactions_unpartitioned;
+
+DROP TABLE transactions_unpartitioned;
+
+ALTER TABLE alerts ADD CONSTRAINT alerts_transaction_id_fkey
+    FOREIGN KEY (transaction_id) REFERENCES transaction_keys(id);
+ALTER TABLE risk_assessments ADD CONSTRAINT risk_assessments_transaction_id_fkey
+    FOREIGN KEY (transaction_id) REFERENCES transaction_keys(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_tx_hash ON transactions(tx_hash);
+CREATE INDEX IF NOT EXISTS idx_transactions_from_address_timestamp ON transactions(from_address, tx_timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_to_address_timestamp ON transactions(to_address, tx_timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_timestamp ON transactions(tx_timestamp);
+CREATE INDEX IF NOT EXISTS idx_transactions_risk_score ON transactions(risk_score);
+CREATE INDEX IF NOT EXISTS idx_transactions_flagged ON transactions(flagged);
+
+CREATE TRIGGER update_transactions_updated_at BEFORE UPDATE ON transactions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
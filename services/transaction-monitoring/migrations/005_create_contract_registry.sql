@@ -0,0 +1,32 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 005_create_contract_registry
+
+-- Registry of known smart contracts (mixers, bridges, DEXes, sanctioned
+-- protocols). source is 'manual' for entries maintained through the API or the
+-- name of the curated feed that supplied the entry; feed syncs only touch
+-- their own entries.
+CREATE TABLE IF NOT EXISTS registered_contracts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chain VARCHAR(64) NOT NULL,
+    address VARCHAR(128) NOT NULL,
+    name VARCHAR(256) NOT NULL DEFAULT '',
+    protocol VARCHAR(128),
+    category VARCHAR(32) NOT NULL,
+    risk_weight DECIMAL(8, 2) NOT NULL DEFAULT 0,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    source VARCHAR(128) NOT NULL DEFAULT 'manual',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT,
+    created_by VARCHAR(128),
+    updated_by VARCHAR(128),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_registered_contracts_category
+        CHECK (category IN ('MIXER', 'BRIDGE', 'DEX', 'SANCTIONED_PROTOCOL', 'OTHER')),
+    CONSTRAINT chk_registered_contracts_risk_weight
+        CHECK (risk_weight >= 0 AND risk_weight <= 100)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_registered_contracts_chain_address ON registered_contracts(chain, address);
+CREATE INDEX IF NOT EXISTS idx_registered_contracts_category ON registered_contracts(category);
+CREATE INDEX IF NOT EXISTS idx_registered_contracts_source ON registered_contracts(source);
@@ -0,0 +1,10 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 006_numeric_amounts
+
+-- Token amounts are stored exactly, in token units, and must keep the full
+-- precision of the chain's base unit: 18 fractional digits covers wei, and 60
+-- integer digits covers any uint256 balance. USD columns keep DECIMAL(32, 8).
+-- Altering the partitioned parent rewrites every partition.
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE NUMERIC(78, 18),
+    ALTER COLUMN gas_price TYPE NUMERIC(78, 18);
@@ -0,0 +1,19 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 007_create_fx_rates
+
+-- Daily reference rates: the price of one unit of base in quote on rate_date,
+-- as published by source. Rates are kept for every day so amounts are always
+-- converted at the rate of the day they apply to.
+CREATE TABLE IF NOT EXISTS fx_rates (
+    base VARCHAR(16) NOT NULL,
+    quote VARCHAR(16) NOT NULL,
+    rate NUMERIC(38, 18) NOT NULL,
+    rate_date DATE NOT NULL,
+    source VARCHAR(128) NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_fx_rates_pair_day_source UNIQUE (base, quote, rate_date, source),
+    CONSTRAINT chk_fx_rates_rate CHECK (rate > 0),
+    CONSTRAINT chk_fx_rates_pair CHECK (base <> quote)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_rates_pair_date ON fx_rates(base, quote, rate_date DESC);
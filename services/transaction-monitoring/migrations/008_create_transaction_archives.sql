@@ -0,0 +1,22 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 008_create_transaction_archives
+
+-- Catalogue of the Parquet objects in cold storage holding transactions moved
+-- out of the transactions table. Archive searches use the first and last
+-- transaction timestamps to pick the objects to read; the checksum is the
+-- SHA-256 of the object, verified whenever it is read back.
+CREATE TABLE IF NOT EXISTS transaction_archives (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    object_key TEXT NOT NULL UNIQUE,
+    row_count INTEGER NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    first_tx_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_tx_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    chains TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_transaction_archives_row_count CHECK (row_count > 0),
+    CONSTRAINT chk_transaction_archives_range CHECK (last_tx_at >= first_tx_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_archives_range ON transaction_archives(first_tx_at, last_tx_at);
@@ -0,0 +1,50 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 009_create_pattern_subscriptions
+
+-- Analysts' standing queries over the ingestion stream. filter holds the
+-- SubscriptionFilter as JSON; a channel, when bound, receives every hit.
+CREATE TABLE IF NOT EXISTS pattern_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(256) NOT NULL,
+    description TEXT,
+    owner VARCHAR(128) NOT NULL DEFAULT '',
+    filter JSONB NOT NULL,
+    channel_type VARCHAR(16),
+    channel_target TEXT,
+    status VARCHAR(16) NOT NULL DEFAULT 'ACTIVE',
+    hit_count BIGINT NOT NULL DEFAULT 0,
+    last_hit_at TIMESTAMP WITH TIME ZONE,
+    updated_by VARCHAR(128),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_pattern_subscriptions_status
+        CHECK (status IN ('ACTIVE', 'PAUSED', 'ARCHIVED')),
+    CONSTRAINT chk_pattern_subscriptions_channel
+        CHECK ((channel_type IS NULL AND channel_target IS NULL)
+            OR (channel_type IN ('WEBHOOK', 'EMAIL') AND channel_target IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_pattern_subscriptions_status ON pattern_subscriptions(status);
+CREATE INDEX IF NOT EXISTS idx_pattern_subscriptions_owner ON pattern_subscriptions(owner);
+
+-- Per-subscription hit log. A transaction is recorded at most once per
+-- subscription, so redelivered or re-analysed transactions are not notified twice.
+CREATE TABLE IF NOT EXISTS subscription_hits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES pattern_subscriptions(id) ON DELETE CASCADE,
+    transaction_id VARCHAR(128) NOT NULL,
+    tx_hash VARCHAR(128) NOT NULL,
+    chain VARCHAR(64) NOT NULL,
+    matched_address VARCHAR(128),
+    direction VARCHAR(8) NOT NULL,
+    amount NUMERIC(78, 18) NOT NULL DEFAULT 0,
+    amount_usd DECIMAL(32, 8) NOT NULL DEFAULT 0,
+    risk_score INTEGER NOT NULL DEFAULT 0,
+    tx_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    matched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    notified_at TIMESTAMP WITH TIME ZONE,
+    notify_error TEXT,
+    CONSTRAINT uq_subscription_hits_transaction UNIQUE (subscription_id, transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_hits_subscription ON subscription_hits(subscription_id, matched_at DESC);
@@ -0,0 +1,22 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 010_query_plan_indexes
+
+-- Indexes for the hottest queries, as asserted by the query plan tests of the
+-- postgres repository package. Each index names the query it serves.
+
+-- Transaction search of the review queue: flagged transactions, newest first.
+-- A partial index stays small as only a fraction of transactions are flagged,
+-- and replaces the boolean index the planner never chose.
+CREATE INDEX IF NOT EXISTS idx_transactions_flagged_timestamp
+    ON transactions(tx_timestamp DESC) WHERE flagged;
+DROP INDEX IF EXISTS idx_transactions_flagged;
+
+-- Transaction search restricted to one chain, newest first
+CREATE INDEX IF NOT EXISTS idx_transactions_chain_timestamp
+    ON transactions(chain, tx_timestamp DESC);
+
+-- Sanctions search by entity name matches a substring, which a btree cannot
+-- serve; a trigram index over active entries can
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_sanctions_entity_name_trgm
+    ON sanctioned_addresses USING GIN (entity_name gin_trgm_ops) WHERE is_active;
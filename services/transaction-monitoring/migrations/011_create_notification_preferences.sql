@@ -0,0 +1,32 @@
+-- Transaction Monitoring Service Database Schema
+-- Migration: 011_create_notification_preferences
+
+-- Subscription hits are graded by risk score and record how they were routed:
+-- sent as they happened, queued for a digest or muted by the owner's preference.
+ALTER TABLE subscription_hits
+    ADD COLUMN IF NOT EXISTS severity VARCHAR(16) NOT NULL DEFAULT 'INFO',
+    ADD COLUMN IF NOT EXISTS delivery VARCHAR(16);
+
+-- Per-user notification preferences. channels holds the NotificationChannels
+-- as JSON; an empty severities array receives every severity.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(128) PRIMARY KEY,
+    channels JSONB NOT NULL DEFAULT '[]',
+    severities TEXT[] NOT NULL DEFAULT '{}',
+    digest_frequency VARCHAR(16) NOT NULL DEFAULT 'REALTIME',
+    last_digest_at TIMESTAMP WITH TIME ZONE,
+    updated_by VARCHAR(128),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_notification_preferences_frequency
+        CHECK (digest_frequency IN ('REALTIME', 'HOURLY', 'DAILY'))
+);
+
+-- Non-critical hits waiting for their owner's next digest. Rows are removed
+-- once the digest is sent.
+CREATE TABLE IF NOT EXISTS notification_digest_queue (
+    hit_id UUID PRIMARY KEY REFERENCES subscription_hits(id) ON DELETE CASCADE,
+    user_id VARCHAR(128) NOT NULL,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_digest_queue_user ON notification_digest_queue(user_id, queued_at);
@@ -4,11 +4,15 @@
 package config
 
 import (
+    "context"
+    "errors"
     "fmt"
     "os"
     "sync"
     "time"
 
+    "github.com/csic-platform/shared/scheduler"
+    "github.com/csic-platform/shared/secrets"
     "gopkg.in/yaml.v3"
 )
 
@@ -24,6 +28,10 @@ type Config struct {
 	Logging    LoggingConfig    `yaml:"logging"`
 	Monitoring MonitoringConfig `yaml:"monitoring"`
 	AuditLog   AuditLogConfig   `yaml:"audit_log"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Secrets    SecretsConfig    `yaml:"secrets"`
+	Startup    StartupConfig    `yaml:"startup"`
+	Scheduler  SchedulerConfig  `yaml:"scheduler"`
 }
 
 // AppConfig contains application metadata
@@ -54,6 +62,11 @@ type DatabaseConfig struct {
     MaxOpenConns    int    `yaml:"max_open_conns"`
     MaxIdleConns    int    `yaml:"max_idle_conns"`
     ConnMaxLifetime int    `yaml:"conn_max_lifetime"` // seconds
+
+    // Read replicas serving list, search and report queries
+    ReadReplicas         []string `yaml:"read_replicas"`          // DSNs, which may be secret references
+    MaxReplicaLag        int      `yaml:"max_replica_lag"`        // seconds a replica may lag before reads avoid it
+    ReplicaCheckInterval int      `yaml:"replica_check_interval"` // seconds
 }
 
 // RedisConfig contains Redis connection settings
@@ -72,6 +85,9 @@ type KafkaConfig struct {
     ConsumerGroup string       `yaml:"consumer_group"`
     Topics        TopicsConfig `yaml:"topics"`
     Security      KafkaSecurity `yaml:"security"`
+    // TenantTopics publishes jurisdiction-scoped events to per-jurisdiction
+    // topics, "<topic>.<jurisdiction>", instead of the shared topic
+    TenantTopics  bool         `yaml:"tenant_topics"`
 }
 
 // TopicsConfig contains Kafka topic names
@@ -162,6 +178,7 @@ type HSMConfig struct {
     Slot        int    `yaml:"slot"`
     KeyLabel    string `yaml:"key_label"`
     KeyType     string `yaml:"key_type"`
+    Region      string `yaml:"region"` // AWS region of aws-kms keys
 }
 
 // LoggingConfig contains logging settings
@@ -170,6 +187,11 @@ type LoggingConfig struct {
     Format  string `yaml:"format"`  // json, text
     Output  string `yaml:"output"`  // stdout, file
     Path    string `yaml:"path"`    // file path if output is file
+
+    // Repeated entries are sampled per second: the first SamplingInitial
+    // are logged, then every SamplingThereafter-th. Zero keeps the default.
+    SamplingInitial    int `yaml:"sampling_initial"`
+    SamplingThereafter int `yaml:"sampling_thereafter"`
 }
 
 // MonitoringConfig contains monitoring settings
@@ -191,6 +213,122 @@ type AuditLogConfig struct {
 	EnableWORM       bool   `yaml:"enable_worm"`
 	MaxFileSize      int64  `yaml:"max_file_size"`
 	EntriesPerFile   int    `yaml:"entries_per_file"`
+	FsyncPerBatch    bool   `yaml:"fsync_per_batch"`
+
+	Replication AuditReplicationConfig `yaml:"replication"`
+	Export      AuditExportConfig      `yaml:"export"`
+	SIEM        AuditSIEMConfig        `yaml:"siem"`
+}
+
+// AuditReplicationConfig contains settings for replicating sealed audit log
+// segments to S3-compatible object storage
+type AuditReplicationConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	Endpoint      string `yaml:"endpoint"`
+	Region        string `yaml:"region"`
+	Bucket        string `yaml:"bucket"`
+	Prefix        string `yaml:"prefix"`
+	AccessKey     string `yaml:"access_key"`
+	SecretKey     string `yaml:"secret_key"`
+	UseSSL        bool   `yaml:"use_ssl"`
+	Interval      int    `yaml:"interval"`       // seconds
+	RetentionDays int    `yaml:"retention_days"` // object-lock retention
+}
+
+// AuditExportConfig contains settings for CSV and JSONL audit export jobs
+type AuditExportConfig struct {
+	Path           string `yaml:"path"`            // directory of finished exports
+	RetentionHours int    `yaml:"retention_hours"` // finished exports are deleted after
+	MaxConcurrent  int    `yaml:"max_concurrent"`  // jobs running at once
+}
+
+// AuditSIEMConfig contains settings for forwarding audit entries to a SIEM as
+// CEF or RFC 5424 syslog messages over TLS
+type AuditSIEMConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	Address      string `yaml:"address"` // host:port of the SIEM syslog receiver
+	Format       string `yaml:"format"`  // cef or syslog
+	Hostname     string `yaml:"hostname"`
+	AppName      string `yaml:"app_name"`
+	Facility     int    `yaml:"facility"`
+	CAFile       string `yaml:"ca_file"`
+	CertFile     string `yaml:"cert_file"` // client certificate for mutual TLS
+	KeyFile      string `yaml:"key_file"`
+	ServerName   string `yaml:"server_name"`
+	QueueSize    int    `yaml:"queue_size"`    // entries read ahead of the connection
+	PollInterval int    `yaml:"poll_interval"` // milliseconds between reads of the log
+	WriteTimeout int    `yaml:"write_timeout"` // seconds
+	MaxBackoff   int    `yaml:"max_backoff"`   // seconds between reconnection attempts
+}
+
+// RateLimitConfig contains per route group token bucket rate limits. Groups
+// without their own entry use the "default" group.
+type RateLimitConfig struct {
+	Enabled bool                     `yaml:"enabled"`
+	Groups  map[string]RateLimitRule `yaml:"groups"`
+}
+
+// RateLimitRule contains the sustained rate and burst size of a token bucket
+type RateLimitRule struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// SecretsConfig contains the secrets backends that secret references in this
+// configuration are resolved against. Any password, key or secret value may be
+// given as a reference such as vault://secret/data/csic/db#password.
+type SecretsConfig struct {
+	CacheTTL      int                    `yaml:"cache_ttl"`      // seconds; secrets without a lease
+	RenewInterval int                    `yaml:"renew_interval"` // seconds between lease renewal checks
+	FetchTimeout  int                    `yaml:"fetch_timeout"`  // seconds to resolve all references
+	Vault         VaultSecretsConfig     `yaml:"vault"`
+	AWS           AWSSecretsConfig       `yaml:"aws"`
+	Plaintext     PlaintextSecretsConfig `yaml:"plaintext"`
+}
+
+// VaultSecretsConfig contains HashiCorp Vault settings for vault:// references
+type VaultSecretsConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Address   string `yaml:"address"`
+	Token     string `yaml:"token"`
+	Namespace string `yaml:"namespace"`
+	Timeout   int    `yaml:"timeout"` // seconds
+}
+
+// AWSSecretsConfig contains AWS settings for ssm:// and kms:// references
+type AWSSecretsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Region  string `yaml:"region"`
+}
+
+// PlaintextSecretsConfig contains development secrets for plaintext://
+// references. It must not be enabled in production.
+type PlaintextSecretsConfig struct {
+	Enabled bool              `yaml:"enabled"`
+	Values  map[string]string `yaml:"values"`
+}
+
+// StartupConfig controls how long a service waits for its dependencies at
+// startup. Unset values take the startup package defaults.
+type StartupConfig struct {
+	MaxWait        int `yaml:"max_wait"`        // seconds required dependencies may take
+	InitialBackoff int `yaml:"initial_backoff"` // seconds, doubled after each failed attempt
+	MaxBackoff     int `yaml:"max_backoff"`     // seconds
+	CheckTimeout   int `yaml:"check_timeout"`   // seconds per readiness check
+}
+
+// SchedulerConfig overrides how a service's background tasks are scheduled,
+// keyed by task name. Tasks not listed keep the service's defaults.
+type SchedulerConfig struct {
+	Tasks map[string]ScheduledTaskConfig `yaml:"tasks"`
+}
+
+// ScheduledTaskConfig overrides the schedule of a background task
+type ScheduledTaskConfig struct {
+	Schedule string `yaml:"schedule"` // cron expression or descriptor such as "@every 1h"
+	Jitter   int    `yaml:"jitter"`   // seconds
+	Timeout  int    `yaml:"timeout"`  // seconds
+	Paused   bool   `yaml:"paused"`
 }
 
 // ConfigLoader handles configuration loading
@@ -198,6 +336,12 @@ type ConfigLoader struct {
     config   *Config
     mu       sync.RWMutex
     filePath string
+    secrets  *secrets.Manager
+
+    // renewErr collects background secret renewals that failed since the
+    // previous Load
+    renewMu  sync.Mutex
+    renewErr error
 }
 
 // NewConfigLoader creates a new configuration loader
@@ -207,7 +351,8 @@ func NewConfigLoader(filePath string) *ConfigLoader {
     }
 }
 
-// Load loads configuration from file
+// Load loads configuration from file. It fails if a leased secret could not be
+// renewed in the background since the previous Load, so Reload reports it.
 func (l *ConfigLoader) Load() (*Config, error) {
     l.mu.Lock()
     defer l.mu.Unlock()
@@ -225,6 +370,14 @@ func (l *ConfigLoader) Load() (*Config, error) {
     // Apply environment variable overrides
     l.applyEnvOverrides(&cfg)
 
+    // Resolve secret references against the configured backends
+    if err := l.resolveSecrets(&cfg); err != nil {
+        return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+    }
+    if err := l.takeRenewErr(); err != nil {
+        return nil, fmt.Errorf("failed to renew secrets: %w", err)
+    }
+
     // Validate configuration
     if err := l.validate(&cfg); err != nil {
         return nil, fmt.Errorf("configuration validation failed: %w", err)
@@ -234,6 +387,107 @@ func (l *ConfigLoader) Load() (*Config, error) {
     return l.config, nil
 }
 
+// Secrets returns the secrets manager used to resolve the configuration, for
+// services that fetch further secrets at runtime. It is nil before Load.
+func (l *ConfigLoader) Secrets() *secrets.Manager {
+    l.mu.RLock()
+    defer l.mu.RUnlock()
+    return l.secrets
+}
+
+// Close stops renewing leased secrets
+func (l *ConfigLoader) Close() {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    if l.secrets != nil {
+        l.secrets.Close()
+        l.secrets = nil
+    }
+}
+
+// resolveSecrets replaces secret references in the configuration with their
+// values. The secrets manager is created on first load and kept, so reloads
+// reuse cached secrets and leases keep being renewed.
+func (l *ConfigLoader) resolveSecrets(cfg *Config) error {
+    if l.secrets == nil {
+        manager, err := newSecretsManager(&cfg.Secrets, l.recordRenewErr)
+        if err != nil {
+            return err
+        }
+        manager.Start(context.Background(), cfg.Secrets.GetRenewInterval())
+        l.secrets = manager
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), cfg.Secrets.GetFetchTimeout())
+    defer cancel()
+
+    values := []*string{
+        &cfg.Database.Password,
+        &cfg.Redis.Password,
+        &cfg.Blockchain.Bitcoin.RPCPassword,
+        &cfg.Security.JWT.Secret,
+        &cfg.AuditLog.Replication.AccessKey,
+        &cfg.AuditLog.Replication.SecretKey,
+    }
+    for i := range cfg.Database.ReadReplicas {
+        values = append(values, &cfg.Database.ReadReplicas[i])
+    }
+    return l.secrets.ResolveAll(ctx, values...)
+}
+
+// recordRenewErr keeps a failed background renewal for the next Load
+func (l *ConfigLoader) recordRenewErr(ref string, err error) {
+    l.renewMu.Lock()
+    defer l.renewMu.Unlock()
+    l.renewErr = errors.Join(l.renewErr, fmt.Errorf("%s: %w", ref, err))
+}
+
+// takeRenewErr returns and clears the failed background renewals
+func (l *ConfigLoader) takeRenewErr() error {
+    l.renewMu.Lock()
+    defer l.renewMu.Unlock()
+    err := l.renewErr
+    l.renewErr = nil
+    return err
+}
+
+// newSecretsManager creates a secrets manager with the enabled providers.
+// onRenewErr is told about leases that failed to renew in the background.
+func newSecretsManager(cfg *SecretsConfig, onRenewErr func(ref string, err error)) (*secrets.Manager, error) {
+    manager := secrets.NewManager(cfg.GetCacheTTL(), onRenewErr)
+
+    if cfg.Vault.Enabled {
+        vault, err := secrets.NewVaultProvider(secrets.VaultConfig{
+            Address:   cfg.Vault.Address,
+            Token:     cfg.Vault.Token,
+            Namespace: cfg.Vault.Namespace,
+            Timeout:   time.Duration(cfg.Vault.Timeout) * time.Second,
+        })
+        if err != nil {
+            return nil, err
+        }
+        manager.Register(secrets.ProviderVault, vault)
+    }
+
+    if cfg.AWS.Enabled {
+        ctx, cancel := context.WithTimeout(context.Background(), cfg.GetFetchTimeout())
+        defer cancel()
+
+        awsCfg, err := secrets.LoadAWSConfig(ctx, cfg.AWS.Region)
+        if err != nil {
+            return nil, err
+        }
+        manager.Register(secrets.ProviderSSM, secrets.NewSSMProvider(awsCfg))
+        manager.Register(secrets.ProviderKMS, secrets.NewKMSProvider(awsCfg))
+    }
+
+    if cfg.Plaintext.Enabled {
+        manager.Register(secrets.ProviderPlaintext, secrets.NewPlaintextProvider(cfg.Plaintext.Values))
+    }
+
+    return manager, nil
+}
+
 // Get returns the current configuration
 func (l *ConfigLoader) Get() *Config {
     l.mu.RLock()
@@ -243,7 +497,8 @@ func (l *ConfigLoader) Get() *Config {
 
 // Reload reloads configuration from file
 func (l *ConfigLoader) Reload() error {
-    return l.Load()
+    _, err := l.Load()
+    return err
 }
 
 // applyEnvOverrides applies environment variable overrides
@@ -278,6 +533,28 @@ func (l *ConfigLoader) applyEnvOverrides(cfg *Config) {
         cfg.Security.JWT.Secret = secret
     }
 
+    // Audit log replication overrides
+    if accessKey := os.Getenv("AUDIT_REPLICATION_ACCESS_KEY"); accessKey != "" {
+        cfg.AuditLog.Replication.AccessKey = accessKey
+    }
+    if secretKey := os.Getenv("AUDIT_REPLICATION_SECRET_KEY"); secretKey != "" {
+        cfg.AuditLog.Replication.SecretKey = secretKey
+    }
+
+    // Secrets backend overrides
+    if addr := os.Getenv("VAULT_ADDR"); addr != "" {
+        cfg.Secrets.Vault.Address = addr
+    }
+    if token := os.Getenv("VAULT_TOKEN"); token != "" {
+        cfg.Secrets.Vault.Token = token
+    }
+    if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+        cfg.Secrets.Vault.Namespace = namespace
+    }
+    if region := os.Getenv("AWS_REGION"); region != "" {
+        cfg.Secrets.AWS.Region = region
+    }
+
     // Server overrides
     if port := os.Getenv("SERVER_PORT"); port != "" {
         fmt.Sscanf(port, "%d", &cfg.Server.Port)
@@ -306,6 +583,30 @@ func (l *ConfigLoader) validate(cfg *Config) error {
         return fmt.Errorf("JWT secret must be changed in production")
     }
 
+    if cfg.RateLimit.Enabled {
+        if _, ok := cfg.RateLimit.Groups["default"]; !ok {
+            return fmt.Errorf("rate limit group \"default\" is required")
+        }
+        for name, rule := range cfg.RateLimit.Groups {
+            if rule.RequestsPerSecond <= 0 || rule.Burst < 1 {
+                return fmt.Errorf("rate limit group %s needs a positive requests_per_second and burst", name)
+            }
+        }
+    }
+
+    if cfg.App.Environment == "production" && cfg.Secrets.Plaintext.Enabled {
+        return fmt.Errorf("the plaintext secrets provider must not be enabled in production")
+    }
+
+    if cfg.AuditLog.Replication.Enabled {
+        if cfg.AuditLog.Replication.Endpoint == "" || cfg.AuditLog.Replication.Bucket == "" {
+            return fmt.Errorf("audit log replication requires an endpoint and a bucket")
+        }
+        if cfg.AuditLog.Replication.RetentionDays <= 0 {
+            return fmt.Errorf("audit log replication requires a positive retention_days")
+        }
+    }
+
     return nil
 }
 
@@ -322,11 +623,36 @@ func (c *DatabaseConfig) GetConnMaxLifetime() time.Duration {
     return time.Duration(c.ConnMaxLifetime) * time.Second
 }
 
+// GetMaxReplicaLag returns how far a read replica may lag and still serve reads
+func (c *DatabaseConfig) GetMaxReplicaLag() time.Duration {
+    if c.MaxReplicaLag <= 0 {
+        return 5 * time.Second
+    }
+    return time.Duration(c.MaxReplicaLag) * time.Second
+}
+
+// GetReplicaCheckInterval returns how often read replica lag is measured
+func (c *DatabaseConfig) GetReplicaCheckInterval() time.Duration {
+    if c.ReplicaCheckInterval <= 0 {
+        return 5 * time.Second
+    }
+    return time.Duration(c.ReplicaCheckInterval) * time.Second
+}
+
 // GetRedisAddr returns the Redis address
 func (c *RedisConfig) GetRedisAddr() string {
     return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+// GetRule returns the rule for a route group, or the default rule if the group
+// has none
+func (c *RateLimitConfig) GetRule(group string) RateLimitRule {
+    if rule, ok := c.Groups[group]; ok {
+        return rule
+    }
+    return c.Groups["default"]
+}
+
 // GetReadTimeout returns read timeout as duration
 func (c *ServerConfig) GetReadTimeout() time.Duration {
     return time.Duration(c.ReadTimeout) * time.Second
@@ -341,3 +667,66 @@ func (c *ServerConfig) GetWriteTimeout() time.Duration {
 func (c *ServerConfig) GetIdleTimeout() time.Duration {
     return time.Duration(60) * time.Second
 }
+
+// GetCacheTTL returns how long secrets without a lease are cached
+func (c *SecretsConfig) GetCacheTTL() time.Duration {
+	if c.CacheTTL <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.CacheTTL) * time.Second
+}
+
+// GetRenewInterval returns how often leased secrets are checked for renewal
+func (c *SecretsConfig) GetRenewInterval() time.Duration {
+	if c.RenewInterval <= 0 {
+		return time.Minute
+	}
+	return time.Duration(c.RenewInterval) * time.Second
+}
+
+// GetFetchTimeout returns the time allowed to resolve the configuration's secrets
+func (c *SecretsConfig) GetFetchTimeout() time.Duration {
+	if c.FetchTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.FetchTimeout) * time.Second
+}
+
+// GetMaxWait returns how long required dependencies may take to connect
+func (c *StartupConfig) GetMaxWait() time.Duration {
+	return time.Duration(c.MaxWait) * time.Second
+}
+
+// GetInitialBackoff returns the delay after a dependency's first failed attempt
+func (c *StartupConfig) GetInitialBackoff() time.Duration {
+	return time.Duration(c.InitialBackoff) * time.Second
+}
+
+// GetMaxBackoff returns the longest delay between dependency attempts
+func (c *StartupConfig) GetMaxBackoff() time.Duration {
+	return time.Duration(c.MaxBackoff) * time.Second
+}
+
+// GetCheckTimeout returns the timeout of each dependency readiness check
+func (c *StartupConfig) GetCheckTimeout() time.Duration {
+	return time.Duration(c.CheckTimeout) * time.Second
+}
+
+// Apply returns the task with any configured overrides applied
+func (c *SchedulerConfig) Apply(task scheduler.Task) scheduler.Task {
+	override, ok := c.Tasks[task.Name]
+	if !ok {
+		return task
+	}
+	if override.Schedule != "" {
+		task.Schedule = override.Schedule
+	}
+	if override.Jitter > 0 {
+		task.Jitter = time.Duration(override.Jitter) * time.Second
+	}
+	if override.Timeout > 0 {
+		task.Timeout = time.Duration(override.Timeout) * time.Second
+	}
+	task.Paused = task.Paused || override.Paused
+	return task
+}
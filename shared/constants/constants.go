@@ -286,6 +286,7 @@ const (
 	HeaderXCorrelationID  = "X-Correlation-ID"
 	HeaderXUserID         = "X-User-ID"
 	HeaderXRole           = "X-Role"
+	HeaderXJurisdictions  = "X-Jurisdictions"
 	HeaderXAPIKey         = "X-API-Key"
 	HeaderAcceptLanguage  = "Accept-Language"
 	HeaderUserAgent       = "User-Agent"
@@ -0,0 +1,120 @@
+// Package correlation carries a correlation ID across the services handling a
+// request, so the audit trail of one user action can be followed end to end.
+// The ID travels in the X-Correlation-ID HTTP header, the x-correlation-id
+// gRPC metadata key and the correlation_id Kafka header, and is kept in the
+// request context in between.
+package correlation
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// Header is the HTTP header carrying the correlation ID
+	Header = "X-Correlation-ID"
+	// RequestIDHeader is adopted as the correlation ID when a caller sends
+	// only a request ID
+	RequestIDHeader = "X-Request-ID"
+	// MetadataKey is the gRPC metadata key carrying the correlation ID
+	MetadataKey = "x-correlation-id"
+	// KafkaHeader is the Kafka message header carrying the correlation ID
+	KafkaHeader = "correlation_id"
+	// GinKey is the gin context key holding the correlation ID
+	GinKey = "CorrelationID"
+
+	// maxIDLength bounds an ID accepted from a caller
+	maxIDLength = 128
+)
+
+type contextKey struct{}
+
+// NewID returns a new correlation ID
+func NewID() string {
+	return uuid.NewString()
+}
+
+// WithID returns a context carrying the correlation ID. An empty ID leaves the
+// context unchanged.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the correlation ID carried by ctx, or "" if there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Ensure returns ctx with a correlation ID, generating one if it has none
+func Ensure(ctx context.Context) (context.Context, string) {
+	if id := FromContext(ctx); id != "" {
+		return ctx, id
+	}
+	id := NewID()
+	return WithID(ctx, id), id
+}
+
+// Middleware returns a gin middleware that adopts the caller's correlation ID,
+// falling back to its request ID, or generates one. The ID is stored in the
+// request context and the gin context, set on the request so proxied calls
+// forward it, and echoed in the response.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := valid(c.GetHeader(Header))
+		if id == "" {
+			id = valid(c.GetHeader(RequestIDHeader))
+		}
+		if id == "" {
+			id = NewID()
+		}
+
+		c.Request = c.Request.WithContext(WithID(c.Request.Context(), id))
+		c.Request.Header.Set(Header, id)
+		c.Set(GinKey, id)
+		c.Header(Header, id)
+
+		c.Next()
+	}
+}
+
+// Headers returns a copy of a message's headers with the correlation ID
+// carried by ctx added. A correlation ID already in headers is kept.
+func Headers(ctx context.Context, headers map[string]string) map[string]string {
+	id := FromContext(ctx)
+	if id == "" || headers[KafkaHeader] != "" {
+		return headers
+	}
+
+	out := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		out[k] = v
+	}
+	out[KafkaHeader] = id
+	return out
+}
+
+// FromHeaders returns a context carrying the correlation ID found in a
+// message's headers
+func FromHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return WithID(ctx, valid(headers[KafkaHeader]))
+}
+
+// valid returns id if it is short enough to be a correlation ID and contains
+// only printable ASCII, or "" otherwise, so callers cannot inject log lines
+func valid(id string) string {
+	if len(id) > maxIDLength {
+		return ""
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return ""
+		}
+	}
+	return id
+}
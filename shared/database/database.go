@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -395,6 +396,3 @@ type ConnectionStats struct {
 func (db *DB) Close() error {
 	return db.DB.Close()
 }
-
-// Import required sync package
-import "sync"
@@ -0,0 +1,12 @@
+package database
+
+import "github.com/gin-gonic/gin"
+
+// ReadYourWrites gives each request a read-your-writes scope, so reads made
+// after the request's first write go to the primary rather than a replica
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithReadYourWrites(c.Request.Context()))
+		c.Next()
+	}
+}
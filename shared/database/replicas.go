@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// replicaLagQuery returns how far a replica's replay is behind the primary.
+// A replica that has replayed everything it received is not lagging, however
+// old its last replayed transaction is.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN 0
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+// replica is a read replica and the outcome of its latest lag check
+type replica struct {
+	db      *sql.DB
+	mu      sync.RWMutex
+	healthy bool
+	lag     time.Duration
+}
+
+// ReplicaRouter sends read-only queries to read replicas and everything else to
+// the primary. Replicas are only used while their lag is within the limit;
+// until a replica's first lag check, and whenever none qualifies, reads go to
+// the primary.
+type ReplicaRouter struct {
+	primary  *sql.DB
+	replicas []*replica
+	maxLag   time.Duration
+	next     uint32
+}
+
+// NewReplicaRouter creates a router over a primary and its read replicas.
+// Without replicas every query goes to the primary.
+func NewReplicaRouter(primary *sql.DB, replicas []*sql.DB, maxLag time.Duration) *ReplicaRouter {
+	r := &ReplicaRouter{
+		primary: primary,
+		maxLag:  maxLag,
+	}
+	for _, db := range replicas {
+		r.replicas = append(r.replicas, &replica{db: db})
+	}
+	return r
+}
+
+// OpenReplicas opens a connection pool for each replica DSN. Replicas are not
+// pinged: one that is down is skipped by the router until a lag check reaches it.
+func OpenReplicas(driver string, dsns []string) ([]*sql.DB, error) {
+	replicas := make([]*sql.DB, 0, len(dsns))
+	for i, dsn := range dsns {
+		db, err := sql.Open(driver, dsn)
+		if err != nil {
+			for _, opened := range replicas {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("failed to open read replica %d: %w", i, err)
+		}
+		replicas = append(replicas, db)
+	}
+	return replicas, nil
+}
+
+// Primary returns the primary's connection pool
+func (r *ReplicaRouter) Primary() *sql.DB {
+	return r.primary
+}
+
+// Writer returns the primary for a write and, within a read-your-writes
+// scope, sends the scope's later reads to the primary too
+func (r *ReplicaRouter) Writer(ctx context.Context) *sql.DB {
+	MarkWritten(ctx)
+	return r.primary
+}
+
+// Reader returns a replica for a read-only query, rotating between replicas
+// within the lag limit. It returns the primary when none is, or when ctx has
+// written or asked for the primary.
+func (r *ReplicaRouter) Reader(ctx context.Context) *sql.DB {
+	if len(r.replicas) == 0 || usePrimary(ctx) {
+		return r.primary
+	}
+
+	start := atomic.AddUint32(&r.next, 1)
+	for i := range r.replicas {
+		rep := r.replicas[(int(start)+i)%len(r.replicas)]
+		rep.mu.RLock()
+		ok := rep.healthy && rep.lag <= r.maxLag
+		rep.mu.RUnlock()
+		if ok {
+			return rep.db
+		}
+	}
+	return r.primary
+}
+
+// Start checks replica lag immediately and then every interval until ctx is
+// cancelled. onError, which may be nil, is told about failed checks.
+func (r *ReplicaRouter) Start(ctx context.Context, interval time.Duration, onError func(error)) {
+	if len(r.replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.CheckLag(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckLag measures each replica's lag. A replica that cannot be reached is
+// taken out of rotation until a later check succeeds.
+func (r *ReplicaRouter) CheckLag(ctx context.Context) error {
+	var errs []error
+	for i, rep := range r.replicas {
+		var seconds float64
+		err := rep.db.QueryRowContext(ctx, replicaLagQuery).Scan(&seconds)
+
+		rep.mu.Lock()
+		rep.healthy = err == nil
+		if err == nil {
+			rep.lag = time.Duration(seconds * float64(time.Second))
+		}
+		rep.mu.Unlock()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check lag of read replica %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the replicas' connection pools. The primary is left open.
+func (r *ReplicaRouter) Close() error {
+	var errs []error
+	for _, rep := range r.replicas {
+		if err := rep.db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// readScopeKey is the context key holding a read-your-writes scope
+type readScopeKey struct{}
+
+// readScope records whether reads in a scope must go to the primary
+type readScope struct {
+	primary atomic.Bool
+}
+
+// WithReadYourWrites returns a context whose reads go to the primary once
+// anything has been written through it, so a request sees its own writes even
+// while replicas lag behind
+func WithReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(readScopeKey{}).(*readScope); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, readScopeKey{}, &readScope{})
+}
+
+// WithPrimary returns a context whose reads always go to the primary
+func WithPrimary(ctx context.Context) context.Context {
+	scope := &readScope{}
+	scope.primary.Store(true)
+	return context.WithValue(ctx, readScopeKey{}, scope)
+}
+
+// MarkWritten sends the remaining reads of ctx's read-your-writes scope to the
+// primary. It does nothing outside a scope.
+func MarkWritten(ctx context.Context) {
+	if scope, ok := ctx.Value(readScopeKey{}).(*readScope); ok {
+		scope.primary.Store(true)
+	}
+}
+
+func usePrimary(ctx context.Context) bool {
+	scope, ok := ctx.Value(readScopeKey{}).(*readScope)
+	return ok && scope.primary.Load()
+}
@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// localeFiles holds one catalog per locale, named <locale>.json. Each catalog
+// maps the English source text of a message to its translation.
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs maps a locale to its messages
+var catalogs = mustLoadCatalogs()
+
+// mustLoadCatalogs parses the embedded catalogs. A malformed catalog is a
+// build defect, so it panics rather than serving untranslated messages.
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: read catalogs: %v", err))
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: read catalog %s: %v", entry.Name(), err))
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: parse catalog %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	return loaded
+}
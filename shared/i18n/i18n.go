@@ -0,0 +1,170 @@
+// Package i18n localizes API messages and generated documents. Message
+// catalogs are embedded per locale and keyed by the English source text, so a
+// message without a translation falls back to English. The locale of a request
+// is negotiated from its Accept-Language header and kept in the request
+// context.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// EnUS is American English, the source language of all messages
+	EnUS = "en-US"
+	// ZhCN is Simplified Chinese
+	ZhCN = "zh-CN"
+	// DefaultLocale is used when a caller accepts none of the supported locales
+	DefaultLocale = EnUS
+
+	// GinKey is the gin context key holding the negotiated locale
+	GinKey = "Locale"
+)
+
+type contextKey struct{}
+
+// Supported returns the locales that have a message catalog
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Normalize maps a language tag to a supported locale, or returns "" if none
+// matches. Tags are matched case-insensitively, then by primary language, so
+// "en-GB" maps to en-US and "zh-Hans" to zh-CN. Traditional Chinese tags do
+// not match zh-CN.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" {
+		return ""
+	}
+	for locale := range catalogs {
+		if strings.ToLower(locale) == tag {
+			return locale
+		}
+	}
+
+	switch primary := strings.SplitN(tag, "-", 2)[0]; primary {
+	case "en":
+		return EnUS
+	case "zh":
+		switch {
+		case strings.Contains(tag, "hant"), strings.HasSuffix(tag, "-tw"),
+			strings.HasSuffix(tag, "-hk"), strings.HasSuffix(tag, "-mo"):
+			return ""
+		}
+		return ZhCN
+	}
+	return ""
+}
+
+// Negotiate picks the supported locale the caller prefers from an
+// Accept-Language header value, honouring quality values. It returns
+// DefaultLocale when the header is empty or names no supported locale.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return DefaultLocale
+		}
+		if locale := Normalize(c.tag); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// WithLocale returns a context carrying the locale. An unsupported locale
+// leaves the context unchanged.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	if locale = Normalize(locale); locale == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the locale carried by ctx, or DefaultLocale if there is
+// none. A *gin.Context may be passed directly once Middleware has run.
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(contextKey{}).(string); ok {
+		return locale
+	}
+	if locale, ok := ctx.Value(GinKey).(string); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Translate returns the message in the locale, or the message itself if the
+// locale's catalog has no translation for it
+func Translate(locale, message string) string {
+	if translated, ok := catalogs[locale][message]; ok && translated != "" {
+		return translated
+	}
+	return message
+}
+
+// T translates a message into the locale carried by ctx
+func T(ctx context.Context, message string) string {
+	return Translate(FromContext(ctx), message)
+}
+
+// Tf translates a format string into the locale carried by ctx and formats
+// it. Translations must keep the verbs of the source text in order.
+func Tf(ctx context.Context, format string, args ...interface{}) string {
+	return fmt.Sprintf(Translate(FromContext(ctx), format), args...)
+}
+
+// Middleware returns a gin middleware that negotiates the request's locale
+// from its Accept-Language header, stores it in the request context and the
+// gin context, and reports it in the Content-Language response header.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := Negotiate(c.GetHeader("Accept-Language"))
+
+		c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), locale))
+		c.Set(GinKey, locale)
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
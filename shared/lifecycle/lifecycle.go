@@ -0,0 +1,207 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Phase is a stage of shutdown. Phases run in order; the hooks within a phase
+// run concurrently.
+type Phase int
+
+const (
+	// PhaseIntake stops new work arriving: listeners stop accepting
+	// connections and consumers stop fetching messages
+	PhaseIntake Phase = iota
+	// PhaseDrain waits for work already accepted: in-flight requests,
+	// messages being handled and running background jobs
+	PhaseDrain
+	// PhaseFlush persists buffered state, such as unsealed audit chain
+	// entries and queued outbox events
+	PhaseFlush
+	// PhaseRelease closes connections and clients
+	PhaseRelease
+)
+
+var phaseNames = map[Phase]string{
+	PhaseIntake:  "intake",
+	PhaseDrain:   "drain",
+	PhaseFlush:   "flush",
+	PhaseRelease: "release",
+}
+
+func (p Phase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("phase(%d)", int(p))
+}
+
+// hook is a registered shutdown step
+type hook struct {
+	phase Phase
+	name  string
+	stop  func(ctx context.Context) error
+}
+
+// Manager shuts a service down in phases under one deadline, so intake stops
+// before work is drained and work is drained before state is flushed
+type Manager struct {
+	onIncomplete func(step Step)
+
+	mu    sync.Mutex
+	hooks []hook
+}
+
+// New creates a lifecycle manager. onIncomplete is called with every step
+// that fails or is still running at the deadline, as soon as it is known, so
+// the failure is reported even if the process is killed before Shutdown
+// returns. Steps of a phase run concurrently, and so may its calls.
+func New(onIncomplete func(step Step)) *Manager {
+	return &Manager{onIncomplete: onIncomplete}
+}
+
+// OnShutdown registers a step to run in a phase. stop should return once its
+// work is done or ctx is done, whichever is first.
+func (m *Manager) OnShutdown(phase Phase, name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{phase: phase, name: name, stop: stop})
+}
+
+// Step is the outcome of a shutdown step
+type Step struct {
+	Phase    string        `json:"phase"`
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Err      error         `json:"-"`
+}
+
+// Report is the outcome of a shutdown
+type Report struct {
+	Steps    []Step        `json:"steps"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Complete reports whether every step finished without error before the deadline
+func (r *Report) Complete() bool {
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Incomplete returns the steps that failed or ran out of time
+func (r *Report) Incomplete() []Step {
+	var steps []Step
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// Err combines the errors of the incomplete steps, naming each step, or
+// returns nil if shutdown was complete
+func (r *Report) Err() error {
+	var errs []error
+	for _, step := range r.Incomplete() {
+		errs = append(errs, fmt.Errorf("%s/%s: %w", step.Phase, step.Name, step.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// releaseGrace is how long release steps may take, even after the shutdown
+// deadline, so connections are closed cleanly when draining ran out of time
+const releaseGrace = 5 * time.Second
+
+// Shutdown runs the registered steps phase by phase until ctx is done. A step
+// still running at the deadline is reported as incomplete and left behind,
+// and steps of later phases are given no further time, except for release.
+func (m *Manager) Shutdown(ctx context.Context) *Report {
+	m.mu.Lock()
+	hooks := append([]hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	start := time.Now()
+	report := &Report{}
+	for phase := PhaseIntake; phase <= PhaseRelease; phase++ {
+		var phaseHooks []hook
+		for _, h := range hooks {
+			if h.phase == phase {
+				phaseHooks = append(phaseHooks, h)
+			}
+		}
+
+		phaseCtx := ctx
+		if phase == PhaseRelease {
+			var cancel context.CancelFunc
+			phaseCtx, cancel = context.WithTimeout(context.WithoutCancel(ctx), releaseGrace)
+			defer cancel()
+		}
+		report.Steps = append(report.Steps, m.runPhase(phaseCtx, phaseHooks)...)
+	}
+	report.Duration = time.Since(start)
+	return report
+}
+
+// runPhase runs a phase's hooks concurrently and waits for them or ctx
+func (m *Manager) runPhase(ctx context.Context, hooks []hook) []Step {
+	steps := make([]Step, len(hooks))
+	var wg sync.WaitGroup
+	for i, h := range hooks {
+		wg.Add(1)
+		go func(i int, h hook) {
+			defer wg.Done()
+			steps[i] = runHook(ctx, h)
+			if steps[i].Err != nil && m.onIncomplete != nil {
+				m.onIncomplete(steps[i])
+			}
+		}(i, h)
+	}
+	wg.Wait()
+	return steps
+}
+
+// runHook runs a hook, giving up on it when ctx is done
+func runHook(ctx context.Context, h hook) Step {
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- h.stop(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("still running at shutdown deadline: %w", ctx.Err())
+	}
+	return Step{
+		Phase:    h.phase.String(),
+		Name:     h.name,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+}
+
+// Func adapts a stop function that takes no context and cannot fail
+func Func(stop func()) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		stop()
+		return nil
+	}
+}
+
+// ErrorFunc adapts a stop function that takes no context
+func ErrorFunc(stop func() error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return stop()
+	}
+}
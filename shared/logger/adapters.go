@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"log"
+
+	"go.uber.org/zap"
+)
+
+// StdLogger returns a standard library logger writing at info level through
+// l, for libraries that accept a *log.Logger
+func (l *Logger) StdLogger() *log.Logger {
+	return zap.NewStdLog(l.Logger)
+}
+
+// RedirectStdLog sends output of the standard library's global logger, such
+// as remaining log.Printf calls, through l at info level. It returns a
+// function restoring the previous output.
+func (l *Logger) RedirectStdLog() func() {
+	return zap.RedirectStdLog(l.Logger)
+}
+
+// KV adapts the logger to interfaces taking loosely typed fields, such as
+// Info(msg string, fields ...interface{}). Fields are alternating keys and
+// values and may include typed fields created with String, Int and the like.
+type KV struct {
+	sugar *zap.SugaredLogger
+}
+
+// KV returns the logger as a KV adapter
+func (l *Logger) KV() *KV {
+	return &KV{sugar: l.Logger.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+// Debug logs a debug level message
+func (k *KV) Debug(msg string, fields ...interface{}) {
+	k.sugar.Debugw(msg, fields...)
+}
+
+// Info logs an info level message
+func (k *KV) Info(msg string, fields ...interface{}) {
+	k.sugar.Infow(msg, fields...)
+}
+
+// Warn logs a warning level message
+func (k *KV) Warn(msg string, fields ...interface{}) {
+	k.sugar.Warnw(msg, fields...)
+}
+
+// Error logs an error level message
+func (k *KV) Error(msg string, fields ...interface{}) {
+	k.sugar.Errorw(msg, fields...)
+}
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"github.com/csic-platform/shared/correlation"
+	"github.com/csic-platform/shared/session"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Standard field names shared by every service, so entries can be searched
+// across services by the same keys
+const (
+	FieldService       = "service"
+	FieldEnvironment   = "env"
+	FieldCorrelationID = "correlation_id"
+	FieldUserID        = "user_id"
+)
+
+// Field is a structured log field
+type Field = zap.Field
+
+// String creates a string field
+func String(key, value string) Field {
+	return zap.String(key, value)
+}
+
+// Int creates an int field
+func Int(key string, value int) Field {
+	return zap.Int(key, value)
+}
+
+// Int64 creates an int64 field
+func Int64(key string, value int64) Field {
+	return zap.Int64(key, value)
+}
+
+// Float64 creates a float64 field
+func Float64(key string, value float64) Field {
+	return zap.Float64(key, value)
+}
+
+// Bool creates a bool field
+func Bool(key string, value bool) Field {
+	return zap.Bool(key, value)
+}
+
+// Duration creates a duration field
+func Duration(key string, value time.Duration) Field {
+	return zap.Duration(key, value)
+}
+
+// Any creates a field for an arbitrary value
+func Any(key string, value interface{}) Field {
+	return zap.Any(key, value)
+}
+
+// Error creates an error field
+func Error(err error) Field {
+	return zap.Error(err)
+}
+
+// WithFields groups fields into one, so call sites can pass a set of fields
+// where a single field is expected. The fields are logged inline.
+func WithFields(fields ...Field) Field {
+	return zap.Inline(fieldGroup(fields))
+}
+
+// fieldGroup logs a group of fields into the enclosing entry
+type fieldGroup []Field
+
+func (g fieldGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range g {
+		field.AddTo(enc)
+	}
+	return nil
+}
+
+// ContextFields returns the correlation ID and user ID carried by ctx as
+// fields, omitting those that are not set
+func ContextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	var fields []Field
+	if id := correlation.FromContext(ctx); id != "" {
+		fields = append(fields, zap.String(FieldCorrelationID, id))
+	}
+	if id := userID(ctx); id != "" {
+		fields = append(fields, zap.String(FieldUserID, id))
+	}
+	return fields
+}
+
+// userID returns the ID of the user whose gateway session ctx carries
+func userID(ctx context.Context) string {
+	if claims := session.FromContext(ctx); claims != nil {
+		return claims.UserID
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SetLevel changes the minimum level logged by the logger and every logger
+// derived from it. The level is one of debug, info, warn or error, in any
+// case.
+func (l *Logger) SetLevel(level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	l.level.SetLevel(parsed)
+	return nil
+}
+
+// Level returns the minimum level logged
+func (l *Logger) Level() string {
+	return l.level.String()
+}
+
+// LevelHandler returns an HTTP handler reporting the level on GET and
+// changing it on PUT with a body such as {"level":"debug"}. It should only be
+// mounted on an internal or authenticated route.
+func (l *Logger) LevelHandler() http.Handler {
+	return l.level
+}
+
+// WatchLevel applies the level returned by source every interval until ctx
+// is cancelled, so a level changed in the configuration takes effect without
+// a restart. Errors from source keep the current level.
+func (l *Logger) WatchLevel(ctx context.Context, interval time.Duration, source func() (string, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			level, err := source()
+			if err != nil {
+				l.Warn("failed to reload log level", zap.Error(err))
+				continue
+			}
+			if level == "" {
+				continue
+			}
+
+			previous := l.Level()
+			if err := l.SetLevel(level); err != nil {
+				l.Warn("ignoring configured log level", zap.Error(err))
+				continue
+			}
+			if current := l.Level(); current != previous {
+				l.Info("log level changed", zap.String("from", previous), zap.String("to", current))
+			}
+		}
+	}
+}
@@ -1,5 +1,8 @@
 // Shared Logger Package - Structured JSON logging for CSIC Platform
-// Government-grade audit logging with structured output
+// Government-grade audit logging with structured output. Every entry carries
+// the service and environment; entries logged with Ctx also carry the
+// request's correlation ID and user ID. The level can be changed at runtime
+// and noisy paths can be sampled.
 
 package logger
 
@@ -11,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/csic-platform/shared/correlation"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -41,29 +45,32 @@ const (
 
 // LogEntry represents a structured log entry
 type LogEntry struct {
-	Timestamp   time.Time              `json:"timestamp"`
-	Level       LogLevel               `json:"level"`
-	Service     string                 `json:"service"`
-	EventType   AuditEventType         `json:"event_type,omitempty"`
-	Operation   string                 `json:"operation"`
-	UserID      string                 `json:"user_id,omitempty"`
-	SessionID   string                 `json:"session_id,omitempty"`
-	Resource    string                 `json:"resource,omitempty"`
-	Action      string                 `json:"action,omitempty"`
-	Result      string                 `json:"result,omitempty"`
-	Message     string                 `json:"message"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	Error       string                 `json:"error,omitempty"`
-	TraceID     string                 `json:"trace_id,omitempty"`
-	IPAddress   string                 `json:"ip_address,omitempty"`
-	UserAgent   string                 `json:"user_agent,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Level     LogLevel               `json:"level"`
+	Service   string                 `json:"service"`
+	EventType AuditEventType         `json:"event_type,omitempty"`
+	Operation string                 `json:"operation"`
+	UserID    string                 `json:"user_id,omitempty"`
+	SessionID string                 `json:"session_id,omitempty"`
+	Resource  string                 `json:"resource,omitempty"`
+	Action    string                 `json:"action,omitempty"`
+	Result    string                 `json:"result,omitempty"`
+	Message   string                 `json:"message"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	TraceID   string                 `json:"trace_id,omitempty"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	UserAgent string                 `json:"user_agent,omitempty"`
 }
 
 // Logger provides structured logging capabilities
 type Logger struct {
 	*zap.Logger
+	// wrapped skips the Logger's own methods when reporting the caller
+	wrapped   *zap.Logger
 	mu        sync.Mutex
 	service   string
+	level     zap.AtomicLevel
 	auditSink AuditSink
 }
 
@@ -120,12 +127,21 @@ func (s *FileAuditSink) Close() error {
 
 // Config holds logger configuration
 type Config struct {
-	ServiceName   string
-	LogLevel      string
-	OutputPath    string
-	AuditLogPath  string
-	Development   bool
-	JSONOutput    bool
+	ServiceName  string
+	Environment  string
+	LogLevel     string
+	OutputPath   string
+	AuditLogPath string
+	Development  bool
+	JSONOutput   bool
+
+	// SamplingInitial and SamplingThereafter sample repeated entries: per
+	// second, the first SamplingInitial entries with the same level and
+	// message are logged and then every SamplingThereafter-th. Zero keeps
+	// zap's default, which samples in production only; a negative
+	// SamplingInitial disables sampling.
+	SamplingInitial    int
+	SamplingThereafter int
 }
 
 // NewLogger creates a new Logger instance
@@ -147,6 +163,20 @@ func NewLogger(cfg Config) (*Logger, error) {
 	}
 	zapCfg.Level = zap.NewAtomicLevelAt(level)
 
+	// Set sampling
+	if cfg.SamplingInitial < 0 {
+		zapCfg.Sampling = nil
+	} else if cfg.SamplingInitial > 0 {
+		thereafter := cfg.SamplingThereafter
+		if thereafter <= 0 {
+			thereafter = cfg.SamplingInitial
+		}
+		zapCfg.Sampling = &zap.SamplingConfig{Initial: cfg.SamplingInitial, Thereafter: thereafter}
+	}
+	if !cfg.JSONOutput && !cfg.Development {
+		zapCfg.Encoding = "console"
+	}
+
 	// Set output path
 	if cfg.OutputPath != "" {
 		zapCfg.OutputPaths = []string{cfg.OutputPath}
@@ -159,10 +189,22 @@ func NewLogger(cfg Config) (*Logger, error) {
 		return nil, fmt.Errorf("failed to build logger: %w", err)
 	}
 
+	// Standard fields carried by every entry
+	var fields []zap.Field
+	if cfg.ServiceName != "" {
+		fields = append(fields, zap.String(FieldService, cfg.ServiceName))
+	}
+	if cfg.Environment != "" {
+		fields = append(fields, zap.String(FieldEnvironment, cfg.Environment))
+	}
+
+	baseLogger = baseLogger.With(fields...)
 	logger := &Logger{
-		Logger:     baseLogger,
-		service:    cfg.ServiceName,
-		auditSink:  &ConsoleAuditSink{},
+		Logger:    baseLogger,
+		wrapped:   baseLogger.WithOptions(zap.AddCallerSkip(1)),
+		service:   cfg.ServiceName,
+		level:     zapCfg.Level,
+		auditSink: &ConsoleAuditSink{},
 	}
 
 	// Setup audit log sink if path provided
@@ -180,12 +222,44 @@ func NewLogger(cfg Config) (*Logger, error) {
 
 // WithService returns a new logger with the specified service name
 func (l *Logger) WithService(service string) *Logger {
-	newLogger := &Logger{
-		Logger:     l.Logger.With(zap.String("service", service)),
-		service:    service,
-		auditSink:  l.auditSink,
+	return l.derive(l.Logger.With(zap.String(FieldService, service)), service)
+}
+
+// Ctx returns a logger adding the correlation ID and user ID carried by ctx
+// to every entry
+func (l *Logger) Ctx(ctx context.Context) *zap.Logger {
+	fields := ContextFields(ctx)
+	if len(fields) == 0 {
+		return l.Logger
+	}
+	return l.Logger.With(fields...)
+}
+
+// Sampled returns a logger for noisy paths, such as health checks and
+// per-message consumer logs: per tick, the first entries with the same level
+// and message are logged and then every thereafter-th
+func (l *Logger) Sampled(tick time.Duration, first, thereafter int) *Logger {
+	return l.derive(Sample(l.Logger, tick, first, thereafter), l.service)
+}
+
+// Sample returns base sampled like Sampled, for code holding a *zap.Logger
+func Sample(base *zap.Logger, tick time.Duration, first, thereafter int) *zap.Logger {
+	return base.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, tick, first, thereafter)
+	}))
+}
+
+// derive returns a logger sharing l's level and audit sink
+func (l *Logger) derive(base *zap.Logger, service string) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return &Logger{
+		Logger:    base,
+		wrapped:   base.WithOptions(zap.AddCallerSkip(1)),
+		service:   service,
+		level:     l.level,
+		auditSink: l.auditSink,
 	}
-	return newLogger
 }
 
 // SetAuditSink replaces the audit sink
@@ -197,23 +271,26 @@ func (l *Logger) SetAuditSink(sink AuditSink) {
 
 // Log creates a structured log entry
 func (l *Logger) Log(ctx context.Context, entry LogEntry) {
-	entry.Timestamp = time.Now().utc()
+	entry.Timestamp = time.Now().UTC()
 	entry.Service = l.service
+	if entry.UserID == "" {
+		entry.UserID = userID(ctx)
+	}
+	if entry.TraceID == "" {
+		entry.TraceID = correlation.FromContext(ctx)
+	}
 
-	// Convert to zap fields
+	// Convert to zap fields; service and environment are already carried by
+	// the logger
 	fields := []zap.Field{
-		zap.String("level", string(entry.Level)),
-		zap.String("service", entry.Service),
 		zap.String("operation", entry.Operation),
-		zap.String("message", entry.Message),
-		zap.Time("timestamp", entry.Timestamp),
 	}
 
 	if entry.EventType != "" {
 		fields = append(fields, zap.String("event_type", string(entry.EventType)))
 	}
 	if entry.UserID != "" {
-		fields = append(fields, zap.String("user_id", entry.UserID))
+		fields = append(fields, zap.String(FieldUserID, entry.UserID))
 	}
 	if entry.SessionID != "" {
 		fields = append(fields, zap.String("session_id", entry.SessionID))
@@ -231,7 +308,7 @@ func (l *Logger) Log(ctx context.Context, entry LogEntry) {
 		fields = append(fields, zap.String("error", entry.Error))
 	}
 	if entry.TraceID != "" {
-		fields = append(fields, zap.String("trace_id", entry.TraceID))
+		fields = append(fields, zap.String(FieldCorrelationID, entry.TraceID))
 	}
 	if entry.IPAddress != "" {
 		fields = append(fields, zap.String("ip_address", entry.IPAddress))
@@ -246,20 +323,25 @@ func (l *Logger) Log(ctx context.Context, entry LogEntry) {
 	// Log at appropriate level
 	switch entry.Level {
 	case LevelDebug:
-		l.Logger.Debug(entry.Message, fields...)
+		l.wrapped.Debug(entry.Message, fields...)
 	case LevelInfo:
-		l.Logger.Info(entry.Message, fields...)
+		l.wrapped.Info(entry.Message, fields...)
 	case LevelWarn:
-		l.Logger.Warn(entry.Message, fields...)
+		l.wrapped.Warn(entry.Message, fields...)
 	case LevelError:
-		l.Logger.Error(entry.Message, fields...)
+		l.wrapped.Error(entry.Message, fields...)
 	case LevelFatal:
-		l.Logger.Fatal(entry.Message, fields...)
+		l.wrapped.Fatal(entry.Message, fields...)
 	}
 
 	// Write to audit log for compliance events
 	if entry.EventType != "" && entry.Level != LevelDebug {
-		l.auditSink.WriteAuditLog(ctx, entry)
+		l.mu.Lock()
+		sink := l.auditSink
+		l.mu.Unlock()
+		if err := sink.WriteAuditLog(ctx, entry); err != nil {
+			l.Logger.Error("failed to write audit log", zap.Error(err))
+		}
 	}
 }
 
@@ -277,27 +359,27 @@ func (l *Logger) Audit(ctx context.Context, eventType AuditEventType, operation,
 
 // Info logs an info level message
 func (l *Logger) Info(message string, fields ...zap.Field) {
-	l.Logger.Info(message, fields...)
+	l.wrapped.Info(message, fields...)
 }
 
 // Error logs an error level message
 func (l *Logger) Error(message string, fields ...zap.Field) {
-	l.Logger.Error(message, fields...)
+	l.wrapped.Error(message, fields...)
 }
 
 // Debug logs a debug level message
 func (l *Logger) Debug(message string, fields ...zap.Field) {
-	l.Logger.Debug(message, fields...)
+	l.wrapped.Debug(message, fields...)
 }
 
 // Warn logs a warning level message
 func (l *Logger) Warn(message string, fields ...zap.Field) {
-	l.Logger.Warn(message, fields...)
+	l.wrapped.Warn(message, fields...)
 }
 
 // Fatal logs a fatal level message and exits
 func (l *Logger) Fatal(message string, fields ...zap.Field) {
-	l.Logger.Fatal(message, fields...)
+	l.wrapped.Fatal(message, fields...)
 }
 
 // WithFields returns a new logger with additional fields
@@ -319,8 +401,3 @@ func (l *Logger) Close() error {
 	}
 	return l.Sync()
 }
-
-// Helper function to ensure UTC timestamp
-func (t time.Time) utc() time.Time {
-	return t.UTC()
-}
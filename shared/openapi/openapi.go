@@ -0,0 +1,382 @@
+// Package openapi generates OpenAPI 3 documents for the platform's HTTP
+// services and validates requests against them.
+//
+// Paths, methods and path parameters are read from the gin router, so every
+// registered route appears in the document. Handlers are annotated with
+// Describe to add a summary, query parameters and the Go types of the request
+// and response bodies, whose schemas are derived from their json and binding
+// tags:
+//
+//	spec.Describe(h.CreateLicense, openapi.Operation{
+//		Summary:  "Create a license",
+//		Tags:     []string{"licenses"},
+//		Request:  domain.CreateLicenseRequest{},
+//		Response: domain.License{},
+//		Status:   http.StatusCreated,
+//	})
+//
+// The same descriptions drive Validator, which rejects request bodies and
+// query parameters that do not match the document before they reach the
+// handler.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.0.3"
+
+// Path is where services serve their document
+const Path = "/api/v1/openapi.json"
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []map[string][]string `json:"security,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a base URL of the API
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations on a path, by lower-case method
+type PathItem map[string]*OperationObject
+
+// OperationObject is an operation in the document
+type OperationObject struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of a request
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is a response of an operation
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a body in one media type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas referenced from the document
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a way of authenticating requests
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+// Operation annotates a handler
+type Operation struct {
+	Summary     string
+	Description string
+	Tags        []string
+	// Query is a struct whose form tags name the query parameters
+	Query interface{}
+	// Request is a value of the JSON request body type, if any
+	Request interface{}
+	// RequestOptional documents a request body the handler can do without
+	RequestOptional bool
+	// Response is a value of the JSON response body type, if any
+	Response interface{}
+	// Status is the success status, 200 by default
+	Status int
+	// ContentType is the response media type, application/json by default
+	ContentType string
+	Deprecated  bool
+}
+
+// Config describes a service's API
+type Config struct {
+	Title       string
+	Version     string
+	Description string
+	Servers     []Server
+	// Error is a value of the error body type, documented as the default
+	// response of every operation
+	Error interface{}
+	// ErrorContentType is the error media type, application/problem+json by default
+	ErrorContentType string
+	// SecuritySchemes apply to every operation
+	SecuritySchemes map[string]*SecurityScheme
+	// Exclude lists paths left out of the document, such as /health
+	Exclude []string
+}
+
+// BearerAuth is the security scheme of JWT bearer tokens
+var BearerAuth = &SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+
+// Spec collects the operation annotations of a service and builds its
+// document from the router
+type Spec struct {
+	config      Config
+	mu          sync.RWMutex
+	schemas     *schemaRegistry
+	errorSchema *Schema
+	operations  map[string]*described
+	document    *Document
+}
+
+// described is an annotated operation with its derived schemas
+type described struct {
+	op       Operation
+	query    []*Parameter
+	request  *Schema
+	response *Schema
+}
+
+// New creates a spec
+func New(config Config) *Spec {
+	if config.ErrorContentType == "" {
+		config.ErrorContentType = "application/problem+json"
+	}
+	s := &Spec{
+		config:     config,
+		schemas:    newSchemaRegistry(),
+		operations: make(map[string]*described),
+	}
+	if config.Error != nil {
+		s.errorSchema = s.schemas.of(reflect.TypeOf(config.Error))
+	}
+	return s
+}
+
+// Describe annotates a handler. Every route served by the handler is
+// described by op. Handlers are described at startup, before serving.
+func (s *Spec) Describe(handler gin.HandlerFunc, op Operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := &described{op: op}
+	if op.Query != nil {
+		d.query = queryParameters(reflect.TypeOf(op.Query), s.schemas)
+	}
+	if op.Request != nil {
+		d.request = s.schemas.of(reflect.TypeOf(op.Request))
+	}
+	if op.Response != nil {
+		d.response = s.schemas.of(reflect.TypeOf(op.Response))
+	}
+	s.operations[handlerName(handler)] = d
+	s.document = nil
+}
+
+// Document builds the document for the routes of a router. Routes of
+// handlers that have not been described get a bare operation.
+func (s *Spec) Document(routes gin.RoutesInfo) *Document {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       s.config.Title,
+			Version:     s.config.Version,
+			Description: s.config.Description,
+		},
+		Servers: s.config.Servers,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			SecuritySchemes: s.config.SecuritySchemes,
+		},
+	}
+	schemes := make([]string, 0, len(s.config.SecuritySchemes))
+	for name := range s.config.SecuritySchemes {
+		schemes = append(schemes, name)
+	}
+	sort.Strings(schemes)
+	for _, name := range schemes {
+		doc.Security = append(doc.Security, map[string][]string{name: {}})
+	}
+
+	sorted := append(gin.RoutesInfo{}, routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	for _, route := range sorted {
+		if s.excluded(route.Path) || route.Path == Path {
+			continue
+		}
+		path, params := templatePath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+
+		d := s.operations[route.Handler]
+		if d == nil {
+			d = &described{}
+		}
+
+		op := &OperationObject{
+			OperationID: operationID(route.Method, path),
+			Summary:     d.op.Summary,
+			Description: d.op.Description,
+			Tags:        d.op.Tags,
+			Deprecated:  d.op.Deprecated,
+			Responses:   make(map[string]*Response),
+		}
+		for _, name := range params {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+		op.Parameters = append(op.Parameters, d.query...)
+
+		if d.request != nil {
+			op.RequestBody = &RequestBody{
+				Required: !d.op.RequestOptional,
+				Content:  map[string]*MediaType{"application/json": {Schema: d.request}},
+			}
+		}
+
+		status := d.op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := &Response{Description: http.StatusText(status)}
+		if d.response != nil {
+			contentType := d.op.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			success.Content = map[string]*MediaType{contentType: {Schema: d.response}}
+		}
+		op.Responses[strconv.Itoa(status)] = success
+		if s.errorSchema != nil {
+			op.Responses["default"] = &Response{
+				Description: "Error",
+				Content:     map[string]*MediaType{s.config.ErrorContentType: {Schema: s.errorSchema}},
+			}
+		}
+
+		(*item)[strings.ToLower(route.Method)] = op
+	}
+
+	doc.Components.Schemas = s.schemas.components
+	s.document = doc
+	return doc
+}
+
+// Handler serves the document for the routes of the router. The document is
+// built on the first request, once every route has been registered.
+func (s *Spec) Handler(router *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.mu.RLock()
+		doc := s.document
+		s.mu.RUnlock()
+		if doc == nil {
+			doc = s.Document(router.Routes())
+		}
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// lookup returns the annotation of a handler, by the name gin reports for it
+func (s *Spec) lookup(handler string) *described {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.operations[handler]
+}
+
+func (s *Spec) excluded(path string) bool {
+	for _, excluded := range s.config.Exclude {
+		if path == excluded {
+			return true
+		}
+	}
+	return false
+}
+
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// templatePath converts a gin path such as /licenses/:id into the OpenAPI
+// template /licenses/{id} and returns the parameter names
+func templatePath(path string) (string, []string) {
+	var params []string
+	templated := pathParam.ReplaceAllStringFunc(path, func(segment string) string {
+		name := segment[1:]
+		params = append(params, name)
+		return "{" + name + "}"
+	})
+	return templated, params
+}
+
+// operationID derives a stable operation ID from the method and path, such
+// as post_licenses_id_approve
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, "{}")
+		if segment == "" || segment == "api" || segment == "v1" {
+			continue
+		}
+		id += "_" + strings.NewReplacer("-", "_", ".", "_").Replace(segment)
+	}
+	return id
+}
+
+// handlerName returns the name gin reports for a handler in RoutesInfo
+func handlerName(handler gin.HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+}
@@ -0,0 +1,381 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is an OpenAPI 3.0 schema object
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Patterns documented for the platform's binding tags; see shared/validation
+var tagPatterns = map[string]string{
+	"btc_address": `^(bc1[a-z0-9]{11,71}|[13][a-km-zA-HJ-NP-Z1-9]{25,34})$`,
+	"eth_address": `^0x[0-9a-fA-F]{40}$`,
+	"tx_hash":     `^(0x)?[0-9a-fA-F]{64}$`,
+	"iso4217":     `^[A-Z]{3}$`,
+	"uuid":        `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+}
+
+var tagFormats = map[string]string{
+	"email": "email",
+	"uuid":  "uuid",
+	"url":   "uri",
+	"uri":   "uri",
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry derives schemas from Go types, placing named struct types
+// in the document's components so they are described once
+type schemaRegistry struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// of returns the schema of a type
+func (r *schemaRegistry) of(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	schema := r.inline(t)
+	if nullable && schema.Ref == "" {
+		schema.Nullable = true
+	}
+	return schema
+}
+
+// inline returns the schema of a non-pointer type, or a reference to it for
+// named structs
+func (r *schemaRegistry) inline(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Kind() != reflect.String && (t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType)):
+		// Types such as decimals marshal to strings
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// Nil slices and maps marshal as null, and null decodes into them
+		return &Schema{Type: "array", Items: r.of(t.Elem()), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.of(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t)
+		}
+		return r.ref(t)
+	default:
+		// Interfaces accept any value
+		return &Schema{}
+	}
+}
+
+// ref returns a reference to the component of a named struct, describing it
+// the first time it is seen
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = r.componentName(t)
+		r.names[t] = name
+		// Reserve the name first, so recursive types refer to themselves
+		r.components[name] = &Schema{}
+		*r.components[name] = *r.object(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// componentName names a struct's component, qualifying it with its package
+// when two packages have types of the same name
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	name := genericName(t.Name())
+	if _, taken := r.components[name]; !taken {
+		return name
+	}
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return strings.ReplaceAll(pkg, "-", "_") + "." + name
+}
+
+// genericName names an instance of a generic type after its type arguments,
+// such as Envelope_AlertList for Envelope[[]*domain.Alert]
+func genericName(name string) string {
+	base, args, generic := strings.Cut(name, "[")
+	if !generic {
+		return name
+	}
+	for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+		suffix := ""
+		for {
+			if strings.HasPrefix(arg, "[]") {
+				arg, suffix = arg[2:], "List"+suffix
+			} else if strings.HasPrefix(arg, "map[") {
+				arg, suffix = arg[strings.Index(arg, "]")+1:], "Map"+suffix
+			} else if strings.HasPrefix(arg, "*") {
+				arg = arg[1:]
+			} else {
+				break
+			}
+		}
+		if i := strings.LastIndex(arg, "."); i >= 0 {
+			arg = arg[i+1:]
+		}
+		base += "_" + arg + suffix
+	}
+	return base
+}
+
+// object describes a struct's JSON fields, including those of embedded structs
+func (r *schemaRegistry) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.fields(t, schema)
+	return schema
+}
+
+func (r *schemaRegistry) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.fields(embedded, schema)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.of(field.Type)
+		if strings.Contains(opts, "string") && property.Type != "string" {
+			property = &Schema{Type: "string"}
+		}
+		if applyBinding(property, field.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		if description := field.Tag.Get("description"); description != "" {
+			if property.Ref != "" {
+				property = &Schema{Ref: property.Ref}
+			}
+			property.Description = description
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyBinding documents the constraints of a binding tag on a property and
+// reports whether the field is required
+func applyBinding(schema *Schema, binding string) bool {
+	required := false
+	if binding == "" {
+		return false
+	}
+	// Constraints after dive apply to the elements of a slice
+	target := schema
+	for _, rule := range strings.Split(binding, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if target.Items != nil {
+				target = target.Items
+			}
+		case "oneof":
+			target.Enum = nil
+			for _, value := range strings.Fields(param) {
+				target.Enum = append(target.Enum, enumValue(target, value))
+			}
+		case "min", "gte", "max", "lte", "gt", "lt", "len":
+			applyBound(target, name, param)
+		default:
+			if pattern, ok := tagPatterns[name]; ok && target.Ref == "" {
+				target.Pattern = pattern
+			}
+			if format, ok := tagFormats[name]; ok && target.Ref == "" {
+				target.Format = format
+			}
+		}
+	}
+	return required
+}
+
+// applyBound documents a size or value bound, which applies to the length of
+// strings and arrays and the value of numbers
+func applyBound(schema *Schema, rule, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	lower := rule == "min" || rule == "gte" || rule == "gt" || rule == "len"
+	upper := rule == "max" || rule == "lte" || rule == "lt" || rule == "len"
+
+	switch schema.Type {
+	case "string", "array":
+		size := int(n)
+		if rule == "gt" {
+			size++
+		}
+		if rule == "lt" {
+			size--
+		}
+		if schema.Type == "string" {
+			if lower {
+				schema.MinLength = &size
+			}
+			if upper {
+				schema.MaxLength = &size
+			}
+		} else {
+			if lower {
+				schema.MinItems = &size
+			}
+			if upper {
+				schema.MaxItems = &size
+			}
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &n
+			schema.ExclusiveMinimum = rule == "gt"
+		}
+		if upper {
+			schema.Maximum = &n
+			schema.ExclusiveMaximum = rule == "lt"
+		}
+	}
+}
+
+// enumValue converts a oneof value to the property's type
+func enumValue(schema *Schema, value string) interface{} {
+	switch schema.Type {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+// queryParameters describes the fields of a struct with form tags as query
+// parameters
+func queryParameters(t reflect.Type, r *schemaRegistry) []*Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("form") == "" {
+			params = append(params, queryParameters(field.Type, r)...)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		schema := r.of(field.Type)
+		schema.Nullable = false
+		param := &Parameter{
+			Name:        name,
+			In:          "query",
+			Description: field.Tag.Get("description"),
+			Schema:      schema,
+		}
+		param.Required = applyBinding(schema, field.Tag.Get("binding"))
+		params = append(params, param)
+	}
+	return params
+}
+
+// ListQuery documents the sorting and pagination parameters of list
+// endpoints parsed by shared/query. Filters of the form filter[field] depend
+// on the endpoint and are not listed.
+type ListQuery struct {
+	Sort     string `form:"sort" description:"Fields to order by, such as created_at:desc,name:asc"`
+	Page     int    `form:"page" binding:"omitempty,min=1" description:"Page number for offset pagination"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1" description:"Items per page"`
+	Cursor   string `form:"cursor" description:"next_cursor of the previous page, for keyset pagination"`
+}
@@ -0,0 +1,376 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/csic-platform/shared/problem"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ValidatorOptions configures request and response validation
+type ValidatorOptions struct {
+	// MaxBodyBytes bounds the request bodies read for validation; larger
+	// bodies are left to the handler. 1 MB by default.
+	MaxBodyBytes int64
+	// Responses also checks JSON responses against the document and logs
+	// mismatches, without changing the response. Meant for development and
+	// contract tests, since every response is buffered.
+	Responses bool
+	// Logger receives response mismatches
+	Logger *zap.Logger
+	// Reject answers requests that do not match, for services whose errors
+	// are not problem documents. problem.Write by default.
+	Reject func(c *gin.Context, err error)
+}
+
+// Validator returns a middleware that checks the query parameters and JSON
+// body of requests to described handlers against their schemas. Requests
+// that do not match are rejected with an InvalidArgument error listing every
+// field at fault, before the handler runs.
+func (s *Spec) Validator(opts ValidatorOptions) gin.HandlerFunc {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 1 << 20
+	}
+	if opts.Reject == nil {
+		opts.Reject = problem.Write
+	}
+	reject := func(c *gin.Context, err error) {
+		opts.Reject(c, err)
+		c.Abort()
+	}
+
+	return func(c *gin.Context) {
+		d := s.lookup(c.HandlerName())
+		if d == nil {
+			c.Next()
+			return
+		}
+
+		v := s.newValidation()
+		for _, param := range d.query {
+			raw, present := c.GetQuery(param.Name)
+			if !present {
+				if param.Required {
+					v.fail(param.Name, "is required")
+				}
+				continue
+			}
+			v.check(param.Schema, queryValue(param.Schema, raw), param.Name)
+		}
+
+		if d.request != nil && hasBody(c.Request) && c.Request.ContentLength <= opts.MaxBodyBytes {
+			body, err := io.ReadAll(io.LimitReader(c.Request.Body, opts.MaxBodyBytes+1))
+			if err != nil {
+				reject(c, problem.Wrap(err, problem.InvalidArgument, "Request body could not be read"))
+				return
+			}
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+			if int64(len(body)) <= opts.MaxBodyBytes && isJSON(c.ContentType()) {
+				value, err := decode(body)
+				if err != nil {
+					reject(c, problem.Invalid("Request body is not valid JSON"))
+					return
+				}
+				v.check(d.request, value, "")
+			}
+		}
+
+		if len(v.errors) > 0 {
+			reject(c, problem.Invalid("Request does not match the API specification", v.errors...))
+			return
+		}
+
+		if !opts.Responses || d.response == nil || opts.Logger == nil {
+			c.Next()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := d.op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if recorder.Status() != status || !isJSON(recorder.Header().Get("Content-Type")) {
+			return
+		}
+		value, err := decode(recorder.body.Bytes())
+		if err != nil {
+			opts.Logger.Warn("response is not valid JSON",
+				zap.String("method", c.Request.Method),
+				zap.String("route", c.FullPath()),
+				zap.Error(err))
+			return
+		}
+		rv := s.newValidation()
+		rv.check(d.response, value, "")
+		for _, fe := range rv.errors {
+			opts.Logger.Warn("response does not match the API specification",
+				zap.String("method", c.Request.Method),
+				zap.String("route", c.FullPath()),
+				zap.String("field", fe.Field),
+				zap.String("problem", fe.Message))
+		}
+	}
+}
+
+// validation collects the fields of a value that do not match a schema
+type validation struct {
+	components map[string]*Schema
+	errors     []problem.FieldError
+}
+
+func (s *Spec) newValidation() *validation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &validation{components: s.schemas.components}
+}
+
+func (v *validation) fail(field, message string) {
+	v.errors = append(v.errors, problem.FieldError{Field: field, Message: message})
+}
+
+// check validates a decoded JSON value, reporting fields by their JSON path
+// such as blockchain_addresses[0].address
+func (v *validation) check(schema *Schema, value interface{}, path string) {
+	field := path
+	if field == "" {
+		field = "body"
+	}
+
+	if value == nil {
+		// Like encoding/json, null leaves a struct unchanged, so references
+		// accept it
+		if !schema.Nullable && schema.Type != "" {
+			v.fail(field, "must not be null")
+		}
+		return
+	}
+	if schema.Ref != "" {
+		resolved, ok := v.components[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if !ok {
+			return
+		}
+		schema = resolved
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			v.fail(field, "must be an object")
+			return
+		}
+		for _, name := range schema.Required {
+			if isMissing(object[name]) {
+				v.fail(join(path, name), "is required")
+			}
+		}
+		for _, name := range sortedKeys(schema.Properties) {
+			if member, ok := object[name]; ok && !isMissing(member) {
+				v.check(schema.Properties[name], member, join(path, name))
+			}
+		}
+		if schema.AdditionalProperties != nil {
+			for _, name := range sortedKeys(object) {
+				v.check(schema.AdditionalProperties, object[name], join(path, name))
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			v.fail(field, "must be an array")
+			return
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			v.fail(field, fmt.Sprintf("must have at least %d items", *schema.MinItems))
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			v.fail(field, fmt.Sprintf("must have at most %d items", *schema.MaxItems))
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				v.check(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			v.fail(field, "must be a string")
+			return
+		}
+		length := len([]rune(str))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			v.fail(field, fmt.Sprintf("must be at least %d characters", *schema.MinLength))
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			v.fail(field, fmt.Sprintf("must be at most %d characters", *schema.MaxLength))
+		}
+		if schema.Pattern != "" && !matches(schema.Pattern, str) {
+			v.fail(field, "has an invalid format")
+		}
+		v.checkEnum(schema, str, field)
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			v.fail(field, "must be a number")
+			return
+		}
+		n, err := number.Float64()
+		if err != nil {
+			v.fail(field, "must be a number")
+			return
+		}
+		if schema.Type == "integer" {
+			if _, err := number.Int64(); err != nil {
+				v.fail(field, "must be an integer")
+				return
+			}
+		}
+		if schema.Minimum != nil && (n < *schema.Minimum || schema.ExclusiveMinimum && n == *schema.Minimum) {
+			v.fail(field, "must be at least "+strconv.FormatFloat(*schema.Minimum, 'f', -1, 64))
+		}
+		if schema.Maximum != nil && (n > *schema.Maximum || schema.ExclusiveMaximum && n == *schema.Maximum) {
+			v.fail(field, "must be at most "+strconv.FormatFloat(*schema.Maximum, 'f', -1, 64))
+		}
+		v.checkEnum(schema, number.String(), field)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(field, "must be a boolean")
+		}
+	}
+}
+
+func (v *validation) checkEnum(schema *Schema, value, field string) {
+	if len(schema.Enum) == 0 {
+		return
+	}
+	allowed := make([]string, len(schema.Enum))
+	for i, option := range schema.Enum {
+		allowed[i] = fmt.Sprint(option)
+		if allowed[i] == value {
+			return
+		}
+	}
+	v.fail(field, "must be one of: "+strings.Join(allowed, " "))
+}
+
+// isMissing reports whether a required member is absent: null or, as for the
+// required binding, an empty string
+func isMissing(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map in order, so errors are reported in
+// the same order on every request
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// queryValue converts a query parameter to the JSON value its schema expects,
+// leaving values that do not convert as strings to be reported
+func queryValue(schema *Schema, raw string) interface{} {
+	switch schema.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case "array":
+		items := make([]interface{}, 0)
+		for _, item := range strings.Split(raw, ",") {
+			if schema.Items != nil {
+				items = append(items, queryValue(schema.Items, item))
+			} else {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return raw
+}
+
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// patterns caches compiled schema patterns
+var patterns sync.Map
+
+func matches(pattern, value string) bool {
+	compiled, ok := patterns.Load(pattern)
+	if !ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return true
+		}
+		compiled, _ = patterns.LoadOrStore(pattern, re)
+	}
+	return compiled.(*regexp.Regexp).MatchString(value)
+}
+
+// responseRecorder keeps a copy of the response body for validation
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,261 @@
+package pdp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/shared/queue"
+	"google.golang.org/grpc"
+)
+
+// ClientOptions controls calls to the policy decision point and how long
+// decisions are cached
+type ClientOptions struct {
+	Timeout time.Duration // per call
+	// CacheTTL caps how long a decision is reused; the server's TTL applies
+	// when shorter. Negative disables caching.
+	CacheTTL time.Duration
+	// CacheSize is the most decisions kept; the cache is emptied when full
+	CacheSize int
+}
+
+// DefaultClientOptions returns the options used for unset fields
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		Timeout:   2 * time.Second,
+		CacheTTL:  30 * time.Second,
+		CacheSize: 10000,
+	}
+}
+
+// cachedDecision is a decision and when it stops being reusable
+type cachedDecision struct {
+	decision  *AccessDecision
+	expiresAt time.Time
+}
+
+// Client asks the control layer's policy decision point for access decisions
+// and caches them locally. Cached decisions are dropped when a policy changes:
+// register HandlePolicyUpdate for PolicyUpdatesTopic on a consumer group
+// unique to the instance, so every instance sees every change.
+type Client struct {
+	conn *grpc.ClientConn
+	opts ClientOptions
+
+	mu    sync.Mutex
+	cache map[string]cachedDecision
+}
+
+// Dial connects to the policy decision point at target
+func Dial(target string, opts ClientOptions, dialOpts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.Dial(target, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial policy decision point: %w", err)
+	}
+	return NewClient(conn, opts), nil
+}
+
+// NewClient creates a client over an existing connection. Unset options take
+// their defaults.
+func NewClient(conn *grpc.ClientConn, opts ClientOptions) *Client {
+	defaults := DefaultClientOptions()
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaults.Timeout
+	}
+	if opts.CacheTTL == 0 {
+		opts.CacheTTL = defaults.CacheTTL
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = defaults.CacheSize
+	}
+
+	return &Client{
+		conn:  conn,
+		opts:  opts,
+		cache: make(map[string]cachedDecision),
+	}
+}
+
+// CheckAccess returns the decision for one request, from the cache when possible
+func (c *Client) CheckAccess(ctx context.Context, req *AccessRequest) (*AccessDecision, error) {
+	key, err := cacheKey(req)
+	if err != nil {
+		return nil, err
+	}
+	if decision, ok := c.cached(key); ok {
+		return decision, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	decision := new(AccessDecision)
+	if err := c.conn.Invoke(ctx, "/"+ServiceName+"/CheckAccess", req, decision, grpc.CallContentSubtype(codecName)); err != nil {
+		return nil, fmt.Errorf("failed to check access: %w", err)
+	}
+
+	c.store(key, decision)
+	return decision, nil
+}
+
+// BulkCheckAccess returns one decision per request, in order. Cached decisions
+// are looked up together and the rest are fetched in a single call, asking
+// once for requests repeated in the batch.
+func (c *Client) BulkCheckAccess(ctx context.Context, reqs []*AccessRequest) ([]*AccessDecision, error) {
+	keys := make([]string, len(reqs))
+	for i, req := range reqs {
+		key, err := cacheKey(req)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+
+	decisions := c.cachedBatch(keys)
+
+	// Requests still undecided, with each distinct one asked once
+	bulk := &BulkCheckAccessRequest{}
+	asked := make(map[string]int)
+	var askedKeys []string
+	var missing []int
+	for i, decision := range decisions {
+		if decision != nil {
+			continue
+		}
+		missing = append(missing, i)
+		if _, ok := asked[keys[i]]; !ok {
+			asked[keys[i]] = len(bulk.Requests)
+			askedKeys = append(askedKeys, keys[i])
+			bulk.Requests = append(bulk.Requests, reqs[i])
+		}
+	}
+	if len(missing) == 0 {
+		return decisions, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	resp := new(BulkCheckAccessResponse)
+	if err := c.conn.Invoke(ctx, "/"+ServiceName+"/BulkCheckAccess", bulk, resp, grpc.CallContentSubtype(codecName)); err != nil {
+		return nil, fmt.Errorf("failed to check access: %w", err)
+	}
+	if len(resp.Decisions) != len(bulk.Requests) {
+		return nil, fmt.Errorf("policy decision point returned %d decisions for %d requests", len(resp.Decisions), len(bulk.Requests))
+	}
+
+	for _, i := range missing {
+		decisions[i] = resp.Decisions[asked[keys[i]]]
+	}
+	c.storeBatch(askedKeys, resp.Decisions)
+	return decisions, nil
+}
+
+// Invalidate drops every cached decision
+func (c *Client) Invalidate() {
+	c.mu.Lock()
+	c.cache = make(map[string]cachedDecision)
+	c.mu.Unlock()
+}
+
+// HandlePolicyUpdate is a queue.Handler for PolicyUpdatesTopic. Any policy
+// change can alter any decision, so the whole cache is dropped.
+func (c *Client) HandlePolicyUpdate(ctx context.Context, msg *queue.Message) error {
+	c.Invalidate()
+	return nil
+}
+
+// Close closes the connection to the policy decision point
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) cached(key string) (*AccessDecision, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.cache, key)
+		return nil, false
+	}
+	return entry.decision, true
+}
+
+// cachedBatch looks up several keys under one lock, returning nil for those
+// not cached
+func (c *Client) cachedBatch(keys []string) []*AccessDecision {
+	decisions := make([]*AccessDecision, len(keys))
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, key := range keys {
+		entry, ok := c.cache[key]
+		if !ok {
+			continue
+		}
+		if now.After(entry.expiresAt) {
+			delete(c.cache, key)
+			continue
+		}
+		decisions[i] = entry.decision
+	}
+	return decisions
+}
+
+// storeBatch caches several decisions under one lock
+func (c *Client) storeBatch(keys []string, decisions []*AccessDecision) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, key := range keys {
+		c.storeLocked(key, decisions[i], now)
+	}
+}
+
+func (c *Client) store(key string, decision *AccessDecision) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.storeLocked(key, decision, time.Now())
+}
+
+// storeLocked caches a decision for the shorter of the client's and the
+// server's TTL. c.mu must be held.
+func (c *Client) storeLocked(key string, decision *AccessDecision, now time.Time) {
+	ttl := c.opts.CacheTTL
+	if server := time.Duration(decision.TTLSeconds) * time.Second; server < ttl {
+		ttl = server
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	if len(c.cache) >= c.opts.CacheSize {
+		c.cache = make(map[string]cachedDecision)
+	}
+	c.cache[key] = cachedDecision{decision: decision, expiresAt: now.Add(ttl)}
+}
+
+// cacheKey identifies a request by a hash of its JSON encoding, which sorts
+// attribute keys and so is the same for equal requests
+func cacheKey(req *AccessRequest) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode access request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
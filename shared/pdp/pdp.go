@@ -0,0 +1,146 @@
+// Package pdp is the gRPC contract of the policy decision point served by the
+// control layer, and a client for the services that ask it for access
+// decisions instead of re-implementing access checks.
+//
+// Messages are plain Go structs sent with a JSON codec, so the service needs
+// no generated code: the control layer registers it with RegisterServer and
+// callers use Client.
+package pdp
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// ServiceName is the fully qualified gRPC service name
+const ServiceName = "csic.pdp.v1.PolicyDecisionPoint"
+
+// PolicyUpdatesTopic carries the control layer's policy change events. Clients
+// drop their cached decisions when one arrives.
+const PolicyUpdatesTopic = "control-layer.policy.updates"
+
+// Decision values
+const (
+	DecisionAllow = "allow"
+	DecisionDeny  = "deny"
+)
+
+// Subject is the user or service asking for access
+type Subject struct {
+	ID    string   `json:"id"`
+	Type  string   `json:"type"` // user, service, api_key
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Resource is what access is asked for
+type Resource struct {
+	Type string `json:"type"` // e.g. wallet, exchange, case
+	ID   string `json:"id,omitempty"`
+}
+
+// AccessRequest asks whether a subject may perform an action on a resource
+type AccessRequest struct {
+	Subject    Subject                `json:"subject"`
+	Action     string                 `json:"action"`
+	Resource   Resource               `json:"resource"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// AccessDecision is the policy decision point's answer to an AccessRequest
+type AccessDecision struct {
+	Decision string `json:"decision"`
+	Reason   string `json:"reason,omitempty"`
+	// PolicyIDs lists the policies that denied the request
+	PolicyIDs []string `json:"policy_ids,omitempty"`
+	// TTLSeconds is how long the caller may reuse the decision
+	TTLSeconds int `json:"ttl_seconds"`
+}
+
+// Allowed reports whether access was granted
+func (d *AccessDecision) Allowed() bool {
+	return d.Decision == DecisionAllow
+}
+
+// BulkCheckAccessRequest asks for several decisions in one call
+type BulkCheckAccessRequest struct {
+	Requests []*AccessRequest `json:"requests"`
+}
+
+// BulkCheckAccessResponse holds one decision per request, in request order
+type BulkCheckAccessResponse struct {
+	Decisions []*AccessDecision `json:"decisions"`
+}
+
+// Server is implemented by the policy decision point
+type Server interface {
+	CheckAccess(ctx context.Context, req *AccessRequest) (*AccessDecision, error)
+	BulkCheckAccess(ctx context.Context, req *BulkCheckAccessRequest) (*BulkCheckAccessResponse, error)
+}
+
+// RegisterServer registers the policy decision point on a gRPC server
+func RegisterServer(registrar grpc.ServiceRegistrar, srv Server) {
+	registrar.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "CheckAccess", Handler: checkAccessHandler},
+		{MethodName: "BulkCheckAccess", Handler: bulkCheckAccessHandler},
+	},
+	Metadata: "pdp",
+}
+
+func checkAccessHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).CheckAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/CheckAccess"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Server).CheckAccess(ctx, req.(*AccessRequest))
+	})
+}
+
+func bulkCheckAccessHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCheckAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).BulkCheckAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/BulkCheckAccess"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Server).BulkCheckAccess(ctx, req.(*BulkCheckAccessRequest))
+	})
+}
+
+// codecName is the gRPC content subtype the service is spoken in
+const codecName = "json"
+
+// jsonCodec encodes gRPC messages as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
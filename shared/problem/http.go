@@ -0,0 +1,142 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/csic-platform/shared/correlation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ContentType is the media type of problem documents
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem document, extended with the error code, the
+// correlation ID, per-field problems and any extensions of the error
+type Problem struct {
+	Type          string       `json:"type"`
+	Title         string       `json:"title"`
+	Status        int          `json:"status"`
+	Detail        string       `json:"detail,omitempty"`
+	Instance      string       `json:"instance,omitempty"`
+	Code          Code         `json:"code"`
+	CorrelationID string       `json:"correlation_id,omitempty"`
+	Errors        []FieldError `json:"errors,omitempty"`
+
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON writes extensions as top-level members alongside the standard
+// ones, which they cannot replace
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type standard Problem
+	data, err := json.Marshal(standard(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]interface{}, len(p.Extensions))
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	var std map[string]json.RawMessage
+	if err := json.Unmarshal(data, &std); err != nil {
+		return nil, err
+	}
+	for k, v := range std {
+		members[k] = v
+	}
+	return json.Marshal(members)
+}
+
+// From builds the problem document for err
+func From(err error) Problem {
+	e := Classify(err)
+	return Problem{
+		Type:       e.Code.Type(),
+		Title:      e.Code.Title(),
+		Status:     e.Code.Status(),
+		Detail:     e.Message,
+		Code:       e.Code,
+		Errors:     e.Fields,
+		Extensions: e.Extensions,
+	}
+}
+
+// Write responds to the request with the problem document for err, records
+// err on the request for Middleware to log, and aborts the handler chain
+func Write(c *gin.Context, err error) {
+	_ = c.Error(err)
+	write(c, err)
+}
+
+func write(c *gin.Context, err error) {
+	p := forRequest(c.Request, err)
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// WriteHTTP responds with the problem document for err, for services on
+// net/http rather than gin. Unlike Write it does not log; callers that log
+// failed requests use LogFields.
+func WriteHTTP(w http.ResponseWriter, r *http.Request, err error) {
+	p := forRequest(r, err)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// forRequest builds the problem document for err in answer to r
+func forRequest(r *http.Request, err error) Problem {
+	p := From(err)
+	p.Instance = r.URL.Path
+	p.CorrelationID = correlation.FromContext(r.Context())
+	return p
+}
+
+// Abort records err on the request and aborts the handler chain, leaving the
+// response to Middleware
+func Abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// Middleware writes the problem document for the last error recorded by a
+// handler, if the handler has not responded, and logs every failed request
+// with the fields from LogFields. Server errors are logged at error level,
+// client errors at info.
+func Middleware(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+		err := c.Errors.Last().Err
+		if !c.Writer.Written() {
+			write(c, err)
+		}
+
+		fields := append(LogFields(err),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("correlation_id", correlation.FromContext(c.Request.Context())),
+		)
+		if CodeOf(err).Status() >= 500 {
+			log.Error("request failed", fields...)
+		} else {
+			log.Info("request rejected", fields...)
+		}
+	}
+}
+
+// LogFields returns the fields every service logs an error with
+func LogFields(err error) []zap.Field {
+	e := Classify(err)
+	return []zap.Field{
+		zap.String("error_code", string(e.Code)),
+		zap.Int("http_status", e.Code.Status()),
+		zap.Error(err),
+	}
+}
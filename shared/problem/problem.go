@@ -0,0 +1,231 @@
+// Package problem is the platform's error taxonomy. Errors carry one of a
+// small set of codes, each mapped to an HTTP status, and are written to
+// clients as RFC 7807 application/problem+json documents with the same
+// fields in every service.
+//
+// Services classify their domain errors once, with Register, and otherwise
+// return plain errors; database errors are classified by SQLSTATE, so a
+// repository can return the driver's error unchanged.
+package problem
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Code classifies an error
+type Code string
+
+// Error codes
+const (
+	InvalidArgument    Code = "INVALID_ARGUMENT"
+	Unauthenticated    Code = "UNAUTHENTICATED"
+	PermissionDenied   Code = "PERMISSION_DENIED"
+	NotFound           Code = "NOT_FOUND"
+	MethodNotAllowed   Code = "METHOD_NOT_ALLOWED"
+	Conflict           Code = "CONFLICT"
+	FailedPrecondition Code = "FAILED_PRECONDITION"
+	// PreconditionRequired is for conditional requests sent without If-Match
+	PreconditionRequired Code = "PRECONDITION_REQUIRED"
+	RateLimited          Code = "RATE_LIMITED"
+	Canceled             Code = "CANCELED"
+	Internal             Code = "INTERNAL"
+	Unavailable          Code = "UNAVAILABLE"
+	DeadlineExceeded     Code = "DEADLINE_EXCEEDED"
+)
+
+var statuses = map[Code]int{
+	InvalidArgument:      http.StatusBadRequest,
+	Unauthenticated:      http.StatusUnauthorized,
+	PermissionDenied:     http.StatusForbidden,
+	NotFound:             http.StatusNotFound,
+	MethodNotAllowed:     http.StatusMethodNotAllowed,
+	Conflict:             http.StatusConflict,
+	FailedPrecondition:   http.StatusUnprocessableEntity,
+	PreconditionRequired: http.StatusPreconditionRequired,
+	RateLimited:          http.StatusTooManyRequests,
+	// 499 is the de facto status for a request the client gave up on
+	Canceled:         499,
+	Internal:         http.StatusInternalServerError,
+	Unavailable:      http.StatusServiceUnavailable,
+	DeadlineExceeded: http.StatusGatewayTimeout,
+}
+
+// Status returns the HTTP status for the code
+func (c Code) Status() int {
+	if status, ok := statuses[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Title returns the short human-readable summary of the code
+func (c Code) Title() string {
+	if c == Canceled {
+		return "Request Canceled"
+	}
+	return http.StatusText(c.Status())
+}
+
+// Type returns the problem type URI identifying the code
+func (c Code) Type() string {
+	return "urn:csic:problem:" + strings.ToLower(strings.ReplaceAll(string(c), "_", "-"))
+}
+
+// Error is an error classified with a code. Message is shown to clients;
+// the wrapped error is only logged.
+type Error struct {
+	Code    Code
+	Message string
+	// Fields lists per-field problems for InvalidArgument errors
+	Fields []FieldError
+	// Extensions are added to the problem document as extra members, such as
+	// the current state of a resource after a conflict
+	Extensions map[string]interface{}
+	Err        error
+}
+
+// FieldError is a problem with one field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	if e.Message == "" {
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// With adds an extension member to the problem document and returns e
+func (e *Error) With(key string, value interface{}) *Error {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]interface{})
+	}
+	e.Extensions[key] = value
+	return e
+}
+
+// New returns an error with a code and a client-facing message
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Newf returns an error with a code and a formatted client-facing message
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap classifies err with a code and a client-facing message. The message of
+// err itself is kept out of responses.
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// Invalid returns an InvalidArgument error listing the fields at fault
+func Invalid(message string, fields ...FieldError) *Error {
+	return &Error{Code: InvalidArgument, Message: message, Fields: fields}
+}
+
+// registry maps sentinel errors to codes
+var registry struct {
+	mu      sync.RWMutex
+	entries []registered
+}
+
+type registered struct {
+	target error
+	code   Code
+}
+
+// Register classifies errors matching any of targets, as by errors.Is, with a
+// code. Services call it at startup for their domain errors; the target's
+// message is then shown to clients.
+func Register(code Code, targets ...error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for _, target := range targets {
+		registry.entries = append(registry.entries, registered{target: target, code: code})
+	}
+}
+
+// sqlState is implemented by the errors of lib/pq and pgx
+type sqlState interface {
+	SQLState() string
+}
+
+// sqlStateCodes classifies database errors by SQLSTATE
+var sqlStateCodes = map[string]Code{
+	"23505": Conflict,           // unique_violation
+	"23503": FailedPrecondition, // foreign_key_violation
+	"23514": InvalidArgument,    // check_violation
+	"22001": InvalidArgument,    // string_data_right_truncation
+	"22P02": InvalidArgument,    // invalid_text_representation
+	"40001": Conflict,           // serialization_failure
+	"40P01": Conflict,           // deadlock_detected
+	"55P03": Conflict,           // lock_not_available
+	"57014": DeadlineExceeded,   // query_canceled
+	"53300": Unavailable,        // too_many_connections
+	"57P01": Unavailable,        // admin_shutdown
+}
+
+// Classify returns err as an *Error, classifying errors that have no code:
+// registered sentinels, database errors and context errors get their code,
+// and anything else is Internal. It returns nil for a nil error.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified
+	}
+
+	registry.mu.RLock()
+	for _, entry := range registry.entries {
+		if errors.Is(err, entry.target) {
+			registry.mu.RUnlock()
+			return Wrap(err, entry.code, entry.target.Error())
+		}
+	}
+	registry.mu.RUnlock()
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return Wrap(err, NotFound, "Resource not found")
+	case errors.Is(err, context.DeadlineExceeded):
+		return Wrap(err, DeadlineExceeded, "The request took too long")
+	case errors.Is(err, context.Canceled):
+		return Wrap(err, Canceled, "The request was canceled")
+	}
+
+	var state sqlState
+	if errors.As(err, &state) {
+		if code, ok := sqlStateCodes[state.SQLState()]; ok {
+			return Wrap(err, code, code.Title())
+		}
+	}
+	return Wrap(err, Internal, "An internal error occurred")
+}
+
+// CodeOf returns the code err is classified with
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	return Classify(err).Code
+}
@@ -0,0 +1,161 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrPlaceholders is returned when a fragment's placeholders do not match the
+// values given for it
+var ErrPlaceholders = errors.New("placeholder count does not match arguments")
+
+// Builder assembles a query from fixed SQL fragments and bound values, for
+// repositories whose filters are not driven by list parameters. Fragments
+// mark where a value goes with ?, which the builder numbers as $1, $2, ...;
+// values only ever travel as arguments, so callers never format a value or a
+// placeholder number into SQL themselves. Write ?? for a literal ?.
+//
+// Fragments must be constants. Intervals are bound like any other value:
+//
+//	b.Where("expiry_date <= NOW() + make_interval(days => ?)", days)
+type Builder struct {
+	base    string
+	where   []string
+	orderBy string
+	limit   int
+	offset  int
+	args    []interface{}
+	err     error
+}
+
+// NewBuilder starts a query from its fixed head, such as "SELECT ... FROM t",
+// which must not have a WHERE clause of its own
+func NewBuilder(base string) *Builder {
+	return &Builder{base: base}
+}
+
+// Where adds a condition, joined to the others with AND. Conditions combining
+// alternatives must bring their own parentheses.
+func (b *Builder) Where(condition string, args ...interface{}) *Builder {
+	rendered, err := b.bind(condition, args)
+	if err != nil {
+		b.fail(err)
+		return b
+	}
+	b.where = append(b.where, rendered)
+	return b
+}
+
+// WhereIn adds a membership condition on column. An empty set matches no rows.
+func (b *Builder) WhereIn(column string, values []interface{}) *Builder {
+	if len(values) == 0 {
+		b.where = append(b.where, "FALSE")
+		return b
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+	return b.Where(column+" IN ("+placeholders+")", values...)
+}
+
+// OrderBy sets the ORDER BY terms. Terms are SQL; a sort chosen by a caller
+// must be mapped to a term through a whitelist, as Table does.
+func (b *Builder) OrderBy(terms ...string) *Builder {
+	b.orderBy = strings.Join(terms, ", ")
+	return b
+}
+
+// Limit bounds the number of rows; n <= 0 means no limit
+func (b *Builder) Limit(n int) *Builder {
+	b.limit = n
+	return b
+}
+
+// Offset skips rows; n <= 0 skips none
+func (b *Builder) Offset(n int) *Builder {
+	b.offset = n
+	return b
+}
+
+// Build returns the query and its arguments, or the first error recorded
+// while building
+func (b *Builder) Build() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	var sql strings.Builder
+	sql.WriteString(b.base)
+	sql.WriteString(whereClause(b.where))
+	if b.orderBy != "" {
+		sql.WriteString(" ORDER BY ")
+		sql.WriteString(b.orderBy)
+	}
+
+	args := append([]interface{}{}, b.args...)
+	if b.limit > 0 {
+		args = append(args, b.limit)
+		sql.WriteString(" LIMIT $" + strconv.Itoa(len(args)))
+	}
+	if b.offset > 0 {
+		args = append(args, b.offset)
+		sql.WriteString(" OFFSET $" + strconv.Itoa(len(args)))
+	}
+	return sql.String(), args, nil
+}
+
+// bind numbers a fragment's placeholders after the arguments bound so far
+func (b *Builder) bind(fragment string, args []interface{}) (string, error) {
+	var out strings.Builder
+	n := 0
+	for i := 0; i < len(fragment); i++ {
+		c := fragment[i]
+		if c != '?' {
+			out.WriteByte(c)
+			continue
+		}
+		if i+1 < len(fragment) && fragment[i+1] == '?' {
+			out.WriteByte('?')
+			i++
+			continue
+		}
+		if n == len(args) {
+			return "", fmt.Errorf("%w: %q", ErrPlaceholders, fragment)
+		}
+		out.WriteString("$" + strconv.Itoa(len(b.args)+n+1))
+		n++
+	}
+	if n != len(args) {
+		return "", fmt.Errorf("%w: %q", ErrPlaceholders, fragment)
+	}
+	b.args = append(b.args, args...)
+	return out.String(), nil
+}
+
+func (b *Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Values converts a typed slice for WhereIn
+func Values[T any](values []T) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+
+// Ident checks that name is a plain SQL identifier, for the rare table or
+// column name that comes from configuration rather than code. It rejects
+// anything needing quotes, so the name can be placed into SQL as is.
+func Ident(name string) (string, error) {
+	if !identifier.MatchString(name) {
+		return "", fmt.Errorf("%w: invalid identifier %q", ErrInvalidQuery, name)
+	}
+	return name, nil
+}
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// cursor is the decoded form of an opaque pagination cursor. It records the
+// sort it was issued for, since the same key means a different position under
+// a different order.
+type cursor struct {
+	Key  string `json:"k"`
+	Sort string `json:"s"`
+}
+
+// NextCursor returns the cursor for the page after the row with the given key,
+// which should be the last row of the current page
+func (p *Params) NextCursor(lastKey string) string {
+	data, _ := json.Marshal(cursor{Key: lastKey, Sort: sortSignature(p.Sorts)})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw string, sorts []Sort) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Key == "" {
+		return "", fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	if c.Sort != sortSignature(sorts) {
+		return "", fmt.Errorf("%w: cursor was issued for a different sort", ErrInvalidQuery)
+	}
+	return c.Key, nil
+}
+
+func sortSignature(sorts []Sort) string {
+	parts := make([]string, len(sorts))
+	for i, s := range sorts {
+		parts[i] = s.Field + ":" + string(s.Direction)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,257 @@
+// Package query parses the sorting, filtering and pagination parameters shared
+// by list endpoints and turns them into parameterized SQL.
+//
+// List endpoints accept:
+//
+//	sort=created_at:desc,name:asc      order by one or more fields
+//	filter[status]=active              equality filter
+//	filter[risk_score][gte]=70         comparison filter (eq, ne, gt, gte, lt, lte)
+//	filter[status][in]=active,pending  set membership
+//	page=2&page_size=50                offset pagination
+//	cursor=<next_cursor>               keyset pagination from a previous page
+//
+// Field names are API names; a Table maps them to columns and rejects any
+// field it does not whitelist, so request input never reaches the SQL text.
+//
+// Repositories with fixed filters of their own assemble them with a Builder,
+// which binds every value as an argument.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidQuery is returned for malformed or disallowed query parameters
+var ErrInvalidQuery = errors.New("invalid query parameters")
+
+// Limits on a single request
+const (
+	maxSorts    = 3
+	maxFilters  = 10
+	maxInValues = 50
+)
+
+// Direction is a sort direction
+type Direction string
+
+// Sort directions
+const (
+	Asc  Direction = "asc"
+	Desc Direction = "desc"
+)
+
+// Operator is a filter comparison operator
+type Operator string
+
+// Filter operators
+const (
+	OpEq  Operator = "eq"
+	OpNe  Operator = "ne"
+	OpGt  Operator = "gt"
+	OpGte Operator = "gte"
+	OpLt  Operator = "lt"
+	OpLte Operator = "lte"
+	OpIn  Operator = "in"
+)
+
+var operatorSQL = map[Operator]string{
+	OpEq:  "=",
+	OpNe:  "<>",
+	OpGt:  ">",
+	OpGte: ">=",
+	OpLt:  "<",
+	OpLte: "<=",
+}
+
+// Sort orders results by a field
+type Sort struct {
+	Field     string
+	Direction Direction
+}
+
+// Filter restricts results to rows whose field compares to the values. Only
+// OpIn takes more than one value.
+type Filter struct {
+	Field  string
+	Op     Operator
+	Values []string
+}
+
+// Params are the parsed list parameters of a request
+type Params struct {
+	Page     int
+	PageSize int
+	// Cursor continues from the last row of a previous page; Page is ignored
+	// when it is set
+	Cursor  string
+	Sorts   []Sort
+	Filters []Filter
+}
+
+// Options are an endpoint's pagination defaults
+type Options struct {
+	DefaultPageSize int
+	MaxPageSize     int
+	// DefaultSort applies when the request has no sort parameter
+	DefaultSort []Sort
+}
+
+// DefaultOptions returns 20 items per page, at most 100, newest first
+func DefaultOptions() Options {
+	return Options{
+		DefaultPageSize: 20,
+		MaxPageSize:     100,
+		DefaultSort:     []Sort{{Field: "created_at", Direction: Desc}},
+	}
+}
+
+// PageInfo describes where a page sits in the full result
+type PageInfo struct {
+	Total      int64
+	NextCursor string
+}
+
+var filterKey = regexp.MustCompile(`^filter\[([a-z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
+// Parse reads list parameters from a query string
+func Parse(values url.Values, opts Options) (*Params, error) {
+	if opts.MaxPageSize <= 0 {
+		opts.MaxPageSize = 100
+	}
+	if opts.DefaultPageSize <= 0 || opts.DefaultPageSize > opts.MaxPageSize {
+		opts.DefaultPageSize = opts.MaxPageSize
+	}
+
+	params := &Params{
+		Page:     1,
+		PageSize: opts.DefaultPageSize,
+		Cursor:   values.Get("cursor"),
+	}
+
+	if p := values.Get("page"); p != "" {
+		page, err := strconv.Atoi(p)
+		if err != nil || page < 1 {
+			return nil, fmt.Errorf("%w: page must be a positive integer", ErrInvalidQuery)
+		}
+		params.Page = page
+	}
+
+	if ps := values.Get("page_size"); ps != "" {
+		pageSize, err := strconv.Atoi(ps)
+		if err != nil || pageSize < 1 || pageSize > opts.MaxPageSize {
+			return nil, fmt.Errorf("%w: page_size must be between 1 and %d", ErrInvalidQuery, opts.MaxPageSize)
+		}
+		params.PageSize = pageSize
+	}
+
+	sorts, err := parseSort(values.Get("sort"))
+	if err != nil {
+		return nil, err
+	}
+	if len(sorts) == 0 {
+		sorts = append(sorts, opts.DefaultSort...)
+	}
+	params.Sorts = sorts
+
+	filters, err := parseFilters(values)
+	if err != nil {
+		return nil, err
+	}
+	params.Filters = filters
+
+	return params, nil
+}
+
+// Offset returns the number of rows before the requested page
+func (p *Params) Offset() int {
+	if p.Cursor != "" || p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.PageSize
+}
+
+// Where adds a filter, letting endpoints map their own parameters onto the
+// standard ones
+func (p *Params) Where(field string, op Operator, values ...string) {
+	p.Filters = append(p.Filters, Filter{Field: field, Op: op, Values: values})
+}
+
+func parseSort(raw string) ([]Sort, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxSorts {
+		return nil, fmt.Errorf("%w: at most %d sort fields", ErrInvalidQuery, maxSorts)
+	}
+
+	sorts := make([]Sort, 0, len(parts))
+	for _, part := range parts {
+		field, dir, _ := strings.Cut(strings.TrimSpace(part), ":")
+		if field == "" {
+			return nil, fmt.Errorf("%w: empty sort field", ErrInvalidQuery)
+		}
+
+		direction := Asc
+		switch strings.ToLower(dir) {
+		case "", "asc":
+		case "desc":
+			direction = Desc
+		default:
+			return nil, fmt.Errorf("%w: sort direction must be asc or desc", ErrInvalidQuery)
+		}
+		sorts = append(sorts, Sort{Field: field, Direction: direction})
+	}
+	return sorts, nil
+}
+
+func parseFilters(values url.Values) ([]Filter, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var filters []Filter
+	for _, key := range keys {
+		vals := values[key]
+
+		m := filterKey.FindStringSubmatch(key)
+		if m == nil {
+			return nil, fmt.Errorf("%w: malformed filter %s", ErrInvalidQuery, key)
+		}
+
+		op := OpEq
+		if m[2] != "" {
+			op = Operator(m[2])
+		}
+
+		var filter Filter
+		switch {
+		case op == OpIn:
+			filter = Filter{Field: m[1], Op: op, Values: strings.Split(vals[0], ",")}
+			if len(filter.Values) > maxInValues {
+				return nil, fmt.Errorf("%w: at most %d values for %s", ErrInvalidQuery, maxInValues, key)
+			}
+		case operatorSQL[op] != "":
+			filter = Filter{Field: m[1], Op: op, Values: vals[:1]}
+		default:
+			return nil, fmt.Errorf("%w: unknown filter operator %s", ErrInvalidQuery, m[2])
+		}
+
+		filters = append(filters, filter)
+		if len(filters) > maxFilters {
+			return nil, fmt.Errorf("%w: at most %d filters", ErrInvalidQuery, maxFilters)
+		}
+	}
+	return filters, nil
+}
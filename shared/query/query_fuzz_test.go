@@ -0,0 +1,171 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var hostileInputs = []string{
+	"",
+	"'",
+	"' OR '1'='1",
+	"1; DROP TABLE entities; --",
+	"7 days'; DELETE FROM licenses; --",
+	"$1",
+	"?",
+	"??",
+	"name) OR (1=1",
+	"\x00",
+	"héllo\u2028world",
+	"/* comment */",
+}
+
+var fuzzTable = Table{
+	Name: "entities",
+	Key:  "id",
+	Columns: map[string]Column{
+		"id":         {Name: "id", Sortable: true, Filterable: true},
+		"name":       {Name: "name", Sortable: true, Filterable: true},
+		"status":     {Name: "status", Filterable: true},
+		"created_at": {Name: "created_at", Sortable: true, Filterable: true},
+	},
+}
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// checkPlaceholders fails unless the query numbers exactly one placeholder
+// per argument
+func checkPlaceholders(t *testing.T, sql string, args []interface{}) {
+	t.Helper()
+	seen := make(map[string]bool)
+	for _, m := range placeholder.FindAllStringSubmatch(sql, -1) {
+		seen[m[1]] = true
+	}
+	if len(seen) != len(args) {
+		t.Fatalf("query %q has %d distinct placeholders for %d args", sql, len(seen), len(args))
+	}
+}
+
+// FuzzBuilderValues checks that bound values never change the query text
+func FuzzBuilderValues(f *testing.F) {
+	for _, s := range hostileInputs {
+		f.Add(s, 7)
+	}
+
+	want := "SELECT id FROM licenses WHERE entity_id = $1 AND status IN ($2, $3)" +
+		" AND expiry_date <= NOW() + make_interval(days => $4) ORDER BY expiry_date ASC LIMIT $5"
+
+	f.Fuzz(func(t *testing.T, value string, days int) {
+		sql, args, err := NewBuilder("SELECT id FROM licenses").
+			Where("entity_id = ?", value).
+			WhereIn("status", Values([]string{value, "ACTIVE"})).
+			Where("expiry_date <= NOW() + make_interval(days => ?)", days).
+			OrderBy("expiry_date ASC").
+			Limit(10).
+			Build()
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+		if sql != want {
+			t.Fatalf("query changed with value %q:\n got %q\nwant %q", value, sql, want)
+		}
+		if args[0] != value || args[1] != value || args[3] != days {
+			t.Fatalf("values not bound as arguments: %v", args)
+		}
+	})
+}
+
+// FuzzBuilderFragments checks placeholder numbering for arbitrary fragments
+func FuzzBuilderFragments(f *testing.F) {
+	for _, s := range hostileInputs {
+		f.Add(s, s, uint8(1))
+	}
+
+	f.Fuzz(func(t *testing.T, first, second string, n uint8) {
+		args := make([]interface{}, n%4)
+		for i := range args {
+			args[i] = first
+		}
+
+		sql, bound, err := NewBuilder("SELECT 1 FROM t").
+			Where(first, args...).
+			Where(second).
+			Build()
+		if err != nil {
+			if !errors.Is(err, ErrPlaceholders) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return
+		}
+		if strings.Contains(first, "$") || strings.Contains(second, "$") {
+			// Fragments that write their own placeholders are the caller's fault
+			return
+		}
+		checkPlaceholders(t, sql, bound)
+	})
+}
+
+// FuzzListQuery runs hostile query strings through Parse and Table.Build and
+// checks that nothing but whitelisted columns reaches the SQL text
+func FuzzListQuery(f *testing.F) {
+	for _, s := range hostileInputs {
+		f.Add(s, s, s)
+	}
+	f.Add("name:desc", "status", "active")
+	f.Add("created_at", "created_at][gte", "2024-01-01")
+
+	f.Fuzz(func(t *testing.T, sort, field, value string) {
+		values := url.Values{}
+		values.Set("sort", sort)
+		values.Set("filter["+field+"]", value)
+
+		params, err := Parse(values, DefaultOptions())
+		if err != nil {
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return
+		}
+		stmt, err := fuzzTable.Build(params)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return
+		}
+
+		sql, args := stmt.Select("id, name")
+		checkPlaceholders(t, sql, args)
+		for _, filter := range params.Filters {
+			if _, ok := fuzzTable.Columns[filter.Field]; !ok {
+				t.Fatalf("filter on unlisted field %q was accepted", filter.Field)
+			}
+		}
+		for _, s := range params.Sorts {
+			if _, ok := fuzzTable.Columns[s.Field]; !ok {
+				t.Fatalf("sort on unlisted field %q was accepted", s.Field)
+			}
+		}
+	})
+}
+
+// FuzzIdent checks that accepted identifiers need no quoting
+func FuzzIdent(f *testing.F) {
+	for _, s := range hostileInputs {
+		f.Add(s)
+	}
+	f.Add("compliance_licenses")
+
+	f.Fuzz(func(t *testing.T, name string) {
+		got, err := Ident(name)
+		if err != nil {
+			return
+		}
+		if strings.ContainsAny(got, " '\";-()/*$?\x00") || got != name {
+			t.Fatalf("Ident accepted %q", name)
+		}
+	})
+}
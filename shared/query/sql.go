@@ -0,0 +1,189 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Column is a field a Table exposes to sorting and filtering
+type Column struct {
+	// Name is the SQL column the field maps to
+	Name string
+	// Sortable columns should be NOT NULL; keyset comparisons skip rows whose
+	// sort value is NULL
+	Sortable   bool
+	Filterable bool
+}
+
+// Table whitelists the fields a list endpoint can sort and filter by
+type Table struct {
+	// Name is the SQL table
+	Name string
+	// Key is a unique column, used to break ties between rows with equal sort
+	// values and to find the row a cursor points at
+	Key     string
+	Columns map[string]Column
+}
+
+// Statement is a list query built from Params for a Table
+type Statement struct {
+	table   Table
+	where   []string
+	args    []interface{}
+	keyset  string
+	key     string
+	orderBy string
+	limit   int
+	offset  int
+}
+
+// Build validates params against the table's whitelist and prepares the list
+// query. Fields the table does not expose are rejected with ErrInvalidQuery.
+func (t Table) Build(p *Params) (*Statement, error) {
+	stmt := &Statement{
+		table:  t,
+		limit:  p.PageSize + 1,
+		offset: p.Offset(),
+	}
+
+	for _, f := range p.Filters {
+		col, ok := t.Columns[f.Field]
+		if !ok || !col.Filterable {
+			return nil, fmt.Errorf("%w: cannot filter by %s", ErrInvalidQuery, f.Field)
+		}
+		stmt.where = append(stmt.where, stmt.condition(col.Name, f))
+	}
+
+	order, err := t.order(p.Sorts)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]string, len(order))
+	for i, s := range order {
+		terms[i] = s.column + " " + strings.ToUpper(string(s.direction))
+	}
+	stmt.orderBy = strings.Join(terms, ", ")
+
+	if p.Cursor != "" {
+		key, err := decodeCursor(p.Cursor, p.Sorts)
+		if err != nil {
+			return nil, err
+		}
+		stmt.key = key
+		stmt.keyset = t.keyset(order, len(stmt.args)+1)
+	}
+
+	return stmt, nil
+}
+
+// Select returns the query for a page of rows with the given select list. It
+// fetches one row more than the page size so HasMore can tell whether another
+// page follows.
+func (s *Statement) Select(columns string) (string, []interface{}) {
+	where := s.where
+	args := append([]interface{}{}, s.args...)
+	if s.keyset != "" {
+		where = append(append([]string{}, where...), s.keyset)
+		args = append(args, s.key)
+	}
+	args = append(args, s.limit, s.offset)
+
+	query := "SELECT " + columns + " FROM " + s.table.Name + whereClause(where) +
+		" ORDER BY " + s.orderBy +
+		fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	return query, args
+}
+
+// Count returns the query for the number of rows matching the filters,
+// regardless of the page or cursor
+func (s *Statement) Count() (string, []interface{}) {
+	return "SELECT COUNT(*) FROM " + s.table.Name + whereClause(s.where), s.args
+}
+
+// HasMore reports whether a Select returning n rows found more rows than fit
+// on the page
+func (s *Statement) HasMore(n int) bool {
+	return n >= s.limit
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// condition renders a filter, binding its values as arguments
+func (s *Statement) condition(column string, f Filter) string {
+	if f.Op == OpIn {
+		placeholders := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			s.args = append(s.args, v)
+			placeholders[i] = fmt.Sprintf("$%d", len(s.args))
+		}
+		return column + " IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	s.args = append(s.args, f.Values[0])
+	return fmt.Sprintf("%s %s $%d", column, operatorSQL[f.Op], len(s.args))
+}
+
+// orderTerm is a resolved sort column
+type orderTerm struct {
+	column    string
+	direction Direction
+}
+
+// order resolves the requested sorts to columns, ending with the key so the
+// order is total
+func (t Table) order(sorts []Sort) ([]orderTerm, error) {
+	order := make([]orderTerm, 0, len(sorts)+1)
+	direction := Asc
+	hasKey := false
+
+	for _, s := range sorts {
+		col, ok := t.Columns[s.Field]
+		if !ok || !col.Sortable {
+			return nil, fmt.Errorf("%w: cannot sort by %s", ErrInvalidQuery, s.Field)
+		}
+		order = append(order, orderTerm{column: col.Name, direction: s.Direction})
+		direction = s.Direction
+		hasKey = hasKey || col.Name == t.Key
+	}
+
+	if !hasKey {
+		order = append(order, orderTerm{column: t.Key, direction: direction})
+	}
+	return order, nil
+}
+
+// keyset renders the condition selecting rows after the cursor row, whose key
+// is bound to placeholder n. The cursor row's sort values are read with
+// subselects, so the cursor only carries its key:
+//
+//	a > (cursor a) OR (a = (cursor a) AND key > cursor key)
+func (t Table) keyset(order []orderTerm, n int) string {
+	value := func(column string) string {
+		if column == t.Key {
+			return fmt.Sprintf("$%d", n)
+		}
+		return fmt.Sprintf("(SELECT %s FROM %s WHERE %s = $%d)", column, t.Name, t.Key, n)
+	}
+
+	alternatives := make([]string, len(order))
+	for i, term := range order {
+		parts := make([]string, 0, i+1)
+		for _, prev := range order[:i] {
+			parts = append(parts, prev.column+" = "+value(prev.column))
+		}
+
+		op := ">"
+		if term.direction == Desc {
+			op = "<"
+		}
+		parts = append(parts, term.column+" "+op+" "+value(term.column))
+		alternatives[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/IBM/sarama"
 	"go.uber.org/zap"
+
+	"github.com/csic-platform/shared/correlation"
 )
 
 // Config holds Kafka configuration
@@ -54,8 +56,8 @@ type Producer struct {
 func NewProducer(cfg Config, logger *zap.Logger) (*Producer, error) {
 	config := sarama.NewConfig()
 	config.ClientID = cfg.ClientID
-	config.Retry.Max = cfg.RetryMax
-	config.Retry.Backoff = cfg.RetryBackoff
+	config.Producer.Retry.Max = cfg.RetryMax
+	config.Producer.Retry.Backoff = cfg.RetryBackoff
 	config.Producer.RequiredAcks = cfg.RequiredAcks
 	config.Producer.Flush.Frequency = cfg.ProducerFlush
 	config.Producer.Return.Successes = true
@@ -93,6 +95,9 @@ func (p *Producer) Send(ctx context.Context, topic string, key string, value int
 		Key:   sarama.StringEncoder(key),
 		Value: sarama.ByteEncoder(data),
 	}
+	if id := correlation.FromContext(ctx); id != "" {
+		msg.Headers = []sarama.RecordHeader{{Key: []byte(correlation.KafkaHeader), Value: []byte(id)}}
+	}
 
 	partition, offset, err := p.producer.SendMessage(msg)
 	if err != nil {
@@ -126,6 +131,7 @@ func (p *Producer) SendWithHeaders(ctx context.Context, topic string, key string
 		return fmt.Errorf("failed to marshal message value: %w", err)
 	}
 
+	headers = correlation.Headers(ctx, headers)
 	saramaHeaders := make([]sarama.RecordHeader, 0, len(headers))
 	for k, v := range headers {
 		saramaHeaders = append(saramaHeaders, sarama.RecordHeader{
@@ -322,7 +328,9 @@ func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 				continue
 			}
 
-			if err := handler(h.ctx, kafkaMsg); err != nil {
+			// Handlers run under the correlation ID of the request that
+			// produced the message
+			if err := handler(correlation.FromHeaders(h.ctx, headers), kafkaMsg); err != nil {
 				h.consumer.logger.Error("failed to process message",
 					zap.String("topic", msg.Topic),
 					zap.Error(err))
@@ -399,6 +407,3 @@ func (tm *TopicManager) ListTopics() ([]string, error) {
 func (tm *TopicManager) Close() error {
 	return tm.admin.Close()
 }
-
-// Import required packages
-import "sync"
@@ -0,0 +1,107 @@
+// Package ratelimit provides a Redis-backed token bucket rate limiter shared by
+// CSIC services, so limits hold across every instance of a service.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills the bucket for the time elapsed since it was last
+// used and takes a token if one is available. Redis' clock is used so that all
+// instances agree on the time. It returns {allowed, remaining, retry_after_ms}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) + tonumber(clock[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(state[1])
+local updatedAt = tonumber(state[2])
+if tokens == nil or updatedAt == nil then
+	tokens = burst
+	updatedAt = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - updatedAt) * rate)
+
+local allowed = 0
+local retryAfter = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retryAfter = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, math.floor(tokens), retryAfter}
+`)
+
+// Rule is a token bucket: requests are sustained at Rate per second with bursts
+// of up to Burst requests
+type Rule struct {
+	Rate  float64
+	Burst int
+}
+
+// Validate checks that the rule describes a usable bucket
+func (r Rule) Validate() error {
+	if r.Rate <= 0 || math.IsInf(r.Rate, 0) || math.IsNaN(r.Rate) {
+		return fmt.Errorf("rate must be positive, got %v", r.Rate)
+	}
+	if r.Burst < 1 {
+		return fmt.Errorf("burst must be at least 1, got %d", r.Burst)
+	}
+	return nil
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Limiter takes tokens from buckets stored in Redis
+type Limiter struct {
+	client    redis.Cmdable
+	keyPrefix string
+}
+
+// NewLimiter creates a limiter whose bucket keys start with keyPrefix
+func NewLimiter(client redis.Cmdable, keyPrefix string) *Limiter {
+	if keyPrefix == "" {
+		keyPrefix = "ratelimit"
+	}
+	return &Limiter{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+// Allow takes a token from the bucket identified by key
+func (l *Limiter) Allow(ctx context.Context, key string, rule Rule) (*Result, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	values, err := tokenBucketScript.Run(ctx, l.client, []string{l.keyPrefix + ":" + key}, rule.Rate, rule.Burst).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(values) != 3 {
+		return nil, fmt.Errorf("unexpected rate limit result: %v", values)
+	}
+
+	return &Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,160 @@
+package resilience
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// BreakerState represents the state of a circuit breaker
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "CLOSED"
+	BreakerOpen     BreakerState = "OPEN"
+	BreakerHalfOpen BreakerState = "HALF_OPEN"
+)
+
+// CircuitBreaker stops traffic to a target after consecutive failures. Once the
+// open timeout has passed a single probe request is let through; its outcome
+// closes the circuit or opens it again.
+type CircuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = 5
+	}
+
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		state:            BreakerClosed,
+	}
+}
+
+// Allow reports whether a request may be sent through the breaker
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success records a successful request and closes the circuit
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure records a failed request, opening the circuit once the threshold of
+// consecutive failures is reached or when the half-open probe fails
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Release gives up a request without recording an outcome, such as when the
+// client went away, so that a half-open breaker can probe again
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// State returns the current state of the breaker
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Breakers holds one circuit breaker per upstream, created on first use
+type Breakers struct {
+	failureThreshold int
+	openTimeout      time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewBreakers creates an empty set of breakers sharing the given settings
+func NewBreakers(failureThreshold int, openTimeout time.Duration) *Breakers {
+	return &Breakers{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		breakers:         make(map[string]*CircuitBreaker),
+	}
+}
+
+// Get returns the breaker for upstream
+func (b *Breakers) Get(upstream string) *CircuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	breaker, ok := b.breakers[upstream]
+	if !ok {
+		breaker = NewCircuitBreaker(b.failureThreshold, b.openTimeout)
+		b.breakers[upstream] = breaker
+	}
+	return breaker
+}
+
+// UpstreamState is the circuit state of one upstream
+type UpstreamState struct {
+	Upstream string       `json:"upstream"`
+	State    BreakerState `json:"state"`
+}
+
+// States returns the circuit state of every upstream called so far, by name
+func (b *Breakers) States() []UpstreamState {
+	b.mu.Lock()
+	states := make([]UpstreamState, 0, len(b.breakers))
+	for upstream, breaker := range b.breakers {
+		states = append(states, UpstreamState{Upstream: upstream, State: breaker.State()})
+	}
+	b.mu.Unlock()
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Upstream < states[j].Upstream
+	})
+	return states
+}
@@ -0,0 +1,29 @@
+package resilience
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor runs unary calls on a connection to upstream through
+// the client. Unavailable, resource exhausted, aborted and server-side
+// internal or unknown errors are failures and retried; any other status is the
+// upstream's answer and returned as is.
+func UnaryClientInterceptor(c *Client, upstream string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return c.Do(ctx, upstream, func(ctx context.Context) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			switch status.Code(err) {
+			case codes.OK:
+				return nil
+			case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown, codes.DeadlineExceeded:
+				return err
+			default:
+				return Permanent(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// StatusError reports a response whose status means the upstream failed.
+// Callers reading a response that a Transport gave up retrying can wrap one
+// so that IsUnavailable recognises it.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("upstream returned status %d", e.StatusCode)
+}
+
+// Transport sends requests to one upstream through a Client. Responses with
+// a 5xx or 429 status and transport errors are failures; idempotent requests
+// are retried, other requests are sent once. When every attempt ends in an
+// error status, the last response is returned as usual so callers can read it.
+type Transport struct {
+	Client         *Client
+	Upstream       string
+	AttemptTimeout time.Duration     // the client's attempt timeout when zero
+	Base           http.RoundTripper // http.DefaultTransport when nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	attempts := 1
+	if isIdempotent(req) && (req.Body == nil || req.GetBody != nil) {
+		attempts = t.Client.opts.MaxAttempts
+	}
+
+	var last *http.Response
+	err := t.Client.do(req.Context(), t.Upstream, attempts, func(ctx context.Context) error {
+		if last != nil {
+			drain(last.Body)
+			last = nil
+		}
+
+		attemptReq, cancel, err := t.prepare(ctx, req)
+		if err != nil {
+			return Permanent(err)
+		}
+
+		resp, err := base.RoundTrip(attemptReq)
+		if err != nil {
+			cancel()
+			return err
+		}
+		resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			last = resp
+			return &StatusError{StatusCode: resp.StatusCode}
+		}
+		last = resp
+		return nil
+	})
+
+	if err != nil && (last == nil || !IsUnavailable(err)) {
+		if last != nil {
+			drain(last.Body)
+		}
+		return nil, err
+	}
+	return last, nil
+}
+
+// prepare clones req for one attempt, with a fresh body and the attempt
+// timeout. The returned cancel function must be called once the response
+// body is done with.
+func (t *Transport) prepare(ctx context.Context, req *http.Request) (*http.Request, context.CancelFunc, error) {
+	cancel := context.CancelFunc(func() {})
+	timeout := t.AttemptTimeout
+	if timeout <= 0 {
+		timeout = t.Client.opts.AttemptTimeout
+	}
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		attemptReq.Body = body
+	}
+	return attemptReq, cancel, nil
+}
+
+// isIdempotent reports whether req may safely be sent more than once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// drain discards the rest of a response body so its connection can be reused
+func drain(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, 64<<10))
+	body.Close()
+}
+
+// cancelBody releases an attempt's context when the response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
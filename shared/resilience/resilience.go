@@ -0,0 +1,193 @@
+// Package resilience protects calls to other services with per-attempt
+// timeouts, retries with exponential backoff and jitter under a shared retry
+// budget, and a circuit breaker per upstream. Callers that can answer from
+// older data check IsUnavailable and fall back, for example to a StaleCache.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the upstream while its circuit is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// UnavailableError reports that an upstream could not serve a call: its
+// circuit was open, or every attempt failed
+type UnavailableError struct {
+	Upstream string
+	Attempts int
+	Err      error
+}
+
+func (e *UnavailableError) Error() string {
+	if errors.Is(e.Err, ErrCircuitOpen) {
+		return fmt.Sprintf("upstream %s unavailable: %v", e.Upstream, e.Err)
+	}
+	return fmt.Sprintf("upstream %s unavailable after %d attempts: %v", e.Upstream, e.Attempts, e.Err)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// IsUnavailable reports whether err means the upstream could not serve the
+// call, as opposed to answering it with an error: the call failed under the
+// client's policy, or a Transport returned a failing status
+func IsUnavailable(err error) bool {
+	var unavailable *UnavailableError
+	var status *StatusError
+	return errors.As(err, &unavailable) || errors.As(err, &status)
+}
+
+// permanentError marks a failure the upstream reported deliberately
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as an answer from the upstream, such as a rejected
+// request, which is neither retried nor counted against its circuit
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Options controls retries and circuit breaking for calls through a Client
+type Options struct {
+	MaxAttempts    int           // including the first; 1 disables retries
+	AttemptTimeout time.Duration // per attempt; zero leaves only the caller's deadline
+	Backoff        Backoff
+	// RetryRatio is the share of requests that may be retried on top of
+	// MinRetriesPerSecond, across all upstreams of the client
+	RetryRatio          float64
+	MinRetriesPerSecond int
+	FailureThreshold    int           // consecutive failures that open a circuit
+	OpenTimeout         time.Duration // how long a circuit stays open before a probe
+}
+
+// DefaultOptions returns the options used for unset fields
+func DefaultOptions() Options {
+	return Options{
+		MaxAttempts:         3,
+		AttemptTimeout:      5 * time.Second,
+		Backoff:             Backoff{Base: 100 * time.Millisecond, Max: 2 * time.Second},
+		RetryRatio:          0.2,
+		MinRetriesPerSecond: 5,
+		FailureThreshold:    5,
+		OpenTimeout:         30 * time.Second,
+	}
+}
+
+// Client runs calls to upstream services under the configured policy. One
+// client is shared by all callers of a process so that they draw on the same
+// retry budget and see the same circuit state.
+type Client struct {
+	opts     Options
+	budget   *RetryBudget
+	breakers *Breakers
+}
+
+// New creates a client, taking defaults for unset options
+func New(opts Options) *Client {
+	defaults := DefaultOptions()
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaults.MaxAttempts
+	}
+	if opts.Backoff.Base <= 0 {
+		opts.Backoff.Base = defaults.Backoff.Base
+	}
+	if opts.Backoff.Max <= 0 {
+		opts.Backoff.Max = defaults.Backoff.Max
+	}
+	if opts.RetryRatio <= 0 {
+		opts.RetryRatio = defaults.RetryRatio
+	}
+	if opts.MinRetriesPerSecond <= 0 {
+		opts.MinRetriesPerSecond = defaults.MinRetriesPerSecond
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaults.OpenTimeout
+	}
+
+	return &Client{
+		opts:     opts,
+		budget:   NewRetryBudget(opts.RetryRatio, opts.MinRetriesPerSecond),
+		breakers: NewBreakers(opts.FailureThreshold, opts.OpenTimeout),
+	}
+}
+
+// Breakers returns the client's circuit breakers, for status reporting
+func (c *Client) Breakers() *Breakers {
+	return c.breakers
+}
+
+// Do calls fn for upstream until it succeeds, returns a Permanent error, or
+// the attempts, retry budget or ctx run out. Each attempt gets its own
+// timeout. Failures after which the upstream should be considered down are
+// returned as an *UnavailableError.
+func (c *Client) Do(ctx context.Context, upstream string, fn func(ctx context.Context) error) error {
+	return c.do(ctx, upstream, c.opts.MaxAttempts, func(ctx context.Context) error {
+		if c.opts.AttemptTimeout <= 0 {
+			return fn(ctx)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, c.opts.AttemptTimeout)
+		defer cancel()
+		return fn(ctx)
+	})
+}
+
+// do is Do with an explicit attempt limit, for calls that are unsafe to
+// repeat, and without the attempt timeout, for calls whose result outlives fn
+func (c *Client) do(ctx context.Context, upstream string, maxAttempts int, fn func(ctx context.Context) error) error {
+	breaker := c.breakers.Get(upstream)
+	c.budget.Deposit()
+
+	var err error
+	attempt := 0
+	for {
+		if !breaker.Allow() {
+			if err == nil {
+				err = ErrCircuitOpen
+			}
+			return &UnavailableError{Upstream: upstream, Attempts: attempt, Err: err}
+		}
+
+		attempt++
+		err = fn(ctx)
+
+		var permanent *permanentError
+		switch {
+		case err == nil:
+			breaker.Success()
+			return nil
+		case errors.As(err, &permanent):
+			breaker.Success()
+			return permanent.err
+		case ctx.Err() != nil:
+			// The caller gave up; that says nothing about the upstream
+			breaker.Release()
+			return err
+		}
+		breaker.Failure()
+
+		if attempt >= maxAttempts || !c.budget.Withdraw() {
+			return &UnavailableError{Upstream: upstream, Attempts: attempt, Err: err}
+		}
+
+		timer := time.NewTimer(c.opts.Backoff.Delay(attempt - 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package resilience
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Backoff computes exponential delays between attempts with full jitter, so
+// that clients retrying after a shared outage do not return in lockstep
+type Backoff struct {
+	Base time.Duration // ceiling of the first delay
+	Max  time.Duration // ceiling of any delay
+}
+
+// Delay returns the wait before the retry following attempt, counting from
+// zero. The delay is drawn uniformly from zero up to Base doubled attempt
+// times, capped at Max.
+func (b Backoff) Delay(attempt int) time.Duration {
+	ceiling := b.Base
+	for i := 0; i < attempt && ceiling < b.Max; i++ {
+		ceiling *= 2
+	}
+	if ceiling > b.Max {
+		ceiling = b.Max
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// RetryBudget caps retries at a share of recent requests so that retries
+// cannot multiply the load on an upstream that is already struggling. Each
+// request deposits ratio tokens and each retry spends one; a trickle of
+// minPerSecond tokens keeps retries possible when traffic is low.
+type RetryBudget struct {
+	ratio     float64
+	minPerSec float64
+	maxTokens float64
+
+	mu      sync.Mutex
+	tokens  float64
+	updated time.Time
+}
+
+// NewRetryBudget creates a full retry budget
+func NewRetryBudget(ratio float64, minPerSecond int) *RetryBudget {
+	maxTokens := float64(max(minPerSecond, 1)) * 10
+	return &RetryBudget{
+		ratio:     ratio,
+		minPerSec: float64(minPerSecond),
+		maxTokens: maxTokens,
+		tokens:    maxTokens,
+		updated:   time.Now(),
+	}
+}
+
+// Deposit records a first attempt
+func (b *RetryBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.tokens = min(b.tokens+b.ratio, b.maxTokens)
+}
+
+// Withdraw reports whether a retry may be made, spending from the budget if so
+func (b *RetryBudget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refill adds the tokens earned since the last update
+func (b *RetryBudget) refill() {
+	now := time.Now()
+	b.tokens = min(b.tokens+now.Sub(b.updated).Seconds()*b.minPerSec, b.maxTokens)
+	b.updated = now
+}
@@ -0,0 +1,58 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+// staleEntry is a cached value and when it was fetched
+type staleEntry[V any] struct {
+	value     V
+	fetchedAt time.Time
+}
+
+// StaleCache keeps the last good answer per key so that a caller can fall
+// back to it while the upstream that gave it is unavailable. Answers older
+// than maxAge are not served.
+type StaleCache[K comparable, V any] struct {
+	maxAge     time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[K]staleEntry[V]
+}
+
+// NewStaleCache creates a cache of at most maxEntries answers; the cache is
+// emptied when full
+func NewStaleCache[K comparable, V any](maxAge time.Duration, maxEntries int) *StaleCache[K, V] {
+	return &StaleCache[K, V]{
+		maxAge:     maxAge,
+		maxEntries: maxEntries,
+		entries:    make(map[K]staleEntry[V]),
+	}
+}
+
+// Put records a fresh answer for key
+func (c *StaleCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.entries = make(map[K]staleEntry[V])
+	}
+	c.entries[key] = staleEntry[V]{value: value, fetchedAt: time.Now()}
+}
+
+// Get returns the last answer for key and when it was fetched, if it is
+// still young enough to serve
+func (c *StaleCache[K, V]) Get(key K) (V, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetchedAt) > c.maxAge {
+		var zero V
+		return zero, time.Time{}, false
+	}
+	return entry.value, entry.fetchedAt, true
+}
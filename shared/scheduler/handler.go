@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/csic-platform/shared/problem"
+	"github.com/gin-gonic/gin"
+)
+
+// Routes registers the runtime API on a route group: listing tasks, pausing
+// and resuming them, and triggering a run outside the schedule. The caller
+// is responsible for restricting the group to operators.
+func (s *Scheduler) Routes(group *gin.RouterGroup) {
+	group.GET("", s.listTasks)
+	group.GET("/:name", s.getTask)
+	group.POST("/:name/pause", s.control(s.Pause))
+	group.POST("/:name/resume", s.control(s.Resume))
+	group.POST("/:name/trigger", s.control(s.Trigger))
+}
+
+func (s *Scheduler) listTasks(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"tasks": s.Tasks()})
+}
+
+func (s *Scheduler) getTask(c *gin.Context) {
+	status, err := s.Task(c.Param("name"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// control applies an action to the named task and responds with its status
+func (s *Scheduler) control(action func(name string) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if err := action(name); err != nil {
+			writeError(c, err)
+			return
+		}
+		s.getTask(c)
+	}
+}
+
+func writeError(c *gin.Context, err error) {
+	code := problem.Internal
+	switch {
+	case errors.Is(err, ErrTaskNotFound):
+		code = problem.NotFound
+	case errors.Is(err, ErrTaskRunning):
+		code = problem.Conflict
+	case errors.Is(err, ErrNotStarted):
+		code = problem.Unavailable
+	}
+	problem.Write(c, problem.Wrap(err, code, err.Error()))
+}
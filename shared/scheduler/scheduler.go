@@ -0,0 +1,380 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Errors returned by the scheduler
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	ErrTaskExists   = errors.New("task already registered")
+	ErrTaskRunning  = errors.New("task is already running")
+	ErrNotStarted   = errors.New("scheduler is not running")
+)
+
+// Run triggers, as recorded in metrics
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// Task is a job run on a schedule
+type Task struct {
+	Name string
+	// Schedule is a cron expression or descriptor accepted by Parse,
+	// evaluated in UTC
+	Schedule string
+	// Jitter delays each scheduled run by a random duration up to this long,
+	// so instances sharing a schedule do not all run at once
+	Jitter time.Duration
+	// Timeout bounds each run; zero means no timeout
+	Timeout time.Duration
+	// RunOnStart runs the task once when the scheduler starts
+	RunOnStart bool
+	// Paused tasks are not run on schedule until resumed
+	Paused bool
+	Run    func(ctx context.Context) error
+}
+
+// TaskStatus describes a task and its latest run
+type TaskStatus struct {
+	Name         string     `json:"name"`
+	Schedule     string     `json:"schedule"`
+	Jitter       string     `json:"jitter,omitempty"`
+	Paused       bool       `json:"paused"`
+	Running      bool       `json:"running"`
+	NextRun      *time.Time `json:"next_run,omitempty"`
+	LastRun      *time.Time `json:"last_run,omitempty"`
+	LastDuration string     `json:"last_duration,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	Runs         int64      `json:"runs"`
+	Failures     int64      `json:"failures"`
+	Skipped      int64      `json:"skipped"`
+}
+
+// task is a registered task and its state, guarded by the scheduler's mutex
+type task struct {
+	Task
+	schedule Schedule
+	wake     chan struct{}
+
+	running      bool
+	nextRun      time.Time
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastError    string
+	runs         int64
+	failures     int64
+	skipped      int64
+}
+
+// Scheduler runs tasks on cron schedules. Each task runs at most once at a
+// time: a run due while the previous one is still going is skipped.
+type Scheduler struct {
+	onError func(task string, err error)
+
+	runs     *prometheus.CounterVec
+	skipped  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	running  *prometheus.GaugeVec
+	lastOK   *prometheus.GaugeVec
+
+	mu    sync.Mutex
+	tasks map[string]*task
+	ctx   context.Context
+	wg    sync.WaitGroup
+}
+
+// New creates a scheduler for a service and registers its metrics. onError is
+// called with every failed run.
+func New(service string, registerer prometheus.Registerer, onError func(task string, err error)) *Scheduler {
+	factory := promauto.With(registerer)
+	labels := prometheus.Labels{"service": service}
+
+	return &Scheduler{
+		onError: onError,
+		tasks:   make(map[string]*task),
+		runs: factory.NewCounterVec(prometheus.CounterOpts{
+			Name:        "scheduler_task_runs_total",
+			Help:        "Total number of scheduled task runs by trigger and result",
+			ConstLabels: labels,
+		}, []string{"task", "trigger", "result"}),
+		skipped: factory.NewCounterVec(prometheus.CounterOpts{
+			Name:        "scheduler_task_skipped_total",
+			Help:        "Total number of task runs skipped because the previous run was still going",
+			ConstLabels: labels,
+		}, []string{"task"}),
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "scheduler_task_duration_seconds",
+			Help:        "Duration of scheduled task runs",
+			ConstLabels: labels,
+			Buckets:     []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 900, 3600},
+		}, []string{"task"}),
+		running: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "scheduler_task_running",
+			Help:        "Whether a task is currently running",
+			ConstLabels: labels,
+		}, []string{"task"}),
+		lastOK: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "scheduler_task_last_success_timestamp_seconds",
+			Help:        "Unix time of the task's last successful run",
+			ConstLabels: labels,
+		}, []string{"task"}),
+	}
+}
+
+// Add registers a task. Tasks added after Start are started immediately.
+func (s *Scheduler) Add(t Task) error {
+	schedule, err := Parse(t.Schedule)
+	if err != nil {
+		return fmt.Errorf("task %s: %w", t.Name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tasks[t.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrTaskExists, t.Name)
+	}
+	registered := &task{Task: t, schedule: schedule, wake: make(chan struct{}, 1)}
+	s.tasks[t.Name] = registered
+	s.running.WithLabelValues(t.Name).Set(0)
+
+	if s.ctx != nil {
+		s.startTask(s.ctx, registered)
+	}
+	return nil
+}
+
+// Start runs every task on its schedule until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ctx = ctx
+	for _, t := range s.tasks {
+		s.startTask(ctx, t)
+	}
+}
+
+// Wait blocks until the scheduler has stopped and running tasks have returned
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// startTask starts a task's loop; the caller holds s.mu
+func (s *Scheduler) startTask(ctx context.Context, t *task) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.loop(ctx, t)
+	}()
+}
+
+// loop waits for each of a task's due times and runs it
+func (s *Scheduler) loop(ctx context.Context, t *task) {
+	if t.RunOnStart {
+		_ = s.run(ctx, t, TriggerSchedule)
+	}
+
+	for {
+		now := time.Now().UTC()
+		next := t.schedule.Next(now)
+		if next.IsZero() {
+			return
+		}
+		if t.Jitter > 0 {
+			next = next.Add(time.Duration(rand.Int63n(int64(t.Jitter))))
+		}
+
+		s.mu.Lock()
+		t.nextRun = next
+		s.mu.Unlock()
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-t.wake:
+			// Rescheduled, for example on resume
+			timer.Stop()
+			continue
+		case <-timer.C:
+		}
+
+		s.mu.Lock()
+		paused := t.Paused
+		s.mu.Unlock()
+		if !paused {
+			_ = s.run(ctx, t, TriggerSchedule)
+		}
+	}
+}
+
+// run starts a run of the task in the background unless one is already going
+func (s *Scheduler) run(ctx context.Context, t *task, trigger string) error {
+	s.mu.Lock()
+	if t.running {
+		t.skipped++
+		s.mu.Unlock()
+		s.skipped.WithLabelValues(t.Name).Inc()
+		return fmt.Errorf("%w: %s", ErrTaskRunning, t.Name)
+	}
+	t.running = true
+	s.mu.Unlock()
+
+	s.running.WithLabelValues(t.Name).Set(1)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		runCtx := ctx
+		if t.Timeout > 0 {
+			var cancel context.CancelFunc
+			runCtx, cancel = context.WithTimeout(ctx, t.Timeout)
+			defer cancel()
+		}
+
+		start := time.Now()
+		err := t.Run(runCtx)
+		elapsed := time.Since(start)
+
+		result := "success"
+		if err != nil {
+			result = "failure"
+		}
+		s.runs.WithLabelValues(t.Name, trigger, result).Inc()
+		s.duration.WithLabelValues(t.Name).Observe(elapsed.Seconds())
+		s.running.WithLabelValues(t.Name).Set(0)
+		if err == nil {
+			s.lastOK.WithLabelValues(t.Name).SetToCurrentTime()
+		}
+
+		s.mu.Lock()
+		t.running = false
+		t.lastRun = start.UTC()
+		t.lastDuration = elapsed
+		t.runs++
+		t.lastError = ""
+		if err != nil {
+			t.failures++
+			t.lastError = err.Error()
+		}
+		s.mu.Unlock()
+
+		if err != nil && s.onError != nil && ctx.Err() == nil {
+			s.onError(t.Name, err)
+		}
+	}()
+	return nil
+}
+
+// Trigger runs a task now, outside its schedule. It fails with ErrTaskRunning
+// if the task is already running.
+func (s *Scheduler) Trigger(name string) error {
+	s.mu.Lock()
+	t, ok := s.tasks[name]
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, name)
+	}
+	if ctx == nil || ctx.Err() != nil {
+		return ErrNotStarted
+	}
+	return s.run(ctx, t, TriggerManual)
+}
+
+// Pause stops a task from running on schedule. A run already going finishes,
+// and the task can still be triggered.
+func (s *Scheduler) Pause(name string) error {
+	return s.setPaused(name, true)
+}
+
+// Resume puts a paused task back on its schedule
+func (s *Scheduler) Resume(name string) error {
+	return s.setPaused(name, false)
+}
+
+func (s *Scheduler) setPaused(name string, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, name)
+	}
+	t.Paused = paused
+
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Tasks returns the status of every task ordered by name
+func (s *Scheduler) Tasks() []TaskStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]TaskStatus, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		statuses = append(statuses, t.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// Task returns the status of a task
+func (s *Scheduler) Task(name string) (*TaskStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, name)
+	}
+	status := t.status()
+	return &status, nil
+}
+
+// status describes the task; the caller holds the scheduler's mutex
+func (t *task) status() TaskStatus {
+	status := TaskStatus{
+		Name:      t.Name,
+		Schedule:  t.Schedule,
+		Paused:    t.Paused,
+		Running:   t.running,
+		LastError: t.lastError,
+		Runs:      t.runs,
+		Failures:  t.failures,
+		Skipped:   t.skipped,
+	}
+	if t.Jitter > 0 {
+		status.Jitter = t.Jitter.String()
+	}
+	if !t.nextRun.IsZero() && !t.Paused {
+		next := t.nextRun
+		status.NextRun = &next
+	}
+	if !t.lastRun.IsZero() {
+		last := t.lastRun
+		status.LastRun = &last
+		status.LastDuration = t.lastDuration.String()
+	}
+	return status
+}
@@ -0,0 +1,85 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// LoadAWSConfig loads AWS credentials from the default chain (environment,
+// shared config, instance or task role) for the given region
+func LoadAWSConfig(ctx context.Context, region string) (aws.Config, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return cfg, nil
+}
+
+// SSMProvider fetches secrets from AWS Systems Manager Parameter Store.
+// ssm:///csic/prod/db-password reads the parameter /csic/prod/db-password,
+// decrypting SecureString parameters.
+type SSMProvider struct {
+	client *ssm.Client
+}
+
+// NewSSMProvider creates a Parameter Store provider
+func NewSSMProvider(cfg aws.Config) *SSMProvider {
+	return &SSMProvider{client: ssm.NewFromConfig(cfg)}
+}
+
+// Fetch reads a parameter
+func (p *SSMProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(path),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		var notFound *ssmtypes.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return nil, fmt.Errorf("failed to get parameter: %w", err)
+	}
+
+	return &Secret{Value: aws.ToString(out.Parameter.Value)}, nil
+}
+
+// KMSProvider decrypts secrets encrypted with AWS KMS. kms://<ciphertext>
+// decrypts the base64 encoded ciphertext blob, so the encrypted value can be
+// kept in configuration.
+type KMSProvider struct {
+	client *kms.Client
+}
+
+// NewKMSProvider creates a KMS provider
+func NewKMSProvider(cfg aws.Config) *KMSProvider {
+	return &KMSProvider{client: kms.NewFromConfig(cfg)}
+}
+
+// Fetch decrypts a base64 encoded ciphertext blob
+func (p *KMSProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KMS ciphertext: %w", err)
+	}
+
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: ciphertext})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return &Secret{Value: string(out.Plaintext)}, nil
+}
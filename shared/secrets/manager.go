@@ -0,0 +1,243 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// renewBefore is the share of a lease left when the manager renews it
+const renewBefore = 3
+
+// cachedSecret is a fetched secret and when it must be fetched or renewed again
+type cachedSecret struct {
+	secret    *Secret
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// Manager resolves secret references through the registered providers. Secrets
+// are fetched lazily on first use and cached; leased secrets are renewed in the
+// background before they expire.
+type Manager struct {
+	providers map[string]Provider
+	cacheTTL  time.Duration
+	onError   func(ref string, err error)
+
+	mu    sync.Mutex
+	cache map[string]*cachedSecret
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager creates a secrets manager. Secrets without a lease are cached for
+// cacheTTL; a non-positive cacheTTL caches them until the manager is closed.
+// onError, which may be nil, is told about failed background renewals.
+func NewManager(cacheTTL time.Duration, onError func(ref string, err error)) *Manager {
+	if onError == nil {
+		onError = func(string, error) {}
+	}
+	return &Manager{
+		providers: make(map[string]Provider),
+		cacheTTL:  cacheTTL,
+		onError:   onError,
+		cache:     make(map[string]*cachedSecret),
+	}
+}
+
+// Register makes a provider available for references with the given prefix
+func (m *Manager) Register(name string, provider Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.providers[name] = provider
+}
+
+// Resolve returns the secret a value refers to, or the value itself if it is
+// not a secret reference
+func (m *Manager) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+
+	secret, err := m.Get(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return secret.Value, nil
+}
+
+// ResolveAll resolves every referenced value in place
+func (m *Manager) ResolveAll(ctx context.Context, values ...*string) error {
+	for _, value := range values {
+		resolved, err := m.Resolve(ctx, *value)
+		if err != nil {
+			return err
+		}
+		*value = resolved
+	}
+	return nil
+}
+
+// Get returns the secret for a reference, fetching it if it is not cached or
+// its cache entry has expired
+func (m *Manager) Get(ctx context.Context, ref string) (*Secret, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	cached, ok := m.cache[ref]
+	m.mu.Unlock()
+	if ok && (cached.expiresAt.IsZero() || now.Before(cached.expiresAt)) {
+		return cached.secret, nil
+	}
+
+	secret, err := m.fetch(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	m.store(ref, secret, now)
+	return secret, nil
+}
+
+// Start renews leased secrets and evicts expired cache entries every interval
+// until Close is called
+func (m *Manager) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.refresh(ctx, interval)
+			}
+		}
+	}()
+}
+
+// Close stops background renewal
+func (m *Manager) Close() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+}
+
+// refresh renews leases that would expire before the next refresh plus a
+// safety margin, and evicts unleased entries whose TTL has passed so they are
+// fetched again on next use
+func (m *Manager) refresh(ctx context.Context, interval time.Duration) {
+	now := time.Now()
+
+	m.mu.Lock()
+	due := make(map[string]*cachedSecret)
+	for ref, cached := range m.cache {
+		if cached.expiresAt.IsZero() {
+			continue
+		}
+		if cached.secret.LeaseID == "" {
+			if !now.Before(cached.expiresAt) {
+				delete(m.cache, ref)
+			}
+			continue
+		}
+		lease := cached.expiresAt.Sub(cached.fetchedAt)
+		if cached.expiresAt.Sub(now) <= lease/renewBefore+interval {
+			due[ref] = cached
+		}
+	}
+	m.mu.Unlock()
+
+	for ref, cached := range due {
+		if err := m.renew(ctx, ref, cached.secret, now); err != nil {
+			m.onError(ref, err)
+		}
+	}
+}
+
+// renew extends a leased secret, fetching it again if the lease cannot be
+// renewed
+func (m *Manager) renew(ctx context.Context, ref string, secret *Secret, now time.Time) error {
+	provider, _, err := m.provider(ref)
+	if err != nil {
+		return err
+	}
+
+	if renewer, ok := provider.(Renewer); ok && secret.Renewable {
+		renewed, err := renewer.Renew(ctx, secret)
+		if err == nil {
+			m.store(ref, renewed, now)
+			return nil
+		}
+		m.onError(ref, fmt.Errorf("failed to renew lease, fetching again: %w", err))
+	}
+
+	fetched, err := m.fetch(ctx, ref)
+	if err != nil {
+		return err
+	}
+	m.store(ref, fetched, now)
+	return nil
+}
+
+func (m *Manager) fetch(ctx context.Context, ref string) (*Secret, error) {
+	provider, path, err := m.provider(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := provider.Fetch(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secret %s: %w", redact(ref), err)
+	}
+	return secret, nil
+}
+
+func (m *Manager) provider(ref string) (Provider, string, error) {
+	name, path, ok := ParseReference(ref)
+	if !ok {
+		return nil, "", fmt.Errorf("not a secret reference: %s", redact(ref))
+	}
+
+	m.mu.Lock()
+	provider, ok := m.providers[name]
+	m.mu.Unlock()
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrProviderNotConfigured, name)
+	}
+	return provider, path, nil
+}
+
+func (m *Manager) store(ref string, secret *Secret, now time.Time) {
+	cached := &cachedSecret{secret: secret, fetchedAt: now}
+	switch {
+	case secret.LeaseDuration > 0:
+		cached.expiresAt = now.Add(secret.LeaseDuration)
+	case m.cacheTTL > 0:
+		cached.expiresAt = now.Add(m.cacheTTL)
+	}
+
+	m.mu.Lock()
+	m.cache[ref] = cached
+	m.mu.Unlock()
+}
+
+// redact shortens a reference for error messages. KMS references carry the
+// ciphertext itself, which is kept out of logs.
+func redact(ref string) string {
+	if name, _, ok := ParseReference(ref); ok && name == ProviderKMS {
+		return ProviderKMS + "://..."
+	}
+	return ref
+}
@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// PlaintextProvider serves secrets from configuration or the environment. It is
+// meant for development, where no secrets backend is available, and is
+// rejected by configuration validation in production.
+//
+// plaintext://db-password resolves to values["db-password"] or, if that is
+// not set, to the environment variable named db-password.
+type PlaintextProvider struct {
+	values map[string]string
+}
+
+// NewPlaintextProvider creates a plaintext provider serving values
+func NewPlaintextProvider(values map[string]string) *PlaintextProvider {
+	return &PlaintextProvider{values: values}
+}
+
+// Fetch returns the named value, falling back to the environment variable of
+// the same name
+func (p *PlaintextProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	if value, ok := p.values[path]; ok {
+		return &Secret{Value: value}, nil
+	}
+	if value, ok := os.LookupEnv(path); ok {
+		return &Secret{Value: value}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+}
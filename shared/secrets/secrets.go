@@ -0,0 +1,84 @@
+// Package secrets resolves secret references in configuration against a secrets
+// backend, so passwords and keys need not be stored in YAML or the environment.
+//
+// A reference is a configuration value of the form <provider>://<path>, for
+// example vault://database/creds/audit-log#password,
+// ssm:///csic/prod/jwt-secret or kms://AQICAHh...; values without a known
+// provider prefix are used as they are.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Provider names used as reference prefixes
+const (
+	ProviderVault     = "vault"
+	ProviderSSM       = "ssm"
+	ProviderKMS       = "kms"
+	ProviderPlaintext = "plaintext"
+)
+
+var (
+	// ErrNotFound is returned when a referenced secret does not exist
+	ErrNotFound = errors.New("secret not found")
+	// ErrProviderNotConfigured is returned for a reference to a provider that
+	// has not been registered
+	ErrProviderNotConfigured = errors.New("secrets provider not configured")
+)
+
+// Secret is a secret value fetched from a provider. Secrets with a lease, such
+// as Vault dynamic database credentials, expire unless renewed.
+type Secret struct {
+	Value         string
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+}
+
+// Provider fetches secrets from a secrets backend
+type Provider interface {
+	// Fetch returns the secret at path; the path syntax is provider specific
+	Fetch(ctx context.Context, path string) (*Secret, error)
+}
+
+// Renewer is implemented by providers whose secrets carry renewable leases
+type Renewer interface {
+	// Renew extends the lease of a secret, returning it with the new lease
+	Renew(ctx context.Context, secret *Secret) (*Secret, error)
+}
+
+// knownProviders are the prefixes treated as secret references. Values with any
+// other scheme, such as postgres:// connection strings, are not references.
+var knownProviders = map[string]bool{
+	ProviderVault:     true,
+	ProviderSSM:       true,
+	ProviderKMS:       true,
+	ProviderPlaintext: true,
+}
+
+// ParseReference splits a secret reference into its provider and path. It
+// reports false for values that are not references.
+func ParseReference(value string) (provider, path string, ok bool) {
+	provider, path, ok = strings.Cut(value, "://")
+	if !ok || !knownProviders[provider] {
+		return "", "", false
+	}
+	return provider, path, true
+}
+
+// IsReference reports whether a configuration value is a secret reference
+func IsReference(value string) bool {
+	_, _, ok := ParseReference(value)
+	return ok
+}
+
+// splitField splits a path#field reference path. field is empty if the path
+// names no field.
+func splitField(path string) (string, string) {
+	path, field, _ := strings.Cut(path, "#")
+	return path, field
+}
@@ -0,0 +1,150 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultConfig contains HashiCorp Vault connection settings
+type VaultConfig struct {
+	Address   string
+	Token     string
+	Namespace string
+	Timeout   time.Duration
+}
+
+// VaultProvider fetches secrets from HashiCorp Vault over its HTTP API.
+//
+// vault://<path>#<field> reads field from the secret at path. KV version 2
+// paths include the data segment, as in vault://secret/data/csic/jwt#secret;
+// dynamic secrets such as vault://database/creds/audit-log#password carry a
+// lease that the Manager renews. The field defaults to "value".
+type VaultProvider struct {
+	cfg    VaultConfig
+	client *http.Client
+}
+
+// NewVaultProvider creates a Vault provider
+func NewVaultProvider(cfg VaultConfig) (*VaultProvider, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("vault address is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("vault token is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	cfg.Address = strings.TrimSuffix(cfg.Address, "/")
+
+	return &VaultProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// vaultResponse is the envelope of a Vault secret read or lease renewal
+type vaultResponse struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Errors        []string               `json:"errors"`
+}
+
+// Fetch reads a field of the secret at path
+func (p *VaultProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	path, field := splitField(path)
+	if field == "" {
+		field = "value"
+	}
+
+	var resp vaultResponse
+	if err := p.do(ctx, http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	data := resp.Data
+	// KV version 2 nests the secret's fields under data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: field %s of %s", ErrNotFound, field, path)
+	}
+
+	return &Secret{
+		Value:         fmt.Sprint(value),
+		LeaseID:       resp.LeaseID,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable:     resp.Renewable,
+	}, nil
+}
+
+// Renew extends a secret's lease by its original duration
+func (p *VaultProvider) Renew(ctx context.Context, secret *Secret) (*Secret, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"lease_id":  secret.LeaseID,
+		"increment": int(secret.LeaseDuration / time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp vaultResponse
+	if err := p.do(ctx, http.MethodPut, "/v1/sys/leases/renew", body, &resp); err != nil {
+		return nil, err
+	}
+
+	renewed := *secret
+	renewed.LeaseID = resp.LeaseID
+	renewed.LeaseDuration = time.Duration(resp.LeaseDuration) * time.Second
+	renewed.Renewable = resp.Renewable
+	return &renewed, nil
+}
+
+func (p *VaultProvider) do(ctx context.Context, method, path string, body []byte, out *vaultResponse) error {
+	req, err := http.NewRequestWithContext(ctx, method, p.cfg.Address+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.cfg.Token)
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read vault response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if err := json.Unmarshal(payload, out); err != nil && resp.StatusCode < 300 {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.Join(out.Errors, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// defaultExemptPrefixes are methods callable without a token: health checks
+// and server reflection
+var defaultExemptPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// AuthInterceptor authenticates gRPC calls by the bearer access token in the
+// "authorization" metadata, rejecting tokens whose session was revoked
+type AuthInterceptor struct {
+	secret  []byte
+	checker Checker
+	exempt  []string
+}
+
+// NewAuthInterceptor creates an interceptor verifying tokens signed with the
+// secret against the checker. Calls to health, reflection and methods with
+// any of the exempt prefixes are let through unauthenticated.
+func NewAuthInterceptor(secret string, checker Checker, exempt ...string) *AuthInterceptor {
+	return &AuthInterceptor{
+		secret:  []byte(secret),
+		checker: checker,
+		exempt:  append(append([]string{}, defaultExemptPrefixes...), exempt...),
+	}
+}
+
+// Unary returns the unary server interceptor
+func (a *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the stream server interceptor
+func (a *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate verifies the call's token and returns a context carrying its claims
+func (a *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range a.exempt {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	claims, err := Verify(ctx, a.secret, a.checker, token)
+	switch {
+	case err == nil:
+		return WithClaims(ctx, claims), nil
+	case errors.Is(err, ErrExpiredToken), errors.Is(err, ErrInvalidToken), errors.Is(err, ErrSessionRevoked):
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return nil, status.Error(codes.Unavailable, "session check failed")
+	}
+}
+
+// bearerToken returns the bearer token in the incoming metadata of ctx
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// serverStream overrides the context of a server stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,156 @@
+// Package session lets services honour gateway sessions. The API gateway
+// issues short-lived access tokens that name their session in the "sid" claim
+// and keeps each live session in Redis under Key(sessionID); revoking a
+// session deletes that key. Services accepting those tokens check them with
+// Verify, which asks a Checker whether the session is still live, so a revoked
+// session stops working everywhere before its token expires.
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// KeyPrefix prefixes the Redis key of every live session
+	KeyPrefix = "session:"
+	// ClaimSessionID is the access token claim naming the session
+	ClaimSessionID = "sid"
+	// ClaimEntityID is the access token claim scoping a regulated entity's
+	// users to that entity's records
+	ClaimEntityID = "entity_id"
+	// ClaimJurisdictions is the access token claim listing the jurisdictions
+	// whose records the user may see; "*" grants all of them
+	ClaimJurisdictions = "jurisdictions"
+)
+
+var (
+	// ErrInvalidToken is returned for a malformed or wrongly signed token
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrExpiredToken is returned for a token past its expiry
+	ErrExpiredToken = errors.New("token has expired")
+	// ErrSessionRevoked is returned when the token's session was revoked or has expired
+	ErrSessionRevoked = errors.New("session has been revoked")
+)
+
+// Key returns the Redis key of a session
+func Key(sessionID string) string {
+	return KeyPrefix + sessionID
+}
+
+// Claims are the claims of a gateway access token
+type Claims struct {
+	UserID        string
+	Username      string
+	Role          string
+	Permissions   []string
+	SessionID     string
+	EntityID      string // set only for regulated entity users
+	Jurisdictions []string
+	TokenID       string
+	IssuedAt      time.Time
+	ExpiresAt     time.Time
+}
+
+// Checker reports whether a session is still live
+type Checker interface {
+	Active(ctx context.Context, sessionID string) (bool, error)
+}
+
+// CheckerFunc adapts a function to a Checker
+type CheckerFunc func(ctx context.Context, sessionID string) (bool, error)
+
+// Active calls f
+func (f CheckerFunc) Active(ctx context.Context, sessionID string) (bool, error) {
+	return f(ctx, sessionID)
+}
+
+// ParseAccessToken verifies an HMAC-signed access token and returns its claims
+func ParseAccessToken(secret []byte, token string) (*Claims, error) {
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return secret, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	mapClaims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{}
+	claims.UserID, _ = mapClaims["user_id"].(string)
+	claims.Username, _ = mapClaims["username"].(string)
+	claims.Role, _ = mapClaims["role"].(string)
+	claims.SessionID, _ = mapClaims[ClaimSessionID].(string)
+	claims.EntityID, _ = mapClaims[ClaimEntityID].(string)
+	claims.TokenID, _ = mapClaims["token_id"].(string)
+	if permissions, ok := mapClaims["permissions"].([]interface{}); ok {
+		for _, p := range permissions {
+			if s, ok := p.(string); ok {
+				claims.Permissions = append(claims.Permissions, s)
+			}
+		}
+	}
+	if jurisdictions, ok := mapClaims[ClaimJurisdictions].([]interface{}); ok {
+		for _, j := range jurisdictions {
+			if s, ok := j.(string); ok {
+				claims.Jurisdictions = append(claims.Jurisdictions, s)
+			}
+		}
+	}
+	if iat, ok := mapClaims["iat"].(float64); ok {
+		claims.IssuedAt = time.Unix(int64(iat), 0)
+	}
+	if exp, ok := mapClaims["exp"].(float64); ok {
+		claims.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	return claims, nil
+}
+
+// Verify parses an access token and checks with the checker that its session
+// is still live. With a nil checker only the signature and expiry are checked;
+// otherwise a token naming no session cannot be revoked and is rejected.
+func Verify(ctx context.Context, secret []byte, checker Checker, token string) (*Claims, error) {
+	claims, err := ParseAccessToken(secret, token)
+	if err != nil {
+		return nil, err
+	}
+	if checker == nil {
+		return claims, nil
+	}
+	if claims.SessionID == "" {
+		return nil, ErrSessionRevoked
+	}
+	active, err := checker.Active(ctx, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, ErrSessionRevoked
+	}
+	return claims, nil
+}
+
+type contextKey struct{}
+
+// WithClaims returns a copy of ctx carrying the caller's claims
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// FromContext returns the caller's claims carried by ctx, or nil
+func FromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(contextKey{}).(*Claims)
+	return claims
+}
@@ -0,0 +1,22 @@
+package startup
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadyHandler serves the readiness report. It responds 200 while the service
+// is ready or degraded and 503 while a required dependency is unavailable, so
+// load balancers keep sending traffic to a service running without Kafka.
+func (o *Orchestrator) ReadyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := o.Readiness(c.Request.Context())
+
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	}
+}
@@ -0,0 +1,297 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/csic-platform/shared/logger"
+	"go.uber.org/zap"
+)
+
+// Dependency readiness states
+const (
+	StateConnecting  = "connecting"
+	StateReady       = "ready"
+	StateUnavailable = "unavailable"
+)
+
+// Overall readiness states
+const (
+	StatusReady    = "ready"
+	StatusDegraded = "degraded"
+	StatusNotReady = "not_ready"
+)
+
+// Dependency is an external system a service needs, such as a database or
+// message broker
+type Dependency struct {
+	Name string
+	// Optional dependencies do not hold up startup: if the first attempt
+	// fails the service starts degraded and Connect keeps being retried in
+	// the background until it succeeds
+	Optional bool
+	// Connect establishes the dependency. It is retried with backoff.
+	Connect func(ctx context.Context) error
+	// Check reports whether a connected dependency is still healthy. When
+	// nil, the dependency is ready once connected.
+	Check func(ctx context.Context) error
+}
+
+// Options controls how dependencies are retried and checked
+type Options struct {
+	InitialBackoff time.Duration // delay after the first failed attempt, doubled on each further attempt
+	MaxBackoff     time.Duration // longest delay between attempts
+	MaxWait        time.Duration // how long required dependencies may take before startup fails
+	CheckTimeout   time.Duration // timeout of each readiness check
+}
+
+// DefaultOptions returns options suited to a rolling deploy
+func DefaultOptions() Options {
+	return Options{
+		InitialBackoff: time.Second,
+		MaxBackoff:     15 * time.Second,
+		MaxWait:        2 * time.Minute,
+		CheckTimeout:   2 * time.Second,
+	}
+}
+
+// dependencyState is a dependency and the outcome of its latest attempt
+type dependencyState struct {
+	dep        Dependency
+	state      string
+	attempts   int
+	lastError  string
+	readySince time.Time
+}
+
+// Orchestrator brings up a service's dependencies in order, retrying each with
+// exponential backoff instead of failing on the first error, and reports their
+// readiness
+type Orchestrator struct {
+	opts   Options
+	logger *logger.Logger
+
+	mu   sync.RWMutex
+	deps []*dependencyState
+
+	wg sync.WaitGroup
+}
+
+// New creates a startup orchestrator. Unset options take their defaults.
+func New(opts Options, log *logger.Logger) *Orchestrator {
+	defaults := DefaultOptions()
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaults.InitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaults.MaxBackoff
+	}
+	if opts.MaxWait <= 0 {
+		opts.MaxWait = defaults.MaxWait
+	}
+	if opts.CheckTimeout <= 0 {
+		opts.CheckTimeout = defaults.CheckTimeout
+	}
+
+	return &Orchestrator{
+		opts:   opts,
+		logger: log,
+	}
+}
+
+// Add registers a dependency. Dependencies are connected in the order added.
+func (o *Orchestrator) Add(dep Dependency) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.deps = append(o.deps, &dependencyState{dep: dep, state: StateConnecting})
+}
+
+// Start connects every dependency. It returns an error if a required
+// dependency cannot be connected within the maximum wait; optional ones that
+// fail are retried in the background until ctx is cancelled.
+func (o *Orchestrator) Start(ctx context.Context) error {
+	o.mu.RLock()
+	deps := append([]*dependencyState(nil), o.deps...)
+	o.mu.RUnlock()
+
+	for _, d := range deps {
+		if d.dep.Optional {
+			if err := o.attempt(ctx, d); err != nil {
+				o.logger.Warn("optional dependency unavailable, starting degraded",
+					zap.String("dependency", d.dep.Name),
+					zap.Error(err),
+				)
+				o.wg.Add(1)
+				go func(d *dependencyState) {
+					defer o.wg.Done()
+					o.retry(ctx, d)
+				}(d)
+			}
+			continue
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, o.opts.MaxWait)
+		err := o.connect(waitCtx, d)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("dependency %s unavailable after %s: %w", d.dep.Name, o.opts.MaxWait, err)
+		}
+	}
+	return nil
+}
+
+// Wait blocks until background retries of optional dependencies have stopped
+func (o *Orchestrator) Wait() {
+	o.wg.Wait()
+}
+
+// connect retries a dependency until it connects or ctx is done
+func (o *Orchestrator) connect(ctx context.Context, d *dependencyState) error {
+	err := o.attempt(ctx, d)
+	if err == nil {
+		return nil
+	}
+
+	backoff := o.opts.InitialBackoff
+	for {
+		o.logger.Warn("dependency unavailable, retrying",
+			zap.String("dependency", d.dep.Name),
+			zap.Int("attempt", o.attempts(d)),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		if err = o.attempt(ctx, d); err == nil {
+			return nil
+		}
+		if backoff *= 2; backoff > o.opts.MaxBackoff {
+			backoff = o.opts.MaxBackoff
+		}
+	}
+}
+
+// retry connects an optional dependency in the background
+func (o *Orchestrator) retry(ctx context.Context, d *dependencyState) {
+	if err := o.connect(ctx, d); err != nil {
+		return
+	}
+	o.logger.Info("optional dependency connected, leaving degraded mode",
+		zap.String("dependency", d.dep.Name),
+		zap.Int("attempts", o.attempts(d)),
+	)
+}
+
+// attempt makes one connection attempt and records its outcome
+func (o *Orchestrator) attempt(ctx context.Context, d *dependencyState) error {
+	err := d.dep.Connect(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	d.attempts++
+	if err != nil {
+		d.state = StateUnavailable
+		d.lastError = err.Error()
+		return err
+	}
+
+	d.state = StateReady
+	d.lastError = ""
+	d.readySince = time.Now().UTC()
+	if d.attempts > 1 {
+		o.logger.Info("dependency connected",
+			zap.String("dependency", d.dep.Name),
+			zap.Int("attempts", d.attempts),
+		)
+	}
+	return nil
+}
+
+func (o *Orchestrator) attempts(d *dependencyState) int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return d.attempts
+}
+
+// DependencyReport is the readiness of one dependency
+type DependencyReport struct {
+	Name       string     `json:"name"`
+	State      string     `json:"state"`
+	Optional   bool       `json:"optional"`
+	Attempts   int        `json:"attempts"`
+	Error      string     `json:"error,omitempty"`
+	ReadySince *time.Time `json:"ready_since,omitempty"`
+}
+
+// Report is the readiness of a service and each of its dependencies
+type Report struct {
+	Status       string             `json:"status"`
+	Dependencies []DependencyReport `json:"dependencies"`
+}
+
+// Ready reports whether every required dependency is ready
+func (r *Report) Ready() bool {
+	return r.Status != StatusNotReady
+}
+
+// Readiness checks every connected dependency and reports the result. The
+// service is not ready while a required dependency is unavailable, and
+// degraded while an optional one is.
+func (o *Orchestrator) Readiness(ctx context.Context) *Report {
+	o.mu.RLock()
+	deps := append([]*dependencyState(nil), o.deps...)
+	o.mu.RUnlock()
+
+	report := &Report{
+		Status:       StatusReady,
+		Dependencies: make([]DependencyReport, 0, len(deps)),
+	}
+
+	for _, d := range deps {
+		o.mu.RLock()
+		dr := DependencyReport{
+			Name:     d.dep.Name,
+			State:    d.state,
+			Optional: d.dep.Optional,
+			Attempts: d.attempts,
+			Error:    d.lastError,
+		}
+		if d.state == StateReady {
+			since := d.readySince
+			dr.ReadySince = &since
+		}
+		o.mu.RUnlock()
+
+		if dr.State == StateReady && d.dep.Check != nil {
+			checkCtx, cancel := context.WithTimeout(ctx, o.opts.CheckTimeout)
+			if err := d.dep.Check(checkCtx); err != nil {
+				dr.State = StateUnavailable
+				dr.Error = err.Error()
+				dr.ReadySince = nil
+			}
+			cancel()
+		}
+
+		if dr.State != StateReady {
+			if !dr.Optional {
+				report.Status = StatusNotReady
+			} else if report.Status == StatusReady {
+				report.Status = StatusDegraded
+			}
+		}
+		report.Dependencies = append(report.Dependencies, dr)
+	}
+
+	return report
+}
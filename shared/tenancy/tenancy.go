@@ -0,0 +1,130 @@
+// Package tenancy partitions platform data by jurisdiction. Exchanges,
+// licenses, policies and reports each belong to one jurisdiction, and a user
+// sees the rows of the jurisdictions named in their access token's
+// "jurisdictions" claim. Services put the caller's Scope in the request
+// context with WithScope and filter rows by it; work without a scope, such as
+// scheduled jobs, is not restricted. A national authority holds the Wildcard
+// jurisdiction and sees every row.
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Wildcard is the jurisdiction granting every jurisdiction
+const Wildcard = "*"
+
+// ErrOutsideJurisdiction is returned when a record belongs to a jurisdiction
+// outside the caller's scope
+var ErrOutsideJurisdiction = errors.New("resource is outside the caller's jurisdiction")
+
+// Scope is the set of jurisdictions a caller may see
+type Scope struct {
+	all           bool
+	jurisdictions []string
+}
+
+// NewScope returns the scope of the given jurisdictions. Codes are compared
+// case-insensitively; the Wildcard grants all jurisdictions.
+func NewScope(jurisdictions ...string) Scope {
+	seen := make(map[string]bool, len(jurisdictions))
+	var s Scope
+	for _, j := range jurisdictions {
+		j = Normalize(j)
+		switch {
+		case j == "" || seen[j]:
+		case j == Wildcard:
+			s.all = true
+		default:
+			seen[j] = true
+			s.jurisdictions = append(s.jurisdictions, j)
+		}
+	}
+	sort.Strings(s.jurisdictions)
+	return s
+}
+
+// All reports whether the scope grants every jurisdiction
+func (s Scope) All() bool {
+	return s.all
+}
+
+// Empty reports whether the scope grants no jurisdiction at all
+func (s Scope) Empty() bool {
+	return !s.all && len(s.jurisdictions) == 0
+}
+
+// Allows reports whether the scope grants the jurisdiction
+func (s Scope) Allows(jurisdiction string) bool {
+	if s.all {
+		return true
+	}
+	jurisdiction = Normalize(jurisdiction)
+	i := sort.SearchStrings(s.jurisdictions, jurisdiction)
+	return i < len(s.jurisdictions) && s.jurisdictions[i] == jurisdiction
+}
+
+// Jurisdictions returns the granted jurisdictions in order. It is nil for a
+// scope granting every jurisdiction, which needs no row filter.
+func (s Scope) Jurisdictions() []string {
+	if s.all {
+		return nil
+	}
+	return append([]string(nil), s.jurisdictions...)
+}
+
+// String renders the scope as a comma-separated list, the form of the
+// X-Jurisdictions header
+func (s Scope) String() string {
+	if s.all {
+		return Wildcard
+	}
+	return strings.Join(s.jurisdictions, ",")
+}
+
+// ParseScope parses a comma-separated list of jurisdictions
+func ParseScope(list string) Scope {
+	return NewScope(strings.Split(list, ",")...)
+}
+
+// Normalize returns the canonical form of a jurisdiction code
+func Normalize(jurisdiction string) string {
+	return strings.ToUpper(strings.TrimSpace(jurisdiction))
+}
+
+type scopeKey struct{}
+
+// WithScope returns a copy of ctx restricted to the scope
+func WithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// FromContext returns the scope carried by ctx. ok is false for unrestricted
+// work that carries none.
+func FromContext(ctx context.Context) (scope Scope, ok bool) {
+	scope, ok = ctx.Value(scopeKey{}).(Scope)
+	return scope, ok
+}
+
+// Topic returns the jurisdiction's own Kafka topic for a base topic, such as
+// "csic.license_events.us-ny" for "csic.license_events" and "US-NY".
+// Characters Kafka does not allow in topic names become underscores. Records
+// without a jurisdiction stay on the base topic.
+func Topic(base, jurisdiction string) string {
+	jurisdiction = strings.ToLower(strings.TrimSpace(jurisdiction))
+	if jurisdiction == "" {
+		return base
+	}
+	suffix := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, jurisdiction)
+	return base + "." + suffix
+}
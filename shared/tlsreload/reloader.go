@@ -0,0 +1,296 @@
+// Package tlsreload serves TLS certificates that can be rotated while a server
+// is running. A Reloader polls a Source, the certificate and key files on disk
+// or secrets in Vault, and swaps in new material when it changes, so listeners
+// pick up a rotated certificate on the next handshake without a restart.
+// Client certificates can additionally be checked against CRLs and OCSP
+// responders with a RevocationChecker.
+package tlsreload
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/csic-platform/shared/secrets"
+)
+
+// Material is the PEM-encoded key pair a server presents, and optionally the
+// CA bundle its clients' certificates must chain to
+type Material struct {
+	CertPEM     []byte
+	KeyPEM      []byte
+	ClientCAPEM []byte
+}
+
+func (m *Material) equal(other *Material) bool {
+	return bytes.Equal(m.CertPEM, other.CertPEM) &&
+		bytes.Equal(m.KeyPEM, other.KeyPEM) &&
+		bytes.Equal(m.ClientCAPEM, other.ClientCAPEM)
+}
+
+// Source loads the current TLS material
+type Source interface {
+	Load(ctx context.Context) (*Material, error)
+}
+
+// FileSource reads TLS material from PEM files. ClientCAFile is optional.
+// The files are read on every load, so replacing them in place, or swapping
+// the symlinks a Kubernetes secret volume uses, is picked up.
+type FileSource struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+// Load reads the certificate, key and client CA files
+func (s *FileSource) Load(ctx context.Context) (*Material, error) {
+	var m Material
+	var err error
+
+	if m.CertPEM, err = os.ReadFile(s.CertFile); err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+	if m.KeyPEM, err = os.ReadFile(s.KeyFile); err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	if s.ClientCAFile != "" {
+		if m.ClientCAPEM, err = os.ReadFile(s.ClientCAFile); err != nil {
+			return nil, fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+	}
+
+	return &m, nil
+}
+
+// SecretSource fetches TLS material through a secrets manager, for example
+// vault://pki/data/control-layer#certificate. ClientCARef is optional. The
+// manager's cache TTL bounds how long a rotated secret takes to be seen.
+type SecretSource struct {
+	Manager     *secrets.Manager
+	CertRef     string
+	KeyRef      string
+	ClientCARef string
+}
+
+// Load fetches the certificate, key and client CA secrets
+func (s *SecretSource) Load(ctx context.Context) (*Material, error) {
+	cert, err := s.Manager.Resolve(ctx, s.CertRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch certificate: %w", err)
+	}
+	key, err := s.Manager.Resolve(ctx, s.KeyRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch key: %w", err)
+	}
+
+	m := &Material{CertPEM: []byte(cert), KeyPEM: []byte(key)}
+	if s.ClientCARef != "" {
+		ca, err := s.Manager.Resolve(ctx, s.ClientCARef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch client CA bundle: %w", err)
+		}
+		m.ClientCAPEM = []byte(ca)
+	}
+
+	return m, nil
+}
+
+// state is a parsed, ready to serve set of material
+type state struct {
+	material  *Material
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// Reloader serves the latest valid TLS material from a Source. Material that
+// fails to load or parse is logged and ignored, and the previous certificate
+// stays in service, so a half-written rotation never breaks handshakes.
+type Reloader struct {
+	source   Source
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu      sync.RWMutex
+	current *state
+
+	onReload func(cert *x509.Certificate)
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewReloader loads the initial material from source and returns a reloader
+// that polls it every interval once started. It fails if the initial material
+// is unusable, so a misconfigured server does not start.
+func NewReloader(ctx context.Context, source Source, interval time.Duration, logger *zap.Logger) (*Reloader, error) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	r := &Reloader{
+		source:   source,
+		interval: interval,
+		logger:   logger,
+	}
+	if _, err := r.Reload(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// OnReload registers a function called with the new leaf certificate after
+// each rotation, e.g. to export its expiry as a metric
+func (r *Reloader) OnReload(fn func(cert *x509.Certificate)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onReload = fn
+}
+
+// Reload loads the material now. It reports whether the served certificate
+// changed; unchanged material is not parsed again.
+func (r *Reloader) Reload(ctx context.Context) (bool, error) {
+	material, err := r.source.Load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	current := r.current
+	r.mu.RUnlock()
+	if current != nil && current.material.equal(material) {
+		return false, nil
+	}
+
+	next, err := parse(material)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	r.current = next
+	onReload := r.onReload
+	r.mu.Unlock()
+
+	leaf := next.cert.Leaf
+	r.logger.Info("TLS certificate loaded",
+		zap.String("subject", leaf.Subject.String()),
+		zap.String("serial", leaf.SerialNumber.String()),
+		zap.Time("not_after", leaf.NotAfter),
+		zap.Bool("client_ca", next.clientCAs != nil),
+	)
+	if onReload != nil {
+		onReload(leaf)
+	}
+
+	return true, nil
+}
+
+// Start polls the source every interval until Stop is called or ctx is done
+func (r *Reloader) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.Reload(ctx); err != nil {
+					r.logger.Error("Failed to reload TLS certificate, keeping the current one", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops polling
+func (r *Reloader) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// GetCertificate returns the current certificate; it has the signature of
+// tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.cert, nil
+}
+
+// ServerConfig returns a TLS configuration serving the current certificate.
+// When the source provides a client CA bundle, clients must present a
+// certificate chaining to it, and checker, if not nil, rejects revoked ones.
+func (r *Reloader) ServerConfig(checker *RevocationChecker) *tls.Config {
+	base := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+	}
+
+	// GetConfigForClient is consulted on every handshake, so a rotated
+	// client CA bundle applies to the next connection
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		clientCAs := r.current.clientCAs
+		r.mu.RUnlock()
+
+		if clientCAs == nil {
+			return nil, nil
+		}
+
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		cfg.ClientCAs = clientCAs
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		if checker != nil {
+			cfg.VerifyPeerCertificate = checker.VerifyPeerCertificate
+		}
+		return cfg, nil
+	}
+
+	return base
+}
+
+// parse validates material and prepares it for serving
+func parse(m *Material) (*state, error) {
+	cert, err := tls.X509KeyPair(m.CertPEM, m.KeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate or key: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+	if time.Now().After(leaf.NotAfter) {
+		return nil, fmt.Errorf("certificate %s expired at %s", leaf.SerialNumber, leaf.NotAfter.Format(time.RFC3339))
+	}
+	cert.Leaf = leaf
+
+	s := &state{material: m, cert: &cert}
+	if len(m.ClientCAPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(m.ClientCAPEM) {
+			return nil, errors.New("client CA bundle contains no certificates")
+		}
+		s.clientCAs = pool
+	}
+
+	return s, nil
+}
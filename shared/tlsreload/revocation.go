@@ -0,0 +1,347 @@
+package tlsreload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ocsp"
+)
+
+// ErrCertificateRevoked is returned for a client certificate listed in a CRL
+// or reported revoked by its OCSP responder
+var ErrCertificateRevoked = errors.New("certificate revoked")
+
+// maxRevocationResponse bounds the size of a downloaded CRL or OCSP response
+const maxRevocationResponse = 16 << 20
+
+// RevocationConfig configures client certificate revocation checking
+type RevocationConfig struct {
+	// CRLFiles and CRLURLs are the CRLs to check against, PEM or DER encoded.
+	// A CRL applies to the certificates of the CA that signed it.
+	CRLFiles []string
+	CRLURLs  []string
+
+	// OCSP queries the responders named in client certificates
+	OCSP bool
+
+	// SoftFail accepts a certificate whose OCSP responder cannot be reached
+	// or answers unknown, when no CRL from its issuer was checked either.
+	// Otherwise such certificates are rejected.
+	SoftFail bool
+
+	// RefreshInterval is how often CRLs are reloaded; OCSP answers are
+	// cached until their next update time, or this long if they have none
+	RefreshInterval time.Duration
+
+	// HTTPTimeout bounds CRL downloads and OCSP queries
+	HTTPTimeout time.Duration
+}
+
+// ocspEntry is a cached OCSP answer
+type ocspEntry struct {
+	status    int
+	expiresAt time.Time
+}
+
+// RevocationChecker rejects client certificates that have been revoked,
+// according to the configured CRLs and the certificates' OCSP responders
+type RevocationChecker struct {
+	cfg    RevocationConfig
+	client *http.Client
+	logger *zap.Logger
+
+	mu   sync.RWMutex
+	crls []*x509.RevocationList
+	ocsp map[string]ocspEntry
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRevocationChecker creates a revocation checker and loads its CRLs. It
+// fails if any CRL cannot be loaded.
+func NewRevocationChecker(ctx context.Context, cfg RevocationConfig, logger *zap.Logger) (*RevocationChecker, error) {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = time.Hour
+	}
+	if cfg.HTTPTimeout <= 0 {
+		cfg.HTTPTimeout = 5 * time.Second
+	}
+
+	c := &RevocationChecker{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.HTTPTimeout},
+		logger: logger,
+		ocsp:   make(map[string]ocspEntry),
+	}
+	if err := c.loadCRLs(ctx); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Start reloads the CRLs every refresh interval until Stop is called. A CRL
+// that fails to reload is logged and the previous set stays in use.
+func (c *RevocationChecker) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.cfg.RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.loadCRLs(ctx); err != nil {
+					c.logger.Error("Failed to reload CRLs, keeping the current ones", zap.Error(err))
+				}
+				c.evictOCSP(time.Now())
+			}
+		}
+	}()
+}
+
+// Stop stops reloading CRLs
+func (c *RevocationChecker) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+}
+
+// VerifyPeerCertificate checks the leaf of each verified chain; it has the
+// signature of tls.Config.VerifyPeerCertificate and runs after the chain has
+// been verified against the client CAs
+func (c *RevocationChecker) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	for _, chain := range verifiedChains {
+		if len(chain) < 2 {
+			continue
+		}
+		if err := c.Check(context.Background(), chain[0], chain[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check reports whether cert, issued by issuer, has been revoked. CRLs are
+// consulted first; OCSP is queried when enabled and the certificate names a
+// responder.
+func (c *RevocationChecker) Check(ctx context.Context, cert, issuer *x509.Certificate) error {
+	crlChecked, revoked := c.checkCRLs(cert, issuer)
+	if revoked {
+		return fmt.Errorf("%w: serial %s listed in CRL of %s", ErrCertificateRevoked, cert.SerialNumber, issuer.Subject)
+	}
+
+	if !c.cfg.OCSP || len(cert.OCSPServer) == 0 {
+		return nil
+	}
+
+	status, err := c.ocspStatus(ctx, cert, issuer)
+	if err == nil {
+		switch status {
+		case ocsp.Good:
+			return nil
+		case ocsp.Revoked:
+			return fmt.Errorf("%w: serial %s reported revoked by OCSP", ErrCertificateRevoked, cert.SerialNumber)
+		default:
+			err = fmt.Errorf("OCSP responder does not know serial %s", cert.SerialNumber)
+		}
+	}
+
+	if crlChecked || c.cfg.SoftFail {
+		c.logger.Warn("OCSP check failed, accepting certificate",
+			zap.String("serial", cert.SerialNumber.String()),
+			zap.Bool("crl_checked", crlChecked),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return fmt.Errorf("unable to check revocation of serial %s: %w", cert.SerialNumber, err)
+}
+
+// checkCRLs reports whether a CRL signed by issuer was found, and whether it
+// lists cert
+func (c *RevocationChecker) checkCRLs(cert, issuer *x509.Certificate) (checked, revoked bool) {
+	c.mu.RLock()
+	crls := c.crls
+	c.mu.RUnlock()
+
+	for _, crl := range crls {
+		if !bytes.Equal(crl.RawIssuer, issuer.RawSubject) || crl.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		checked = true
+		if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+			c.logger.Warn("Using stale CRL",
+				zap.String("issuer", issuer.Subject.String()),
+				zap.Time("next_update", crl.NextUpdate),
+			)
+		}
+		for _, entry := range crl.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return true, true
+			}
+		}
+	}
+	return checked, false
+}
+
+// ocspStatus returns the OCSP status of cert, from cache when fresh
+func (c *RevocationChecker) ocspStatus(ctx context.Context, cert, issuer *x509.Certificate) (int, error) {
+	key := ocspKey(cert, issuer)
+	now := time.Now()
+
+	c.mu.RLock()
+	entry, ok := c.ocsp[key]
+	c.mu.RUnlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.status, nil
+	}
+
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create OCSP request: %w", err)
+	}
+
+	var lastErr error
+	for _, server := range cert.OCSPServer {
+		resp, err := c.queryOCSP(ctx, server, req, cert, issuer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		expiresAt := resp.NextUpdate
+		if expiresAt.IsZero() {
+			expiresAt = now.Add(c.cfg.RefreshInterval)
+		}
+		c.mu.Lock()
+		c.ocsp[key] = ocspEntry{status: resp.Status, expiresAt: expiresAt}
+		c.mu.Unlock()
+
+		return resp.Status, nil
+	}
+
+	return 0, lastErr
+}
+
+// queryOCSP asks one responder for the status of cert
+func (c *RevocationChecker) queryOCSP(ctx context.Context, server string, req []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OCSP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+
+	body, err := c.fetch(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("OCSP query to %s failed: %w", server, err)
+	}
+
+	resp, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCSP response from %s: %w", server, err)
+	}
+	return resp, nil
+}
+
+// loadCRLs loads every configured CRL, replacing the current set only if all
+// of them load
+func (c *RevocationChecker) loadCRLs(ctx context.Context) error {
+	var crls []*x509.RevocationList
+
+	for _, path := range c.cfg.CRLFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CRL %s: %w", path, err)
+		}
+		crl, err := parseCRL(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse CRL %s: %w", path, err)
+		}
+		crls = append(crls, crl)
+	}
+
+	for _, url := range c.cfg.CRLURLs {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build CRL request for %s: %w", url, err)
+		}
+		data, err := c.fetch(req)
+		if err != nil {
+			return fmt.Errorf("failed to download CRL %s: %w", url, err)
+		}
+		crl, err := parseCRL(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse CRL %s: %w", url, err)
+		}
+		crls = append(crls, crl)
+	}
+
+	c.mu.Lock()
+	c.crls = crls
+	c.mu.Unlock()
+	return nil
+}
+
+// fetch performs an HTTP request and returns the body of a 200 response
+func (c *RevocationChecker) fetch(req *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRevocationResponse))
+}
+
+// evictOCSP drops expired OCSP answers
+func (c *RevocationChecker) evictOCSP(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.ocsp {
+		if !now.Before(entry.expiresAt) {
+			delete(c.ocsp, key)
+		}
+	}
+}
+
+// parseCRL parses a PEM or DER encoded CRL
+func parseCRL(data []byte) (*x509.RevocationList, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		if !strings.Contains(block.Type, "CRL") {
+			return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		data = block.Bytes
+	}
+	return x509.ParseRevocationList(data)
+}
+
+// ocspKey identifies a certificate by its issuer's key and its serial
+func ocspKey(cert, issuer *x509.Certificate) string {
+	sum := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	return fmt.Sprintf("%x:%s", sum, cert.SerialNumber)
+}
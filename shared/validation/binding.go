@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/csic-platform/shared/problem"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Binding tags registered with gin's validator by RegisterBindings
+const (
+	TagBTCAddress      = "btc_address"
+	TagETHAddress      = "eth_address"
+	TagTxHash          = "tx_hash"
+	TagISO4217         = "iso4217"
+	TagPositiveDecimal = "positive_decimal"
+	// TagChainAddress checks an address against the chain named by a sibling
+	// field, as in binding:"chain_address=Chain"
+	TagChainAddress = "chain_address"
+)
+
+// tagMessages are the field-level messages for failed tags
+var tagMessages = map[string]string{
+	"required":         "is required",
+	TagBTCAddress:      "must be a valid Bitcoin address",
+	TagETHAddress:      "must be a valid Ethereum address",
+	TagTxHash:          "must be a 32-byte hex transaction hash",
+	TagISO4217:         "must be an ISO 4217 currency code",
+	TagPositiveDecimal: "must be a positive decimal number",
+	TagChainAddress:    "must be a valid address for the chain",
+	"oneof":            "must be one of: %s",
+	"min":              "must be at least %s",
+	"max":              "must be at most %s",
+	"gt":               "must be greater than %s",
+	"gte":              "must be at least %s",
+	"lt":               "must be less than %s",
+	"lte":              "must be at most %s",
+	"len":              "must have length %s",
+	"email":            "must be a valid email address",
+	"uuid":             "must be a valid UUID",
+	"url":              "must be a valid URL",
+}
+
+// RegisterBindings adds the platform's validators to gin's binding engine
+// and reports fields by their JSON names. Services call it once at startup,
+// before serving requests.
+func RegisterBindings() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("gin binding engine is not go-playground/validator")
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "uri"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	validators := map[string]validator.Func{
+		TagBTCAddress:      stringValidator(IsBTCAddress),
+		TagETHAddress:      stringValidator(IsETHAddress),
+		TagTxHash:          stringValidator(IsTxHash),
+		TagISO4217:         stringValidator(IsISO4217),
+		TagPositiveDecimal: positiveDecimal,
+		TagChainAddress:    chainAddress,
+	}
+	for tag, fn := range validators {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return fmt.Errorf("register %s: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+func stringValidator(check func(string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		return field.Kind() == reflect.String && check(field.String())
+	}
+}
+
+// positiveDecimal accepts decimal strings and numbers greater than zero
+func positiveDecimal(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		return IsPositiveDecimal(field.String())
+	case reflect.Float32, reflect.Float64:
+		return field.Float() > 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() > 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint() > 0
+	}
+	// Types such as decimal amounts that render as a decimal string
+	if s, ok := field.Interface().(fmt.Stringer); ok {
+		return IsPositiveDecimal(s.String())
+	}
+	return false
+}
+
+func chainAddress(fl validator.FieldLevel) bool {
+	chain, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !found || kind != reflect.String || fl.Field().Kind() != reflect.String {
+		return false
+	}
+	return IsChainAddress(chain.String(), fl.Field().String())
+}
+
+// BindError converts an error from binding a request into an InvalidArgument
+// problem. Validation failures are listed field by field, using the fields'
+// JSON paths; malformed bodies are reported as a whole.
+func BindError(err error) *problem.Error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return problem.Invalid("Malformed request body: " + err.Error())
+	}
+
+	fields := make([]problem.FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		fields[i] = problem.FieldError{Field: fieldPath(fe), Message: message(fe)}
+	}
+	return problem.Invalid("Request validation failed", fields...)
+}
+
+// FieldErrors lists the field-level failures in a binding error, or returns
+// nil if err is not a validation failure
+func FieldErrors(err error) []problem.FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+	return BindError(err).Fields
+}
+
+// fieldPath drops the struct name from the namespace, giving a path such as
+// blockchain_addresses[0].address
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+func message(fe validator.FieldError) string {
+	msg, ok := tagMessages[fe.Tag()]
+	if !ok {
+		return "failed " + fe.Tag() + " validation"
+	}
+	if strings.Contains(msg, "%s") {
+		return fmt.Sprintf(msg, fe.Param())
+	}
+	return msg
+}
@@ -0,0 +1,244 @@
+package validation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// IsBTCAddress reports whether s is a Bitcoin address: a base58check P2PKH or
+// P2SH address, or a bech32 (SegWit v0) or bech32m (Taproot and later)
+// address, for mainnet, testnet or regtest. Checksums are verified.
+func IsBTCAddress(s string) bool {
+	if isBech32Address(s) {
+		return true
+	}
+	return isBase58Address(s)
+}
+
+var base58Versions = map[byte]bool{
+	0x00: true, // mainnet P2PKH
+	0x05: true, // mainnet P2SH
+	0x6f: true, // testnet P2PKH
+	0xc4: true, // testnet P2SH
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func isBase58Address(s string) bool {
+	if len(s) < 26 || len(s) > 35 {
+		return false
+	}
+
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base58Alphabet, s[i])
+		if digit < 0 {
+			return false
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	// Each leading '1' encodes a leading zero byte
+	zeros := len(s) - len(strings.TrimLeft(s, "1"))
+	decoded := append(make([]byte, zeros), n.Bytes()...)
+	if len(decoded) != 25 || !base58Versions[decoded[0]] {
+		return false
+	}
+
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	return string(second[:4]) == string(decoded[21:])
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants of bech32 and bech32m (BIP 173, BIP 350)
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+var bech32HRPs = map[string]bool{"bc": true, "tb": true, "bcrt": true}
+
+func isBech32Address(s string) bool {
+	if len(s) > 90 || (strings.ToLower(s) != s && strings.ToUpper(s) != s) {
+		return false
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || !bech32HRPs[s[:sep]] || len(s)-sep-1 < 7 {
+		return false
+	}
+	hrp, encoded := s[:sep], s[sep+1:]
+
+	data := make([]byte, len(encoded))
+	for i := 0; i < len(encoded); i++ {
+		value := strings.IndexByte(bech32Charset, encoded[i])
+		if value < 0 {
+			return false
+		}
+		data[i] = byte(value)
+	}
+
+	checksum := bech32Polymod(append(bech32ExpandHRP(hrp), data...))
+	payload := data[:len(data)-6]
+	if len(payload) == 0 {
+		return false
+	}
+
+	version := payload[0]
+	switch {
+	case version == 0 && checksum != bech32Const:
+		return false
+	case version > 0 && version <= 16 && checksum != bech32mConst:
+		return false
+	case version > 16:
+		return false
+	}
+
+	program, ok := convertBits(payload[1:], 5, 8)
+	if !ok || len(program) < 2 || len(program) > 40 {
+		return false
+	}
+	return version != 0 || len(program) == 20 || len(program) == 32
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32ExpandHRP(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits regroups bits, rejecting non-zero padding as BIP 173 requires
+func convertBits(data []byte, from, to uint) ([]byte, bool) {
+	var acc, bits uint
+	maxv := uint(1)<<to - 1
+	var out []byte
+	for _, value := range data {
+		acc = acc<<from | uint(value)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if bits >= from || (acc<<(to-bits))&maxv != 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+var ethAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// IsETHAddress reports whether s is an Ethereum address. Mixed-case
+// addresses must carry a valid EIP-55 checksum; all-lowercase and
+// all-uppercase addresses carry none.
+func IsETHAddress(s string) bool {
+	if !ethAddressPattern.MatchString(s) {
+		return false
+	}
+	hexPart := s[2:]
+	if hexPart == strings.ToLower(hexPart) || hexPart == strings.ToUpper(hexPart) {
+		return true
+	}
+	return hexPart == eip55(hexPart)
+}
+
+// eip55 returns the checksummed form of a 40-digit hex address
+func eip55(address string) string {
+	lower := strings.ToLower(address)
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	out := []byte(lower)
+	for i, c := range out {
+		if c >= 'a' && c <= 'f' && digest[i] >= '8' {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return string(out)
+}
+
+var txHashPattern = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+
+// IsTxHash reports whether s is a 32-byte transaction hash in hex, as used by
+// Bitcoin and, with a 0x prefix, by Ethereum and other EVM chains
+func IsTxHash(s string) bool {
+	return txHashPattern.MatchString(s)
+}
+
+// IsChainAddress reports whether s is an address on chain, given by name or
+// ticker. Addresses on chains without a specific check only need to be
+// non-blank printable text without spaces.
+func IsChainAddress(chain, s string) bool {
+	switch strings.ToUpper(chain) {
+	case "BTC", "BITCOIN":
+		return IsBTCAddress(s)
+	case "ETH", "ETHEREUM", "POLYGON", "MATIC", "BSC", "BNB", "ARBITRUM", "OPTIMISM", "AVALANCHE", "AVAX":
+		return IsETHAddress(s)
+	}
+	return s != "" && len(s) <= 128 && !strings.ContainsAny(s, " \t\r\n\x00")
+}
+
+// iso4217 lists active ISO 4217 currency codes
+var iso4217 = makeSet(`AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB
+BRL BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR
+FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES
+KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR
+MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR
+SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH
+UGX USD UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL XAU XAG XDR`)
+
+func makeSet(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, code := range strings.Fields(list) {
+		set[code] = true
+	}
+	return set
+}
+
+// IsISO4217 reports whether s is an active ISO 4217 currency code
+func IsISO4217(s string) bool {
+	return iso4217[s]
+}
+
+var decimalPattern = regexp.MustCompile(`^[0-9]{1,30}(\.[0-9]{1,18})?$`)
+
+// IsPositiveDecimal reports whether s is a plain decimal number greater than
+// zero, with at most 18 fractional digits and no sign or exponent
+func IsPositiveDecimal(s string) bool {
+	if !decimalPattern.MatchString(s) {
+		return false
+	}
+	return strings.Trim(s, "0.") != ""
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -240,8 +241,8 @@ func MinValue(fieldName string, min float64) ValidatorFunc {
 			if v == "" {
 				return nil
 			}
-			parsed, err := fmt.Sscanf(v, "%f")
-			if err != nil || parsed != 1 {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
 				return &ValidationError{
 					Field:   fieldName,
 					Message: "must be a numeric value",
@@ -284,8 +285,8 @@ func MaxValue(fieldName string, max float64) ValidatorFunc {
 			if v == "" {
 				return nil
 			}
-			parsed, err := fmt.Sscanf(v, "%f")
-			if err != nil || parsed != 1 {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
 				return &ValidationError{
 					Field:   fieldName,
 					Message: "must be a numeric value",
@@ -414,17 +415,15 @@ func CryptoAddress(fieldName string, chain string) ValidatorFunc {
 
 		switch strings.ToUpper(chain) {
 		case "BTC", "BITCOIN":
-			// Bitcoin address validation (simplified)
-			if len(str) < 26 || len(str) > 35 {
+			if !IsBTCAddress(str) {
 				return &ValidationError{
 					Field:   fieldName,
-					Message: "invalid Bitcoin address length",
+					Message: "invalid Bitcoin address",
 					Value:   value,
 				}
 			}
 		case "ETH", "ETHEREUM":
-			// Ethereum address validation
-			if !strings.HasPrefix(str, "0x") || len(str) != 42 {
+			if !IsETHAddress(str) {
 				return &ValidationError{
 					Field:   fieldName,
 					Message: "invalid Ethereum address",